@@ -38,6 +38,8 @@ import (
 	"tailscale.com/util/clientmetric"
 
 	"cdr.dev/slog"
+	"github.com/coder/coder/v2/agent/agentactivity"
+	"github.com/coder/coder/v2/agent/agentcontainers"
 	"github.com/coder/coder/v2/agent/agentproc"
 	"github.com/coder/coder/v2/agent/agentscripts"
 	"github.com/coder/coder/v2/agent/agentssh"
@@ -49,6 +51,7 @@ import (
 	"github.com/coder/coder/v2/codersdk"
 	"github.com/coder/coder/v2/codersdk/agentsdk"
 	"github.com/coder/coder/v2/codersdk/workspacesdk"
+	"github.com/coder/coder/v2/pty"
 	"github.com/coder/coder/v2/tailnet"
 	tailnetproto "github.com/coder/coder/v2/tailnet/proto"
 	"github.com/coder/retry"
@@ -92,6 +95,16 @@ type Options struct {
 	// ProcessManagementTick is used for testing process priority management.
 	ProcessManagementTick <-chan time.Time
 	BlockFileTransfer     bool
+	// ContainerClient lists and attaches to containers in the workspace. If
+	// nil, the docker or podman CLI is used if it's in PATH.
+	ContainerClient agentcontainers.Client
+	// ReportContainersInterval is how often the containers are listed and
+	// reported to coderd when they changed.
+	ReportContainersInterval time.Duration
+	// Activity are the sources of activity besides connections that are
+	// reported with the stats, so templates can keep busy workspaces
+	// running.
+	Activity agentactivity.Options
 }
 
 type Client interface {
@@ -152,6 +165,12 @@ func New(options Options) Agent {
 	if options.Syscaller == nil {
 		options.Syscaller = agentproc.NewSyscaller()
 	}
+	if options.ContainerClient == nil {
+		options.ContainerClient = agentcontainers.DetectCLIClient()
+	}
+	if options.ReportContainersInterval == 0 {
+		options.ReportContainersInterval = 10 * time.Second
+	}
 
 	hardCtx, hardCancel := context.WithCancel(context.Background())
 	gracefulCtx, gracefulCancel := context.WithCancel(hardCtx)
@@ -185,7 +204,11 @@ func New(options Options) Agent {
 		modifiedProcs:                      options.ModifiedProcesses,
 		processManagementTick:              options.ProcessManagementTick,
 		logSender:                          agentsdk.NewLogSender(options.Logger),
+		sessionRecordingSender:             agentsdk.NewSessionRecordingSender(options.Logger.Named("session-recordings")),
 		blockFileTransfer:                  options.BlockFileTransfer,
+		containerClient:                    options.ContainerClient,
+		reportContainersInterval:           options.ReportContainersInterval,
+		activityDetector:                   agentactivity.New(options.Logger.Named("activity"), options.Filesystem, options.Syscaller, options.Activity),
 
 		prometheusRegistry: prometheusRegistry,
 		metrics:            newAgentMetrics(prometheusRegistry),
@@ -217,8 +240,9 @@ type agent struct {
 	portCacheDuration time.Duration
 	subsystems        []codersdk.AgentSubsystem
 
-	reconnectingPTYs       sync.Map
-	reconnectingPTYTimeout time.Duration
+	reconnectingPTYs        sync.Map
+	reconnectingPTYSessions reconnectingPTYSessions
+	reconnectingPTYTimeout  time.Duration
 
 	// we track 2 contexts and associated cancel functions: "graceful" which is Done when it is time
 	// to start gracefully shutting down and "hard" which is Done when it is time to close
@@ -242,6 +266,7 @@ type agent struct {
 	sshServer                          *agentssh.Server
 	sshMaxTimeout                      time.Duration
 	blockFileTransfer                  bool
+	fileUploads                        fileUploads
 
 	lifecycleUpdate            chan struct{}
 	lifecycleReported          chan codersdk.WorkspaceAgentLifecycle
@@ -253,6 +278,12 @@ type agent struct {
 	addresses     []netip.Prefix
 	statsReporter *statsReporter
 	logSender     *agentsdk.LogSender
+	// sessionRecordingSender uploads recordings of SSH sessions if the
+	// template enables them.
+	sessionRecordingSender *agentsdk.SessionRecordingSender
+	// forwardPolicy is the port forwarding policy of the template, parsed
+	// from the manifest. It's nil until the manifest is fetched.
+	forwardPolicy atomic.Pointer[agentssh.ForwardPolicy]
 
 	connCountReconnectingPTY atomic.Int64
 
@@ -261,6 +292,11 @@ type agent struct {
 	// labeled in Coder with the agent + workspace.
 	metrics   *agentMetrics
 	syscaller agentproc.Syscaller
+	// containerClient is nil if no container runtime was found.
+	containerClient agentcontainers.Client
+	// reportContainersInterval is how often the containers are reported.
+	reportContainersInterval time.Duration
+	activityDetector         *agentactivity.Detector
 
 	// modifiedProcs is used for testing process priority management.
 	modifiedProcs chan []*agentproc.Process
@@ -281,6 +317,10 @@ func (a *agent) init() {
 		UpdateEnv:           a.updateCommandEnv,
 		WorkingDirectory:    func() string { return a.manifest.Load().Directory },
 		BlockFileTransfer:   a.blockFileTransfer,
+		RecordSession:       a.recordSession,
+		ForwardPolicy:       a.forwardPolicy.Load,
+		ReportForwardDenied: a.reportForwardDenied,
+		ContainerClient:     a.containerClient,
 	})
 	if err != nil {
 		panic(err)
@@ -299,6 +339,7 @@ func (a *agent) init() {
 	// Register runner metrics. If the prom registry is nil, the metrics
 	// will not report anywhere.
 	a.scriptRunner.RegisterMetrics(a.prometheusRegistry)
+	go a.activityDetector.Run(a.hardCtx)
 	go a.runLoop()
 }
 
@@ -796,6 +837,12 @@ func (a *agent) run() (retErr error) {
 			return err
 		})
 
+	// recordings of sessions that end during shutdown should still be uploaded.
+	connMan.start("send session recordings", gracefulShutdownBehaviorRemain,
+		func(ctx context.Context, conn drpc.Conn) error {
+			return a.sessionRecordingSender.SendLoop(ctx, proto.NewDRPCAgentClient(conn))
+		})
+
 	// part of graceful shut down is reporting the final lifecycle states, e.g "ShuttingDown" so the
 	// lifecycle reporting has to be via gracefulShutdownBehaviorRemain
 	connMan.start("report lifecycle", gracefulShutdownBehaviorRemain, a.reportLifecycle)
@@ -803,6 +850,8 @@ func (a *agent) run() (retErr error) {
 	// metadata reporting can cease as soon as we start gracefully shutting down
 	connMan.start("report metadata", gracefulShutdownBehaviorStop, a.reportMetadata)
 
+	connMan.start("report containers", gracefulShutdownBehaviorStop, a.reportContainers)
+
 	// channels to sync goroutines below
 	//  handle manifest
 	//       |
@@ -831,7 +880,7 @@ func (a *agent) run() (retErr error) {
 			}
 			manifest := a.manifest.Load()
 			NewWorkspaceAppHealthReporter(
-				a.logger, manifest.Apps, agentsdk.AppHealthPoster(proto.NewDRPCAgentClient(conn)),
+				a.logger, manifest.Apps, a.sshServer.CreateCommand, agentsdk.AppHealthPoster(proto.NewDRPCAgentClient(conn)),
 			)(ctx)
 			return nil
 		})
@@ -919,6 +968,20 @@ func (a *agent) handleManifest(manifestOK *checkpoint) func(ctx context.Context,
 			return xerrors.Errorf("update workspace agent startup: %w", err)
 		}
 
+		forwardPolicy, err := agentssh.NewForwardPolicy(manifest.PortForwardingPolicy)
+		if err != nil {
+			// coderd validates the policy, so this should never happen.
+			// Deny all port forwards rather than allowing ones the policy
+			// may have meant to deny.
+			a.logger.Error(ctx, "failed to parse port forwarding policy, denying all port forwards", slog.Error(err))
+			forwardPolicy, _ = agentssh.NewForwardPolicy(codersdk.PortForwardingPolicy{
+				DirectTCPIP:   &codersdk.PortForwardingRule{},
+				RemoteForward: &codersdk.PortForwardingRule{},
+				UnixSocket:    &codersdk.UnixSocketForwardingRule{},
+			})
+		}
+		a.forwardPolicy.Store(forwardPolicy)
+
 		oldManifest := a.manifest.Swap(&manifest)
 		manifestOK.complete(nil)
 		sentResult = true
@@ -1028,6 +1091,43 @@ func (a *agent) createOrUpdateNetwork(manifestOK, networkOK *checkpoint) func(co
 	}
 }
 
+// recordSession returns the writer an SSH session is recorded to, or nil if
+// the template doesn't enable session recording.
+func (a *agent) recordSession(id uuid.UUID, sessionType, command string, startedAt time.Time) io.WriteCloser {
+	manifest := a.manifest.Load()
+	if manifest == nil || !manifest.SessionRecording {
+		return nil
+	}
+	return a.sessionRecordingSender.Record(id, sessionType, command, startedAt)
+}
+
+// reportForwardDenied reports a port forward denied by the port forwarding
+// policy to the agent logs, so template admins can see which forwards their
+// policy blocks.
+func (a *agent) reportForwardDenied(kind, destination string, err error) {
+	a.logSender.Enqueue(agentsdk.PortForwardingLogSourceID, agentsdk.Log{
+		CreatedAt: dbtime.Now(),
+		Output:    fmt.Sprintf("Denied %s port forward to %s: %s", kind, destination, err),
+		Level:     codersdk.LogLevelWarn,
+	})
+}
+
+// allowForwardTCP checks connections made over the Coder network to ports on
+// localhost that aren't served by the agent itself.
+func (a *agent) allowForwardTCP(_, dst netip.AddrPort) bool {
+	err := a.forwardPolicy.Load().CheckLocalPort(dst.Port())
+	if err != nil {
+		destination := net.JoinHostPort("localhost", strconv.Itoa(int(dst.Port())))
+		a.logger.Warn(a.hardCtx, "port forward denied by policy",
+			slog.F("kind", agentssh.ForwardKindDirectTCPIP),
+			slog.F("destination", destination),
+			slog.Error(err))
+		a.reportForwardDenied(agentssh.ForwardKindDirectTCPIP, destination, err)
+		return false
+	}
+	return true
+}
+
 // updateCommandEnv updates the provided command environment with the
 // following set of environment variables:
 // - Predefined workspace environment variables
@@ -1146,6 +1246,7 @@ func (a *agent) createTailnet(ctx context.Context, agentID uuid.UUID, derpMap *t
 		Logger:              a.logger.Named("net.tailnet"),
 		ListenPort:          a.tailnetListenPort,
 		BlockEndpoints:      disableDirectConnections,
+		AllowForwardTCP:     a.allowForwardTCP,
 	})
 	if err != nil {
 		return nil, xerrors.Errorf("create tailnet: %w", err)
@@ -1402,7 +1503,7 @@ func (a *agent) handleReconnectingPTY(ctx context.Context, logger slog.Logger, m
 	a.connCountReconnectingPTY.Add(1)
 	defer a.connCountReconnectingPTY.Add(-1)
 
-	connectionID := uuid.NewString()
+	connectionID := uuid.New()
 	connLogger := logger.With(slog.F("message_id", msg.ID), slog.F("connection_id", connectionID))
 	connLogger.Debug(ctx, "starting handler")
 
@@ -1423,6 +1524,12 @@ func (a *agent) handleReconnectingPTY(ctx context.Context, logger slog.Logger, m
 		connLogger.Info(ctx, "reconnecting pty connection closed")
 	}()
 
+	if _, ok := a.reconnectingPTYs.Load(msg.ID); !ok && msg.ReadOnly {
+		// Viewers can only attach to existing sessions.
+		connLogger.Warn(ctx, "reconnecting pty to attach to read only not found")
+		return nil
+	}
+
 	var rpty reconnectingpty.ReconnectingPTY
 	sendConnected := make(chan reconnectingpty.ReconnectingPTY, 1)
 	// On store, reserve this ID to prevent multiple concurrent new connections.
@@ -1439,7 +1546,17 @@ func (a *agent) handleReconnectingPTY(ctx context.Context, logger slog.Logger, m
 			return xerrors.Errorf("reconnecting pty closed before connection")
 		}
 		c <- rpty // Put it back for the next reconnect.
+		if !msg.ShareSession && !a.reconnectingPTYSessions.startedBy(msg.ID, msg.UserID) {
+			// Attaching to the session of another user needs the
+			// share_session permission, which coderd checked.
+			connLogger.Warn(ctx, "reconnecting pty was started by another user", slog.F("user_id", msg.UserID))
+			return nil
+		}
 	} else {
+		if msg.ReadOnly {
+			// The session closed since it was looked up.
+			return xerrors.Errorf("reconnecting pty %s not found", msg.ID)
+		}
 		connLogger.Debug(ctx, "creating new reconnecting pty")
 
 		connected := false
@@ -1451,7 +1568,17 @@ func (a *agent) handleReconnectingPTY(ctx context.Context, logger slog.Logger, m
 		}()
 
 		// Empty command will default to the users shell!
-		cmd, err := a.sshServer.CreateCommand(ctx, msg.Command, nil)
+		var cmd *pty.Cmd
+		var err error
+		if msg.Container != "" {
+			connLogger.Info(ctx, "starting reconnecting pty in container", slog.F("container", msg.Container), slog.F("container_user", msg.ContainerUser))
+			cmd, err = a.sshServer.CreateContainerCommand(ctx, msg.Container, agentcontainers.ExecOptions{
+				User: msg.ContainerUser,
+				TTY:  true,
+			}, msg.Command, nil)
+		} else {
+			cmd, err = a.sshServer.CreateCommand(ctx, msg.Command, nil)
+		}
 		if err != nil {
 			a.metrics.reconnectingPTYErrors.WithLabelValues("create_command").Add(1)
 			return xerrors.Errorf("create command: %w", err)
@@ -1462,18 +1589,30 @@ func (a *agent) handleReconnectingPTY(ctx context.Context, logger slog.Logger, m
 			Metrics: a.metrics.reconnectingPTYErrors,
 		}, logger.With(slog.F("message_id", msg.ID)))
 
+		a.reconnectingPTYSessions.start(msg)
 		if err = a.trackGoroutine(func() {
 			rpty.Wait()
 			a.reconnectingPTYs.Delete(msg.ID)
+			a.reconnectingPTYSessions.stop(msg.ID)
 		}); err != nil {
 			rpty.Close(err)
+			a.reconnectingPTYSessions.stop(msg.ID)
 			return xerrors.Errorf("start routine: %w", err)
 		}
 
 		connected = true
 		sendConnected <- rpty
 	}
-	return rpty.Attach(ctx, connectionID, conn, msg.Height, msg.Width, connLogger)
+
+	height, width := msg.Height, msg.Width
+	if msg.ReadOnly {
+		// Viewers must not resize the pty of the other clients.
+		height, width = 0, 0
+		conn = reconnectingpty.ReadOnlyConn(conn)
+	}
+	detach := a.reconnectingPTYSessions.attach(msg.ID, connectionID, conn, msg.ReadOnly, msg.Takeover)
+	defer detach()
+	return rpty.Attach(ctx, connectionID.String(), conn, height, width, connLogger)
 }
 
 // Collect collects additional stats from the agent
@@ -1499,6 +1638,12 @@ func (a *agent) Collect(ctx context.Context, networkStats map[netlogtype.Connect
 
 	stats.SessionCountReconnectingPty = a.connCountReconnectingPTY.Load()
 
+	if a.activityDetector.Enabled() {
+		sources := a.activityDetector.Active(ctx)
+		stats.Active = len(sources) > 0
+		a.logger.Debug(ctx, "checked activity sources", slog.F("active", sources))
+	}
+
 	// Compute the median connection latency!
 	a.logger.Debug(ctx, "starting peer latency measurement for stats")
 	var wg sync.WaitGroup
@@ -1896,6 +2041,10 @@ lifecycleWaitLoop:
 	if err != nil {
 		a.logger.Warn(context.Background(), "timed out waiting for all logs to be sent", slog.Error(err))
 	}
+	err = a.sessionRecordingSender.WaitUntilEmpty(a.hardCtx)
+	if err != nil {
+		a.logger.Warn(context.Background(), "timed out waiting for all session recordings to be sent", slog.Error(err))
+	}
 
 	a.hardCancel()
 	if a.network != nil {
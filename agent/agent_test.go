@@ -47,6 +47,7 @@ import (
 	"cdr.dev/slog/sloggers/sloghuman"
 	"cdr.dev/slog/sloggers/slogtest"
 	"github.com/coder/coder/v2/agent"
+	"github.com/coder/coder/v2/agent/agentactivity"
 	"github.com/coder/coder/v2/agent/agentproc"
 	"github.com/coder/coder/v2/agent/agentproc/agentproctest"
 	"github.com/coder/coder/v2/agent/agentssh"
@@ -130,6 +131,33 @@ func TestAgent_Stats_ReconnectingPTY(t *testing.T) {
 	)
 }
 
+func TestAgent_Stats_Activity(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+	defer cancel()
+
+	//nolint:dogsled
+	conn, _, stats, fs, _ := setupAgent(t, agentsdk.Manifest{}, 0, func(_ *agenttest.Client, o *agent.Options) {
+		o.Activity = agentactivity.Options{
+			HeartbeatFile: "/home/coder/.coder-activity",
+		}
+	})
+	require.True(t, conn.AwaitReachable(ctx))
+
+	// The workspace is idle until the heartbeat file is touched.
+	s := <-stats
+	require.False(t, s.GetActive())
+	require.NoError(t, afero.WriteFile(fs, "/home/coder/.coder-activity", nil, 0o600))
+	require.Eventuallyf(t, func() bool {
+		var ok bool
+		s, ok = <-stats
+		return ok && s.Active
+	}, testutil.WaitLong, testutil.IntervalFast,
+		"never saw active stats: %+v", s,
+	)
+}
+
 func TestAgent_Stats_Magic(t *testing.T) {
 	t.Parallel()
 	t.Run("StripsEnvironmentVariable", func(t *testing.T) {
@@ -758,6 +786,49 @@ func TestAgent_Session_TTY_FastCommandHasOutput(t *testing.T) {
 	}
 }
 
+func TestAgent_Session_TTY_Recording(t *testing.T) {
+	t.Parallel()
+	if runtime.GOOS == "windows" {
+		t.Skip("ConPTY appears to be inconsistent on Windows.")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+	defer cancel()
+	//nolint:dogsled
+	conn, client, _, _, _ := setupAgent(t, agentsdk.Manifest{SessionRecording: true}, 0)
+	sshClient, err := conn.SSHClient(ctx)
+	require.NoError(t, err)
+	defer sshClient.Close()
+
+	session, err := sshClient.NewSession()
+	require.NoError(t, err)
+	defer session.Close()
+	err = session.RequestPty("xterm", 24, 80, ssh.TerminalModes{})
+	require.NoError(t, err)
+	var stdout bytes.Buffer
+	session.Stdout = &stdout
+	err = session.Run("echo recorded")
+	require.NoError(t, err)
+	require.Contains(t, stdout.String(), "recorded")
+
+	var recording agenttest.SessionRecording
+	require.Eventually(t, func() bool {
+		recordings := client.GetSessionRecordings()
+		if len(recordings) != 1 || !recordings[0].Ended {
+			return false
+		}
+		recording = recordings[0]
+		return true
+	}, testutil.WaitShort, testutil.IntervalFast)
+
+	require.Equal(t, "ssh", recording.Recording.SessionType)
+	require.Equal(t, "echo recorded", recording.Recording.Command)
+	lines := strings.Split(strings.TrimSpace(string(recording.Data)), "\n")
+	require.Contains(t, lines[0], `"version":2`)
+	require.Contains(t, lines[0], `"width":80`)
+	require.Contains(t, string(recording.Data), `"o","recorded`)
+}
+
 func TestAgent_Session_TTY_HugeOutputIsNotLost(t *testing.T) {
 	t.Parallel()
 
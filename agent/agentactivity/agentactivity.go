@@ -0,0 +1,202 @@
+// Package agentactivity detects that a workspace is in use without a
+// connection to its agent, e.g. because a build or a batch job is running.
+package agentactivity
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/afero"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
+	"github.com/coder/coder/v2/agent/agentproc"
+	"github.com/coder/coder/v2/cli/clistat"
+)
+
+// The sources of activity, as returned by Detector.Active.
+const (
+	SourceCPU       = "cpu"
+	SourceProcess   = "process"
+	SourceHeartbeat = "heartbeat"
+)
+
+// DefaultHeartbeatTimeout is how long a workspace is active after the
+// heartbeat file was modified if no timeout is configured.
+const DefaultHeartbeatTimeout = 5 * time.Minute
+
+// cpuSampleInterval is the interval CPU usage is averaged over. It's longer
+// than the default of clistat so short spikes don't count as activity.
+const cpuSampleInterval = time.Second
+
+// cpuCheckInterval is how often Run samples the CPU usage.
+const cpuCheckInterval = 10 * time.Second
+
+// Options are the sources of activity. Sources with a zero value are
+// disabled.
+type Options struct {
+	// CPUThreshold is the CPU usage of the workspace in percent of the
+	// available CPU above which it's active. In containers, the usage and
+	// limit of the container are used.
+	CPUThreshold float64
+	// Processes are the names of executables that keep the workspace active
+	// while they run, e.g. "make".
+	Processes []string
+	// HeartbeatFile is a file that keeps the workspace active for
+	// HeartbeatTimeout after it was modified, so user tooling can touch it
+	// while it works.
+	HeartbeatFile    string
+	HeartbeatTimeout time.Duration
+}
+
+// Enabled returns true if a source of activity is configured.
+func (o Options) Enabled() bool {
+	return o.CPUThreshold > 0 || len(o.Processes) > 0 || o.HeartbeatFile != ""
+}
+
+// Detector checks the configured sources of activity.
+type Detector struct {
+	opts      Options
+	logger    slog.Logger
+	fs        afero.Fs
+	syscaller agentproc.Syscaller
+
+	// cpuUsage returns the CPU usage of the workspace in percent.
+	cpuUsage func() (float64, error)
+	now      func() time.Time
+
+	mu sync.Mutex
+	// cpuActive is the result of the last CPU sample.
+	cpuActive bool
+}
+
+// New returns a detector of the sources in opts. fs and syscaller are used
+// to list processes and to stat the heartbeat file.
+func New(logger slog.Logger, fs afero.Fs, syscaller agentproc.Syscaller, opts Options) *Detector {
+	if opts.HeartbeatTimeout <= 0 {
+		opts.HeartbeatTimeout = DefaultHeartbeatTimeout
+	}
+	return &Detector{
+		opts:      opts,
+		logger:    logger,
+		fs:        fs,
+		syscaller: syscaller,
+		cpuUsage:  cpuUsage,
+		now:       time.Now,
+	}
+}
+
+// Enabled returns true if the detector has a source of activity.
+func (d *Detector) Enabled() bool {
+	return d.opts.Enabled()
+}
+
+// Run samples the CPU usage until ctx is done. Sampling blocks for a
+// second, so it's done in the background instead of when the agent collects
+// its stats, and Active reports the last sample.
+func (d *Detector) Run(ctx context.Context) {
+	if d.opts.CPUThreshold <= 0 {
+		return
+	}
+	ticker := time.NewTicker(cpuCheckInterval)
+	defer ticker.Stop()
+	for {
+		d.sampleCPU(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *Detector) sampleCPU(ctx context.Context) {
+	usage, err := d.cpuUsage()
+	if err != nil {
+		d.logger.Warn(ctx, "check cpu usage for activity", slog.Error(err))
+	}
+	d.mu.Lock()
+	d.cpuActive = err == nil && usage >= d.opts.CPUThreshold
+	d.mu.Unlock()
+}
+
+// Active returns the sources that currently report activity. Sources that
+// fail to be checked are logged and treated as inactive. The CPU is only
+// active once Run sampled it.
+func (d *Detector) Active(ctx context.Context) []string {
+	var active []string
+	d.mu.Lock()
+	cpuActive := d.cpuActive
+	d.mu.Unlock()
+	if cpuActive {
+		active = append(active, SourceCPU)
+	}
+	if len(d.opts.Processes) > 0 {
+		running, err := d.processRunning()
+		if err != nil {
+			d.logger.Warn(ctx, "check processes for activity", slog.Error(err))
+		} else if running {
+			active = append(active, SourceProcess)
+		}
+	}
+	if d.opts.HeartbeatFile != "" {
+		info, err := d.fs.Stat(d.opts.HeartbeatFile)
+		switch {
+		case err == nil:
+			if d.now().Sub(info.ModTime()) < d.opts.HeartbeatTimeout {
+				active = append(active, SourceHeartbeat)
+			}
+		// A missing file means that the tooling hasn't run yet.
+		case !xerrors.Is(err, afero.ErrFileNotFound):
+			d.logger.Warn(ctx, "check heartbeat file for activity", slog.F("path", d.opts.HeartbeatFile), slog.Error(err))
+		}
+	}
+	return active
+}
+
+// processRunning returns true if one of the configured processes runs. The
+// name of a process is the base name of its first argument, since the name
+// of the executable is truncated by the kernel.
+func (d *Detector) processRunning() (bool, error) {
+	procs, err := agentproc.List(d.fs, d.syscaller)
+	if err != nil {
+		return false, xerrors.Errorf("list processes: %w", err)
+	}
+	for _, proc := range procs {
+		argv0, _, _ := strings.Cut(proc.CmdLine, "\x00")
+		if argv0 == "" {
+			continue
+		}
+		if slices.Contains(d.opts.Processes, filepath.Base(argv0)) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// cpuUsage returns the CPU usage of the container the agent runs in, or of
+// the host if it doesn't run in one.
+func cpuUsage() (float64, error) {
+	statter, err := clistat.New(clistat.WithSampleInterval(cpuSampleInterval))
+	if err != nil {
+		return 0, err
+	}
+	res, err := statter.ContainerCPU()
+	if err != nil {
+		return 0, err
+	}
+	if res == nil || res.Total == nil {
+		res, err = statter.HostCPU()
+		if err != nil {
+			return 0, err
+		}
+	}
+	if res.Total == nil || *res.Total == 0 {
+		return 0, xerrors.New("unknown available cpu")
+	}
+	return res.Used / *res.Total * 100, nil
+}
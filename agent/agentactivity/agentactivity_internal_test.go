@@ -0,0 +1,107 @@
+package agentactivity
+
+import (
+	"runtime"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog/sloggers/slogtest"
+	"github.com/coder/coder/v2/agent/agentproc"
+	"github.com/coder/coder/v2/agent/agentproc/agentproctest"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestDetector(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Disabled", func(t *testing.T) {
+		t.Parallel()
+
+		d := New(slogtest.Make(t, nil), afero.NewMemMapFs(), nil, Options{})
+		require.False(t, d.Enabled())
+		require.Empty(t, d.Active(testutil.Context(t, testutil.WaitShort)))
+	})
+
+	t.Run("CPU", func(t *testing.T) {
+		t.Parallel()
+
+		usage := 10.0
+		d := New(slogtest.Make(t, nil), afero.NewMemMapFs(), nil, Options{CPUThreshold: 50})
+		d.cpuUsage = func() (float64, error) {
+			return usage, nil
+		}
+		ctx := testutil.Context(t, testutil.WaitShort)
+		require.True(t, d.Enabled())
+		// Nothing is active until the CPU was sampled.
+		usage = 75
+		require.Empty(t, d.Active(ctx))
+		d.sampleCPU(ctx)
+		require.Equal(t, []string{SourceCPU}, d.Active(ctx))
+		usage = 10
+		d.sampleCPU(ctx)
+		require.Empty(t, d.Active(ctx))
+	})
+
+	t.Run("CPUError", func(t *testing.T) {
+		t.Parallel()
+
+		logger := slogtest.Make(t, &slogtest.Options{IgnoreErrors: true})
+		d := New(logger, afero.NewMemMapFs(), nil, Options{CPUThreshold: 50})
+		d.cpuUsage = func() (float64, error) {
+			return 0, xerrors.New("no cgroup")
+		}
+		ctx := testutil.Context(t, testutil.WaitShort)
+		d.sampleCPU(ctx)
+		require.Empty(t, d.Active(ctx))
+	})
+
+	t.Run("Process", func(t *testing.T) {
+		t.Parallel()
+
+		if runtime.GOOS != "linux" {
+			t.Skip("processes are only listed on linux")
+		}
+
+		fs := afero.NewMemMapFs()
+		sc := agentproctest.NewMockSyscaller(gomock.NewController(t))
+		proc := agentproctest.GenerateProcess(t, fs, func(p *agentproc.Process) {
+			p.CmdLine = "/usr/bin/make\x00-j8\x00all"
+		})
+		sc.EXPECT().Kill(proc.PID, syscall.Signal(0)).Return(nil).AnyTimes()
+		ctx := testutil.Context(t, testutil.WaitShort)
+
+		d := New(slogtest.Make(t, nil), fs, sc, Options{Processes: []string{"go", "make"}})
+		require.Equal(t, []string{SourceProcess}, d.Active(ctx))
+
+		d = New(slogtest.Make(t, nil), fs, sc, Options{Processes: []string{"cargo"}})
+		require.Empty(t, d.Active(ctx))
+	})
+
+	t.Run("Heartbeat", func(t *testing.T) {
+		t.Parallel()
+
+		fs := afero.NewMemMapFs()
+		d := New(slogtest.Make(t, nil), fs, nil, Options{
+			HeartbeatFile:    "/home/coder/.coder-activity",
+			HeartbeatTimeout: time.Minute,
+		})
+		ctx := testutil.Context(t, testutil.WaitShort)
+
+		// The file doesn't exist until the tooling touches it.
+		require.Empty(t, d.Active(ctx))
+
+		require.NoError(t, afero.WriteFile(fs, "/home/coder/.coder-activity", nil, 0o600))
+		require.Equal(t, []string{SourceHeartbeat}, d.Active(ctx))
+
+		d.now = func() time.Time {
+			return time.Now().Add(2 * time.Minute)
+		}
+		require.Empty(t, d.Active(ctx))
+	})
+}
@@ -0,0 +1,44 @@
+// Package agentcontainerstest contains a fake container runtime client for
+// testing container support in the agent.
+package agentcontainerstest
+
+import (
+	"context"
+
+	"github.com/coder/coder/v2/agent/agentcontainers"
+	"github.com/coder/coder/v2/codersdk"
+)
+
+var _ agentcontainers.Client = (*FakeClient)(nil)
+
+// FakeClient is a container runtime client that returns fixed containers and
+// runs commands in the workspace instead of in a container.
+type FakeClient struct {
+	Containers []codersdk.WorkspaceAgentContainer
+	ListErr    error
+	// Exec returns the command line that stands in for running args in the
+	// container. If nil, args are run in the workspace as is.
+	Exec func(container string, opts agentcontainers.ExecOptions, args ...string) []string
+}
+
+func (*FakeClient) Runtime() string {
+	return "fake"
+}
+
+func (f *FakeClient) List(context.Context) ([]codersdk.WorkspaceAgentContainer, error) {
+	if f.ListErr != nil {
+		return nil, f.ListErr
+	}
+	containers := make([]codersdk.WorkspaceAgentContainer, 0, len(f.Containers))
+	for _, container := range f.Containers {
+		containers = append(containers, agentcontainers.WithDevcontainer(container))
+	}
+	return containers, nil
+}
+
+func (f *FakeClient) ExecCommand(container string, opts agentcontainers.ExecOptions, args ...string) []string {
+	if f.Exec != nil {
+		return f.Exec(container, opts, args...)
+	}
+	return args
+}
@@ -0,0 +1,64 @@
+// Package agentcontainers contains logic for discovering and attaching to
+// Docker and Podman containers running in the same context as the agent.
+package agentcontainers
+
+import (
+	"context"
+
+	"github.com/coder/coder/v2/codersdk"
+)
+
+const (
+	// DevcontainerLocalFolderLabel is set by the devcontainer CLI to the
+	// folder of the workspace a devcontainer was created from.
+	DevcontainerLocalFolderLabel = "devcontainer.local_folder"
+	// DevcontainerConfigFileLabel is set by the devcontainer CLI to the path
+	// of the devcontainer.json a devcontainer was created from.
+	DevcontainerConfigFileLabel = "devcontainer.config_file"
+)
+
+// loginShellScript starts the login shell of the user in a container, which
+// isn't known to the agent. awk is used over getent since minimal images
+// often lack the latter.
+const loginShellScript = `shell=$(awk -F: -v u="$(id -un)" '$1 == u { print $7 }' /etc/passwd 2>/dev/null); exec "${shell:-/bin/sh}" -l`
+
+// Client is a client of a container runtime.
+type Client interface {
+	// Runtime returns the name of the container runtime, e.g. "docker".
+	Runtime() string
+	// List returns the running containers.
+	List(ctx context.Context) ([]codersdk.WorkspaceAgentContainer, error)
+	// ExecCommand returns the command line that runs args in the container,
+	// which is a container name or ID.
+	ExecCommand(container string, opts ExecOptions, args ...string) []string
+}
+
+// ExecOptions are options for running a command in a container.
+type ExecOptions struct {
+	// User is the user the command runs as. If empty, the default user of
+	// the container is used.
+	User string
+	// TTY allocates a terminal for the command.
+	TTY bool
+	// Env are environment variables in the KEY=VALUE form set for the
+	// command.
+	Env []string
+}
+
+// ShellCommand returns the command that runs script with the shell of a
+// container, like CreateCommand does in the workspace. An empty script
+// starts a login shell.
+func ShellCommand(script string) []string {
+	if script == "" {
+		return []string{"/bin/sh", "-c", loginShellScript}
+	}
+	return []string{"/bin/sh", "-c", script}
+}
+
+// WithDevcontainer sets the devcontainer fields of the container from its
+// labels.
+func WithDevcontainer(container codersdk.WorkspaceAgentContainer) codersdk.WorkspaceAgentContainer {
+	container.DevcontainerWorkspaceFolder = container.Labels[DevcontainerLocalFolderLabel]
+	container.DevcontainerConfigPath = container.Labels[DevcontainerConfigFileLabel]
+	return container
+}
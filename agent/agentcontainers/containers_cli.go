@@ -0,0 +1,219 @@
+package agentcontainers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/codersdk"
+)
+
+// runtimeBinaries are the CLIs of the supported container runtimes in order
+// of preference. Podman is compatible with the Docker CLI for the commands
+// used here.
+var runtimeBinaries = []string{"docker", "podman"}
+
+// DetectCLIClient returns a client for the first container runtime CLI found
+// in PATH, or nil if there is none.
+func DetectCLIClient() Client {
+	for _, name := range runtimeBinaries {
+		if binary, err := exec.LookPath(name); err == nil {
+			return NewCLIClient(binary)
+		}
+	}
+	return nil
+}
+
+// NewCLIClient returns a client that runs the Docker compatible CLI binary.
+func NewCLIClient(binary string) Client {
+	return &cliClient{
+		binary: binary,
+		run: func(ctx context.Context, name string, args ...string) ([]byte, error) {
+			var stderr bytes.Buffer
+			cmd := exec.CommandContext(ctx, name, args...)
+			cmd.Stderr = &stderr
+			out, err := cmd.Output()
+			if err != nil {
+				return out, &runError{
+					cmd:    filepath.Base(name) + " " + args[0],
+					err:    err,
+					stderr: strings.TrimSpace(stderr.String()),
+				}
+			}
+			return out, nil
+		},
+	}
+}
+
+type cliClient struct {
+	binary string
+	// run returns the stdout of the command. If the command fails, the
+	// error is a *runError and stdout is returned as well.
+	run func(ctx context.Context, name string, args ...string) ([]byte, error)
+}
+
+// runError is returned when a command of the CLI exits with an error.
+type runError struct {
+	cmd    string
+	err    error
+	stderr string
+}
+
+func (e *runError) Error() string {
+	return fmt.Sprintf("run %s: %s: %s", e.cmd, e.err, e.stderr)
+}
+
+func (e *runError) Unwrap() error {
+	return e.err
+}
+
+// onlyNoSuchContainer returns whether err only reports containers that don't
+// exist. Containers that exit between `ps` and `inspect` are reported like
+// that, and the other containers are still inspected.
+func onlyNoSuchContainer(err error) bool {
+	var runErr *runError
+	if !xerrors.As(err, &runErr) || runErr.stderr == "" {
+		return false
+	}
+	for _, line := range strings.Split(runErr.stderr, "\n") {
+		if !strings.Contains(strings.ToLower(line), "no such container") {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *cliClient) Runtime() string {
+	return strings.TrimSuffix(filepath.Base(c.binary), ".exe")
+}
+
+func (c *cliClient) List(ctx context.Context) ([]codersdk.WorkspaceAgentContainer, error) {
+	out, err := c.run(ctx, c.binary, "ps", "--quiet", "--no-trunc")
+	if err != nil {
+		return nil, err
+	}
+	ids := strings.Fields(string(out))
+	if len(ids) == 0 {
+		return []codersdk.WorkspaceAgentContainer{}, nil
+	}
+	out, err = c.run(ctx, c.binary, append([]string{"inspect", "--type=container"}, ids...)...)
+	if err != nil && !onlyNoSuchContainer(err) {
+		return nil, err
+	}
+	if len(bytes.TrimSpace(out)) == 0 {
+		return []codersdk.WorkspaceAgentContainer{}, nil
+	}
+	return parseInspect(out)
+}
+
+func (c *cliClient) ExecCommand(container string, opts ExecOptions, args ...string) []string {
+	cmd := []string{c.binary, "exec", "--interactive"}
+	if opts.TTY {
+		cmd = append(cmd, "--tty")
+	}
+	if opts.User != "" {
+		cmd = append(cmd, "--user", opts.User)
+	}
+	for _, kv := range opts.Env {
+		cmd = append(cmd, "--env", kv)
+	}
+	cmd = append(cmd, container)
+	return append(cmd, args...)
+}
+
+// inspectContainer is the subset of the output of `docker inspect` and
+// `podman inspect` that is reported.
+type inspectContainer struct {
+	ID      string    `json:"Id"`
+	Name    string    `json:"Name"`
+	Created time.Time `json:"Created"`
+	State   struct {
+		Status string `json:"Status"`
+	} `json:"State"`
+	Config struct {
+		Image  string            `json:"Image"`
+		Labels map[string]string `json:"Labels"`
+	} `json:"Config"`
+	NetworkSettings struct {
+		// Ports maps a port of the container, e.g. "8080/tcp", to the
+		// addresses it's published on. Exposed ports that aren't published
+		// have no bindings.
+		Ports map[string][]struct {
+			HostIP   string `json:"HostIp"`
+			HostPort string `json:"HostPort"`
+		} `json:"Ports"`
+	} `json:"NetworkSettings"`
+}
+
+func parseInspect(data []byte) ([]codersdk.WorkspaceAgentContainer, error) {
+	var inspected []inspectContainer
+	if err := json.Unmarshal(data, &inspected); err != nil {
+		return nil, xerrors.Errorf("decode inspect output: %w", err)
+	}
+
+	containers := make([]codersdk.WorkspaceAgentContainer, 0, len(inspected))
+	for _, in := range inspected {
+		labels := in.Config.Labels
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		container := codersdk.WorkspaceAgentContainer{
+			ID:        in.ID,
+			Name:      strings.TrimPrefix(in.Name, "/"),
+			Image:     in.Config.Image,
+			CreatedAt: in.Created,
+			Status:    in.State.Status,
+			Labels:    labels,
+			Ports:     []codersdk.WorkspaceAgentContainerPort{},
+		}
+		for spec, bindings := range in.NetworkSettings.Ports {
+			rawPort, network, _ := strings.Cut(spec, "/")
+			port, err := strconv.ParseUint(rawPort, 10, 16)
+			if err != nil {
+				return nil, xerrors.Errorf("parse port %q of container %s: %w", spec, container.Name, err)
+			}
+			if len(bindings) == 0 {
+				container.Ports = append(container.Ports, codersdk.WorkspaceAgentContainerPort{
+					Port:    uint16(port),
+					Network: network,
+				})
+				continue
+			}
+			for _, binding := range bindings {
+				hostPort, err := strconv.ParseUint(binding.HostPort, 10, 16)
+				if err != nil {
+					return nil, xerrors.Errorf("parse host port %q of container %s: %w", binding.HostPort, container.Name, err)
+				}
+				container.Ports = append(container.Ports, codersdk.WorkspaceAgentContainerPort{
+					Port:     uint16(port),
+					Network:  network,
+					HostIP:   binding.HostIP,
+					HostPort: uint16(hostPort),
+				})
+			}
+		}
+		slices.SortFunc(container.Ports, func(a, b codersdk.WorkspaceAgentContainerPort) int {
+			if a.Port != b.Port {
+				return int(a.Port) - int(b.Port)
+			}
+			if a.Network != b.Network {
+				return strings.Compare(a.Network, b.Network)
+			}
+			return strings.Compare(a.HostIP, b.HostIP)
+		})
+		containers = append(containers, WithDevcontainer(container))
+	}
+	slices.SortFunc(containers, func(a, b codersdk.WorkspaceAgentContainer) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return containers, nil
+}
@@ -0,0 +1,169 @@
+package agentcontainers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/codersdk"
+)
+
+// inspectOutput is trimmed `docker inspect` output of a devcontainer and a
+// plain container.
+const inspectOutput = `[
+  {
+    "Id": "4f2a0bfa",
+    "Created": "2024-09-10T12:30:00.123456789Z",
+    "Name": "/web",
+    "State": {"Status": "running"},
+    "Config": {"Image": "nginx:latest", "Labels": null},
+    "NetworkSettings": {
+      "Ports": {
+        "80/tcp": [
+          {"HostIp": "0.0.0.0", "HostPort": "8080"},
+          {"HostIp": "::", "HostPort": "8080"}
+        ],
+        "443/tcp": null
+      }
+    }
+  },
+  {
+    "Id": "0b1c2d3e",
+    "Created": "2024-09-10T11:00:00Z",
+    "Name": "/vibrant_tesla",
+    "State": {"Status": "running"},
+    "Config": {
+      "Image": "vsc-coder-1a2b3c",
+      "Labels": {
+        "devcontainer.local_folder": "/home/coder/coder",
+        "devcontainer.config_file": "/home/coder/coder/.devcontainer/devcontainer.json"
+      }
+    },
+    "NetworkSettings": {"Ports": {}}
+  }
+]`
+
+func TestCLIClientList(t *testing.T) {
+	t.Parallel()
+
+	var calls [][]string
+	client := &cliClient{
+		binary: "/usr/bin/docker",
+		run: func(_ context.Context, name string, args ...string) ([]byte, error) {
+			assert.Equal(t, "/usr/bin/docker", name)
+			calls = append(calls, args)
+			if args[0] == "ps" {
+				return []byte("4f2a0bfa\n0b1c2d3e\n"), nil
+			}
+			return []byte(inspectOutput), nil
+		},
+	}
+	require.Equal(t, "docker", client.Runtime())
+
+	containers, err := client.List(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, [][]string{
+		{"ps", "--quiet", "--no-trunc"},
+		{"inspect", "--type=container", "4f2a0bfa", "0b1c2d3e"},
+	}, calls)
+
+	require.Equal(t, []codersdk.WorkspaceAgentContainer{
+		{
+			ID:        "0b1c2d3e",
+			Name:      "vibrant_tesla",
+			Image:     "vsc-coder-1a2b3c",
+			CreatedAt: time.Date(2024, 9, 10, 11, 0, 0, 0, time.UTC),
+			Status:    "running",
+			Labels: map[string]string{
+				DevcontainerLocalFolderLabel: "/home/coder/coder",
+				DevcontainerConfigFileLabel:  "/home/coder/coder/.devcontainer/devcontainer.json",
+			},
+			Ports:                       []codersdk.WorkspaceAgentContainerPort{},
+			DevcontainerWorkspaceFolder: "/home/coder/coder",
+			DevcontainerConfigPath:      "/home/coder/coder/.devcontainer/devcontainer.json",
+		},
+		{
+			ID:        "4f2a0bfa",
+			Name:      "web",
+			Image:     "nginx:latest",
+			CreatedAt: time.Date(2024, 9, 10, 12, 30, 0, 123456789, time.UTC),
+			Status:    "running",
+			Labels:    map[string]string{},
+			Ports: []codersdk.WorkspaceAgentContainerPort{
+				{Port: 80, Network: "tcp", HostIP: "0.0.0.0", HostPort: 8080},
+				{Port: 80, Network: "tcp", HostIP: "::", HostPort: 8080},
+				{Port: 443, Network: "tcp"},
+			},
+		},
+	}, containers)
+}
+
+func TestCLIClientListNone(t *testing.T) {
+	t.Parallel()
+
+	client := &cliClient{
+		binary: "podman",
+		run: func(_ context.Context, _ string, args ...string) ([]byte, error) {
+			require.Equal(t, "ps", args[0], "inspect must not run without containers")
+			return []byte("\n"), nil
+		},
+	}
+	containers, err := client.List(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, containers)
+	require.NotNil(t, containers)
+}
+
+func TestCLIClientListExited(t *testing.T) {
+	t.Parallel()
+
+	// A container that exits between `ps` and `inspect` fails the inspect,
+	// but the other containers are still reported.
+	client := &cliClient{
+		binary: "docker",
+		run: func(_ context.Context, _ string, args ...string) ([]byte, error) {
+			if args[0] == "ps" {
+				return []byte("4f2a0bfa\n0b1c2d3e\n9e8d7c6b\n"), nil
+			}
+			return []byte(inspectOutput), &runError{
+				cmd:    "docker inspect",
+				err:    xerrors.New("exit status 1"),
+				stderr: "Error: No such container: 9e8d7c6b",
+			}
+		},
+	}
+	containers, err := client.List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, containers, 2)
+
+	client.run = func(_ context.Context, _ string, args ...string) ([]byte, error) {
+		if args[0] == "ps" {
+			return []byte("4f2a0bfa\n"), nil
+		}
+		return nil, &runError{
+			cmd:    "docker inspect",
+			err:    xerrors.New("exit status 1"),
+			stderr: "permission denied while trying to connect to the Docker daemon socket",
+		}
+	}
+	_, err = client.List(context.Background())
+	require.ErrorContains(t, err, "permission denied")
+}
+
+func TestCLIClientExecCommand(t *testing.T) {
+	t.Parallel()
+
+	client := &cliClient{binary: "podman"}
+	require.Equal(t,
+		[]string{"podman", "exec", "--interactive", "web", "ls"},
+		client.ExecCommand("web", ExecOptions{}, "ls"),
+	)
+	require.Equal(t,
+		[]string{"podman", "exec", "--interactive", "--tty", "--user", "node", "--env", "TERM=xterm", "web", "/bin/sh", "-c", "echo hi"},
+		client.ExecCommand("web", ExecOptions{User: "node", TTY: true, Env: []string{"TERM=xterm"}}, ShellCommand("echo hi")...),
+	)
+}
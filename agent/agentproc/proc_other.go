@@ -4,6 +4,8 @@
 package agentproc
 
 import (
+	"time"
+
 	"github.com/spf13/afero"
 )
 
@@ -22,3 +24,11 @@ func (*Process) Cmd() string {
 func List(afero.Fs, Syscaller) ([]*Process, error) {
 	return nil, errUnimplemented
 }
+
+func (*Process) Stat(afero.Fs, time.Time) (Stat, error) {
+	return Stat{}, errUnimplemented
+}
+
+func BootTime(afero.Fs) (time.Time, error) {
+	return time.Time{}, errUnimplemented
+}
@@ -1,9 +1,11 @@
 package agentproc_test
 
 import (
+	"os"
 	"runtime"
 	"syscall"
 	"testing"
+	"time"
 
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/require"
@@ -163,4 +165,39 @@ func TestProcess(t *testing.T) {
 
 		require.Equal(t, expectedName, proc.Cmd())
 	})
+
+	t.Run("Stat", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			fs   = afero.NewMemMapFs()
+			proc = &agentproc.Process{
+				PID: 32,
+				Dir: "/proc/32",
+			}
+			bootTime = time.Unix(1700000000, 0)
+		)
+
+		err := afero.WriteFile(fs, "/proc/stat", []byte("cpu  1 2 3 4\nbtime 1700000000\nprocesses 42\n"), 0o444)
+		require.NoError(t, err)
+		// The name contains a space and a parenthesis to check the fields
+		// are split after the name.
+		err = afero.WriteFile(fs, "/proc/32/stat", []byte("32 (my (cmd) S 1 32 32 0 -1 4194560 100 0 0 0 150 50 0 0 20 0 1 0 6000 1000000 25 18446744073709551615 0 0 0 0 0 0 0 0 0 0 0 0 17 0 0 0 0 0 0\n"), 0o444)
+		require.NoError(t, err)
+		err = afero.WriteFile(fs, "/proc/32/status", []byte("Name:\tmy (cmd\nPPid:\t1\nUid:\t1000\t1000\t1000\t1000\n"), 0o444)
+		require.NoError(t, err)
+
+		actualBootTime, err := agentproc.BootTime(fs)
+		require.NoError(t, err)
+		require.True(t, bootTime.Equal(actualBootTime))
+
+		stat, err := proc.Stat(fs, bootTime)
+		require.NoError(t, err)
+		require.Equal(t, "my (cmd", stat.Name)
+		require.EqualValues(t, 1, stat.ParentPID)
+		require.EqualValues(t, 1000, stat.UID)
+		require.Equal(t, 2*time.Second, stat.CPUTime)
+		require.EqualValues(t, 25*os.Getpagesize(), stat.RSS)
+		require.True(t, bootTime.Add(time.Minute).Equal(stat.StartTime))
+	})
 }
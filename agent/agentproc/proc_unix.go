@@ -10,6 +10,7 @@ import (
 	"strconv"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/spf13/afero"
 	"golang.org/x/xerrors"
@@ -116,6 +117,117 @@ func (p *Process) SetNiceness(sc Syscaller, score int) error {
 	return nil
 }
 
+// userHZ is the unit of the process times in /proc. It's 100 on all
+// architectures Linux supports, procps assumes the same.
+const userHZ = 100
+
+// Stat reads the resource usage of the process. bootTime is used to
+// compute when the process started, see BootTime.
+func (p *Process) Stat(fs afero.Fs, bootTime time.Time) (Stat, error) {
+	data, err := afero.ReadFile(fs, filepath.Join(p.Dir, "stat"))
+	if err != nil {
+		return Stat{}, xerrors.Errorf("read stat: %w", err)
+	}
+	// The name is in parentheses and may contain spaces and parentheses
+	// itself, so the fields are split after the last one.
+	raw := string(data)
+	open, closing := strings.IndexByte(raw, '('), strings.LastIndexByte(raw, ')')
+	if open < 0 || closing < open {
+		return Stat{}, xerrors.Errorf("malformed stat %q", raw)
+	}
+	// The fields start at the state, which is the third field.
+	fields := strings.Fields(raw[closing+1:])
+	if len(fields) < 22 {
+		return Stat{}, xerrors.Errorf("malformed stat %q", raw)
+	}
+	field := func(n int) (int64, error) {
+		v, err := strconv.ParseInt(fields[n-3], 10, 64)
+		if err != nil {
+			return 0, xerrors.Errorf("parse field %d of stat: %w", n, err)
+		}
+		return v, nil
+	}
+	ppid, err := field(4)
+	if err != nil {
+		return Stat{}, err
+	}
+	utime, err := field(14)
+	if err != nil {
+		return Stat{}, err
+	}
+	stime, err := field(15)
+	if err != nil {
+		return Stat{}, err
+	}
+	startTime, err := field(22)
+	if err != nil {
+		return Stat{}, err
+	}
+	rss, err := field(24)
+	if err != nil {
+		return Stat{}, err
+	}
+
+	uid, err := p.uid(fs)
+	if err != nil {
+		return Stat{}, err
+	}
+
+	return Stat{
+		Name:      raw[open+1 : closing],
+		ParentPID: int32(ppid),
+		UID:       uid,
+		CPUTime:   time.Duration(utime+stime) * time.Second / userHZ,
+		RSS:       rss * int64(os.Getpagesize()),
+		StartTime: bootTime.Add(time.Duration(startTime) * time.Second / userHZ),
+	}, nil
+}
+
+// uid returns the real user ID of the process.
+func (p *Process) uid(fs afero.Fs) (uint32, error) {
+	data, err := afero.ReadFile(fs, filepath.Join(p.Dir, "status"))
+	if err != nil {
+		return 0, xerrors.Errorf("read status: %w", err)
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		value, ok := strings.CutPrefix(line, "Uid:")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(value)
+		if len(fields) == 0 {
+			break
+		}
+		uid, err := strconv.ParseUint(fields[0], 10, 32)
+		if err != nil {
+			return 0, xerrors.Errorf("parse uid: %w", err)
+		}
+		return uint32(uid), nil
+	}
+	return 0, xerrors.New("no uid in status")
+}
+
+// BootTime returns when the system booted, which process start times are
+// relative to.
+func BootTime(fs afero.Fs) (time.Time, error) {
+	data, err := afero.ReadFile(fs, filepath.Join(defaultProcDir, "stat"))
+	if err != nil {
+		return time.Time{}, xerrors.Errorf("read stat: %w", err)
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		value, ok := strings.CutPrefix(line, "btime ")
+		if !ok {
+			continue
+		}
+		btime, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			return time.Time{}, xerrors.Errorf("parse btime: %w", err)
+		}
+		return time.Unix(btime, 0), nil
+	}
+	return time.Time{}, xerrors.New("no btime in stat")
+}
+
 func (p *Process) Cmd() string {
 	return strings.Join(p.cmdLine(), " ")
 }
@@ -2,6 +2,7 @@ package agentproc
 
 import (
 	"syscall"
+	"time"
 )
 
 type Syscaller interface {
@@ -19,3 +20,17 @@ type Process struct {
 	PID         int32
 	OOMScoreAdj int
 }
+
+// Stat is the resource usage of a process, read from /proc.
+type Stat struct {
+	// Name is the name of the executable, truncated to 15 characters by
+	// the kernel.
+	Name      string
+	ParentPID int32
+	UID       uint32
+	// CPUTime is the time the process has spent in user and kernel mode.
+	CPUTime time.Duration
+	// RSS is the resident set size of the process in bytes.
+	RSS       int64
+	StartTime time.Time
+}
@@ -9,6 +9,7 @@ import (
 	"os/exec"
 	"os/user"
 	"path/filepath"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -41,6 +42,12 @@ var (
 	//    blocking login, and avoiding doing so indefinitely)
 	// 2. Improved command cancellation on timeout
 	ErrOutputPipesOpen = xerrors.New("script exited without closing output pipes")
+	// ErrScriptNotFound is returned when running a script the agent doesn't
+	// know about.
+	ErrScriptNotFound = xerrors.New("script not found")
+	// ErrScriptRunning is returned when running a script that is already
+	// running.
+	ErrScriptRunning = xerrors.New("script is already running")
 
 	parser = cron.NewParser(cron.Second | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.DowOptional)
 )
@@ -69,6 +76,7 @@ func New(opts Options) *Runner {
 		cronCtxCancel: cronCtxCancel,
 		cron:          cron.New(cron.WithParser(parser)),
 		closed:        make(chan struct{}),
+		running:       make(map[uuid.UUID]chan struct{}),
 		dataDir:       filepath.Join(opts.DataDirBase, "coder-script-data"),
 		scriptsExecuted: prometheus.NewCounterVec(prometheus.CounterOpts{
 			Namespace: "agent",
@@ -90,10 +98,18 @@ type Runner struct {
 	closeMutex      sync.Mutex
 	cron            *cron.Cron
 	initialized     atomic.Bool
-	scripts         []codersdk.WorkspaceAgentScript
 	dataDir         string
 	scriptCompleted ScriptCompletedFunc
 
+	// mu protects scripts and running, which are read when scripts are run
+	// on demand.
+	mu      sync.Mutex
+	scripts []codersdk.WorkspaceAgentScript
+	// running holds the scripts with a run in progress, with a channel that's
+	// closed once the run is done. A script only runs once at a time, however
+	// it's started.
+	running map[uuid.UUID]chan struct{}
+
 	// scriptsExecuted includes all scripts executed by the workspace agent. Agents
 	// execute startup scripts, and scripts on a cron schedule. Both will increment
 	// this counter.
@@ -127,7 +143,9 @@ func (r *Runner) Init(scripts []codersdk.WorkspaceAgentScript, scriptCompleted S
 		return xerrors.New("init: already initialized")
 	}
 	r.initialized.Store(true)
+	r.mu.Lock()
 	r.scripts = scripts
+	r.mu.Unlock()
 	r.scriptCompleted = scriptCompleted
 	r.Logger.Info(r.cronCtx, "initializing agent scripts", slog.F("script_count", len(scripts)), slog.F("log_dir", r.LogDir))
 
@@ -142,7 +160,7 @@ func (r *Runner) Init(scripts []codersdk.WorkspaceAgentScript, scriptCompleted S
 		}
 		script := script
 		_, err := r.cron.AddFunc(script.Cron, func() {
-			err := r.trackRun(r.cronCtx, script, ExecuteCronScripts)
+			err := r.runOnce(r.cronCtx, script, ExecuteCronScripts)
 			if err != nil {
 				r.Logger.Warn(context.Background(), "run agent script on schedule", slog.Error(err))
 			}
@@ -188,12 +206,19 @@ const (
 	ExecuteStartScripts
 	ExecuteStopScripts
 	ExecuteCronScripts
+	// ExecuteManualScript is the option of scripts run on demand with
+	// RunScript. Execute doesn't run any scripts with it.
+	ExecuteManualScript
 )
 
 // Execute runs a set of scripts according to a filter.
 func (r *Runner) Execute(ctx context.Context, option ExecuteOption) error {
+	r.mu.Lock()
+	scripts := r.scripts
+	r.mu.Unlock()
+
 	var eg errgroup.Group
-	for _, script := range r.scripts {
+	for _, script := range scripts {
 		runScript := (option == ExecuteStartScripts && script.RunOnStart) ||
 			(option == ExecuteStopScripts && script.RunOnStop) ||
 			(option == ExecuteCronScripts && script.Cron != "") ||
@@ -205,7 +230,7 @@ func (r *Runner) Execute(ctx context.Context, option ExecuteOption) error {
 
 		script := script
 		eg.Go(func() error {
-			err := r.trackRun(ctx, script, option)
+			err := r.runAfter(ctx, script, option)
 			if err != nil {
 				return xerrors.Errorf("run agent script %q: %w", script.LogSourceID, err)
 			}
@@ -215,6 +240,108 @@ func (r *Runner) Execute(ctx context.Context, option ExecuteOption) error {
 	return eg.Wait()
 }
 
+// RunScript runs a script on demand, regardless of when it's configured to
+// run. The script runs in the background until it exits, or the runner is
+// closed.
+func (r *Runner) RunScript(id uuid.UUID) error {
+	r.mu.Lock()
+	idx := slices.IndexFunc(r.scripts, func(script codersdk.WorkspaceAgentScript) bool {
+		return script.ID == id
+	})
+	if idx == -1 {
+		r.mu.Unlock()
+		return ErrScriptNotFound
+	}
+	script := r.scripts[idx]
+	r.mu.Unlock()
+
+	// Mark the script as running before returning, so that it can't be
+	// started twice.
+	done, err := r.startRun(id)
+	if err != nil {
+		return err
+	}
+	err = r.trackCommandGoroutine(func() {
+		defer done()
+		err := r.trackRun(r.cronCtx, script, ExecuteManualScript)
+		if err != nil {
+			r.Logger.Warn(r.cronCtx, "run agent script on demand", slog.Error(err))
+		}
+	})
+	if err != nil {
+		done()
+		return xerrors.Errorf("run script: %w", err)
+	}
+	return nil
+}
+
+// startRun marks the script as running, and returns the function that marks
+// it as done. It returns ErrScriptRunning if the script is already running.
+func (r *Runner) startRun(id uuid.UUID) (done func(), err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.running[id]; ok {
+		return nil, ErrScriptRunning
+	}
+	return r.markRunningLocked(id), nil
+}
+
+// awaitRun is like startRun, but waits for a run in progress to finish
+// instead of failing.
+func (r *Runner) awaitRun(ctx context.Context, id uuid.UUID) (done func(), err error) {
+	for {
+		r.mu.Lock()
+		running, ok := r.running[id]
+		if !ok {
+			done := r.markRunningLocked(id)
+			r.mu.Unlock()
+			return done, nil
+		}
+		r.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-running:
+		}
+	}
+}
+
+// markRunningLocked marks the script as running, and returns the function
+// that marks it as done. r.mu must be held.
+func (r *Runner) markRunningLocked(id uuid.UUID) func() {
+	running := make(chan struct{})
+	r.running[id] = running
+	return func() {
+		r.mu.Lock()
+		delete(r.running, id)
+		r.mu.Unlock()
+		close(running)
+	}
+}
+
+// runOnce runs the script unless it's already running.
+func (r *Runner) runOnce(ctx context.Context, script codersdk.WorkspaceAgentScript, option ExecuteOption) error {
+	done, err := r.startRun(script.ID)
+	if err != nil {
+		return err
+	}
+	defer done()
+	return r.trackRun(ctx, script, option)
+}
+
+// runAfter runs the script once a run in progress, e.g. one on demand, has
+// finished. The scripts of the agent's lifecycle must run, so they wait
+// rather than fail.
+func (r *Runner) runAfter(ctx context.Context, script codersdk.WorkspaceAgentScript, option ExecuteOption) error {
+	done, err := r.awaitRun(ctx, script.ID)
+	if err != nil {
+		return err
+	}
+	defer done()
+	return r.trackRun(ctx, script, option)
+}
+
 // trackRun wraps "run" with metrics.
 func (r *Runner) trackRun(ctx context.Context, script codersdk.WorkspaceAgentScript, option ExecuteOption) error {
 	err := r.run(ctx, script, option)
@@ -351,6 +478,8 @@ func (r *Runner) run(ctx context.Context, script codersdk.WorkspaceAgentScript,
 				stage = proto.Timing_STOP
 			case ExecuteCronScripts:
 				stage = proto.Timing_CRON
+			case ExecuteManualScript:
+				stage = proto.Timing_MANUAL
 			}
 
 			var status proto.Timing_Status
@@ -436,13 +565,17 @@ func (r *Runner) run(ctx context.Context, script codersdk.WorkspaceAgentScript,
 
 func (r *Runner) Close() error {
 	r.closeMutex.Lock()
-	defer r.closeMutex.Unlock()
 	if r.isClosed() {
+		r.closeMutex.Unlock()
 		return nil
 	}
 	close(r.closed)
 	// Must cancel the cron ctx BEFORE stopping the cron.
 	r.cronCtxCancel()
+	// No goroutines can be tracked once closed, so the waits below don't
+	// need the mutex. Running scripts take it to track their commands, so
+	// holding it would deadlock them.
+	r.closeMutex.Unlock()
 	<-r.cron.Stop().Done()
 	r.cmdCloseWait.Wait()
 	return nil
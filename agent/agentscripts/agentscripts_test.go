@@ -18,6 +18,7 @@ import (
 	"github.com/coder/coder/v2/agent/agentscripts"
 	"github.com/coder/coder/v2/agent/agentssh"
 	"github.com/coder/coder/v2/agent/agenttest"
+	"github.com/coder/coder/v2/agent/proto"
 	"github.com/coder/coder/v2/codersdk"
 	"github.com/coder/coder/v2/codersdk/agentsdk"
 	"github.com/coder/coder/v2/testutil"
@@ -142,6 +143,49 @@ func TestScriptReportsTiming(t *testing.T) {
 	require.GreaterOrEqual(t, timing.End.AsTime(), timing.Start.AsTime())
 }
 
+func TestRunScript(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutil.Context(t, testutil.WaitMedium)
+	fLogger := newFakeScriptLogger()
+	runner := setup(t, func(uuid2 uuid.UUID) agentscripts.ScriptLogger {
+		return fLogger
+	})
+	defer runner.Close()
+
+	aAPI := agenttest.NewFakeAgentAPI(t, slogtest.Make(t, nil), nil, nil)
+	id := uuid.New()
+	err := runner.Init([]codersdk.WorkspaceAgentScript{{
+		ID:          id,
+		LogSourceID: uuid.New(),
+		Script:      "sleep 1 && echo hello",
+		RunOnStop:   true,
+	}}, aAPI.ScriptCompleted)
+	require.NoError(t, err)
+
+	require.ErrorIs(t, runner.RunScript(uuid.New()), agentscripts.ErrScriptNotFound)
+	require.NoError(t, runner.RunScript(id))
+	require.ErrorIs(t, runner.RunScript(id), agentscripts.ErrScriptRunning)
+	// Scripts run by the agent, e.g. on shutdown, wait for a run on demand to
+	// finish rather than overlapping with it or failing.
+	require.NoError(t, runner.Execute(ctx, agentscripts.ExecuteStopScripts))
+
+	for range 2 {
+		log := testutil.RequireRecvCtx(ctx, t, fLogger.logs)
+		require.Equal(t, "hello", log.Output)
+	}
+
+	require.Eventually(t, func() bool {
+		return len(aAPI.GetTimings()) == 2
+	}, testutil.WaitShort, testutil.IntervalFast)
+	manual, stop := aAPI.GetTimings()[0], aAPI.GetTimings()[1]
+	require.Equal(t, proto.Timing_MANUAL, manual.Stage)
+	require.Equal(t, proto.Timing_OK, manual.Status)
+	require.Equal(t, proto.Timing_STOP, stop.Stage)
+	require.Equal(t, proto.Timing_OK, stop.Status)
+	require.False(t, stop.Start.AsTime().Before(manual.End.AsTime()), "runs overlapped")
+}
+
 // TestCronClose exists because cron.Run() can happen after cron.Close().
 // If this happens, there used to be a deadlock.
 func TestCronClose(t *testing.T) {
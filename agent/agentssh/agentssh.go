@@ -14,6 +14,8 @@ import (
 	"os/user"
 	"path/filepath"
 	"runtime"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -30,6 +32,7 @@ import (
 
 	"cdr.dev/slog"
 
+	"github.com/coder/coder/v2/agent/agentcontainers"
 	"github.com/coder/coder/v2/agent/usershell"
 	"github.com/coder/coder/v2/codersdk"
 	"github.com/coder/coder/v2/pty"
@@ -49,6 +52,13 @@ const (
 	// MagicSessionTypeJetBrains is set in the SSH config by the JetBrains
 	// extension to identify itself.
 	MagicSessionTypeJetBrains = "jetbrains"
+	// ContainerEnvironmentVariable is set by clients to run the session in
+	// the container of the workspace with this name or ID. It's stripped
+	// from the environment of the session.
+	ContainerEnvironmentVariable = "CODER_CONTAINER"
+	// ContainerUserEnvironmentVariable is set by clients to run the session
+	// as this user in the container instead of its default user.
+	ContainerUserEnvironmentVariable = "CODER_CONTAINER_USER"
 	// MagicProcessCmdlineJetBrains is a string in a process's command line that
 	// uniquely identifies it as JetBrains software.
 	MagicProcessCmdlineJetBrains = "idea.vendor.name=JetBrains"
@@ -84,6 +94,19 @@ type Config struct {
 	X11DisplayOffset *int
 	// BlockFileTransfer restricts use of file transfer applications.
 	BlockFileTransfer bool
+	// RecordSession returns the writer a session with a PTY is recorded to
+	// in the asciicast v2 format, or nil if the session should not be
+	// recorded. The writer is closed when the session ends.
+	RecordSession func(id uuid.UUID, sessionType, command string, startedAt time.Time) io.WriteCloser
+	// ForwardPolicy returns the policy port forwards are checked against. A
+	// nil policy allows all port forwards.
+	ForwardPolicy func() *ForwardPolicy
+	// ReportForwardDenied is called when a port forward is denied by the
+	// policy.
+	ReportForwardDenied func(kind, destination string, err error)
+	// ContainerClient is the client of the container runtime sessions in
+	// containers are run with. If nil, such sessions fail.
+	ContainerClient agentcontainers.Client
 }
 
 type Server struct {
@@ -146,9 +169,14 @@ func NewServer(ctx context.Context, logger slog.Logger, prometheusRegistry *prom
 			return home
 		}
 	}
+	if config.ForwardPolicy == nil {
+		config.ForwardPolicy = func() *ForwardPolicy { return nil }
+	}
+	if config.ReportForwardDenied == nil {
+		config.ReportForwardDenied = func(string, string, error) {}
+	}
 
 	forwardHandler := &ssh.ForwardedTCPHandler{}
-	unixForwardHandler := newForwardedUnixHandler(logger)
 
 	metrics := newSSHServerMetrics(prometheusRegistry)
 	s := &Server{
@@ -162,15 +190,12 @@ func NewServer(ctx context.Context, logger slog.Logger, prometheusRegistry *prom
 
 		metrics: metrics,
 	}
+	unixForwardHandler := newForwardedUnixHandler(logger, s.checkUnixSocket)
 
 	srv := &ssh.Server{
 		ChannelHandlers: map[string]ssh.ChannelHandler{
-			"direct-tcpip": func(srv *ssh.Server, conn *gossh.ServerConn, newChan gossh.NewChannel, ctx ssh.Context) {
-				// Wrapper is designed to find and track JetBrains Gateway connections.
-				wrapped := NewJetbrainsChannelWatcher(ctx, s.logger, newChan, &s.connCountJetBrains)
-				ssh.DirectTCPIPHandler(srv, conn, wrapped, ctx)
-			},
-			"direct-streamlocal@openssh.com": directStreamLocalHandler,
+			"direct-tcpip":                   s.directTCPIPHandler,
+			"direct-streamlocal@openssh.com": s.directStreamLocalHandler,
 			"session":                        ssh.DefaultSessionHandler,
 		},
 		ConnectionFailedCallback: func(conn net.Conn, err error) {
@@ -189,7 +214,8 @@ func NewServer(ctx context.Context, logger slog.Logger, prometheusRegistry *prom
 		Handler:     s.sessionHandler,
 		HostSigners: []ssh.Signer{randomSigner},
 		LocalPortForwardingCallback: func(ctx ssh.Context, destinationHost string, destinationPort uint32) bool {
-			// Allow local port forwarding all!
+			// The policy is checked in directTCPIPHandler, which also
+			// pins the resolved destination address.
 			s.logger.Debug(ctx, "local port forward",
 				slog.F("destination_host", destinationHost),
 				slog.F("destination_port", destinationPort))
@@ -199,10 +225,14 @@ func NewServer(ctx context.Context, logger slog.Logger, prometheusRegistry *prom
 			return true
 		},
 		ReversePortForwardingCallback: func(ctx ssh.Context, bindHost string, bindPort uint32) bool {
-			// Allow reverse port forwarding all!
 			s.logger.Debug(ctx, "reverse port forward",
 				slog.F("bind_host", bindHost),
 				slog.F("bind_port", bindPort))
+			err := s.config.ForwardPolicy().CheckRemoteForward(ctx, bindHost, bindPort)
+			if err != nil {
+				s.denyForward(ctx, ForwardKindRemoteForward, net.JoinHostPort(bindHost, strconv.FormatUint(uint64(bindPort), 10)), err)
+				return false
+			}
 			return true
 		},
 		RequestHandlers: map[string]ssh.RequestHandler{
@@ -254,12 +284,14 @@ func (s *Server) ConnStats() ConnStats {
 
 func (s *Server) sessionHandler(session ssh.Session) {
 	ctx := session.Context()
+	// Assigning a random uuid for each session is useful for tracking
+	// logs for the same ssh session. It also identifies the recording of
+	// the session, if any.
+	id := uuid.New()
 	logger := s.logger.With(
 		slog.F("remote_addr", session.RemoteAddr()),
 		slog.F("local_addr", session.LocalAddr()),
-		// Assigning a random uuid for each session is useful for tracking
-		// logs for the same ssh session.
-		slog.F("id", uuid.NewString()),
+		slog.F("id", id.String()),
 	)
 	logger.Info(ctx, "handling ssh session")
 
@@ -306,7 +338,7 @@ func (s *Server) sessionHandler(session ssh.Session) {
 		return
 	}
 
-	err := s.sessionStart(logger, session, extraEnv)
+	err := s.sessionStart(logger, session, id, extraEnv)
 	var exitError *exec.ExitError
 	if xerrors.As(err, &exitError) {
 		code := exitError.ExitCode()
@@ -376,7 +408,7 @@ func (s *Server) fileTransferBlocked(session ssh.Session) bool {
 	return false
 }
 
-func (s *Server) sessionStart(logger slog.Logger, session ssh.Session, extraEnv []string) (retErr error) {
+func (s *Server) sessionStart(logger slog.Logger, session ssh.Session, id uuid.UUID, extraEnv []string) (retErr error) {
 	ctx := session.Context()
 	env := append(session.Environ(), extraEnv...)
 	var magicType string
@@ -403,10 +435,35 @@ func (s *Server) sessionStart(logger slog.Logger, session ssh.Session, extraEnv
 		logger.Warn(ctx, "invalid magic ssh session type specified", slog.F("type", magicType))
 	}
 
+	var container, containerUser string
+	env = slices.DeleteFunc(env, func(kv string) bool {
+		key, value, _ := strings.Cut(kv, "=")
+		switch key {
+		case ContainerEnvironmentVariable:
+			container = value
+		case ContainerUserEnvironmentVariable:
+			containerUser = value
+		default:
+			return false
+		}
+		return true
+	})
+
 	magicTypeLabel := magicTypeMetricLabel(magicType)
 	sshPty, windowSize, isPty := session.Pty()
 
-	cmd, err := s.CreateCommand(ctx, session.RawCommand(), env)
+	var cmd *pty.Cmd
+	var err error
+	if container != "" {
+		opts := agentcontainers.ExecOptions{User: containerUser, TTY: isPty, Env: env}
+		if isPty {
+			opts.Env = append(slices.Clone(env), fmt.Sprintf("TERM=%s", sshPty.Term))
+		}
+		logger.Info(ctx, "starting session in container", slog.F("container", container), slog.F("container_user", containerUser))
+		cmd, err = s.CreateContainerCommand(ctx, container, opts, session.RawCommand(), env)
+	} else {
+		cmd, err = s.CreateCommand(ctx, session.RawCommand(), env)
+	}
 	if err != nil {
 		ptyLabel := "no"
 		if isPty {
@@ -433,7 +490,24 @@ func (s *Server) sessionStart(logger slog.Logger, session ssh.Session, extraEnv
 	}
 
 	if isPty {
-		return s.startPTYSession(logger, session, magicTypeLabel, cmd, sshPty, windowSize)
+		var ptySess ptySession = session
+		if s.config.RecordSession != nil {
+			startedAt := time.Now()
+			if w := s.config.RecordSession(id, magicTypeLabel, session.RawCommand(), startedAt); w != nil {
+				rec, err := newAsciicastRecorder(w, startedAt, sshPty)
+				if err != nil {
+					// Recording is best effort, don't fail the session.
+					logger.Warn(ctx, "failed to start session recording", slog.Error(err))
+					_ = w.Close()
+				} else {
+					logger.Info(ctx, "recording ssh session")
+					defer rec.Close()
+					ptySess = &recordedPTYSession{ptySession: session, rec: rec}
+					windowSize = recordWindowSize(ctx.Done(), rec, windowSize)
+				}
+			}
+		}
+		return s.startPTYSession(logger, ptySess, magicTypeLabel, cmd, sshPty, windowSize)
 	}
 	return s.startNonPTYSession(logger, session, magicTypeLabel, cmd.AsExec())
 }
@@ -759,6 +833,18 @@ func (s *Server) CreateCommand(ctx context.Context, script string, env []string)
 	return cmd, nil
 }
 
+// CreateContainerCommand returns a command that runs script in the container
+// with the name or ID, or starts a login shell if the script is empty. The
+// container runtime is run by the users shell like CreateCommand does, so
+// env applies to the runtime rather than the container.
+func (s *Server) CreateContainerCommand(ctx context.Context, container string, opts agentcontainers.ExecOptions, script string, env []string) (*pty.Cmd, error) {
+	if s.config.ContainerClient == nil {
+		return nil, xerrors.New("no container runtime was found in the workspace, docker or podman must be installed")
+	}
+	args := s.config.ContainerClient.ExecCommand(container, opts, agentcontainers.ShellCommand(script)...)
+	return s.CreateCommand(ctx, shellquote.Join(args...), env)
+}
+
 func (s *Server) Serve(l net.Listener) (retErr error) {
 	s.logger.Info(context.Background(), "started serving listener", slog.F("listen_addr", l.Addr()))
 	defer func() {
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net"
 	"runtime"
+	"strconv"
 	"strings"
 	"sync"
 	"testing"
@@ -23,6 +24,7 @@ import (
 	"cdr.dev/slog/sloggers/slogtest"
 
 	"github.com/coder/coder/v2/agent/agentssh"
+	"github.com/coder/coder/v2/codersdk"
 	"github.com/coder/coder/v2/pty/ptytest"
 	"github.com/coder/coder/v2/testutil"
 )
@@ -296,6 +298,88 @@ func TestNewServer_Signal(t *testing.T) {
 	})
 }
 
+func TestNewServer_ForwardPolicy(t *testing.T) {
+	t.Parallel()
+
+	allowed, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer allowed.Close()
+	go func() {
+		for {
+			conn, err := allowed.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.Close()
+		}
+	}()
+	denied, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer denied.Close()
+
+	allowedPort := allowed.Addr().(*net.TCPAddr).Port
+	policy, err := agentssh.NewForwardPolicy(codersdk.PortForwardingPolicy{
+		DirectTCPIP: &codersdk.PortForwardingRule{
+			AllowedCIDRs: []string{"127.0.0.0/8"},
+			AllowedPorts: []string{strconv.Itoa(allowedPort)},
+		},
+		RemoteForward: &codersdk.PortForwardingRule{},
+	})
+	require.NoError(t, err)
+
+	var (
+		mu           sync.Mutex
+		deniedKinds  []string
+		destinations []string
+	)
+	ctx := context.Background()
+	logger := slogtest.Make(t, nil)
+	s, err := agentssh.NewServer(ctx, logger, prometheus.NewRegistry(), afero.NewMemMapFs(), &agentssh.Config{
+		ForwardPolicy: func() *agentssh.ForwardPolicy { return policy },
+		ReportForwardDenied: func(kind, destination string, _ error) {
+			mu.Lock()
+			defer mu.Unlock()
+			deniedKinds = append(deniedKinds, kind)
+			destinations = append(destinations, destination)
+		},
+	})
+	require.NoError(t, err)
+	defer s.Close()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		err := s.Serve(ln)
+		assert.Error(t, err) // Server is closed.
+	}()
+
+	c := sshClient(t, ln.Addr().String())
+
+	conn, err := c.Dial("tcp", allowed.Addr().String())
+	require.NoError(t, err)
+	_ = conn.Close()
+
+	_, err = c.Dial("tcp", denied.Addr().String())
+	var openErr *ssh.OpenChannelError
+	require.ErrorAs(t, err, &openErr)
+	require.Equal(t, ssh.Prohibited, openErr.Reason)
+
+	_, err = c.Listen("tcp", "127.0.0.1:0")
+	require.Error(t, err)
+
+	mu.Lock()
+	require.Equal(t, []string{agentssh.ForwardKindDirectTCPIP, agentssh.ForwardKindRemoteForward}, deniedKinds)
+	require.Equal(t, []string{denied.Addr().String(), "127.0.0.1:0"}, destinations)
+	mu.Unlock()
+
+	err = s.Close()
+	require.NoError(t, err)
+	<-done
+}
+
 func sshClient(t *testing.T, addr string) *ssh.Client {
 	conn, err := net.Dial("tcp", addr)
 	require.NoError(t, err)
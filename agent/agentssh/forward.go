@@ -37,6 +37,8 @@ type forwardedUnixHandler struct {
 	sync.Mutex
 	log      slog.Logger
 	forwards map[forwardKey]net.Listener
+	// allow reports whether a socket path may be forwarded.
+	allow func(ctx context.Context, path string) bool
 }
 
 type forwardKey struct {
@@ -44,10 +46,11 @@ type forwardKey struct {
 	addr      string
 }
 
-func newForwardedUnixHandler(log slog.Logger) *forwardedUnixHandler {
+func newForwardedUnixHandler(log slog.Logger, allow func(ctx context.Context, path string) bool) *forwardedUnixHandler {
 	return &forwardedUnixHandler{
 		log:      log,
 		forwards: make(map[forwardKey]net.Listener),
+		allow:    allow,
 	}
 }
 
@@ -73,6 +76,10 @@ func (h *forwardedUnixHandler) HandleSSHRequest(ctx ssh.Context, _ *ssh.Server,
 		log = log.With(slog.F("socket_path", addr))
 		log.Debug(ctx, "request begin SSH unix forward")
 
+		if !h.allow(ctx, addr) {
+			return false, nil
+		}
+
 		key := forwardKey{
 			sessionID: ctx.SessionID(),
 			addr:      addr,
@@ -214,13 +221,17 @@ type directStreamLocalPayload struct {
 	Reserved2 uint32
 }
 
-func directStreamLocalHandler(_ *ssh.Server, _ *gossh.ServerConn, newChan gossh.NewChannel, ctx ssh.Context) {
+func (s *Server) directStreamLocalHandler(_ *ssh.Server, _ *gossh.ServerConn, newChan gossh.NewChannel, ctx ssh.Context) {
 	var reqPayload directStreamLocalPayload
 	err := gossh.Unmarshal(newChan.ExtraData(), &reqPayload)
 	if err != nil {
 		_ = newChan.Reject(gossh.ConnectionFailed, "could not parse direct-streamlocal@openssh.com channel payload")
 		return
 	}
+	if !s.checkUnixSocket(ctx, reqPayload.SocketPath) {
+		_ = newChan.Reject(gossh.Prohibited, "unix socket forward denied by policy")
+		return
+	}
 
 	var dialer net.Dialer
 	dconn, err := dialer.DialContext(ctx, "unix", reqPayload.SocketPath)
@@ -0,0 +1,262 @@
+package agentssh
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"path/filepath"
+	"strconv"
+
+	"github.com/gliderlabs/ssh"
+	gossh "golang.org/x/crypto/ssh"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
+
+	"github.com/coder/coder/v2/codersdk"
+)
+
+// The kinds of port forwards a ForwardPolicy distinguishes, as reported to
+// Config.ReportForwardDenied.
+const (
+	ForwardKindDirectTCPIP   = "direct-tcpip"
+	ForwardKindRemoteForward = "tcpip-forward"
+	ForwardKindUnixSocket    = "streamlocal"
+)
+
+// ForwardPolicy is a parsed codersdk.PortForwardingPolicy that port forwards
+// are checked against. A nil *ForwardPolicy allows all port forwards.
+type ForwardPolicy struct {
+	directTCPIP   *forwardRule
+	remoteForward *forwardRule
+	// unixSocket is nil if Unix socket forwards aren't restricted.
+	unixSocket *[]string
+
+	lookupNetIP func(ctx context.Context, network, host string) ([]netip.Addr, error)
+}
+
+type forwardRule struct {
+	prefixes []netip.Prefix
+	ports    []codersdk.PortRange
+}
+
+// NewForwardPolicy parses a port forwarding policy.
+func NewForwardPolicy(policy codersdk.PortForwardingPolicy) (*ForwardPolicy, error) {
+	p := &ForwardPolicy{
+		lookupNetIP: net.DefaultResolver.LookupNetIP,
+	}
+	var err error
+	p.directTCPIP, err = newForwardRule(policy.DirectTCPIP)
+	if err != nil {
+		return nil, xerrors.Errorf("direct_tcpip: %w", err)
+	}
+	p.remoteForward, err = newForwardRule(policy.RemoteForward)
+	if err != nil {
+		return nil, xerrors.Errorf("remote_forward: %w", err)
+	}
+	if policy.UnixSocket != nil {
+		paths := policy.UnixSocket.AllowedPaths
+		for _, pattern := range paths {
+			if _, err := filepath.Match(pattern, ""); err != nil {
+				return nil, xerrors.Errorf("unix_socket: invalid path pattern %q: %w", pattern, err)
+			}
+		}
+		p.unixSocket = &paths
+	}
+	return p, nil
+}
+
+func newForwardRule(rule *codersdk.PortForwardingRule) (*forwardRule, error) {
+	if rule == nil {
+		return nil, nil
+	}
+	prefixes, err := rule.Prefixes()
+	if err != nil {
+		return nil, err
+	}
+	ports, err := rule.PortRanges()
+	if err != nil {
+		return nil, err
+	}
+	return &forwardRule{prefixes: prefixes, ports: ports}, nil
+}
+
+func (r *forwardRule) allowsPort(port uint32) bool {
+	for _, ports := range r.ports {
+		if port <= 0xffff && ports.Contains(uint16(port)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *forwardRule) allowsAddr(addr netip.Addr) bool {
+	for _, prefix := range r.prefixes {
+		if prefix.Contains(addr.Unmap()) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolve returns the addresses of host, which is either an IP address or a
+// hostname.
+func (p *ForwardPolicy) resolve(ctx context.Context, host string) ([]netip.Addr, error) {
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return []netip.Addr{addr}, nil
+	}
+	addrs, err := p.lookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return nil, xerrors.Errorf("resolve %q: %w", host, err)
+	}
+	if len(addrs) == 0 {
+		return nil, xerrors.Errorf("resolve %q: no addresses found", host)
+	}
+	return addrs, nil
+}
+
+// CheckDirectTCPIP checks a local port forward to host and port. It returns
+// the host to dial, which is the allowed IP address host resolved to if local
+// port forwards are restricted. Dialing the resolved address prevents the
+// hostname from resolving to a different address between the check and the
+// dial.
+func (p *ForwardPolicy) CheckDirectTCPIP(ctx context.Context, host string, port uint32) (string, error) {
+	if p == nil || p.directTCPIP == nil {
+		return host, nil
+	}
+	if !p.directTCPIP.allowsPort(port) {
+		return "", xerrors.Errorf("port %d is not allowed", port)
+	}
+	addrs, err := p.resolve(ctx, host)
+	if err != nil {
+		return "", err
+	}
+	for _, addr := range addrs {
+		if p.directTCPIP.allowsAddr(addr) {
+			return addr.Unmap().String(), nil
+		}
+	}
+	return "", xerrors.Errorf("address %s is not allowed", addrs[0])
+}
+
+// CheckLocalPort checks a connection to a port on localhost made to the
+// workspace over the Coder network. These are subject to the same rule as
+// local port forwards.
+func (p *ForwardPolicy) CheckLocalPort(port uint16) error {
+	if p == nil || p.directTCPIP == nil {
+		return nil
+	}
+	if !p.directTCPIP.allowsPort(uint32(port)) {
+		return xerrors.Errorf("port %d is not allowed", port)
+	}
+	addr := netip.AddrFrom4([4]byte{127, 0, 0, 1})
+	if !p.directTCPIP.allowsAddr(addr) {
+		return xerrors.Errorf("address %s is not allowed", addr)
+	}
+	return nil
+}
+
+// CheckRemoteForward checks a remote port forward that listens on host and
+// port. Every address host resolves to must be allowed, and an empty host
+// means listening on all addresses.
+func (p *ForwardPolicy) CheckRemoteForward(ctx context.Context, host string, port uint32) error {
+	if p == nil || p.remoteForward == nil {
+		return nil
+	}
+	if !p.remoteForward.allowsPort(port) {
+		return xerrors.Errorf("port %d is not allowed", port)
+	}
+	var addrs []netip.Addr
+	switch host {
+	case "", "*":
+		addrs = []netip.Addr{netip.IPv4Unspecified(), netip.IPv6Unspecified()}
+	default:
+		var err error
+		addrs, err = p.resolve(ctx, host)
+		if err != nil {
+			return err
+		}
+	}
+	for _, addr := range addrs {
+		if !p.remoteForward.allowsAddr(addr) {
+			return xerrors.Errorf("address %s is not allowed", addr)
+		}
+	}
+	return nil
+}
+
+// CheckUnixSocket checks a forward of the Unix socket at path in either
+// direction. Relative paths are resolved against the working directory of
+// the agent, like the listener or dialer does.
+func (p *ForwardPolicy) CheckUnixSocket(path string) error {
+	if p == nil || p.unixSocket == nil {
+		return nil
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return xerrors.Errorf("resolve path: %w", err)
+	}
+	for _, pattern := range *p.unixSocket {
+		// The patterns are validated when the policy is parsed.
+		if ok, _ := filepath.Match(pattern, abs); ok {
+			return nil
+		}
+	}
+	return xerrors.Errorf("path %q is not allowed", abs)
+}
+
+// denyForward logs a port forward that was denied by the policy and reports
+// it to the agent.
+func (s *Server) denyForward(ctx context.Context, kind, destination string, err error) {
+	s.logger.Warn(ctx, "port forward denied by policy",
+		slog.F("kind", kind),
+		slog.F("destination", destination),
+		slog.Error(err))
+	s.config.ReportForwardDenied(kind, destination, err)
+}
+
+// checkUnixSocket checks a Unix socket forward against the policy, and
+// reports it if it's denied.
+func (s *Server) checkUnixSocket(ctx context.Context, path string) bool {
+	err := s.config.ForwardPolicy().CheckUnixSocket(path)
+	if err != nil {
+		s.denyForward(ctx, ForwardKindUnixSocket, path, err)
+		return false
+	}
+	return true
+}
+
+// directTCPIPHandler checks local port forwards against the policy before
+// handing them to the default handler.
+func (s *Server) directTCPIPHandler(srv *ssh.Server, conn *gossh.ServerConn, newChan gossh.NewChannel, ctx ssh.Context) {
+	var d localForwardChannelData
+	if err := gossh.Unmarshal(newChan.ExtraData(), &d); err != nil {
+		_ = newChan.Reject(gossh.ConnectionFailed, "error parsing forward data: "+err.Error())
+		return
+	}
+	host, err := s.config.ForwardPolicy().CheckDirectTCPIP(ctx, d.DestAddr, d.DestPort)
+	if err != nil {
+		s.denyForward(ctx, ForwardKindDirectTCPIP, net.JoinHostPort(d.DestAddr, strconv.FormatUint(uint64(d.DestPort), 10)), err)
+		_ = newChan.Reject(gossh.Prohibited, fmt.Sprintf("port forward denied by policy: %s", err))
+		return
+	}
+	if host != d.DestAddr {
+		d.DestAddr = host
+		newChan = &rewrittenNewChannel{NewChannel: newChan, extraData: gossh.Marshal(&d)}
+	}
+
+	// Wrapper is designed to find and track JetBrains Gateway connections.
+	wrapped := NewJetbrainsChannelWatcher(ctx, s.logger, newChan, &s.connCountJetBrains)
+	ssh.DirectTCPIPHandler(srv, conn, wrapped, ctx)
+}
+
+// rewrittenNewChannel replaces the extra data of a channel request.
+type rewrittenNewChannel struct {
+	gossh.NewChannel
+	extraData []byte
+}
+
+func (c *rewrittenNewChannel) ExtraData() []byte {
+	return c.extraData
+}
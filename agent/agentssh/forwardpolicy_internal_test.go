@@ -0,0 +1,141 @@
+package agentssh
+
+import (
+	"context"
+	"net/netip"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/codersdk"
+)
+
+func TestForwardPolicy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Nil", func(t *testing.T) {
+		t.Parallel()
+
+		var p *ForwardPolicy
+		host, err := p.CheckDirectTCPIP(context.Background(), "example.com", 22)
+		require.NoError(t, err)
+		require.Equal(t, "example.com", host)
+		require.NoError(t, p.CheckRemoteForward(context.Background(), "", 8080))
+		require.NoError(t, p.CheckUnixSocket("/tmp/socket"))
+		require.NoError(t, p.CheckLocalPort(8080))
+	})
+
+	t.Run("UnrestrictedKinds", func(t *testing.T) {
+		t.Parallel()
+
+		p, err := NewForwardPolicy(codersdk.PortForwardingPolicy{
+			UnixSocket: &codersdk.UnixSocketForwardingRule{},
+		})
+		require.NoError(t, err)
+		host, err := p.CheckDirectTCPIP(context.Background(), "10.0.0.1", 22)
+		require.NoError(t, err)
+		require.Equal(t, "10.0.0.1", host)
+		require.NoError(t, p.CheckRemoteForward(context.Background(), "", 8080))
+		require.Error(t, p.CheckUnixSocket("/tmp/socket"))
+	})
+
+	t.Run("DirectTCPIP", func(t *testing.T) {
+		t.Parallel()
+
+		p, err := NewForwardPolicy(codersdk.PortForwardingPolicy{
+			DirectTCPIP: &codersdk.PortForwardingRule{
+				AllowedCIDRs: []string{"127.0.0.0/8", "10.1.0.0/16"},
+				AllowedPorts: []string{"22", "8000-8999"},
+			},
+		})
+		require.NoError(t, err)
+		p.lookupNetIP = func(_ context.Context, _, host string) ([]netip.Addr, error) {
+			switch host {
+			case "internal.example.com":
+				return []netip.Addr{netip.MustParseAddr("192.168.1.1"), netip.MustParseAddr("10.1.2.3")}, nil
+			default:
+				return []netip.Addr{netip.MustParseAddr("93.184.216.34")}, nil
+			}
+		}
+		ctx := context.Background()
+
+		host, err := p.CheckDirectTCPIP(ctx, "127.0.0.1", 8080)
+		require.NoError(t, err)
+		require.Equal(t, "127.0.0.1", host)
+		// 4in6 addresses are checked as IPv4 addresses.
+		host, err = p.CheckDirectTCPIP(ctx, "::ffff:127.0.0.1", 22)
+		require.NoError(t, err)
+		require.Equal(t, "127.0.0.1", host)
+		// Hostnames are pinned to the allowed address they resolved to.
+		host, err = p.CheckDirectTCPIP(ctx, "internal.example.com", 22)
+		require.NoError(t, err)
+		require.Equal(t, "10.1.2.3", host)
+
+		_, err = p.CheckDirectTCPIP(ctx, "127.0.0.1", 9000)
+		require.ErrorContains(t, err, "port 9000 is not allowed")
+		_, err = p.CheckDirectTCPIP(ctx, "example.com", 22)
+		require.ErrorContains(t, err, "address 93.184.216.34 is not allowed")
+
+		require.NoError(t, p.CheckLocalPort(8443))
+		require.Error(t, p.CheckLocalPort(3000))
+	})
+
+	t.Run("RemoteForward", func(t *testing.T) {
+		t.Parallel()
+
+		p, err := NewForwardPolicy(codersdk.PortForwardingPolicy{
+			RemoteForward: &codersdk.PortForwardingRule{
+				AllowedCIDRs: []string{"127.0.0.1", "::1"},
+				AllowedPorts: []string{"1024-65535"},
+			},
+		})
+		require.NoError(t, err)
+		p.lookupNetIP = func(context.Context, string, string) ([]netip.Addr, error) {
+			return []netip.Addr{netip.MustParseAddr("127.0.0.1"), netip.MustParseAddr("::1")}, nil
+		}
+		ctx := context.Background()
+
+		require.NoError(t, p.CheckRemoteForward(ctx, "127.0.0.1", 8080))
+		require.NoError(t, p.CheckRemoteForward(ctx, "localhost", 8080))
+		require.Error(t, p.CheckRemoteForward(ctx, "127.0.0.1", 80))
+		// Listening on all addresses isn't covered by the loopback CIDRs.
+		require.Error(t, p.CheckRemoteForward(ctx, "", 8080))
+		require.Error(t, p.CheckRemoteForward(ctx, "*", 8080))
+		require.Error(t, p.CheckRemoteForward(ctx, "0.0.0.0", 8080))
+	})
+
+	t.Run("UnixSocket", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		p, err := NewForwardPolicy(codersdk.PortForwardingPolicy{
+			UnixSocket: &codersdk.UnixSocketForwardingRule{
+				AllowedPaths: []string{filepath.Join(dir, "*.sock")},
+			},
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, p.CheckUnixSocket(filepath.Join(dir, "agent.sock")))
+		require.NoError(t, p.CheckUnixSocket(filepath.Join(dir, "sub", "..", "agent.sock")))
+		require.Error(t, p.CheckUnixSocket(filepath.Join(dir, "sub", "agent.sock")))
+		require.Error(t, p.CheckUnixSocket("/run/docker.sock"))
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewForwardPolicy(codersdk.PortForwardingPolicy{
+			DirectTCPIP: &codersdk.PortForwardingRule{AllowedCIDRs: []string{"not-a-cidr"}},
+		})
+		require.ErrorContains(t, err, "direct_tcpip")
+		_, err = NewForwardPolicy(codersdk.PortForwardingPolicy{
+			RemoteForward: &codersdk.PortForwardingRule{AllowedPorts: []string{"9000-8000"}},
+		})
+		require.ErrorContains(t, err, "remote_forward")
+		_, err = NewForwardPolicy(codersdk.PortForwardingPolicy{
+			UnixSocket: &codersdk.UnixSocketForwardingRule{AllowedPaths: []string{"/tmp/["}},
+		})
+		require.ErrorContains(t, err, "unix_socket")
+	})
+}
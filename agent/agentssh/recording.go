@@ -0,0 +1,181 @@
+package agentssh
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gliderlabs/ssh"
+	"golang.org/x/xerrors"
+)
+
+// asciicastHeader is the first line of an asciicast v2 recording, see
+// https://docs.asciinema.org/manual/asciicast/v2/.
+type asciicastHeader struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// asciicastRecorder writes the output, input and resizes of a terminal
+// session as asciicast v2 events. Every event is written with a single call
+// to Write.
+type asciicastRecorder struct {
+	mu    sync.Mutex
+	w     io.WriteCloser
+	start time.Time
+	now   func() time.Time
+	// incomplete holds the trailing bytes of a UTF-8 sequence that was split
+	// across reads, keyed by event code.
+	incomplete map[string][]byte
+	closed     bool
+}
+
+func newAsciicastRecorder(w io.WriteCloser, start time.Time, pty ssh.Pty) (*asciicastRecorder, error) {
+	r := &asciicastRecorder{
+		w:          w,
+		start:      start,
+		now:        time.Now,
+		incomplete: make(map[string][]byte),
+	}
+	header := asciicastHeader{
+		Version:   2,
+		Width:     pty.Window.Width,
+		Height:    pty.Window.Height,
+		Timestamp: start.Unix(),
+	}
+	if pty.Term != "" {
+		header.Env = map[string]string{"TERM": pty.Term}
+	}
+	line, err := json.Marshal(header)
+	if err != nil {
+		return nil, xerrors.Errorf("marshal header: %w", err)
+	}
+	_, err = w.Write(append(line, '\n'))
+	if err != nil {
+		return nil, xerrors.Errorf("write header: %w", err)
+	}
+	return r, nil
+}
+
+// output records data written to the terminal.
+func (r *asciicastRecorder) output(p []byte) {
+	r.event("o", p)
+}
+
+// input records data typed by the user.
+func (r *asciicastRecorder) input(p []byte) {
+	r.event("i", p)
+}
+
+// resize records a change of the terminal size.
+func (r *asciicastRecorder) resize(width, height int) {
+	r.event("r", []byte(fmt.Sprintf("%dx%d", width, height)))
+}
+
+func (r *asciicastRecorder) event(code string, p []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return
+	}
+
+	data := append(r.incomplete[code], p...)
+	n := completeUTF8Len(data)
+	r.incomplete[code] = append([]byte(nil), data[n:]...)
+	if n == 0 {
+		return
+	}
+
+	elapsed := r.now().Sub(r.start)
+	line, err := json.Marshal([]any{
+		float64(elapsed.Microseconds()) / 1e6,
+		code,
+		string(data[:n]),
+	})
+	if err != nil {
+		return
+	}
+	// Recording is best effort, the session must not fail because of it.
+	_, _ = r.w.Write(append(line, '\n'))
+}
+
+// Close ends the recording.
+func (r *asciicastRecorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+	return r.w.Close()
+}
+
+// completeUTF8Len returns the length of the prefix of p that doesn't end in
+// an incomplete UTF-8 sequence.
+func completeUTF8Len(p []byte) int {
+	for i := len(p) - 1; i >= 0 && i >= len(p)-utf8.UTFMax; i-- {
+		if !utf8.RuneStart(p[i]) {
+			continue
+		}
+		if !utf8.FullRune(p[i:]) {
+			return i
+		}
+		break
+	}
+	return len(p)
+}
+
+// recordedPTYSession records everything read from and written to the
+// wrapped session.
+type recordedPTYSession struct {
+	ptySession
+	rec *asciicastRecorder
+}
+
+func (s *recordedPTYSession) Read(p []byte) (int, error) {
+	n, err := s.ptySession.Read(p)
+	if n > 0 {
+		s.rec.input(p[:n])
+	}
+	return n, err
+}
+
+func (s *recordedPTYSession) Write(p []byte) (int, error) {
+	n, err := s.ptySession.Write(p)
+	if n > 0 {
+		s.rec.output(p[:n])
+	}
+	return n, err
+}
+
+// recordWindowSize records the window changes received on windowSize and
+// forwards them on the returned channel until done is closed.
+func recordWindowSize(done <-chan struct{}, rec *asciicastRecorder, windowSize <-chan ssh.Window) <-chan ssh.Window {
+	out := make(chan ssh.Window)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-done:
+				return
+			case win, ok := <-windowSize:
+				if !ok {
+					return
+				}
+				rec.resize(win.Width, win.Height)
+				select {
+				case <-done:
+					return
+				case out <- win:
+				}
+			}
+		}
+	}()
+	return out
+}
@@ -0,0 +1,75 @@
+package agentssh
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/stretchr/testify/require"
+)
+
+type bufferCloser struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (b *bufferCloser) Close() error {
+	b.closed = true
+	return nil
+}
+
+func TestAsciicastRecorder(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := start
+	buf := &bufferCloser{}
+	rec, err := newAsciicastRecorder(buf, start, gliderssh.Pty{
+		Term:   "xterm-256color",
+		Window: gliderssh.Window{Width: 80, Height: 24},
+	})
+	require.NoError(t, err)
+	rec.now = func() time.Time { return now }
+
+	now = start.Add(500 * time.Millisecond)
+	rec.input([]byte("ls\r"))
+	// "é" split across two writes is recorded once it is complete.
+	now = start.Add(time.Second)
+	rec.output([]byte("caf\xc3"))
+	now = start.Add(1500 * time.Millisecond)
+	rec.output([]byte("\xa9\r\n"))
+	now = start.Add(2 * time.Second)
+	rec.resize(100, 30)
+	require.NoError(t, rec.Close())
+	require.True(t, buf.closed)
+	// events after close are dropped
+	rec.output([]byte("ignored"))
+
+	s := bufio.NewScanner(&buf.Buffer)
+	require.True(t, s.Scan())
+	var header asciicastHeader
+	require.NoError(t, json.Unmarshal(s.Bytes(), &header))
+	require.Equal(t, asciicastHeader{
+		Version:   2,
+		Width:     80,
+		Height:    24,
+		Timestamp: start.Unix(),
+		Env:       map[string]string{"TERM": "xterm-256color"},
+	}, header)
+
+	var events [][]any
+	for s.Scan() {
+		var event []any
+		require.NoError(t, json.Unmarshal(s.Bytes(), &event))
+		events = append(events, event)
+	}
+	require.Equal(t, [][]any{
+		{0.5, "i", "ls\r"},
+		{1.0, "o", "caf"},
+		{1.5, "o", "é\r\n"},
+		{2.0, "r", "100x30"},
+	}, events)
+}
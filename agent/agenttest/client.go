@@ -138,6 +138,14 @@ func (c *Client) GetStartupLogs() []agentsdk.Log {
 	return c.logs
 }
 
+func (c *Client) GetSessionRecordings() []SessionRecording {
+	return c.fakeAgentAPI.GetSessionRecordings()
+}
+
+func (c *Client) GetContainers() *agentproto.UpdateContainersRequest {
+	return c.fakeAgentAPI.GetContainers()
+}
+
 func (c *Client) SetAnnouncementBannersFunc(f func() ([]codersdk.BannerConfig, error)) {
 	c.fakeAgentAPI.SetAnnouncementBannersFunc(f)
 }
@@ -171,6 +179,8 @@ type FakeAgentAPI struct {
 	lifecycleStates []codersdk.WorkspaceAgentLifecycle
 	metadata        map[string]agentsdk.Metadata
 	timings         []*agentproto.Timing
+	recordings      map[uuid.UUID]*SessionRecording
+	containers      *agentproto.UpdateContainersRequest
 
 	getAnnouncementBannersFunc func() ([]codersdk.BannerConfig, error)
 }
@@ -316,6 +326,86 @@ func (f *FakeAgentAPI) ScriptCompleted(_ context.Context, req *agentproto.Worksp
 	return &agentproto.WorkspaceAgentScriptCompletedResponse{}, nil
 }
 
+// SessionRecording is a session recording uploaded to the FakeAgentAPI.
+type SessionRecording struct {
+	Recording *agentproto.SessionRecording
+	Data      []byte
+	Ended     bool
+}
+
+func (f *FakeAgentAPI) GetSessionRecordings() []SessionRecording {
+	f.Lock()
+	defer f.Unlock()
+	recordings := make([]SessionRecording, 0, len(f.recordings))
+	for _, r := range f.recordings {
+		recordings = append(recordings, SessionRecording{
+			Recording: r.Recording,
+			Data:      slices.Clone(r.Data),
+			Ended:     r.Ended,
+		})
+	}
+	return recordings
+}
+
+func (f *FakeAgentAPI) StartSessionRecording(_ context.Context, req *agentproto.StartSessionRecordingRequest) (*agentproto.StartSessionRecordingResponse, error) {
+	id, err := uuid.FromBytes(req.GetRecording().GetId())
+	if err != nil {
+		return nil, err
+	}
+	f.Lock()
+	defer f.Unlock()
+	if f.recordings == nil {
+		f.recordings = make(map[uuid.UUID]*SessionRecording)
+	}
+	if _, ok := f.recordings[id]; !ok {
+		f.recordings[id] = &SessionRecording{Recording: req.Recording}
+	}
+	return &agentproto.StartSessionRecordingResponse{}, nil
+}
+
+func (f *FakeAgentAPI) UploadSessionRecordingChunk(_ context.Context, req *agentproto.UploadSessionRecordingChunkRequest) (*agentproto.UploadSessionRecordingChunkResponse, error) {
+	id, err := uuid.FromBytes(req.RecordingId)
+	if err != nil {
+		return nil, err
+	}
+	f.Lock()
+	defer f.Unlock()
+	r, ok := f.recordings[id]
+	if !ok {
+		return nil, xerrors.Errorf("unknown session recording %s", id)
+	}
+	r.Data = append(r.Data, req.Data...)
+	return &agentproto.UploadSessionRecordingChunkResponse{}, nil
+}
+
+func (f *FakeAgentAPI) EndSessionRecording(_ context.Context, req *agentproto.EndSessionRecordingRequest) (*agentproto.EndSessionRecordingResponse, error) {
+	id, err := uuid.FromBytes(req.RecordingId)
+	if err != nil {
+		return nil, err
+	}
+	f.Lock()
+	defer f.Unlock()
+	r, ok := f.recordings[id]
+	if !ok {
+		return nil, xerrors.Errorf("unknown session recording %s", id)
+	}
+	r.Ended = true
+	return &agentproto.EndSessionRecordingResponse{}, nil
+}
+
+func (f *FakeAgentAPI) GetContainers() *agentproto.UpdateContainersRequest {
+	f.Lock()
+	defer f.Unlock()
+	return f.containers
+}
+
+func (f *FakeAgentAPI) UpdateContainers(_ context.Context, req *agentproto.UpdateContainersRequest) (*agentproto.UpdateContainersResponse, error) {
+	f.Lock()
+	defer f.Unlock()
+	f.containers = req
+	return &agentproto.UpdateContainersResponse{}, nil
+}
+
 func NewFakeAgentAPI(t testing.TB, logger slog.Logger, manifest *agentproto.Manifest, statsCh chan *agentproto.Stats) *FakeAgentAPI {
 	return &FakeAgentAPI{
 		t:           t,
@@ -38,6 +38,11 @@ func (a *agent) apiHandler() http.Handler {
 	promHandler := PrometheusMetricsHandler(a.prometheusRegistry, a.logger)
 	r.Get("/api/v0/listening-ports", lp.handler)
 	r.Get("/api/v0/netcheck", a.HandleNetcheck)
+	r.Mount("/api/v0/files", a.filesHandler())
+	r.Get("/api/v0/processes", a.handleListProcesses)
+	r.Get("/api/v0/reconnecting-ptys", a.handleListReconnectingPTYs)
+	r.With(withoutDeadlines).Post("/api/v0/exec", a.handleExec)
+	r.Post("/api/v0/scripts/{id}/run", a.handleRunScript)
 	r.Get("/debug/logs", a.HandleHTTPDebugLogs)
 	r.Get("/debug/magicsock", a.HandleHTTPDebugMagicsock)
 	r.Get("/debug/magicsock/debug-logging/{state}", a.HandleHTTPMagicsockDebugLoggingState)
@@ -1,17 +1,26 @@
 package agent
 
 import (
+	"bytes"
 	"context"
+	"io"
+	"net"
 	"net/http"
+	"os"
+	"regexp"
 	"sync"
 	"time"
 
 	"github.com/google/uuid"
 	"golang.org/x/xerrors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
 	"cdr.dev/slog"
 	"github.com/coder/coder/v2/codersdk"
 	"github.com/coder/coder/v2/codersdk/agentsdk"
+	"github.com/coder/coder/v2/pty"
 	"github.com/coder/quartz"
 )
 
@@ -21,9 +30,13 @@ type PostWorkspaceAgentAppHealth func(context.Context, agentsdk.PostAppHealthsRe
 // WorkspaceAppHealthReporter is a function that checks and reports the health of the workspace apps until the passed context is canceled.
 type WorkspaceAppHealthReporter func(ctx context.Context)
 
+// CreateHealthcheckCommand creates the command run by a "command" app health
+// check.
+type CreateHealthcheckCommand func(ctx context.Context, script string, env []string) (*pty.Cmd, error)
+
 // NewWorkspaceAppHealthReporter creates a WorkspaceAppHealthReporter that reports app health to coderd.
-func NewWorkspaceAppHealthReporter(logger slog.Logger, apps []codersdk.WorkspaceApp, postWorkspaceAgentAppHealth PostWorkspaceAgentAppHealth) WorkspaceAppHealthReporter {
-	return NewAppHealthReporterWithClock(logger, apps, postWorkspaceAgentAppHealth, quartz.NewReal())
+func NewWorkspaceAppHealthReporter(logger slog.Logger, apps []codersdk.WorkspaceApp, createCommand CreateHealthcheckCommand, postWorkspaceAgentAppHealth PostWorkspaceAgentAppHealth) WorkspaceAppHealthReporter {
+	return NewAppHealthReporterWithClock(logger, apps, createCommand, postWorkspaceAgentAppHealth, quartz.NewReal())
 }
 
 // NewAppHealthReporterWithClock is only called directly by test code.  Product code should call
@@ -31,6 +44,7 @@ func NewWorkspaceAppHealthReporter(logger slog.Logger, apps []codersdk.Workspace
 func NewAppHealthReporterWithClock(
 	logger slog.Logger,
 	apps []codersdk.WorkspaceApp,
+	createCommand CreateHealthcheckCommand,
 	postWorkspaceAgentAppHealth PostWorkspaceAgentAppHealth,
 	clk quartz.Clock,
 ) WorkspaceAppHealthReporter {
@@ -68,6 +82,23 @@ func NewAppHealthReporterWithClock(
 				continue
 			}
 			app := nextApp
+			var bodyRegex *regexp.Regexp
+			if app.Healthcheck.BodyRegex != "" {
+				var err error
+				bodyRegex, err = regexp.Compile(app.Healthcheck.BodyRegex)
+				if err != nil {
+					// coderd validates the regex, so this should never happen.
+					logger.Error(ctx, "invalid app healthcheck body regex",
+						slog.F("id", app.ID.String()),
+						slog.F("slug", app.Slug),
+						slog.Error(err),
+					)
+					mu.Lock()
+					health[app.ID] = codersdk.WorkspaceAppHealthUnhealthy
+					mu.Unlock()
+					continue
+				}
+			}
 			go func() {
 				_ = clk.TickerFunc(ctx, time.Duration(app.Healthcheck.Interval)*time.Second, func() error {
 					// We time out at the healthcheck interval to prevent getting too backed up, but
@@ -86,23 +117,7 @@ func NewAppHealthReporterWithClock(
 						"timeout", app.Slug)
 					defer timeout.Stop()
 
-					err := func() error {
-						req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, app.Healthcheck.URL, nil)
-						if err != nil {
-							return err
-						}
-						res, err := http.DefaultClient.Do(req)
-						if err != nil {
-							return err
-						}
-						// successful healthcheck is a non-5XX status code
-						_ = res.Body.Close()
-						if res.StatusCode >= http.StatusInternalServerError {
-							return xerrors.Errorf("error status code: %d", res.StatusCode)
-						}
-
-						return nil
-					}()
+					err := checkAppHealth(reqCtx, app.Healthcheck, bodyRegex, createCommand)
 					if err != nil {
 						nowUnhealthy := false
 						mu.Lock()
@@ -164,7 +179,100 @@ func NewAppHealthReporterWithClock(
 }
 
 func shouldStartTicker(app codersdk.WorkspaceApp) bool {
-	return app.Healthcheck.URL != "" && app.Healthcheck.Interval > 0 && app.Healthcheck.Threshold > 0
+	if app.Healthcheck.Interval <= 0 || app.Healthcheck.Threshold <= 0 {
+		return false
+	}
+	switch app.Healthcheck.Kind {
+	case codersdk.WorkspaceAppHealthcheckKindTCP, codersdk.WorkspaceAppHealthcheckKindGRPC:
+		return app.Healthcheck.Address != ""
+	case codersdk.WorkspaceAppHealthcheckKindCommand:
+		return app.Healthcheck.Command != ""
+	case codersdk.WorkspaceAppHealthcheckKindFile:
+		return app.Healthcheck.Path != ""
+	default:
+		return app.Healthcheck.URL != ""
+	}
+}
+
+// checkAppHealth runs a single health check of an app, returning an error if
+// the app is unhealthy.
+func checkAppHealth(ctx context.Context, healthcheck codersdk.Healthcheck, bodyRegex *regexp.Regexp, createCommand CreateHealthcheckCommand) error {
+	switch healthcheck.Kind {
+	case codersdk.WorkspaceAppHealthcheckKindTCP:
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, "tcp", healthcheck.Address)
+		if err != nil {
+			return err
+		}
+		_ = conn.Close()
+		return nil
+	case codersdk.WorkspaceAppHealthcheckKindGRPC:
+		conn, err := grpc.NewClient(healthcheck.Address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			return xerrors.Errorf("create grpc client: %w", err)
+		}
+		defer conn.Close()
+		res, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{
+			Service: healthcheck.GRPCService,
+		})
+		if err != nil {
+			return err
+		}
+		if res.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+			return xerrors.Errorf("error serving status: %s", res.GetStatus())
+		}
+		return nil
+	case codersdk.WorkspaceAppHealthcheckKindCommand:
+		if createCommand == nil {
+			return xerrors.New("command health checks are not supported")
+		}
+		cmdPty, err := createCommand(ctx, healthcheck.Command, nil)
+		if err != nil {
+			return xerrors.Errorf("create command: %w", err)
+		}
+		cmd := cmdPty.AsExec()
+		// successful healthcheck is an exit code of 0
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			const outputLimit = 1 << 10
+			if len(out) > outputLimit {
+				out = out[len(out)-outputLimit:]
+			}
+			return xerrors.Errorf("run command: %w: %s", err, bytes.TrimSpace(out))
+		}
+		return nil
+	case codersdk.WorkspaceAppHealthcheckKindFile:
+		_, err := os.Stat(healthcheck.Path)
+		return err
+	default:
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, healthcheck.URL, nil)
+		if err != nil {
+			return err
+		}
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer res.Body.Close()
+		if healthcheck.ExpectedStatus != 0 {
+			if res.StatusCode != int(healthcheck.ExpectedStatus) {
+				return xerrors.Errorf("unexpected status code: %d", res.StatusCode)
+			}
+		} else if res.StatusCode >= http.StatusInternalServerError {
+			// successful healthcheck is a non-5XX status code
+			return xerrors.Errorf("error status code: %d", res.StatusCode)
+		}
+		if bodyRegex != nil {
+			body, err := io.ReadAll(io.LimitReader(res.Body, 1<<20))
+			if err != nil {
+				return xerrors.Errorf("read body: %w", err)
+			}
+			if !bodyRegex.Match(body) {
+				return xerrors.Errorf("body does not match %q", bodyRegex.String())
+			}
+		}
+		return nil
+	}
 }
 
 func healthChanged(old map[uuid.UUID]codersdk.WorkspaceAppHealth, new map[uuid.UUID]codersdk.WorkspaceAppHealth) bool {
@@ -0,0 +1,130 @@
+package agent
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/pty"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestCheckAppHealth(t *testing.T) {
+	t.Parallel()
+
+	t.Run("HTTP", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitShort)
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte(`{"status":"ready"}`))
+		}))
+		defer srv.Close()
+
+		healthcheck := codersdk.Healthcheck{URL: srv.URL}
+		require.NoError(t, checkAppHealth(ctx, healthcheck, nil, nil))
+
+		healthcheck.ExpectedStatus = http.StatusAccepted
+		require.NoError(t, checkAppHealth(ctx, healthcheck, regexp.MustCompile(`"status":"ready"`), nil))
+
+		err := checkAppHealth(ctx, healthcheck, regexp.MustCompile(`"status":"starting"`), nil)
+		require.ErrorContains(t, err, "body does not match")
+
+		healthcheck.ExpectedStatus = http.StatusOK
+		err = checkAppHealth(ctx, healthcheck, nil, nil)
+		require.ErrorContains(t, err, "unexpected status code: 202")
+	})
+
+	t.Run("TCP", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitShort)
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		addr := l.Addr().String()
+
+		healthcheck := codersdk.Healthcheck{
+			Kind:    codersdk.WorkspaceAppHealthcheckKindTCP,
+			Address: addr,
+		}
+		require.NoError(t, checkAppHealth(ctx, healthcheck, nil, nil))
+
+		require.NoError(t, l.Close())
+		require.Error(t, checkAppHealth(ctx, healthcheck, nil, nil))
+	})
+
+	t.Run("GRPC", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitShort)
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		healthServer := health.NewServer()
+		healthServer.SetServingStatus("app.v1.App", healthpb.HealthCheckResponse_NOT_SERVING)
+		srv := grpc.NewServer()
+		healthpb.RegisterHealthServer(srv, healthServer)
+		go func() {
+			_ = srv.Serve(l)
+		}()
+		defer srv.Stop()
+
+		healthcheck := codersdk.Healthcheck{
+			Kind:    codersdk.WorkspaceAppHealthcheckKindGRPC,
+			Address: l.Addr().String(),
+		}
+		require.NoError(t, checkAppHealth(ctx, healthcheck, nil, nil))
+
+		healthcheck.GRPCService = "app.v1.App"
+		err = checkAppHealth(ctx, healthcheck, nil, nil)
+		require.ErrorContains(t, err, "NOT_SERVING")
+
+		healthServer.SetServingStatus("app.v1.App", healthpb.HealthCheckResponse_SERVING)
+		require.NoError(t, checkAppHealth(ctx, healthcheck, nil, nil))
+	})
+
+	t.Run("Command", func(t *testing.T) {
+		t.Parallel()
+		if runtime.GOOS == "windows" {
+			t.Skip("uses sh")
+		}
+		ctx := testutil.Context(t, testutil.WaitShort)
+		createCommand := func(ctx context.Context, script string, _ []string) (*pty.Cmd, error) {
+			return pty.CommandContext(ctx, "sh", "-c", script), nil
+		}
+
+		healthcheck := codersdk.Healthcheck{
+			Kind:    codersdk.WorkspaceAppHealthcheckKindCommand,
+			Command: "exit 0",
+		}
+		require.NoError(t, checkAppHealth(ctx, healthcheck, nil, createCommand))
+
+		healthcheck.Command = "echo not ready; exit 3"
+		err := checkAppHealth(ctx, healthcheck, nil, createCommand)
+		require.ErrorContains(t, err, "not ready")
+	})
+
+	t.Run("File", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitShort)
+		path := filepath.Join(t.TempDir(), "ready")
+
+		healthcheck := codersdk.Healthcheck{
+			Kind: codersdk.WorkspaceAppHealthcheckKindFile,
+			Path: path,
+		}
+		require.Error(t, checkAppHealth(ctx, healthcheck, nil, nil))
+
+		require.NoError(t, os.WriteFile(path, nil, 0o600))
+		require.NoError(t, checkAppHealth(ctx, healthcheck, nil, nil))
+	})
+}
@@ -262,7 +262,7 @@ func setupAppReporter(
 
 	go agent.NewAppHealthReporterWithClock(
 		slogtest.Make(t, nil).Leveled(slog.LevelDebug),
-		apps, agentsdk.AppHealthPoster(fakeAAPI), clk,
+		apps, nil, agentsdk.AppHealthPoster(fakeAAPI), clk,
 	)(ctx)
 
 	return fakeAAPI, func() {
@@ -0,0 +1,59 @@
+package agent
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/xerrors"
+	protobuf "google.golang.org/protobuf/proto"
+	"storj.io/drpc"
+
+	"cdr.dev/slog"
+	"github.com/coder/coder/v2/agent/proto"
+	"github.com/coder/coder/v2/codersdk/agentsdk"
+)
+
+// reportContainers periodically lists the containers running in the workspace
+// and reports them to coderd whenever they change. Coderd stores the last
+// report and serves it to clients, so a failure to list is logged and the
+// previous report is kept.
+func (a *agent) reportContainers(ctx context.Context, conn drpc.Conn) error {
+	if a.containerClient == nil {
+		return nil
+	}
+	aAPI := proto.NewDRPCAgentClient(conn)
+
+	var lastReport *proto.UpdateContainersRequest
+	ticker := time.NewTicker(a.reportContainersInterval)
+	defer ticker.Stop()
+	for {
+		containers, err := a.containerClient.List(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			a.logger.Warn(ctx, "failed to list containers", slog.Error(err))
+		} else {
+			req := &proto.UpdateContainersRequest{
+				Runtime:    a.containerClient.Runtime(),
+				Containers: make([]*proto.WorkspaceAgentContainer, 0, len(containers)),
+			}
+			for _, container := range containers {
+				req.Containers = append(req.Containers, agentsdk.ProtoFromContainer(container))
+			}
+			if lastReport == nil || !protobuf.Equal(req, lastReport) {
+				_, err = aAPI.UpdateContainers(ctx, req)
+				if err != nil {
+					return xerrors.Errorf("update containers: %w", err)
+				}
+				lastReport = req
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
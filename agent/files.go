@@ -0,0 +1,441 @@
+package agent
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
+
+	"github.com/coder/coder/v2/coderd/httpapi"
+	"github.com/coder/coder/v2/codersdk"
+)
+
+// uploadSuffix is appended to the name of a file while it's being uploaded.
+// The partial upload is moved to the file once it's complete.
+const uploadSuffix = ".coder-upload"
+
+// filesHandler serves the file transfer API, which lets clients list, download
+// and upload files without SSH.
+func (a *agent) filesHandler() http.Handler {
+	r := chi.NewRouter()
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			if a.blockFileTransfer {
+				httpapi.Write(r.Context(), rw, http.StatusForbidden, codersdk.Response{
+					Message: "File transfer is blocked by the workspace agent.",
+				})
+				return
+			}
+			next.ServeHTTP(rw, r)
+		})
+	})
+	r.Get("/stat", a.handleFileStat)
+	r.Get("/list", a.handleListFiles)
+	r.Get("/upload", a.handleUploadProgress)
+	r.Group(func(r chi.Router) {
+		r.Use(withoutDeadlines)
+		r.Get("/download", a.handleDownloadFile)
+		r.Get("/archive", a.handleArchiveDirectory)
+		r.Put("/upload", a.handleUploadFile)
+	})
+	return r
+}
+
+// withoutDeadlines lifts the read and write timeouts of the HTTP API server,
+// which are too short for transferring large files.
+func withoutDeadlines(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rc := http.NewResponseController(rw)
+		_ = rc.SetReadDeadline(time.Time{})
+		_ = rc.SetWriteDeadline(time.Time{})
+		next.ServeHTTP(rw, r)
+	})
+}
+
+// resolveFilePath returns the absolute path of the path query parameter.
+// Relative paths are resolved against the working directory of the agent,
+// which is where SSH sessions start.
+func (a *agent) resolveFilePath(rw http.ResponseWriter, r *http.Request) (string, bool) {
+	path := r.URL.Query().Get("path")
+	if path == "" {
+		httpapi.Write(r.Context(), rw, http.StatusBadRequest, codersdk.Response{
+			Message: "The path query parameter is required.",
+		})
+		return "", false
+	}
+	if path[0] == '~' {
+		home, err := userHomeDir()
+		if err != nil {
+			httpapi.InternalServerError(rw, err)
+			return "", false
+		}
+		path = filepath.Join(home, path[1:])
+	}
+	if !filepath.IsAbs(path) {
+		dir := ""
+		if manifest := a.manifest.Load(); manifest != nil {
+			dir = manifest.Directory
+		}
+		if dir == "" {
+			home, err := userHomeDir()
+			if err != nil {
+				httpapi.InternalServerError(rw, err)
+				return "", false
+			}
+			dir = home
+		}
+		path = filepath.Join(dir, path)
+	}
+	return filepath.Clean(path), true
+}
+
+// writeFileError writes an error for a failed file operation, using the
+// status code that matches the error.
+func writeFileError(rw http.ResponseWriter, r *http.Request, message string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		status = http.StatusNotFound
+	case errors.Is(err, fs.ErrPermission):
+		status = http.StatusForbidden
+	}
+	httpapi.Write(r.Context(), rw, status, codersdk.Response{
+		Message: message,
+		Detail:  err.Error(),
+	})
+}
+
+func fileInfo(path string, info fs.FileInfo) codersdk.WorkspaceAgentFileInfo {
+	out := codersdk.WorkspaceAgentFileInfo{
+		Name:    info.Name(),
+		Path:    path,
+		Size:    info.Size(),
+		Mode:    info.Mode().String(),
+		ModTime: info.ModTime(),
+		IsDir:   info.IsDir(),
+	}
+	if info.Mode()&fs.ModeSymlink != 0 {
+		out.SymlinkTarget, _ = os.Readlink(path)
+	}
+	return out
+}
+
+func (a *agent) handleFileStat(rw http.ResponseWriter, r *http.Request) {
+	path, ok := a.resolveFilePath(rw, r)
+	if !ok {
+		return
+	}
+	info, err := os.Lstat(path)
+	if err != nil {
+		writeFileError(rw, r, "Failed to stat file.", err)
+		return
+	}
+	httpapi.Write(r.Context(), rw, http.StatusOK, fileInfo(path, info))
+}
+
+func (a *agent) handleListFiles(rw http.ResponseWriter, r *http.Request) {
+	path, ok := a.resolveFilePath(rw, r)
+	if !ok {
+		return
+	}
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		writeFileError(rw, r, "Failed to list directory.", err)
+		return
+	}
+	list := codersdk.WorkspaceAgentFileList{
+		Path:  path,
+		Files: make([]codersdk.WorkspaceAgentFileInfo, 0, len(entries)),
+	}
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil {
+			// The file was removed after the directory was read.
+			continue
+		}
+		list.Files = append(list.Files, fileInfo(filepath.Join(path, entry.Name()), info))
+	}
+	httpapi.Write(r.Context(), rw, http.StatusOK, list)
+}
+
+// handleDownloadFile serves the contents of a file. Range requests are
+// supported so interrupted downloads can be resumed.
+func (a *agent) handleDownloadFile(rw http.ResponseWriter, r *http.Request) {
+	path, ok := a.resolveFilePath(rw, r)
+	if !ok {
+		return
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		writeFileError(rw, r, "Failed to open file.", err)
+		return
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		writeFileError(rw, r, "Failed to stat file.", err)
+		return
+	}
+	if info.IsDir() {
+		httpapi.Write(r.Context(), rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Path is a directory, download it as an archive instead.",
+		})
+		return
+	}
+	rw.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": info.Name()}))
+	http.ServeContent(rw, r, info.Name(), info.ModTime(), f)
+}
+
+// handleArchiveDirectory streams a directory as a tar archive. The entries
+// of the archive are prefixed with the name of the directory.
+func (a *agent) handleArchiveDirectory(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	path, ok := a.resolveFilePath(rw, r)
+	if !ok {
+		return
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		writeFileError(rw, r, "Failed to stat directory.", err)
+		return
+	}
+	if !info.IsDir() {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Path is not a directory.",
+		})
+		return
+	}
+
+	name := info.Name()
+	if name == string(filepath.Separator) {
+		name = "root"
+	}
+	rw.Header().Set("Content-Type", "application/x-tar")
+	rw.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": name + ".tar"}))
+	rw.WriteHeader(http.StatusOK)
+
+	err = writeTar(rw, path, name)
+	if err != nil {
+		// The status has been sent already, so abort the response to let
+		// the client know the archive is incomplete.
+		a.logger.Warn(ctx, "failed to archive directory", slog.F("path", path), slog.Error(err))
+		panic(http.ErrAbortHandler)
+	}
+}
+
+func writeTar(w io.Writer, dir, prefix string) error {
+	tw := tar.NewWriter(w)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		var link string
+		switch {
+		case info.Mode().IsRegular(), info.IsDir():
+		case info.Mode()&fs.ModeSymlink != 0:
+			link, err = os.Readlink(path)
+			if err != nil {
+				return err
+			}
+		default:
+			// Sockets, devices and pipes can't be archived.
+			return nil
+		}
+		hdr, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(filepath.Join(prefix, rel))
+		if info.IsDir() {
+			hdr.Name += "/"
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		// Copy at most the size in the header, the file may have grown
+		// since it was stat'ed.
+		_, err = io.CopyN(tw, f, hdr.Size)
+		return err
+	})
+	if err != nil {
+		return xerrors.Errorf("walk directory: %w", err)
+	}
+	return tw.Close()
+}
+
+// fileUploads tracks the paths being uploaded to, so concurrent uploads to
+// the same path don't write to the same partial file.
+type fileUploads struct {
+	mu     sync.Mutex
+	active map[string]struct{}
+}
+
+func (u *fileUploads) acquire(path string) bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if _, ok := u.active[path]; ok {
+		return false
+	}
+	if u.active == nil {
+		u.active = make(map[string]struct{})
+	}
+	u.active[path] = struct{}{}
+	return true
+}
+
+func (u *fileUploads) release(path string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	delete(u.active, path)
+}
+
+func partialUploadPath(path string) string {
+	return filepath.Join(filepath.Dir(path), "."+filepath.Base(path)+uploadSuffix)
+}
+
+// handleUploadProgress returns how much of an upload has been received, so
+// clients can resume interrupted uploads.
+func (a *agent) handleUploadProgress(rw http.ResponseWriter, r *http.Request) {
+	path, ok := a.resolveFilePath(rw, r)
+	if !ok {
+		return
+	}
+	upload := codersdk.WorkspaceAgentFileUpload{Path: path}
+	info, err := os.Stat(partialUploadPath(path))
+	switch {
+	case err == nil:
+		upload.Offset = info.Size()
+	case !errors.Is(err, fs.ErrNotExist):
+		writeFileError(rw, r, "Failed to stat partial upload.", err)
+		return
+	}
+	httpapi.Write(r.Context(), rw, http.StatusOK, upload)
+}
+
+// handleUploadFile appends a chunk to an upload. The chunk must start where
+// the previous chunk ended, and the upload is moved to its path once the
+// chunk marked as complete is received.
+func (a *agent) handleUploadFile(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	path, ok := a.resolveFilePath(rw, r)
+	if !ok {
+		return
+	}
+	query := r.URL.Query()
+	offset, err := strconv.ParseInt(query.Get("offset"), 10, 64)
+	if err != nil || offset < 0 {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "The offset query parameter must be a non-negative integer.",
+		})
+		return
+	}
+	complete := query.Get("complete") == "true"
+	mode := fs.FileMode(0o644)
+	if m := query.Get("mode"); m != "" {
+		parsed, err := strconv.ParseUint(m, 8, 32)
+		if err != nil {
+			httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+				Message: "The mode query parameter must be an octal number.",
+				Detail:  err.Error(),
+			})
+			return
+		}
+		mode = fs.FileMode(parsed).Perm()
+	}
+
+	if !a.fileUploads.acquire(path) {
+		httpapi.Write(ctx, rw, http.StatusConflict, codersdk.Response{
+			Message: "Another upload to this path is in progress.",
+		})
+		return
+	}
+	defer a.fileUploads.release(path)
+
+	err = os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		writeFileError(rw, r, "Failed to create parent directory.", err)
+		return
+	}
+	partialPath := partialUploadPath(path)
+	flags := os.O_WRONLY | os.O_CREATE
+	if offset == 0 {
+		// Restart the upload from the beginning.
+		flags |= os.O_TRUNC
+	}
+	f, err := os.OpenFile(partialPath, flags, 0o600)
+	if err != nil {
+		writeFileError(rw, r, "Failed to open partial upload.", err)
+		return
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		writeFileError(rw, r, "Failed to stat partial upload.", err)
+		return
+	}
+	if info.Size() != offset {
+		httpapi.Write(ctx, rw, http.StatusConflict, codersdk.Response{
+			Message: fmt.Sprintf("The upload must be resumed at offset %d.", info.Size()),
+		})
+		return
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		writeFileError(rw, r, "Failed to seek partial upload.", err)
+		return
+	}
+	n, err := io.Copy(f, r.Body)
+	upload := codersdk.WorkspaceAgentFileUpload{
+		Path:   path,
+		Offset: offset + n,
+	}
+	if err != nil {
+		// Keep what was received so the upload can be resumed.
+		writeFileError(rw, r, "Failed to write upload.", err)
+		return
+	}
+	if complete {
+		if err := f.Chmod(mode); err != nil {
+			writeFileError(rw, r, "Failed to set file mode.", err)
+			return
+		}
+		if err := f.Close(); err != nil {
+			writeFileError(rw, r, "Failed to write upload.", err)
+			return
+		}
+		if err := os.Rename(partialPath, path); err != nil {
+			writeFileError(rw, r, "Failed to move upload to path.", err)
+			return
+		}
+		upload.Complete = true
+	}
+	httpapi.Write(ctx, rw, http.StatusOK, upload)
+}
@@ -0,0 +1,232 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"os/exec"
+	"os/user"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"cdr.dev/slog"
+
+	"github.com/coder/coder/v2/agent/agentproc"
+	"github.com/coder/coder/v2/agent/agentssh"
+	"github.com/coder/coder/v2/coderd/httpapi"
+	"github.com/coder/coder/v2/codersdk"
+)
+
+// execOutputLimit is the number of bytes of each output stream of an exec
+// request that are returned.
+const execOutputLimit = 1 << 20
+
+// handleListProcesses returns the process tree of the workspace. This is
+// tested by coderd's TestWorkspaceAgentProcesses test.
+func (a *agent) handleListProcesses(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if runtime.GOOS != "linux" {
+		httpapi.Write(ctx, rw, http.StatusNotImplemented, codersdk.Response{
+			Message: "Listing processes is only supported on Linux.",
+		})
+		return
+	}
+
+	procs, err := agentproc.List(a.filesystem, a.syscaller)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to list processes.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	bootTime, err := agentproc.BootTime(a.filesystem)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to read boot time.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	now := time.Now()
+	usernames := map[uint32]string{}
+	processes := make(map[int32]codersdk.WorkspaceAgentProcess, len(procs))
+	for _, proc := range procs {
+		stat, err := proc.Stat(a.filesystem, bootTime)
+		if err != nil {
+			// The process most likely exited since it was listed.
+			a.logger.Debug(ctx, "failed to stat process", slog.F("pid", proc.PID), slog.Error(err))
+			continue
+		}
+		username, ok := usernames[stat.UID]
+		if !ok {
+			username = strconv.FormatUint(uint64(stat.UID), 10)
+			if u, err := user.LookupId(username); err == nil {
+				username = u.Username
+			}
+			usernames[stat.UID] = username
+		}
+		var cpuPercent float64
+		if elapsed := now.Sub(stat.StartTime); elapsed > 0 {
+			cpuPercent = 100 * stat.CPUTime.Seconds() / elapsed.Seconds()
+		}
+		processes[proc.PID] = codersdk.WorkspaceAgentProcess{
+			PID:         proc.PID,
+			ParentPID:   stat.ParentPID,
+			Name:        stat.Name,
+			Command:     strings.TrimSpace(proc.Cmd()),
+			User:        username,
+			CPUPercent:  cpuPercent,
+			MemoryBytes: stat.RSS,
+			StartedAt:   stat.StartTime,
+		}
+	}
+
+	children := map[int32][]int32{}
+	var roots []int32
+	for pid, proc := range processes {
+		if _, ok := processes[proc.ParentPID]; !ok || proc.ParentPID == pid {
+			roots = append(roots, pid)
+			continue
+		}
+		children[proc.ParentPID] = append(children[proc.ParentPID], pid)
+	}
+	var tree func(pids []int32) []codersdk.WorkspaceAgentProcess
+	tree = func(pids []int32) []codersdk.WorkspaceAgentProcess {
+		slices.Sort(pids)
+		out := make([]codersdk.WorkspaceAgentProcess, 0, len(pids))
+		for _, pid := range pids {
+			proc := processes[pid]
+			proc.Children = tree(children[pid])
+			out = append(out, proc)
+		}
+		return out
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, codersdk.WorkspaceAgentProcessesResponse{
+		Processes: tree(roots),
+	})
+}
+
+// handleExec runs a command without a terminal and returns its output once
+// it exits.
+func (a *agent) handleExec(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req codersdk.WorkspaceAgentExecRequest
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+	if a.blockFileTransfer && isFileTransferCommand(req.Command) {
+		httpapi.Write(ctx, rw, http.StatusForbidden, codersdk.Response{
+			Message: "File transfer is blocked by the workspace agent.",
+		})
+		return
+	}
+
+	if req.TimeoutMillis > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(req.TimeoutMillis)*time.Millisecond)
+		defer cancel()
+	}
+	cmdPty, err := a.sshServer.CreateCommand(ctx, req.Command, nil)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to create command.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	cmd := cmdPty.AsExec()
+	if req.Dir != "" {
+		dir := req.Dir
+		if dir == "~" || strings.HasPrefix(dir, "~/") {
+			home, err := userHomeDir()
+			if err != nil {
+				httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+					Message: "Failed to get home directory.",
+					Detail:  err.Error(),
+				})
+				return
+			}
+			dir = filepath.Join(home, dir[1:])
+		}
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(cmd.Dir, dir)
+		}
+		cmd.Dir = dir
+	}
+
+	stdout := &limitedBuffer{limit: execOutputLimit}
+	stderr := &limitedBuffer{limit: execOutputLimit}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	// Don't wait forever for background processes that keep the output
+	// open after the command was killed.
+	cmd.WaitDelay = 5 * time.Second
+
+	err = cmd.Start()
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Failed to start command.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	a.logger.Info(ctx, "exec started", slog.F("command", req.Command), slog.F("pid", cmd.Process.Pid))
+
+	err = cmd.Wait()
+	resp := codersdk.WorkspaceAgentExecResponse{
+		ExitCode:  cmd.ProcessState.ExitCode(),
+		Stdout:    stdout.String(),
+		Stderr:    stderr.String(),
+		Truncated: stdout.truncated || stderr.truncated,
+		TimedOut:  errors.Is(ctx.Err(), context.DeadlineExceeded),
+	}
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) && !errors.Is(err, exec.ErrWaitDelay) {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to run command.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	a.logger.Info(ctx, "exec exited", slog.F("command", req.Command), slog.F("exit_code", resp.ExitCode))
+
+	// The request context may have expired, but the client is still
+	// waiting for the result.
+	httpapi.Write(context.WithoutCancel(ctx), rw, http.StatusOK, resp)
+}
+
+// isFileTransferCommand reports whether command runs one of the file
+// transfer commands blocked over SSH.
+func isFileTransferCommand(command string) bool {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return false
+	}
+	return slices.Contains(agentssh.BlockedFileTransferCommands, filepath.Base(fields[0]))
+}
+
+// limitedBuffer keeps the first limit bytes written to it and discards the
+// rest.
+type limitedBuffer struct {
+	bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	n := len(p)
+	if remaining := b.limit - b.Len(); len(p) > remaining {
+		p = p[:max(remaining, 0)]
+		b.truncated = true
+	}
+	_, _ = b.Buffer.Write(p)
+	return n, nil
+}
@@ -48,6 +48,10 @@ type DRPCAgentClient interface {
 	BatchCreateLogs(ctx context.Context, in *BatchCreateLogsRequest) (*BatchCreateLogsResponse, error)
 	GetAnnouncementBanners(ctx context.Context, in *GetAnnouncementBannersRequest) (*GetAnnouncementBannersResponse, error)
 	ScriptCompleted(ctx context.Context, in *WorkspaceAgentScriptCompletedRequest) (*WorkspaceAgentScriptCompletedResponse, error)
+	StartSessionRecording(ctx context.Context, in *StartSessionRecordingRequest) (*StartSessionRecordingResponse, error)
+	UploadSessionRecordingChunk(ctx context.Context, in *UploadSessionRecordingChunkRequest) (*UploadSessionRecordingChunkResponse, error)
+	EndSessionRecording(ctx context.Context, in *EndSessionRecordingRequest) (*EndSessionRecordingResponse, error)
+	UpdateContainers(ctx context.Context, in *UpdateContainersRequest) (*UpdateContainersResponse, error)
 }
 
 type drpcAgentClient struct {
@@ -150,6 +154,42 @@ func (c *drpcAgentClient) ScriptCompleted(ctx context.Context, in *WorkspaceAgen
 	return out, nil
 }
 
+func (c *drpcAgentClient) StartSessionRecording(ctx context.Context, in *StartSessionRecordingRequest) (*StartSessionRecordingResponse, error) {
+	out := new(StartSessionRecordingResponse)
+	err := c.cc.Invoke(ctx, "/coder.agent.v2.Agent/StartSessionRecording", drpcEncoding_File_agent_proto_agent_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcAgentClient) UploadSessionRecordingChunk(ctx context.Context, in *UploadSessionRecordingChunkRequest) (*UploadSessionRecordingChunkResponse, error) {
+	out := new(UploadSessionRecordingChunkResponse)
+	err := c.cc.Invoke(ctx, "/coder.agent.v2.Agent/UploadSessionRecordingChunk", drpcEncoding_File_agent_proto_agent_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcAgentClient) EndSessionRecording(ctx context.Context, in *EndSessionRecordingRequest) (*EndSessionRecordingResponse, error) {
+	out := new(EndSessionRecordingResponse)
+	err := c.cc.Invoke(ctx, "/coder.agent.v2.Agent/EndSessionRecording", drpcEncoding_File_agent_proto_agent_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcAgentClient) UpdateContainers(ctx context.Context, in *UpdateContainersRequest) (*UpdateContainersResponse, error) {
+	out := new(UpdateContainersResponse)
+	err := c.cc.Invoke(ctx, "/coder.agent.v2.Agent/UpdateContainers", drpcEncoding_File_agent_proto_agent_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 type DRPCAgentServer interface {
 	GetManifest(context.Context, *GetManifestRequest) (*Manifest, error)
 	GetServiceBanner(context.Context, *GetServiceBannerRequest) (*ServiceBanner, error)
@@ -161,6 +201,10 @@ type DRPCAgentServer interface {
 	BatchCreateLogs(context.Context, *BatchCreateLogsRequest) (*BatchCreateLogsResponse, error)
 	GetAnnouncementBanners(context.Context, *GetAnnouncementBannersRequest) (*GetAnnouncementBannersResponse, error)
 	ScriptCompleted(context.Context, *WorkspaceAgentScriptCompletedRequest) (*WorkspaceAgentScriptCompletedResponse, error)
+	StartSessionRecording(context.Context, *StartSessionRecordingRequest) (*StartSessionRecordingResponse, error)
+	UploadSessionRecordingChunk(context.Context, *UploadSessionRecordingChunkRequest) (*UploadSessionRecordingChunkResponse, error)
+	EndSessionRecording(context.Context, *EndSessionRecordingRequest) (*EndSessionRecordingResponse, error)
+	UpdateContainers(context.Context, *UpdateContainersRequest) (*UpdateContainersResponse, error)
 }
 
 type DRPCAgentUnimplementedServer struct{}
@@ -205,9 +249,25 @@ func (s *DRPCAgentUnimplementedServer) ScriptCompleted(context.Context, *Workspa
 	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
 }
 
+func (s *DRPCAgentUnimplementedServer) StartSessionRecording(context.Context, *StartSessionRecordingRequest) (*StartSessionRecordingResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+}
+
+func (s *DRPCAgentUnimplementedServer) UploadSessionRecordingChunk(context.Context, *UploadSessionRecordingChunkRequest) (*UploadSessionRecordingChunkResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+}
+
+func (s *DRPCAgentUnimplementedServer) EndSessionRecording(context.Context, *EndSessionRecordingRequest) (*EndSessionRecordingResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+}
+
+func (s *DRPCAgentUnimplementedServer) UpdateContainers(context.Context, *UpdateContainersRequest) (*UpdateContainersResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+}
+
 type DRPCAgentDescription struct{}
 
-func (DRPCAgentDescription) NumMethods() int { return 10 }
+func (DRPCAgentDescription) NumMethods() int { return 14 }
 
 func (DRPCAgentDescription) Method(n int) (string, drpc.Encoding, drpc.Receiver, interface{}, bool) {
 	switch n {
@@ -301,6 +361,42 @@ func (DRPCAgentDescription) Method(n int) (string, drpc.Encoding, drpc.Receiver,
 						in1.(*WorkspaceAgentScriptCompletedRequest),
 					)
 			}, DRPCAgentServer.ScriptCompleted, true
+	case 10:
+		return "/coder.agent.v2.Agent/StartSessionRecording", drpcEncoding_File_agent_proto_agent_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCAgentServer).
+					StartSessionRecording(
+						ctx,
+						in1.(*StartSessionRecordingRequest),
+					)
+			}, DRPCAgentServer.StartSessionRecording, true
+	case 11:
+		return "/coder.agent.v2.Agent/UploadSessionRecordingChunk", drpcEncoding_File_agent_proto_agent_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCAgentServer).
+					UploadSessionRecordingChunk(
+						ctx,
+						in1.(*UploadSessionRecordingChunkRequest),
+					)
+			}, DRPCAgentServer.UploadSessionRecordingChunk, true
+	case 12:
+		return "/coder.agent.v2.Agent/EndSessionRecording", drpcEncoding_File_agent_proto_agent_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCAgentServer).
+					EndSessionRecording(
+						ctx,
+						in1.(*EndSessionRecordingRequest),
+					)
+			}, DRPCAgentServer.EndSessionRecording, true
+	case 13:
+		return "/coder.agent.v2.Agent/UpdateContainers", drpcEncoding_File_agent_proto_agent_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCAgentServer).
+					UpdateContainers(
+						ctx,
+						in1.(*UpdateContainersRequest),
+					)
+			}, DRPCAgentServer.UpdateContainers, true
 	default:
 		return "", nil, nil, nil, false
 	}
@@ -469,3 +565,67 @@ func (x *drpcAgent_ScriptCompletedStream) SendAndClose(m *WorkspaceAgentScriptCo
 	}
 	return x.CloseSend()
 }
+
+type DRPCAgent_StartSessionRecordingStream interface {
+	drpc.Stream
+	SendAndClose(*StartSessionRecordingResponse) error
+}
+
+type drpcAgent_StartSessionRecordingStream struct {
+	drpc.Stream
+}
+
+func (x *drpcAgent_StartSessionRecordingStream) SendAndClose(m *StartSessionRecordingResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_agent_proto_agent_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCAgent_UploadSessionRecordingChunkStream interface {
+	drpc.Stream
+	SendAndClose(*UploadSessionRecordingChunkResponse) error
+}
+
+type drpcAgent_UploadSessionRecordingChunkStream struct {
+	drpc.Stream
+}
+
+func (x *drpcAgent_UploadSessionRecordingChunkStream) SendAndClose(m *UploadSessionRecordingChunkResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_agent_proto_agent_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCAgent_EndSessionRecordingStream interface {
+	drpc.Stream
+	SendAndClose(*EndSessionRecordingResponse) error
+}
+
+type drpcAgent_EndSessionRecordingStream struct {
+	drpc.Stream
+}
+
+func (x *drpcAgent_EndSessionRecordingStream) SendAndClose(m *EndSessionRecordingResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_agent_proto_agent_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCAgent_UpdateContainersStream interface {
+	drpc.Stream
+	SendAndClose(*UpdateContainersResponse) error
+}
+
+type drpcAgent_UpdateContainersStream struct {
+	drpc.Stream
+}
+
+func (x *drpcAgent_UpdateContainersStream) SendAndClose(m *UpdateContainersResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_agent_proto_agent_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
@@ -36,3 +36,19 @@ type DRPCAgentClient21 interface {
 	BatchCreateLogs(ctx context.Context, in *BatchCreateLogsRequest) (*BatchCreateLogsResponse, error)
 	GetAnnouncementBanners(ctx context.Context, in *GetAnnouncementBannersRequest) (*GetAnnouncementBannersResponse, error)
 }
+
+// DRPCAgentClient22 is the Agent API at v2.2. It adds ScriptCompleted, and is useful if you want to
+// be maximally compatible with Coderd Release Versions that do not support session recording.
+type DRPCAgentClient22 interface {
+	DRPCAgentClient21
+	ScriptCompleted(ctx context.Context, in *WorkspaceAgentScriptCompletedRequest) (*WorkspaceAgentScriptCompletedResponse, error)
+}
+
+// DRPCAgentClient23 is the Agent API at v2.3. It adds session recording, and is useful if you want
+// to be maximally compatible with Coderd Release Versions that do not support container reporting.
+type DRPCAgentClient23 interface {
+	DRPCAgentClient22
+	StartSessionRecording(ctx context.Context, in *StartSessionRecordingRequest) (*StartSessionRecordingResponse, error)
+	UploadSessionRecordingChunk(ctx context.Context, in *UploadSessionRecordingChunkRequest) (*UploadSessionRecordingChunkResponse, error)
+	EndSessionRecording(ctx context.Context, in *EndSessionRecordingRequest) (*EndSessionRecordingResponse, error)
+}
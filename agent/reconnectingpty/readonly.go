@@ -0,0 +1,27 @@
+package reconnectingpty
+
+import (
+	"io"
+	"net"
+)
+
+// ReadOnlyConn wraps a connection so it can be attached to a reconnecting pty
+// as a viewer.  The output of the pty is written to it as usual, but input and
+// resizes sent over it are discarded.
+func ReadOnlyConn(conn net.Conn) net.Conn {
+	return &readOnlyConn{Conn: conn}
+}
+
+type readOnlyConn struct {
+	net.Conn
+}
+
+// Read discards everything sent over the connection and only returns once
+// reading from it fails, e.g. because it was closed.
+func (c *readOnlyConn) Read([]byte) (int, error) {
+	_, err := io.Copy(io.Discard, c.Conn)
+	if err == nil {
+		err = io.EOF
+	}
+	return 0, err
+}
@@ -225,17 +225,22 @@ func (rpty *screenReconnectingPTY) doAttach(ctx context.Context, conn net.Conn,
 	}, rpty.command.Args[1:]...)...)
 	cmd.Env = append(rpty.command.Env, "TERM=xterm-256color")
 	cmd.Dir = rpty.command.Dir
-	ptty, process, err := pty.Start(cmd, pty.WithPTYOption(
-		pty.WithSSHRequest(ssh.Pty{
-			Window: ssh.Window{
-				// Make sure to spawn at the right size because if we resize afterward it
-				// leaves confusing padding (screen will resize such that the screen
-				// contents are aligned to the bottom).
-				Height: int(height),
-				Width:  int(width),
-			},
-		}),
-	))
+	var opts []pty.StartOption
+	// Viewers attach without a size and get the default one.
+	if height != 0 && width != 0 {
+		opts = append(opts, pty.WithPTYOption(
+			pty.WithSSHRequest(ssh.Pty{
+				Window: ssh.Window{
+					// Make sure to spawn at the right size because if we resize afterward it
+					// leaves confusing padding (screen will resize such that the screen
+					// contents are aligned to the bottom).
+					Height: int(height),
+					Width:  int(width),
+				},
+			}),
+		))
+	}
+	ptty, process, err := pty.Start(cmd, opts...)
 	if err != nil {
 		rpty.metrics.WithLabelValues("screen_spawn").Add(1)
 		return nil, nil, err
@@ -0,0 +1,138 @@
+package agent
+
+import (
+	"net"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/coder/coder/v2/coderd/httpapi"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/codersdk/workspacesdk"
+)
+
+// reconnectingPTYSessions tracks the live reconnecting ptys and the
+// connections attached to them so they can be listed and taken over.
+type reconnectingPTYSessions struct {
+	mu       sync.Mutex
+	sessions map[uuid.UUID]*reconnectingPTYSession
+}
+
+type reconnectingPTYSession struct {
+	userID    uuid.UUID
+	command   string
+	container string
+	startedAt time.Time
+	conns     map[uuid.UUID]*reconnectingPTYConn
+}
+
+type reconnectingPTYConn struct {
+	conn       net.Conn
+	readOnly   bool
+	attachedAt time.Time
+}
+
+// start records a reconnecting pty started with the init message.
+func (s *reconnectingPTYSessions) start(msg workspacesdk.AgentReconnectingPTYInit) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.sessions == nil {
+		s.sessions = map[uuid.UUID]*reconnectingPTYSession{}
+	}
+	s.sessions[msg.ID] = &reconnectingPTYSession{
+		userID:    msg.UserID,
+		command:   msg.Command,
+		container: msg.Container,
+		startedAt: time.Now(),
+		conns:     map[uuid.UUID]*reconnectingPTYConn{},
+	}
+}
+
+// stop forgets a reconnecting pty once it closed.
+func (s *reconnectingPTYSessions) stop(id uuid.UUID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, id)
+}
+
+// startedBy reports whether the reconnecting pty was started by the user.
+func (s *reconnectingPTYSessions) startedBy(id, userID uuid.UUID) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, ok := s.sessions[id]
+	return ok && session.userID == userID
+}
+
+// attach records a connection to a reconnecting pty and returns a function
+// that removes it again. With takeover, the other connections that aren't
+// read only are closed, which detaches them.
+func (s *reconnectingPTYSessions) attach(id, connID uuid.UUID, conn net.Conn, readOnly, takeover bool) func() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, ok := s.sessions[id]
+	if !ok {
+		return func() {}
+	}
+	if takeover {
+		for otherID, other := range session.conns {
+			if other.readOnly {
+				continue
+			}
+			_ = other.conn.Close()
+			delete(session.conns, otherID)
+		}
+	}
+	session.conns[connID] = &reconnectingPTYConn{
+		conn:       conn,
+		readOnly:   readOnly,
+		attachedAt: time.Now(),
+	}
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(session.conns, connID)
+	}
+}
+
+// list returns the live reconnecting ptys, oldest first.
+func (s *reconnectingPTYSessions) list() []codersdk.WorkspaceAgentReconnectingPTY {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sessions := make([]codersdk.WorkspaceAgentReconnectingPTY, 0, len(s.sessions))
+	for id, session := range s.sessions {
+		conns := make([]codersdk.WorkspaceAgentReconnectingPTYConnection, 0, len(session.conns))
+		for connID, conn := range session.conns {
+			conns = append(conns, codersdk.WorkspaceAgentReconnectingPTYConnection{
+				ID:         connID,
+				ReadOnly:   conn.readOnly,
+				AttachedAt: conn.attachedAt,
+			})
+		}
+		sort.Slice(conns, func(i, j int) bool {
+			return conns[i].AttachedAt.Before(conns[j].AttachedAt)
+		})
+		sessions = append(sessions, codersdk.WorkspaceAgentReconnectingPTY{
+			ID:          id,
+			UserID:      session.userID,
+			Command:     session.command,
+			Container:   session.container,
+			StartedAt:   session.startedAt,
+			Connections: conns,
+		})
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].StartedAt.Before(sessions[j].StartedAt)
+	})
+	return sessions
+}
+
+// handleListReconnectingPTYs returns the live reconnecting ptys. This is
+// tested by coderd's TestWorkspaceAgentReconnectingPTYs test.
+func (a *agent) handleListReconnectingPTYs(rw http.ResponseWriter, r *http.Request) {
+	httpapi.Write(r.Context(), rw, http.StatusOK, codersdk.WorkspaceAgentReconnectingPTYsResponse{
+		Sessions: a.reconnectingPTYSessions.list(),
+	})
+}
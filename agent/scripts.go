@@ -0,0 +1,52 @@
+package agent
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/coder/coder/v2/agent/agentscripts"
+	"github.com/coder/coder/v2/coderd/httpapi"
+	"github.com/coder/coder/v2/codersdk"
+)
+
+// handleRunScript runs a script on demand. The script runs in the background,
+// and its run is reported like runs of scheduled scripts. This is tested by
+// coderd's TestWorkspaceAgentRunScript test.
+func (a *agent) handleRunScript(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Invalid script ID.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	err = a.scriptRunner.RunScript(id)
+	switch {
+	case errors.Is(err, agentscripts.ErrScriptNotFound):
+		httpapi.Write(ctx, rw, http.StatusNotFound, codersdk.Response{
+			Message: "Script not found.",
+		})
+		return
+	case errors.Is(err, agentscripts.ErrScriptRunning):
+		httpapi.Write(ctx, rw, http.StatusConflict, codersdk.Response{
+			Message: "The script is already running.",
+		})
+		return
+	case err != nil:
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to run script.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusAccepted, codersdk.Response{
+		Message: "The script is running.",
+	})
+}
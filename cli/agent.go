@@ -26,6 +26,7 @@ import (
 	"cdr.dev/slog/sloggers/slogjson"
 	"cdr.dev/slog/sloggers/slogstackdriver"
 	"github.com/coder/coder/v2/agent"
+	"github.com/coder/coder/v2/agent/agentactivity"
 	"github.com/coder/coder/v2/agent/agentproc"
 	"github.com/coder/coder/v2/agent/agentssh"
 	"github.com/coder/coder/v2/agent/reaper"
@@ -52,6 +53,11 @@ func (r *RootCmd) workspaceAgent() *serpent.Command {
 		blockFileTransfer   bool
 		agentHeaderCommand  string
 		agentHeader         []string
+
+		activityCPUThreshold     float64
+		activityProcesses        []string
+		activityHeartbeatFile    string
+		activityHeartbeatTimeout time.Duration
 	)
 	cmd := &serpent.Command{
 		Use:   "agent",
@@ -328,6 +334,12 @@ func (r *RootCmd) workspaceAgent() *serpent.Command {
 				ModifiedProcesses: nil,
 
 				BlockFileTransfer: blockFileTransfer,
+				Activity: agentactivity.Options{
+					CPUThreshold:     activityCPUThreshold,
+					Processes:        activityProcesses,
+					HeartbeatFile:    activityHeartbeatFile,
+					HeartbeatTimeout: activityHeartbeatTimeout,
+				},
 			})
 
 			promHandler := agent.PrometheusMetricsHandler(prometheusRegistry, logger)
@@ -450,6 +462,31 @@ func (r *RootCmd) workspaceAgent() *serpent.Command {
 			Description: fmt.Sprintf("Block file transfer using known applications: %s.", strings.Join(agentssh.BlockedFileTransferCommands, ",")),
 			Value:       serpent.BoolOf(&blockFileTransfer),
 		},
+		{
+			Flag:        "activity-cpu-threshold",
+			Env:         "CODER_AGENT_ACTIVITY_CPU_THRESHOLD",
+			Description: "Report the workspace as active while its CPU usage is above this percentage of the available CPU. Templates can opt in to bumping the deadline of workspaces on this activity.",
+			Value:       serpent.Float64Of(&activityCPUThreshold),
+		},
+		{
+			Flag:        "activity-process",
+			Env:         "CODER_AGENT_ACTIVITY_PROCESSES",
+			Description: "Report the workspace as active while a process with one of these names runs, e.g. make. Only supported on Linux.",
+			Value:       serpent.StringArrayOf(&activityProcesses),
+		},
+		{
+			Flag:        "activity-heartbeat-file",
+			Env:         "CODER_AGENT_ACTIVITY_HEARTBEAT_FILE",
+			Description: "Report the workspace as active while this file was modified within the heartbeat timeout, so tooling can keep the workspace running by touching it.",
+			Value:       serpent.StringOf(&activityHeartbeatFile),
+		},
+		{
+			Flag:        "activity-heartbeat-timeout",
+			Env:         "CODER_AGENT_ACTIVITY_HEARTBEAT_TIMEOUT",
+			Default:     agentactivity.DefaultHeartbeatTimeout.String(),
+			Description: "How long the workspace is active after the heartbeat file was modified.",
+			Value:       serpent.DurationOf(&activityHeartbeatTimeout),
+		},
 	}
 
 	return cmd
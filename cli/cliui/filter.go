@@ -55,7 +55,7 @@ func (w *WorkspaceFilter) AttachOptions(opts *serpent.OptionSet) {
 		},
 		serpent.Option{
 			Flag:        "search",
-			Description: "Search for a workspace with a query.",
+			Description: "Search for a workspace with a query. Terms can be negated with a '-' prefix, and combined with OR inside parentheses.",
 			Default:     defaultQuery,
 			Value:       serpent.StringOf(&w.searchQuery),
 		},
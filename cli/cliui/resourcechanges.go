@@ -0,0 +1,69 @@
+package cliui
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/pretty"
+)
+
+// ResourceChanges displays the changes a build makes to the resources of a
+// workspace, similar to the output of `terraform plan`.
+//
+//	~ docker_container.workspace[0] will be updated in place
+//	    image: "ubuntu:22.04" → "ubuntu:24.04"
+//	-/+ docker_volume.home must be replaced
+//	    name: "home" → "home-2" (forces replacement)
+//
+//	0 to create, 1 to update, 1 to replace, 0 to delete.
+func ResourceChanges(writer io.Writer, changes []codersdk.ResourceChange) {
+	if len(changes) == 0 {
+		_, _ = fmt.Fprintln(writer, "No changes to the resources of the workspace.")
+		return
+	}
+
+	counts := map[codersdk.ResourceChangeAction]int{}
+	for _, change := range changes {
+		counts[change.Action]++
+
+		symbol, description, style := "~", "will be updated in place", DefaultStyles.Warn
+		switch change.Action {
+		case codersdk.ResourceChangeActionCreate:
+			symbol, description, style = "+", "will be created", DefaultStyles.Keyword
+		case codersdk.ResourceChangeActionReplace:
+			symbol, description, style = "-/+", "must be replaced", DefaultStyles.Error
+		case codersdk.ResourceChangeActionDelete:
+			symbol, description, style = "-", "will be deleted", DefaultStyles.Error
+		}
+		_, _ = fmt.Fprintf(writer, "%s %s %s\n", pretty.Sprint(style, symbol), Bold(change.Address), description)
+
+		for _, attribute := range change.Attributes {
+			before, after := attribute.Before, attribute.After
+			switch {
+			case attribute.Sensitive:
+				before, after = "(sensitive value)", "(sensitive value)"
+			case attribute.Unknown:
+				after = "(known after apply)"
+			}
+			if before == "" {
+				before = "null"
+			}
+			if after == "" {
+				after = "null"
+			}
+			line := fmt.Sprintf("    %s: %s → %s", attribute.Name, before, after)
+			if attribute.ForcesReplacement {
+				line += pretty.Sprint(DefaultStyles.Error, " (forces replacement)")
+			}
+			_, _ = fmt.Fprintln(writer, line)
+		}
+	}
+
+	_, _ = fmt.Fprintf(writer, "\n%d to create, %d to update, %d to replace, %d to delete.\n",
+		counts[codersdk.ResourceChangeActionCreate],
+		counts[codersdk.ResourceChangeActionUpdate],
+		counts[codersdk.ResourceChangeActionReplace],
+		counts[codersdk.ResourceChangeActionDelete],
+	)
+}
@@ -0,0 +1,375 @@
+package cli
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/serpent"
+)
+
+// copyChunkSize is the size of the chunks files are uploaded in. An
+// interrupted upload resumes from the last chunk that was received.
+const copyChunkSize = 4 << 20
+
+// copyTarget is a source or destination of `coder cp`. It's remote if
+// workspace is set.
+type copyTarget struct {
+	workspace string
+	path      string
+}
+
+// parseCopyTarget parses `<workspace>[.<agent>]:<path>` as a remote target,
+// and anything else as a local path.
+func parseCopyTarget(arg string) copyTarget {
+	idx := strings.Index(arg, ":")
+	if idx <= 0 || strings.HasPrefix(arg, ".") || strings.HasPrefix(arg, "/") {
+		return copyTarget{path: arg}
+	}
+	// Don't mistake a drive letter for a workspace.
+	if runtime.GOOS == "windows" && idx == 1 {
+		return copyTarget{path: arg}
+	}
+	return copyTarget{workspace: arg[:idx], path: arg[idx+1:]}
+}
+
+func (r *RootCmd) cp() *serpent.Command {
+	var retries int64
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Annotations: workspaceCommand,
+		Use:         "cp <source> <destination>",
+		Short:       "Copy files to or from a workspace",
+		Long: "Exactly one of the source and destination must be in a workspace, in the form <workspace>[.<agent>]:<path>. " +
+			"Relative paths in a workspace are relative to the working directory of the agent. " +
+			"Interrupted copies resume where they left off when the command is run again.\n\n" + FormatExamples(
+			Example{
+				Description: "Copy a file to the home directory of a workspace",
+				Command:     "coder cp ./notes.txt my-workspace:~/notes.txt",
+			},
+			Example{
+				Description: "Copy a directory out of a workspace",
+				Command:     "coder cp my-workspace.main:project/build ./build",
+			},
+		),
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(2),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			ctx := inv.Context()
+
+			src, dst := parseCopyTarget(inv.Args[0]), parseCopyTarget(inv.Args[1])
+			if (src.workspace == "") == (dst.workspace == "") {
+				return xerrors.New("exactly one of the source and destination must be in a workspace, e.g. my-workspace:~/file")
+			}
+			remote := src
+			if dst.workspace != "" {
+				remote = dst
+			}
+			_, workspaceAgent, err := getWorkspaceAndAgent(ctx, inv, client, false, remote.workspace)
+			if err != nil {
+				return err
+			}
+
+			c := &copier{
+				inv:     inv,
+				client:  client,
+				agentID: workspaceAgent.ID,
+				retries: int(retries),
+			}
+			if dst.workspace != "" {
+				return c.upload(ctx, src.path, dst)
+			}
+			return c.download(ctx, src, dst.path)
+		},
+	}
+	cmd.Options = serpent.OptionSet{
+		{
+			Flag:        "retries",
+			Description: "Number of times to resume a file transfer that failed before giving up.",
+			Default:     "3",
+			Value:       serpent.Int64Of(&retries),
+		},
+	}
+	return cmd
+}
+
+type copier struct {
+	inv     *serpent.Invocation
+	client  *codersdk.Client
+	agentID uuid.UUID
+	retries int
+}
+
+// upload copies a local file or directory into the workspace. If the
+// destination is an existing directory, the source is copied into it.
+func (c *copier) upload(ctx context.Context, src string, dst copyTarget) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	dstPath := dst.path
+	if dstPath == "" {
+		dstPath = "."
+	}
+	remoteInfo, err := c.client.WorkspaceAgentFileStat(ctx, c.agentID, dstPath)
+	if err == nil && remoteInfo.IsDir {
+		dstPath = path.Join(remoteInfo.Path, filepath.Base(src))
+	} else if err != nil && !isNotFound(err) {
+		return xerrors.Errorf("stat %s:%s: %w", dst.workspace, dstPath, err)
+	}
+
+	if !info.IsDir() {
+		return c.uploadFile(ctx, src, dstPath, info)
+	}
+	return filepath.WalkDir(src, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			// Directories are created for the files in them, and other
+			// kinds of files can't be copied.
+			if !d.IsDir() {
+				_, _ = fmt.Fprintf(c.inv.Stderr, "Skipping %s, only regular files can be copied.\n", p)
+			}
+			return nil
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		return c.uploadFile(ctx, p, path.Join(dstPath, filepath.ToSlash(rel)), info)
+	})
+}
+
+func (c *copier) uploadFile(ctx context.Context, src, dst string, info fs.FileInfo) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var attempt int
+	for {
+		err = c.uploadFileFrom(ctx, f, dst, info)
+		if err == nil {
+			break
+		}
+		if attempt >= c.retries || ctx.Err() != nil || isClientError(err) {
+			return xerrors.Errorf("upload %s: %w", src, err)
+		}
+		attempt++
+		_, _ = fmt.Fprintf(c.inv.Stderr, "Upload of %s failed, resuming: %s\n", src, err)
+	}
+	_, _ = fmt.Fprintf(c.inv.Stdout, "Copied %s to %s\n", src, dst)
+	return nil
+}
+
+// uploadFileFrom uploads f in chunks, starting where a previous upload of
+// the file left off.
+func (c *copier) uploadFileFrom(ctx context.Context, f *os.File, dst string, info fs.FileInfo) error {
+	progress, err := c.client.WorkspaceAgentFileUploadProgress(ctx, c.agentID, dst)
+	if err != nil {
+		return err
+	}
+	offset := progress.Offset
+	if offset > info.Size() {
+		// The partial upload isn't of this file.
+		offset = 0
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+	for {
+		chunk := io.LimitReader(f, copyChunkSize)
+		complete := info.Size()-offset <= copyChunkSize
+		upload, err := c.client.WorkspaceAgentUploadFile(ctx, c.agentID, codersdk.UploadWorkspaceAgentFileRequest{
+			Path:     dst,
+			Offset:   offset,
+			Complete: complete,
+			Mode:     uint32(info.Mode().Perm()),
+		}, chunk)
+		if err != nil {
+			return err
+		}
+		if complete {
+			return nil
+		}
+		offset = upload.Offset
+	}
+}
+
+// download copies a file or directory out of the workspace. If the
+// destination is an existing directory, the source is copied into it.
+func (c *copier) download(ctx context.Context, src copyTarget, dst string) error {
+	info, err := c.client.WorkspaceAgentFileStat(ctx, c.agentID, src.path)
+	if err != nil {
+		return xerrors.Errorf("stat %s:%s: %w", src.workspace, src.path, err)
+	}
+	if localInfo, err := os.Stat(dst); err == nil && localInfo.IsDir() {
+		dst = filepath.Join(dst, info.Name)
+	}
+
+	if info.IsDir {
+		return c.downloadDirectory(ctx, info.Path, dst)
+	}
+
+	var attempt int
+	for {
+		err = c.downloadFile(ctx, info, dst)
+		if err == nil {
+			break
+		}
+		if attempt >= c.retries || ctx.Err() != nil || isClientError(err) {
+			return xerrors.Errorf("download %s: %w", info.Path, err)
+		}
+		attempt++
+		_, _ = fmt.Fprintf(c.inv.Stderr, "Download of %s failed, resuming: %s\n", info.Path, err)
+	}
+	_, _ = fmt.Fprintf(c.inv.Stdout, "Copied %s to %s\n", info.Path, dst)
+	return nil
+}
+
+// downloadFile downloads a file into a partial file next to dst, resuming
+// from the size of the partial file, and moves it to dst once complete.
+func (c *copier) downloadFile(ctx context.Context, info codersdk.WorkspaceAgentFileInfo, dst string) error {
+	partial := filepath.Join(filepath.Dir(dst), "."+filepath.Base(dst)+".coder-download")
+	f, err := os.OpenFile(partial, os.O_WRONLY|os.O_CREATE, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	offset, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+	if offset > info.Size {
+		// The partial download isn't of this file.
+		if err := f.Truncate(0); err != nil {
+			return err
+		}
+		offset, err = f.Seek(0, io.SeekStart)
+		if err != nil {
+			return err
+		}
+	}
+
+	rc, err := c.client.WorkspaceAgentDownloadFile(ctx, c.agentID, info.Path, offset)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	if _, err := io.Copy(f, rc); err != nil {
+		return err
+	}
+	mode, err := parseFileMode(info.Mode)
+	if err != nil {
+		return err
+	}
+	if err := f.Chmod(mode); err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(partial, dst)
+}
+
+// downloadDirectory extracts a tar archive of a directory in the workspace
+// into dst.
+func (c *copier) downloadDirectory(ctx context.Context, src, dst string) error {
+	rc, err := c.client.WorkspaceAgentArchiveDirectory(ctx, c.agentID, src)
+	if err != nil {
+		return xerrors.Errorf("archive %s: %w", src, err)
+	}
+	defer rc.Close()
+
+	tr := tar.NewReader(rc)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return xerrors.Errorf("read archive of %s: %w", src, err)
+		}
+		// Entries are prefixed with the name of the directory.
+		_, name, _ := strings.Cut(strings.TrimSuffix(hdr.Name, "/"), "/")
+		name = filepath.FromSlash(name)
+		if name != "" && !filepath.IsLocal(name) {
+			return xerrors.Errorf("archive of %s contains invalid path %q", src, hdr.Name)
+		}
+		target := filepath.Join(dst, name)
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(target, hdr.FileInfo().Mode().Perm()|0o700)
+		case tar.TypeSymlink:
+			err = os.Symlink(hdr.Linkname, target)
+		case tar.TypeReg:
+			err = extractFile(tr, target, hdr.FileInfo().Mode().Perm())
+		}
+		if err != nil {
+			return xerrors.Errorf("extract %s: %w", hdr.Name, err)
+		}
+	}
+	_, _ = fmt.Fprintf(c.inv.Stdout, "Copied %s to %s\n", src, dst)
+	return nil
+}
+
+func extractFile(r io.Reader, path string, mode fs.FileMode) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err := io.Copy(f, r); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// parseFileMode parses the permission bits of a mode in the format of
+// `ls -l`, e.g. "-rw-r--r--".
+func parseFileMode(s string) (fs.FileMode, error) {
+	if len(s) < 9 {
+		return 0, xerrors.Errorf("invalid file mode %q", s)
+	}
+	var mode fs.FileMode
+	for i, c := range s[len(s)-9:] {
+		if c != '-' {
+			mode |= 1 << (8 - i)
+		}
+	}
+	return mode, nil
+}
+
+func isNotFound(err error) bool {
+	var sdkErr *codersdk.Error
+	return errors.As(err, &sdkErr) && sdkErr.StatusCode() == http.StatusNotFound
+}
+
+// isClientError reports whether a transfer failed in a way that retrying
+// won't fix.
+func isClientError(err error) bool {
+	var sdkErr *codersdk.Error
+	return errors.As(err, &sdkErr) && sdkErr.StatusCode() >= http.StatusBadRequest && sdkErr.StatusCode() < http.StatusInternalServerError
+}
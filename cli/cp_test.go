@@ -0,0 +1,127 @@
+package cli_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/agent/agenttest"
+	"github.com/coder/coder/v2/cli/clitest"
+	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestCp(t *testing.T) {
+	t.Parallel()
+
+	t.Run("File", func(t *testing.T) {
+		t.Parallel()
+
+		client, workspace, agentToken := setupWorkspaceForAgent(t)
+		_ = agenttest.New(t, client.URL, agentToken)
+		_ = coderdtest.AwaitWorkspaceAgents(t, client, workspace.ID)
+
+		local := filepath.Join(t.TempDir(), "local.txt")
+		require.NoError(t, os.WriteFile(local, []byte("hello world"), 0o640))
+		// The agent runs in the test process, so paths in the workspace
+		// are local too.
+		remoteDir := t.TempDir()
+		remote := filepath.Join(remoteDir, "remote.txt")
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		inv, root := clitest.New(t, "cp", local, workspace.Name+":"+remote)
+		clitest.SetupConfig(t, client, root)
+		require.NoError(t, inv.WithContext(ctx).Run())
+
+		data, err := os.ReadFile(remote)
+		require.NoError(t, err)
+		require.Equal(t, "hello world", string(data))
+		info, err := os.Stat(remote)
+		require.NoError(t, err)
+		require.Equal(t, os.FileMode(0o640), info.Mode().Perm())
+
+		// Copying into a directory keeps the name of the file.
+		downloadDir := t.TempDir()
+		inv, root = clitest.New(t, "cp", workspace.Name+":"+remote, downloadDir)
+		clitest.SetupConfig(t, client, root)
+		require.NoError(t, inv.WithContext(ctx).Run())
+
+		data, err = os.ReadFile(filepath.Join(downloadDir, "remote.txt"))
+		require.NoError(t, err)
+		require.Equal(t, "hello world", string(data))
+	})
+
+	t.Run("ResumeDownload", func(t *testing.T) {
+		t.Parallel()
+
+		client, workspace, agentToken := setupWorkspaceForAgent(t)
+		_ = agenttest.New(t, client.URL, agentToken)
+		_ = coderdtest.AwaitWorkspaceAgents(t, client, workspace.ID)
+
+		remote := filepath.Join(t.TempDir(), "remote.txt")
+		require.NoError(t, os.WriteFile(remote, []byte("hello world"), 0o600))
+		localDir := t.TempDir()
+		// A previous download was interrupted after the first six bytes.
+		require.NoError(t, os.WriteFile(filepath.Join(localDir, ".local.txt.coder-download"), []byte("hello "), 0o600))
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		inv, root := clitest.New(t, "cp", workspace.Name+":"+remote, filepath.Join(localDir, "local.txt"))
+		clitest.SetupConfig(t, client, root)
+		require.NoError(t, inv.WithContext(ctx).Run())
+
+		data, err := os.ReadFile(filepath.Join(localDir, "local.txt"))
+		require.NoError(t, err)
+		require.Equal(t, "hello world", string(data))
+		_, err = os.Stat(filepath.Join(localDir, ".local.txt.coder-download"))
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("Directory", func(t *testing.T) {
+		t.Parallel()
+
+		client, workspace, agentToken := setupWorkspaceForAgent(t)
+		_ = agenttest.New(t, client.URL, agentToken)
+		_ = coderdtest.AwaitWorkspaceAgents(t, client, workspace.ID)
+
+		src := filepath.Join(t.TempDir(), "project")
+		require.NoError(t, os.MkdirAll(filepath.Join(src, "sub"), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(src, "a.txt"), []byte("a"), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(src, "sub", "b.txt"), []byte("b"), 0o600))
+
+		// Upload into an existing directory in the workspace.
+		remoteDir := t.TempDir()
+		ctx := testutil.Context(t, testutil.WaitLong)
+		inv, root := clitest.New(t, "cp", src, workspace.Name+":"+remoteDir)
+		clitest.SetupConfig(t, client, root)
+		require.NoError(t, inv.WithContext(ctx).Run())
+
+		data, err := os.ReadFile(filepath.Join(remoteDir, "project", "sub", "b.txt"))
+		require.NoError(t, err)
+		require.Equal(t, "b", string(data))
+
+		// Download to a new directory.
+		dst := filepath.Join(t.TempDir(), "copy")
+		inv, root = clitest.New(t, "cp", workspace.Name+":"+filepath.Join(remoteDir, "project"), dst)
+		clitest.SetupConfig(t, client, root)
+		require.NoError(t, inv.WithContext(ctx).Run())
+
+		data, err = os.ReadFile(filepath.Join(dst, "a.txt"))
+		require.NoError(t, err)
+		require.Equal(t, "a", string(data))
+		data, err = os.ReadFile(filepath.Join(dst, "sub", "b.txt"))
+		require.NoError(t, err)
+		require.Equal(t, "b", string(data))
+	})
+
+	t.Run("NoWorkspace", func(t *testing.T) {
+		t.Parallel()
+
+		client, _, _ := setupWorkspaceForAgent(t)
+		inv, root := clitest.New(t, "cp", "a.txt", "b.txt")
+		clitest.SetupConfig(t, client, root)
+		err := inv.Run()
+		require.ErrorContains(t, err, "exactly one of the source and destination must be in a workspace")
+	})
+}
@@ -30,6 +30,7 @@ func (r *RootCmd) create() *serpent.Command {
 		parameterFlags     workspaceParameterFlags
 		autoUpdates        string
 		copyParametersFrom string
+		cloneFrom          string
 		// Organization context is only required if more than 1 template
 		// shares the same name across multiple organizations.
 		orgContext = NewOrganizationContext()
@@ -44,6 +45,10 @@ func (r *RootCmd) create() *serpent.Command {
 				Description: "Create a workspace for another user (if you have permission)",
 				Command:     "coder create <username>/<workspace_name>",
 			},
+			Example{
+				Description: "Clone a teammate's workspace, including its parameters and schedule",
+				Command:     "coder create <workspace_name> --from <username>/<workspace_name>",
+			},
 		),
 		Middleware: serpent.Chain(r.InitClient(client)),
 		Handler: func(inv *serpent.Invocation) error {
@@ -84,6 +89,20 @@ func (r *RootCmd) create() *serpent.Command {
 				return xerrors.Errorf("a workspace already exists named %q", workspaceName)
 			}
 
+			if cloneFrom != "" {
+				if workspaceOwner != codersdk.Me {
+					return xerrors.New("cloned workspaces are owned by you, so --from cannot be used to create a workspace for another user")
+				}
+				if templateName != "" || copyParametersFrom != "" {
+					return xerrors.New("--from cannot be used with --template or --copy-parameters-from")
+				}
+				cliBuildParameters, err := asWorkspaceBuildParameters(parameterFlags.richParameters)
+				if err != nil {
+					return xerrors.Errorf("can't parse given parameter values: %w", err)
+				}
+				return cloneWorkspace(inv, client, cloneFrom, workspaceName, cliBuildParameters)
+			}
+
 			var sourceWorkspace codersdk.Workspace
 			if copyParametersFrom != "" {
 				sourceWorkspaceOwner, sourceWorkspaceName, err := splitNamedWorkspace(copyParametersFrom)
@@ -339,6 +358,12 @@ func (r *RootCmd) create() *serpent.Command {
 			Description: "Specify the source workspace name to copy parameters from.",
 			Value:       serpent.StringOf(&copyParametersFrom),
 		},
+		serpent.Option{
+			Flag:        "from",
+			Env:         "CODER_WORKSPACE_FROM",
+			Description: "Clone the workspace from another workspace, which may be owned by another user (e.g. <username>/<workspace_name>). The template version, parameters, schedule and automatic updates setting are copied on the server.",
+			Value:       serpent.StringOf(&cloneFrom),
+		},
 		cliui.SkipPromptOption(),
 	)
 	cmd.Options = append(cmd.Options, parameterFlags.cliParameters()...)
@@ -347,6 +372,50 @@ func (r *RootCmd) create() *serpent.Command {
 	return cmd
 }
 
+// cloneWorkspace creates a workspace from the named source workspace. The
+// parameters only override the ones copied from the source.
+func cloneWorkspace(inv *serpent.Invocation, client *codersdk.Client, from, name string, parameters []codersdk.WorkspaceBuildParameter) error {
+	ctx := inv.Context()
+	sourceOwner, sourceName, err := splitNamedWorkspace(from)
+	if err != nil {
+		return err
+	}
+	source, err := client.WorkspaceByOwnerAndName(ctx, sourceOwner, sourceName, codersdk.WorkspaceOptions{})
+	if err != nil {
+		return xerrors.Errorf("get source workspace: %w", err)
+	}
+
+	_, _ = fmt.Fprintf(inv.Stdout, "Coder will clone the %s workspace, using the same template %q.\n", cliui.Keyword(source.FullName()), source.TemplateName)
+	_, err = cliui.Prompt(inv, cliui.PromptOptions{
+		Text:      "Confirm create?",
+		IsConfirm: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	workspace, err := client.CloneWorkspace(ctx, source.ID, codersdk.CloneWorkspaceRequest{
+		Name:                name,
+		RichParameterValues: parameters,
+	})
+	if err != nil {
+		return xerrors.Errorf("clone workspace: %w", err)
+	}
+
+	err = cliui.WorkspaceBuild(ctx, inv.Stdout, client, workspace.LatestBuild.ID)
+	if err != nil {
+		return xerrors.Errorf("watch build: %w", err)
+	}
+
+	_, _ = fmt.Fprintf(
+		inv.Stdout,
+		"\nThe %s workspace has been created at %s!\n",
+		cliui.Keyword(workspace.Name),
+		cliui.Timestamp(time.Now()),
+	)
+	return nil
+}
+
 type prepWorkspaceBuildArgs struct {
 	Action            WorkspaceCLIAction
 	TemplateVersionID uuid.UUID
@@ -362,6 +431,11 @@ type prepWorkspaceBuildArgs struct {
 	RichParameters        []codersdk.WorkspaceBuildParameter
 	RichParameterFile     string
 	RichParameterDefaults []codersdk.WorkspaceBuildParameter
+
+	// PreviewWorkspaceID plans the dry-run against the state of the
+	// workspace, and shows the changes to its resources instead of the
+	// resources.
+	PreviewWorkspaceID uuid.UUID
 }
 
 // prepWorkspaceBuild will ensure a workspace build will succeed on the latest template version.
@@ -413,6 +487,7 @@ func prepWorkspaceBuild(inv *serpent.Invocation, client *codersdk.Client, args p
 	// Run a dry-run with the given parameters to check correctness
 	dryRun, err := client.CreateTemplateVersionDryRun(inv.Context(), templateVersion.ID, codersdk.CreateTemplateVersionDryRunRequest{
 		WorkspaceName:       args.NewWorkspaceName,
+		WorkspaceID:         args.PreviewWorkspaceID,
 		RichParameterValues: buildParameters,
 	})
 	if err != nil {
@@ -438,6 +513,15 @@ func prepWorkspaceBuild(inv *serpent.Invocation, client *codersdk.Client, args p
 		return nil, xerrors.Errorf("dry-run workspace: %w", err)
 	}
 
+	if args.PreviewWorkspaceID != uuid.Nil {
+		changes, err := client.TemplateVersionDryRunResourceChanges(inv.Context(), templateVersion.ID, dryRun.ID)
+		if err != nil {
+			return nil, xerrors.Errorf("get workspace dry-run resource changes: %w", err)
+		}
+		cliui.ResourceChanges(inv.Stdout, changes)
+		return buildParameters, nil
+	}
+
 	resources, err := client.TemplateVersionDryRunResources(inv.Context(), templateVersion.ID, dryRun.ID)
 	if err != nil {
 		return nil, xerrors.Errorf("get workspace dry-run resources: %w", err)
@@ -596,6 +596,57 @@ func TestCreateWithRichParameters(t *testing.T) {
 		require.Contains(t, buildParameters, codersdk.WorkspaceBuildParameter{Name: secondParameterName, Value: secondParameterValue})
 		require.Contains(t, buildParameters, codersdk.WorkspaceBuildParameter{Name: immutableParameterName, Value: immutableParameterValue})
 	})
+
+	t.Run("CloneFromOtherUser", func(t *testing.T) {
+		t.Parallel()
+
+		client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+		owner := coderdtest.CreateFirstUser(t, client)
+		member, memberUser := coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+		version := coderdtest.CreateTemplateVersion(t, client, owner.OrganizationID, echoResponses)
+		coderdtest.AwaitTemplateVersionJobCompleted(t, client, version.ID)
+
+		template := coderdtest.CreateTemplate(t, client, owner.OrganizationID, version.ID)
+
+		// Firstly, the member creates a workspace using template with parameters.
+		inv, root := clitest.New(t, "create", "my-workspace", "--template", template.Name, "-y",
+			"--start-at", "9:30AM Mon-Fri Europe/Dublin",
+			"--parameter", fmt.Sprintf("%s=%s", firstParameterName, firstParameterValue),
+			"--parameter", fmt.Sprintf("%s=%s", secondParameterName, secondParameterValue),
+			"--parameter", fmt.Sprintf("%s=%s", immutableParameterName, immutableParameterValue))
+		clitest.SetupConfig(t, member, root)
+		pty := ptytest.New(t).Attach(inv)
+		inv.Stdout = pty.Output()
+		inv.Stderr = pty.Output()
+		err := inv.Run()
+		require.NoError(t, err, "can't create first workspace")
+
+		// Secondly, the owner clones it, overriding a parameter.
+		const otherWorkspace = "other-workspace"
+
+		inv, root = clitest.New(t, "create", otherWorkspace, "--from", memberUser.Username+"/my-workspace", "-y",
+			"--parameter", fmt.Sprintf("%s=%s", firstParameterName, "overridden"))
+		clitest.SetupConfig(t, client, root)
+		pty = ptytest.New(t).Attach(inv)
+		inv.Stdout = pty.Output()
+		inv.Stderr = pty.Output()
+		err = inv.Run()
+		require.NoError(t, err, "can't clone the source workspace")
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+		source, err := client.WorkspaceByOwnerAndName(ctx, memberUser.Username, "my-workspace", codersdk.WorkspaceOptions{})
+		require.NoError(t, err)
+		clone, err := client.WorkspaceByOwnerAndName(ctx, codersdk.Me, otherWorkspace, codersdk.WorkspaceOptions{})
+		require.NoError(t, err)
+		require.Equal(t, source.AutostartSchedule, clone.AutostartSchedule)
+
+		buildParameters, err := client.WorkspaceBuildParameters(ctx, clone.LatestBuild.ID)
+		require.NoError(t, err)
+		require.Len(t, buildParameters, 3)
+		require.Contains(t, buildParameters, codersdk.WorkspaceBuildParameter{Name: firstParameterName, Value: "overridden"})
+		require.Contains(t, buildParameters, codersdk.WorkspaceBuildParameter{Name: secondParameterName, Value: secondParameterValue})
+		require.Contains(t, buildParameters, codersdk.WorkspaceBuildParameter{Name: immutableParameterName, Value: immutableParameterValue})
+	})
 }
 
 func TestCreateValidateRichParameters(t *testing.T) {
@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/cli/cliui"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/serpent"
+)
+
+func (r *RootCmd) execCommand() *serpent.Command {
+	var (
+		dir     string
+		timeout time.Duration
+	)
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Annotations: workspaceCommand,
+		Use:         "exec <workspace> -- <command>",
+		Short:       "Run a command in a workspace without a terminal",
+		Long: "The command is run by the shell of the workspace user, like commands run over SSH, and its output is " +
+			"printed once it exits. The exit code of the command is the exit code of this command.\n\n" + FormatExamples(
+			Example{
+				Description: "Check the disk usage of a workspace",
+				Command:     "coder exec my-workspace -- df -h",
+			},
+			Example{
+				Description: "Run a script in a directory of the main agent, killing it after a minute",
+				Command:     "coder exec my-workspace.main --dir ~/project --timeout 1m -- make test",
+			},
+		),
+		Middleware: serpent.Chain(
+			serpent.RequireRangeArgs(2, -1),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			ctx := inv.Context()
+
+			_, workspaceAgent, err := getWorkspaceAndAgent(ctx, inv, client, false, inv.Args[0])
+			if err != nil {
+				return err
+			}
+			res, err := client.WorkspaceAgentExec(ctx, workspaceAgent.ID, codersdk.WorkspaceAgentExecRequest{
+				// Like SSH, the arguments are joined and interpreted by the
+				// shell.
+				Command:       strings.Join(inv.Args[1:], " "),
+				Dir:           dir,
+				TimeoutMillis: timeout.Milliseconds(),
+			})
+			if err != nil {
+				return xerrors.Errorf("run command: %w", err)
+			}
+
+			_, _ = fmt.Fprint(inv.Stdout, res.Stdout)
+			_, _ = fmt.Fprint(inv.Stderr, res.Stderr)
+			if res.Truncated {
+				cliui.Warn(inv.Stderr, "The output of the command was truncated.")
+			}
+			if res.TimedOut {
+				return ExitError(res.ExitCode, xerrors.Errorf("the command timed out after %s", timeout))
+			}
+			if res.ExitCode != 0 {
+				return ExitError(res.ExitCode, nil)
+			}
+			return nil
+		},
+	}
+	cmd.Options = serpent.OptionSet{
+		{
+			Flag:        "dir",
+			Description: "The working directory of the command. Relative paths are relative to the working directory of the agent.",
+			Value:       serpent.StringOf(&dir),
+		},
+		{
+			Flag:        "timeout",
+			Description: "Kill the command if it runs longer than this. Defaults to no timeout.",
+			Value:       serpent.DurationOf(&timeout),
+		},
+	}
+	return cmd
+}
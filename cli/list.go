@@ -78,6 +78,7 @@ func workspaceListRowFromWorkspace(now time.Time, workspace codersdk.Workspace)
 func (r *RootCmd) list() *serpent.Command {
 	var (
 		filter    cliui.WorkspaceFilter
+		saved     string
 		formatter = cliui.NewOutputFormatter(
 			cliui.TableFormat(
 				[]workspaceListRow{},
@@ -101,13 +102,45 @@ func (r *RootCmd) list() *serpent.Command {
 		Annotations: workspaceCommand,
 		Use:         "list",
 		Short:       "List workspaces",
-		Aliases:     []string{"ls"},
+		Long: FormatExamples(
+			Example{
+				Description: "List running workspaces on a template version that were started by autostart",
+				Command:     `coder list --search "template:docker template_version:v2 status:running build_reason:autostart"`,
+			},
+			Example{
+				Description: "List workspaces with a disconnected agent, excluding your own",
+				Command:     `coder list --search "agent_status:disconnected -owner:me"`,
+			},
+			Example{
+				Description: "List workspaces whose latest build failed or was canceled",
+				Command:     `coder list --search "owner:me (status:failed OR status:canceled)"`,
+			},
+			Example{
+				Description: "List workspaces matching a saved search",
+				Command:     "coder list --saved stale-ws",
+			},
+		),
+		Aliases: []string{"ls"},
 		Middleware: serpent.Chain(
 			serpent.RequireNArgs(0),
 			r.InitClient(client),
 		),
 		Handler: func(inv *serpent.Invocation) error {
-			res, err := queryConvertWorkspaces(inv.Context(), client, filter.Filter(), workspaceListRowFromWorkspace)
+			f := filter.Filter()
+			if saved != "" {
+				query, err := savedSearchQuery(inv.Context(), client, codersdk.SavedSearchResourceTypeWorkspace, saved)
+				if err != nil {
+					return err
+				}
+				// An explicit --search narrows the saved search down further,
+				// rather than the default query replacing it.
+				if inv.ParsedFlags().Changed("search") && f.FilterQuery != "" {
+					query += " " + f.FilterQuery
+				}
+				f.FilterQuery = query
+			}
+
+			res, err := queryConvertWorkspaces(inv.Context(), client, f, workspaceListRowFromWorkspace)
 			if err != nil {
 				return err
 			}
@@ -129,6 +162,11 @@ func (r *RootCmd) list() *serpent.Command {
 			return err
 		},
 	}
+	cmd.Options = append(cmd.Options, serpent.Option{
+		Flag:        "saved",
+		Description: "List workspaces matching a saved search, combined with --search if it is given. See \"coder saved-searches\".",
+		Value:       serpent.StringOf(&saved),
+	})
 	filter.AttachOptions(&cmd.Options)
 	formatter.AttachOptions(&cmd.Options)
 	return cmd
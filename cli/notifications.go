@@ -2,11 +2,17 @@ package cli
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"golang.org/x/xerrors"
 
 	"github.com/coder/serpent"
 
+	"github.com/coder/coder/v2/cli/cliui"
+	"github.com/coder/coder/v2/coderd/util/ptr"
+	"github.com/coder/coder/v2/coderd/util/tz"
 	"github.com/coder/coder/v2/codersdk"
 )
 
@@ -14,7 +20,8 @@ func (r *RootCmd) notifications() *serpent.Command {
 	cmd := &serpent.Command{
 		Use:   "notifications",
 		Short: "Manage Coder notifications",
-		Long: "Administrators can use these commands to change notification settings.\n" + FormatExamples(
+		Long: "Administrators can use these commands to change notification settings, and users can choose how and when " +
+			"they are notified.\n" + FormatExamples(
 			Example{
 				Description: "Pause Coder notifications. Administrators can temporarily stop notifiers from dispatching messages in case of the target outage (for example: unavailable SMTP server or Webhook not responding).",
 				Command:     "coder notifications pause",
@@ -23,6 +30,18 @@ func (r *RootCmd) notifications() *serpent.Command {
 				Description: "Resume Coder notifications",
 				Command:     "coder notifications resume",
 			},
+			Example{
+				Description: "Receive \"Workspace Deleted\" notifications by webhook instead of the default method",
+				Command:     "coder notifications set-method \"Workspace Deleted\" webhook",
+			},
+			Example{
+				Description: "Hold non-urgent notifications overnight",
+				Command:     "coder notifications quiet-hours set 22:00 07:00 --timezone Europe/London",
+			},
+			Example{
+				Description: "List your unread inbox notifications",
+				Command:     "coder notifications inbox --unread",
+			},
 		),
 		Aliases: []string{"notification"},
 		Handler: func(inv *serpent.Invocation) error {
@@ -31,6 +50,10 @@ func (r *RootCmd) notifications() *serpent.Command {
 		Children: []*serpent.Command{
 			r.pauseNotifications(),
 			r.resumeNotifications(),
+			r.notificationPreferences(),
+			r.setNotificationMethod(),
+			r.notificationQuietHours(),
+			r.notificationInbox(),
 		},
 	}
 	return cmd
@@ -83,3 +106,428 @@ func (r *RootCmd) resumeNotifications() *serpent.Command {
 	}
 	return cmd
 }
+
+type notificationPreferenceRow struct {
+	ID       uuid.UUID `json:"id" table:"id"`
+	Name     string    `json:"name" table:"name,default_sort"`
+	Group    string    `json:"group" table:"group"`
+	Disabled bool      `json:"disabled" table:"disabled"`
+	Method   string    `json:"method" table:"method"`
+}
+
+func (r *RootCmd) notificationPreferences() *serpent.Command {
+	formatter := cliui.NewOutputFormatter(
+		cliui.TableFormat([]notificationPreferenceRow{}, []string{"name", "group", "disabled", "method"}),
+		cliui.JSONFormat(),
+	)
+
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:   "preferences",
+		Short: "List your notification preferences",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(0),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			ctx := inv.Context()
+
+			me, err := client.User(ctx, codersdk.Me)
+			if err != nil {
+				return xerrors.Errorf("get current user: %w", err)
+			}
+			templates, err := client.GetSystemNotificationTemplates(ctx)
+			if err != nil {
+				return xerrors.Errorf("get notification templates: %w", err)
+			}
+			prefs, err := client.GetUserNotificationPreferences(ctx, me.ID)
+			if err != nil {
+				return xerrors.Errorf("get notification preferences: %w", err)
+			}
+
+			byTemplate := make(map[uuid.UUID]codersdk.NotificationPreference, len(prefs))
+			for _, pref := range prefs {
+				byTemplate[pref.NotificationTemplateID] = pref
+			}
+
+			rows := make([]notificationPreferenceRow, 0, len(templates))
+			for _, tmpl := range templates {
+				pref := byTemplate[tmpl.ID]
+				method := pref.Method
+				if method == "" {
+					method = "default"
+				}
+				rows = append(rows, notificationPreferenceRow{
+					ID:       tmpl.ID,
+					Name:     tmpl.Name,
+					Group:    tmpl.Group,
+					Disabled: pref.Disabled,
+					Method:   method,
+				})
+			}
+
+			out, err := formatter.Format(ctx, rows)
+			if err != nil {
+				return err
+			}
+
+			_, err = fmt.Fprintln(inv.Stdout, out)
+			return err
+		},
+	}
+
+	formatter.AttachOptions(&cmd.Options)
+	return cmd
+}
+
+func (r *RootCmd) setNotificationMethod() *serpent.Command {
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:   "set-method <template> <method>",
+		Short: "Choose how you receive a notification",
+		Long: "Set the method by which you receive the given notification template, identified by name or ID. " +
+			"Use \"default\" as the method to revert to the method chosen by your administrator.",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(2),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			ctx := inv.Context()
+
+			me, err := client.User(ctx, codersdk.Me)
+			if err != nil {
+				return xerrors.Errorf("get current user: %w", err)
+			}
+			templates, err := client.GetSystemNotificationTemplates(ctx)
+			if err != nil {
+				return xerrors.Errorf("get notification templates: %w", err)
+			}
+
+			var tmpl *codersdk.NotificationTemplate
+			for i, t := range templates {
+				if strings.EqualFold(t.Name, inv.Args[0]) || t.ID.String() == inv.Args[0] {
+					tmpl = &templates[i]
+					break
+				}
+			}
+			if tmpl == nil {
+				return xerrors.Errorf("notification template %q not found", inv.Args[0])
+			}
+
+			method := inv.Args[1]
+			if method == "default" {
+				method = ""
+			}
+
+			_, err = client.UpdateUserNotificationPreferences(ctx, me.ID, codersdk.UpdateUserNotificationPreferences{
+				TemplateMethodMap: map[string]string{tmpl.ID.String(): method},
+			})
+			if err != nil {
+				return xerrors.Errorf("update notification preferences: %w", err)
+			}
+
+			if method == "" {
+				_, _ = fmt.Fprintf(inv.Stderr, "%q notifications will be sent using the default method.\n", tmpl.Name)
+				return nil
+			}
+			_, _ = fmt.Fprintf(inv.Stderr, "%q notifications will be sent using %s.\n", tmpl.Name, method)
+			return nil
+		},
+	}
+	return cmd
+}
+
+func (r *RootCmd) notificationQuietHours() *serpent.Command {
+	cmd := &serpent.Command{
+		Use:   "quiet-hours",
+		Short: "Manage your notification quiet hours",
+		Long: "Non-urgent notifications, such as workspace auto-updates, are held during your quiet hours and " +
+			"delivered once they end.",
+		Handler: func(inv *serpent.Invocation) error {
+			return inv.Command.HelpHandler(inv)
+		},
+		Children: []*serpent.Command{
+			r.showNotificationQuietHours(),
+			r.setNotificationQuietHours(),
+			r.clearNotificationQuietHours(),
+		},
+	}
+	return cmd
+}
+
+func (r *RootCmd) showNotificationQuietHours() *serpent.Command {
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:   "show",
+		Short: "Show your notification quiet hours",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(0),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			ctx := inv.Context()
+
+			me, err := client.User(ctx, codersdk.Me)
+			if err != nil {
+				return xerrors.Errorf("get current user: %w", err)
+			}
+			qh, err := client.GetUserNotificationQuietHours(ctx, me.ID)
+			if err != nil {
+				return xerrors.Errorf("get notification quiet hours: %w", err)
+			}
+
+			if !qh.Enabled {
+				_, _ = fmt.Fprintln(inv.Stdout, "Quiet hours are not set.")
+				return nil
+			}
+			_, _ = fmt.Fprintf(inv.Stdout, "Quiet hours are from %s to %s (%s).\n", qh.Start, qh.End, qh.Timezone)
+			return nil
+		},
+	}
+	return cmd
+}
+
+func (r *RootCmd) setNotificationQuietHours() *serpent.Command {
+	var timezone string
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:   "set <start> <end>",
+		Short: "Set your notification quiet hours",
+		Long: "Start and end are times of day in 24-hour HH:MM format. If end is before start, quiet hours span " +
+			"midnight.",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(2),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			ctx := inv.Context()
+
+			if timezone == "" {
+				loc, err := tz.TimezoneIANA()
+				if err != nil {
+					return xerrors.Errorf("unable to determine local timezone, specify one with --timezone: %w", err)
+				}
+				timezone = loc.String()
+			}
+
+			me, err := client.User(ctx, codersdk.Me)
+			if err != nil {
+				return xerrors.Errorf("get current user: %w", err)
+			}
+			qh, err := client.UpdateUserNotificationQuietHours(ctx, me.ID, codersdk.NotificationQuietHours{
+				Enabled:  true,
+				Start:    inv.Args[0],
+				End:      inv.Args[1],
+				Timezone: timezone,
+			})
+			if err != nil {
+				return xerrors.Errorf("set notification quiet hours: %w", err)
+			}
+
+			_, _ = fmt.Fprintf(inv.Stderr, "Quiet hours are now from %s to %s (%s).\n", qh.Start, qh.End, qh.Timezone)
+			return nil
+		},
+	}
+
+	cmd.Options = serpent.OptionSet{
+		{
+			Flag:        "timezone",
+			Env:         "CODER_NOTIFICATIONS_QUIET_HOURS_TIMEZONE",
+			Description: "IANA timezone in which quiet hours are observed. Defaults to the local timezone.",
+			Value:       serpent.StringOf(&timezone),
+		},
+	}
+	return cmd
+}
+
+func (r *RootCmd) clearNotificationQuietHours() *serpent.Command {
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:   "clear",
+		Short: "Remove your notification quiet hours",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(0),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			ctx := inv.Context()
+
+			me, err := client.User(ctx, codersdk.Me)
+			if err != nil {
+				return xerrors.Errorf("get current user: %w", err)
+			}
+			_, err = client.UpdateUserNotificationQuietHours(ctx, me.ID, codersdk.NotificationQuietHours{Enabled: false})
+			if err != nil {
+				return xerrors.Errorf("clear notification quiet hours: %w", err)
+			}
+
+			_, _ = fmt.Fprintln(inv.Stderr, "Quiet hours have been removed.")
+			return nil
+		},
+	}
+	return cmd
+}
+
+type inboxNotificationRow struct {
+	ID        uuid.UUID `json:"id" table:"id,nosort"`
+	Title     string    `json:"title" table:"title"`
+	Read      bool      `json:"read" table:"read"`
+	CreatedAt time.Time `json:"created_at" table:"created at"`
+}
+
+func (r *RootCmd) notificationInbox() *serpent.Command {
+	var (
+		unread          bool
+		includeArchived bool
+		limit           int64
+	)
+	formatter := cliui.NewOutputFormatter(
+		cliui.ChangeFormatterData(
+			cliui.TableFormat([]inboxNotificationRow{}, []string{"id", "title", "read", "created at"}),
+			func(data any) (any, error) {
+				notifs, ok := data.([]codersdk.InboxNotification)
+				if !ok {
+					return nil, xerrors.Errorf("expected type %T, got %T", notifs, data)
+				}
+				rows := make([]inboxNotificationRow, 0, len(notifs))
+				for _, n := range notifs {
+					rows = append(rows, inboxNotificationRow{
+						ID:        n.ID,
+						Title:     n.Title,
+						Read:      n.ReadAt != nil,
+						CreatedAt: n.CreatedAt,
+					})
+				}
+				return rows, nil
+			},
+		),
+		cliui.JSONFormat(),
+	)
+
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:   "inbox",
+		Short: "List the notifications in your inbox",
+		Long: "Notifications which are sent using the \"inbox\" method are kept in your inbox until you archive " +
+			"them. They are listed newest first.",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(0),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			ctx := inv.Context()
+
+			me, err := client.User(ctx, codersdk.Me)
+			if err != nil {
+				return xerrors.Errorf("get current user: %w", err)
+			}
+
+			req := codersdk.ListInboxNotificationsRequest{
+				IncludeArchived: includeArchived,
+				Pagination:      codersdk.Pagination{Limit: int(limit)},
+			}
+			if unread {
+				req.ReadStatus = codersdk.InboxNotificationReadStatusUnread
+			}
+			resp, err := client.ListInboxNotifications(ctx, me.ID, req)
+			if err != nil {
+				return xerrors.Errorf("list inbox notifications: %w", err)
+			}
+
+			if len(resp.Notifications) == 0 {
+				_, _ = fmt.Fprintln(inv.Stderr, "No notifications found.")
+				return nil
+			}
+
+			out, err := formatter.Format(ctx, resp.Notifications)
+			if err != nil {
+				return err
+			}
+
+			_, err = fmt.Fprintln(inv.Stdout, out)
+			return err
+		},
+		Children: []*serpent.Command{
+			r.readInboxNotification(),
+		},
+	}
+
+	cmd.Options = serpent.OptionSet{
+		{
+			Flag:        "unread",
+			Description: "Only list unread notifications.",
+			Value:       serpent.BoolOf(&unread),
+		},
+		{
+			Flag:        "archived",
+			Description: "Include archived notifications.",
+			Value:       serpent.BoolOf(&includeArchived),
+		},
+		{
+			Flag:          "limit",
+			FlagShorthand: "n",
+			Description:   "The maximum number of notifications to list.",
+			Default:       "25",
+			Value:         serpent.Int64Of(&limit),
+		},
+	}
+	formatter.AttachOptions(&cmd.Options)
+	return cmd
+}
+
+func (r *RootCmd) readInboxNotification() *serpent.Command {
+	var all bool
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:   "read [id]",
+		Short: "Mark inbox notifications as read",
+		Middleware: serpent.Chain(
+			serpent.RequireRangeArgs(0, 1),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			ctx := inv.Context()
+
+			if all == (len(inv.Args) == 1) {
+				return xerrors.New("specify either a notification ID or --all")
+			}
+
+			me, err := client.User(ctx, codersdk.Me)
+			if err != nil {
+				return xerrors.Errorf("get current user: %w", err)
+			}
+
+			if all {
+				resp, err := client.MarkAllInboxNotificationsAsRead(ctx, me.ID)
+				if err != nil {
+					return xerrors.Errorf("mark inbox notifications as read: %w", err)
+				}
+				_, _ = fmt.Fprintf(inv.Stderr, "Marked %d notification(s) as read.\n", resp.Updated)
+				return nil
+			}
+
+			id, err := uuid.Parse(inv.Args[0])
+			if err != nil {
+				return xerrors.Errorf("invalid notification ID %q: %w", inv.Args[0], err)
+			}
+			_, err = client.UpdateInboxNotification(ctx, me.ID, id, codersdk.UpdateInboxNotificationRequest{
+				Read: ptr.Ref(true),
+			})
+			if err != nil {
+				return xerrors.Errorf("mark inbox notification as read: %w", err)
+			}
+
+			_, _ = fmt.Fprintln(inv.Stderr, "Notification marked as read.")
+			return nil
+		},
+	}
+
+	cmd.Options = serpent.OptionSet{
+		{
+			Flag:        "all",
+			Description: "Mark all unread notifications as read.",
+			Value:       serpent.BoolOf(&all),
+		},
+	}
+	return cmd
+}
@@ -6,12 +6,18 @@ import (
 	"encoding/json"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/coder/coder/v2/cli/clitest"
 	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbgen"
+	"github.com/coder/coder/v2/coderd/database/dbtestutil"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
+	"github.com/coder/coder/v2/coderd/notifications"
 	"github.com/coder/coder/v2/codersdk"
 	"github.com/coder/coder/v2/testutil"
 )
@@ -110,3 +116,118 @@ func TestPauseNotifications_RegularUser(t *testing.T) {
 	require.NoError(t, err)
 	require.False(t, settings.NotifierPaused) // still running
 }
+
+func TestNotificationQuietHours(t *testing.T) {
+	t.Parallel()
+
+	// given
+	ownerClient := coderdtest.New(t, createOpts(t))
+	owner := coderdtest.CreateFirstUser(t, ownerClient)
+	memberClient, member := coderdtest.CreateAnotherUser(t, ownerClient, owner.OrganizationID)
+
+	// when
+	inv, root := clitest.New(t, "notifications", "quiet-hours", "set", "22:00", "07:00", "--timezone", "Europe/London")
+	clitest.SetupConfig(t, memberClient, root)
+	err := inv.Run()
+	require.NoError(t, err)
+
+	// then
+	ctx := testutil.Context(t, testutil.WaitShort)
+	qh, err := memberClient.GetUserNotificationQuietHours(ctx, member.ID)
+	require.NoError(t, err)
+	require.Equal(t, codersdk.NotificationQuietHours{
+		Enabled:  true,
+		Start:    "22:00",
+		End:      "07:00",
+		Timezone: "Europe/London",
+	}, qh)
+
+	inv, root = clitest.New(t, "notifications", "quiet-hours", "show")
+	clitest.SetupConfig(t, memberClient, root)
+	var buf bytes.Buffer
+	inv.Stdout = &buf
+	err = inv.Run()
+	require.NoError(t, err)
+	require.Contains(t, buf.String(), "from 22:00 to 07:00 (Europe/London)")
+
+	// when
+	inv, root = clitest.New(t, "notifications", "quiet-hours", "clear")
+	clitest.SetupConfig(t, memberClient, root)
+	err = inv.Run()
+	require.NoError(t, err)
+
+	// then
+	qh, err = memberClient.GetUserNotificationQuietHours(ctx, member.ID)
+	require.NoError(t, err)
+	require.False(t, qh.Enabled)
+}
+
+func TestNotificationInbox(t *testing.T) {
+	t.Parallel()
+
+	// given
+	db, ps := dbtestutil.NewDB(t)
+	opts := createOpts(t)
+	opts.Database = db
+	opts.Pubsub = ps
+	ownerClient := coderdtest.New(t, opts)
+	owner := coderdtest.CreateFirstUser(t, ownerClient)
+	memberClient, member := coderdtest.CreateAnotherUser(t, ownerClient, owner.OrganizationID)
+
+	now := dbtime.Now()
+	older := dbgen.InboxNotification(t, db, database.InboxNotification{
+		UserID:     member.ID,
+		TemplateID: notifications.TemplateWorkspaceDeleted,
+		Title:      "Workspace \"alpha\" deleted",
+		CreatedAt:  now.Add(-time.Minute),
+	})
+	newer := dbgen.InboxNotification(t, db, database.InboxNotification{
+		UserID:     member.ID,
+		TemplateID: notifications.TemplateWorkspaceDeleted,
+		Title:      "Workspace \"beta\" deleted",
+		CreatedAt:  now,
+	})
+
+	// when
+	inv, root := clitest.New(t, "notifications", "inbox", "--output", "json")
+	clitest.SetupConfig(t, memberClient, root)
+	var buf bytes.Buffer
+	inv.Stdout = &buf
+	err := inv.Run()
+	require.NoError(t, err)
+
+	// then
+	var listed []codersdk.InboxNotification
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &listed))
+	require.Len(t, listed, 2)
+	require.Equal(t, newer.ID, listed[0].ID)
+	require.Equal(t, older.ID, listed[1].ID)
+
+	// when
+	inv, root = clitest.New(t, "notifications", "inbox", "read", older.ID.String())
+	clitest.SetupConfig(t, memberClient, root)
+	err = inv.Run()
+	require.NoError(t, err)
+
+	// then
+	inv, root = clitest.New(t, "notifications", "inbox", "--unread")
+	clitest.SetupConfig(t, memberClient, root)
+	buf.Reset()
+	inv.Stdout = &buf
+	err = inv.Run()
+	require.NoError(t, err)
+	require.Contains(t, buf.String(), newer.ID.String())
+	require.NotContains(t, buf.String(), older.ID.String())
+
+	// when
+	inv, root = clitest.New(t, "notifications", "inbox", "read", "--all")
+	clitest.SetupConfig(t, memberClient, root)
+	err = inv.Run()
+	require.NoError(t, err)
+
+	// then
+	ctx := testutil.Context(t, testutil.WaitShort)
+	resp, err := memberClient.ListInboxNotifications(ctx, member.ID, codersdk.ListInboxNotificationsRequest{})
+	require.NoError(t, err)
+	require.Zero(t, resp.UnreadCount)
+}
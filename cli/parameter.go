@@ -131,6 +131,9 @@ func parseParameterMapFile(parameterFile string) (map[string]string, error) {
 // buildFlags contains options relating to troubleshooting provisioner jobs.
 type buildFlags struct {
 	provisionerLogDebug bool
+	// preview plans the build against the state of the workspace and shows
+	// the changes to its resources. It's only set by `coder update`.
+	preview bool
 }
 
 func (bf *buildFlags) cliOptions() []serpent.Option {
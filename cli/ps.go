@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/cli/cliui"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/serpent"
+)
+
+type processRow struct {
+	PID       int32     `json:"pid" table:"pid,nosort"`
+	User      string    `json:"user" table:"user"`
+	CPU       string    `json:"cpu" table:"cpu"`
+	Memory    string    `json:"memory" table:"memory"`
+	StartedAt time.Time `json:"started_at" table:"started at"`
+	Command   string    `json:"command" table:"command"`
+}
+
+// processRows flattens a process tree into rows, indenting the commands of
+// child processes like `ps --forest`.
+func processRows(procs []codersdk.WorkspaceAgentProcess, depth int) []processRow {
+	var rows []processRow
+	for _, proc := range procs {
+		command := proc.Command
+		if command == "" {
+			// Kernel threads have no command line.
+			command = "[" + proc.Name + "]"
+		}
+		if depth > 0 {
+			command = strings.Repeat("  ", depth-1) + `\_ ` + command
+		}
+		rows = append(rows, processRow{
+			PID:       proc.PID,
+			User:      proc.User,
+			CPU:       fmt.Sprintf("%.1f%%", proc.CPUPercent),
+			Memory:    humanize.IBytes(uint64(proc.MemoryBytes)),
+			StartedAt: proc.StartedAt,
+			Command:   command,
+		})
+		rows = append(rows, processRows(proc.Children, depth+1)...)
+	}
+	return rows
+}
+
+func (r *RootCmd) ps() *serpent.Command {
+	formatter := cliui.NewOutputFormatter(
+		cliui.ChangeFormatterData(
+			cliui.TableFormat([]processRow{}, []string{"pid", "user", "cpu", "memory", "command"}),
+			func(data any) (any, error) {
+				res, ok := data.(codersdk.WorkspaceAgentProcessesResponse)
+				if !ok {
+					return nil, xerrors.Errorf("expected type %T, got %T", res, data)
+				}
+				return processRows(res.Processes, 0), nil
+			},
+		),
+		cliui.JSONFormat(),
+	)
+
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Annotations: workspaceCommand,
+		Use:         "ps <workspace>",
+		Short:       "List the processes running in a workspace",
+		Long: "CPU usage is averaged over the lifetime of each process. Listing processes is only supported " +
+			"by agents running on Linux.",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(1),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			ctx := inv.Context()
+
+			_, workspaceAgent, err := getWorkspaceAndAgent(ctx, inv, client, false, inv.Args[0])
+			if err != nil {
+				return err
+			}
+			res, err := client.WorkspaceAgentProcesses(ctx, workspaceAgent.ID)
+			if err != nil {
+				return xerrors.Errorf("list processes: %w", err)
+			}
+
+			out, err := formatter.Format(ctx, res)
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(inv.Stdout, out)
+			return err
+		},
+	}
+	formatter.AttachOptions(&cmd.Options)
+	return cmd
+}
@@ -93,6 +93,9 @@ func (r *RootCmd) CoreSubcommands() []*serpent.Command {
 		r.portForward(),
 		r.publickey(),
 		r.resetPassword(),
+		r.savedSearches(),
+		r.sessions(),
+		r.snapshot(),
 		r.state(),
 		r.templates(),
 		r.tokens(),
@@ -102,15 +105,19 @@ func (r *RootCmd) CoreSubcommands() []*serpent.Command {
 		// Workspace Commands
 		r.autoupdate(),
 		r.configSSH(),
+		r.cp(),
 		r.create(),
 		r.deleteWorkspace(),
+		r.execCommand(),
 		r.favorite(),
 		r.list(),
 		r.open(),
 		r.ping(),
+		r.ps(),
 		r.rename(),
 		r.restart(),
 		r.schedules(),
+		r.scripts(),
 		r.show(),
 		r.speedtest(),
 		r.ssh(),
@@ -0,0 +1,311 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/cli/cliui"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/pretty"
+	"github.com/coder/serpent"
+)
+
+var savedSearchResourceTypes = []string{
+	string(codersdk.SavedSearchResourceTypeWorkspace),
+	string(codersdk.SavedSearchResourceTypeTemplate),
+	string(codersdk.SavedSearchResourceTypeUser),
+	string(codersdk.SavedSearchResourceTypeAuditLog),
+}
+
+func (r *RootCmd) savedSearches() *serpent.Command {
+	cmd := &serpent.Command{
+		Use:   "saved-searches",
+		Short: "Manage saved searches",
+		Long: "Saved searches are named search queries for workspaces, templates, users and audit logs, which can be shared with an organization or group.\n" + FormatExamples(
+			Example{
+				Description: "Save a search for dormant workspaces owned by others, and share it with your organization",
+				Command:     `coder saved-searches create stale-ws --query "dormant:true -owner:me" --share`,
+			},
+			Example{
+				Description: "List workspaces matching a saved search",
+				Command:     "coder list --saved stale-ws",
+			},
+			Example{
+				Description: "List your saved audit log searches, and those shared with you",
+				Command:     "coder saved-searches list --type audit_log",
+			},
+		),
+		Aliases: []string{"saved-search"},
+		Handler: func(inv *serpent.Invocation) error {
+			return inv.Command.HelpHandler(inv)
+		},
+		Children: []*serpent.Command{
+			r.createSavedSearch(),
+			r.listSavedSearches(),
+			r.deleteSavedSearch(),
+		},
+	}
+	return cmd
+}
+
+func (r *RootCmd) createSavedSearch() *serpent.Command {
+	var (
+		resourceType string
+		query        string
+		share        bool
+		group        string
+		orgContext   = NewOrganizationContext()
+	)
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:   "create <name>",
+		Short: "Save a search query",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(1),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			ctx := inv.Context()
+			req := codersdk.CreateSavedSearchRequest{
+				ResourceType: codersdk.SavedSearchResourceType(resourceType),
+				Name:         inv.Args[0],
+				Query:        query,
+			}
+			if share || group != "" {
+				org, err := orgContext.Selected(inv, client)
+				if err != nil {
+					return err
+				}
+				req.OrganizationID = &org.ID
+				if group != "" {
+					g, err := client.GroupByOrgAndName(ctx, org.ID, group)
+					if err != nil {
+						return xerrors.Errorf("get group %q: %w", group, err)
+					}
+					req.GroupID = &g.ID
+				}
+			}
+
+			search, err := client.CreateSavedSearch(ctx, codersdk.Me, req)
+			if err != nil {
+				return xerrors.Errorf("create saved search: %w", err)
+			}
+
+			_, _ = fmt.Fprintf(inv.Stdout, "Saved %s search %s.\n", search.ResourceType, pretty.Sprint(cliui.DefaultStyles.Keyword, search.Name))
+			return nil
+		},
+	}
+
+	cmd.Options = serpent.OptionSet{
+		{
+			Flag:        "type",
+			Description: "The type of resource the query searches.",
+			Default:     string(codersdk.SavedSearchResourceTypeWorkspace),
+			Value:       serpent.EnumOf(&resourceType, savedSearchResourceTypes...),
+		},
+		{
+			Flag:        "query",
+			Description: "The search query, in the syntax used by the resource's --search flag or search bar.",
+			Value:       serpent.StringOf(&query),
+		},
+		{
+			Flag:        "share",
+			Description: "Share the search with the members of the organization.",
+			Value:       serpent.BoolOf(&share),
+		},
+		{
+			Flag:        "group",
+			Description: "Share the search with the members of a group in the organization.",
+			Value:       serpent.StringOf(&group),
+		},
+	}
+	orgContext.AttachOptions(cmd)
+	return cmd
+}
+
+type savedSearchListRow struct {
+	// For JSON format:
+	codersdk.SavedSearch `table:"-"`
+
+	// For table format:
+	Name       string `json:"-" table:"name,default_sort"`
+	Type       string `json:"-" table:"type"`
+	Query      string `json:"-" table:"query"`
+	SharedWith string `json:"-" table:"shared with"`
+	Owner      string `json:"-" table:"owner"`
+}
+
+func savedSearchListRowFromSavedSearch(me uuid.UUID, search codersdk.SavedSearch) savedSearchListRow {
+	sharedWith := "-"
+	switch {
+	case search.GroupID != nil:
+		sharedWith = "group " + search.GroupID.String()
+	case search.OrganizationID != nil:
+		sharedWith = "organization " + search.OrganizationID.String()
+	}
+	owner := search.UserID.String()
+	if search.UserID == me {
+		owner = codersdk.Me
+	}
+	return savedSearchListRow{
+		SavedSearch: search,
+		Name:        search.Name,
+		Type:        string(search.ResourceType),
+		Query:       search.Query,
+		SharedWith:  sharedWith,
+		Owner:       owner,
+	}
+}
+
+func (r *RootCmd) listSavedSearches() *serpent.Command {
+	var (
+		resourceType string
+		formatter    = cliui.NewOutputFormatter(
+			cliui.TableFormat([]savedSearchListRow{}, []string{"name", "type", "query", "shared with", "owner"}),
+			cliui.JSONFormat(),
+		)
+	)
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List your saved searches, and those shared with you",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(0),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			ctx := inv.Context()
+			me, err := client.User(ctx, codersdk.Me)
+			if err != nil {
+				return xerrors.Errorf("get current user: %w", err)
+			}
+			searches, err := client.SavedSearches(ctx, codersdk.Me, codersdk.SavedSearchesRequest{
+				ResourceType: codersdk.SavedSearchResourceType(resourceType),
+			})
+			if err != nil {
+				return xerrors.Errorf("list saved searches: %w", err)
+			}
+
+			if len(searches) == 0 {
+				cliui.Infof(inv.Stderr, "No saved searches found.")
+				return nil
+			}
+
+			rows := make([]savedSearchListRow, 0, len(searches))
+			for _, search := range searches {
+				rows = append(rows, savedSearchListRowFromSavedSearch(me.ID, search))
+			}
+			out, err := formatter.Format(ctx, rows)
+			if err != nil {
+				return err
+			}
+
+			_, err = fmt.Fprintln(inv.Stdout, out)
+			return err
+		},
+	}
+
+	cmd.Options = serpent.OptionSet{
+		{
+			Flag:        "type",
+			Description: "Only list searches for this type of resource.",
+			Value:       serpent.EnumOf(&resourceType, savedSearchResourceTypes...),
+		},
+	}
+	formatter.AttachOptions(&cmd.Options)
+	return cmd
+}
+
+func (r *RootCmd) deleteSavedSearch() *serpent.Command {
+	var resourceType string
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:   "delete <name>",
+		Short: "Delete a saved search",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(1),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			ctx := inv.Context()
+			me, err := client.User(ctx, codersdk.Me)
+			if err != nil {
+				return xerrors.Errorf("get current user: %w", err)
+			}
+			searches, err := client.SavedSearches(ctx, codersdk.Me, codersdk.SavedSearchesRequest{
+				ResourceType: codersdk.SavedSearchResourceType(resourceType),
+			})
+			if err != nil {
+				return xerrors.Errorf("list saved searches: %w", err)
+			}
+			for _, search := range searches {
+				// Searches shared with the user can only be deleted by their owner.
+				if search.UserID != me.ID || search.Name != inv.Args[0] {
+					continue
+				}
+				err = client.DeleteSavedSearch(ctx, codersdk.Me, search.ID)
+				if err != nil {
+					return xerrors.Errorf("delete saved search: %w", err)
+				}
+				_, _ = fmt.Fprintf(inv.Stdout, "Deleted %s search %s.\n", search.ResourceType, pretty.Sprint(cliui.DefaultStyles.Keyword, search.Name))
+				return nil
+			}
+			return xerrors.Errorf("you have no saved %s search named %q", resourceType, inv.Args[0])
+		},
+	}
+
+	cmd.Options = serpent.OptionSet{
+		{
+			Flag:        "type",
+			Description: "The type of resource the search is for.",
+			Default:     string(codersdk.SavedSearchResourceTypeWorkspace),
+			Value:       serpent.EnumOf(&resourceType, savedSearchResourceTypes...),
+		},
+	}
+	return cmd
+}
+
+// savedSearchQuery returns the query of the named saved search. The user's own
+// search takes precedence over searches shared with them, and a name shared by
+// more than one other user must be disambiguated by saving a search of your
+// own.
+func savedSearchQuery(ctx context.Context, client *codersdk.Client, resourceType codersdk.SavedSearchResourceType, name string) (string, error) {
+	me, err := client.User(ctx, codersdk.Me)
+	if err != nil {
+		return "", xerrors.Errorf("get current user: %w", err)
+	}
+	searches, err := client.SavedSearches(ctx, codersdk.Me, codersdk.SavedSearchesRequest{
+		ResourceType: resourceType,
+	})
+	if err != nil {
+		return "", xerrors.Errorf("list saved searches: %w", err)
+	}
+
+	var shared []codersdk.SavedSearch
+	for _, search := range searches {
+		if search.Name != name {
+			continue
+		}
+		if search.UserID == me.ID {
+			return search.Query, nil
+		}
+		shared = append(shared, search)
+	}
+	switch len(shared) {
+	case 0:
+		return "", xerrors.Errorf("no saved %s search named %q", resourceType, name)
+	case 1:
+		return shared[0].Query, nil
+	default:
+		queries := make([]string, 0, len(shared))
+		for _, search := range shared {
+			queries = append(queries, fmt.Sprintf("%q", search.Query))
+		}
+		return "", xerrors.Errorf("%d saved %s searches named %q are shared with you (%s); save your own to choose one", len(shared), resourceType, name, strings.Join(queries, ", "))
+	}
+}
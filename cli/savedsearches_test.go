@@ -0,0 +1,135 @@
+package cli_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/cli/clitest"
+	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbfake"
+	"github.com/coder/coder/v2/coderd/util/ptr"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestSavedSearches(t *testing.T) {
+	t.Parallel()
+
+	t.Run("CreateListDelete", func(t *testing.T) {
+		t.Parallel()
+
+		client := coderdtest.New(t, nil)
+		owner := coderdtest.CreateFirstUser(t, client)
+		member, _ := coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		inv, root := clitest.New(t, "saved-searches", "create", "failed-logins", "--type", "audit_log", "--query", "action:login", "--share")
+		clitest.SetupConfig(t, member, root)
+		err := inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+
+		inv, root = clitest.New(t, "saved-searches", "list", "--output", "json")
+		clitest.SetupConfig(t, member, root)
+		out := bytes.NewBuffer(nil)
+		inv.Stdout = out
+		err = inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+		var searches []codersdk.SavedSearch
+		require.NoError(t, json.Unmarshal(out.Bytes(), &searches))
+		require.Len(t, searches, 1)
+		require.Equal(t, "failed-logins", searches[0].Name)
+		require.Equal(t, codersdk.SavedSearchResourceTypeAuditLog, searches[0].ResourceType)
+		require.Equal(t, owner.OrganizationID, *searches[0].OrganizationID)
+
+		// Invalid queries are rejected.
+		inv, root = clitest.New(t, "saved-searches", "create", "broken", "--query", "status:bogus")
+		clitest.SetupConfig(t, member, root)
+		err = inv.WithContext(ctx).Run()
+		require.ErrorContains(t, err, "status")
+
+		inv, root = clitest.New(t, "saved-searches", "delete", "failed-logins", "--type", "audit_log")
+		clitest.SetupConfig(t, member, root)
+		err = inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+
+		searches, err = member.SavedSearches(ctx, codersdk.Me, codersdk.SavedSearchesRequest{})
+		require.NoError(t, err)
+		require.Empty(t, searches)
+	})
+
+	t.Run("ListSaved", func(t *testing.T) {
+		t.Parallel()
+
+		client, db := coderdtest.NewWithDatabase(t, nil)
+		owner := coderdtest.CreateFirstUser(t, client)
+		member, memberUser := coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+		mine := dbfake.WorkspaceBuild(t, db, database.Workspace{
+			OrganizationID: owner.OrganizationID,
+			OwnerID:        memberUser.ID,
+		}).Do()
+		_ = dbfake.WorkspaceBuild(t, db, database.Workspace{
+			OrganizationID: owner.OrganizationID,
+			OwnerID:        owner.UserID,
+		}).Do()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		// The owner shares a search which lists everyone else's workspaces.
+		_, err := client.CreateSavedSearch(ctx, codersdk.Me, codersdk.CreateSavedSearchRequest{
+			ResourceType:   codersdk.SavedSearchResourceTypeWorkspace,
+			Name:           "others",
+			Query:          "-owner:me",
+			OrganizationID: ptr.Ref(owner.OrganizationID),
+		})
+		require.NoError(t, err)
+
+		inv, root := clitest.New(t, "list", "--saved", "others", "--output", "json")
+		clitest.SetupConfig(t, client, root)
+		out := bytes.NewBuffer(nil)
+		inv.Stdout = out
+		err = inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+		var workspaces []codersdk.Workspace
+		require.NoError(t, json.Unmarshal(out.Bytes(), &workspaces))
+		require.Len(t, workspaces, 1)
+		require.Equal(t, mine.Workspace.ID, workspaces[0].ID)
+
+		// Search terms narrow the saved search down further.
+		inv, root = clitest.New(t, "list", "--saved", "others", "--search", "name:"+mine.Workspace.Name, "--output", "json")
+		clitest.SetupConfig(t, client, root)
+		out = bytes.NewBuffer(nil)
+		inv.Stdout = out
+		err = inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+		workspaces = nil
+		require.NoError(t, json.Unmarshal(out.Bytes(), &workspaces))
+		require.Len(t, workspaces, 1)
+		require.Equal(t, mine.Workspace.ID, workspaces[0].ID)
+
+		inv, root = clitest.New(t, "list", "--saved", "others", "--search", "name:does-not-exist", "--output", "json")
+		clitest.SetupConfig(t, client, root)
+		out = bytes.NewBuffer(nil)
+		inv.Stdout = out
+		err = inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+		require.Empty(t, out.String())
+
+		// The member can use the shared search too, but cannot see the
+		// owner's workspace.
+		inv, root = clitest.New(t, "list", "--saved", "others", "--output", "json")
+		clitest.SetupConfig(t, member, root)
+		out = bytes.NewBuffer(nil)
+		inv.Stdout = out
+		err = inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+		require.Empty(t, out.String())
+
+		inv, root = clitest.New(t, "list", "--saved", "missing")
+		clitest.SetupConfig(t, member, root)
+		err = inv.WithContext(ctx).Run()
+		require.ErrorContains(t, err, `no saved workspace search named "missing"`)
+	})
+}
@@ -0,0 +1,249 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/cli/cliui"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/pretty"
+	"github.com/coder/serpent"
+)
+
+func (r *RootCmd) scripts() *serpent.Command {
+	cmd := &serpent.Command{
+		Use:   "scripts",
+		Short: "List, run and view the run history of the scripts of a workspace",
+		Long: "Scripts are defined by the template of the workspace, and are run by the workspace agent on start, " +
+			"on stop or on a schedule. Any script can also be run on demand.\n" + FormatExamples(
+			Example{
+				Description: "List the scripts of a workspace",
+				Command:     "coder scripts list my-workspace",
+			},
+			Example{
+				Description: "Rerun a failed startup script without restarting the workspace",
+				Command:     `coder scripts run my-workspace "Install dependencies"`,
+			},
+			Example{
+				Description: "View the recent runs of a script",
+				Command:     `coder scripts history my-workspace "Install dependencies"`,
+			},
+		),
+		Aliases: []string{"script"},
+		Handler: func(inv *serpent.Invocation) error {
+			return inv.Command.HelpHandler(inv)
+		},
+		Children: []*serpent.Command{
+			r.listScripts(),
+			r.runScript(),
+			r.scriptHistory(),
+		},
+	}
+	return cmd
+}
+
+// findAgentScript returns the script of an agent with the given display name
+// or ID.
+func findAgentScript(agent codersdk.WorkspaceAgent, nameOrID string) (codersdk.WorkspaceAgentScript, error) {
+	names := make([]string, 0, len(agent.Scripts))
+	for _, script := range agent.Scripts {
+		if script.ID.String() == nameOrID || strings.EqualFold(script.DisplayName, nameOrID) {
+			return script, nil
+		}
+		names = append(names, fmt.Sprintf("%q", script.DisplayName))
+	}
+	if len(names) == 0 {
+		return codersdk.WorkspaceAgentScript{}, xerrors.Errorf("agent %q has no scripts", agent.Name)
+	}
+	return codersdk.WorkspaceAgentScript{}, xerrors.Errorf("script %q not found in agent %q, available scripts: %s", nameOrID, agent.Name, strings.Join(names, ", "))
+}
+
+type scriptListRow struct {
+	// For JSON format:
+	codersdk.WorkspaceAgentScript `table:"-"`
+
+	// For table format:
+	Name     string `json:"-" table:"name,default_sort"`
+	ID       string `json:"-" table:"id"`
+	RunsOn   string `json:"-" table:"runs on"`
+	Schedule string `json:"-" table:"schedule"`
+	Timeout  string `json:"-" table:"timeout"`
+}
+
+func (r *RootCmd) listScripts() *serpent.Command {
+	formatter := cliui.NewOutputFormatter(
+		cliui.TableFormat([]scriptListRow{}, []string{"name", "runs on", "schedule"}),
+		cliui.JSONFormat(),
+	)
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:     "list <workspace>",
+		Aliases: []string{"ls"},
+		Short:   "List the scripts of a workspace",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(1),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			ctx := inv.Context()
+			_, agent, err := getWorkspaceAndAgent(ctx, inv, client, false, inv.Args[0])
+			if err != nil {
+				return err
+			}
+			if len(agent.Scripts) == 0 {
+				cliui.Infof(inv.Stderr, "The %s agent has no scripts.", agent.Name)
+				return nil
+			}
+
+			rows := make([]scriptListRow, 0, len(agent.Scripts))
+			for _, script := range agent.Scripts {
+				var runsOn []string
+				if script.RunOnStart {
+					runsOn = append(runsOn, "start")
+				}
+				if script.RunOnStop {
+					runsOn = append(runsOn, "stop")
+				}
+				timeout := "none"
+				if script.Timeout > 0 {
+					timeout = script.Timeout.String()
+				}
+				rows = append(rows, scriptListRow{
+					WorkspaceAgentScript: script,
+					Name:                 script.DisplayName,
+					ID:                   script.ID.String(),
+					RunsOn:               strings.Join(runsOn, ", "),
+					Schedule:             script.Cron,
+					Timeout:              timeout,
+				})
+			}
+			out, err := formatter.Format(ctx, rows)
+			if err != nil {
+				return err
+			}
+
+			_, err = fmt.Fprintln(inv.Stdout, out)
+			return err
+		},
+	}
+
+	formatter.AttachOptions(&cmd.Options)
+	return cmd
+}
+
+func (r *RootCmd) runScript() *serpent.Command {
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:   "run <workspace> <script>",
+		Short: "Run a script of a workspace",
+		Long: "The script is identified by its name or ID, and runs in the background. Its output is written " +
+			"to the logs of the workspace agent, and its run is added to the run history of the script when it exits.",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(2),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			ctx := inv.Context()
+			workspace, agent, err := getWorkspaceAndAgent(ctx, inv, client, false, inv.Args[0])
+			if err != nil {
+				return err
+			}
+			script, err := findAgentScript(agent, inv.Args[1])
+			if err != nil {
+				return err
+			}
+
+			err = client.WorkspaceAgentRunScript(ctx, agent.ID, script.ID)
+			if err != nil {
+				return xerrors.Errorf("run script: %w", err)
+			}
+
+			_, _ = fmt.Fprintf(inv.Stdout,
+				"Started the %s script. Run %s to see the result.\n",
+				pretty.Sprint(cliui.DefaultStyles.Keyword, script.DisplayName),
+				pretty.Sprint(cliui.DefaultStyles.Code, fmt.Sprintf("coder scripts history %s %q", workspace.Name, script.DisplayName)),
+			)
+			return nil
+		},
+	}
+	return cmd
+}
+
+type scriptRunRow struct {
+	// For JSON format:
+	codersdk.WorkspaceAgentScriptRun `table:"-"`
+
+	// For table format:
+	StartedAt   time.Time `json:"-" table:"started at,default_sort"`
+	Trigger     string    `json:"-" table:"trigger"`
+	Status      string    `json:"-" table:"status"`
+	ExitCode    int32     `json:"-" table:"exit code"`
+	Duration    string    `json:"-" table:"duration"`
+	LogSourceID string    `json:"-" table:"log source id"`
+	LogPath     string    `json:"-" table:"log path"`
+}
+
+func (r *RootCmd) scriptHistory() *serpent.Command {
+	formatter := cliui.NewOutputFormatter(
+		cliui.TableFormat([]scriptRunRow{}, []string{"started at", "trigger", "status", "exit code", "duration"}),
+		cliui.JSONFormat(),
+	)
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:   "history <workspace> <script>",
+		Short: "List the recent runs of a script of a workspace",
+		Long: "The script is identified by its name or ID. The output of each run is in the logs of the " +
+			"workspace agent, under the log source of the script.",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(2),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			ctx := inv.Context()
+			_, agent, err := getWorkspaceAndAgent(ctx, inv, client, false, inv.Args[0])
+			if err != nil {
+				return err
+			}
+			script, err := findAgentScript(agent, inv.Args[1])
+			if err != nil {
+				return err
+			}
+
+			runs, err := client.WorkspaceAgentScriptRuns(ctx, agent.ID, script.ID)
+			if err != nil {
+				return xerrors.Errorf("list script runs: %w", err)
+			}
+			if len(runs) == 0 {
+				cliui.Infof(inv.Stderr, "The %s script hasn't run yet.", script.DisplayName)
+				return nil
+			}
+
+			rows := make([]scriptRunRow, 0, len(runs))
+			for _, run := range runs {
+				rows = append(rows, scriptRunRow{
+					WorkspaceAgentScriptRun: run,
+					StartedAt:               run.StartedAt,
+					Trigger:                 string(run.Stage),
+					Status:                  string(run.Status),
+					ExitCode:                run.ExitCode,
+					Duration:                run.EndedAt.Sub(run.StartedAt).Round(time.Millisecond).String(),
+					LogSourceID:             run.LogSourceID.String(),
+					LogPath:                 run.LogPath,
+				})
+			}
+			out, err := formatter.Format(ctx, rows)
+			if err != nil {
+				return err
+			}
+
+			_, err = fmt.Fprintln(inv.Stdout, out)
+			return err
+		},
+	}
+
+	formatter.AttachOptions(&cmd.Options)
+	return cmd
+}
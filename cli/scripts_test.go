@@ -0,0 +1,75 @@
+package cli_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/agent/agenttest"
+	"github.com/coder/coder/v2/cli/clitest"
+	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/provisionersdk/proto"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestScripts(t *testing.T) {
+	t.Parallel()
+
+	client, workspace, agentToken := setupWorkspaceForAgent(t, func(agents []*proto.Agent) []*proto.Agent {
+		agents[0].Scripts = []*proto.Script{{
+			DisplayName: "Say hello",
+			Script:      "echo hello",
+			Cron:        "0 0 1 1 *",
+		}}
+		return agents
+	})
+	_ = agenttest.New(t, client.URL, agentToken)
+	_ = coderdtest.AwaitWorkspaceAgents(t, client, workspace.ID)
+
+	t.Run("List", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		inv, root := clitest.New(t, "scripts", "list", workspace.Name)
+		clitest.SetupConfig(t, client, root)
+		var out bytes.Buffer
+		inv.Stdout = &out
+		require.NoError(t, inv.WithContext(ctx).Run())
+
+		require.Contains(t, out.String(), "Say hello")
+		require.Contains(t, out.String(), "0 0 1 1 *")
+	})
+
+	t.Run("RunAndHistory", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		inv, root := clitest.New(t, "scripts", "run", workspace.Name, "say hello")
+		clitest.SetupConfig(t, client, root)
+		var out bytes.Buffer
+		inv.Stdout = &out
+		require.NoError(t, inv.WithContext(ctx).Run())
+		require.Contains(t, out.String(), "Started the Say hello script")
+
+		require.Eventually(t, func() bool {
+			inv, root := clitest.New(t, "scripts", "history", workspace.Name, "Say hello")
+			clitest.SetupConfig(t, client, root)
+			var out bytes.Buffer
+			inv.Stdout = &out
+			err := inv.WithContext(ctx).Run()
+			return assert.NoError(t, err) && bytes.Contains(out.Bytes(), []byte("manual"))
+		}, testutil.WaitLong, testutil.IntervalMedium)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		inv, root := clitest.New(t, "scripts", "run", workspace.Name, "nope")
+		clitest.SetupConfig(t, client, root)
+		err := inv.WithContext(ctx).Run()
+		require.ErrorContains(t, err, `available scripts: "Say hello"`)
+	})
+}
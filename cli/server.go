@@ -71,6 +71,7 @@ import (
 	"github.com/coder/coder/v2/cli/cliutil"
 	"github.com/coder/coder/v2/cli/config"
 	"github.com/coder/coder/v2/coderd"
+	"github.com/coder/coder/v2/coderd/auditarchive"
 	"github.com/coder/coder/v2/coderd/autobuild"
 	"github.com/coder/coder/v2/coderd/database"
 	"github.com/coder/coder/v2/coderd/database/awsiamrds"
@@ -81,6 +82,7 @@ import (
 	"github.com/coder/coder/v2/coderd/database/migrations"
 	"github.com/coder/coder/v2/coderd/database/pubsub"
 	"github.com/coder/coder/v2/coderd/devtunnel"
+	"github.com/coder/coder/v2/coderd/driftcheck"
 	"github.com/coder/coder/v2/coderd/externalauth"
 	"github.com/coder/coder/v2/coderd/gitsshkey"
 	"github.com/coder/coder/v2/coderd/httpmw"
@@ -89,6 +91,7 @@ import (
 	"github.com/coder/coder/v2/coderd/prometheusmetrics"
 	"github.com/coder/coder/v2/coderd/prometheusmetrics/insights"
 	"github.com/coder/coder/v2/coderd/promoauth"
+	"github.com/coder/coder/v2/coderd/provisionerstate"
 	"github.com/coder/coder/v2/coderd/schedule"
 	"github.com/coder/coder/v2/coderd/telemetry"
 	"github.com/coder/coder/v2/coderd/tracing"
@@ -616,6 +619,11 @@ func (r *RootCmd) Server(newAPI func(context.Context, *coderd.Options) (*coderd.
 				options.TLSCertificates = httpServers.TLSConfig.Certificates
 			}
 
+			options.ProvisionerStateStore, err = provisionerstate.NewStore(ctx, vals.Provisioner.StateStore)
+			if err != nil {
+				return xerrors.Errorf("create provisioner state store: %w", err)
+			}
+
 			if vals.StrictTransportSecurity > 0 {
 				options.StrictTransportSecurityCfg, err = httpmw.HSTSConfigOptions(
 					int(vals.StrictTransportSecurity.Value()), vals.StrictTransportSecurityOptions,
@@ -971,6 +979,27 @@ func (r *RootCmd) Server(newAPI func(context.Context, *coderd.Options) (*coderd.
 			for _, pt := range vals.Provisioner.DaemonTypes {
 				provisionerTypes = append(provisionerTypes, codersdk.ProvisionerType(pt))
 			}
+			// The versions of IaC engines that template versions can require
+			// are installed once and shared by all built-in provisioners.
+			var iacBinaries []terraform.Binary
+			if vals.Provisioner.IaCMirrorDir.String() != "" && vals.Provisioner.Daemons.Value() > 0 {
+				iacBinaries, err = terraform.InstallFromMirror(ctx, logger.Named("provisionerd"), vals.Provisioner.IaCMirrorDir.String(), filepath.Join(cacheDir, "iac"))
+				if err != nil {
+					return xerrors.Errorf("install IaC binaries from mirror: %w", err)
+				}
+			}
+			// Remote modules are cached once for all built-in provisioners.
+			var moduleCache *terraform.ModuleCache
+			if vals.Provisioner.ModuleCacheMaxSize.Value() > 0 && vals.Provisioner.Daemons.Value() > 0 {
+				moduleCache, err = terraform.NewModuleCache(
+					filepath.Join(cacheDir, "modules"),
+					vals.Provisioner.ModuleCacheMaxSize.Value(),
+					terraform.NewModuleCacheMetrics(options.PrometheusRegistry),
+				)
+				if err != nil {
+					return xerrors.Errorf("create module cache: %w", err)
+				}
+			}
 			for i := int64(0); i < vals.Provisioner.Daemons.Value(); i++ {
 				suffix := fmt.Sprintf("%d", i)
 				// The suffix is added to the hostname, so we may need to trim to fit into
@@ -979,7 +1008,7 @@ func (r *RootCmd) Server(newAPI func(context.Context, *coderd.Options) (*coderd.
 				name := fmt.Sprintf("%s-%s", hostname, suffix)
 				daemonCacheDir := filepath.Join(cacheDir, fmt.Sprintf("provisioner-%d", i))
 				daemon, err := newProvisionerDaemon(
-					ctx, coderAPI, provisionerdMetrics, logger, vals, daemonCacheDir, errCh, &provisionerdWaitGroup, name, provisionerTypes,
+					ctx, coderAPI, provisionerdMetrics, logger, vals, daemonCacheDir, errCh, &provisionerdWaitGroup, name, provisionerTypes, iacBinaries, moduleCache,
 				)
 				if err != nil {
 					return xerrors.Errorf("create provisioner daemon: %w", err)
@@ -992,7 +1021,20 @@ func (r *RootCmd) Server(newAPI func(context.Context, *coderd.Options) (*coderd.
 			defer shutdownConns()
 
 			// Ensures that old database entries are cleaned up over time!
-			purger := dbpurge.New(ctx, logger.Named("dbpurge"), options.Database, quartz.NewReal())
+			purgeOpts := []dbpurge.Option{
+				dbpurge.WithAgentMetadataHistoryRetention(vals.AgentMetadataHistoryRetention.Value()),
+			}
+			if retention := vals.AuditLogging.Retention.Value(); retention > 0 {
+				archive, err := auditarchive.NewStore(ctx, vals.AuditLogging.Archive)
+				if err != nil {
+					return xerrors.Errorf("create audit log archive: %w", err)
+				}
+				if archive == nil {
+					logger.Warn(ctx, "audit log retention is configured without an archive destination, expired audit logs will be deleted without being archived")
+				}
+				purgeOpts = append(purgeOpts, dbpurge.WithAuditLogRetention(retention, archive))
+			}
+			purger := dbpurge.New(ctx, logger.Named("dbpurge"), options.Database, quartz.NewReal(), purgeOpts...)
 			defer purger.Close()
 
 			// Updates workspace usage
@@ -1021,7 +1063,7 @@ func (r *RootCmd) Server(newAPI func(context.Context, *coderd.Options) (*coderd.
 				// The notification manager is responsible for:
 				//   - creating notifiers and managing their lifecycles (notifiers are responsible for dequeueing/sending notifications)
 				//   - keeping the store updated with status updates
-				notificationsManager, err = notifications.NewManager(cfg, options.Database, helpers, metrics, logger.Named("notifications.manager"))
+				notificationsManager, err = notifications.NewManager(cfg, options.Database, options.Pubsub, helpers, metrics, logger.Named("notifications.manager"))
 				if err != nil {
 					return xerrors.Errorf("failed to instantiate notification manager: %w", err)
 				}
@@ -1032,6 +1074,10 @@ func (r *RootCmd) Server(newAPI func(context.Context, *coderd.Options) (*coderd.
 				// Run report generator to distribute periodic reports.
 				notificationReportGenerator := reports.NewReportGenerator(ctx, logger.Named("notifications.report_generator"), options.Database, options.NotificationsEnqueuer, quartz.NewReal())
 				defer notificationReportGenerator.Close()
+
+				// Run digest generator to deliver notifications collected within a digest window together.
+				notificationDigestGenerator := reports.NewDigestGenerator(ctx, logger.Named("notifications.digest_generator"), options.Database, options.NotificationsEnqueuer, quartz.NewReal())
+				defer notificationDigestGenerator.Close()
 			}
 
 			// Wrap the server in middleware that redirects to the access URL if
@@ -1105,6 +1151,16 @@ func (r *RootCmd) Server(newAPI func(context.Context, *coderd.Options) (*coderd.
 			hangDetector.Start()
 			defer hangDetector.Close()
 
+			if interval := vals.WorkspaceDriftCheckInterval.Value(); interval > 0 {
+				// Workspaces are due to be checked at different times, so
+				// look for them more often than the interval.
+				driftCheckTicker := time.NewTicker(min(interval, time.Minute))
+				defer driftCheckTicker.Stop()
+				driftCheckScheduler := driftcheck.New(ctx, options.Database, options.Pubsub, logger.Named("driftcheck"), interval, driftCheckTicker.C)
+				driftCheckScheduler.Start()
+				defer driftCheckScheduler.Close()
+			}
+
 			waitForProvisionerJobs := false
 			// Currently there is no way to ask the server to shut
 			// itself down, so any exit signal will result in a non-zero
@@ -1302,6 +1358,7 @@ func (r *RootCmd) Server(newAPI func(context.Context, *coderd.Options) (*coderd.
 	serverCmd.Children = append(
 		serverCmd.Children,
 		createAdminUserCmd, postgresBuiltinURLCmd, postgresBuiltinServeCmd,
+		r.newAuditCommand(), r.newProvisionerStateCommand(),
 	)
 
 	return serverCmd
@@ -1425,6 +1482,8 @@ func newProvisionerDaemon(
 	wg *sync.WaitGroup,
 	name string,
 	provisionerTypes []codersdk.ProvisionerType,
+	iacBinaries []terraform.Binary,
+	moduleCache *terraform.ModuleCache,
 ) (srv *provisionerd.Server, err error) {
 	ctx, cancel := context.WithCancel(ctx)
 	defer func() {
@@ -1448,6 +1507,14 @@ func newProvisionerDaemon(
 	provisionerTypes = slice.Unique(provisionerTypes)
 	provisionerLogger := logger.Named(fmt.Sprintf("provisionerd-%s", name))
 
+	// Only terraform provisioners can run the IaC binaries.
+	advertised := make([]provisionersdk.IaCBinary, 0, len(iacBinaries))
+	if slice.Contains(provisionerTypes, codersdk.ProvisionerTypeTerraform) {
+		for _, b := range iacBinaries {
+			advertised = append(advertised, b.IaCBinary)
+		}
+	}
+
 	// Populate the connector with the supported types.
 	connector := provisionerd.LocalProvisioners{}
 	for _, provisionerType := range provisionerTypes {
@@ -1506,8 +1573,10 @@ func newProvisionerDaemon(
 						Logger:        provisionerLogger,
 						WorkDirectory: workDir,
 					},
-					CachePath: tfDir,
-					Tracer:    tracer,
+					CachePath:   tfDir,
+					Binaries:    iacBinaries,
+					ModuleCache: moduleCache,
+					Tracer:      tracer,
 				})
 				if err != nil && !xerrors.Is(err, context.Canceled) {
 					select {
@@ -1526,7 +1595,7 @@ func newProvisionerDaemon(
 	return provisionerd.New(func(dialCtx context.Context) (proto.DRPCProvisionerDaemonClient, error) {
 		// This debounces calls to listen every second. Read the comment
 		// in provisionerdserver.go to learn more!
-		return coderAPI.CreateInMemoryProvisionerDaemon(dialCtx, name, provisionerTypes)
+		return coderAPI.CreateInMemoryProvisionerDaemon(dialCtx, name, provisionerTypes, advertised)
 	}, &provisionerd.Options{
 		Logger:              provisionerLogger,
 		UpdateInterval:      time.Second,
@@ -0,0 +1,222 @@
+//go:build !slim
+
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
+	"cdr.dev/slog/sloggers/sloghuman"
+	"github.com/coder/coder/v2/cli/cliui"
+	"github.com/coder/coder/v2/coderd/auditarchive"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/awsiamrds"
+	"github.com/coder/coder/v2/coderd/objectstore"
+	"github.com/coder/coder/v2/coderd/searchquery"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/serpent"
+)
+
+func (r *RootCmd) newAuditCommand() *serpent.Command {
+	return &serpent.Command{
+		Use:   "audit",
+		Short: "Manage audit logs stored in the database.",
+		Handler: func(inv *serpent.Invocation) error {
+			return inv.Command.HelpHandler(inv)
+		},
+		Children: []*serpent.Command{
+			r.newAuditExportCommand(),
+		},
+	}
+}
+
+func (r *RootCmd) newAuditExportCommand() *serpent.Command {
+	const dateLayout = "2006-01-02"
+	var (
+		dbURL     string
+		pgAuth    string
+		from      string
+		to        string
+		filter    string
+		outputDir string
+	)
+	cmd := &serpent.Command{
+		Use:   "export",
+		Short: "Export audit logs to an archive in the same format used by the audit log retention policy.",
+		Long: FormatExamples(
+			Example{
+				Description: "Export all audit logs from the first quarter of 2024",
+				Command:     "coder server audit export --from 2024-01-01 --to 2024-03-31 --output-dir ./audit-archive",
+			},
+			Example{
+				Description: "Export only workspace deletions, using the audit log search syntax",
+				Command:     `coder server audit export --from 2024-01-01 --to 2024-01-31 --filter "resource_type:workspace action:delete"`,
+			},
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			ctx := inv.Context()
+
+			cfg := r.createConfig()
+			logger := inv.Logger.AppendSinks(sloghuman.Sink(inv.Stderr))
+			if r.verbose {
+				logger = logger.Leveled(slog.LevelDebug)
+			}
+
+			ctx, cancel := inv.SignalNotifyContext(ctx, StopSignals...)
+			defer cancel()
+
+			var dateFrom, dateTo time.Time
+			var err error
+			if from != "" {
+				dateFrom, err = time.Parse(dateLayout, from)
+				if err != nil {
+					return xerrors.Errorf("parse --from: expected a date formatted as YYYY-MM-DD: %w", err)
+				}
+			}
+			if to != "" {
+				dateTo, err = time.Parse(dateLayout, to)
+				if err != nil {
+					return xerrors.Errorf("parse --to: expected a date formatted as YYYY-MM-DD: %w", err)
+				}
+				// Include the whole of the final day, as the audit log search does.
+				dateTo = dateTo.Add(24*time.Hour - time.Second)
+			}
+			if !dateFrom.IsZero() && !dateTo.IsZero() && dateTo.Before(dateFrom) {
+				return xerrors.New("--to must not be before --from")
+			}
+
+			if dbURL == "" {
+				cliui.Infof(inv.Stderr, "Using built-in PostgreSQL (%s)", cfg.PostgresPath())
+				url, closePg, err := startBuiltinPostgres(ctx, cfg, logger)
+				if err != nil {
+					return err
+				}
+				defer func() {
+					_ = closePg()
+				}()
+				dbURL = url
+			}
+
+			sqlDriver := "postgres"
+			if codersdk.PostgresAuth(pgAuth) == codersdk.PostgresAuthAWSIAMRDS {
+				sqlDriver, err = awsiamrds.Register(inv.Context(), sqlDriver)
+				if err != nil {
+					return xerrors.Errorf("register aws rds iam auth: %w", err)
+				}
+			}
+
+			sqlDB, err := ConnectToPostgres(ctx, logger, sqlDriver, dbURL)
+			if err != nil {
+				return xerrors.Errorf("connect to postgres: %w", err)
+			}
+			defer func() {
+				_ = sqlDB.Close()
+			}()
+			db := database.New(sqlDB)
+
+			params, errs := searchquery.AuditLogs(ctx, db, filter)
+			if len(errs) > 0 {
+				msgs := make([]string, 0, len(errs))
+				for _, e := range errs {
+					msgs = append(msgs, fmt.Sprintf("%s: %s", e.Field, e.Detail))
+				}
+				return xerrors.Errorf("invalid filter: %s", strings.Join(msgs, "; "))
+			}
+			if (!dateFrom.IsZero() && !params.DateFrom.IsZero()) || (!dateTo.IsZero() && !params.DateTo.IsZero()) {
+				return xerrors.New("use either --from and --to, or date_from and date_to in --filter, but not both")
+			}
+			if !dateFrom.IsZero() {
+				params.DateFrom = dateFrom
+			}
+			if !dateTo.IsZero() {
+				params.DateTo = dateTo
+			}
+
+			store, err := objectstore.NewDirStore(outputDir)
+			if err != nil {
+				return err
+			}
+			w := auditarchive.NewWriter(store, time.Now(), auditarchive.DefaultMaxFileRecords)
+
+			const batchSize = 1000
+			params.LimitOpt = batchSize
+			for {
+				rows, err := db.GetAuditLogsOffset(ctx, params)
+				if err != nil {
+					return xerrors.Errorf("get audit logs: %w", err)
+				}
+				for _, row := range rows {
+					if err := w.Write(ctx, auditarchive.RecordFromRow(row)); err != nil {
+						return xerrors.Errorf("write archive: %w", err)
+					}
+				}
+				if len(rows) < batchSize {
+					break
+				}
+				params.OffsetOpt += batchSize
+			}
+
+			manifest, err := w.Close(ctx)
+			if err != nil {
+				return xerrors.Errorf("write archive: %w", err)
+			}
+			if manifest.Records == 0 {
+				cliui.Warn(inv.Stderr, "No audit logs matched, nothing was exported.")
+				return nil
+			}
+
+			absDir, err := filepath.Abs(outputDir)
+			if err != nil {
+				absDir = outputDir
+			}
+			_, _ = fmt.Fprintf(inv.Stdout, "Exported %d audit logs to %d files in %s\n", manifest.Records, len(manifest.Files), absDir)
+			return nil
+		},
+	}
+
+	cmd.Options.Add(
+		serpent.Option{
+			Env:         "CODER_PG_CONNECTION_URL",
+			Flag:        "postgres-url",
+			Description: "URL of a PostgreSQL database. If empty, the built-in PostgreSQL deployment will be used (Coder must not be already running in this case).",
+			Value:       serpent.StringOf(&dbURL),
+		},
+		serpent.Option{
+			Name:        "Postgres Connection Auth",
+			Description: "Type of auth to use when connecting to postgres.",
+			Flag:        "postgres-connection-auth",
+			Env:         "CODER_PG_CONNECTION_AUTH",
+			Default:     "password",
+			Value:       serpent.EnumOf(&pgAuth, codersdk.PostgresAuthDrivers...),
+		},
+		serpent.Option{
+			Flag:        "from",
+			Description: "Only export audit logs from this date onwards, formatted as YYYY-MM-DD.",
+			Value:       serpent.StringOf(&from),
+		},
+		serpent.Option{
+			Flag:        "to",
+			Description: "Only export audit logs up to and including this date, formatted as YYYY-MM-DD.",
+			Value:       serpent.StringOf(&to),
+		},
+		serpent.Option{
+			Flag:        "filter",
+			Description: "Only export audit logs matching this search query, which uses the same syntax as the audit log search (e.g. \"resource_type:workspace action:delete\").",
+			Value:       serpent.StringOf(&filter),
+		},
+		serpent.Option{
+			Flag:          "output-dir",
+			FlagShorthand: "o",
+			Description:   "The directory to which the archive is written. It is created if it does not exist.",
+			Default:       ".",
+			Value:         serpent.StringOf(&outputDir),
+		},
+	)
+
+	return cmd
+}
@@ -0,0 +1,146 @@
+//go:build !slim
+
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
+	"cdr.dev/slog/sloggers/sloghuman"
+	"github.com/coder/coder/v2/cli/cliui"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/awsiamrds"
+	"github.com/coder/coder/v2/coderd/provisionerstate"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/serpent"
+)
+
+func (r *RootCmd) newProvisionerStateCommand() *serpent.Command {
+	return &serpent.Command{
+		Use:   "provisioner-state",
+		Short: "Manage where the provisioner state of workspace builds is stored.",
+		Handler: func(inv *serpent.Invocation) error {
+			return inv.Command.HelpHandler(inv)
+		},
+		Children: []*serpent.Command{
+			r.newProvisionerStateMigrateCommand(),
+		},
+	}
+}
+
+func (r *RootCmd) newProvisionerStateMigrateCommand() *serpent.Command {
+	var (
+		dbURL      string
+		pgAuth     string
+		toDatabase bool
+		vals       = new(codersdk.DeploymentValues)
+	)
+	cmd := &serpent.Command{
+		Use:   "migrate",
+		Short: "Move the provisioner state of workspace builds from the database into the configured state store, or back.",
+		Long: "Builds that complete while the state is being moved store their state wherever Coder is configured to, so stop Coder or change its configuration first. " +
+			"Objects are left in the state store after their state has been moved back into the database.\n\n" + FormatExamples(
+			Example{
+				Description: "Move the state of every build into an S3 bucket",
+				Command:     "coder server provisioner-state migrate --provisioner-state-store-s3-bucket coder-state",
+			},
+			Example{
+				Description: "Move the state of every build from a directory back into the database",
+				Command:     "coder server provisioner-state migrate --provisioner-state-store-dir /var/lib/coder/state --to-database",
+			},
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			ctx := inv.Context()
+
+			cfg := r.createConfig()
+			logger := inv.Logger.AppendSinks(sloghuman.Sink(inv.Stderr))
+			if r.verbose {
+				logger = logger.Leveled(slog.LevelDebug)
+			}
+
+			ctx, cancel := inv.SignalNotifyContext(ctx, StopSignals...)
+			defer cancel()
+
+			store, err := provisionerstate.NewStore(ctx, vals.Provisioner.StateStore)
+			if err != nil {
+				return xerrors.Errorf("create provisioner state store: %w", err)
+			}
+			if store == nil {
+				return xerrors.New("no provisioner state store is configured, set --provisioner-state-store-dir or --provisioner-state-store-s3-bucket")
+			}
+
+			if dbURL == "" {
+				cliui.Infof(inv.Stderr, "Using built-in PostgreSQL (%s)", cfg.PostgresPath())
+				url, closePg, err := startBuiltinPostgres(ctx, cfg, logger)
+				if err != nil {
+					return err
+				}
+				defer func() {
+					_ = closePg()
+				}()
+				dbURL = url
+			}
+
+			sqlDriver := "postgres"
+			if codersdk.PostgresAuth(pgAuth) == codersdk.PostgresAuthAWSIAMRDS {
+				sqlDriver, err = awsiamrds.Register(inv.Context(), sqlDriver)
+				if err != nil {
+					return xerrors.Errorf("register aws rds iam auth: %w", err)
+				}
+			}
+
+			sqlDB, err := ConnectToPostgres(ctx, logger, sqlDriver, dbURL)
+			if err != nil {
+				return xerrors.Errorf("connect to postgres: %w", err)
+			}
+			defer func() {
+				_ = sqlDB.Close()
+			}()
+
+			stats, err := provisionerstate.Migrate(ctx, logger, database.New(sqlDB), store, toDatabase)
+			if err != nil {
+				return err
+			}
+
+			destination := "the state store"
+			if toDatabase {
+				destination = "the database"
+			}
+			_, _ = fmt.Fprintf(inv.Stdout, "Moved the state of %d workspace builds into %s\n", stats.Moved, destination)
+			if stats.Skipped > 0 {
+				cliui.Warnf(inv.Stderr, "Skipped %d workspace builds that changed while their state was being moved. Run the command again to move them.", stats.Skipped)
+			}
+			return nil
+		},
+	}
+
+	cmd.Options.Add(
+		serpent.Option{
+			Env:         "CODER_PG_CONNECTION_URL",
+			Flag:        "postgres-url",
+			Description: "URL of a PostgreSQL database. If empty, the built-in PostgreSQL deployment will be used (Coder must not be already running in this case).",
+			Value:       serpent.StringOf(&dbURL),
+		},
+		serpent.Option{
+			Name:        "Postgres Connection Auth",
+			Description: "Type of auth to use when connecting to postgres.",
+			Flag:        "postgres-connection-auth",
+			Env:         "CODER_PG_CONNECTION_AUTH",
+			Default:     "password",
+			Value:       serpent.EnumOf(&pgAuth, codersdk.PostgresAuthDrivers...),
+		},
+		serpent.Option{
+			Flag:        "to-database",
+			Description: "Move the state from the state store back into the database, instead of from the database into the state store.",
+			Value:       serpent.BoolOf(&toDatabase),
+		},
+	)
+	// The state store is configured with the same options as the server.
+	cmd.Options = append(cmd.Options, vals.Options().Filter(func(opt serpent.Option) bool {
+		return strings.HasPrefix(opt.Flag, "provisioner-state-store-")
+	})...)
+	return cmd
+}
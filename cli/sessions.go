@@ -0,0 +1,538 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mattn/go-isatty"
+	"golang.org/x/term"
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/cli/cliui"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/codersdk/workspacesdk"
+	"github.com/coder/coder/v2/pty"
+	"github.com/coder/serpent"
+)
+
+// sessionDetachKey is Ctrl+\, which detaches from a session without
+// closing it.
+const sessionDetachKey = 0x1c
+
+func (r *RootCmd) sessions() *serpent.Command {
+	cmd := &serpent.Command{
+		Use:   "sessions",
+		Short: "List, attach to and replay terminal sessions of workspaces",
+		Long: "Live sessions are the terminals of a workspace that can be reconnected to, e.g. web terminals. " +
+			"Attaching to the sessions of other users, or watching or taking over any session, requires the share_session " +
+			"permission on the workspace besides SSH.\n\n" +
+			"Sessions are recorded if the template of the workspace enables session recording. " +
+			"Recordings are only available to users that can read the audit logs.\n" + FormatExamples(
+			Example{
+				Description: "List the live sessions of a workspace",
+				Command:     "coder sessions ls my-workspace",
+			},
+			Example{
+				Description: "Watch a session of a colleague without being able to type into it",
+				Command:     "coder sessions attach alice/my-workspace 2d6f1e4b-7a0c-4b8e-9d3f-5c1a2b3c4d5e --read-only",
+			},
+			Example{
+				Description: "Enable session recording for a template",
+				Command:     "coder templates edit my-template --session-recording",
+			},
+			Example{
+				Description: "List the recorded sessions of a workspace",
+				Command:     "coder sessions recordings my-workspace",
+			},
+			Example{
+				Description: "Replay a session twice as fast as it was recorded",
+				Command:     "coder sessions replay 2d6f1e4b-7a0c-4b8e-9d3f-5c1a2b3c4d5e --speed 2",
+			},
+		),
+		Aliases: []string{"session"},
+		Handler: func(inv *serpent.Invocation) error {
+			return inv.Command.HelpHandler(inv)
+		},
+		Children: []*serpent.Command{
+			r.listSessions(),
+			r.listSessionRecordings(),
+			r.attachSession(),
+			r.replaySession(),
+		},
+	}
+	return cmd
+}
+
+type sessionRecordingListRow struct {
+	// For JSON format:
+	codersdk.WorkspaceAgentSessionRecording `table:"-"`
+
+	// For table format:
+	ID        string    `json:"-" table:"id"`
+	Type      string    `json:"-" table:"type"`
+	Command   string    `json:"-" table:"command"`
+	StartedAt time.Time `json:"-" table:"started at,default_sort"`
+	Duration  string    `json:"-" table:"duration"`
+}
+
+func (r *RootCmd) listSessionRecordings() *serpent.Command {
+	var (
+		formatter = cliui.NewOutputFormatter(
+			cliui.TableFormat([]sessionRecordingListRow{}, []string{"id", "type", "command", "started at", "duration"}),
+			cliui.JSONFormat(),
+		)
+	)
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:   "recordings <workspace>",
+		Short: "List the recorded sessions of a workspace",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(1),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			ctx := inv.Context()
+			workspace, err := namedWorkspace(ctx, client, inv.Args[0])
+			if err != nil {
+				return err
+			}
+			recordings, err := client.WorkspaceSessionRecordings(ctx, workspace.ID)
+			if err != nil {
+				return xerrors.Errorf("list session recordings: %w", err)
+			}
+
+			if len(recordings) == 0 {
+				cliui.Infof(inv.Stderr, "No recorded sessions found for the %s workspace.", workspace.Name)
+				return nil
+			}
+
+			rows := make([]sessionRecordingListRow, 0, len(recordings))
+			for _, recording := range recordings {
+				duration := "in progress"
+				if recording.EndedAt != nil {
+					duration = recording.EndedAt.Sub(recording.StartedAt).Round(time.Second).String()
+				}
+				rows = append(rows, sessionRecordingListRow{
+					WorkspaceAgentSessionRecording: recording,
+					ID:                             recording.ID.String(),
+					Type:                           recording.SessionType,
+					Command:                        recording.Command,
+					StartedAt:                      recording.StartedAt,
+					Duration:                       duration,
+				})
+			}
+			out, err := formatter.Format(ctx, rows)
+			if err != nil {
+				return err
+			}
+
+			_, err = fmt.Fprintln(inv.Stdout, out)
+			return err
+		},
+	}
+
+	formatter.AttachOptions(&cmd.Options)
+	return cmd
+}
+
+type sessionListRow struct {
+	// For JSON format:
+	codersdk.WorkspaceAgentReconnectingPTY `table:"-"`
+
+	// For table format:
+	ID        string    `json:"-" table:"id"`
+	Agent     string    `json:"-" table:"agent"`
+	Command   string    `json:"-" table:"command"`
+	Container string    `json:"-" table:"container"`
+	StartedAt time.Time `json:"-" table:"started at,default_sort"`
+	Clients   string    `json:"-" table:"clients"`
+}
+
+func (r *RootCmd) listSessions() *serpent.Command {
+	var (
+		formatter = cliui.NewOutputFormatter(
+			cliui.TableFormat([]sessionListRow{}, []string{"id", "agent", "command", "started at", "clients"}),
+			cliui.JSONFormat(),
+		)
+	)
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:     "list <workspace>",
+		Aliases: []string{"ls"},
+		Short:   "List the live sessions of a workspace",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(1),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			ctx := inv.Context()
+			workspace, err := namedWorkspace(ctx, client, inv.Args[0])
+			if err != nil {
+				return err
+			}
+
+			var rows []sessionListRow
+			for _, resource := range workspace.LatestBuild.Resources {
+				for _, agent := range resource.Agents {
+					if agent.Status != codersdk.WorkspaceAgentConnected {
+						continue
+					}
+					res, err := client.WorkspaceAgentReconnectingPTYs(ctx, agent.ID)
+					if err != nil {
+						return xerrors.Errorf("list sessions of agent %q: %w", agent.Name, err)
+					}
+					for _, session := range res.Sessions {
+						command := session.Command
+						if command == "" {
+							command = "(login shell)"
+						}
+						readOnly := 0
+						for _, conn := range session.Connections {
+							if conn.ReadOnly {
+								readOnly++
+							}
+						}
+						clients := fmt.Sprint(len(session.Connections))
+						if readOnly > 0 {
+							clients += fmt.Sprintf(" (%d read only)", readOnly)
+						}
+						rows = append(rows, sessionListRow{
+							WorkspaceAgentReconnectingPTY: session,
+							ID:                            session.ID.String(),
+							Agent:                         agent.Name,
+							Command:                       command,
+							Container:                     session.Container,
+							StartedAt:                     session.StartedAt,
+							Clients:                       clients,
+						})
+					}
+				}
+			}
+
+			if len(rows) == 0 {
+				cliui.Infof(inv.Stderr, "No live sessions found for the %s workspace.", workspace.Name)
+				return nil
+			}
+			out, err := formatter.Format(ctx, rows)
+			if err != nil {
+				return err
+			}
+
+			_, err = fmt.Fprintln(inv.Stdout, out)
+			return err
+		},
+	}
+
+	formatter.AttachOptions(&cmd.Options)
+	return cmd
+}
+
+func (r *RootCmd) attachSession() *serpent.Command {
+	var (
+		readOnly bool
+		takeover bool
+	)
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:   "attach <workspace> <id>",
+		Short: "Attach to a live session of a workspace",
+		Long: "Other clients of the session keep seeing the same terminal. Press Ctrl+\\ to detach, " +
+			"which leaves the session running.",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(2),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			ctx, cancel := context.WithCancel(inv.Context())
+			defer cancel()
+
+			id, err := uuid.Parse(inv.Args[1])
+			if err != nil {
+				return xerrors.Errorf("invalid session ID %q: %w", inv.Args[1], err)
+			}
+			if readOnly && takeover {
+				return xerrors.New("--read-only and --takeover can't be used together")
+			}
+			workspace, err := namedWorkspace(ctx, client, inv.Args[0])
+			if err != nil {
+				return err
+			}
+
+			// Attaching to an ID that doesn't exist would start a new
+			// session, so find the agent that runs it first.
+			var agentID uuid.UUID
+			for _, resource := range workspace.LatestBuild.Resources {
+				for _, agent := range resource.Agents {
+					if agent.Status != codersdk.WorkspaceAgentConnected {
+						continue
+					}
+					res, err := client.WorkspaceAgentReconnectingPTYs(ctx, agent.ID)
+					if err != nil {
+						return xerrors.Errorf("list sessions of agent %q: %w", agent.Name, err)
+					}
+					for _, session := range res.Sessions {
+						if session.ID == id {
+							agentID = agent.ID
+						}
+					}
+				}
+			}
+			if agentID == uuid.Nil {
+				return xerrors.Errorf("no live session %s found for the %s workspace", id, workspace.Name)
+			}
+
+			stdinFile, validIn := inv.Stdin.(*os.File)
+			stdoutFile, validOut := inv.Stdout.(*os.File)
+			isTerminal := validIn && validOut && isatty.IsTerminal(stdinFile.Fd()) && isatty.IsTerminal(stdoutFile.Fd())
+			var width, height int
+			if isTerminal {
+				width, height, _ = term.GetSize(int(stdoutFile.Fd()))
+			}
+
+			conn, err := workspacesdk.New(client).AgentReconnectingPTY(ctx, workspacesdk.WorkspaceAgentReconnectingPTYOpts{
+				AgentID:   agentID,
+				Reconnect: id,
+				Width:     uint16(width),  //nolint:gosec
+				Height:    uint16(height), //nolint:gosec
+				ReadOnly:  readOnly,
+				Takeover:  takeover,
+			})
+			if err != nil {
+				return xerrors.Errorf("attach to session: %w", err)
+			}
+			defer conn.Close()
+			go func() {
+				<-ctx.Done()
+				_ = conn.Close()
+			}()
+
+			if isTerminal {
+				inState, err := pty.MakeInputRaw(stdinFile.Fd())
+				if err != nil {
+					return err
+				}
+				defer func() {
+					_ = pty.RestoreTerminal(stdinFile.Fd(), inState)
+				}()
+				outState, err := pty.MakeOutputRaw(stdoutFile.Fd())
+				if err != nil {
+					return err
+				}
+				defer func() {
+					_ = pty.RestoreTerminal(stdoutFile.Fd(), outState)
+				}()
+
+				// Viewers don't resize the terminal of the session.
+				if !readOnly {
+					windowChange := listenWindowSize(ctx)
+					go func() {
+						for {
+							select {
+							case <-ctx.Done():
+								return
+							case <-windowChange:
+							}
+							width, height, err := term.GetSize(int(stdoutFile.Fd()))
+							if err != nil {
+								continue
+							}
+							_ = writeReconnectingPTYRequest(conn, workspacesdk.ReconnectingPTYRequest{
+								Width:  uint16(width),  //nolint:gosec
+								Height: uint16(height), //nolint:gosec
+							})
+						}
+					}()
+				}
+			}
+
+			go func() {
+				buf := make([]byte, 4096)
+				for {
+					n, err := inv.Stdin.Read(buf)
+					if n > 0 {
+						data, detach := buf[:n], false
+						if i := bytes.IndexByte(data, sessionDetachKey); i >= 0 {
+							data, detach = data[:i], true
+						}
+						// The agent discards the input of viewers, so it's
+						// only read for the detach key.
+						if len(data) > 0 && !readOnly {
+							if err := writeReconnectingPTYRequest(conn, workspacesdk.ReconnectingPTYRequest{Data: string(data)}); err != nil {
+								return
+							}
+						}
+						if detach {
+							cancel()
+							return
+						}
+					}
+					if err != nil {
+						return
+					}
+				}
+			}()
+
+			_, err = io.Copy(inv.Stdout, conn)
+			if err != nil && ctx.Err() == nil {
+				return xerrors.Errorf("read session: %w", err)
+			}
+			return nil
+		},
+	}
+	cmd.Options = serpent.OptionSet{
+		{
+			Flag:        "read-only",
+			Description: "Watch the session without sending input to it.",
+			Value:       serpent.BoolOf(&readOnly),
+		},
+		{
+			Flag:        "takeover",
+			Description: "Detach the other clients of the session that can send input to it.",
+			Value:       serpent.BoolOf(&takeover),
+		},
+	}
+	return cmd
+}
+
+func writeReconnectingPTYRequest(w io.Writer, req workspacesdk.ReconnectingPTYRequest) error {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+func (r *RootCmd) replaySession() *serpent.Command {
+	var (
+		speed         float64
+		idleTimeLimit time.Duration
+	)
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:   "replay <id>",
+		Short: "Replay a recorded session in the terminal",
+		Long:  "The output of the session is written to the terminal with the timing it was recorded with.",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(1),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			ctx := inv.Context()
+			id, err := uuid.Parse(inv.Args[0])
+			if err != nil {
+				return xerrors.Errorf("invalid session recording ID %q: %w", inv.Args[0], err)
+			}
+			if speed <= 0 {
+				return xerrors.New("--speed must be greater than zero")
+			}
+
+			cast, err := client.SessionRecordingCast(ctx, id)
+			if err != nil {
+				return xerrors.Errorf("fetch session recording: %w", err)
+			}
+			defer cast.Close()
+
+			return replayAsciicast(ctx, inv.Stdout, cast, speed, idleTimeLimit)
+		},
+	}
+	cmd.Options = serpent.OptionSet{
+		{
+			Flag:        "speed",
+			Description: "Playback speed, e.g. 2 replays the session twice as fast as it was recorded.",
+			Default:     "1",
+			Value:       serpent.Float64Of(&speed),
+		},
+		{
+			Flag:        "idle-time-limit",
+			Description: "Limit pauses in the output to this duration. Set to 0 to keep the recorded pauses.",
+			Default:     "2s",
+			Value:       serpent.DurationOf(&idleTimeLimit),
+		},
+	}
+	return cmd
+}
+
+// replayAsciicast writes the output of an asciicast v2 recording to w with
+// the timing it was recorded with.
+func replayAsciicast(ctx context.Context, w io.Writer, r io.Reader, speed float64, idleTimeLimit time.Duration) error {
+	s := bufio.NewScanner(r)
+	// A single event holds at most one read of the terminal, but be generous.
+	s.Buffer(make([]byte, 0, 64<<10), 4<<20)
+	if !s.Scan() {
+		if err := s.Err(); err != nil {
+			return xerrors.Errorf("read header: %w", err)
+		}
+		return xerrors.New("the session recording is empty")
+	}
+	var header struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal(s.Bytes(), &header); err != nil {
+		return xerrors.Errorf("parse header: %w", err)
+	}
+	if header.Version != 2 {
+		return xerrors.Errorf("unsupported asciicast version %d", header.Version)
+	}
+
+	var (
+		last    float64
+		pending time.Duration
+	)
+	for s.Scan() {
+		var (
+			event []json.RawMessage
+			at    float64
+			code  string
+			data  string
+		)
+		if err := json.Unmarshal(s.Bytes(), &event); err != nil {
+			return xerrors.Errorf("parse event: %w", err)
+		}
+		if len(event) != 3 {
+			return xerrors.Errorf("invalid event %q", s.Text())
+		}
+		if err := json.Unmarshal(event[0], &at); err != nil {
+			return xerrors.Errorf("parse event time: %w", err)
+		}
+		if err := json.Unmarshal(event[1], &code); err != nil {
+			return xerrors.Errorf("parse event code: %w", err)
+		}
+		if err := json.Unmarshal(event[2], &data); err != nil {
+			return xerrors.Errorf("parse event data: %w", err)
+		}
+
+		delay := time.Duration((at - last) * float64(time.Second))
+		last = at
+		if idleTimeLimit > 0 && delay > idleTimeLimit {
+			delay = idleTimeLimit
+		}
+		pending += time.Duration(float64(delay) / speed)
+		// Only output is replayed, input is echoed by the terminal anyway.
+		if code != "o" {
+			continue
+		}
+
+		if pending > 0 {
+			t := time.NewTimer(pending)
+			select {
+			case <-ctx.Done():
+				t.Stop()
+				return ctx.Err()
+			case <-t.C:
+			}
+			pending = 0
+		}
+		if _, err := io.WriteString(w, data); err != nil {
+			return err
+		}
+	}
+	return s.Err()
+}
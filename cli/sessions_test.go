@@ -0,0 +1,224 @@
+package cli_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"runtime"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/agent/agenttest"
+	"github.com/coder/coder/v2/cli/clitest"
+	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbauthz"
+	"github.com/coder/coder/v2/coderd/database/dbfake"
+	"github.com/coder/coder/v2/coderd/database/dbgen"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/codersdk/workspacesdk"
+	"github.com/coder/coder/v2/pty/ptytest"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestSessions(t *testing.T) {
+	t.Parallel()
+
+	client, db := coderdtest.NewWithDatabase(t, nil)
+	owner := coderdtest.CreateFirstUser(t, client)
+	r := dbfake.WorkspaceBuild(t, db, database.Workspace{
+		OrganizationID: owner.OrganizationID,
+		OwnerID:        owner.UserID,
+	}).WithAgent().Do()
+
+	ctx := testutil.Context(t, testutil.WaitLong)
+	agents, err := db.GetWorkspaceAgentsInLatestBuildByWorkspaceID(dbauthz.AsSystemRestricted(ctx), r.Workspace.ID)
+	require.NoError(t, err)
+	recording := dbgen.WorkspaceAgentSessionRecording(t, db, database.WorkspaceAgentSessionRecording{
+		WorkspaceID: r.Workspace.ID,
+		AgentID:     agents[0].ID,
+	})
+	err = db.InsertWorkspaceAgentSessionRecordingChunk(dbauthz.AsSystemRestricted(ctx), database.InsertWorkspaceAgentSessionRecordingChunkParams{
+		RecordingID: recording.ID,
+		Data: []byte(`{"version":2,"width":80,"height":24,"timestamp":1700000000}` + "\n" +
+			`[0.5,"i","l"]` + "\n" +
+			`[1.0,"o","$ ls\r\n"]` + "\n" +
+			`[60.0,"o","README.md\r\n"]` + "\n"),
+	})
+	require.NoError(t, err)
+
+	t.Run("Recordings", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		inv, root := clitest.New(t, "sessions", "recordings", r.Workspace.Name, "--output", "json")
+		clitest.SetupConfig(t, client, root)
+		out := bytes.NewBuffer(nil)
+		inv.Stdout = out
+		err := inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+
+		var recordings []codersdk.WorkspaceAgentSessionRecording
+		require.NoError(t, json.Unmarshal(out.Bytes(), &recordings))
+		require.Len(t, recordings, 1)
+		require.Equal(t, recording.ID, recordings[0].ID)
+		require.Equal(t, "ssh", recordings[0].SessionType)
+	})
+
+	t.Run("RecordingsColumns", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		inv, root := clitest.New(t, "sessions", "recordings", r.Workspace.Name, "--column", "id,type")
+		clitest.SetupConfig(t, client, root)
+		out := bytes.NewBuffer(nil)
+		inv.Stdout = out
+		err := inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+		require.Contains(t, out.String(), recording.ID.String())
+		require.Contains(t, out.String(), "TYPE")
+		require.NotContains(t, out.String(), "DURATION")
+	})
+
+	t.Run("Replay", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		// The idle time limit keeps the minute long pause from slowing down
+		// the test.
+		inv, root := clitest.New(t, "sessions", "replay", recording.ID.String(), "--speed", "10", "--idle-time-limit", "100ms")
+		clitest.SetupConfig(t, client, root)
+		out := bytes.NewBuffer(nil)
+		inv.Stdout = out
+		err := inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+		require.Equal(t, "$ ls\r\nREADME.md\r\n", out.String())
+	})
+
+	t.Run("InvalidID", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		inv, root := clitest.New(t, "sessions", "replay", "not-a-uuid")
+		clitest.SetupConfig(t, client, root)
+		err := inv.WithContext(ctx).Run()
+		require.ErrorContains(t, err, "invalid session recording ID")
+	})
+}
+
+func TestSessionsLive(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("the commands are POSIX shell")
+	}
+
+	client, workspace, agentToken := setupWorkspaceForAgent(t)
+	_ = agenttest.New(t, client.URL, agentToken)
+	resources := coderdtest.AwaitWorkspaceAgents(t, client, workspace.ID)
+
+	ctx := testutil.Context(t, testutil.WaitLong)
+	id := uuid.New()
+	writer, err := workspacesdk.New(client).AgentReconnectingPTY(ctx, workspacesdk.WorkspaceAgentReconnectingPTYOpts{
+		AgentID:   resources[0].Agents[0].ID,
+		Reconnect: id,
+		Width:     80,
+		Height:    80,
+		Command:   "bash --norc",
+	})
+	require.NoError(t, err)
+	defer writer.Close()
+	require.Eventually(t, func() bool {
+		res, err := client.WorkspaceAgentReconnectingPTYs(ctx, resources[0].Agents[0].ID)
+		return err == nil && len(res.Sessions) == 1
+	}, testutil.WaitShort, testutil.IntervalFast)
+
+	t.Run("List", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		inv, root := clitest.New(t, "sessions", "ls", workspace.Name, "--output", "json")
+		clitest.SetupConfig(t, client, root)
+		out := bytes.NewBuffer(nil)
+		inv.Stdout = out
+		err := inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+
+		var sessions []codersdk.WorkspaceAgentReconnectingPTY
+		require.NoError(t, json.Unmarshal(out.Bytes(), &sessions))
+		require.Len(t, sessions, 1)
+		require.Equal(t, id, sessions[0].ID)
+		require.Equal(t, "bash --norc", sessions[0].Command)
+	})
+
+	t.Run("AttachUnknown", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		inv, root := clitest.New(t, "sessions", "attach", workspace.Name, uuid.NewString(), "--read-only")
+		clitest.SetupConfig(t, client, root)
+		err := inv.WithContext(ctx).Run()
+		require.ErrorContains(t, err, "no live session")
+	})
+
+	t.Run("AttachReadOnlyAndTakeover", func(t *testing.T) {
+		t.Parallel()
+
+		inv, root := clitest.New(t, "sessions", "attach", workspace.Name, id.String(), "--read-only", "--takeover")
+		clitest.SetupConfig(t, client, root)
+		err := inv.Run()
+		require.ErrorContains(t, err, "can't be used together")
+	})
+}
+
+func TestSessionsAttachReadOnly(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("the commands are POSIX shell")
+	}
+
+	client, workspace, agentToken := setupWorkspaceForAgent(t)
+	_ = agenttest.New(t, client.URL, agentToken)
+	resources := coderdtest.AwaitWorkspaceAgents(t, client, workspace.ID)
+
+	ctx := testutil.Context(t, testutil.WaitLong)
+	id := uuid.New()
+	writer, err := workspacesdk.New(client).AgentReconnectingPTY(ctx, workspacesdk.WorkspaceAgentReconnectingPTYOpts{
+		AgentID:   resources[0].Agents[0].ID,
+		Reconnect: id,
+		Width:     80,
+		Height:    80,
+		Command:   "bash --norc",
+	})
+	require.NoError(t, err)
+	defer writer.Close()
+	require.Eventually(t, func() bool {
+		res, err := client.WorkspaceAgentReconnectingPTYs(ctx, resources[0].Agents[0].ID)
+		return err == nil && len(res.Sessions) == 1
+	}, testutil.WaitShort, testutil.IntervalFast)
+	write := func(data string) {
+		t.Helper()
+		req, err := json.Marshal(workspacesdk.ReconnectingPTYRequest{Data: data})
+		require.NoError(t, err)
+		_, err = writer.Write(req)
+		require.NoError(t, err)
+	}
+
+	inv, root := clitest.New(t, "sessions", "attach", workspace.Name, id.String(), "--read-only")
+	clitest.SetupConfig(t, client, root)
+	pty := ptytest.New(t).Attach(inv)
+	w := clitest.StartWithWaiter(t, inv.WithContext(ctx))
+
+	// The viewer sees the output of the writer, and is detached once the
+	// session exits.
+	require.Eventually(t, func() bool {
+		res, err := client.WorkspaceAgentReconnectingPTYs(ctx, resources[0].Agents[0].ID)
+		return err == nil && len(res.Sessions) == 1 && len(res.Sessions[0].Connections) == 2
+	}, testutil.WaitShort, testutil.IntervalFast)
+	write("echo attached-$((1+1))\r")
+	pty.ExpectMatchContext(ctx, "attached-2")
+	write("exit\r")
+	w.RequireSuccess()
+}
@@ -0,0 +1,281 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/cli/cliui"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/serpent"
+)
+
+func (r *RootCmd) snapshot() *serpent.Command {
+	cmd := &serpent.Command{
+		Use:   "snapshot",
+		Short: "Take and restore snapshots of workspaces",
+		Long: "Snapshots are taken by the provisioner of the workspace's template, which must support them.\n" + FormatExamples(
+			Example{
+				Description: "Snapshot a workspace before upgrading it",
+				Command:     "coder snapshot create my-workspace before-upgrade",
+			},
+			Example{
+				Description: "Restore the snapshot once the workspace is stopped",
+				Command:     "coder snapshot restore my-workspace before-upgrade",
+			},
+		),
+		Aliases: []string{"snapshots"},
+		Handler: func(inv *serpent.Invocation) error {
+			return inv.Command.HelpHandler(inv)
+		},
+		Children: []*serpent.Command{
+			r.createSnapshot(),
+			r.listSnapshots(),
+			r.restoreSnapshot(),
+			r.deleteSnapshot(),
+		},
+	}
+	return cmd
+}
+
+func (r *RootCmd) createSnapshot() *serpent.Command {
+	var bflags buildFlags
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:   "create <workspace> [name]",
+		Short: "Take a snapshot of the latest build of a workspace",
+		Long:  "The snapshot is named after the current time unless a name is given.",
+		Middleware: serpent.Chain(
+			serpent.RequireRangeArgs(1, 2),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			ctx := inv.Context()
+			workspace, err := namedWorkspace(ctx, client, inv.Args[0])
+			if err != nil {
+				return err
+			}
+			req := codersdk.CreateWorkspaceSnapshotRequest{
+				Name: time.Now().UTC().Format("20060102-150405"),
+			}
+			if len(inv.Args) > 1 {
+				req.Name = inv.Args[1]
+			}
+			if bflags.provisionerLogDebug {
+				req.LogLevel = codersdk.ProvisionerLogLevelDebug
+			}
+
+			snapshot, err := client.CreateWorkspaceSnapshot(ctx, workspace.ID, req)
+			if err != nil {
+				return xerrors.Errorf("create snapshot: %w", err)
+			}
+			err = cliui.ProvisionerJob(ctx, inv.Stdout, cliui.ProvisionerJobOptions{
+				Fetch: func() (codersdk.ProvisionerJob, error) {
+					snapshot, err := client.WorkspaceSnapshot(ctx, workspace.ID, snapshot.ID)
+					return snapshot.Job, err
+				},
+				Logs: func() (<-chan codersdk.ProvisionerJobLog, io.Closer, error) {
+					return client.WorkspaceSnapshotLogsAfter(ctx, workspace.ID, snapshot.ID, 0)
+				},
+			})
+			if err != nil {
+				return xerrors.Errorf("take snapshot: %w", err)
+			}
+
+			_, _ = fmt.Fprintf(inv.Stdout, "\nSnapshot %s of the %s workspace has been taken!\n",
+				cliui.Keyword(snapshot.Name), cliui.Keyword(workspace.Name))
+			return nil
+		},
+	}
+	cmd.Options = append(cmd.Options, bflags.cliOptions()...)
+	return cmd
+}
+
+type snapshotListRow struct {
+	// For JSON format:
+	codersdk.WorkspaceSnapshot `table:"-"`
+
+	// For table format:
+	Name      string    `json:"-" table:"name"`
+	CreatedAt time.Time `json:"-" table:"created at,default_sort"`
+	Status    string    `json:"-" table:"status"`
+}
+
+func (r *RootCmd) listSnapshots() *serpent.Command {
+	var (
+		formatter = cliui.NewOutputFormatter(
+			cliui.TableFormat([]snapshotListRow{}, []string{"name", "created at", "status"}),
+			cliui.JSONFormat(),
+		)
+	)
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:     "list <workspace>",
+		Aliases: []string{"ls"},
+		Short:   "List the snapshots of a workspace",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(1),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			ctx := inv.Context()
+			workspace, err := namedWorkspace(ctx, client, inv.Args[0])
+			if err != nil {
+				return err
+			}
+			snapshots, err := client.WorkspaceSnapshots(ctx, workspace.ID)
+			if err != nil {
+				return xerrors.Errorf("list snapshots: %w", err)
+			}
+
+			if len(snapshots) == 0 {
+				cliui.Infof(inv.Stderr, "No snapshots found for the %s workspace.", workspace.Name)
+				return nil
+			}
+
+			rows := make([]snapshotListRow, 0, len(snapshots))
+			for _, snapshot := range snapshots {
+				rows = append(rows, snapshotListRow{
+					WorkspaceSnapshot: snapshot,
+					Name:              snapshot.Name,
+					CreatedAt:         snapshot.CreatedAt,
+					Status:            string(snapshot.Job.Status),
+				})
+			}
+			out, err := formatter.Format(ctx, rows)
+			if err != nil {
+				return err
+			}
+
+			_, err = fmt.Fprintln(inv.Stdout, out)
+			return err
+		},
+	}
+
+	formatter.AttachOptions(&cmd.Options)
+	return cmd
+}
+
+func (r *RootCmd) restoreSnapshot() *serpent.Command {
+	var bflags buildFlags
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:   "restore <workspace> <snapshot>",
+		Short: "Restore a snapshot into a stopped workspace",
+		Long:  "The workspace is left stopped, and starts from the restored snapshot on its next build.",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(2),
+			r.InitClient(client),
+		),
+		Options: serpent.OptionSet{
+			cliui.SkipPromptOption(),
+		},
+		Handler: func(inv *serpent.Invocation) error {
+			ctx := inv.Context()
+			workspace, err := namedWorkspace(ctx, client, inv.Args[0])
+			if err != nil {
+				return err
+			}
+			snapshot, err := namedSnapshot(ctx, client, workspace, inv.Args[1])
+			if err != nil {
+				return err
+			}
+
+			_, err = cliui.Prompt(inv, cliui.PromptOptions{
+				Text:      fmt.Sprintf("Restore snapshot %s? Changes made to the workspace since it was taken will be lost.", cliui.Keyword(snapshot.Name)),
+				IsConfirm: true,
+				Default:   cliui.ConfirmNo,
+			})
+			if err != nil {
+				return err
+			}
+
+			req := codersdk.RestoreWorkspaceSnapshotRequest{}
+			if bflags.provisionerLogDebug {
+				req.LogLevel = codersdk.ProvisionerLogLevelDebug
+			}
+			job, err := client.RestoreWorkspaceSnapshot(ctx, workspace.ID, snapshot.ID, req)
+			if err != nil {
+				return xerrors.Errorf("restore snapshot: %w", err)
+			}
+			err = cliui.ProvisionerJob(ctx, inv.Stdout, cliui.ProvisionerJobOptions{
+				Fetch: func() (codersdk.ProvisionerJob, error) {
+					return client.WorkspaceSnapshotRestore(ctx, workspace.ID, snapshot.ID, job.ID)
+				},
+				Logs: func() (<-chan codersdk.ProvisionerJobLog, io.Closer, error) {
+					return client.WorkspaceSnapshotRestoreLogsAfter(ctx, workspace.ID, snapshot.ID, job.ID, 0)
+				},
+			})
+			if err != nil {
+				return xerrors.Errorf("restore snapshot: %w", err)
+			}
+
+			_, _ = fmt.Fprintf(inv.Stdout, "\nSnapshot %s has been restored! Start the %s workspace with %s.\n",
+				cliui.Keyword(snapshot.Name), cliui.Keyword(workspace.Name),
+				cliui.Code("coder start "+workspace.Name))
+			return nil
+		},
+	}
+	cmd.Options = append(cmd.Options, bflags.cliOptions()...)
+	return cmd
+}
+
+func (r *RootCmd) deleteSnapshot() *serpent.Command {
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:   "delete <workspace> <snapshot>",
+		Short: "Delete a snapshot of a workspace",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(2),
+			r.InitClient(client),
+		),
+		Options: serpent.OptionSet{
+			cliui.SkipPromptOption(),
+		},
+		Handler: func(inv *serpent.Invocation) error {
+			ctx := inv.Context()
+			workspace, err := namedWorkspace(ctx, client, inv.Args[0])
+			if err != nil {
+				return err
+			}
+			snapshot, err := namedSnapshot(ctx, client, workspace, inv.Args[1])
+			if err != nil {
+				return err
+			}
+
+			_, err = cliui.Prompt(inv, cliui.PromptOptions{
+				Text:      fmt.Sprintf("Delete snapshot %s?", cliui.Keyword(snapshot.Name)),
+				IsConfirm: true,
+				Default:   cliui.ConfirmNo,
+			})
+			if err != nil {
+				return err
+			}
+
+			err = client.DeleteWorkspaceSnapshot(ctx, workspace.ID, snapshot.ID)
+			if err != nil {
+				return xerrors.Errorf("delete snapshot: %w", err)
+			}
+			_, _ = fmt.Fprintf(inv.Stdout, "Deleted snapshot %s.\n", cliui.Keyword(snapshot.Name))
+			return nil
+		},
+	}
+	return cmd
+}
+
+// namedSnapshot returns the snapshot of the workspace with the given name.
+func namedSnapshot(ctx context.Context, client *codersdk.Client, workspace codersdk.Workspace, name string) (codersdk.WorkspaceSnapshot, error) {
+	snapshots, err := client.WorkspaceSnapshots(ctx, workspace.ID)
+	if err != nil {
+		return codersdk.WorkspaceSnapshot{}, xerrors.Errorf("list snapshots: %w", err)
+	}
+	for _, snapshot := range snapshots {
+		if snapshot.Name == name {
+			return snapshot, nil
+		}
+	}
+	return codersdk.WorkspaceSnapshot{}, xerrors.Errorf("the %s workspace has no snapshot named %q", workspace.Name, name)
+}
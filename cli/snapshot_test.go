@@ -0,0 +1,75 @@
+package cli_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/cli/clitest"
+	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestSnapshot(t *testing.T) {
+	t.Parallel()
+
+	client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+	owner := coderdtest.CreateFirstUser(t, client)
+	member, _ := coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+	version := coderdtest.CreateTemplateVersion(t, client, owner.OrganizationID, nil)
+	coderdtest.AwaitTemplateVersionJobCompleted(t, client, version.ID)
+	template := coderdtest.CreateTemplate(t, client, owner.OrganizationID, version.ID)
+	workspace := coderdtest.CreateWorkspace(t, member, template.ID)
+	coderdtest.AwaitWorkspaceBuildJobCompleted(t, member, workspace.LatestBuild.ID)
+
+	ctx := testutil.Context(t, testutil.WaitLong)
+
+	inv, root := clitest.New(t, "snapshot", "create", workspace.Name, "before-upgrade")
+	clitest.SetupConfig(t, member, root)
+	out := bytes.NewBuffer(nil)
+	inv.Stdout = out
+	err := inv.WithContext(ctx).Run()
+	require.NoError(t, err)
+	require.Contains(t, out.String(), "has been taken")
+
+	inv, root = clitest.New(t, "snapshot", "list", workspace.Name, "--output", "json")
+	clitest.SetupConfig(t, member, root)
+	out = bytes.NewBuffer(nil)
+	inv.Stdout = out
+	err = inv.WithContext(ctx).Run()
+	require.NoError(t, err)
+	var snapshots []codersdk.WorkspaceSnapshot
+	require.NoError(t, json.Unmarshal(out.Bytes(), &snapshots))
+	require.Len(t, snapshots, 1)
+	require.Equal(t, "before-upgrade", snapshots[0].Name)
+	require.Equal(t, codersdk.ProvisionerJobSucceeded, snapshots[0].Job.Status)
+
+	// Restoring requires the workspace to be stopped.
+	inv, root = clitest.New(t, "snapshot", "restore", workspace.Name, "before-upgrade", "--yes")
+	clitest.SetupConfig(t, member, root)
+	err = inv.WithContext(ctx).Run()
+	require.Error(t, err)
+
+	coderdtest.MustTransitionWorkspace(t, member, workspace.ID, database.WorkspaceTransitionStart, database.WorkspaceTransitionStop)
+
+	inv, root = clitest.New(t, "snapshot", "restore", workspace.Name, "before-upgrade", "--yes")
+	clitest.SetupConfig(t, member, root)
+	out = bytes.NewBuffer(nil)
+	inv.Stdout = out
+	err = inv.WithContext(ctx).Run()
+	require.NoError(t, err)
+	require.Contains(t, out.String(), "has been restored")
+
+	inv, root = clitest.New(t, "snapshot", "delete", workspace.Name, "before-upgrade", "--yes")
+	clitest.SetupConfig(t, member, root)
+	err = inv.WithContext(ctx).Run()
+	require.NoError(t, err)
+
+	snapshots, err = member.WorkspaceSnapshots(ctx, workspace.ID)
+	require.NoError(t, err)
+	require.Empty(t, snapshots)
+}
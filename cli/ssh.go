@@ -29,6 +29,7 @@ import (
 
 	"cdr.dev/slog"
 	"cdr.dev/slog/sloggers/sloghuman"
+	"github.com/coder/coder/v2/agent/agentssh"
 	"github.com/coder/coder/v2/cli/cliui"
 	"github.com/coder/coder/v2/cli/cliutil"
 	"github.com/coder/coder/v2/coderd/autobuild/notify"
@@ -65,6 +66,8 @@ func (r *RootCmd) ssh() *serpent.Command {
 		logDirPath       string
 		remoteForwards   []string
 		env              []string
+		container        string
+		containerUser    string
 		usageApp         string
 		disableAutostart bool
 		appearanceConfig codersdk.AppearanceConfig
@@ -179,6 +182,19 @@ func (r *RootCmd) ssh() *serpent.Command {
 				}
 				parsedEnv = append(parsedEnv, [2]string{k, v})
 			}
+			if container != "" {
+				if stdio {
+					return xerrors.New("--container can't be used in the stdio mode")
+				}
+				// The agent runs the session in the container instead of the
+				// workspace when these are set.
+				parsedEnv = append(parsedEnv, [2]string{agentssh.ContainerEnvironmentVariable, container})
+				if containerUser != "" {
+					parsedEnv = append(parsedEnv, [2]string{agentssh.ContainerUserEnvironmentVariable, containerUser})
+				}
+			} else if containerUser != "" {
+				return xerrors.New("--container-user requires --container")
+			}
 
 			workspace, workspaceAgent, err := getWorkspaceAndAgent(ctx, inv, client, !disableAutostart, inv.Args[0])
 			if err != nil {
@@ -533,6 +549,18 @@ func (r *RootCmd) ssh() *serpent.Command {
 			FlagShorthand: "e",
 			Value:         serpent.StringArrayOf(&env),
 		},
+		{
+			Flag:        "container",
+			Description: "Start the session in the Docker or Podman container of the workspace with this name or ID, e.g. a devcontainer.",
+			Env:         "CODER_SSH_CONTAINER",
+			Value:       serpent.StringOf(&container),
+		},
+		{
+			Flag:        "container-user",
+			Description: "The user to start the session as in the container. Defaults to the default user of the container.",
+			Env:         "CODER_SSH_CONTAINER_USER",
+			Value:       serpent.StringOf(&containerUser),
+		},
 		{
 			Flag:        "usage-app",
 			Description: "Specifies the usage app to use for workspace activity tracking.",
@@ -34,6 +34,8 @@ import (
 	"cdr.dev/slog/sloggers/slogtest"
 
 	"github.com/coder/coder/v2/agent"
+	"github.com/coder/coder/v2/agent/agentcontainers"
+	"github.com/coder/coder/v2/agent/agentcontainers/agentcontainerstest"
 	"github.com/coder/coder/v2/agent/agentssh"
 	"github.com/coder/coder/v2/agent/agenttest"
 	agentproto "github.com/coder/coder/v2/agent/proto"
@@ -1013,6 +1015,57 @@ func TestSSH(t *testing.T) {
 		pty.WriteLine("exit")
 	})
 
+	t.Run("Container", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("Test not supported on windows")
+		}
+
+		t.Parallel()
+
+		// The fake reports the container instead of exec'ing into it.
+		fake := &agentcontainerstest.FakeClient{
+			Exec: func(container string, opts agentcontainers.ExecOptions, _ ...string) []string {
+				return []string{"echo", "exec", container, "as", opts.User}
+			},
+		}
+		client, workspace, agentToken := setupWorkspaceForAgent(t)
+		_ = agenttest.New(t, client.URL, agentToken, func(o *agent.Options) {
+			o.ContainerClient = fake
+		})
+		coderdtest.AwaitWorkspaceAgents(t, client, workspace.ID)
+
+		inv, root := clitest.New(t,
+			"ssh",
+			workspace.Name,
+			"--container",
+			"vibrant_tesla",
+			"--container-user",
+			"node",
+		)
+		clitest.SetupConfig(t, client, root)
+
+		pty := ptytest.New(t).Attach(inv)
+		inv.Stderr = pty.Output()
+
+		ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitSuperLong)
+		defer cancel()
+
+		w := clitest.StartWithWaiter(t, inv.WithContext(ctx))
+		pty.ExpectMatchContext(ctx, "exec vibrant_tesla as node")
+		w.RequireSuccess()
+	})
+
+	t.Run("ContainerUserWithoutContainer", func(t *testing.T) {
+		t.Parallel()
+
+		client, workspace, _ := setupWorkspaceForAgent(t)
+		inv, root := clitest.New(t, "ssh", workspace.Name, "--container-user", "node")
+		clitest.SetupConfig(t, client, root)
+
+		err := inv.Run()
+		require.ErrorContains(t, err, "--container-user requires --container")
+	})
+
 	t.Run("RemoteForwardUnixSocket", func(t *testing.T) {
 		if runtime.GOOS == "windows" {
 			t.Skip("Test not supported on windows")
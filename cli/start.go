@@ -111,7 +111,7 @@ func buildWorkspaceStartRequest(inv *serpent.Invocation, client *codersdk.Client
 		return codersdk.CreateWorkspaceBuildRequest{}, xerrors.Errorf("unable to parse rich parameter defaults: %w", err)
 	}
 
-	buildParameters, err := prepWorkspaceBuild(inv, client, prepWorkspaceBuildArgs{
+	args := prepWorkspaceBuildArgs{
 		Action:              action,
 		TemplateVersionID:   version,
 		NewWorkspaceName:    workspace.Name,
@@ -123,7 +123,11 @@ func buildWorkspaceStartRequest(inv *serpent.Invocation, client *codersdk.Client
 		RichParameters:        cliRichParameters,
 		RichParameterFile:     parameterFlags.richParameterFile,
 		RichParameterDefaults: cliRichParameterDefaults,
-	})
+	}
+	if buildFlags.preview {
+		args.PreviewWorkspaceID = workspace.ID
+	}
+	buildParameters, err := prepWorkspaceBuild(inv, client, args)
 	if err != nil {
 		return codersdk.CreateWorkspaceBuildRequest{}, err
 	}
@@ -1,14 +1,18 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/spf13/afero"
 	"golang.org/x/xerrors"
 
 	"github.com/coder/coder/v2/cli/clistat"
 	"github.com/coder/coder/v2/cli/cliui"
+	"github.com/coder/coder/v2/codersdk"
 	"github.com/coder/serpent"
 )
 
@@ -28,29 +32,71 @@ func initStatterMW(tgt **clistat.Statter, fs afero.Fs) serpent.MiddlewareFunc {
 
 func (r *RootCmd) stat() *serpent.Command {
 	var (
-		st        *clistat.Statter
-		fs        = afero.NewReadOnlyFs(afero.NewOsFs())
-		formatter = cliui.NewOutputFormatter(
-			cliui.TableFormat([]statsRow{}, []string{
-				"host cpu",
-				"host memory",
-				"home disk",
-				"container cpu",
-				"container memory",
-			}),
+		historyKey    string
+		workspaceName string
+		since         time.Duration
+		st            *clistat.Statter
+		fs            = afero.NewReadOnlyFs(afero.NewOsFs())
+		formatter     = cliui.NewOutputFormatter(
+			statTableFormat{
+				OutputFormat: cliui.TableFormat([]statsRow{}, []string{
+					"host cpu",
+					"host memory",
+					"home disk",
+					"container cpu",
+					"container memory",
+				}),
+			},
 			cliui.JSONFormat(),
 		)
 	)
+	client := new(codersdk.Client)
 	cmd := &serpent.Command{
-		Use:        "stat",
-		Short:      "Show resource usage for the current workspace.",
+		Use:   "stat",
+		Short: "Show resource usage for the current workspace.",
+		Long: "With --history, the recorded values of a metadata key of a workspace agent are shown instead, " +
+			"such as a key that reports the CPU usage of the workspace.\n" + FormatExamples(
+			Example{
+				Description: "Show the CPU usage of the current workspace in the last 6 hours",
+				Command:     "coder stat --history cpu --since 6h",
+			},
+			Example{
+				Description: "Show the memory usage of the main agent of another workspace",
+				Command:     "coder stat --history mem --workspace my-workspace.main",
+			},
+		),
 		Middleware: initStatterMW(&st, fs),
 		Children: []*serpent.Command{
 			r.statCPU(fs),
 			r.statMem(fs),
 			r.statDisk(fs),
 		},
+		Options: serpent.OptionSet{
+			{
+				Flag:        "history",
+				Description: "Show the recorded values of this metadata key of a workspace agent, instead of the resource usage of this machine.",
+				Value:       serpent.StringOf(&historyKey),
+			},
+			{
+				Flag:        "workspace",
+				Env:         "CODER_WORKSPACE_NAME",
+				Description: "The workspace to show the metadata history of, optionally as <workspace>.<agent>. Defaults to the current workspace.",
+				Value:       serpent.StringOf(&workspaceName),
+			},
+			{
+				Flag:        "since",
+				Description: "Only show the metadata history of this long ago onwards.",
+				Default:     "1h",
+				Value:       serpent.DurationOf(&since),
+			},
+		},
 		Handler: func(inv *serpent.Invocation) error {
+			if historyKey != "" {
+				return r.InitClient(client)(func(inv *serpent.Invocation) error {
+					return statHistory(inv, client, formatter, workspaceName, historyKey, since)
+				})(inv)
+			}
+
 			var sr statsRow
 
 			// Get CPU measurements first.
@@ -283,6 +329,75 @@ func (*RootCmd) statDisk(fs afero.Fs) *serpent.Command {
 	return cmd
 }
 
+// statHistory writes the recorded values of a metadata key of the agent of
+// the given workspace.
+func statHistory(inv *serpent.Invocation, client *codersdk.Client, formatter *cliui.OutputFormatter, workspaceName, key string, since time.Duration) error {
+	ctx := inv.Context()
+	if workspaceName == "" {
+		return xerrors.New("--workspace is required outside of a workspace")
+	}
+	// Inside a workspace, pick the agent this command runs in.
+	if agentName := inv.Environ.Get("CODER_WORKSPACE_AGENT_NAME"); agentName != "" && !strings.Contains(workspaceName, ".") {
+		workspaceName += "." + agentName
+	}
+	_, agent, err := getWorkspaceAndAgent(ctx, inv, client, false, workspaceName)
+	if err != nil {
+		return err
+	}
+
+	req := codersdk.WorkspaceAgentMetadataHistoryRequest{}
+	if since > 0 {
+		req.After = time.Now().Add(-since)
+	}
+	samples, err := client.WorkspaceAgentMetadataHistory(ctx, agent.ID, key, req)
+	if err != nil {
+		return xerrors.Errorf("get metadata history: %w", err)
+	}
+	if len(samples) == 0 {
+		cliui.Infof(inv.Stderr, "No values of %s were recorded in the last %s.", key, since)
+		return nil
+	}
+
+	rows := make([]metadataSampleRow, 0, len(samples))
+	for _, sample := range samples {
+		rows = append(rows, metadataSampleRow{
+			WorkspaceAgentMetadataSample: sample,
+			CollectedAt:                  sample.CollectedAt,
+			Value:                        strings.TrimSpace(sample.Value),
+			Error:                        sample.Error,
+		})
+	}
+	out, err := formatter.Format(ctx, rows)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(inv.Stdout, out)
+	return err
+}
+
+type metadataSampleRow struct {
+	// For JSON format:
+	codersdk.WorkspaceAgentMetadataSample `table:"-"`
+
+	// For table format:
+	CollectedAt time.Time `json:"-" table:"collected at,default_sort"`
+	Value       string    `json:"-" table:"value"`
+	Error       string    `json:"-" table:"error"`
+}
+
+// statTableFormat formats metadata history as a table of its own, since the
+// columns of the stats table don't apply to it.
+type statTableFormat struct {
+	cliui.OutputFormat
+}
+
+func (f statTableFormat) Format(ctx context.Context, data any) (string, error) {
+	if rows, ok := data.([]metadataSampleRow); ok {
+		return cliui.DisplayTable(rows, "collected at", nil)
+	}
+	return f.OutputFormat.Format(ctx, data)
+}
+
 type statsRow struct {
 	HostCPU         *clistat.Result `json:"host_cpu" table:"host cpu,default_sort"`
 	HostMemory      *clistat.Result `json:"host_memory" table:"host memory"`
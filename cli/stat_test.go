@@ -6,11 +6,16 @@ import (
 	"encoding/json"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
+	agentproto "github.com/coder/coder/v2/agent/proto"
 	"github.com/coder/coder/v2/cli/clistat"
 	"github.com/coder/coder/v2/cli/clitest"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/codersdk/agentsdk"
+	"github.com/coder/coder/v2/provisionersdk/proto"
 	"github.com/coder/coder/v2/testutil"
 )
 
@@ -183,3 +188,73 @@ func TestStatDiskCmd(t *testing.T) {
 		require.Contains(t, err.Error(), `not found: "/this/path/does/not/exist"`)
 	})
 }
+
+func TestStatHistory(t *testing.T) {
+	t.Parallel()
+
+	client, workspace, agentToken := setupWorkspaceForAgent(t, func(agents []*proto.Agent) []*proto.Agent {
+		agents[0].Metadata = []*proto.Agent_Metadata{{
+			DisplayName: "CPU",
+			Key:         "cpu",
+			Script:      "echo 12%",
+			Interval:    10,
+			Timeout:     3,
+		}}
+		return agents
+	})
+
+	ctx := testutil.Context(t, testutil.WaitMedium)
+	agentClient := agentsdk.New(client.URL)
+	agentClient.SetSessionToken(agentToken)
+	conn, err := agentClient.ConnectRPC(ctx)
+	require.NoError(t, err)
+	defer func() {
+		_ = conn.Close()
+	}()
+	_, err = agentproto.NewDRPCAgentClient(conn).BatchUpdateMetadata(ctx, &agentproto.BatchUpdateMetadataRequest{
+		Metadata: []*agentproto.Metadata{{
+			Key: "cpu",
+			Result: agentsdk.ProtoFromMetadataResult(codersdk.WorkspaceAgentMetadataResult{
+				CollectedAt: time.Now(),
+				Value:       "12%",
+			}),
+		}},
+	})
+	require.NoError(t, err)
+
+	t.Run("Table", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitMedium)
+		inv, root := clitest.New(t, "stat", "--history", "cpu", "--workspace", workspace.Name)
+		clitest.SetupConfig(t, client, root)
+		buf := new(bytes.Buffer)
+		inv.Stdout = buf
+		err := inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+		require.Contains(t, buf.String(), "COLLECTED AT")
+		require.Contains(t, buf.String(), "12%")
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitMedium)
+		inv, root := clitest.New(t, "stat", "--history", "cpu", "--workspace", workspace.Name, "--output=json")
+		clitest.SetupConfig(t, client, root)
+		buf := new(bytes.Buffer)
+		inv.Stdout = buf
+		err := inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+		var samples []codersdk.WorkspaceAgentMetadataSample
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &samples))
+		require.Len(t, samples, 1)
+		require.Equal(t, "12%", samples[0].Value)
+	})
+
+	t.Run("NoWorkspace", func(t *testing.T) {
+		t.Parallel()
+		inv, root := clitest.New(t, "stat", "--history", "cpu")
+		clitest.SetupConfig(t, client, root)
+		err := inv.WithContext(ctx).Run()
+		require.ErrorContains(t, err, "--workspace is required")
+	})
+}
@@ -1,8 +1,12 @@
 package cli
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"os"
 	"time"
 
 	"golang.org/x/xerrors"
@@ -35,6 +39,9 @@ func (r *RootCmd) templateEdit() *serpent.Command {
 		requireActiveVersion           bool
 		deprecationMessage             string
 		disableEveryone                bool
+		sessionRecording               bool
+		portForwardingPolicyFile       string
+		agentActivityBump              bool
 		orgContext                     = NewOrganizationContext()
 	)
 	client := new(codersdk.Client)
@@ -166,6 +173,25 @@ func (r *RootCmd) templateEdit() *serpent.Command {
 				disableEveryoneGroup = disableEveryone
 			}
 
+			var recordSessions *bool
+			if userSetOption(inv, "session-recording") {
+				recordSessions = &sessionRecording
+			}
+
+			var bumpOnAgentActivity *bool
+			if userSetOption(inv, "agent-activity-bump") {
+				bumpOnAgentActivity = &agentActivityBump
+			}
+
+			var portForwardingPolicy *codersdk.PortForwardingPolicy
+			if userSetOption(inv, "port-forwarding-policy") {
+				policy, err := readPortForwardingPolicy(inv, portForwardingPolicyFile)
+				if err != nil {
+					return err
+				}
+				portForwardingPolicy = &policy
+			}
+
 			req := codersdk.UpdateTemplateMeta{
 				Name:               name,
 				DisplayName:        displayName,
@@ -189,6 +215,9 @@ func (r *RootCmd) templateEdit() *serpent.Command {
 				RequireActiveVersion:           requireActiveVersion,
 				DeprecationMessage:             deprecated,
 				DisableEveryoneGroupAccess:     disableEveryoneGroup,
+				SessionRecording:               recordSessions,
+				PortForwardingPolicy:           portForwardingPolicy,
+				AgentActivityBump:              bumpOnAgentActivity,
 			}
 
 			_, err = client.UpdateTemplateMeta(inv.Context(), template.ID, req)
@@ -301,9 +330,55 @@ func (r *RootCmd) templateEdit() *serpent.Command {
 			Value:   serpent.BoolOf(&disableEveryone),
 			Default: "false",
 		},
+		{
+			Flag: "session-recording",
+			Description: "Record the terminal sessions of workspaces created from this template. " +
+				"Recordings can be replayed with \"coder sessions replay\" by users that can read the audit logs.",
+			Value: serpent.BoolOf(&sessionRecording),
+		},
+		{
+			Flag: "port-forwarding-policy",
+			Description: "Path to a JSON file with the policy that restricts SSH port forwards into workspaces of this template, or \"-\" to read it from stdin. " +
+				"Use an empty object to remove all restrictions. See https://coder.com/docs/networking/port-forwarding#port-forwarding-policy for the format.",
+			Value: serpent.StringOf(&portForwardingPolicyFile),
+		},
+		{
+			Flag: "agent-activity-bump",
+			Description: "Bump the deadline of workspaces created from this template while their agents report activity without a connection, e.g. a running build. " +
+				"The sources of activity are configured with the --activity flags of the agent.",
+			Value: serpent.BoolOf(&agentActivityBump),
+		},
 		cliui.SkipPromptOption(),
 	}
 	orgContext.AttachOptions(cmd)
 
 	return cmd
 }
+
+// readPortForwardingPolicy reads a port forwarding policy from a JSON file,
+// or from stdin if path is "-".
+func readPortForwardingPolicy(inv *serpent.Invocation, path string) (codersdk.PortForwardingPolicy, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if path == "-" {
+		data, err = io.ReadAll(inv.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return codersdk.PortForwardingPolicy{}, xerrors.Errorf("read port forwarding policy: %w", err)
+	}
+
+	var policy codersdk.PortForwardingPolicy
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&policy); err != nil {
+		return codersdk.PortForwardingPolicy{}, xerrors.Errorf("parse port forwarding policy: %w", err)
+	}
+	if err := policy.Validate(); err != nil {
+		return codersdk.PortForwardingPolicy{}, xerrors.Errorf("invalid port forwarding policy: %w", err)
+	}
+	return policy, nil
+}
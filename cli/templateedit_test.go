@@ -9,6 +9,8 @@ import (
 	"net/http/httptest"
 	"net/http/httputil"
 	"net/url"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"sync/atomic"
@@ -838,6 +840,116 @@ func TestTemplateEdit(t *testing.T) {
 		require.Error(t, err)
 		require.ErrorContains(t, err, "appears to be an AGPL deployment")
 	})
+	t.Run("SessionRecording", func(t *testing.T) {
+		t.Parallel()
+		client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+		owner := coderdtest.CreateFirstUser(t, client)
+
+		version := coderdtest.CreateTemplateVersion(t, client, owner.OrganizationID, nil)
+		_ = coderdtest.AwaitTemplateVersionJobCompleted(t, client, version.ID)
+		template := coderdtest.CreateTemplate(t, client, owner.OrganizationID, version.ID)
+		require.False(t, template.SessionRecording)
+
+		inv, root := clitest.New(t, "templates", "edit", template.Name, "--session-recording")
+		clitest.SetupConfig(t, client, root)
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		err := inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+
+		updated, err := client.Template(ctx, template.ID)
+		require.NoError(t, err)
+		require.True(t, updated.SessionRecording)
+
+		// Other edits leave the setting unchanged.
+		inv, root = clitest.New(t, "templates", "edit", template.Name, "--description", "recorded")
+		clitest.SetupConfig(t, client, root)
+		err = inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+
+		updated, err = client.Template(ctx, template.ID)
+		require.NoError(t, err)
+		require.True(t, updated.SessionRecording)
+		require.Equal(t, "recorded", updated.Description)
+	})
+	t.Run("AgentActivityBump", func(t *testing.T) {
+		t.Parallel()
+		client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+		owner := coderdtest.CreateFirstUser(t, client)
+
+		version := coderdtest.CreateTemplateVersion(t, client, owner.OrganizationID, nil)
+		_ = coderdtest.AwaitTemplateVersionJobCompleted(t, client, version.ID)
+		template := coderdtest.CreateTemplate(t, client, owner.OrganizationID, version.ID)
+		require.False(t, template.AgentActivityBump)
+
+		inv, root := clitest.New(t, "templates", "edit", template.Name, "--agent-activity-bump")
+		clitest.SetupConfig(t, client, root)
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		err := inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+
+		updated, err := client.Template(ctx, template.ID)
+		require.NoError(t, err)
+		require.True(t, updated.AgentActivityBump)
+
+		inv, root = clitest.New(t, "templates", "edit", template.Name, "--agent-activity-bump=false")
+		clitest.SetupConfig(t, client, root)
+		err = inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+
+		updated, err = client.Template(ctx, template.ID)
+		require.NoError(t, err)
+		require.False(t, updated.AgentActivityBump)
+	})
+	t.Run("PortForwardingPolicy", func(t *testing.T) {
+		t.Parallel()
+		client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+		owner := coderdtest.CreateFirstUser(t, client)
+
+		version := coderdtest.CreateTemplateVersion(t, client, owner.OrganizationID, nil)
+		_ = coderdtest.AwaitTemplateVersionJobCompleted(t, client, version.ID)
+		template := coderdtest.CreateTemplate(t, client, owner.OrganizationID, version.ID)
+		require.Equal(t, codersdk.PortForwardingPolicy{}, template.PortForwardingPolicy)
+
+		policyFile := filepath.Join(t.TempDir(), "policy.json")
+		err := os.WriteFile(policyFile, []byte(`{"direct_tcpip": {"allowed_cidrs": ["127.0.0.0/8"], "allowed_ports": ["8000-8999"]}}`), 0o600)
+		require.NoError(t, err)
+
+		inv, root := clitest.New(t, "templates", "edit", template.Name, "--port-forwarding-policy", policyFile)
+		clitest.SetupConfig(t, client, root)
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		err = inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+
+		updated, err := client.Template(ctx, template.ID)
+		require.NoError(t, err)
+		require.Equal(t, codersdk.PortForwardingPolicy{
+			DirectTCPIP: &codersdk.PortForwardingRule{
+				AllowedCIDRs: []string{"127.0.0.0/8"},
+				AllowedPorts: []string{"8000-8999"},
+			},
+		}, updated.PortForwardingPolicy)
+
+		// Invalid policies are rejected before they're sent.
+		inv, root = clitest.New(t, "templates", "edit", template.Name, "--port-forwarding-policy", "-")
+		clitest.SetupConfig(t, client, root)
+		inv.Stdin = strings.NewReader(`{"remote_forward": {"allowed_cidrs": [], "allowed_ports": ["0"]}}`)
+		err = inv.WithContext(ctx).Run()
+		require.ErrorContains(t, err, "remote_forward")
+
+		// An empty policy removes the restrictions.
+		inv, root = clitest.New(t, "templates", "edit", template.Name, "--port-forwarding-policy", "-")
+		clitest.SetupConfig(t, client, root)
+		inv.Stdin = strings.NewReader(`{}`)
+		err = inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+
+		updated, err = client.Template(ctx, template.ID)
+		require.NoError(t, err)
+		require.Equal(t, codersdk.PortForwardingPolicy{}, updated.PortForwardingPolicy)
+	})
 	t.Run("DefaultValues", func(t *testing.T) {
 		t.Parallel()
 		client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
@@ -31,6 +31,8 @@ func (r *RootCmd) templatePush() *serpent.Command {
 		commandLineVariables []string
 		alwaysPrompt         bool
 		provisionerTags      []string
+		iacEngine            string
+		iacVersion           string
 		uploadFlags          templateUploadFlags
 		activate             bool
 		orgContext           = NewOrganizationContext()
@@ -133,6 +135,8 @@ func (r *RootCmd) templatePush() *serpent.Command {
 				Provisioner:        codersdk.ProvisionerType(provisioner),
 				FileID:             resp.ID,
 				ProvisionerTags:    tags,
+				IaCEngine:          iacEngine,
+				IaCVersion:         iacVersion,
 				UserVariableValues: userVariableValues,
 			}
 
@@ -215,6 +219,16 @@ func (r *RootCmd) templatePush() *serpent.Command {
 			Description: "Specify a set of tags to target provisioner daemons.",
 			Value:       serpent.StringArrayOf(&provisionerTags),
 		},
+		{
+			Flag:        "iac-engine",
+			Description: "Require the template version to be provisioned by this IaC engine, run by provisioner daemons that have it installed from a mirror.",
+			Value:       serpent.EnumOf(&iacEngine, provisionersdk.IaCEngines...),
+		},
+		{
+			Flag:        "iac-version",
+			Description: "Constrain the version of the IaC engine, in the syntax of Terraform's required_version, e.g. \"~> 1.8.0\". Requires --iac-engine.",
+			Value:       serpent.StringOf(&iacVersion),
+		},
 		{
 			Flag:        "name",
 			Description: "Specify a name for the new template version. It will be automatically generated if not provided.",
@@ -391,8 +405,12 @@ type createValidTemplateVersionArgs struct {
 	// ReuseParameters will attempt to reuse params from the Template field
 	// before prompting the user. Set to false to always prompt for param
 	// values.
-	ReuseParameters    bool
-	ProvisionerTags    map[string]string
+	ReuseParameters bool
+	ProvisionerTags map[string]string
+	// IaCEngine and IaCVersion optionally require the version to be
+	// provisioned by a version of an IaC engine.
+	IaCEngine          string
+	IaCVersion         string
 	UserVariableValues []codersdk.VariableValue
 }
 
@@ -400,13 +418,15 @@ func createValidTemplateVersion(inv *serpent.Invocation, args createValidTemplat
 	client := args.Client
 
 	req := codersdk.CreateTemplateVersionRequest{
-		Name:               args.Name,
-		Message:            args.Message,
-		StorageMethod:      codersdk.ProvisionerStorageMethodFile,
-		FileID:             args.FileID,
-		Provisioner:        args.Provisioner,
-		ProvisionerTags:    args.ProvisionerTags,
-		UserVariableValues: args.UserVariableValues,
+		Name:                 args.Name,
+		Message:              args.Message,
+		StorageMethod:        codersdk.ProvisionerStorageMethodFile,
+		FileID:               args.FileID,
+		Provisioner:          args.Provisioner,
+		ProvisionerTags:      args.ProvisionerTags,
+		IaCEngine:            args.IaCEngine,
+		IaCVersionConstraint: args.IaCVersion,
+		UserVariableValues:   args.UserVariableValues,
 	}
 	if args.Template != nil {
 		req.TemplateID = args.Template.ID
@@ -19,7 +19,8 @@ func (r *RootCmd) update() *serpent.Command {
 		Annotations: workspaceCommand,
 		Use:         "update <workspace>",
 		Short:       "Will update and start a given workspace if it is out of date",
-		Long:        "Use --always-prompt to change the parameter values of the workspace.",
+		Long: "Use --always-prompt to change the parameter values of the workspace. " +
+			"Use --preview to show the changes to the resources of the workspace without updating it.",
 		Middleware: serpent.Chain(
 			serpent.RequireNArgs(1),
 			r.InitClient(client),
@@ -34,6 +35,14 @@ func (r *RootCmd) update() *serpent.Command {
 				return nil
 			}
 
+			if bflags.preview {
+				_, err = buildWorkspaceStartRequest(inv, client, workspace, parameterFlags, bflags, WorkspaceUpdate)
+				if err != nil {
+					return xerrors.Errorf("preview update: %w", err)
+				}
+				return nil
+			}
+
 			build, err := startWorkspace(inv, client, workspace, parameterFlags, bflags, WorkspaceUpdate)
 			if err != nil {
 				return xerrors.Errorf("start workspace: %w", err)
@@ -57,5 +66,10 @@ func (r *RootCmd) update() *serpent.Command {
 
 	cmd.Options = append(cmd.Options, parameterFlags.allOptions()...)
 	cmd.Options = append(cmd.Options, bflags.cliOptions()...)
+	cmd.Options = append(cmd.Options, serpent.Option{
+		Flag:        "preview",
+		Description: "Show the changes the update makes to the resources of the workspace, without updating it.",
+		Value:       serpent.BoolOf(&bflags.preview),
+	})
 	return cmd
 }
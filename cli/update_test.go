@@ -78,6 +78,61 @@ func TestUpdate(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, version2.ID.String(), ws.LatestBuild.TemplateVersionID.String())
 	})
+
+	t.Run("Preview", func(t *testing.T) {
+		t.Parallel()
+
+		client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+		owner := coderdtest.CreateFirstUser(t, client)
+		member, memberUser := coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+		version1 := coderdtest.CreateTemplateVersion(t, client, owner.OrganizationID, nil)
+		coderdtest.AwaitTemplateVersionJobCompleted(t, client, version1.ID)
+		template := coderdtest.CreateTemplate(t, client, owner.OrganizationID, version1.ID)
+		workspace := coderdtest.CreateWorkspace(t, member, template.ID)
+		coderdtest.AwaitWorkspaceBuildJobCompleted(t, member, workspace.LatestBuild.ID)
+
+		version2 := coderdtest.UpdateTemplateVersion(t, client, owner.OrganizationID, &echo.Responses{
+			Parse: echo.ParseComplete,
+			ProvisionPlan: []*proto.Response{{
+				Type: &proto.Response_Plan{
+					Plan: &proto.PlanComplete{
+						ResourceChanges: []*proto.ResourceChange{{
+							Address: "docker_container.workspace",
+							Type:    "docker_container",
+							Name:    "workspace",
+							Action:  proto.ResourceChange_UPDATE,
+							Attributes: []*proto.ResourceChange_AttributeChange{{
+								Name:   "image",
+								Before: `"ubuntu:22.04"`,
+								After:  `"ubuntu:24.04"`,
+							}},
+						}},
+					},
+				},
+			}},
+			ProvisionApply: echo.ApplyComplete,
+		}, template.ID)
+		coderdtest.AwaitTemplateVersionJobCompleted(t, client, version2.ID)
+		err := client.UpdateActiveTemplateVersion(context.Background(), template.ID, codersdk.UpdateActiveTemplateVersion{
+			ID: version2.ID,
+		})
+		require.NoError(t, err)
+
+		inv, root := clitest.New(t, "update", workspace.Name, "--preview")
+		clitest.SetupConfig(t, member, root)
+		pty := ptytest.New(t).Attach(inv)
+		w := clitest.StartWithWaiter(t, inv)
+
+		pty.ExpectMatch("docker_container.workspace will be updated in place")
+		pty.ExpectMatch(`image: "ubuntu:22.04" → "ubuntu:24.04"`)
+		pty.ExpectMatch("0 to create, 1 to update, 0 to replace, 0 to delete.")
+		w.RequireSuccess()
+
+		// The preview doesn't update the workspace.
+		ws, err := member.WorkspaceByOwnerAndName(context.Background(), memberUser.Username, workspace.Name, codersdk.WorkspaceOptions{})
+		require.NoError(t, err)
+		require.Equal(t, version1.ID, ws.LatestBuild.TemplateVersionID)
+	})
 }
 
 func TestUpdateWithRichParameters(t *testing.T) {
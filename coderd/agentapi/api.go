@@ -43,6 +43,8 @@ type API struct {
 	*MetadataAPI
 	*LogsAPI
 	*ScriptsAPI
+	*SessionRecordingsAPI
+	*ContainersAPI
 	*tailnet.DRPCService
 
 	mu                sync.Mutex
@@ -74,6 +76,7 @@ type Options struct {
 	DerpMapUpdateFrequency    time.Duration
 	ExternalAuthConfigs       []*externalauth.Config
 	Experiments               codersdk.Experiments
+	RecordMetadataHistory     bool
 
 	// Optional:
 	// WorkspaceID avoids a future lookup to find the workspace ID by setting
@@ -139,10 +142,11 @@ func New(opts Options) *API {
 	}
 
 	api.MetadataAPI = &MetadataAPI{
-		AgentFn:  api.agent,
-		Database: opts.Database,
-		Pubsub:   opts.Pubsub,
-		Log:      opts.Log,
+		AgentFn:       api.agent,
+		Database:      opts.Database,
+		Pubsub:        opts.Pubsub,
+		Log:           opts.Log,
+		RecordHistory: opts.RecordMetadataHistory,
 	}
 
 	api.LogsAPI = &LogsAPI{
@@ -157,6 +161,19 @@ func New(opts Options) *API {
 		Database: opts.Database,
 	}
 
+	api.SessionRecordingsAPI = &SessionRecordingsAPI{
+		AgentFn:       api.agent,
+		WorkspaceIDFn: api.workspaceID,
+		Database:      opts.Database,
+		Log:           opts.Log,
+	}
+
+	api.ContainersAPI = &ContainersAPI{
+		AgentFn:  api.agent,
+		Database: opts.Database,
+		Log:      opts.Log,
+	}
+
 	api.DRPCService = &tailnet.DRPCService{
 		CoordPtr:                opts.TailnetCoordinator,
 		Logger:                  opts.Log,
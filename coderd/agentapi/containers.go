@@ -0,0 +1,82 @@
+package agentapi
+
+import (
+	"context"
+	"encoding/json"
+
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
+	agentproto "github.com/coder/coder/v2/agent/proto"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
+	"github.com/coder/coder/v2/codersdk/agentsdk"
+)
+
+type ContainersAPI struct {
+	AgentFn  func(context.Context) (database.WorkspaceAgent, error)
+	Database database.Store
+	Log      slog.Logger
+}
+
+// UpdateContainers replaces the containers stored for the agent with the
+// ones it reports.
+func (a *ContainersAPI) UpdateContainers(ctx context.Context, req *agentproto.UpdateContainersRequest) (*agentproto.UpdateContainersResponse, error) {
+	workspaceAgent, err := a.AgentFn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reportedAt := dbtime.Now()
+	params := make([]database.InsertWorkspaceAgentContainerParams, 0, len(req.Containers))
+	for _, protoContainer := range req.Containers {
+		container, err := agentsdk.ContainerFromProto(protoContainer)
+		if err != nil {
+			return nil, xerrors.Errorf("convert container: %w", err)
+		}
+		labels, err := json.Marshal(container.Labels)
+		if err != nil {
+			return nil, xerrors.Errorf("marshal labels of container %s: %w", container.Name, err)
+		}
+		ports, err := json.Marshal(container.Ports)
+		if err != nil {
+			return nil, xerrors.Errorf("marshal ports of container %s: %w", container.Name, err)
+		}
+		params = append(params, database.InsertWorkspaceAgentContainerParams{
+			WorkspaceAgentID: workspaceAgent.ID,
+			ContainerID:      container.ID,
+			Name:             container.Name,
+			Image:            container.Image,
+			Status:           container.Status,
+			Runtime:          req.Runtime,
+			Labels:           labels,
+			Ports:            ports,
+			CreatedAt:        dbtime.Time(container.CreatedAt),
+			ReportedAt:       reportedAt,
+		})
+	}
+
+	a.Log.Debug(ctx, "got containers update",
+		slog.F("agent_id", workspaceAgent.ID),
+		slog.F("runtime", req.Runtime),
+		slog.F("containers", len(params)),
+	)
+
+	err = a.Database.InTx(func(tx database.Store) error {
+		err := tx.DeleteWorkspaceAgentContainersByAgentID(ctx, workspaceAgent.ID)
+		if err != nil {
+			return xerrors.Errorf("delete containers: %w", err)
+		}
+		for _, param := range params {
+			err = tx.InsertWorkspaceAgentContainer(ctx, param)
+			if err != nil {
+				return xerrors.Errorf("insert container %s: %w", param.Name, err)
+			}
+		}
+		return nil
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &agentproto.UpdateContainersResponse{}, nil
+}
@@ -0,0 +1,96 @@
+package agentapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"cdr.dev/slog/sloggers/slogtest"
+	agentproto "github.com/coder/coder/v2/agent/proto"
+	"github.com/coder/coder/v2/coderd/agentapi"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbmem"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestUpdateContainers(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutil.Context(t, testutil.WaitShort)
+	db := dbmem.New()
+	agent := database.WorkspaceAgent{ID: uuid.New()}
+	api := &agentapi.ContainersAPI{
+		AgentFn: func(context.Context) (database.WorkspaceAgent, error) {
+			return agent, nil
+		},
+		Database: db,
+		Log:      slogtest.Make(t, nil),
+	}
+
+	createdAt := time.Date(2024, 9, 10, 11, 0, 0, 0, time.UTC)
+	_, err := api.UpdateContainers(ctx, &agentproto.UpdateContainersRequest{
+		Runtime: "docker",
+		Containers: []*agentproto.WorkspaceAgentContainer{
+			{
+				Id:        "4f2a0bfa",
+				Name:      "web",
+				Image:     "nginx:latest",
+				CreatedAt: timestamppb.New(createdAt),
+				Status:    "running",
+				Labels:    map[string]string{"app": "web"},
+				Ports: []*agentproto.WorkspaceAgentContainer_Port{
+					{Port: 80, Network: "tcp", HostIp: "0.0.0.0", HostPort: 8080},
+				},
+			},
+			{
+				Id:        "0b1c2d3e",
+				Name:      "postgres",
+				Image:     "postgres:16",
+				CreatedAt: timestamppb.New(createdAt),
+				Status:    "running",
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	rows, err := db.GetWorkspaceAgentContainersByAgentID(ctx, agent.ID)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	require.Equal(t, "postgres", rows[0].Name)
+	require.Equal(t, "web", rows[1].Name)
+	require.Equal(t, "docker", rows[1].Runtime)
+	require.True(t, rows[1].CreatedAt.Equal(createdAt))
+	var ports []codersdk.WorkspaceAgentContainerPort
+	require.NoError(t, json.Unmarshal(rows[1].Ports, &ports))
+	require.Equal(t, []codersdk.WorkspaceAgentContainerPort{
+		{Port: 80, Network: "tcp", HostIP: "0.0.0.0", HostPort: 8080},
+	}, ports)
+	var labels map[string]string
+	require.NoError(t, json.Unmarshal(rows[0].Labels, &labels))
+	require.Empty(t, labels)
+
+	// A report replaces the previous one, so stopped containers are removed.
+	_, err = api.UpdateContainers(ctx, &agentproto.UpdateContainersRequest{
+		Runtime: "docker",
+		Containers: []*agentproto.WorkspaceAgentContainer{
+			{Id: "0b1c2d3e", Name: "postgres", Image: "postgres:16", CreatedAt: timestamppb.New(createdAt), Status: "running"},
+		},
+	})
+	require.NoError(t, err)
+	rows, err = db.GetWorkspaceAgentContainersByAgentID(ctx, agent.ID)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.Equal(t, "0b1c2d3e", rows[0].ContainerID)
+
+	_, err = api.UpdateContainers(ctx, &agentproto.UpdateContainersRequest{Runtime: "docker"})
+	require.NoError(t, err)
+	rows, err = db.GetWorkspaceAgentContainersByAgentID(ctx, agent.ID)
+	require.NoError(t, err)
+	require.Empty(t, rows)
+}
@@ -52,23 +52,17 @@ func (a *LogsAPI) BatchCreateLogs(ctx context.Context, req *agentproto.BatchCrea
 	// not provided in the request body. We default to the external
 	// log source in this case.
 	if logSourceID == uuid.Nil {
-		// Use the external log source
-		externalSources, err := a.Database.InsertWorkspaceAgentLogSources(ctx, database.InsertWorkspaceAgentLogSourcesParams{
-			WorkspaceAgentID: workspaceAgent.ID,
-			CreatedAt:        a.now(),
-			ID:               []uuid.UUID{agentsdk.ExternalLogSourceID},
-			DisplayName:      []string{"External"},
-			Icon:             []string{"/emojis/1f310.png"},
-		})
-		if database.IsUniqueViolation(err, database.UniqueWorkspaceAgentLogSourcesPkey) {
-			err = nil
-			logSourceID = agentsdk.ExternalLogSourceID
-		}
+		logSourceID, err = a.insertStaticLogSource(ctx, workspaceAgent, agentsdk.ExternalLogSourceID, "External", "/emojis/1f310.png")
 		if err != nil {
 			return nil, xerrors.Errorf("insert external workspace agent log source: %w", err)
 		}
-		if len(externalSources) == 1 {
-			logSourceID = externalSources[0].ID
+	}
+	// Denied port forwards are reported to a log source that isn't defined
+	// by the template, so create it on first use.
+	if logSourceID == agentsdk.PortForwardingLogSourceID {
+		_, err = a.insertStaticLogSource(ctx, workspaceAgent, agentsdk.PortForwardingLogSourceID, "Port forwarding", "/emojis/1f6ab.png")
+		if err != nil {
+			return nil, xerrors.Errorf("insert port forwarding workspace agent log source: %w", err)
 		}
 	}
 
@@ -151,3 +145,25 @@ func (a *LogsAPI) BatchCreateLogs(ctx context.Context, req *agentproto.BatchCrea
 
 	return &agentproto.BatchCreateLogsResponse{}, nil
 }
+
+// insertStaticLogSource creates a log source with a statically-defined ID for
+// the agent if it doesn't exist yet, and returns its ID.
+func (a *LogsAPI) insertStaticLogSource(ctx context.Context, workspaceAgent database.WorkspaceAgent, id uuid.UUID, displayName, icon string) (uuid.UUID, error) {
+	sources, err := a.Database.InsertWorkspaceAgentLogSources(ctx, database.InsertWorkspaceAgentLogSourcesParams{
+		WorkspaceAgentID: workspaceAgent.ID,
+		CreatedAt:        a.now(),
+		ID:               []uuid.UUID{id},
+		DisplayName:      []string{displayName},
+		Icon:             []string{icon},
+	})
+	if database.IsUniqueViolation(err, database.UniqueWorkspaceAgentLogSourcesPkey) {
+		return id, nil
+	}
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if len(sources) == 1 {
+		return sources[0].ID, nil
+	}
+	return uuid.Nil, nil
+}
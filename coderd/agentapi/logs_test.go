@@ -373,6 +373,55 @@ func TestBatchCreateLogs(t *testing.T) {
 		})
 	})
 
+	t.Run("UsePortForwardingLogSourceID", func(t *testing.T) {
+		t.Parallel()
+
+		dbM := dbmock.NewMockStore(gomock.NewController(t))
+
+		now := dbtime.Now()
+		api := &agentapi.LogsAPI{
+			AgentFn: func(context.Context) (database.WorkspaceAgent, error) {
+				return agent, nil
+			},
+			Database:                          dbM,
+			Log:                               slogtest.Make(t, nil),
+			PublishWorkspaceUpdateFn:          func(context.Context, *database.WorkspaceAgent) error { return nil },
+			PublishWorkspaceAgentLogsUpdateFn: func(context.Context, uuid.UUID, agentsdk.LogsNotifyMessage) {},
+			TimeNowFn:                         func() time.Time { return now },
+		}
+
+		req := &agentproto.BatchCreateLogsRequest{
+			LogSourceId: agentsdk.PortForwardingLogSourceID[:],
+			Logs: []*agentproto.Log{
+				{
+					CreatedAt: timestamppb.New(now),
+					Level:     agentproto.Log_WARN,
+					Output:    "denied",
+				},
+			},
+		}
+
+		dbM.EXPECT().InsertWorkspaceAgentLogSources(gomock.Any(), database.InsertWorkspaceAgentLogSourcesParams{
+			WorkspaceAgentID: agent.ID,
+			CreatedAt:        now,
+			ID:               []uuid.UUID{agentsdk.PortForwardingLogSourceID},
+			DisplayName:      []string{"Port forwarding"},
+			Icon:             []string{"/emojis/1f6ab.png"},
+		}).Return([]database.WorkspaceAgentLogSource{{ID: agentsdk.PortForwardingLogSourceID}}, nil)
+		dbM.EXPECT().InsertWorkspaceAgentLogs(gomock.Any(), database.InsertWorkspaceAgentLogsParams{
+			AgentID:      agent.ID,
+			LogSourceID:  agentsdk.PortForwardingLogSourceID,
+			CreatedAt:    now,
+			Output:       []string{"denied"},
+			Level:        []database.LogLevel{database.LogLevelWarn},
+			OutputLength: int32(len("denied")),
+		}).Return([]database.WorkspaceAgentLog{{ID: 1, LogSourceID: agentsdk.PortForwardingLogSourceID}}, nil)
+
+		resp, err := api.BatchCreateLogs(context.Background(), req)
+		require.NoError(t, err)
+		require.Equal(t, &agentproto.BatchCreateLogsResponse{}, resp)
+	})
+
 	t.Run("Overflow", func(t *testing.T) {
 		t.Parallel()
 
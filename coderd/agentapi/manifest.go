@@ -20,6 +20,7 @@ import (
 	"github.com/coder/coder/v2/coderd/externalauth"
 	"github.com/coder/coder/v2/coderd/workspaceapps/appurl"
 	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/codersdk/agentsdk"
 	"github.com/coder/coder/v2/tailnet"
 )
 
@@ -52,6 +53,7 @@ func (a *ManifestAPI) GetManifest(ctx context.Context, _ *agentproto.GetManifest
 		metadata  []database.WorkspaceAgentMetadatum
 		workspace database.Workspace
 		owner     database.User
+		template  database.Template
 	)
 
 	var eg errgroup.Group
@@ -83,6 +85,11 @@ func (a *ManifestAPI) GetManifest(ctx context.Context, _ *agentproto.GetManifest
 		if err != nil {
 			return xerrors.Errorf("getting workspace owner by id: %w", err)
 		}
+		// nolint:gocritic // The owner may not be able to read the template, but the agent needs its settings.
+		template, err = a.Database.GetTemplateByID(dbauthz.AsSystemRestricted(ctx), workspace.TemplateID)
+		if err != nil {
+			return xerrors.Errorf("getting workspace template by id: %w", err)
+		}
 		return err
 	})
 	err = eg.Wait()
@@ -129,6 +136,8 @@ func (a *ManifestAPI) GetManifest(ctx context.Context, _ *agentproto.GetManifest
 		MotdPath:                 workspaceAgent.MOTDFile,
 		DisableDirectConnections: a.DisableDirectConnections,
 		DerpForceWebsockets:      a.DerpForceWebSockets,
+		SessionRecording:         template.SessionRecording,
+		PortForwardingPolicy:     agentsdk.ProtoFromPortForwardingPolicy(db2sdk.PortForwardingPolicy(template.PortForwardingPolicy)),
 
 		DerpMap:  tailnet.DERPMapToProto(a.DerpMapFn()),
 		Scripts:  dbAgentScriptsToProto(scripts),
@@ -213,6 +222,11 @@ func dbAppToProto(dbApp database.WorkspaceApp, agent database.WorkspaceAgent, ow
 		return nil, xerrors.Errorf("unknown app health: %q", dbApp.SharingLevel)
 	}
 
+	healthcheckKindRaw, ok := agentproto.WorkspaceApp_Healthcheck_Kind_value[strings.ToUpper(string(dbApp.HealthcheckKind))]
+	if !ok {
+		return nil, xerrors.Errorf("unknown app healthcheck kind: %q", dbApp.HealthcheckKind)
+	}
+
 	return &agentproto.WorkspaceApp{
 		Id:            dbApp.ID[:],
 		Url:           dbApp.Url.String,
@@ -225,9 +239,16 @@ func dbAppToProto(dbApp database.WorkspaceApp, agent database.WorkspaceAgent, ow
 		SubdomainName: db2sdk.AppSubdomain(dbApp, agent.Name, workspace.Name, ownerName),
 		SharingLevel:  agentproto.WorkspaceApp_SharingLevel(sharingLevelRaw),
 		Healthcheck: &agentproto.WorkspaceApp_Healthcheck{
-			Url:       dbApp.HealthcheckUrl,
-			Interval:  durationpb.New(time.Duration(dbApp.HealthcheckInterval) * time.Second),
-			Threshold: dbApp.HealthcheckThreshold,
+			Url:            dbApp.HealthcheckUrl,
+			Interval:       durationpb.New(time.Duration(dbApp.HealthcheckInterval) * time.Second),
+			Threshold:      dbApp.HealthcheckThreshold,
+			Kind:           agentproto.WorkspaceApp_Healthcheck_Kind(healthcheckKindRaw),
+			ExpectedStatus: dbApp.HealthcheckExpectedStatus,
+			BodyRegex:      dbApp.HealthcheckBodyRegex,
+			Address:        dbApp.HealthcheckAddress,
+			GrpcService:    dbApp.HealthcheckGrpcService,
+			Command:        dbApp.HealthcheckCommand,
+			Path:           dbApp.HealthcheckPath,
 		},
 		Health: agentproto.WorkspaceApp_Health(healthRaw),
 		Hidden: dbApp.Hidden,
@@ -45,10 +45,21 @@ func TestGetManifest(t *testing.T) {
 			ID:       uuid.New(),
 			Username: "cool-user",
 		}
+		template = database.Template{
+			ID:               uuid.New(),
+			SessionRecording: true,
+			PortForwardingPolicy: database.PortForwardingPolicy{
+				DirectTCPIP: &database.PortForwardingRule{
+					AllowedCIDRs: []string{"127.0.0.1"},
+					AllowedPorts: []string{"8080"},
+				},
+			},
+		}
 		workspace = database.Workspace{
-			ID:      uuid.New(),
-			OwnerID: owner.ID,
-			Name:    "cool-workspace",
+			ID:         uuid.New(),
+			OwnerID:    owner.ID,
+			TemplateID: template.ID,
+			Name:       "cool-workspace",
 		}
 		agent = database.WorkspaceAgent{
 			ID:   uuid.New(),
@@ -62,32 +73,36 @@ func TestGetManifest(t *testing.T) {
 		}
 		apps = []database.WorkspaceApp{
 			{
-				ID:                   uuid.New(),
-				Url:                  sql.NullString{String: "http://localhost:1234", Valid: true},
-				External:             false,
-				Slug:                 "cool-app-1",
-				DisplayName:          "app 1",
-				Command:              sql.NullString{String: "cool command", Valid: true},
-				Icon:                 "/icon.png",
-				Subdomain:            true,
-				SharingLevel:         database.AppSharingLevelAuthenticated,
-				Health:               database.WorkspaceAppHealthHealthy,
-				HealthcheckUrl:       "http://localhost:1234/health",
-				HealthcheckInterval:  10,
-				HealthcheckThreshold: 3,
+				ID:                        uuid.New(),
+				Url:                       sql.NullString{String: "http://localhost:1234", Valid: true},
+				External:                  false,
+				Slug:                      "cool-app-1",
+				DisplayName:               "app 1",
+				Command:                   sql.NullString{String: "cool command", Valid: true},
+				Icon:                      "/icon.png",
+				Subdomain:                 true,
+				SharingLevel:              database.AppSharingLevelAuthenticated,
+				Health:                    database.WorkspaceAppHealthHealthy,
+				HealthcheckUrl:            "http://localhost:1234/health",
+				HealthcheckInterval:       10,
+				HealthcheckThreshold:      3,
+				HealthcheckKind:           database.WorkspaceAppHealthcheckKindHttp,
+				HealthcheckExpectedStatus: 200,
+				HealthcheckBodyRegex:      "^ok$",
 			},
 			{
-				ID:           uuid.New(),
-				Url:          sql.NullString{String: "http://google.com", Valid: true},
-				External:     true,
-				Slug:         "google",
-				DisplayName:  "Literally Google",
-				Command:      sql.NullString{Valid: false},
-				Icon:         "/google.png",
-				Subdomain:    false,
-				SharingLevel: database.AppSharingLevelPublic,
-				Health:       database.WorkspaceAppHealthDisabled,
-				Hidden:       false,
+				ID:              uuid.New(),
+				Url:             sql.NullString{String: "http://google.com", Valid: true},
+				External:        true,
+				Slug:            "google",
+				DisplayName:     "Literally Google",
+				Command:         sql.NullString{Valid: false},
+				Icon:            "/google.png",
+				Subdomain:       false,
+				SharingLevel:    database.AppSharingLevelPublic,
+				Health:          database.WorkspaceAppHealthDisabled,
+				Hidden:          false,
+				HealthcheckKind: database.WorkspaceAppHealthcheckKindHttp,
 			},
 			{
 				ID:                   uuid.New(),
@@ -104,6 +119,23 @@ func TestGetManifest(t *testing.T) {
 				HealthcheckInterval:  20,
 				HealthcheckThreshold: 5,
 				Hidden:               true,
+				HealthcheckKind:      database.WorkspaceAppHealthcheckKindHttp,
+			},
+			{
+				ID:                   uuid.New(),
+				External:             false,
+				Slug:                 "cool-db",
+				DisplayName:          "cool database",
+				Command:              sql.NullString{String: "psql", Valid: true},
+				Icon:                 "",
+				Subdomain:            false,
+				SharingLevel:         database.AppSharingLevelOwner,
+				Health:               database.WorkspaceAppHealthInitializing,
+				HealthcheckInterval:  15,
+				HealthcheckThreshold: 2,
+				Hidden:               false,
+				HealthcheckKind:      database.WorkspaceAppHealthcheckKindCommand,
+				HealthcheckCommand:   "pg_isready",
 			},
 		}
 		scripts = []database.WorkspaceAgentScript{
@@ -181,9 +213,12 @@ func TestGetManifest(t *testing.T) {
 				SubdomainName: fmt.Sprintf("%s--%s--%s--%s", apps[0].Slug, agent.Name, workspace.Name, owner.Username),
 				SharingLevel:  agentproto.WorkspaceApp_AUTHENTICATED,
 				Healthcheck: &agentproto.WorkspaceApp_Healthcheck{
-					Url:       apps[0].HealthcheckUrl,
-					Interval:  durationpb.New(time.Duration(apps[0].HealthcheckInterval) * time.Second),
-					Threshold: apps[0].HealthcheckThreshold,
+					Url:            apps[0].HealthcheckUrl,
+					Interval:       durationpb.New(time.Duration(apps[0].HealthcheckInterval) * time.Second),
+					Threshold:      apps[0].HealthcheckThreshold,
+					Kind:           agentproto.WorkspaceApp_Healthcheck_HTTP,
+					ExpectedStatus: apps[0].HealthcheckExpectedStatus,
+					BodyRegex:      apps[0].HealthcheckBodyRegex,
 				},
 				Health: agentproto.WorkspaceApp_HEALTHY,
 				Hidden: false,
@@ -222,10 +257,31 @@ func TestGetManifest(t *testing.T) {
 					Url:       apps[2].HealthcheckUrl,
 					Interval:  durationpb.New(time.Duration(apps[2].HealthcheckInterval) * time.Second),
 					Threshold: apps[2].HealthcheckThreshold,
+					Kind:      agentproto.WorkspaceApp_Healthcheck_HTTP,
 				},
 				Health: agentproto.WorkspaceApp_UNHEALTHY,
 				Hidden: true,
 			},
+			{
+				Id:            apps[3].ID[:],
+				Url:           "",
+				External:      apps[3].External,
+				Slug:          apps[3].Slug,
+				DisplayName:   apps[3].DisplayName,
+				Command:       apps[3].Command.String,
+				Icon:          apps[3].Icon,
+				Subdomain:     false,
+				SubdomainName: "",
+				SharingLevel:  agentproto.WorkspaceApp_OWNER,
+				Healthcheck: &agentproto.WorkspaceApp_Healthcheck{
+					Interval:  durationpb.New(time.Duration(apps[3].HealthcheckInterval) * time.Second),
+					Threshold: apps[3].HealthcheckThreshold,
+					Kind:      agentproto.WorkspaceApp_Healthcheck_COMMAND,
+					Command:   apps[3].HealthcheckCommand,
+				},
+				Health: agentproto.WorkspaceApp_INITIALIZING,
+				Hidden: false,
+			},
 		}
 		protoScripts = []*agentproto.WorkspaceAgentScript{
 			{
@@ -303,6 +359,7 @@ func TestGetManifest(t *testing.T) {
 		}).Return(metadata, nil)
 		mDB.EXPECT().GetWorkspaceByID(gomock.Any(), workspace.ID).Return(workspace, nil)
 		mDB.EXPECT().GetUserByID(gomock.Any(), workspace.OwnerID).Return(owner, nil)
+		mDB.EXPECT().GetTemplateByID(gomock.Any(), template.ID).Return(template, nil)
 
 		got, err := api.GetManifest(context.Background(), &agentproto.GetManifestRequest{})
 		require.NoError(t, err)
@@ -320,6 +377,13 @@ func TestGetManifest(t *testing.T) {
 			MotdPath:                 agent.MOTDFile,
 			DisableDirectConnections: true,
 			DerpForceWebsockets:      true,
+			SessionRecording:         true,
+			PortForwardingPolicy: &agentproto.PortForwardingPolicy{
+				DirectTcpip: &agentproto.PortForwardingPolicy_Rule{
+					AllowedCidrs: []string{"127.0.0.1"},
+					AllowedPorts: []string{"8080"},
+				},
+			},
 			// tailnet.DERPMapToProto() is extensively tested elsewhere, so it's
 			// not necessary to manually recreate a big DERP map here like we
 			// did for apps and metadata.
@@ -370,6 +434,7 @@ func TestGetManifest(t *testing.T) {
 		}).Return(metadata, nil)
 		mDB.EXPECT().GetWorkspaceByID(gomock.Any(), workspace.ID).Return(workspace, nil)
 		mDB.EXPECT().GetUserByID(gomock.Any(), workspace.OwnerID).Return(owner, nil)
+		mDB.EXPECT().GetTemplateByID(gomock.Any(), template.ID).Return(template, nil)
 
 		got, err := api.GetManifest(context.Background(), &agentproto.GetManifestRequest{})
 		require.NoError(t, err)
@@ -387,6 +452,13 @@ func TestGetManifest(t *testing.T) {
 			MotdPath:                 agent.MOTDFile,
 			DisableDirectConnections: true,
 			DerpForceWebsockets:      true,
+			SessionRecording:         true,
+			PortForwardingPolicy: &agentproto.PortForwardingPolicy{
+				DirectTcpip: &agentproto.PortForwardingPolicy_Rule{
+					AllowedCidrs: []string{"127.0.0.1"},
+					AllowedPorts: []string{"8080"},
+				},
+			},
 			// tailnet.DERPMapToProto() is extensively tested elsewhere, so it's
 			// not necessary to manually recreate a big DERP map here like we
 			// did for apps and metadata.
@@ -21,6 +21,9 @@ type MetadataAPI struct {
 	Database database.Store
 	Pubsub   pubsub.Pubsub
 	Log      slog.Logger
+	// RecordHistory also records each update as a sample in the metadata
+	// history, which dbpurge trims according to its retention.
+	RecordHistory bool
 
 	TimeNowFn func() time.Time // defaults to dbtime.Now()
 }
@@ -111,6 +114,12 @@ func (a *MetadataAPI) BatchUpdateMetadata(ctx context.Context, req *agentproto.B
 	if err != nil {
 		return nil, xerrors.Errorf("update workspace agent metadata in database: %w", err)
 	}
+	if a.RecordHistory {
+		err = a.Database.InsertWorkspaceAgentMetadataHistory(ctx, database.InsertWorkspaceAgentMetadataHistoryParams(dbUpdate))
+		if err != nil {
+			return nil, xerrors.Errorf("insert workspace agent metadata history in database: %w", err)
+		}
+	}
 
 	payload, err := json.Marshal(WorkspaceAgentMetadataChannelPayload{
 		CollectedAt: collectedAt,
@@ -106,6 +106,61 @@ func TestBatchUpdateMetadata(t *testing.T) {
 		}, gotEvent)
 	})
 
+	t.Run("RecordHistory", func(t *testing.T) {
+		t.Parallel()
+
+		dbM := dbmock.NewMockStore(gomock.NewController(t))
+		pub := &fakePublisher{}
+
+		now := dbtime.Now()
+		req := &agentproto.BatchUpdateMetadataRequest{
+			Metadata: []*agentproto.Metadata{
+				{
+					Key: "cpu",
+					Result: &agentproto.WorkspaceAgentMetadata_Result{
+						CollectedAt: timestamppb.New(now.Add(-10 * time.Second)),
+						Age:         10,
+						Value:       "12%",
+						Error:       "",
+					},
+				},
+			},
+		}
+
+		dbM.EXPECT().UpdateWorkspaceAgentMetadata(gomock.Any(), database.UpdateWorkspaceAgentMetadataParams{
+			WorkspaceAgentID: agent.ID,
+			Key:              []string{"cpu"},
+			Value:            []string{"12%"},
+			Error:            []string{""},
+			CollectedAt:      []time.Time{now},
+		}).Return(nil)
+		dbM.EXPECT().InsertWorkspaceAgentMetadataHistory(gomock.Any(), database.InsertWorkspaceAgentMetadataHistoryParams{
+			WorkspaceAgentID: agent.ID,
+			Key:              []string{"cpu"},
+			Value:            []string{"12%"},
+			Error:            []string{""},
+			CollectedAt:      []time.Time{now},
+		}).Return(nil)
+
+		api := &agentapi.MetadataAPI{
+			AgentFn: func(context.Context) (database.WorkspaceAgent, error) {
+				return agent, nil
+			},
+			Database:      dbM,
+			Pubsub:        pub,
+			Log:           slogtest.Make(t, nil),
+			RecordHistory: true,
+			TimeNowFn: func() time.Time {
+				return now
+			},
+		}
+
+		resp, err := api.BatchUpdateMetadata(context.Background(), req)
+		require.NoError(t, err)
+		require.Equal(t, &agentproto.BatchUpdateMetadataResponse{}, resp)
+		require.Equal(t, 1, len(pub.publishes))
+	})
+
 	t.Run("ExceededLength", func(t *testing.T) {
 		t.Parallel()
 
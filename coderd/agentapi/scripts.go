@@ -31,6 +31,8 @@ func (s *ScriptsAPI) ScriptCompleted(ctx context.Context, req *agentproto.Worksp
 		stage = database.WorkspaceAgentScriptTimingStageStop
 	case agentproto.Timing_CRON:
 		stage = database.WorkspaceAgentScriptTimingStageCron
+	case agentproto.Timing_MANUAL:
+		stage = database.WorkspaceAgentScriptTimingStageManual
 	}
 
 	var status database.WorkspaceAgentScriptTimingStatus
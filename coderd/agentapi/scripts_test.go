@@ -53,6 +53,16 @@ func TestScriptCompleted(t *testing.T) {
 				ExitCode: 0,
 			},
 		},
+		{
+			scriptID: uuid.New(),
+			timing: &agentproto.Timing{
+				Stage:    agentproto.Timing_MANUAL,
+				Start:    timestamppb.New(dbtime.Now()),
+				End:      timestamppb.New(dbtime.Now().Add(time.Second)),
+				Status:   agentproto.Timing_OK,
+				ExitCode: 0,
+			},
+		},
 		{
 			scriptID: uuid.New(),
 			timing: &agentproto.Timing{
@@ -105,6 +115,8 @@ func protoScriptTimingStageToDatabase(stage agentproto.Timing_Stage) database.Wo
 		dbStage = database.WorkspaceAgentScriptTimingStageStop
 	case agentproto.Timing_CRON:
 		dbStage = database.WorkspaceAgentScriptTimingStageCron
+	case agentproto.Timing_MANUAL:
+		dbStage = database.WorkspaceAgentScriptTimingStageManual
 	}
 	return dbStage
 }
@@ -0,0 +1,169 @@
+package agentapi
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
+	agentproto "github.com/coder/coder/v2/agent/proto"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbauthz"
+)
+
+type SessionRecordingsAPI struct {
+	AgentFn       func(context.Context) (database.WorkspaceAgent, error)
+	WorkspaceIDFn func(context.Context, *database.WorkspaceAgent) (uuid.UUID, error)
+	Database      database.Store
+	Log           slog.Logger
+
+	mu sync.Mutex
+	// owned caches the recordings that are known to belong to this agent so
+	// that uploading chunks doesn't require a lookup per request.
+	owned map[uuid.UUID]struct{}
+}
+
+func (a *SessionRecordingsAPI) StartSessionRecording(ctx context.Context, req *agentproto.StartSessionRecordingRequest) (*agentproto.StartSessionRecordingResponse, error) {
+	if req.Recording == nil {
+		return nil, xerrors.New("recording is required")
+	}
+	recordingID, err := uuid.FromBytes(req.Recording.Id)
+	if err != nil {
+		return nil, xerrors.Errorf("parse recording id: %w", err)
+	}
+
+	workspaceAgent, err := a.AgentFn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	workspaceID, err := a.WorkspaceIDFn(ctx, &workspaceAgent)
+	if err != nil {
+		return nil, err
+	}
+
+	//nolint:gocritic // The agent cannot write recordings itself, they are only readable by auditors.
+	ctx = dbauthz.AsSystemRestricted(ctx)
+	_, err = a.Database.InsertWorkspaceAgentSessionRecording(ctx, database.InsertWorkspaceAgentSessionRecordingParams{
+		ID:          recordingID,
+		WorkspaceID: workspaceID,
+		AgentID:     workspaceAgent.ID,
+		SessionType: req.Recording.SessionType,
+		Command:     req.Recording.Command,
+		StartedAt:   req.Recording.StartedAt.AsTime(),
+	})
+	// The agent retries requests that may have already been applied when
+	// reconnecting, so starting a recording twice is not an error.
+	if database.IsUniqueViolation(err, database.UniqueWorkspaceAgentSessionRecordingsPkey) {
+		err = a.authorizeRecording(ctx, workspaceAgent.ID, recordingID)
+	}
+	if err != nil {
+		return nil, xerrors.Errorf("insert session recording: %w", err)
+	}
+
+	a.mu.Lock()
+	if a.owned == nil {
+		a.owned = make(map[uuid.UUID]struct{})
+	}
+	a.owned[recordingID] = struct{}{}
+	a.mu.Unlock()
+
+	return &agentproto.StartSessionRecordingResponse{}, nil
+}
+
+func (a *SessionRecordingsAPI) UploadSessionRecordingChunk(ctx context.Context, req *agentproto.UploadSessionRecordingChunkRequest) (*agentproto.UploadSessionRecordingChunkResponse, error) {
+	recordingID, err := uuid.FromBytes(req.RecordingId)
+	if err != nil {
+		return nil, xerrors.Errorf("parse recording id: %w", err)
+	}
+	workspaceAgent, err := a.AgentFn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	//nolint:gocritic // The agent cannot write recordings itself, they are only readable by auditors.
+	ctx = dbauthz.AsSystemRestricted(ctx)
+	err = a.authorizeRecording(ctx, workspaceAgent.ID, recordingID)
+	if err != nil {
+		return nil, err
+	}
+	err = a.Database.InsertWorkspaceAgentSessionRecordingChunk(ctx, database.InsertWorkspaceAgentSessionRecordingChunkParams{
+		RecordingID: recordingID,
+		Sequence:    req.Sequence,
+		Data:        req.Data,
+	})
+	if database.IsUniqueViolation(err, database.UniqueWorkspaceAgentSessionRecordingChunksPkey) {
+		a.Log.Debug(ctx, "ignoring duplicate session recording chunk",
+			slog.F("recording_id", recordingID), slog.F("sequence", req.Sequence))
+		err = nil
+	}
+	if err != nil {
+		return nil, xerrors.Errorf("insert session recording chunk: %w", err)
+	}
+
+	return &agentproto.UploadSessionRecordingChunkResponse{}, nil
+}
+
+func (a *SessionRecordingsAPI) EndSessionRecording(ctx context.Context, req *agentproto.EndSessionRecordingRequest) (*agentproto.EndSessionRecordingResponse, error) {
+	recordingID, err := uuid.FromBytes(req.RecordingId)
+	if err != nil {
+		return nil, xerrors.Errorf("parse recording id: %w", err)
+	}
+	workspaceAgent, err := a.AgentFn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	//nolint:gocritic // The agent cannot write recordings itself, they are only readable by auditors.
+	ctx = dbauthz.AsSystemRestricted(ctx)
+	err = a.authorizeRecording(ctx, workspaceAgent.ID, recordingID)
+	if err != nil {
+		return nil, err
+	}
+	err = a.Database.UpdateWorkspaceAgentSessionRecordingEndedAt(ctx, database.UpdateWorkspaceAgentSessionRecordingEndedAtParams{
+		ID: recordingID,
+		EndedAt: sql.NullTime{
+			Time:  req.EndedAt.AsTime(),
+			Valid: req.EndedAt != nil,
+		},
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("update session recording ended at: %w", err)
+	}
+
+	a.mu.Lock()
+	delete(a.owned, recordingID)
+	a.mu.Unlock()
+
+	return &agentproto.EndSessionRecordingResponse{}, nil
+}
+
+// authorizeRecording ensures the recording was created by the given agent,
+// which prevents an agent from writing to recordings of other workspaces.
+func (a *SessionRecordingsAPI) authorizeRecording(ctx context.Context, agentID, recordingID uuid.UUID) error {
+	a.mu.Lock()
+	_, ok := a.owned[recordingID]
+	a.mu.Unlock()
+	if ok {
+		return nil
+	}
+
+	// The recording may have been started on a previous connection.
+	recording, err := a.Database.GetWorkspaceAgentSessionRecordingByID(ctx, recordingID)
+	if err != nil {
+		return xerrors.Errorf("get session recording %q: %w", recordingID, err)
+	}
+	if recording.AgentID != agentID {
+		return xerrors.Errorf("session recording %q does not belong to this agent", recordingID)
+	}
+
+	a.mu.Lock()
+	if a.owned == nil {
+		a.owned = make(map[uuid.UUID]struct{})
+	}
+	a.owned[recordingID] = struct{}{}
+	a.mu.Unlock()
+	return nil
+}
@@ -0,0 +1,127 @@
+package agentapi_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"cdr.dev/slog/sloggers/slogtest"
+	agentproto "github.com/coder/coder/v2/agent/proto"
+	"github.com/coder/coder/v2/coderd/agentapi"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbmem"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestSessionRecordings(t *testing.T) {
+	t.Parallel()
+
+	newAPI := func(t *testing.T, db database.Store) (*agentapi.SessionRecordingsAPI, database.WorkspaceAgent, uuid.UUID) {
+		agent := database.WorkspaceAgent{ID: uuid.New()}
+		workspaceID := uuid.New()
+		return &agentapi.SessionRecordingsAPI{
+			AgentFn: func(context.Context) (database.WorkspaceAgent, error) {
+				return agent, nil
+			},
+			WorkspaceIDFn: func(context.Context, *database.WorkspaceAgent) (uuid.UUID, error) {
+				return workspaceID, nil
+			},
+			Database: db,
+			Log:      slogtest.Make(t, nil),
+		}, agent, workspaceID
+	}
+
+	t.Run("Mainline", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitShort)
+		db := dbmem.New()
+		api, agent, workspaceID := newAPI(t, db)
+
+		id := uuid.New()
+		startedAt := dbtime.Now()
+		req := &agentproto.StartSessionRecordingRequest{
+			Recording: &agentproto.SessionRecording{
+				Id:          id[:],
+				SessionType: "ssh",
+				Command:     "htop",
+				StartedAt:   timestamppb.New(startedAt),
+			},
+		}
+		_, err := api.StartSessionRecording(ctx, req)
+		require.NoError(t, err)
+		// Starting the same recording again, e.g. after a reconnect, is a no-op.
+		_, err = api.StartSessionRecording(ctx, req)
+		require.NoError(t, err)
+
+		for i, data := range []string{"hello ", "world", "world"} {
+			seq := int32(i)
+			if i == 2 {
+				// Retried chunks are ignored.
+				seq = 1
+			}
+			_, err = api.UploadSessionRecordingChunk(ctx, &agentproto.UploadSessionRecordingChunkRequest{
+				RecordingId: id[:],
+				Sequence:    seq,
+				Data:        []byte(data),
+			})
+			require.NoError(t, err)
+		}
+
+		endedAt := startedAt.Add(time.Minute)
+		_, err = api.EndSessionRecording(ctx, &agentproto.EndSessionRecordingRequest{
+			RecordingId: id[:],
+			EndedAt:     timestamppb.New(endedAt),
+		})
+		require.NoError(t, err)
+
+		recording, err := db.GetWorkspaceAgentSessionRecordingByID(ctx, id)
+		require.NoError(t, err)
+		require.Equal(t, workspaceID, recording.WorkspaceID)
+		require.Equal(t, agent.ID, recording.AgentID)
+		require.Equal(t, "ssh", recording.SessionType)
+		require.Equal(t, "htop", recording.Command)
+		require.True(t, recording.EndedAt.Valid)
+		require.Equal(t, endedAt, recording.EndedAt.Time.UTC())
+		require.EqualValues(t, len("hello world"), recording.Size)
+
+		chunks, err := db.GetWorkspaceAgentSessionRecordingChunks(ctx, id)
+		require.NoError(t, err)
+		require.Len(t, chunks, 2)
+		require.Equal(t, "hello ", string(chunks[0].Data))
+		require.Equal(t, "world", string(chunks[1].Data))
+	})
+
+	t.Run("OtherAgent", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitShort)
+		db := dbmem.New()
+		api, _, _ := newAPI(t, db)
+		otherAPI, _, _ := newAPI(t, db)
+
+		id := uuid.New()
+		_, err := api.StartSessionRecording(ctx, &agentproto.StartSessionRecordingRequest{
+			Recording: &agentproto.SessionRecording{
+				Id:          id[:],
+				SessionType: "ssh",
+				StartedAt:   timestamppb.Now(),
+			},
+		})
+		require.NoError(t, err)
+
+		_, err = otherAPI.UploadSessionRecordingChunk(ctx, &agentproto.UploadSessionRecordingChunkRequest{
+			RecordingId: id[:],
+			Data:        []byte("injected"),
+		})
+		require.ErrorContains(t, err, "does not belong to this agent")
+		_, err = otherAPI.EndSessionRecording(ctx, &agentproto.EndSessionRecordingRequest{
+			RecordingId: id[:],
+			EndedAt:     timestamppb.Now(),
+		})
+		require.ErrorContains(t, err, "does not belong to this agent")
+	})
+}
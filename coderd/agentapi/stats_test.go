@@ -240,6 +240,75 @@ func TestUpdateStates(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("AgentActivity", func(t *testing.T) {
+		t.Parallel()
+
+		for _, optIn := range []bool{true, false} {
+			var (
+				now                   = dbtime.Now()
+				dbM                   = dbmock.NewMockStore(gomock.NewController(t))
+				ps                    = pubsub.NewInMemory()
+				templateScheduleStore = schedule.MockTemplateScheduleStore{
+					GetFn: func(context.Context, database.Store, uuid.UUID) (schedule.TemplateScheduleOptions, error) {
+						panic("should not be called")
+					},
+					SetFn: func(context.Context, database.Store, database.Template, schedule.TemplateScheduleOptions) (database.Template, error) {
+						panic("not implemented")
+					},
+				}
+				batcher = &workspacestatstest.StatsBatcher{}
+
+				// The agent reports activity without a connection, e.g.
+				// because a build is running.
+				req = &agentproto.UpdateStatsRequest{
+					Stats: &agentproto.Stats{
+						ConnectionsByProto: map[string]int64{},
+						ConnectionCount:    0,
+						Active:             true,
+					},
+				}
+			)
+			api := agentapi.StatsAPI{
+				AgentFn: func(context.Context) (database.WorkspaceAgent, error) {
+					return agent, nil
+				},
+				Database: dbM,
+				StatsReporter: workspacestats.NewReporter(workspacestats.ReporterOptions{
+					Database:              dbM,
+					Pubsub:                ps,
+					StatsBatcher:          batcher,
+					TemplateScheduleStore: templateScheduleStorePtr(templateScheduleStore),
+				}),
+				AgentStatsRefreshInterval: 10 * time.Second,
+				TimeNowFn: func() time.Time {
+					return now
+				},
+			}
+
+			// The deadline is only bumped if the template opted in. The
+			// setting comes with the workspace, so the template isn't loaded.
+			dbM.EXPECT().GetWorkspaceByAgentID(gomock.Any(), agent.ID).Return(database.GetWorkspaceByAgentIDRow{
+				Workspace:                 workspace,
+				TemplateName:              template.Name,
+				TemplateAgentActivityBump: optIn,
+			}, nil)
+			if optIn {
+				dbM.EXPECT().ActivityBumpWorkspace(gomock.Any(), database.ActivityBumpWorkspaceParams{
+					WorkspaceID:   workspace.ID,
+					NextAutostart: time.Time{}.UTC(),
+				}).Return(nil)
+			}
+
+			dbM.EXPECT().UpdateWorkspaceLastUsedAt(gomock.Any(), database.UpdateWorkspaceLastUsedAtParams{
+				ID:         workspace.ID,
+				LastUsedAt: now,
+			}).Return(nil)
+
+			_, err := api.UpdateStats(context.Background(), req)
+			require.NoError(t, err)
+		}
+	})
+
 	t.Run("NoStats", func(t *testing.T) {
 		t.Parallel()
 
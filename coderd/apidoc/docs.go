@@ -3753,6 +3753,71 @@ const docTemplate = `{
                 }
             }
         },
+        "/session-recordings/{sessionrecording}": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Audit"
+                ],
+                "summary": "Get session recording",
+                "operationId": "get-session-recording",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Session recording ID",
+                        "name": "sessionrecording",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.WorkspaceAgentSessionRecording"
+                        }
+                    }
+                }
+            }
+        },
+        "/session-recordings/{sessionrecording}/cast": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "description": "The recording is returned in the asciicast v2 format.",
+                "tags": [
+                    "Audit"
+                ],
+                "summary": "Get session recording contents",
+                "operationId": "get-session-recording-contents",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Session recording ID",
+                        "name": "sessionrecording",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
         "/templates": {
             "get": {
                 "security": [
@@ -4613,6 +4678,53 @@ const docTemplate = `{
                 }
             }
         },
+        "/templateversions/{templateversion}/dry-run/{jobID}/resource-changes": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "description": "The changes are only recorded for dry-runs that preview an\nupdate of a workspace.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Get template version dry-run resource changes by job ID",
+                "operationId": "get-template-version-dry-run-resource-changes-by-job-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Template version ID",
+                        "name": "templateversion",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Job ID",
+                        "name": "jobID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/codersdk.ResourceChange"
+                            }
+                        }
+                    }
+                }
+            }
+        },
         "/templateversions/{templateversion}/dry-run/{jobID}/resources": {
             "get": {
                 "security": [
@@ -5844,7 +5956,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{user}/notifications/preferences": {
+        "/users/{user}/notifications/inbox": {
             "get": {
                 "security": [
                     {
@@ -5857,8 +5969,8 @@ const docTemplate = `{
                 "tags": [
                     "Notifications"
                 ],
-                "summary": "Get user notification preferences",
-                "operationId": "get-user-notification-preferences",
+                "summary": "List user inbox notifications",
+                "operationId": "list-user-inbox-notifications",
                 "parameters": [
                     {
                         "type": "string",
@@ -5866,47 +5978,70 @@ const docTemplate = `{
                         "name": "user",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "enum": [
+                            "all",
+                            "read",
+                            "unread"
+                        ],
+                        "type": "string",
+                        "description": "Filter by read status",
+                        "name": "read_status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include archived notifications",
+                        "name": "include_archived",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "After ID",
+                        "name": "after_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page offset",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/codersdk.NotificationPreference"
-                            }
+                            "$ref": "#/definitions/codersdk.ListInboxNotificationsResponse"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/users/{user}/notifications/inbox/mark-all-read": {
             "put": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Notifications"
                 ],
-                "summary": "Update user notification preferences",
-                "operationId": "update-user-notification-preferences",
+                "summary": "Mark all user inbox notifications as read",
+                "operationId": "mark-all-user-inbox-notifications-as-read",
                 "parameters": [
-                    {
-                        "description": "Preferences",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/codersdk.UpdateUserNotificationPreferences"
-                        }
-                    },
                     {
                         "type": "string",
                         "description": "User ID, name, or me",
@@ -5919,16 +6054,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/codersdk.NotificationPreference"
-                            }
+                            "$ref": "#/definitions/codersdk.MarkAllInboxNotificationsAsReadResponse"
                         }
                     }
                 }
             }
         },
-        "/users/{user}/organizations": {
+        "/users/{user}/notifications/inbox/watch": {
             "get": {
                 "security": [
                     {
@@ -5936,13 +6068,13 @@ const docTemplate = `{
                     }
                 ],
                 "produces": [
-                    "application/json"
+                    "text/event-stream"
                 ],
                 "tags": [
-                    "Users"
+                    "Notifications"
                 ],
-                "summary": "Get organizations by user",
-                "operationId": "get-organizations-by-user",
+                "summary": "Watch user inbox notifications",
+                "operationId": "watch-user-inbox-notifications",
                 "parameters": [
                     {
                         "type": "string",
@@ -5956,30 +6088,30 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/codersdk.Organization"
-                            }
+                            "$ref": "#/definitions/codersdk.Response"
                         }
                     }
                 }
             }
         },
-        "/users/{user}/organizations/{organizationname}": {
-            "get": {
+        "/users/{user}/notifications/inbox/{id}": {
+            "put": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Notifications"
                 ],
-                "summary": "Get organization by user and organization name",
-                "operationId": "get-organization-by-user-and-organization-name",
+                "summary": "Update user inbox notification",
+                "operationId": "update-user-inbox-notification",
                 "parameters": [
                     {
                         "type": "string",
@@ -5990,37 +6122,47 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Organization name",
-                        "name": "organizationname",
+                        "format": "uuid",
+                        "description": "Inbox notification ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Inbox notification state",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.UpdateInboxNotificationRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/codersdk.Organization"
+                            "$ref": "#/definitions/codersdk.InboxNotification"
                         }
                     }
                 }
             }
         },
-        "/users/{user}/password": {
-            "put": {
+        "/users/{user}/notifications/preferences": {
+            "get": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
-                "consumes": [
+                "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Notifications"
                 ],
-                "summary": "Update user password",
-                "operationId": "update-user-password",
+                "summary": "Get user notification preferences",
+                "operationId": "get-user-notification-preferences",
                 "parameters": [
                     {
                         "type": "string",
@@ -6028,25 +6170,20 @@ const docTemplate = `{
                         "name": "user",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Update password request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/codersdk.UpdateUserPasswordRequest"
-                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/codersdk.NotificationPreference"
+                            }
+                        }
                     }
                 }
-            }
-        },
-        "/users/{user}/profile": {
+            },
             "put": {
                 "security": [
                     {
@@ -6060,39 +6197,42 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Notifications"
                 ],
-                "summary": "Update user profile",
-                "operationId": "update-user-profile",
+                "summary": "Update user notification preferences",
+                "operationId": "update-user-notification-preferences",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "User ID, name, or me",
-                        "name": "user",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Updated profile",
+                        "description": "Preferences",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/codersdk.UpdateUserProfileRequest"
+                            "$ref": "#/definitions/codersdk.UpdateUserNotificationPreferences"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID, name, or me",
+                        "name": "user",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/codersdk.User"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/codersdk.NotificationPreference"
+                            }
                         }
                     }
                 }
             }
         },
-        "/users/{user}/quiet-hours": {
+        "/users/{user}/notifications/quiet-hours": {
             "get": {
                 "security": [
                     {
@@ -6103,15 +6243,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Enterprise"
+                    "Notifications"
                 ],
-                "summary": "Get user quiet hours schedule",
-                "operationId": "get-user-quiet-hours-schedule",
+                "summary": "Get user notification quiet hours",
+                "operationId": "get-user-notification-quiet-hours",
                 "parameters": [
                     {
                         "type": "string",
-                        "format": "uuid",
-                        "description": "User ID",
+                        "description": "User ID, name, or me",
                         "name": "user",
                         "in": "path",
                         "required": true
@@ -6121,10 +6260,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/codersdk.UserQuietHoursScheduleResponse"
-                            }
+                            "$ref": "#/definitions/codersdk.NotificationQuietHours"
                         }
                     }
                 }
@@ -6142,28 +6278,61 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Enterprise"
+                    "Notifications"
                 ],
-                "summary": "Update user quiet hours schedule",
-                "operationId": "update-user-quiet-hours-schedule",
+                "summary": "Update user notification quiet hours",
+                "operationId": "update-user-notification-quiet-hours",
                 "parameters": [
+                    {
+                        "description": "Quiet hours",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.NotificationQuietHours"
+                        }
+                    },
                     {
                         "type": "string",
-                        "format": "uuid",
-                        "description": "User ID",
+                        "description": "User ID, name, or me",
                         "name": "user",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Update schedule request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/codersdk.UpdateUserQuietHoursScheduleRequest"
+                            "$ref": "#/definitions/codersdk.NotificationQuietHours"
                         }
                     }
+                }
+            }
+        },
+        "/users/{user}/organizations": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get organizations by user",
+                "operationId": "get-organizations-by-user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID, name, or me",
+                        "name": "user",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
                 "responses": {
                     "200": {
@@ -6171,14 +6340,14 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/codersdk.UserQuietHoursScheduleResponse"
+                                "$ref": "#/definitions/codersdk.Organization"
                             }
                         }
                     }
                 }
             }
         },
-        "/users/{user}/roles": {
+        "/users/{user}/organizations/{organizationname}": {
             "get": {
                 "security": [
                     {
@@ -6191,8 +6360,8 @@ const docTemplate = `{
                 "tags": [
                     "Users"
                 ],
-                "summary": "Get user roles",
-                "operationId": "get-user-roles",
+                "summary": "Get organization by user and organization name",
+                "operationId": "get-organization-by-user-and-organization-name",
                 "parameters": [
                     {
                         "type": "string",
@@ -6200,17 +6369,26 @@ const docTemplate = `{
                         "name": "user",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organizationname",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/codersdk.User"
+                            "$ref": "#/definitions/codersdk.Organization"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/users/{user}/password": {
             "put": {
                 "security": [
                     {
@@ -6220,14 +6398,11 @@ const docTemplate = `{
                 "consumes": [
                     "application/json"
                 ],
-                "produces": [
-                    "application/json"
-                ],
                 "tags": [
                     "Users"
                 ],
-                "summary": "Assign role to user",
-                "operationId": "assign-role-to-user",
+                "summary": "Update user password",
+                "operationId": "update-user-password",
                 "parameters": [
                     {
                         "type": "string",
@@ -6237,40 +6412,40 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Update roles request",
+                        "description": "Update password request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/codersdk.UpdateRoles"
+                            "$ref": "#/definitions/codersdk.UpdateUserPasswordRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/codersdk.User"
-                        }
+                    "204": {
+                        "description": "No Content"
                     }
                 }
             }
         },
-        "/users/{user}/status/activate": {
+        "/users/{user}/profile": {
             "put": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Users"
                 ],
-                "summary": "Activate user account",
-                "operationId": "activate-user-account",
+                "summary": "Update user profile",
+                "operationId": "update-user-profile",
                 "parameters": [
                     {
                         "type": "string",
@@ -6278,6 +6453,15 @@ const docTemplate = `{
                         "name": "user",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Updated profile",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.UpdateUserProfileRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -6290,8 +6474,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{user}/status/suspend": {
-            "put": {
+        "/users/{user}/quiet-hours": {
+            "get": {
                 "security": [
                     {
                         "CoderSessionToken": []
@@ -6301,14 +6485,15 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Enterprise"
                 ],
-                "summary": "Suspend user account",
-                "operationId": "suspend-user-account",
+                "summary": "Get user quiet hours schedule",
+                "operationId": "get-user-quiet-hours-schedule",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID, name, or me",
+                        "format": "uuid",
+                        "description": "User ID",
                         "name": "user",
                         "in": "path",
                         "required": true
@@ -6318,60 +6503,64 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/codersdk.User"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/codersdk.UserQuietHoursScheduleResponse"
+                            }
                         }
                     }
                 }
-            }
-        },
-        "/users/{user}/workspace/{workspacename}": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Workspaces"
+                    "Enterprise"
                 ],
-                "summary": "Get workspace metadata by user and workspace name",
-                "operationId": "get-workspace-metadata-by-user-and-workspace-name",
+                "summary": "Update user quiet hours schedule",
+                "operationId": "update-user-quiet-hours-schedule",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID, name, or me",
+                        "format": "uuid",
+                        "description": "User ID",
                         "name": "user",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Workspace name",
-                        "name": "workspacename",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Return data instead of HTTP 404 if the workspace is deleted",
-                        "name": "include_deleted",
-                        "in": "query"
+                        "description": "Update schedule request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.UpdateUserQuietHoursScheduleRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/codersdk.Workspace"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/codersdk.UserQuietHoursScheduleResponse"
+                            }
                         }
                     }
                 }
             }
         },
-        "/users/{user}/workspace/{workspacename}/builds/{buildnumber}": {
+        "/users/{user}/roles": {
             "get": {
                 "security": [
                     {
@@ -6382,10 +6571,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Builds"
+                    "Users"
                 ],
-                "summary": "Get workspace build by user, workspace name, and build number",
-                "operationId": "get-workspace-build-by-user-workspace-name-and-build-number",
+                "summary": "Get user roles",
+                "operationId": "get-user-roles",
                 "parameters": [
                     {
                         "type": "string",
@@ -6393,41 +6582,23 @@ const docTemplate = `{
                         "name": "user",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Workspace name",
-                        "name": "workspacename",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "format": "number",
-                        "description": "Build number",
-                        "name": "buildnumber",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/codersdk.WorkspaceBuild"
+                            "$ref": "#/definitions/codersdk.User"
                         }
                     }
                 }
-            }
-        },
-        "/users/{user}/workspaces": {
-            "post": {
+            },
+            "put": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
-                "description": "Create a new workspace using a template. The request must\nspecify either the Template ID or the Template Version ID,\nnot both. If the Template ID is specified, the active version\nof the template will be used.",
                 "consumes": [
                     "application/json"
                 ],
@@ -6435,25 +6606,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Workspaces"
+                    "Users"
                 ],
-                "summary": "Create user workspace",
-                "operationId": "create-user-workspace",
+                "summary": "Assign role to user",
+                "operationId": "assign-role-to-user",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Username, UUID, or me",
+                        "description": "User ID, name, or me",
                         "name": "user",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Create workspace request",
+                        "description": "Update roles request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/codersdk.CreateWorkspaceRequest"
+                            "$ref": "#/definitions/codersdk.UpdateRoles"
                         }
                     }
                 ],
@@ -6461,13 +6632,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/codersdk.Workspace"
+                            "$ref": "#/definitions/codersdk.User"
                         }
                     }
                 }
             }
         },
-        "/workspace-quota/{user}": {
+        "/users/{user}/saved-searches": {
             "get": {
                 "security": [
                     {
@@ -6478,11 +6649,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Enterprise"
+                    "Users"
                 ],
-                "summary": "Get workspace quota by user deprecated",
-                "operationId": "get-workspace-quota-by-user-deprecated",
-                "deprecated": true,
+                "summary": "Get saved searches by user",
+                "operationId": "get-saved-searches-by-user",
                 "parameters": [
                     {
                         "type": "string",
@@ -6490,19 +6660,32 @@ const docTemplate = `{
                         "name": "user",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "enum": [
+                            "workspace",
+                            "template",
+                            "user",
+                            "audit_log"
+                        ],
+                        "type": "string",
+                        "description": "Resource type",
+                        "name": "type",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/codersdk.WorkspaceQuota"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/codersdk.SavedSearch"
+                            }
                         }
                     }
                 }
-            }
-        },
-        "/workspaceagents/aws-instance-identity": {
+            },
             "post": {
                 "security": [
                     {
@@ -6516,33 +6699,80 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Agents"
+                    "Users"
                 ],
-                "summary": "Authenticate agent on AWS instance",
-                "operationId": "authenticate-agent-on-aws-instance",
+                "summary": "Create saved search for user",
+                "operationId": "create-saved-search-for-user",
                 "parameters": [
                     {
-                        "description": "Instance identity token",
+                        "type": "string",
+                        "description": "User ID, name, or me",
+                        "name": "user",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Create saved search request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/agentsdk.AWSInstanceIdentityToken"
+                            "$ref": "#/definitions/codersdk.CreateSavedSearchRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/agentsdk.AuthenticateResponse"
+                            "$ref": "#/definitions/codersdk.SavedSearch"
                         }
                     }
                 }
             }
         },
-        "/workspaceagents/azure-instance-identity": {
-            "post": {
+        "/users/{user}/saved-searches/{savedsearch}": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get saved search by user and ID",
+                "operationId": "get-saved-search-by-user-and-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID, name, or me",
+                        "name": "user",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Saved search ID",
+                        "name": "savedsearch",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.SavedSearch"
+                        }
+                    }
+                }
+            },
+            "put": {
                 "security": [
                     {
                         "CoderSessionToken": []
@@ -6555,18 +6785,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Agents"
+                    "Users"
                 ],
-                "summary": "Authenticate agent on Azure instance",
-                "operationId": "authenticate-agent-on-azure-instance",
+                "summary": "Update saved search for user",
+                "operationId": "update-saved-search-for-user",
                 "parameters": [
                     {
-                        "description": "Instance identity token",
+                        "type": "string",
+                        "description": "User ID, name, or me",
+                        "name": "user",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Saved search ID",
+                        "name": "savedsearch",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update saved search request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/agentsdk.AzureInstanceIdentityToken"
+                            "$ref": "#/definitions/codersdk.UpdateSavedSearchRequest"
                         }
                     }
                 ],
@@ -6574,81 +6819,82 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/agentsdk.AuthenticateResponse"
+                            "$ref": "#/definitions/codersdk.SavedSearch"
                         }
                     }
                 }
-            }
-        },
-        "/workspaceagents/connection": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
-                "produces": [
-                    "application/json"
-                ],
                 "tags": [
-                    "Agents"
+                    "Users"
+                ],
+                "summary": "Delete saved search for user",
+                "operationId": "delete-saved-search-for-user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID, name, or me",
+                        "name": "user",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Saved search ID",
+                        "name": "savedsearch",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get connection info for workspace agent generic",
-                "operationId": "get-connection-info-for-workspace-agent-generic",
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/workspacesdk.AgentConnectionInfo"
-                        }
+                    "204": {
+                        "description": "No Content"
                     }
-                },
-                "x-apidocgen": {
-                    "skip": true
                 }
             }
         },
-        "/workspaceagents/google-instance-identity": {
-            "post": {
+        "/users/{user}/status/activate": {
+            "put": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Agents"
+                    "Users"
                 ],
-                "summary": "Authenticate agent on Google Cloud instance",
-                "operationId": "authenticate-agent-on-google-cloud-instance",
+                "summary": "Activate user account",
+                "operationId": "activate-user-account",
                 "parameters": [
                     {
-                        "description": "Instance identity token",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/agentsdk.GoogleInstanceIdentityToken"
-                        }
+                        "type": "string",
+                        "description": "User ID, name, or me",
+                        "name": "user",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/agentsdk.AuthenticateResponse"
+                            "$ref": "#/definitions/codersdk.User"
                         }
                     }
                 }
             }
         },
-        "/workspaceagents/me/external-auth": {
-            "get": {
+        "/users/{user}/status/suspend": {
+            "put": {
                 "security": [
                     {
                         "CoderSessionToken": []
@@ -6658,43 +6904,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Agents"
+                    "Users"
                 ],
-                "summary": "Get workspace agent external auth",
-                "operationId": "get-workspace-agent-external-auth",
+                "summary": "Suspend user account",
+                "operationId": "suspend-user-account",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Match",
-                        "name": "match",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Provider ID",
-                        "name": "id",
-                        "in": "query",
+                        "description": "User ID, name, or me",
+                        "name": "user",
+                        "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Wait for a new token to be issued",
-                        "name": "listen",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/agentsdk.ExternalAuthResponse"
+                            "$ref": "#/definitions/codersdk.User"
                         }
                     }
                 }
             }
         },
-        "/workspaceagents/me/gitauth": {
+        "/users/{user}/workspace/{workspacename}": {
             "get": {
                 "security": [
                     {
@@ -6705,29 +6938,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Agents"
+                    "Workspaces"
                 ],
-                "summary": "Removed: Get workspace agent git auth",
-                "operationId": "removed-get-workspace-agent-git-auth",
+                "summary": "Get workspace metadata by user and workspace name",
+                "operationId": "get-workspace-metadata-by-user-and-workspace-name",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Match",
-                        "name": "match",
-                        "in": "query",
+                        "description": "User ID, name, or me",
+                        "name": "user",
+                        "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Provider ID",
-                        "name": "id",
-                        "in": "query",
+                        "description": "Workspace name",
+                        "name": "workspacename",
+                        "in": "path",
                         "required": true
                     },
                     {
                         "type": "boolean",
-                        "description": "Wait for a new token to be issued",
-                        "name": "listen",
+                        "description": "Return data instead of HTTP 404 if the workspace is deleted",
+                        "name": "include_deleted",
                         "in": "query"
                     }
                 ],
@@ -6735,13 +6968,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/agentsdk.ExternalAuthResponse"
+                            "$ref": "#/definitions/codersdk.Workspace"
                         }
                     }
                 }
             }
         },
-        "/workspaceagents/me/gitsshkey": {
+        "/users/{user}/workspace/{workspacename}/builds/{buildnumber}": {
             "get": {
                 "security": [
                     {
@@ -6752,27 +6985,52 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Agents"
+                    "Builds"
+                ],
+                "summary": "Get workspace build by user, workspace name, and build number",
+                "operationId": "get-workspace-build-by-user-workspace-name-and-build-number",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID, name, or me",
+                        "name": "user",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Workspace name",
+                        "name": "workspacename",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "number",
+                        "description": "Build number",
+                        "name": "buildnumber",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get workspace agent Git SSH key",
-                "operationId": "get-workspace-agent-git-ssh-key",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/agentsdk.GitSSHKey"
+                            "$ref": "#/definitions/codersdk.WorkspaceBuild"
                         }
                     }
                 }
             }
         },
-        "/workspaceagents/me/log-source": {
+        "/users/{user}/workspaces": {
             "post": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
+                "description": "Create a new workspace using a template. The request must\nspecify either the Template ID or the Template Version ID,\nnot both. If the Template ID is specified, the active version\nof the template will be used.",
                 "consumes": [
                     "application/json"
                 ],
@@ -6780,18 +7038,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Agents"
+                    "Workspaces"
                 ],
-                "summary": "Post workspace agent log source",
-                "operationId": "post-workspace-agent-log-source",
+                "summary": "Create user workspace",
+                "operationId": "create-user-workspace",
                 "parameters": [
                     {
-                        "description": "Log source request",
+                        "type": "string",
+                        "description": "Username, UUID, or me",
+                        "name": "user",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Create workspace request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/agentsdk.PostLogSourceRequest"
+                            "$ref": "#/definitions/codersdk.CreateWorkspaceRequest"
                         }
                     }
                 ],
@@ -6799,109 +7064,126 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/codersdk.WorkspaceAgentLogSource"
+                            "$ref": "#/definitions/codersdk.Workspace"
                         }
                     }
                 }
             }
         },
-        "/workspaceagents/me/logs": {
-            "patch": {
+        "/workspace-quota/{user}": {
+            "get": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Agents"
+                    "Enterprise"
                 ],
-                "summary": "Patch workspace agent logs",
-                "operationId": "patch-workspace-agent-logs",
+                "summary": "Get workspace quota by user deprecated",
+                "operationId": "get-workspace-quota-by-user-deprecated",
+                "deprecated": true,
                 "parameters": [
                     {
-                        "description": "logs",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/agentsdk.PatchLogs"
-                        }
+                        "type": "string",
+                        "description": "User ID, name, or me",
+                        "name": "user",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/codersdk.Response"
+                            "$ref": "#/definitions/codersdk.WorkspaceQuota"
                         }
                     }
                 }
             }
         },
-        "/workspaceagents/me/rpc": {
-            "get": {
+        "/workspaceagents/aws-instance-identity": {
+            "post": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
                     "Agents"
                 ],
-                "summary": "Workspace agent RPC API",
-                "operationId": "workspace-agent-rpc-api",
+                "summary": "Authenticate agent on AWS instance",
+                "operationId": "authenticate-agent-on-aws-instance",
+                "parameters": [
+                    {
+                        "description": "Instance identity token",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/agentsdk.AWSInstanceIdentityToken"
+                        }
+                    }
+                ],
                 "responses": {
-                    "101": {
-                        "description": "Switching Protocols"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/agentsdk.AuthenticateResponse"
+                        }
                     }
-                },
-                "x-apidocgen": {
-                    "skip": true
                 }
             }
         },
-        "/workspaceagents/{workspaceagent}": {
-            "get": {
+        "/workspaceagents/azure-instance-identity": {
+            "post": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Agents"
                 ],
-                "summary": "Get workspace agent by ID",
-                "operationId": "get-workspace-agent-by-id",
+                "summary": "Authenticate agent on Azure instance",
+                "operationId": "authenticate-agent-on-azure-instance",
                 "parameters": [
                     {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "Workspace agent ID",
-                        "name": "workspaceagent",
-                        "in": "path",
-                        "required": true
+                        "description": "Instance identity token",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/agentsdk.AzureInstanceIdentityToken"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/codersdk.WorkspaceAgent"
+                            "$ref": "#/definitions/agentsdk.AuthenticateResponse"
                         }
                     }
                 }
             }
         },
-        "/workspaceagents/{workspaceagent}/connection": {
+        "/workspaceagents/connection": {
             "get": {
                 "security": [
                     {
@@ -6914,18 +7196,8 @@ const docTemplate = `{
                 "tags": [
                     "Agents"
                 ],
-                "summary": "Get connection info for workspace agent",
-                "operationId": "get-connection-info-for-workspace-agent",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "Workspace agent ID",
-                        "name": "workspaceagent",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
+                "summary": "Get connection info for workspace agent generic",
+                "operationId": "get-connection-info-for-workspace-agent-generic",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -6933,39 +7205,52 @@ const docTemplate = `{
                             "$ref": "#/definitions/workspacesdk.AgentConnectionInfo"
                         }
                     }
+                },
+                "x-apidocgen": {
+                    "skip": true
                 }
             }
         },
-        "/workspaceagents/{workspaceagent}/coordinate": {
-            "get": {
+        "/workspaceagents/google-instance-identity": {
+            "post": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
                     "Agents"
                 ],
-                "summary": "Coordinate workspace agent",
-                "operationId": "coordinate-workspace-agent",
+                "summary": "Authenticate agent on Google Cloud instance",
+                "operationId": "authenticate-agent-on-google-cloud-instance",
                 "parameters": [
                     {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "Workspace agent ID",
-                        "name": "workspaceagent",
-                        "in": "path",
-                        "required": true
+                        "description": "Instance identity token",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/agentsdk.GoogleInstanceIdentityToken"
+                        }
                     }
                 ],
                 "responses": {
-                    "101": {
-                        "description": "Switching Protocols"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/agentsdk.AuthenticateResponse"
+                        }
                     }
                 }
             }
         },
-        "/workspaceagents/{workspaceagent}/listening-ports": {
+        "/workspaceagents/me/external-auth": {
             "get": {
                 "security": [
                     {
@@ -6978,29 +7263,41 @@ const docTemplate = `{
                 "tags": [
                     "Agents"
                 ],
-                "summary": "Get listening ports for workspace agent",
-                "operationId": "get-listening-ports-for-workspace-agent",
+                "summary": "Get workspace agent external auth",
+                "operationId": "get-workspace-agent-external-auth",
                 "parameters": [
                     {
                         "type": "string",
-                        "format": "uuid",
-                        "description": "Workspace agent ID",
-                        "name": "workspaceagent",
-                        "in": "path",
+                        "description": "Match",
+                        "name": "match",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Provider ID",
+                        "name": "id",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Wait for a new token to be issued",
+                        "name": "listen",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/codersdk.WorkspaceAgentListeningPortsResponse"
+                            "$ref": "#/definitions/agentsdk.ExternalAuthResponse"
                         }
                     }
                 }
             }
         },
-        "/workspaceagents/{workspaceagent}/logs": {
+        "/workspaceagents/me/gitauth": {
             "get": {
                 "security": [
                     {
@@ -7013,39 +7310,27 @@ const docTemplate = `{
                 "tags": [
                     "Agents"
                 ],
-                "summary": "Get logs by workspace agent",
-                "operationId": "get-logs-by-workspace-agent",
+                "summary": "Removed: Get workspace agent git auth",
+                "operationId": "removed-get-workspace-agent-git-auth",
                 "parameters": [
                     {
                         "type": "string",
-                        "format": "uuid",
-                        "description": "Workspace agent ID",
-                        "name": "workspaceagent",
-                        "in": "path",
+                        "description": "Match",
+                        "name": "match",
+                        "in": "query",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Before log id",
-                        "name": "before",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "After log id",
-                        "name": "after",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Follow log stream",
-                        "name": "follow",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Provider ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
                     },
                     {
                         "type": "boolean",
-                        "description": "Disable compression for WebSocket connection",
-                        "name": "no_compression",
+                        "description": "Wait for a new token to be issued",
+                        "name": "listen",
                         "in": "query"
                     }
                 ],
@@ -7053,91 +7338,1176 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/codersdk.WorkspaceAgentLog"
-                            }
+                            "$ref": "#/definitions/agentsdk.ExternalAuthResponse"
                         }
                     }
                 }
             }
         },
-        "/workspaceagents/{workspaceagent}/pty": {
+        "/workspaceagents/me/gitsshkey": {
             "get": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
                     "Agents"
                 ],
-                "summary": "Open PTY to workspace agent",
-                "operationId": "open-pty-to-workspace-agent",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "Workspace agent ID",
-                        "name": "workspaceagent",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
+                "summary": "Get workspace agent Git SSH key",
+                "operationId": "get-workspace-agent-git-ssh-key",
                 "responses": {
-                    "101": {
-                        "description": "Switching Protocols"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/agentsdk.GitSSHKey"
+                        }
                     }
                 }
             }
         },
-        "/workspaceagents/{workspaceagent}/startup-logs": {
-            "get": {
+        "/workspaceagents/me/log-source": {
+            "post": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Agents"
                 ],
-                "summary": "Removed: Get logs by workspace agent",
-                "operationId": "removed-get-logs-by-workspace-agent",
+                "summary": "Post workspace agent log source",
+                "operationId": "post-workspace-agent-log-source",
                 "parameters": [
                     {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "Workspace agent ID",
-                        "name": "workspaceagent",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Before log id",
-                        "name": "before",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "After log id",
-                        "name": "after",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Follow log stream",
-                        "name": "follow",
+                        "description": "Log source request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/agentsdk.PostLogSourceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.WorkspaceAgentLogSource"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaceagents/me/logs": {
+            "patch": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Agents"
+                ],
+                "summary": "Patch workspace agent logs",
+                "operationId": "patch-workspace-agent-logs",
+                "parameters": [
+                    {
+                        "description": "logs",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/agentsdk.PatchLogs"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaceagents/me/rpc": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "tags": [
+                    "Agents"
+                ],
+                "summary": "Workspace agent RPC API",
+                "operationId": "workspace-agent-rpc-api",
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols"
+                    }
+                },
+                "x-apidocgen": {
+                    "skip": true
+                }
+            }
+        },
+        "/workspaceagents/{workspaceagent}": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Agents"
+                ],
+                "summary": "Get workspace agent by ID",
+                "operationId": "get-workspace-agent-by-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Workspace agent ID",
+                        "name": "workspaceagent",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.WorkspaceAgent"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaceagents/{workspaceagent}/connection": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Agents"
+                ],
+                "summary": "Get connection info for workspace agent",
+                "operationId": "get-connection-info-for-workspace-agent",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Workspace agent ID",
+                        "name": "workspaceagent",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/workspacesdk.AgentConnectionInfo"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaceagents/{workspaceagent}/containers": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "description": "Containers are listed periodically by the agent with the\ndocker or podman CLI and reported to coderd. Devcontainers are\ndetected by the labels the devcontainer CLI sets.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Agents"
+                ],
+                "summary": "Get running containers for workspace agent",
+                "operationId": "get-running-containers-for-workspace-agent",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Workspace agent ID",
+                        "name": "workspaceagent",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.WorkspaceAgentListContainersResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaceagents/{workspaceagent}/coordinate": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "tags": [
+                    "Agents"
+                ],
+                "summary": "Coordinate workspace agent",
+                "operationId": "coordinate-workspace-agent",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Workspace agent ID",
+                        "name": "workspaceagent",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols"
+                    }
+                }
+            }
+        },
+        "/workspaceagents/{workspaceagent}/exec": {
+            "post": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "description": "The command is run without a terminal, and the response is\nreturned once it exits.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Agents"
+                ],
+                "summary": "Run command in workspace agent",
+                "operationId": "run-command-in-workspace-agent",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Workspace agent ID",
+                        "name": "workspaceagent",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Exec request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.WorkspaceAgentExecRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.WorkspaceAgentExecResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaceagents/{workspaceagent}/files/archive": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "tags": [
+                    "Agents"
+                ],
+                "summary": "Download directory from workspace agent as tar archive",
+                "operationId": "download-directory-from-workspace-agent-as-tar-archive",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Workspace agent ID",
+                        "name": "workspaceagent",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Path of the directory, relative paths are relative to the agent directory",
+                        "name": "path",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/workspaceagents/{workspaceagent}/files/download": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "description": "Range requests are supported to resume downloads.",
+                "tags": [
+                    "Agents"
+                ],
+                "summary": "Download file from workspace agent",
+                "operationId": "download-file-from-workspace-agent",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Workspace agent ID",
+                        "name": "workspaceagent",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Path of the file, relative paths are relative to the agent directory",
+                        "name": "path",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/workspaceagents/{workspaceagent}/files/list": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Agents"
+                ],
+                "summary": "List directory of workspace agent",
+                "operationId": "list-directory-of-workspace-agent",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Workspace agent ID",
+                        "name": "workspaceagent",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Path of the directory, relative paths are relative to the agent directory",
+                        "name": "path",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.WorkspaceAgentFileList"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaceagents/{workspaceagent}/files/stat": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Agents"
+                ],
+                "summary": "Get file info from workspace agent",
+                "operationId": "get-file-info-from-workspace-agent",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Workspace agent ID",
+                        "name": "workspaceagent",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Path of the file, relative paths are relative to the agent directory",
+                        "name": "path",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.WorkspaceAgentFileInfo"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaceagents/{workspaceagent}/files/upload": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Agents"
+                ],
+                "summary": "Get file upload progress from workspace agent",
+                "operationId": "get-file-upload-progress-from-workspace-agent",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Workspace agent ID",
+                        "name": "workspaceagent",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Path of the file, relative paths are relative to the agent directory",
+                        "name": "path",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.WorkspaceAgentFileUpload"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "description": "Chunks must be sent in order, starting at the offset of the upload progress.",
+                "consumes": [
+                    "application/octet-stream"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Agents"
+                ],
+                "summary": "Upload file chunk to workspace agent",
+                "operationId": "upload-file-chunk-to-workspace-agent",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Workspace agent ID",
+                        "name": "workspaceagent",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Path of the file, relative paths are relative to the agent directory",
+                        "name": "path",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset of the chunk in the file",
+                        "name": "offset",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Move the upload to its path after this chunk",
+                        "name": "complete",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Octal permission bits of the file, defaults to 644",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Contents of the chunk",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.WorkspaceAgentFileUpload"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaceagents/{workspaceagent}/listening-ports": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Agents"
+                ],
+                "summary": "Get listening ports for workspace agent",
+                "operationId": "get-listening-ports-for-workspace-agent",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Workspace agent ID",
+                        "name": "workspaceagent",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.WorkspaceAgentListeningPortsResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaceagents/{workspaceagent}/logs": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Agents"
+                ],
+                "summary": "Get logs by workspace agent",
+                "operationId": "get-logs-by-workspace-agent",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Workspace agent ID",
+                        "name": "workspaceagent",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Before log id",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "After log id",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Follow log stream",
+                        "name": "follow",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Disable compression for WebSocket connection",
+                        "name": "no_compression",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/codersdk.WorkspaceAgentLog"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaceagents/{workspaceagent}/metadata/{key}/history": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "description": "Samples are only recorded when the agent metadata history\nretention of the deployment is greater than zero.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Agents"
+                ],
+                "summary": "Get workspace agent metadata history",
+                "operationId": "get-workspace-agent-metadata-history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Workspace agent ID",
+                        "name": "workspaceagent",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Metadata key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Only return samples collected after this time",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of the newest samples to return",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/codersdk.WorkspaceAgentMetadataSample"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaceagents/{workspaceagent}/processes": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Agents"
+                ],
+                "summary": "Get processes of workspace agent",
+                "operationId": "get-processes-of-workspace-agent",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Workspace agent ID",
+                        "name": "workspaceagent",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.WorkspaceAgentProcessesResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaceagents/{workspaceagent}/pty": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "tags": [
+                    "Agents"
+                ],
+                "summary": "Open PTY to workspace agent",
+                "operationId": "open-pty-to-workspace-agent",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Workspace agent ID",
+                        "name": "workspaceagent",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols"
+                    }
+                }
+            }
+        },
+        "/workspaceagents/{workspaceagent}/reconnecting-ptys": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "description": "Sessions can be attached to read only or taken over through\nthe PTY endpoint with the read_only and takeover query\nparameters, which requires the share_session permission on the\nworkspace. The sessions of other users are only listed with\nthat permission as well.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Agents"
+                ],
+                "summary": "Get reconnecting PTY sessions of workspace agent",
+                "operationId": "get-reconnecting-pty-sessions-of-workspace-agent",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Workspace agent ID",
+                        "name": "workspaceagent",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.WorkspaceAgentReconnectingPTYsResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaceagents/{workspaceagent}/scripts/{script}/run": {
+            "post": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "description": "The script runs in the background. Its run is added to the\nruns of the script when it exits.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Agents"
+                ],
+                "summary": "Run workspace agent script",
+                "operationId": "run-workspace-agent-script",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Workspace agent ID",
+                        "name": "workspaceagent",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Script ID",
+                        "name": "script",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaceagents/{workspaceagent}/scripts/{script}/runs": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Agents"
+                ],
+                "summary": "Get runs of workspace agent script",
+                "operationId": "get-runs-of-workspace-agent-script",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Workspace agent ID",
+                        "name": "workspaceagent",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Script ID",
+                        "name": "script",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/codersdk.WorkspaceAgentScriptRun"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaceagents/{workspaceagent}/startup-logs": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Agents"
+                ],
+                "summary": "Removed: Get logs by workspace agent",
+                "operationId": "removed-get-logs-by-workspace-agent",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Workspace agent ID",
+                        "name": "workspaceagent",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Before log id",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "After log id",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Follow log stream",
+                        "name": "follow",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Disable compression for WebSocket connection",
+                        "name": "no_compression",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/codersdk.WorkspaceAgentLog"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaceagents/{workspaceagent}/watch-metadata": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "tags": [
+                    "Agents"
+                ],
+                "summary": "Watch for workspace agent metadata updates",
+                "operationId": "watch-for-workspace-agent-metadata-updates",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Workspace agent ID",
+                        "name": "workspaceagent",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success"
+                    }
+                },
+                "x-apidocgen": {
+                    "skip": true
+                }
+            }
+        },
+        "/workspacebuilds/{workspacebuild}": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Builds"
+                ],
+                "summary": "Get workspace build",
+                "operationId": "get-workspace-build",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace build ID",
+                        "name": "workspacebuild",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.WorkspaceBuild"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspacebuilds/{workspacebuild}/cancel": {
+            "patch": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Builds"
+                ],
+                "summary": "Cancel workspace build",
+                "operationId": "cancel-workspace-build",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace build ID",
+                        "name": "workspacebuild",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspacebuilds/{workspacebuild}/logs": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Builds"
+                ],
+                "summary": "Get workspace build logs",
+                "operationId": "get-workspace-build-logs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace build ID",
+                        "name": "workspacebuild",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Before Unix timestamp",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "After Unix timestamp",
+                        "name": "after",
                         "in": "query"
                     },
                     {
-                        "type": "boolean",
-                        "description": "Disable compression for WebSocket connection",
-                        "name": "no_compression",
-                        "in": "query"
+                        "type": "boolean",
+                        "description": "Follow log stream",
+                        "name": "follow",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/codersdk.ProvisionerJobLog"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/workspacebuilds/{workspacebuild}/parameters": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Builds"
+                ],
+                "summary": "Get build parameters for workspace build",
+                "operationId": "get-build-parameters-for-workspace-build",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace build ID",
+                        "name": "workspacebuild",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -7146,46 +8516,52 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/codersdk.WorkspaceAgentLog"
+                                "$ref": "#/definitions/codersdk.WorkspaceBuildParameter"
                             }
                         }
                     }
                 }
             }
         },
-        "/workspaceagents/{workspaceagent}/watch-metadata": {
+        "/workspacebuilds/{workspacebuild}/resources": {
             "get": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Agents"
+                    "Builds"
                 ],
-                "summary": "Watch for workspace agent metadata updates",
-                "operationId": "watch-for-workspace-agent-metadata-updates",
+                "summary": "Removed: Get workspace resources for workspace build",
+                "operationId": "removed-get-workspace-resources-for-workspace-build",
+                "deprecated": true,
                 "parameters": [
                     {
                         "type": "string",
-                        "format": "uuid",
-                        "description": "Workspace agent ID",
-                        "name": "workspaceagent",
+                        "description": "Workspace build ID",
+                        "name": "workspacebuild",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success"
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/codersdk.WorkspaceResource"
+                            }
+                        }
                     }
-                },
-                "x-apidocgen": {
-                    "skip": true
                 }
             }
         },
-        "/workspacebuilds/{workspacebuild}": {
+        "/workspacebuilds/{workspacebuild}/state": {
             "get": {
                 "security": [
                     {
@@ -7198,8 +8574,8 @@ const docTemplate = `{
                 "tags": [
                     "Builds"
                 ],
-                "summary": "Get workspace build",
-                "operationId": "get-workspace-build",
+                "summary": "Get provisioner state for workspace build",
+                "operationId": "get-provisioner-state-for-workspace-build",
                 "parameters": [
                     {
                         "type": "string",
@@ -7219,41 +8595,250 @@ const docTemplate = `{
                 }
             }
         },
-        "/workspacebuilds/{workspacebuild}/cancel": {
-            "patch": {
+        "/workspaceproxies": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Enterprise"
+                ],
+                "summary": "Get workspace proxies",
+                "operationId": "get-workspace-proxies",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/codersdk.RegionsResponse-codersdk_WorkspaceProxy"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Enterprise"
+                ],
+                "summary": "Create workspace proxy",
+                "operationId": "create-workspace-proxy",
+                "parameters": [
+                    {
+                        "description": "Create workspace proxy request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.CreateWorkspaceProxyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.WorkspaceProxy"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaceproxies/me/app-stats": {
+            "post": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Enterprise"
+                ],
+                "summary": "Report workspace app stats",
+                "operationId": "report-workspace-app-stats",
+                "parameters": [
+                    {
+                        "description": "Report app stats request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/wsproxysdk.ReportAppStatsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                },
+                "x-apidocgen": {
+                    "skip": true
+                }
+            }
+        },
+        "/workspaceproxies/me/coordinate": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "tags": [
+                    "Enterprise"
+                ],
+                "summary": "Workspace Proxy Coordinate",
+                "operationId": "workspace-proxy-coordinate",
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols"
+                    }
+                },
+                "x-apidocgen": {
+                    "skip": true
+                }
+            }
+        },
+        "/workspaceproxies/me/deregister": {
+            "post": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Enterprise"
+                ],
+                "summary": "Deregister workspace proxy",
+                "operationId": "deregister-workspace-proxy",
+                "parameters": [
+                    {
+                        "description": "Deregister workspace proxy request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/wsproxysdk.DeregisterWorkspaceProxyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                },
+                "x-apidocgen": {
+                    "skip": true
+                }
+            }
+        },
+        "/workspaceproxies/me/issue-signed-app-token": {
+            "post": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Enterprise"
+                ],
+                "summary": "Issue signed workspace app token",
+                "operationId": "issue-signed-workspace-app-token",
+                "parameters": [
+                    {
+                        "description": "Issue signed app token request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/workspaceapps.IssueTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/wsproxysdk.IssueSignedAppTokenResponse"
+                        }
+                    }
+                },
+                "x-apidocgen": {
+                    "skip": true
+                }
+            }
+        },
+        "/workspaceproxies/me/register": {
+            "post": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Builds"
+                    "Enterprise"
                 ],
-                "summary": "Cancel workspace build",
-                "operationId": "cancel-workspace-build",
+                "summary": "Register workspace proxy",
+                "operationId": "register-workspace-proxy",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Workspace build ID",
-                        "name": "workspacebuild",
-                        "in": "path",
-                        "required": true
+                        "description": "Register workspace proxy request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/wsproxysdk.RegisterWorkspaceProxyRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/codersdk.Response"
+                            "$ref": "#/definitions/wsproxysdk.RegisterWorkspaceProxyResponse"
                         }
                     }
+                },
+                "x-apidocgen": {
+                    "skip": true
                 }
             }
         },
-        "/workspacebuilds/{workspacebuild}/logs": {
+        "/workspaceproxies/{workspaceproxy}": {
             "get": {
                 "security": [
                     {
@@ -7264,52 +8849,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Builds"
+                    "Enterprise"
                 ],
-                "summary": "Get workspace build logs",
-                "operationId": "get-workspace-build-logs",
+                "summary": "Get workspace proxy",
+                "operationId": "get-workspace-proxy",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Workspace build ID",
-                        "name": "workspacebuild",
+                        "format": "uuid",
+                        "description": "Proxy ID or name",
+                        "name": "workspaceproxy",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Before Unix timestamp",
-                        "name": "before",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "After Unix timestamp",
-                        "name": "after",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Follow log stream",
-                        "name": "follow",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/codersdk.ProvisionerJobLog"
-                            }
+                            "$ref": "#/definitions/codersdk.WorkspaceProxy"
                         }
                     }
                 }
-            }
-        },
-        "/workspacebuilds/{workspacebuild}/parameters": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "CoderSessionToken": []
@@ -7319,15 +8882,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Builds"
+                    "Enterprise"
                 ],
-                "summary": "Get build parameters for workspace build",
-                "operationId": "get-build-parameters-for-workspace-build",
+                "summary": "Delete workspace proxy",
+                "operationId": "delete-workspace-proxy",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Workspace build ID",
-                        "name": "workspacebuild",
+                        "format": "uuid",
+                        "description": "Proxy ID or name",
+                        "name": "workspaceproxy",
                         "in": "path",
                         "required": true
                     }
@@ -7336,54 +8900,58 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/codersdk.WorkspaceBuildParameter"
-                            }
+                            "$ref": "#/definitions/codersdk.Response"
                         }
                     }
                 }
-            }
-        },
-        "/workspacebuilds/{workspacebuild}/resources": {
-            "get": {
+            },
+            "patch": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Builds"
+                    "Enterprise"
                 ],
-                "summary": "Removed: Get workspace resources for workspace build",
-                "operationId": "removed-get-workspace-resources-for-workspace-build",
-                "deprecated": true,
+                "summary": "Update workspace proxy",
+                "operationId": "update-workspace-proxy",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Workspace build ID",
-                        "name": "workspacebuild",
+                        "format": "uuid",
+                        "description": "Proxy ID or name",
+                        "name": "workspaceproxy",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Update workspace proxy request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.PatchWorkspaceProxy"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/codersdk.WorkspaceResource"
-                            }
+                            "$ref": "#/definitions/codersdk.WorkspaceProxy"
                         }
                     }
                 }
             }
         },
-        "/workspacebuilds/{workspacebuild}/state": {
+        "/workspaces": {
             "get": {
                 "security": [
                     {
@@ -7394,30 +8962,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Builds"
+                    "Workspaces"
                 ],
-                "summary": "Get provisioner state for workspace build",
-                "operationId": "get-provisioner-state-for-workspace-build",
+                "summary": "List workspaces",
+                "operationId": "list-workspaces",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Workspace build ID",
-                        "name": "workspacebuild",
-                        "in": "path",
-                        "required": true
+                        "description": "Search query in the format ` + "`" + `key:value` + "`" + `. Available keys are: owner, template, template_version, name, status, has-agent, agent_status, initiator, build_reason, dormant, last_used_after, last_used_before, created_after, created_before. Terms can be negated with a ` + "`" + `-` + "`" + ` prefix, and combined with OR inside parentheses.",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page offset",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/codersdk.WorkspaceBuild"
+                            "$ref": "#/definitions/codersdk.WorkspacesResponse"
                         }
                     }
                 }
             }
         },
-        "/workspaceproxies": {
+        "/workspaces/{workspace}": {
             "get": {
                 "security": [
                     {
@@ -7428,23 +9007,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Enterprise"
+                    "Workspaces"
+                ],
+                "summary": "Get workspace metadata by ID",
+                "operationId": "get-workspace-metadata-by-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Workspace ID",
+                        "name": "workspace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return data instead of HTTP 404 if the workspace is deleted",
+                        "name": "include_deleted",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get workspace proxies",
-                "operationId": "get-workspace-proxies",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/codersdk.RegionsResponse-codersdk_WorkspaceProxy"
-                            }
+                            "$ref": "#/definitions/codersdk.Workspace"
                         }
                     }
                 }
             },
-            "post": {
+            "patch": {
                 "security": [
                     {
                         "CoderSessionToken": []
@@ -7453,37 +9045,39 @@ const docTemplate = `{
                 "consumes": [
                     "application/json"
                 ],
-                "produces": [
-                    "application/json"
-                ],
                 "tags": [
-                    "Enterprise"
+                    "Workspaces"
                 ],
-                "summary": "Create workspace proxy",
-                "operationId": "create-workspace-proxy",
+                "summary": "Update workspace metadata by ID",
+                "operationId": "update-workspace-metadata-by-id",
                 "parameters": [
                     {
-                        "description": "Create workspace proxy request",
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Workspace ID",
+                        "name": "workspace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Metadata update request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/codersdk.CreateWorkspaceProxyRequest"
+                            "$ref": "#/definitions/codersdk.UpdateWorkspaceRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/codersdk.WorkspaceProxy"
-                        }
+                    "204": {
+                        "description": "No Content"
                     }
                 }
             }
         },
-        "/workspaceproxies/me/app-stats": {
-            "post": {
+        "/workspaces/{workspace}/autostart": {
+            "put": {
                 "security": [
                     {
                         "CoderSessionToken": []
@@ -7493,55 +9087,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Enterprise"
+                    "Workspaces"
                 ],
-                "summary": "Report workspace app stats",
-                "operationId": "report-workspace-app-stats",
+                "summary": "Update workspace autostart schedule by ID",
+                "operationId": "update-workspace-autostart-schedule-by-id",
                 "parameters": [
                     {
-                        "description": "Report app stats request",
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Workspace ID",
+                        "name": "workspace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Schedule update request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/wsproxysdk.ReportAppStatsRequest"
+                            "$ref": "#/definitions/codersdk.UpdateWorkspaceAutostartRequest"
                         }
-                    }
-                ],
-                "responses": {
-                    "204": {
-                        "description": "No Content"
-                    }
-                },
-                "x-apidocgen": {
-                    "skip": true
-                }
-            }
-        },
-        "/workspaceproxies/me/coordinate": {
-            "get": {
-                "security": [
-                    {
-                        "CoderSessionToken": []
-                    }
-                ],
-                "tags": [
-                    "Enterprise"
+                    }
                 ],
-                "summary": "Workspace Proxy Coordinate",
-                "operationId": "workspace-proxy-coordinate",
                 "responses": {
-                    "101": {
-                        "description": "Switching Protocols"
+                    "204": {
+                        "description": "No Content"
                     }
-                },
-                "x-apidocgen": {
-                    "skip": true
                 }
             }
         },
-        "/workspaceproxies/me/deregister": {
-            "post": {
+        "/workspaces/{workspace}/autoupdates": {
+            "put": {
                 "security": [
                     {
                         "CoderSessionToken": []
@@ -7551,18 +9128,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Enterprise"
+                    "Workspaces"
                 ],
-                "summary": "Deregister workspace proxy",
-                "operationId": "deregister-workspace-proxy",
+                "summary": "Update workspace automatic updates by ID",
+                "operationId": "update-workspace-automatic-updates-by-id",
                 "parameters": [
                     {
-                        "description": "Deregister workspace proxy request",
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Workspace ID",
+                        "name": "workspace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Automatic updates request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/wsproxysdk.DeregisterWorkspaceProxyRequest"
+                            "$ref": "#/definitions/codersdk.UpdateWorkspaceAutomaticUpdatesRequest"
                         }
                     }
                 ],
@@ -7570,55 +9155,72 @@ const docTemplate = `{
                     "204": {
                         "description": "No Content"
                     }
-                },
-                "x-apidocgen": {
-                    "skip": true
                 }
             }
         },
-        "/workspaceproxies/me/issue-signed-app-token": {
-            "post": {
+        "/workspaces/{workspace}/builds": {
+            "get": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Enterprise"
+                    "Builds"
                 ],
-                "summary": "Issue signed workspace app token",
-                "operationId": "issue-signed-workspace-app-token",
+                "summary": "Get workspace builds by workspace ID",
+                "operationId": "get-workspace-builds-by-workspace-id",
                 "parameters": [
                     {
-                        "description": "Issue signed app token request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/workspaceapps.IssueTokenRequest"
-                        }
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Workspace ID",
+                        "name": "workspace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "After ID",
+                        "name": "after_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Since timestamp",
+                        "name": "since",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/wsproxysdk.IssueSignedAppTokenResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/codersdk.WorkspaceBuild"
+                            }
                         }
                     }
-                },
-                "x-apidocgen": {
-                    "skip": true
                 }
-            }
-        },
-        "/workspaceproxies/me/register": {
+            },
             "post": {
                 "security": [
                     {
@@ -7632,102 +9234,136 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Enterprise"
+                    "Builds"
                 ],
-                "summary": "Register workspace proxy",
-                "operationId": "register-workspace-proxy",
+                "summary": "Create workspace build",
+                "operationId": "create-workspace-build",
                 "parameters": [
                     {
-                        "description": "Register workspace proxy request",
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Workspace ID",
+                        "name": "workspace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Create workspace build request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/wsproxysdk.RegisterWorkspaceProxyRequest"
+                            "$ref": "#/definitions/codersdk.CreateWorkspaceBuildRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/wsproxysdk.RegisterWorkspaceProxyResponse"
+                            "$ref": "#/definitions/codersdk.WorkspaceBuild"
                         }
                     }
-                },
-                "x-apidocgen": {
-                    "skip": true
                 }
             }
         },
-        "/workspaceproxies/{workspaceproxy}": {
-            "get": {
+        "/workspaces/{workspace}/clone": {
+            "post": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
+                "description": "Create a new workspace for the authenticated user from an\nexisting workspace, which may be owned by another user. The\nclone uses the template version, build parameters, schedule and\nautomatic updates setting of the source workspace.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Enterprise"
+                    "Workspaces"
                 ],
-                "summary": "Get workspace proxy",
-                "operationId": "get-workspace-proxy",
+                "summary": "Clone workspace",
+                "operationId": "clone-workspace",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Proxy ID or name",
-                        "name": "workspaceproxy",
+                        "description": "Workspace ID",
+                        "name": "workspace",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Clone workspace request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.CloneWorkspaceRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/codersdk.WorkspaceProxy"
+                            "$ref": "#/definitions/codersdk.Workspace"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/workspaces/{workspace}/dormant": {
+            "put": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Enterprise"
+                    "Workspaces"
                 ],
-                "summary": "Delete workspace proxy",
-                "operationId": "delete-workspace-proxy",
+                "summary": "Update workspace dormancy status by id.",
+                "operationId": "update-workspace-dormancy-status-by-id",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Proxy ID or name",
-                        "name": "workspaceproxy",
+                        "description": "Workspace ID",
+                        "name": "workspace",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Make a workspace dormant or active",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.UpdateWorkspaceDormancy"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/codersdk.Response"
+                            "$ref": "#/definitions/codersdk.Workspace"
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/workspaces/{workspace}/extend": {
+            "put": {
                 "security": [
                     {
                         "CoderSessionToken": []
@@ -7740,26 +9376,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Enterprise"
+                    "Workspaces"
                 ],
-                "summary": "Update workspace proxy",
-                "operationId": "update-workspace-proxy",
+                "summary": "Extend workspace deadline by ID",
+                "operationId": "extend-workspace-deadline-by-id",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Proxy ID or name",
-                        "name": "workspaceproxy",
+                        "description": "Workspace ID",
+                        "name": "workspace",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Update workspace proxy request",
+                        "description": "Extend deadline update request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/codersdk.PatchWorkspaceProxy"
+                            "$ref": "#/definitions/codersdk.PutExtendWorkspaceRequest"
                         }
                     }
                 ],
@@ -7767,58 +9403,69 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/codersdk.WorkspaceProxy"
+                            "$ref": "#/definitions/codersdk.Response"
                         }
                     }
                 }
             }
         },
-        "/workspaces": {
-            "get": {
+        "/workspaces/{workspace}/favorite": {
+            "put": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
-                "produces": [
-                    "application/json"
-                ],
                 "tags": [
                     "Workspaces"
                 ],
-                "summary": "List workspaces",
-                "operationId": "list-workspaces",
+                "summary": "Favorite workspace by ID.",
+                "operationId": "favorite-workspace-by-id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Search query in the format ` + "`" + `key:value` + "`" + `. Available keys are: owner, template, name, status, has-agent, dormant, last_used_after, last_used_before.",
-                        "name": "q",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page limit",
-                        "name": "limit",
-                        "in": "query"
-                    },
+                        "format": "uuid",
+                        "description": "Workspace ID",
+                        "name": "workspace",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            },
+            "delete": {
+                "security": [
                     {
-                        "type": "integer",
-                        "description": "Page offset",
-                        "name": "offset",
-                        "in": "query"
+                        "CoderSessionToken": []
+                    }
+                ],
+                "tags": [
+                    "Workspaces"
+                ],
+                "summary": "Unfavorite workspace by ID.",
+                "operationId": "unfavorite-workspace-by-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Workspace ID",
+                        "name": "workspace",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/codersdk.WorkspacesResponse"
-                        }
+                    "204": {
+                        "description": "No Content"
                     }
                 }
             }
         },
-        "/workspaces/{workspace}": {
+        "/workspaces/{workspace}/port-share": {
             "get": {
                 "security": [
                     {
@@ -7829,10 +9476,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Workspaces"
+                    "PortSharing"
                 ],
-                "summary": "Get workspace metadata by ID",
-                "operationId": "get-workspace-metadata-by-id",
+                "summary": "Get workspace agent port shares",
+                "operationId": "get-workspace-agent-port-shares",
                 "parameters": [
                     {
                         "type": "string",
@@ -7841,24 +9488,18 @@ const docTemplate = `{
                         "name": "workspace",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Return data instead of HTTP 404 if the workspace is deleted",
-                        "name": "include_deleted",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/codersdk.Workspace"
+                            "$ref": "#/definitions/codersdk.WorkspaceAgentPortShares"
                         }
                     }
                 }
             },
-            "patch": {
+            "post": {
                 "security": [
                     {
                         "CoderSessionToken": []
@@ -7867,11 +9508,14 @@ const docTemplate = `{
                 "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Workspaces"
+                    "PortSharing"
                 ],
-                "summary": "Update workspace metadata by ID",
-                "operationId": "update-workspace-metadata-by-id",
+                "summary": "Upsert workspace agent port share",
+                "operationId": "upsert-workspace-agent-port-share",
                 "parameters": [
                     {
                         "type": "string",
@@ -7882,24 +9526,25 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Metadata update request",
+                        "description": "Upsert port sharing level request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/codersdk.UpdateWorkspaceRequest"
+                            "$ref": "#/definitions/codersdk.UpsertWorkspaceAgentPortShareRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.WorkspaceAgentPortShare"
+                        }
                     }
                 }
-            }
-        },
-        "/workspaces/{workspace}/autostart": {
-            "put": {
+            },
+            "delete": {
                 "security": [
                     {
                         "CoderSessionToken": []
@@ -7909,10 +9554,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Workspaces"
+                    "PortSharing"
                 ],
-                "summary": "Update workspace autostart schedule by ID",
-                "operationId": "update-workspace-autostart-schedule-by-id",
+                "summary": "Get workspace agent port shares",
+                "operationId": "get-workspace-agent-port-shares",
                 "parameters": [
                     {
                         "type": "string",
@@ -7923,37 +9568,37 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Schedule update request",
+                        "description": "Delete port sharing level request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/codersdk.UpdateWorkspaceAutostartRequest"
+                            "$ref": "#/definitions/codersdk.DeleteWorkspaceAgentPortShareRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK"
                     }
                 }
             }
         },
-        "/workspaces/{workspace}/autoupdates": {
-            "put": {
+        "/workspaces/{workspace}/resolve-autostart": {
+            "get": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
-                "consumes": [
+                "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Workspaces"
                 ],
-                "summary": "Update workspace automatic updates by ID",
-                "operationId": "update-workspace-automatic-updates-by-id",
+                "summary": "Resolve workspace autostart by id.",
+                "operationId": "resolve-workspace-autostart-by-id",
                 "parameters": [
                     {
                         "type": "string",
@@ -7962,25 +9607,19 @@ const docTemplate = `{
                         "name": "workspace",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Automatic updates request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/codersdk.UpdateWorkspaceAutomaticUpdatesRequest"
-                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.ResolveAutostartResponse"
+                        }
                     }
                 }
             }
         },
-        "/workspaces/{workspace}/builds": {
+        "/workspaces/{workspace}/session-recordings": {
             "get": {
                 "security": [
                     {
@@ -7991,10 +9630,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Builds"
+                    "Audit"
                 ],
-                "summary": "Get workspace builds by workspace ID",
-                "operationId": "get-workspace-builds-by-workspace-id",
+                "summary": "Get workspace session recordings",
+                "operationId": "get-workspace-session-recordings",
                 "parameters": [
                     {
                         "type": "string",
@@ -8003,32 +9642,6 @@ const docTemplate = `{
                         "name": "workspace",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "After ID",
-                        "name": "after_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page limit",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page offset",
-                        "name": "offset",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "format": "date-time",
-                        "description": "Since timestamp",
-                        "name": "since",
-                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -8037,29 +9650,28 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/codersdk.WorkspaceBuild"
+                                "$ref": "#/definitions/codersdk.WorkspaceAgentSessionRecording"
                             }
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/workspaces/{workspace}/snapshots": {
+            "get": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Builds"
+                    "Workspaces"
                 ],
-                "summary": "Create workspace build",
-                "operationId": "create-workspace-build",
+                "summary": "Get workspace snapshots",
+                "operationId": "get-workspace-snapshots",
                 "parameters": [
                     {
                         "type": "string",
@@ -8068,29 +9680,21 @@ const docTemplate = `{
                         "name": "workspace",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Create workspace build request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/codersdk.CreateWorkspaceBuildRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/codersdk.WorkspaceBuild"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/codersdk.WorkspaceSnapshot"
+                            }
                         }
                     }
                 }
-            }
-        },
-        "/workspaces/{workspace}/dormant": {
-            "put": {
+            },
+            "post": {
                 "security": [
                     {
                         "CoderSessionToken": []
@@ -8105,8 +9709,8 @@ const docTemplate = `{
                 "tags": [
                     "Workspaces"
                 ],
-                "summary": "Update workspace dormancy status by id.",
-                "operationId": "update-workspace-dormancy-status-by-id",
+                "summary": "Create workspace snapshot",
+                "operationId": "create-workspace-snapshot",
                 "parameters": [
                     {
                         "type": "string",
@@ -8117,43 +9721,40 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Make a workspace dormant or active",
+                        "description": "Create workspace snapshot request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/codersdk.UpdateWorkspaceDormancy"
+                            "$ref": "#/definitions/codersdk.CreateWorkspaceSnapshotRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/codersdk.Workspace"
+                            "$ref": "#/definitions/codersdk.WorkspaceSnapshot"
                         }
                     }
                 }
             }
         },
-        "/workspaces/{workspace}/extend": {
-            "put": {
+        "/workspaces/{workspace}/snapshots/{workspacesnapshot}": {
+            "get": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Workspaces"
                 ],
-                "summary": "Extend workspace deadline by ID",
-                "operationId": "extend-workspace-deadline-by-id",
+                "summary": "Get workspace snapshot",
+                "operationId": "get-workspace-snapshot",
                 "parameters": [
                     {
                         "type": "string",
@@ -8164,27 +9765,24 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Extend deadline update request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/codersdk.PutExtendWorkspaceRequest"
-                        }
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Workspace snapshot ID",
+                        "name": "workspacesnapshot",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/codersdk.Response"
+                            "$ref": "#/definitions/codersdk.WorkspaceSnapshot"
                         }
                     }
                 }
-            }
-        },
-        "/workspaces/{workspace}/favorite": {
-            "put": {
+            },
+            "delete": {
                 "security": [
                     {
                         "CoderSessionToken": []
@@ -8193,8 +9791,8 @@ const docTemplate = `{
                 "tags": [
                     "Workspaces"
                 ],
-                "summary": "Favorite workspace by ID.",
-                "operationId": "favorite-workspace-by-id",
+                "summary": "Delete workspace snapshot",
+                "operationId": "delete-workspace-snapshot",
                 "parameters": [
                     {
                         "type": "string",
@@ -8203,31 +9801,12 @@ const docTemplate = `{
                         "name": "workspace",
                         "in": "path",
                         "required": true
-                    }
-                ],
-                "responses": {
-                    "204": {
-                        "description": "No Content"
-                    }
-                }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "CoderSessionToken": []
-                    }
-                ],
-                "tags": [
-                    "Workspaces"
-                ],
-                "summary": "Unfavorite workspace by ID.",
-                "operationId": "unfavorite-workspace-by-id",
-                "parameters": [
+                    },
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Workspace ID",
-                        "name": "workspace",
+                        "description": "Workspace snapshot ID",
+                        "name": "workspacesnapshot",
                         "in": "path",
                         "required": true
                     }
@@ -8239,7 +9818,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/workspaces/{workspace}/port-share": {
+        "/workspaces/{workspace}/snapshots/{workspacesnapshot}/logs": {
             "get": {
                 "security": [
                     {
@@ -8250,10 +9829,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "PortSharing"
+                    "Workspaces"
                 ],
-                "summary": "Get workspace agent port shares",
-                "operationId": "get-workspace-agent-port-shares",
+                "summary": "Get workspace snapshot logs",
+                "operationId": "get-workspace-snapshot-logs",
                 "parameters": [
                     {
                         "type": "string",
@@ -8262,17 +9841,48 @@ const docTemplate = `{
                         "name": "workspace",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Workspace snapshot ID",
+                        "name": "workspacesnapshot",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Before log id",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "After log id",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Follow log stream",
+                        "name": "follow",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/codersdk.WorkspaceAgentPortShares"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/codersdk.ProvisionerJobLog"
+                            }
                         }
                     }
                 }
-            },
+            }
+        },
+        "/workspaces/{workspace}/snapshots/{workspacesnapshot}/restore": {
             "post": {
                 "security": [
                     {
@@ -8286,10 +9896,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "PortSharing"
+                    "Workspaces"
                 ],
-                "summary": "Upsert workspace agent port share",
-                "operationId": "upsert-workspace-agent-port-share",
+                "summary": "Restore workspace snapshot",
+                "operationId": "restore-workspace-snapshot",
                 "parameters": [
                     {
                         "type": "string",
@@ -8300,38 +9910,48 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Upsert port sharing level request",
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Workspace snapshot ID",
+                        "name": "workspacesnapshot",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Restore workspace snapshot request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/codersdk.UpsertWorkspaceAgentPortShareRequest"
+                            "$ref": "#/definitions/codersdk.RestoreWorkspaceSnapshotRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/codersdk.WorkspaceAgentPortShare"
+                            "$ref": "#/definitions/codersdk.ProvisionerJob"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/workspaces/{workspace}/snapshots/{workspacesnapshot}/restore/{jobID}": {
+            "get": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
-                "consumes": [
+                "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "PortSharing"
+                    "Workspaces"
                 ],
-                "summary": "Get workspace agent port shares",
-                "operationId": "get-workspace-agent-port-shares",
+                "summary": "Get workspace snapshot restore by job ID",
+                "operationId": "get-workspace-snapshot-restore-by-job-id",
                 "parameters": [
                     {
                         "type": "string",
@@ -8342,23 +9962,33 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Delete port sharing level request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/codersdk.DeleteWorkspaceAgentPortShareRequest"
-                        }
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Workspace snapshot ID",
+                        "name": "workspacesnapshot",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Job ID",
+                        "name": "jobID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.ProvisionerJob"
+                        }
                     }
                 }
             }
         },
-        "/workspaces/{workspace}/resolve-autostart": {
+        "/workspaces/{workspace}/snapshots/{workspacesnapshot}/restore/{jobID}/logs": {
             "get": {
                 "security": [
                     {
@@ -8371,8 +10001,8 @@ const docTemplate = `{
                 "tags": [
                     "Workspaces"
                 ],
-                "summary": "Resolve workspace autostart by id.",
-                "operationId": "resolve-workspace-autostart-by-id",
+                "summary": "Get workspace snapshot restore logs by job ID",
+                "operationId": "get-workspace-snapshot-restore-logs-by-job-id",
                 "parameters": [
                     {
                         "type": "string",
@@ -8381,13 +10011,50 @@ const docTemplate = `{
                         "name": "workspace",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Workspace snapshot ID",
+                        "name": "workspacesnapshot",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Job ID",
+                        "name": "jobID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Before log id",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "After log id",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Follow log stream",
+                        "name": "follow",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/codersdk.ResolveAutostartResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/codersdk.ProvisionerJobLog"
+                            }
                         }
                     }
                 }
@@ -8972,7 +10639,8 @@ const docTemplate = `{
                 "stop",
                 "login",
                 "logout",
-                "register"
+                "register",
+                "exec"
             ],
             "x-enum-varnames": [
                 "AuditActionCreate",
@@ -8982,7 +10650,8 @@ const docTemplate = `{
                 "AuditActionStop",
                 "AuditActionLogin",
                 "AuditActionLogout",
-                "AuditActionRegister"
+                "AuditActionRegister",
+                "AuditActionExec"
             ]
         },
         "codersdk.AuditDiff": {
@@ -9073,6 +10742,38 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.AuditLogArchiveConfig": {
+            "type": "object",
+            "properties": {
+                "directory": {
+                    "description": "The local directory to which archives are written.",
+                    "type": "string"
+                },
+                "s3": {
+                    "$ref": "#/definitions/codersdk.ObjectStoreS3Config"
+                }
+            }
+        },
+        "codersdk.AuditLogOTLPConfig": {
+            "type": "object",
+            "properties": {
+                "endpoint": {
+                    "description": "The OTLP/HTTP endpoint of the collector.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/serpent.URL"
+                        }
+                    ]
+                },
+                "headers": {
+                    "description": "Headers sent with every export request, formatted as key=value.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
         "codersdk.AuditLogResponse": {
             "type": "object",
             "properties": {
@@ -9087,6 +10788,49 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.AuditLogSyslogConfig": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "description": "The host:port address of the syslog server. Messages are sent over TCP.",
+                    "type": "string"
+                },
+                "tls": {
+                    "description": "Whether to connect to the syslog server with TLS, as described in RFC 5425.",
+                    "type": "boolean"
+                },
+                "tls_ca_file": {
+                    "description": "A PEM-encoded CA certificate used to verify the syslog server. Defaults to the system certificate pool.",
+                    "type": "string"
+                }
+            }
+        },
+        "codersdk.AuditLoggingConfig": {
+            "type": "object",
+            "properties": {
+                "archive": {
+                    "$ref": "#/definitions/codersdk.AuditLogArchiveConfig"
+                },
+                "buffer_dir": {
+                    "description": "The directory in which audit logs are buffered until they have been delivered to the syslog or OTLP collector.\nDefaults to a directory within the cache directory.",
+                    "type": "string"
+                },
+                "buffer_max_size": {
+                    "description": "The maximum size in bytes of the buffer of each streaming backend. The oldest audit logs are dropped once it is\nfull.",
+                    "type": "integer"
+                },
+                "otlp": {
+                    "$ref": "#/definitions/codersdk.AuditLogOTLPConfig"
+                },
+                "retention": {
+                    "description": "How long audit logs are kept before they are deleted. Zero keeps them forever.",
+                    "type": "integer"
+                },
+                "syslog": {
+                    "$ref": "#/definitions/codersdk.AuditLogSyslogConfig"
+                }
+            }
+        },
         "codersdk.AuthMethod": {
             "type": "object",
             "properties": {
@@ -9265,6 +11009,24 @@ const docTemplate = `{
                 "BuildReasonAutostop"
             ]
         },
+        "codersdk.CloneWorkspaceRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "rich_parameter_values": {
+                    "description": "RichParameterValues override the build parameters of the source\nworkspace. Ephemeral parameters are never copied, so they must be\nprovided here if needed.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/codersdk.WorkspaceBuildParameter"
+                    }
+                }
+            }
+        },
         "codersdk.ConnectionLatency": {
             "type": "object",
             "properties": {
@@ -9414,6 +11176,42 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.CreateSavedSearchRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "resource_type"
+            ],
+            "properties": {
+                "group_id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "organization_id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "query": {
+                    "type": "string"
+                },
+                "resource_type": {
+                    "enum": [
+                        "workspace",
+                        "template",
+                        "user",
+                        "audit_log"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/codersdk.SavedSearchResourceType"
+                        }
+                    ]
+                }
+            }
+        },
         "codersdk.CreateTemplateRequest": {
             "type": "object",
             "required": [
@@ -9523,6 +11321,11 @@ const docTemplate = `{
                         "$ref": "#/definitions/codersdk.VariableValue"
                     }
                 },
+                "workspace_id": {
+                    "description": "WorkspaceID previews an update of the workspace to the template\nversion. The plan is made against the state of its latest build, and\nthe changes to its resources are returned by\nTemplateVersionDryRunResourceChanges.",
+                    "type": "string",
+                    "format": "uuid"
+                },
                 "workspace_name": {
                     "type": "string"
                 }
@@ -9542,6 +11345,19 @@ const docTemplate = `{
                     "type": "string",
                     "format": "uuid"
                 },
+                "iac_engine": {
+                    "description": "IaCEngine optionally requires the version to be provisioned by a specific\nIaC engine. Jobs of the version, and of workspace builds that use it, are\nonly acquired by provisioner daemons with a binary of the engine that\nsatisfies IaCVersionConstraint.",
+                    "type": "string",
+                    "enum": [
+                        "terraform",
+                        "opentofu"
+                    ]
+                },
+                "iac_version_constraint": {
+                    "description": "IaCVersionConstraint constrains the version of IaCEngine, in the syntax of\nTerraform's required_version. If empty, any version is accepted.",
+                    "type": "string",
+                    "example": "~\u003e 1.8.0"
+                },
                 "message": {
                     "type": "string"
                 },
@@ -9820,6 +11636,28 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.CreateWorkspaceSnapshotRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "log_level": {
+                    "description": "Log level changes the default logging verbosity of a provider (\"info\" if empty).",
+                    "enum": [
+                        "debug"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/codersdk.ProvisionerLogLevel"
+                        }
+                    ]
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
         "codersdk.CustomRoleRequest": {
             "type": "object",
             "properties": {
@@ -10023,12 +11861,18 @@ const docTemplate = `{
                 "agent_fallback_troubleshooting_url": {
                     "$ref": "#/definitions/serpent.URL"
                 },
+                "agent_metadata_history_retention": {
+                    "type": "integer"
+                },
                 "agent_stat_refresh_interval": {
                     "type": "integer"
                 },
                 "allow_workspace_renames": {
                     "type": "boolean"
                 },
+                "audit_logging": {
+                    "$ref": "#/definitions/codersdk.AuditLoggingConfig"
+                },
                 "autobuild_poll_interval": {
                     "type": "integer"
                 },
@@ -10204,6 +12048,9 @@ const docTemplate = `{
                 "wildcard_access_url": {
                     "type": "string"
                 },
+                "workspace_drift_check_interval": {
+                    "type": "integer"
+                },
                 "write_config": {
                     "type": "boolean"
                 }
@@ -10626,18 +12473,57 @@ const docTemplate = `{
                     "description": "RegexFilter is a regular expression that filters the groups returned by\nthe OIDC provider. Any group not matched by this regex will be ignored.\nIf the group filter is nil, then no group filtering will occur.",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/regexp.Regexp"
+                            "$ref": "#/definitions/regexp.Regexp"
+                        }
+                    ]
+                }
+            }
+        },
+        "codersdk.Healthcheck": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "description": "Address is the host and port a \"tcp\" or \"grpc\" health check connects to.",
+                    "type": "string"
+                },
+                "body_regex": {
+                    "description": "BodyRegex is a regular expression the response body of an \"http\"\nhealth check must match.",
+                    "type": "string"
+                },
+                "command": {
+                    "description": "Command is run by a \"command\" health check, which is healthy when the\ncommand exits with code 0.",
+                    "type": "string"
+                },
+                "expected_status": {
+                    "description": "ExpectedStatus is the status code an \"http\" health check must return.\nWhen zero, any status code below 500 is healthy.",
+                    "type": "integer"
+                },
+                "grpc_service": {
+                    "description": "GRPCService is the service a \"grpc\" health check asks the status of\nusing the gRPC health checking protocol. When empty, the status of the\nserver is checked.",
+                    "type": "string"
+                },
+                "interval": {
+                    "description": "Interval specifies the seconds between each health check.",
+                    "type": "integer"
+                },
+                "kind": {
+                    "description": "Kind specifies how the app health is checked. An empty kind is \"http\".",
+                    "enum": [
+                        "http",
+                        "tcp",
+                        "grpc",
+                        "command",
+                        "file"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/codersdk.WorkspaceAppHealthcheckKind"
                         }
                     ]
-                }
-            }
-        },
-        "codersdk.Healthcheck": {
-            "type": "object",
-            "properties": {
-                "interval": {
-                    "description": "Interval specifies the seconds between each health check.",
-                    "type": "integer"
+                },
+                "path": {
+                    "description": "Path is the file a \"file\" health check checks the existence of.",
+                    "type": "string"
                 },
                 "threshold": {
                     "description": "Threshold specifies the number of consecutive failed health checks before returning \"unhealthy\".",
@@ -10660,6 +12546,59 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.InboxNotification": {
+            "type": "object",
+            "properties": {
+                "actions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/codersdk.InboxNotificationAction"
+                    }
+                },
+                "archived_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "content": {
+                    "description": "Content is the body of the notification, in markdown.",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "read_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "template_id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string",
+                    "format": "uuid"
+                }
+            }
+        },
+        "codersdk.InboxNotificationAction": {
+            "type": "object",
+            "properties": {
+                "label": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
         "codersdk.InsightsReportInterval": {
             "type": "string",
             "enum": [
@@ -10770,6 +12709,21 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.ListInboxNotificationsResponse": {
+            "type": "object",
+            "properties": {
+                "notifications": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/codersdk.InboxNotification"
+                    }
+                },
+                "unread_count": {
+                    "description": "UnreadCount is the total number of unread, unarchived notifications in the user's inbox, regardless of the\nfilters and pagination of the request.",
+                    "type": "integer"
+                }
+            }
+        },
         "codersdk.LogLevel": {
             "type": "string",
             "enum": [
@@ -10864,6 +12818,14 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.MarkAllInboxNotificationsAsReadResponse": {
+            "type": "object",
+            "properties": {
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
         "codersdk.MinimalOrganization": {
             "type": "object",
             "required": [
@@ -10929,12 +12891,37 @@ const docTemplate = `{
                     "type": "string",
                     "format": "uuid"
                 },
+                "method": {
+                    "description": "Method is the user's chosen dispatch method for this template. It is empty if the template or deployment default\napplies.",
+                    "type": "string"
+                },
                 "updated_at": {
                     "type": "string",
                     "format": "date-time"
                 }
             }
         },
+        "codersdk.NotificationQuietHours": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "end": {
+                    "type": "string",
+                    "example": "07:00"
+                },
+                "start": {
+                    "description": "Start and End are times of day in 24-hour \"HH:MM\" format. If End is before Start, the window spans midnight.",
+                    "type": "string",
+                    "example": "22:00"
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "Europe/London"
+                }
+            }
+        },
         "codersdk.NotificationTemplate": {
             "type": "object",
             "properties": {
@@ -10968,6 +12955,10 @@ const docTemplate = `{
         "codersdk.NotificationsConfig": {
             "type": "object",
             "properties": {
+                "digest_window": {
+                    "description": "How long to collect notifications which have a digest before delivering them together; 0 disables digests.",
+                    "type": "integer"
+                },
                 "dispatch_timeout": {
                     "description": "How long to wait while a notification is being sent before giving up.",
                     "type": "integer"
@@ -10997,13 +12988,21 @@ const docTemplate = `{
                     "type": "integer"
                 },
                 "method": {
-                    "description": "Which delivery method to use (available options: 'smtp', 'webhook').",
+                    "description": "Which delivery method to use (available options: 'smtp', 'webhook', 'slack', 'teams', 'inbox').",
                     "type": "string"
                 },
                 "retry_interval": {
                     "description": "The minimum time between retries.",
                     "type": "integer"
                 },
+                "slack": {
+                    "description": "Slack settings.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/codersdk.NotificationsSlackConfig"
+                        }
+                    ]
+                },
                 "sync_buffer_size": {
                     "description": "The notifications system buffers message updates in memory to ease pressure on the database.\nThis option controls how many updates are kept in memory. The lower this value the\nlower the change of state inconsistency in a non-graceful shutdown - but it also increases load on the\ndatabase. It is recommended to keep this option at its default value.",
                     "type": "integer"
@@ -11012,6 +13011,14 @@ const docTemplate = `{
                     "description": "The notifications system buffers message updates in memory to ease pressure on the database.\nThis option controls how often it synchronizes its state with the database. The shorter this value the\nlower the change of state inconsistency in a non-graceful shutdown - but it also increases load on the\ndatabase. It is recommended to keep this option at its default value.",
                     "type": "integer"
                 },
+                "teams": {
+                    "description": "Microsoft Teams settings.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/codersdk.NotificationsTeamsConfig"
+                        }
+                    ]
+                },
                 "webhook": {
                     "description": "Webhook settings.",
                     "allOf": [
@@ -11121,6 +13128,44 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.NotificationsSlackConfig": {
+            "type": "object",
+            "properties": {
+                "api_url": {
+                    "description": "The base URL of the Slack Web API.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/serpent.URL"
+                        }
+                    ]
+                },
+                "bot_token": {
+                    "description": "The bot token used to send direct messages to users, who are looked up by their email address.",
+                    "type": "string"
+                },
+                "endpoint": {
+                    "description": "The incoming webhook URL to which messages will be posted.\nIgnored if BotToken is set.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/serpent.URL"
+                        }
+                    ]
+                }
+            }
+        },
+        "codersdk.NotificationsTeamsConfig": {
+            "type": "object",
+            "properties": {
+                "endpoint": {
+                    "description": "The workflow webhook URL to which Adaptive Card messages will be posted.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/serpent.URL"
+                        }
+                    ]
+                }
+            }
+        },
         "codersdk.NotificationsWebhookConfig": {
             "type": "object",
             "properties": {
@@ -11380,6 +13425,31 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.ObjectStoreS3Config": {
+            "type": "object",
+            "properties": {
+                "bucket": {
+                    "description": "The bucket in which objects are stored.",
+                    "type": "string"
+                },
+                "endpoint": {
+                    "description": "The endpoint of an S3-compatible object store. Defaults to AWS S3.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/serpent.URL"
+                        }
+                    ]
+                },
+                "prefix": {
+                    "description": "A prefix prepended to the name of every object.",
+                    "type": "string"
+                },
+                "region": {
+                    "description": "The region of the bucket. Defaults to the region of the ambient AWS configuration.",
+                    "type": "string"
+                }
+            }
+        },
         "codersdk.Organization": {
             "type": "object",
             "required": [
@@ -11572,6 +13642,54 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.PortForwardingPolicy": {
+            "type": "object",
+            "properties": {
+                "direct_tcpip": {
+                    "description": "DirectTCPIP restricts local port forwards (` + "`" + `ssh -L` + "`" + `, ` + "`" + `coder\nport-forward` + "`" + `) by destination. Connections made to the workspace over\nthe Coder network are checked against it as connections to localhost.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/codersdk.PortForwardingRule"
+                        }
+                    ]
+                },
+                "remote_forward": {
+                    "description": "RemoteForward restricts remote port forwards (` + "`" + `ssh -R` + "`" + `) by the address\nthe agent listens on.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/codersdk.PortForwardingRule"
+                        }
+                    ]
+                },
+                "unix_socket": {
+                    "description": "UnixSocket restricts forwards of Unix sockets in both directions by\nsocket path.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/codersdk.UnixSocketForwardingRule"
+                        }
+                    ]
+                }
+            }
+        },
+        "codersdk.PortForwardingRule": {
+            "type": "object",
+            "properties": {
+                "allowed_cidrs": {
+                    "description": "AllowedCIDRs are CIDRs or single IP addresses, e.g. \"127.0.0.0/8\".",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "allowed_ports": {
+                    "description": "AllowedPorts are ports or inclusive port ranges, e.g. \"8000-8999\".",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
         "codersdk.PostOAuth2ProviderAppRequest": {
             "type": "object",
             "required": [
@@ -11660,6 +13778,15 @@ const docTemplate = `{
                 },
                 "force_cancel_interval": {
                     "type": "integer"
+                },
+                "iac_mirror_dir": {
+                    "type": "string"
+                },
+                "module_cache_max_size": {
+                    "type": "integer"
+                },
+                "state_store": {
+                    "$ref": "#/definitions/codersdk.ProvisionerStateStoreConfig"
                 }
             }
         },
@@ -11887,6 +14014,18 @@ const docTemplate = `{
                 "ProvisionerLogLevelDebug"
             ]
         },
+        "codersdk.ProvisionerStateStoreConfig": {
+            "type": "object",
+            "properties": {
+                "directory": {
+                    "description": "The local directory in which provisioner state is stored.",
+                    "type": "string"
+                },
+                "s3": {
+                    "$ref": "#/definitions/codersdk.ObjectStoreS3Config"
+                }
+            }
+        },
         "codersdk.ProvisionerStorageMethod": {
             "type": "string",
             "enum": [
@@ -11999,6 +14138,7 @@ const docTemplate = `{
                 "read",
                 "read_personal",
                 "ssh",
+                "share_session",
                 "update",
                 "update_personal",
                 "use",
@@ -12014,6 +14154,7 @@ const docTemplate = `{
                 "ActionRead",
                 "ActionReadPersonal",
                 "ActionSSH",
+                "ActionShareSession",
                 "ActionUpdate",
                 "ActionUpdatePersonal",
                 "ActionUse",
@@ -12038,6 +14179,7 @@ const docTemplate = `{
                 "group",
                 "group_member",
                 "idpsync_settings",
+                "inbox_notification",
                 "license",
                 "notification_preference",
                 "notification_template",
@@ -12049,6 +14191,7 @@ const docTemplate = `{
                 "provisioner_daemon",
                 "provisioner_keys",
                 "replicas",
+                "saved_search",
                 "system",
                 "tailnet_coordinator",
                 "template",
@@ -12071,6 +14214,7 @@ const docTemplate = `{
                 "ResourceGroup",
                 "ResourceGroupMember",
                 "ResourceIdpsyncSettings",
+                "ResourceInboxNotification",
                 "ResourceLicense",
                 "ResourceNotificationPreference",
                 "ResourceNotificationTemplate",
@@ -12082,6 +14226,7 @@ const docTemplate = `{
                 "ResourceProvisionerDaemon",
                 "ResourceProvisionerKeys",
                 "ResourceReplicas",
+                "ResourceSavedSearch",
                 "ResourceSystem",
                 "ResourceTailnetCoordinator",
                 "ResourceTemplate",
@@ -12236,23 +14381,97 @@ const docTemplate = `{
                     "type": "string",
                     "format": "uuid"
                 },
-                "region_id": {
-                    "description": "RegionID is the region of the replica.",
-                    "type": "integer"
+                "region_id": {
+                    "description": "RegionID is the region of the replica.",
+                    "type": "integer"
+                },
+                "relay_address": {
+                    "description": "RelayAddress is the accessible address to relay DERP connections.",
+                    "type": "string"
+                }
+            }
+        },
+        "codersdk.ResolveAutostartResponse": {
+            "type": "object",
+            "properties": {
+                "parameter_mismatch": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "codersdk.ResourceAttributeChange": {
+            "type": "object",
+            "properties": {
+                "after": {
+                    "type": "string"
+                },
+                "before": {
+                    "description": "Before and After are the JSON encoded values of the attribute. They\nare empty if the attribute is sensitive, or if the resource doesn't\nhave it before or after the change.",
+                    "type": "string"
+                },
+                "forces_replacement": {
+                    "description": "ForcesReplacement is true if the change of the attribute is why the\nresource is replaced.",
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "sensitive": {
+                    "type": "boolean"
+                },
+                "unknown": {
+                    "description": "Unknown is true if the value is only known once the change is made.",
+                    "type": "boolean"
+                }
+            }
+        },
+        "codersdk.ResourceChange": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "enum": [
+                        "create",
+                        "update",
+                        "replace",
+                        "delete"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/codersdk.ResourceChangeAction"
+                        }
+                    ]
+                },
+                "address": {
+                    "type": "string"
+                },
+                "attributes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/codersdk.ResourceAttributeChange"
+                    }
+                },
+                "name": {
+                    "type": "string"
                 },
-                "relay_address": {
-                    "description": "RelayAddress is the accessible address to relay DERP connections.",
+                "type": {
                     "type": "string"
                 }
             }
         },
-        "codersdk.ResolveAutostartResponse": {
-            "type": "object",
-            "properties": {
-                "parameter_mismatch": {
-                    "type": "boolean"
-                }
-            }
+        "codersdk.ResourceChangeAction": {
+            "type": "string",
+            "enum": [
+                "create",
+                "update",
+                "replace",
+                "delete"
+            ],
+            "x-enum-varnames": [
+                "ResourceChangeActionCreate",
+                "ResourceChangeActionUpdate",
+                "ResourceChangeActionReplace",
+                "ResourceChangeActionDelete"
+            ]
         },
         "codersdk.ResourceType": {
             "type": "string",
@@ -12315,6 +14534,22 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.RestoreWorkspaceSnapshotRequest": {
+            "type": "object",
+            "properties": {
+                "log_level": {
+                    "description": "Log level changes the default logging verbosity of a provider (\"info\" if empty).",
+                    "enum": [
+                        "debug"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/codersdk.ProvisionerLogLevel"
+                        }
+                    ]
+                }
+            }
+        },
         "codersdk.Role": {
             "type": "object",
             "properties": {
@@ -12398,6 +14633,71 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.SavedSearch": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "group_id": {
+                    "description": "GroupID is the group the search is shared with. It is omitted for\nprivate searches and for searches shared with a whole organization.",
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "organization_id": {
+                    "description": "OrganizationID is the organization the search is shared with. It is\nomitted for private searches.",
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "query": {
+                    "type": "string"
+                },
+                "resource_type": {
+                    "enum": [
+                        "workspace",
+                        "template",
+                        "user",
+                        "audit_log"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/codersdk.SavedSearchResourceType"
+                        }
+                    ]
+                },
+                "updated_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "user_id": {
+                    "type": "string",
+                    "format": "uuid"
+                }
+            }
+        },
+        "codersdk.SavedSearchResourceType": {
+            "type": "string",
+            "enum": [
+                "workspace",
+                "template",
+                "user",
+                "audit_log"
+            ],
+            "x-enum-varnames": [
+                "SavedSearchResourceTypeWorkspace",
+                "SavedSearchResourceTypeTemplate",
+                "SavedSearchResourceTypeUser",
+                "SavedSearchResourceTypeAuditLog"
+            ]
+        },
         "codersdk.SessionCountDeploymentStats": {
             "type": "object",
             "properties": {
@@ -12542,6 +14842,10 @@ const docTemplate = `{
                 "activity_bump_ms": {
                     "type": "integer"
                 },
+                "agent_activity_bump": {
+                    "description": "AgentActivityBump bumps the deadline of workspaces built from the\ntemplate while their agents report activity without a connection,\ne.g. a running build.",
+                    "type": "boolean"
+                },
                 "allow_user_autostart": {
                     "description": "AllowUserAutostart and AllowUserAutostop are enterprise-only. Their\nvalues are only used if your license is entitled to use the advanced\ntemplate scheduling feature.",
                     "type": "boolean"
@@ -12623,6 +14927,14 @@ const docTemplate = `{
                     "type": "string",
                     "format": "url"
                 },
+                "port_forwarding_policy": {
+                    "description": "PortForwardingPolicy restricts the SSH port forwards the agents of\nworkspaces built from the template allow.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/codersdk.PortForwardingPolicy"
+                        }
+                    ]
+                },
                 "provisioner": {
                     "type": "string",
                     "enum": [
@@ -12633,6 +14945,10 @@ const docTemplate = `{
                     "description": "RequireActiveVersion mandates that workspaces are built with the active\ntemplate version.",
                     "type": "boolean"
                 },
+                "session_recording": {
+                    "description": "SessionRecording records the terminal sessions of workspaces built\nfrom the template.",
+                    "type": "boolean"
+                },
                 "time_til_dormant_autodelete_ms": {
                     "type": "integer"
                 },
@@ -13014,6 +15330,13 @@ const docTemplate = `{
                 "created_by": {
                     "$ref": "#/definitions/codersdk.MinimalUser"
                 },
+                "iac_engine": {
+                    "description": "IaCEngine and IaCVersionConstraint are the IaC engine, and the constraint\non its version, that the version requires, if any.",
+                    "type": "string"
+                },
+                "iac_version_constraint": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "string",
                     "format": "uuid"
@@ -13244,6 +15567,17 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.UnixSocketForwardingRule": {
+            "type": "object",
+            "properties": {
+                "allowed_paths": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
         "codersdk.UpdateActiveTemplateVersion": {
             "type": "object",
             "required": [
@@ -13298,6 +15632,17 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.UpdateInboxNotificationRequest": {
+            "type": "object",
+            "properties": {
+                "archived": {
+                    "type": "boolean"
+                },
+                "read": {
+                    "type": "boolean"
+                }
+            }
+        },
         "codersdk.UpdateOrganizationRequest": {
             "type": "object",
             "properties": {
@@ -13326,6 +15671,28 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.UpdateSavedSearchRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "group_id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "organization_id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "query": {
+                    "type": "string"
+                }
+            }
+        },
         "codersdk.UpdateTemplateACL": {
             "type": "object",
             "properties": {
@@ -13372,6 +15739,13 @@ const docTemplate = `{
                     "additionalProperties": {
                         "type": "boolean"
                     }
+                },
+                "template_method_map": {
+                    "description": "TemplateMethodMap sets the user's chosen dispatch method per template. An empty method reverts to the template or\ndeployment default. Templates which are omitted retain their current method.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 }
             }
         },
@@ -14053,17 +16427,183 @@ const docTemplate = `{
                 "subsystems": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/codersdk.AgentSubsystem"
+                        "$ref": "#/definitions/codersdk.AgentSubsystem"
+                    }
+                },
+                "troubleshooting_url": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "codersdk.WorkspaceAgentContainer": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "devcontainer_config_path": {
+                    "description": "DevcontainerConfigPath is the path to the devcontainer.json the\ncontainer was created from.",
+                    "type": "string"
+                },
+                "devcontainer_workspace_folder": {
+                    "description": "DevcontainerWorkspaceFolder is the folder of the workspace the\ncontainer was created from by the devcontainer CLI. It's empty if the\ncontainer isn't a devcontainer.",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "description": "Name is the name of the container without a leading slash. It can be\nused instead of the ID to attach to the container.",
+                    "type": "string"
+                },
+                "ports": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/codersdk.WorkspaceAgentContainerPort"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "codersdk.WorkspaceAgentContainerPort": {
+            "type": "object",
+            "properties": {
+                "host_ip": {
+                    "description": "HostIP is the address the port is published on in the workspace.",
+                    "type": "string"
+                },
+                "host_port": {
+                    "type": "integer"
+                },
+                "network": {
+                    "description": "Network is the protocol of the port, \"tcp\" or \"udp\".",
+                    "type": "string"
+                },
+                "port": {
+                    "description": "Port is the port inside the container.",
+                    "type": "integer"
+                }
+            }
+        },
+        "codersdk.WorkspaceAgentExecRequest": {
+            "type": "object",
+            "required": [
+                "command"
+            ],
+            "properties": {
+                "command": {
+                    "description": "Command is run by the shell of the workspace user, like commands run\nover SSH.",
+                    "type": "string"
+                },
+                "dir": {
+                    "description": "Dir is the working directory of the command. Defaults to the working\ndirectory of the agent.",
+                    "type": "string"
+                },
+                "timeout_ms": {
+                    "description": "TimeoutMillis kills the command if it runs longer. Defaults to no\ntimeout.",
+                    "type": "integer"
+                }
+            }
+        },
+        "codersdk.WorkspaceAgentExecResponse": {
+            "type": "object",
+            "properties": {
+                "exit_code": {
+                    "description": "ExitCode is -1 if the command was killed by a signal, e.g. because it\ntimed out.",
+                    "type": "integer"
+                },
+                "stderr": {
+                    "type": "string"
+                },
+                "stdout": {
+                    "type": "string"
+                },
+                "timed_out": {
+                    "type": "boolean"
+                },
+                "truncated": {
+                    "description": "Truncated is true if the command wrote more output than the agent\nkeeps, which is 1 MiB per stream.",
+                    "type": "boolean"
+                }
+            }
+        },
+        "codersdk.WorkspaceAgentFileInfo": {
+            "type": "object",
+            "properties": {
+                "is_dir": {
+                    "type": "boolean"
+                },
+                "mod_time": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "mode": {
+                    "description": "Mode is the file mode in the format of ` + "`" + `ls -l` + "`" + `, e.g. \"-rw-r--r--\".",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "path": {
+                    "description": "Path is the absolute path of the file in the workspace.",
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "symlink_target": {
+                    "description": "SymlinkTarget is the target of the file if it's a symbolic link.",
+                    "type": "string"
+                }
+            }
+        },
+        "codersdk.WorkspaceAgentFileList": {
+            "type": "object",
+            "properties": {
+                "files": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/codersdk.WorkspaceAgentFileInfo"
                     }
                 },
-                "troubleshooting_url": {
+                "path": {
+                    "description": "Path is the absolute path of the directory.",
                     "type": "string"
+                }
+            }
+        },
+        "codersdk.WorkspaceAgentFileUpload": {
+            "type": "object",
+            "properties": {
+                "complete": {
+                    "description": "Complete is true once the upload has been moved to its path.",
+                    "type": "boolean"
                 },
-                "updated_at": {
-                    "type": "string",
-                    "format": "date-time"
+                "offset": {
+                    "description": "Offset is the number of bytes received so far. The next chunk of the\nupload must start at this offset.",
+                    "type": "integer"
                 },
-                "version": {
+                "path": {
+                    "description": "Path is the absolute path of the file being uploaded.",
                     "type": "string"
                 }
             }
@@ -14108,6 +16648,21 @@ const docTemplate = `{
                 "WorkspaceAgentLifecycleOff"
             ]
         },
+        "codersdk.WorkspaceAgentListContainersResponse": {
+            "type": "object",
+            "properties": {
+                "containers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/codersdk.WorkspaceAgentContainer"
+                    }
+                },
+                "runtime": {
+                    "description": "Runtime is the container runtime the containers were listed with,\n\"docker\" or \"podman\". It's empty if there are no containers.",
+                    "type": "string"
+                }
+            }
+        },
         "codersdk.WorkspaceAgentListeningPort": {
             "type": "object",
             "properties": {
@@ -14181,6 +16736,21 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.WorkspaceAgentMetadataSample": {
+            "type": "object",
+            "properties": {
+                "collected_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
         "codersdk.WorkspaceAgentPortShare": {
             "type": "object",
             "properties": {
@@ -14254,6 +16824,120 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.WorkspaceAgentProcess": {
+            "type": "object",
+            "properties": {
+                "children": {
+                    "description": "Children are the processes started by this process.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/codersdk.WorkspaceAgentProcess"
+                    }
+                },
+                "command": {
+                    "description": "Command is the command line of the process. It's empty for kernel\nthreads.",
+                    "type": "string"
+                },
+                "cpu_percent": {
+                    "description": "CPUPercent is the CPU usage of the process averaged over its\nlifetime, like ` + "`" + `ps` + "`" + ` reports it. It may exceed 100 for processes that\nuse multiple cores.",
+                    "type": "number"
+                },
+                "memory_bytes": {
+                    "description": "MemoryBytes is the resident set size of the process.",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "Name is the name of the executable, truncated to 15 characters.",
+                    "type": "string"
+                },
+                "parent_pid": {
+                    "type": "integer"
+                },
+                "pid": {
+                    "type": "integer"
+                },
+                "started_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "user": {
+                    "type": "string"
+                }
+            }
+        },
+        "codersdk.WorkspaceAgentProcessesResponse": {
+            "type": "object",
+            "properties": {
+                "processes": {
+                    "description": "Processes are the processes whose parent isn't visible to the agent,\nusually only the init process.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/codersdk.WorkspaceAgentProcess"
+                    }
+                }
+            }
+        },
+        "codersdk.WorkspaceAgentReconnectingPTY": {
+            "type": "object",
+            "properties": {
+                "command": {
+                    "description": "Command is the command the session was started with. It's empty for\nthe login shell of the workspace user.",
+                    "type": "string"
+                },
+                "connections": {
+                    "description": "Connections are the clients attached to the session.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/codersdk.WorkspaceAgentReconnectingPTYConnection"
+                    }
+                },
+                "container": {
+                    "description": "Container is the container of the workspace the session runs in.",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "started_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "user_id": {
+                    "description": "UserID is the user that started the session.",
+                    "type": "string",
+                    "format": "uuid"
+                }
+            }
+        },
+        "codersdk.WorkspaceAgentReconnectingPTYConnection": {
+            "type": "object",
+            "properties": {
+                "attached_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "read_only": {
+                    "description": "ReadOnly is true if the input of the client is discarded.",
+                    "type": "boolean"
+                }
+            }
+        },
+        "codersdk.WorkspaceAgentReconnectingPTYsResponse": {
+            "type": "object",
+            "properties": {
+                "sessions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/codersdk.WorkspaceAgentReconnectingPTY"
+                    }
+                }
+            }
+        },
         "codersdk.WorkspaceAgentScript": {
             "type": "object",
             "properties": {
@@ -14291,6 +16975,129 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.WorkspaceAgentScriptRun": {
+            "type": "object",
+            "properties": {
+                "ended_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "exit_code": {
+                    "type": "integer"
+                },
+                "log_path": {
+                    "description": "LogPath is the file in the workspace the output of the latest run of\nthe script is written to.",
+                    "type": "string"
+                },
+                "log_source_id": {
+                    "description": "LogSourceID is the source of the output of the run in the logs of the\nagent. The output is the logs of the source created between the start\nand the end of the run.",
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "script_id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "stage": {
+                    "enum": [
+                        "start",
+                        "stop",
+                        "cron",
+                        "manual"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/codersdk.WorkspaceAgentScriptRunStage"
+                        }
+                    ]
+                },
+                "started_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "status": {
+                    "enum": [
+                        "ok",
+                        "exit_failure",
+                        "timed_out",
+                        "pipes_left_open"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/codersdk.WorkspaceAgentScriptRunStatus"
+                        }
+                    ]
+                }
+            }
+        },
+        "codersdk.WorkspaceAgentScriptRunStage": {
+            "type": "string",
+            "enum": [
+                "start",
+                "stop",
+                "cron",
+                "manual"
+            ],
+            "x-enum-varnames": [
+                "WorkspaceAgentScriptRunStageStart",
+                "WorkspaceAgentScriptRunStageStop",
+                "WorkspaceAgentScriptRunStageCron",
+                "WorkspaceAgentScriptRunStageManual"
+            ]
+        },
+        "codersdk.WorkspaceAgentScriptRunStatus": {
+            "type": "string",
+            "enum": [
+                "ok",
+                "exit_failure",
+                "timed_out",
+                "pipes_left_open"
+            ],
+            "x-enum-varnames": [
+                "WorkspaceAgentScriptRunStatusOK",
+                "WorkspaceAgentScriptRunStatusExitFailure",
+                "WorkspaceAgentScriptRunStatusTimedOut",
+                "WorkspaceAgentScriptRunStatusPipesLeftOpen"
+            ]
+        },
+        "codersdk.WorkspaceAgentSessionRecording": {
+            "type": "object",
+            "properties": {
+                "agent_id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "command": {
+                    "description": "Command is the command the session ran, empty for a login shell.",
+                    "type": "string"
+                },
+                "ended_at": {
+                    "description": "EndedAt is nil while the session is in progress.",
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "session_type": {
+                    "description": "SessionType is the kind of SSH session that was recorded, e.g. \"ssh\"\nor \"vscode\".",
+                    "type": "string"
+                },
+                "size": {
+                    "description": "Size is the number of bytes of the recording that were uploaded.",
+                    "type": "integer"
+                },
+                "started_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "workspace_id": {
+                    "type": "string",
+                    "format": "uuid"
+                }
+            }
+        },
         "codersdk.WorkspaceAgentStartupScriptBehavior": {
             "type": "string",
             "enum": [
@@ -14398,6 +17205,23 @@ const docTemplate = `{
                 "WorkspaceAppHealthUnhealthy"
             ]
         },
+        "codersdk.WorkspaceAppHealthcheckKind": {
+            "type": "string",
+            "enum": [
+                "http",
+                "tcp",
+                "grpc",
+                "command",
+                "file"
+            ],
+            "x-enum-varnames": [
+                "WorkspaceAppHealthcheckKindHTTP",
+                "WorkspaceAppHealthcheckKindTCP",
+                "WorkspaceAppHealthcheckKindGRPC",
+                "WorkspaceAppHealthcheckKindCommand",
+                "WorkspaceAppHealthcheckKindFile"
+            ]
+        },
         "codersdk.WorkspaceAppSharingLevel": {
             "type": "string",
             "enum": [
@@ -14579,6 +17403,13 @@ const docTemplate = `{
         "codersdk.WorkspaceHealth": {
             "type": "object",
             "properties": {
+                "drifted_resources": {
+                    "description": "DriftedResources lists the resources that were changed or deleted\noutside of Coder since the latest build, as found by the latest drift\ncheck of the workspace.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/codersdk.ResourceChange"
+                    }
+                },
                 "failing_agents": {
                     "description": "FailingAgents lists the IDs of the agents that are failing, if any.",
                     "type": "array",
@@ -14752,6 +17583,38 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.WorkspaceSnapshot": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "created_by": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "job": {
+                    "$ref": "#/definitions/codersdk.ProvisionerJob"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "workspace_build_id": {
+                    "description": "WorkspaceBuildID is the build the snapshot was taken from.",
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "workspace_id": {
+                    "type": "string",
+                    "format": "uuid"
+                }
+            }
+        },
         "codersdk.WorkspaceStatus": {
             "type": "string",
             "enum": [
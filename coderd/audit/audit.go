@@ -22,6 +22,14 @@ type AdditionalFields struct {
 	BuildReason    database.BuildReason `json:"build_reason"`
 	WorkspaceOwner string               `json:"workspace_owner"`
 	WorkspaceID    uuid.UUID            `json:"workspace_id"`
+	// SourceWorkspace and SourceWorkspaceID are set on workspaces created by
+	// cloning another workspace. SourceWorkspace is "owner/name".
+	SourceWorkspace   string     `json:"source_workspace,omitempty"`
+	SourceWorkspaceID *uuid.UUID `json:"source_workspace_id,omitempty"`
+	// AgentName and Command are set on commands run in workspaces with the
+	// exec API.
+	AgentName string `json:"agent_name,omitempty"`
+	Command   string `json:"command,omitempty"`
 }
 
 func NewNop() Auditor {
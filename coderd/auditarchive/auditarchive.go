@@ -0,0 +1,241 @@
+// Package auditarchive writes audit logs to compressed, checksummed archives so
+// that they can be retained outside of the database.
+//
+// An archive consists of one or more gzipped JSON Lines files, each holding up
+// to a fixed number of records, and a JSON manifest which lists every file
+// alongside its SHA-256 checksum. The manifest is written last, so an archive
+// without one is incomplete.
+package auditarchive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/objectstore"
+	"github.com/coder/coder/v2/codersdk"
+)
+
+// DefaultMaxFileRecords is the number of records after which a new archive
+// file is started.
+const DefaultMaxFileRecords = 10_000
+
+// Store persists archive files.
+type Store interface {
+	// Put writes the named file. Implementations must not leave a partially
+	// written file behind if they fail.
+	Put(ctx context.Context, name string, data []byte) error
+}
+
+// NewStore creates the store for the archive destination in the given
+// configuration. It returns nil if no destination is configured.
+func NewStore(ctx context.Context, cfg codersdk.AuditLogArchiveConfig) (Store, error) {
+	switch {
+	case cfg.Directory.String() != "" && cfg.S3.Bucket.String() != "":
+		return nil, xerrors.New("only one audit log archive destination may be configured")
+	case cfg.Directory.String() != "":
+		return objectstore.NewDirStore(cfg.Directory.String())
+	case cfg.S3.Bucket.String() != "":
+		return objectstore.NewS3Store(ctx, cfg.S3)
+	default:
+		return nil, nil
+	}
+}
+
+// Record is the archived representation of an audit log.
+type Record struct {
+	ID               uuid.UUID       `json:"id"`
+	Time             time.Time       `json:"time"`
+	OrganizationID   uuid.UUID       `json:"organization_id"`
+	UserID           uuid.UUID       `json:"user_id"`
+	Username         string          `json:"username,omitempty"`
+	UserEmail        string          `json:"user_email,omitempty"`
+	IP               string          `json:"ip,omitempty"`
+	UserAgent        string          `json:"user_agent,omitempty"`
+	ResourceType     string          `json:"resource_type"`
+	ResourceID       uuid.UUID       `json:"resource_id"`
+	ResourceTarget   string          `json:"resource_target"`
+	ResourceIcon     string          `json:"resource_icon,omitempty"`
+	Action           string          `json:"action"`
+	Diff             json.RawMessage `json:"diff,omitempty"`
+	StatusCode       int32           `json:"status_code"`
+	AdditionalFields json.RawMessage `json:"additional_fields,omitempty"`
+	RequestID        uuid.UUID       `json:"request_id"`
+}
+
+// RecordFromRow converts an audit log returned by GetAuditLogsOffset into its
+// archived representation.
+func RecordFromRow(row database.GetAuditLogsOffsetRow) Record {
+	return RecordFromLog(row.AuditLog, row.UserUsername.String, row.UserEmail.String)
+}
+
+// RecordFromLog converts an audit log, and the username and email of the user
+// who caused it, into its archived representation.
+func RecordFromLog(alog database.AuditLog, username, email string) Record {
+	r := Record{
+		ID:               alog.ID,
+		Time:             alog.Time.UTC(),
+		OrganizationID:   alog.OrganizationID,
+		UserID:           alog.UserID,
+		Username:         username,
+		UserEmail:        email,
+		UserAgent:        alog.UserAgent.String,
+		ResourceType:     string(alog.ResourceType),
+		ResourceID:       alog.ResourceID,
+		ResourceTarget:   alog.ResourceTarget,
+		ResourceIcon:     alog.ResourceIcon,
+		Action:           string(alog.Action),
+		Diff:             alog.Diff,
+		StatusCode:       alog.StatusCode,
+		AdditionalFields: alog.AdditionalFields,
+		RequestID:        alog.RequestID,
+	}
+	if alog.Ip.Valid {
+		r.IP = alog.Ip.IPNet.IP.String()
+	}
+	return r
+}
+
+// Manifest describes a complete archive.
+type Manifest struct {
+	CreatedAt time.Time      `json:"created_at"`
+	Records   int64          `json:"records"`
+	Files     []ManifestFile `json:"files"`
+}
+
+// ManifestFile describes a single file of an archive.
+type ManifestFile struct {
+	Name    string `json:"name"`
+	SHA256  string `json:"sha256"`
+	Size    int64  `json:"size"`
+	Records int64  `json:"records"`
+	// The earliest and latest times of the records in the file.
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// Writer writes records to an archive, starting a new file whenever the
+// current one is full. Files are buffered in memory until they are complete.
+type Writer struct {
+	store      Store
+	name       string
+	maxRecords int
+	// nameByRecord names the archive after its first record once it is
+	// written, rather than after the time it was created at.
+	nameByRecord bool
+
+	manifest Manifest
+	file     ManifestFile
+	buf      bytes.Buffer
+	gz       *gzip.Writer
+	enc      *json.Encoder
+}
+
+// NewWriter creates a writer for an archive which is named after the time it
+// was created at. Files hold at most maxRecords records each.
+func NewWriter(store Store, createdAt time.Time, maxRecords int) *Writer {
+	if maxRecords <= 0 {
+		maxRecords = DefaultMaxFileRecords
+	}
+	createdAt = createdAt.UTC()
+	return &Writer{
+		store:      store,
+		name:       "audit-logs-" + createdAt.Format("20060102T150405Z"),
+		maxRecords: maxRecords,
+		manifest:   Manifest{CreatedAt: createdAt},
+	}
+}
+
+// NewIdempotentWriter creates a writer for an archive which is named after the
+// time and ID of the first record written to it. Archiving the same records in
+// the same order again overwrites the earlier archive instead of creating a
+// duplicate, e.g. when a purge is retried after its deletions were rolled back.
+func NewIdempotentWriter(store Store, createdAt time.Time, maxRecords int) *Writer {
+	w := NewWriter(store, createdAt, maxRecords)
+	w.nameByRecord = true
+	return w
+}
+
+// Write adds a record to the archive.
+func (w *Writer) Write(ctx context.Context, r Record) error {
+	if w.nameByRecord {
+		w.name = "audit-logs-" + r.Time.UTC().Format("20060102T150405Z") + "-" + r.ID.String()
+		w.nameByRecord = false
+	}
+	if w.gz == nil {
+		w.buf.Reset()
+		w.gz = gzip.NewWriter(&w.buf)
+		w.enc = json.NewEncoder(w.gz)
+		w.file = ManifestFile{
+			Name: fmt.Sprintf("%s-%04d.jsonl.gz", w.name, len(w.manifest.Files)+1),
+		}
+	}
+
+	if err := w.enc.Encode(r); err != nil {
+		return xerrors.Errorf("encode record %s: %w", r.ID, err)
+	}
+	if w.file.Records == 0 || r.Time.Before(w.file.From) {
+		w.file.From = r.Time
+	}
+	if r.Time.After(w.file.To) {
+		w.file.To = r.Time
+	}
+	w.file.Records++
+
+	if w.file.Records >= int64(w.maxRecords) {
+		return w.flush(ctx)
+	}
+	return nil
+}
+
+// flush completes the current file and stores it.
+func (w *Writer) flush(ctx context.Context) error {
+	if w.gz == nil {
+		return nil
+	}
+	if err := w.gz.Close(); err != nil {
+		return xerrors.Errorf("compress %s: %w", w.file.Name, err)
+	}
+	w.gz, w.enc = nil, nil
+
+	data := w.buf.Bytes()
+	sum := sha256.Sum256(data)
+	w.file.SHA256 = hex.EncodeToString(sum[:])
+	w.file.Size = int64(len(data))
+	if err := w.store.Put(ctx, w.file.Name, data); err != nil {
+		return xerrors.Errorf("store %s: %w", w.file.Name, err)
+	}
+
+	w.manifest.Files = append(w.manifest.Files, w.file)
+	w.manifest.Records += w.file.Records
+	return nil
+}
+
+// Close stores any partially filled file followed by the manifest, and
+// returns the manifest. Nothing is stored if no records were written.
+func (w *Writer) Close(ctx context.Context) (Manifest, error) {
+	if err := w.flush(ctx); err != nil {
+		return Manifest{}, err
+	}
+	if len(w.manifest.Files) == 0 {
+		return w.manifest, nil
+	}
+
+	data, err := json.MarshalIndent(w.manifest, "", "  ")
+	if err != nil {
+		return Manifest{}, xerrors.Errorf("encode manifest: %w", err)
+	}
+	if err := w.store.Put(ctx, w.name+".manifest.json", data); err != nil {
+		return Manifest{}, xerrors.Errorf("store manifest: %w", err)
+	}
+	return w.manifest, nil
+}
@@ -0,0 +1,227 @@
+package auditarchive_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/coderd/auditarchive"
+	"github.com/coder/coder/v2/coderd/objectstore"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestWriter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Rotates", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+		dir := t.TempDir()
+		store, err := objectstore.NewDirStore(dir)
+		require.NoError(t, err)
+
+		createdAt := time.Date(2024, time.October, 1, 12, 30, 0, 0, time.UTC)
+		w := auditarchive.NewWriter(store, createdAt, 2)
+		records := make([]auditarchive.Record, 5)
+		for i := range records {
+			records[i] = auditarchive.Record{
+				ID:           uuid.New(),
+				Time:         createdAt.Add(-time.Duration(i) * time.Hour),
+				ResourceType: "workspace",
+				Action:       "create",
+			}
+			require.NoError(t, w.Write(ctx, records[i]))
+		}
+		manifest, err := w.Close(ctx)
+		require.NoError(t, err)
+
+		require.EqualValues(t, len(records), manifest.Records)
+		require.Len(t, manifest.Files, 3)
+		require.Equal(t, "audit-logs-20241001T123000Z-0001.jsonl.gz", manifest.Files[0].Name)
+		require.EqualValues(t, 1, manifest.Files[2].Records)
+		require.Equal(t, records[1].Time, manifest.Files[0].From)
+		require.Equal(t, records[0].Time, manifest.Files[0].To)
+
+		var got []uuid.UUID
+		for _, f := range manifest.Files {
+			data, err := os.ReadFile(filepath.Join(dir, f.Name))
+			require.NoError(t, err)
+			sum := sha256.Sum256(data)
+			require.Equal(t, f.SHA256, hex.EncodeToString(sum[:]))
+			require.EqualValues(t, len(data), f.Size)
+			for _, r := range decode(t, data) {
+				got = append(got, r.ID)
+			}
+		}
+		want := make([]uuid.UUID, 0, len(records))
+		for _, r := range records {
+			want = append(want, r.ID)
+		}
+		require.Equal(t, want, got)
+
+		data, err := os.ReadFile(filepath.Join(dir, "audit-logs-20241001T123000Z.manifest.json"))
+		require.NoError(t, err)
+		var stored auditarchive.Manifest
+		require.NoError(t, json.Unmarshal(data, &stored))
+		require.Equal(t, manifest, stored)
+	})
+
+	t.Run("Idempotent", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+		dir := t.TempDir()
+		store, err := objectstore.NewDirStore(dir)
+		require.NoError(t, err)
+
+		first := time.Date(2024, time.October, 1, 12, 30, 0, 0, time.UTC)
+		records := make([]auditarchive.Record, 3)
+		for i := range records {
+			records[i] = auditarchive.Record{
+				ID:           uuid.New(),
+				Time:         first.Add(time.Duration(i) * time.Hour),
+				ResourceType: "workspace",
+				Action:       "create",
+			}
+		}
+
+		// Archiving the same records twice, e.g. because the first purge was
+		// rolled back, overwrites the first archive.
+		for _, createdAt := range []time.Time{first.AddDate(0, 1, 0), first.AddDate(0, 1, 1)} {
+			w := auditarchive.NewIdempotentWriter(store, createdAt, 2)
+			for _, r := range records {
+				require.NoError(t, w.Write(ctx, r))
+			}
+			_, err := w.Close(ctx)
+			require.NoError(t, err)
+		}
+
+		name := "audit-logs-20241001T123000Z-" + records[0].ID.String()
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		names := make([]string, 0, len(entries))
+		for _, e := range entries {
+			names = append(names, e.Name())
+		}
+		require.ElementsMatch(t, []string{
+			name + "-0001.jsonl.gz",
+			name + "-0002.jsonl.gz",
+			name + ".manifest.json",
+		}, names)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+		dir := t.TempDir()
+		store, err := objectstore.NewDirStore(dir)
+		require.NoError(t, err)
+
+		manifest, err := auditarchive.NewWriter(store, time.Now(), 0).Close(ctx)
+		require.NoError(t, err)
+		require.Zero(t, manifest.Records)
+
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		require.Empty(t, entries)
+	})
+}
+
+func TestNewStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutil.Context(t, testutil.WaitShort)
+
+	store, err := auditarchive.NewStore(ctx, codersdk.AuditLogArchiveConfig{})
+	require.NoError(t, err)
+	require.Nil(t, store)
+
+	var cfg codersdk.AuditLogArchiveConfig
+	require.NoError(t, cfg.Directory.Set(t.TempDir()))
+	store, err = auditarchive.NewStore(ctx, cfg)
+	require.NoError(t, err)
+	require.IsType(t, &objectstore.DirStore{}, store)
+
+	require.NoError(t, cfg.S3.Bucket.Set("audit"))
+	_, err = auditarchive.NewStore(ctx, cfg)
+	require.ErrorContains(t, err, "only one audit log archive destination")
+}
+
+//nolint:paralleltest // It sets environment variables.
+func TestS3Store(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "test-access-key")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test-secret-key")
+	t.Setenv("AWS_REGION", "eu-west-2")
+
+	var (
+		mu      sync.Mutex
+		uploads = map[string][]byte{}
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || !strings.Contains(r.Header.Get("Authorization"), "Credential=test-access-key/") {
+			rw.WriteHeader(http.StatusForbidden)
+			return
+		}
+		data, _ := io.ReadAll(r.Body)
+		sum := sha256.Sum256(data)
+		if r.Header.Get("X-Amz-Content-Sha256") != hex.EncodeToString(sum[:]) {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		uploads[r.URL.Path] = data
+		mu.Unlock()
+	}))
+	t.Cleanup(srv.Close)
+
+	ctx := testutil.Context(t, testutil.WaitShort)
+	var cfg codersdk.AuditLogArchiveConfig
+	require.NoError(t, cfg.S3.Bucket.Set("audit"))
+	require.NoError(t, cfg.S3.Prefix.Set("/coder/"))
+	require.NoError(t, cfg.S3.Endpoint.Set(srv.URL))
+	store, err := auditarchive.NewStore(ctx, cfg)
+	require.NoError(t, err)
+
+	createdAt := time.Date(2024, time.October, 1, 0, 0, 0, 0, time.UTC)
+	w := auditarchive.NewWriter(store, createdAt, 0)
+	require.NoError(t, w.Write(ctx, auditarchive.Record{ID: uuid.New(), Time: createdAt}))
+	_, err = w.Close(ctx)
+	require.NoError(t, err)
+
+	mu.Lock()
+	require.Contains(t, uploads, "/audit/coder/audit-logs-20241001T000000Z-0001.jsonl.gz")
+	require.Contains(t, uploads, "/audit/coder/audit-logs-20241001T000000Z.manifest.json")
+	mu.Unlock()
+}
+
+func decode(t *testing.T, data []byte) []auditarchive.Record {
+	t.Helper()
+
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	dec := json.NewDecoder(gz)
+	var records []auditarchive.Record
+	for dec.More() {
+		var r auditarchive.Record
+		require.NoError(t, dec.Decode(&r))
+		records = append(records, r)
+	}
+	return records
+}
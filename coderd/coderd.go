@@ -65,6 +65,7 @@ import (
 	"github.com/coder/coder/v2/coderd/portsharing"
 	"github.com/coder/coder/v2/coderd/prometheusmetrics"
 	"github.com/coder/coder/v2/coderd/provisionerdserver"
+	"github.com/coder/coder/v2/coderd/provisionerstate"
 	"github.com/coder/coder/v2/coderd/rbac"
 	"github.com/coder/coder/v2/coderd/rbac/policy"
 	"github.com/coder/coder/v2/coderd/rbac/rolestore"
@@ -244,6 +245,9 @@ type Options struct {
 	WorkspaceUsageTracker *workspacestats.UsageTracker
 	// NotificationsEnqueuer handles enqueueing notifications for delivery by SMTP, webhook, etc.
 	NotificationsEnqueuer notifications.Enqueuer
+	// ProvisionerStateStore stores the provisioner state of workspace builds
+	// outside of the database. If nil, state is stored in the database.
+	ProvisionerStateStore provisionerstate.Store
 
 	// IDPSync holds all configured values for syncing external IDP users into Coder.
 	IDPSync idpsync.IDPSync
@@ -966,6 +970,7 @@ func New(options *Options) *API {
 				r.Post("/", api.postTemplateVersionDryRun)
 				r.Get("/{jobID}", api.templateVersionDryRun)
 				r.Get("/{jobID}/resources", api.templateVersionDryRunResources)
+				r.Get("/{jobID}/resource-changes", api.templateVersionDryRunResourceChanges)
 				r.Get("/{jobID}/logs", api.templateVersionDryRunLogs)
 				r.Patch("/{jobID}/cancel", api.patchTemplateVersionDryRunCancel)
 			})
@@ -1057,11 +1062,30 @@ func New(options *Options) *API {
 					})
 					r.Get("/gitsshkey", api.gitSSHKey)
 					r.Put("/gitsshkey", api.regenerateGitSSHKey)
+					r.Route("/saved-searches", func(r chi.Router) {
+						r.Get("/", api.savedSearches)
+						r.Post("/", api.postSavedSearch)
+						r.Route("/{savedsearch}", func(r chi.Router) {
+							r.Get("/", api.savedSearch)
+							r.Put("/", api.putSavedSearch)
+							r.Delete("/", api.deleteSavedSearch)
+						})
+					})
 					r.Route("/notifications", func(r chi.Router) {
 						r.Route("/preferences", func(r chi.Router) {
 							r.Get("/", api.userNotificationPreferences)
 							r.Put("/", api.putUserNotificationPreferences)
 						})
+						r.Route("/quiet-hours", func(r chi.Router) {
+							r.Get("/", api.userNotificationQuietHours)
+							r.Put("/", api.putUserNotificationQuietHours)
+						})
+						r.Route("/inbox", func(r chi.Router) {
+							r.Get("/", api.userInboxNotifications)
+							r.Get("/watch", api.watchUserInboxNotifications)
+							r.Put("/mark-all-read", api.markAllUserInboxNotificationsAsRead)
+							r.Put("/{id}", api.putUserInboxNotification)
+						})
 					})
 				})
 			})
@@ -1106,11 +1130,28 @@ func New(options *Options) *API {
 				)
 				r.Get("/", api.workspaceAgent)
 				r.Get("/watch-metadata", api.watchWorkspaceAgentMetadata)
+				r.Get("/metadata/{key}/history", api.workspaceAgentMetadataHistory)
 				r.Get("/startup-logs", api.workspaceAgentLogsDeprecated)
 				r.Get("/logs", api.workspaceAgentLogs)
 				r.Get("/listening-ports", api.workspaceAgentListeningPorts)
+				r.Get("/containers", api.workspaceAgentListContainers)
 				r.Get("/connection", api.workspaceAgentConnection)
 				r.Get("/coordinate", api.workspaceAgentClientCoordinate)
+				r.Route("/files", func(r chi.Router) {
+					r.Get("/stat", api.workspaceAgentFileStat)
+					r.Get("/list", api.workspaceAgentListFiles)
+					r.Get("/download", api.workspaceAgentDownloadFile)
+					r.Get("/archive", api.workspaceAgentArchiveDirectory)
+					r.Get("/upload", api.workspaceAgentFileUploadProgress)
+					r.Put("/upload", api.workspaceAgentUploadFile)
+				})
+				r.Get("/processes", api.workspaceAgentProcesses)
+				r.Post("/exec", api.workspaceAgentExec)
+				r.Get("/reconnecting-ptys", api.workspaceAgentReconnectingPTYs)
+				r.Route("/scripts/{script}", func(r chi.Router) {
+					r.Get("/runs", api.workspaceAgentScriptRuns)
+					r.Post("/run", api.workspaceAgentRunScript)
+				})
 
 				// PTY is part of workspaceAppServer.
 			})
@@ -1126,6 +1167,7 @@ func New(options *Options) *API {
 				)
 				r.Get("/", api.workspace)
 				r.Patch("/", api.patchWorkspace)
+				r.Post("/clone", api.postWorkspaceClone)
 				r.Route("/builds", func(r chi.Router) {
 					r.Get("/", api.workspaceBuilds)
 					r.Post("/", api.postWorkspaceBuilds)
@@ -1150,8 +1192,28 @@ func New(options *Options) *API {
 					r.Delete("/", api.deleteWorkspaceAgentPortShare)
 				})
 				r.Get("/timings", api.workspaceTimings)
+				r.Get("/session-recordings", api.workspaceSessionRecordings)
+				r.Route("/snapshots", func(r chi.Router) {
+					r.Get("/", api.workspaceSnapshots)
+					r.Post("/", api.postWorkspaceSnapshot)
+					r.Route("/{workspacesnapshot}", func(r chi.Router) {
+						r.Get("/", api.workspaceSnapshot)
+						r.Delete("/", api.deleteWorkspaceSnapshot)
+						r.Get("/logs", api.workspaceSnapshotLogs)
+						r.Route("/restore", func(r chi.Router) {
+							r.Post("/", api.postWorkspaceSnapshotRestore)
+							r.Get("/{jobID}", api.workspaceSnapshotRestore)
+							r.Get("/{jobID}/logs", api.workspaceSnapshotRestoreLogs)
+						})
+					})
+				})
 			})
 		})
+		r.Route("/session-recordings/{sessionrecording}", func(r chi.Router) {
+			r.Use(apiKeyMiddleware)
+			r.Get("/", api.sessionRecording)
+			r.Get("/cast", api.sessionRecordingCast)
+		})
 		r.Route("/workspacebuilds/{workspacebuild}", func(r chi.Router) {
 			r.Use(
 				apiKeyMiddleware,
@@ -1457,11 +1519,12 @@ func compressHandler(h http.Handler) http.Handler {
 
 // CreateInMemoryProvisionerDaemon is an in-memory connection to a provisionerd.
 // Useful when starting coderd and provisionerd in the same process.
-func (api *API) CreateInMemoryProvisionerDaemon(dialCtx context.Context, name string, provisionerTypes []codersdk.ProvisionerType) (client proto.DRPCProvisionerDaemonClient, err error) {
-	return api.CreateInMemoryTaggedProvisionerDaemon(dialCtx, name, provisionerTypes, nil)
+// iacBinaries are the versions of IaC engines the provisioner can run.
+func (api *API) CreateInMemoryProvisionerDaemon(dialCtx context.Context, name string, provisionerTypes []codersdk.ProvisionerType, iacBinaries []provisionersdk.IaCBinary) (client proto.DRPCProvisionerDaemonClient, err error) {
+	return api.CreateInMemoryTaggedProvisionerDaemon(dialCtx, name, provisionerTypes, nil, iacBinaries)
 }
 
-func (api *API) CreateInMemoryTaggedProvisionerDaemon(dialCtx context.Context, name string, provisionerTypes []codersdk.ProvisionerType, provisionerTags map[string]string) (client proto.DRPCProvisionerDaemonClient, err error) {
+func (api *API) CreateInMemoryTaggedProvisionerDaemon(dialCtx context.Context, name string, provisionerTypes []codersdk.ProvisionerType, provisionerTags map[string]string, iacBinaries []provisionersdk.IaCBinary) (client proto.DRPCProvisionerDaemonClient, err error) {
 	tracer := api.TracerProvider.Tracer(tracing.TracerName)
 	clientSession, serverSession := drpc.MemTransportPipe()
 	defer func() {
@@ -1528,6 +1591,8 @@ func (api *API) CreateInMemoryTaggedProvisionerDaemon(dialCtx context.Context, n
 		provisionerdserver.Options{
 			OIDCConfig:          api.OIDCConfig,
 			ExternalAuthConfigs: api.ExternalAuthConfigs,
+			StateStore:          api.ProvisionerStateStore,
+			IaCBinaries:         iacBinaries,
 		},
 		api.NotificationsEnqueuer,
 	)
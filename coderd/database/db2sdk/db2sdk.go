@@ -512,9 +512,16 @@ func Apps(dbApps []database.WorkspaceApp, agent database.WorkspaceAgent, ownerNa
 			SubdomainName: AppSubdomain(dbApp, agent.Name, workspace.Name, ownerName),
 			SharingLevel:  codersdk.WorkspaceAppSharingLevel(dbApp.SharingLevel),
 			Healthcheck: codersdk.Healthcheck{
-				URL:       dbApp.HealthcheckUrl,
-				Interval:  dbApp.HealthcheckInterval,
-				Threshold: dbApp.HealthcheckThreshold,
+				URL:            dbApp.HealthcheckUrl,
+				Interval:       dbApp.HealthcheckInterval,
+				Threshold:      dbApp.HealthcheckThreshold,
+				Kind:           codersdk.WorkspaceAppHealthcheckKind(dbApp.HealthcheckKind),
+				ExpectedStatus: dbApp.HealthcheckExpectedStatus,
+				BodyRegex:      dbApp.HealthcheckBodyRegex,
+				Address:        dbApp.HealthcheckAddress,
+				GRPCService:    dbApp.HealthcheckGrpcService,
+				Command:        dbApp.HealthcheckCommand,
+				Path:           dbApp.HealthcheckPath,
 			},
 			Health: codersdk.WorkspaceAppHealth(dbApp.Health),
 			Hidden: dbApp.Hidden,
@@ -659,3 +666,25 @@ func Organization(organization database.Organization) codersdk.Organization {
 		IsDefault:   organization.IsDefault,
 	}
 }
+
+func PortForwardingPolicy(policy database.PortForwardingPolicy) codersdk.PortForwardingPolicy {
+	var out codersdk.PortForwardingPolicy
+	if policy.DirectTCPIP != nil {
+		out.DirectTCPIP = &codersdk.PortForwardingRule{
+			AllowedCIDRs: policy.DirectTCPIP.AllowedCIDRs,
+			AllowedPorts: policy.DirectTCPIP.AllowedPorts,
+		}
+	}
+	if policy.RemoteForward != nil {
+		out.RemoteForward = &codersdk.PortForwardingRule{
+			AllowedCIDRs: policy.RemoteForward.AllowedCIDRs,
+			AllowedPorts: policy.RemoteForward.AllowedPorts,
+		}
+	}
+	if policy.UnixSocket != nil {
+		out.UnixSocket = &codersdk.UnixSocketForwardingRule{
+			AllowedPaths: policy.UnixSocket.AllowedPaths,
+		}
+	}
+	return out
+}
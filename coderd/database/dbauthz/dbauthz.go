@@ -253,6 +253,7 @@ var (
 					rbac.ResourceDeploymentConfig.Type:       {policy.ActionCreate, policy.ActionUpdate, policy.ActionDelete},
 					rbac.ResourceNotificationPreference.Type: {policy.ActionCreate, policy.ActionUpdate, policy.ActionDelete},
 					rbac.ResourceNotificationTemplate.Type:   {policy.ActionCreate, policy.ActionUpdate, policy.ActionDelete},
+					rbac.ResourceInboxNotification.Type:      {policy.ActionCreate},
 					rbac.ResourceCryptoKey.Type:              {policy.ActionCreate, policy.ActionUpdate, policy.ActionDelete},
 				}),
 				Org:  map[string][]rbac.Permission{},
@@ -771,6 +772,27 @@ func authorizedTemplateVersionFromJob(ctx context.Context, q *querier, job datab
 	}
 }
 
+// authorizedWorkspaceFromJobInput returns the workspace of a snapshot or drift
+// check job, both of which reference the build they act on in their input.
+func authorizedWorkspaceFromJobInput(ctx context.Context, q *querier, job database.ProvisionerJob) (database.Workspace, error) {
+	// TODO: As with dry-runs, we need to inspect the json payload to find
+	// the workspace the job belongs to. The build is used rather than the
+	// snapshot, since snapshots can be deleted after a restore is queued.
+	tmp := struct {
+		WorkspaceBuildID uuid.UUID `json:"workspace_build_id"`
+	}{}
+	err := json.Unmarshal(job.Input, &tmp)
+	if err != nil {
+		return database.Workspace{}, xerrors.Errorf("%s unmarshal: %w", job.Type, err)
+	}
+	build, err := q.db.GetWorkspaceBuildByID(ctx, tmp.WorkspaceBuildID)
+	if err != nil {
+		return database.Workspace{}, err
+	}
+	// Authorized call to get the workspace.
+	return q.GetWorkspaceByID(ctx, build.WorkspaceID)
+}
+
 func (q *querier) authorizeTemplateInsights(ctx context.Context, templateIDs []uuid.UUID) error {
 	// Abort early if can read all template insights, aka admins.
 	// TODO: If we know the org, that would allow org admins to abort early too.
@@ -794,6 +816,18 @@ func (q *querier) authorizeTemplateInsights(ctx context.Context, templateIDs []u
 	return nil
 }
 
+// authorizeSessionRecordings checks if the user can view the session
+// recordings of a workspace. Recordings are audit data, so rather than access
+// to the workspace this requires being able to read the audit log of the
+// workspace's organization.
+func (q *querier) authorizeSessionRecordings(ctx context.Context, workspaceID uuid.UUID) error {
+	workspace, err := q.db.GetWorkspaceByID(ctx, workspaceID)
+	if err != nil {
+		return err
+	}
+	return q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceAuditLog.InOrg(workspace.OrganizationID))
+}
+
 // customRoleEscalationCheck checks to make sure the caller has every permission they are adding
 // to a custom role. This prevents permission escalation.
 func (q *querier) customRoleEscalationCheck(ctx context.Context, actor rbac.Subject, perm rbac.Permission, object rbac.Object) error {
@@ -989,6 +1023,13 @@ func (q *querier) CleanTailnetTunnels(ctx context.Context) error {
 	return q.db.CleanTailnetTunnels(ctx)
 }
 
+func (q *querier) CountUnreadInboxNotificationsByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceInboxNotification.WithOwner(userID.String())); err != nil {
+		return 0, err
+	}
+	return q.db.CountUnreadInboxNotificationsByUserID(ctx, userID)
+}
+
 // TODO: Handle org scoped lookups
 func (q *querier) CustomRoles(ctx context.Context, arg database.CustomRolesParams) ([]database.CustomRole, error) {
 	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceAssignRole); err != nil {
@@ -1035,6 +1076,13 @@ func (q *querier) DeleteApplicationConnectAPIKeysByUserID(ctx context.Context, u
 	return q.db.DeleteApplicationConnectAPIKeysByUserID(ctx, userID)
 }
 
+func (q *querier) DeleteAuditLogsByIDs(ctx context.Context, ids []uuid.UUID) error {
+	if err := q.authorizeContext(ctx, policy.ActionDelete, rbac.ResourceSystem); err != nil {
+		return err
+	}
+	return q.db.DeleteAuditLogsByIDs(ctx, ids)
+}
+
 func (q *querier) DeleteCoordinator(ctx context.Context, id uuid.UUID) error {
 	if err := q.authorizeContext(ctx, policy.ActionDelete, rbac.ResourceTailnetCoordinator); err != nil {
 		return err
@@ -1159,6 +1207,13 @@ func (q *querier) DeleteOldWorkspaceAgentLogs(ctx context.Context, threshold tim
 	return q.db.DeleteOldWorkspaceAgentLogs(ctx, threshold)
 }
 
+func (q *querier) DeleteOldWorkspaceAgentMetadataHistory(ctx context.Context, arg database.DeleteOldWorkspaceAgentMetadataHistoryParams) error {
+	if err := q.authorizeContext(ctx, policy.ActionDelete, rbac.ResourceSystem); err != nil {
+		return err
+	}
+	return q.db.DeleteOldWorkspaceAgentMetadataHistory(ctx, arg)
+}
+
 func (q *querier) DeleteOldWorkspaceAgentStats(ctx context.Context) error {
 	if err := q.authorizeContext(ctx, policy.ActionDelete, rbac.ResourceSystem); err != nil {
 		return err
@@ -1198,6 +1253,10 @@ func (q *querier) DeleteRuntimeConfig(ctx context.Context, key string) error {
 	return q.db.DeleteRuntimeConfig(ctx, key)
 }
 
+func (q *querier) DeleteSavedSearchByID(ctx context.Context, id uuid.UUID) error {
+	return deleteQ(q.log, q.auth, q.db.GetSavedSearchByID, q.db.DeleteSavedSearchByID)(ctx, id)
+}
+
 func (q *querier) DeleteTailnetAgent(ctx context.Context, arg database.DeleteTailnetAgentParams) (database.DeleteTailnetAgentRow, error) {
 	if err := q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourceTailnetCoordinator); err != nil {
 		return database.DeleteTailnetAgentRow{}, err
@@ -1233,6 +1292,27 @@ func (q *querier) DeleteTailnetTunnel(ctx context.Context, arg database.DeleteTa
 	return q.db.DeleteTailnetTunnel(ctx, arg)
 }
 
+func (q *querier) DeleteUserNotificationQuietHours(ctx context.Context, userID uuid.UUID) error {
+	if err := q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourceNotificationPreference.WithOwner(userID.String())); err != nil {
+		return err
+	}
+	return q.db.DeleteUserNotificationQuietHours(ctx, userID)
+}
+
+func (q *querier) DeleteWorkspaceAgentContainersByAgentID(ctx context.Context, workspaceAgentID uuid.UUID) error {
+	workspace, err := q.db.GetWorkspaceByAgentID(ctx, workspaceAgentID)
+	if err != nil {
+		return err
+	}
+
+	err = q.authorizeContext(ctx, policy.ActionUpdate, workspace)
+	if err != nil {
+		return err
+	}
+
+	return q.db.DeleteWorkspaceAgentContainersByAgentID(ctx, workspaceAgentID)
+}
+
 func (q *querier) DeleteWorkspaceAgentPortShare(ctx context.Context, arg database.DeleteWorkspaceAgentPortShareParams) error {
 	w, err := q.db.GetWorkspaceByID(ctx, arg.WorkspaceID)
 	if err != nil {
@@ -1260,6 +1340,24 @@ func (q *querier) DeleteWorkspaceAgentPortSharesByTemplate(ctx context.Context,
 	return q.db.DeleteWorkspaceAgentPortSharesByTemplate(ctx, templateID)
 }
 
+func (q *querier) DeleteWorkspaceSnapshotByID(ctx context.Context, id uuid.UUID) error {
+	snapshot, err := q.db.GetWorkspaceSnapshotByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	w, err := q.db.GetWorkspaceByID(ctx, snapshot.WorkspaceID)
+	if err != nil {
+		return err
+	}
+
+	// deleting a workspace snapshot is more akin to just updating the workspace.
+	if err = q.authorizeContext(ctx, policy.ActionUpdate, w.RBACObject()); err != nil {
+		return xerrors.Errorf("authorize context: %w", err)
+	}
+
+	return q.db.DeleteWorkspaceSnapshotByID(ctx, id)
+}
+
 func (q *querier) EnqueueNotificationMessage(ctx context.Context, arg database.EnqueueNotificationMessageParams) error {
 	if err := q.authorizeContext(ctx, policy.ActionCreate, rbac.ResourceSystem); err != nil {
 		return err
@@ -1316,6 +1414,13 @@ func (q *querier) GetActiveWorkspaceBuildsByTemplateID(ctx context.Context, temp
 	return q.db.GetActiveWorkspaceBuildsByTemplateID(ctx, templateID)
 }
 
+func (q *querier) GetActiveWorkspaceSnapshotJobsByWorkspaceID(ctx context.Context, workspaceID uuid.UUID) ([]database.ProvisionerJob, error) {
+	if _, err := q.GetWorkspaceByID(ctx, workspaceID); err != nil {
+		return nil, err
+	}
+	return q.db.GetActiveWorkspaceSnapshotJobsByWorkspaceID(ctx, workspaceID)
+}
+
 func (q *querier) GetAllTailnetAgents(ctx context.Context) ([]database.TailnetAgent, error) {
 	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceTailnetCoordinator); err != nil {
 		return []database.TailnetAgent{}, err
@@ -1361,6 +1466,13 @@ func (q *querier) GetApplicationName(ctx context.Context) (string, error) {
 	return q.db.GetApplicationName(ctx)
 }
 
+func (q *querier) GetAuditLogsBefore(ctx context.Context, arg database.GetAuditLogsBeforeParams) ([]database.GetAuditLogsBeforeRow, error) {
+	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceSystem); err != nil {
+		return nil, err
+	}
+	return q.db.GetAuditLogsBefore(ctx, arg)
+}
+
 func (q *querier) GetAuditLogsOffset(ctx context.Context, arg database.GetAuditLogsOffsetParams) ([]database.GetAuditLogsOffsetRow, error) {
 	// Shortcut if the user is an owner. The SQL filter is noticeable,
 	// and this is an easy win for owners. Which is the common case.
@@ -1568,6 +1680,24 @@ func (q *querier) GetHungProvisionerJobs(ctx context.Context, hungSince time.Tim
 	return q.db.GetHungProvisionerJobs(ctx, hungSince)
 }
 
+func (q *querier) GetInProgressWorkspaceDriftCheckCountsByTemplate(ctx context.Context) ([]database.GetInProgressWorkspaceDriftCheckCountsByTemplateRow, error) {
+	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceSystem); err != nil {
+		return nil, err
+	}
+	return q.db.GetInProgressWorkspaceDriftCheckCountsByTemplate(ctx)
+}
+
+func (q *querier) GetInboxNotificationByID(ctx context.Context, id uuid.UUID) (database.InboxNotification, error) {
+	return fetch(q.log, q.auth, q.db.GetInboxNotificationByID)(ctx, id)
+}
+
+func (q *querier) GetInboxNotificationsByUserID(ctx context.Context, arg database.GetInboxNotificationsByUserIDParams) ([]database.InboxNotification, error) {
+	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceInboxNotification.WithOwner(arg.UserID.String())); err != nil {
+		return nil, err
+	}
+	return q.db.GetInboxNotificationsByUserID(ctx, arg)
+}
+
 func (q *querier) GetJFrogXrayScanByWorkspaceAndAgentID(ctx context.Context, arg database.GetJFrogXrayScanByWorkspaceAndAgentIDParams) (database.JfrogXrayScan, error) {
 	if _, err := fetch(q.log, q.auth, q.db.GetWorkspaceByID)(ctx, arg.WorkspaceID); err != nil {
 		return database.JfrogXrayScan{}, err
@@ -1632,6 +1762,13 @@ func (q *querier) GetLogoURL(ctx context.Context) (string, error) {
 	return q.db.GetLogoURL(ctx)
 }
 
+func (q *querier) GetNotificationMessagesAwaitingDigest(ctx context.Context) ([]database.NotificationMessage, error) {
+	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceSystem); err != nil {
+		return nil, err
+	}
+	return q.db.GetNotificationMessagesAwaitingDigest(ctx)
+}
+
 func (q *querier) GetNotificationMessagesByStatus(ctx context.Context, arg database.GetNotificationMessagesByStatusParams) ([]database.NotificationMessage, error) {
 	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceSystem); err != nil {
 		return nil, err
@@ -1786,6 +1923,13 @@ func (q *querier) GetParameterSchemasByJobID(ctx context.Context, jobID uuid.UUI
 	return q.db.GetParameterSchemasByJobID(ctx, jobID)
 }
 
+func (q *querier) GetPendingProvisionerJobIaCRequirements(ctx context.Context, organizationID uuid.UUID) ([]string, error) {
+	// if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceSystem); err != nil {
+	// return nil, err
+	// }
+	return q.db.GetPendingProvisionerJobIaCRequirements(ctx, organizationID)
+}
+
 func (q *querier) GetPreviousTemplateVersion(ctx context.Context, arg database.GetPreviousTemplateVersionParams) (database.TemplateVersion, error) {
 	// An actor can read the previous template version if they can read the related template.
 	// If no linked template exists, we check if the actor can read *a* template.
@@ -1831,6 +1975,13 @@ func (q *querier) GetProvisionerJobByID(ctx context.Context, id uuid.UUID) (data
 		if err != nil {
 			return database.ProvisionerJob{}, err
 		}
+	case database.ProvisionerJobTypeWorkspaceSnapshot, database.ProvisionerJobTypeWorkspaceDriftCheck:
+		// If we can read the workspace the snapshot or drift check belongs to,
+		// we can read the job.
+		_, err := authorizedWorkspaceFromJobInput(ctx, q, job)
+		if err != nil {
+			return database.ProvisionerJob{}, err
+		}
 	default:
 		return database.ProvisionerJob{}, xerrors.Errorf("unknown job type: %q", job.Type)
 	}
@@ -1838,6 +1989,29 @@ func (q *querier) GetProvisionerJobByID(ctx context.Context, id uuid.UUID) (data
 	return job, nil
 }
 
+func (q *querier) GetProvisionerJobResourceChangesByJobID(ctx context.Context, jobID uuid.UUID) ([]database.ProvisionerJobResourceChange, error) {
+	job, err := q.db.GetProvisionerJobByID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	// Resource changes are only recorded for dry-runs, which can be read by
+	// anyone who can read the template version, and for drift checks, which
+	// can be read by anyone who can read the workspace.
+	switch job.Type {
+	case database.ProvisionerJobTypeTemplateVersionDryRun:
+		if _, err := authorizedTemplateVersionFromJob(ctx, q, job); err != nil {
+			return nil, err
+		}
+	case database.ProvisionerJobTypeWorkspaceDriftCheck:
+		if _, err := authorizedWorkspaceFromJobInput(ctx, q, job); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, xerrors.Errorf("unsupported job type: %s", job.Type)
+	}
+	return q.db.GetProvisionerJobResourceChangesByJobID(ctx, jobID)
+}
+
 func (q *querier) GetProvisionerJobTimingsByJobID(ctx context.Context, jobID uuid.UUID) ([]database.ProvisionerJobTiming, error) {
 	_, err := q.GetProvisionerJobByID(ctx, jobID)
 	if err != nil {
@@ -1925,6 +2099,14 @@ func (q *querier) GetRuntimeConfig(ctx context.Context, key string) (string, err
 	return q.db.GetRuntimeConfig(ctx, key)
 }
 
+func (q *querier) GetSavedSearchByID(ctx context.Context, id uuid.UUID) (database.SavedSearch, error) {
+	return fetch(q.log, q.auth, q.db.GetSavedSearchByID)(ctx, id)
+}
+
+func (q *querier) GetSavedSearches(ctx context.Context, arg database.GetSavedSearchesParams) ([]database.SavedSearch, error) {
+	return fetchWithPostFilter(q.auth, policy.ActionRead, q.db.GetSavedSearches)(ctx, arg)
+}
+
 func (q *querier) GetTailnetAgents(ctx context.Context, id uuid.UUID) ([]database.TailnetAgent, error) {
 	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceTailnetCoordinator); err != nil {
 		return nil, err
@@ -2296,6 +2478,13 @@ func (q *querier) GetUserNotificationPreferences(ctx context.Context, userID uui
 	return q.db.GetUserNotificationPreferences(ctx, userID)
 }
 
+func (q *querier) GetUserNotificationQuietHours(ctx context.Context, userID uuid.UUID) (database.NotificationQuietHour, error) {
+	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceNotificationPreference.WithOwner(userID.String())); err != nil {
+		return database.NotificationQuietHour{}, err
+	}
+	return q.db.GetUserNotificationQuietHours(ctx, userID)
+}
+
 func (q *querier) GetUserWorkspaceBuildParameters(ctx context.Context, params database.GetUserWorkspaceBuildParametersParams) ([]database.GetUserWorkspaceBuildParametersRow, error) {
 	u, err := q.db.GetUserByID(ctx, params.OwnerID)
 	if err != nil {
@@ -2363,6 +2552,20 @@ func (q *querier) GetWorkspaceAgentByInstanceID(ctx context.Context, authInstanc
 	return agent, nil
 }
 
+func (q *querier) GetWorkspaceAgentContainersByAgentID(ctx context.Context, workspaceAgentID uuid.UUID) ([]database.WorkspaceAgentContainer, error) {
+	workspace, err := q.db.GetWorkspaceByAgentID(ctx, workspaceAgentID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = q.authorizeContext(ctx, policy.ActionRead, workspace)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.db.GetWorkspaceAgentContainersByAgentID(ctx, workspaceAgentID)
+}
+
 func (q *querier) GetWorkspaceAgentLifecycleStateByID(ctx context.Context, id uuid.UUID) (database.GetWorkspaceAgentLifecycleStateByIDRow, error) {
 	_, err := q.GetWorkspaceAgentByID(ctx, id)
 	if err != nil {
@@ -2400,6 +2603,20 @@ func (q *querier) GetWorkspaceAgentMetadata(ctx context.Context, arg database.Ge
 	return q.db.GetWorkspaceAgentMetadata(ctx, arg)
 }
 
+func (q *querier) GetWorkspaceAgentMetadataHistory(ctx context.Context, arg database.GetWorkspaceAgentMetadataHistoryParams) ([]database.WorkspaceAgentMetadataHistory, error) {
+	workspace, err := q.db.GetWorkspaceByAgentID(ctx, arg.WorkspaceAgentID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = q.authorizeContext(ctx, policy.ActionRead, workspace)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.db.GetWorkspaceAgentMetadataHistory(ctx, arg)
+}
+
 func (q *querier) GetWorkspaceAgentPortShare(ctx context.Context, arg database.GetWorkspaceAgentPortShareParams) (database.WorkspaceAgentPortShare, error) {
 	w, err := q.db.GetWorkspaceByID(ctx, arg.WorkspaceID)
 	if err != nil {
@@ -2414,6 +2631,13 @@ func (q *querier) GetWorkspaceAgentPortShare(ctx context.Context, arg database.G
 	return q.db.GetWorkspaceAgentPortShare(ctx, arg)
 }
 
+func (q *querier) GetWorkspaceAgentScriptTimingsByScriptID(ctx context.Context, arg database.GetWorkspaceAgentScriptTimingsByScriptIDParams) ([]database.WorkspaceAgentScriptTiming, error) {
+	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceSystem); err != nil {
+		return nil, err
+	}
+	return q.db.GetWorkspaceAgentScriptTimingsByScriptID(ctx, arg)
+}
+
 func (q *querier) GetWorkspaceAgentScriptsByAgentIDs(ctx context.Context, ids []uuid.UUID) ([]database.WorkspaceAgentScript, error) {
 	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceSystem); err != nil {
 		return nil, err
@@ -2421,6 +2645,31 @@ func (q *querier) GetWorkspaceAgentScriptsByAgentIDs(ctx context.Context, ids []
 	return q.db.GetWorkspaceAgentScriptsByAgentIDs(ctx, ids)
 }
 
+func (q *querier) GetWorkspaceAgentSessionRecordingByID(ctx context.Context, id uuid.UUID) (database.WorkspaceAgentSessionRecording, error) {
+	recording, err := q.db.GetWorkspaceAgentSessionRecordingByID(ctx, id)
+	if err != nil {
+		return database.WorkspaceAgentSessionRecording{}, err
+	}
+	if err := q.authorizeSessionRecordings(ctx, recording.WorkspaceID); err != nil {
+		return database.WorkspaceAgentSessionRecording{}, err
+	}
+	return recording, nil
+}
+
+func (q *querier) GetWorkspaceAgentSessionRecordingChunks(ctx context.Context, recordingID uuid.UUID) ([]database.WorkspaceAgentSessionRecordingChunk, error) {
+	if _, err := q.GetWorkspaceAgentSessionRecordingByID(ctx, recordingID); err != nil {
+		return nil, err
+	}
+	return q.db.GetWorkspaceAgentSessionRecordingChunks(ctx, recordingID)
+}
+
+func (q *querier) GetWorkspaceAgentSessionRecordingsByWorkspaceID(ctx context.Context, workspaceID uuid.UUID) ([]database.WorkspaceAgentSessionRecording, error) {
+	if err := q.authorizeSessionRecordings(ctx, workspaceID); err != nil {
+		return nil, err
+	}
+	return q.db.GetWorkspaceAgentSessionRecordingsByWorkspaceID(ctx, workspaceID)
+}
+
 func (q *querier) GetWorkspaceAgentStats(ctx context.Context, createdAfter time.Time) ([]database.GetWorkspaceAgentStatsRow, error) {
 	return q.db.GetWorkspaceAgentStats(ctx, createdAfter)
 }
@@ -2560,6 +2809,13 @@ func (q *querier) GetWorkspaceBuildsCreatedAfter(ctx context.Context, createdAt
 	return q.db.GetWorkspaceBuildsCreatedAfter(ctx, createdAt)
 }
 
+func (q *querier) GetWorkspaceBuildsToMigrateProvisionerState(ctx context.Context, arg database.GetWorkspaceBuildsToMigrateProvisionerStateParams) ([]database.WorkspaceBuild, error) {
+	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceSystem); err != nil {
+		return nil, err
+	}
+	return q.db.GetWorkspaceBuildsToMigrateProvisionerState(ctx, arg)
+}
+
 func (q *querier) GetWorkspaceByAgentID(ctx context.Context, agentID uuid.UUID) (database.GetWorkspaceByAgentIDRow, error) {
 	return fetch(q.log, q.auth, q.db.GetWorkspaceByAgentID)(ctx, agentID)
 }
@@ -2576,6 +2832,13 @@ func (q *querier) GetWorkspaceByWorkspaceAppID(ctx context.Context, workspaceApp
 	return fetch(q.log, q.auth, q.db.GetWorkspaceByWorkspaceAppID)(ctx, workspaceAppID)
 }
 
+func (q *querier) GetWorkspaceDriftedResourcesByWorkspaceIDs(ctx context.Context, ids []uuid.UUID) ([]database.GetWorkspaceDriftedResourcesByWorkspaceIDsRow, error) {
+	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceSystem); err != nil {
+		return nil, err
+	}
+	return q.db.GetWorkspaceDriftedResourcesByWorkspaceIDs(ctx, ids)
+}
+
 func (q *querier) GetWorkspaceProxies(ctx context.Context) ([]database.WorkspaceProxy, error) {
 	return fetchWithPostFilter(q.auth, policy.ActionRead, func(ctx context.Context, _ interface{}) ([]database.WorkspaceProxy, error) {
 		return q.db.GetWorkspaceProxies(ctx)
@@ -2663,6 +2926,12 @@ func (q *querier) GetWorkspaceResourcesByJobID(ctx context.Context, jobID uuid.U
 			return nil, err
 		}
 		obj = workspace
+	case database.ProvisionerJobTypeWorkspaceSnapshot, database.ProvisionerJobTypeWorkspaceDriftCheck:
+		workspace, err := authorizedWorkspaceFromJobInput(ctx, q, job)
+		if err != nil {
+			return nil, err
+		}
+		obj = workspace
 	default:
 		return nil, xerrors.Errorf("unknown job type: %s", job.Type)
 	}
@@ -2690,6 +2959,27 @@ func (q *querier) GetWorkspaceResourcesCreatedAfter(ctx context.Context, created
 	return q.db.GetWorkspaceResourcesCreatedAfter(ctx, createdAt)
 }
 
+func (q *querier) GetWorkspaceSnapshotByID(ctx context.Context, id uuid.UUID) (database.WorkspaceSnapshot, error) {
+	snapshot, err := q.db.GetWorkspaceSnapshotByID(ctx, id)
+	if err != nil {
+		return database.WorkspaceSnapshot{}, err
+	}
+
+	// reading a workspace snapshot is more akin to just reading the workspace.
+	if _, err := q.GetWorkspaceByID(ctx, snapshot.WorkspaceID); err != nil {
+		return database.WorkspaceSnapshot{}, err
+	}
+	return snapshot, nil
+}
+
+func (q *querier) GetWorkspaceSnapshotsByWorkspaceID(ctx context.Context, workspaceID uuid.UUID) ([]database.WorkspaceSnapshot, error) {
+	// listing snapshots is more akin to reading the workspace.
+	if _, err := q.GetWorkspaceByID(ctx, workspaceID); err != nil {
+		return nil, err
+	}
+	return q.db.GetWorkspaceSnapshotsByWorkspaceID(ctx, workspaceID)
+}
+
 func (q *querier) GetWorkspaceUniqueOwnerCountByTemplateIDs(ctx context.Context, templateIds []uuid.UUID) ([]database.GetWorkspaceUniqueOwnerCountByTemplateIDsRow, error) {
 	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceSystem); err != nil {
 		return nil, err
@@ -2705,6 +2995,13 @@ func (q *querier) GetWorkspaces(ctx context.Context, arg database.GetWorkspacesP
 	return q.db.GetAuthorizedWorkspaces(ctx, arg, prep)
 }
 
+func (q *querier) GetWorkspacesEligibleForDriftCheck(ctx context.Context, checkedBefore time.Time) ([]database.GetWorkspacesEligibleForDriftCheckRow, error) {
+	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceSystem); err != nil {
+		return nil, err
+	}
+	return q.db.GetWorkspacesEligibleForDriftCheck(ctx, checkedBefore)
+}
+
 func (q *querier) GetWorkspacesEligibleForTransition(ctx context.Context, now time.Time) ([]database.Workspace, error) {
 	return q.db.GetWorkspacesEligibleForTransition(ctx, now)
 }
@@ -2803,6 +3100,13 @@ func (q *querier) InsertGroupMember(ctx context.Context, arg database.InsertGrou
 	return update(q.log, q.auth, fetch, q.db.InsertGroupMember)(ctx, arg)
 }
 
+func (q *querier) InsertInboxNotification(ctx context.Context, arg database.InsertInboxNotificationParams) (int64, error) {
+	if err := q.authorizeContext(ctx, policy.ActionCreate, rbac.ResourceInboxNotification.WithOwner(arg.UserID.String())); err != nil {
+		return 0, err
+	}
+	return q.db.InsertInboxNotification(ctx, arg)
+}
+
 func (q *querier) InsertLicense(ctx context.Context, arg database.InsertLicenseParams) (database.License, error) {
 	if err := q.authorizeContext(ctx, policy.ActionCreate, rbac.ResourceLicense); err != nil {
 		return database.License{}, err
@@ -2887,6 +3191,13 @@ func (q *querier) InsertProvisionerJobLogs(ctx context.Context, arg database.Ins
 	return q.db.InsertProvisionerJobLogs(ctx, arg)
 }
 
+func (q *querier) InsertProvisionerJobResourceChange(ctx context.Context, arg database.InsertProvisionerJobResourceChangeParams) (database.ProvisionerJobResourceChange, error) {
+	if err := q.authorizeContext(ctx, policy.ActionCreate, rbac.ResourceSystem); err != nil {
+		return database.ProvisionerJobResourceChange{}, err
+	}
+	return q.db.InsertProvisionerJobResourceChange(ctx, arg)
+}
+
 // TODO: We need to create a ProvisionerJob resource type
 func (q *querier) InsertProvisionerJobTimings(ctx context.Context, arg database.InsertProvisionerJobTimingsParams) ([]database.ProvisionerJobTiming, error) {
 	// if err := q.authorizeContext(ctx, policy.ActionCreate, rbac.ResourceSystem); err != nil {
@@ -2906,6 +3217,16 @@ func (q *querier) InsertReplica(ctx context.Context, arg database.InsertReplicaP
 	return q.db.InsertReplica(ctx, arg)
 }
 
+func (q *querier) InsertSavedSearch(ctx context.Context, arg database.InsertSavedSearchParams) (database.SavedSearch, error) {
+	obj := database.SavedSearch{
+		ID:             arg.ID,
+		UserID:         arg.UserID,
+		OrganizationID: arg.OrganizationID,
+		GroupID:        arg.GroupID,
+	}.RBACObject()
+	return insert(q.log, q.auth, obj, q.db.InsertSavedSearch)(ctx, arg)
+}
+
 func (q *querier) InsertTemplate(ctx context.Context, arg database.InsertTemplateParams) error {
 	obj := rbac.ResourceTemplate.InOrg(arg.OrganizationID)
 	if err := q.authorizeContext(ctx, policy.ActionCreate, obj); err != nil {
@@ -3007,6 +3328,20 @@ func (q *querier) InsertWorkspaceAgent(ctx context.Context, arg database.InsertW
 	return q.db.InsertWorkspaceAgent(ctx, arg)
 }
 
+func (q *querier) InsertWorkspaceAgentContainer(ctx context.Context, arg database.InsertWorkspaceAgentContainerParams) error {
+	workspace, err := q.db.GetWorkspaceByAgentID(ctx, arg.WorkspaceAgentID)
+	if err != nil {
+		return err
+	}
+
+	err = q.authorizeContext(ctx, policy.ActionUpdate, workspace)
+	if err != nil {
+		return err
+	}
+
+	return q.db.InsertWorkspaceAgentContainer(ctx, arg)
+}
+
 func (q *querier) InsertWorkspaceAgentLogSources(ctx context.Context, arg database.InsertWorkspaceAgentLogSourcesParams) ([]database.WorkspaceAgentLogSource, error) {
 	// TODO: This is used by the agent, should we have an rbac check here?
 	return q.db.InsertWorkspaceAgentLogSources(ctx, arg)
@@ -3027,6 +3362,20 @@ func (q *querier) InsertWorkspaceAgentMetadata(ctx context.Context, arg database
 	return q.db.InsertWorkspaceAgentMetadata(ctx, arg)
 }
 
+func (q *querier) InsertWorkspaceAgentMetadataHistory(ctx context.Context, arg database.InsertWorkspaceAgentMetadataHistoryParams) error {
+	workspace, err := q.db.GetWorkspaceByAgentID(ctx, arg.WorkspaceAgentID)
+	if err != nil {
+		return err
+	}
+
+	err = q.authorizeContext(ctx, policy.ActionUpdate, workspace)
+	if err != nil {
+		return err
+	}
+
+	return q.db.InsertWorkspaceAgentMetadataHistory(ctx, arg)
+}
+
 func (q *querier) InsertWorkspaceAgentScriptTimings(ctx context.Context, arg database.InsertWorkspaceAgentScriptTimingsParams) error {
 	if err := q.authorizeContext(ctx, policy.ActionCreate, rbac.ResourceSystem); err != nil {
 		return err
@@ -3041,6 +3390,20 @@ func (q *querier) InsertWorkspaceAgentScripts(ctx context.Context, arg database.
 	return q.db.InsertWorkspaceAgentScripts(ctx, arg)
 }
 
+func (q *querier) InsertWorkspaceAgentSessionRecording(ctx context.Context, arg database.InsertWorkspaceAgentSessionRecordingParams) (database.WorkspaceAgentSessionRecording, error) {
+	if err := q.authorizeContext(ctx, policy.ActionCreate, rbac.ResourceSystem); err != nil {
+		return database.WorkspaceAgentSessionRecording{}, err
+	}
+	return q.db.InsertWorkspaceAgentSessionRecording(ctx, arg)
+}
+
+func (q *querier) InsertWorkspaceAgentSessionRecordingChunk(ctx context.Context, arg database.InsertWorkspaceAgentSessionRecordingChunkParams) error {
+	if err := q.authorizeContext(ctx, policy.ActionCreate, rbac.ResourceSystem); err != nil {
+		return err
+	}
+	return q.db.InsertWorkspaceAgentSessionRecordingChunk(ctx, arg)
+}
+
 func (q *querier) InsertWorkspaceAgentStats(ctx context.Context, arg database.InsertWorkspaceAgentStatsParams) error {
 	if err := q.authorizeContext(ctx, policy.ActionCreate, rbac.ResourceSystem); err != nil {
 		return err
@@ -3124,6 +3487,13 @@ func (q *querier) InsertWorkspaceBuildParameters(ctx context.Context, arg databa
 	return q.db.InsertWorkspaceBuildParameters(ctx, arg)
 }
 
+func (q *querier) InsertWorkspaceDriftCheck(ctx context.Context, arg database.InsertWorkspaceDriftCheckParams) (database.WorkspaceDriftCheck, error) {
+	if err := q.authorizeContext(ctx, policy.ActionCreate, rbac.ResourceSystem); err != nil {
+		return database.WorkspaceDriftCheck{}, err
+	}
+	return q.db.InsertWorkspaceDriftCheck(ctx, arg)
+}
+
 func (q *querier) InsertWorkspaceProxy(ctx context.Context, arg database.InsertWorkspaceProxyParams) (database.WorkspaceProxy, error) {
 	return insert(q.log, q.auth, rbac.ResourceWorkspaceProxy, q.db.InsertWorkspaceProxy)(ctx, arg)
 }
@@ -3142,6 +3512,20 @@ func (q *querier) InsertWorkspaceResourceMetadata(ctx context.Context, arg datab
 	return q.db.InsertWorkspaceResourceMetadata(ctx, arg)
 }
 
+func (q *querier) InsertWorkspaceSnapshot(ctx context.Context, arg database.InsertWorkspaceSnapshotParams) (database.WorkspaceSnapshot, error) {
+	w, err := q.db.GetWorkspaceByID(ctx, arg.WorkspaceID)
+	if err != nil {
+		return database.WorkspaceSnapshot{}, err
+	}
+
+	// taking a snapshot of a workspace is more akin to just updating the workspace.
+	if err = q.authorizeContext(ctx, policy.ActionUpdate, w.RBACObject()); err != nil {
+		return database.WorkspaceSnapshot{}, xerrors.Errorf("authorize context: %w", err)
+	}
+
+	return q.db.InsertWorkspaceSnapshot(ctx, arg)
+}
+
 func (q *querier) ListProvisionerKeysByOrganization(ctx context.Context, organizationID uuid.UUID) ([]database.ProvisionerKey, error) {
 	return fetchWithPostFilter(q.auth, policy.ActionRead, q.db.ListProvisionerKeysByOrganization)(ctx, organizationID)
 }
@@ -3164,6 +3548,28 @@ func (q *querier) ListWorkspaceAgentPortShares(ctx context.Context, workspaceID
 	return q.db.ListWorkspaceAgentPortShares(ctx, workspaceID)
 }
 
+func (q *querier) LockWorkspaceByID(ctx context.Context, id uuid.UUID) error {
+	// Locking leaves the workspace as it is, so it only needs to be readable.
+	if _, err := q.GetWorkspaceByID(ctx, id); err != nil {
+		return err
+	}
+	return q.db.LockWorkspaceByID(ctx, id)
+}
+
+func (q *querier) MarkAllInboxNotificationsAsRead(ctx context.Context, arg database.MarkAllInboxNotificationsAsReadParams) (int64, error) {
+	if err := q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourceInboxNotification.WithOwner(arg.UserID.String())); err != nil {
+		return 0, err
+	}
+	return q.db.MarkAllInboxNotificationsAsRead(ctx, arg)
+}
+
+func (q *querier) MarkNotificationMessagesDigested(ctx context.Context, arg database.MarkNotificationMessagesDigestedParams) error {
+	if err := q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourceSystem); err != nil {
+		return err
+	}
+	return q.db.MarkNotificationMessagesDigested(ctx, arg)
+}
+
 func (q *querier) OrganizationMembers(ctx context.Context, arg database.OrganizationMembersParams) ([]database.OrganizationMembersRow, error) {
 	return fetchWithPostFilter(q.auth, policy.ActionRead, q.db.OrganizationMembers)(ctx, arg)
 }
@@ -3188,6 +3594,13 @@ func (q *querier) RegisterWorkspaceProxy(ctx context.Context, arg database.Regis
 	return updateWithReturn(q.log, q.auth, fetch, q.db.RegisterWorkspaceProxy)(ctx, arg)
 }
 
+func (q *querier) ReleaseNotificationMessagesFromDigest(ctx context.Context, ids []uuid.UUID) error {
+	if err := q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourceSystem); err != nil {
+		return err
+	}
+	return q.db.ReleaseNotificationMessagesFromDigest(ctx, ids)
+}
+
 func (q *querier) RemoveUserFromAllGroups(ctx context.Context, userID uuid.UUID) error {
 	// This is a system function to clear user groups in group sync.
 	if err := q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourceSystem); err != nil {
@@ -3307,6 +3720,13 @@ func (q *querier) UpdateInactiveUsersToDormant(ctx context.Context, lastSeenAfte
 	return q.db.UpdateInactiveUsersToDormant(ctx, lastSeenAfter)
 }
 
+func (q *querier) UpdateInboxNotificationStatus(ctx context.Context, arg database.UpdateInboxNotificationStatusParams) (database.InboxNotification, error) {
+	fetch := func(ctx context.Context, arg database.UpdateInboxNotificationStatusParams) (database.InboxNotification, error) {
+		return q.db.GetInboxNotificationByID(ctx, arg.ID)
+	}
+	return updateWithReturn(q.log, q.auth, fetch, q.db.UpdateInboxNotificationStatus)(ctx, arg)
+}
+
 func (q *querier) UpdateMemberRoles(ctx context.Context, arg database.UpdateMemberRolesParams) (database.OrganizationMember, error) {
 	// Authorized fetch will check that the actor has read access to the org member since the org member is returned.
 	member, err := database.ExpectOne(q.OrganizationMembers(ctx, database.OrganizationMembersParams{
@@ -3445,6 +3865,15 @@ func (q *querier) UpdateProvisionerJobWithCancelByID(ctx context.Context, arg da
 				return err
 			}
 		}
+	case database.ProvisionerJobTypeWorkspaceSnapshot, database.ProvisionerJobTypeWorkspaceDriftCheck:
+		workspace, err := authorizedWorkspaceFromJobInput(ctx, q, job)
+		if err != nil {
+			return err
+		}
+		err = q.authorizeContext(ctx, policy.ActionUpdate, workspace)
+		if err != nil {
+			return err
+		}
 	default:
 		return xerrors.Errorf("unknown job type: %q", job.Type)
 	}
@@ -3466,6 +3895,13 @@ func (q *querier) UpdateReplica(ctx context.Context, arg database.UpdateReplicaP
 	return q.db.UpdateReplica(ctx, arg)
 }
 
+func (q *querier) UpdateSavedSearch(ctx context.Context, arg database.UpdateSavedSearchParams) (database.SavedSearch, error) {
+	fetch := func(ctx context.Context, arg database.UpdateSavedSearchParams) (database.SavedSearch, error) {
+		return q.db.GetSavedSearchByID(ctx, arg.ID)
+	}
+	return updateWithReturn(q.log, q.auth, fetch, q.db.UpdateSavedSearch)(ctx, arg)
+}
+
 func (q *querier) UpdateTailnetPeerStatusByCoordinator(ctx context.Context, arg database.UpdateTailnetPeerStatusByCoordinatorParams) error {
 	if err := q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourceTailnetCoordinator); err != nil {
 		return err
@@ -3788,6 +4224,13 @@ func (q *querier) UpdateWorkspaceAgentMetadata(ctx context.Context, arg database
 	return q.db.UpdateWorkspaceAgentMetadata(ctx, arg)
 }
 
+func (q *querier) UpdateWorkspaceAgentSessionRecordingEndedAt(ctx context.Context, arg database.UpdateWorkspaceAgentSessionRecordingEndedAtParams) error {
+	if err := q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourceSystem); err != nil {
+		return err
+	}
+	return q.db.UpdateWorkspaceAgentSessionRecordingEndedAt(ctx, arg)
+}
+
 func (q *querier) UpdateWorkspaceAgentStartupByID(ctx context.Context, arg database.UpdateWorkspaceAgentStartupByIDParams) error {
 	agent, err := q.db.GetWorkspaceAgentByID(ctx, arg.ID)
 	if err != nil {
@@ -3873,6 +4316,13 @@ func (q *querier) UpdateWorkspaceBuildProvisionerStateByID(ctx context.Context,
 	return q.db.UpdateWorkspaceBuildProvisionerStateByID(ctx, arg)
 }
 
+func (q *querier) UpdateWorkspaceBuildProvisionerStateLocationByID(ctx context.Context, arg database.UpdateWorkspaceBuildProvisionerStateLocationByIDParams) (int64, error) {
+	if err := q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourceSystem); err != nil {
+		return 0, err
+	}
+	return q.db.UpdateWorkspaceBuildProvisionerStateLocationByID(ctx, arg)
+}
+
 // Deprecated: Use SoftDeleteWorkspaceByID
 func (q *querier) UpdateWorkspaceDeletedByID(ctx context.Context, arg database.UpdateWorkspaceDeletedByIDParams) error {
 	// TODO deleteQ me, placeholder for database.Store
@@ -3911,6 +4361,13 @@ func (q *querier) UpdateWorkspaceProxyDeleted(ctx context.Context, arg database.
 	return deleteQ(q.log, q.auth, fetch, q.db.UpdateWorkspaceProxyDeleted)(ctx, arg)
 }
 
+func (q *querier) UpdateWorkspaceSnapshotDataByID(ctx context.Context, arg database.UpdateWorkspaceSnapshotDataByIDParams) error {
+	if err := q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourceSystem); err != nil {
+		return err
+	}
+	return q.db.UpdateWorkspaceSnapshotDataByID(ctx, arg)
+}
+
 func (q *querier) UpdateWorkspaceTTL(ctx context.Context, arg database.UpdateWorkspaceTTLParams) error {
 	fetch := func(ctx context.Context, arg database.UpdateWorkspaceTTLParams) (database.Workspace, error) {
 		return q.db.GetWorkspaceByID(ctx, arg.ID)
@@ -4094,6 +4551,13 @@ func (q *querier) UpsertTemplateUsageStats(ctx context.Context) error {
 	return q.db.UpsertTemplateUsageStats(ctx)
 }
 
+func (q *querier) UpsertUserNotificationQuietHours(ctx context.Context, arg database.UpsertUserNotificationQuietHoursParams) (database.NotificationQuietHour, error) {
+	if err := q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourceNotificationPreference.WithOwner(arg.UserID.String())); err != nil {
+		return database.NotificationQuietHour{}, err
+	}
+	return q.db.UpsertUserNotificationQuietHours(ctx, arg)
+}
+
 func (q *querier) UpsertWorkspaceAgentPortShare(ctx context.Context, arg database.UpsertWorkspaceAgentPortShareParams) (database.WorkspaceAgentPortShare, error) {
 	workspace, err := q.db.GetWorkspaceByID(ctx, arg.WorkspaceID)
 	if err != nil {
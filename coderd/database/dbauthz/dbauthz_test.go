@@ -276,6 +276,14 @@ func (s *MethodTestSuite) TestAuditLogs() {
 			LimitOpt: 10,
 		}, emptyPreparedAuthorized{}).Asserts(rbac.ResourceAuditLog, policy.ActionRead)
 	}))
+	s.Run("DeleteAuditLogsByIDs", s.Subtest(func(db database.Store, check *expects) {
+		alog := dbgen.AuditLog(s.T(), db, database.AuditLog{})
+		check.Args([]uuid.UUID{alog.ID}).Asserts(rbac.ResourceSystem, policy.ActionDelete)
+	}))
+	s.Run("GetAuditLogsBefore", s.Subtest(func(db database.Store, check *expects) {
+		_ = dbgen.AuditLog(s.T(), db, database.AuditLog{})
+		check.Args(database.GetAuditLogsBeforeParams{Before: dbtime.Now().Add(time.Hour), LimitOpt: 10}).Asserts(rbac.ResourceSystem, policy.ActionRead)
+	}))
 }
 
 func (s *MethodTestSuite) TestFile() {
@@ -496,6 +504,28 @@ func (s *MethodTestSuite) TestProvisionerJob() {
 		})
 		check.Args(j.ID).Asserts(v.RBACObject(tpl), policy.ActionRead).Returns(j)
 	}))
+	s.Run("WorkspaceSnapshot/GetProvisionerJobByID", s.Subtest(func(db database.Store, check *expects) {
+		w := dbgen.Workspace(s.T(), db, database.Workspace{})
+		b := dbgen.WorkspaceBuild(s.T(), db, database.WorkspaceBuild{WorkspaceID: w.ID})
+		j := dbgen.ProvisionerJob(s.T(), db, nil, database.ProvisionerJob{
+			Type: database.ProvisionerJobTypeWorkspaceSnapshot,
+			Input: must(json.Marshal(struct {
+				WorkspaceBuildID uuid.UUID `json:"workspace_build_id"`
+			}{WorkspaceBuildID: b.ID})),
+		})
+		check.Args(j.ID).Asserts(w, policy.ActionRead).Returns(j)
+	}))
+	s.Run("WorkspaceDriftCheck/GetProvisionerJobByID", s.Subtest(func(db database.Store, check *expects) {
+		w := dbgen.Workspace(s.T(), db, database.Workspace{})
+		b := dbgen.WorkspaceBuild(s.T(), db, database.WorkspaceBuild{WorkspaceID: w.ID})
+		j := dbgen.ProvisionerJob(s.T(), db, nil, database.ProvisionerJob{
+			Type: database.ProvisionerJobTypeWorkspaceDriftCheck,
+			Input: must(json.Marshal(struct {
+				WorkspaceBuildID uuid.UUID `json:"workspace_build_id"`
+			}{WorkspaceBuildID: b.ID})),
+		})
+		check.Args(j.ID).Asserts(w, policy.ActionRead).Returns(j)
+	}))
 	s.Run("Build/UpdateProvisionerJobWithCancelByID", s.Subtest(func(db database.Store, check *expects) {
 		tpl := dbgen.Template(s.T(), db, database.Template{AllowUserCancelWorkspaceJobs: true})
 		w := dbgen.Workspace(s.T(), db, database.Workspace{TemplateID: tpl.ID})
@@ -551,6 +581,18 @@ func (s *MethodTestSuite) TestProvisionerJob() {
 		check.Args(database.UpdateProvisionerJobWithCancelByIDParams{ID: j.ID}).
 			Asserts(v.RBACObject(tpl), []policy.Action{policy.ActionRead, policy.ActionUpdate}).Returns()
 	}))
+	s.Run("WorkspaceSnapshot/UpdateProvisionerJobWithCancelByID", s.Subtest(func(db database.Store, check *expects) {
+		w := dbgen.Workspace(s.T(), db, database.Workspace{})
+		b := dbgen.WorkspaceBuild(s.T(), db, database.WorkspaceBuild{WorkspaceID: w.ID})
+		j := dbgen.ProvisionerJob(s.T(), db, nil, database.ProvisionerJob{
+			Type: database.ProvisionerJobTypeWorkspaceSnapshot,
+			Input: must(json.Marshal(struct {
+				WorkspaceBuildID uuid.UUID `json:"workspace_build_id"`
+			}{WorkspaceBuildID: b.ID})),
+		})
+		check.Args(database.UpdateProvisionerJobWithCancelByIDParams{ID: j.ID}).
+			Asserts(w, []policy.Action{policy.ActionRead, policy.ActionUpdate}).Returns()
+	}))
 	s.Run("GetProvisionerJobTimingsByJobID", s.Subtest(func(db database.Store, check *expects) {
 		w := dbgen.Workspace(s.T(), db, database.Workspace{})
 		j := dbgen.ProvisionerJob(s.T(), db, nil, database.ProvisionerJob{
@@ -571,6 +613,48 @@ func (s *MethodTestSuite) TestProvisionerJob() {
 		})
 		check.Args(j.ID).Asserts(w, policy.ActionRead).Returns(t)
 	}))
+	s.Run("GetProvisionerJobResourceChangesByJobID", s.Subtest(func(db database.Store, check *expects) {
+		tpl := dbgen.Template(s.T(), db, database.Template{})
+		v := dbgen.TemplateVersion(s.T(), db, database.TemplateVersion{
+			TemplateID: uuid.NullUUID{UUID: tpl.ID, Valid: true},
+		})
+		j := dbgen.ProvisionerJob(s.T(), db, nil, database.ProvisionerJob{
+			Type: database.ProvisionerJobTypeTemplateVersionDryRun,
+			Input: must(json.Marshal(struct {
+				TemplateVersionID uuid.UUID `json:"template_version_id"`
+			}{TemplateVersionID: v.ID})),
+		})
+		c, err := db.InsertProvisionerJobResourceChange(context.Background(), database.InsertProvisionerJobResourceChangeParams{
+			JobID:      j.ID,
+			Address:    "docker_volume.home",
+			Type:       "docker_volume",
+			Name:       "home",
+			Action:     database.ResourceChangeActionReplace,
+			Attributes: json.RawMessage("[]"),
+		})
+		require.NoError(s.T(), err)
+		check.Args(j.ID).Asserts(v.RBACObject(tpl), policy.ActionRead).Returns([]database.ProvisionerJobResourceChange{c})
+	}))
+	s.Run("WorkspaceDriftCheck/GetProvisionerJobResourceChangesByJobID", s.Subtest(func(db database.Store, check *expects) {
+		w := dbgen.Workspace(s.T(), db, database.Workspace{})
+		b := dbgen.WorkspaceBuild(s.T(), db, database.WorkspaceBuild{WorkspaceID: w.ID})
+		j := dbgen.ProvisionerJob(s.T(), db, nil, database.ProvisionerJob{
+			Type: database.ProvisionerJobTypeWorkspaceDriftCheck,
+			Input: must(json.Marshal(struct {
+				WorkspaceBuildID uuid.UUID `json:"workspace_build_id"`
+			}{WorkspaceBuildID: b.ID})),
+		})
+		c, err := db.InsertProvisionerJobResourceChange(context.Background(), database.InsertProvisionerJobResourceChangeParams{
+			JobID:      j.ID,
+			Address:    "docker_volume.home",
+			Type:       "docker_volume",
+			Name:       "home",
+			Action:     database.ResourceChangeActionDelete,
+			Attributes: json.RawMessage("[]"),
+		})
+		require.NoError(s.T(), err)
+		check.Args(j.ID).Asserts(w, policy.ActionRead).Returns([]database.ProvisionerJobResourceChange{c})
+	}))
 	s.Run("GetProvisionerJobsByIDs", s.Subtest(func(db database.Store, check *expects) {
 		a := dbgen.ProvisionerJob(s.T(), db, nil, database.ProvisionerJob{})
 		b := dbgen.ProvisionerJob(s.T(), db, nil, database.ProvisionerJob{})
@@ -1527,6 +1611,55 @@ func (s *MethodTestSuite) TestWorkspace() {
 			Keys:             []string{"test"},
 		}).Asserts(ws, policy.ActionRead)
 	}))
+	s.Run("GetWorkspaceAgentMetadataHistory", s.Subtest(func(db database.Store, check *expects) {
+		tpl := dbgen.Template(s.T(), db, database.Template{})
+		ws := dbgen.Workspace(s.T(), db, database.Workspace{
+			TemplateID: tpl.ID,
+		})
+		build := dbgen.WorkspaceBuild(s.T(), db, database.WorkspaceBuild{WorkspaceID: ws.ID, JobID: uuid.New()})
+		res := dbgen.WorkspaceResource(s.T(), db, database.WorkspaceResource{JobID: build.JobID})
+		agt := dbgen.WorkspaceAgent(s.T(), db, database.WorkspaceAgent{ResourceID: res.ID})
+		check.Args(database.GetWorkspaceAgentMetadataHistoryParams{
+			WorkspaceAgentID: agt.ID,
+			Key:              "test",
+			Limit:            10,
+		}).Asserts(ws, policy.ActionRead)
+	}))
+	s.Run("GetWorkspaceAgentContainersByAgentID", s.Subtest(func(db database.Store, check *expects) {
+		tpl := dbgen.Template(s.T(), db, database.Template{})
+		ws := dbgen.Workspace(s.T(), db, database.Workspace{
+			TemplateID: tpl.ID,
+		})
+		build := dbgen.WorkspaceBuild(s.T(), db, database.WorkspaceBuild{WorkspaceID: ws.ID, JobID: uuid.New()})
+		res := dbgen.WorkspaceResource(s.T(), db, database.WorkspaceResource{JobID: build.JobID})
+		agt := dbgen.WorkspaceAgent(s.T(), db, database.WorkspaceAgent{ResourceID: res.ID})
+		check.Args(agt.ID).Asserts(ws, policy.ActionRead)
+	}))
+	s.Run("DeleteWorkspaceAgentContainersByAgentID", s.Subtest(func(db database.Store, check *expects) {
+		tpl := dbgen.Template(s.T(), db, database.Template{})
+		ws := dbgen.Workspace(s.T(), db, database.Workspace{
+			TemplateID: tpl.ID,
+		})
+		build := dbgen.WorkspaceBuild(s.T(), db, database.WorkspaceBuild{WorkspaceID: ws.ID, JobID: uuid.New()})
+		res := dbgen.WorkspaceResource(s.T(), db, database.WorkspaceResource{JobID: build.JobID})
+		agt := dbgen.WorkspaceAgent(s.T(), db, database.WorkspaceAgent{ResourceID: res.ID})
+		check.Args(agt.ID).Asserts(ws, policy.ActionUpdate).Returns()
+	}))
+	s.Run("InsertWorkspaceAgentContainer", s.Subtest(func(db database.Store, check *expects) {
+		tpl := dbgen.Template(s.T(), db, database.Template{})
+		ws := dbgen.Workspace(s.T(), db, database.Workspace{
+			TemplateID: tpl.ID,
+		})
+		build := dbgen.WorkspaceBuild(s.T(), db, database.WorkspaceBuild{WorkspaceID: ws.ID, JobID: uuid.New()})
+		res := dbgen.WorkspaceResource(s.T(), db, database.WorkspaceResource{JobID: build.JobID})
+		agt := dbgen.WorkspaceAgent(s.T(), db, database.WorkspaceAgent{ResourceID: res.ID})
+		check.Args(database.InsertWorkspaceAgentContainerParams{
+			WorkspaceAgentID: agt.ID,
+			ContainerID:      "0b1c2d3e",
+			Labels:           json.RawMessage("{}"),
+			Ports:            json.RawMessage("[]"),
+		}).Asserts(ws, policy.ActionUpdate).Returns()
+	}))
 	s.Run("GetWorkspaceAgentByInstanceID", s.Subtest(func(db database.Store, check *expects) {
 		tpl := dbgen.Template(s.T(), db, database.Template{})
 		ws := dbgen.Workspace(s.T(), db, database.Workspace{
@@ -1562,6 +1695,18 @@ func (s *MethodTestSuite) TestWorkspace() {
 			WorkspaceAgentID: agt.ID,
 		}).Asserts(ws, policy.ActionUpdate).Returns()
 	}))
+	s.Run("InsertWorkspaceAgentMetadataHistory", s.Subtest(func(db database.Store, check *expects) {
+		tpl := dbgen.Template(s.T(), db, database.Template{})
+		ws := dbgen.Workspace(s.T(), db, database.Workspace{
+			TemplateID: tpl.ID,
+		})
+		build := dbgen.WorkspaceBuild(s.T(), db, database.WorkspaceBuild{WorkspaceID: ws.ID, JobID: uuid.New()})
+		res := dbgen.WorkspaceResource(s.T(), db, database.WorkspaceResource{JobID: build.JobID})
+		agt := dbgen.WorkspaceAgent(s.T(), db, database.WorkspaceAgent{ResourceID: res.ID})
+		check.Args(database.InsertWorkspaceAgentMetadataHistoryParams{
+			WorkspaceAgentID: agt.ID,
+		}).Asserts(ws, policy.ActionUpdate).Returns()
+	}))
 	s.Run("UpdateWorkspaceAgentLogOverflowByID", s.Subtest(func(db database.Store, check *expects) {
 		tpl := dbgen.Template(s.T(), db, database.Template{})
 		ws := dbgen.Workspace(s.T(), db, database.Workspace{
@@ -1705,6 +1850,17 @@ func (s *MethodTestSuite) TestWorkspace() {
 		job := dbgen.ProvisionerJob(s.T(), db, nil, database.ProvisionerJob{ID: build.JobID, Type: database.ProvisionerJobTypeWorkspaceBuild})
 		check.Args(job.ID).Asserts(ws, policy.ActionRead).Returns([]database.WorkspaceResource{})
 	}))
+	s.Run("WorkspaceSnapshot/GetWorkspaceResourcesByJobID", s.Subtest(func(db database.Store, check *expects) {
+		ws := dbgen.Workspace(s.T(), db, database.Workspace{})
+		build := dbgen.WorkspaceBuild(s.T(), db, database.WorkspaceBuild{WorkspaceID: ws.ID})
+		job := dbgen.ProvisionerJob(s.T(), db, nil, database.ProvisionerJob{
+			Type: database.ProvisionerJobTypeWorkspaceSnapshot,
+			Input: must(json.Marshal(struct {
+				WorkspaceBuildID uuid.UUID `json:"workspace_build_id"`
+			}{WorkspaceBuildID: build.ID})),
+		})
+		check.Args(job.ID).Asserts(ws, []policy.Action{policy.ActionRead, policy.ActionRead}).Returns([]database.WorkspaceResource{})
+	}))
 	s.Run("Template/GetWorkspaceResourcesByJobID", s.Subtest(func(db database.Store, check *expects) {
 		tpl := dbgen.Template(s.T(), db, database.Template{})
 		v := dbgen.TemplateVersion(s.T(), db, database.TemplateVersion{TemplateID: uuid.NullUUID{UUID: tpl.ID, Valid: true}, JobID: uuid.New()})
@@ -1915,6 +2071,11 @@ func (s *MethodTestSuite) TestWorkspace() {
 		ws := dbgen.Workspace(s.T(), db, database.Workspace{OwnerID: u.ID})
 		check.Args(ws.ID).Asserts(ws, policy.ActionUpdate).Returns()
 	}))
+	s.Run("LockWorkspaceByID", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		ws := dbgen.Workspace(s.T(), db, database.Workspace{OwnerID: u.ID})
+		check.Args(ws.ID).Asserts(ws, policy.ActionRead).Returns()
+	}))
 }
 
 func (s *MethodTestSuite) TestWorkspacePortSharing() {
@@ -1973,6 +2134,107 @@ func (s *MethodTestSuite) TestWorkspacePortSharing() {
 	}))
 }
 
+func (s *MethodTestSuite) TestWorkspaceSnapshots() {
+	s.Run("InsertWorkspaceSnapshot", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		ws := dbgen.Workspace(s.T(), db, database.Workspace{OwnerID: u.ID})
+		b := dbgen.WorkspaceBuild(s.T(), db, database.WorkspaceBuild{WorkspaceID: ws.ID})
+		check.Args(database.InsertWorkspaceSnapshotParams{
+			ID:               uuid.New(),
+			WorkspaceID:      ws.ID,
+			WorkspaceBuildID: b.ID,
+			JobID:            uuid.New(),
+			Name:             "snapshot",
+			CreatedBy:        u.ID,
+			CreatedAt:        dbtime.Now(),
+		}).Asserts(ws, policy.ActionUpdate)
+	}))
+	s.Run("GetWorkspaceSnapshotByID", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		ws := dbgen.Workspace(s.T(), db, database.Workspace{OwnerID: u.ID})
+		snapshot := dbgen.WorkspaceSnapshot(s.T(), db, database.WorkspaceSnapshot{WorkspaceID: ws.ID, CreatedBy: u.ID})
+		check.Args(snapshot.ID).Asserts(ws, policy.ActionRead).Returns(snapshot)
+	}))
+	s.Run("GetWorkspaceSnapshotsByWorkspaceID", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		ws := dbgen.Workspace(s.T(), db, database.Workspace{OwnerID: u.ID})
+		snapshot := dbgen.WorkspaceSnapshot(s.T(), db, database.WorkspaceSnapshot{WorkspaceID: ws.ID, CreatedBy: u.ID})
+		check.Args(ws.ID).Asserts(ws, policy.ActionRead).Returns([]database.WorkspaceSnapshot{snapshot})
+	}))
+	s.Run("GetActiveWorkspaceSnapshotJobsByWorkspaceID", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		ws := dbgen.Workspace(s.T(), db, database.Workspace{OwnerID: u.ID})
+		b := dbgen.WorkspaceBuild(s.T(), db, database.WorkspaceBuild{WorkspaceID: ws.ID})
+		j := dbgen.ProvisionerJob(s.T(), db, nil, database.ProvisionerJob{
+			Type: database.ProvisionerJobTypeWorkspaceSnapshot,
+			Input: must(json.Marshal(struct {
+				WorkspaceBuildID uuid.UUID `json:"workspace_build_id"`
+			}{WorkspaceBuildID: b.ID})),
+		})
+		check.Args(ws.ID).Asserts(ws, policy.ActionRead).Returns([]database.ProvisionerJob{j})
+	}))
+	s.Run("UpdateWorkspaceSnapshotDataByID", s.Subtest(func(db database.Store, check *expects) {
+		snapshot := dbgen.WorkspaceSnapshot(s.T(), db, database.WorkspaceSnapshot{})
+		check.Args(database.UpdateWorkspaceSnapshotDataByIDParams{
+			ID:   snapshot.ID,
+			Data: []byte("data"),
+		}).Asserts(rbac.ResourceSystem, policy.ActionUpdate).Returns()
+	}))
+	s.Run("DeleteWorkspaceSnapshotByID", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		ws := dbgen.Workspace(s.T(), db, database.Workspace{OwnerID: u.ID})
+		snapshot := dbgen.WorkspaceSnapshot(s.T(), db, database.WorkspaceSnapshot{WorkspaceID: ws.ID, CreatedBy: u.ID})
+		check.Args(snapshot.ID).Asserts(ws, policy.ActionUpdate).Returns()
+	}))
+}
+
+func (s *MethodTestSuite) TestWorkspaceAgentSessionRecordings() {
+	s.Run("InsertWorkspaceAgentSessionRecording", s.Subtest(func(db database.Store, check *expects) {
+		check.Args(database.InsertWorkspaceAgentSessionRecordingParams{
+			ID:          uuid.New(),
+			WorkspaceID: uuid.New(),
+			AgentID:     uuid.New(),
+			SessionType: "ssh",
+			StartedAt:   dbtime.Now(),
+		}).Asserts(rbac.ResourceSystem, policy.ActionCreate)
+	}))
+	s.Run("InsertWorkspaceAgentSessionRecordingChunk", s.Subtest(func(db database.Store, check *expects) {
+		recording := dbgen.WorkspaceAgentSessionRecording(s.T(), db, database.WorkspaceAgentSessionRecording{})
+		check.Args(database.InsertWorkspaceAgentSessionRecordingChunkParams{
+			RecordingID: recording.ID,
+			Sequence:    0,
+			Data:        []byte("{}"),
+		}).Asserts(rbac.ResourceSystem, policy.ActionCreate).Returns()
+	}))
+	s.Run("UpdateWorkspaceAgentSessionRecordingEndedAt", s.Subtest(func(db database.Store, check *expects) {
+		recording := dbgen.WorkspaceAgentSessionRecording(s.T(), db, database.WorkspaceAgentSessionRecording{})
+		check.Args(database.UpdateWorkspaceAgentSessionRecordingEndedAtParams{
+			ID:      recording.ID,
+			EndedAt: sql.NullTime{Time: dbtime.Now(), Valid: true},
+		}).Asserts(rbac.ResourceSystem, policy.ActionUpdate).Returns()
+	}))
+	s.Run("GetWorkspaceAgentSessionRecordingByID", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		ws := dbgen.Workspace(s.T(), db, database.Workspace{OwnerID: u.ID})
+		recording := dbgen.WorkspaceAgentSessionRecording(s.T(), db, database.WorkspaceAgentSessionRecording{WorkspaceID: ws.ID})
+		check.Args(recording.ID).Asserts(rbac.ResourceAuditLog.InOrg(ws.OrganizationID), policy.ActionRead).Returns(recording)
+	}))
+	s.Run("GetWorkspaceAgentSessionRecordingsByWorkspaceID", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		ws := dbgen.Workspace(s.T(), db, database.Workspace{OwnerID: u.ID})
+		recording := dbgen.WorkspaceAgentSessionRecording(s.T(), db, database.WorkspaceAgentSessionRecording{WorkspaceID: ws.ID})
+		check.Args(ws.ID).Asserts(rbac.ResourceAuditLog.InOrg(ws.OrganizationID), policy.ActionRead).Returns([]database.WorkspaceAgentSessionRecording{recording})
+	}))
+	s.Run("GetWorkspaceAgentSessionRecordingChunks", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		ws := dbgen.Workspace(s.T(), db, database.Workspace{OwnerID: u.ID})
+		recording := dbgen.WorkspaceAgentSessionRecording(s.T(), db, database.WorkspaceAgentSessionRecording{WorkspaceID: ws.ID})
+		chunk := database.WorkspaceAgentSessionRecordingChunk{RecordingID: recording.ID, Sequence: 0, Data: []byte("{}")}
+		require.NoError(s.T(), db.InsertWorkspaceAgentSessionRecordingChunk(context.Background(), database.InsertWorkspaceAgentSessionRecordingChunkParams(chunk)))
+		check.Args(recording.ID).Asserts(rbac.ResourceAuditLog.InOrg(ws.OrganizationID), policy.ActionRead).Returns([]database.WorkspaceAgentSessionRecordingChunk{chunk})
+	}))
+}
+
 func (s *MethodTestSuite) TestProvisionerKeys() {
 	s.Run("InsertProvisionerKey", s.Subtest(func(db database.Store, check *expects) {
 		org := dbgen.Organization(s.T(), db, database.Organization{})
@@ -2405,6 +2667,22 @@ func (s *MethodTestSuite) TestSystemFunctions() {
 			ProvisionerState: []byte("testing"),
 		}).Asserts(rbac.ResourceSystem, policy.ActionUpdate)
 	}))
+	s.Run("UpdateWorkspaceBuildProvisionerStateLocationByID", s.Subtest(func(db database.Store, check *expects) {
+		ws := dbgen.Workspace(s.T(), db, database.Workspace{})
+		build := dbgen.WorkspaceBuild(s.T(), db, database.WorkspaceBuild{WorkspaceID: ws.ID, JobID: uuid.New()})
+		check.Args(database.UpdateWorkspaceBuildProvisionerStateLocationByIDParams{
+			ID:                  build.ID,
+			ProvisionerStateRef: "ref",
+			UpdatedAt:           build.UpdatedAt,
+		}).Asserts(rbac.ResourceSystem, policy.ActionUpdate).Returns(int64(1))
+	}))
+	s.Run("GetWorkspaceBuildsToMigrateProvisionerState", s.Subtest(func(db database.Store, check *expects) {
+		ws := dbgen.Workspace(s.T(), db, database.Workspace{})
+		build := dbgen.WorkspaceBuild(s.T(), db, database.WorkspaceBuild{WorkspaceID: ws.ID, JobID: uuid.New(), ProvisionerState: []byte("state")})
+		check.Args(database.GetWorkspaceBuildsToMigrateProvisionerStateParams{
+			LimitCount: 10,
+		}).Asserts(rbac.ResourceSystem, policy.ActionRead).Returns([]database.WorkspaceBuild{build})
+	}))
 	s.Run("UpsertLastUpdateCheck", s.Subtest(func(db database.Store, check *expects) {
 		check.Args("value").Asserts(rbac.ResourceSystem, policy.ActionUpdate)
 	}))
@@ -2528,9 +2806,10 @@ func (s *MethodTestSuite) TestSystemFunctions() {
 	}))
 	s.Run("InsertWorkspaceApp", s.Subtest(func(db database.Store, check *expects) {
 		check.Args(database.InsertWorkspaceAppParams{
-			ID:           uuid.New(),
-			Health:       database.WorkspaceAppHealthDisabled,
-			SharingLevel: database.AppSharingLevelOwner,
+			ID:              uuid.New(),
+			Health:          database.WorkspaceAppHealthDisabled,
+			SharingLevel:    database.AppSharingLevelOwner,
+			HealthcheckKind: database.WorkspaceAppHealthcheckKindHttp,
 		}).Asserts(rbac.ResourceSystem, policy.ActionCreate)
 	}))
 	s.Run("InsertWorkspaceResourceMetadata", s.Subtest(func(db database.Store, check *expects) {
@@ -2586,6 +2865,15 @@ func (s *MethodTestSuite) TestSystemFunctions() {
 			JobID: j.ID,
 		}).Asserts( /*rbac.ResourceSystem, policy.ActionCreate*/ )
 	}))
+	s.Run("InsertProvisionerJobResourceChange", s.Subtest(func(db database.Store, check *expects) {
+		j := dbgen.ProvisionerJob(s.T(), db, nil, database.ProvisionerJob{})
+		check.Args(database.InsertProvisionerJobResourceChangeParams{
+			JobID:      j.ID,
+			Address:    "docker_volume.home",
+			Action:     database.ResourceChangeActionReplace,
+			Attributes: json.RawMessage("[]"),
+		}).Asserts(rbac.ResourceSystem, policy.ActionCreate)
+	}))
 	s.Run("InsertProvisionerJobTimings", s.Subtest(func(db database.Store, check *expects) {
 		// TODO: we need to create a ProvisionerJob resource
 		j := dbgen.ProvisionerJob(s.T(), db, nil, database.ProvisionerJob{})
@@ -2626,6 +2914,9 @@ func (s *MethodTestSuite) TestSystemFunctions() {
 	s.Run("DeleteOldWorkspaceAgentLogs", s.Subtest(func(db database.Store, check *expects) {
 		check.Args(time.Time{}).Asserts(rbac.ResourceSystem, policy.ActionDelete)
 	}))
+	s.Run("DeleteOldWorkspaceAgentMetadataHistory", s.Subtest(func(db database.Store, check *expects) {
+		check.Args(database.DeleteOldWorkspaceAgentMetadataHistoryParams{}).Asserts(rbac.ResourceSystem, policy.ActionDelete)
+	}))
 	s.Run("InsertWorkspaceAgentStats", s.Subtest(func(db database.Store, check *expects) {
 		check.Args(database.InsertWorkspaceAgentStatsParams{}).Asserts(rbac.ResourceSystem, policy.ActionCreate).Errors(errMatchAny)
 	}))
@@ -2700,6 +2991,9 @@ func (s *MethodTestSuite) TestSystemFunctions() {
 	s.Run("GetHungProvisionerJobs", s.Subtest(func(db database.Store, check *expects) {
 		check.Args(time.Time{}).Asserts()
 	}))
+	s.Run("GetPendingProvisionerJobIaCRequirements", s.Subtest(func(db database.Store, check *expects) {
+		check.Args(uuid.New()).Asserts()
+	}))
 	s.Run("UpsertOAuthSigningKey", s.Subtest(func(db database.Store, check *expects) {
 		check.Args("foo").Asserts(rbac.ResourceSystem, policy.ActionUpdate)
 	}))
@@ -2751,6 +3045,27 @@ func (s *MethodTestSuite) TestSystemFunctions() {
 	s.Run("GetWorkspacesEligibleForTransition", s.Subtest(func(db database.Store, check *expects) {
 		check.Args(time.Time{}).Asserts()
 	}))
+	s.Run("GetWorkspacesEligibleForDriftCheck", s.Subtest(func(db database.Store, check *expects) {
+		check.Args(time.Time{}).Asserts(rbac.ResourceSystem, policy.ActionRead)
+	}))
+	s.Run("GetInProgressWorkspaceDriftCheckCountsByTemplate", s.Subtest(func(db database.Store, check *expects) {
+		check.Args().Asserts(rbac.ResourceSystem, policy.ActionRead)
+	}))
+	s.Run("GetWorkspaceDriftedResourcesByWorkspaceIDs", s.Subtest(func(db database.Store, check *expects) {
+		check.Args([]uuid.UUID{uuid.New()}).Asserts(rbac.ResourceSystem, policy.ActionRead)
+	}))
+	s.Run("InsertWorkspaceDriftCheck", s.Subtest(func(db database.Store, check *expects) {
+		w := dbgen.Workspace(s.T(), db, database.Workspace{})
+		b := dbgen.WorkspaceBuild(s.T(), db, database.WorkspaceBuild{WorkspaceID: w.ID})
+		j := dbgen.ProvisionerJob(s.T(), db, nil, database.ProvisionerJob{Type: database.ProvisionerJobTypeWorkspaceDriftCheck})
+		check.Args(database.InsertWorkspaceDriftCheckParams{
+			ID:               uuid.New(),
+			WorkspaceID:      w.ID,
+			WorkspaceBuildID: b.ID,
+			JobID:            j.ID,
+			CreatedAt:        dbtime.Now(),
+		}).Asserts(rbac.ResourceSystem, policy.ActionCreate)
+	}))
 	s.Run("InsertTemplateVersionVariable", s.Subtest(func(db database.Store, check *expects) {
 		check.Args(database.InsertTemplateVersionVariableParams{}).Asserts(rbac.ResourceSystem, policy.ActionCreate)
 	}))
@@ -2766,6 +3081,12 @@ func (s *MethodTestSuite) TestSystemFunctions() {
 	s.Run("GetWorkspaceAgentScriptsByAgentIDs", s.Subtest(func(db database.Store, check *expects) {
 		check.Args([]uuid.UUID{uuid.New()}).Asserts(rbac.ResourceSystem, policy.ActionRead)
 	}))
+	s.Run("GetWorkspaceAgentScriptTimingsByScriptID", s.Subtest(func(db database.Store, check *expects) {
+		check.Args(database.GetWorkspaceAgentScriptTimingsByScriptIDParams{
+			ScriptID: uuid.New(),
+			Limit:    10,
+		}).Asserts(rbac.ResourceSystem, policy.ActionRead)
+	}))
 	s.Run("GetWorkspaceAgentLogSourcesByAgentIDs", s.Subtest(func(db database.Store, check *expects) {
 		check.Args([]uuid.UUID{uuid.New()}).Asserts(rbac.ResourceSystem, policy.ActionRead)
 	}))
@@ -2889,6 +3210,15 @@ func (s *MethodTestSuite) TestNotifications() {
 		u := dbgen.User(s.T(), db, database.User{})
 		check.Args(database.FetchNewMessageMetadataParams{UserID: u.ID}).Asserts(rbac.ResourceSystem, policy.ActionRead)
 	}))
+	s.Run("GetNotificationMessagesAwaitingDigest", s.Subtest(func(db database.Store, check *expects) {
+		check.Args().Asserts(rbac.ResourceSystem, policy.ActionRead)
+	}))
+	s.Run("MarkNotificationMessagesDigested", s.Subtest(func(db database.Store, check *expects) {
+		check.Args(database.MarkNotificationMessagesDigestedParams{}).Asserts(rbac.ResourceSystem, policy.ActionUpdate)
+	}))
+	s.Run("ReleaseNotificationMessagesFromDigest", s.Subtest(func(db database.Store, check *expects) {
+		check.Args([]uuid.UUID{}).Asserts(rbac.ResourceSystem, policy.ActionUpdate)
+	}))
 	s.Run("GetNotificationMessagesByStatus", s.Subtest(func(db database.Store, check *expects) {
 		// TODO: update this test once we have a specific role for notifications
 		check.Args(database.GetNotificationMessagesByStatusParams{
@@ -2930,8 +3260,148 @@ func (s *MethodTestSuite) TestNotifications() {
 			UserID:                  user.ID,
 			NotificationTemplateIds: []uuid.UUID{notifications.TemplateWorkspaceAutoUpdated, notifications.TemplateWorkspaceDeleted},
 			Disableds:               []bool{true, false},
+			Methods:                 []string{"", string(database.NotificationMethodWebhook)},
+		}).Asserts(rbac.ResourceNotificationPreference.WithOwner(user.ID.String()), policy.ActionUpdate)
+	}))
+
+	// Notification quiet hours
+	s.Run("GetUserNotificationQuietHours", s.Subtest(func(db database.Store, check *expects) {
+		user := dbgen.User(s.T(), db, database.User{})
+		qh, err := db.UpsertUserNotificationQuietHours(context.Background(), database.UpsertUserNotificationQuietHoursParams{
+			UserID:      user.ID,
+			StartMinute: 22 * 60,
+			EndMinute:   7 * 60,
+			Timezone:    "Europe/London",
+		})
+		require.NoError(s.T(), err)
+		check.Args(user.ID).
+			Asserts(rbac.ResourceNotificationPreference.WithOwner(user.ID.String()), policy.ActionRead).
+			Returns(qh)
+	}))
+	s.Run("UpsertUserNotificationQuietHours", s.Subtest(func(db database.Store, check *expects) {
+		user := dbgen.User(s.T(), db, database.User{})
+		check.Args(database.UpsertUserNotificationQuietHoursParams{
+			UserID:      user.ID,
+			StartMinute: 22 * 60,
+			EndMinute:   7 * 60,
+			Timezone:    "Europe/London",
 		}).Asserts(rbac.ResourceNotificationPreference.WithOwner(user.ID.String()), policy.ActionUpdate)
 	}))
+	s.Run("DeleteUserNotificationQuietHours", s.Subtest(func(db database.Store, check *expects) {
+		user := dbgen.User(s.T(), db, database.User{})
+		check.Args(user.ID).
+			Asserts(rbac.ResourceNotificationPreference.WithOwner(user.ID.String()), policy.ActionUpdate)
+	}))
+
+	// Inbox notifications
+	s.Run("InsertInboxNotification", s.Subtest(func(db database.Store, check *expects) {
+		user := dbgen.User(s.T(), db, database.User{})
+		check.Args(database.InsertInboxNotificationParams{
+			ID:         uuid.New(),
+			UserID:     user.ID,
+			TemplateID: notifications.TemplateWorkspaceDeleted,
+			Title:      "title",
+			Content:    "content",
+			Actions:    json.RawMessage("[]"),
+			CreatedAt:  dbtime.Now(),
+		}).Asserts(rbac.ResourceInboxNotification.WithOwner(user.ID.String()), policy.ActionCreate)
+	}))
+	s.Run("GetInboxNotificationByID", s.Subtest(func(db database.Store, check *expects) {
+		user := dbgen.User(s.T(), db, database.User{})
+		notif := dbgen.InboxNotification(s.T(), db, database.InboxNotification{UserID: user.ID, TemplateID: notifications.TemplateWorkspaceDeleted})
+		check.Args(notif.ID).Asserts(notif, policy.ActionRead).Returns(notif)
+	}))
+	s.Run("GetInboxNotificationsByUserID", s.Subtest(func(db database.Store, check *expects) {
+		user := dbgen.User(s.T(), db, database.User{})
+		notif := dbgen.InboxNotification(s.T(), db, database.InboxNotification{UserID: user.ID, TemplateID: notifications.TemplateWorkspaceDeleted})
+		check.Args(database.GetInboxNotificationsByUserIDParams{UserID: user.ID}).
+			Asserts(rbac.ResourceInboxNotification.WithOwner(user.ID.String()), policy.ActionRead).
+			Returns([]database.InboxNotification{notif})
+	}))
+	s.Run("CountUnreadInboxNotificationsByUserID", s.Subtest(func(db database.Store, check *expects) {
+		user := dbgen.User(s.T(), db, database.User{})
+		_ = dbgen.InboxNotification(s.T(), db, database.InboxNotification{UserID: user.ID, TemplateID: notifications.TemplateWorkspaceDeleted})
+		check.Args(user.ID).
+			Asserts(rbac.ResourceInboxNotification.WithOwner(user.ID.String()), policy.ActionRead).
+			Returns(int64(1))
+	}))
+	s.Run("UpdateInboxNotificationStatus", s.Subtest(func(db database.Store, check *expects) {
+		user := dbgen.User(s.T(), db, database.User{})
+		notif := dbgen.InboxNotification(s.T(), db, database.InboxNotification{UserID: user.ID, TemplateID: notifications.TemplateWorkspaceDeleted})
+		check.Args(database.UpdateInboxNotificationStatusParams{
+			ID:     notif.ID,
+			ReadAt: sql.NullTime{Time: dbtime.Now(), Valid: true},
+		}).Asserts(notif, policy.ActionUpdate)
+	}))
+	s.Run("MarkAllInboxNotificationsAsRead", s.Subtest(func(db database.Store, check *expects) {
+		user := dbgen.User(s.T(), db, database.User{})
+		check.Args(database.MarkAllInboxNotificationsAsReadParams{
+			UserID: user.ID,
+			ReadAt: sql.NullTime{Time: dbtime.Now(), Valid: true},
+		}).Asserts(rbac.ResourceInboxNotification.WithOwner(user.ID.String()), policy.ActionUpdate)
+	}))
+}
+
+func (s *MethodTestSuite) TestSavedSearches() {
+	s.Run("InsertSavedSearch", s.Subtest(func(db database.Store, check *expects) {
+		user := dbgen.User(s.T(), db, database.User{})
+		arg := database.InsertSavedSearchParams{
+			ID:           uuid.New(),
+			UserID:       user.ID,
+			ResourceType: database.SavedSearchResourceTypeWorkspace,
+			Name:         "stale",
+			Query:        "dormant:true",
+			CreatedAt:    dbtime.Now(),
+			UpdatedAt:    dbtime.Now(),
+		}
+		check.Args(arg).Asserts(rbac.ResourceSavedSearch.WithID(arg.ID).WithOwner(user.ID.String()), policy.ActionCreate)
+	}))
+	s.Run("Shared/InsertSavedSearch", s.Subtest(func(db database.Store, check *expects) {
+		user := dbgen.User(s.T(), db, database.User{})
+		org := dbgen.Organization(s.T(), db, database.Organization{})
+		arg := database.InsertSavedSearchParams{
+			ID:             uuid.New(),
+			UserID:         user.ID,
+			ResourceType:   database.SavedSearchResourceTypeTemplate,
+			Name:           "deprecated",
+			Query:          "deprecated:true",
+			OrganizationID: uuid.NullUUID{UUID: org.ID, Valid: true},
+			CreatedAt:      dbtime.Now(),
+			UpdatedAt:      dbtime.Now(),
+		}
+		check.Args(arg).Asserts(database.SavedSearch{
+			ID:             arg.ID,
+			UserID:         arg.UserID,
+			OrganizationID: arg.OrganizationID,
+		}, policy.ActionCreate)
+	}))
+	s.Run("GetSavedSearchByID", s.Subtest(func(db database.Store, check *expects) {
+		user := dbgen.User(s.T(), db, database.User{})
+		search := dbgen.SavedSearch(s.T(), db, database.SavedSearch{UserID: user.ID})
+		check.Args(search.ID).Asserts(search, policy.ActionRead).Returns(search)
+	}))
+	s.Run("GetSavedSearches", s.Subtest(func(db database.Store, check *expects) {
+		user := dbgen.User(s.T(), db, database.User{})
+		search := dbgen.SavedSearch(s.T(), db, database.SavedSearch{UserID: user.ID})
+		check.Args(database.GetSavedSearchesParams{UserID: user.ID}).
+			Asserts(search, policy.ActionRead).
+			Returns([]database.SavedSearch{search})
+	}))
+	s.Run("UpdateSavedSearch", s.Subtest(func(db database.Store, check *expects) {
+		user := dbgen.User(s.T(), db, database.User{})
+		search := dbgen.SavedSearch(s.T(), db, database.SavedSearch{UserID: user.ID})
+		check.Args(database.UpdateSavedSearchParams{
+			ID:        search.ID,
+			Name:      "renamed",
+			Query:     search.Query,
+			UpdatedAt: dbtime.Now(),
+		}).Asserts(search, policy.ActionUpdate)
+	}))
+	s.Run("DeleteSavedSearchByID", s.Subtest(func(db database.Store, check *expects) {
+		user := dbgen.User(s.T(), db, database.User{})
+		search := dbgen.SavedSearch(s.T(), db, database.SavedSearch{UserID: user.ID})
+		check.Args(search.ID).Asserts(search, policy.ActionDelete)
+	}))
 }
 
 func (s *MethodTestSuite) TestOAuth2ProviderApps() {
@@ -228,19 +228,20 @@ func WorkspaceBuild(t testing.TB, db database.Store, orig database.WorkspaceBuil
 	var build database.WorkspaceBuild
 	err := db.InTx(func(db database.Store) error {
 		err := db.InsertWorkspaceBuild(genCtx, database.InsertWorkspaceBuildParams{
-			ID:                buildID,
-			CreatedAt:         takeFirst(orig.CreatedAt, dbtime.Now()),
-			UpdatedAt:         takeFirst(orig.UpdatedAt, dbtime.Now()),
-			WorkspaceID:       takeFirst(orig.WorkspaceID, uuid.New()),
-			TemplateVersionID: takeFirst(orig.TemplateVersionID, uuid.New()),
-			BuildNumber:       takeFirst(orig.BuildNumber, 1),
-			Transition:        takeFirst(orig.Transition, database.WorkspaceTransitionStart),
-			InitiatorID:       takeFirst(orig.InitiatorID, uuid.New()),
-			JobID:             takeFirst(orig.JobID, uuid.New()),
-			ProvisionerState:  takeFirstSlice(orig.ProvisionerState, []byte{}),
-			Deadline:          takeFirst(orig.Deadline, dbtime.Now().Add(time.Hour)),
-			MaxDeadline:       takeFirst(orig.MaxDeadline, time.Time{}),
-			Reason:            takeFirst(orig.Reason, database.BuildReasonInitiator),
+			ID:                  buildID,
+			CreatedAt:           takeFirst(orig.CreatedAt, dbtime.Now()),
+			UpdatedAt:           takeFirst(orig.UpdatedAt, dbtime.Now()),
+			WorkspaceID:         takeFirst(orig.WorkspaceID, uuid.New()),
+			TemplateVersionID:   takeFirst(orig.TemplateVersionID, uuid.New()),
+			BuildNumber:         takeFirst(orig.BuildNumber, 1),
+			Transition:          takeFirst(orig.Transition, database.WorkspaceTransitionStart),
+			InitiatorID:         takeFirst(orig.InitiatorID, uuid.New()),
+			JobID:               takeFirst(orig.JobID, uuid.New()),
+			ProvisionerState:    takeFirstSlice(orig.ProvisionerState, []byte{}),
+			Deadline:            takeFirst(orig.Deadline, dbtime.Now().Add(time.Hour)),
+			MaxDeadline:         takeFirst(orig.MaxDeadline, time.Time{}),
+			Reason:              takeFirst(orig.Reason, database.BuildReasonInitiator),
+			ProvisionerStateRef: orig.ProvisionerStateRef,
 		})
 		if err != nil {
 			return err
@@ -541,15 +542,22 @@ func WorkspaceApp(t testing.TB, db database.Store, orig database.WorkspaceApp) d
 			String: takeFirst(orig.Url.String),
 			Valid:  orig.Url.Valid,
 		},
-		External:             orig.External,
-		Subdomain:            orig.Subdomain,
-		SharingLevel:         takeFirst(orig.SharingLevel, database.AppSharingLevelOwner),
-		HealthcheckUrl:       takeFirst(orig.HealthcheckUrl, "https://localhost:8000"),
-		HealthcheckInterval:  takeFirst(orig.HealthcheckInterval, 60),
-		HealthcheckThreshold: takeFirst(orig.HealthcheckThreshold, 60),
-		Health:               takeFirst(orig.Health, database.WorkspaceAppHealthHealthy),
-		DisplayOrder:         takeFirst(orig.DisplayOrder, 1),
-		Hidden:               orig.Hidden,
+		External:                  orig.External,
+		Subdomain:                 orig.Subdomain,
+		SharingLevel:              takeFirst(orig.SharingLevel, database.AppSharingLevelOwner),
+		HealthcheckUrl:            takeFirst(orig.HealthcheckUrl, "https://localhost:8000"),
+		HealthcheckInterval:       takeFirst(orig.HealthcheckInterval, 60),
+		HealthcheckThreshold:      takeFirst(orig.HealthcheckThreshold, 60),
+		Health:                    takeFirst(orig.Health, database.WorkspaceAppHealthHealthy),
+		DisplayOrder:              takeFirst(orig.DisplayOrder, 1),
+		Hidden:                    orig.Hidden,
+		HealthcheckKind:           takeFirst(orig.HealthcheckKind, database.WorkspaceAppHealthcheckKindHttp),
+		HealthcheckExpectedStatus: orig.HealthcheckExpectedStatus,
+		HealthcheckBodyRegex:      orig.HealthcheckBodyRegex,
+		HealthcheckAddress:        orig.HealthcheckAddress,
+		HealthcheckGrpcService:    orig.HealthcheckGrpcService,
+		HealthcheckCommand:        orig.HealthcheckCommand,
+		HealthcheckPath:           orig.HealthcheckPath,
 	})
 	require.NoError(t, err, "insert app")
 	return resource
@@ -935,6 +943,77 @@ func CryptoKey(t testing.TB, db database.Store, seed database.CryptoKey) databas
 	return key
 }
 
+// InboxNotification inserts an inbox notification. The seed's TemplateID must refer to an existing notification
+// template.
+func InboxNotification(t testing.TB, db database.Store, seed database.InboxNotification) database.InboxNotification {
+	t.Helper()
+
+	id := takeFirst(seed.ID, uuid.New())
+	_, err := db.InsertInboxNotification(genCtx, database.InsertInboxNotificationParams{
+		ID:         id,
+		UserID:     takeFirst(seed.UserID, uuid.New()),
+		TemplateID: seed.TemplateID,
+		Title:      takeFirst(seed.Title, testutil.GetRandomName(t)),
+		Content:    takeFirst(seed.Content, testutil.GetRandomName(t)),
+		Actions:    takeFirstSlice(seed.Actions, json.RawMessage("[]")),
+		CreatedAt:  takeFirst(seed.CreatedAt, dbtime.Now()),
+	})
+	require.NoError(t, err, "insert inbox notification")
+
+	notif, err := db.GetInboxNotificationByID(genCtx, id)
+	require.NoError(t, err, "get inbox notification")
+	return notif
+}
+
+func SavedSearch(t testing.TB, db database.Store, seed database.SavedSearch) database.SavedSearch {
+	t.Helper()
+
+	search, err := db.InsertSavedSearch(genCtx, database.InsertSavedSearchParams{
+		ID:             takeFirst(seed.ID, uuid.New()),
+		UserID:         takeFirst(seed.UserID, uuid.New()),
+		ResourceType:   takeFirst(seed.ResourceType, database.SavedSearchResourceTypeWorkspace),
+		Name:           takeFirst(seed.Name, testutil.GetRandomName(t)),
+		Query:          takeFirst(seed.Query, "owner:me"),
+		OrganizationID: seed.OrganizationID,
+		GroupID:        seed.GroupID,
+		CreatedAt:      takeFirst(seed.CreatedAt, dbtime.Now()),
+		UpdatedAt:      takeFirst(seed.UpdatedAt, dbtime.Now()),
+	})
+	require.NoError(t, err, "insert saved search")
+	return search
+}
+
+func WorkspaceSnapshot(t testing.TB, db database.Store, seed database.WorkspaceSnapshot) database.WorkspaceSnapshot {
+	t.Helper()
+
+	snapshot, err := db.InsertWorkspaceSnapshot(genCtx, database.InsertWorkspaceSnapshotParams{
+		ID:               takeFirst(seed.ID, uuid.New()),
+		WorkspaceID:      takeFirst(seed.WorkspaceID, uuid.New()),
+		WorkspaceBuildID: takeFirst(seed.WorkspaceBuildID, uuid.New()),
+		JobID:            takeFirst(seed.JobID, uuid.New()),
+		Name:             takeFirst(seed.Name, testutil.GetRandomName(t)),
+		CreatedBy:        takeFirst(seed.CreatedBy, uuid.New()),
+		CreatedAt:        takeFirst(seed.CreatedAt, dbtime.Now()),
+	})
+	require.NoError(t, err, "insert workspace snapshot")
+	return snapshot
+}
+
+func WorkspaceAgentSessionRecording(t testing.TB, db database.Store, seed database.WorkspaceAgentSessionRecording) database.WorkspaceAgentSessionRecording {
+	t.Helper()
+
+	recording, err := db.InsertWorkspaceAgentSessionRecording(genCtx, database.InsertWorkspaceAgentSessionRecordingParams{
+		ID:          takeFirst(seed.ID, uuid.New()),
+		WorkspaceID: takeFirst(seed.WorkspaceID, uuid.New()),
+		AgentID:     takeFirst(seed.AgentID, uuid.New()),
+		SessionType: takeFirst(seed.SessionType, "ssh"),
+		Command:     seed.Command,
+		StartedAt:   takeFirst(seed.StartedAt, dbtime.Now()),
+	})
+	require.NoError(t, err, "insert workspace agent session recording")
+	return recording
+}
+
 func ProvisionerJobTimings(t testing.TB, db database.Store, seed database.InsertProvisionerJobTimingsParams) []database.ProvisionerJobTiming {
 	timings, err := db.InsertProvisionerJobTimings(genCtx, seed)
 	require.NoError(t, err, "insert provisioner job timings")
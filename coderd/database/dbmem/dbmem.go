@@ -188,55 +188,65 @@ type data struct {
 	userLinks           []database.UserLink
 
 	// New tables
-	auditLogs                       []database.AuditLog
-	cryptoKeys                      []database.CryptoKey
-	dbcryptKeys                     []database.DBCryptKey
-	files                           []database.File
-	externalAuthLinks               []database.ExternalAuthLink
-	gitSSHKey                       []database.GitSSHKey
-	groupMembers                    []database.GroupMemberTable
-	groups                          []database.Group
-	jfrogXRayScans                  []database.JfrogXrayScan
-	licenses                        []database.License
-	notificationMessages            []database.NotificationMessage
-	notificationPreferences         []database.NotificationPreference
-	notificationReportGeneratorLogs []database.NotificationReportGeneratorLog
-	oauth2ProviderApps              []database.OAuth2ProviderApp
-	oauth2ProviderAppSecrets        []database.OAuth2ProviderAppSecret
-	oauth2ProviderAppCodes          []database.OAuth2ProviderAppCode
-	oauth2ProviderAppTokens         []database.OAuth2ProviderAppToken
-	parameterSchemas                []database.ParameterSchema
-	provisionerDaemons              []database.ProvisionerDaemon
-	provisionerJobLogs              []database.ProvisionerJobLog
-	provisionerJobs                 []database.ProvisionerJob
-	provisionerKeys                 []database.ProvisionerKey
-	replicas                        []database.Replica
-	templateVersions                []database.TemplateVersionTable
-	templateVersionParameters       []database.TemplateVersionParameter
-	templateVersionVariables        []database.TemplateVersionVariable
-	templateVersionWorkspaceTags    []database.TemplateVersionWorkspaceTag
-	templates                       []database.TemplateTable
-	templateUsageStats              []database.TemplateUsageStat
-	workspaceAgents                 []database.WorkspaceAgent
-	workspaceAgentMetadata          []database.WorkspaceAgentMetadatum
-	workspaceAgentLogs              []database.WorkspaceAgentLog
-	workspaceAgentLogSources        []database.WorkspaceAgentLogSource
-	workspaceAgentPortShares        []database.WorkspaceAgentPortShare
-	workspaceAgentScriptTimings     []database.WorkspaceAgentScriptTiming
-	workspaceAgentScripts           []database.WorkspaceAgentScript
-	workspaceAgentStats             []database.WorkspaceAgentStat
-	workspaceApps                   []database.WorkspaceApp
-	workspaceAppStatsLastInsertID   int64
-	workspaceAppStats               []database.WorkspaceAppStat
-	workspaceBuilds                 []database.WorkspaceBuild
-	workspaceBuildParameters        []database.WorkspaceBuildParameter
-	workspaceResourceMetadata       []database.WorkspaceResourceMetadatum
-	workspaceResources              []database.WorkspaceResource
-	workspaces                      []database.Workspace
-	workspaceProxies                []database.WorkspaceProxy
-	customRoles                     []database.CustomRole
-	provisionerJobTimings           []database.ProvisionerJobTiming
-	runtimeConfig                   map[string]string
+	auditLogs                            []database.AuditLog
+	cryptoKeys                           []database.CryptoKey
+	dbcryptKeys                          []database.DBCryptKey
+	files                                []database.File
+	externalAuthLinks                    []database.ExternalAuthLink
+	gitSSHKey                            []database.GitSSHKey
+	groupMembers                         []database.GroupMemberTable
+	groups                               []database.Group
+	inboxNotifications                   []database.InboxNotification
+	jfrogXRayScans                       []database.JfrogXrayScan
+	licenses                             []database.License
+	notificationMessages                 []database.NotificationMessage
+	notificationPreferences              []database.NotificationPreference
+	notificationQuietHours               []database.NotificationQuietHour
+	notificationReportGeneratorLogs      []database.NotificationReportGeneratorLog
+	oauth2ProviderApps                   []database.OAuth2ProviderApp
+	oauth2ProviderAppSecrets             []database.OAuth2ProviderAppSecret
+	oauth2ProviderAppCodes               []database.OAuth2ProviderAppCode
+	oauth2ProviderAppTokens              []database.OAuth2ProviderAppToken
+	parameterSchemas                     []database.ParameterSchema
+	provisionerDaemons                   []database.ProvisionerDaemon
+	provisionerJobLogs                   []database.ProvisionerJobLog
+	provisionerJobs                      []database.ProvisionerJob
+	provisionerKeys                      []database.ProvisionerKey
+	replicas                             []database.Replica
+	savedSearches                        []database.SavedSearch
+	templateVersions                     []database.TemplateVersionTable
+	templateVersionParameters            []database.TemplateVersionParameter
+	templateVersionVariables             []database.TemplateVersionVariable
+	templateVersionWorkspaceTags         []database.TemplateVersionWorkspaceTag
+	templates                            []database.TemplateTable
+	templateUsageStats                   []database.TemplateUsageStat
+	workspaceAgents                      []database.WorkspaceAgent
+	workspaceAgentMetadata               []database.WorkspaceAgentMetadatum
+	workspaceAgentMetadataHistory        []database.WorkspaceAgentMetadataHistory
+	workspaceAgentLogs                   []database.WorkspaceAgentLog
+	workspaceAgentContainers             []database.WorkspaceAgentContainer
+	workspaceAgentLogSources             []database.WorkspaceAgentLogSource
+	workspaceAgentPortShares             []database.WorkspaceAgentPortShare
+	workspaceAgentScriptTimings          []database.WorkspaceAgentScriptTiming
+	workspaceAgentScripts                []database.WorkspaceAgentScript
+	workspaceAgentSessionRecordings      []database.WorkspaceAgentSessionRecording
+	workspaceAgentSessionRecordingChunks []database.WorkspaceAgentSessionRecordingChunk
+	workspaceAgentStats                  []database.WorkspaceAgentStat
+	workspaceApps                        []database.WorkspaceApp
+	workspaceAppStatsLastInsertID        int64
+	workspaceAppStats                    []database.WorkspaceAppStat
+	workspaceBuilds                      []database.WorkspaceBuild
+	workspaceBuildParameters             []database.WorkspaceBuildParameter
+	workspaceDriftChecks                 []database.WorkspaceDriftCheck
+	workspaceResourceMetadata            []database.WorkspaceResourceMetadatum
+	workspaceResources                   []database.WorkspaceResource
+	workspaceSnapshots                   []database.WorkspaceSnapshot
+	workspaces                           []database.Workspace
+	workspaceProxies                     []database.WorkspaceProxy
+	customRoles                          []database.CustomRole
+	provisionerJobResourceChanges        []database.ProvisionerJobResourceChange
+	provisionerJobTimings                []database.ProvisionerJobTiming
+	runtimeConfig                        map[string]string
 	// Locks is a map of lock names. Any keys within the map are currently
 	// locked.
 	locks                            map[int64]struct{}
@@ -445,6 +455,154 @@ func mapAgentStatus(dbAgent database.WorkspaceAgent, agentInactiveDisconnectTime
 	return status
 }
 
+// workspaceStatusMatches returns whether the latest build of a workspace is in
+// the given workspace status. This logic should match the logic in the
+// workspace.sql file.
+func workspaceStatusMatches(build database.WorkspaceBuild, job database.ProvisionerJob, status string) bool {
+	switch database.WorkspaceStatus(status) {
+	case database.WorkspaceStatusStarting:
+		return job.JobStatus == database.ProvisionerJobStatusRunning &&
+			build.Transition == database.WorkspaceTransitionStart
+	case database.WorkspaceStatusStopping:
+		return job.JobStatus == database.ProvisionerJobStatusRunning &&
+			build.Transition == database.WorkspaceTransitionStop
+	case database.WorkspaceStatusDeleting:
+		return job.JobStatus == database.ProvisionerJobStatusRunning &&
+			build.Transition == database.WorkspaceTransitionDelete
+
+	case "started":
+		return job.JobStatus == database.ProvisionerJobStatusSucceeded &&
+			build.Transition == database.WorkspaceTransitionStart
+	case database.WorkspaceStatusDeleted:
+		return job.JobStatus == database.ProvisionerJobStatusSucceeded &&
+			build.Transition == database.WorkspaceTransitionDelete
+	case database.WorkspaceStatusStopped:
+		return job.JobStatus == database.ProvisionerJobStatusSucceeded &&
+			build.Transition == database.WorkspaceTransitionStop
+	case database.WorkspaceStatusRunning:
+		return job.JobStatus == database.ProvisionerJobStatusSucceeded &&
+			build.Transition == database.WorkspaceTransitionStart
+	default:
+		return job.JobStatus == database.ProvisionerJobStatus(status)
+	}
+}
+
+// hasAgentStatusNoLock returns whether any agent created by the job has the
+// given status.
+func (q *FakeQuerier) hasAgentStatusNoLock(ctx context.Context, jobID uuid.UUID, status string, agentInactiveDisconnectTimeoutSeconds int64) (bool, error) {
+	workspaceResources, err := q.getWorkspaceResourcesByJobIDNoLock(ctx, jobID)
+	if err != nil {
+		return false, xerrors.Errorf("get workspace resources: %w", err)
+	}
+
+	var workspaceResourceIDs []uuid.UUID
+	for _, wr := range workspaceResources {
+		workspaceResourceIDs = append(workspaceResourceIDs, wr.ID)
+	}
+
+	workspaceAgents, err := q.getWorkspaceAgentsByResourceIDsNoLock(ctx, workspaceResourceIDs)
+	if err != nil {
+		return false, xerrors.Errorf("get workspace agents: %w", err)
+	}
+
+	for _, wa := range workspaceAgents {
+		if mapAgentStatus(wa, agentInactiveDisconnectTimeoutSeconds) == status {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// workspaceMatchesTermsNoLock returns whether the workspace matches the search
+// terms in arg. Terms in the same group are ORed together, and every group
+// must match. This logic should match the logic in the workspace.sql file.
+func (q *FakeQuerier) workspaceMatchesTermsNoLock(ctx context.Context, workspace database.Workspace, arg database.GetWorkspacesParams) (bool, error) {
+	build, err := q.getLatestWorkspaceBuildByWorkspaceIDNoLock(ctx, workspace.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return false, xerrors.Errorf("get latest build: %w", err)
+	}
+	hasBuild := err == nil
+	var job database.ProvisionerJob
+	if hasBuild {
+		job, err = q.getProvisionerJobByIDNoLock(ctx, build.JobID)
+		if err != nil {
+			return false, xerrors.Errorf("get provisioner job: %w", err)
+		}
+	}
+
+	matchTerm := func(key, value string) (bool, error) {
+		switch key {
+		case "owner":
+			owner, err := q.getUserByIDNoLock(workspace.OwnerID)
+			return err == nil && strings.EqualFold(owner.Username, value), nil
+		case "template":
+			template, err := q.getTemplateByIDNoLock(ctx, workspace.TemplateID)
+			return err == nil && strings.EqualFold(template.Name, value), nil
+		case "name":
+			return strings.Contains(strings.ToLower(workspace.Name), strings.ToLower(value)), nil
+		}
+		if !hasBuild {
+			return false, nil
+		}
+		switch key {
+		case "template_version":
+			version, err := q.getTemplateVersionByIDNoLock(ctx, build.TemplateVersionID)
+			return err == nil && strings.EqualFold(version.Name, value), nil
+		case "template_version_lt", "template_version_lte", "template_version_gt", "template_version_gte":
+			version, err := q.getTemplateVersionByIDNoLock(ctx, build.TemplateVersionID)
+			if err != nil {
+				return false, nil
+			}
+			for _, ref := range q.templateVersions {
+				if ref.TemplateID.UUID != workspace.TemplateID || !strings.EqualFold(ref.Name, value) {
+					continue
+				}
+				c := version.CreatedAt.Compare(ref.CreatedAt)
+				switch key {
+				case "template_version_lt":
+					return c < 0, nil
+				case "template_version_lte":
+					return c <= 0, nil
+				case "template_version_gt":
+					return c > 0, nil
+				default:
+					return c >= 0, nil
+				}
+			}
+			return false, nil
+		case "initiator":
+			initiator, err := q.getUserByIDNoLock(build.InitiatorID)
+			return err == nil && !initiator.Deleted && strings.EqualFold(initiator.Username, value), nil
+		case "build_reason":
+			return string(build.Reason) == value, nil
+		case "status":
+			return workspaceStatusMatches(build, job, value), nil
+		case "agent_status":
+			if build.Transition != database.WorkspaceTransitionStart {
+				return false, nil
+			}
+			return q.hasAgentStatusNoLock(ctx, job.ID, value, arg.AgentInactiveDisconnectTimeoutSeconds)
+		default:
+			return false, nil
+		}
+	}
+
+	groups := make(map[int32]bool)
+	for i, group := range arg.TermGroups {
+		match, err := matchTerm(arg.TermKeys[i], arg.TermValues[i])
+		if err != nil {
+			return false, err
+		}
+		groups[group] = groups[group] || match != arg.TermNegated[i]
+	}
+	for _, match := range groups {
+		if !match {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
 func (q *FakeQuerier) convertToWorkspaceRowsNoLock(ctx context.Context, workspaces []database.Workspace, count int64, withSummary bool) []database.GetWorkspacesRow { //nolint:revive // withSummary flag ensures the extra technical row
 	rows := make([]database.GetWorkspacesRow, 0, len(workspaces))
 	for _, w := range workspaces {
@@ -1073,6 +1231,12 @@ func (q *FakeQuerier) AcquireNotificationMessages(_ context.Context, arg databas
 
 	var out []database.AcquireNotificationMessagesRow
 	for _, nm := range list {
+		// Messages held back by quiet hours, or awaiting a digest, remain queued until they are released.
+		if nm.DigestTemplateID.Valid || (nm.HeldUntil.Valid && nm.HeldUntil.Time.After(dbtime.Now())) {
+			q.notificationMessages = append(q.notificationMessages, nm)
+			continue
+		}
+
 		acquirableStatuses := []database.NotificationMessageStatus{database.NotificationMessageStatusPending, database.NotificationMessageStatusTemporaryFailure}
 		if !slices.Contains(acquirableStatuses, nm.Status) {
 			continue
@@ -1105,7 +1269,27 @@ func (q *FakeQuerier) AcquireProvisionerJob(_ context.Context, arg database.Acqu
 	q.mutex.Lock()
 	defer q.mutex.Unlock()
 
-	for index, provisionerJob := range q.provisionerJobs {
+	// Drift checks are low priority, so they are only acquired once there
+	// are no other jobs to acquire.
+	indexes := make([]int, 0, len(q.provisionerJobs))
+	for index := range q.provisionerJobs {
+		indexes = append(indexes, index)
+	}
+	slices.SortStableFunc(indexes, func(a, b int) int {
+		aDriftCheck := q.provisionerJobs[a].Type == database.ProvisionerJobTypeWorkspaceDriftCheck
+		bDriftCheck := q.provisionerJobs[b].Type == database.ProvisionerJobTypeWorkspaceDriftCheck
+		switch {
+		case aDriftCheck == bDriftCheck:
+			return 0
+		case bDriftCheck:
+			return -1
+		default:
+			return 1
+		}
+	})
+
+	for _, index := range indexes {
+		provisionerJob := q.provisionerJobs[index]
 		if provisionerJob.OrganizationID != arg.OrganizationID {
 			continue
 		}
@@ -1131,15 +1315,23 @@ func (q *FakeQuerier) AcquireProvisionerJob(_ context.Context, arg database.Acqu
 			}
 		}
 
+		// The IaC requirement tags of the job are matched separately.
+		jobTags := maps.Clone(provisionerJob.Tags)
+		delete(jobTags, provisionersdk.TagIaCEngine)
+		delete(jobTags, provisionersdk.TagIaCVersion)
+
 		// Special case for untagged provisioners: only match untagged jobs.
 		// Ref: coderd/database/queries/provisionerjobs.sql:24-30
 		// CASE WHEN nested.tags :: jsonb = '{"scope": "organization", "owner": ""}' :: jsonb
 		//      THEN nested.tags :: jsonb = @tags :: jsonb
-		if tagsEqual(provisionerJob.Tags, tagsUntagged) && !tagsEqual(provisionerJob.Tags, tags) {
+		if tagsEqual(jobTags, tagsUntagged) && !tagsEqual(jobTags, tags) {
 			continue
 		}
 		// ELSE nested.tags :: jsonb <@ @tags :: jsonb
-		if !tagsSubset(provisionerJob.Tags, tags) {
+		if !tagsSubset(jobTags, tags) {
+			continue
+		}
+		if requirement, ok := provisionersdk.IaCRequirementFromTags(provisionerJob.Tags); ok && !slices.Contains(arg.IacRequirements, requirement.String()) {
 			continue
 		}
 		provisionerJob.StartedAt = arg.StartedAt
@@ -1387,6 +1579,19 @@ func (*FakeQuerier) CleanTailnetTunnels(context.Context) error {
 	return ErrUnimplemented
 }
 
+func (q *FakeQuerier) CountUnreadInboxNotificationsByUserID(_ context.Context, userID uuid.UUID) (int64, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	var count int64
+	for _, n := range q.inboxNotifications {
+		if n.UserID == userID && !n.ReadAt.Valid && !n.ArchivedAt.Valid {
+			count++
+		}
+	}
+	return count, nil
+}
+
 func (q *FakeQuerier) CustomRoles(_ context.Context, arg database.CustomRolesParams) ([]database.CustomRole, error) {
 	q.mutex.Lock()
 	defer q.mutex.Unlock()
@@ -1488,6 +1693,16 @@ func (*FakeQuerier) DeleteCoordinator(context.Context, uuid.UUID) error {
 	return ErrUnimplemented
 }
 
+func (q *FakeQuerier) DeleteAuditLogsByIDs(_ context.Context, ids []uuid.UUID) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.auditLogs = slices.DeleteFunc(q.auditLogs, func(alog database.AuditLog) bool {
+		return slices.Contains(ids, alog.ID)
+	})
+	return nil
+}
+
 func (q *FakeQuerier) DeleteCryptoKey(_ context.Context, arg database.DeleteCryptoKeyParams) (database.CryptoKey, error) {
 	err := validateDatabaseType(arg)
 	if err != nil {
@@ -1876,6 +2091,40 @@ func (q *FakeQuerier) DeleteOldWorkspaceAgentLogs(_ context.Context, threshold t
 	return nil
 }
 
+func (q *FakeQuerier) DeleteOldWorkspaceAgentMetadataHistory(_ context.Context, arg database.DeleteOldWorkspaceAgentMetadataHistoryParams) error {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	type agentKey struct {
+		agentID uuid.UUID
+		key     string
+	}
+	// Count the newest samples first, so the oldest are beyond the limit.
+	slices.SortFunc(q.workspaceAgentMetadataHistory, func(a, b database.WorkspaceAgentMetadataHistory) int {
+		return b.CollectedAt.Compare(a.CollectedAt)
+	})
+	counts := make(map[agentKey]int32)
+	kept := make([]database.WorkspaceAgentMetadataHistory, 0, len(q.workspaceAgentMetadataHistory))
+	for _, sample := range q.workspaceAgentMetadataHistory {
+		if sample.CollectedAt.Before(arg.CollectedBefore) {
+			continue
+		}
+		k := agentKey{agentID: sample.WorkspaceAgentID, key: sample.Key}
+		counts[k]++
+		if counts[k] > arg.MaxSamplesPerKey {
+			continue
+		}
+		kept = append(kept, sample)
+	}
+	q.workspaceAgentMetadataHistory = kept
+	return nil
+}
+
 func (q *FakeQuerier) DeleteOldWorkspaceAgentStats(_ context.Context) error {
 	q.mutex.Lock()
 	defer q.mutex.Unlock()
@@ -2053,6 +2302,42 @@ func (*FakeQuerier) DeleteTailnetTunnel(_ context.Context, arg database.DeleteTa
 	return database.DeleteTailnetTunnelRow{}, ErrUnimplemented
 }
 
+func (q *FakeQuerier) DeleteSavedSearchByID(_ context.Context, id uuid.UUID) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for i, search := range q.savedSearches {
+		if search.ID == id {
+			q.savedSearches = append(q.savedSearches[:i], q.savedSearches[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (q *FakeQuerier) DeleteUserNotificationQuietHours(_ context.Context, userID uuid.UUID) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for i, qh := range q.notificationQuietHours {
+		if qh.UserID == userID {
+			q.notificationQuietHours = append(q.notificationQuietHours[:i], q.notificationQuietHours[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (q *FakeQuerier) DeleteWorkspaceAgentContainersByAgentID(_ context.Context, workspaceAgentID uuid.UUID) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.workspaceAgentContainers = slices.DeleteFunc(q.workspaceAgentContainers, func(container database.WorkspaceAgentContainer) bool {
+		return container.WorkspaceAgentID == workspaceAgentID
+	})
+	return nil
+}
+
 func (q *FakeQuerier) DeleteWorkspaceAgentPortShare(_ context.Context, arg database.DeleteWorkspaceAgentPortShareParams) error {
 	err := validateDatabaseType(arg)
 	if err != nil {
@@ -2096,6 +2381,19 @@ func (q *FakeQuerier) DeleteWorkspaceAgentPortSharesByTemplate(_ context.Context
 	return nil
 }
 
+func (q *FakeQuerier) DeleteWorkspaceSnapshotByID(_ context.Context, id uuid.UUID) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for i, snapshot := range q.workspaceSnapshots {
+		if snapshot.ID == id {
+			q.workspaceSnapshots = append(q.workspaceSnapshots[:i], q.workspaceSnapshots[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
 func (q *FakeQuerier) EnqueueNotificationMessage(_ context.Context, arg database.EnqueueNotificationMessageParams) error {
 	err := validateDatabaseType(arg)
 	if err != nil {
@@ -2119,6 +2417,8 @@ func (q *FakeQuerier) EnqueueNotificationMessage(_ context.Context, arg database
 		NotificationTemplateID: arg.NotificationTemplateID,
 		Targets:                arg.Targets,
 		CreatedBy:              arg.CreatedBy,
+		HeldUntil:              arg.HeldUntil,
+		DigestTemplateID:       arg.DigestTemplateID,
 		// Default fields.
 		CreatedAt: dbtime.Now(),
 		Status:    database.NotificationMessageStatusPending,
@@ -2154,6 +2454,9 @@ func (q *FakeQuerier) FetchNewMessageMetadata(_ context.Context, arg database.Fe
 		return database.FetchNewMessageMetadataRow{}, err
 	}
 
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
 	user, err := q.getUserByIDNoLock(arg.UserID)
 	if err != nil {
 		return database.FetchNewMessageMetadataRow{}, xerrors.Errorf("fetch user: %w", err)
@@ -2170,13 +2473,23 @@ func (q *FakeQuerier) FetchNewMessageMetadata(_ context.Context, arg database.Fe
 		return database.FetchNewMessageMetadataRow{}, err
 	}
 
+	var userMethod database.NullNotificationMethod
+	for _, np := range q.notificationPreferences {
+		if np.UserID == arg.UserID && np.NotificationTemplateID == arg.NotificationTemplateID {
+			userMethod = np.Method
+			break
+		}
+	}
+
 	return database.FetchNewMessageMetadataRow{
-		UserEmail:        user.Email,
-		UserName:         userName,
-		UserUsername:     user.Username,
-		NotificationName: "Some notification",
-		Actions:          actions,
-		UserID:           arg.UserID,
+		UserEmail:              user.Email,
+		UserName:               userName,
+		UserUsername:           user.Username,
+		NotificationName:       "Some notification",
+		NotificationTemplateID: arg.NotificationTemplateID,
+		Actions:                actions,
+		UserID:                 arg.UserID,
+		UserMethod:             userMethod,
 	}, nil
 }
 
@@ -2307,6 +2620,35 @@ func (*FakeQuerier) GetAllTailnetTunnels(context.Context) ([]database.TailnetTun
 	return nil, ErrUnimplemented
 }
 
+func (q *FakeQuerier) GetActiveWorkspaceSnapshotJobsByWorkspaceID(_ context.Context, workspaceID uuid.UUID) ([]database.ProvisionerJob, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	jobs := []database.ProvisionerJob{}
+	for _, job := range q.provisionerJobs {
+		if job.Type != database.ProvisionerJobTypeWorkspaceSnapshot {
+			continue
+		}
+		if !codersdk.ProvisionerJobStatus(job.JobStatus).Active() {
+			continue
+		}
+		var input struct {
+			WorkspaceBuildID uuid.UUID `json:"workspace_build_id"`
+		}
+		if err := json.Unmarshal(job.Input, &input); err != nil {
+			return nil, err
+		}
+		build, err := q.getWorkspaceBuildByIDNoLock(context.Background(), input.WorkspaceBuildID)
+		if err != nil {
+			return nil, err
+		}
+		if build.WorkspaceID == workspaceID {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs, nil
+}
+
 func (q *FakeQuerier) GetAnnouncementBanners(_ context.Context) (string, error) {
 	q.mutex.RLock()
 	defer q.mutex.RUnlock()
@@ -2336,6 +2678,43 @@ func (q *FakeQuerier) GetApplicationName(_ context.Context) (string, error) {
 	return q.applicationName, nil
 }
 
+func (q *FakeQuerier) GetAuditLogsBefore(_ context.Context, arg database.GetAuditLogsBeforeParams) ([]database.GetAuditLogsBeforeRow, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return nil, err
+	}
+
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	var logs []database.AuditLog
+	for _, alog := range q.auditLogs {
+		if alog.Time.Before(arg.Before) {
+			logs = append(logs, alog)
+		}
+	}
+	slices.SortFunc(logs, func(a, b database.AuditLog) int {
+		if c := a.Time.Compare(b.Time); c != 0 {
+			return c
+		}
+		return slice.Ascending(a.ID.String(), b.ID.String())
+	})
+	if arg.LimitOpt > 0 && len(logs) > int(arg.LimitOpt) {
+		logs = logs[:arg.LimitOpt]
+	}
+
+	rows := make([]database.GetAuditLogsBeforeRow, 0, len(logs))
+	for _, alog := range logs {
+		row := database.GetAuditLogsBeforeRow{AuditLog: alog}
+		if user, err := q.getUserByIDNoLock(alog.UserID); err == nil {
+			row.UserUsername = sql.NullString{String: user.Username, Valid: true}
+			row.UserEmail = sql.NullString{String: user.Email, Valid: true}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
 func (q *FakeQuerier) GetAuditLogsOffset(ctx context.Context, arg database.GetAuditLogsOffsetParams) ([]database.GetAuditLogsOffsetRow, error) {
 	return q.GetAuthorizedAuditLogsOffset(ctx, arg, nil)
 }
@@ -3021,6 +3400,119 @@ func (q *FakeQuerier) GetHungProvisionerJobs(_ context.Context, hungSince time.T
 	return hungJobs, nil
 }
 
+func (q *FakeQuerier) GetInProgressWorkspaceDriftCheckCountsByTemplate(ctx context.Context) ([]database.GetInProgressWorkspaceDriftCheckCountsByTemplateRow, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	counts := map[uuid.UUID]int64{}
+	for _, check := range q.workspaceDriftChecks {
+		job, err := q.getProvisionerJobByIDNoLock(ctx, check.JobID)
+		if err != nil {
+			return nil, err
+		}
+		if job.CompletedAt.Valid {
+			continue
+		}
+		workspace, err := q.getWorkspaceByIDNoLock(ctx, check.WorkspaceID)
+		if err != nil {
+			return nil, err
+		}
+		counts[workspace.TemplateID]++
+	}
+
+	rows := make([]database.GetInProgressWorkspaceDriftCheckCountsByTemplateRow, 0, len(counts))
+	for templateID, count := range counts {
+		rows = append(rows, database.GetInProgressWorkspaceDriftCheckCountsByTemplateRow{
+			TemplateID: templateID,
+			Count:      count,
+		})
+	}
+	return rows, nil
+}
+
+func (q *FakeQuerier) GetInboxNotificationByID(_ context.Context, id uuid.UUID) (database.InboxNotification, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	for _, n := range q.inboxNotifications {
+		if n.ID == id {
+			return n, nil
+		}
+	}
+	return database.InboxNotification{}, sql.ErrNoRows
+}
+
+func (q *FakeQuerier) GetInboxNotificationsByUserID(_ context.Context, arg database.GetInboxNotificationsByUserIDParams) ([]database.InboxNotification, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return nil, err
+	}
+
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	// newer reports whether a sorts before b: newest first, with the ID breaking ties.
+	newer := func(a, b database.InboxNotification) bool {
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.After(b.CreatedAt)
+		}
+		return bytes.Compare(a.ID[:], b.ID[:]) > 0
+	}
+
+	var cursor *database.InboxNotification
+	if arg.AfterID != uuid.Nil {
+		for i := range q.inboxNotifications {
+			if q.inboxNotifications[i].ID == arg.AfterID {
+				cursor = &q.inboxNotifications[i]
+				break
+			}
+		}
+		// If the cursor does not exist, there is nothing after it.
+		if cursor == nil {
+			return []database.InboxNotification{}, nil
+		}
+	}
+
+	out := make([]database.InboxNotification, 0)
+	for _, n := range q.inboxNotifications {
+		if n.UserID != arg.UserID {
+			continue
+		}
+		switch arg.ReadStatus {
+		case "read":
+			if !n.ReadAt.Valid {
+				continue
+			}
+		case "unread":
+			if n.ReadAt.Valid {
+				continue
+			}
+		}
+		if !arg.IncludeArchived && n.ArchivedAt.Valid {
+			continue
+		}
+		if cursor != nil && !newer(*cursor, n) {
+			continue
+		}
+		out = append(out, n)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return newer(out[i], out[j])
+	})
+
+	if arg.OffsetOpt > 0 {
+		if int(arg.OffsetOpt) > len(out)-1 {
+			return []database.InboxNotification{}, nil
+		}
+		out = out[arg.OffsetOpt:]
+	}
+	if arg.LimitOpt > 0 && int(arg.LimitOpt) < len(out) {
+		out = out[:arg.LimitOpt]
+	}
+	return out, nil
+}
+
 func (q *FakeQuerier) GetJFrogXrayScanByWorkspaceAndAgentID(_ context.Context, arg database.GetJFrogXrayScanByWorkspaceAndAgentIDParams) (database.JfrogXrayScan, error) {
 	err := validateDatabaseType(arg)
 	if err != nil {
@@ -3157,6 +3649,33 @@ func (q *FakeQuerier) GetLogoURL(_ context.Context) (string, error) {
 	return q.logoURL, nil
 }
 
+func (q *FakeQuerier) GetNotificationMessagesAwaitingDigest(_ context.Context) ([]database.NotificationMessage, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	now := dbtime.Now()
+	var out []database.NotificationMessage
+	for _, m := range q.notificationMessages {
+		if !m.DigestTemplateID.Valid || m.Status != database.NotificationMessageStatusPending {
+			continue
+		}
+		if m.HeldUntil.Valid && !m.HeldUntil.Time.Before(now) {
+			continue
+		}
+		out = append(out, m)
+	}
+	slices.SortStableFunc(out, func(a, b database.NotificationMessage) int {
+		if c := slice.Ascending(a.UserID.String(), b.UserID.String()); c != 0 {
+			return c
+		}
+		if c := slice.Ascending(a.NotificationTemplateID.String(), b.NotificationTemplateID.String()); c != 0 {
+			return c
+		}
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return out, nil
+}
+
 func (q *FakeQuerier) GetNotificationMessagesByStatus(_ context.Context, arg database.GetNotificationMessagesByStatusParams) ([]database.NotificationMessage, error) {
 	err := validateDatabaseType(arg)
 	if err != nil {
@@ -3469,6 +3988,24 @@ func (q *FakeQuerier) GetParameterSchemasByJobID(_ context.Context, jobID uuid.U
 	return parameters, nil
 }
 
+func (q *FakeQuerier) GetPendingProvisionerJobIaCRequirements(_ context.Context, organizationID uuid.UUID) ([]string, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	var requirements []string
+	for _, provisionerJob := range q.provisionerJobs {
+		if provisionerJob.OrganizationID != organizationID || provisionerJob.StartedAt.Valid {
+			continue
+		}
+		requirement, ok := provisionersdk.IaCRequirementFromTags(provisionerJob.Tags)
+		if !ok || slices.Contains(requirements, requirement.String()) {
+			continue
+		}
+		requirements = append(requirements, requirement.String())
+	}
+	return requirements, nil
+}
+
 func (q *FakeQuerier) GetPreviousTemplateVersion(_ context.Context, arg database.GetPreviousTemplateVersionParams) (database.TemplateVersion, error) {
 	if err := validateDatabaseType(arg); err != nil {
 		return database.TemplateVersion{}, err
@@ -3560,6 +4097,22 @@ func (q *FakeQuerier) GetProvisionerJobByID(ctx context.Context, id uuid.UUID) (
 	return q.getProvisionerJobByIDNoLock(ctx, id)
 }
 
+func (q *FakeQuerier) GetProvisionerJobResourceChangesByJobID(_ context.Context, jobID uuid.UUID) ([]database.ProvisionerJobResourceChange, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	changes := []database.ProvisionerJobResourceChange{}
+	for _, change := range q.provisionerJobResourceChanges {
+		if change.JobID == jobID {
+			changes = append(changes, change)
+		}
+	}
+	slices.SortFunc(changes, func(a, b database.ProvisionerJobResourceChange) int {
+		return strings.Compare(a.Address, b.Address)
+	})
+	return changes, nil
+}
+
 func (q *FakeQuerier) GetProvisionerJobTimingsByJobID(_ context.Context, jobID uuid.UUID) ([]database.ProvisionerJobTiming, error) {
 	q.mutex.RLock()
 	defer q.mutex.RUnlock()
@@ -3831,20 +4384,86 @@ func (*FakeQuerier) GetTailnetAgents(context.Context, uuid.UUID) ([]database.Tai
 	return nil, ErrUnimplemented
 }
 
-func (*FakeQuerier) GetTailnetClientsForAgent(context.Context, uuid.UUID) ([]database.TailnetClient, error) {
-	return nil, ErrUnimplemented
-}
+func (*FakeQuerier) GetTailnetClientsForAgent(context.Context, uuid.UUID) ([]database.TailnetClient, error) {
+	return nil, ErrUnimplemented
+}
+
+func (*FakeQuerier) GetTailnetPeers(context.Context, uuid.UUID) ([]database.TailnetPeer, error) {
+	return nil, ErrUnimplemented
+}
+
+func (*FakeQuerier) GetTailnetTunnelPeerBindings(context.Context, uuid.UUID) ([]database.GetTailnetTunnelPeerBindingsRow, error) {
+	return nil, ErrUnimplemented
+}
+
+func (*FakeQuerier) GetTailnetTunnelPeerIDs(context.Context, uuid.UUID) ([]database.GetTailnetTunnelPeerIDsRow, error) {
+	return nil, ErrUnimplemented
+}
+
+func (q *FakeQuerier) GetSavedSearchByID(_ context.Context, id uuid.UUID) (database.SavedSearch, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	for _, search := range q.savedSearches {
+		if search.ID == id {
+			return search, nil
+		}
+	}
+	return database.SavedSearch{}, sql.ErrNoRows
+}
+
+func (q *FakeQuerier) GetSavedSearches(_ context.Context, arg database.GetSavedSearchesParams) ([]database.SavedSearch, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return nil, err
+	}
+
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
 
-func (*FakeQuerier) GetTailnetPeers(context.Context, uuid.UUID) ([]database.TailnetPeer, error) {
-	return nil, ErrUnimplemented
-}
+	// Organization members are implicitly members of the organization's
+	// "Everyone" group, which shares the organization's ID.
+	groupIDs := make(map[uuid.UUID]bool)
+	for _, member := range q.groupMembers {
+		if member.UserID == arg.UserID {
+			groupIDs[member.GroupID] = true
+		}
+	}
+	for _, member := range q.organizationMembers {
+		if member.UserID == arg.UserID {
+			groupIDs[member.OrganizationID] = true
+		}
+	}
 
-func (*FakeQuerier) GetTailnetTunnelPeerBindings(context.Context, uuid.UUID) ([]database.GetTailnetTunnelPeerBindingsRow, error) {
-	return nil, ErrUnimplemented
-}
+	searches := make([]database.SavedSearch, 0)
+	for _, search := range q.savedSearches {
+		if arg.ResourceType != "" && string(search.ResourceType) != arg.ResourceType {
+			continue
+		}
+		sharedWith := search.OrganizationID
+		if search.GroupID.Valid {
+			sharedWith = search.GroupID
+		}
+		if search.UserID != arg.UserID && !(sharedWith.Valid && groupIDs[sharedWith.UUID]) {
+			continue
+		}
+		searches = append(searches, search)
+	}
 
-func (*FakeQuerier) GetTailnetTunnelPeerIDs(context.Context, uuid.UUID) ([]database.GetTailnetTunnelPeerIDsRow, error) {
-	return nil, ErrUnimplemented
+	slices.SortFunc(searches, func(a, b database.SavedSearch) int {
+		// The user's own searches come first.
+		if aOwn, bOwn := a.UserID == arg.UserID, b.UserID == arg.UserID; aOwn != bOwn {
+			if aOwn {
+				return -1
+			}
+			return 1
+		}
+		if n := strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name)); n != 0 {
+			return n
+		}
+		return bytes.Compare(a.ID[:], b.ID[:])
+	})
+	return searches, nil
 }
 
 func (q *FakeQuerier) GetTemplateAppInsights(ctx context.Context, arg database.GetTemplateAppInsightsParams) ([]database.GetTemplateAppInsightsRow, error) {
@@ -5411,6 +6030,18 @@ func (q *FakeQuerier) GetUserNotificationPreferences(_ context.Context, userID u
 	return out, nil
 }
 
+func (q *FakeQuerier) GetUserNotificationQuietHours(_ context.Context, userID uuid.UUID) (database.NotificationQuietHour, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	for _, qh := range q.notificationQuietHours {
+		if qh.UserID == userID {
+			return qh, nil
+		}
+	}
+	return database.NotificationQuietHour{}, sql.ErrNoRows
+}
+
 func (q *FakeQuerier) GetUserWorkspaceBuildParameters(_ context.Context, params database.GetUserWorkspaceBuildParametersParams) ([]database.GetUserWorkspaceBuildParametersRow, error) {
 	q.mutex.RLock()
 	defer q.mutex.RUnlock()
@@ -5686,6 +6317,22 @@ func (q *FakeQuerier) GetWorkspaceAgentByInstanceID(_ context.Context, instanceI
 	return database.WorkspaceAgent{}, sql.ErrNoRows
 }
 
+func (q *FakeQuerier) GetWorkspaceAgentContainersByAgentID(_ context.Context, workspaceAgentID uuid.UUID) ([]database.WorkspaceAgentContainer, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	containers := make([]database.WorkspaceAgentContainer, 0)
+	for _, container := range q.workspaceAgentContainers {
+		if container.WorkspaceAgentID == workspaceAgentID {
+			containers = append(containers, container)
+		}
+	}
+	slices.SortFunc(containers, func(a, b database.WorkspaceAgentContainer) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return containers, nil
+}
+
 func (q *FakeQuerier) GetWorkspaceAgentLifecycleStateByID(ctx context.Context, id uuid.UUID) (database.GetWorkspaceAgentLifecycleStateByIDRow, error) {
 	q.mutex.RLock()
 	defer q.mutex.RUnlock()
@@ -5758,6 +6405,30 @@ func (q *FakeQuerier) GetWorkspaceAgentMetadata(_ context.Context, arg database.
 	return metadata, nil
 }
 
+func (q *FakeQuerier) GetWorkspaceAgentMetadataHistory(_ context.Context, arg database.GetWorkspaceAgentMetadataHistoryParams) ([]database.WorkspaceAgentMetadataHistory, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return nil, err
+	}
+
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	samples := make([]database.WorkspaceAgentMetadataHistory, 0)
+	for _, sample := range q.workspaceAgentMetadataHistory {
+		if sample.WorkspaceAgentID == arg.WorkspaceAgentID && sample.Key == arg.Key && sample.CollectedAt.After(arg.CollectedAfter) {
+			samples = append(samples, sample)
+		}
+	}
+	slices.SortFunc(samples, func(a, b database.WorkspaceAgentMetadataHistory) int {
+		return b.CollectedAt.Compare(a.CollectedAt)
+	})
+	if len(samples) > int(arg.Limit) {
+		samples = samples[:arg.Limit]
+	}
+	return samples, nil
+}
+
 func (q *FakeQuerier) GetWorkspaceAgentPortShare(_ context.Context, arg database.GetWorkspaceAgentPortShareParams) (database.WorkspaceAgentPortShare, error) {
 	err := validateDatabaseType(arg)
 	if err != nil {
@@ -5776,6 +6447,30 @@ func (q *FakeQuerier) GetWorkspaceAgentPortShare(_ context.Context, arg database
 	return database.WorkspaceAgentPortShare{}, sql.ErrNoRows
 }
 
+func (q *FakeQuerier) GetWorkspaceAgentScriptTimingsByScriptID(_ context.Context, arg database.GetWorkspaceAgentScriptTimingsByScriptIDParams) ([]database.WorkspaceAgentScriptTiming, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return nil, err
+	}
+
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	timings := make([]database.WorkspaceAgentScriptTiming, 0)
+	for _, timing := range q.workspaceAgentScriptTimings {
+		if timing.ScriptID == arg.ScriptID {
+			timings = append(timings, timing)
+		}
+	}
+	slices.SortFunc(timings, func(a, b database.WorkspaceAgentScriptTiming) int {
+		return b.StartedAt.Compare(a.StartedAt)
+	})
+	if arg.Limit > 0 && len(timings) > int(arg.Limit) {
+		timings = timings[:arg.Limit]
+	}
+	return timings, nil
+}
+
 func (q *FakeQuerier) GetWorkspaceAgentScriptsByAgentIDs(_ context.Context, ids []uuid.UUID) ([]database.WorkspaceAgentScript, error) {
 	q.mutex.RLock()
 	defer q.mutex.RUnlock()
@@ -5792,6 +6487,50 @@ func (q *FakeQuerier) GetWorkspaceAgentScriptsByAgentIDs(_ context.Context, ids
 	return scripts, nil
 }
 
+func (q *FakeQuerier) GetWorkspaceAgentSessionRecordingByID(_ context.Context, id uuid.UUID) (database.WorkspaceAgentSessionRecording, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	for _, recording := range q.workspaceAgentSessionRecordings {
+		if recording.ID == id {
+			return recording, nil
+		}
+	}
+	return database.WorkspaceAgentSessionRecording{}, sql.ErrNoRows
+}
+
+func (q *FakeQuerier) GetWorkspaceAgentSessionRecordingChunks(_ context.Context, recordingID uuid.UUID) ([]database.WorkspaceAgentSessionRecordingChunk, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	chunks := make([]database.WorkspaceAgentSessionRecordingChunk, 0)
+	for _, chunk := range q.workspaceAgentSessionRecordingChunks {
+		if chunk.RecordingID == recordingID {
+			chunks = append(chunks, chunk)
+		}
+	}
+	slices.SortFunc(chunks, func(a, b database.WorkspaceAgentSessionRecordingChunk) int {
+		return int(a.Sequence - b.Sequence)
+	})
+	return chunks, nil
+}
+
+func (q *FakeQuerier) GetWorkspaceAgentSessionRecordingsByWorkspaceID(_ context.Context, workspaceID uuid.UUID) ([]database.WorkspaceAgentSessionRecording, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	recordings := make([]database.WorkspaceAgentSessionRecording, 0)
+	for _, recording := range q.workspaceAgentSessionRecordings {
+		if recording.WorkspaceID == workspaceID {
+			recordings = append(recordings, recording)
+		}
+	}
+	slices.SortFunc(recordings, func(a, b database.WorkspaceAgentSessionRecording) int {
+		return b.StartedAt.Compare(a.StartedAt)
+	})
+	return recordings, nil
+}
+
 func (q *FakeQuerier) GetWorkspaceAgentStats(_ context.Context, createdAfter time.Time) ([]database.GetWorkspaceAgentStatsRow, error) {
 	q.mutex.RLock()
 	defer q.mutex.RUnlock()
@@ -6434,6 +7173,38 @@ func (q *FakeQuerier) GetWorkspaceBuildsCreatedAfter(_ context.Context, after ti
 	return workspaceBuilds, nil
 }
 
+func (q *FakeQuerier) GetWorkspaceBuildsToMigrateProvisionerState(_ context.Context, arg database.GetWorkspaceBuildsToMigrateProvisionerStateParams) ([]database.WorkspaceBuild, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return nil, err
+	}
+
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	builds := make([]database.WorkspaceBuild, 0)
+	for _, build := range q.workspaceBuilds {
+		if bytes.Compare(build.ID[:], arg.AfterID[:]) <= 0 {
+			continue
+		}
+		if arg.InStateStore {
+			if build.ProvisionerStateRef == "" {
+				continue
+			}
+		} else if build.ProvisionerStateRef != "" || len(build.ProvisionerState) == 0 {
+			continue
+		}
+		builds = append(builds, q.workspaceBuildWithUserNoLock(build))
+	}
+	slices.SortFunc(builds, func(a, b database.WorkspaceBuild) int {
+		return bytes.Compare(a.ID[:], b.ID[:])
+	})
+	if arg.LimitCount > 0 && len(builds) > int(arg.LimitCount) {
+		builds = builds[:arg.LimitCount]
+	}
+	return builds, nil
+}
+
 func (q *FakeQuerier) GetWorkspaceByAgentID(ctx context.Context, agentID uuid.UUID) (database.GetWorkspaceByAgentIDRow, error) {
 	q.mutex.RLock()
 	defer q.mutex.RUnlock()
@@ -6449,8 +7220,9 @@ func (q *FakeQuerier) GetWorkspaceByAgentID(ctx context.Context, agentID uuid.UU
 	}
 
 	return database.GetWorkspaceByAgentIDRow{
-		Workspace:    w,
-		TemplateName: tpl.Name,
+		Workspace:                 w,
+		TemplateName:              tpl.Name,
+		TemplateAgentActivityBump: tpl.AgentActivityBump,
 	}, nil
 }
 
@@ -6510,6 +7282,60 @@ func (q *FakeQuerier) GetWorkspaceByWorkspaceAppID(_ context.Context, workspaceA
 	return database.Workspace{}, sql.ErrNoRows
 }
 
+func (q *FakeQuerier) GetWorkspaceDriftedResourcesByWorkspaceIDs(ctx context.Context, ids []uuid.UUID) ([]database.GetWorkspaceDriftedResourcesByWorkspaceIDsRow, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	rows := make([]database.GetWorkspaceDriftedResourcesByWorkspaceIDsRow, 0)
+	for _, id := range ids {
+		var latest database.WorkspaceDriftCheck
+		for _, check := range q.workspaceDriftChecks {
+			if check.WorkspaceID != id || check.CreatedAt.Before(latest.CreatedAt) {
+				continue
+			}
+			job, err := q.getProvisionerJobByIDNoLock(ctx, check.JobID)
+			if err != nil {
+				return nil, err
+			}
+			if job.JobStatus != database.ProvisionerJobStatusSucceeded {
+				continue
+			}
+			latest = check
+		}
+		if latest.ID == uuid.Nil {
+			continue
+		}
+		build, err := q.getLatestWorkspaceBuildByWorkspaceIDNoLock(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if build.ID != latest.WorkspaceBuildID {
+			continue
+		}
+		for _, change := range q.provisionerJobResourceChanges {
+			if change.JobID != latest.JobID {
+				continue
+			}
+			rows = append(rows, database.GetWorkspaceDriftedResourcesByWorkspaceIDsRow{
+				WorkspaceID: id,
+				JobID:       change.JobID,
+				Address:     change.Address,
+				Type:        change.Type,
+				Name:        change.Name,
+				Action:      change.Action,
+				Attributes:  change.Attributes,
+			})
+		}
+	}
+	slices.SortFunc(rows, func(a, b database.GetWorkspaceDriftedResourcesByWorkspaceIDsRow) int {
+		if c := slices.Compare(a.WorkspaceID[:], b.WorkspaceID[:]); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Address, b.Address)
+	})
+	return rows, nil
+}
+
 func (q *FakeQuerier) GetWorkspaceProxies(_ context.Context) ([]database.WorkspaceProxy, error) {
 	q.mutex.RLock()
 	defer q.mutex.RUnlock()
@@ -6674,6 +7500,34 @@ func (q *FakeQuerier) GetWorkspaceResourcesCreatedAfter(_ context.Context, after
 	return resources, nil
 }
 
+func (q *FakeQuerier) GetWorkspaceSnapshotByID(_ context.Context, id uuid.UUID) (database.WorkspaceSnapshot, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	for _, snapshot := range q.workspaceSnapshots {
+		if snapshot.ID == id {
+			return snapshot, nil
+		}
+	}
+	return database.WorkspaceSnapshot{}, sql.ErrNoRows
+}
+
+func (q *FakeQuerier) GetWorkspaceSnapshotsByWorkspaceID(_ context.Context, workspaceID uuid.UUID) ([]database.WorkspaceSnapshot, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	snapshots := make([]database.WorkspaceSnapshot, 0)
+	for _, snapshot := range q.workspaceSnapshots {
+		if snapshot.WorkspaceID == workspaceID {
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].CreatedAt.After(snapshots[j].CreatedAt)
+	})
+	return snapshots, nil
+}
+
 func (q *FakeQuerier) GetWorkspaceUniqueOwnerCountByTemplateIDs(_ context.Context, templateIds []uuid.UUID) ([]database.GetWorkspaceUniqueOwnerCountByTemplateIDsRow, error) {
 	q.mutex.RLock()
 	defer q.mutex.RUnlock()
@@ -6714,6 +7568,81 @@ func (q *FakeQuerier) GetWorkspaces(ctx context.Context, arg database.GetWorkspa
 	return workspaceRows, err
 }
 
+func (q *FakeQuerier) GetWorkspacesEligibleForDriftCheck(ctx context.Context, checkedBefore time.Time) ([]database.GetWorkspacesEligibleForDriftCheckRow, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	type eligible struct {
+		row     database.GetWorkspacesEligibleForDriftCheckRow
+		builtAt time.Time
+	}
+	workspaces := make([]eligible, 0)
+	for _, workspace := range q.workspaces {
+		if workspace.Deleted || workspace.DormantAt.Valid {
+			continue
+		}
+		build, err := q.getLatestWorkspaceBuildByWorkspaceIDNoLock(ctx, workspace.ID)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if build.Transition != database.WorkspaceTransitionStart {
+			continue
+		}
+		job, err := q.getProvisionerJobByIDNoLock(ctx, build.JobID)
+		if err != nil {
+			return nil, err
+		}
+		if job.JobStatus != database.ProvisionerJobStatusSucceeded {
+			continue
+		}
+
+		checked := false
+		for _, check := range q.workspaceDriftChecks {
+			if check.WorkspaceID != workspace.ID {
+				continue
+			}
+			checkJob, err := q.getProvisionerJobByIDNoLock(ctx, check.JobID)
+			if err != nil {
+				return nil, err
+			}
+			if check.CreatedAt.After(checkedBefore) || !checkJob.CompletedAt.Valid {
+				checked = true
+				break
+			}
+		}
+		if checked {
+			continue
+		}
+
+		workspaces = append(workspaces, eligible{
+			row: database.GetWorkspacesEligibleForDriftCheckRow{
+				WorkspaceID:      workspace.ID,
+				OwnerID:          workspace.OwnerID,
+				TemplateID:       workspace.TemplateID,
+				WorkspaceBuildID: build.ID,
+				OrganizationID:   job.OrganizationID,
+				Provisioner:      job.Provisioner,
+				StorageMethod:    job.StorageMethod,
+				FileID:           job.FileID,
+				Tags:             maps.Clone(job.Tags),
+			},
+			builtAt: build.CreatedAt,
+		})
+	}
+	slices.SortStableFunc(workspaces, func(a, b eligible) int {
+		return a.builtAt.Compare(b.builtAt)
+	})
+
+	rows := make([]database.GetWorkspacesEligibleForDriftCheckRow, 0, len(workspaces))
+	for _, workspace := range workspaces {
+		rows = append(rows, workspace.row)
+	}
+	return rows, nil
+}
+
 func (q *FakeQuerier) GetWorkspacesEligibleForTransition(ctx context.Context, now time.Time) ([]database.Workspace, error) {
 	q.mutex.RLock()
 	defer q.mutex.RUnlock()
@@ -7055,6 +7984,37 @@ func (q *FakeQuerier) InsertGroupMember(_ context.Context, arg database.InsertGr
 	return nil
 }
 
+func (q *FakeQuerier) InsertInboxNotification(_ context.Context, arg database.InsertInboxNotificationParams) (int64, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return 0, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for _, n := range q.inboxNotifications {
+		if n.ID == arg.ID {
+			return 0, nil
+		}
+	}
+
+	actions := arg.Actions
+	if len(actions) == 0 {
+		actions = json.RawMessage("[]")
+	}
+	q.inboxNotifications = append(q.inboxNotifications, database.InboxNotification{
+		ID:         arg.ID,
+		UserID:     arg.UserID,
+		TemplateID: arg.TemplateID,
+		Title:      arg.Title,
+		Content:    arg.Content,
+		Actions:    actions,
+		CreatedAt:  arg.CreatedAt,
+	})
+	return 1, nil
+}
+
 func (q *FakeQuerier) InsertLicense(
 	_ context.Context, arg database.InsertLicenseParams,
 ) (database.License, error) {
@@ -7340,6 +8300,34 @@ func (q *FakeQuerier) InsertProvisionerJobLogs(_ context.Context, arg database.I
 	return logs, nil
 }
 
+func (q *FakeQuerier) InsertProvisionerJobResourceChange(_ context.Context, arg database.InsertProvisionerJobResourceChangeParams) (database.ProvisionerJobResourceChange, error) {
+	if err := validateDatabaseType(arg); err != nil {
+		return database.ProvisionerJobResourceChange{}, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for _, change := range q.provisionerJobResourceChanges {
+		if change.JobID == arg.JobID && change.Address == arg.Address {
+			return database.ProvisionerJobResourceChange{}, errUniqueConstraint
+		}
+	}
+	change := database.ProvisionerJobResourceChange{
+		JobID:      arg.JobID,
+		Address:    arg.Address,
+		Type:       arg.Type,
+		Name:       arg.Name,
+		Action:     arg.Action,
+		Attributes: arg.Attributes,
+	}
+	if change.Attributes == nil {
+		change.Attributes = json.RawMessage("[]")
+	}
+	q.provisionerJobResourceChanges = append(q.provisionerJobResourceChanges, change)
+	return change, nil
+}
+
 func (q *FakeQuerier) InsertProvisionerJobTimings(_ context.Context, arg database.InsertProvisionerJobTimingsParams) ([]database.ProvisionerJobTiming, error) {
 	err := validateDatabaseType(arg)
 	if err != nil {
@@ -7416,8 +8404,39 @@ func (q *FakeQuerier) InsertReplica(_ context.Context, arg database.InsertReplic
 		DatabaseLatency: arg.DatabaseLatency,
 		Primary:         arg.Primary,
 	}
-	q.replicas = append(q.replicas, replica)
-	return replica, nil
+	q.replicas = append(q.replicas, replica)
+	return replica, nil
+}
+
+func (q *FakeQuerier) InsertSavedSearch(_ context.Context, arg database.InsertSavedSearchParams) (database.SavedSearch, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return database.SavedSearch{}, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for _, search := range q.savedSearches {
+		if search.UserID == arg.UserID && search.ResourceType == arg.ResourceType && search.Name == arg.Name {
+			return database.SavedSearch{}, newUniqueConstraintError(database.UniqueSavedSearchesUserIDResourceTypeNameKey)
+		}
+	}
+
+	//nolint:gosimple
+	search := database.SavedSearch{
+		ID:             arg.ID,
+		UserID:         arg.UserID,
+		ResourceType:   arg.ResourceType,
+		Name:           arg.Name,
+		Query:          arg.Query,
+		OrganizationID: arg.OrganizationID,
+		GroupID:        arg.GroupID,
+		CreatedAt:      arg.CreatedAt,
+		UpdatedAt:      arg.UpdatedAt,
+	}
+	q.savedSearches = append(q.savedSearches, search)
+	return search, nil
 }
 
 func (q *FakeQuerier) InsertTemplate(_ context.Context, arg database.InsertTemplateParams) error {
@@ -7739,6 +8758,36 @@ func (q *FakeQuerier) InsertWorkspaceAgent(_ context.Context, arg database.Inser
 	return agent, nil
 }
 
+func (q *FakeQuerier) InsertWorkspaceAgentContainer(_ context.Context, arg database.InsertWorkspaceAgentContainerParams) error {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for _, container := range q.workspaceAgentContainers {
+		if container.WorkspaceAgentID == arg.WorkspaceAgentID && container.ContainerID == arg.ContainerID {
+			return errUniqueConstraint
+		}
+	}
+	//nolint:gosimple // Prefer explicit field assignment for clarity.
+	q.workspaceAgentContainers = append(q.workspaceAgentContainers, database.WorkspaceAgentContainer{
+		WorkspaceAgentID: arg.WorkspaceAgentID,
+		ContainerID:      arg.ContainerID,
+		Name:             arg.Name,
+		Image:            arg.Image,
+		Status:           arg.Status,
+		Runtime:          arg.Runtime,
+		Labels:           arg.Labels,
+		Ports:            arg.Ports,
+		CreatedAt:        arg.CreatedAt,
+		ReportedAt:       arg.ReportedAt,
+	})
+	return nil
+}
+
 func (q *FakeQuerier) InsertWorkspaceAgentLogSources(_ context.Context, arg database.InsertWorkspaceAgentLogSourcesParams) ([]database.WorkspaceAgentLogSource, error) {
 	err := validateDatabaseType(arg)
 	if err != nil {
@@ -7827,6 +8876,33 @@ func (q *FakeQuerier) InsertWorkspaceAgentMetadata(_ context.Context, arg databa
 	return nil
 }
 
+func (q *FakeQuerier) InsertWorkspaceAgentMetadataHistory(_ context.Context, arg database.InsertWorkspaceAgentMetadataHistoryParams) error {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for i, key := range arg.Key {
+		for _, m := range q.workspaceAgentMetadata {
+			if m.WorkspaceAgentID != arg.WorkspaceAgentID || m.Key != key {
+				continue
+			}
+			q.workspaceAgentMetadataHistory = append(q.workspaceAgentMetadataHistory, database.WorkspaceAgentMetadataHistory{
+				WorkspaceAgentID: arg.WorkspaceAgentID,
+				Key:              key,
+				Value:            arg.Value[i],
+				Error:            arg.Error[i],
+				CollectedAt:      arg.CollectedAt[i],
+			})
+			break
+		}
+	}
+	return nil
+}
+
 func (q *FakeQuerier) InsertWorkspaceAgentScriptTimings(_ context.Context, arg database.InsertWorkspaceAgentScriptTimingsParams) error {
 	err := validateDatabaseType(arg)
 	if err != nil {
@@ -7873,6 +8949,7 @@ func (q *FakeQuerier) InsertWorkspaceAgentScripts(_ context.Context, arg databas
 			RunOnStart:       arg.RunOnStart[index],
 			RunOnStop:        arg.RunOnStop[index],
 			TimeoutSeconds:   arg.TimeoutSeconds[index],
+			DisplayName:      arg.DisplayName[index],
 			CreatedAt:        arg.CreatedAt,
 		}
 		scripts = append(scripts, script)
@@ -7881,6 +8958,63 @@ func (q *FakeQuerier) InsertWorkspaceAgentScripts(_ context.Context, arg databas
 	return scripts, nil
 }
 
+func (q *FakeQuerier) InsertWorkspaceAgentSessionRecording(_ context.Context, arg database.InsertWorkspaceAgentSessionRecordingParams) (database.WorkspaceAgentSessionRecording, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return database.WorkspaceAgentSessionRecording{}, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for _, recording := range q.workspaceAgentSessionRecordings {
+		if recording.ID == arg.ID {
+			return database.WorkspaceAgentSessionRecording{}, newUniqueConstraintError(database.UniqueWorkspaceAgentSessionRecordingsPkey)
+		}
+	}
+
+	//nolint:gosimple
+	recording := database.WorkspaceAgentSessionRecording{
+		ID:          arg.ID,
+		WorkspaceID: arg.WorkspaceID,
+		AgentID:     arg.AgentID,
+		SessionType: arg.SessionType,
+		Command:     arg.Command,
+		StartedAt:   arg.StartedAt,
+	}
+	q.workspaceAgentSessionRecordings = append(q.workspaceAgentSessionRecordings, recording)
+	return recording, nil
+}
+
+func (q *FakeQuerier) InsertWorkspaceAgentSessionRecordingChunk(_ context.Context, arg database.InsertWorkspaceAgentSessionRecordingChunkParams) error {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for _, chunk := range q.workspaceAgentSessionRecordingChunks {
+		if chunk.RecordingID == arg.RecordingID && chunk.Sequence == arg.Sequence {
+			return newUniqueConstraintError(database.UniqueWorkspaceAgentSessionRecordingChunksPkey)
+		}
+	}
+	for i, recording := range q.workspaceAgentSessionRecordings {
+		if recording.ID != arg.RecordingID {
+			continue
+		}
+		q.workspaceAgentSessionRecordings[i].Size += int64(len(arg.Data))
+		q.workspaceAgentSessionRecordingChunks = append(q.workspaceAgentSessionRecordingChunks, database.WorkspaceAgentSessionRecordingChunk{
+			RecordingID: arg.RecordingID,
+			Sequence:    arg.Sequence,
+			Data:        slices.Clone(arg.Data),
+		})
+		return nil
+	}
+	return errForeignKeyConstraint
+}
+
 func (q *FakeQuerier) InsertWorkspaceAgentStats(_ context.Context, arg database.InsertWorkspaceAgentStatsParams) error {
 	err := validateDatabaseType(arg)
 	if err != nil {
@@ -7939,23 +9073,30 @@ func (q *FakeQuerier) InsertWorkspaceApp(_ context.Context, arg database.InsertW
 
 	// nolint:gosimple
 	workspaceApp := database.WorkspaceApp{
-		ID:                   arg.ID,
-		AgentID:              arg.AgentID,
-		CreatedAt:            arg.CreatedAt,
-		Slug:                 arg.Slug,
-		DisplayName:          arg.DisplayName,
-		Icon:                 arg.Icon,
-		Command:              arg.Command,
-		Url:                  arg.Url,
-		External:             arg.External,
-		Subdomain:            arg.Subdomain,
-		SharingLevel:         arg.SharingLevel,
-		HealthcheckUrl:       arg.HealthcheckUrl,
-		HealthcheckInterval:  arg.HealthcheckInterval,
-		HealthcheckThreshold: arg.HealthcheckThreshold,
-		Health:               arg.Health,
-		Hidden:               arg.Hidden,
-		DisplayOrder:         arg.DisplayOrder,
+		ID:                        arg.ID,
+		AgentID:                   arg.AgentID,
+		CreatedAt:                 arg.CreatedAt,
+		Slug:                      arg.Slug,
+		DisplayName:               arg.DisplayName,
+		Icon:                      arg.Icon,
+		Command:                   arg.Command,
+		Url:                       arg.Url,
+		External:                  arg.External,
+		Subdomain:                 arg.Subdomain,
+		SharingLevel:              arg.SharingLevel,
+		HealthcheckUrl:            arg.HealthcheckUrl,
+		HealthcheckInterval:       arg.HealthcheckInterval,
+		HealthcheckThreshold:      arg.HealthcheckThreshold,
+		Health:                    arg.Health,
+		Hidden:                    arg.Hidden,
+		DisplayOrder:              arg.DisplayOrder,
+		HealthcheckKind:           arg.HealthcheckKind,
+		HealthcheckExpectedStatus: arg.HealthcheckExpectedStatus,
+		HealthcheckBodyRegex:      arg.HealthcheckBodyRegex,
+		HealthcheckAddress:        arg.HealthcheckAddress,
+		HealthcheckGrpcService:    arg.HealthcheckGrpcService,
+		HealthcheckCommand:        arg.HealthcheckCommand,
+		HealthcheckPath:           arg.HealthcheckPath,
 	}
 	q.workspaceApps = append(q.workspaceApps, workspaceApp)
 	return workspaceApp, nil
@@ -8008,19 +9149,20 @@ func (q *FakeQuerier) InsertWorkspaceBuild(_ context.Context, arg database.Inser
 	defer q.mutex.Unlock()
 
 	workspaceBuild := database.WorkspaceBuild{
-		ID:                arg.ID,
-		CreatedAt:         arg.CreatedAt,
-		UpdatedAt:         arg.UpdatedAt,
-		WorkspaceID:       arg.WorkspaceID,
-		TemplateVersionID: arg.TemplateVersionID,
-		BuildNumber:       arg.BuildNumber,
-		Transition:        arg.Transition,
-		InitiatorID:       arg.InitiatorID,
-		JobID:             arg.JobID,
-		ProvisionerState:  arg.ProvisionerState,
-		Deadline:          arg.Deadline,
-		MaxDeadline:       arg.MaxDeadline,
-		Reason:            arg.Reason,
+		ID:                  arg.ID,
+		CreatedAt:           arg.CreatedAt,
+		UpdatedAt:           arg.UpdatedAt,
+		WorkspaceID:         arg.WorkspaceID,
+		TemplateVersionID:   arg.TemplateVersionID,
+		BuildNumber:         arg.BuildNumber,
+		Transition:          arg.Transition,
+		InitiatorID:         arg.InitiatorID,
+		JobID:               arg.JobID,
+		ProvisionerState:    arg.ProvisionerState,
+		Deadline:            arg.Deadline,
+		MaxDeadline:         arg.MaxDeadline,
+		Reason:              arg.Reason,
+		ProvisionerStateRef: arg.ProvisionerStateRef,
 	}
 	q.workspaceBuilds = append(q.workspaceBuilds, workspaceBuild)
 	return nil
@@ -8044,6 +9186,33 @@ func (q *FakeQuerier) InsertWorkspaceBuildParameters(_ context.Context, arg data
 	return nil
 }
 
+func (q *FakeQuerier) InsertWorkspaceDriftCheck(_ context.Context, arg database.InsertWorkspaceDriftCheckParams) (database.WorkspaceDriftCheck, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return database.WorkspaceDriftCheck{}, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for _, check := range q.workspaceDriftChecks {
+		if check.JobID == arg.JobID {
+			return database.WorkspaceDriftCheck{}, newUniqueConstraintError(database.UniqueWorkspaceDriftChecksJobIDKey)
+		}
+	}
+
+	//nolint:gosimple
+	check := database.WorkspaceDriftCheck{
+		ID:               arg.ID,
+		WorkspaceID:      arg.WorkspaceID,
+		WorkspaceBuildID: arg.WorkspaceBuildID,
+		JobID:            arg.JobID,
+		CreatedAt:        arg.CreatedAt,
+	}
+	q.workspaceDriftChecks = append(q.workspaceDriftChecks, check)
+	return check, nil
+}
+
 func (q *FakeQuerier) InsertWorkspaceProxy(_ context.Context, arg database.InsertWorkspaceProxyParams) (database.WorkspaceProxy, error) {
 	q.mutex.Lock()
 	defer q.mutex.Unlock()
@@ -8130,6 +9299,36 @@ func (q *FakeQuerier) InsertWorkspaceResourceMetadata(_ context.Context, arg dat
 	return metadata, nil
 }
 
+func (q *FakeQuerier) InsertWorkspaceSnapshot(_ context.Context, arg database.InsertWorkspaceSnapshotParams) (database.WorkspaceSnapshot, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return database.WorkspaceSnapshot{}, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for _, snapshot := range q.workspaceSnapshots {
+		if snapshot.WorkspaceID == arg.WorkspaceID && snapshot.Name == arg.Name {
+			return database.WorkspaceSnapshot{}, newUniqueConstraintError(database.UniqueWorkspaceSnapshotsWorkspaceIDNameKey)
+		}
+	}
+
+	//nolint:gosimple
+	snapshot := database.WorkspaceSnapshot{
+		ID:               arg.ID,
+		WorkspaceID:      arg.WorkspaceID,
+		WorkspaceBuildID: arg.WorkspaceBuildID,
+		JobID:            arg.JobID,
+		Name:             arg.Name,
+		Data:             []byte{},
+		CreatedBy:        arg.CreatedBy,
+		CreatedAt:        arg.CreatedAt,
+	}
+	q.workspaceSnapshots = append(q.workspaceSnapshots, snapshot)
+	return snapshot, nil
+}
+
 func (q *FakeQuerier) ListProvisionerKeysByOrganization(_ context.Context, organizationID uuid.UUID) ([]database.ProvisionerKey, error) {
 	q.mutex.RLock()
 	defer q.mutex.RUnlock()
@@ -8177,6 +9376,53 @@ func (q *FakeQuerier) ListWorkspaceAgentPortShares(_ context.Context, workspaceI
 	return shares, nil
 }
 
+func (*FakeQuerier) LockWorkspaceByID(_ context.Context, _ uuid.UUID) error {
+	// Transactions are serialized already, so there is nothing to lock.
+	return nil
+}
+
+func (q *FakeQuerier) MarkAllInboxNotificationsAsRead(_ context.Context, arg database.MarkAllInboxNotificationsAsReadParams) (int64, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return 0, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	var count int64
+	for i, n := range q.inboxNotifications {
+		if n.UserID != arg.UserID || n.ReadAt.Valid {
+			continue
+		}
+		q.inboxNotifications[i].ReadAt = arg.ReadAt
+		count++
+	}
+	return count, nil
+}
+
+func (q *FakeQuerier) MarkNotificationMessagesDigested(_ context.Context, arg database.MarkNotificationMessagesDigestedParams) error {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for i, m := range q.notificationMessages {
+		if !slices.Contains(arg.IDs, m.ID) {
+			continue
+		}
+		m.DigestTemplateID = uuid.NullUUID{}
+		m.Status = database.NotificationMessageStatusInhibited
+		m.StatusReason = sql.NullString{String: "Delivered in digest " + arg.DigestMessageID.String(), Valid: true}
+		m.UpdatedAt = sql.NullTime{Time: dbtime.Now(), Valid: true}
+		q.notificationMessages[i] = m
+	}
+	return nil
+}
+
 func (q *FakeQuerier) OrganizationMembers(_ context.Context, arg database.OrganizationMembersParams) ([]database.OrganizationMembersRow, error) {
 	if err := validateDatabaseType(arg); err != nil {
 		return []database.OrganizationMembersRow{}, err
@@ -8251,6 +9497,21 @@ func (q *FakeQuerier) RegisterWorkspaceProxy(_ context.Context, arg database.Reg
 	return database.WorkspaceProxy{}, sql.ErrNoRows
 }
 
+func (q *FakeQuerier) ReleaseNotificationMessagesFromDigest(_ context.Context, ids []uuid.UUID) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for i, m := range q.notificationMessages {
+		if !slices.Contains(ids, m.ID) {
+			continue
+		}
+		m.DigestTemplateID = uuid.NullUUID{}
+		m.UpdatedAt = sql.NullTime{Time: dbtime.Now(), Valid: true}
+		q.notificationMessages[i] = m
+	}
+	return nil
+}
+
 func (q *FakeQuerier) RemoveUserFromAllGroups(_ context.Context, userID uuid.UUID) error {
 	q.mutex.Lock()
 	defer q.mutex.Unlock()
@@ -8535,6 +9796,26 @@ func (q *FakeQuerier) UpdateInactiveUsersToDormant(_ context.Context, params dat
 	return updated, nil
 }
 
+func (q *FakeQuerier) UpdateInboxNotificationStatus(_ context.Context, arg database.UpdateInboxNotificationStatusParams) (database.InboxNotification, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return database.InboxNotification{}, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for i, n := range q.inboxNotifications {
+		if n.ID != arg.ID {
+			continue
+		}
+		q.inboxNotifications[i].ReadAt = arg.ReadAt
+		q.inboxNotifications[i].ArchivedAt = arg.ArchivedAt
+		return q.inboxNotifications[i], nil
+	}
+	return database.InboxNotification{}, sql.ErrNoRows
+}
+
 func (q *FakeQuerier) UpdateMemberRoles(_ context.Context, arg database.UpdateMemberRolesParams) (database.OrganizationMember, error) {
 	if err := validateDatabaseType(arg); err != nil {
 		return database.OrganizationMember{}, err
@@ -8778,6 +10059,35 @@ func (*FakeQuerier) UpdateTailnetPeerStatusByCoordinator(context.Context, databa
 	return ErrUnimplemented
 }
 
+func (q *FakeQuerier) UpdateSavedSearch(_ context.Context, arg database.UpdateSavedSearchParams) (database.SavedSearch, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return database.SavedSearch{}, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for i, search := range q.savedSearches {
+		if search.ID != arg.ID {
+			continue
+		}
+		for _, other := range q.savedSearches {
+			if other.ID != search.ID && other.UserID == search.UserID && other.ResourceType == search.ResourceType && other.Name == arg.Name {
+				return database.SavedSearch{}, newUniqueConstraintError(database.UniqueSavedSearchesUserIDResourceTypeNameKey)
+			}
+		}
+		search.Name = arg.Name
+		search.Query = arg.Query
+		search.OrganizationID = arg.OrganizationID
+		search.GroupID = arg.GroupID
+		search.UpdatedAt = arg.UpdatedAt
+		q.savedSearches[i] = search
+		return search, nil
+	}
+	return database.SavedSearch{}, sql.ErrNoRows
+}
+
 func (q *FakeQuerier) UpdateTemplateACLByID(_ context.Context, arg database.UpdateTemplateACLByIDParams) error {
 	if err := validateDatabaseType(arg); err != nil {
 		return err
@@ -8879,6 +10189,9 @@ func (q *FakeQuerier) UpdateTemplateMetaByID(_ context.Context, arg database.Upd
 		tpl.GroupACL = arg.GroupACL
 		tpl.AllowUserCancelWorkspaceJobs = arg.AllowUserCancelWorkspaceJobs
 		tpl.MaxPortSharingLevel = arg.MaxPortSharingLevel
+		tpl.SessionRecording = arg.SessionRecording
+		tpl.PortForwardingPolicy = arg.PortForwardingPolicy
+		tpl.AgentActivityBump = arg.AgentActivityBump
 		q.templates[idx] = tpl
 		return nil
 	}
@@ -9184,7 +10497,11 @@ func (q *FakeQuerier) UpdateUserNotificationPreferences(_ context.Context, arg d
 			found      bool
 			templateID = arg.NotificationTemplateIds[i]
 			disabled   = arg.Disableds[i]
+			method     database.NullNotificationMethod
 		)
+		if i < len(arg.Methods) && arg.Methods[i] != "" {
+			method = database.NullNotificationMethod{NotificationMethod: database.NotificationMethod(arg.Methods[i]), Valid: true}
+		}
 
 		for j, np := range q.notificationPreferences {
 			if np.UserID != arg.UserID {
@@ -9196,6 +10513,7 @@ func (q *FakeQuerier) UpdateUserNotificationPreferences(_ context.Context, arg d
 			}
 
 			np.Disabled = disabled
+			np.Method = method
 			np.UpdatedAt = dbtime.Now()
 			q.notificationPreferences[j] = np
 
@@ -9207,6 +10525,7 @@ func (q *FakeQuerier) UpdateUserNotificationPreferences(_ context.Context, arg d
 		if !found {
 			np := database.NotificationPreference{
 				Disabled:               disabled,
+				Method:                 method,
 				UserID:                 arg.UserID,
 				NotificationTemplateID: templateID,
 				CreatedAt:              dbtime.Now(),
@@ -9425,6 +10744,24 @@ func (q *FakeQuerier) UpdateWorkspaceAgentMetadata(_ context.Context, arg databa
 	return nil
 }
 
+func (q *FakeQuerier) UpdateWorkspaceAgentSessionRecordingEndedAt(_ context.Context, arg database.UpdateWorkspaceAgentSessionRecordingEndedAtParams) error {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for i, recording := range q.workspaceAgentSessionRecordings {
+		if recording.ID == arg.ID {
+			q.workspaceAgentSessionRecordings[i].EndedAt = arg.EndedAt
+			return nil
+		}
+	}
+	return nil
+}
+
 func (q *FakeQuerier) UpdateWorkspaceAgentStartupByID(_ context.Context, arg database.UpdateWorkspaceAgentStartupByIDParams) error {
 	if err := validateDatabaseType(arg); err != nil {
 		return err
@@ -9580,6 +10917,7 @@ func (q *FakeQuerier) UpdateWorkspaceBuildProvisionerStateByID(_ context.Context
 			continue
 		}
 		build.ProvisionerState = arg.ProvisionerState
+		build.ProvisionerStateRef = arg.ProvisionerStateRef
 		build.UpdatedAt = arg.UpdatedAt
 		q.workspaceBuilds[idx] = build
 		return nil
@@ -9588,6 +10926,27 @@ func (q *FakeQuerier) UpdateWorkspaceBuildProvisionerStateByID(_ context.Context
 	return sql.ErrNoRows
 }
 
+func (q *FakeQuerier) UpdateWorkspaceBuildProvisionerStateLocationByID(_ context.Context, arg database.UpdateWorkspaceBuildProvisionerStateLocationByIDParams) (int64, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return 0, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for idx, build := range q.workspaceBuilds {
+		if build.ID != arg.ID || !build.UpdatedAt.Equal(arg.UpdatedAt) {
+			continue
+		}
+		build.ProvisionerState = arg.ProvisionerState
+		build.ProvisionerStateRef = arg.ProvisionerStateRef
+		q.workspaceBuilds[idx] = build
+		return 1, nil
+	}
+	return 0, nil
+}
+
 func (q *FakeQuerier) UpdateWorkspaceDeletedByID(_ context.Context, arg database.UpdateWorkspaceDeletedByIDParams) error {
 	if err := validateDatabaseType(arg); err != nil {
 		return err
@@ -9706,6 +11065,25 @@ func (q *FakeQuerier) UpdateWorkspaceProxyDeleted(_ context.Context, arg databas
 	return sql.ErrNoRows
 }
 
+func (q *FakeQuerier) UpdateWorkspaceSnapshotDataByID(_ context.Context, arg database.UpdateWorkspaceSnapshotDataByIDParams) error {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for i, snapshot := range q.workspaceSnapshots {
+		if snapshot.ID == arg.ID {
+			snapshot.Data = arg.Data
+			q.workspaceSnapshots[i] = snapshot
+			return nil
+		}
+	}
+	return nil
+}
+
 func (q *FakeQuerier) UpdateWorkspaceTTL(_ context.Context, arg database.UpdateWorkspaceTTLParams) error {
 	if err := validateDatabaseType(arg); err != nil {
 		return err
@@ -10576,6 +11954,39 @@ TemplateUsageStatsInsertLoop:
 	return nil
 }
 
+func (q *FakeQuerier) UpsertUserNotificationQuietHours(_ context.Context, arg database.UpsertUserNotificationQuietHoursParams) (database.NotificationQuietHour, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return database.NotificationQuietHour{}, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for i, qh := range q.notificationQuietHours {
+		if qh.UserID != arg.UserID {
+			continue
+		}
+		qh.StartMinute = arg.StartMinute
+		qh.EndMinute = arg.EndMinute
+		qh.Timezone = arg.Timezone
+		qh.UpdatedAt = dbtime.Now()
+		q.notificationQuietHours[i] = qh
+		return qh, nil
+	}
+
+	qh := database.NotificationQuietHour{
+		UserID:      arg.UserID,
+		StartMinute: arg.StartMinute,
+		EndMinute:   arg.EndMinute,
+		Timezone:    arg.Timezone,
+		CreatedAt:   dbtime.Now(),
+		UpdatedAt:   dbtime.Now(),
+	}
+	q.notificationQuietHours = append(q.notificationQuietHours, qh)
+	return qh, nil
+}
+
 func (q *FakeQuerier) UpsertWorkspaceAgentPortShare(_ context.Context, arg database.UpsertWorkspaceAgentPortShareParams) (database.WorkspaceAgentPortShare, error) {
 	err := validateDatabaseType(arg)
 	if err != nil {
@@ -10889,35 +12300,7 @@ func (q *FakeQuerier) GetAuthorizedWorkspaces(ctx context.Context, arg database.
 				return nil, xerrors.Errorf("get provisioner job: %w", err)
 			}
 
-			// This logic should match the logic in the workspace.sql file.
-			var statusMatch bool
-			switch database.WorkspaceStatus(arg.Status) {
-			case database.WorkspaceStatusStarting:
-				statusMatch = job.JobStatus == database.ProvisionerJobStatusRunning &&
-					build.Transition == database.WorkspaceTransitionStart
-			case database.WorkspaceStatusStopping:
-				statusMatch = job.JobStatus == database.ProvisionerJobStatusRunning &&
-					build.Transition == database.WorkspaceTransitionStop
-			case database.WorkspaceStatusDeleting:
-				statusMatch = job.JobStatus == database.ProvisionerJobStatusRunning &&
-					build.Transition == database.WorkspaceTransitionDelete
-
-			case "started":
-				statusMatch = job.JobStatus == database.ProvisionerJobStatusSucceeded &&
-					build.Transition == database.WorkspaceTransitionStart
-			case database.WorkspaceStatusDeleted:
-				statusMatch = job.JobStatus == database.ProvisionerJobStatusSucceeded &&
-					build.Transition == database.WorkspaceTransitionDelete
-			case database.WorkspaceStatusStopped:
-				statusMatch = job.JobStatus == database.ProvisionerJobStatusSucceeded &&
-					build.Transition == database.WorkspaceTransitionStop
-			case database.WorkspaceStatusRunning:
-				statusMatch = job.JobStatus == database.ProvisionerJobStatusSucceeded &&
-					build.Transition == database.WorkspaceTransitionStart
-			default:
-				statusMatch = job.JobStatus == database.ProvisionerJobStatus(arg.Status)
-			}
-			if !statusMatch {
+			if !workspaceStatusMatches(build, job, arg.Status) {
 				continue
 			}
 		}
@@ -10933,26 +12316,9 @@ func (q *FakeQuerier) GetAuthorizedWorkspaces(ctx context.Context, arg database.
 				return nil, xerrors.Errorf("get provisioner job: %w", err)
 			}
 
-			workspaceResources, err := q.getWorkspaceResourcesByJobIDNoLock(ctx, job.ID)
-			if err != nil {
-				return nil, xerrors.Errorf("get workspace resources: %w", err)
-			}
-
-			var workspaceResourceIDs []uuid.UUID
-			for _, wr := range workspaceResources {
-				workspaceResourceIDs = append(workspaceResourceIDs, wr.ID)
-			}
-
-			workspaceAgents, err := q.getWorkspaceAgentsByResourceIDsNoLock(ctx, workspaceResourceIDs)
+			hasAgentMatched, err := q.hasAgentStatusNoLock(ctx, job.ID, arg.HasAgent, arg.AgentInactiveDisconnectTimeoutSeconds)
 			if err != nil {
-				return nil, xerrors.Errorf("get workspace agents: %w", err)
-			}
-
-			var hasAgentMatched bool
-			for _, wa := range workspaceAgents {
-				if mapAgentStatus(wa, arg.AgentInactiveDisconnectTimeoutSeconds) == arg.HasAgent {
-					hasAgentMatched = true
-				}
+				return nil, err
 			}
 
 			if !hasAgentMatched {
@@ -10964,6 +12330,24 @@ func (q *FakeQuerier) GetAuthorizedWorkspaces(ctx context.Context, arg database.
 			continue
 		}
 
+		if !arg.CreatedBefore.IsZero() && workspace.CreatedAt.After(arg.CreatedBefore) {
+			continue
+		}
+
+		if !arg.CreatedAfter.IsZero() && workspace.CreatedAt.Before(arg.CreatedAfter) {
+			continue
+		}
+
+		if len(arg.TermGroups) > 0 {
+			match, err := q.workspaceMatchesTermsNoLock(ctx, workspace, arg)
+			if err != nil {
+				return nil, err
+			}
+			if !match {
+				continue
+			}
+		}
+
 		if len(arg.TemplateIDs) > 0 {
 			match := false
 			for _, id := range arg.TemplateIDs {
@@ -63,6 +63,342 @@ type metricsStore struct {
 	txDuration     prometheus.Histogram
 }
 
+func (m metricsStore) CountUnreadInboxNotificationsByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	start := time.Now()
+	r0, r1 := m.s.CountUnreadInboxNotificationsByUserID(ctx, userID)
+	m.queryLatencies.WithLabelValues("CountUnreadInboxNotificationsByUserID").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) DeleteAuditLogsByIDs(ctx context.Context, ids []uuid.UUID) error {
+	start := time.Now()
+	r0 := m.s.DeleteAuditLogsByIDs(ctx, ids)
+	m.queryLatencies.WithLabelValues("DeleteAuditLogsByIDs").Observe(time.Since(start).Seconds())
+	return r0
+}
+
+func (m metricsStore) DeleteOldWorkspaceAgentMetadataHistory(ctx context.Context, arg database.DeleteOldWorkspaceAgentMetadataHistoryParams) error {
+	start := time.Now()
+	r0 := m.s.DeleteOldWorkspaceAgentMetadataHistory(ctx, arg)
+	m.queryLatencies.WithLabelValues("DeleteOldWorkspaceAgentMetadataHistory").Observe(time.Since(start).Seconds())
+	return r0
+}
+
+func (m metricsStore) DeleteSavedSearchByID(ctx context.Context, id uuid.UUID) error {
+	start := time.Now()
+	r0 := m.s.DeleteSavedSearchByID(ctx, id)
+	m.queryLatencies.WithLabelValues("DeleteSavedSearchByID").Observe(time.Since(start).Seconds())
+	return r0
+}
+
+func (m metricsStore) DeleteUserNotificationQuietHours(ctx context.Context, userID uuid.UUID) error {
+	start := time.Now()
+	r0 := m.s.DeleteUserNotificationQuietHours(ctx, userID)
+	m.queryLatencies.WithLabelValues("DeleteUserNotificationQuietHours").Observe(time.Since(start).Seconds())
+	return r0
+}
+
+func (m metricsStore) DeleteWorkspaceAgentContainersByAgentID(ctx context.Context, workspaceAgentID uuid.UUID) error {
+	start := time.Now()
+	r0 := m.s.DeleteWorkspaceAgentContainersByAgentID(ctx, workspaceAgentID)
+	m.queryLatencies.WithLabelValues("DeleteWorkspaceAgentContainersByAgentID").Observe(time.Since(start).Seconds())
+	return r0
+}
+
+func (m metricsStore) DeleteWorkspaceSnapshotByID(ctx context.Context, id uuid.UUID) error {
+	start := time.Now()
+	r0 := m.s.DeleteWorkspaceSnapshotByID(ctx, id)
+	m.queryLatencies.WithLabelValues("DeleteWorkspaceSnapshotByID").Observe(time.Since(start).Seconds())
+	return r0
+}
+
+func (m metricsStore) GetActiveWorkspaceSnapshotJobsByWorkspaceID(ctx context.Context, workspaceID uuid.UUID) ([]database.ProvisionerJob, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetActiveWorkspaceSnapshotJobsByWorkspaceID(ctx, workspaceID)
+	m.queryLatencies.WithLabelValues("GetActiveWorkspaceSnapshotJobsByWorkspaceID").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) GetAuditLogsBefore(ctx context.Context, arg database.GetAuditLogsBeforeParams) ([]database.GetAuditLogsBeforeRow, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetAuditLogsBefore(ctx, arg)
+	m.queryLatencies.WithLabelValues("GetAuditLogsBefore").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) GetInProgressWorkspaceDriftCheckCountsByTemplate(ctx context.Context) ([]database.GetInProgressWorkspaceDriftCheckCountsByTemplateRow, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetInProgressWorkspaceDriftCheckCountsByTemplate(ctx)
+	m.queryLatencies.WithLabelValues("GetInProgressWorkspaceDriftCheckCountsByTemplate").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) GetInboxNotificationByID(ctx context.Context, id uuid.UUID) (database.InboxNotification, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetInboxNotificationByID(ctx, id)
+	m.queryLatencies.WithLabelValues("GetInboxNotificationByID").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) GetInboxNotificationsByUserID(ctx context.Context, arg database.GetInboxNotificationsByUserIDParams) ([]database.InboxNotification, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetInboxNotificationsByUserID(ctx, arg)
+	m.queryLatencies.WithLabelValues("GetInboxNotificationsByUserID").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) GetNotificationMessagesAwaitingDigest(ctx context.Context) ([]database.NotificationMessage, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetNotificationMessagesAwaitingDigest(ctx)
+	m.queryLatencies.WithLabelValues("GetNotificationMessagesAwaitingDigest").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) GetPendingProvisionerJobIaCRequirements(ctx context.Context, organizationID uuid.UUID) ([]string, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetPendingProvisionerJobIaCRequirements(ctx, organizationID)
+	m.queryLatencies.WithLabelValues("GetPendingProvisionerJobIaCRequirements").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) GetProvisionerJobResourceChangesByJobID(ctx context.Context, jobID uuid.UUID) ([]database.ProvisionerJobResourceChange, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetProvisionerJobResourceChangesByJobID(ctx, jobID)
+	m.queryLatencies.WithLabelValues("GetProvisionerJobResourceChangesByJobID").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) GetSavedSearchByID(ctx context.Context, id uuid.UUID) (database.SavedSearch, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetSavedSearchByID(ctx, id)
+	m.queryLatencies.WithLabelValues("GetSavedSearchByID").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) GetSavedSearches(ctx context.Context, arg database.GetSavedSearchesParams) ([]database.SavedSearch, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetSavedSearches(ctx, arg)
+	m.queryLatencies.WithLabelValues("GetSavedSearches").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) GetUserNotificationQuietHours(ctx context.Context, userID uuid.UUID) (database.NotificationQuietHour, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetUserNotificationQuietHours(ctx, userID)
+	m.queryLatencies.WithLabelValues("GetUserNotificationQuietHours").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) GetWorkspaceAgentContainersByAgentID(ctx context.Context, workspaceAgentID uuid.UUID) ([]database.WorkspaceAgentContainer, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetWorkspaceAgentContainersByAgentID(ctx, workspaceAgentID)
+	m.queryLatencies.WithLabelValues("GetWorkspaceAgentContainersByAgentID").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) GetWorkspaceAgentMetadataHistory(ctx context.Context, arg database.GetWorkspaceAgentMetadataHistoryParams) ([]database.WorkspaceAgentMetadataHistory, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetWorkspaceAgentMetadataHistory(ctx, arg)
+	m.queryLatencies.WithLabelValues("GetWorkspaceAgentMetadataHistory").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) GetWorkspaceAgentScriptTimingsByScriptID(ctx context.Context, arg database.GetWorkspaceAgentScriptTimingsByScriptIDParams) ([]database.WorkspaceAgentScriptTiming, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetWorkspaceAgentScriptTimingsByScriptID(ctx, arg)
+	m.queryLatencies.WithLabelValues("GetWorkspaceAgentScriptTimingsByScriptID").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) GetWorkspaceAgentSessionRecordingByID(ctx context.Context, id uuid.UUID) (database.WorkspaceAgentSessionRecording, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetWorkspaceAgentSessionRecordingByID(ctx, id)
+	m.queryLatencies.WithLabelValues("GetWorkspaceAgentSessionRecordingByID").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) GetWorkspaceAgentSessionRecordingChunks(ctx context.Context, recordingID uuid.UUID) ([]database.WorkspaceAgentSessionRecordingChunk, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetWorkspaceAgentSessionRecordingChunks(ctx, recordingID)
+	m.queryLatencies.WithLabelValues("GetWorkspaceAgentSessionRecordingChunks").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) GetWorkspaceAgentSessionRecordingsByWorkspaceID(ctx context.Context, workspaceID uuid.UUID) ([]database.WorkspaceAgentSessionRecording, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetWorkspaceAgentSessionRecordingsByWorkspaceID(ctx, workspaceID)
+	m.queryLatencies.WithLabelValues("GetWorkspaceAgentSessionRecordingsByWorkspaceID").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) GetWorkspaceBuildsToMigrateProvisionerState(ctx context.Context, arg database.GetWorkspaceBuildsToMigrateProvisionerStateParams) ([]database.WorkspaceBuild, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetWorkspaceBuildsToMigrateProvisionerState(ctx, arg)
+	m.queryLatencies.WithLabelValues("GetWorkspaceBuildsToMigrateProvisionerState").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) GetWorkspaceDriftedResourcesByWorkspaceIDs(ctx context.Context, ids []uuid.UUID) ([]database.GetWorkspaceDriftedResourcesByWorkspaceIDsRow, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetWorkspaceDriftedResourcesByWorkspaceIDs(ctx, ids)
+	m.queryLatencies.WithLabelValues("GetWorkspaceDriftedResourcesByWorkspaceIDs").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) GetWorkspaceSnapshotByID(ctx context.Context, id uuid.UUID) (database.WorkspaceSnapshot, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetWorkspaceSnapshotByID(ctx, id)
+	m.queryLatencies.WithLabelValues("GetWorkspaceSnapshotByID").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) GetWorkspaceSnapshotsByWorkspaceID(ctx context.Context, workspaceID uuid.UUID) ([]database.WorkspaceSnapshot, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetWorkspaceSnapshotsByWorkspaceID(ctx, workspaceID)
+	m.queryLatencies.WithLabelValues("GetWorkspaceSnapshotsByWorkspaceID").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) GetWorkspacesEligibleForDriftCheck(ctx context.Context, checkedBefore time.Time) ([]database.GetWorkspacesEligibleForDriftCheckRow, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetWorkspacesEligibleForDriftCheck(ctx, checkedBefore)
+	m.queryLatencies.WithLabelValues("GetWorkspacesEligibleForDriftCheck").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) InsertInboxNotification(ctx context.Context, arg database.InsertInboxNotificationParams) (int64, error) {
+	start := time.Now()
+	r0, r1 := m.s.InsertInboxNotification(ctx, arg)
+	m.queryLatencies.WithLabelValues("InsertInboxNotification").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) InsertProvisionerJobResourceChange(ctx context.Context, arg database.InsertProvisionerJobResourceChangeParams) (database.ProvisionerJobResourceChange, error) {
+	start := time.Now()
+	r0, r1 := m.s.InsertProvisionerJobResourceChange(ctx, arg)
+	m.queryLatencies.WithLabelValues("InsertProvisionerJobResourceChange").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) InsertSavedSearch(ctx context.Context, arg database.InsertSavedSearchParams) (database.SavedSearch, error) {
+	start := time.Now()
+	r0, r1 := m.s.InsertSavedSearch(ctx, arg)
+	m.queryLatencies.WithLabelValues("InsertSavedSearch").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) InsertWorkspaceAgentContainer(ctx context.Context, arg database.InsertWorkspaceAgentContainerParams) error {
+	start := time.Now()
+	r0 := m.s.InsertWorkspaceAgentContainer(ctx, arg)
+	m.queryLatencies.WithLabelValues("InsertWorkspaceAgentContainer").Observe(time.Since(start).Seconds())
+	return r0
+}
+
+func (m metricsStore) InsertWorkspaceAgentMetadataHistory(ctx context.Context, arg database.InsertWorkspaceAgentMetadataHistoryParams) error {
+	start := time.Now()
+	r0 := m.s.InsertWorkspaceAgentMetadataHistory(ctx, arg)
+	m.queryLatencies.WithLabelValues("InsertWorkspaceAgentMetadataHistory").Observe(time.Since(start).Seconds())
+	return r0
+}
+
+func (m metricsStore) InsertWorkspaceAgentSessionRecording(ctx context.Context, arg database.InsertWorkspaceAgentSessionRecordingParams) (database.WorkspaceAgentSessionRecording, error) {
+	start := time.Now()
+	r0, r1 := m.s.InsertWorkspaceAgentSessionRecording(ctx, arg)
+	m.queryLatencies.WithLabelValues("InsertWorkspaceAgentSessionRecording").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) InsertWorkspaceAgentSessionRecordingChunk(ctx context.Context, arg database.InsertWorkspaceAgentSessionRecordingChunkParams) error {
+	start := time.Now()
+	r0 := m.s.InsertWorkspaceAgentSessionRecordingChunk(ctx, arg)
+	m.queryLatencies.WithLabelValues("InsertWorkspaceAgentSessionRecordingChunk").Observe(time.Since(start).Seconds())
+	return r0
+}
+
+func (m metricsStore) InsertWorkspaceDriftCheck(ctx context.Context, arg database.InsertWorkspaceDriftCheckParams) (database.WorkspaceDriftCheck, error) {
+	start := time.Now()
+	r0, r1 := m.s.InsertWorkspaceDriftCheck(ctx, arg)
+	m.queryLatencies.WithLabelValues("InsertWorkspaceDriftCheck").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) InsertWorkspaceSnapshot(ctx context.Context, arg database.InsertWorkspaceSnapshotParams) (database.WorkspaceSnapshot, error) {
+	start := time.Now()
+	r0, r1 := m.s.InsertWorkspaceSnapshot(ctx, arg)
+	m.queryLatencies.WithLabelValues("InsertWorkspaceSnapshot").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) LockWorkspaceByID(ctx context.Context, id uuid.UUID) error {
+	start := time.Now()
+	r0 := m.s.LockWorkspaceByID(ctx, id)
+	m.queryLatencies.WithLabelValues("LockWorkspaceByID").Observe(time.Since(start).Seconds())
+	return r0
+}
+
+func (m metricsStore) MarkAllInboxNotificationsAsRead(ctx context.Context, arg database.MarkAllInboxNotificationsAsReadParams) (int64, error) {
+	start := time.Now()
+	r0, r1 := m.s.MarkAllInboxNotificationsAsRead(ctx, arg)
+	m.queryLatencies.WithLabelValues("MarkAllInboxNotificationsAsRead").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) MarkNotificationMessagesDigested(ctx context.Context, arg database.MarkNotificationMessagesDigestedParams) error {
+	start := time.Now()
+	r0 := m.s.MarkNotificationMessagesDigested(ctx, arg)
+	m.queryLatencies.WithLabelValues("MarkNotificationMessagesDigested").Observe(time.Since(start).Seconds())
+	return r0
+}
+
+func (m metricsStore) ReleaseNotificationMessagesFromDigest(ctx context.Context, ids []uuid.UUID) error {
+	start := time.Now()
+	r0 := m.s.ReleaseNotificationMessagesFromDigest(ctx, ids)
+	m.queryLatencies.WithLabelValues("ReleaseNotificationMessagesFromDigest").Observe(time.Since(start).Seconds())
+	return r0
+}
+
+func (m metricsStore) UpdateInboxNotificationStatus(ctx context.Context, arg database.UpdateInboxNotificationStatusParams) (database.InboxNotification, error) {
+	start := time.Now()
+	r0, r1 := m.s.UpdateInboxNotificationStatus(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpdateInboxNotificationStatus").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) UpdateSavedSearch(ctx context.Context, arg database.UpdateSavedSearchParams) (database.SavedSearch, error) {
+	start := time.Now()
+	r0, r1 := m.s.UpdateSavedSearch(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpdateSavedSearch").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) UpdateWorkspaceAgentSessionRecordingEndedAt(ctx context.Context, arg database.UpdateWorkspaceAgentSessionRecordingEndedAtParams) error {
+	start := time.Now()
+	r0 := m.s.UpdateWorkspaceAgentSessionRecordingEndedAt(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpdateWorkspaceAgentSessionRecordingEndedAt").Observe(time.Since(start).Seconds())
+	return r0
+}
+
+func (m metricsStore) UpdateWorkspaceBuildProvisionerStateLocationByID(ctx context.Context, arg database.UpdateWorkspaceBuildProvisionerStateLocationByIDParams) (int64, error) {
+	start := time.Now()
+	r0, r1 := m.s.UpdateWorkspaceBuildProvisionerStateLocationByID(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpdateWorkspaceBuildProvisionerStateLocationByID").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) UpdateWorkspaceSnapshotDataByID(ctx context.Context, arg database.UpdateWorkspaceSnapshotDataByIDParams) error {
+	start := time.Now()
+	r0 := m.s.UpdateWorkspaceSnapshotDataByID(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpdateWorkspaceSnapshotDataByID").Observe(time.Since(start).Seconds())
+	return r0
+}
+
+func (m metricsStore) UpsertUserNotificationQuietHours(ctx context.Context, arg database.UpsertUserNotificationQuietHoursParams) (database.NotificationQuietHour, error) {
+	start := time.Now()
+	r0, r1 := m.s.UpsertUserNotificationQuietHours(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpsertUserNotificationQuietHours").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) Wrappers() []string {
 	return append(m.s.Wrappers(), wrapname)
 }
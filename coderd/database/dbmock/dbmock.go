@@ -218,6 +218,21 @@ func (mr *MockStoreMockRecorder) CleanTailnetTunnels(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanTailnetTunnels", reflect.TypeOf((*MockStore)(nil).CleanTailnetTunnels), arg0)
 }
 
+// CountUnreadInboxNotificationsByUserID mocks base method.
+func (m *MockStore) CountUnreadInboxNotificationsByUserID(arg0 context.Context, arg1 uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUnreadInboxNotificationsByUserID", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUnreadInboxNotificationsByUserID indicates an expected call of CountUnreadInboxNotificationsByUserID.
+func (mr *MockStoreMockRecorder) CountUnreadInboxNotificationsByUserID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUnreadInboxNotificationsByUserID", reflect.TypeOf((*MockStore)(nil).CountUnreadInboxNotificationsByUserID), arg0, arg1)
+}
+
 // CustomRoles mocks base method.
 func (m *MockStore) CustomRoles(arg0 context.Context, arg1 database.CustomRolesParams) ([]database.CustomRole, error) {
 	m.ctrl.T.Helper()
@@ -303,6 +318,20 @@ func (mr *MockStoreMockRecorder) DeleteApplicationConnectAPIKeysByUserID(arg0, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteApplicationConnectAPIKeysByUserID", reflect.TypeOf((*MockStore)(nil).DeleteApplicationConnectAPIKeysByUserID), arg0, arg1)
 }
 
+// DeleteAuditLogsByIDs mocks base method.
+func (m *MockStore) DeleteAuditLogsByIDs(arg0 context.Context, arg1 []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAuditLogsByIDs", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAuditLogsByIDs indicates an expected call of DeleteAuditLogsByIDs.
+func (mr *MockStoreMockRecorder) DeleteAuditLogsByIDs(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAuditLogsByIDs", reflect.TypeOf((*MockStore)(nil).DeleteAuditLogsByIDs), arg0, arg1)
+}
+
 // DeleteCoordinator mocks base method.
 func (m *MockStore) DeleteCoordinator(arg0 context.Context, arg1 uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -529,6 +558,20 @@ func (mr *MockStoreMockRecorder) DeleteOldWorkspaceAgentLogs(arg0, arg1 any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOldWorkspaceAgentLogs", reflect.TypeOf((*MockStore)(nil).DeleteOldWorkspaceAgentLogs), arg0, arg1)
 }
 
+// DeleteOldWorkspaceAgentMetadataHistory mocks base method.
+func (m *MockStore) DeleteOldWorkspaceAgentMetadataHistory(arg0 context.Context, arg1 database.DeleteOldWorkspaceAgentMetadataHistoryParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOldWorkspaceAgentMetadataHistory", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOldWorkspaceAgentMetadataHistory indicates an expected call of DeleteOldWorkspaceAgentMetadataHistory.
+func (mr *MockStoreMockRecorder) DeleteOldWorkspaceAgentMetadataHistory(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOldWorkspaceAgentMetadataHistory", reflect.TypeOf((*MockStore)(nil).DeleteOldWorkspaceAgentMetadataHistory), arg0, arg1)
+}
+
 // DeleteOldWorkspaceAgentStats mocks base method.
 func (m *MockStore) DeleteOldWorkspaceAgentStats(arg0 context.Context) error {
 	m.ctrl.T.Helper()
@@ -613,6 +656,20 @@ func (mr *MockStoreMockRecorder) DeleteRuntimeConfig(arg0, arg1 any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRuntimeConfig", reflect.TypeOf((*MockStore)(nil).DeleteRuntimeConfig), arg0, arg1)
 }
 
+// DeleteSavedSearchByID mocks base method.
+func (m *MockStore) DeleteSavedSearchByID(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSavedSearchByID", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSavedSearchByID indicates an expected call of DeleteSavedSearchByID.
+func (mr *MockStoreMockRecorder) DeleteSavedSearchByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSavedSearchByID", reflect.TypeOf((*MockStore)(nil).DeleteSavedSearchByID), arg0, arg1)
+}
+
 // DeleteTailnetAgent mocks base method.
 func (m *MockStore) DeleteTailnetAgent(arg0 context.Context, arg1 database.DeleteTailnetAgentParams) (database.DeleteTailnetAgentRow, error) {
 	m.ctrl.T.Helper()
@@ -687,6 +744,34 @@ func (mr *MockStoreMockRecorder) DeleteTailnetTunnel(arg0, arg1 any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTailnetTunnel", reflect.TypeOf((*MockStore)(nil).DeleteTailnetTunnel), arg0, arg1)
 }
 
+// DeleteUserNotificationQuietHours mocks base method.
+func (m *MockStore) DeleteUserNotificationQuietHours(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserNotificationQuietHours", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserNotificationQuietHours indicates an expected call of DeleteUserNotificationQuietHours.
+func (mr *MockStoreMockRecorder) DeleteUserNotificationQuietHours(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserNotificationQuietHours", reflect.TypeOf((*MockStore)(nil).DeleteUserNotificationQuietHours), arg0, arg1)
+}
+
+// DeleteWorkspaceAgentContainersByAgentID mocks base method.
+func (m *MockStore) DeleteWorkspaceAgentContainersByAgentID(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWorkspaceAgentContainersByAgentID", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWorkspaceAgentContainersByAgentID indicates an expected call of DeleteWorkspaceAgentContainersByAgentID.
+func (mr *MockStoreMockRecorder) DeleteWorkspaceAgentContainersByAgentID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWorkspaceAgentContainersByAgentID", reflect.TypeOf((*MockStore)(nil).DeleteWorkspaceAgentContainersByAgentID), arg0, arg1)
+}
+
 // DeleteWorkspaceAgentPortShare mocks base method.
 func (m *MockStore) DeleteWorkspaceAgentPortShare(arg0 context.Context, arg1 database.DeleteWorkspaceAgentPortShareParams) error {
 	m.ctrl.T.Helper()
@@ -715,6 +800,20 @@ func (mr *MockStoreMockRecorder) DeleteWorkspaceAgentPortSharesByTemplate(arg0,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWorkspaceAgentPortSharesByTemplate", reflect.TypeOf((*MockStore)(nil).DeleteWorkspaceAgentPortSharesByTemplate), arg0, arg1)
 }
 
+// DeleteWorkspaceSnapshotByID mocks base method.
+func (m *MockStore) DeleteWorkspaceSnapshotByID(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWorkspaceSnapshotByID", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWorkspaceSnapshotByID indicates an expected call of DeleteWorkspaceSnapshotByID.
+func (mr *MockStoreMockRecorder) DeleteWorkspaceSnapshotByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWorkspaceSnapshotByID", reflect.TypeOf((*MockStore)(nil).DeleteWorkspaceSnapshotByID), arg0, arg1)
+}
+
 // EnqueueNotificationMessage mocks base method.
 func (m *MockStore) EnqueueNotificationMessage(arg0 context.Context, arg1 database.EnqueueNotificationMessageParams) error {
 	m.ctrl.T.Helper()
@@ -863,6 +962,21 @@ func (mr *MockStoreMockRecorder) GetActiveWorkspaceBuildsByTemplateID(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveWorkspaceBuildsByTemplateID", reflect.TypeOf((*MockStore)(nil).GetActiveWorkspaceBuildsByTemplateID), arg0, arg1)
 }
 
+// GetActiveWorkspaceSnapshotJobsByWorkspaceID mocks base method.
+func (m *MockStore) GetActiveWorkspaceSnapshotJobsByWorkspaceID(arg0 context.Context, arg1 uuid.UUID) ([]database.ProvisionerJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveWorkspaceSnapshotJobsByWorkspaceID", arg0, arg1)
+	ret0, _ := ret[0].([]database.ProvisionerJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveWorkspaceSnapshotJobsByWorkspaceID indicates an expected call of GetActiveWorkspaceSnapshotJobsByWorkspaceID.
+func (mr *MockStoreMockRecorder) GetActiveWorkspaceSnapshotJobsByWorkspaceID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveWorkspaceSnapshotJobsByWorkspaceID", reflect.TypeOf((*MockStore)(nil).GetActiveWorkspaceSnapshotJobsByWorkspaceID), arg0, arg1)
+}
+
 // GetAllTailnetAgents mocks base method.
 func (m *MockStore) GetAllTailnetAgents(arg0 context.Context) ([]database.TailnetAgent, error) {
 	m.ctrl.T.Helper()
@@ -968,6 +1082,21 @@ func (mr *MockStoreMockRecorder) GetApplicationName(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApplicationName", reflect.TypeOf((*MockStore)(nil).GetApplicationName), arg0)
 }
 
+// GetAuditLogsBefore mocks base method.
+func (m *MockStore) GetAuditLogsBefore(arg0 context.Context, arg1 database.GetAuditLogsBeforeParams) ([]database.GetAuditLogsBeforeRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditLogsBefore", arg0, arg1)
+	ret0, _ := ret[0].([]database.GetAuditLogsBeforeRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuditLogsBefore indicates an expected call of GetAuditLogsBefore.
+func (mr *MockStoreMockRecorder) GetAuditLogsBefore(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditLogsBefore", reflect.TypeOf((*MockStore)(nil).GetAuditLogsBefore), arg0, arg1)
+}
+
 // GetAuditLogsOffset mocks base method.
 func (m *MockStore) GetAuditLogsOffset(arg0 context.Context, arg1 database.GetAuditLogsOffsetParams) ([]database.GetAuditLogsOffsetRow, error) {
 	m.ctrl.T.Helper()
@@ -1463,6 +1592,51 @@ func (mr *MockStoreMockRecorder) GetHungProvisionerJobs(arg0, arg1 any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHungProvisionerJobs", reflect.TypeOf((*MockStore)(nil).GetHungProvisionerJobs), arg0, arg1)
 }
 
+// GetInProgressWorkspaceDriftCheckCountsByTemplate mocks base method.
+func (m *MockStore) GetInProgressWorkspaceDriftCheckCountsByTemplate(arg0 context.Context) ([]database.GetInProgressWorkspaceDriftCheckCountsByTemplateRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInProgressWorkspaceDriftCheckCountsByTemplate", arg0)
+	ret0, _ := ret[0].([]database.GetInProgressWorkspaceDriftCheckCountsByTemplateRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInProgressWorkspaceDriftCheckCountsByTemplate indicates an expected call of GetInProgressWorkspaceDriftCheckCountsByTemplate.
+func (mr *MockStoreMockRecorder) GetInProgressWorkspaceDriftCheckCountsByTemplate(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInProgressWorkspaceDriftCheckCountsByTemplate", reflect.TypeOf((*MockStore)(nil).GetInProgressWorkspaceDriftCheckCountsByTemplate), arg0)
+}
+
+// GetInboxNotificationByID mocks base method.
+func (m *MockStore) GetInboxNotificationByID(arg0 context.Context, arg1 uuid.UUID) (database.InboxNotification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInboxNotificationByID", arg0, arg1)
+	ret0, _ := ret[0].(database.InboxNotification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInboxNotificationByID indicates an expected call of GetInboxNotificationByID.
+func (mr *MockStoreMockRecorder) GetInboxNotificationByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInboxNotificationByID", reflect.TypeOf((*MockStore)(nil).GetInboxNotificationByID), arg0, arg1)
+}
+
+// GetInboxNotificationsByUserID mocks base method.
+func (m *MockStore) GetInboxNotificationsByUserID(arg0 context.Context, arg1 database.GetInboxNotificationsByUserIDParams) ([]database.InboxNotification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInboxNotificationsByUserID", arg0, arg1)
+	ret0, _ := ret[0].([]database.InboxNotification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInboxNotificationsByUserID indicates an expected call of GetInboxNotificationsByUserID.
+func (mr *MockStoreMockRecorder) GetInboxNotificationsByUserID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInboxNotificationsByUserID", reflect.TypeOf((*MockStore)(nil).GetInboxNotificationsByUserID), arg0, arg1)
+}
+
 // GetJFrogXrayScanByWorkspaceAndAgentID mocks base method.
 func (m *MockStore) GetJFrogXrayScanByWorkspaceAndAgentID(arg0 context.Context, arg1 database.GetJFrogXrayScanByWorkspaceAndAgentIDParams) (database.JfrogXrayScan, error) {
 	m.ctrl.T.Helper()
@@ -1598,6 +1772,21 @@ func (mr *MockStoreMockRecorder) GetLogoURL(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogoURL", reflect.TypeOf((*MockStore)(nil).GetLogoURL), arg0)
 }
 
+// GetNotificationMessagesAwaitingDigest mocks base method.
+func (m *MockStore) GetNotificationMessagesAwaitingDigest(arg0 context.Context) ([]database.NotificationMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationMessagesAwaitingDigest", arg0)
+	ret0, _ := ret[0].([]database.NotificationMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotificationMessagesAwaitingDigest indicates an expected call of GetNotificationMessagesAwaitingDigest.
+func (mr *MockStoreMockRecorder) GetNotificationMessagesAwaitingDigest(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationMessagesAwaitingDigest", reflect.TypeOf((*MockStore)(nil).GetNotificationMessagesAwaitingDigest), arg0)
+}
+
 // GetNotificationMessagesByStatus mocks base method.
 func (m *MockStore) GetNotificationMessagesByStatus(arg0 context.Context, arg1 database.GetNotificationMessagesByStatusParams) ([]database.NotificationMessage, error) {
 	m.ctrl.T.Helper()
@@ -1913,6 +2102,21 @@ func (mr *MockStoreMockRecorder) GetParameterSchemasByJobID(arg0, arg1 any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetParameterSchemasByJobID", reflect.TypeOf((*MockStore)(nil).GetParameterSchemasByJobID), arg0, arg1)
 }
 
+// GetPendingProvisionerJobIaCRequirements mocks base method.
+func (m *MockStore) GetPendingProvisionerJobIaCRequirements(arg0 context.Context, arg1 uuid.UUID) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingProvisionerJobIaCRequirements", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingProvisionerJobIaCRequirements indicates an expected call of GetPendingProvisionerJobIaCRequirements.
+func (mr *MockStoreMockRecorder) GetPendingProvisionerJobIaCRequirements(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingProvisionerJobIaCRequirements", reflect.TypeOf((*MockStore)(nil).GetPendingProvisionerJobIaCRequirements), arg0, arg1)
+}
+
 // GetPreviousTemplateVersion mocks base method.
 func (m *MockStore) GetPreviousTemplateVersion(arg0 context.Context, arg1 database.GetPreviousTemplateVersionParams) (database.TemplateVersion, error) {
 	m.ctrl.T.Helper()
@@ -1973,6 +2177,21 @@ func (mr *MockStoreMockRecorder) GetProvisionerJobByID(arg0, arg1 any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProvisionerJobByID", reflect.TypeOf((*MockStore)(nil).GetProvisionerJobByID), arg0, arg1)
 }
 
+// GetProvisionerJobResourceChangesByJobID mocks base method.
+func (m *MockStore) GetProvisionerJobResourceChangesByJobID(arg0 context.Context, arg1 uuid.UUID) ([]database.ProvisionerJobResourceChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProvisionerJobResourceChangesByJobID", arg0, arg1)
+	ret0, _ := ret[0].([]database.ProvisionerJobResourceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProvisionerJobResourceChangesByJobID indicates an expected call of GetProvisionerJobResourceChangesByJobID.
+func (mr *MockStoreMockRecorder) GetProvisionerJobResourceChangesByJobID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProvisionerJobResourceChangesByJobID", reflect.TypeOf((*MockStore)(nil).GetProvisionerJobResourceChangesByJobID), arg0, arg1)
+}
+
 // GetProvisionerJobTimingsByJobID mocks base method.
 func (m *MockStore) GetProvisionerJobTimingsByJobID(arg0 context.Context, arg1 uuid.UUID) ([]database.ProvisionerJobTiming, error) {
 	m.ctrl.T.Helper()
@@ -2168,6 +2387,36 @@ func (mr *MockStoreMockRecorder) GetRuntimeConfig(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRuntimeConfig", reflect.TypeOf((*MockStore)(nil).GetRuntimeConfig), arg0, arg1)
 }
 
+// GetSavedSearchByID mocks base method.
+func (m *MockStore) GetSavedSearchByID(arg0 context.Context, arg1 uuid.UUID) (database.SavedSearch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSavedSearchByID", arg0, arg1)
+	ret0, _ := ret[0].(database.SavedSearch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSavedSearchByID indicates an expected call of GetSavedSearchByID.
+func (mr *MockStoreMockRecorder) GetSavedSearchByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSavedSearchByID", reflect.TypeOf((*MockStore)(nil).GetSavedSearchByID), arg0, arg1)
+}
+
+// GetSavedSearches mocks base method.
+func (m *MockStore) GetSavedSearches(arg0 context.Context, arg1 database.GetSavedSearchesParams) ([]database.SavedSearch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSavedSearches", arg0, arg1)
+	ret0, _ := ret[0].([]database.SavedSearch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSavedSearches indicates an expected call of GetSavedSearches.
+func (mr *MockStoreMockRecorder) GetSavedSearches(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSavedSearches", reflect.TypeOf((*MockStore)(nil).GetSavedSearches), arg0, arg1)
+}
+
 // GetTailnetAgents mocks base method.
 func (m *MockStore) GetTailnetAgents(arg0 context.Context, arg1 uuid.UUID) ([]database.TailnetAgent, error) {
 	m.ctrl.T.Helper()
@@ -2753,6 +3002,21 @@ func (mr *MockStoreMockRecorder) GetUserNotificationPreferences(arg0, arg1 any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserNotificationPreferences", reflect.TypeOf((*MockStore)(nil).GetUserNotificationPreferences), arg0, arg1)
 }
 
+// GetUserNotificationQuietHours mocks base method.
+func (m *MockStore) GetUserNotificationQuietHours(arg0 context.Context, arg1 uuid.UUID) (database.NotificationQuietHour, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserNotificationQuietHours", arg0, arg1)
+	ret0, _ := ret[0].(database.NotificationQuietHour)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserNotificationQuietHours indicates an expected call of GetUserNotificationQuietHours.
+func (mr *MockStoreMockRecorder) GetUserNotificationQuietHours(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserNotificationQuietHours", reflect.TypeOf((*MockStore)(nil).GetUserNotificationQuietHours), arg0, arg1)
+}
+
 // GetUserWorkspaceBuildParameters mocks base method.
 func (m *MockStore) GetUserWorkspaceBuildParameters(arg0 context.Context, arg1 database.GetUserWorkspaceBuildParametersParams) ([]database.GetUserWorkspaceBuildParametersRow, error) {
 	m.ctrl.T.Helper()
@@ -2843,6 +3107,21 @@ func (mr *MockStoreMockRecorder) GetWorkspaceAgentByInstanceID(arg0, arg1 any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceAgentByInstanceID", reflect.TypeOf((*MockStore)(nil).GetWorkspaceAgentByInstanceID), arg0, arg1)
 }
 
+// GetWorkspaceAgentContainersByAgentID mocks base method.
+func (m *MockStore) GetWorkspaceAgentContainersByAgentID(arg0 context.Context, arg1 uuid.UUID) ([]database.WorkspaceAgentContainer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceAgentContainersByAgentID", arg0, arg1)
+	ret0, _ := ret[0].([]database.WorkspaceAgentContainer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceAgentContainersByAgentID indicates an expected call of GetWorkspaceAgentContainersByAgentID.
+func (mr *MockStoreMockRecorder) GetWorkspaceAgentContainersByAgentID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceAgentContainersByAgentID", reflect.TypeOf((*MockStore)(nil).GetWorkspaceAgentContainersByAgentID), arg0, arg1)
+}
+
 // GetWorkspaceAgentLifecycleStateByID mocks base method.
 func (m *MockStore) GetWorkspaceAgentLifecycleStateByID(arg0 context.Context, arg1 uuid.UUID) (database.GetWorkspaceAgentLifecycleStateByIDRow, error) {
 	m.ctrl.T.Helper()
@@ -2903,6 +3182,21 @@ func (mr *MockStoreMockRecorder) GetWorkspaceAgentMetadata(arg0, arg1 any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceAgentMetadata", reflect.TypeOf((*MockStore)(nil).GetWorkspaceAgentMetadata), arg0, arg1)
 }
 
+// GetWorkspaceAgentMetadataHistory mocks base method.
+func (m *MockStore) GetWorkspaceAgentMetadataHistory(arg0 context.Context, arg1 database.GetWorkspaceAgentMetadataHistoryParams) ([]database.WorkspaceAgentMetadataHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceAgentMetadataHistory", arg0, arg1)
+	ret0, _ := ret[0].([]database.WorkspaceAgentMetadataHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceAgentMetadataHistory indicates an expected call of GetWorkspaceAgentMetadataHistory.
+func (mr *MockStoreMockRecorder) GetWorkspaceAgentMetadataHistory(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceAgentMetadataHistory", reflect.TypeOf((*MockStore)(nil).GetWorkspaceAgentMetadataHistory), arg0, arg1)
+}
+
 // GetWorkspaceAgentPortShare mocks base method.
 func (m *MockStore) GetWorkspaceAgentPortShare(arg0 context.Context, arg1 database.GetWorkspaceAgentPortShareParams) (database.WorkspaceAgentPortShare, error) {
 	m.ctrl.T.Helper()
@@ -2918,6 +3212,21 @@ func (mr *MockStoreMockRecorder) GetWorkspaceAgentPortShare(arg0, arg1 any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceAgentPortShare", reflect.TypeOf((*MockStore)(nil).GetWorkspaceAgentPortShare), arg0, arg1)
 }
 
+// GetWorkspaceAgentScriptTimingsByScriptID mocks base method.
+func (m *MockStore) GetWorkspaceAgentScriptTimingsByScriptID(arg0 context.Context, arg1 database.GetWorkspaceAgentScriptTimingsByScriptIDParams) ([]database.WorkspaceAgentScriptTiming, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceAgentScriptTimingsByScriptID", arg0, arg1)
+	ret0, _ := ret[0].([]database.WorkspaceAgentScriptTiming)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceAgentScriptTimingsByScriptID indicates an expected call of GetWorkspaceAgentScriptTimingsByScriptID.
+func (mr *MockStoreMockRecorder) GetWorkspaceAgentScriptTimingsByScriptID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceAgentScriptTimingsByScriptID", reflect.TypeOf((*MockStore)(nil).GetWorkspaceAgentScriptTimingsByScriptID), arg0, arg1)
+}
+
 // GetWorkspaceAgentScriptsByAgentIDs mocks base method.
 func (m *MockStore) GetWorkspaceAgentScriptsByAgentIDs(arg0 context.Context, arg1 []uuid.UUID) ([]database.WorkspaceAgentScript, error) {
 	m.ctrl.T.Helper()
@@ -2933,6 +3242,51 @@ func (mr *MockStoreMockRecorder) GetWorkspaceAgentScriptsByAgentIDs(arg0, arg1 a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceAgentScriptsByAgentIDs", reflect.TypeOf((*MockStore)(nil).GetWorkspaceAgentScriptsByAgentIDs), arg0, arg1)
 }
 
+// GetWorkspaceAgentSessionRecordingByID mocks base method.
+func (m *MockStore) GetWorkspaceAgentSessionRecordingByID(arg0 context.Context, arg1 uuid.UUID) (database.WorkspaceAgentSessionRecording, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceAgentSessionRecordingByID", arg0, arg1)
+	ret0, _ := ret[0].(database.WorkspaceAgentSessionRecording)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceAgentSessionRecordingByID indicates an expected call of GetWorkspaceAgentSessionRecordingByID.
+func (mr *MockStoreMockRecorder) GetWorkspaceAgentSessionRecordingByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceAgentSessionRecordingByID", reflect.TypeOf((*MockStore)(nil).GetWorkspaceAgentSessionRecordingByID), arg0, arg1)
+}
+
+// GetWorkspaceAgentSessionRecordingChunks mocks base method.
+func (m *MockStore) GetWorkspaceAgentSessionRecordingChunks(arg0 context.Context, arg1 uuid.UUID) ([]database.WorkspaceAgentSessionRecordingChunk, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceAgentSessionRecordingChunks", arg0, arg1)
+	ret0, _ := ret[0].([]database.WorkspaceAgentSessionRecordingChunk)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceAgentSessionRecordingChunks indicates an expected call of GetWorkspaceAgentSessionRecordingChunks.
+func (mr *MockStoreMockRecorder) GetWorkspaceAgentSessionRecordingChunks(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceAgentSessionRecordingChunks", reflect.TypeOf((*MockStore)(nil).GetWorkspaceAgentSessionRecordingChunks), arg0, arg1)
+}
+
+// GetWorkspaceAgentSessionRecordingsByWorkspaceID mocks base method.
+func (m *MockStore) GetWorkspaceAgentSessionRecordingsByWorkspaceID(arg0 context.Context, arg1 uuid.UUID) ([]database.WorkspaceAgentSessionRecording, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceAgentSessionRecordingsByWorkspaceID", arg0, arg1)
+	ret0, _ := ret[0].([]database.WorkspaceAgentSessionRecording)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceAgentSessionRecordingsByWorkspaceID indicates an expected call of GetWorkspaceAgentSessionRecordingsByWorkspaceID.
+func (mr *MockStoreMockRecorder) GetWorkspaceAgentSessionRecordingsByWorkspaceID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceAgentSessionRecordingsByWorkspaceID", reflect.TypeOf((*MockStore)(nil).GetWorkspaceAgentSessionRecordingsByWorkspaceID), arg0, arg1)
+}
+
 // GetWorkspaceAgentStats mocks base method.
 func (m *MockStore) GetWorkspaceAgentStats(arg0 context.Context, arg1 time.Time) ([]database.GetWorkspaceAgentStatsRow, error) {
 	m.ctrl.T.Helper()
@@ -3203,6 +3557,21 @@ func (mr *MockStoreMockRecorder) GetWorkspaceBuildsCreatedAfter(arg0, arg1 any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceBuildsCreatedAfter", reflect.TypeOf((*MockStore)(nil).GetWorkspaceBuildsCreatedAfter), arg0, arg1)
 }
 
+// GetWorkspaceBuildsToMigrateProvisionerState mocks base method.
+func (m *MockStore) GetWorkspaceBuildsToMigrateProvisionerState(arg0 context.Context, arg1 database.GetWorkspaceBuildsToMigrateProvisionerStateParams) ([]database.WorkspaceBuild, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceBuildsToMigrateProvisionerState", arg0, arg1)
+	ret0, _ := ret[0].([]database.WorkspaceBuild)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceBuildsToMigrateProvisionerState indicates an expected call of GetWorkspaceBuildsToMigrateProvisionerState.
+func (mr *MockStoreMockRecorder) GetWorkspaceBuildsToMigrateProvisionerState(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceBuildsToMigrateProvisionerState", reflect.TypeOf((*MockStore)(nil).GetWorkspaceBuildsToMigrateProvisionerState), arg0, arg1)
+}
+
 // GetWorkspaceByAgentID mocks base method.
 func (m *MockStore) GetWorkspaceByAgentID(arg0 context.Context, arg1 uuid.UUID) (database.GetWorkspaceByAgentIDRow, error) {
 	m.ctrl.T.Helper()
@@ -3263,6 +3632,21 @@ func (mr *MockStoreMockRecorder) GetWorkspaceByWorkspaceAppID(arg0, arg1 any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceByWorkspaceAppID", reflect.TypeOf((*MockStore)(nil).GetWorkspaceByWorkspaceAppID), arg0, arg1)
 }
 
+// GetWorkspaceDriftedResourcesByWorkspaceIDs mocks base method.
+func (m *MockStore) GetWorkspaceDriftedResourcesByWorkspaceIDs(arg0 context.Context, arg1 []uuid.UUID) ([]database.GetWorkspaceDriftedResourcesByWorkspaceIDsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceDriftedResourcesByWorkspaceIDs", arg0, arg1)
+	ret0, _ := ret[0].([]database.GetWorkspaceDriftedResourcesByWorkspaceIDsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceDriftedResourcesByWorkspaceIDs indicates an expected call of GetWorkspaceDriftedResourcesByWorkspaceIDs.
+func (mr *MockStoreMockRecorder) GetWorkspaceDriftedResourcesByWorkspaceIDs(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceDriftedResourcesByWorkspaceIDs", reflect.TypeOf((*MockStore)(nil).GetWorkspaceDriftedResourcesByWorkspaceIDs), arg0, arg1)
+}
+
 // GetWorkspaceProxies mocks base method.
 func (m *MockStore) GetWorkspaceProxies(arg0 context.Context) ([]database.WorkspaceProxy, error) {
 	m.ctrl.T.Helper()
@@ -3413,6 +3797,36 @@ func (mr *MockStoreMockRecorder) GetWorkspaceResourcesCreatedAfter(arg0, arg1 an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceResourcesCreatedAfter", reflect.TypeOf((*MockStore)(nil).GetWorkspaceResourcesCreatedAfter), arg0, arg1)
 }
 
+// GetWorkspaceSnapshotByID mocks base method.
+func (m *MockStore) GetWorkspaceSnapshotByID(arg0 context.Context, arg1 uuid.UUID) (database.WorkspaceSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceSnapshotByID", arg0, arg1)
+	ret0, _ := ret[0].(database.WorkspaceSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceSnapshotByID indicates an expected call of GetWorkspaceSnapshotByID.
+func (mr *MockStoreMockRecorder) GetWorkspaceSnapshotByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceSnapshotByID", reflect.TypeOf((*MockStore)(nil).GetWorkspaceSnapshotByID), arg0, arg1)
+}
+
+// GetWorkspaceSnapshotsByWorkspaceID mocks base method.
+func (m *MockStore) GetWorkspaceSnapshotsByWorkspaceID(arg0 context.Context, arg1 uuid.UUID) ([]database.WorkspaceSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceSnapshotsByWorkspaceID", arg0, arg1)
+	ret0, _ := ret[0].([]database.WorkspaceSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceSnapshotsByWorkspaceID indicates an expected call of GetWorkspaceSnapshotsByWorkspaceID.
+func (mr *MockStoreMockRecorder) GetWorkspaceSnapshotsByWorkspaceID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceSnapshotsByWorkspaceID", reflect.TypeOf((*MockStore)(nil).GetWorkspaceSnapshotsByWorkspaceID), arg0, arg1)
+}
+
 // GetWorkspaceUniqueOwnerCountByTemplateIDs mocks base method.
 func (m *MockStore) GetWorkspaceUniqueOwnerCountByTemplateIDs(arg0 context.Context, arg1 []uuid.UUID) ([]database.GetWorkspaceUniqueOwnerCountByTemplateIDsRow, error) {
 	m.ctrl.T.Helper()
@@ -3443,6 +3857,21 @@ func (mr *MockStoreMockRecorder) GetWorkspaces(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaces", reflect.TypeOf((*MockStore)(nil).GetWorkspaces), arg0, arg1)
 }
 
+// GetWorkspacesEligibleForDriftCheck mocks base method.
+func (m *MockStore) GetWorkspacesEligibleForDriftCheck(arg0 context.Context, arg1 time.Time) ([]database.GetWorkspacesEligibleForDriftCheckRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspacesEligibleForDriftCheck", arg0, arg1)
+	ret0, _ := ret[0].([]database.GetWorkspacesEligibleForDriftCheckRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspacesEligibleForDriftCheck indicates an expected call of GetWorkspacesEligibleForDriftCheck.
+func (mr *MockStoreMockRecorder) GetWorkspacesEligibleForDriftCheck(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspacesEligibleForDriftCheck", reflect.TypeOf((*MockStore)(nil).GetWorkspacesEligibleForDriftCheck), arg0, arg1)
+}
+
 // GetWorkspacesEligibleForTransition mocks base method.
 func (m *MockStore) GetWorkspacesEligibleForTransition(arg0 context.Context, arg1 time.Time) ([]database.Workspace, error) {
 	m.ctrl.T.Helper()
@@ -3663,6 +4092,21 @@ func (mr *MockStoreMockRecorder) InsertGroupMember(arg0, arg1 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertGroupMember", reflect.TypeOf((*MockStore)(nil).InsertGroupMember), arg0, arg1)
 }
 
+// InsertInboxNotification mocks base method.
+func (m *MockStore) InsertInboxNotification(arg0 context.Context, arg1 database.InsertInboxNotificationParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertInboxNotification", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertInboxNotification indicates an expected call of InsertInboxNotification.
+func (mr *MockStoreMockRecorder) InsertInboxNotification(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertInboxNotification", reflect.TypeOf((*MockStore)(nil).InsertInboxNotification), arg0, arg1)
+}
+
 // InsertLicense mocks base method.
 func (m *MockStore) InsertLicense(arg0 context.Context, arg1 database.InsertLicenseParams) (database.License, error) {
 	m.ctrl.T.Helper()
@@ -3813,6 +4257,21 @@ func (mr *MockStoreMockRecorder) InsertProvisionerJobLogs(arg0, arg1 any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertProvisionerJobLogs", reflect.TypeOf((*MockStore)(nil).InsertProvisionerJobLogs), arg0, arg1)
 }
 
+// InsertProvisionerJobResourceChange mocks base method.
+func (m *MockStore) InsertProvisionerJobResourceChange(arg0 context.Context, arg1 database.InsertProvisionerJobResourceChangeParams) (database.ProvisionerJobResourceChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertProvisionerJobResourceChange", arg0, arg1)
+	ret0, _ := ret[0].(database.ProvisionerJobResourceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertProvisionerJobResourceChange indicates an expected call of InsertProvisionerJobResourceChange.
+func (mr *MockStoreMockRecorder) InsertProvisionerJobResourceChange(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertProvisionerJobResourceChange", reflect.TypeOf((*MockStore)(nil).InsertProvisionerJobResourceChange), arg0, arg1)
+}
+
 // InsertProvisionerJobTimings mocks base method.
 func (m *MockStore) InsertProvisionerJobTimings(arg0 context.Context, arg1 database.InsertProvisionerJobTimingsParams) ([]database.ProvisionerJobTiming, error) {
 	m.ctrl.T.Helper()
@@ -3858,6 +4317,21 @@ func (mr *MockStoreMockRecorder) InsertReplica(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertReplica", reflect.TypeOf((*MockStore)(nil).InsertReplica), arg0, arg1)
 }
 
+// InsertSavedSearch mocks base method.
+func (m *MockStore) InsertSavedSearch(arg0 context.Context, arg1 database.InsertSavedSearchParams) (database.SavedSearch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertSavedSearch", arg0, arg1)
+	ret0, _ := ret[0].(database.SavedSearch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertSavedSearch indicates an expected call of InsertSavedSearch.
+func (mr *MockStoreMockRecorder) InsertSavedSearch(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertSavedSearch", reflect.TypeOf((*MockStore)(nil).InsertSavedSearch), arg0, arg1)
+}
+
 // InsertTemplate mocks base method.
 func (m *MockStore) InsertTemplate(arg0 context.Context, arg1 database.InsertTemplateParams) error {
 	m.ctrl.T.Helper()
@@ -4020,6 +4494,20 @@ func (mr *MockStoreMockRecorder) InsertWorkspaceAgent(arg0, arg1 any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertWorkspaceAgent", reflect.TypeOf((*MockStore)(nil).InsertWorkspaceAgent), arg0, arg1)
 }
 
+// InsertWorkspaceAgentContainer mocks base method.
+func (m *MockStore) InsertWorkspaceAgentContainer(arg0 context.Context, arg1 database.InsertWorkspaceAgentContainerParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertWorkspaceAgentContainer", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertWorkspaceAgentContainer indicates an expected call of InsertWorkspaceAgentContainer.
+func (mr *MockStoreMockRecorder) InsertWorkspaceAgentContainer(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertWorkspaceAgentContainer", reflect.TypeOf((*MockStore)(nil).InsertWorkspaceAgentContainer), arg0, arg1)
+}
+
 // InsertWorkspaceAgentLogSources mocks base method.
 func (m *MockStore) InsertWorkspaceAgentLogSources(arg0 context.Context, arg1 database.InsertWorkspaceAgentLogSourcesParams) ([]database.WorkspaceAgentLogSource, error) {
 	m.ctrl.T.Helper()
@@ -4064,6 +4552,20 @@ func (mr *MockStoreMockRecorder) InsertWorkspaceAgentMetadata(arg0, arg1 any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertWorkspaceAgentMetadata", reflect.TypeOf((*MockStore)(nil).InsertWorkspaceAgentMetadata), arg0, arg1)
 }
 
+// InsertWorkspaceAgentMetadataHistory mocks base method.
+func (m *MockStore) InsertWorkspaceAgentMetadataHistory(arg0 context.Context, arg1 database.InsertWorkspaceAgentMetadataHistoryParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertWorkspaceAgentMetadataHistory", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertWorkspaceAgentMetadataHistory indicates an expected call of InsertWorkspaceAgentMetadataHistory.
+func (mr *MockStoreMockRecorder) InsertWorkspaceAgentMetadataHistory(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertWorkspaceAgentMetadataHistory", reflect.TypeOf((*MockStore)(nil).InsertWorkspaceAgentMetadataHistory), arg0, arg1)
+}
+
 // InsertWorkspaceAgentScriptTimings mocks base method.
 func (m *MockStore) InsertWorkspaceAgentScriptTimings(arg0 context.Context, arg1 database.InsertWorkspaceAgentScriptTimingsParams) error {
 	m.ctrl.T.Helper()
@@ -4093,6 +4595,35 @@ func (mr *MockStoreMockRecorder) InsertWorkspaceAgentScripts(arg0, arg1 any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertWorkspaceAgentScripts", reflect.TypeOf((*MockStore)(nil).InsertWorkspaceAgentScripts), arg0, arg1)
 }
 
+// InsertWorkspaceAgentSessionRecording mocks base method.
+func (m *MockStore) InsertWorkspaceAgentSessionRecording(arg0 context.Context, arg1 database.InsertWorkspaceAgentSessionRecordingParams) (database.WorkspaceAgentSessionRecording, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertWorkspaceAgentSessionRecording", arg0, arg1)
+	ret0, _ := ret[0].(database.WorkspaceAgentSessionRecording)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertWorkspaceAgentSessionRecording indicates an expected call of InsertWorkspaceAgentSessionRecording.
+func (mr *MockStoreMockRecorder) InsertWorkspaceAgentSessionRecording(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertWorkspaceAgentSessionRecording", reflect.TypeOf((*MockStore)(nil).InsertWorkspaceAgentSessionRecording), arg0, arg1)
+}
+
+// InsertWorkspaceAgentSessionRecordingChunk mocks base method.
+func (m *MockStore) InsertWorkspaceAgentSessionRecordingChunk(arg0 context.Context, arg1 database.InsertWorkspaceAgentSessionRecordingChunkParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertWorkspaceAgentSessionRecordingChunk", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertWorkspaceAgentSessionRecordingChunk indicates an expected call of InsertWorkspaceAgentSessionRecordingChunk.
+func (mr *MockStoreMockRecorder) InsertWorkspaceAgentSessionRecordingChunk(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertWorkspaceAgentSessionRecordingChunk", reflect.TypeOf((*MockStore)(nil).InsertWorkspaceAgentSessionRecordingChunk), arg0, arg1)
+}
+
 // InsertWorkspaceAgentStats mocks base method.
 func (m *MockStore) InsertWorkspaceAgentStats(arg0 context.Context, arg1 database.InsertWorkspaceAgentStatsParams) error {
 	m.ctrl.T.Helper()
@@ -4164,6 +4695,21 @@ func (mr *MockStoreMockRecorder) InsertWorkspaceBuildParameters(arg0, arg1 any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertWorkspaceBuildParameters", reflect.TypeOf((*MockStore)(nil).InsertWorkspaceBuildParameters), arg0, arg1)
 }
 
+// InsertWorkspaceDriftCheck mocks base method.
+func (m *MockStore) InsertWorkspaceDriftCheck(arg0 context.Context, arg1 database.InsertWorkspaceDriftCheckParams) (database.WorkspaceDriftCheck, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertWorkspaceDriftCheck", arg0, arg1)
+	ret0, _ := ret[0].(database.WorkspaceDriftCheck)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertWorkspaceDriftCheck indicates an expected call of InsertWorkspaceDriftCheck.
+func (mr *MockStoreMockRecorder) InsertWorkspaceDriftCheck(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertWorkspaceDriftCheck", reflect.TypeOf((*MockStore)(nil).InsertWorkspaceDriftCheck), arg0, arg1)
+}
+
 // InsertWorkspaceProxy mocks base method.
 func (m *MockStore) InsertWorkspaceProxy(arg0 context.Context, arg1 database.InsertWorkspaceProxyParams) (database.WorkspaceProxy, error) {
 	m.ctrl.T.Helper()
@@ -4209,6 +4755,21 @@ func (mr *MockStoreMockRecorder) InsertWorkspaceResourceMetadata(arg0, arg1 any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertWorkspaceResourceMetadata", reflect.TypeOf((*MockStore)(nil).InsertWorkspaceResourceMetadata), arg0, arg1)
 }
 
+// InsertWorkspaceSnapshot mocks base method.
+func (m *MockStore) InsertWorkspaceSnapshot(arg0 context.Context, arg1 database.InsertWorkspaceSnapshotParams) (database.WorkspaceSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertWorkspaceSnapshot", arg0, arg1)
+	ret0, _ := ret[0].(database.WorkspaceSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertWorkspaceSnapshot indicates an expected call of InsertWorkspaceSnapshot.
+func (mr *MockStoreMockRecorder) InsertWorkspaceSnapshot(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertWorkspaceSnapshot", reflect.TypeOf((*MockStore)(nil).InsertWorkspaceSnapshot), arg0, arg1)
+}
+
 // ListProvisionerKeysByOrganization mocks base method.
 func (m *MockStore) ListProvisionerKeysByOrganization(arg0 context.Context, arg1 uuid.UUID) ([]database.ProvisionerKey, error) {
 	m.ctrl.T.Helper()
@@ -4254,6 +4815,49 @@ func (mr *MockStoreMockRecorder) ListWorkspaceAgentPortShares(arg0, arg1 any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWorkspaceAgentPortShares", reflect.TypeOf((*MockStore)(nil).ListWorkspaceAgentPortShares), arg0, arg1)
 }
 
+// LockWorkspaceByID mocks base method.
+func (m *MockStore) LockWorkspaceByID(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockWorkspaceByID", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockWorkspaceByID indicates an expected call of LockWorkspaceByID.
+func (mr *MockStoreMockRecorder) LockWorkspaceByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockWorkspaceByID", reflect.TypeOf((*MockStore)(nil).LockWorkspaceByID), arg0, arg1)
+}
+
+// MarkAllInboxNotificationsAsRead mocks base method.
+func (m *MockStore) MarkAllInboxNotificationsAsRead(arg0 context.Context, arg1 database.MarkAllInboxNotificationsAsReadParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAllInboxNotificationsAsRead", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkAllInboxNotificationsAsRead indicates an expected call of MarkAllInboxNotificationsAsRead.
+func (mr *MockStoreMockRecorder) MarkAllInboxNotificationsAsRead(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAllInboxNotificationsAsRead", reflect.TypeOf((*MockStore)(nil).MarkAllInboxNotificationsAsRead), arg0, arg1)
+}
+
+// MarkNotificationMessagesDigested mocks base method.
+func (m *MockStore) MarkNotificationMessagesDigested(arg0 context.Context, arg1 database.MarkNotificationMessagesDigestedParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNotificationMessagesDigested", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkNotificationMessagesDigested indicates an expected call of MarkNotificationMessagesDigested.
+func (mr *MockStoreMockRecorder) MarkNotificationMessagesDigested(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationMessagesDigested", reflect.TypeOf((*MockStore)(nil).MarkNotificationMessagesDigested), arg0, arg1)
+}
+
 // OrganizationMembers mocks base method.
 func (m *MockStore) OrganizationMembers(arg0 context.Context, arg1 database.OrganizationMembersParams) ([]database.OrganizationMembersRow, error) {
 	m.ctrl.T.Helper()
@@ -4313,6 +4917,20 @@ func (mr *MockStoreMockRecorder) RegisterWorkspaceProxy(arg0, arg1 any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterWorkspaceProxy", reflect.TypeOf((*MockStore)(nil).RegisterWorkspaceProxy), arg0, arg1)
 }
 
+// ReleaseNotificationMessagesFromDigest mocks base method.
+func (m *MockStore) ReleaseNotificationMessagesFromDigest(arg0 context.Context, arg1 []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseNotificationMessagesFromDigest", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseNotificationMessagesFromDigest indicates an expected call of ReleaseNotificationMessagesFromDigest.
+func (mr *MockStoreMockRecorder) ReleaseNotificationMessagesFromDigest(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseNotificationMessagesFromDigest", reflect.TypeOf((*MockStore)(nil).ReleaseNotificationMessagesFromDigest), arg0, arg1)
+}
+
 // RemoveUserFromAllGroups mocks base method.
 func (m *MockStore) RemoveUserFromAllGroups(arg0 context.Context, arg1 uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -4503,6 +5121,21 @@ func (mr *MockStoreMockRecorder) UpdateInactiveUsersToDormant(arg0, arg1 any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInactiveUsersToDormant", reflect.TypeOf((*MockStore)(nil).UpdateInactiveUsersToDormant), arg0, arg1)
 }
 
+// UpdateInboxNotificationStatus mocks base method.
+func (m *MockStore) UpdateInboxNotificationStatus(arg0 context.Context, arg1 database.UpdateInboxNotificationStatusParams) (database.InboxNotification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateInboxNotificationStatus", arg0, arg1)
+	ret0, _ := ret[0].(database.InboxNotification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateInboxNotificationStatus indicates an expected call of UpdateInboxNotificationStatus.
+func (mr *MockStoreMockRecorder) UpdateInboxNotificationStatus(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInboxNotificationStatus", reflect.TypeOf((*MockStore)(nil).UpdateInboxNotificationStatus), arg0, arg1)
+}
+
 // UpdateMemberRoles mocks base method.
 func (m *MockStore) UpdateMemberRoles(arg0 context.Context, arg1 database.UpdateMemberRolesParams) (database.OrganizationMember, error) {
 	m.ctrl.T.Helper()
@@ -4649,6 +5282,21 @@ func (mr *MockStoreMockRecorder) UpdateReplica(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReplica", reflect.TypeOf((*MockStore)(nil).UpdateReplica), arg0, arg1)
 }
 
+// UpdateSavedSearch mocks base method.
+func (m *MockStore) UpdateSavedSearch(arg0 context.Context, arg1 database.UpdateSavedSearchParams) (database.SavedSearch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSavedSearch", arg0, arg1)
+	ret0, _ := ret[0].(database.SavedSearch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSavedSearch indicates an expected call of UpdateSavedSearch.
+func (mr *MockStoreMockRecorder) UpdateSavedSearch(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSavedSearch", reflect.TypeOf((*MockStore)(nil).UpdateSavedSearch), arg0, arg1)
+}
+
 // UpdateTailnetPeerStatusByCoordinator mocks base method.
 func (m *MockStore) UpdateTailnetPeerStatusByCoordinator(arg0 context.Context, arg1 database.UpdateTailnetPeerStatusByCoordinatorParams) error {
 	m.ctrl.T.Helper()
@@ -5066,6 +5714,20 @@ func (mr *MockStoreMockRecorder) UpdateWorkspaceAgentMetadata(arg0, arg1 any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkspaceAgentMetadata", reflect.TypeOf((*MockStore)(nil).UpdateWorkspaceAgentMetadata), arg0, arg1)
 }
 
+// UpdateWorkspaceAgentSessionRecordingEndedAt mocks base method.
+func (m *MockStore) UpdateWorkspaceAgentSessionRecordingEndedAt(arg0 context.Context, arg1 database.UpdateWorkspaceAgentSessionRecordingEndedAtParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWorkspaceAgentSessionRecordingEndedAt", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWorkspaceAgentSessionRecordingEndedAt indicates an expected call of UpdateWorkspaceAgentSessionRecordingEndedAt.
+func (mr *MockStoreMockRecorder) UpdateWorkspaceAgentSessionRecordingEndedAt(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkspaceAgentSessionRecordingEndedAt", reflect.TypeOf((*MockStore)(nil).UpdateWorkspaceAgentSessionRecordingEndedAt), arg0, arg1)
+}
+
 // UpdateWorkspaceAgentStartupByID mocks base method.
 func (m *MockStore) UpdateWorkspaceAgentStartupByID(arg0 context.Context, arg1 database.UpdateWorkspaceAgentStartupByIDParams) error {
 	m.ctrl.T.Helper()
@@ -5164,6 +5826,21 @@ func (mr *MockStoreMockRecorder) UpdateWorkspaceBuildProvisionerStateByID(arg0,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkspaceBuildProvisionerStateByID", reflect.TypeOf((*MockStore)(nil).UpdateWorkspaceBuildProvisionerStateByID), arg0, arg1)
 }
 
+// UpdateWorkspaceBuildProvisionerStateLocationByID mocks base method.
+func (m *MockStore) UpdateWorkspaceBuildProvisionerStateLocationByID(arg0 context.Context, arg1 database.UpdateWorkspaceBuildProvisionerStateLocationByIDParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWorkspaceBuildProvisionerStateLocationByID", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWorkspaceBuildProvisionerStateLocationByID indicates an expected call of UpdateWorkspaceBuildProvisionerStateLocationByID.
+func (mr *MockStoreMockRecorder) UpdateWorkspaceBuildProvisionerStateLocationByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkspaceBuildProvisionerStateLocationByID", reflect.TypeOf((*MockStore)(nil).UpdateWorkspaceBuildProvisionerStateLocationByID), arg0, arg1)
+}
+
 // UpdateWorkspaceDeletedByID mocks base method.
 func (m *MockStore) UpdateWorkspaceDeletedByID(arg0 context.Context, arg1 database.UpdateWorkspaceDeletedByIDParams) error {
 	m.ctrl.T.Helper()
@@ -5236,6 +5913,20 @@ func (mr *MockStoreMockRecorder) UpdateWorkspaceProxyDeleted(arg0, arg1 any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkspaceProxyDeleted", reflect.TypeOf((*MockStore)(nil).UpdateWorkspaceProxyDeleted), arg0, arg1)
 }
 
+// UpdateWorkspaceSnapshotDataByID mocks base method.
+func (m *MockStore) UpdateWorkspaceSnapshotDataByID(arg0 context.Context, arg1 database.UpdateWorkspaceSnapshotDataByIDParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWorkspaceSnapshotDataByID", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWorkspaceSnapshotDataByID indicates an expected call of UpdateWorkspaceSnapshotDataByID.
+func (mr *MockStoreMockRecorder) UpdateWorkspaceSnapshotDataByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkspaceSnapshotDataByID", reflect.TypeOf((*MockStore)(nil).UpdateWorkspaceSnapshotDataByID), arg0, arg1)
+}
+
 // UpdateWorkspaceTTL mocks base method.
 func (m *MockStore) UpdateWorkspaceTTL(arg0 context.Context, arg1 database.UpdateWorkspaceTTLParams) error {
 	m.ctrl.T.Helper()
@@ -5565,6 +6256,21 @@ func (mr *MockStoreMockRecorder) UpsertTemplateUsageStats(arg0 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertTemplateUsageStats", reflect.TypeOf((*MockStore)(nil).UpsertTemplateUsageStats), arg0)
 }
 
+// UpsertUserNotificationQuietHours mocks base method.
+func (m *MockStore) UpsertUserNotificationQuietHours(arg0 context.Context, arg1 database.UpsertUserNotificationQuietHoursParams) (database.NotificationQuietHour, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertUserNotificationQuietHours", arg0, arg1)
+	ret0, _ := ret[0].(database.NotificationQuietHour)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertUserNotificationQuietHours indicates an expected call of UpsertUserNotificationQuietHours.
+func (mr *MockStoreMockRecorder) UpsertUserNotificationQuietHours(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertUserNotificationQuietHours", reflect.TypeOf((*MockStore)(nil).UpsertUserNotificationQuietHours), arg0, arg1)
+}
+
 // UpsertWorkspaceAgentPortShare mocks base method.
 func (m *MockStore) UpsertWorkspaceAgentPortShare(arg0 context.Context, arg1 database.UpsertWorkspaceAgentPortShareParams) (database.WorkspaceAgentPortShare, error) {
 	m.ctrl.T.Helper()
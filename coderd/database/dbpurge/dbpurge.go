@@ -5,10 +5,12 @@ import (
 	"io"
 	"time"
 
+	"github.com/google/uuid"
 	"golang.org/x/xerrors"
 
 	"cdr.dev/slog"
 
+	"github.com/coder/coder/v2/coderd/auditarchive"
 	"github.com/coder/coder/v2/coderd/database"
 	"github.com/coder/coder/v2/coderd/database/dbauthz"
 	"github.com/coder/coder/v2/coderd/database/dbtime"
@@ -18,13 +20,54 @@ import (
 const (
 	delay          = 10 * time.Minute
 	maxAgentLogAge = 7 * 24 * time.Hour
+
+	auditLogBatchSize = 1000
+	// maxAuditLogsPerPurge bounds the size of each purge's transaction; any
+	// remaining expired audit logs are purged on subsequent ticks.
+	maxAuditLogsPerPurge = 50 * auditLogBatchSize
+	// maxAgentMetadataSamplesPerKey bounds the metadata history of each key
+	// regardless of its retention. At the common 10 second interval that's
+	// more than a day of samples.
+	maxAgentMetadataSamplesPerKey = 10_000
 )
 
+type options struct {
+	auditLogRetention             time.Duration
+	auditLogArchive               auditarchive.Store
+	agentMetadataHistoryRetention time.Duration
+}
+
+// Option configures optional purging behavior.
+type Option func(*options)
+
+// WithAuditLogRetention deletes audit logs older than the given age. If an
+// archive store is given, the audit logs are archived to it before they are
+// deleted, and are only deleted if archiving succeeds.
+func WithAuditLogRetention(maxAge time.Duration, archive auditarchive.Store) Option {
+	return func(o *options) {
+		o.auditLogRetention = maxAge
+		o.auditLogArchive = archive
+	}
+}
+
+// WithAgentMetadataHistoryRetention deletes workspace agent metadata samples
+// older than the given age. Without it, or with an age of 0, all samples are
+// deleted.
+func WithAgentMetadataHistoryRetention(maxAge time.Duration) Option {
+	return func(o *options) {
+		o.agentMetadataHistoryRetention = maxAge
+	}
+}
+
 // New creates a new periodically purging database instance.
 // It is the caller's responsibility to call Close on the returned instance.
 //
 // This is for cleaning up old, unused resources from the database that take up space.
-func New(ctx context.Context, logger slog.Logger, db database.Store, clk quartz.Clock) io.Closer {
+func New(ctx context.Context, logger slog.Logger, db database.Store, clk quartz.Clock, opts ...Option) io.Closer {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
 	closed := make(chan struct{})
 
 	ctx, cancelFunc := context.WithCancel(ctx)
@@ -56,12 +99,27 @@ func New(ctx context.Context, logger slog.Logger, db database.Store, clk quartz.
 			if err := tx.DeleteOldWorkspaceAgentStats(ctx); err != nil {
 				return xerrors.Errorf("failed to delete old workspace agent stats: %w", err)
 			}
+			if err := tx.DeleteOldWorkspaceAgentMetadataHistory(ctx, database.DeleteOldWorkspaceAgentMetadataHistoryParams{
+				CollectedBefore:  start.Add(-o.agentMetadataHistoryRetention),
+				MaxSamplesPerKey: maxAgentMetadataSamplesPerKey,
+			}); err != nil {
+				return xerrors.Errorf("failed to delete old workspace agent metadata history: %w", err)
+			}
 			if err := tx.DeleteOldProvisionerDaemons(ctx); err != nil {
 				return xerrors.Errorf("failed to delete old provisioner daemons: %w", err)
 			}
 			if err := tx.DeleteOldNotificationMessages(ctx); err != nil {
 				return xerrors.Errorf("failed to delete old notification messages: %w", err)
 			}
+			if o.auditLogRetention > 0 {
+				purged, err := purgeAuditLogs(ctx, tx, start.Add(-o.auditLogRetention), o.auditLogArchive, start)
+				if err != nil {
+					return xerrors.Errorf("failed to purge old audit logs: %w", err)
+				}
+				if purged > 0 {
+					logger.Info(ctx, "purged old audit logs", slog.F("count", purged), slog.F("archived", o.auditLogArchive != nil))
+				}
+			}
 
 			logger.Info(ctx, "purged old database entries", slog.F("duration", clk.Since(start)))
 
@@ -93,6 +151,61 @@ func New(ctx context.Context, logger slog.Logger, db database.Store, clk quartz.
 	}
 }
 
+// purgeAuditLogs deletes up to maxAuditLogsPerPurge audit logs from before the
+// given time, archiving them first if an archive store is given. The archive is
+// completed before returning, so that the deletions are rolled back along with
+// the transaction if archiving fails.
+//
+// The archive is written before the transaction commits, so a purge may leave
+// an archive behind for audit logs which were not deleted. Audit logs are
+// purged oldest first and the archive is named after the first of them, so the
+// next attempt overwrites that archive rather than duplicating it.
+func purgeAuditLogs(ctx context.Context, db database.Store, before time.Time, archive auditarchive.Store, now time.Time) (int, error) {
+	var w *auditarchive.Writer
+	if archive != nil {
+		w = auditarchive.NewIdempotentWriter(archive, now, auditarchive.DefaultMaxFileRecords)
+	}
+
+	var purged int
+	for purged < maxAuditLogsPerPurge {
+		rows, err := db.GetAuditLogsBefore(ctx, database.GetAuditLogsBeforeParams{
+			Before:   before,
+			LimitOpt: auditLogBatchSize,
+		})
+		if err != nil {
+			return 0, xerrors.Errorf("get audit logs: %w", err)
+		}
+		if len(rows) == 0 {
+			break
+		}
+
+		ids := make([]uuid.UUID, 0, len(rows))
+		for _, row := range rows {
+			if w != nil {
+				if err := w.Write(ctx, auditarchive.RecordFromLog(row.AuditLog, row.UserUsername.String, row.UserEmail.String)); err != nil {
+					return 0, xerrors.Errorf("archive audit log: %w", err)
+				}
+			}
+			ids = append(ids, row.AuditLog.ID)
+		}
+		if err := db.DeleteAuditLogsByIDs(ctx, ids); err != nil {
+			return 0, xerrors.Errorf("delete audit logs: %w", err)
+		}
+		purged += len(rows)
+
+		if len(rows) < auditLogBatchSize {
+			break
+		}
+	}
+
+	if w != nil {
+		if _, err := w.Close(ctx); err != nil {
+			return 0, xerrors.Errorf("complete archive: %w", err)
+		}
+	}
+	return purged, nil
+}
+
 type instance struct {
 	cancel context.CancelFunc
 	closed chan struct{}
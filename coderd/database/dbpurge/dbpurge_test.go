@@ -3,10 +3,15 @@ package dbpurge_test
 import (
 	"bufio"
 	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -19,6 +24,7 @@ import (
 	"cdr.dev/slog"
 	"cdr.dev/slog/sloggers/slogtest"
 
+	"github.com/coder/coder/v2/coderd/auditarchive"
 	"github.com/coder/coder/v2/coderd/database"
 	"github.com/coder/coder/v2/coderd/database/dbgen"
 	"github.com/coder/coder/v2/coderd/database/dbmem"
@@ -26,6 +32,7 @@ import (
 	"github.com/coder/coder/v2/coderd/database/dbrollup"
 	"github.com/coder/coder/v2/coderd/database/dbtestutil"
 	"github.com/coder/coder/v2/coderd/database/dbtime"
+	"github.com/coder/coder/v2/coderd/objectstore"
 	"github.com/coder/coder/v2/codersdk"
 	"github.com/coder/coder/v2/provisionerd/proto"
 	"github.com/coder/coder/v2/provisionersdk"
@@ -490,3 +497,119 @@ func containsProvisionerDaemon(daemons []database.ProvisionerDaemon, name string
 		return d.Name == name
 	})
 }
+
+//nolint:paralleltest // It uses LockIDDBPurge.
+func TestDeleteOldAuditLogs(t *testing.T) {
+	ctx := testutil.Context(t, testutil.WaitShort)
+	clk := quartz.NewMock(t)
+	now := dbtime.Now()
+	clk.Set(now).MustWait(ctx)
+
+	db, _ := dbtestutil.NewDB(t, dbtestutil.WithDumpOnFailure())
+	logger := slogtest.Make(t, &slogtest.Options{IgnoreErrors: true})
+	user := dbgen.User(t, db, database.User{})
+
+	// Given: audit logs from either side of a 30 day retention window.
+	expired := []database.AuditLog{
+		dbgen.AuditLog(t, db, database.AuditLog{UserID: user.ID, Time: now.AddDate(0, 0, -45)}),
+		dbgen.AuditLog(t, db, database.AuditLog{UserID: user.ID, Time: now.AddDate(0, 0, -31)}),
+	}
+	retained := dbgen.AuditLog(t, db, database.AuditLog{UserID: user.ID, Time: now.AddDate(0, 0, -29)})
+
+	dir := t.TempDir()
+	archive, err := objectstore.NewDirStore(dir)
+	require.NoError(t, err)
+
+	// When: dbpurge runs
+	done := awaitDoTick(ctx, t, clk)
+	closer := dbpurge.New(ctx, logger, db, clk, dbpurge.WithAuditLogRetention(30*24*time.Hour, archive))
+	defer closer.Close()
+	<-done // doTick() has now run.
+
+	// Then: only the expired audit logs are deleted.
+	logs, err := db.GetAuditLogsOffset(ctx, database.GetAuditLogsOffsetParams{})
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	require.Equal(t, retained.ID, logs[0].AuditLog.ID)
+
+	// Then: the expired audit logs were archived first.
+	manifests, err := filepath.Glob(filepath.Join(dir, "*.manifest.json"))
+	require.NoError(t, err)
+	require.Len(t, manifests, 1)
+	// The archive is named after the oldest audit log, so that a retried purge
+	// overwrites it.
+	require.Equal(t, "audit-logs-"+expired[0].Time.UTC().Format("20060102T150405Z")+"-"+expired[0].ID.String()+".manifest.json", filepath.Base(manifests[0]))
+	data, err := os.ReadFile(manifests[0])
+	require.NoError(t, err)
+	var manifest auditarchive.Manifest
+	require.NoError(t, json.Unmarshal(data, &manifest))
+	require.EqualValues(t, len(expired), manifest.Records)
+	require.Len(t, manifest.Files, 1)
+
+	data, err = os.ReadFile(filepath.Join(dir, manifest.Files[0].Name))
+	require.NoError(t, err)
+	sum := sha256.Sum256(data)
+	require.Equal(t, manifest.Files[0].SHA256, hex.EncodeToString(sum[:]))
+
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	dec := json.NewDecoder(gz)
+	var archived []uuid.UUID
+	for dec.More() {
+		var record auditarchive.Record
+		require.NoError(t, dec.Decode(&record))
+		require.Equal(t, user.Username, record.Username)
+		archived = append(archived, record.ID)
+	}
+	require.ElementsMatch(t, []uuid.UUID{expired[0].ID, expired[1].ID}, archived)
+}
+
+func TestDeleteOldWorkspaceAgentMetadataHistory(t *testing.T) {
+	ctx := testutil.Context(t, testutil.WaitShort)
+	clk := quartz.NewMock(t)
+	now := dbtime.Now()
+	clk.Set(now).MustWait(ctx)
+
+	db, _ := dbtestutil.NewDB(t, dbtestutil.WithDumpOnFailure())
+	org := dbgen.Organization(t, db, database.Organization{})
+	user := dbgen.User(t, db, database.User{})
+	tv := dbgen.TemplateVersion(t, db, database.TemplateVersion{OrganizationID: org.ID, CreatedBy: user.ID})
+	tmpl := dbgen.Template(t, db, database.Template{OrganizationID: org.ID, ActiveVersionID: tv.ID, CreatedBy: user.ID})
+	ws := dbgen.Workspace(t, db, database.Workspace{OwnerID: user.ID, OrganizationID: org.ID, TemplateID: tmpl.ID})
+	wb := mustCreateWorkspaceBuild(t, db, org, tv, ws.ID, now, 1)
+	agent := mustCreateAgent(t, db, wb)
+	logger := slogtest.Make(t, &slogtest.Options{IgnoreErrors: true})
+
+	// Given: metadata samples from either side of a 24 hour retention window.
+	err := db.InsertWorkspaceAgentMetadata(ctx, database.InsertWorkspaceAgentMetadataParams{
+		WorkspaceAgentID: agent.ID,
+		DisplayName:      "CPU",
+		Key:              "cpu",
+	})
+	require.NoError(t, err)
+	err = db.InsertWorkspaceAgentMetadataHistory(ctx, database.InsertWorkspaceAgentMetadataHistoryParams{
+		WorkspaceAgentID: agent.ID,
+		Key:              []string{"cpu", "cpu", "cpu"},
+		Value:            []string{"expired", "retained", "latest"},
+		Error:            []string{"", "", ""},
+		CollectedAt:      []time.Time{now.Add(-25 * time.Hour), now.Add(-23 * time.Hour), now},
+	})
+	require.NoError(t, err)
+
+	// When: dbpurge runs
+	done := awaitDoTick(ctx, t, clk)
+	closer := dbpurge.New(ctx, logger, db, clk, dbpurge.WithAgentMetadataHistoryRetention(24*time.Hour))
+	defer closer.Close()
+	<-done // doTick() has now run.
+
+	// Then: only the expired sample is deleted.
+	samples, err := db.GetWorkspaceAgentMetadataHistory(ctx, database.GetWorkspaceAgentMetadataHistoryParams{
+		WorkspaceAgentID: agent.ID,
+		Key:              "cpu",
+		Limit:            10,
+	})
+	require.NoError(t, err)
+	require.Len(t, samples, 2)
+	require.Equal(t, "latest", samples[0].Value)
+	require.Equal(t, "retained", samples[1].Value)
+}
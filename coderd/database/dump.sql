@@ -19,7 +19,8 @@ CREATE TYPE audit_action AS ENUM (
     'stop',
     'login',
     'logout',
-    'register'
+    'register',
+    'exec'
 );
 
 CREATE TYPE automatic_updates AS ENUM (
@@ -96,7 +97,10 @@ CREATE TYPE notification_message_status AS ENUM (
 
 CREATE TYPE notification_method AS ENUM (
     'smtp',
-    'webhook'
+    'webhook',
+    'slack',
+    'teams',
+    'inbox'
 );
 
 CREATE TYPE notification_template_kind AS ENUM (
@@ -152,7 +156,9 @@ CREATE TYPE provisioner_job_timing_stage AS ENUM (
 CREATE TYPE provisioner_job_type AS ENUM (
     'template_version_import',
     'workspace_build',
-    'template_version_dry_run'
+    'template_version_dry_run',
+    'workspace_snapshot',
+    'workspace_drift_check'
 );
 
 CREATE TYPE provisioner_storage_method AS ENUM (
@@ -164,6 +170,13 @@ CREATE TYPE provisioner_type AS ENUM (
     'terraform'
 );
 
+CREATE TYPE resource_change_action AS ENUM (
+    'create',
+    'update',
+    'replace',
+    'delete'
+);
+
 CREATE TYPE resource_type AS ENUM (
     'organization',
     'template',
@@ -186,6 +199,13 @@ CREATE TYPE resource_type AS ENUM (
     'notification_template'
 );
 
+CREATE TYPE saved_search_resource_type AS ENUM (
+    'workspace',
+    'template',
+    'user',
+    'audit_log'
+);
+
 CREATE TYPE startup_script_behavior AS ENUM (
     'blocking',
     'non-blocking'
@@ -219,7 +239,8 @@ CREATE TYPE workspace_agent_lifecycle_state AS ENUM (
 CREATE TYPE workspace_agent_script_timing_stage AS ENUM (
     'start',
     'stop',
-    'cron'
+    'cron',
+    'manual'
 );
 
 COMMENT ON TYPE workspace_agent_script_timing_stage IS 'What stage the script was ran in.';
@@ -247,6 +268,14 @@ CREATE TYPE workspace_app_health AS ENUM (
     'unhealthy'
 );
 
+CREATE TYPE workspace_app_healthcheck_kind AS ENUM (
+    'http',
+    'tcp',
+    'grpc',
+    'command',
+    'file'
+);
+
 CREATE TYPE workspace_transition AS ENUM (
     'start',
     'stop',
@@ -710,6 +739,24 @@ CREATE VIEW group_members_expanded AS
 
 COMMENT ON VIEW group_members_expanded IS 'Joins group members with user information, organization ID, group name. Includes both regular group members and organization members (as part of the "Everyone" group).';
 
+CREATE TABLE inbox_notifications (
+    id uuid NOT NULL,
+    user_id uuid NOT NULL,
+    template_id uuid NOT NULL,
+    title text NOT NULL,
+    content text NOT NULL,
+    actions jsonb DEFAULT '[]'::jsonb NOT NULL,
+    read_at timestamp with time zone,
+    archived_at timestamp with time zone,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+COMMENT ON TABLE inbox_notifications IS 'Rendered notifications delivered by the inbox method, which users can view in the dashboard or CLI';
+
+COMMENT ON COLUMN inbox_notifications.id IS 'The ID of the notification message from which this inbox notification was dispatched';
+
+COMMENT ON COLUMN inbox_notifications.actions IS 'Array of {label, url} objects linking to related resources';
+
 CREATE TABLE jfrog_xray_scans (
     agent_id uuid NOT NULL,
     workspace_id uuid NOT NULL,
@@ -755,19 +802,45 @@ CREATE TABLE notification_messages (
     leased_until timestamp with time zone,
     next_retry_after timestamp with time zone,
     queued_seconds double precision,
-    dedupe_hash text
+    dedupe_hash text,
+    held_until timestamp with time zone,
+    digest_template_id uuid
 );
 
 COMMENT ON COLUMN notification_messages.dedupe_hash IS 'Auto-generated by insert/update trigger, used to prevent duplicate notifications from being enqueued on the same day';
 
+COMMENT ON COLUMN notification_messages.held_until IS 'Messages are not dispatched until this time; set for non-urgent messages enqueued during the user''s quiet hours, and for messages awaiting the end of their digest window';
+
+COMMENT ON COLUMN notification_messages.digest_template_id IS 'Set while the message awaits the digest generator, which delivers it together with other messages for the same user and template; such messages are not dispatched individually';
+
 CREATE TABLE notification_preferences (
     user_id uuid NOT NULL,
     notification_template_id uuid NOT NULL,
     disabled boolean DEFAULT false NOT NULL,
     created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
-    updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+    updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    method notification_method
 );
 
+COMMENT ON COLUMN notification_preferences.method IS 'Method by which the user wants to receive this notification; NULL defers to the template or deployment default';
+
+CREATE TABLE notification_quiet_hours (
+    user_id uuid NOT NULL,
+    start_minute integer NOT NULL,
+    end_minute integer NOT NULL,
+    timezone text NOT NULL,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT notification_quiet_hours_end_minute_check CHECK (((end_minute >= 0) AND (end_minute < 1440))),
+    CONSTRAINT notification_quiet_hours_start_minute_check CHECK (((start_minute >= 0) AND (start_minute < 1440)))
+);
+
+COMMENT ON TABLE notification_quiet_hours IS 'Daily window during which non-urgent notifications are held back from a user';
+
+COMMENT ON COLUMN notification_quiet_hours.start_minute IS 'Minutes after midnight in the user''s timezone at which quiet hours begin';
+
+COMMENT ON COLUMN notification_quiet_hours.end_minute IS 'Minutes after midnight in the user''s timezone at which quiet hours end; may be less than start_minute if the window spans midnight';
+
 CREATE TABLE notification_report_generator_logs (
     notification_template_id uuid NOT NULL,
     last_generated_at timestamp with time zone NOT NULL
@@ -783,13 +856,16 @@ CREATE TABLE notification_templates (
     actions jsonb,
     "group" text,
     method notification_method,
-    kind notification_template_kind DEFAULT 'system'::notification_template_kind NOT NULL
+    kind notification_template_kind DEFAULT 'system'::notification_template_kind NOT NULL,
+    digest_template_id uuid
 );
 
 COMMENT ON TABLE notification_templates IS 'Templates from which to create notification messages.';
 
 COMMENT ON COLUMN notification_templates.method IS 'NULL defers to the deployment-level method';
 
+COMMENT ON COLUMN notification_templates.digest_template_id IS 'Template with which messages for the same user enqueued within the same digest window are delivered together; NULL disables digests';
+
 CREATE TABLE oauth2_provider_app_codes (
     id uuid NOT NULL,
     created_at timestamp with time zone NOT NULL,
@@ -932,6 +1008,19 @@ SELECT
     NULL::double precision AS graph_secs,
     NULL::double precision AS apply_secs;
 
+CREATE TABLE provisioner_job_resource_changes (
+    job_id uuid NOT NULL,
+    address text NOT NULL,
+    type text NOT NULL,
+    name text NOT NULL,
+    action resource_change_action NOT NULL,
+    attributes jsonb DEFAULT '[]'::jsonb NOT NULL
+);
+
+COMMENT ON TABLE provisioner_job_resource_changes IS 'Changes to the resources of a workspace: planned by a dry-run job that previews an update of it, or detected by a drift check job';
+
+COMMENT ON COLUMN provisioner_job_resource_changes.attributes IS 'The changed top-level attributes of the resource, without the values of sensitive attributes';
+
 CREATE TABLE provisioner_job_timings (
     job_id uuid NOT NULL,
     started_at timestamp with time zone NOT NULL,
@@ -1005,6 +1094,27 @@ CREATE TABLE replicas (
     "primary" boolean DEFAULT true NOT NULL
 );
 
+CREATE TABLE saved_searches (
+    id uuid NOT NULL,
+    user_id uuid NOT NULL,
+    resource_type saved_search_resource_type NOT NULL,
+    name text NOT NULL,
+    query text NOT NULL,
+    organization_id uuid,
+    group_id uuid,
+    created_at timestamp with time zone NOT NULL,
+    updated_at timestamp with time zone NOT NULL,
+    CONSTRAINT saved_searches_group_requires_organization CHECK (((group_id IS NULL) OR (organization_id IS NOT NULL)))
+);
+
+COMMENT ON TABLE saved_searches IS 'Named search queries, which users can share with an organization or group';
+
+COMMENT ON COLUMN saved_searches.query IS 'The search query, in the syntax used to filter resources of resource_type';
+
+COMMENT ON COLUMN saved_searches.organization_id IS 'The organization the search is shared with, or NULL if it is private';
+
+COMMENT ON COLUMN saved_searches.group_id IS 'The group within organization_id the search is shared with, or NULL if it is shared with all members';
+
 CREATE TABLE site_configs (
     key character varying(256) NOT NULL,
     value text NOT NULL
@@ -1253,7 +1363,10 @@ CREATE TABLE templates (
     require_active_version boolean DEFAULT false NOT NULL,
     deprecated text DEFAULT ''::text NOT NULL,
     activity_bump bigint DEFAULT '3600000000000'::bigint NOT NULL,
-    max_port_sharing_level app_sharing_level DEFAULT 'owner'::app_sharing_level NOT NULL
+    max_port_sharing_level app_sharing_level DEFAULT 'owner'::app_sharing_level NOT NULL,
+    session_recording boolean DEFAULT false NOT NULL,
+    port_forwarding_policy jsonb DEFAULT '{}'::jsonb NOT NULL,
+    agent_activity_bump boolean DEFAULT false NOT NULL
 );
 
 COMMENT ON COLUMN templates.default_ttl IS 'The default duration for autostop for workspaces created from this template.';
@@ -1274,6 +1387,12 @@ COMMENT ON COLUMN templates.autostart_block_days_of_week IS 'A bitmap of days of
 
 COMMENT ON COLUMN templates.deprecated IS 'If set to a non empty string, the template will no longer be able to be used. The message will be displayed to the user.';
 
+COMMENT ON COLUMN templates.session_recording IS 'Whether interactive SSH sessions into workspaces of this template are recorded';
+
+COMMENT ON COLUMN templates.port_forwarding_policy IS 'Restricts the destinations that SSH port forwards into workspaces of this template may use, enforced by the workspace agent';
+
+COMMENT ON COLUMN templates.agent_activity_bump IS 'Whether activity reported by workspace agents without a connection, like a running build, bumps the deadline of workspaces of this template';
+
 CREATE VIEW template_with_names AS
  SELECT templates.id,
     templates.created_at,
@@ -1303,6 +1422,9 @@ CREATE VIEW template_with_names AS
     templates.deprecated,
     templates.activity_bump,
     templates.max_port_sharing_level,
+    templates.session_recording,
+    templates.port_forwarding_policy,
+    templates.agent_activity_bump,
     COALESCE(visible_users.avatar_url, ''::text) AS created_by_avatar_url,
     COALESCE(visible_users.username, ''::text) AS created_by_username,
     COALESCE(organizations.name, ''::text) AS organization_name,
@@ -1332,6 +1454,23 @@ COMMENT ON COLUMN user_links.oauth_refresh_token_key_id IS 'The ID of the key us
 
 COMMENT ON COLUMN user_links.debug_context IS 'Debug information includes information like id_token and userinfo claims.';
 
+CREATE UNLOGGED TABLE workspace_agent_containers (
+    workspace_agent_id uuid NOT NULL,
+    container_id text NOT NULL,
+    name text NOT NULL,
+    image text NOT NULL,
+    status text NOT NULL,
+    runtime text NOT NULL,
+    labels jsonb DEFAULT '{}'::jsonb NOT NULL,
+    ports jsonb DEFAULT '[]'::jsonb NOT NULL,
+    created_at timestamp with time zone NOT NULL,
+    reported_at timestamp with time zone NOT NULL
+);
+
+COMMENT ON TABLE workspace_agent_containers IS 'The containers running in the workspace of an agent, as last reported by the agent.';
+
+COMMENT ON COLUMN workspace_agent_containers.created_at IS 'When the container was created, as reported by the container runtime.';
+
 CREATE TABLE workspace_agent_log_sources (
     workspace_agent_id uuid NOT NULL,
     id uuid NOT NULL,
@@ -1364,6 +1503,16 @@ CREATE UNLOGGED TABLE workspace_agent_metadata (
 
 COMMENT ON COLUMN workspace_agent_metadata.display_order IS 'Specifies the order in which to display agent metadata in user interfaces.';
 
+CREATE UNLOGGED TABLE workspace_agent_metadata_history (
+    workspace_agent_id uuid NOT NULL,
+    key character varying(127) NOT NULL,
+    value character varying(65535) DEFAULT ''::character varying NOT NULL,
+    error character varying(65535) DEFAULT ''::character varying NOT NULL,
+    collected_at timestamp with time zone NOT NULL
+);
+
+COMMENT ON TABLE workspace_agent_metadata_history IS 'Historical samples of workspace agent metadata values. Rows are purged by dbpurge according to the agent metadata history retention.';
+
 CREATE TABLE workspace_agent_port_share (
     workspace_id uuid NOT NULL,
     agent_name text NOT NULL,
@@ -1405,6 +1554,35 @@ CREATE SEQUENCE workspace_agent_startup_logs_id_seq
 
 ALTER SEQUENCE workspace_agent_startup_logs_id_seq OWNED BY workspace_agent_logs.id;
 
+CREATE TABLE workspace_agent_session_recording_chunks (
+    recording_id uuid NOT NULL,
+    sequence integer NOT NULL,
+    data bytea NOT NULL
+);
+
+COMMENT ON TABLE workspace_agent_session_recording_chunks IS 'Chunks of a session recording, which concatenated in sequence order form the asciicast file';
+
+CREATE TABLE workspace_agent_session_recordings (
+    id uuid NOT NULL,
+    workspace_id uuid NOT NULL,
+    agent_id uuid NOT NULL,
+    session_type text NOT NULL,
+    command text DEFAULT ''::text NOT NULL,
+    started_at timestamp with time zone NOT NULL,
+    ended_at timestamp with time zone,
+    size bigint DEFAULT 0 NOT NULL
+);
+
+COMMENT ON TABLE workspace_agent_session_recordings IS 'Recordings of interactive PTY sessions made by workspace agents, in asciicast v2 format';
+
+COMMENT ON COLUMN workspace_agent_session_recordings.id IS 'The ID the agent assigned to the SSH session, which also appears in the agent logs';
+
+COMMENT ON COLUMN workspace_agent_session_recordings.session_type IS 'The type of SSH session, e.g. ssh, vscode or jetbrains';
+
+COMMENT ON COLUMN workspace_agent_session_recordings.command IS 'The command run in the session, empty for a login shell';
+
+COMMENT ON COLUMN workspace_agent_session_recordings.size IS 'The total size in bytes of the recording chunks';
+
 CREATE TABLE workspace_agent_stats (
     id uuid NOT NULL,
     created_at timestamp with time zone NOT NULL,
@@ -1545,13 +1723,28 @@ CREATE TABLE workspace_apps (
     slug text NOT NULL,
     external boolean DEFAULT false NOT NULL,
     display_order integer DEFAULT 0 NOT NULL,
-    hidden boolean DEFAULT false NOT NULL
+    hidden boolean DEFAULT false NOT NULL,
+    healthcheck_kind workspace_app_healthcheck_kind DEFAULT 'http'::workspace_app_healthcheck_kind NOT NULL,
+    healthcheck_expected_status integer DEFAULT 0 NOT NULL,
+    healthcheck_body_regex text DEFAULT ''::text NOT NULL,
+    healthcheck_address text DEFAULT ''::text NOT NULL,
+    healthcheck_grpc_service text DEFAULT ''::text NOT NULL,
+    healthcheck_command text DEFAULT ''::text NOT NULL,
+    healthcheck_path text DEFAULT ''::text NOT NULL
 );
 
 COMMENT ON COLUMN workspace_apps.display_order IS 'Specifies the order in which to display agent app in user interfaces.';
 
 COMMENT ON COLUMN workspace_apps.hidden IS 'Determines if the app is not shown in user interfaces.';
 
+COMMENT ON COLUMN workspace_apps.healthcheck_kind IS 'Specifies how the health of the app is checked. Each kind uses its own subset of the healthcheck columns.';
+
+COMMENT ON COLUMN workspace_apps.healthcheck_expected_status IS 'The status code an http health check must return. When 0, any status code below 500 is healthy.';
+
+COMMENT ON COLUMN workspace_apps.healthcheck_body_regex IS 'A regular expression the body of an http health check response must match.';
+
+COMMENT ON COLUMN workspace_apps.healthcheck_address IS 'The host and port a tcp or grpc health check connects to.';
+
 CREATE TABLE workspace_build_parameters (
     workspace_build_id uuid NOT NULL,
     name text NOT NULL,
@@ -1576,9 +1769,12 @@ CREATE TABLE workspace_builds (
     deadline timestamp with time zone DEFAULT '0001-01-01 00:00:00+00'::timestamp with time zone NOT NULL,
     reason build_reason DEFAULT 'initiator'::build_reason NOT NULL,
     daily_cost integer DEFAULT 0 NOT NULL,
-    max_deadline timestamp with time zone DEFAULT '0001-01-01 00:00:00+00'::timestamp with time zone NOT NULL
+    max_deadline timestamp with time zone DEFAULT '0001-01-01 00:00:00+00'::timestamp with time zone NOT NULL,
+    provisioner_state_ref text DEFAULT ''::text NOT NULL
 );
 
+COMMENT ON COLUMN workspace_builds.provisioner_state_ref IS 'A reference to the provisioner state in the configured state store. If set, provisioner_state is empty.';
+
 CREATE VIEW workspace_build_with_user AS
  SELECT workspace_builds.id,
     workspace_builds.created_at,
@@ -1594,6 +1790,7 @@ CREATE VIEW workspace_build_with_user AS
     workspace_builds.reason,
     workspace_builds.daily_cost,
     workspace_builds.max_deadline,
+    workspace_builds.provisioner_state_ref,
     COALESCE(visible_users.avatar_url, ''::text) AS initiator_by_avatar_url,
     COALESCE(visible_users.username, ''::text) AS initiator_by_username
    FROM (workspace_builds
@@ -1601,6 +1798,20 @@ CREATE VIEW workspace_build_with_user AS
 
 COMMENT ON VIEW workspace_build_with_user IS 'Joins in the username + avatar url of the initiated by user.';
 
+CREATE TABLE workspace_drift_checks (
+    id uuid NOT NULL,
+    workspace_id uuid NOT NULL,
+    workspace_build_id uuid NOT NULL,
+    job_id uuid NOT NULL,
+    created_at timestamp with time zone NOT NULL
+);
+
+COMMENT ON TABLE workspace_drift_checks IS 'Checks of the infrastructure of a workspace for drift from the state of a build, run by a provisioner job';
+
+COMMENT ON COLUMN workspace_drift_checks.workspace_build_id IS 'The build whose state was checked';
+
+COMMENT ON COLUMN workspace_drift_checks.job_id IS 'The provisioner job that ran the check, whose resource changes are the drifted resources';
+
 CREATE TABLE workspace_proxies (
     id uuid NOT NULL,
     name text NOT NULL,
@@ -1670,6 +1881,25 @@ CREATE TABLE workspace_resources (
     daily_cost integer DEFAULT 0 NOT NULL
 );
 
+CREATE TABLE workspace_snapshots (
+    id uuid NOT NULL,
+    workspace_id uuid NOT NULL,
+    workspace_build_id uuid NOT NULL,
+    job_id uuid NOT NULL,
+    name text NOT NULL,
+    data bytea DEFAULT '\x'::bytea NOT NULL,
+    created_by uuid NOT NULL,
+    created_at timestamp with time zone NOT NULL
+);
+
+COMMENT ON TABLE workspace_snapshots IS 'Named snapshots of a workspace, taken by the provisioner of the template';
+
+COMMENT ON COLUMN workspace_snapshots.workspace_build_id IS 'The build whose state the snapshot was taken from';
+
+COMMENT ON COLUMN workspace_snapshots.job_id IS 'The provisioner job that created the snapshot';
+
+COMMENT ON COLUMN workspace_snapshots.data IS 'Opaque data returned by the provisioner, which is handed back to it on restore';
+
 CREATE TABLE workspaces (
     id uuid NOT NULL,
     created_at timestamp with time zone NOT NULL,
@@ -1747,6 +1977,9 @@ ALTER TABLE ONLY groups
 ALTER TABLE ONLY groups
     ADD CONSTRAINT groups_pkey PRIMARY KEY (id);
 
+ALTER TABLE ONLY inbox_notifications
+    ADD CONSTRAINT inbox_notifications_pkey PRIMARY KEY (id);
+
 ALTER TABLE ONLY jfrog_xray_scans
     ADD CONSTRAINT jfrog_xray_scans_pkey PRIMARY KEY (agent_id, workspace_id);
 
@@ -1762,6 +1995,9 @@ ALTER TABLE ONLY notification_messages
 ALTER TABLE ONLY notification_preferences
     ADD CONSTRAINT notification_preferences_pkey PRIMARY KEY (user_id, notification_template_id);
 
+ALTER TABLE ONLY notification_quiet_hours
+    ADD CONSTRAINT notification_quiet_hours_pkey PRIMARY KEY (user_id);
+
 ALTER TABLE ONLY notification_report_generator_logs
     ADD CONSTRAINT notification_report_generator_logs_pkey PRIMARY KEY (notification_template_id);
 
@@ -1822,12 +2058,21 @@ ALTER TABLE ONLY provisioner_daemons
 ALTER TABLE ONLY provisioner_job_logs
     ADD CONSTRAINT provisioner_job_logs_pkey PRIMARY KEY (id);
 
+ALTER TABLE ONLY provisioner_job_resource_changes
+    ADD CONSTRAINT provisioner_job_resource_changes_pkey PRIMARY KEY (job_id, address);
+
 ALTER TABLE ONLY provisioner_jobs
     ADD CONSTRAINT provisioner_jobs_pkey PRIMARY KEY (id);
 
 ALTER TABLE ONLY provisioner_keys
     ADD CONSTRAINT provisioner_keys_pkey PRIMARY KEY (id);
 
+ALTER TABLE ONLY saved_searches
+    ADD CONSTRAINT saved_searches_pkey PRIMARY KEY (id);
+
+ALTER TABLE ONLY saved_searches
+    ADD CONSTRAINT saved_searches_user_id_resource_type_name_key UNIQUE (user_id, resource_type, name);
+
 ALTER TABLE ONLY site_configs
     ADD CONSTRAINT site_configs_key_key UNIQUE (key);
 
@@ -1876,6 +2121,9 @@ ALTER TABLE ONLY user_links
 ALTER TABLE ONLY users
     ADD CONSTRAINT users_pkey PRIMARY KEY (id);
 
+ALTER TABLE ONLY workspace_agent_containers
+    ADD CONSTRAINT workspace_agent_containers_pkey PRIMARY KEY (workspace_agent_id, container_id);
+
 ALTER TABLE ONLY workspace_agent_log_sources
     ADD CONSTRAINT workspace_agent_log_sources_pkey PRIMARY KEY (workspace_agent_id, id);
 
@@ -1891,6 +2139,12 @@ ALTER TABLE ONLY workspace_agent_script_timings
 ALTER TABLE ONLY workspace_agent_scripts
     ADD CONSTRAINT workspace_agent_scripts_id_key UNIQUE (id);
 
+ALTER TABLE ONLY workspace_agent_session_recording_chunks
+    ADD CONSTRAINT workspace_agent_session_recording_chunks_pkey PRIMARY KEY (recording_id, sequence);
+
+ALTER TABLE ONLY workspace_agent_session_recordings
+    ADD CONSTRAINT workspace_agent_session_recordings_pkey PRIMARY KEY (id);
+
 ALTER TABLE ONLY workspace_agent_logs
     ADD CONSTRAINT workspace_agent_startup_logs_pkey PRIMARY KEY (id);
 
@@ -1921,6 +2175,12 @@ ALTER TABLE ONLY workspace_builds
 ALTER TABLE ONLY workspace_builds
     ADD CONSTRAINT workspace_builds_workspace_id_build_number_key UNIQUE (workspace_id, build_number);
 
+ALTER TABLE ONLY workspace_drift_checks
+    ADD CONSTRAINT workspace_drift_checks_job_id_key UNIQUE (job_id);
+
+ALTER TABLE ONLY workspace_drift_checks
+    ADD CONSTRAINT workspace_drift_checks_pkey PRIMARY KEY (id);
+
 ALTER TABLE ONLY workspace_proxies
     ADD CONSTRAINT workspace_proxies_pkey PRIMARY KEY (id);
 
@@ -1936,6 +2196,12 @@ ALTER TABLE ONLY workspace_resource_metadata
 ALTER TABLE ONLY workspace_resources
     ADD CONSTRAINT workspace_resources_pkey PRIMARY KEY (id);
 
+ALTER TABLE ONLY workspace_snapshots
+    ADD CONSTRAINT workspace_snapshots_pkey PRIMARY KEY (id);
+
+ALTER TABLE ONLY workspace_snapshots
+    ADD CONSTRAINT workspace_snapshots_workspace_id_name_key UNIQUE (workspace_id, name);
+
 ALTER TABLE ONLY workspaces
     ADD CONSTRAINT workspaces_pkey PRIMARY KEY (id);
 
@@ -1959,6 +2225,8 @@ CREATE INDEX idx_custom_roles_id ON custom_roles USING btree (id);
 
 CREATE UNIQUE INDEX idx_custom_roles_name_lower ON custom_roles USING btree (lower(name));
 
+CREATE INDEX idx_inbox_notifications_user_id_created_at ON inbox_notifications USING btree (user_id, created_at DESC);
+
 CREATE INDEX idx_notification_messages_status ON notification_messages USING btree (status);
 
 CREATE INDEX idx_organization_member_organization_id_uuid ON organization_members USING btree (organization_id);
@@ -1973,6 +2241,8 @@ CREATE UNIQUE INDEX idx_provisioner_daemons_org_name_owner_key ON provisioner_da
 
 COMMENT ON INDEX idx_provisioner_daemons_org_name_owner_key IS 'Allow unique provisioner daemon names by organization and user';
 
+CREATE INDEX idx_saved_searches_organization_id ON saved_searches USING btree (organization_id) WHERE (organization_id IS NOT NULL);
+
 CREATE INDEX idx_tailnet_agents_coordinator ON tailnet_agents USING btree (coordinator_id);
 
 CREATE INDEX idx_tailnet_clients_coordinator ON tailnet_clients USING btree (coordinator_id);
@@ -2013,10 +2283,14 @@ CREATE UNIQUE INDEX users_email_lower_idx ON users USING btree (lower(email)) WH
 
 CREATE UNIQUE INDEX users_username_lower_idx ON users USING btree (lower(username)) WHERE (deleted = false);
 
+CREATE INDEX workspace_agent_metadata_history_agent_id_key_collected_at_idx ON workspace_agent_metadata_history USING btree (workspace_agent_id, key, collected_at DESC);
+
 CREATE INDEX workspace_agent_scripts_workspace_agent_id_idx ON workspace_agent_scripts USING btree (workspace_agent_id);
 
 COMMENT ON INDEX workspace_agent_scripts_workspace_agent_id_idx IS 'Foreign key support index for faster lookups';
 
+CREATE INDEX workspace_agent_session_recordings_workspace_id_idx ON workspace_agent_session_recordings USING btree (workspace_id, started_at DESC);
+
 CREATE INDEX workspace_agent_startup_logs_id_agent_id_idx ON workspace_agent_logs USING btree (agent_id, id);
 
 CREATE INDEX workspace_agent_stats_template_id_created_at_user_id_idx ON workspace_agent_stats USING btree (template_id, created_at, user_id) INCLUDE (session_count_vscode, session_count_jetbrains, session_count_reconnecting_pty, session_count_ssh, connection_median_latency_ms) WHERE (connection_count > 0);
@@ -2029,6 +2303,8 @@ CREATE INDEX workspace_agents_resource_id_idx ON workspace_agents USING btree (r
 
 CREATE INDEX workspace_app_stats_workspace_id_idx ON workspace_app_stats USING btree (workspace_id);
 
+CREATE INDEX workspace_drift_checks_workspace_id_created_at_idx ON workspace_drift_checks USING btree (workspace_id, created_at DESC);
+
 CREATE UNIQUE INDEX workspace_proxies_lower_name_idx ON workspace_proxies USING btree (lower(name)) WHERE (deleted = false);
 
 CREATE INDEX workspace_resources_job_id_idx ON workspace_resources USING btree (job_id);
@@ -2141,12 +2417,21 @@ ALTER TABLE ONLY group_members
 ALTER TABLE ONLY groups
     ADD CONSTRAINT groups_organization_id_fkey FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY inbox_notifications
+    ADD CONSTRAINT inbox_notifications_template_id_fkey FOREIGN KEY (template_id) REFERENCES notification_templates(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY inbox_notifications
+    ADD CONSTRAINT inbox_notifications_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY jfrog_xray_scans
     ADD CONSTRAINT jfrog_xray_scans_agent_id_fkey FOREIGN KEY (agent_id) REFERENCES workspace_agents(id) ON DELETE CASCADE;
 
 ALTER TABLE ONLY jfrog_xray_scans
     ADD CONSTRAINT jfrog_xray_scans_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES workspaces(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY notification_messages
+    ADD CONSTRAINT notification_messages_digest_template_id_fkey FOREIGN KEY (digest_template_id) REFERENCES notification_templates(id) ON DELETE SET NULL;
+
 ALTER TABLE ONLY notification_messages
     ADD CONSTRAINT notification_messages_notification_template_id_fkey FOREIGN KEY (notification_template_id) REFERENCES notification_templates(id) ON DELETE CASCADE;
 
@@ -2159,6 +2444,12 @@ ALTER TABLE ONLY notification_preferences
 ALTER TABLE ONLY notification_preferences
     ADD CONSTRAINT notification_preferences_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY notification_quiet_hours
+    ADD CONSTRAINT notification_quiet_hours_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY notification_templates
+    ADD CONSTRAINT notification_templates_digest_template_id_fkey FOREIGN KEY (digest_template_id) REFERENCES notification_templates(id) ON DELETE SET NULL;
+
 ALTER TABLE ONLY oauth2_provider_app_codes
     ADD CONSTRAINT oauth2_provider_app_codes_app_id_fkey FOREIGN KEY (app_id) REFERENCES oauth2_provider_apps(id) ON DELETE CASCADE;
 
@@ -2192,6 +2483,9 @@ ALTER TABLE ONLY provisioner_daemons
 ALTER TABLE ONLY provisioner_job_logs
     ADD CONSTRAINT provisioner_job_logs_job_id_fkey FOREIGN KEY (job_id) REFERENCES provisioner_jobs(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY provisioner_job_resource_changes
+    ADD CONSTRAINT provisioner_job_resource_changes_job_id_fkey FOREIGN KEY (job_id) REFERENCES provisioner_jobs(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY provisioner_job_timings
     ADD CONSTRAINT provisioner_job_timings_job_id_fkey FOREIGN KEY (job_id) REFERENCES provisioner_jobs(id) ON DELETE CASCADE;
 
@@ -2201,6 +2495,15 @@ ALTER TABLE ONLY provisioner_jobs
 ALTER TABLE ONLY provisioner_keys
     ADD CONSTRAINT provisioner_keys_organization_id_fkey FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY saved_searches
+    ADD CONSTRAINT saved_searches_group_id_fkey FOREIGN KEY (group_id) REFERENCES groups(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY saved_searches
+    ADD CONSTRAINT saved_searches_organization_id_fkey FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY saved_searches
+    ADD CONSTRAINT saved_searches_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY tailnet_agents
     ADD CONSTRAINT tailnet_agents_coordinator_id_fkey FOREIGN KEY (coordinator_id) REFERENCES tailnet_coordinators(id) ON DELETE CASCADE;
 
@@ -2249,12 +2552,18 @@ ALTER TABLE ONLY user_links
 ALTER TABLE ONLY user_links
     ADD CONSTRAINT user_links_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY workspace_agent_containers
+    ADD CONSTRAINT workspace_agent_containers_workspace_agent_id_fkey FOREIGN KEY (workspace_agent_id) REFERENCES workspace_agents(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY workspace_agent_log_sources
     ADD CONSTRAINT workspace_agent_log_sources_workspace_agent_id_fkey FOREIGN KEY (workspace_agent_id) REFERENCES workspace_agents(id) ON DELETE CASCADE;
 
 ALTER TABLE ONLY workspace_agent_metadata
     ADD CONSTRAINT workspace_agent_metadata_workspace_agent_id_fkey FOREIGN KEY (workspace_agent_id) REFERENCES workspace_agents(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY workspace_agent_metadata_history
+    ADD CONSTRAINT workspace_agent_metadata_history_workspace_agent_id_fkey FOREIGN KEY (workspace_agent_id) REFERENCES workspace_agents(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY workspace_agent_port_share
     ADD CONSTRAINT workspace_agent_port_share_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES workspaces(id) ON DELETE CASCADE;
 
@@ -2264,6 +2573,15 @@ ALTER TABLE ONLY workspace_agent_script_timings
 ALTER TABLE ONLY workspace_agent_scripts
     ADD CONSTRAINT workspace_agent_scripts_workspace_agent_id_fkey FOREIGN KEY (workspace_agent_id) REFERENCES workspace_agents(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY workspace_agent_session_recording_chunks
+    ADD CONSTRAINT workspace_agent_session_recording_chunks_recording_id_fkey FOREIGN KEY (recording_id) REFERENCES workspace_agent_session_recordings(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY workspace_agent_session_recordings
+    ADD CONSTRAINT workspace_agent_session_recordings_agent_id_fkey FOREIGN KEY (agent_id) REFERENCES workspace_agents(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY workspace_agent_session_recordings
+    ADD CONSTRAINT workspace_agent_session_recordings_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES workspaces(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY workspace_agent_logs
     ADD CONSTRAINT workspace_agent_startup_logs_agent_id_fkey FOREIGN KEY (agent_id) REFERENCES workspace_agents(id) ON DELETE CASCADE;
 
@@ -2294,12 +2612,33 @@ ALTER TABLE ONLY workspace_builds
 ALTER TABLE ONLY workspace_builds
     ADD CONSTRAINT workspace_builds_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES workspaces(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY workspace_drift_checks
+    ADD CONSTRAINT workspace_drift_checks_job_id_fkey FOREIGN KEY (job_id) REFERENCES provisioner_jobs(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY workspace_drift_checks
+    ADD CONSTRAINT workspace_drift_checks_workspace_build_id_fkey FOREIGN KEY (workspace_build_id) REFERENCES workspace_builds(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY workspace_drift_checks
+    ADD CONSTRAINT workspace_drift_checks_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES workspaces(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY workspace_resource_metadata
     ADD CONSTRAINT workspace_resource_metadata_workspace_resource_id_fkey FOREIGN KEY (workspace_resource_id) REFERENCES workspace_resources(id) ON DELETE CASCADE;
 
 ALTER TABLE ONLY workspace_resources
     ADD CONSTRAINT workspace_resources_job_id_fkey FOREIGN KEY (job_id) REFERENCES provisioner_jobs(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY workspace_snapshots
+    ADD CONSTRAINT workspace_snapshots_created_by_fkey FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE RESTRICT;
+
+ALTER TABLE ONLY workspace_snapshots
+    ADD CONSTRAINT workspace_snapshots_job_id_fkey FOREIGN KEY (job_id) REFERENCES provisioner_jobs(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY workspace_snapshots
+    ADD CONSTRAINT workspace_snapshots_workspace_build_id_fkey FOREIGN KEY (workspace_build_id) REFERENCES workspace_builds(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY workspace_snapshots
+    ADD CONSTRAINT workspace_snapshots_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES workspaces(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY workspaces
     ADD CONSTRAINT workspaces_organization_id_fkey FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE RESTRICT;
 
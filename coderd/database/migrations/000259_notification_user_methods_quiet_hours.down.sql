@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS notification_quiet_hours;
+
+ALTER TABLE notification_messages
+	DROP COLUMN IF EXISTS held_until;
+
+ALTER TABLE notification_preferences
+	DROP COLUMN IF EXISTS method;
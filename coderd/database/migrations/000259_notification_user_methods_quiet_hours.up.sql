@@ -0,0 +1,23 @@
+ALTER TABLE notification_preferences
+	ADD COLUMN method notification_method;
+
+COMMENT ON COLUMN notification_preferences.method IS 'Method by which the user wants to receive this notification; NULL defers to the template or deployment default';
+
+ALTER TABLE notification_messages
+	ADD COLUMN held_until timestamp with time zone;
+
+COMMENT ON COLUMN notification_messages.held_until IS 'Non-urgent messages enqueued during the user''s quiet hours are not dispatched until this time';
+
+CREATE TABLE notification_quiet_hours
+(
+	user_id      uuid                     NOT NULL PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+	start_minute integer                  NOT NULL CHECK (start_minute >= 0 AND start_minute < 1440),
+	end_minute   integer                  NOT NULL CHECK (end_minute >= 0 AND end_minute < 1440),
+	timezone     text                     NOT NULL,
+	created_at   timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at   timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+COMMENT ON TABLE notification_quiet_hours IS 'Daily window during which non-urgent notifications are held back from a user';
+COMMENT ON COLUMN notification_quiet_hours.start_minute IS 'Minutes after midnight in the user''s timezone at which quiet hours begin';
+COMMENT ON COLUMN notification_quiet_hours.end_minute IS 'Minutes after midnight in the user''s timezone at which quiet hours end; may be less than start_minute if the window spans midnight';
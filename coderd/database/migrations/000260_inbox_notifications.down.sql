@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS inbox_notifications;
+
+-- It's not possible to drop enum values from enum types, so the up migration has "IF NOT EXISTS".
@@ -0,0 +1,20 @@
+ALTER TYPE notification_method ADD VALUE IF NOT EXISTS 'inbox';
+
+CREATE TABLE inbox_notifications
+(
+	id          uuid                     NOT NULL PRIMARY KEY,
+	user_id     uuid                     NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+	template_id uuid                     NOT NULL REFERENCES notification_templates (id) ON DELETE CASCADE,
+	title       text                     NOT NULL,
+	content     text                     NOT NULL,
+	actions     jsonb                    NOT NULL DEFAULT '[]'::jsonb,
+	read_at     timestamp with time zone,
+	archived_at timestamp with time zone,
+	created_at  timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+COMMENT ON TABLE inbox_notifications IS 'Rendered notifications delivered by the inbox method, which users can view in the dashboard or CLI';
+COMMENT ON COLUMN inbox_notifications.id IS 'The ID of the notification message from which this inbox notification was dispatched';
+COMMENT ON COLUMN inbox_notifications.actions IS 'Array of {label, url} objects linking to related resources';
+
+CREATE INDEX idx_inbox_notifications_user_id_created_at ON inbox_notifications (user_id, created_at DESC);
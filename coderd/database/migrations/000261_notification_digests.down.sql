@@ -0,0 +1,10 @@
+COMMENT ON COLUMN notification_messages.held_until IS 'Non-urgent messages enqueued during the user''s quiet hours are not dispatched until this time';
+
+ALTER TABLE notification_messages
+	DROP COLUMN digest_template_id;
+
+ALTER TABLE notification_templates
+	DROP COLUMN digest_template_id;
+
+DELETE FROM notification_templates
+WHERE id IN ('7f1c8a4e-3b9d-4e2a-8c6f-1d5e9b2a7c30', 'c2e5d9b1-6a4f-4f8e-9d3c-8b7a2e1f5d46');
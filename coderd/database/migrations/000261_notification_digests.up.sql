@@ -0,0 +1,43 @@
+INSERT INTO notification_templates (id, name, title_template, body_template, "group", actions)
+VALUES ('7f1c8a4e-3b9d-4e2a-8c6f-1d5e9b2a7c30', 'Workspaces Marked as Dormant', E'{{.Data.count}} workspaces marked as dormant',
+        E'Hi {{.UserName}}\n\n' ||
+        E'The following workspaces have been marked as [**dormant**](https://coder.com/docs/templates/schedule#dormancy-threshold-enterprise):\n\n' ||
+        E'{{range .Data.items}}- [**{{.name}}**]({{.url}}) because of {{.reason}}; it will be [automatically deleted](https://coder.com/docs/templates/schedule#dormancy-auto-deletion-enterprise) after {{.timeTilDormant}} of inactivity\n{{end}}\n' ||
+        E'To prevent deletion, use your workspaces.',
+        'Workspace Events', '[
+        {
+            "label": "View workspaces",
+            "url": "{{ base_url }}/workspaces"
+        }
+    ]'::jsonb),
+       ('c2e5d9b1-6a4f-4f8e-9d3c-8b7a2e1f5d46', 'Workspaces Marked for Deletion', E'{{.Data.count}} workspaces marked for deletion',
+        E'Hi {{.UserName}}\n\n' ||
+        E'The following workspaces have been marked for **deletion** after a period of [dormancy](https://coder.com/docs/templates/schedule#dormancy-auto-deletion-enterprise):\n\n' ||
+        E'{{range .Data.items}}- [**{{.name}}**]({{.url}}) will be deleted after {{.timeTilDormant}} because of {{.reason}}\n{{end}}\n' ||
+        E'To prevent deletion, use your workspaces.',
+        'Workspace Events', '[
+        {
+            "label": "View workspaces",
+            "url": "{{ base_url }}/workspaces"
+        }
+    ]'::jsonb);
+
+ALTER TABLE notification_templates
+	ADD COLUMN digest_template_id uuid REFERENCES notification_templates (id) ON DELETE SET NULL;
+
+COMMENT ON COLUMN notification_templates.digest_template_id IS 'Template with which messages for the same user enqueued within the same digest window are delivered together; NULL disables digests';
+
+ALTER TABLE notification_messages
+	ADD COLUMN digest_template_id uuid REFERENCES notification_templates (id) ON DELETE SET NULL;
+
+COMMENT ON COLUMN notification_messages.digest_template_id IS 'Set while the message awaits the digest generator, which delivers it together with other messages for the same user and template; such messages are not dispatched individually';
+
+COMMENT ON COLUMN notification_messages.held_until IS 'Messages are not dispatched until this time; set for non-urgent messages enqueued during the user''s quiet hours, and for messages awaiting the end of their digest window';
+
+UPDATE notification_templates
+SET digest_template_id = '7f1c8a4e-3b9d-4e2a-8c6f-1d5e9b2a7c30'
+WHERE id = '0ea69165-ec14-4314-91f1-69566ac3c5a0';
+
+UPDATE notification_templates
+SET digest_template_id = 'c2e5d9b1-6a4f-4f8e-9d3c-8b7a2e1f5d46'
+WHERE id = '51ce2fdf-c9ca-4be1-8d70-628674f9bc42';
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS saved_searches;
+
+DROP TYPE IF EXISTS saved_search_resource_type;
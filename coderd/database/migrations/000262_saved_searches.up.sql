@@ -0,0 +1,23 @@
+CREATE TYPE saved_search_resource_type AS ENUM ('workspace', 'template', 'user', 'audit_log');
+
+CREATE TABLE saved_searches
+(
+	id              uuid                       NOT NULL PRIMARY KEY,
+	user_id         uuid                       NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+	resource_type   saved_search_resource_type NOT NULL,
+	name            text                       NOT NULL,
+	query           text                       NOT NULL,
+	organization_id uuid REFERENCES organizations (id) ON DELETE CASCADE,
+	group_id        uuid REFERENCES groups (id) ON DELETE CASCADE,
+	created_at      timestamp with time zone   NOT NULL,
+	updated_at      timestamp with time zone   NOT NULL,
+	CONSTRAINT saved_searches_user_id_resource_type_name_key UNIQUE (user_id, resource_type, name),
+	CONSTRAINT saved_searches_group_requires_organization CHECK (group_id IS NULL OR organization_id IS NOT NULL)
+);
+
+COMMENT ON TABLE saved_searches IS 'Named search queries, which users can share with an organization or group';
+COMMENT ON COLUMN saved_searches.query IS 'The search query, in the syntax used to filter resources of resource_type';
+COMMENT ON COLUMN saved_searches.organization_id IS 'The organization the search is shared with, or NULL if it is private';
+COMMENT ON COLUMN saved_searches.group_id IS 'The group within organization_id the search is shared with, or NULL if it is shared with all members';
+
+CREATE INDEX idx_saved_searches_organization_id ON saved_searches (organization_id) WHERE organization_id IS NOT NULL;
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS workspace_snapshots;
+
+-- It's not possible to drop enum values from enum types, so the UP has "IF NOT
+-- EXISTS".
+
+-- Delete all provisioner jobs that use the new enum value.
+DELETE FROM provisioner_jobs WHERE type = 'workspace_snapshot';
@@ -0,0 +1,19 @@
+ALTER TYPE provisioner_job_type ADD VALUE IF NOT EXISTS 'workspace_snapshot';
+
+CREATE TABLE workspace_snapshots
+(
+	id                 uuid                     NOT NULL PRIMARY KEY,
+	workspace_id       uuid                     NOT NULL REFERENCES workspaces (id) ON DELETE CASCADE,
+	workspace_build_id uuid                     NOT NULL REFERENCES workspace_builds (id) ON DELETE CASCADE,
+	job_id             uuid                     NOT NULL REFERENCES provisioner_jobs (id) ON DELETE CASCADE,
+	name               text                     NOT NULL,
+	data               bytea                    NOT NULL DEFAULT ''::bytea,
+	created_by         uuid                     NOT NULL REFERENCES users (id) ON DELETE RESTRICT,
+	created_at         timestamp with time zone NOT NULL,
+	CONSTRAINT workspace_snapshots_workspace_id_name_key UNIQUE (workspace_id, name)
+);
+
+COMMENT ON TABLE workspace_snapshots IS 'Named snapshots of a workspace, taken by the provisioner of the template';
+COMMENT ON COLUMN workspace_snapshots.workspace_build_id IS 'The build whose state the snapshot was taken from';
+COMMENT ON COLUMN workspace_snapshots.job_id IS 'The provisioner job that created the snapshot';
+COMMENT ON COLUMN workspace_snapshots.data IS 'Opaque data returned by the provisioner, which is handed back to it on restore';
@@ -0,0 +1,58 @@
+ALTER TABLE templates ADD COLUMN session_recording boolean NOT NULL DEFAULT false;
+
+COMMENT ON COLUMN templates.session_recording IS 'Whether interactive SSH sessions into workspaces of this template are recorded';
+
+-- Update the template_with_names view by recreating it.
+DROP VIEW template_with_names;
+CREATE VIEW
+	template_with_names
+AS
+SELECT
+	templates.*,
+	coalesce(visible_users.avatar_url, '') AS created_by_avatar_url,
+	coalesce(visible_users.username, '') AS created_by_username,
+	coalesce(organizations.name, '') AS organization_name,
+	coalesce(organizations.display_name, '') AS organization_display_name,
+	coalesce(organizations.icon, '') AS organization_icon
+FROM
+	templates
+		LEFT JOIN
+	visible_users
+	ON
+		templates.created_by = visible_users.id
+		LEFT JOIN
+	organizations
+	ON templates.organization_id = organizations.id
+;
+
+COMMENT ON VIEW template_with_names IS 'Joins in the display name information such as username, avatar, and organization name.';
+
+CREATE TABLE workspace_agent_session_recordings
+(
+	id           uuid                     NOT NULL PRIMARY KEY,
+	workspace_id uuid                     NOT NULL REFERENCES workspaces (id) ON DELETE CASCADE,
+	agent_id     uuid                     NOT NULL REFERENCES workspace_agents (id) ON DELETE CASCADE,
+	session_type text                     NOT NULL,
+	command      text                     NOT NULL DEFAULT '',
+	started_at   timestamp with time zone NOT NULL,
+	ended_at     timestamp with time zone,
+	size         bigint                   NOT NULL DEFAULT 0
+);
+
+COMMENT ON TABLE workspace_agent_session_recordings IS 'Recordings of interactive PTY sessions made by workspace agents, in asciicast v2 format';
+COMMENT ON COLUMN workspace_agent_session_recordings.id IS 'The ID the agent assigned to the SSH session, which also appears in the agent logs';
+COMMENT ON COLUMN workspace_agent_session_recordings.session_type IS 'The type of SSH session, e.g. ssh, vscode or jetbrains';
+COMMENT ON COLUMN workspace_agent_session_recordings.command IS 'The command run in the session, empty for a login shell';
+COMMENT ON COLUMN workspace_agent_session_recordings.size IS 'The total size in bytes of the recording chunks';
+
+CREATE INDEX workspace_agent_session_recordings_workspace_id_idx ON workspace_agent_session_recordings (workspace_id, started_at DESC);
+
+CREATE TABLE workspace_agent_session_recording_chunks
+(
+	recording_id uuid    NOT NULL REFERENCES workspace_agent_session_recordings (id) ON DELETE CASCADE,
+	sequence     integer NOT NULL,
+	data         bytea   NOT NULL,
+	PRIMARY KEY (recording_id, sequence)
+);
+
+COMMENT ON TABLE workspace_agent_session_recording_chunks IS 'Chunks of a session recording, which concatenated in sequence order form the asciicast file';
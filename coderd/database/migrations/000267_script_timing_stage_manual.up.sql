@@ -0,0 +1,2 @@
+ALTER TYPE workspace_agent_script_timing_stage
+  ADD VALUE IF NOT EXISTS 'manual';
@@ -0,0 +1,10 @@
+ALTER TABLE workspace_apps
+	DROP COLUMN healthcheck_kind,
+	DROP COLUMN healthcheck_expected_status,
+	DROP COLUMN healthcheck_body_regex,
+	DROP COLUMN healthcheck_address,
+	DROP COLUMN healthcheck_grpc_service,
+	DROP COLUMN healthcheck_command,
+	DROP COLUMN healthcheck_path;
+
+DROP TYPE workspace_app_healthcheck_kind;
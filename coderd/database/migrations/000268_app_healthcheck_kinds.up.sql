@@ -0,0 +1,21 @@
+CREATE TYPE workspace_app_healthcheck_kind AS ENUM (
+    'http',
+    'tcp',
+    'grpc',
+    'command',
+    'file'
+);
+
+ALTER TABLE workspace_apps
+	ADD COLUMN healthcheck_kind workspace_app_healthcheck_kind NOT NULL DEFAULT 'http'::workspace_app_healthcheck_kind,
+	ADD COLUMN healthcheck_expected_status integer NOT NULL DEFAULT 0,
+	ADD COLUMN healthcheck_body_regex text NOT NULL DEFAULT ''::text,
+	ADD COLUMN healthcheck_address text NOT NULL DEFAULT ''::text,
+	ADD COLUMN healthcheck_grpc_service text NOT NULL DEFAULT ''::text,
+	ADD COLUMN healthcheck_command text NOT NULL DEFAULT ''::text,
+	ADD COLUMN healthcheck_path text NOT NULL DEFAULT ''::text;
+
+COMMENT ON COLUMN workspace_apps.healthcheck_kind IS 'Specifies how the health of the app is checked. Each kind uses its own subset of the healthcheck columns.';
+COMMENT ON COLUMN workspace_apps.healthcheck_expected_status IS 'The status code an http health check must return. When 0, any status code below 500 is healthy.';
+COMMENT ON COLUMN workspace_apps.healthcheck_body_regex IS 'A regular expression the body of an http health check response must match.';
+COMMENT ON COLUMN workspace_apps.healthcheck_address IS 'The host and port a tcp or grpc health check connects to.';
@@ -0,0 +1,11 @@
+CREATE UNLOGGED TABLE workspace_agent_metadata_history (
+    workspace_agent_id uuid NOT NULL REFERENCES workspace_agents(id) ON DELETE CASCADE,
+    key character varying(127) NOT NULL,
+    value character varying(65535) DEFAULT ''::character varying NOT NULL,
+    error character varying(65535) DEFAULT ''::character varying NOT NULL,
+    collected_at timestamp with time zone NOT NULL
+);
+
+COMMENT ON TABLE workspace_agent_metadata_history IS 'Historical samples of workspace agent metadata values. Rows are purged by dbpurge according to the agent metadata history retention.';
+
+CREATE INDEX workspace_agent_metadata_history_agent_id_key_collected_at_idx ON workspace_agent_metadata_history USING btree (workspace_agent_id, key, collected_at DESC);
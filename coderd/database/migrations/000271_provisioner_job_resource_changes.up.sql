@@ -0,0 +1,15 @@
+CREATE TYPE resource_change_action AS ENUM ('create', 'update', 'replace', 'delete');
+
+CREATE TABLE provisioner_job_resource_changes
+(
+	job_id     uuid                   NOT NULL REFERENCES provisioner_jobs (id) ON DELETE CASCADE,
+	address    text                   NOT NULL,
+	type       text                   NOT NULL,
+	name       text                   NOT NULL,
+	action     resource_change_action NOT NULL,
+	attributes jsonb                  NOT NULL DEFAULT '[]'::jsonb,
+	PRIMARY KEY (job_id, address)
+);
+
+COMMENT ON TABLE provisioner_job_resource_changes IS 'Changes the plan of a dry-run job makes to the resources of the workspace it previews an update of';
+COMMENT ON COLUMN provisioner_job_resource_changes.attributes IS 'The changed top-level attributes of the resource, without the values of sensitive attributes';
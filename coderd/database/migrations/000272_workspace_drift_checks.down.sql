@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS workspace_drift_checks;
+
+COMMENT ON TABLE provisioner_job_resource_changes IS 'Changes the plan of a dry-run job makes to the resources of the workspace it previews an update of';
+
+-- It's not possible to drop enum values from enum types, so the UP has "IF NOT
+-- EXISTS".
+
+-- Delete all provisioner jobs that use the new enum value.
+DELETE FROM provisioner_jobs WHERE type = 'workspace_drift_check';
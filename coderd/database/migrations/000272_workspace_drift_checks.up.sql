@@ -0,0 +1,18 @@
+ALTER TYPE provisioner_job_type ADD VALUE IF NOT EXISTS 'workspace_drift_check';
+
+CREATE TABLE workspace_drift_checks
+(
+	id                 uuid                     NOT NULL PRIMARY KEY,
+	workspace_id       uuid                     NOT NULL REFERENCES workspaces (id) ON DELETE CASCADE,
+	workspace_build_id uuid                     NOT NULL REFERENCES workspace_builds (id) ON DELETE CASCADE,
+	job_id             uuid                     NOT NULL UNIQUE REFERENCES provisioner_jobs (id) ON DELETE CASCADE,
+	created_at         timestamp with time zone NOT NULL
+);
+
+CREATE INDEX workspace_drift_checks_workspace_id_created_at_idx ON workspace_drift_checks (workspace_id, created_at DESC);
+
+COMMENT ON TABLE workspace_drift_checks IS 'Checks of the infrastructure of a workspace for drift from the state of a build, run by a provisioner job';
+COMMENT ON COLUMN workspace_drift_checks.workspace_build_id IS 'The build whose state was checked';
+COMMENT ON COLUMN workspace_drift_checks.job_id IS 'The provisioner job that ran the check, whose resource changes are the drifted resources';
+
+COMMENT ON TABLE provisioner_job_resource_changes IS 'Changes to the resources of a workspace: planned by a dry-run job that previews an update of it, or detected by a drift check job';
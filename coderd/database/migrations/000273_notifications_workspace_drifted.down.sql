@@ -0,0 +1 @@
+DELETE FROM notification_templates WHERE id = '8b4a1c8e-3f2d-4c6b-9e7a-5d1f0a2b6c94';
@@ -0,0 +1,16 @@
+INSERT INTO notification_templates (id, name, title_template, body_template, "group", actions)
+VALUES ('8b4a1c8e-3f2d-4c6b-9e7a-5d1f0a2b6c94', 'Workspace Drifted', E'Workspace "{{.Labels.name}}" has drifted from its state',
+        E'Hi {{.UserName}},
+
+Resources of your workspace **{{.Labels.name}}** were changed or deleted outside of Coder:
+{{range $resource := .Data.drifted_resources}}
+* `{{$resource.address}}` ({{if eq $resource.action "delete"}}deleted{{else}}changed{{end}})
+{{- end}}
+
+Restart the workspace to restore the resources, or contact your template administrator if the drift is expected.',
+        'Workspace Events', '[
+        {
+            "label": "View workspace",
+            "url": "{{ base_url }}/@{{.UserName}}/{{.Labels.name}}"
+        }
+    ]'::jsonb);
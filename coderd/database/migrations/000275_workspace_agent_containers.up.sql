@@ -0,0 +1,17 @@
+CREATE UNLOGGED TABLE workspace_agent_containers (
+    workspace_agent_id uuid NOT NULL REFERENCES workspace_agents(id) ON DELETE CASCADE,
+    container_id text NOT NULL,
+    name text NOT NULL,
+    image text NOT NULL,
+    status text NOT NULL,
+    runtime text NOT NULL,
+    labels jsonb DEFAULT '{}'::jsonb NOT NULL,
+    ports jsonb DEFAULT '[]'::jsonb NOT NULL,
+    created_at timestamp with time zone NOT NULL,
+    reported_at timestamp with time zone NOT NULL,
+    PRIMARY KEY (workspace_agent_id, container_id)
+);
+
+COMMENT ON TABLE workspace_agent_containers IS 'The containers running in the workspace of an agent, as last reported by the agent.';
+
+COMMENT ON COLUMN workspace_agent_containers.created_at IS 'When the container was created, as reported by the container runtime.';
@@ -0,0 +1,2 @@
+INSERT INTO notification_quiet_hours (user_id, start_minute, end_minute, timezone, created_at, updated_at)
+VALUES ('a0061a8e-7db7-4585-838c-3116a003dd21', 1320, 420, 'Europe/London', '2024-09-20 10:30:00+00', '2024-09-20 10:30:00+00');
@@ -0,0 +1,4 @@
+INSERT INTO inbox_notifications (id, user_id, template_id, title, content, actions, read_at, created_at)
+VALUES ('2f1f1a44-2d3e-4c71-9b61-1c3a0c7e9b55', 'a0061a8e-7db7-4585-838c-3116a003dd21', 'a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11',
+		'Workspace "dev" deleted', 'Your workspace **dev** was deleted.', '[{"label": "View workspaces", "url": "https://coder.example.com/workspaces"}]',
+		NULL, '2024-09-20 10:30:00+00');
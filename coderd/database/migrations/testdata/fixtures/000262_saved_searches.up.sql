@@ -0,0 +1,3 @@
+INSERT INTO saved_searches (id, user_id, resource_type, name, query, organization_id, group_id, created_at, updated_at)
+VALUES ('6c2c1a4f-0f3e-4b8e-9a43-93d1c2b5e7a1', '30095c71-380b-457a-8995-97b8ee6e5307', 'workspace', 'stale-ws',
+		'dormant:true -owner:me', 'bb640d07-ca8a-4869-b6bc-ae61ebb2fda1', NULL, '2024-09-20 10:30:00+00', '2024-09-20 10:30:00+00');
@@ -0,0 +1,4 @@
+INSERT INTO workspace_snapshots (id, workspace_id, workspace_build_id, job_id, name, data, created_by, created_at)
+VALUES ('e1b6d5a4-7c1b-4a0e-9f7e-2d3c4b5a6f70', '3a9a1feb-e89d-457c-9d53-ac751b198ebe', 'ea36844d-8eb6-41a2-a237-e9a8ae3f99ea',
+		'104c5815-7bd2-4d09-b76c-00c61b95f0a6', 'before-upgrade', '\x', '30095c71-380b-457a-8995-97b8ee6e5307',
+		'2024-09-20 10:30:00+00');
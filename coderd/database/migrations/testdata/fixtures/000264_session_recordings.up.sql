@@ -0,0 +1,6 @@
+INSERT INTO workspace_agent_session_recordings (id, workspace_id, agent_id, session_type, command, started_at, ended_at, size)
+VALUES ('7c0f1a3e-5b2d-4e8f-9a61-3d2c4b5e6f71', '3a9a1feb-e89d-457c-9d53-ac751b198ebe', '45e89705-e09d-4850-bcec-f9a937f5d78d',
+		'ssh', '', '2024-09-20 10:30:00+00', '2024-09-20 10:35:00+00', 59);
+
+INSERT INTO workspace_agent_session_recording_chunks (recording_id, sequence, data)
+VALUES ('7c0f1a3e-5b2d-4e8f-9a61-3d2c4b5e6f71', 0, '{"version":2,"width":80,"height":24,"timestamp":1726828200}');
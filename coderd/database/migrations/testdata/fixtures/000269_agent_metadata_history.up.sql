@@ -0,0 +1,2 @@
+INSERT INTO workspace_agent_metadata_history (workspace_agent_id, key, value, error, collected_at)
+VALUES ('45e89705-e09d-4850-bcec-f9a937f5d78d', 'cpu', '12%', '', '2024-09-20 10:30:00+00');
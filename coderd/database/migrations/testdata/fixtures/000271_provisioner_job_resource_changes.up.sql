@@ -0,0 +1,3 @@
+INSERT INTO provisioner_job_resource_changes (job_id, address, type, name, action, attributes)
+VALUES ('104c5815-7bd2-4d09-b76c-00c61b95f0a6', 'docker_volume.home', 'docker_volume', 'home', 'replace',
+		'[{"name": "name", "before": "\"coder-home\"", "after": "\"coder-home-2\"", "forces_replacement": true}]');
@@ -0,0 +1,3 @@
+INSERT INTO workspace_drift_checks (id, workspace_id, workspace_build_id, job_id, created_at)
+VALUES ('5f0a3a2e-1c9b-4d7e-8a6f-3b2c1d0e9f84', '3a9a1feb-e89d-457c-9d53-ac751b198ebe', 'ea36844d-8eb6-41a2-a237-e9a8ae3f99ea',
+		'104c5815-7bd2-4d09-b76c-00c61b95f0a6', '2024-09-20 11:00:00+00');
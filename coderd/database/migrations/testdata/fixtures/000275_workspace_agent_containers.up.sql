@@ -0,0 +1,2 @@
+INSERT INTO workspace_agent_containers (workspace_agent_id, container_id, name, image, status, runtime, labels, ports, created_at, reported_at)
+VALUES ('45e89705-e09d-4850-bcec-f9a937f5d78d', '0b1c2d3e', 'vibrant_tesla', 'postgres:16', 'running', 'docker', '{}', '[]', '2024-09-20 10:30:00+00', '2024-09-20 10:31:00+00');
@@ -305,6 +305,29 @@ func (a GetOAuth2ProviderAppsByUserIDRow) RBACObject() rbac.Object {
 	return a.OAuth2ProviderApp.RBACObject()
 }
 
+func (n InboxNotification) RBACObject() rbac.Object {
+	return rbac.ResourceInboxNotification.WithID(n.ID).WithOwner(n.UserID.String())
+}
+
+func (s SavedSearch) RBACObject() rbac.Object {
+	obj := rbac.ResourceSavedSearch.WithID(s.ID).WithOwner(s.UserID.String())
+	if !s.OrganizationID.Valid {
+		return obj
+	}
+	// Searches shared with a whole organization are readable by its
+	// "Everyone" group, which shares the organization's ID.
+	shared := s.OrganizationID.UUID
+	if s.GroupID.Valid {
+		shared = s.GroupID.UUID
+	}
+	return obj.InOrg(s.OrganizationID.UUID).
+		WithGroupACL(map[string][]policy.Action{
+			shared.String(): {
+				policy.ActionRead,
+			},
+		})
+}
+
 type WorkspaceAgentConnectionStatus struct {
 	Status           WorkspaceAgentStatus `json:"status"`
 	FirstConnectedAt *time.Time           `json:"first_connected_at"`
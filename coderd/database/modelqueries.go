@@ -116,6 +116,9 @@ func (q *sqlQuerier) GetAuthorizedTemplates(ctx context.Context, arg GetTemplate
 			&i.Deprecated,
 			&i.ActivityBump,
 			&i.MaxPortSharingLevel,
+			&i.SessionRecording,
+			&i.PortForwardingPolicy,
+			&i.AgentActivityBump,
 			&i.CreatedByAvatarURL,
 			&i.CreatedByUsername,
 			&i.OrganizationName,
@@ -260,6 +263,12 @@ func (q *sqlQuerier) GetAuthorizedWorkspaces(ctx context.Context, arg GetWorkspa
 		arg.LastUsedBefore,
 		arg.LastUsedAfter,
 		arg.UsingActive,
+		arg.CreatedBefore,
+		arg.CreatedAfter,
+		pq.Array(arg.TermGroups),
+		pq.Array(arg.TermKeys),
+		pq.Array(arg.TermValues),
+		pq.Array(arg.TermNegated),
 		arg.RequesterID,
 		arg.Offset,
 		arg.Limit,
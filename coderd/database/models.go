@@ -146,6 +146,7 @@ const (
 	AuditActionLogin    AuditAction = "login"
 	AuditActionLogout   AuditAction = "logout"
 	AuditActionRegister AuditAction = "register"
+	AuditActionExec     AuditAction = "exec"
 )
 
 func (e *AuditAction) Scan(src interface{}) error {
@@ -192,7 +193,8 @@ func (e AuditAction) Valid() bool {
 		AuditActionStop,
 		AuditActionLogin,
 		AuditActionLogout,
-		AuditActionRegister:
+		AuditActionRegister,
+		AuditActionExec:
 		return true
 	}
 	return false
@@ -208,6 +210,7 @@ func AllAuditActionValues() []AuditAction {
 		AuditActionLogin,
 		AuditActionLogout,
 		AuditActionRegister,
+		AuditActionExec,
 	}
 }
 
@@ -799,6 +802,9 @@ type NotificationMethod string
 const (
 	NotificationMethodSmtp    NotificationMethod = "smtp"
 	NotificationMethodWebhook NotificationMethod = "webhook"
+	NotificationMethodSlack   NotificationMethod = "slack"
+	NotificationMethodTeams   NotificationMethod = "teams"
+	NotificationMethodInbox   NotificationMethod = "inbox"
 )
 
 func (e *NotificationMethod) Scan(src interface{}) error {
@@ -839,7 +845,10 @@ func (ns NullNotificationMethod) Value() (driver.Value, error) {
 func (e NotificationMethod) Valid() bool {
 	switch e {
 	case NotificationMethodSmtp,
-		NotificationMethodWebhook:
+		NotificationMethodWebhook,
+		NotificationMethodSlack,
+		NotificationMethodTeams,
+		NotificationMethodInbox:
 		return true
 	}
 	return false
@@ -849,6 +858,9 @@ func AllNotificationMethodValues() []NotificationMethod {
 	return []NotificationMethod{
 		NotificationMethodSmtp,
 		NotificationMethodWebhook,
+		NotificationMethodSlack,
+		NotificationMethodTeams,
+		NotificationMethodInbox,
 	}
 }
 
@@ -1347,6 +1359,8 @@ const (
 	ProvisionerJobTypeTemplateVersionImport ProvisionerJobType = "template_version_import"
 	ProvisionerJobTypeWorkspaceBuild        ProvisionerJobType = "workspace_build"
 	ProvisionerJobTypeTemplateVersionDryRun ProvisionerJobType = "template_version_dry_run"
+	ProvisionerJobTypeWorkspaceSnapshot     ProvisionerJobType = "workspace_snapshot"
+	ProvisionerJobTypeWorkspaceDriftCheck   ProvisionerJobType = "workspace_drift_check"
 )
 
 func (e *ProvisionerJobType) Scan(src interface{}) error {
@@ -1388,7 +1402,9 @@ func (e ProvisionerJobType) Valid() bool {
 	switch e {
 	case ProvisionerJobTypeTemplateVersionImport,
 		ProvisionerJobTypeWorkspaceBuild,
-		ProvisionerJobTypeTemplateVersionDryRun:
+		ProvisionerJobTypeTemplateVersionDryRun,
+		ProvisionerJobTypeWorkspaceSnapshot,
+		ProvisionerJobTypeWorkspaceDriftCheck:
 		return true
 	}
 	return false
@@ -1399,6 +1415,8 @@ func AllProvisionerJobTypeValues() []ProvisionerJobType {
 		ProvisionerJobTypeTemplateVersionImport,
 		ProvisionerJobTypeWorkspaceBuild,
 		ProvisionerJobTypeTemplateVersionDryRun,
+		ProvisionerJobTypeWorkspaceSnapshot,
+		ProvisionerJobTypeWorkspaceDriftCheck,
 	}
 }
 
@@ -1515,6 +1533,70 @@ func AllProvisionerTypeValues() []ProvisionerType {
 	}
 }
 
+type ResourceChangeAction string
+
+const (
+	ResourceChangeActionCreate  ResourceChangeAction = "create"
+	ResourceChangeActionUpdate  ResourceChangeAction = "update"
+	ResourceChangeActionReplace ResourceChangeAction = "replace"
+	ResourceChangeActionDelete  ResourceChangeAction = "delete"
+)
+
+func (e *ResourceChangeAction) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ResourceChangeAction(s)
+	case string:
+		*e = ResourceChangeAction(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ResourceChangeAction: %T", src)
+	}
+	return nil
+}
+
+type NullResourceChangeAction struct {
+	ResourceChangeAction ResourceChangeAction `json:"resource_change_action"`
+	Valid                bool                 `json:"valid"` // Valid is true if ResourceChangeAction is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullResourceChangeAction) Scan(value interface{}) error {
+	if value == nil {
+		ns.ResourceChangeAction, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ResourceChangeAction.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullResourceChangeAction) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ResourceChangeAction), nil
+}
+
+func (e ResourceChangeAction) Valid() bool {
+	switch e {
+	case ResourceChangeActionCreate,
+		ResourceChangeActionUpdate,
+		ResourceChangeActionReplace,
+		ResourceChangeActionDelete:
+		return true
+	}
+	return false
+}
+
+func AllResourceChangeActionValues() []ResourceChangeAction {
+	return []ResourceChangeAction{
+		ResourceChangeActionCreate,
+		ResourceChangeActionUpdate,
+		ResourceChangeActionReplace,
+		ResourceChangeActionDelete,
+	}
+}
+
 type ResourceType string
 
 const (
@@ -1624,6 +1706,70 @@ func AllResourceTypeValues() []ResourceType {
 	}
 }
 
+type SavedSearchResourceType string
+
+const (
+	SavedSearchResourceTypeWorkspace SavedSearchResourceType = "workspace"
+	SavedSearchResourceTypeTemplate  SavedSearchResourceType = "template"
+	SavedSearchResourceTypeUser      SavedSearchResourceType = "user"
+	SavedSearchResourceTypeAuditLog  SavedSearchResourceType = "audit_log"
+)
+
+func (e *SavedSearchResourceType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = SavedSearchResourceType(s)
+	case string:
+		*e = SavedSearchResourceType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for SavedSearchResourceType: %T", src)
+	}
+	return nil
+}
+
+type NullSavedSearchResourceType struct {
+	SavedSearchResourceType SavedSearchResourceType `json:"saved_search_resource_type"`
+	Valid                   bool                    `json:"valid"` // Valid is true if SavedSearchResourceType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullSavedSearchResourceType) Scan(value interface{}) error {
+	if value == nil {
+		ns.SavedSearchResourceType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.SavedSearchResourceType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullSavedSearchResourceType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.SavedSearchResourceType), nil
+}
+
+func (e SavedSearchResourceType) Valid() bool {
+	switch e {
+	case SavedSearchResourceTypeWorkspace,
+		SavedSearchResourceTypeTemplate,
+		SavedSearchResourceTypeUser,
+		SavedSearchResourceTypeAuditLog:
+		return true
+	}
+	return false
+}
+
+func AllSavedSearchResourceTypeValues() []SavedSearchResourceType {
+	return []SavedSearchResourceType{
+		SavedSearchResourceTypeWorkspace,
+		SavedSearchResourceTypeTemplate,
+		SavedSearchResourceTypeUser,
+		SavedSearchResourceTypeAuditLog,
+	}
+}
+
 type StartupScriptBehavior string
 
 const (
@@ -1885,9 +2031,10 @@ func AllWorkspaceAgentLifecycleStateValues() []WorkspaceAgentLifecycleState {
 type WorkspaceAgentScriptTimingStage string
 
 const (
-	WorkspaceAgentScriptTimingStageStart WorkspaceAgentScriptTimingStage = "start"
-	WorkspaceAgentScriptTimingStageStop  WorkspaceAgentScriptTimingStage = "stop"
-	WorkspaceAgentScriptTimingStageCron  WorkspaceAgentScriptTimingStage = "cron"
+	WorkspaceAgentScriptTimingStageStart  WorkspaceAgentScriptTimingStage = "start"
+	WorkspaceAgentScriptTimingStageStop   WorkspaceAgentScriptTimingStage = "stop"
+	WorkspaceAgentScriptTimingStageCron   WorkspaceAgentScriptTimingStage = "cron"
+	WorkspaceAgentScriptTimingStageManual WorkspaceAgentScriptTimingStage = "manual"
 )
 
 func (e *WorkspaceAgentScriptTimingStage) Scan(src interface{}) error {
@@ -1929,7 +2076,8 @@ func (e WorkspaceAgentScriptTimingStage) Valid() bool {
 	switch e {
 	case WorkspaceAgentScriptTimingStageStart,
 		WorkspaceAgentScriptTimingStageStop,
-		WorkspaceAgentScriptTimingStageCron:
+		WorkspaceAgentScriptTimingStageCron,
+		WorkspaceAgentScriptTimingStageManual:
 		return true
 	}
 	return false
@@ -1940,6 +2088,7 @@ func AllWorkspaceAgentScriptTimingStageValues() []WorkspaceAgentScriptTimingStag
 		WorkspaceAgentScriptTimingStageStart,
 		WorkspaceAgentScriptTimingStageStop,
 		WorkspaceAgentScriptTimingStageCron,
+		WorkspaceAgentScriptTimingStageManual,
 	}
 }
 
@@ -2136,6 +2285,73 @@ func AllWorkspaceAppHealthValues() []WorkspaceAppHealth {
 	}
 }
 
+type WorkspaceAppHealthcheckKind string
+
+const (
+	WorkspaceAppHealthcheckKindHttp    WorkspaceAppHealthcheckKind = "http"
+	WorkspaceAppHealthcheckKindTcp     WorkspaceAppHealthcheckKind = "tcp"
+	WorkspaceAppHealthcheckKindGrpc    WorkspaceAppHealthcheckKind = "grpc"
+	WorkspaceAppHealthcheckKindCommand WorkspaceAppHealthcheckKind = "command"
+	WorkspaceAppHealthcheckKindFile    WorkspaceAppHealthcheckKind = "file"
+)
+
+func (e *WorkspaceAppHealthcheckKind) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = WorkspaceAppHealthcheckKind(s)
+	case string:
+		*e = WorkspaceAppHealthcheckKind(s)
+	default:
+		return fmt.Errorf("unsupported scan type for WorkspaceAppHealthcheckKind: %T", src)
+	}
+	return nil
+}
+
+type NullWorkspaceAppHealthcheckKind struct {
+	WorkspaceAppHealthcheckKind WorkspaceAppHealthcheckKind `json:"workspace_app_healthcheck_kind"`
+	Valid                       bool                        `json:"valid"` // Valid is true if WorkspaceAppHealthcheckKind is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullWorkspaceAppHealthcheckKind) Scan(value interface{}) error {
+	if value == nil {
+		ns.WorkspaceAppHealthcheckKind, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.WorkspaceAppHealthcheckKind.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullWorkspaceAppHealthcheckKind) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.WorkspaceAppHealthcheckKind), nil
+}
+
+func (e WorkspaceAppHealthcheckKind) Valid() bool {
+	switch e {
+	case WorkspaceAppHealthcheckKindHttp,
+		WorkspaceAppHealthcheckKindTcp,
+		WorkspaceAppHealthcheckKindGrpc,
+		WorkspaceAppHealthcheckKindCommand,
+		WorkspaceAppHealthcheckKindFile:
+		return true
+	}
+	return false
+}
+
+func AllWorkspaceAppHealthcheckKindValues() []WorkspaceAppHealthcheckKind {
+	return []WorkspaceAppHealthcheckKind{
+		WorkspaceAppHealthcheckKindHttp,
+		WorkspaceAppHealthcheckKindTcp,
+		WorkspaceAppHealthcheckKindGrpc,
+		WorkspaceAppHealthcheckKindCommand,
+		WorkspaceAppHealthcheckKindFile,
+	}
+}
+
 type WorkspaceTransition string
 
 const (
@@ -2343,6 +2559,21 @@ type GroupMemberTable struct {
 	GroupID uuid.UUID `db:"group_id" json:"group_id"`
 }
 
+// Rendered notifications delivered by the inbox method, which users can view in the dashboard or CLI
+type InboxNotification struct {
+	// The ID of the notification message from which this inbox notification was dispatched
+	ID         uuid.UUID `db:"id" json:"id"`
+	UserID     uuid.UUID `db:"user_id" json:"user_id"`
+	TemplateID uuid.UUID `db:"template_id" json:"template_id"`
+	Title      string    `db:"title" json:"title"`
+	Content    string    `db:"content" json:"content"`
+	// Array of {label, url} objects linking to related resources
+	Actions    json.RawMessage `db:"actions" json:"actions"`
+	ReadAt     sql.NullTime    `db:"read_at" json:"read_at"`
+	ArchivedAt sql.NullTime    `db:"archived_at" json:"archived_at"`
+	CreatedAt  time.Time       `db:"created_at" json:"created_at"`
+}
+
 type JfrogXrayScan struct {
 	AgentID     uuid.UUID `db:"agent_id" json:"agent_id"`
 	WorkspaceID uuid.UUID `db:"workspace_id" json:"workspace_id"`
@@ -2379,6 +2610,10 @@ type NotificationMessage struct {
 	QueuedSeconds          sql.NullFloat64           `db:"queued_seconds" json:"queued_seconds"`
 	// Auto-generated by insert/update trigger, used to prevent duplicate notifications from being enqueued on the same day
 	DedupeHash sql.NullString `db:"dedupe_hash" json:"dedupe_hash"`
+	// Messages are not dispatched until this time; set for non-urgent messages enqueued during the user's quiet hours, and for messages awaiting the end of their digest window
+	HeldUntil sql.NullTime `db:"held_until" json:"held_until"`
+	// Set while the message awaits the digest generator, which delivers it together with other messages for the same user and template; such messages are not dispatched individually
+	DigestTemplateID uuid.NullUUID `db:"digest_template_id" json:"digest_template_id"`
 }
 
 type NotificationPreference struct {
@@ -2387,6 +2622,20 @@ type NotificationPreference struct {
 	Disabled               bool      `db:"disabled" json:"disabled"`
 	CreatedAt              time.Time `db:"created_at" json:"created_at"`
 	UpdatedAt              time.Time `db:"updated_at" json:"updated_at"`
+	// Method by which the user wants to receive this notification; NULL defers to the template or deployment default
+	Method NullNotificationMethod `db:"method" json:"method"`
+}
+
+// Daily window during which non-urgent notifications are held back from a user
+type NotificationQuietHour struct {
+	UserID uuid.UUID `db:"user_id" json:"user_id"`
+	// Minutes after midnight in the user's timezone at which quiet hours begin
+	StartMinute int32 `db:"start_minute" json:"start_minute"`
+	// Minutes after midnight in the user's timezone at which quiet hours end; may be less than start_minute if the window spans midnight
+	EndMinute int32     `db:"end_minute" json:"end_minute"`
+	Timezone  string    `db:"timezone" json:"timezone"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
 }
 
 // Log of generated reports for users.
@@ -2406,6 +2655,8 @@ type NotificationTemplate struct {
 	// NULL defers to the deployment-level method
 	Method NullNotificationMethod   `db:"method" json:"method"`
 	Kind   NotificationTemplateKind `db:"kind" json:"kind"`
+	// Template with which messages for the same user enqueued within the same digest window are delivered together; NULL disables digests
+	DigestTemplateID uuid.NullUUID `db:"digest_template_id" json:"digest_template_id"`
 }
 
 // A table used to configure apps that can use Coder as an OAuth2 provider, the reverse of what we are calling external authentication.
@@ -2550,6 +2801,17 @@ type ProvisionerJobLog struct {
 	ID        int64     `db:"id" json:"id"`
 }
 
+// Changes to the resources of a workspace: planned by a dry-run job that previews an update of it, or detected by a drift check job
+type ProvisionerJobResourceChange struct {
+	JobID   uuid.UUID            `db:"job_id" json:"job_id"`
+	Address string               `db:"address" json:"address"`
+	Type    string               `db:"type" json:"type"`
+	Name    string               `db:"name" json:"name"`
+	Action  ResourceChangeAction `db:"action" json:"action"`
+	// The changed top-level attributes of the resource, without the values of sensitive attributes
+	Attributes json.RawMessage `db:"attributes" json:"attributes"`
+}
+
 type ProvisionerJobStat struct {
 	JobID          uuid.UUID            `db:"job_id" json:"job_id"`
 	JobStatus      ProvisionerJobStatus `db:"job_status" json:"job_status"`
@@ -2601,6 +2863,22 @@ type Replica struct {
 	Primary         bool         `db:"primary" json:"primary"`
 }
 
+// Named search queries, which users can share with an organization or group
+type SavedSearch struct {
+	ID           uuid.UUID               `db:"id" json:"id"`
+	UserID       uuid.UUID               `db:"user_id" json:"user_id"`
+	ResourceType SavedSearchResourceType `db:"resource_type" json:"resource_type"`
+	Name         string                  `db:"name" json:"name"`
+	// The search query, in the syntax used to filter resources of resource_type
+	Query string `db:"query" json:"query"`
+	// The organization the search is shared with, or NULL if it is private
+	OrganizationID uuid.NullUUID `db:"organization_id" json:"organization_id"`
+	// The group within organization_id the search is shared with, or NULL if it is shared with all members
+	GroupID   uuid.NullUUID `db:"group_id" json:"group_id"`
+	CreatedAt time.Time     `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time     `db:"updated_at" json:"updated_at"`
+}
+
 type SiteConfig struct {
 	Key   string `db:"key" json:"key"`
 	Value string `db:"value" json:"value"`
@@ -2650,39 +2928,42 @@ type TailnetTunnel struct {
 
 // Joins in the display name information such as username, avatar, and organization name.
 type Template struct {
-	ID                            uuid.UUID       `db:"id" json:"id"`
-	CreatedAt                     time.Time       `db:"created_at" json:"created_at"`
-	UpdatedAt                     time.Time       `db:"updated_at" json:"updated_at"`
-	OrganizationID                uuid.UUID       `db:"organization_id" json:"organization_id"`
-	Deleted                       bool            `db:"deleted" json:"deleted"`
-	Name                          string          `db:"name" json:"name"`
-	Provisioner                   ProvisionerType `db:"provisioner" json:"provisioner"`
-	ActiveVersionID               uuid.UUID       `db:"active_version_id" json:"active_version_id"`
-	Description                   string          `db:"description" json:"description"`
-	DefaultTTL                    int64           `db:"default_ttl" json:"default_ttl"`
-	CreatedBy                     uuid.UUID       `db:"created_by" json:"created_by"`
-	Icon                          string          `db:"icon" json:"icon"`
-	UserACL                       TemplateACL     `db:"user_acl" json:"user_acl"`
-	GroupACL                      TemplateACL     `db:"group_acl" json:"group_acl"`
-	DisplayName                   string          `db:"display_name" json:"display_name"`
-	AllowUserCancelWorkspaceJobs  bool            `db:"allow_user_cancel_workspace_jobs" json:"allow_user_cancel_workspace_jobs"`
-	AllowUserAutostart            bool            `db:"allow_user_autostart" json:"allow_user_autostart"`
-	AllowUserAutostop             bool            `db:"allow_user_autostop" json:"allow_user_autostop"`
-	FailureTTL                    int64           `db:"failure_ttl" json:"failure_ttl"`
-	TimeTilDormant                int64           `db:"time_til_dormant" json:"time_til_dormant"`
-	TimeTilDormantAutoDelete      int64           `db:"time_til_dormant_autodelete" json:"time_til_dormant_autodelete"`
-	AutostopRequirementDaysOfWeek int16           `db:"autostop_requirement_days_of_week" json:"autostop_requirement_days_of_week"`
-	AutostopRequirementWeeks      int64           `db:"autostop_requirement_weeks" json:"autostop_requirement_weeks"`
-	AutostartBlockDaysOfWeek      int16           `db:"autostart_block_days_of_week" json:"autostart_block_days_of_week"`
-	RequireActiveVersion          bool            `db:"require_active_version" json:"require_active_version"`
-	Deprecated                    string          `db:"deprecated" json:"deprecated"`
-	ActivityBump                  int64           `db:"activity_bump" json:"activity_bump"`
-	MaxPortSharingLevel           AppSharingLevel `db:"max_port_sharing_level" json:"max_port_sharing_level"`
-	CreatedByAvatarURL            string          `db:"created_by_avatar_url" json:"created_by_avatar_url"`
-	CreatedByUsername             string          `db:"created_by_username" json:"created_by_username"`
-	OrganizationName              string          `db:"organization_name" json:"organization_name"`
-	OrganizationDisplayName       string          `db:"organization_display_name" json:"organization_display_name"`
-	OrganizationIcon              string          `db:"organization_icon" json:"organization_icon"`
+	ID                            uuid.UUID            `db:"id" json:"id"`
+	CreatedAt                     time.Time            `db:"created_at" json:"created_at"`
+	UpdatedAt                     time.Time            `db:"updated_at" json:"updated_at"`
+	OrganizationID                uuid.UUID            `db:"organization_id" json:"organization_id"`
+	Deleted                       bool                 `db:"deleted" json:"deleted"`
+	Name                          string               `db:"name" json:"name"`
+	Provisioner                   ProvisionerType      `db:"provisioner" json:"provisioner"`
+	ActiveVersionID               uuid.UUID            `db:"active_version_id" json:"active_version_id"`
+	Description                   string               `db:"description" json:"description"`
+	DefaultTTL                    int64                `db:"default_ttl" json:"default_ttl"`
+	CreatedBy                     uuid.UUID            `db:"created_by" json:"created_by"`
+	Icon                          string               `db:"icon" json:"icon"`
+	UserACL                       TemplateACL          `db:"user_acl" json:"user_acl"`
+	GroupACL                      TemplateACL          `db:"group_acl" json:"group_acl"`
+	DisplayName                   string               `db:"display_name" json:"display_name"`
+	AllowUserCancelWorkspaceJobs  bool                 `db:"allow_user_cancel_workspace_jobs" json:"allow_user_cancel_workspace_jobs"`
+	AllowUserAutostart            bool                 `db:"allow_user_autostart" json:"allow_user_autostart"`
+	AllowUserAutostop             bool                 `db:"allow_user_autostop" json:"allow_user_autostop"`
+	FailureTTL                    int64                `db:"failure_ttl" json:"failure_ttl"`
+	TimeTilDormant                int64                `db:"time_til_dormant" json:"time_til_dormant"`
+	TimeTilDormantAutoDelete      int64                `db:"time_til_dormant_autodelete" json:"time_til_dormant_autodelete"`
+	AutostopRequirementDaysOfWeek int16                `db:"autostop_requirement_days_of_week" json:"autostop_requirement_days_of_week"`
+	AutostopRequirementWeeks      int64                `db:"autostop_requirement_weeks" json:"autostop_requirement_weeks"`
+	AutostartBlockDaysOfWeek      int16                `db:"autostart_block_days_of_week" json:"autostart_block_days_of_week"`
+	RequireActiveVersion          bool                 `db:"require_active_version" json:"require_active_version"`
+	Deprecated                    string               `db:"deprecated" json:"deprecated"`
+	ActivityBump                  int64                `db:"activity_bump" json:"activity_bump"`
+	MaxPortSharingLevel           AppSharingLevel      `db:"max_port_sharing_level" json:"max_port_sharing_level"`
+	SessionRecording              bool                 `db:"session_recording" json:"session_recording"`
+	PortForwardingPolicy          PortForwardingPolicy `db:"port_forwarding_policy" json:"port_forwarding_policy"`
+	AgentActivityBump             bool                 `db:"agent_activity_bump" json:"agent_activity_bump"`
+	CreatedByAvatarURL            string               `db:"created_by_avatar_url" json:"created_by_avatar_url"`
+	CreatedByUsername             string               `db:"created_by_username" json:"created_by_username"`
+	OrganizationName              string               `db:"organization_name" json:"organization_name"`
+	OrganizationDisplayName       string               `db:"organization_display_name" json:"organization_display_name"`
+	OrganizationIcon              string               `db:"organization_icon" json:"organization_icon"`
 }
 
 type TemplateTable struct {
@@ -2723,6 +3004,12 @@ type TemplateTable struct {
 	Deprecated          string          `db:"deprecated" json:"deprecated"`
 	ActivityBump        int64           `db:"activity_bump" json:"activity_bump"`
 	MaxPortSharingLevel AppSharingLevel `db:"max_port_sharing_level" json:"max_port_sharing_level"`
+	// Whether interactive SSH sessions into workspaces of this template are recorded
+	SessionRecording bool `db:"session_recording" json:"session_recording"`
+	// Restricts the destinations that SSH port forwards into workspaces of this template may use, enforced by the workspace agent
+	PortForwardingPolicy PortForwardingPolicy `db:"port_forwarding_policy" json:"port_forwarding_policy"`
+	// Whether activity reported by workspace agents without a connection, like a running build, bumps the deadline of workspaces of this template
+	AgentActivityBump bool `db:"agent_activity_bump" json:"agent_activity_bump"`
 }
 
 // Records aggregated usage statistics for templates/users. All usage is rounded up to the nearest minute.
@@ -2957,6 +3244,21 @@ type WorkspaceAgent struct {
 	DisplayOrder int32 `db:"display_order" json:"display_order"`
 }
 
+// The containers running in the workspace of an agent, as last reported by the agent.
+type WorkspaceAgentContainer struct {
+	WorkspaceAgentID uuid.UUID       `db:"workspace_agent_id" json:"workspace_agent_id"`
+	ContainerID      string          `db:"container_id" json:"container_id"`
+	Name             string          `db:"name" json:"name"`
+	Image            string          `db:"image" json:"image"`
+	Status           string          `db:"status" json:"status"`
+	Runtime          string          `db:"runtime" json:"runtime"`
+	Labels           json.RawMessage `db:"labels" json:"labels"`
+	Ports            json.RawMessage `db:"ports" json:"ports"`
+	// When the container was created, as reported by the container runtime.
+	CreatedAt  time.Time `db:"created_at" json:"created_at"`
+	ReportedAt time.Time `db:"reported_at" json:"reported_at"`
+}
+
 type WorkspaceAgentLog struct {
 	AgentID     uuid.UUID `db:"agent_id" json:"agent_id"`
 	CreatedAt   time.Time `db:"created_at" json:"created_at"`
@@ -2988,6 +3290,15 @@ type WorkspaceAgentMetadatum struct {
 	DisplayOrder int32 `db:"display_order" json:"display_order"`
 }
 
+// Historical samples of workspace agent metadata values. Rows are purged by dbpurge according to the agent metadata history retention.
+type WorkspaceAgentMetadataHistory struct {
+	WorkspaceAgentID uuid.UUID `db:"workspace_agent_id" json:"workspace_agent_id"`
+	Key              string    `db:"key" json:"key"`
+	Value            string    `db:"value" json:"value"`
+	Error            string    `db:"error" json:"error"`
+	CollectedAt      time.Time `db:"collected_at" json:"collected_at"`
+}
+
 type WorkspaceAgentPortShare struct {
 	WorkspaceID uuid.UUID         `db:"workspace_id" json:"workspace_id"`
 	AgentName   string            `db:"agent_name" json:"agent_name"`
@@ -3020,6 +3331,29 @@ type WorkspaceAgentScriptTiming struct {
 	Status    WorkspaceAgentScriptTimingStatus `db:"status" json:"status"`
 }
 
+// Recordings of interactive PTY sessions made by workspace agents, in asciicast v2 format
+type WorkspaceAgentSessionRecording struct {
+	// The ID the agent assigned to the SSH session, which also appears in the agent logs
+	ID          uuid.UUID `db:"id" json:"id"`
+	WorkspaceID uuid.UUID `db:"workspace_id" json:"workspace_id"`
+	AgentID     uuid.UUID `db:"agent_id" json:"agent_id"`
+	// The type of SSH session, e.g. ssh, vscode or jetbrains
+	SessionType string `db:"session_type" json:"session_type"`
+	// The command run in the session, empty for a login shell
+	Command   string       `db:"command" json:"command"`
+	StartedAt time.Time    `db:"started_at" json:"started_at"`
+	EndedAt   sql.NullTime `db:"ended_at" json:"ended_at"`
+	// The total size in bytes of the recording chunks
+	Size int64 `db:"size" json:"size"`
+}
+
+// Chunks of a session recording, which concatenated in sequence order form the asciicast file
+type WorkspaceAgentSessionRecordingChunk struct {
+	RecordingID uuid.UUID `db:"recording_id" json:"recording_id"`
+	Sequence    int32     `db:"sequence" json:"sequence"`
+	Data        []byte    `db:"data" json:"data"`
+}
+
 type WorkspaceAgentStat struct {
 	ID                          uuid.UUID       `db:"id" json:"id"`
 	CreatedAt                   time.Time       `db:"created_at" json:"created_at"`
@@ -3061,6 +3395,17 @@ type WorkspaceApp struct {
 	DisplayOrder int32 `db:"display_order" json:"display_order"`
 	// Determines if the app is not shown in user interfaces.
 	Hidden bool `db:"hidden" json:"hidden"`
+	// Specifies how the health of the app is checked. Each kind uses its own subset of the healthcheck columns.
+	HealthcheckKind WorkspaceAppHealthcheckKind `db:"healthcheck_kind" json:"healthcheck_kind"`
+	// The status code an http health check must return. When 0, any status code below 500 is healthy.
+	HealthcheckExpectedStatus int32 `db:"healthcheck_expected_status" json:"healthcheck_expected_status"`
+	// A regular expression the body of an http health check response must match.
+	HealthcheckBodyRegex string `db:"healthcheck_body_regex" json:"healthcheck_body_regex"`
+	// The host and port a tcp or grpc health check connects to.
+	HealthcheckAddress     string `db:"healthcheck_address" json:"healthcheck_address"`
+	HealthcheckGrpcService string `db:"healthcheck_grpc_service" json:"healthcheck_grpc_service"`
+	HealthcheckCommand     string `db:"healthcheck_command" json:"healthcheck_command"`
+	HealthcheckPath        string `db:"healthcheck_path" json:"healthcheck_path"`
 }
 
 // A record of workspace app usage statistics
@@ -3103,6 +3448,7 @@ type WorkspaceBuild struct {
 	Reason               BuildReason         `db:"reason" json:"reason"`
 	DailyCost            int32               `db:"daily_cost" json:"daily_cost"`
 	MaxDeadline          time.Time           `db:"max_deadline" json:"max_deadline"`
+	ProvisionerStateRef  string              `db:"provisioner_state_ref" json:"provisioner_state_ref"`
 	InitiatorByAvatarUrl string              `db:"initiator_by_avatar_url" json:"initiator_by_avatar_url"`
 	InitiatorByUsername  string              `db:"initiator_by_username" json:"initiator_by_username"`
 }
@@ -3130,6 +3476,19 @@ type WorkspaceBuildTable struct {
 	Reason            BuildReason         `db:"reason" json:"reason"`
 	DailyCost         int32               `db:"daily_cost" json:"daily_cost"`
 	MaxDeadline       time.Time           `db:"max_deadline" json:"max_deadline"`
+	// A reference to the provisioner state in the configured state store. If set, provisioner_state is empty.
+	ProvisionerStateRef string `db:"provisioner_state_ref" json:"provisioner_state_ref"`
+}
+
+// Checks of the infrastructure of a workspace for drift from the state of a build, run by a provisioner job
+type WorkspaceDriftCheck struct {
+	ID          uuid.UUID `db:"id" json:"id"`
+	WorkspaceID uuid.UUID `db:"workspace_id" json:"workspace_id"`
+	// The build whose state was checked
+	WorkspaceBuildID uuid.UUID `db:"workspace_build_id" json:"workspace_build_id"`
+	// The provisioner job that ran the check, whose resource changes are the drifted resources
+	JobID     uuid.UUID `db:"job_id" json:"job_id"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
 }
 
 type WorkspaceProxy struct {
@@ -3175,3 +3534,18 @@ type WorkspaceResourceMetadatum struct {
 	Sensitive           bool           `db:"sensitive" json:"sensitive"`
 	ID                  int64          `db:"id" json:"id"`
 }
+
+// Named snapshots of a workspace, taken by the provisioner of the template
+type WorkspaceSnapshot struct {
+	ID          uuid.UUID `db:"id" json:"id"`
+	WorkspaceID uuid.UUID `db:"workspace_id" json:"workspace_id"`
+	// The build whose state the snapshot was taken from
+	WorkspaceBuildID uuid.UUID `db:"workspace_build_id" json:"workspace_build_id"`
+	// The provisioner job that created the snapshot
+	JobID uuid.UUID `db:"job_id" json:"job_id"`
+	Name  string    `db:"name" json:"name"`
+	// Opaque data returned by the provisioner, which is handed back to it on restore
+	Data      []byte    `db:"data" json:"data"`
+	CreatedBy uuid.UUID `db:"created_by" json:"created_by"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
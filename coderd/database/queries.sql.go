@@ -441,6 +441,89 @@ func (q *sqlQuerier) UpdateAPIKeyByID(ctx context.Context, arg UpdateAPIKeyByIDP
 	return err
 }
 
+const deleteAuditLogsByIDs = `-- name: DeleteAuditLogsByIDs :exec
+DELETE FROM audit_logs WHERE id = ANY($1 :: uuid[])
+`
+
+// Used by dbpurge to delete audit logs once they have passed the retention
+// window and been archived.
+func (q *sqlQuerier) DeleteAuditLogsByIDs(ctx context.Context, ids []uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteAuditLogsByIDs, pq.Array(ids))
+	return err
+}
+
+const getAuditLogsBefore = `-- name: GetAuditLogsBefore :many
+SELECT
+    audit_logs.id, audit_logs.time, audit_logs.user_id, audit_logs.organization_id, audit_logs.ip, audit_logs.user_agent, audit_logs.resource_type, audit_logs.resource_id, audit_logs.resource_target, audit_logs.action, audit_logs.diff, audit_logs.status_code, audit_logs.additional_fields, audit_logs.request_id, audit_logs.resource_icon,
+    users.username AS user_username,
+    users.email AS user_email
+FROM
+    audit_logs
+    LEFT JOIN users ON audit_logs.user_id = users.id
+WHERE
+    audit_logs."time" < $1
+ORDER BY
+    audit_logs."time" ASC,
+    audit_logs.id ASC
+LIMIT
+    $2
+`
+
+type GetAuditLogsBeforeParams struct {
+	Before   time.Time `db:"before" json:"before"`
+	LimitOpt int32     `db:"limit_opt" json:"limit_opt"`
+}
+
+type GetAuditLogsBeforeRow struct {
+	AuditLog     AuditLog       `db:"audit_log" json:"audit_log"`
+	UserUsername sql.NullString `db:"user_username" json:"user_username"`
+	UserEmail    sql.NullString `db:"user_email" json:"user_email"`
+}
+
+// Used by dbpurge to archive audit logs which have passed the retention
+// window. They are returned oldest first, so that retrying a purge whose
+// transaction was rolled back archives the same batches again.
+func (q *sqlQuerier) GetAuditLogsBefore(ctx context.Context, arg GetAuditLogsBeforeParams) ([]GetAuditLogsBeforeRow, error) {
+	rows, err := q.db.QueryContext(ctx, getAuditLogsBefore, arg.Before, arg.LimitOpt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetAuditLogsBeforeRow
+	for rows.Next() {
+		var i GetAuditLogsBeforeRow
+		if err := rows.Scan(
+			&i.AuditLog.ID,
+			&i.AuditLog.Time,
+			&i.AuditLog.UserID,
+			&i.AuditLog.OrganizationID,
+			&i.AuditLog.Ip,
+			&i.AuditLog.UserAgent,
+			&i.AuditLog.ResourceType,
+			&i.AuditLog.ResourceID,
+			&i.AuditLog.ResourceTarget,
+			&i.AuditLog.Action,
+			&i.AuditLog.Diff,
+			&i.AuditLog.StatusCode,
+			&i.AuditLog.AdditionalFields,
+			&i.AuditLog.RequestID,
+			&i.AuditLog.ResourceIcon,
+			&i.UserUsername,
+			&i.UserEmail,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getAuditLogsOffset = `-- name: GetAuditLogsOffset :many
 SELECT
     audit_logs.id, audit_logs.time, audit_logs.user_id, audit_logs.organization_id, audit_logs.ip, audit_logs.user_agent, audit_logs.resource_type, audit_logs.resource_id, audit_logs.resource_target, audit_logs.action, audit_logs.diff, audit_logs.status_code, audit_logs.additional_fields, audit_logs.request_id, audit_logs.resource_icon,
@@ -3531,7 +3614,7 @@ const acquireNotificationMessages = `-- name: AcquireNotificationMessages :many
 WITH acquired AS (
     UPDATE
         notification_messages
-            SET queued_seconds = GREATEST(0, EXTRACT(EPOCH FROM (NOW() - updated_at)))::FLOAT,
+            SET queued_seconds = GREATEST(0, EXTRACT(EPOCH FROM (NOW() - GREATEST(updated_at, held_until))))::FLOAT,
                 updated_at = NOW(),
                 status = 'leased'::notification_message_status,
                 status_reason = 'Leased by notifier ' || $1::uuid,
@@ -3563,12 +3646,16 @@ WITH acquired AS (
                                  ELSE true
                                  END
                              )
+                           -- messages held back by the user's quiet hours or a digest window are not released until the window ends
+                           AND (nm.held_until IS NULL OR nm.held_until < NOW())
+                           -- messages awaiting a digest are delivered by the digest generator
+                           AND nm.digest_template_id IS NULL
                          ORDER BY nm.created_at ASC
                                   -- Ensure that multiple concurrent readers cannot retrieve the same rows
                              FOR UPDATE OF nm
                                  SKIP LOCKED
                          LIMIT $4)
-            RETURNING id, notification_template_id, user_id, method, status, status_reason, created_by, payload, attempt_count, targets, created_at, updated_at, leased_until, next_retry_after, queued_seconds, dedupe_hash)
+            RETURNING id, notification_template_id, user_id, method, status, status_reason, created_by, payload, attempt_count, targets, created_at, updated_at, leased_until, next_retry_after, queued_seconds, dedupe_hash, held_until, digest_template_id)
 SELECT
     -- message
     nm.id,
@@ -3667,11 +3754,12 @@ SET queued_seconds   = 0,
     leased_until     = NULL,
     next_retry_after = CASE
                            WHEN (attempt_count + 1 < $1::int)
-                               THEN NOW() + CONCAT($2::int, ' seconds')::interval END
+                               THEN NOW() + CONCAT(GREATEST($2::int, subquery.retry_after), ' seconds')::interval END
 FROM (SELECT UNNEST($3::uuid[])                             AS id,
              UNNEST($4::timestamptz[])               AS failed_at,
              UNNEST($5::notification_message_status[]) AS status,
-             UNNEST($6::text[])                  AS status_reason) AS subquery
+             UNNEST($6::text[])                  AS status_reason,
+             UNNEST($7::int[])                     AS retry_after) AS subquery
 WHERE notification_messages.id = subquery.id
 `
 
@@ -3682,6 +3770,7 @@ type BulkMarkNotificationMessagesFailedParams struct {
 	FailedAts     []time.Time                 `db:"failed_ats" json:"failed_ats"`
 	Statuses      []NotificationMessageStatus `db:"statuses" json:"statuses"`
 	StatusReasons []string                    `db:"status_reasons" json:"status_reasons"`
+	RetryAfters   []int32                     `db:"retry_afters" json:"retry_afters"`
 }
 
 func (q *sqlQuerier) BulkMarkNotificationMessagesFailed(ctx context.Context, arg BulkMarkNotificationMessagesFailedParams) (int64, error) {
@@ -3692,6 +3781,7 @@ func (q *sqlQuerier) BulkMarkNotificationMessagesFailed(ctx context.Context, arg
 		pq.Array(arg.FailedAts),
 		pq.Array(arg.Statuses),
 		pq.Array(arg.StatusReasons),
+		pq.Array(arg.RetryAfters),
 	)
 	if err != nil {
 		return 0, err
@@ -3742,8 +3832,19 @@ func (q *sqlQuerier) DeleteOldNotificationMessages(ctx context.Context) error {
 	return err
 }
 
+const deleteUserNotificationQuietHours = `-- name: DeleteUserNotificationQuietHours :exec
+DELETE
+FROM notification_quiet_hours
+WHERE user_id = $1::uuid
+`
+
+func (q *sqlQuerier) DeleteUserNotificationQuietHours(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteUserNotificationQuietHours, userID)
+	return err
+}
+
 const enqueueNotificationMessage = `-- name: EnqueueNotificationMessage :exec
-INSERT INTO notification_messages (id, notification_template_id, user_id, method, payload, targets, created_by, created_at)
+INSERT INTO notification_messages (id, notification_template_id, user_id, method, payload, targets, created_by, created_at, held_until, digest_template_id)
 VALUES ($1,
         $2,
         $3,
@@ -3751,7 +3852,9 @@ VALUES ($1,
         $5::jsonb,
         $6,
         $7,
-        $8)
+        $8,
+        $9::timestamptz,
+        $10::uuid)
 `
 
 type EnqueueNotificationMessageParams struct {
@@ -3763,6 +3866,8 @@ type EnqueueNotificationMessageParams struct {
 	Targets                []uuid.UUID        `db:"targets" json:"targets"`
 	CreatedBy              string             `db:"created_by" json:"created_by"`
 	CreatedAt              time.Time          `db:"created_at" json:"created_at"`
+	HeldUntil              sql.NullTime       `db:"held_until" json:"held_until"`
+	DigestTemplateID       uuid.NullUUID      `db:"digest_template_id" json:"digest_template_id"`
 }
 
 func (q *sqlQuerier) EnqueueNotificationMessage(ctx context.Context, arg EnqueueNotificationMessageParams) error {
@@ -3775,6 +3880,8 @@ func (q *sqlQuerier) EnqueueNotificationMessage(ctx context.Context, arg Enqueue
 		pq.Array(arg.Targets),
 		arg.CreatedBy,
 		arg.CreatedAt,
+		arg.HeldUntil,
+		arg.DigestTemplateID,
 	)
 	return err
 }
@@ -3784,12 +3891,16 @@ SELECT nt.name                                                    AS notificatio
        nt.id                                                      AS notification_template_id,
        nt.actions                                                 AS actions,
        nt.method                                                  AS custom_method,
+       np.method                                                  AS user_method,
        u.id                                                       AS user_id,
        u.email                                                    AS user_email,
        COALESCE(NULLIF(u.name, ''), NULLIF(u.username, ''))::text AS user_name,
-       u.username                                                 AS user_username
-FROM notification_templates nt,
-     users u
+       u.username                                                 AS user_username,
+       nt.digest_template_id                                      AS digest_template_id
+FROM notification_templates nt
+         CROSS JOIN users u
+         LEFT JOIN notification_preferences AS np
+                   ON (np.user_id = u.id AND np.notification_template_id = nt.id)
 WHERE nt.id = $1
   AND u.id = $2
 `
@@ -3804,10 +3915,12 @@ type FetchNewMessageMetadataRow struct {
 	NotificationTemplateID uuid.UUID              `db:"notification_template_id" json:"notification_template_id"`
 	Actions                []byte                 `db:"actions" json:"actions"`
 	CustomMethod           NullNotificationMethod `db:"custom_method" json:"custom_method"`
+	UserMethod             NullNotificationMethod `db:"user_method" json:"user_method"`
 	UserID                 uuid.UUID              `db:"user_id" json:"user_id"`
 	UserEmail              string                 `db:"user_email" json:"user_email"`
 	UserName               string                 `db:"user_name" json:"user_name"`
 	UserUsername           string                 `db:"user_username" json:"user_username"`
+	DigestTemplateID       uuid.NullUUID          `db:"digest_template_id" json:"digest_template_id"`
 }
 
 // This is used to build up the notification_message's JSON payload.
@@ -3819,16 +3932,71 @@ func (q *sqlQuerier) FetchNewMessageMetadata(ctx context.Context, arg FetchNewMe
 		&i.NotificationTemplateID,
 		&i.Actions,
 		&i.CustomMethod,
+		&i.UserMethod,
 		&i.UserID,
 		&i.UserEmail,
 		&i.UserName,
 		&i.UserUsername,
+		&i.DigestTemplateID,
 	)
 	return i, err
 }
 
+const getNotificationMessagesAwaitingDigest = `-- name: GetNotificationMessagesAwaitingDigest :many
+SELECT id, notification_template_id, user_id, method, status, status_reason, created_by, payload, attempt_count, targets, created_at, updated_at, leased_until, next_retry_after, queued_seconds, dedupe_hash, held_until, digest_template_id
+FROM notification_messages
+WHERE digest_template_id IS NOT NULL
+  AND status = 'pending'::notification_message_status
+  AND (held_until IS NULL OR held_until < NOW())
+ORDER BY user_id, notification_template_id, created_at
+`
+
+// Returns the messages awaiting a digest whose digest window has ended, ordered so that the messages which are delivered
+// together are adjacent.
+func (q *sqlQuerier) GetNotificationMessagesAwaitingDigest(ctx context.Context) ([]NotificationMessage, error) {
+	rows, err := q.db.QueryContext(ctx, getNotificationMessagesAwaitingDigest)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []NotificationMessage
+	for rows.Next() {
+		var i NotificationMessage
+		if err := rows.Scan(
+			&i.ID,
+			&i.NotificationTemplateID,
+			&i.UserID,
+			&i.Method,
+			&i.Status,
+			&i.StatusReason,
+			&i.CreatedBy,
+			&i.Payload,
+			&i.AttemptCount,
+			pq.Array(&i.Targets),
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.LeasedUntil,
+			&i.NextRetryAfter,
+			&i.QueuedSeconds,
+			&i.DedupeHash,
+			&i.HeldUntil,
+			&i.DigestTemplateID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getNotificationMessagesByStatus = `-- name: GetNotificationMessagesByStatus :many
-SELECT id, notification_template_id, user_id, method, status, status_reason, created_by, payload, attempt_count, targets, created_at, updated_at, leased_until, next_retry_after, queued_seconds, dedupe_hash
+SELECT id, notification_template_id, user_id, method, status, status_reason, created_by, payload, attempt_count, targets, created_at, updated_at, leased_until, next_retry_after, queued_seconds, dedupe_hash, held_until, digest_template_id
 FROM notification_messages
 WHERE status = $1
 LIMIT $2::int
@@ -3865,6 +4033,8 @@ func (q *sqlQuerier) GetNotificationMessagesByStatus(ctx context.Context, arg Ge
 			&i.NextRetryAfter,
 			&i.QueuedSeconds,
 			&i.DedupeHash,
+			&i.HeldUntil,
+			&i.DigestTemplateID,
 		); err != nil {
 			return nil, err
 		}
@@ -3897,7 +4067,7 @@ func (q *sqlQuerier) GetNotificationReportGeneratorLogByTemplate(ctx context.Con
 }
 
 const getNotificationTemplateByID = `-- name: GetNotificationTemplateByID :one
-SELECT id, name, title_template, body_template, actions, "group", method, kind
+SELECT id, name, title_template, body_template, actions, "group", method, kind, digest_template_id
 FROM notification_templates
 WHERE id = $1::uuid
 `
@@ -3914,12 +4084,13 @@ func (q *sqlQuerier) GetNotificationTemplateByID(ctx context.Context, id uuid.UU
 		&i.Group,
 		&i.Method,
 		&i.Kind,
+		&i.DigestTemplateID,
 	)
 	return i, err
 }
 
 const getNotificationTemplatesByKind = `-- name: GetNotificationTemplatesByKind :many
-SELECT id, name, title_template, body_template, actions, "group", method, kind
+SELECT id, name, title_template, body_template, actions, "group", method, kind, digest_template_id
 FROM notification_templates
 WHERE kind = $1::notification_template_kind
 ORDER BY name ASC
@@ -3943,6 +4114,7 @@ func (q *sqlQuerier) GetNotificationTemplatesByKind(ctx context.Context, kind No
 			&i.Group,
 			&i.Method,
 			&i.Kind,
+			&i.DigestTemplateID,
 		); err != nil {
 			return nil, err
 		}
@@ -3958,7 +4130,7 @@ func (q *sqlQuerier) GetNotificationTemplatesByKind(ctx context.Context, kind No
 }
 
 const getUserNotificationPreferences = `-- name: GetUserNotificationPreferences :many
-SELECT user_id, notification_template_id, disabled, created_at, updated_at
+SELECT user_id, notification_template_id, disabled, created_at, updated_at, method
 FROM notification_preferences
 WHERE user_id = $1::uuid
 `
@@ -3978,6 +4150,7 @@ func (q *sqlQuerier) GetUserNotificationPreferences(ctx context.Context, userID
 			&i.Disabled,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Method,
 		); err != nil {
 			return nil, err
 		}
@@ -3992,11 +4165,64 @@ func (q *sqlQuerier) GetUserNotificationPreferences(ctx context.Context, userID
 	return items, nil
 }
 
+const getUserNotificationQuietHours = `-- name: GetUserNotificationQuietHours :one
+SELECT user_id, start_minute, end_minute, timezone, created_at, updated_at
+FROM notification_quiet_hours
+WHERE user_id = $1::uuid
+`
+
+func (q *sqlQuerier) GetUserNotificationQuietHours(ctx context.Context, userID uuid.UUID) (NotificationQuietHour, error) {
+	row := q.db.QueryRowContext(ctx, getUserNotificationQuietHours, userID)
+	var i NotificationQuietHour
+	err := row.Scan(
+		&i.UserID,
+		&i.StartMinute,
+		&i.EndMinute,
+		&i.Timezone,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const markNotificationMessagesDigested = `-- name: MarkNotificationMessagesDigested :exec
+UPDATE notification_messages
+SET digest_template_id = NULL,
+    status             = 'inhibited'::notification_message_status,
+    status_reason      = 'Delivered in digest ' || $1::uuid,
+    updated_at         = NOW()
+WHERE id = ANY ($2::uuid[])
+`
+
+type MarkNotificationMessagesDigestedParams struct {
+	DigestMessageID uuid.UUID   `db:"digest_message_id" json:"digest_message_id"`
+	IDs             []uuid.UUID `db:"ids" json:"ids"`
+}
+
+// Marks messages awaiting a digest as delivered in the given digest message.
+func (q *sqlQuerier) MarkNotificationMessagesDigested(ctx context.Context, arg MarkNotificationMessagesDigestedParams) error {
+	_, err := q.db.ExecContext(ctx, markNotificationMessagesDigested, arg.DigestMessageID, pq.Array(arg.IDs))
+	return err
+}
+
+const releaseNotificationMessagesFromDigest = `-- name: ReleaseNotificationMessagesFromDigest :exec
+UPDATE notification_messages
+SET digest_template_id = NULL,
+    updated_at         = NOW()
+WHERE id = ANY ($1::uuid[])
+`
+
+// Releases messages awaiting a digest to be dispatched individually, e.g. when no other message joined them.
+func (q *sqlQuerier) ReleaseNotificationMessagesFromDigest(ctx context.Context, ids []uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, releaseNotificationMessagesFromDigest, pq.Array(ids))
+	return err
+}
+
 const updateNotificationTemplateMethodByID = `-- name: UpdateNotificationTemplateMethodByID :one
 UPDATE notification_templates
 SET method = $1::notification_method
 WHERE id = $2::uuid
-RETURNING id, name, title_template, body_template, actions, "group", method, kind
+RETURNING id, name, title_template, body_template, actions, "group", method, kind, digest_template_id
 `
 
 type UpdateNotificationTemplateMethodByIDParams struct {
@@ -4016,18 +4242,24 @@ func (q *sqlQuerier) UpdateNotificationTemplateMethodByID(ctx context.Context, a
 		&i.Group,
 		&i.Method,
 		&i.Kind,
+		&i.DigestTemplateID,
 	)
 	return i, err
 }
 
 const updateUserNotificationPreferences = `-- name: UpdateUserNotificationPreferences :execrows
 INSERT
-INTO notification_preferences (user_id, notification_template_id, disabled)
-SELECT $1::uuid, new_values.notification_template_id, new_values.disabled
+INTO notification_preferences (user_id, notification_template_id, disabled, method)
+SELECT $1::uuid,
+       new_values.notification_template_id,
+       new_values.disabled,
+       NULLIF(new_values.method, '')::notification_method
 FROM (SELECT UNNEST($2::uuid[]) AS notification_template_id,
-             UNNEST($3::bool[])                 AS disabled) AS new_values
+             UNNEST($3::bool[])                 AS disabled,
+             UNNEST($4::text[])                   AS method) AS new_values
 ON CONFLICT (user_id, notification_template_id) DO UPDATE
     SET disabled   = EXCLUDED.disabled,
+        method     = EXCLUDED.method,
         updated_at = CURRENT_TIMESTAMP
 `
 
@@ -4035,10 +4267,17 @@ type UpdateUserNotificationPreferencesParams struct {
 	UserID                  uuid.UUID   `db:"user_id" json:"user_id"`
 	NotificationTemplateIds []uuid.UUID `db:"notification_template_ids" json:"notification_template_ids"`
 	Disableds               []bool      `db:"disableds" json:"disableds"`
+	Methods                 []string    `db:"methods" json:"methods"`
 }
 
+// An empty method defers to the template or deployment default.
 func (q *sqlQuerier) UpdateUserNotificationPreferences(ctx context.Context, arg UpdateUserNotificationPreferencesParams) (int64, error) {
-	result, err := q.db.ExecContext(ctx, updateUserNotificationPreferences, arg.UserID, pq.Array(arg.NotificationTemplateIds), pq.Array(arg.Disableds))
+	result, err := q.db.ExecContext(ctx, updateUserNotificationPreferences,
+		arg.UserID,
+		pq.Array(arg.NotificationTemplateIds),
+		pq.Array(arg.Disableds),
+		pq.Array(arg.Methods),
+	)
 	if err != nil {
 		return 0, err
 	}
@@ -4062,140 +4301,383 @@ func (q *sqlQuerier) UpsertNotificationReportGeneratorLog(ctx context.Context, a
 	return err
 }
 
-const deleteOAuth2ProviderAppByID = `-- name: DeleteOAuth2ProviderAppByID :exec
-DELETE FROM oauth2_provider_apps WHERE id = $1
-`
-
-func (q *sqlQuerier) DeleteOAuth2ProviderAppByID(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.ExecContext(ctx, deleteOAuth2ProviderAppByID, id)
-	return err
-}
-
-const deleteOAuth2ProviderAppCodeByID = `-- name: DeleteOAuth2ProviderAppCodeByID :exec
-DELETE FROM oauth2_provider_app_codes WHERE id = $1
-`
-
-func (q *sqlQuerier) DeleteOAuth2ProviderAppCodeByID(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.ExecContext(ctx, deleteOAuth2ProviderAppCodeByID, id)
-	return err
-}
-
-const deleteOAuth2ProviderAppCodesByAppAndUserID = `-- name: DeleteOAuth2ProviderAppCodesByAppAndUserID :exec
-DELETE FROM oauth2_provider_app_codes WHERE app_id = $1 AND user_id = $2
-`
-
-type DeleteOAuth2ProviderAppCodesByAppAndUserIDParams struct {
-	AppID  uuid.UUID `db:"app_id" json:"app_id"`
-	UserID uuid.UUID `db:"user_id" json:"user_id"`
-}
-
-func (q *sqlQuerier) DeleteOAuth2ProviderAppCodesByAppAndUserID(ctx context.Context, arg DeleteOAuth2ProviderAppCodesByAppAndUserIDParams) error {
-	_, err := q.db.ExecContext(ctx, deleteOAuth2ProviderAppCodesByAppAndUserID, arg.AppID, arg.UserID)
-	return err
-}
-
-const deleteOAuth2ProviderAppSecretByID = `-- name: DeleteOAuth2ProviderAppSecretByID :exec
-DELETE FROM oauth2_provider_app_secrets WHERE id = $1
-`
-
-func (q *sqlQuerier) DeleteOAuth2ProviderAppSecretByID(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.ExecContext(ctx, deleteOAuth2ProviderAppSecretByID, id)
-	return err
-}
-
-const deleteOAuth2ProviderAppTokensByAppAndUserID = `-- name: DeleteOAuth2ProviderAppTokensByAppAndUserID :exec
-DELETE FROM
-  oauth2_provider_app_tokens
-USING
-  oauth2_provider_app_secrets, api_keys
-WHERE
-  oauth2_provider_app_secrets.id = oauth2_provider_app_tokens.app_secret_id
-  AND api_keys.id = oauth2_provider_app_tokens.api_key_id
-  AND oauth2_provider_app_secrets.app_id = $1
-	AND api_keys.user_id = $2
+const upsertUserNotificationQuietHours = `-- name: UpsertUserNotificationQuietHours :one
+INSERT
+INTO notification_quiet_hours (user_id, start_minute, end_minute, timezone)
+VALUES ($1::uuid, $2::int, $3::int, $4::text)
+ON CONFLICT (user_id) DO UPDATE
+    SET start_minute = EXCLUDED.start_minute,
+        end_minute   = EXCLUDED.end_minute,
+        timezone     = EXCLUDED.timezone,
+        updated_at   = CURRENT_TIMESTAMP
+RETURNING user_id, start_minute, end_minute, timezone, created_at, updated_at
 `
 
-type DeleteOAuth2ProviderAppTokensByAppAndUserIDParams struct {
-	AppID  uuid.UUID `db:"app_id" json:"app_id"`
-	UserID uuid.UUID `db:"user_id" json:"user_id"`
-}
-
-func (q *sqlQuerier) DeleteOAuth2ProviderAppTokensByAppAndUserID(ctx context.Context, arg DeleteOAuth2ProviderAppTokensByAppAndUserIDParams) error {
-	_, err := q.db.ExecContext(ctx, deleteOAuth2ProviderAppTokensByAppAndUserID, arg.AppID, arg.UserID)
-	return err
+type UpsertUserNotificationQuietHoursParams struct {
+	UserID      uuid.UUID `db:"user_id" json:"user_id"`
+	StartMinute int32     `db:"start_minute" json:"start_minute"`
+	EndMinute   int32     `db:"end_minute" json:"end_minute"`
+	Timezone    string    `db:"timezone" json:"timezone"`
 }
 
-const getOAuth2ProviderAppByID = `-- name: GetOAuth2ProviderAppByID :one
-SELECT id, created_at, updated_at, name, icon, callback_url FROM oauth2_provider_apps WHERE id = $1
-`
-
-func (q *sqlQuerier) GetOAuth2ProviderAppByID(ctx context.Context, id uuid.UUID) (OAuth2ProviderApp, error) {
-	row := q.db.QueryRowContext(ctx, getOAuth2ProviderAppByID, id)
-	var i OAuth2ProviderApp
+func (q *sqlQuerier) UpsertUserNotificationQuietHours(ctx context.Context, arg UpsertUserNotificationQuietHoursParams) (NotificationQuietHour, error) {
+	row := q.db.QueryRowContext(ctx, upsertUserNotificationQuietHours,
+		arg.UserID,
+		arg.StartMinute,
+		arg.EndMinute,
+		arg.Timezone,
+	)
+	var i NotificationQuietHour
 	err := row.Scan(
-		&i.ID,
+		&i.UserID,
+		&i.StartMinute,
+		&i.EndMinute,
+		&i.Timezone,
 		&i.CreatedAt,
 		&i.UpdatedAt,
-		&i.Name,
-		&i.Icon,
-		&i.CallbackURL,
 	)
 	return i, err
 }
 
-const getOAuth2ProviderAppCodeByID = `-- name: GetOAuth2ProviderAppCodeByID :one
-SELECT id, created_at, expires_at, secret_prefix, hashed_secret, user_id, app_id FROM oauth2_provider_app_codes WHERE id = $1
+const countUnreadInboxNotificationsByUserID = `-- name: CountUnreadInboxNotificationsByUserID :one
+SELECT COUNT(*)
+FROM inbox_notifications
+WHERE user_id = $1
+	AND read_at IS NULL
+	AND archived_at IS NULL
 `
 
-func (q *sqlQuerier) GetOAuth2ProviderAppCodeByID(ctx context.Context, id uuid.UUID) (OAuth2ProviderAppCode, error) {
-	row := q.db.QueryRowContext(ctx, getOAuth2ProviderAppCodeByID, id)
-	var i OAuth2ProviderAppCode
-	err := row.Scan(
-		&i.ID,
-		&i.CreatedAt,
-		&i.ExpiresAt,
-		&i.SecretPrefix,
-		&i.HashedSecret,
-		&i.UserID,
-		&i.AppID,
-	)
-	return i, err
+func (q *sqlQuerier) CountUnreadInboxNotificationsByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countUnreadInboxNotificationsByUserID, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
 }
 
-const getOAuth2ProviderAppCodeByPrefix = `-- name: GetOAuth2ProviderAppCodeByPrefix :one
-SELECT id, created_at, expires_at, secret_prefix, hashed_secret, user_id, app_id FROM oauth2_provider_app_codes WHERE secret_prefix = $1
+const getInboxNotificationByID = `-- name: GetInboxNotificationByID :one
+SELECT id, user_id, template_id, title, content, actions, read_at, archived_at, created_at
+FROM inbox_notifications
+WHERE id = $1
 `
 
-func (q *sqlQuerier) GetOAuth2ProviderAppCodeByPrefix(ctx context.Context, secretPrefix []byte) (OAuth2ProviderAppCode, error) {
-	row := q.db.QueryRowContext(ctx, getOAuth2ProviderAppCodeByPrefix, secretPrefix)
-	var i OAuth2ProviderAppCode
+func (q *sqlQuerier) GetInboxNotificationByID(ctx context.Context, id uuid.UUID) (InboxNotification, error) {
+	row := q.db.QueryRowContext(ctx, getInboxNotificationByID, id)
+	var i InboxNotification
 	err := row.Scan(
 		&i.ID,
-		&i.CreatedAt,
-		&i.ExpiresAt,
-		&i.SecretPrefix,
-		&i.HashedSecret,
 		&i.UserID,
-		&i.AppID,
+		&i.TemplateID,
+		&i.Title,
+		&i.Content,
+		&i.Actions,
+		&i.ReadAt,
+		&i.ArchivedAt,
+		&i.CreatedAt,
 	)
 	return i, err
 }
 
-const getOAuth2ProviderAppSecretByID = `-- name: GetOAuth2ProviderAppSecretByID :one
-SELECT id, created_at, last_used_at, hashed_secret, display_secret, app_id, secret_prefix FROM oauth2_provider_app_secrets WHERE id = $1
-`
-
-func (q *sqlQuerier) GetOAuth2ProviderAppSecretByID(ctx context.Context, id uuid.UUID) (OAuth2ProviderAppSecret, error) {
-	row := q.db.QueryRowContext(ctx, getOAuth2ProviderAppSecretByID, id)
-	var i OAuth2ProviderAppSecret
-	err := row.Scan(
-		&i.ID,
-		&i.CreatedAt,
-		&i.LastUsedAt,
-		&i.HashedSecret,
-		&i.DisplaySecret,
-		&i.AppID,
-		&i.SecretPrefix,
+const getInboxNotificationsByUserID = `-- name: GetInboxNotificationsByUserID :many
+SELECT id, user_id, template_id, title, content, actions, read_at, archived_at, created_at
+FROM inbox_notifications
+WHERE user_id = $1
+	AND CASE
+		WHEN $2 :: text = 'read' THEN read_at IS NOT NULL
+		WHEN $2 :: text = 'unread' THEN read_at IS NULL
+		ELSE true
+	END
+	AND ($3 :: boolean OR archived_at IS NULL)
+	AND CASE
+		-- The pagination cursor is the last ID of the previous page.
+		WHEN $4 :: uuid != '00000000-0000-0000-0000-000000000000'::uuid THEN (
+			(created_at, id) < (
+				SELECT
+					created_at, id
+				FROM
+					inbox_notifications
+				WHERE
+					id = $4
+			)
+		)
+		ELSE true
+	END
+ORDER BY
+	-- Newest first; the ID breaks ties between notifications created at the same time.
+	created_at DESC, id DESC
+OFFSET $5
+LIMIT
+	-- A null limit means "no limit", so 0 means return all
+	NULLIF($6 :: int, 0)
+`
+
+type GetInboxNotificationsByUserIDParams struct {
+	UserID          uuid.UUID `db:"user_id" json:"user_id"`
+	ReadStatus      string    `db:"read_status" json:"read_status"`
+	IncludeArchived bool      `db:"include_archived" json:"include_archived"`
+	AfterID         uuid.UUID `db:"after_id" json:"after_id"`
+	OffsetOpt       int32     `db:"offset_opt" json:"offset_opt"`
+	LimitOpt        int32     `db:"limit_opt" json:"limit_opt"`
+}
+
+func (q *sqlQuerier) GetInboxNotificationsByUserID(ctx context.Context, arg GetInboxNotificationsByUserIDParams) ([]InboxNotification, error) {
+	rows, err := q.db.QueryContext(ctx, getInboxNotificationsByUserID,
+		arg.UserID,
+		arg.ReadStatus,
+		arg.IncludeArchived,
+		arg.AfterID,
+		arg.OffsetOpt,
+		arg.LimitOpt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []InboxNotification
+	for rows.Next() {
+		var i InboxNotification
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.TemplateID,
+			&i.Title,
+			&i.Content,
+			&i.Actions,
+			&i.ReadAt,
+			&i.ArchivedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const insertInboxNotification = `-- name: InsertInboxNotification :execrows
+INSERT INTO inbox_notifications (id, user_id, template_id, title, content, actions, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (id) DO NOTHING
+`
+
+type InsertInboxNotificationParams struct {
+	ID         uuid.UUID       `db:"id" json:"id"`
+	UserID     uuid.UUID       `db:"user_id" json:"user_id"`
+	TemplateID uuid.UUID       `db:"template_id" json:"template_id"`
+	Title      string          `db:"title" json:"title"`
+	Content    string          `db:"content" json:"content"`
+	Actions    json.RawMessage `db:"actions" json:"actions"`
+	CreatedAt  time.Time       `db:"created_at" json:"created_at"`
+}
+
+// Inbox notifications share the ID of the message from which they were dispatched, so a message which is dispatched
+// more than once (e.g. after its lease expires) is only stored once.
+func (q *sqlQuerier) InsertInboxNotification(ctx context.Context, arg InsertInboxNotificationParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, insertInboxNotification,
+		arg.ID,
+		arg.UserID,
+		arg.TemplateID,
+		arg.Title,
+		arg.Content,
+		arg.Actions,
+		arg.CreatedAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const markAllInboxNotificationsAsRead = `-- name: MarkAllInboxNotificationsAsRead :execrows
+UPDATE inbox_notifications
+SET read_at = $1
+WHERE user_id = $2
+	AND read_at IS NULL
+`
+
+type MarkAllInboxNotificationsAsReadParams struct {
+	ReadAt sql.NullTime `db:"read_at" json:"read_at"`
+	UserID uuid.UUID    `db:"user_id" json:"user_id"`
+}
+
+func (q *sqlQuerier) MarkAllInboxNotificationsAsRead(ctx context.Context, arg MarkAllInboxNotificationsAsReadParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, markAllInboxNotificationsAsRead, arg.ReadAt, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateInboxNotificationStatus = `-- name: UpdateInboxNotificationStatus :one
+UPDATE inbox_notifications
+SET read_at     = $1,
+	archived_at = $2
+WHERE id = $3
+RETURNING id, user_id, template_id, title, content, actions, read_at, archived_at, created_at
+`
+
+type UpdateInboxNotificationStatusParams struct {
+	ReadAt     sql.NullTime `db:"read_at" json:"read_at"`
+	ArchivedAt sql.NullTime `db:"archived_at" json:"archived_at"`
+	ID         uuid.UUID    `db:"id" json:"id"`
+}
+
+func (q *sqlQuerier) UpdateInboxNotificationStatus(ctx context.Context, arg UpdateInboxNotificationStatusParams) (InboxNotification, error) {
+	row := q.db.QueryRowContext(ctx, updateInboxNotificationStatus, arg.ReadAt, arg.ArchivedAt, arg.ID)
+	var i InboxNotification
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.TemplateID,
+		&i.Title,
+		&i.Content,
+		&i.Actions,
+		&i.ReadAt,
+		&i.ArchivedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteOAuth2ProviderAppByID = `-- name: DeleteOAuth2ProviderAppByID :exec
+DELETE FROM oauth2_provider_apps WHERE id = $1
+`
+
+func (q *sqlQuerier) DeleteOAuth2ProviderAppByID(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteOAuth2ProviderAppByID, id)
+	return err
+}
+
+const deleteOAuth2ProviderAppCodeByID = `-- name: DeleteOAuth2ProviderAppCodeByID :exec
+DELETE FROM oauth2_provider_app_codes WHERE id = $1
+`
+
+func (q *sqlQuerier) DeleteOAuth2ProviderAppCodeByID(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteOAuth2ProviderAppCodeByID, id)
+	return err
+}
+
+const deleteOAuth2ProviderAppCodesByAppAndUserID = `-- name: DeleteOAuth2ProviderAppCodesByAppAndUserID :exec
+DELETE FROM oauth2_provider_app_codes WHERE app_id = $1 AND user_id = $2
+`
+
+type DeleteOAuth2ProviderAppCodesByAppAndUserIDParams struct {
+	AppID  uuid.UUID `db:"app_id" json:"app_id"`
+	UserID uuid.UUID `db:"user_id" json:"user_id"`
+}
+
+func (q *sqlQuerier) DeleteOAuth2ProviderAppCodesByAppAndUserID(ctx context.Context, arg DeleteOAuth2ProviderAppCodesByAppAndUserIDParams) error {
+	_, err := q.db.ExecContext(ctx, deleteOAuth2ProviderAppCodesByAppAndUserID, arg.AppID, arg.UserID)
+	return err
+}
+
+const deleteOAuth2ProviderAppSecretByID = `-- name: DeleteOAuth2ProviderAppSecretByID :exec
+DELETE FROM oauth2_provider_app_secrets WHERE id = $1
+`
+
+func (q *sqlQuerier) DeleteOAuth2ProviderAppSecretByID(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteOAuth2ProviderAppSecretByID, id)
+	return err
+}
+
+const deleteOAuth2ProviderAppTokensByAppAndUserID = `-- name: DeleteOAuth2ProviderAppTokensByAppAndUserID :exec
+DELETE FROM
+  oauth2_provider_app_tokens
+USING
+  oauth2_provider_app_secrets, api_keys
+WHERE
+  oauth2_provider_app_secrets.id = oauth2_provider_app_tokens.app_secret_id
+  AND api_keys.id = oauth2_provider_app_tokens.api_key_id
+  AND oauth2_provider_app_secrets.app_id = $1
+	AND api_keys.user_id = $2
+`
+
+type DeleteOAuth2ProviderAppTokensByAppAndUserIDParams struct {
+	AppID  uuid.UUID `db:"app_id" json:"app_id"`
+	UserID uuid.UUID `db:"user_id" json:"user_id"`
+}
+
+func (q *sqlQuerier) DeleteOAuth2ProviderAppTokensByAppAndUserID(ctx context.Context, arg DeleteOAuth2ProviderAppTokensByAppAndUserIDParams) error {
+	_, err := q.db.ExecContext(ctx, deleteOAuth2ProviderAppTokensByAppAndUserID, arg.AppID, arg.UserID)
+	return err
+}
+
+const getOAuth2ProviderAppByID = `-- name: GetOAuth2ProviderAppByID :one
+SELECT id, created_at, updated_at, name, icon, callback_url FROM oauth2_provider_apps WHERE id = $1
+`
+
+func (q *sqlQuerier) GetOAuth2ProviderAppByID(ctx context.Context, id uuid.UUID) (OAuth2ProviderApp, error) {
+	row := q.db.QueryRowContext(ctx, getOAuth2ProviderAppByID, id)
+	var i OAuth2ProviderApp
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Name,
+		&i.Icon,
+		&i.CallbackURL,
+	)
+	return i, err
+}
+
+const getOAuth2ProviderAppCodeByID = `-- name: GetOAuth2ProviderAppCodeByID :one
+SELECT id, created_at, expires_at, secret_prefix, hashed_secret, user_id, app_id FROM oauth2_provider_app_codes WHERE id = $1
+`
+
+func (q *sqlQuerier) GetOAuth2ProviderAppCodeByID(ctx context.Context, id uuid.UUID) (OAuth2ProviderAppCode, error) {
+	row := q.db.QueryRowContext(ctx, getOAuth2ProviderAppCodeByID, id)
+	var i OAuth2ProviderAppCode
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+		&i.SecretPrefix,
+		&i.HashedSecret,
+		&i.UserID,
+		&i.AppID,
+	)
+	return i, err
+}
+
+const getOAuth2ProviderAppCodeByPrefix = `-- name: GetOAuth2ProviderAppCodeByPrefix :one
+SELECT id, created_at, expires_at, secret_prefix, hashed_secret, user_id, app_id FROM oauth2_provider_app_codes WHERE secret_prefix = $1
+`
+
+func (q *sqlQuerier) GetOAuth2ProviderAppCodeByPrefix(ctx context.Context, secretPrefix []byte) (OAuth2ProviderAppCode, error) {
+	row := q.db.QueryRowContext(ctx, getOAuth2ProviderAppCodeByPrefix, secretPrefix)
+	var i OAuth2ProviderAppCode
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+		&i.SecretPrefix,
+		&i.HashedSecret,
+		&i.UserID,
+		&i.AppID,
+	)
+	return i, err
+}
+
+const getOAuth2ProviderAppSecretByID = `-- name: GetOAuth2ProviderAppSecretByID :one
+SELECT id, created_at, last_used_at, hashed_secret, display_secret, app_id, secret_prefix FROM oauth2_provider_app_secrets WHERE id = $1
+`
+
+func (q *sqlQuerier) GetOAuth2ProviderAppSecretByID(ctx context.Context, id uuid.UUID) (OAuth2ProviderAppSecret, error) {
+	row := q.db.QueryRowContext(ctx, getOAuth2ProviderAppSecretByID, id)
+	var i OAuth2ProviderAppSecret
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.LastUsedAt,
+		&i.HashedSecret,
+		&i.DisplaySecret,
+		&i.AppID,
+		&i.SecretPrefix,
 	)
 	return i, err
 }
@@ -5491,14 +5973,22 @@ WHERE
 			AND nested.organization_id = $3
 			-- Ensure the caller has the correct provisioner.
 			AND nested.provisioner = ANY($4 :: provisioner_type [ ])
+			-- The IaC requirement tags of the job are matched separately below.
 			AND CASE
 				-- Special case for untagged provisioners: only match untagged jobs.
-				WHEN nested.tags :: jsonb = '{"scope": "organization", "owner": ""}' :: jsonb
-				THEN nested.tags :: jsonb = $5 :: jsonb
+				WHEN nested.tags :: jsonb - 'coder.iac.engine' - 'coder.iac.version' = '{"scope": "organization", "owner": ""}' :: jsonb
+				THEN nested.tags :: jsonb - 'coder.iac.engine' - 'coder.iac.version' = $5 :: jsonb
 				-- Ensure the caller satisfies all job tags.
-				ELSE nested.tags :: jsonb <@ $5 :: jsonb
+				ELSE nested.tags :: jsonb - 'coder.iac.engine' - 'coder.iac.version' <@ $5 :: jsonb
 			END
+			-- Ensure the caller has a binary that satisfies the IaC requirement of the job.
+			AND (
+				nested.tags ->> 'coder.iac.engine' IS NULL
+				OR (nested.tags ->> 'coder.iac.engine') || ' ' || COALESCE(nested.tags ->> 'coder.iac.version', '') = ANY($6 :: text [ ])
+			)
 		ORDER BY
+			-- Drift checks are low priority, so they don't delay builds.
+			nested.type = 'workspace_drift_check' :: provisioner_job_type,
 			nested.created_at
 		FOR UPDATE
 		SKIP LOCKED
@@ -5508,11 +5998,12 @@ WHERE
 `
 
 type AcquireProvisionerJobParams struct {
-	StartedAt      sql.NullTime      `db:"started_at" json:"started_at"`
-	WorkerID       uuid.NullUUID     `db:"worker_id" json:"worker_id"`
-	OrganizationID uuid.UUID         `db:"organization_id" json:"organization_id"`
-	Types          []ProvisionerType `db:"types" json:"types"`
-	Tags           json.RawMessage   `db:"tags" json:"tags"`
+	StartedAt       sql.NullTime      `db:"started_at" json:"started_at"`
+	WorkerID        uuid.NullUUID     `db:"worker_id" json:"worker_id"`
+	OrganizationID  uuid.UUID         `db:"organization_id" json:"organization_id"`
+	Types           []ProvisionerType `db:"types" json:"types"`
+	Tags            json.RawMessage   `db:"tags" json:"tags"`
+	IacRequirements []string          `db:"iac_requirements" json:"iac_requirements"`
 }
 
 // Acquires the lock for a single job that isn't started, completed,
@@ -5528,6 +6019,7 @@ func (q *sqlQuerier) AcquireProvisionerJob(ctx context.Context, arg AcquireProvi
 		arg.OrganizationID,
 		pq.Array(arg.Types),
 		arg.Tags,
+		pq.Array(arg.IacRequirements),
 	)
 	var i ProvisionerJob
 	err := row.Scan(
@@ -5608,6 +6100,43 @@ func (q *sqlQuerier) GetHungProvisionerJobs(ctx context.Context, updatedAt time.
 	return items, nil
 }
 
+const getPendingProvisionerJobIaCRequirements = `-- name: GetPendingProvisionerJobIaCRequirements :many
+SELECT DISTINCT
+	((tags ->> 'coder.iac.engine') || ' ' || COALESCE(tags ->> 'coder.iac.version', '')) :: text AS requirement
+FROM
+	provisioner_jobs
+WHERE
+	started_at IS NULL
+	AND organization_id = $1
+	AND tags ->> 'coder.iac.engine' IS NOT NULL
+`
+
+// Returns the distinct IaC requirements of the pending jobs of an
+// organization, formatted as the engine followed by the version constraint, so
+// that a provisioner daemon can work out which of them its binaries satisfy.
+func (q *sqlQuerier) GetPendingProvisionerJobIaCRequirements(ctx context.Context, organizationID uuid.UUID) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, getPendingProvisionerJobIaCRequirements, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var requirement string
+		if err := rows.Scan(&requirement); err != nil {
+			return nil, err
+		}
+		items = append(items, requirement)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getProvisionerJobByID = `-- name: GetProvisionerJobByID :one
 SELECT
 	id, created_at, updated_at, started_at, canceled_at, completed_at, error, organization_id, initiator_id, provisioner, storage_method, type, input, worker_id, file_id, tags, error_code, trace_metadata, job_status
@@ -5644,21 +6173,57 @@ func (q *sqlQuerier) GetProvisionerJobByID(ctx context.Context, id uuid.UUID) (P
 	return i, err
 }
 
-const getProvisionerJobTimingsByJobID = `-- name: GetProvisionerJobTimingsByJobID :many
-SELECT job_id, started_at, ended_at, stage, source, action, resource FROM provisioner_job_timings
+const getProvisionerJobResourceChangesByJobID = `-- name: GetProvisionerJobResourceChangesByJobID :many
+SELECT job_id, address, type, name, action, attributes FROM provisioner_job_resource_changes
 WHERE job_id = $1
-ORDER BY started_at ASC
+ORDER BY address ASC
 `
 
-func (q *sqlQuerier) GetProvisionerJobTimingsByJobID(ctx context.Context, jobID uuid.UUID) ([]ProvisionerJobTiming, error) {
-	rows, err := q.db.QueryContext(ctx, getProvisionerJobTimingsByJobID, jobID)
+func (q *sqlQuerier) GetProvisionerJobResourceChangesByJobID(ctx context.Context, jobID uuid.UUID) ([]ProvisionerJobResourceChange, error) {
+	rows, err := q.db.QueryContext(ctx, getProvisionerJobResourceChangesByJobID, jobID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []ProvisionerJobTiming
+	var items []ProvisionerJobResourceChange
 	for rows.Next() {
-		var i ProvisionerJobTiming
+		var i ProvisionerJobResourceChange
+		if err := rows.Scan(
+			&i.JobID,
+			&i.Address,
+			&i.Type,
+			&i.Name,
+			&i.Action,
+			&i.Attributes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getProvisionerJobTimingsByJobID = `-- name: GetProvisionerJobTimingsByJobID :many
+SELECT job_id, started_at, ended_at, stage, source, action, resource FROM provisioner_job_timings
+WHERE job_id = $1
+ORDER BY started_at ASC
+`
+
+func (q *sqlQuerier) GetProvisionerJobTimingsByJobID(ctx context.Context, jobID uuid.UUID) ([]ProvisionerJobTiming, error) {
+	rows, err := q.db.QueryContext(ctx, getProvisionerJobTimingsByJobID, jobID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ProvisionerJobTiming
+	for rows.Next() {
+		var i ProvisionerJobTiming
 		if err := rows.Scan(
 			&i.JobID,
 			&i.StartedAt,
@@ -5939,6 +6504,42 @@ func (q *sqlQuerier) InsertProvisionerJob(ctx context.Context, arg InsertProvisi
 	return i, err
 }
 
+const insertProvisionerJobResourceChange = `-- name: InsertProvisionerJobResourceChange :one
+INSERT INTO provisioner_job_resource_changes (job_id, address, type, name, action, attributes)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING job_id, address, type, name, action, attributes
+`
+
+type InsertProvisionerJobResourceChangeParams struct {
+	JobID      uuid.UUID            `db:"job_id" json:"job_id"`
+	Address    string               `db:"address" json:"address"`
+	Type       string               `db:"type" json:"type"`
+	Name       string               `db:"name" json:"name"`
+	Action     ResourceChangeAction `db:"action" json:"action"`
+	Attributes json.RawMessage      `db:"attributes" json:"attributes"`
+}
+
+func (q *sqlQuerier) InsertProvisionerJobResourceChange(ctx context.Context, arg InsertProvisionerJobResourceChangeParams) (ProvisionerJobResourceChange, error) {
+	row := q.db.QueryRowContext(ctx, insertProvisionerJobResourceChange,
+		arg.JobID,
+		arg.Address,
+		arg.Type,
+		arg.Name,
+		arg.Action,
+		arg.Attributes,
+	)
+	var i ProvisionerJobResourceChange
+	err := row.Scan(
+		&i.JobID,
+		&i.Address,
+		&i.Type,
+		&i.Name,
+		&i.Action,
+		&i.Attributes,
+	)
+	return i, err
+}
+
 const insertProvisionerJobTimings = `-- name: InsertProvisionerJobTimings :many
 INSERT INTO provisioner_job_timings (job_id, started_at, ended_at, stage, source, action, resource)
 SELECT
@@ -7115,6 +7716,189 @@ func (q *sqlQuerier) UpdateCustomRole(ctx context.Context, arg UpdateCustomRoleP
 	return i, err
 }
 
+const deleteSavedSearchByID = `-- name: DeleteSavedSearchByID :exec
+DELETE FROM saved_searches
+WHERE id = $1
+`
+
+func (q *sqlQuerier) DeleteSavedSearchByID(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteSavedSearchByID, id)
+	return err
+}
+
+const getSavedSearchByID = `-- name: GetSavedSearchByID :one
+SELECT id, user_id, resource_type, name, query, organization_id, group_id, created_at, updated_at
+FROM saved_searches
+WHERE id = $1
+`
+
+func (q *sqlQuerier) GetSavedSearchByID(ctx context.Context, id uuid.UUID) (SavedSearch, error) {
+	row := q.db.QueryRowContext(ctx, getSavedSearchByID, id)
+	var i SavedSearch
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ResourceType,
+		&i.Name,
+		&i.Query,
+		&i.OrganizationID,
+		&i.GroupID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getSavedSearches = `-- name: GetSavedSearches :many
+SELECT id, user_id, resource_type, name, query, organization_id, group_id, created_at, updated_at
+FROM saved_searches
+WHERE CASE
+		WHEN $1 :: text != '' THEN
+			resource_type = $1 :: saved_search_resource_type
+		ELSE true
+	END
+	AND (
+		user_id = $2
+		-- Searches shared with a whole organization are keyed by the
+		-- organization's "Everyone" group, which shares the organization's ID.
+		OR COALESCE(group_id, organization_id) IN (
+			SELECT group_id
+			FROM group_members_expanded
+			WHERE group_members_expanded.user_id = $2
+		)
+	)
+ORDER BY user_id != $2, LOWER(name), id
+`
+
+type GetSavedSearchesParams struct {
+	ResourceType string    `db:"resource_type" json:"resource_type"`
+	UserID       uuid.UUID `db:"user_id" json:"user_id"`
+}
+
+// Returns the searches saved by the user, followed by the searches other users
+// have shared with them.
+func (q *sqlQuerier) GetSavedSearches(ctx context.Context, arg GetSavedSearchesParams) ([]SavedSearch, error) {
+	rows, err := q.db.QueryContext(ctx, getSavedSearches, arg.ResourceType, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SavedSearch
+	for rows.Next() {
+		var i SavedSearch
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ResourceType,
+			&i.Name,
+			&i.Query,
+			&i.OrganizationID,
+			&i.GroupID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const insertSavedSearch = `-- name: InsertSavedSearch :one
+INSERT INTO saved_searches (id, user_id, resource_type, name, query, organization_id, group_id, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING id, user_id, resource_type, name, query, organization_id, group_id, created_at, updated_at
+`
+
+type InsertSavedSearchParams struct {
+	ID             uuid.UUID               `db:"id" json:"id"`
+	UserID         uuid.UUID               `db:"user_id" json:"user_id"`
+	ResourceType   SavedSearchResourceType `db:"resource_type" json:"resource_type"`
+	Name           string                  `db:"name" json:"name"`
+	Query          string                  `db:"query" json:"query"`
+	OrganizationID uuid.NullUUID           `db:"organization_id" json:"organization_id"`
+	GroupID        uuid.NullUUID           `db:"group_id" json:"group_id"`
+	CreatedAt      time.Time               `db:"created_at" json:"created_at"`
+	UpdatedAt      time.Time               `db:"updated_at" json:"updated_at"`
+}
+
+func (q *sqlQuerier) InsertSavedSearch(ctx context.Context, arg InsertSavedSearchParams) (SavedSearch, error) {
+	row := q.db.QueryRowContext(ctx, insertSavedSearch,
+		arg.ID,
+		arg.UserID,
+		arg.ResourceType,
+		arg.Name,
+		arg.Query,
+		arg.OrganizationID,
+		arg.GroupID,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	var i SavedSearch
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ResourceType,
+		&i.Name,
+		&i.Query,
+		&i.OrganizationID,
+		&i.GroupID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateSavedSearch = `-- name: UpdateSavedSearch :one
+UPDATE saved_searches
+SET name            = $1,
+	query           = $2,
+	organization_id = $3,
+	group_id        = $4,
+	updated_at      = $5
+WHERE id = $6
+RETURNING id, user_id, resource_type, name, query, organization_id, group_id, created_at, updated_at
+`
+
+type UpdateSavedSearchParams struct {
+	Name           string        `db:"name" json:"name"`
+	Query          string        `db:"query" json:"query"`
+	OrganizationID uuid.NullUUID `db:"organization_id" json:"organization_id"`
+	GroupID        uuid.NullUUID `db:"group_id" json:"group_id"`
+	UpdatedAt      time.Time     `db:"updated_at" json:"updated_at"`
+	ID             uuid.UUID     `db:"id" json:"id"`
+}
+
+func (q *sqlQuerier) UpdateSavedSearch(ctx context.Context, arg UpdateSavedSearchParams) (SavedSearch, error) {
+	row := q.db.QueryRowContext(ctx, updateSavedSearch,
+		arg.Name,
+		arg.Query,
+		arg.OrganizationID,
+		arg.GroupID,
+		arg.UpdatedAt,
+		arg.ID,
+	)
+	var i SavedSearch
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ResourceType,
+		&i.Name,
+		&i.Query,
+		&i.OrganizationID,
+		&i.GroupID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const deleteRuntimeConfig = `-- name: DeleteRuntimeConfig :exec
 DELETE FROM site_configs
 WHERE site_configs.key = $1
@@ -8226,7 +9010,7 @@ func (q *sqlQuerier) GetTemplateAverageBuildTime(ctx context.Context, arg GetTem
 
 const getTemplateByID = `-- name: GetTemplateByID :one
 SELECT
-	id, created_at, updated_at, organization_id, deleted, name, provisioner, active_version_id, description, default_ttl, created_by, icon, user_acl, group_acl, display_name, allow_user_cancel_workspace_jobs, allow_user_autostart, allow_user_autostop, failure_ttl, time_til_dormant, time_til_dormant_autodelete, autostop_requirement_days_of_week, autostop_requirement_weeks, autostart_block_days_of_week, require_active_version, deprecated, activity_bump, max_port_sharing_level, created_by_avatar_url, created_by_username, organization_name, organization_display_name, organization_icon
+	id, created_at, updated_at, organization_id, deleted, name, provisioner, active_version_id, description, default_ttl, created_by, icon, user_acl, group_acl, display_name, allow_user_cancel_workspace_jobs, allow_user_autostart, allow_user_autostop, failure_ttl, time_til_dormant, time_til_dormant_autodelete, autostop_requirement_days_of_week, autostop_requirement_weeks, autostart_block_days_of_week, require_active_version, deprecated, activity_bump, max_port_sharing_level, session_recording, port_forwarding_policy, agent_activity_bump, created_by_avatar_url, created_by_username, organization_name, organization_display_name, organization_icon
 FROM
 	template_with_names
 WHERE
@@ -8267,6 +9051,9 @@ func (q *sqlQuerier) GetTemplateByID(ctx context.Context, id uuid.UUID) (Templat
 		&i.Deprecated,
 		&i.ActivityBump,
 		&i.MaxPortSharingLevel,
+		&i.SessionRecording,
+		&i.PortForwardingPolicy,
+		&i.AgentActivityBump,
 		&i.CreatedByAvatarURL,
 		&i.CreatedByUsername,
 		&i.OrganizationName,
@@ -8278,7 +9065,7 @@ func (q *sqlQuerier) GetTemplateByID(ctx context.Context, id uuid.UUID) (Templat
 
 const getTemplateByOrganizationAndName = `-- name: GetTemplateByOrganizationAndName :one
 SELECT
-	id, created_at, updated_at, organization_id, deleted, name, provisioner, active_version_id, description, default_ttl, created_by, icon, user_acl, group_acl, display_name, allow_user_cancel_workspace_jobs, allow_user_autostart, allow_user_autostop, failure_ttl, time_til_dormant, time_til_dormant_autodelete, autostop_requirement_days_of_week, autostop_requirement_weeks, autostart_block_days_of_week, require_active_version, deprecated, activity_bump, max_port_sharing_level, created_by_avatar_url, created_by_username, organization_name, organization_display_name, organization_icon
+	id, created_at, updated_at, organization_id, deleted, name, provisioner, active_version_id, description, default_ttl, created_by, icon, user_acl, group_acl, display_name, allow_user_cancel_workspace_jobs, allow_user_autostart, allow_user_autostop, failure_ttl, time_til_dormant, time_til_dormant_autodelete, autostop_requirement_days_of_week, autostop_requirement_weeks, autostart_block_days_of_week, require_active_version, deprecated, activity_bump, max_port_sharing_level, session_recording, port_forwarding_policy, agent_activity_bump, created_by_avatar_url, created_by_username, organization_name, organization_display_name, organization_icon
 FROM
 	template_with_names AS templates
 WHERE
@@ -8327,6 +9114,9 @@ func (q *sqlQuerier) GetTemplateByOrganizationAndName(ctx context.Context, arg G
 		&i.Deprecated,
 		&i.ActivityBump,
 		&i.MaxPortSharingLevel,
+		&i.SessionRecording,
+		&i.PortForwardingPolicy,
+		&i.AgentActivityBump,
 		&i.CreatedByAvatarURL,
 		&i.CreatedByUsername,
 		&i.OrganizationName,
@@ -8337,7 +9127,7 @@ func (q *sqlQuerier) GetTemplateByOrganizationAndName(ctx context.Context, arg G
 }
 
 const getTemplates = `-- name: GetTemplates :many
-SELECT id, created_at, updated_at, organization_id, deleted, name, provisioner, active_version_id, description, default_ttl, created_by, icon, user_acl, group_acl, display_name, allow_user_cancel_workspace_jobs, allow_user_autostart, allow_user_autostop, failure_ttl, time_til_dormant, time_til_dormant_autodelete, autostop_requirement_days_of_week, autostop_requirement_weeks, autostart_block_days_of_week, require_active_version, deprecated, activity_bump, max_port_sharing_level, created_by_avatar_url, created_by_username, organization_name, organization_display_name, organization_icon FROM template_with_names AS templates
+SELECT id, created_at, updated_at, organization_id, deleted, name, provisioner, active_version_id, description, default_ttl, created_by, icon, user_acl, group_acl, display_name, allow_user_cancel_workspace_jobs, allow_user_autostart, allow_user_autostop, failure_ttl, time_til_dormant, time_til_dormant_autodelete, autostop_requirement_days_of_week, autostop_requirement_weeks, autostart_block_days_of_week, require_active_version, deprecated, activity_bump, max_port_sharing_level, session_recording, port_forwarding_policy, agent_activity_bump, created_by_avatar_url, created_by_username, organization_name, organization_display_name, organization_icon FROM template_with_names AS templates
 ORDER BY (name, id) ASC
 `
 
@@ -8379,6 +9169,9 @@ func (q *sqlQuerier) GetTemplates(ctx context.Context) ([]Template, error) {
 			&i.Deprecated,
 			&i.ActivityBump,
 			&i.MaxPortSharingLevel,
+			&i.SessionRecording,
+			&i.PortForwardingPolicy,
+			&i.AgentActivityBump,
 			&i.CreatedByAvatarURL,
 			&i.CreatedByUsername,
 			&i.OrganizationName,
@@ -8400,7 +9193,7 @@ func (q *sqlQuerier) GetTemplates(ctx context.Context) ([]Template, error) {
 
 const getTemplatesWithFilter = `-- name: GetTemplatesWithFilter :many
 SELECT
-	id, created_at, updated_at, organization_id, deleted, name, provisioner, active_version_id, description, default_ttl, created_by, icon, user_acl, group_acl, display_name, allow_user_cancel_workspace_jobs, allow_user_autostart, allow_user_autostop, failure_ttl, time_til_dormant, time_til_dormant_autodelete, autostop_requirement_days_of_week, autostop_requirement_weeks, autostart_block_days_of_week, require_active_version, deprecated, activity_bump, max_port_sharing_level, created_by_avatar_url, created_by_username, organization_name, organization_display_name, organization_icon
+	id, created_at, updated_at, organization_id, deleted, name, provisioner, active_version_id, description, default_ttl, created_by, icon, user_acl, group_acl, display_name, allow_user_cancel_workspace_jobs, allow_user_autostart, allow_user_autostop, failure_ttl, time_til_dormant, time_til_dormant_autodelete, autostop_requirement_days_of_week, autostop_requirement_weeks, autostart_block_days_of_week, require_active_version, deprecated, activity_bump, max_port_sharing_level, session_recording, port_forwarding_policy, agent_activity_bump, created_by_avatar_url, created_by_username, organization_name, organization_display_name, organization_icon
 FROM
 	template_with_names AS templates
 WHERE
@@ -8500,6 +9293,9 @@ func (q *sqlQuerier) GetTemplatesWithFilter(ctx context.Context, arg GetTemplate
 			&i.Deprecated,
 			&i.ActivityBump,
 			&i.MaxPortSharingLevel,
+			&i.SessionRecording,
+			&i.PortForwardingPolicy,
+			&i.AgentActivityBump,
 			&i.CreatedByAvatarURL,
 			&i.CreatedByUsername,
 			&i.OrganizationName,
@@ -8676,21 +9472,27 @@ SET
 	display_name = $6,
 	allow_user_cancel_workspace_jobs = $7,
 	group_acl = $8,
-	max_port_sharing_level = $9
+	max_port_sharing_level = $9,
+	session_recording = $10,
+	port_forwarding_policy = $11,
+	agent_activity_bump = $12
 WHERE
 	id = $1
 `
 
 type UpdateTemplateMetaByIDParams struct {
-	ID                           uuid.UUID       `db:"id" json:"id"`
-	UpdatedAt                    time.Time       `db:"updated_at" json:"updated_at"`
-	Description                  string          `db:"description" json:"description"`
-	Name                         string          `db:"name" json:"name"`
-	Icon                         string          `db:"icon" json:"icon"`
-	DisplayName                  string          `db:"display_name" json:"display_name"`
-	AllowUserCancelWorkspaceJobs bool            `db:"allow_user_cancel_workspace_jobs" json:"allow_user_cancel_workspace_jobs"`
-	GroupACL                     TemplateACL     `db:"group_acl" json:"group_acl"`
-	MaxPortSharingLevel          AppSharingLevel `db:"max_port_sharing_level" json:"max_port_sharing_level"`
+	ID                           uuid.UUID            `db:"id" json:"id"`
+	UpdatedAt                    time.Time            `db:"updated_at" json:"updated_at"`
+	Description                  string               `db:"description" json:"description"`
+	Name                         string               `db:"name" json:"name"`
+	Icon                         string               `db:"icon" json:"icon"`
+	DisplayName                  string               `db:"display_name" json:"display_name"`
+	AllowUserCancelWorkspaceJobs bool                 `db:"allow_user_cancel_workspace_jobs" json:"allow_user_cancel_workspace_jobs"`
+	GroupACL                     TemplateACL          `db:"group_acl" json:"group_acl"`
+	MaxPortSharingLevel          AppSharingLevel      `db:"max_port_sharing_level" json:"max_port_sharing_level"`
+	SessionRecording             bool                 `db:"session_recording" json:"session_recording"`
+	PortForwardingPolicy         PortForwardingPolicy `db:"port_forwarding_policy" json:"port_forwarding_policy"`
+	AgentActivityBump            bool                 `db:"agent_activity_bump" json:"agent_activity_bump"`
 }
 
 func (q *sqlQuerier) UpdateTemplateMetaByID(ctx context.Context, arg UpdateTemplateMetaByIDParams) error {
@@ -8704,6 +9506,9 @@ func (q *sqlQuerier) UpdateTemplateMetaByID(ctx context.Context, arg UpdateTempl
 		arg.AllowUserCancelWorkspaceJobs,
 		arg.GroupACL,
 		arg.MaxPortSharingLevel,
+		arg.SessionRecording,
+		arg.PortForwardingPolicy,
+		arg.AgentActivityBump,
 	)
 	return err
 }
@@ -10746,6 +11551,110 @@ func (q *sqlQuerier) UpdateUserStatus(ctx context.Context, arg UpdateUserStatusP
 	return i, err
 }
 
+const deleteWorkspaceAgentContainersByAgentID = `-- name: DeleteWorkspaceAgentContainersByAgentID :exec
+DELETE FROM
+	workspace_agent_containers
+WHERE
+	workspace_agent_id = $1
+`
+
+func (q *sqlQuerier) DeleteWorkspaceAgentContainersByAgentID(ctx context.Context, workspaceAgentID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteWorkspaceAgentContainersByAgentID, workspaceAgentID)
+	return err
+}
+
+const getWorkspaceAgentContainersByAgentID = `-- name: GetWorkspaceAgentContainersByAgentID :many
+SELECT
+	workspace_agent_id, container_id, name, image, status, runtime, labels, ports, created_at, reported_at
+FROM
+	workspace_agent_containers
+WHERE
+	workspace_agent_id = $1
+ORDER BY
+	name ASC
+`
+
+func (q *sqlQuerier) GetWorkspaceAgentContainersByAgentID(ctx context.Context, workspaceAgentID uuid.UUID) ([]WorkspaceAgentContainer, error) {
+	rows, err := q.db.QueryContext(ctx, getWorkspaceAgentContainersByAgentID, workspaceAgentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WorkspaceAgentContainer
+	for rows.Next() {
+		var i WorkspaceAgentContainer
+		if err := rows.Scan(
+			&i.WorkspaceAgentID,
+			&i.ContainerID,
+			&i.Name,
+			&i.Image,
+			&i.Status,
+			&i.Runtime,
+			&i.Labels,
+			&i.Ports,
+			&i.CreatedAt,
+			&i.ReportedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const insertWorkspaceAgentContainer = `-- name: InsertWorkspaceAgentContainer :exec
+INSERT INTO
+	workspace_agent_containers (
+		workspace_agent_id,
+		container_id,
+		name,
+		image,
+		status,
+		runtime,
+		labels,
+		ports,
+		created_at,
+		reported_at
+	)
+VALUES
+	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+`
+
+type InsertWorkspaceAgentContainerParams struct {
+	WorkspaceAgentID uuid.UUID       `db:"workspace_agent_id" json:"workspace_agent_id"`
+	ContainerID      string          `db:"container_id" json:"container_id"`
+	Name             string          `db:"name" json:"name"`
+	Image            string          `db:"image" json:"image"`
+	Status           string          `db:"status" json:"status"`
+	Runtime          string          `db:"runtime" json:"runtime"`
+	Labels           json.RawMessage `db:"labels" json:"labels"`
+	Ports            json.RawMessage `db:"ports" json:"ports"`
+	CreatedAt        time.Time       `db:"created_at" json:"created_at"`
+	ReportedAt       time.Time       `db:"reported_at" json:"reported_at"`
+}
+
+func (q *sqlQuerier) InsertWorkspaceAgentContainer(ctx context.Context, arg InsertWorkspaceAgentContainerParams) error {
+	_, err := q.db.ExecContext(ctx, insertWorkspaceAgentContainer,
+		arg.WorkspaceAgentID,
+		arg.ContainerID,
+		arg.Name,
+		arg.Image,
+		arg.Status,
+		arg.Runtime,
+		arg.Labels,
+		arg.Ports,
+		arg.CreatedAt,
+		arg.ReportedAt,
+	)
+	return err
+}
+
 const deleteWorkspaceAgentPortShare = `-- name: DeleteWorkspaceAgentPortShare :exec
 DELETE FROM
 	workspace_agent_port_share
@@ -10980,11 +11889,60 @@ func (q *sqlQuerier) DeleteOldWorkspaceAgentLogs(ctx context.Context, threshold
 	return err
 }
 
+const deleteOldWorkspaceAgentMetadataHistory = `-- name: DeleteOldWorkspaceAgentMetadataHistory :exec
+WITH cutoffs AS (
+	-- The cutoff of each key is found through the history index, so only
+	-- the deleted samples are visited rather than the whole table.
+	SELECT
+		wam.workspace_agent_id,
+		wam.key,
+		GREATEST($1::timestamptz, oldest_kept.collected_at) AS collected_at
+	FROM
+		workspace_agent_metadata wam
+	LEFT JOIN LATERAL (
+		SELECT
+			collected_at
+		FROM
+			workspace_agent_metadata_history
+		WHERE
+			workspace_agent_id = wam.workspace_agent_id
+			AND key = wam.key
+		ORDER BY
+			collected_at DESC
+		OFFSET
+			$2::int - 1
+		LIMIT
+			1
+	) AS oldest_kept ON TRUE
+)
+DELETE FROM
+	workspace_agent_metadata_history wamh
+USING
+	cutoffs
+WHERE
+	wamh.workspace_agent_id = cutoffs.workspace_agent_id
+	AND wamh.key = cutoffs.key
+	AND wamh.collected_at < cutoffs.collected_at
+`
+
+type DeleteOldWorkspaceAgentMetadataHistoryParams struct {
+	CollectedBefore  time.Time `db:"collected_before" json:"collected_before"`
+	MaxSamplesPerKey int32     `db:"max_samples_per_key" json:"max_samples_per_key"`
+}
+
+// Deletes samples collected before the given time, and samples beyond the
+// newest max_samples_per_key of each key. History is only recorded for keys
+// in workspace_agent_metadata, and is deleted with the agent.
+func (q *sqlQuerier) DeleteOldWorkspaceAgentMetadataHistory(ctx context.Context, arg DeleteOldWorkspaceAgentMetadataHistoryParams) error {
+	_, err := q.db.ExecContext(ctx, deleteOldWorkspaceAgentMetadataHistory, arg.CollectedBefore, arg.MaxSamplesPerKey)
+	return err
+}
+
 const getWorkspaceAgentAndLatestBuildByAuthToken = `-- name: GetWorkspaceAgentAndLatestBuildByAuthToken :one
 SELECT
 	workspaces.id, workspaces.created_at, workspaces.updated_at, workspaces.owner_id, workspaces.organization_id, workspaces.template_id, workspaces.deleted, workspaces.name, workspaces.autostart_schedule, workspaces.ttl, workspaces.last_used_at, workspaces.dormant_at, workspaces.deleting_at, workspaces.automatic_updates, workspaces.favorite,
 	workspace_agents.id, workspace_agents.created_at, workspace_agents.updated_at, workspace_agents.name, workspace_agents.first_connected_at, workspace_agents.last_connected_at, workspace_agents.disconnected_at, workspace_agents.resource_id, workspace_agents.auth_token, workspace_agents.auth_instance_id, workspace_agents.architecture, workspace_agents.environment_variables, workspace_agents.operating_system, workspace_agents.instance_metadata, workspace_agents.resource_metadata, workspace_agents.directory, workspace_agents.version, workspace_agents.last_connected_replica_id, workspace_agents.connection_timeout_seconds, workspace_agents.troubleshooting_url, workspace_agents.motd_file, workspace_agents.lifecycle_state, workspace_agents.expanded_directory, workspace_agents.logs_length, workspace_agents.logs_overflowed, workspace_agents.started_at, workspace_agents.ready_at, workspace_agents.subsystems, workspace_agents.display_apps, workspace_agents.api_version, workspace_agents.display_order,
-	workspace_build_with_user.id, workspace_build_with_user.created_at, workspace_build_with_user.updated_at, workspace_build_with_user.workspace_id, workspace_build_with_user.template_version_id, workspace_build_with_user.build_number, workspace_build_with_user.transition, workspace_build_with_user.initiator_id, workspace_build_with_user.provisioner_state, workspace_build_with_user.job_id, workspace_build_with_user.deadline, workspace_build_with_user.reason, workspace_build_with_user.daily_cost, workspace_build_with_user.max_deadline, workspace_build_with_user.initiator_by_avatar_url, workspace_build_with_user.initiator_by_username
+	workspace_build_with_user.id, workspace_build_with_user.created_at, workspace_build_with_user.updated_at, workspace_build_with_user.workspace_id, workspace_build_with_user.template_version_id, workspace_build_with_user.build_number, workspace_build_with_user.transition, workspace_build_with_user.initiator_id, workspace_build_with_user.provisioner_state, workspace_build_with_user.job_id, workspace_build_with_user.deadline, workspace_build_with_user.reason, workspace_build_with_user.daily_cost, workspace_build_with_user.max_deadline, workspace_build_with_user.provisioner_state_ref, workspace_build_with_user.initiator_by_avatar_url, workspace_build_with_user.initiator_by_username
 FROM
 	workspace_agents
 JOIN
@@ -11086,6 +12044,7 @@ func (q *sqlQuerier) GetWorkspaceAgentAndLatestBuildByAuthToken(ctx context.Cont
 		&i.WorkspaceBuild.Reason,
 		&i.WorkspaceBuild.DailyCost,
 		&i.WorkspaceBuild.MaxDeadline,
+		&i.WorkspaceBuild.ProvisionerStateRef,
 		&i.WorkspaceBuild.InitiatorByAvatarUrl,
 		&i.WorkspaceBuild.InitiatorByUsername,
 	)
@@ -11343,16 +12302,120 @@ func (q *sqlQuerier) GetWorkspaceAgentMetadata(ctx context.Context, arg GetWorks
 	return items, nil
 }
 
-const getWorkspaceAgentsByResourceIDs = `-- name: GetWorkspaceAgentsByResourceIDs :many
+const getWorkspaceAgentMetadataHistory = `-- name: GetWorkspaceAgentMetadataHistory :many
 SELECT
-	id, created_at, updated_at, name, first_connected_at, last_connected_at, disconnected_at, resource_id, auth_token, auth_instance_id, architecture, environment_variables, operating_system, instance_metadata, resource_metadata, directory, version, last_connected_replica_id, connection_timeout_seconds, troubleshooting_url, motd_file, lifecycle_state, expanded_directory, logs_length, logs_overflowed, started_at, ready_at, subsystems, display_apps, api_version, display_order
+	workspace_agent_id, key, value, error, collected_at
 FROM
-	workspace_agents
+	workspace_agent_metadata_history
 WHERE
-	resource_id = ANY($1 :: uuid [ ])
+	workspace_agent_id = $1
+	AND key = $2
+	AND collected_at > $3::timestamptz
+ORDER BY
+	collected_at DESC
+LIMIT
+	$4::int
 `
 
-func (q *sqlQuerier) GetWorkspaceAgentsByResourceIDs(ctx context.Context, ids []uuid.UUID) ([]WorkspaceAgent, error) {
+type GetWorkspaceAgentMetadataHistoryParams struct {
+	WorkspaceAgentID uuid.UUID `db:"workspace_agent_id" json:"workspace_agent_id"`
+	Key              string    `db:"key" json:"key"`
+	CollectedAfter   time.Time `db:"collected_after" json:"collected_after"`
+	Limit            int32     `db:"limit" json:"limit"`
+}
+
+func (q *sqlQuerier) GetWorkspaceAgentMetadataHistory(ctx context.Context, arg GetWorkspaceAgentMetadataHistoryParams) ([]WorkspaceAgentMetadataHistory, error) {
+	rows, err := q.db.QueryContext(ctx, getWorkspaceAgentMetadataHistory,
+		arg.WorkspaceAgentID,
+		arg.Key,
+		arg.CollectedAfter,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WorkspaceAgentMetadataHistory
+	for rows.Next() {
+		var i WorkspaceAgentMetadataHistory
+		if err := rows.Scan(
+			&i.WorkspaceAgentID,
+			&i.Key,
+			&i.Value,
+			&i.Error,
+			&i.CollectedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getWorkspaceAgentScriptTimingsByScriptID = `-- name: GetWorkspaceAgentScriptTimingsByScriptID :many
+SELECT
+	script_id, started_at, ended_at, exit_code, stage, status
+FROM
+	workspace_agent_script_timings
+WHERE
+	script_id = $1
+ORDER BY
+	started_at DESC
+LIMIT
+	$2
+`
+
+type GetWorkspaceAgentScriptTimingsByScriptIDParams struct {
+	ScriptID uuid.UUID `db:"script_id" json:"script_id"`
+	Limit    int32     `db:"limit" json:"limit"`
+}
+
+func (q *sqlQuerier) GetWorkspaceAgentScriptTimingsByScriptID(ctx context.Context, arg GetWorkspaceAgentScriptTimingsByScriptIDParams) ([]WorkspaceAgentScriptTiming, error) {
+	rows, err := q.db.QueryContext(ctx, getWorkspaceAgentScriptTimingsByScriptID, arg.ScriptID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WorkspaceAgentScriptTiming
+	for rows.Next() {
+		var i WorkspaceAgentScriptTiming
+		if err := rows.Scan(
+			&i.ScriptID,
+			&i.StartedAt,
+			&i.EndedAt,
+			&i.ExitCode,
+			&i.Stage,
+			&i.Status,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getWorkspaceAgentsByResourceIDs = `-- name: GetWorkspaceAgentsByResourceIDs :many
+SELECT
+	id, created_at, updated_at, name, first_connected_at, last_connected_at, disconnected_at, resource_id, auth_token, auth_instance_id, architecture, environment_variables, operating_system, instance_metadata, resource_metadata, directory, version, last_connected_replica_id, connection_timeout_seconds, troubleshooting_url, motd_file, lifecycle_state, expanded_directory, logs_length, logs_overflowed, started_at, ready_at, subsystems, display_apps, api_version, display_order
+FROM
+	workspace_agents
+WHERE
+	resource_id = ANY($1 :: uuid [ ])
+`
+
+func (q *sqlQuerier) GetWorkspaceAgentsByResourceIDs(ctx context.Context, ids []uuid.UUID) ([]WorkspaceAgent, error) {
 	rows, err := q.db.QueryContext(ctx, getWorkspaceAgentsByResourceIDs, pq.Array(ids))
 	if err != nil {
 		return nil, err
@@ -11802,6 +12865,58 @@ func (q *sqlQuerier) InsertWorkspaceAgentMetadata(ctx context.Context, arg Inser
 	return err
 }
 
+const insertWorkspaceAgentMetadataHistory = `-- name: InsertWorkspaceAgentMetadataHistory :exec
+WITH metadata AS (
+	SELECT
+		unnest($2::text[]) AS key,
+		unnest($3::text[]) AS value,
+		unnest($4::text[]) AS error,
+		unnest($5::timestamptz[]) AS collected_at
+)
+INSERT INTO
+	workspace_agent_metadata_history (
+		workspace_agent_id,
+		key,
+		value,
+		error,
+		collected_at
+	)
+SELECT
+	wam.workspace_agent_id,
+	m.key,
+	m.value,
+	m.error,
+	m.collected_at
+FROM
+	metadata m
+JOIN
+	workspace_agent_metadata wam
+ON
+	wam.workspace_agent_id = $1
+	AND wam.key = m.key
+`
+
+type InsertWorkspaceAgentMetadataHistoryParams struct {
+	WorkspaceAgentID uuid.UUID   `db:"workspace_agent_id" json:"workspace_agent_id"`
+	Key              []string    `db:"key" json:"key"`
+	Value            []string    `db:"value" json:"value"`
+	Error            []string    `db:"error" json:"error"`
+	CollectedAt      []time.Time `db:"collected_at" json:"collected_at"`
+}
+
+// Records a sample for each of the given keys that the agent has metadata
+// for, so an agent can't fill the history with undeclared keys.
+func (q *sqlQuerier) InsertWorkspaceAgentMetadataHistory(ctx context.Context, arg InsertWorkspaceAgentMetadataHistoryParams) error {
+	_, err := q.db.ExecContext(ctx, insertWorkspaceAgentMetadataHistory,
+		arg.WorkspaceAgentID,
+		pq.Array(arg.Key),
+		pq.Array(arg.Value),
+		pq.Array(arg.Error),
+		pq.Array(arg.CollectedAt),
+	)
+	return err
+}
+
 const insertWorkspaceAgentScriptTimings = `-- name: InsertWorkspaceAgentScriptTimings :exec
 INSERT INTO
     workspace_agent_script_timings (
@@ -11989,6 +13104,174 @@ func (q *sqlQuerier) UpdateWorkspaceAgentStartupByID(ctx context.Context, arg Up
 	return err
 }
 
+const getWorkspaceAgentSessionRecordingByID = `-- name: GetWorkspaceAgentSessionRecordingByID :one
+SELECT id, workspace_id, agent_id, session_type, command, started_at, ended_at, size
+FROM workspace_agent_session_recordings
+WHERE id = $1
+`
+
+func (q *sqlQuerier) GetWorkspaceAgentSessionRecordingByID(ctx context.Context, id uuid.UUID) (WorkspaceAgentSessionRecording, error) {
+	row := q.db.QueryRowContext(ctx, getWorkspaceAgentSessionRecordingByID, id)
+	var i WorkspaceAgentSessionRecording
+	err := row.Scan(
+		&i.ID,
+		&i.WorkspaceID,
+		&i.AgentID,
+		&i.SessionType,
+		&i.Command,
+		&i.StartedAt,
+		&i.EndedAt,
+		&i.Size,
+	)
+	return i, err
+}
+
+const getWorkspaceAgentSessionRecordingChunks = `-- name: GetWorkspaceAgentSessionRecordingChunks :many
+SELECT recording_id, sequence, data
+FROM workspace_agent_session_recording_chunks
+WHERE recording_id = $1
+ORDER BY sequence ASC
+`
+
+func (q *sqlQuerier) GetWorkspaceAgentSessionRecordingChunks(ctx context.Context, recordingID uuid.UUID) ([]WorkspaceAgentSessionRecordingChunk, error) {
+	rows, err := q.db.QueryContext(ctx, getWorkspaceAgentSessionRecordingChunks, recordingID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WorkspaceAgentSessionRecordingChunk
+	for rows.Next() {
+		var i WorkspaceAgentSessionRecordingChunk
+		if err := rows.Scan(&i.RecordingID, &i.Sequence, &i.Data); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getWorkspaceAgentSessionRecordingsByWorkspaceID = `-- name: GetWorkspaceAgentSessionRecordingsByWorkspaceID :many
+SELECT id, workspace_id, agent_id, session_type, command, started_at, ended_at, size
+FROM workspace_agent_session_recordings
+WHERE workspace_id = $1
+ORDER BY started_at DESC
+`
+
+func (q *sqlQuerier) GetWorkspaceAgentSessionRecordingsByWorkspaceID(ctx context.Context, workspaceID uuid.UUID) ([]WorkspaceAgentSessionRecording, error) {
+	rows, err := q.db.QueryContext(ctx, getWorkspaceAgentSessionRecordingsByWorkspaceID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WorkspaceAgentSessionRecording
+	for rows.Next() {
+		var i WorkspaceAgentSessionRecording
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.AgentID,
+			&i.SessionType,
+			&i.Command,
+			&i.StartedAt,
+			&i.EndedAt,
+			&i.Size,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const insertWorkspaceAgentSessionRecording = `-- name: InsertWorkspaceAgentSessionRecording :one
+INSERT INTO workspace_agent_session_recordings (id, workspace_id, agent_id, session_type, command, started_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, workspace_id, agent_id, session_type, command, started_at, ended_at, size
+`
+
+type InsertWorkspaceAgentSessionRecordingParams struct {
+	ID          uuid.UUID `db:"id" json:"id"`
+	WorkspaceID uuid.UUID `db:"workspace_id" json:"workspace_id"`
+	AgentID     uuid.UUID `db:"agent_id" json:"agent_id"`
+	SessionType string    `db:"session_type" json:"session_type"`
+	Command     string    `db:"command" json:"command"`
+	StartedAt   time.Time `db:"started_at" json:"started_at"`
+}
+
+func (q *sqlQuerier) InsertWorkspaceAgentSessionRecording(ctx context.Context, arg InsertWorkspaceAgentSessionRecordingParams) (WorkspaceAgentSessionRecording, error) {
+	row := q.db.QueryRowContext(ctx, insertWorkspaceAgentSessionRecording,
+		arg.ID,
+		arg.WorkspaceID,
+		arg.AgentID,
+		arg.SessionType,
+		arg.Command,
+		arg.StartedAt,
+	)
+	var i WorkspaceAgentSessionRecording
+	err := row.Scan(
+		&i.ID,
+		&i.WorkspaceID,
+		&i.AgentID,
+		&i.SessionType,
+		&i.Command,
+		&i.StartedAt,
+		&i.EndedAt,
+		&i.Size,
+	)
+	return i, err
+}
+
+const insertWorkspaceAgentSessionRecordingChunk = `-- name: InsertWorkspaceAgentSessionRecordingChunk :exec
+WITH chunk AS (
+	INSERT INTO workspace_agent_session_recording_chunks (recording_id, sequence, data)
+	VALUES ($1, $2, $3)
+	RETURNING recording_id, length(data) AS size
+)
+UPDATE workspace_agent_session_recordings
+SET size = workspace_agent_session_recordings.size + chunk.size
+FROM chunk
+WHERE workspace_agent_session_recordings.id = chunk.recording_id
+`
+
+type InsertWorkspaceAgentSessionRecordingChunkParams struct {
+	RecordingID uuid.UUID `db:"recording_id" json:"recording_id"`
+	Sequence    int32     `db:"sequence" json:"sequence"`
+	Data        []byte    `db:"data" json:"data"`
+}
+
+func (q *sqlQuerier) InsertWorkspaceAgentSessionRecordingChunk(ctx context.Context, arg InsertWorkspaceAgentSessionRecordingChunkParams) error {
+	_, err := q.db.ExecContext(ctx, insertWorkspaceAgentSessionRecordingChunk, arg.RecordingID, arg.Sequence, arg.Data)
+	return err
+}
+
+const updateWorkspaceAgentSessionRecordingEndedAt = `-- name: UpdateWorkspaceAgentSessionRecordingEndedAt :exec
+UPDATE workspace_agent_session_recordings
+SET ended_at = $2
+WHERE id = $1
+`
+
+type UpdateWorkspaceAgentSessionRecordingEndedAtParams struct {
+	ID      uuid.UUID    `db:"id" json:"id"`
+	EndedAt sql.NullTime `db:"ended_at" json:"ended_at"`
+}
+
+func (q *sqlQuerier) UpdateWorkspaceAgentSessionRecordingEndedAt(ctx context.Context, arg UpdateWorkspaceAgentSessionRecordingEndedAtParams) error {
+	_, err := q.db.ExecContext(ctx, updateWorkspaceAgentSessionRecordingEndedAt, arg.ID, arg.EndedAt)
+	return err
+}
+
 const deleteOldWorkspaceAgentStats = `-- name: DeleteOldWorkspaceAgentStats :exec
 DELETE FROM
 	workspace_agent_stats
@@ -12762,7 +14045,7 @@ func (q *sqlQuerier) InsertWorkspaceAgentStats(ctx context.Context, arg InsertWo
 }
 
 const getWorkspaceAppByAgentIDAndSlug = `-- name: GetWorkspaceAppByAgentIDAndSlug :one
-SELECT id, created_at, agent_id, display_name, icon, command, url, healthcheck_url, healthcheck_interval, healthcheck_threshold, health, subdomain, sharing_level, slug, external, display_order, hidden FROM workspace_apps WHERE agent_id = $1 AND slug = $2
+SELECT id, created_at, agent_id, display_name, icon, command, url, healthcheck_url, healthcheck_interval, healthcheck_threshold, health, subdomain, sharing_level, slug, external, display_order, hidden, healthcheck_kind, healthcheck_expected_status, healthcheck_body_regex, healthcheck_address, healthcheck_grpc_service, healthcheck_command, healthcheck_path FROM workspace_apps WHERE agent_id = $1 AND slug = $2
 `
 
 type GetWorkspaceAppByAgentIDAndSlugParams struct {
@@ -12791,12 +14074,19 @@ func (q *sqlQuerier) GetWorkspaceAppByAgentIDAndSlug(ctx context.Context, arg Ge
 		&i.External,
 		&i.DisplayOrder,
 		&i.Hidden,
+		&i.HealthcheckKind,
+		&i.HealthcheckExpectedStatus,
+		&i.HealthcheckBodyRegex,
+		&i.HealthcheckAddress,
+		&i.HealthcheckGrpcService,
+		&i.HealthcheckCommand,
+		&i.HealthcheckPath,
 	)
 	return i, err
 }
 
 const getWorkspaceAppsByAgentID = `-- name: GetWorkspaceAppsByAgentID :many
-SELECT id, created_at, agent_id, display_name, icon, command, url, healthcheck_url, healthcheck_interval, healthcheck_threshold, health, subdomain, sharing_level, slug, external, display_order, hidden FROM workspace_apps WHERE agent_id = $1 ORDER BY slug ASC
+SELECT id, created_at, agent_id, display_name, icon, command, url, healthcheck_url, healthcheck_interval, healthcheck_threshold, health, subdomain, sharing_level, slug, external, display_order, hidden, healthcheck_kind, healthcheck_expected_status, healthcheck_body_regex, healthcheck_address, healthcheck_grpc_service, healthcheck_command, healthcheck_path FROM workspace_apps WHERE agent_id = $1 ORDER BY slug ASC
 `
 
 func (q *sqlQuerier) GetWorkspaceAppsByAgentID(ctx context.Context, agentID uuid.UUID) ([]WorkspaceApp, error) {
@@ -12826,6 +14116,13 @@ func (q *sqlQuerier) GetWorkspaceAppsByAgentID(ctx context.Context, agentID uuid
 			&i.External,
 			&i.DisplayOrder,
 			&i.Hidden,
+			&i.HealthcheckKind,
+			&i.HealthcheckExpectedStatus,
+			&i.HealthcheckBodyRegex,
+			&i.HealthcheckAddress,
+			&i.HealthcheckGrpcService,
+			&i.HealthcheckCommand,
+			&i.HealthcheckPath,
 		); err != nil {
 			return nil, err
 		}
@@ -12841,7 +14138,7 @@ func (q *sqlQuerier) GetWorkspaceAppsByAgentID(ctx context.Context, agentID uuid
 }
 
 const getWorkspaceAppsByAgentIDs = `-- name: GetWorkspaceAppsByAgentIDs :many
-SELECT id, created_at, agent_id, display_name, icon, command, url, healthcheck_url, healthcheck_interval, healthcheck_threshold, health, subdomain, sharing_level, slug, external, display_order, hidden FROM workspace_apps WHERE agent_id = ANY($1 :: uuid [ ]) ORDER BY slug ASC
+SELECT id, created_at, agent_id, display_name, icon, command, url, healthcheck_url, healthcheck_interval, healthcheck_threshold, health, subdomain, sharing_level, slug, external, display_order, hidden, healthcheck_kind, healthcheck_expected_status, healthcheck_body_regex, healthcheck_address, healthcheck_grpc_service, healthcheck_command, healthcheck_path FROM workspace_apps WHERE agent_id = ANY($1 :: uuid [ ]) ORDER BY slug ASC
 `
 
 func (q *sqlQuerier) GetWorkspaceAppsByAgentIDs(ctx context.Context, ids []uuid.UUID) ([]WorkspaceApp, error) {
@@ -12871,6 +14168,13 @@ func (q *sqlQuerier) GetWorkspaceAppsByAgentIDs(ctx context.Context, ids []uuid.
 			&i.External,
 			&i.DisplayOrder,
 			&i.Hidden,
+			&i.HealthcheckKind,
+			&i.HealthcheckExpectedStatus,
+			&i.HealthcheckBodyRegex,
+			&i.HealthcheckAddress,
+			&i.HealthcheckGrpcService,
+			&i.HealthcheckCommand,
+			&i.HealthcheckPath,
 		); err != nil {
 			return nil, err
 		}
@@ -12886,7 +14190,7 @@ func (q *sqlQuerier) GetWorkspaceAppsByAgentIDs(ctx context.Context, ids []uuid.
 }
 
 const getWorkspaceAppsCreatedAfter = `-- name: GetWorkspaceAppsCreatedAfter :many
-SELECT id, created_at, agent_id, display_name, icon, command, url, healthcheck_url, healthcheck_interval, healthcheck_threshold, health, subdomain, sharing_level, slug, external, display_order, hidden FROM workspace_apps WHERE created_at > $1 ORDER BY slug ASC
+SELECT id, created_at, agent_id, display_name, icon, command, url, healthcheck_url, healthcheck_interval, healthcheck_threshold, health, subdomain, sharing_level, slug, external, display_order, hidden, healthcheck_kind, healthcheck_expected_status, healthcheck_body_regex, healthcheck_address, healthcheck_grpc_service, healthcheck_command, healthcheck_path FROM workspace_apps WHERE created_at > $1 ORDER BY slug ASC
 `
 
 func (q *sqlQuerier) GetWorkspaceAppsCreatedAfter(ctx context.Context, createdAt time.Time) ([]WorkspaceApp, error) {
@@ -12916,6 +14220,13 @@ func (q *sqlQuerier) GetWorkspaceAppsCreatedAfter(ctx context.Context, createdAt
 			&i.External,
 			&i.DisplayOrder,
 			&i.Hidden,
+			&i.HealthcheckKind,
+			&i.HealthcheckExpectedStatus,
+			&i.HealthcheckBodyRegex,
+			&i.HealthcheckAddress,
+			&i.HealthcheckGrpcService,
+			&i.HealthcheckCommand,
+			&i.HealthcheckPath,
 		); err != nil {
 			return nil, err
 		}
@@ -12949,30 +14260,44 @@ INSERT INTO
         healthcheck_threshold,
         health,
         display_order,
-        hidden
+        hidden,
+        healthcheck_kind,
+        healthcheck_expected_status,
+        healthcheck_body_regex,
+        healthcheck_address,
+        healthcheck_grpc_service,
+        healthcheck_command,
+        healthcheck_path
     )
 VALUES
-    ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17) RETURNING id, created_at, agent_id, display_name, icon, command, url, healthcheck_url, healthcheck_interval, healthcheck_threshold, health, subdomain, sharing_level, slug, external, display_order, hidden
+    ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24) RETURNING id, created_at, agent_id, display_name, icon, command, url, healthcheck_url, healthcheck_interval, healthcheck_threshold, health, subdomain, sharing_level, slug, external, display_order, hidden, healthcheck_kind, healthcheck_expected_status, healthcheck_body_regex, healthcheck_address, healthcheck_grpc_service, healthcheck_command, healthcheck_path
 `
 
 type InsertWorkspaceAppParams struct {
-	ID                   uuid.UUID          `db:"id" json:"id"`
-	CreatedAt            time.Time          `db:"created_at" json:"created_at"`
-	AgentID              uuid.UUID          `db:"agent_id" json:"agent_id"`
-	Slug                 string             `db:"slug" json:"slug"`
-	DisplayName          string             `db:"display_name" json:"display_name"`
-	Icon                 string             `db:"icon" json:"icon"`
-	Command              sql.NullString     `db:"command" json:"command"`
-	Url                  sql.NullString     `db:"url" json:"url"`
-	External             bool               `db:"external" json:"external"`
-	Subdomain            bool               `db:"subdomain" json:"subdomain"`
-	SharingLevel         AppSharingLevel    `db:"sharing_level" json:"sharing_level"`
-	HealthcheckUrl       string             `db:"healthcheck_url" json:"healthcheck_url"`
-	HealthcheckInterval  int32              `db:"healthcheck_interval" json:"healthcheck_interval"`
-	HealthcheckThreshold int32              `db:"healthcheck_threshold" json:"healthcheck_threshold"`
-	Health               WorkspaceAppHealth `db:"health" json:"health"`
-	DisplayOrder         int32              `db:"display_order" json:"display_order"`
-	Hidden               bool               `db:"hidden" json:"hidden"`
+	ID                        uuid.UUID                   `db:"id" json:"id"`
+	CreatedAt                 time.Time                   `db:"created_at" json:"created_at"`
+	AgentID                   uuid.UUID                   `db:"agent_id" json:"agent_id"`
+	Slug                      string                      `db:"slug" json:"slug"`
+	DisplayName               string                      `db:"display_name" json:"display_name"`
+	Icon                      string                      `db:"icon" json:"icon"`
+	Command                   sql.NullString              `db:"command" json:"command"`
+	Url                       sql.NullString              `db:"url" json:"url"`
+	External                  bool                        `db:"external" json:"external"`
+	Subdomain                 bool                        `db:"subdomain" json:"subdomain"`
+	SharingLevel              AppSharingLevel             `db:"sharing_level" json:"sharing_level"`
+	HealthcheckUrl            string                      `db:"healthcheck_url" json:"healthcheck_url"`
+	HealthcheckInterval       int32                       `db:"healthcheck_interval" json:"healthcheck_interval"`
+	HealthcheckThreshold      int32                       `db:"healthcheck_threshold" json:"healthcheck_threshold"`
+	Health                    WorkspaceAppHealth          `db:"health" json:"health"`
+	DisplayOrder              int32                       `db:"display_order" json:"display_order"`
+	Hidden                    bool                        `db:"hidden" json:"hidden"`
+	HealthcheckKind           WorkspaceAppHealthcheckKind `db:"healthcheck_kind" json:"healthcheck_kind"`
+	HealthcheckExpectedStatus int32                       `db:"healthcheck_expected_status" json:"healthcheck_expected_status"`
+	HealthcheckBodyRegex      string                      `db:"healthcheck_body_regex" json:"healthcheck_body_regex"`
+	HealthcheckAddress        string                      `db:"healthcheck_address" json:"healthcheck_address"`
+	HealthcheckGrpcService    string                      `db:"healthcheck_grpc_service" json:"healthcheck_grpc_service"`
+	HealthcheckCommand        string                      `db:"healthcheck_command" json:"healthcheck_command"`
+	HealthcheckPath           string                      `db:"healthcheck_path" json:"healthcheck_path"`
 }
 
 func (q *sqlQuerier) InsertWorkspaceApp(ctx context.Context, arg InsertWorkspaceAppParams) (WorkspaceApp, error) {
@@ -12994,6 +14319,13 @@ func (q *sqlQuerier) InsertWorkspaceApp(ctx context.Context, arg InsertWorkspace
 		arg.Health,
 		arg.DisplayOrder,
 		arg.Hidden,
+		arg.HealthcheckKind,
+		arg.HealthcheckExpectedStatus,
+		arg.HealthcheckBodyRegex,
+		arg.HealthcheckAddress,
+		arg.HealthcheckGrpcService,
+		arg.HealthcheckCommand,
+		arg.HealthcheckPath,
 	)
 	var i WorkspaceApp
 	err := row.Scan(
@@ -13014,6 +14346,13 @@ func (q *sqlQuerier) InsertWorkspaceApp(ctx context.Context, arg InsertWorkspace
 		&i.External,
 		&i.DisplayOrder,
 		&i.Hidden,
+		&i.HealthcheckKind,
+		&i.HealthcheckExpectedStatus,
+		&i.HealthcheckBodyRegex,
+		&i.HealthcheckAddress,
+		&i.HealthcheckGrpcService,
+		&i.HealthcheckCommand,
+		&i.HealthcheckPath,
 	)
 	return i, err
 }
@@ -13218,7 +14557,7 @@ func (q *sqlQuerier) InsertWorkspaceBuildParameters(ctx context.Context, arg Ins
 }
 
 const getActiveWorkspaceBuildsByTemplateID = `-- name: GetActiveWorkspaceBuildsByTemplateID :many
-SELECT wb.id, wb.created_at, wb.updated_at, wb.workspace_id, wb.template_version_id, wb.build_number, wb.transition, wb.initiator_id, wb.provisioner_state, wb.job_id, wb.deadline, wb.reason, wb.daily_cost, wb.max_deadline, wb.initiator_by_avatar_url, wb.initiator_by_username
+SELECT wb.id, wb.created_at, wb.updated_at, wb.workspace_id, wb.template_version_id, wb.build_number, wb.transition, wb.initiator_id, wb.provisioner_state, wb.job_id, wb.deadline, wb.reason, wb.daily_cost, wb.max_deadline, wb.provisioner_state_ref, wb.initiator_by_avatar_url, wb.initiator_by_username
 FROM (
     SELECT
         workspace_id, MAX(build_number) as max_build_number
@@ -13272,6 +14611,7 @@ func (q *sqlQuerier) GetActiveWorkspaceBuildsByTemplateID(ctx context.Context, t
 			&i.Reason,
 			&i.DailyCost,
 			&i.MaxDeadline,
+			&i.ProvisionerStateRef,
 			&i.InitiatorByAvatarUrl,
 			&i.InitiatorByUsername,
 		); err != nil {
@@ -13367,7 +14707,7 @@ func (q *sqlQuerier) GetFailedWorkspaceBuildsByTemplateID(ctx context.Context, a
 
 const getLatestWorkspaceBuildByWorkspaceID = `-- name: GetLatestWorkspaceBuildByWorkspaceID :one
 SELECT
-	id, created_at, updated_at, workspace_id, template_version_id, build_number, transition, initiator_id, provisioner_state, job_id, deadline, reason, daily_cost, max_deadline, initiator_by_avatar_url, initiator_by_username
+	id, created_at, updated_at, workspace_id, template_version_id, build_number, transition, initiator_id, provisioner_state, job_id, deadline, reason, daily_cost, max_deadline, provisioner_state_ref, initiator_by_avatar_url, initiator_by_username
 FROM
 	workspace_build_with_user AS workspace_builds
 WHERE
@@ -13396,6 +14736,7 @@ func (q *sqlQuerier) GetLatestWorkspaceBuildByWorkspaceID(ctx context.Context, w
 		&i.Reason,
 		&i.DailyCost,
 		&i.MaxDeadline,
+		&i.ProvisionerStateRef,
 		&i.InitiatorByAvatarUrl,
 		&i.InitiatorByUsername,
 	)
@@ -13403,7 +14744,7 @@ func (q *sqlQuerier) GetLatestWorkspaceBuildByWorkspaceID(ctx context.Context, w
 }
 
 const getLatestWorkspaceBuilds = `-- name: GetLatestWorkspaceBuilds :many
-SELECT wb.id, wb.created_at, wb.updated_at, wb.workspace_id, wb.template_version_id, wb.build_number, wb.transition, wb.initiator_id, wb.provisioner_state, wb.job_id, wb.deadline, wb.reason, wb.daily_cost, wb.max_deadline, wb.initiator_by_avatar_url, wb.initiator_by_username
+SELECT wb.id, wb.created_at, wb.updated_at, wb.workspace_id, wb.template_version_id, wb.build_number, wb.transition, wb.initiator_id, wb.provisioner_state, wb.job_id, wb.deadline, wb.reason, wb.daily_cost, wb.max_deadline, wb.provisioner_state_ref, wb.initiator_by_avatar_url, wb.initiator_by_username
 FROM (
     SELECT
         workspace_id, MAX(build_number) as max_build_number
@@ -13441,6 +14782,7 @@ func (q *sqlQuerier) GetLatestWorkspaceBuilds(ctx context.Context) ([]WorkspaceB
 			&i.Reason,
 			&i.DailyCost,
 			&i.MaxDeadline,
+			&i.ProvisionerStateRef,
 			&i.InitiatorByAvatarUrl,
 			&i.InitiatorByUsername,
 		); err != nil {
@@ -13458,7 +14800,7 @@ func (q *sqlQuerier) GetLatestWorkspaceBuilds(ctx context.Context) ([]WorkspaceB
 }
 
 const getLatestWorkspaceBuildsByWorkspaceIDs = `-- name: GetLatestWorkspaceBuildsByWorkspaceIDs :many
-SELECT wb.id, wb.created_at, wb.updated_at, wb.workspace_id, wb.template_version_id, wb.build_number, wb.transition, wb.initiator_id, wb.provisioner_state, wb.job_id, wb.deadline, wb.reason, wb.daily_cost, wb.max_deadline, wb.initiator_by_avatar_url, wb.initiator_by_username
+SELECT wb.id, wb.created_at, wb.updated_at, wb.workspace_id, wb.template_version_id, wb.build_number, wb.transition, wb.initiator_id, wb.provisioner_state, wb.job_id, wb.deadline, wb.reason, wb.daily_cost, wb.max_deadline, wb.provisioner_state_ref, wb.initiator_by_avatar_url, wb.initiator_by_username
 FROM (
     SELECT
         workspace_id, MAX(build_number) as max_build_number
@@ -13498,6 +14840,7 @@ func (q *sqlQuerier) GetLatestWorkspaceBuildsByWorkspaceIDs(ctx context.Context,
 			&i.Reason,
 			&i.DailyCost,
 			&i.MaxDeadline,
+			&i.ProvisionerStateRef,
 			&i.InitiatorByAvatarUrl,
 			&i.InitiatorByUsername,
 		); err != nil {
@@ -13516,7 +14859,7 @@ func (q *sqlQuerier) GetLatestWorkspaceBuildsByWorkspaceIDs(ctx context.Context,
 
 const getWorkspaceBuildByID = `-- name: GetWorkspaceBuildByID :one
 SELECT
-	id, created_at, updated_at, workspace_id, template_version_id, build_number, transition, initiator_id, provisioner_state, job_id, deadline, reason, daily_cost, max_deadline, initiator_by_avatar_url, initiator_by_username
+	id, created_at, updated_at, workspace_id, template_version_id, build_number, transition, initiator_id, provisioner_state, job_id, deadline, reason, daily_cost, max_deadline, provisioner_state_ref, initiator_by_avatar_url, initiator_by_username
 FROM
 	workspace_build_with_user AS workspace_builds
 WHERE
@@ -13543,6 +14886,7 @@ func (q *sqlQuerier) GetWorkspaceBuildByID(ctx context.Context, id uuid.UUID) (W
 		&i.Reason,
 		&i.DailyCost,
 		&i.MaxDeadline,
+		&i.ProvisionerStateRef,
 		&i.InitiatorByAvatarUrl,
 		&i.InitiatorByUsername,
 	)
@@ -13551,7 +14895,7 @@ func (q *sqlQuerier) GetWorkspaceBuildByID(ctx context.Context, id uuid.UUID) (W
 
 const getWorkspaceBuildByJobID = `-- name: GetWorkspaceBuildByJobID :one
 SELECT
-	id, created_at, updated_at, workspace_id, template_version_id, build_number, transition, initiator_id, provisioner_state, job_id, deadline, reason, daily_cost, max_deadline, initiator_by_avatar_url, initiator_by_username
+	id, created_at, updated_at, workspace_id, template_version_id, build_number, transition, initiator_id, provisioner_state, job_id, deadline, reason, daily_cost, max_deadline, provisioner_state_ref, initiator_by_avatar_url, initiator_by_username
 FROM
 	workspace_build_with_user AS workspace_builds
 WHERE
@@ -13578,6 +14922,7 @@ func (q *sqlQuerier) GetWorkspaceBuildByJobID(ctx context.Context, jobID uuid.UU
 		&i.Reason,
 		&i.DailyCost,
 		&i.MaxDeadline,
+		&i.ProvisionerStateRef,
 		&i.InitiatorByAvatarUrl,
 		&i.InitiatorByUsername,
 	)
@@ -13586,7 +14931,7 @@ func (q *sqlQuerier) GetWorkspaceBuildByJobID(ctx context.Context, jobID uuid.UU
 
 const getWorkspaceBuildByWorkspaceIDAndBuildNumber = `-- name: GetWorkspaceBuildByWorkspaceIDAndBuildNumber :one
 SELECT
-	id, created_at, updated_at, workspace_id, template_version_id, build_number, transition, initiator_id, provisioner_state, job_id, deadline, reason, daily_cost, max_deadline, initiator_by_avatar_url, initiator_by_username
+	id, created_at, updated_at, workspace_id, template_version_id, build_number, transition, initiator_id, provisioner_state, job_id, deadline, reason, daily_cost, max_deadline, provisioner_state_ref, initiator_by_avatar_url, initiator_by_username
 FROM
 	workspace_build_with_user AS workspace_builds
 WHERE
@@ -13617,6 +14962,7 @@ func (q *sqlQuerier) GetWorkspaceBuildByWorkspaceIDAndBuildNumber(ctx context.Co
 		&i.Reason,
 		&i.DailyCost,
 		&i.MaxDeadline,
+		&i.ProvisionerStateRef,
 		&i.InitiatorByAvatarUrl,
 		&i.InitiatorByUsername,
 	)
@@ -13692,7 +15038,7 @@ func (q *sqlQuerier) GetWorkspaceBuildStatsByTemplates(ctx context.Context, sinc
 
 const getWorkspaceBuildsByWorkspaceID = `-- name: GetWorkspaceBuildsByWorkspaceID :many
 SELECT
-	id, created_at, updated_at, workspace_id, template_version_id, build_number, transition, initiator_id, provisioner_state, job_id, deadline, reason, daily_cost, max_deadline, initiator_by_avatar_url, initiator_by_username
+	id, created_at, updated_at, workspace_id, template_version_id, build_number, transition, initiator_id, provisioner_state, job_id, deadline, reason, daily_cost, max_deadline, provisioner_state_ref, initiator_by_avatar_url, initiator_by_username
 FROM
 	workspace_build_with_user AS workspace_builds
 WHERE
@@ -13762,6 +15108,7 @@ func (q *sqlQuerier) GetWorkspaceBuildsByWorkspaceID(ctx context.Context, arg Ge
 			&i.Reason,
 			&i.DailyCost,
 			&i.MaxDeadline,
+			&i.ProvisionerStateRef,
 			&i.InitiatorByAvatarUrl,
 			&i.InitiatorByUsername,
 		); err != nil {
@@ -13779,7 +15126,7 @@ func (q *sqlQuerier) GetWorkspaceBuildsByWorkspaceID(ctx context.Context, arg Ge
 }
 
 const getWorkspaceBuildsCreatedAfter = `-- name: GetWorkspaceBuildsCreatedAfter :many
-SELECT id, created_at, updated_at, workspace_id, template_version_id, build_number, transition, initiator_id, provisioner_state, job_id, deadline, reason, daily_cost, max_deadline, initiator_by_avatar_url, initiator_by_username FROM workspace_build_with_user WHERE created_at > $1
+SELECT id, created_at, updated_at, workspace_id, template_version_id, build_number, transition, initiator_id, provisioner_state, job_id, deadline, reason, daily_cost, max_deadline, provisioner_state_ref, initiator_by_avatar_url, initiator_by_username FROM workspace_build_with_user WHERE created_at > $1
 `
 
 func (q *sqlQuerier) GetWorkspaceBuildsCreatedAfter(ctx context.Context, createdAt time.Time) ([]WorkspaceBuild, error) {
@@ -13806,6 +15153,73 @@ func (q *sqlQuerier) GetWorkspaceBuildsCreatedAfter(ctx context.Context, created
 			&i.Reason,
 			&i.DailyCost,
 			&i.MaxDeadline,
+			&i.ProvisionerStateRef,
+			&i.InitiatorByAvatarUrl,
+			&i.InitiatorByUsername,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getWorkspaceBuildsToMigrateProvisionerState = `-- name: GetWorkspaceBuildsToMigrateProvisionerState :many
+SELECT
+	id, created_at, updated_at, workspace_id, template_version_id, build_number, transition, initiator_id, provisioner_state, job_id, deadline, reason, daily_cost, max_deadline, provisioner_state_ref, initiator_by_avatar_url, initiator_by_username
+FROM
+	workspace_build_with_user
+WHERE
+	id > $1::uuid
+	AND CASE
+		WHEN $2::boolean THEN provisioner_state_ref != ''
+		ELSE provisioner_state_ref = '' AND length(provisioner_state) > 0
+	END
+ORDER BY
+	id
+LIMIT
+	$3::int
+`
+
+type GetWorkspaceBuildsToMigrateProvisionerStateParams struct {
+	AfterID      uuid.UUID `db:"after_id" json:"after_id"`
+	InStateStore bool      `db:"in_state_store" json:"in_state_store"`
+	LimitCount   int32     `db:"limit_count" json:"limit_count"`
+}
+
+// Returns a page of the builds whose provisioner state is stored in the
+// database, or in the state store if @in_state_store is set, ordered by ID.
+func (q *sqlQuerier) GetWorkspaceBuildsToMigrateProvisionerState(ctx context.Context, arg GetWorkspaceBuildsToMigrateProvisionerStateParams) ([]WorkspaceBuild, error) {
+	rows, err := q.db.QueryContext(ctx, getWorkspaceBuildsToMigrateProvisionerState, arg.AfterID, arg.InStateStore, arg.LimitCount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WorkspaceBuild
+	for rows.Next() {
+		var i WorkspaceBuild
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.WorkspaceID,
+			&i.TemplateVersionID,
+			&i.BuildNumber,
+			&i.Transition,
+			&i.InitiatorID,
+			&i.ProvisionerState,
+			&i.JobID,
+			&i.Deadline,
+			&i.Reason,
+			&i.DailyCost,
+			&i.MaxDeadline,
+			&i.ProvisionerStateRef,
 			&i.InitiatorByAvatarUrl,
 			&i.InitiatorByUsername,
 		); err != nil {
@@ -13822,126 +15236,432 @@ func (q *sqlQuerier) GetWorkspaceBuildsCreatedAfter(ctx context.Context, created
 	return items, nil
 }
 
-const insertWorkspaceBuild = `-- name: InsertWorkspaceBuild :exec
-INSERT INTO
-	workspace_builds (
-		id,
-		created_at,
-		updated_at,
-		workspace_id,
-		template_version_id,
-		"build_number",
-		transition,
-		initiator_id,
-		job_id,
-		provisioner_state,
-		deadline,
-		max_deadline,
-		reason
-	)
-VALUES
-	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+const insertWorkspaceBuild = `-- name: InsertWorkspaceBuild :exec
+INSERT INTO
+	workspace_builds (
+		id,
+		created_at,
+		updated_at,
+		workspace_id,
+		template_version_id,
+		"build_number",
+		transition,
+		initiator_id,
+		job_id,
+		provisioner_state,
+		deadline,
+		max_deadline,
+		reason,
+		provisioner_state_ref
+	)
+VALUES
+	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+`
+
+type InsertWorkspaceBuildParams struct {
+	ID                  uuid.UUID           `db:"id" json:"id"`
+	CreatedAt           time.Time           `db:"created_at" json:"created_at"`
+	UpdatedAt           time.Time           `db:"updated_at" json:"updated_at"`
+	WorkspaceID         uuid.UUID           `db:"workspace_id" json:"workspace_id"`
+	TemplateVersionID   uuid.UUID           `db:"template_version_id" json:"template_version_id"`
+	BuildNumber         int32               `db:"build_number" json:"build_number"`
+	Transition          WorkspaceTransition `db:"transition" json:"transition"`
+	InitiatorID         uuid.UUID           `db:"initiator_id" json:"initiator_id"`
+	JobID               uuid.UUID           `db:"job_id" json:"job_id"`
+	ProvisionerState    []byte              `db:"provisioner_state" json:"provisioner_state"`
+	Deadline            time.Time           `db:"deadline" json:"deadline"`
+	MaxDeadline         time.Time           `db:"max_deadline" json:"max_deadline"`
+	Reason              BuildReason         `db:"reason" json:"reason"`
+	ProvisionerStateRef string              `db:"provisioner_state_ref" json:"provisioner_state_ref"`
+}
+
+func (q *sqlQuerier) InsertWorkspaceBuild(ctx context.Context, arg InsertWorkspaceBuildParams) error {
+	_, err := q.db.ExecContext(ctx, insertWorkspaceBuild,
+		arg.ID,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+		arg.WorkspaceID,
+		arg.TemplateVersionID,
+		arg.BuildNumber,
+		arg.Transition,
+		arg.InitiatorID,
+		arg.JobID,
+		arg.ProvisionerState,
+		arg.Deadline,
+		arg.MaxDeadline,
+		arg.Reason,
+		arg.ProvisionerStateRef,
+	)
+	return err
+}
+
+const updateWorkspaceBuildCostByID = `-- name: UpdateWorkspaceBuildCostByID :exec
+UPDATE
+	workspace_builds
+SET
+	daily_cost = $2
+WHERE
+	id = $1
+`
+
+type UpdateWorkspaceBuildCostByIDParams struct {
+	ID        uuid.UUID `db:"id" json:"id"`
+	DailyCost int32     `db:"daily_cost" json:"daily_cost"`
+}
+
+func (q *sqlQuerier) UpdateWorkspaceBuildCostByID(ctx context.Context, arg UpdateWorkspaceBuildCostByIDParams) error {
+	_, err := q.db.ExecContext(ctx, updateWorkspaceBuildCostByID, arg.ID, arg.DailyCost)
+	return err
+}
+
+const updateWorkspaceBuildDeadlineByID = `-- name: UpdateWorkspaceBuildDeadlineByID :exec
+UPDATE
+	workspace_builds
+SET
+	deadline = $1::timestamptz,
+	max_deadline = $2::timestamptz,
+	updated_at = $3::timestamptz
+WHERE id = $4::uuid
+`
+
+type UpdateWorkspaceBuildDeadlineByIDParams struct {
+	Deadline    time.Time `db:"deadline" json:"deadline"`
+	MaxDeadline time.Time `db:"max_deadline" json:"max_deadline"`
+	UpdatedAt   time.Time `db:"updated_at" json:"updated_at"`
+	ID          uuid.UUID `db:"id" json:"id"`
+}
+
+func (q *sqlQuerier) UpdateWorkspaceBuildDeadlineByID(ctx context.Context, arg UpdateWorkspaceBuildDeadlineByIDParams) error {
+	_, err := q.db.ExecContext(ctx, updateWorkspaceBuildDeadlineByID,
+		arg.Deadline,
+		arg.MaxDeadline,
+		arg.UpdatedAt,
+		arg.ID,
+	)
+	return err
+}
+
+const updateWorkspaceBuildProvisionerStateByID = `-- name: UpdateWorkspaceBuildProvisionerStateByID :exec
+UPDATE
+	workspace_builds
+SET
+	provisioner_state = $1::bytea,
+	provisioner_state_ref = $2::text,
+	updated_at = $3::timestamptz
+WHERE id = $4::uuid
+`
+
+type UpdateWorkspaceBuildProvisionerStateByIDParams struct {
+	ProvisionerState    []byte    `db:"provisioner_state" json:"provisioner_state"`
+	ProvisionerStateRef string    `db:"provisioner_state_ref" json:"provisioner_state_ref"`
+	UpdatedAt           time.Time `db:"updated_at" json:"updated_at"`
+	ID                  uuid.UUID `db:"id" json:"id"`
+}
+
+func (q *sqlQuerier) UpdateWorkspaceBuildProvisionerStateByID(ctx context.Context, arg UpdateWorkspaceBuildProvisionerStateByIDParams) error {
+	_, err := q.db.ExecContext(ctx, updateWorkspaceBuildProvisionerStateByID,
+		arg.ProvisionerState,
+		arg.ProvisionerStateRef,
+		arg.UpdatedAt,
+		arg.ID,
+	)
+	return err
+}
+
+const updateWorkspaceBuildProvisionerStateLocationByID = `-- name: UpdateWorkspaceBuildProvisionerStateLocationByID :execrows
+UPDATE
+	workspace_builds
+SET
+	provisioner_state = $1::bytea,
+	provisioner_state_ref = $2::text
+WHERE
+	id = $3::uuid
+	AND updated_at = $4::timestamptz
+`
+
+type UpdateWorkspaceBuildProvisionerStateLocationByIDParams struct {
+	ProvisionerState    []byte    `db:"provisioner_state" json:"provisioner_state"`
+	ProvisionerStateRef string    `db:"provisioner_state_ref" json:"provisioner_state_ref"`
+	ID                  uuid.UUID `db:"id" json:"id"`
+	UpdatedAt           time.Time `db:"updated_at" json:"updated_at"`
+}
+
+// Moves the provisioner state of a build between the database and the state
+// store. The build is only updated if it hasn't changed since it was read, so
+// that a state written by a concurrent build is never replaced. updated_at is
+// left as is, since the state itself doesn't change.
+func (q *sqlQuerier) UpdateWorkspaceBuildProvisionerStateLocationByID(ctx context.Context, arg UpdateWorkspaceBuildProvisionerStateLocationByIDParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateWorkspaceBuildProvisionerStateLocationByID,
+		arg.ProvisionerState,
+		arg.ProvisionerStateRef,
+		arg.ID,
+		arg.UpdatedAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getInProgressWorkspaceDriftCheckCountsByTemplate = `-- name: GetInProgressWorkspaceDriftCheckCountsByTemplate :many
+SELECT
+	workspaces.template_id,
+	COUNT(*) AS count
+FROM
+	workspace_drift_checks
+INNER JOIN
+	workspaces ON workspaces.id = workspace_drift_checks.workspace_id
+INNER JOIN
+	provisioner_jobs ON provisioner_jobs.id = workspace_drift_checks.job_id
+WHERE
+	provisioner_jobs.completed_at IS NULL
+GROUP BY
+	workspaces.template_id
+`
+
+type GetInProgressWorkspaceDriftCheckCountsByTemplateRow struct {
+	TemplateID uuid.UUID `db:"template_id" json:"template_id"`
+	Count      int64     `db:"count" json:"count"`
+}
+
+func (q *sqlQuerier) GetInProgressWorkspaceDriftCheckCountsByTemplate(ctx context.Context) ([]GetInProgressWorkspaceDriftCheckCountsByTemplateRow, error) {
+	rows, err := q.db.QueryContext(ctx, getInProgressWorkspaceDriftCheckCountsByTemplate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetInProgressWorkspaceDriftCheckCountsByTemplateRow
+	for rows.Next() {
+		var i GetInProgressWorkspaceDriftCheckCountsByTemplateRow
+		if err := rows.Scan(&i.TemplateID, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getWorkspaceDriftedResourcesByWorkspaceIDs = `-- name: GetWorkspaceDriftedResourcesByWorkspaceIDs :many
+SELECT
+	latest_checks.workspace_id,
+	provisioner_job_resource_changes.job_id,
+	provisioner_job_resource_changes.address,
+	provisioner_job_resource_changes.type,
+	provisioner_job_resource_changes.name,
+	provisioner_job_resource_changes.action,
+	provisioner_job_resource_changes.attributes
+FROM (
+	SELECT DISTINCT ON (workspace_drift_checks.workspace_id)
+		workspace_drift_checks.workspace_id,
+		workspace_drift_checks.workspace_build_id,
+		workspace_drift_checks.job_id
+	FROM
+		workspace_drift_checks
+	INNER JOIN
+		provisioner_jobs ON provisioner_jobs.id = workspace_drift_checks.job_id
+	WHERE
+		workspace_drift_checks.workspace_id = ANY($1 :: uuid [ ])
+		AND provisioner_jobs.job_status = 'succeeded'::provisioner_job_status
+	ORDER BY
+		workspace_drift_checks.workspace_id, workspace_drift_checks.created_at DESC
+) AS latest_checks
+INNER JOIN
+	provisioner_job_resource_changes ON provisioner_job_resource_changes.job_id = latest_checks.job_id
+WHERE
+	latest_checks.workspace_build_id = (
+		SELECT
+			id
+		FROM
+			workspace_builds
+		WHERE
+			workspace_builds.workspace_id = latest_checks.workspace_id
+		ORDER BY
+			build_number DESC
+		LIMIT
+			1
+	)
+ORDER BY
+	latest_checks.workspace_id, provisioner_job_resource_changes.address
+`
+
+type GetWorkspaceDriftedResourcesByWorkspaceIDsRow struct {
+	WorkspaceID uuid.UUID            `db:"workspace_id" json:"workspace_id"`
+	JobID       uuid.UUID            `db:"job_id" json:"job_id"`
+	Address     string               `db:"address" json:"address"`
+	Type        string               `db:"type" json:"type"`
+	Name        string               `db:"name" json:"name"`
+	Action      ResourceChangeAction `db:"action" json:"action"`
+	Attributes  json.RawMessage      `db:"attributes" json:"attributes"`
+}
+
+// Returns the resources that the latest successful drift check of each
+// workspace found to have drifted, as long as it checked the latest build of
+// the workspace. Drift found in the state of an earlier build is stale.
+func (q *sqlQuerier) GetWorkspaceDriftedResourcesByWorkspaceIDs(ctx context.Context, ids []uuid.UUID) ([]GetWorkspaceDriftedResourcesByWorkspaceIDsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getWorkspaceDriftedResourcesByWorkspaceIDs, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetWorkspaceDriftedResourcesByWorkspaceIDsRow
+	for rows.Next() {
+		var i GetWorkspaceDriftedResourcesByWorkspaceIDsRow
+		if err := rows.Scan(
+			&i.WorkspaceID,
+			&i.JobID,
+			&i.Address,
+			&i.Type,
+			&i.Name,
+			&i.Action,
+			&i.Attributes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getWorkspacesEligibleForDriftCheck = `-- name: GetWorkspacesEligibleForDriftCheck :many
+SELECT
+	workspaces.id AS workspace_id,
+	workspaces.owner_id,
+	workspaces.template_id,
+	workspace_builds.id AS workspace_build_id,
+	provisioner_jobs.organization_id,
+	provisioner_jobs.provisioner,
+	provisioner_jobs.storage_method,
+	provisioner_jobs.file_id,
+	provisioner_jobs.tags
+FROM
+	workspaces
+INNER JOIN
+	workspace_builds ON workspace_builds.workspace_id = workspaces.id
+INNER JOIN
+	provisioner_jobs ON provisioner_jobs.id = workspace_builds.job_id
+WHERE
+	workspaces.deleted = false
+	AND workspaces.dormant_at IS NULL
+	AND workspace_builds.build_number = (
+		SELECT
+			MAX(build_number)
+		FROM
+			workspace_builds
+		WHERE
+			workspace_builds.workspace_id = workspaces.id
+	)
+	AND workspace_builds.transition = 'start'::workspace_transition
+	AND provisioner_jobs.job_status = 'succeeded'::provisioner_job_status
+	AND NOT EXISTS (
+		SELECT
+			1
+		FROM
+			workspace_drift_checks
+		INNER JOIN
+			provisioner_jobs AS check_jobs ON check_jobs.id = workspace_drift_checks.job_id
+		WHERE
+			workspace_drift_checks.workspace_id = workspaces.id
+			AND (
+				workspace_drift_checks.created_at > $1 :: timestamptz
+				OR check_jobs.completed_at IS NULL
+			)
+	)
+ORDER BY
+	workspace_builds.created_at ASC
+`
+
+type GetWorkspacesEligibleForDriftCheckRow struct {
+	WorkspaceID      uuid.UUID                `db:"workspace_id" json:"workspace_id"`
+	OwnerID          uuid.UUID                `db:"owner_id" json:"owner_id"`
+	TemplateID       uuid.UUID                `db:"template_id" json:"template_id"`
+	WorkspaceBuildID uuid.UUID                `db:"workspace_build_id" json:"workspace_build_id"`
+	OrganizationID   uuid.UUID                `db:"organization_id" json:"organization_id"`
+	Provisioner      ProvisionerType          `db:"provisioner" json:"provisioner"`
+	StorageMethod    ProvisionerStorageMethod `db:"storage_method" json:"storage_method"`
+	FileID           uuid.UUID                `db:"file_id" json:"file_id"`
+	Tags             StringMap                `db:"tags" json:"tags"`
+}
+
+// Returns the running workspaces whose infrastructure is due to be checked for
+// drift: the latest build successfully started them, and they haven't been
+// checked since @checked_before and aren't being checked. The provisioner job
+// of the build is returned, so the check can run on the same provisioners.
+func (q *sqlQuerier) GetWorkspacesEligibleForDriftCheck(ctx context.Context, checkedBefore time.Time) ([]GetWorkspacesEligibleForDriftCheckRow, error) {
+	rows, err := q.db.QueryContext(ctx, getWorkspacesEligibleForDriftCheck, checkedBefore)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetWorkspacesEligibleForDriftCheckRow
+	for rows.Next() {
+		var i GetWorkspacesEligibleForDriftCheckRow
+		if err := rows.Scan(
+			&i.WorkspaceID,
+			&i.OwnerID,
+			&i.TemplateID,
+			&i.WorkspaceBuildID,
+			&i.OrganizationID,
+			&i.Provisioner,
+			&i.StorageMethod,
+			&i.FileID,
+			&i.Tags,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const insertWorkspaceDriftCheck = `-- name: InsertWorkspaceDriftCheck :one
+INSERT INTO workspace_drift_checks (id, workspace_id, workspace_build_id, job_id, created_at)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, workspace_id, workspace_build_id, job_id, created_at
 `
 
-type InsertWorkspaceBuildParams struct {
-	ID                uuid.UUID           `db:"id" json:"id"`
-	CreatedAt         time.Time           `db:"created_at" json:"created_at"`
-	UpdatedAt         time.Time           `db:"updated_at" json:"updated_at"`
-	WorkspaceID       uuid.UUID           `db:"workspace_id" json:"workspace_id"`
-	TemplateVersionID uuid.UUID           `db:"template_version_id" json:"template_version_id"`
-	BuildNumber       int32               `db:"build_number" json:"build_number"`
-	Transition        WorkspaceTransition `db:"transition" json:"transition"`
-	InitiatorID       uuid.UUID           `db:"initiator_id" json:"initiator_id"`
-	JobID             uuid.UUID           `db:"job_id" json:"job_id"`
-	ProvisionerState  []byte              `db:"provisioner_state" json:"provisioner_state"`
-	Deadline          time.Time           `db:"deadline" json:"deadline"`
-	MaxDeadline       time.Time           `db:"max_deadline" json:"max_deadline"`
-	Reason            BuildReason         `db:"reason" json:"reason"`
+type InsertWorkspaceDriftCheckParams struct {
+	ID               uuid.UUID `db:"id" json:"id"`
+	WorkspaceID      uuid.UUID `db:"workspace_id" json:"workspace_id"`
+	WorkspaceBuildID uuid.UUID `db:"workspace_build_id" json:"workspace_build_id"`
+	JobID            uuid.UUID `db:"job_id" json:"job_id"`
+	CreatedAt        time.Time `db:"created_at" json:"created_at"`
 }
 
-func (q *sqlQuerier) InsertWorkspaceBuild(ctx context.Context, arg InsertWorkspaceBuildParams) error {
-	_, err := q.db.ExecContext(ctx, insertWorkspaceBuild,
+func (q *sqlQuerier) InsertWorkspaceDriftCheck(ctx context.Context, arg InsertWorkspaceDriftCheckParams) (WorkspaceDriftCheck, error) {
+	row := q.db.QueryRowContext(ctx, insertWorkspaceDriftCheck,
 		arg.ID,
-		arg.CreatedAt,
-		arg.UpdatedAt,
 		arg.WorkspaceID,
-		arg.TemplateVersionID,
-		arg.BuildNumber,
-		arg.Transition,
-		arg.InitiatorID,
+		arg.WorkspaceBuildID,
 		arg.JobID,
-		arg.ProvisionerState,
-		arg.Deadline,
-		arg.MaxDeadline,
-		arg.Reason,
+		arg.CreatedAt,
 	)
-	return err
-}
-
-const updateWorkspaceBuildCostByID = `-- name: UpdateWorkspaceBuildCostByID :exec
-UPDATE
-	workspace_builds
-SET
-	daily_cost = $2
-WHERE
-	id = $1
-`
-
-type UpdateWorkspaceBuildCostByIDParams struct {
-	ID        uuid.UUID `db:"id" json:"id"`
-	DailyCost int32     `db:"daily_cost" json:"daily_cost"`
-}
-
-func (q *sqlQuerier) UpdateWorkspaceBuildCostByID(ctx context.Context, arg UpdateWorkspaceBuildCostByIDParams) error {
-	_, err := q.db.ExecContext(ctx, updateWorkspaceBuildCostByID, arg.ID, arg.DailyCost)
-	return err
-}
-
-const updateWorkspaceBuildDeadlineByID = `-- name: UpdateWorkspaceBuildDeadlineByID :exec
-UPDATE
-	workspace_builds
-SET
-	deadline = $1::timestamptz,
-	max_deadline = $2::timestamptz,
-	updated_at = $3::timestamptz
-WHERE id = $4::uuid
-`
-
-type UpdateWorkspaceBuildDeadlineByIDParams struct {
-	Deadline    time.Time `db:"deadline" json:"deadline"`
-	MaxDeadline time.Time `db:"max_deadline" json:"max_deadline"`
-	UpdatedAt   time.Time `db:"updated_at" json:"updated_at"`
-	ID          uuid.UUID `db:"id" json:"id"`
-}
-
-func (q *sqlQuerier) UpdateWorkspaceBuildDeadlineByID(ctx context.Context, arg UpdateWorkspaceBuildDeadlineByIDParams) error {
-	_, err := q.db.ExecContext(ctx, updateWorkspaceBuildDeadlineByID,
-		arg.Deadline,
-		arg.MaxDeadline,
-		arg.UpdatedAt,
-		arg.ID,
+	var i WorkspaceDriftCheck
+	err := row.Scan(
+		&i.ID,
+		&i.WorkspaceID,
+		&i.WorkspaceBuildID,
+		&i.JobID,
+		&i.CreatedAt,
 	)
-	return err
-}
-
-const updateWorkspaceBuildProvisionerStateByID = `-- name: UpdateWorkspaceBuildProvisionerStateByID :exec
-UPDATE
-	workspace_builds
-SET
-	provisioner_state = $1::bytea,
-	updated_at = $2::timestamptz
-WHERE id = $3::uuid
-`
-
-type UpdateWorkspaceBuildProvisionerStateByIDParams struct {
-	ProvisionerState []byte    `db:"provisioner_state" json:"provisioner_state"`
-	UpdatedAt        time.Time `db:"updated_at" json:"updated_at"`
-	ID               uuid.UUID `db:"id" json:"id"`
-}
-
-func (q *sqlQuerier) UpdateWorkspaceBuildProvisionerStateByID(ctx context.Context, arg UpdateWorkspaceBuildProvisionerStateByIDParams) error {
-	_, err := q.db.ExecContext(ctx, updateWorkspaceBuildProvisionerStateByID, arg.ProvisionerState, arg.UpdatedAt, arg.ID)
-	return err
+	return i, err
 }
 
 const getWorkspaceResourceByID = `-- name: GetWorkspaceResourceByID :one
@@ -14386,7 +16106,8 @@ func (q *sqlQuerier) GetDeploymentWorkspaceStats(ctx context.Context) (GetDeploy
 const getWorkspaceByAgentID = `-- name: GetWorkspaceByAgentID :one
 SELECT
 	workspaces.id, workspaces.created_at, workspaces.updated_at, workspaces.owner_id, workspaces.organization_id, workspaces.template_id, workspaces.deleted, workspaces.name, workspaces.autostart_schedule, workspaces.ttl, workspaces.last_used_at, workspaces.dormant_at, workspaces.deleting_at, workspaces.automatic_updates, workspaces.favorite,
-	templates.name as template_name
+	templates.name as template_name,
+	templates.agent_activity_bump as template_agent_activity_bump
 FROM
 	workspaces
 INNER JOIN
@@ -14417,8 +16138,9 @@ WHERE
 `
 
 type GetWorkspaceByAgentIDRow struct {
-	Workspace    Workspace `db:"workspace" json:"workspace"`
-	TemplateName string    `db:"template_name" json:"template_name"`
+	Workspace                 Workspace `db:"workspace" json:"workspace"`
+	TemplateName              string    `db:"template_name" json:"template_name"`
+	TemplateAgentActivityBump bool      `db:"template_agent_activity_bump" json:"template_agent_activity_bump"`
 }
 
 func (q *sqlQuerier) GetWorkspaceByAgentID(ctx context.Context, agentID uuid.UUID) (GetWorkspaceByAgentIDRow, error) {
@@ -14441,6 +16163,7 @@ func (q *sqlQuerier) GetWorkspaceByAgentID(ctx context.Context, agentID uuid.UUI
 		&i.Workspace.AutomaticUpdates,
 		&i.Workspace.Favorite,
 		&i.TemplateName,
+		&i.TemplateAgentActivityBump,
 	)
 	return i, err
 }
@@ -14648,7 +16371,10 @@ LEFT JOIN LATERAL (
 		workspace_builds.id,
 		workspace_builds.transition,
 		workspace_builds.template_version_id,
+		workspace_builds.initiator_id,
+		workspace_builds.reason,
 		template_versions.name AS template_version_name,
+		template_versions.created_at AS template_version_created_at,
 		provisioner_jobs.id AS provisioner_job_id,
 		provisioner_jobs.started_at,
 		provisioner_jobs.updated_at,
@@ -14675,7 +16401,7 @@ LEFT JOIN LATERAL (
 ) latest_build ON TRUE
 LEFT JOIN LATERAL (
 	SELECT
-		id, created_at, updated_at, organization_id, deleted, name, provisioner, active_version_id, description, default_ttl, created_by, icon, user_acl, group_acl, display_name, allow_user_cancel_workspace_jobs, allow_user_autostart, allow_user_autostop, failure_ttl, time_til_dormant, time_til_dormant_autodelete, autostop_requirement_days_of_week, autostop_requirement_weeks, autostart_block_days_of_week, require_active_version, deprecated, activity_bump, max_port_sharing_level
+		id, created_at, updated_at, organization_id, deleted, name, provisioner, active_version_id, description, default_ttl, created_by, icon, user_acl, group_acl, display_name, allow_user_cancel_workspace_jobs, allow_user_autostart, allow_user_autostop, failure_ttl, time_til_dormant, time_til_dormant_autodelete, autostop_requirement_days_of_week, autostop_requirement_weeks, autostart_block_days_of_week, require_active_version, deprecated, activity_bump, max_port_sharing_level, session_recording, port_forwarding_policy, agent_activity_bump
 	FROM
 		templates
 	WHERE
@@ -14865,6 +16591,118 @@ WHERE
 			  (latest_build.template_version_id = template.active_version_id) = $18 :: boolean
 		  ELSE true
 	END
+	-- Filter by created_at
+	AND CASE
+		  WHEN $19 :: timestamp with time zone > '0001-01-01 00:00:00Z' THEN
+				  workspaces.created_at <= $19
+		  ELSE true
+	END
+	AND CASE
+		  WHEN $20 :: timestamp with time zone > '0001-01-01 00:00:00Z' THEN
+				  workspaces.created_at >= $20
+		  ELSE true
+	END
+	-- Filter by search terms, which are used for negated terms, terms combined
+	-- with OR, and keys that have no dedicated filter above. The 4 arrays
+	-- describe one term per index. Terms in the same group are ORed together,
+	-- and every group must match.
+	AND NOT EXISTS (
+		SELECT
+			1
+		FROM
+			unnest($21 :: integer[], $22 :: text[], $23 :: text[], $24 :: boolean[]) AS term(grp, key, value, negated)
+		GROUP BY
+			term.grp
+		HAVING
+			NOT bool_or(COALESCE(
+				CASE term.key
+					WHEN 'owner' THEN
+						lower(users.username) = lower(term.value)
+					WHEN 'template' THEN
+						lower(template.name) = lower(term.value)
+					WHEN 'template_version' THEN
+						lower(latest_build.template_version_name) = lower(term.value)
+					-- Versions are compared by when they were created, against
+					-- the version of the given name of the workspace's template.
+					WHEN 'template_version_lt' THEN
+						latest_build.template_version_created_at < (SELECT created_at FROM template_versions WHERE template_id = workspaces.template_id AND lower(name) = lower(term.value))
+					WHEN 'template_version_lte' THEN
+						latest_build.template_version_created_at <= (SELECT created_at FROM template_versions WHERE template_id = workspaces.template_id AND lower(name) = lower(term.value))
+					WHEN 'template_version_gt' THEN
+						latest_build.template_version_created_at > (SELECT created_at FROM template_versions WHERE template_id = workspaces.template_id AND lower(name) = lower(term.value))
+					WHEN 'template_version_gte' THEN
+						latest_build.template_version_created_at >= (SELECT created_at FROM template_versions WHERE template_id = workspaces.template_id AND lower(name) = lower(term.value))
+					WHEN 'name' THEN
+						workspaces.name ILIKE '%' || term.value || '%'
+					WHEN 'initiator' THEN
+						latest_build.initiator_id = (SELECT id FROM users WHERE lower(username) = lower(term.value) AND deleted = false)
+					WHEN 'build_reason' THEN
+						latest_build.reason :: text = term.value
+					-- This must match the status filter above.
+					WHEN 'status' THEN
+						CASE term.value
+							WHEN 'starting' THEN
+								latest_build.job_status = 'running'::provisioner_job_status AND
+								latest_build.transition = 'start'::workspace_transition
+							WHEN 'stopping' THEN
+								latest_build.job_status = 'running'::provisioner_job_status AND
+								latest_build.transition = 'stop'::workspace_transition
+							WHEN 'deleting' THEN
+								latest_build.job_status = 'running'::provisioner_job_status AND
+								latest_build.transition = 'delete'::workspace_transition
+							WHEN 'deleted' THEN
+								latest_build.job_status = 'succeeded'::provisioner_job_status AND
+								latest_build.transition = 'delete'::workspace_transition
+							WHEN 'stopped' THEN
+								latest_build.job_status = 'succeeded'::provisioner_job_status AND
+								latest_build.transition = 'stop'::workspace_transition
+							WHEN 'started' THEN
+								latest_build.job_status = 'succeeded'::provisioner_job_status AND
+								latest_build.transition = 'start'::workspace_transition
+							WHEN 'running' THEN
+								latest_build.job_status = 'succeeded'::provisioner_job_status AND
+								latest_build.transition = 'start'::workspace_transition
+							ELSE
+								latest_build.job_status :: text = term.value
+						END
+					-- This must match the has_agent filter above.
+					WHEN 'agent_status' THEN
+						EXISTS (
+							SELECT
+								1
+							FROM
+								workspace_resources
+							JOIN
+								workspace_agents
+							ON
+								workspace_agents.resource_id = workspace_resources.id
+							WHERE
+								workspace_resources.job_id = latest_build.provisioner_job_id AND
+								latest_build.transition = 'start'::workspace_transition AND
+								term.value = (
+									CASE
+										WHEN workspace_agents.first_connected_at IS NULL THEN
+											CASE
+												WHEN workspace_agents.connection_timeout_seconds > 0 AND NOW() - workspace_agents.created_at > workspace_agents.connection_timeout_seconds * INTERVAL '1 second' THEN
+													'timeout'
+												ELSE
+													'connecting'
+											END
+										WHEN workspace_agents.disconnected_at > workspace_agents.last_connected_at THEN
+											'disconnected'
+										WHEN NOW() - workspace_agents.last_connected_at > INTERVAL '1 second' * $14 :: bigint THEN
+											'disconnected'
+										WHEN workspace_agents.last_connected_at IS NOT NULL THEN
+											'connected'
+										ELSE
+											NULL
+									END
+								)
+						)
+					ELSE
+						false
+				END, false) != term.negated)
+	)
 	-- Authorize Filter clause will be injected below in GetAuthorizedWorkspaces
 	-- @authorize_filter
 ), filtered_workspaces_order AS (
@@ -14874,7 +16712,7 @@ WHERE
 		filtered_workspaces fw
 	ORDER BY
 		-- To ensure that 'favorite' workspaces show up first in the list only for their owner.
-		CASE WHEN owner_id = $19 AND favorite THEN 0 ELSE 1 END ASC,
+		CASE WHEN owner_id = $25 AND favorite THEN 0 ELSE 1 END ASC,
 		(latest_build_completed_at IS NOT NULL AND
 			latest_build_canceled_at IS NULL AND
 			latest_build_error IS NULL AND
@@ -14883,11 +16721,11 @@ WHERE
 		LOWER(name) ASC
 	LIMIT
 		CASE
-			WHEN $21 :: integer > 0 THEN
-				$21
+			WHEN $27 :: integer > 0 THEN
+				$27
 		END
 	OFFSET
-		$20
+		$26
 ), filtered_workspaces_order_with_summary AS (
 	SELECT
 		fwo.id, fwo.created_at, fwo.updated_at, fwo.owner_id, fwo.organization_id, fwo.template_id, fwo.deleted, fwo.name, fwo.autostart_schedule, fwo.ttl, fwo.last_used_at, fwo.dormant_at, fwo.deleting_at, fwo.automatic_updates, fwo.favorite, fwo.template_name, fwo.template_version_id, fwo.template_version_name, fwo.username, fwo.latest_build_completed_at, fwo.latest_build_canceled_at, fwo.latest_build_error, fwo.latest_build_transition, fwo.latest_build_status
@@ -14923,7 +16761,7 @@ WHERE
 		'start'::workspace_transition, -- latest_build_transition
 		'unknown'::provisioner_job_status -- latest_build_status
 	WHERE
-		$22 :: boolean = true
+		$28 :: boolean = true
 ), total_count AS (
 	SELECT
 		count(*) AS count
@@ -14958,6 +16796,12 @@ type GetWorkspacesParams struct {
 	LastUsedBefore                        time.Time    `db:"last_used_before" json:"last_used_before"`
 	LastUsedAfter                         time.Time    `db:"last_used_after" json:"last_used_after"`
 	UsingActive                           sql.NullBool `db:"using_active" json:"using_active"`
+	CreatedBefore                         time.Time    `db:"created_before" json:"created_before"`
+	CreatedAfter                          time.Time    `db:"created_after" json:"created_after"`
+	TermGroups                            []int32      `db:"term_groups" json:"term_groups"`
+	TermKeys                              []string     `db:"term_keys" json:"term_keys"`
+	TermValues                            []string     `db:"term_values" json:"term_values"`
+	TermNegated                           []bool       `db:"term_negated" json:"term_negated"`
 	RequesterID                           uuid.UUID    `db:"requester_id" json:"requester_id"`
 	Offset                                int32        `db:"offset_" json:"offset_"`
 	Limit                                 int32        `db:"limit_" json:"limit_"`
@@ -15015,6 +16859,12 @@ func (q *sqlQuerier) GetWorkspaces(ctx context.Context, arg GetWorkspacesParams)
 		arg.LastUsedBefore,
 		arg.LastUsedAfter,
 		arg.UsingActive,
+		arg.CreatedBefore,
+		arg.CreatedAfter,
+		pq.Array(arg.TermGroups),
+		pq.Array(arg.TermKeys),
+		pq.Array(arg.TermValues),
+		pq.Array(arg.TermNegated),
 		arg.RequesterID,
 		arg.Offset,
 		arg.Limit,
@@ -15248,6 +17098,19 @@ func (q *sqlQuerier) InsertWorkspace(ctx context.Context, arg InsertWorkspacePar
 	return i, err
 }
 
+const lockWorkspaceByID = `-- name: LockWorkspaceByID :exec
+UPDATE workspaces SET updated_at = updated_at WHERE id = $1
+`
+
+// Updates the row of the workspace without changing it, so transactions that
+// queue jobs on the workspace conflict with each other. A repeatable read
+// transaction fails with a serialization error if a concurrent transaction
+// locked the workspace after it began.
+func (q *sqlQuerier) LockWorkspaceByID(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, lockWorkspaceByID, id)
+	return err
+}
+
 const unfavoriteWorkspace = `-- name: UnfavoriteWorkspace :exec
 UPDATE workspaces SET favorite = false WHERE id = $1
 `
@@ -15648,3 +17511,184 @@ func (q *sqlQuerier) InsertWorkspaceAgentScripts(ctx context.Context, arg Insert
 	}
 	return items, nil
 }
+
+const deleteWorkspaceSnapshotByID = `-- name: DeleteWorkspaceSnapshotByID :exec
+DELETE FROM workspace_snapshots
+WHERE id = $1
+`
+
+func (q *sqlQuerier) DeleteWorkspaceSnapshotByID(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteWorkspaceSnapshotByID, id)
+	return err
+}
+
+const getActiveWorkspaceSnapshotJobsByWorkspaceID = `-- name: GetActiveWorkspaceSnapshotJobsByWorkspaceID :many
+SELECT provisioner_jobs.id, provisioner_jobs.created_at, provisioner_jobs.updated_at, provisioner_jobs.started_at, provisioner_jobs.canceled_at, provisioner_jobs.completed_at, provisioner_jobs.error, provisioner_jobs.organization_id, provisioner_jobs.initiator_id, provisioner_jobs.provisioner, provisioner_jobs.storage_method, provisioner_jobs.type, provisioner_jobs.input, provisioner_jobs.worker_id, provisioner_jobs.file_id, provisioner_jobs.tags, provisioner_jobs.error_code, provisioner_jobs.trace_metadata, provisioner_jobs.job_status
+FROM provisioner_jobs
+JOIN workspace_builds ON workspace_builds.id = (provisioner_jobs.input ->> 'workspace_build_id')::uuid
+WHERE provisioner_jobs.type = 'workspace_snapshot'::provisioner_job_type
+	AND workspace_builds.workspace_id = $1
+	AND provisioner_jobs.job_status IN ('pending', 'running', 'canceling')
+`
+
+// Returns the jobs that create or restore a snapshot of the workspace and
+// haven't completed yet.
+func (q *sqlQuerier) GetActiveWorkspaceSnapshotJobsByWorkspaceID(ctx context.Context, workspaceID uuid.UUID) ([]ProvisionerJob, error) {
+	rows, err := q.db.QueryContext(ctx, getActiveWorkspaceSnapshotJobsByWorkspaceID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ProvisionerJob
+	for rows.Next() {
+		var i ProvisionerJob
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.StartedAt,
+			&i.CanceledAt,
+			&i.CompletedAt,
+			&i.Error,
+			&i.OrganizationID,
+			&i.InitiatorID,
+			&i.Provisioner,
+			&i.StorageMethod,
+			&i.Type,
+			&i.Input,
+			&i.WorkerID,
+			&i.FileID,
+			&i.Tags,
+			&i.ErrorCode,
+			&i.TraceMetadata,
+			&i.JobStatus,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getWorkspaceSnapshotByID = `-- name: GetWorkspaceSnapshotByID :one
+SELECT id, workspace_id, workspace_build_id, job_id, name, data, created_by, created_at
+FROM workspace_snapshots
+WHERE id = $1
+`
+
+func (q *sqlQuerier) GetWorkspaceSnapshotByID(ctx context.Context, id uuid.UUID) (WorkspaceSnapshot, error) {
+	row := q.db.QueryRowContext(ctx, getWorkspaceSnapshotByID, id)
+	var i WorkspaceSnapshot
+	err := row.Scan(
+		&i.ID,
+		&i.WorkspaceID,
+		&i.WorkspaceBuildID,
+		&i.JobID,
+		&i.Name,
+		&i.Data,
+		&i.CreatedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getWorkspaceSnapshotsByWorkspaceID = `-- name: GetWorkspaceSnapshotsByWorkspaceID :many
+SELECT id, workspace_id, workspace_build_id, job_id, name, data, created_by, created_at
+FROM workspace_snapshots
+WHERE workspace_id = $1
+ORDER BY created_at DESC
+`
+
+func (q *sqlQuerier) GetWorkspaceSnapshotsByWorkspaceID(ctx context.Context, workspaceID uuid.UUID) ([]WorkspaceSnapshot, error) {
+	rows, err := q.db.QueryContext(ctx, getWorkspaceSnapshotsByWorkspaceID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WorkspaceSnapshot
+	for rows.Next() {
+		var i WorkspaceSnapshot
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.WorkspaceBuildID,
+			&i.JobID,
+			&i.Name,
+			&i.Data,
+			&i.CreatedBy,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const insertWorkspaceSnapshot = `-- name: InsertWorkspaceSnapshot :one
+INSERT INTO workspace_snapshots (id, workspace_id, workspace_build_id, job_id, name, created_by, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, workspace_id, workspace_build_id, job_id, name, data, created_by, created_at
+`
+
+type InsertWorkspaceSnapshotParams struct {
+	ID               uuid.UUID `db:"id" json:"id"`
+	WorkspaceID      uuid.UUID `db:"workspace_id" json:"workspace_id"`
+	WorkspaceBuildID uuid.UUID `db:"workspace_build_id" json:"workspace_build_id"`
+	JobID            uuid.UUID `db:"job_id" json:"job_id"`
+	Name             string    `db:"name" json:"name"`
+	CreatedBy        uuid.UUID `db:"created_by" json:"created_by"`
+	CreatedAt        time.Time `db:"created_at" json:"created_at"`
+}
+
+func (q *sqlQuerier) InsertWorkspaceSnapshot(ctx context.Context, arg InsertWorkspaceSnapshotParams) (WorkspaceSnapshot, error) {
+	row := q.db.QueryRowContext(ctx, insertWorkspaceSnapshot,
+		arg.ID,
+		arg.WorkspaceID,
+		arg.WorkspaceBuildID,
+		arg.JobID,
+		arg.Name,
+		arg.CreatedBy,
+		arg.CreatedAt,
+	)
+	var i WorkspaceSnapshot
+	err := row.Scan(
+		&i.ID,
+		&i.WorkspaceID,
+		&i.WorkspaceBuildID,
+		&i.JobID,
+		&i.Name,
+		&i.Data,
+		&i.CreatedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const updateWorkspaceSnapshotDataByID = `-- name: UpdateWorkspaceSnapshotDataByID :exec
+UPDATE workspace_snapshots
+SET data = $2
+WHERE id = $1
+`
+
+type UpdateWorkspaceSnapshotDataByIDParams struct {
+	ID   uuid.UUID `db:"id" json:"id"`
+	Data []byte    `db:"data" json:"data"`
+}
+
+func (q *sqlQuerier) UpdateWorkspaceSnapshotDataByID(ctx context.Context, arg UpdateWorkspaceSnapshotDataByIDParams) error {
+	_, err := q.db.ExecContext(ctx, updateWorkspaceSnapshotDataByID, arg.ID, arg.Data)
+	return err
+}
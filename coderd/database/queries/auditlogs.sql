@@ -151,3 +151,27 @@ INSERT INTO
     )
 VALUES
 	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15) RETURNING *;
+
+-- name: GetAuditLogsBefore :many
+-- Used by dbpurge to archive audit logs which have passed the retention
+-- window. They are returned oldest first, so that retrying a purge whose
+-- transaction was rolled back archives the same batches again.
+SELECT
+    sqlc.embed(audit_logs),
+    users.username AS user_username,
+    users.email AS user_email
+FROM
+    audit_logs
+    LEFT JOIN users ON audit_logs.user_id = users.id
+WHERE
+    audit_logs."time" < @before
+ORDER BY
+    audit_logs."time" ASC,
+    audit_logs.id ASC
+LIMIT
+    @limit_opt;
+
+-- name: DeleteAuditLogsByIDs :exec
+-- Used by dbpurge to delete audit logs once they have passed the retention
+-- window and been archived.
+DELETE FROM audit_logs WHERE id = ANY(@ids :: uuid[]);
@@ -4,17 +4,21 @@ SELECT nt.name                                                    AS notificatio
        nt.id                                                      AS notification_template_id,
        nt.actions                                                 AS actions,
        nt.method                                                  AS custom_method,
+       np.method                                                  AS user_method,
        u.id                                                       AS user_id,
        u.email                                                    AS user_email,
        COALESCE(NULLIF(u.name, ''), NULLIF(u.username, ''))::text AS user_name,
-       u.username                                                 AS user_username
-FROM notification_templates nt,
-     users u
+       u.username                                                 AS user_username,
+       nt.digest_template_id                                      AS digest_template_id
+FROM notification_templates nt
+         CROSS JOIN users u
+         LEFT JOIN notification_preferences AS np
+                   ON (np.user_id = u.id AND np.notification_template_id = nt.id)
 WHERE nt.id = @notification_template_id
   AND u.id = @user_id;
 
 -- name: EnqueueNotificationMessage :exec
-INSERT INTO notification_messages (id, notification_template_id, user_id, method, payload, targets, created_by, created_at)
+INSERT INTO notification_messages (id, notification_template_id, user_id, method, payload, targets, created_by, created_at, held_until, digest_template_id)
 VALUES (@id,
         @notification_template_id,
         @user_id,
@@ -22,7 +26,35 @@ VALUES (@id,
         @payload::jsonb,
         @targets,
         @created_by,
-        @created_at);
+        @created_at,
+        sqlc.narg('held_until')::timestamptz,
+        sqlc.narg('digest_template_id')::uuid);
+
+-- Returns the messages awaiting a digest whose digest window has ended, ordered so that the messages which are delivered
+-- together are adjacent.
+-- name: GetNotificationMessagesAwaitingDigest :many
+SELECT *
+FROM notification_messages
+WHERE digest_template_id IS NOT NULL
+  AND status = 'pending'::notification_message_status
+  AND (held_until IS NULL OR held_until < NOW())
+ORDER BY user_id, notification_template_id, created_at;
+
+-- Releases messages awaiting a digest to be dispatched individually, e.g. when no other message joined them.
+-- name: ReleaseNotificationMessagesFromDigest :exec
+UPDATE notification_messages
+SET digest_template_id = NULL,
+    updated_at         = NOW()
+WHERE id = ANY (@ids::uuid[]);
+
+-- Marks messages awaiting a digest as delivered in the given digest message.
+-- name: MarkNotificationMessagesDigested :exec
+UPDATE notification_messages
+SET digest_template_id = NULL,
+    status             = 'inhibited'::notification_message_status,
+    status_reason      = 'Delivered in digest ' || @digest_message_id::uuid,
+    updated_at         = NOW()
+WHERE id = ANY (@ids::uuid[]);
 
 -- Acquires the lease for a given count of notification messages, to enable concurrent dequeuing and subsequent sending.
 -- Only rows that aren't already leased (or ones which are leased but have exceeded their lease period) are returned.
@@ -39,7 +71,7 @@ VALUES (@id,
 WITH acquired AS (
     UPDATE
         notification_messages
-            SET queued_seconds = GREATEST(0, EXTRACT(EPOCH FROM (NOW() - updated_at)))::FLOAT,
+            SET queued_seconds = GREATEST(0, EXTRACT(EPOCH FROM (NOW() - GREATEST(updated_at, held_until))))::FLOAT,
                 updated_at = NOW(),
                 status = 'leased'::notification_message_status,
                 status_reason = 'Leased by notifier ' || sqlc.arg('notifier_id')::uuid,
@@ -71,6 +103,10 @@ WITH acquired AS (
                                  ELSE true
                                  END
                              )
+                           -- messages held back by the user's quiet hours or a digest window are not released until the window ends
+                           AND (nm.held_until IS NULL OR nm.held_until < NOW())
+                           -- messages awaiting a digest are delivered by the digest generator
+                           AND nm.digest_template_id IS NULL
                          ORDER BY nm.created_at ASC
                                   -- Ensure that multiple concurrent readers cannot retrieve the same rows
                              FOR UPDATE OF nm
@@ -107,11 +143,12 @@ SET queued_seconds   = 0,
     leased_until     = NULL,
     next_retry_after = CASE
                            WHEN (attempt_count + 1 < @max_attempts::int)
-                               THEN NOW() + CONCAT(@retry_interval::int, ' seconds')::interval END
+                               THEN NOW() + CONCAT(GREATEST(@retry_interval::int, subquery.retry_after), ' seconds')::interval END
 FROM (SELECT UNNEST(@ids::uuid[])                             AS id,
              UNNEST(@failed_ats::timestamptz[])               AS failed_at,
              UNNEST(@statuses::notification_message_status[]) AS status,
-             UNNEST(@status_reasons::text[])                  AS status_reason) AS subquery
+             UNNEST(@status_reasons::text[])                  AS status_reason,
+             UNNEST(@retry_afters::int[])                     AS retry_after) AS subquery
 WHERE notification_messages.id = subquery.id;
 
 -- name: BulkMarkNotificationMessagesSent :execrows
@@ -149,15 +186,42 @@ FROM notification_preferences
 WHERE user_id = @user_id::uuid;
 
 -- name: UpdateUserNotificationPreferences :execrows
+-- An empty method defers to the template or deployment default.
 INSERT
-INTO notification_preferences (user_id, notification_template_id, disabled)
-SELECT @user_id::uuid, new_values.notification_template_id, new_values.disabled
+INTO notification_preferences (user_id, notification_template_id, disabled, method)
+SELECT @user_id::uuid,
+       new_values.notification_template_id,
+       new_values.disabled,
+       NULLIF(new_values.method, '')::notification_method
 FROM (SELECT UNNEST(@notification_template_ids::uuid[]) AS notification_template_id,
-             UNNEST(@disableds::bool[])                 AS disabled) AS new_values
+             UNNEST(@disableds::bool[])                 AS disabled,
+             UNNEST(@methods::text[])                   AS method) AS new_values
 ON CONFLICT (user_id, notification_template_id) DO UPDATE
     SET disabled   = EXCLUDED.disabled,
+        method     = EXCLUDED.method,
         updated_at = CURRENT_TIMESTAMP;
 
+-- name: GetUserNotificationQuietHours :one
+SELECT *
+FROM notification_quiet_hours
+WHERE user_id = @user_id::uuid;
+
+-- name: UpsertUserNotificationQuietHours :one
+INSERT
+INTO notification_quiet_hours (user_id, start_minute, end_minute, timezone)
+VALUES (@user_id::uuid, @start_minute::int, @end_minute::int, @timezone::text)
+ON CONFLICT (user_id) DO UPDATE
+    SET start_minute = EXCLUDED.start_minute,
+        end_minute   = EXCLUDED.end_minute,
+        timezone     = EXCLUDED.timezone,
+        updated_at   = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: DeleteUserNotificationQuietHours :exec
+DELETE
+FROM notification_quiet_hours
+WHERE user_id = @user_id::uuid;
+
 -- name: UpdateNotificationTemplateMethodByID :one
 UPDATE notification_templates
 SET method = sqlc.narg('method')::notification_method
@@ -0,0 +1,63 @@
+-- name: InsertInboxNotification :execrows
+-- Inbox notifications share the ID of the message from which they were dispatched, so a message which is dispatched
+-- more than once (e.g. after its lease expires) is only stored once.
+INSERT INTO inbox_notifications (id, user_id, template_id, title, content, actions, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (id) DO NOTHING;
+
+-- name: GetInboxNotificationByID :one
+SELECT *
+FROM inbox_notifications
+WHERE id = $1;
+
+-- name: GetInboxNotificationsByUserID :many
+SELECT *
+FROM inbox_notifications
+WHERE user_id = @user_id
+	AND CASE
+		WHEN @read_status :: text = 'read' THEN read_at IS NOT NULL
+		WHEN @read_status :: text = 'unread' THEN read_at IS NULL
+		ELSE true
+	END
+	AND (@include_archived :: boolean OR archived_at IS NULL)
+	AND CASE
+		-- The pagination cursor is the last ID of the previous page.
+		WHEN @after_id :: uuid != '00000000-0000-0000-0000-000000000000'::uuid THEN (
+			(created_at, id) < (
+				SELECT
+					created_at, id
+				FROM
+					inbox_notifications
+				WHERE
+					id = @after_id
+			)
+		)
+		ELSE true
+	END
+ORDER BY
+	-- Newest first; the ID breaks ties between notifications created at the same time.
+	created_at DESC, id DESC
+OFFSET @offset_opt
+LIMIT
+	-- A null limit means "no limit", so 0 means return all
+	NULLIF(@limit_opt :: int, 0);
+
+-- name: CountUnreadInboxNotificationsByUserID :one
+SELECT COUNT(*)
+FROM inbox_notifications
+WHERE user_id = $1
+	AND read_at IS NULL
+	AND archived_at IS NULL;
+
+-- name: UpdateInboxNotificationStatus :one
+UPDATE inbox_notifications
+SET read_at     = @read_at,
+	archived_at = @archived_at
+WHERE id = @id
+RETURNING *;
+
+-- name: MarkAllInboxNotificationsAsRead :execrows
+UPDATE inbox_notifications
+SET read_at = @read_at
+WHERE user_id = @user_id
+	AND read_at IS NULL;
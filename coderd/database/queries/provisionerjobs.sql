@@ -22,14 +22,22 @@ WHERE
 			AND nested.organization_id = @organization_id
 			-- Ensure the caller has the correct provisioner.
 			AND nested.provisioner = ANY(@types :: provisioner_type [ ])
+			-- The IaC requirement tags of the job are matched separately below.
 			AND CASE
 				-- Special case for untagged provisioners: only match untagged jobs.
-				WHEN nested.tags :: jsonb = '{"scope": "organization", "owner": ""}' :: jsonb
-				THEN nested.tags :: jsonb = @tags :: jsonb
+				WHEN nested.tags :: jsonb - 'coder.iac.engine' - 'coder.iac.version' = '{"scope": "organization", "owner": ""}' :: jsonb
+				THEN nested.tags :: jsonb - 'coder.iac.engine' - 'coder.iac.version' = @tags :: jsonb
 				-- Ensure the caller satisfies all job tags.
-				ELSE nested.tags :: jsonb <@ @tags :: jsonb
+				ELSE nested.tags :: jsonb - 'coder.iac.engine' - 'coder.iac.version' <@ @tags :: jsonb
 			END
+			-- Ensure the caller has a binary that satisfies the IaC requirement of the job.
+			AND (
+				nested.tags ->> 'coder.iac.engine' IS NULL
+				OR (nested.tags ->> 'coder.iac.engine') || ' ' || COALESCE(nested.tags ->> 'coder.iac.version', '') = ANY(@iac_requirements :: text [ ])
+			)
 		ORDER BY
+			-- Drift checks are low priority, so they don't delay builds.
+			nested.type = 'workspace_drift_check' :: provisioner_job_type,
 			nested.created_at
 		FOR UPDATE
 		SKIP LOCKED
@@ -37,6 +45,19 @@ WHERE
 			1
 	) RETURNING *;
 
+-- name: GetPendingProvisionerJobIaCRequirements :many
+-- Returns the distinct IaC requirements of the pending jobs of an
+-- organization, formatted as the engine followed by the version constraint, so
+-- that a provisioner daemon can work out which of them its binaries satisfy.
+SELECT DISTINCT
+	((tags ->> 'coder.iac.engine') || ' ' || COALESCE(tags ->> 'coder.iac.version', '')) :: text AS requirement
+FROM
+	provisioner_jobs
+WHERE
+	started_at IS NULL
+	AND organization_id = @organization_id
+	AND tags ->> 'coder.iac.engine' IS NOT NULL;
+
 -- name: GetProvisionerJobByID :one
 SELECT
 	*
@@ -160,4 +181,13 @@ RETURNING *;
 -- name: GetProvisionerJobTimingsByJobID :many
 SELECT * FROM provisioner_job_timings
 WHERE job_id = $1
-ORDER BY started_at ASC;
\ No newline at end of file
+ORDER BY started_at ASC;
+-- name: InsertProvisionerJobResourceChange :one
+INSERT INTO provisioner_job_resource_changes (job_id, address, type, name, action, attributes)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetProvisionerJobResourceChangesByJobID :many
+SELECT * FROM provisioner_job_resource_changes
+WHERE job_id = $1
+ORDER BY address ASC;
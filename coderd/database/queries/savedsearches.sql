@@ -0,0 +1,45 @@
+-- name: InsertSavedSearch :one
+INSERT INTO saved_searches (id, user_id, resource_type, name, query, organization_id, group_id, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING *;
+
+-- name: GetSavedSearchByID :one
+SELECT *
+FROM saved_searches
+WHERE id = $1;
+
+-- name: GetSavedSearches :many
+-- Returns the searches saved by the user, followed by the searches other users
+-- have shared with them.
+SELECT *
+FROM saved_searches
+WHERE CASE
+		WHEN @resource_type :: text != '' THEN
+			resource_type = @resource_type :: saved_search_resource_type
+		ELSE true
+	END
+	AND (
+		user_id = @user_id
+		-- Searches shared with a whole organization are keyed by the
+		-- organization's "Everyone" group, which shares the organization's ID.
+		OR COALESCE(group_id, organization_id) IN (
+			SELECT group_id
+			FROM group_members_expanded
+			WHERE group_members_expanded.user_id = @user_id
+		)
+	)
+ORDER BY user_id != @user_id, LOWER(name), id;
+
+-- name: UpdateSavedSearch :one
+UPDATE saved_searches
+SET name            = @name,
+	query           = @query,
+	organization_id = @organization_id,
+	group_id        = @group_id,
+	updated_at      = @updated_at
+WHERE id = @id
+RETURNING *;
+
+-- name: DeleteSavedSearchByID :exec
+DELETE FROM saved_searches
+WHERE id = $1;
@@ -124,7 +124,10 @@ SET
 	display_name = $6,
 	allow_user_cancel_workspace_jobs = $7,
 	group_acl = $8,
-	max_port_sharing_level = $9
+	max_port_sharing_level = $9,
+	session_recording = $10,
+	port_forwarding_policy = $11,
+	agent_activity_bump = $12
 WHERE
 	id = $1
 ;
@@ -0,0 +1,32 @@
+-- name: GetWorkspaceAgentContainersByAgentID :many
+SELECT
+	*
+FROM
+	workspace_agent_containers
+WHERE
+	workspace_agent_id = $1
+ORDER BY
+	name ASC;
+
+-- name: DeleteWorkspaceAgentContainersByAgentID :exec
+DELETE FROM
+	workspace_agent_containers
+WHERE
+	workspace_agent_id = $1;
+
+-- name: InsertWorkspaceAgentContainer :exec
+INSERT INTO
+	workspace_agent_containers (
+		workspace_agent_id,
+		container_id,
+		name,
+		image,
+		status,
+		runtime,
+		labels,
+		ports,
+		created_at,
+		reported_at
+	)
+VALUES
+	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);
@@ -139,6 +139,90 @@ WHERE
 	workspace_agent_id = $1
 	AND CASE WHEN COALESCE(array_length(sqlc.arg('keys')::text[], 1), 0) > 0 THEN key = ANY(sqlc.arg('keys')::text[]) ELSE TRUE END;
 
+-- name: InsertWorkspaceAgentMetadataHistory :exec
+-- Records a sample for each of the given keys that the agent has metadata
+-- for, so an agent can't fill the history with undeclared keys.
+WITH metadata AS (
+	SELECT
+		unnest(sqlc.arg('key')::text[]) AS key,
+		unnest(sqlc.arg('value')::text[]) AS value,
+		unnest(sqlc.arg('error')::text[]) AS error,
+		unnest(sqlc.arg('collected_at')::timestamptz[]) AS collected_at
+)
+INSERT INTO
+	workspace_agent_metadata_history (
+		workspace_agent_id,
+		key,
+		value,
+		error,
+		collected_at
+	)
+SELECT
+	wam.workspace_agent_id,
+	m.key,
+	m.value,
+	m.error,
+	m.collected_at
+FROM
+	metadata m
+JOIN
+	workspace_agent_metadata wam
+ON
+	wam.workspace_agent_id = $1
+	AND wam.key = m.key;
+
+-- name: GetWorkspaceAgentMetadataHistory :many
+SELECT
+	*
+FROM
+	workspace_agent_metadata_history
+WHERE
+	workspace_agent_id = sqlc.arg('workspace_agent_id')
+	AND key = sqlc.arg('key')
+	AND collected_at > sqlc.arg('collected_after')::timestamptz
+ORDER BY
+	collected_at DESC
+LIMIT
+	sqlc.arg('limit')::int;
+
+-- name: DeleteOldWorkspaceAgentMetadataHistory :exec
+-- Deletes samples collected before the given time, and samples beyond the
+-- newest max_samples_per_key of each key. History is only recorded for keys
+-- in workspace_agent_metadata, and is deleted with the agent.
+WITH cutoffs AS (
+	-- The cutoff of each key is found through the history index, so only
+	-- the deleted samples are visited rather than the whole table.
+	SELECT
+		wam.workspace_agent_id,
+		wam.key,
+		GREATEST(sqlc.arg('collected_before')::timestamptz, oldest_kept.collected_at) AS collected_at
+	FROM
+		workspace_agent_metadata wam
+	LEFT JOIN LATERAL (
+		SELECT
+			collected_at
+		FROM
+			workspace_agent_metadata_history
+		WHERE
+			workspace_agent_id = wam.workspace_agent_id
+			AND key = wam.key
+		ORDER BY
+			collected_at DESC
+		OFFSET
+			sqlc.arg('max_samples_per_key')::int - 1
+		LIMIT
+			1
+	) AS oldest_kept ON TRUE
+)
+DELETE FROM
+	workspace_agent_metadata_history wamh
+USING
+	cutoffs
+WHERE
+	wamh.workspace_agent_id = cutoffs.workspace_agent_id
+	AND wamh.key = cutoffs.key
+	AND wamh.collected_at < cutoffs.collected_at;
+
 -- name: UpdateWorkspaceAgentLogOverflowByID :exec
 UPDATE
 	workspace_agents
@@ -300,3 +384,15 @@ INSERT INTO
     )
 VALUES
     ($1, $2, $3, $4, $5, $6);
+
+-- name: GetWorkspaceAgentScriptTimingsByScriptID :many
+SELECT
+	*
+FROM
+	workspace_agent_script_timings
+WHERE
+	script_id = $1
+ORDER BY
+	started_at DESC
+LIMIT
+	$2;
@@ -0,0 +1,37 @@
+-- name: InsertWorkspaceAgentSessionRecording :one
+INSERT INTO workspace_agent_session_recordings (id, workspace_id, agent_id, session_type, command, started_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: InsertWorkspaceAgentSessionRecordingChunk :exec
+WITH chunk AS (
+	INSERT INTO workspace_agent_session_recording_chunks (recording_id, sequence, data)
+	VALUES (@recording_id, @sequence, @data)
+	RETURNING recording_id, length(data) AS size
+)
+UPDATE workspace_agent_session_recordings
+SET size = workspace_agent_session_recordings.size + chunk.size
+FROM chunk
+WHERE workspace_agent_session_recordings.id = chunk.recording_id;
+
+-- name: UpdateWorkspaceAgentSessionRecordingEndedAt :exec
+UPDATE workspace_agent_session_recordings
+SET ended_at = $2
+WHERE id = $1;
+
+-- name: GetWorkspaceAgentSessionRecordingByID :one
+SELECT *
+FROM workspace_agent_session_recordings
+WHERE id = $1;
+
+-- name: GetWorkspaceAgentSessionRecordingsByWorkspaceID :many
+SELECT *
+FROM workspace_agent_session_recordings
+WHERE workspace_id = $1
+ORDER BY started_at DESC;
+
+-- name: GetWorkspaceAgentSessionRecordingChunks :many
+SELECT *
+FROM workspace_agent_session_recording_chunks
+WHERE recording_id = $1
+ORDER BY sequence ASC;
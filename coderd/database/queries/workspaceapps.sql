@@ -29,10 +29,17 @@ INSERT INTO
         healthcheck_threshold,
         health,
         display_order,
-        hidden
+        hidden,
+        healthcheck_kind,
+        healthcheck_expected_status,
+        healthcheck_body_regex,
+        healthcheck_address,
+        healthcheck_grpc_service,
+        healthcheck_command,
+        healthcheck_path
     )
 VALUES
-    ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17) RETURNING *;
+    ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24) RETURNING *;
 
 -- name: UpdateWorkspaceAppHealthByID :exec
 UPDATE
@@ -120,10 +120,11 @@ INSERT INTO
 		provisioner_state,
 		deadline,
 		max_deadline,
-		reason
+		reason,
+		provisioner_state_ref
 	)
 VALUES
-	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13);
+	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14);
 
 -- name: UpdateWorkspaceBuildCostByID :exec
 UPDATE
@@ -147,9 +148,42 @@ UPDATE
 	workspace_builds
 SET
 	provisioner_state = @provisioner_state::bytea,
+	provisioner_state_ref = @provisioner_state_ref::text,
 	updated_at = @updated_at::timestamptz
 WHERE id = @id::uuid;
 
+-- name: GetWorkspaceBuildsToMigrateProvisionerState :many
+-- Returns a page of the builds whose provisioner state is stored in the
+-- database, or in the state store if @in_state_store is set, ordered by ID.
+SELECT
+	*
+FROM
+	workspace_build_with_user
+WHERE
+	id > @after_id::uuid
+	AND CASE
+		WHEN @in_state_store::boolean THEN provisioner_state_ref != ''
+		ELSE provisioner_state_ref = '' AND length(provisioner_state) > 0
+	END
+ORDER BY
+	id
+LIMIT
+	@limit_count::int;
+
+-- name: UpdateWorkspaceBuildProvisionerStateLocationByID :execrows
+-- Moves the provisioner state of a build between the database and the state
+-- store. The build is only updated if it hasn't changed since it was read, so
+-- that a state written by a concurrent build is never replaced. updated_at is
+-- left as is, since the state itself doesn't change.
+UPDATE
+	workspace_builds
+SET
+	provisioner_state = @provisioner_state::bytea,
+	provisioner_state_ref = @provisioner_state_ref::text
+WHERE
+	id = @id::uuid
+	AND updated_at = @updated_at::timestamptz;
+
 -- name: GetActiveWorkspaceBuildsByTemplateID :many
 SELECT wb.*
 FROM (
@@ -0,0 +1,115 @@
+-- name: InsertWorkspaceDriftCheck :one
+INSERT INTO workspace_drift_checks (id, workspace_id, workspace_build_id, job_id, created_at)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- Returns the running workspaces whose infrastructure is due to be checked for
+-- drift: the latest build successfully started them, and they haven't been
+-- checked since @checked_before and aren't being checked. The provisioner job
+-- of the build is returned, so the check can run on the same provisioners.
+-- name: GetWorkspacesEligibleForDriftCheck :many
+SELECT
+	workspaces.id AS workspace_id,
+	workspaces.owner_id,
+	workspaces.template_id,
+	workspace_builds.id AS workspace_build_id,
+	provisioner_jobs.organization_id,
+	provisioner_jobs.provisioner,
+	provisioner_jobs.storage_method,
+	provisioner_jobs.file_id,
+	provisioner_jobs.tags
+FROM
+	workspaces
+INNER JOIN
+	workspace_builds ON workspace_builds.workspace_id = workspaces.id
+INNER JOIN
+	provisioner_jobs ON provisioner_jobs.id = workspace_builds.job_id
+WHERE
+	workspaces.deleted = false
+	AND workspaces.dormant_at IS NULL
+	AND workspace_builds.build_number = (
+		SELECT
+			MAX(build_number)
+		FROM
+			workspace_builds
+		WHERE
+			workspace_builds.workspace_id = workspaces.id
+	)
+	AND workspace_builds.transition = 'start'::workspace_transition
+	AND provisioner_jobs.job_status = 'succeeded'::provisioner_job_status
+	AND NOT EXISTS (
+		SELECT
+			1
+		FROM
+			workspace_drift_checks
+		INNER JOIN
+			provisioner_jobs AS check_jobs ON check_jobs.id = workspace_drift_checks.job_id
+		WHERE
+			workspace_drift_checks.workspace_id = workspaces.id
+			AND (
+				workspace_drift_checks.created_at > @checked_before :: timestamptz
+				OR check_jobs.completed_at IS NULL
+			)
+	)
+ORDER BY
+	workspace_builds.created_at ASC;
+
+-- name: GetInProgressWorkspaceDriftCheckCountsByTemplate :many
+SELECT
+	workspaces.template_id,
+	COUNT(*) AS count
+FROM
+	workspace_drift_checks
+INNER JOIN
+	workspaces ON workspaces.id = workspace_drift_checks.workspace_id
+INNER JOIN
+	provisioner_jobs ON provisioner_jobs.id = workspace_drift_checks.job_id
+WHERE
+	provisioner_jobs.completed_at IS NULL
+GROUP BY
+	workspaces.template_id;
+
+-- Returns the resources that the latest successful drift check of each
+-- workspace found to have drifted, as long as it checked the latest build of
+-- the workspace. Drift found in the state of an earlier build is stale.
+-- name: GetWorkspaceDriftedResourcesByWorkspaceIDs :many
+SELECT
+	latest_checks.workspace_id,
+	provisioner_job_resource_changes.job_id,
+	provisioner_job_resource_changes.address,
+	provisioner_job_resource_changes.type,
+	provisioner_job_resource_changes.name,
+	provisioner_job_resource_changes.action,
+	provisioner_job_resource_changes.attributes
+FROM (
+	SELECT DISTINCT ON (workspace_drift_checks.workspace_id)
+		workspace_drift_checks.workspace_id,
+		workspace_drift_checks.workspace_build_id,
+		workspace_drift_checks.job_id
+	FROM
+		workspace_drift_checks
+	INNER JOIN
+		provisioner_jobs ON provisioner_jobs.id = workspace_drift_checks.job_id
+	WHERE
+		workspace_drift_checks.workspace_id = ANY(@ids :: uuid [ ])
+		AND provisioner_jobs.job_status = 'succeeded'::provisioner_job_status
+	ORDER BY
+		workspace_drift_checks.workspace_id, workspace_drift_checks.created_at DESC
+) AS latest_checks
+INNER JOIN
+	provisioner_job_resource_changes ON provisioner_job_resource_changes.job_id = latest_checks.job_id
+WHERE
+	latest_checks.workspace_build_id = (
+		SELECT
+			id
+		FROM
+			workspace_builds
+		WHERE
+			workspace_builds.workspace_id = latest_checks.workspace_id
+		ORDER BY
+			build_number DESC
+		LIMIT
+			1
+	)
+ORDER BY
+	latest_checks.workspace_id, provisioner_job_resource_changes.address;
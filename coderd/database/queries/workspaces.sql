@@ -47,7 +47,8 @@ WHERE
 -- name: GetWorkspaceByAgentID :one
 SELECT
 	sqlc.embed(workspaces),
-	templates.name as template_name
+	templates.name as template_name,
+	templates.agent_activity_bump as template_agent_activity_bump
 FROM
 	workspaces
 INNER JOIN
@@ -109,7 +110,10 @@ LEFT JOIN LATERAL (
 		workspace_builds.id,
 		workspace_builds.transition,
 		workspace_builds.template_version_id,
+		workspace_builds.initiator_id,
+		workspace_builds.reason,
 		template_versions.name AS template_version_name,
+		template_versions.created_at AS template_version_created_at,
 		provisioner_jobs.id AS provisioner_job_id,
 		provisioner_jobs.started_at,
 		provisioner_jobs.updated_at,
@@ -326,6 +330,118 @@ WHERE
 			  (latest_build.template_version_id = template.active_version_id) = sqlc.narg('using_active') :: boolean
 		  ELSE true
 	END
+	-- Filter by created_at
+	AND CASE
+		  WHEN @created_before :: timestamp with time zone > '0001-01-01 00:00:00Z' THEN
+				  workspaces.created_at <= @created_before
+		  ELSE true
+	END
+	AND CASE
+		  WHEN @created_after :: timestamp with time zone > '0001-01-01 00:00:00Z' THEN
+				  workspaces.created_at >= @created_after
+		  ELSE true
+	END
+	-- Filter by search terms, which are used for negated terms, terms combined
+	-- with OR, and keys that have no dedicated filter above. The 4 arrays
+	-- describe one term per index. Terms in the same group are ORed together,
+	-- and every group must match.
+	AND NOT EXISTS (
+		SELECT
+			1
+		FROM
+			unnest(@term_groups :: integer[], @term_keys :: text[], @term_values :: text[], @term_negated :: boolean[]) AS term(grp, key, value, negated)
+		GROUP BY
+			term.grp
+		HAVING
+			NOT bool_or(COALESCE(
+				CASE term.key
+					WHEN 'owner' THEN
+						lower(users.username) = lower(term.value)
+					WHEN 'template' THEN
+						lower(template.name) = lower(term.value)
+					WHEN 'template_version' THEN
+						lower(latest_build.template_version_name) = lower(term.value)
+					-- Versions are compared by when they were created, against
+					-- the version of the given name of the workspace's template.
+					WHEN 'template_version_lt' THEN
+						latest_build.template_version_created_at < (SELECT created_at FROM template_versions WHERE template_id = workspaces.template_id AND lower(name) = lower(term.value))
+					WHEN 'template_version_lte' THEN
+						latest_build.template_version_created_at <= (SELECT created_at FROM template_versions WHERE template_id = workspaces.template_id AND lower(name) = lower(term.value))
+					WHEN 'template_version_gt' THEN
+						latest_build.template_version_created_at > (SELECT created_at FROM template_versions WHERE template_id = workspaces.template_id AND lower(name) = lower(term.value))
+					WHEN 'template_version_gte' THEN
+						latest_build.template_version_created_at >= (SELECT created_at FROM template_versions WHERE template_id = workspaces.template_id AND lower(name) = lower(term.value))
+					WHEN 'name' THEN
+						workspaces.name ILIKE '%' || term.value || '%'
+					WHEN 'initiator' THEN
+						latest_build.initiator_id = (SELECT id FROM users WHERE lower(username) = lower(term.value) AND deleted = false)
+					WHEN 'build_reason' THEN
+						latest_build.reason :: text = term.value
+					-- This must match the status filter above.
+					WHEN 'status' THEN
+						CASE term.value
+							WHEN 'starting' THEN
+								latest_build.job_status = 'running'::provisioner_job_status AND
+								latest_build.transition = 'start'::workspace_transition
+							WHEN 'stopping' THEN
+								latest_build.job_status = 'running'::provisioner_job_status AND
+								latest_build.transition = 'stop'::workspace_transition
+							WHEN 'deleting' THEN
+								latest_build.job_status = 'running'::provisioner_job_status AND
+								latest_build.transition = 'delete'::workspace_transition
+							WHEN 'deleted' THEN
+								latest_build.job_status = 'succeeded'::provisioner_job_status AND
+								latest_build.transition = 'delete'::workspace_transition
+							WHEN 'stopped' THEN
+								latest_build.job_status = 'succeeded'::provisioner_job_status AND
+								latest_build.transition = 'stop'::workspace_transition
+							WHEN 'started' THEN
+								latest_build.job_status = 'succeeded'::provisioner_job_status AND
+								latest_build.transition = 'start'::workspace_transition
+							WHEN 'running' THEN
+								latest_build.job_status = 'succeeded'::provisioner_job_status AND
+								latest_build.transition = 'start'::workspace_transition
+							ELSE
+								latest_build.job_status :: text = term.value
+						END
+					-- This must match the has_agent filter above.
+					WHEN 'agent_status' THEN
+						EXISTS (
+							SELECT
+								1
+							FROM
+								workspace_resources
+							JOIN
+								workspace_agents
+							ON
+								workspace_agents.resource_id = workspace_resources.id
+							WHERE
+								workspace_resources.job_id = latest_build.provisioner_job_id AND
+								latest_build.transition = 'start'::workspace_transition AND
+								term.value = (
+									CASE
+										WHEN workspace_agents.first_connected_at IS NULL THEN
+											CASE
+												WHEN workspace_agents.connection_timeout_seconds > 0 AND NOW() - workspace_agents.created_at > workspace_agents.connection_timeout_seconds * INTERVAL '1 second' THEN
+													'timeout'
+												ELSE
+													'connecting'
+											END
+										WHEN workspace_agents.disconnected_at > workspace_agents.last_connected_at THEN
+											'disconnected'
+										WHEN NOW() - workspace_agents.last_connected_at > INTERVAL '1 second' * @agent_inactive_disconnect_timeout_seconds :: bigint THEN
+											'disconnected'
+										WHEN workspace_agents.last_connected_at IS NOT NULL THEN
+											'connected'
+										ELSE
+											NULL
+									END
+								)
+						)
+					ELSE
+						false
+				END, false) != term.negated)
+	)
 	-- Authorize Filter clause will be injected below in GetAuthorizedWorkspaces
 	-- @authorize_filter
 ), filtered_workspaces_order AS (
@@ -687,3 +803,10 @@ UPDATE workspaces SET favorite = true WHERE id = @id;
 
 -- name: UnfavoriteWorkspace :exec
 UPDATE workspaces SET favorite = false WHERE id = @id;
+
+-- name: LockWorkspaceByID :exec
+-- Updates the row of the workspace without changing it, so transactions that
+-- queue jobs on the workspace conflict with each other. A repeatable read
+-- transaction fails with a serialization error if a concurrent transaction
+-- locked the workspace after it began.
+UPDATE workspaces SET updated_at = updated_at WHERE id = @id;
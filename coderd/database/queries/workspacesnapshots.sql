@@ -0,0 +1,34 @@
+-- name: InsertWorkspaceSnapshot :one
+INSERT INTO workspace_snapshots (id, workspace_id, workspace_build_id, job_id, name, created_by, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetWorkspaceSnapshotByID :one
+SELECT *
+FROM workspace_snapshots
+WHERE id = $1;
+
+-- name: GetWorkspaceSnapshotsByWorkspaceID :many
+SELECT *
+FROM workspace_snapshots
+WHERE workspace_id = $1
+ORDER BY created_at DESC;
+
+-- name: GetActiveWorkspaceSnapshotJobsByWorkspaceID :many
+-- Returns the jobs that create or restore a snapshot of the workspace and
+-- haven't completed yet.
+SELECT provisioner_jobs.*
+FROM provisioner_jobs
+JOIN workspace_builds ON workspace_builds.id = (provisioner_jobs.input ->> 'workspace_build_id')::uuid
+WHERE provisioner_jobs.type = 'workspace_snapshot'::provisioner_job_type
+	AND workspace_builds.workspace_id = @workspace_id
+	AND provisioner_jobs.job_status IN ('pending', 'running', 'canceling');
+
+-- name: UpdateWorkspaceSnapshotDataByID :exec
+UPDATE workspace_snapshots
+SET data = $2
+WHERE id = $1;
+
+-- name: DeleteWorkspaceSnapshotByID :exec
+DELETE FROM workspace_snapshots
+WHERE id = $1;
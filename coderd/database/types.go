@@ -69,6 +69,37 @@ func (t TemplateACL) Value() (driver.Value, error) {
 	return json.Marshal(t)
 }
 
+// PortForwardingPolicy restricts the port forwards the agents of a template's
+// workspaces allow. A nil rule allows all forwards of its kind.
+type PortForwardingPolicy struct {
+	DirectTCPIP   *PortForwardingRule       `json:"direct_tcpip,omitempty"`
+	RemoteForward *PortForwardingRule       `json:"remote_forward,omitempty"`
+	UnixSocket    *UnixSocketForwardingRule `json:"unix_socket,omitempty"`
+}
+
+type PortForwardingRule struct {
+	AllowedCIDRs []string `json:"allowed_cidrs"`
+	AllowedPorts []string `json:"allowed_ports"`
+}
+
+type UnixSocketForwardingRule struct {
+	AllowedPaths []string `json:"allowed_paths"`
+}
+
+func (p *PortForwardingPolicy) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case string:
+		return json.Unmarshal([]byte(v), &p)
+	case []byte:
+		return json.Unmarshal(v, &p)
+	}
+	return xerrors.Errorf("unexpected type %T", src)
+}
+
+func (p PortForwardingPolicy) Value() (driver.Value, error) {
+	return json.Marshal(p)
+}
+
 type ExternalAuthProvider struct {
 	ID       string `json:"id"`
 	Optional bool   `json:"optional,omitempty"`
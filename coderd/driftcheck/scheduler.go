@@ -0,0 +1,236 @@
+// Package driftcheck periodically queues jobs that check the infrastructure of
+// running workspaces for drift from their Terraform state.
+package driftcheck
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbauthz"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
+	"github.com/coder/coder/v2/coderd/database/provisionerjobs"
+	"github.com/coder/coder/v2/coderd/database/pubsub"
+	"github.com/coder/coder/v2/coderd/provisionerdserver"
+)
+
+// MaxInProgressChecksPerTemplate is the maximum number of drift checks of the
+// workspaces of a template that are queued or running at once. Drift checks
+// run on the same provisioners as the builds of the template, so they are
+// limited to not starve those builds.
+const MaxInProgressChecksPerTemplate = 2
+
+// acquireLockError is returned when the scheduler fails to acquire a lock and
+// cancels the current run.
+type acquireLockError struct{}
+
+// Error implements error.
+func (acquireLockError) Error() string {
+	return "lock is held by another client"
+}
+
+// Scheduler queues a drift check job for every running workspace that hasn't
+// been checked within the interval.
+type Scheduler struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	db       database.Store
+	pubsub   pubsub.Pubsub
+	log      slog.Logger
+	interval time.Duration
+	tick     <-chan time.Time
+	stats    chan<- Stats
+}
+
+// Stats contains statistics about the last run of the scheduler.
+type Stats struct {
+	// QueuedJobIDs contains the IDs of the drift check jobs that were queued.
+	QueuedJobIDs []uuid.UUID
+	// Error is the fatal error that occurred during the last run of the
+	// scheduler, if any.
+	Error error
+}
+
+// New returns a new drift check scheduler, which checks every running
+// workspace once per interval.
+func New(ctx context.Context, db database.Store, pub pubsub.Pubsub, log slog.Logger, interval time.Duration, tick <-chan time.Time) *Scheduler {
+	//nolint:gocritic // Drift checks are queued by the system.
+	ctx, cancel := context.WithCancel(dbauthz.AsSystemRestricted(ctx))
+	return &Scheduler{
+		ctx:      ctx,
+		cancel:   cancel,
+		done:     make(chan struct{}),
+		db:       db,
+		pubsub:   pub,
+		log:      log,
+		interval: interval,
+		tick:     tick,
+		stats:    nil,
+	}
+}
+
+// WithStatsChannel will cause Scheduler to push a Stats to ch after every
+// tick. This push is blocking, so if ch is not read, the scheduler will hang.
+// This should only be used in tests.
+func (s *Scheduler) WithStatsChannel(ch chan<- Stats) *Scheduler {
+	s.stats = ch
+	return s
+}
+
+// Start will cause the scheduler to queue drift checks on every tick from its
+// channel. It will stop when its context is Done, or when its channel is
+// closed.
+//
+// Start should only be called once.
+func (s *Scheduler) Start() {
+	go func() {
+		defer close(s.done)
+		defer s.cancel()
+
+		for {
+			select {
+			case <-s.ctx.Done():
+				return
+			case t, ok := <-s.tick:
+				if !ok {
+					return
+				}
+				stats := s.run(t)
+				if stats.Error != nil && !xerrors.As(stats.Error, &acquireLockError{}) {
+					s.log.Warn(s.ctx, "error running workspace drift check scheduler once", slog.Error(stats.Error))
+				}
+				if s.stats != nil {
+					select {
+					case <-s.ctx.Done():
+						return
+					case s.stats <- stats:
+					}
+				}
+			}
+		}
+	}()
+}
+
+// Wait will block until the scheduler is stopped.
+func (s *Scheduler) Wait() {
+	<-s.done
+}
+
+// Close will stop the scheduler.
+func (s *Scheduler) Close() {
+	s.cancel()
+	<-s.done
+}
+
+func (s *Scheduler) run(t time.Time) Stats {
+	ctx, cancel := context.WithTimeout(s.ctx, time.Minute)
+	defer cancel()
+
+	stats := Stats{
+		QueuedJobIDs: []uuid.UUID{},
+		Error:        nil,
+	}
+	var jobs []database.ProvisionerJob
+	err := s.db.InTx(func(db database.Store) error {
+		// Only one replica queues drift checks at a time, so the limit per
+		// template holds.
+		locked, err := db.TryAcquireLock(ctx, database.LockIDWorkspaceDriftCheck)
+		if err != nil {
+			return xerrors.Errorf("acquire lock: %w", err)
+		}
+		if !locked {
+			return acquireLockError{}
+		}
+
+		workspaces, err := db.GetWorkspacesEligibleForDriftCheck(ctx, t.Add(-s.interval))
+		if err != nil {
+			return xerrors.Errorf("get workspaces eligible for drift check: %w", err)
+		}
+		if len(workspaces) == 0 {
+			return nil
+		}
+		counts, err := db.GetInProgressWorkspaceDriftCheckCountsByTemplate(ctx)
+		if err != nil {
+			return xerrors.Errorf("get in progress drift check counts: %w", err)
+		}
+		inProgress := make(map[uuid.UUID]int64, len(counts))
+		for _, count := range counts {
+			inProgress[count.TemplateID] = count.Count
+		}
+
+		for _, workspace := range workspaces {
+			if inProgress[workspace.TemplateID] >= MaxInProgressChecksPerTemplate {
+				continue
+			}
+			job, err := insertDriftCheckJob(ctx, db, workspace)
+			if err != nil {
+				return xerrors.Errorf("insert drift check of workspace %s: %w", workspace.WorkspaceID, err)
+			}
+			inProgress[workspace.TemplateID]++
+			jobs = append(jobs, job)
+		}
+		return nil
+	}, nil)
+	if err != nil {
+		stats.Error = err
+		return stats
+	}
+
+	for _, job := range jobs {
+		err = provisionerjobs.PostJob(s.pubsub, job)
+		if err != nil {
+			// Client probably doesn't care about this error, so just log it.
+			s.log.Error(ctx, "failed to post provisioner job to pubsub", slog.F("job_id", job.ID), slog.Error(err))
+		}
+		stats.QueuedJobIDs = append(stats.QueuedJobIDs, job.ID)
+	}
+	return stats
+}
+
+// insertDriftCheckJob queues a drift check job on the provisioners that ran
+// the latest build of the workspace.
+func insertDriftCheckJob(ctx context.Context, db database.Store, workspace database.GetWorkspacesEligibleForDriftCheckRow) (database.ProvisionerJob, error) {
+	input, err := json.Marshal(provisionerdserver.WorkspaceDriftCheckJob{
+		WorkspaceBuildID: workspace.WorkspaceBuildID,
+	})
+	if err != nil {
+		return database.ProvisionerJob{}, xerrors.Errorf("marshal job input: %w", err)
+	}
+
+	now := dbtime.Now()
+	job, err := db.InsertProvisionerJob(ctx, database.InsertProvisionerJobParams{
+		ID:             uuid.New(),
+		CreatedAt:      now,
+		UpdatedAt:      now,
+		OrganizationID: workspace.OrganizationID,
+		InitiatorID:    workspace.OwnerID,
+		Provisioner:    workspace.Provisioner,
+		StorageMethod:  workspace.StorageMethod,
+		FileID:         workspace.FileID,
+		Type:           database.ProvisionerJobTypeWorkspaceDriftCheck,
+		Input:          input,
+		// Copy tags from the build, so the job runs on the same provisioners.
+		Tags: workspace.Tags,
+	})
+	if err != nil {
+		return database.ProvisionerJob{}, xerrors.Errorf("insert provisioner job: %w", err)
+	}
+	_, err = db.InsertWorkspaceDriftCheck(ctx, database.InsertWorkspaceDriftCheckParams{
+		ID:               uuid.New(),
+		WorkspaceID:      workspace.WorkspaceID,
+		WorkspaceBuildID: workspace.WorkspaceBuildID,
+		JobID:            job.ID,
+		CreatedAt:        now,
+	})
+	if err != nil {
+		return database.ProvisionerJob{}, xerrors.Errorf("insert workspace drift check: %w", err)
+	}
+	return job, nil
+}
@@ -0,0 +1,169 @@
+package driftcheck_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"cdr.dev/slog"
+	"cdr.dev/slog/sloggers/slogtest"
+	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbauthz"
+	"github.com/coder/coder/v2/coderd/database/dbgen"
+	"github.com/coder/coder/v2/coderd/database/dbtestutil"
+	"github.com/coder/coder/v2/coderd/driftcheck"
+	"github.com/coder/coder/v2/coderd/provisionerdserver"
+	"github.com/coder/coder/v2/coderd/rbac"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+func TestScheduler(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx        = testutil.Context(t, testutil.WaitLong)
+		db, pubsub = dbtestutil.NewDB(t)
+		log        = slogtest.Make(t, nil)
+		tickCh     = make(chan time.Time)
+		statsCh    = make(chan driftcheck.Stats)
+		now        = time.Now()
+		org        = dbgen.Organization(t, db, database.Organization{})
+		user       = dbgen.User(t, db, database.User{})
+		template   = dbgen.Template(t, db, database.Template{
+			OrganizationID: org.ID,
+			CreatedBy:      user.ID,
+		})
+		otherTemplate = dbgen.Template(t, db, database.Template{
+			OrganizationID: org.ID,
+			CreatedBy:      user.ID,
+		})
+	)
+
+	// More running workspaces of the template than may be checked at once,
+	// a running workspace of another template and a stopped workspace.
+	running := make([]database.Workspace, 0, driftcheck.MaxInProgressChecksPerTemplate+1)
+	for i := 0; i < driftcheck.MaxInProgressChecksPerTemplate+1; i++ {
+		running = append(running, workspaceWithBuild(t, db, org.ID, user.ID, template.ID, database.WorkspaceTransitionStart))
+	}
+	other := workspaceWithBuild(t, db, org.ID, user.ID, otherTemplate.ID, database.WorkspaceTransitionStart)
+	_ = workspaceWithBuild(t, db, org.ID, user.ID, template.ID, database.WorkspaceTransitionStop)
+
+	scheduler := driftcheck.New(ctx, wrapDBAuthz(db, log), pubsub, log, time.Hour, tickCh).WithStatsChannel(statsCh)
+	scheduler.Start()
+	defer scheduler.Close()
+
+	// The checks of the template are limited, the other template has its own
+	// limit and stopped workspaces aren't checked.
+	tickCh <- now
+	stats := <-statsCh
+	require.NoError(t, stats.Error)
+	require.Len(t, stats.QueuedJobIDs, driftcheck.MaxInProgressChecksPerTemplate+1)
+	checks := driftCheckJobs(ctx, t, db, stats.QueuedJobIDs)
+	require.Contains(t, checks, other.ID)
+	require.NotContains(t, checks, running[len(running)-1].ID)
+
+	// Nothing is queued while the checks are in progress.
+	tickCh <- now
+	stats = <-statsCh
+	require.NoError(t, stats.Error)
+	require.Empty(t, stats.QueuedJobIDs)
+
+	// Once a check completes, the remaining workspace is checked.
+	completeJobs(ctx, t, db, checks[running[0].ID])
+	tickCh <- now
+	stats = <-statsCh
+	require.NoError(t, stats.Error)
+	require.Len(t, stats.QueuedJobIDs, 1)
+	require.Contains(t, driftCheckJobs(ctx, t, db, stats.QueuedJobIDs), running[len(running)-1].ID)
+
+	// Workspaces are checked again after the interval.
+	for _, jobID := range checks {
+		completeJobs(ctx, t, db, jobID)
+	}
+	completeJobs(ctx, t, db, stats.QueuedJobIDs...)
+	tickCh <- now.Add(2 * time.Hour)
+	stats = <-statsCh
+	require.NoError(t, stats.Error)
+	require.Len(t, stats.QueuedJobIDs, driftcheck.MaxInProgressChecksPerTemplate+1)
+}
+
+func workspaceWithBuild(t *testing.T, db database.Store, orgID, userID, templateID uuid.UUID, transition database.WorkspaceTransition) database.Workspace {
+	t.Helper()
+
+	templateVersion := dbgen.TemplateVersion(t, db, database.TemplateVersion{
+		OrganizationID: orgID,
+		TemplateID:     uuid.NullUUID{UUID: templateID, Valid: true},
+		CreatedBy:      userID,
+	})
+	workspace := dbgen.Workspace(t, db, database.Workspace{
+		OwnerID:        userID,
+		OrganizationID: orgID,
+		TemplateID:     templateID,
+	})
+	job := dbgen.ProvisionerJob(t, db, nil, database.ProvisionerJob{
+		OrganizationID: orgID,
+		InitiatorID:    userID,
+		Type:           database.ProvisionerJobTypeWorkspaceBuild,
+		StartedAt:      sql.NullTime{Time: time.Now(), Valid: true},
+		CompletedAt:    sql.NullTime{Time: time.Now(), Valid: true},
+	})
+	_ = dbgen.WorkspaceBuild(t, db, database.WorkspaceBuild{
+		WorkspaceID:       workspace.ID,
+		TemplateVersionID: templateVersion.ID,
+		JobID:             job.ID,
+		Transition:        transition,
+	})
+	return workspace
+}
+
+func completeJobs(ctx context.Context, t *testing.T, db database.Store, jobIDs ...uuid.UUID) {
+	t.Helper()
+
+	for _, jobID := range jobIDs {
+		err := db.UpdateProvisionerJobWithCompleteByID(ctx, database.UpdateProvisionerJobWithCompleteByIDParams{
+			ID:          jobID,
+			UpdatedAt:   time.Now(),
+			CompletedAt: sql.NullTime{Time: time.Now(), Valid: true},
+		})
+		require.NoError(t, err)
+	}
+}
+
+// driftCheckJobs returns the drift check jobs by the workspace they check.
+func driftCheckJobs(ctx context.Context, t *testing.T, db database.Store, jobIDs []uuid.UUID) map[uuid.UUID]uuid.UUID {
+	t.Helper()
+
+	checks := map[uuid.UUID]uuid.UUID{}
+	for _, jobID := range jobIDs {
+		job, err := db.GetProvisionerJobByID(ctx, jobID)
+		require.NoError(t, err)
+		require.Equal(t, database.ProvisionerJobTypeWorkspaceDriftCheck, job.Type)
+		var input provisionerdserver.WorkspaceDriftCheckJob
+		require.NoError(t, json.Unmarshal(job.Input, &input))
+		build, err := db.GetWorkspaceBuildByID(ctx, input.WorkspaceBuildID)
+		require.NoError(t, err)
+		checks[build.WorkspaceID] = jobID
+	}
+	return checks
+}
+
+func wrapDBAuthz(db database.Store, logger slog.Logger) database.Store {
+	return dbauthz.New(
+		db,
+		rbac.NewStrictCachingAuthorizer(prometheus.NewRegistry()),
+		logger,
+		coderdtest.AccessControlStorePointer(),
+	)
+}
@@ -2,17 +2,28 @@ package coderd
 
 import (
 	"bytes"
+	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"golang.org/x/xerrors"
 
 	"cdr.dev/slog"
 
 	"github.com/coder/coder/v2/coderd/audit"
 	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
 	"github.com/coder/coder/v2/coderd/httpapi"
 	"github.com/coder/coder/v2/coderd/httpmw"
+	"github.com/coder/coder/v2/coderd/notifications"
+	"github.com/coder/coder/v2/coderd/notifications/dispatch"
 	"github.com/coder/coder/v2/coderd/rbac"
 	"github.com/coder/coder/v2/codersdk"
 )
@@ -216,13 +227,24 @@ func (api *API) putUserNotificationPreferences(rw http.ResponseWriter, r *http.R
 		return
 	}
 
-	// Build query params.
-	input := database.UpdateUserNotificationPreferencesParams{
-		UserID:                  user.ID,
-		NotificationTemplateIds: make([]uuid.UUID, 0, len(prefs.TemplateDisabledMap)),
-		Disableds:               make([]bool, 0, len(prefs.TemplateDisabledMap)),
+	// Templates which appear in only one of the maps retain their current value for the other setting.
+	current, err := api.Database.GetUserNotificationPreferences(ctx, user.ID)
+	if err != nil {
+		logger.Error(ctx, "failed to retrieve preferences", slog.Error(err))
+
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to retrieve user notification preferences.",
+			Detail:  err.Error(),
+		})
+		return
 	}
-	for tmplID, disabled := range prefs.TemplateDisabledMap {
+	existing := make(map[uuid.UUID]database.NotificationPreference, len(current))
+	for _, pref := range current {
+		existing[pref.NotificationTemplateID] = pref
+	}
+
+	updates := make(map[uuid.UUID]database.NotificationPreference, len(prefs.TemplateDisabledMap)+len(prefs.TemplateMethodMap))
+	parseTemplateID := func(tmplID string) (uuid.UUID, bool) {
 		id, err := uuid.Parse(tmplID)
 		if err != nil {
 			logger.Warn(ctx, "failed to parse notification template UUID", slog.F("input", tmplID), slog.Error(err))
@@ -231,11 +253,69 @@ func (api *API) putUserNotificationPreferences(rw http.ResponseWriter, r *http.R
 				Message: "Unable to parse notification template UUID.",
 				Detail:  err.Error(),
 			})
+			return uuid.Nil, false
+		}
+		if _, ok := updates[id]; !ok {
+			updates[id] = existing[id]
+		}
+		return id, true
+	}
+
+	for tmplID, disabled := range prefs.TemplateDisabledMap {
+		id, ok := parseTemplateID(tmplID)
+		if !ok {
+			return
+		}
+
+		pref := updates[id]
+		pref.Disabled = disabled
+		updates[id] = pref
+	}
+	// Only methods which are configured on the deployment can deliver notifications.
+	enabled := notifications.EnabledMethods(api.DeploymentValues.Notifications)
+	for tmplID, method := range prefs.TemplateMethodMap {
+		id, ok := parseTemplateID(tmplID)
+		if !ok {
+			return
+		}
+
+		nm := database.NotificationMethod(method)
+		if method != "" && !slices.Contains(enabled, nm) {
+			acceptable := make([]string, len(enabled))
+			for i, v := range enabled {
+				acceptable[i] = string(v)
+			}
+
+			httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+				Message: "Invalid request to update user notification preferences",
+				Validations: []codersdk.ValidationError{
+					{
+						Field: "template_method_map",
+						Detail: fmt.Sprintf("%q is not a method enabled on this deployment; %s are the available options",
+							method, strings.Join(acceptable, ", "),
+						),
+					},
+				},
+			})
 			return
 		}
 
+		pref := updates[id]
+		pref.Method = database.NullNotificationMethod{NotificationMethod: nm, Valid: method != ""}
+		updates[id] = pref
+	}
+
+	// Build query params.
+	input := database.UpdateUserNotificationPreferencesParams{
+		UserID:                  user.ID,
+		NotificationTemplateIds: make([]uuid.UUID, 0, len(updates)),
+		Disableds:               make([]bool, 0, len(updates)),
+		Methods:                 make([]string, 0, len(updates)),
+	}
+	for id, pref := range updates {
 		input.NotificationTemplateIds = append(input.NotificationTemplateIds, id)
-		input.Disableds = append(input.Disableds, disabled)
+		input.Disableds = append(input.Disableds, pref.Disabled)
+		input.Methods = append(input.Methods, string(pref.Method.NotificationMethod))
 	}
 
 	// Update preferences with params.
@@ -268,6 +348,409 @@ func (api *API) putUserNotificationPreferences(rw http.ResponseWriter, r *http.R
 	httpapi.Write(ctx, rw, http.StatusOK, out)
 }
 
+// @Summary Get user notification quiet hours
+// @ID get-user-notification-quiet-hours
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Notifications
+// @Param user path string true "User ID, name, or me"
+// @Success 200 {object} codersdk.NotificationQuietHours
+// @Router /users/{user}/notifications/quiet-hours [get]
+func (api *API) userNotificationQuietHours(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx    = r.Context()
+		user   = httpmw.UserParam(r)
+		logger = api.Logger.Named("notifications.quiet_hours").With(slog.F("user_id", user.ID))
+	)
+
+	qh, err := api.Database.GetUserNotificationQuietHours(ctx, user.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		httpapi.Write(ctx, rw, http.StatusOK, codersdk.NotificationQuietHours{})
+		return
+	}
+	if err != nil {
+		logger.Error(ctx, "failed to retrieve quiet hours", slog.Error(err))
+
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to retrieve user notification quiet hours.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, convertNotificationQuietHours(qh))
+}
+
+// @Summary Update user notification quiet hours
+// @ID update-user-notification-quiet-hours
+// @Security CoderSessionToken
+// @Accept json
+// @Produce json
+// @Tags Notifications
+// @Param request body codersdk.NotificationQuietHours true "Quiet hours"
+// @Param user path string true "User ID, name, or me"
+// @Success 200 {object} codersdk.NotificationQuietHours
+// @Router /users/{user}/notifications/quiet-hours [put]
+func (api *API) putUserNotificationQuietHours(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx    = r.Context()
+		user   = httpmw.UserParam(r)
+		logger = api.Logger.Named("notifications.quiet_hours").With(slog.F("user_id", user.ID))
+	)
+
+	var req codersdk.NotificationQuietHours
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+
+	if !req.Enabled {
+		err := api.Database.DeleteUserNotificationQuietHours(ctx, user.ID)
+		if err != nil {
+			logger.Error(ctx, "failed to remove quiet hours", slog.Error(err))
+
+			httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+				Message: "Failed to remove user notification quiet hours.",
+				Detail:  err.Error(),
+			})
+			return
+		}
+
+		httpapi.Write(ctx, rw, http.StatusOK, codersdk.NotificationQuietHours{})
+		return
+	}
+
+	var validations []codersdk.ValidationError
+	start, err := parseTimeOfDay(req.Start)
+	if err != nil {
+		validations = append(validations, codersdk.ValidationError{Field: "start", Detail: err.Error()})
+	}
+	end, err := parseTimeOfDay(req.End)
+	if err != nil {
+		validations = append(validations, codersdk.ValidationError{Field: "end", Detail: err.Error()})
+	}
+	if req.Timezone == "" {
+		validations = append(validations, codersdk.ValidationError{Field: "timezone", Detail: "timezone is required"})
+	} else if _, err := time.LoadLocation(req.Timezone); err != nil {
+		validations = append(validations, codersdk.ValidationError{Field: "timezone", Detail: err.Error()})
+	}
+	if len(validations) > 0 {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message:     "Invalid request to update user notification quiet hours",
+			Validations: validations,
+		})
+		return
+	}
+
+	qh, err := api.Database.UpsertUserNotificationQuietHours(ctx, database.UpsertUserNotificationQuietHoursParams{
+		UserID:      user.ID,
+		StartMinute: start,
+		EndMinute:   end,
+		Timezone:    req.Timezone,
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to update quiet hours", slog.Error(err))
+
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to update user notification quiet hours.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, convertNotificationQuietHours(qh))
+}
+
+// @Summary List user inbox notifications
+// @ID list-user-inbox-notifications
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Notifications
+// @Param user path string true "User ID, name, or me"
+// @Param read_status query string false "Filter by read status" Enums(all,read,unread)
+// @Param include_archived query bool false "Include archived notifications"
+// @Param after_id query string false "After ID" format(uuid)
+// @Param limit query int false "Page limit"
+// @Param offset query int false "Page offset"
+// @Success 200 {object} codersdk.ListInboxNotificationsResponse
+// @Router /users/{user}/notifications/inbox [get]
+func (api *API) userInboxNotifications(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx    = r.Context()
+		user   = httpmw.UserParam(r)
+		logger = api.Logger.Named("notifications.inbox").With(slog.F("user_id", user.ID))
+	)
+
+	page, ok := parsePagination(rw, r)
+	if !ok {
+		return
+	}
+
+	parser := httpapi.NewQueryParamParser()
+	readStatus := httpapi.ParseCustom(parser, r.URL.Query(), codersdk.InboxNotificationReadStatusAll, "read_status", func(v string) (codersdk.InboxNotificationReadStatus, error) {
+		switch status := codersdk.InboxNotificationReadStatus(v); status {
+		case codersdk.InboxNotificationReadStatusAll, codersdk.InboxNotificationReadStatusRead, codersdk.InboxNotificationReadStatusUnread:
+			return status, nil
+		default:
+			return "", xerrors.Errorf("%q is not a valid read status, expected one of %q, %q or %q", v,
+				codersdk.InboxNotificationReadStatusAll, codersdk.InboxNotificationReadStatusRead, codersdk.InboxNotificationReadStatusUnread)
+		}
+	})
+	includeArchived := parser.Boolean(r.URL.Query(), false, "include_archived")
+	if len(parser.Errors) > 0 {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message:     "Invalid query parameters.",
+			Validations: parser.Errors,
+		})
+		return
+	}
+
+	notifs, err := api.Database.GetInboxNotificationsByUserID(ctx, database.GetInboxNotificationsByUserIDParams{
+		UserID:          user.ID,
+		ReadStatus:      string(readStatus),
+		IncludeArchived: includeArchived,
+		AfterID:         page.AfterID,
+		OffsetOpt:       int32(page.Offset),
+		LimitOpt:        int32(page.Limit),
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to retrieve inbox notifications", slog.Error(err))
+
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to retrieve user inbox notifications.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	unread, err := api.Database.CountUnreadInboxNotificationsByUserID(ctx, user.ID)
+	if err != nil {
+		logger.Error(ctx, "failed to count unread inbox notifications", slog.Error(err))
+
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to count unread user inbox notifications.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	out := make([]codersdk.InboxNotification, 0, len(notifs))
+	for _, notif := range notifs {
+		out = append(out, convertInboxNotification(notif))
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, codersdk.ListInboxNotificationsResponse{
+		Notifications: out,
+		UnreadCount:   int(unread),
+	})
+}
+
+// @Summary Watch user inbox notifications
+// @ID watch-user-inbox-notifications
+// @Security CoderSessionToken
+// @Produce text/event-stream
+// @Tags Notifications
+// @Param user path string true "User ID, name, or me"
+// @Success 200 {object} codersdk.Response
+// @Router /users/{user}/notifications/inbox/watch [get]
+func (api *API) watchUserInboxNotifications(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx  = r.Context()
+		user = httpmw.UserParam(r)
+	)
+
+	sendEvent, senderClosed, err := httpapi.ServerSentEventSender(rw, r)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error setting up server-sent events.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	// Prevent handler from returning until the sender is closed.
+	defer func() {
+		<-senderClosed
+	}()
+
+	sendNotification := func(_ context.Context, msg []byte) {
+		id, err := uuid.ParseBytes(msg)
+		if err != nil {
+			_ = sendEvent(ctx, codersdk.ServerSentEvent{
+				Type: codersdk.ServerSentEventTypeError,
+				Data: codersdk.Response{
+					Message: "Internal error parsing inbox notification ID.",
+					Detail:  err.Error(),
+				},
+			})
+			return
+		}
+
+		notif, err := api.Database.GetInboxNotificationByID(ctx, id)
+		if err != nil {
+			_ = sendEvent(ctx, codersdk.ServerSentEvent{
+				Type: codersdk.ServerSentEventTypeError,
+				Data: codersdk.Response{
+					Message: "Internal error fetching inbox notification.",
+					Detail:  err.Error(),
+				},
+			})
+			return
+		}
+
+		_ = sendEvent(ctx, codersdk.ServerSentEvent{
+			Type: codersdk.ServerSentEventTypeData,
+			Data: convertInboxNotification(notif),
+		})
+	}
+
+	cancelSubscribe, err := api.Pubsub.Subscribe(dispatch.InboxNotificationChannel(user.ID), sendNotification)
+	if err != nil {
+		_ = sendEvent(ctx, codersdk.ServerSentEvent{
+			Type: codersdk.ServerSentEventTypeError,
+			Data: codersdk.Response{
+				Message: "Internal error subscribing to inbox notifications.",
+				Detail:  err.Error(),
+			},
+		})
+		return
+	}
+	defer cancelSubscribe()
+
+	// An initial ping signals to the request that the server is now ready
+	// and the client can begin servicing a channel with data.
+	_ = sendEvent(ctx, codersdk.ServerSentEvent{
+		Type: codersdk.ServerSentEventTypePing,
+	})
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-senderClosed:
+			return
+		}
+	}
+}
+
+// @Summary Update user inbox notification
+// @ID update-user-inbox-notification
+// @Security CoderSessionToken
+// @Accept json
+// @Produce json
+// @Tags Notifications
+// @Param user path string true "User ID, name, or me"
+// @Param id path string true "Inbox notification ID" format(uuid)
+// @Param request body codersdk.UpdateInboxNotificationRequest true "Inbox notification state"
+// @Success 200 {object} codersdk.InboxNotification
+// @Router /users/{user}/notifications/inbox/{id} [put]
+func (api *API) putUserInboxNotification(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx    = r.Context()
+		user   = httpmw.UserParam(r)
+		logger = api.Logger.Named("notifications.inbox").With(slog.F("user_id", user.ID))
+	)
+
+	id, ok := httpmw.ParseUUIDParam(rw, r, "id")
+	if !ok {
+		return
+	}
+
+	var req codersdk.UpdateInboxNotificationRequest
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+
+	notif, err := api.Database.GetInboxNotificationByID(ctx, id)
+	if httpapi.Is404Error(err) || (err == nil && notif.UserID != user.ID) {
+		httpapi.ResourceNotFound(rw)
+		return
+	}
+	if err != nil {
+		logger.Error(ctx, "failed to retrieve inbox notification", slog.Error(err), slog.F("id", id))
+
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to retrieve inbox notification.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	now := dbtime.Now()
+	params := database.UpdateInboxNotificationStatusParams{
+		ID:         notif.ID,
+		ReadAt:     notif.ReadAt,
+		ArchivedAt: notif.ArchivedAt,
+	}
+	if req.Read != nil && *req.Read != params.ReadAt.Valid {
+		params.ReadAt = sql.NullTime{Time: now, Valid: *req.Read}
+	}
+	if req.Archived != nil && *req.Archived != params.ArchivedAt.Valid {
+		params.ArchivedAt = sql.NullTime{Time: now, Valid: *req.Archived}
+	}
+
+	updated, err := api.Database.UpdateInboxNotificationStatus(ctx, params)
+	if err != nil {
+		logger.Error(ctx, "failed to update inbox notification", slog.Error(err), slog.F("id", id))
+
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to update inbox notification.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, convertInboxNotification(updated))
+}
+
+// @Summary Mark all user inbox notifications as read
+// @ID mark-all-user-inbox-notifications-as-read
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Notifications
+// @Param user path string true "User ID, name, or me"
+// @Success 200 {object} codersdk.MarkAllInboxNotificationsAsReadResponse
+// @Router /users/{user}/notifications/inbox/mark-all-read [put]
+func (api *API) markAllUserInboxNotificationsAsRead(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx    = r.Context()
+		user   = httpmw.UserParam(r)
+		logger = api.Logger.Named("notifications.inbox").With(slog.F("user_id", user.ID))
+	)
+
+	updated, err := api.Database.MarkAllInboxNotificationsAsRead(ctx, database.MarkAllInboxNotificationsAsReadParams{
+		UserID: user.ID,
+		ReadAt: sql.NullTime{Time: dbtime.Now(), Valid: true},
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to mark inbox notifications as read", slog.Error(err))
+
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to mark user inbox notifications as read.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, codersdk.MarkAllInboxNotificationsAsReadResponse{Updated: updated})
+}
+
+// parseTimeOfDay converts a 24-hour "HH:MM" time into minutes after midnight.
+func parseTimeOfDay(s string) (int32, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, xerrors.Errorf("%q is not a valid time of day, expected HH:MM", s)
+	}
+	return int32(t.Hour()*60 + t.Minute()), nil
+}
+
+func convertNotificationQuietHours(qh database.NotificationQuietHour) codersdk.NotificationQuietHours {
+	return codersdk.NotificationQuietHours{
+		Enabled:  true,
+		Start:    fmt.Sprintf("%02d:%02d", qh.StartMinute/60, qh.StartMinute%60),
+		End:      fmt.Sprintf("%02d:%02d", qh.EndMinute/60, qh.EndMinute%60),
+		Timezone: qh.Timezone,
+	}
+}
+
 func convertNotificationTemplates(in []database.NotificationTemplate) (out []codersdk.NotificationTemplate) {
 	for _, tmpl := range in {
 		out = append(out, codersdk.NotificationTemplate{
@@ -290,9 +773,34 @@ func convertNotificationPreferences(in []database.NotificationPreference) (out [
 		out = append(out, codersdk.NotificationPreference{
 			NotificationTemplateID: pref.NotificationTemplateID,
 			Disabled:               pref.Disabled,
+			Method:                 string(pref.Method.NotificationMethod),
 			UpdatedAt:              pref.UpdatedAt,
 		})
 	}
 
 	return out
 }
+
+func convertInboxNotification(n database.InboxNotification) codersdk.InboxNotification {
+	out := codersdk.InboxNotification{
+		ID:         n.ID,
+		UserID:     n.UserID,
+		TemplateID: n.TemplateID,
+		Title:      n.Title,
+		Content:    n.Content,
+		Actions:    []codersdk.InboxNotificationAction{},
+		CreatedAt:  n.CreatedAt,
+	}
+	// Actions are written by the dispatcher, so they are always well-formed; an unparseable value simply yields none.
+	_ = json.Unmarshal(n.Actions, &out.Actions)
+	if out.Actions == nil {
+		out.Actions = []codersdk.InboxNotificationAction{}
+	}
+	if n.ReadAt.Valid {
+		out.ReadAt = &n.ReadAt.Time
+	}
+	if n.ArchivedAt.Valid {
+		out.ArchivedAt = &n.ArchivedAt.Time
+	}
+	return out
+}
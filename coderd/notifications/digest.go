@@ -0,0 +1,11 @@
+package notifications
+
+import (
+	"time"
+)
+
+// DigestWindowRelease determines when the digest window which the given time falls into ends. Windows are aligned to
+// multiples of their length, so that every message for a template enqueued within the same window is released at once.
+func DigestWindowRelease(window time.Duration, now time.Time) time.Time {
+	return now.Truncate(window).Add(window)
+}
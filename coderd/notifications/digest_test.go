@@ -0,0 +1,65 @@
+package notifications_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/coderd/notifications"
+)
+
+func TestDigestWindowRelease(t *testing.T) {
+	t.Parallel()
+
+	at := func(hour, minute, second int) time.Time {
+		return time.Date(2024, time.September, 20, hour, minute, second, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name   string
+		window time.Duration
+		now    time.Time
+		want   time.Time
+	}{
+		{
+			name:   "start of window",
+			window: 5 * time.Minute,
+			now:    at(12, 0, 0),
+			want:   at(12, 5, 0),
+		},
+		{
+			name:   "middle of window",
+			window: 5 * time.Minute,
+			now:    at(12, 2, 30),
+			want:   at(12, 5, 0),
+		},
+		{
+			name:   "end of window",
+			window: 5 * time.Minute,
+			now:    at(12, 4, 59),
+			want:   at(12, 5, 0),
+		},
+		{
+			name:   "hourly window",
+			window: time.Hour,
+			now:    at(23, 59, 0),
+			want:   time.Date(2024, time.September, 21, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := notifications.DigestWindowRelease(tc.window, tc.now)
+			require.True(t, tc.want.Equal(got), "expected release at %s, got %s", tc.want, got)
+		})
+	}
+
+	// Messages enqueued anywhere within the same window are released together.
+	require.Equal(t,
+		notifications.DigestWindowRelease(time.Minute, at(8, 30, 1)),
+		notifications.DigestWindowRelease(time.Minute, at(8, 30, 59)),
+	)
+}
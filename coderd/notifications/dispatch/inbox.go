@@ -0,0 +1,98 @@
+package dispatch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
+
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
+	"github.com/coder/coder/v2/coderd/database/pubsub"
+	"github.com/coder/coder/v2/coderd/notifications/types"
+	markdown "github.com/coder/coder/v2/coderd/render"
+)
+
+// InboxStore is the subset of the store used by the InboxHandler.
+type InboxStore interface {
+	InsertInboxNotification(ctx context.Context, arg database.InsertInboxNotificationParams) (int64, error)
+}
+
+// InboxHandler dispatches notification messages to the recipient's in-app inbox, where they are persisted so that they
+// can be viewed later in the dashboard or CLI.
+type InboxHandler struct {
+	store  InboxStore
+	pubsub pubsub.Pubsub
+	log    slog.Logger
+}
+
+// InboxNotificationChannel returns the pubsub channel on which the IDs of new inbox notifications for the given user are
+// published.
+func InboxNotificationChannel(userID uuid.UUID) string {
+	return fmt.Sprintf("inbox_notification:%s", userID)
+}
+
+func NewInboxHandler(store InboxStore, ps pubsub.Pubsub, log slog.Logger) *InboxHandler {
+	return &InboxHandler{store: store, pubsub: ps, log: log}
+}
+
+func (s *InboxHandler) Dispatcher(payload types.MessagePayload, titleTmpl, bodyTmpl string) (DeliveryFunc, error) {
+	userID, err := uuid.Parse(payload.UserID)
+	if err != nil {
+		return nil, xerrors.Errorf("parse user ID: %w", err)
+	}
+	templateID, err := uuid.Parse(payload.NotificationTemplateID)
+	if err != nil {
+		return nil, xerrors.Errorf("parse template ID: %w", err)
+	}
+
+	title, err := markdown.PlaintextFromMarkdown(titleTmpl)
+	if err != nil {
+		return nil, xerrors.Errorf("render title: %w", err)
+	}
+
+	actions := payload.Actions
+	if actions == nil {
+		actions = []types.TemplateAction{}
+	}
+	encodedActions, err := json.Marshal(actions)
+	if err != nil {
+		return nil, xerrors.Errorf("marshal actions: %w", err)
+	}
+
+	// The body is stored as markdown so that clients may render it as they see fit.
+	return s.dispatch(userID, templateID, title, bodyTmpl, encodedActions), nil
+}
+
+func (s *InboxHandler) dispatch(userID, templateID uuid.UUID, title, content string, actions json.RawMessage) DeliveryFunc {
+	return func(ctx context.Context, msgID uuid.UUID) (retryable bool, err error) {
+		inserted, err := s.store.InsertInboxNotification(ctx, database.InsertInboxNotificationParams{
+			ID:         msgID,
+			UserID:     userID,
+			TemplateID: templateID,
+			Title:      title,
+			Content:    content,
+			Actions:    actions,
+			CreatedAt:  dbtime.Now(),
+		})
+		if err != nil {
+			return true, xerrors.Errorf("insert inbox notification: %w", err)
+		}
+		if inserted == 0 {
+			// This message was already delivered to the inbox, but its status was not updated; don't notify watchers twice.
+			s.log.Debug(ctx, "inbox notification already exists", slog.F("msg_id", msgID))
+			return false, nil
+		}
+
+		// The notification has been stored at this point, so failing to publish only delays its appearance for any
+		// watchers until their next fetch; it is not worth retrying the dispatch over.
+		if err := s.pubsub.Publish(InboxNotificationChannel(userID), []byte(msgID.String())); err != nil {
+			s.log.Warn(ctx, "failed to publish inbox notification", slog.F("msg_id", msgID), slog.Error(err))
+		}
+		return false, nil
+	}
+}
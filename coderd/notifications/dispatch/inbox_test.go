@@ -0,0 +1,120 @@
+package dispatch_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog/sloggers/slogtest"
+
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbmem"
+	"github.com/coder/coder/v2/coderd/database/pubsub"
+	"github.com/coder/coder/v2/coderd/notifications/dispatch"
+	"github.com/coder/coder/v2/coderd/notifications/types"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestInbox(t *testing.T) {
+	t.Parallel()
+
+	const (
+		title = "Workspace **bobby-dev** deleted"
+		body  = "Hi Bobby,\n\nYour workspace **bobby-dev** was deleted."
+	)
+
+	userID := uuid.New()
+	templateID := uuid.New()
+	msgPayload := types.MessagePayload{
+		Version:                "1.0",
+		NotificationName:       "Workspace Deleted",
+		NotificationTemplateID: templateID.String(),
+		UserID:                 userID.String(),
+		Actions: []types.TemplateAction{
+			{Label: "View workspaces", URL: "https://coder.com/workspaces"},
+		},
+		Labels: map[string]string{},
+	}
+
+	t.Run("Delivers", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+		db := dbmem.New()
+		ps := pubsub.NewInMemory()
+
+		published := make(chan []byte, 2)
+		cancel, err := ps.Subscribe(dispatch.InboxNotificationChannel(userID), func(_ context.Context, msg []byte) {
+			published <- msg
+		})
+		require.NoError(t, err)
+		defer cancel()
+
+		handler := dispatch.NewInboxHandler(db, ps, slogtest.Make(t, nil))
+		deliveryFn, err := handler.Dispatcher(msgPayload, title, body)
+		require.NoError(t, err)
+
+		msgID := uuid.New()
+		retryable, err := deliveryFn(ctx, msgID)
+		require.NoError(t, err)
+		require.False(t, retryable)
+
+		// The notification is stored with a plaintext title and a markdown body.
+		notif, err := db.GetInboxNotificationByID(ctx, msgID)
+		require.NoError(t, err)
+		require.Equal(t, userID, notif.UserID)
+		require.Equal(t, templateID, notif.TemplateID)
+		require.Equal(t, "Workspace bobby-dev deleted", notif.Title)
+		require.Equal(t, body, notif.Content)
+		require.False(t, notif.ReadAt.Valid)
+		var actions []types.TemplateAction
+		require.NoError(t, json.Unmarshal(notif.Actions, &actions))
+		require.Equal(t, msgPayload.Actions, actions)
+
+		// Watchers are told about it.
+		require.Equal(t, msgID.String(), string(testutil.RequireRecvCtx(ctx, t, published)))
+
+		// Delivering the same message again neither duplicates it nor notifies watchers again.
+		retryable, err = deliveryFn(ctx, msgID)
+		require.NoError(t, err)
+		require.False(t, retryable)
+		notifs, err := db.GetInboxNotificationsByUserID(ctx, database.GetInboxNotificationsByUserIDParams{UserID: userID})
+		require.NoError(t, err)
+		require.Len(t, notifs, 1)
+		require.Empty(t, published)
+	})
+
+	t.Run("Invalid user ID", func(t *testing.T) {
+		t.Parallel()
+
+		payload := msgPayload
+		payload.UserID = "not-a-uuid"
+
+		handler := dispatch.NewInboxHandler(dbmem.New(), pubsub.NewInMemory(), slogtest.Make(t, nil))
+		_, err := handler.Dispatcher(payload, title, body)
+		require.ErrorContains(t, err, "parse user ID")
+	})
+
+	t.Run("Store failure is retryable", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+		handler := dispatch.NewInboxHandler(failingInboxStore{}, pubsub.NewInMemory(), slogtest.Make(t, nil))
+		deliveryFn, err := handler.Dispatcher(msgPayload, title, body)
+		require.NoError(t, err)
+
+		retryable, err := deliveryFn(ctx, uuid.New())
+		require.ErrorContains(t, err, "insert inbox notification")
+		require.True(t, retryable)
+	})
+}
+
+type failingInboxStore struct{}
+
+func (failingInboxStore) InsertInboxNotification(context.Context, database.InsertInboxNotificationParams) (int64, error) {
+	return 0, xerrors.New("database is unavailable")
+}
@@ -0,0 +1,300 @@
+package dispatch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
+
+	"github.com/coder/coder/v2/coderd/notifications/types"
+	markdown "github.com/coder/coder/v2/coderd/render"
+	"github.com/coder/coder/v2/codersdk"
+)
+
+// Slack limits the length of a header's and a section's text, the number of elements in an actions block, and the
+// length of a button's text. See https://api.slack.com/reference/block-kit/blocks.
+const (
+	slackMaxHeader      = 150
+	slackMaxSection     = 3000
+	slackMaxActions     = 25
+	slackMaxButtonLabel = 75
+)
+
+// SlackHandler dispatches notification messages to Slack.
+//
+// If a bot token is configured, messages are sent as direct messages to the recipient, who is looked up in the Slack
+// workspace by their email address. Otherwise, messages are posted to the configured incoming webhook.
+type SlackHandler struct {
+	cfg codersdk.NotificationsSlackConfig
+	log slog.Logger
+
+	cl *http.Client
+}
+
+// SlackMessage describes a message to be posted to Slack, composed of Block Kit blocks.
+// See https://api.slack.com/reference/block-kit.
+type SlackMessage struct {
+	// Channel is only used when posting via the Web API; incoming webhooks are bound to a channel.
+	Channel string `json:"channel,omitempty"`
+	// Text is used as a fallback for notifications and clients which cannot render blocks.
+	Text   string       `json:"text"`
+	Blocks []SlackBlock `json:"blocks"`
+}
+
+type SlackBlock struct {
+	Type     string         `json:"type"`
+	Text     *SlackText     `json:"text,omitempty"`
+	Elements []SlackElement `json:"elements,omitempty"`
+}
+
+type SlackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type SlackElement struct {
+	Type     string     `json:"type"`
+	Text     *SlackText `json:"text,omitempty"`
+	URL      string     `json:"url,omitempty"`
+	ActionID string     `json:"action_id,omitempty"`
+}
+
+// slackResponse is the common envelope of all Slack Web API responses.
+type slackResponse struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+	User  struct {
+		ID string `json:"id"`
+	} `json:"user"`
+}
+
+func NewSlackHandler(cfg codersdk.NotificationsSlackConfig, log slog.Logger) *SlackHandler {
+	return &SlackHandler{cfg: cfg, log: log, cl: &http.Client{}}
+}
+
+func (s *SlackHandler) Dispatcher(payload types.MessagePayload, titleTmpl, bodyTmpl string) (DeliveryFunc, error) {
+	if s.cfg.BotToken.String() == "" && s.cfg.Endpoint.String() == "" {
+		return nil, xerrors.New("neither slack bot token nor endpoint defined")
+	}
+
+	title, err := markdown.PlaintextFromMarkdown(titleTmpl)
+	if err != nil {
+		return nil, xerrors.Errorf("render title: %w", err)
+	}
+	body, err := markdown.PlaintextFromMarkdown(bodyTmpl)
+	if err != nil {
+		return nil, xerrors.Errorf("render body: %w", err)
+	}
+
+	msg := SlackMessage{
+		Text:   title,
+		Blocks: slackBlocks(title, body, payload.Actions),
+	}
+
+	if s.cfg.BotToken.String() != "" {
+		if payload.UserEmail == "" {
+			return nil, xerrors.New("recipient has no email address")
+		}
+		return s.dispatchDirect(msg, payload.UserEmail), nil
+	}
+	return s.dispatchWebhook(msg), nil
+}
+
+func slackBlocks(title, body string, actions []types.TemplateAction) []SlackBlock {
+	blocks := []SlackBlock{
+		{Type: "header", Text: &SlackText{Type: "plain_text", Text: slackTruncate(title, slackMaxHeader)}},
+		{Type: "section", Text: &SlackText{Type: "plain_text", Text: slackTruncate(body, slackMaxSection)}},
+	}
+
+	if len(actions) == 0 {
+		return blocks
+	}
+
+	buttons := make([]SlackElement, 0, len(actions))
+	for i, action := range actions {
+		if i >= slackMaxActions {
+			break
+		}
+		buttons = append(buttons, SlackElement{
+			Type:     "button",
+			Text:     &SlackText{Type: "plain_text", Text: slackTruncate(action.Label, slackMaxButtonLabel)},
+			URL:      action.URL,
+			ActionID: "action_" + strconv.Itoa(i),
+		})
+	}
+	return append(blocks, SlackBlock{Type: "actions", Elements: buttons})
+}
+
+// slackTruncate cuts the text to the given number of characters, since Slack rejects the whole message if a text is
+// too long.
+func slackTruncate(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) > limit {
+		runes = runes[:limit]
+	}
+	return string(runes)
+}
+
+// dispatchWebhook posts the message to an incoming webhook.
+func (s *SlackHandler) dispatchWebhook(msg SlackMessage) DeliveryFunc {
+	return func(ctx context.Context, msgID uuid.UUID) (retryable bool, err error) {
+		m, err := json.Marshal(msg)
+		if err != nil {
+			return false, xerrors.Errorf("marshal payload: %v", err)
+		}
+
+		// Outer context has a deadline (see CODER_NOTIFICATIONS_DISPATCH_TIMEOUT).
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.Endpoint.String(), bytes.NewBuffer(m))
+		if err != nil {
+			return false, xerrors.Errorf("create HTTP request: %v", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := s.cl.Do(req)
+		if err != nil {
+			return handleRequestErr(err)
+		}
+		defer resp.Body.Close()
+
+		return s.handleResponse(ctx, msgID, resp)
+	}
+}
+
+// dispatchDirect looks up the recipient by their email address and sends them a direct message via the Web API.
+func (s *SlackHandler) dispatchDirect(msg SlackMessage, email string) DeliveryFunc {
+	return func(ctx context.Context, msgID uuid.UUID) (retryable bool, err error) {
+		userID, retryable, err := s.lookupUser(ctx, msgID, email)
+		if err != nil {
+			return retryable, xerrors.Errorf("lookup user: %w", err)
+		}
+
+		// Posting to a user ID opens a direct message conversation with the bot.
+		msg.Channel = userID
+		m, err := json.Marshal(msg)
+		if err != nil {
+			return false, xerrors.Errorf("marshal payload: %v", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.apiURL("chat.postMessage"), bytes.NewBuffer(m))
+		if err != nil {
+			return false, xerrors.Errorf("create HTTP request: %v", err)
+		}
+		req.Header.Set("Content-Type", "application/json; charset=utf-8")
+		req.Header.Set("Authorization", "Bearer "+s.cfg.BotToken.String())
+
+		resp, err := s.cl.Do(req)
+		if err != nil {
+			return handleRequestErr(err)
+		}
+		defer resp.Body.Close()
+
+		return s.handleResponse(ctx, msgID, resp)
+	}
+}
+
+func (s *SlackHandler) lookupUser(ctx context.Context, msgID uuid.UUID, email string) (string, bool, error) {
+	endpoint := s.apiURL("users.lookupByEmail") + "?" + url.Values{"email": {email}}.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", false, xerrors.Errorf("create HTTP request: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+s.cfg.BotToken.String())
+
+	resp, err := s.cl.Do(req)
+	if err != nil {
+		retryable, err := handleRequestErr(err)
+		return "", retryable, err
+	}
+	defer resp.Body.Close()
+
+	var sr slackResponse
+	if retryable, err := s.decodeResponse(ctx, msgID, resp, &sr); err != nil {
+		return "", retryable, err
+	}
+	if sr.User.ID == "" {
+		return "", false, xerrors.New("no user ID in response")
+	}
+	return sr.User.ID, false, nil
+}
+
+func (s *SlackHandler) apiURL(method string) string {
+	base := s.cfg.APIURL.String()
+	if base == "" {
+		base = "https://slack.com/api"
+	}
+	return base + "/" + method
+}
+
+// handleResponse interprets the response to a message post. Incoming webhooks respond with a plain "ok" body while
+// the Web API responds with a JSON envelope, so only the latter is decoded.
+func (s *SlackHandler) handleResponse(ctx context.Context, msgID uuid.UUID, resp *http.Response) (bool, error) {
+	if !strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json") {
+		return checkHTTPResponse(ctx, s.log, msgID, resp)
+	}
+
+	var sr slackResponse
+	return s.decodeResponse(ctx, msgID, resp, &sr)
+}
+
+func (s *SlackHandler) decodeResponse(ctx context.Context, msgID uuid.UUID, resp *http.Response, sr *slackResponse) (bool, error) {
+	if retryable, err := checkHTTPResponse(ctx, s.log, msgID, resp); err != nil {
+		return retryable, err
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(sr); err != nil {
+		return true, xerrors.Errorf("decode response: %w", err)
+	}
+	if !sr.OK {
+		// Errors returned with a 200 status (e.g. "users_not_found", "invalid_auth", "channel_not_found") are
+		// configuration or data problems which will not resolve themselves by retrying.
+		return false, xerrors.Errorf("slack API error: %s", sr.Error)
+	}
+	return false, nil
+}
+
+// handleRequestErr classifies an error returned by http.Client.Do; these are generally transient.
+func handleRequestErr(err error) (bool, error) {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true, xerrors.Errorf("request timeout: %w", err)
+	}
+	return true, xerrors.Errorf("request failed: %w", err)
+}
+
+// checkHTTPResponse validates the status code of a chat service's response, returning a *RateLimitedError if the
+// request was throttled.
+func checkHTTPResponse(ctx context.Context, log slog.Logger, msgID uuid.UUID, resp *http.Response) (bool, error) {
+	if resp.StatusCode == http.StatusTooManyRequests {
+		var retryAfter time.Duration
+		if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+			retryAfter = time.Duration(secs) * time.Second
+		}
+		return true, &RateLimitedError{RetryAfter: retryAfter}
+	}
+
+	if resp.StatusCode/100 > 2 {
+		// Body could be quite long here, let's grab the first 512B and hope it contains useful debug info.
+		respBody := make([]byte, 512)
+		lr := io.LimitReader(resp.Body, int64(len(respBody)))
+		n, err := lr.Read(respBody)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return true, xerrors.Errorf("non-2xx response (%d), read body: %w", resp.StatusCode, err)
+		}
+		log.Warn(ctx, "unsuccessful delivery", slog.F("status_code", resp.StatusCode),
+			slog.F("response", respBody[:n]), slog.F("msg_id", msgID))
+		// Client errors other than throttling indicate a malformed request or a revoked endpoint.
+		return resp.StatusCode/100 != 4, xerrors.Errorf("non-2xx response (%d)", resp.StatusCode)
+	}
+
+	return false, nil
+}
@@ -0,0 +1,251 @@
+package dispatch_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"cdr.dev/slog"
+	"cdr.dev/slog/sloggers/slogtest"
+	"github.com/coder/serpent"
+
+	"github.com/coder/coder/v2/coderd/notifications/dispatch"
+	"github.com/coder/coder/v2/coderd/notifications/types"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestSlack(t *testing.T) {
+	t.Parallel()
+
+	const (
+		title = "Workspace \"bobby-dev\" deleted"
+		body  = "Hi Bobby,\n\nYour workspace **bobby-dev** was deleted."
+		email = "bobby@coder.com"
+		token = "xoxb-test"
+	)
+
+	msgPayload := types.MessagePayload{
+		Version:          "1.0",
+		NotificationName: "Workspace Deleted",
+		UserEmail:        email,
+		Actions: []types.TemplateAction{
+			{Label: "View workspaces", URL: "https://coder.com/workspaces"},
+		},
+		Labels: map[string]string{},
+	}
+
+	tests := []struct {
+		name     string
+		botToken string
+		serverFn func(w http.ResponseWriter, r *http.Request)
+
+		expectSuccess   bool
+		expectRetryable bool
+		expectErr       string
+	}{
+		{
+			name: "webhook",
+			serverFn: func(w http.ResponseWriter, r *http.Request) {
+				var msg dispatch.SlackMessage
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&msg))
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				assert.Empty(t, msg.Channel)
+				assertSlackMessage(t, msg)
+
+				_, _ = w.Write([]byte("ok"))
+			},
+			expectSuccess: true,
+		},
+		{
+			name:     "direct message",
+			botToken: token,
+			serverFn: func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "Bearer "+token, r.Header.Get("Authorization"))
+				w.Header().Set("Content-Type", "application/json")
+
+				switch r.URL.Path {
+				case "/users.lookupByEmail":
+					assert.Equal(t, email, r.URL.Query().Get("email"))
+					_, _ = w.Write([]byte(`{"ok":true,"user":{"id":"U012AB3CD"}}`))
+				case "/chat.postMessage":
+					var msg dispatch.SlackMessage
+					assert.NoError(t, json.NewDecoder(r.Body).Decode(&msg))
+					assert.Equal(t, "U012AB3CD", msg.Channel)
+					assertSlackMessage(t, msg)
+					_, _ = w.Write([]byte(`{"ok":true}`))
+				default:
+					t.Errorf("unexpected request to %s", r.URL.Path)
+				}
+			},
+			expectSuccess: true,
+		},
+		{
+			name:     "user not found",
+			botToken: token,
+			serverFn: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"ok":false,"error":"users_not_found"}`))
+			},
+			expectSuccess:   false,
+			expectRetryable: false,
+			expectErr:       "users_not_found",
+		},
+		{
+			name: "rate limited",
+			serverFn: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Retry-After", "30")
+				w.WriteHeader(http.StatusTooManyRequests)
+			},
+			expectSuccess:   false,
+			expectRetryable: true,
+			expectErr:       "rate limited, retry after 30s",
+		},
+		{
+			name: "webhook revoked",
+			serverFn: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte("no_service"))
+			},
+			expectSuccess:   false,
+			expectRetryable: false,
+			expectErr:       "non-2xx response (404)",
+		},
+		{
+			name: "server error",
+			serverFn: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			expectSuccess:   false,
+			expectRetryable: true,
+			expectErr:       "non-2xx response (500)",
+		},
+	}
+
+	logger := slogtest.Make(t, &slogtest.Options{IgnoreErrors: true}).Leveled(slog.LevelDebug)
+
+	// nolint:paralleltest // Irrelevant as of Go v1.22
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+			t.Cleanup(cancel)
+
+			server := httptest.NewServer(http.HandlerFunc(tc.serverFn))
+			t.Cleanup(server.Close)
+
+			endpoint, err := url.Parse(server.URL)
+			require.NoError(t, err)
+
+			cfg := codersdk.NotificationsSlackConfig{
+				APIURL:   *serpent.URLOf(endpoint),
+				BotToken: serpent.String(tc.botToken),
+			}
+			if tc.botToken == "" {
+				cfg.Endpoint = *serpent.URLOf(endpoint)
+			}
+
+			handler := dispatch.NewSlackHandler(cfg, logger.With(slog.F("test", tc.name)))
+			deliveryFn, err := handler.Dispatcher(msgPayload, title, body)
+			require.NoError(t, err)
+
+			retryable, err := deliveryFn(ctx, uuid.New())
+			if tc.expectSuccess {
+				require.NoError(t, err)
+				require.False(t, retryable)
+				return
+			}
+
+			require.ErrorContains(t, err, tc.expectErr)
+			require.Equal(t, tc.expectRetryable, retryable)
+		})
+	}
+}
+
+func TestSlackNotConfigured(t *testing.T) {
+	t.Parallel()
+
+	handler := dispatch.NewSlackHandler(codersdk.NotificationsSlackConfig{}, slogtest.Make(t, nil))
+	_, err := handler.Dispatcher(types.MessagePayload{}, "title", "body")
+	require.ErrorContains(t, err, "neither slack bot token nor endpoint defined")
+}
+
+func TestSlackHeaderTruncated(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutil.Context(t, testutil.WaitShort)
+	title := strings.Repeat("é", 200)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg dispatch.SlackMessage
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&msg))
+		// Slack rejects headers longer than 150 characters, but the fallback text is not limited.
+		assert.Equal(t, title, msg.Text)
+		if assert.NotEmpty(t, msg.Blocks) {
+			assert.Equal(t, strings.Repeat("é", 150), msg.Blocks[0].Text.Text)
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	t.Cleanup(server.Close)
+
+	endpoint, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	handler := dispatch.NewSlackHandler(codersdk.NotificationsSlackConfig{Endpoint: *serpent.URLOf(endpoint)}, slogtest.Make(t, nil))
+	deliveryFn, err := handler.Dispatcher(types.MessagePayload{}, title, "body")
+	require.NoError(t, err)
+
+	_, err = deliveryFn(ctx, uuid.New())
+	require.NoError(t, err)
+}
+
+func TestSlackSectionTruncated(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutil.Context(t, testutil.WaitShort)
+	body := strings.Repeat("é", 4000)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg dispatch.SlackMessage
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&msg))
+		// Slack rejects sections longer than 3000 characters.
+		if assert.Len(t, msg.Blocks, 2) {
+			assert.Equal(t, "section", msg.Blocks[1].Type)
+			assert.Equal(t, strings.Repeat("é", 3000), msg.Blocks[1].Text.Text)
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	t.Cleanup(server.Close)
+
+	endpoint, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	handler := dispatch.NewSlackHandler(codersdk.NotificationsSlackConfig{Endpoint: *serpent.URLOf(endpoint)}, slogtest.Make(t, nil))
+	deliveryFn, err := handler.Dispatcher(types.MessagePayload{}, "title", body)
+	require.NoError(t, err)
+
+	_, err = deliveryFn(ctx, uuid.New())
+	require.NoError(t, err)
+}
+
+func assertSlackMessage(t *testing.T, msg dispatch.SlackMessage) {
+	t.Helper()
+
+	assert.Equal(t, "Workspace \"bobby-dev\" deleted", msg.Text)
+	if !assert.Len(t, msg.Blocks, 3) {
+		return
+	}
+	assert.Equal(t, "header", msg.Blocks[0].Type)
+	assert.Equal(t, "section", msg.Blocks[1].Type)
+	assert.Contains(t, msg.Blocks[1].Text.Text, "Your workspace bobby-dev was deleted.")
+	assert.Equal(t, "actions", msg.Blocks[2].Type)
+	if assert.Len(t, msg.Blocks[2].Elements, 1) {
+		assert.Equal(t, "View workspaces", msg.Blocks[2].Elements[0].Text.Text)
+		assert.Equal(t, "https://coder.com/workspaces", msg.Blocks[2].Elements[0].URL)
+	}
+}
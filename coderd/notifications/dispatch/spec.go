@@ -2,6 +2,8 @@ package dispatch
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -11,3 +13,17 @@ import (
 // any error that may have arisen.
 // If (false, nil) is returned, that is considered a successful dispatch.
 type DeliveryFunc func(ctx context.Context, msgID uuid.UUID) (retryable bool, err error)
+
+// RateLimitedError is returned by a DeliveryFunc when the receiving service has throttled the request.
+// These errors are always retryable.
+type RateLimitedError struct {
+	// RetryAfter is the delay requested by the service, if any.
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitedError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("rate limited, retry after %s", e.RetryAfter)
+	}
+	return "rate limited"
+}
@@ -0,0 +1,136 @@
+package dispatch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
+
+	"github.com/coder/coder/v2/coderd/notifications/types"
+	markdown "github.com/coder/coder/v2/coderd/render"
+	"github.com/coder/coder/v2/codersdk"
+)
+
+const (
+	adaptiveCardContentType = "application/vnd.microsoft.card.adaptive"
+	adaptiveCardSchema      = "http://adaptivecards.io/schemas/adaptive-card.json"
+	adaptiveCardVersion     = "1.4"
+)
+
+// TeamsHandler dispatches notification messages to a Microsoft Teams workflow webhook as Adaptive Cards.
+// See https://learn.microsoft.com/en-us/microsoftteams/platform/task-modules-and-cards/cards/cards-reference#adaptive-card.
+type TeamsHandler struct {
+	cfg codersdk.NotificationsTeamsConfig
+	log slog.Logger
+
+	cl *http.Client
+}
+
+// TeamsMessage describes the JSON payload delivered to the Teams workflow webhook.
+type TeamsMessage struct {
+	Type        string            `json:"type"`
+	Attachments []TeamsAttachment `json:"attachments"`
+}
+
+type TeamsAttachment struct {
+	ContentType string       `json:"contentType"`
+	Content     AdaptiveCard `json:"content"`
+}
+
+type AdaptiveCard struct {
+	Schema  string                `json:"$schema"`
+	Type    string                `json:"type"`
+	Version string                `json:"version"`
+	Body    []AdaptiveCardElement `json:"body"`
+	Actions []AdaptiveCardAction  `json:"actions,omitempty"`
+	// MsgID is not part of the Adaptive Card schema, but is carried along for workflows which want to deduplicate.
+	MsgID uuid.UUID `json:"msg_id"`
+}
+
+type AdaptiveCardElement struct {
+	Type   string `json:"type"`
+	Text   string `json:"text"`
+	Size   string `json:"size,omitempty"`
+	Weight string `json:"weight,omitempty"`
+	Wrap   bool   `json:"wrap"`
+}
+
+type AdaptiveCardAction struct {
+	Type  string `json:"type"`
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+func NewTeamsHandler(cfg codersdk.NotificationsTeamsConfig, log slog.Logger) *TeamsHandler {
+	return &TeamsHandler{cfg: cfg, log: log, cl: &http.Client{}}
+}
+
+func (t *TeamsHandler) Dispatcher(payload types.MessagePayload, titleTmpl, bodyTmpl string) (DeliveryFunc, error) {
+	if t.cfg.Endpoint.String() == "" {
+		return nil, xerrors.New("teams endpoint not defined")
+	}
+
+	title, err := markdown.PlaintextFromMarkdown(titleTmpl)
+	if err != nil {
+		return nil, xerrors.Errorf("render title: %w", err)
+	}
+	// Adaptive Card TextBlocks support a subset of markdown, which our templates make use of.
+	// See https://learn.microsoft.com/en-us/adaptive-cards/authoring-cards/text-features.
+	body := bodyTmpl
+
+	card := AdaptiveCard{
+		Schema:  adaptiveCardSchema,
+		Type:    "AdaptiveCard",
+		Version: adaptiveCardVersion,
+		Body: []AdaptiveCardElement{
+			{Type: "TextBlock", Text: title, Size: "Large", Weight: "Bolder", Wrap: true},
+			{Type: "TextBlock", Text: body, Wrap: true},
+		},
+	}
+	for _, action := range payload.Actions {
+		card.Actions = append(card.Actions, AdaptiveCardAction{
+			Type:  "Action.OpenUrl",
+			Title: action.Label,
+			URL:   action.URL,
+		})
+	}
+
+	return t.dispatch(card), nil
+}
+
+func (t *TeamsHandler) dispatch(card AdaptiveCard) DeliveryFunc {
+	return func(ctx context.Context, msgID uuid.UUID) (retryable bool, err error) {
+		card.MsgID = msgID
+		msg := TeamsMessage{
+			Type: "message",
+			Attachments: []TeamsAttachment{
+				{ContentType: adaptiveCardContentType, Content: card},
+			},
+		}
+		m, err := json.Marshal(msg)
+		if err != nil {
+			return false, xerrors.Errorf("marshal payload: %v", err)
+		}
+
+		// Outer context has a deadline (see CODER_NOTIFICATIONS_DISPATCH_TIMEOUT).
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.cfg.Endpoint.String(), bytes.NewBuffer(m))
+		if err != nil {
+			return false, xerrors.Errorf("create HTTP request: %v", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Message-Id", msgID.String())
+
+		resp, err := t.cl.Do(req)
+		if err != nil {
+			return handleRequestErr(err)
+		}
+		defer resp.Body.Close()
+
+		return checkHTTPResponse(ctx, t.log, msgID, resp)
+	}
+}
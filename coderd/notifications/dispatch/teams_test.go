@@ -0,0 +1,131 @@
+package dispatch_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"cdr.dev/slog"
+	"cdr.dev/slog/sloggers/slogtest"
+	"github.com/coder/serpent"
+
+	"github.com/coder/coder/v2/coderd/notifications/dispatch"
+	"github.com/coder/coder/v2/coderd/notifications/types"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestTeams(t *testing.T) {
+	t.Parallel()
+
+	msgPayload := types.MessagePayload{
+		Version:          "1.0",
+		NotificationName: "Workspace Deleted",
+		Actions: []types.TemplateAction{
+			{Label: "View workspaces", URL: "https://coder.com/workspaces"},
+		},
+		Labels: map[string]string{},
+	}
+
+	tests := []struct {
+		name     string
+		serverFn func(uuid.UUID, http.ResponseWriter, *http.Request)
+
+		expectSuccess   bool
+		expectRetryable bool
+		expectErr       string
+	}{
+		{
+			name: "successful",
+			serverFn: func(msgID uuid.UUID, w http.ResponseWriter, r *http.Request) {
+				var msg dispatch.TeamsMessage
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&msg))
+				assert.Equal(t, msgID.String(), r.Header.Get("X-Message-Id"))
+				assert.Equal(t, "message", msg.Type)
+				if !assert.Len(t, msg.Attachments, 1) {
+					return
+				}
+
+				card := msg.Attachments[0].Content
+				assert.Equal(t, "application/vnd.microsoft.card.adaptive", msg.Attachments[0].ContentType)
+				assert.Equal(t, "AdaptiveCard", card.Type)
+				assert.Equal(t, msgID, card.MsgID)
+				if assert.Len(t, card.Body, 2) {
+					assert.Equal(t, "Workspace deleted", card.Body[0].Text)
+					assert.Equal(t, "Your workspace **bobby-dev** was deleted.", card.Body[1].Text)
+				}
+				if assert.Len(t, card.Actions, 1) {
+					assert.Equal(t, "Action.OpenUrl", card.Actions[0].Type)
+					assert.Equal(t, "View workspaces", card.Actions[0].Title)
+					assert.Equal(t, "https://coder.com/workspaces", card.Actions[0].URL)
+				}
+
+				w.WriteHeader(http.StatusAccepted)
+			},
+			expectSuccess: true,
+		},
+		{
+			name: "rate limited",
+			serverFn: func(_ uuid.UUID, w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusTooManyRequests)
+			},
+			expectSuccess:   false,
+			expectRetryable: true,
+			expectErr:       "rate limited",
+		},
+		{
+			name: "bad request",
+			serverFn: func(_ uuid.UUID, w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusBadRequest)
+			},
+			expectSuccess:   false,
+			expectRetryable: false,
+			expectErr:       "non-2xx response (400)",
+		},
+	}
+
+	logger := slogtest.Make(t, &slogtest.Options{IgnoreErrors: true}).Leveled(slog.LevelDebug)
+
+	// nolint:paralleltest // Irrelevant as of Go v1.22
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+			t.Cleanup(cancel)
+
+			msgID := uuid.New()
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				tc.serverFn(msgID, w, r)
+			}))
+			t.Cleanup(server.Close)
+
+			endpoint, err := url.Parse(server.URL)
+			require.NoError(t, err)
+
+			cfg := codersdk.NotificationsTeamsConfig{
+				Endpoint: *serpent.URLOf(endpoint),
+			}
+			handler := dispatch.NewTeamsHandler(cfg, logger.With(slog.F("test", tc.name)))
+			deliveryFn, err := handler.Dispatcher(msgPayload, "Workspace deleted", "Your workspace **bobby-dev** was deleted.")
+			require.NoError(t, err)
+
+			retryable, err := deliveryFn(ctx, msgID)
+			if tc.expectSuccess {
+				require.NoError(t, err)
+				require.False(t, retryable)
+				return
+			}
+
+			require.ErrorContains(t, err, tc.expectErr)
+			require.Equal(t, tc.expectRetryable, retryable)
+		})
+	}
+}
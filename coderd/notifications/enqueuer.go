@@ -2,9 +2,12 @@ package notifications
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"strings"
 	"text/template"
+	"time"
 
 	"github.com/google/uuid"
 	"golang.org/x/xerrors"
@@ -29,6 +32,9 @@ type StoreEnqueuer struct {
 	log   slog.Logger
 
 	defaultMethod database.NotificationMethod
+	// digestWindow is the length of the window over which messages for templates with a digest are collected before the
+	// digest generator delivers them together. Zero disables digests.
+	digestWindow time.Duration
 	// helpers holds a map of template funcs which are used when rendering templates. These need to be passed in because
 	// the template funcs will return values which are inappropriately encapsulated in this struct.
 	helpers template.FuncMap
@@ -47,6 +53,7 @@ func NewStoreEnqueuer(cfg codersdk.NotificationsConfig, store Store, helpers tem
 		store:         store,
 		log:           log,
 		defaultMethod: method,
+		digestWindow:  cfg.DigestWindow.Value(),
 		helpers:       helpers,
 		clock:         clock,
 	}, nil
@@ -73,6 +80,27 @@ func (s *StoreEnqueuer) EnqueueWithData(ctx context.Context, userID, templateID
 	if metadata.CustomMethod.Valid {
 		dispatchMethod = metadata.CustomMethod.NotificationMethod
 	}
+	// A user's own choice of method takes precedence over the template's and the deployment's.
+	if metadata.UserMethod.Valid {
+		dispatchMethod = metadata.UserMethod.NotificationMethod
+	}
+
+	// Messages for templates with a digest await the digest generator, which delivers those for the same user together
+	// once the digest window ends.
+	var (
+		digestTemplateID uuid.NullUUID
+		digestWindow     time.Duration
+	)
+	if metadata.DigestTemplateID.Valid && s.digestWindow > 0 {
+		digestTemplateID = metadata.DigestTemplateID
+		digestWindow = s.digestWindow
+	}
+
+	heldUntil, err := s.heldUntil(ctx, userID, templateID, digestWindow)
+	if err != nil {
+		s.log.Warn(ctx, "failed to determine hold", slog.F("template_id", templateID), slog.F("user_id", userID), slog.Error(err))
+		return nil, xerrors.Errorf("enqueue notification (hold): %w", err)
+	}
 
 	payload, err := s.buildPayload(metadata, labels, data)
 	if err != nil {
@@ -95,6 +123,8 @@ func (s *StoreEnqueuer) EnqueueWithData(ctx context.Context, userID, templateID
 		Targets:                targets,
 		CreatedBy:              createdBy,
 		CreatedAt:              dbtime.Time(s.clock.Now().UTC()),
+		HeldUntil:              heldUntil,
+		DigestTemplateID:       digestTemplateID,
 	})
 	if err != nil {
 		// We have a trigger on the notification_messages table named `inhibit_enqueue_if_disabled` which prevents messages
@@ -117,10 +147,62 @@ func (s *StoreEnqueuer) EnqueueWithData(ctx context.Context, userID, templateID
 		return nil, xerrors.Errorf("enqueue notification: %w", err)
 	}
 
-	s.log.Debug(ctx, "enqueued notification", slog.F("msg_id", id))
+	s.log.Debug(ctx, "enqueued notification", slog.F("msg_id", id), slog.F("held_until", heldUntil.Time))
 	return &id, nil
 }
 
+// heldUntil determines whether a message should be held back before it is dispatched, and if so until when.
+// Messages awaiting a digest are held until the end of the current digest window so that they can be delivered
+// together. Non-urgent notifications are additionally held until the end of the user's quiet hours; all
+// others are dispatched as soon as possible.
+func (s *StoreEnqueuer) heldUntil(ctx context.Context, userID, templateID uuid.UUID, digestWindow time.Duration) (sql.NullTime, error) {
+	var release time.Time
+	if digestWindow > 0 {
+		release = DigestWindowRelease(digestWindow, s.clock.Now())
+	}
+
+	quietRelease, err := s.quietHoursRelease(ctx, userID, templateID)
+	if err != nil {
+		return sql.NullTime{}, err
+	}
+	if quietRelease.After(release) {
+		release = quietRelease
+	}
+
+	if release.IsZero() {
+		return sql.NullTime{}, nil
+	}
+	return sql.NullTime{Time: dbtime.Time(release.UTC()), Valid: true}, nil
+}
+
+// quietHoursRelease determines when the user's quiet hours end, if the message is non-urgent and enqueued during them.
+// A zero time is returned if the message should not be held.
+func (s *StoreEnqueuer) quietHoursRelease(ctx context.Context, userID, templateID uuid.UUID) (time.Time, error) {
+	if _, ok := nonUrgentTemplates[templateID]; !ok {
+		return time.Time{}, nil
+	}
+
+	qh, err := s.store.GetUserNotificationQuietHours(ctx, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, xerrors.Errorf("get quiet hours: %w", err)
+	}
+
+	release, held, err := QuietHoursRelease(int(qh.StartMinute), int(qh.EndMinute), qh.Timezone, s.clock.Now())
+	if err != nil {
+		// Misconfigured quiet hours should not prevent the notification from being delivered.
+		s.log.Warn(ctx, "invalid quiet hours, ignoring", slog.F("user_id", userID), slog.Error(err))
+		return time.Time{}, nil
+	}
+	if !held {
+		return time.Time{}, nil
+	}
+
+	return release, nil
+}
+
 // buildPayload creates the payload that the notification will for variable substitution and/or routing.
 // The payload contains information about the recipient, the event that triggered the notification, and any subsequent
 // actions which can be taken by the recipient.
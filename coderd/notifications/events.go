@@ -13,6 +13,10 @@ var (
 	TemplateWorkspaceAutoUpdated       = uuid.MustParse("c34a0c09-0704-4cac-bd1c-0c0146811c2b")
 	TemplateWorkspaceMarkedForDeletion = uuid.MustParse("51ce2fdf-c9ca-4be1-8d70-628674f9bc42")
 	TemplateWorkspaceManualBuildFailed = uuid.MustParse("2faeee0f-26cb-4e96-821c-85ccb9f71513")
+	TemplateWorkspaceDrifted           = uuid.MustParse("8b4a1c8e-3f2d-4c6b-9e7a-5d1f0a2b6c94")
+
+	TemplateWorkspacesDormantDigest           = uuid.MustParse("7f1c8a4e-3b9d-4e2a-8c6f-1d5e9b2a7c30")
+	TemplateWorkspacesMarkedForDeletionDigest = uuid.MustParse("c2e5d9b1-6a4f-4f8e-9d3c-8b7a2e1f5d46")
 )
 
 // Account-related events.
@@ -32,3 +36,15 @@ var (
 
 	TemplateWorkspaceBuildsFailedReport = uuid.MustParse("34a20db2-e9cc-4a93-b0e4-8569699d7a00")
 )
+
+// nonUrgentTemplates are notifications which are not time-sensitive, and are therefore held back during a user's quiet
+// hours rather than being dispatched immediately.
+var nonUrgentTemplates = map[uuid.UUID]struct{}{
+	TemplateWorkspaceAutoUpdated:        {},
+	TemplateWorkspaceDormant:            {},
+	TemplateWorkspaceMarkedForDeletion:  {},
+	TemplateWorkspaceBuildsFailedReport: {},
+
+	TemplateWorkspacesDormantDigest:           {},
+	TemplateWorkspacesMarkedForDeletionDigest: {},
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/coder/quartz"
 
 	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/pubsub"
 	"github.com/coder/coder/v2/coderd/notifications/dispatch"
 	"github.com/coder/coder/v2/codersdk"
 )
@@ -72,9 +73,11 @@ func WithTestClock(clock quartz.Clock) ManagerOption {
 
 // NewManager instantiates a new Manager instance which coordinates notification enqueuing and delivery.
 //
+// ps is used to inform watchers of a user's inbox about newly-delivered inbox notifications.
+//
 // helpers is a map of template helpers which are used to customize notification messages to use global settings like
 // access URL etc.
-func NewManager(cfg codersdk.NotificationsConfig, store Store, helpers template.FuncMap, metrics *Metrics, log slog.Logger, opts ...ManagerOption) (*Manager, error) {
+func NewManager(cfg codersdk.NotificationsConfig, store Store, ps pubsub.Pubsub, helpers template.FuncMap, metrics *Metrics, log slog.Logger, opts ...ManagerOption) (*Manager, error) {
 	// TODO(dannyk): add the ability to use multiple notification methods.
 	var method database.NotificationMethod
 	if err := method.Scan(cfg.Method.String()); err != nil {
@@ -108,7 +111,7 @@ func NewManager(cfg codersdk.NotificationsConfig, store Store, helpers template.
 		stop: make(chan any),
 		done: make(chan any),
 
-		handlers: defaultHandlers(cfg, helpers, log),
+		handlers: defaultHandlers(cfg, store, ps, helpers, log),
 		helpers:  helpers,
 
 		clock: quartz.NewReal(),
@@ -120,11 +123,39 @@ func NewManager(cfg codersdk.NotificationsConfig, store Store, helpers template.
 }
 
 // defaultHandlers builds a set of known handlers; panics if any error occurs as these handlers should be valid at compile time.
-func defaultHandlers(cfg codersdk.NotificationsConfig, helpers template.FuncMap, log slog.Logger) map[database.NotificationMethod]Handler {
+func defaultHandlers(cfg codersdk.NotificationsConfig, store Store, ps pubsub.Pubsub, helpers template.FuncMap, log slog.Logger) map[database.NotificationMethod]Handler {
 	return map[database.NotificationMethod]Handler{
 		database.NotificationMethodSmtp:    dispatch.NewSMTPHandler(cfg.SMTP, helpers, log.Named("dispatcher.smtp")),
 		database.NotificationMethodWebhook: dispatch.NewWebhookHandler(cfg.Webhook, log.Named("dispatcher.webhook")),
+		database.NotificationMethodSlack:   dispatch.NewSlackHandler(cfg.Slack, log.Named("dispatcher.slack")),
+		database.NotificationMethodTeams:   dispatch.NewTeamsHandler(cfg.Teams, log.Named("dispatcher.teams")),
+		database.NotificationMethodInbox:   dispatch.NewInboxHandler(store, ps, log.Named("dispatcher.inbox")),
+	}
+}
+
+// EnabledMethods returns the delivery methods which are configured on the deployment, and so can be chosen by users.
+// Inbox notifications need no configuration, and the deployment's default method is always considered enabled.
+func EnabledMethods(cfg codersdk.NotificationsConfig) []database.NotificationMethod {
+	var methods []database.NotificationMethod
+	for _, nm := range database.AllNotificationMethodValues() {
+		var enabled bool
+		switch nm {
+		case database.NotificationMethodSmtp:
+			enabled = cfg.SMTP.Smarthost.Host != ""
+		case database.NotificationMethodWebhook:
+			enabled = cfg.Webhook.Endpoint.String() != ""
+		case database.NotificationMethodSlack:
+			enabled = cfg.Slack.BotToken.String() != "" || cfg.Slack.Endpoint.String() != ""
+		case database.NotificationMethodTeams:
+			enabled = cfg.Teams.Endpoint.String() != ""
+		case database.NotificationMethodInbox:
+			enabled = true
+		}
+		if enabled || string(nm) == cfg.Method.String() {
+			methods = append(methods, nm)
+		}
 	}
+	return methods
 }
 
 // WithHandlers allows for tests to inject their own handlers to verify functionality.
@@ -293,6 +324,7 @@ func (m *Manager) syncUpdates(ctx context.Context) {
 			reason = res.err.Error()
 		}
 		failureParams.StatusReasons = append(failureParams.StatusReasons, reason)
+		failureParams.RetryAfters = append(failureParams.RetryAfters, int32(res.retryAfter.Seconds()))
 	}
 
 	// Execute bulk updates for success/failure concurrently.
@@ -399,4 +431,7 @@ type dispatchResult struct {
 	err       error
 	retryable bool
 	inhibited bool
+	// retryAfter is how long the dispatcher asked us to wait before retrying;
+	// the message is not retried sooner than this, nor sooner than RetryInterval.
+	retryAfter time.Duration
 }
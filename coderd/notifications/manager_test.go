@@ -41,7 +41,7 @@ func TestBufferedUpdates(t *testing.T) {
 	cfg.StoreSyncInterval = serpent.Duration(time.Hour) // Ensure we don't sync the store automatically.
 
 	// GIVEN: a manager which will pass or fail notifications based on their "nice" labels
-	mgr, err := notifications.NewManager(cfg, interceptor, defaultHelpers(), createMetrics(), api.Logger.Named("notifications-manager"))
+	mgr, err := notifications.NewManager(cfg, interceptor, api.Pubsub, defaultHelpers(), createMetrics(), api.Logger.Named("notifications-manager"))
 	require.NoError(t, err)
 	mgr.WithHandlers(map[database.NotificationMethod]notifications.Handler{
 		database.NotificationMethodSmtp: santa,
@@ -163,7 +163,7 @@ func TestStopBeforeRun(t *testing.T) {
 	_, _, api := coderdtest.NewWithAPI(t, nil)
 
 	// GIVEN: a standard manager
-	mgr, err := notifications.NewManager(defaultNotificationsConfig(database.NotificationMethodSmtp), api.Database, defaultHelpers(), createMetrics(), api.Logger.Named("notifications-manager"))
+	mgr, err := notifications.NewManager(defaultNotificationsConfig(database.NotificationMethodSmtp), api.Database, api.Pubsub, defaultHelpers(), createMetrics(), api.Logger.Named("notifications-manager"))
 	require.NoError(t, err)
 
 	// THEN: validate that the manager can be stopped safely without Run() having been called yet
@@ -173,12 +173,56 @@ func TestStopBeforeRun(t *testing.T) {
 	}, testutil.WaitShort, testutil.IntervalFast)
 }
 
+func TestRateLimitedRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	// nolint:gocritic // Unit test.
+	ctx := dbauthz.AsSystemRestricted(testutil.Context(t, testutil.WaitSuperLong))
+	_, _, api := coderdtest.NewWithAPI(t, nil)
+
+	interceptor := &syncInterceptor{Store: api.Database}
+	cfg := defaultNotificationsConfig(database.NotificationMethodSmtp)
+	cfg.StoreSyncInterval = serpent.Duration(time.Hour) // Ensure we don't sync the store automatically.
+
+	// GIVEN: a handler which is rate limited by the service it dispatches to
+	mgr, err := notifications.NewManager(cfg, interceptor, api.Pubsub, defaultHelpers(), createMetrics(), api.Logger.Named("notifications-manager"))
+	require.NoError(t, err)
+	mgr.WithHandlers(map[database.NotificationMethod]notifications.Handler{
+		database.NotificationMethodSmtp: &rateLimitedHandler{retryAfter: 5 * time.Minute},
+	})
+	enq, err := notifications.NewStoreEnqueuer(cfg, interceptor, defaultHelpers(), api.Logger.Named("notifications-enqueuer"), quartz.NewReal())
+	require.NoError(t, err)
+
+	user := dbgen.User(t, api.Database, database.User{})
+
+	// WHEN: a notification is dispatched
+	_, err = enq.Enqueue(ctx, user.ID, notifications.TemplateWorkspaceDeleted, map[string]string{}, "")
+	require.NoError(t, err)
+	mgr.Run(ctx)
+
+	require.Eventually(t, func() bool {
+		_, failure := mgr.BufferedUpdatesCount()
+		return failure == 1
+	}, testutil.WaitShort, testutil.IntervalFast)
+	require.NoError(t, mgr.Stop(ctx))
+
+	// THEN: the message is rescheduled after the delay the service asked for
+	require.EventuallyWithT(t, func(ct *assert.CollectT) {
+		assert.EqualValues(ct, 1, interceptor.failed.Load())
+		params, ok := interceptor.lastFailed.Load().(database.BulkMarkNotificationMessagesFailedParams)
+		if assert.True(ct, ok) {
+			assert.Equal(ct, []int32{300}, params.RetryAfters)
+		}
+	}, testutil.WaitMedium, testutil.IntervalFast)
+}
+
 type syncInterceptor struct {
 	notifications.Store
 
-	sent   atomic.Int32
-	failed atomic.Int32
-	err    atomic.Value
+	sent       atomic.Int32
+	failed     atomic.Int32
+	lastFailed atomic.Value
+	err        atomic.Value
 }
 
 func (b *syncInterceptor) BulkMarkNotificationMessagesSent(ctx context.Context, arg database.BulkMarkNotificationMessagesSentParams) (int64, error) {
@@ -193,6 +237,7 @@ func (b *syncInterceptor) BulkMarkNotificationMessagesSent(ctx context.Context,
 func (b *syncInterceptor) BulkMarkNotificationMessagesFailed(ctx context.Context, arg database.BulkMarkNotificationMessagesFailedParams) (int64, error) {
 	updated, err := b.Store.BulkMarkNotificationMessagesFailed(ctx, arg)
 	b.failed.Add(int32(updated))
+	b.lastFailed.Store(arg)
 	if err != nil {
 		b.err.Store(err)
 	}
@@ -217,6 +262,17 @@ func (s *santaHandler) Dispatcher(payload types.MessagePayload, _, _ string) (di
 	}, nil
 }
 
+// rateLimitedHandler fails every message as rate limited.
+type rateLimitedHandler struct {
+	retryAfter time.Duration
+}
+
+func (r *rateLimitedHandler) Dispatcher(types.MessagePayload, string, string) (dispatch.DeliveryFunc, error) {
+	return func(context.Context, uuid.UUID) (bool, error) {
+		return true, &dispatch.RateLimitedError{RetryAfter: r.retryAfter}
+	}, nil
+}
+
 type enqueueInterceptor struct {
 	notifications.Store
 
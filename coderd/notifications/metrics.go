@@ -9,8 +9,9 @@ import (
 )
 
 type Metrics struct {
-	DispatchAttempts *prometheus.CounterVec
-	RetryCount       *prometheus.CounterVec
+	DispatchAttempts      *prometheus.CounterVec
+	RetryCount            *prometheus.CounterVec
+	RateLimitedDispatches *prometheus.CounterVec
 
 	QueuedSeconds *prometheus.HistogramVec
 
@@ -45,6 +46,10 @@ func NewMetrics(reg prometheus.Registerer) *Metrics {
 			Name: "retry_count", Namespace: ns, Subsystem: subsystem,
 			Help: "The count of notification dispatch retry attempts.",
 		}, []string{LabelMethod, LabelTemplateID}),
+		RateLimitedDispatches: promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
+			Name: "rate_limited_dispatches_total", Namespace: ns, Subsystem: subsystem,
+			Help: "The number of dispatch attempts which were throttled by the receiving service.",
+		}, []string{LabelMethod}),
 
 		// Aggregating on LabelTemplateID as well would cause a cardinality explosion.
 		QueuedSeconds: promauto.With(reg).NewHistogramVec(prometheus.HistogramOpts{
@@ -77,7 +77,7 @@ func TestBasicNotificationRoundtrip(t *testing.T) {
 	interceptor := &syncInterceptor{Store: api.Database}
 	cfg := defaultNotificationsConfig(method)
 	cfg.RetryInterval = serpent.Duration(time.Hour) // Ensure retries don't interfere with the test
-	mgr, err := notifications.NewManager(cfg, interceptor, defaultHelpers(), createMetrics(), api.Logger.Named("manager"))
+	mgr, err := notifications.NewManager(cfg, interceptor, api.Pubsub, defaultHelpers(), createMetrics(), api.Logger.Named("manager"))
 	require.NoError(t, err)
 	mgr.WithHandlers(map[database.NotificationMethod]notifications.Handler{method: handler})
 	t.Cleanup(func() {
@@ -154,7 +154,7 @@ func TestSMTPDispatch(t *testing.T) {
 		Hello:     "localhost",
 	}
 	handler := newDispatchInterceptor(dispatch.NewSMTPHandler(cfg.SMTP, defaultHelpers(), api.Logger.Named("smtp")))
-	mgr, err := notifications.NewManager(cfg, api.Database, defaultHelpers(), createMetrics(), api.Logger.Named("manager"))
+	mgr, err := notifications.NewManager(cfg, api.Database, api.Pubsub, defaultHelpers(), createMetrics(), api.Logger.Named("manager"))
 	require.NoError(t, err)
 	mgr.WithHandlers(map[database.NotificationMethod]notifications.Handler{method: handler})
 	t.Cleanup(func() {
@@ -218,7 +218,7 @@ func TestWebhookDispatch(t *testing.T) {
 	cfg.Webhook = codersdk.NotificationsWebhookConfig{
 		Endpoint: *serpent.URLOf(endpoint),
 	}
-	mgr, err := notifications.NewManager(cfg, api.Database, defaultHelpers(), createMetrics(), api.Logger.Named("manager"))
+	mgr, err := notifications.NewManager(cfg, api.Database, api.Pubsub, defaultHelpers(), createMetrics(), api.Logger.Named("manager"))
 	require.NoError(t, err)
 	t.Cleanup(func() {
 		assert.NoError(t, mgr.Stop(ctx))
@@ -318,7 +318,7 @@ func TestBackpressure(t *testing.T) {
 	storeInterceptor := &syncInterceptor{Store: api.Database}
 
 	// GIVEN: a notification manager whose updates will be intercepted
-	mgr, err := notifications.NewManager(cfg, storeInterceptor, defaultHelpers(), createMetrics(), api.Logger.Named("manager"))
+	mgr, err := notifications.NewManager(cfg, storeInterceptor, api.Pubsub, defaultHelpers(), createMetrics(), api.Logger.Named("manager"))
 	require.NoError(t, err)
 	mgr.WithHandlers(map[database.NotificationMethod]notifications.Handler{method: handler})
 	enq, err := notifications.NewStoreEnqueuer(cfg, api.Database, defaultHelpers(), api.Logger.Named("enqueuer"), quartz.NewReal())
@@ -415,7 +415,7 @@ func TestRetries(t *testing.T) {
 	// Intercept calls to submit the buffered updates to the store.
 	storeInterceptor := &syncInterceptor{Store: api.Database}
 
-	mgr, err := notifications.NewManager(cfg, storeInterceptor, defaultHelpers(), createMetrics(), api.Logger.Named("manager"))
+	mgr, err := notifications.NewManager(cfg, storeInterceptor, api.Pubsub, defaultHelpers(), createMetrics(), api.Logger.Named("manager"))
 	require.NoError(t, err)
 	t.Cleanup(func() {
 		assert.NoError(t, mgr.Stop(ctx))
@@ -479,7 +479,7 @@ func TestExpiredLeaseIsRequeued(t *testing.T) {
 	mgrCtx, cancelManagerCtx := context.WithCancel(dbauthz.AsSystemRestricted(context.Background()))
 	t.Cleanup(cancelManagerCtx)
 
-	mgr, err := notifications.NewManager(cfg, noopInterceptor, defaultHelpers(), createMetrics(), api.Logger.Named("manager"))
+	mgr, err := notifications.NewManager(cfg, noopInterceptor, api.Pubsub, defaultHelpers(), createMetrics(), api.Logger.Named("manager"))
 	require.NoError(t, err)
 	enq, err := notifications.NewStoreEnqueuer(cfg, api.Database, defaultHelpers(), api.Logger.Named("enqueuer"), quartz.NewReal())
 	require.NoError(t, err)
@@ -527,7 +527,7 @@ func TestExpiredLeaseIsRequeued(t *testing.T) {
 	// Intercept calls to submit the buffered updates to the store.
 	storeInterceptor := &syncInterceptor{Store: api.Database}
 	handler := newDispatchInterceptor(&fakeHandler{})
-	mgr, err = notifications.NewManager(cfg, storeInterceptor, defaultHelpers(), createMetrics(), api.Logger.Named("manager"))
+	mgr, err = notifications.NewManager(cfg, storeInterceptor, api.Pubsub, defaultHelpers(), createMetrics(), api.Logger.Named("manager"))
 	require.NoError(t, err)
 	mgr.WithHandlers(map[database.NotificationMethod]notifications.Handler{method: handler})
 
@@ -568,7 +568,7 @@ func TestInvalidConfig(t *testing.T) {
 	cfg.DispatchTimeout = serpent.Duration(leasePeriod)
 
 	// WHEN: the manager is created with invalid config
-	_, err := notifications.NewManager(cfg, api.Database, defaultHelpers(), createMetrics(), api.Logger.Named("manager"))
+	_, err := notifications.NewManager(cfg, api.Database, api.Pubsub, defaultHelpers(), createMetrics(), api.Logger.Named("manager"))
 
 	// THEN: the manager will fail to be created, citing invalid config as error
 	require.ErrorIs(t, err, notifications.ErrInvalidDispatchTimeout)
@@ -591,7 +591,7 @@ func TestNotifierPaused(t *testing.T) {
 	const fetchInterval = time.Millisecond * 100
 	cfg := defaultNotificationsConfig(method)
 	cfg.FetchInterval = serpent.Duration(fetchInterval)
-	mgr, err := notifications.NewManager(cfg, api.Database, defaultHelpers(), createMetrics(), api.Logger.Named("manager"))
+	mgr, err := notifications.NewManager(cfg, api.Database, api.Pubsub, defaultHelpers(), createMetrics(), api.Logger.Named("manager"))
 	require.NoError(t, err)
 	mgr.WithHandlers(map[database.NotificationMethod]notifications.Handler{method: handler})
 	t.Cleanup(func() {
@@ -758,6 +758,56 @@ func TestNotificationTemplatesCanRender(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "TemplateWorkspacesDormantDigest",
+			id:   notifications.TemplateWorkspacesDormantDigest,
+			payload: types.MessagePayload{
+				UserName: "Bobby",
+				Labels:   map[string]string{},
+				Data: map[string]any{
+					"count": 2.0,
+					"items": []map[string]any{
+						{
+							"name":           "bobby-workspace",
+							"reason":         "breached the template's threshold for inactivity",
+							"timeTilDormant": "24 hours",
+							"url":            "http://test.com/@bobby/bobby-workspace",
+						},
+						{
+							"name":           "bobby-workspace-2",
+							"reason":         "breached the template's threshold for inactivity",
+							"timeTilDormant": "24 hours",
+							"url":            "http://test.com/@bobby/bobby-workspace-2",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "TemplateWorkspacesMarkedForDeletionDigest",
+			id:   notifications.TemplateWorkspacesMarkedForDeletionDigest,
+			payload: types.MessagePayload{
+				UserName: "Bobby",
+				Labels:   map[string]string{},
+				Data: map[string]any{
+					"count": 2.0,
+					"items": []map[string]any{
+						{
+							"name":           "bobby-workspace",
+							"reason":         "template updated to new dormancy policy",
+							"timeTilDormant": "24 hours",
+							"url":            "http://test.com/@bobby/bobby-workspace",
+						},
+						{
+							"name":           "bobby-workspace-2",
+							"reason":         "template updated to new dormancy policy",
+							"timeTilDormant": "24 hours",
+							"url":            "http://test.com/@bobby/bobby-workspace-2",
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "TemplateUserAccountCreated",
 			id:   notifications.TemplateUserAccountCreated,
@@ -844,6 +894,28 @@ func TestNotificationTemplatesCanRender(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "TemplateWorkspaceDrifted",
+			id:   notifications.TemplateWorkspaceDrifted,
+			payload: types.MessagePayload{
+				UserName: "Bobby",
+				Labels: map[string]string{
+					"name": "bobby-workspace",
+				},
+				Data: map[string]any{
+					"drifted_resources": []map[string]any{
+						{
+							"address": "docker_container.workspace[0]",
+							"action":  "update",
+						},
+						{
+							"address": "docker_volume.home",
+							"action":  "delete",
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "TemplateWorkspaceBuildsFailedReport",
 			id:   notifications.TemplateWorkspaceBuildsFailedReport,
@@ -1012,7 +1084,7 @@ func TestDisabledAfterEnqueue(t *testing.T) {
 	method := database.NotificationMethodSmtp
 	cfg := defaultNotificationsConfig(method)
 
-	mgr, err := notifications.NewManager(cfg, api.Database, defaultHelpers(), createMetrics(), api.Logger.Named("manager"))
+	mgr, err := notifications.NewManager(cfg, api.Database, api.Pubsub, defaultHelpers(), createMetrics(), api.Logger.Named("manager"))
 	require.NoError(t, err)
 	t.Cleanup(func() {
 		assert.NoError(t, mgr.Stop(ctx))
@@ -1120,7 +1192,7 @@ func TestCustomNotificationMethod(t *testing.T) {
 		Endpoint: *serpent.URLOf(endpoint),
 	}
 
-	mgr, err := notifications.NewManager(cfg, api.Database, defaultHelpers(), createMetrics(), api.Logger.Named("manager"))
+	mgr, err := notifications.NewManager(cfg, api.Database, api.Pubsub, defaultHelpers(), createMetrics(), api.Logger.Named("manager"))
 	require.NoError(t, err)
 	t.Cleanup(func() {
 		_ = mgr.Stop(ctx)
@@ -1155,6 +1227,177 @@ func TestCustomNotificationMethod(t *testing.T) {
 	}, testutil.WaitLong, testutil.IntervalFast)
 }
 
+// TestUserNotificationMethod ensures that a user's chosen method for a template takes precedence over the template's
+// and the deployment's methods.
+func TestUserNotificationMethod(t *testing.T) {
+	t.Parallel()
+
+	// nolint:gocritic // Unit test.
+	ctx := dbauthz.AsSystemRestricted(testutil.Context(t, testutil.WaitSuperLong))
+	_, _, api := coderdtest.NewWithAPI(t, nil)
+
+	// GIVEN: an enqueuer which defaults to SMTP, and a user who prefers webhooks for a given template
+	cfg := defaultNotificationsConfig(database.NotificationMethodSmtp)
+	enq, err := notifications.NewStoreEnqueuer(cfg, api.Database, defaultHelpers(), api.Logger.Named("enqueuer"), quartz.NewReal())
+	require.NoError(t, err)
+	user := createSampleUser(t, api.Database)
+
+	_, err = api.Database.UpdateUserNotificationPreferences(ctx, database.UpdateUserNotificationPreferencesParams{
+		UserID:                  user.ID,
+		NotificationTemplateIds: []uuid.UUID{notifications.TemplateWorkspaceDeleted},
+		Disableds:               []bool{false},
+		Methods:                 []string{string(database.NotificationMethodWebhook)},
+	})
+	require.NoError(t, err)
+
+	// WHEN: notifications of that template and of another template are enqueued
+	preferredID, err := enq.Enqueue(ctx, user.ID, notifications.TemplateWorkspaceDeleted, map[string]string{}, "test")
+	require.NoError(t, err)
+	defaultID, err := enq.Enqueue(ctx, user.ID, notifications.TemplateWorkspaceAutobuildFailed, map[string]string{}, "test")
+	require.NoError(t, err)
+
+	// THEN: only the former uses the user's chosen method
+	msgs, err := api.Database.GetNotificationMessagesByStatus(ctx, database.GetNotificationMessagesByStatusParams{
+		Status: database.NotificationMessageStatusPending,
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	require.Len(t, msgs, 2)
+	for _, msg := range msgs {
+		switch msg.ID {
+		case *preferredID:
+			require.Equal(t, database.NotificationMethodWebhook, msg.Method)
+		case *defaultID:
+			require.Equal(t, database.NotificationMethodSmtp, msg.Method)
+		default:
+			t.Fatalf("unexpected message %s", msg.ID)
+		}
+	}
+}
+
+// TestQuietHours ensures that non-urgent notifications enqueued during a user's quiet hours are held until the window
+// ends, while urgent notifications are not.
+func TestQuietHours(t *testing.T) {
+	t.Parallel()
+
+	// nolint:gocritic // Unit test.
+	ctx := dbauthz.AsSystemRestricted(testutil.Context(t, testutil.WaitSuperLong))
+	_, _, api := coderdtest.NewWithAPI(t, nil)
+
+	// Set the time to 23:00 UTC.
+	mClock := quartz.NewMock(t)
+	mClock.Set(time.Date(2024, 1, 15, 23, 0, 0, 0, time.UTC))
+
+	cfg := defaultNotificationsConfig(database.NotificationMethodSmtp)
+	enq, err := notifications.NewStoreEnqueuer(cfg, api.Database, defaultHelpers(), api.Logger.Named("enqueuer"), mClock)
+	require.NoError(t, err)
+	user := createSampleUser(t, api.Database)
+
+	// GIVEN: a user whose quiet hours span midnight
+	_, err = api.Database.UpsertUserNotificationQuietHours(ctx, database.UpsertUserNotificationQuietHoursParams{
+		UserID:      user.ID,
+		StartMinute: 22 * 60,
+		EndMinute:   7 * 60,
+		Timezone:    "UTC",
+	})
+	require.NoError(t, err)
+
+	// WHEN: a non-urgent and an urgent notification are enqueued during quiet hours
+	heldID, err := enq.Enqueue(ctx, user.ID, notifications.TemplateWorkspaceAutoUpdated, map[string]string{}, "test")
+	require.NoError(t, err)
+	urgentID, err := enq.Enqueue(ctx, user.ID, notifications.TemplateWorkspaceDeleted, map[string]string{}, "test")
+	require.NoError(t, err)
+
+	// THEN: only the non-urgent notification is held until the end of the window
+	msgs, err := api.Database.GetNotificationMessagesByStatus(ctx, database.GetNotificationMessagesByStatusParams{
+		Status: database.NotificationMessageStatusPending,
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	require.Len(t, msgs, 2)
+	for _, msg := range msgs {
+		switch msg.ID {
+		case *heldID:
+			require.True(t, msg.HeldUntil.Valid)
+			require.True(t, msg.HeldUntil.Time.Equal(time.Date(2024, 1, 16, 7, 0, 0, 0, time.UTC)), "unexpected release time %s", msg.HeldUntil.Time)
+		case *urgentID:
+			require.False(t, msg.HeldUntil.Valid)
+		default:
+			t.Fatalf("unexpected message %s", msg.ID)
+		}
+	}
+}
+
+// TestDigestHeld ensures that messages for templates with a digest are held until the end of the digest window, and
+// are then left to the digest generator.
+func TestDigestHeld(t *testing.T) {
+	t.Parallel()
+
+	// SETUP
+	if !dbtestutil.WillUsePostgres() {
+		t.Skip("This test requires postgres; it relies on the digest templates of the built-in templates")
+	}
+
+	// nolint:gocritic // Unit test.
+	ctx := dbauthz.AsSystemRestricted(testutil.Context(t, testutil.WaitSuperLong))
+	_, _, api := coderdtest.NewWithAPI(t, nil)
+
+	mClock := quartz.NewMock(t)
+	mClock.Set(time.Date(2024, 1, 15, 12, 2, 30, 0, time.UTC))
+
+	cfg := defaultNotificationsConfig(database.NotificationMethodSmtp)
+	cfg.DigestWindow = serpent.Duration(5 * time.Minute)
+	enq, err := notifications.NewStoreEnqueuer(cfg, api.Database, defaultHelpers(), api.Logger.Named("enqueuer"), mClock)
+	require.NoError(t, err)
+	user := createSampleUser(t, api.Database)
+
+	// WHEN: a notification whose template has a digest and one whose template does not are enqueued
+	heldID, err := enq.Enqueue(ctx, user.ID, notifications.TemplateWorkspaceDormant, map[string]string{"name": "bobby-dev"}, "test")
+	require.NoError(t, err)
+	immediateID, err := enq.Enqueue(ctx, user.ID, notifications.TemplateWorkspaceDeleted, map[string]string{"name": "bobby-dev"}, "test")
+	require.NoError(t, err)
+
+	// THEN: only the former is held, until the end of the current window
+	msgs, err := api.Database.GetNotificationMessagesByStatus(ctx, database.GetNotificationMessagesByStatusParams{
+		Status: database.NotificationMessageStatusPending,
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	require.Len(t, msgs, 2)
+	for _, msg := range msgs {
+		switch msg.ID {
+		case *heldID:
+			require.True(t, msg.HeldUntil.Valid)
+			require.True(t, msg.HeldUntil.Time.Equal(time.Date(2024, 1, 15, 12, 5, 0, 0, time.UTC)), "unexpected release time %s", msg.HeldUntil.Time)
+			require.Equal(t, uuid.NullUUID{UUID: notifications.TemplateWorkspacesDormantDigest, Valid: true}, msg.DigestTemplateID)
+		case *immediateID:
+			require.False(t, msg.HeldUntil.Valid)
+			require.False(t, msg.DigestTemplateID.Valid)
+		default:
+			t.Fatalf("unexpected message %s", msg.ID)
+		}
+	}
+	// WHEN: digests are disabled
+	cfg.DigestWindow = 0
+	enq, err = notifications.NewStoreEnqueuer(cfg, api.Database, defaultHelpers(), api.Logger.Named("enqueuer"), mClock)
+	require.NoError(t, err)
+	notHeldID, err := enq.Enqueue(ctx, user.ID, notifications.TemplateWorkspaceDormant, map[string]string{"name": "bobby-test"}, "test")
+	require.NoError(t, err)
+
+	// THEN: the message is neither held nor left for the digest generator
+	msgs, err = api.Database.GetNotificationMessagesByStatus(ctx, database.GetNotificationMessagesByStatusParams{
+		Status: database.NotificationMessageStatusPending,
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	for _, msg := range msgs {
+		if msg.ID == *notHeldID {
+			require.False(t, msg.HeldUntil.Valid)
+			require.False(t, msg.DigestTemplateID.Valid)
+		}
+	}
+}
+
 func TestNotificationsTemplates(t *testing.T) {
 	t.Parallel()
 
@@ -1209,7 +1452,7 @@ func TestNotificationDuplicates(t *testing.T) {
 	method := database.NotificationMethodSmtp
 	cfg := defaultNotificationsConfig(method)
 
-	mgr, err := notifications.NewManager(cfg, api.Database, defaultHelpers(), createMetrics(), api.Logger.Named("manager"))
+	mgr, err := notifications.NewManager(cfg, api.Database, api.Pubsub, defaultHelpers(), createMetrics(), api.Logger.Named("manager"))
 	require.NoError(t, err)
 	t.Cleanup(func() {
 		assert.NoError(t, mgr.Stop(ctx))
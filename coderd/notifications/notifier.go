@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"sync"
 	"text/template"
+	"time"
 
 	"github.com/google/uuid"
 	"golang.org/x/sync/errgroup"
@@ -274,6 +275,11 @@ func (n *notifier) deliver(ctx context.Context, msg database.AcquireNotification
 			return err
 		}
 
+		var rateLimited *dispatch.RateLimitedError
+		if xerrors.As(err, &rateLimited) {
+			n.metrics.RateLimitedDispatches.WithLabelValues(string(msg.Method)).Inc()
+		}
+
 		select {
 		case <-ctx.Done():
 			logger.Warn(context.Background(), "cannot record dispatch failure result", slog.Error(ctx.Err()))
@@ -318,12 +324,19 @@ func (n *notifier) newFailedDispatch(msg database.AcquireNotificationMessagesRow
 
 	n.metrics.DispatchAttempts.WithLabelValues(string(msg.Method), msg.TemplateID.String(), result).Inc()
 
+	var retryAfter time.Duration
+	var rateLimited *dispatch.RateLimitedError
+	if xerrors.As(err, &rateLimited) {
+		retryAfter = rateLimited.RetryAfter
+	}
+
 	return dispatchResult{
-		notifier:  n.id,
-		msg:       msg.ID,
-		ts:        dbtime.Time(n.clock.Now().UTC()),
-		err:       err,
-		retryable: retryable,
+		notifier:   n.id,
+		msg:        msg.ID,
+		ts:         dbtime.Time(n.clock.Now().UTC()),
+		err:        err,
+		retryable:  retryable,
+		retryAfter: retryAfter,
 	}
 }
 
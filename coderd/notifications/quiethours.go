@@ -0,0 +1,49 @@
+package notifications
+
+import (
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+const minutesPerDay = 24 * 60
+
+// QuietHoursRelease determines whether the given time falls within a daily quiet hours window, and if so, when that
+// window ends. The window is described by minutes after midnight in the given timezone; if end is before start, the
+// window spans midnight. A window whose start and end are equal is empty.
+func QuietHoursRelease(startMinute, endMinute int, timezone string, now time.Time) (release time.Time, held bool, err error) {
+	if startMinute < 0 || startMinute >= minutesPerDay || endMinute < 0 || endMinute >= minutesPerDay {
+		return time.Time{}, false, xerrors.Errorf("quiet hours %d-%d out of range", startMinute, endMinute)
+	}
+
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return time.Time{}, false, xerrors.Errorf("load timezone %q: %w", timezone, err)
+	}
+
+	if startMinute == endMinute {
+		return time.Time{}, false, nil
+	}
+
+	local := now.In(loc)
+	minute := local.Hour()*60 + local.Minute()
+	// endOn builds the window's end relative to the current day; time.Date normalizes day overflow and accounts for DST.
+	endOn := func(dayOffset int) time.Time {
+		return time.Date(local.Year(), local.Month(), local.Day()+dayOffset, endMinute/60, endMinute%60, 0, 0, loc)
+	}
+
+	switch {
+	case startMinute < endMinute:
+		if minute >= startMinute && minute < endMinute {
+			return endOn(0), true, nil
+		}
+	case minute >= startMinute:
+		// Window spans midnight, and we're in the evening portion of it.
+		return endOn(1), true, nil
+	case minute < endMinute:
+		// Window spans midnight, and we're in the morning portion of it.
+		return endOn(0), true, nil
+	}
+
+	return time.Time{}, false, nil
+}
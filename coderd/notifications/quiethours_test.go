@@ -0,0 +1,113 @@
+package notifications_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/coderd/notifications"
+)
+
+func TestQuietHoursRelease(t *testing.T) {
+	t.Parallel()
+
+	london, err := time.LoadLocation("Europe/London")
+	require.NoError(t, err)
+
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, time.September, day, hour, minute, 0, 0, london)
+	}
+
+	tests := []struct {
+		name        string
+		start, end  int
+		timezone    string
+		now         time.Time
+		wantHeld    bool
+		wantRelease time.Time
+		wantErr     string
+	}{
+		{
+			name:  "daytime window, inside",
+			start: 9 * 60, end: 17 * 60,
+			timezone:    "Europe/London",
+			now:         at(20, 12, 0),
+			wantHeld:    true,
+			wantRelease: at(20, 17, 0),
+		},
+		{
+			name:  "daytime window, outside",
+			start: 9 * 60, end: 17 * 60,
+			timezone: "Europe/London",
+			now:      at(20, 17, 0),
+		},
+		{
+			name:  "overnight window, evening",
+			start: 22 * 60, end: 7 * 60,
+			timezone:    "Europe/London",
+			now:         at(20, 23, 30),
+			wantHeld:    true,
+			wantRelease: at(21, 7, 0),
+		},
+		{
+			name:  "overnight window, morning",
+			start: 22 * 60, end: 7 * 60,
+			timezone:    "Europe/London",
+			now:         at(21, 6, 59),
+			wantHeld:    true,
+			wantRelease: at(21, 7, 0),
+		},
+		{
+			name:  "overnight window, outside",
+			start: 22 * 60, end: 7 * 60,
+			timezone: "Europe/London",
+			now:      at(21, 12, 0),
+		},
+		{
+			name:  "evaluated in the user's timezone",
+			start: 22 * 60, end: 7 * 60,
+			timezone:    "America/New_York",
+			now:         at(20, 3, 0), // 22:00 in New York.
+			wantHeld:    true,
+			wantRelease: at(20, 12, 0), // 07:00 in New York.
+		},
+		{
+			name:  "empty window",
+			start: 8 * 60, end: 8 * 60,
+			timezone: "Europe/London",
+			now:      at(20, 8, 0),
+		},
+		{
+			name:  "invalid timezone",
+			start: 22 * 60, end: 7 * 60,
+			timezone: "Mars/Olympus_Mons",
+			now:      at(20, 23, 0),
+			wantErr:  "load timezone",
+		},
+		{
+			name:  "out of range",
+			start: 22 * 60, end: 24 * 60,
+			timezone: "Europe/London",
+			now:      at(20, 23, 0),
+			wantErr:  "out of range",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			release, held, err := notifications.QuietHoursRelease(tc.start, tc.end, tc.timezone, tc.now)
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantHeld, held)
+			if tc.wantHeld {
+				require.True(t, tc.wantRelease.Equal(release), "expected release at %s, got %s", tc.wantRelease, release)
+			}
+		})
+	}
+}
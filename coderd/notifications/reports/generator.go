@@ -3,6 +3,7 @@ package reports
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"io"
 	"slices"
 	"sort"
@@ -18,14 +19,42 @@ import (
 	"github.com/coder/coder/v2/coderd/database/dbauthz"
 	"github.com/coder/coder/v2/coderd/database/dbtime"
 	"github.com/coder/coder/v2/coderd/notifications"
+	"github.com/coder/coder/v2/coderd/notifications/types"
 	"github.com/coder/coder/v2/codersdk"
 )
 
 const (
 	delay = 15 * time.Minute
+	// digestDelay is how often the digest generator looks for messages whose digest window has ended. Messages are
+	// delivered at most this long after their window ends.
+	digestDelay = time.Minute
 )
 
 func NewReportGenerator(ctx context.Context, logger slog.Logger, db database.Store, enqueuer notifications.Enqueuer, clk quartz.Clock) io.Closer {
+	return newGenerator(ctx, logger, db, clk, "report generator", database.LockIDNotificationsReportGenerator, delay, func(ctx context.Context) error {
+		err := reportFailedWorkspaceBuilds(ctx, logger, db, enqueuer, clk)
+		if err != nil {
+			return xerrors.Errorf("unable to generate reports with failed workspace builds: %w", err)
+		}
+		return nil
+	})
+}
+
+// NewDigestGenerator periodically combines the messages awaiting a digest whose digest window has ended into a single
+// notification per user and template. It keeps running when digests are disabled so that messages held before they
+// were disabled are still delivered.
+func NewDigestGenerator(ctx context.Context, logger slog.Logger, db database.Store, enqueuer notifications.Enqueuer, clk quartz.Clock) io.Closer {
+	return newGenerator(ctx, logger, db, clk, "digest generator", database.LockIDNotificationsDigestGenerator, digestDelay, func(ctx context.Context) error {
+		err := generateDigests(ctx, logger, db, enqueuer)
+		if err != nil {
+			return xerrors.Errorf("unable to generate digests: %w", err)
+		}
+		return nil
+	})
+}
+
+// newGenerator runs the given job every interval on a single replica at a time, guarded by the given advisory lock.
+func newGenerator(ctx context.Context, logger slog.Logger, db database.Store, clk quartz.Clock, name string, lockID int64, interval time.Duration, job func(ctx context.Context) error) io.Closer {
 	closed := make(chan struct{})
 
 	ctx, cancelFunc := context.WithCancel(ctx)
@@ -33,32 +62,32 @@ func NewReportGenerator(ctx context.Context, logger slog.Logger, db database.Sto
 	ctx = dbauthz.AsSystemRestricted(ctx)
 
 	// Start the ticker with the initial delay.
-	ticker := clk.NewTicker(delay)
+	ticker := clk.NewTicker(interval)
 	ticker.Stop()
 	doTick := func(start time.Time) {
-		defer ticker.Reset(delay)
+		defer ticker.Reset(interval)
 		// Start a transaction to grab advisory lock, we don't want to run generator jobs at the same time (multiple replicas).
 		if err := db.InTx(func(tx database.Store) error {
 			// Acquire a lock to ensure that only one instance of the generator is running at a time.
-			ok, err := tx.TryAcquireLock(ctx, database.LockIDNotificationsReportGenerator)
+			ok, err := tx.TryAcquireLock(ctx, lockID)
 			if err != nil {
-				return xerrors.Errorf("failed to acquire report generator lock: %w", err)
+				return xerrors.Errorf("failed to acquire %s lock: %w", name, err)
 			}
 			if !ok {
-				logger.Debug(ctx, "unable to acquire lock for generating periodic reports, skipping")
+				logger.Debug(ctx, "unable to acquire lock, skipping", slog.F("generator", name))
 				return nil
 			}
 
-			err = reportFailedWorkspaceBuilds(ctx, logger, db, enqueuer, clk)
+			err = job(ctx)
 			if err != nil {
-				return xerrors.Errorf("unable to generate reports with failed workspace builds: %w", err)
+				return err
 			}
 
-			logger.Info(ctx, "report generator finished", slog.F("duration", clk.Since(start)))
+			logger.Info(ctx, name+" finished", slog.F("duration", clk.Since(start)))
 
 			return nil
 		}, nil); err != nil {
-			logger.Error(ctx, "failed to generate reports", slog.Error(err))
+			logger.Error(ctx, "failed to run "+name, slog.Error(err))
 			return
 		}
 	}
@@ -71,7 +100,7 @@ func NewReportGenerator(ctx context.Context, logger slog.Logger, db database.Sto
 		for {
 			select {
 			case <-ctx.Done():
-				logger.Debug(ctx, "closing report generator")
+				logger.Debug(ctx, "closing "+name)
 				return
 			case tick := <-ticker.C:
 				ticker.Stop()
@@ -264,6 +293,95 @@ func buildDataForReportFailedWorkspaceBuilds(stats database.GetWorkspaceBuildSta
 	}
 }
 
+// generateDigests delivers the messages whose digest window has ended. Messages for the same user and template are
+// combined into a single notification using the template's digest template, after which the originals are marked as
+// delivered; a message which no other joined, whose digest the user disabled or whose digest can't be built is
+// dispatched on its own instead.
+func generateDigests(ctx context.Context, logger slog.Logger, db database.Store, enqueuer notifications.Enqueuer) error {
+	msgs, err := db.GetNotificationMessagesAwaitingDigest(ctx)
+	if err != nil {
+		return xerrors.Errorf("unable to fetch messages awaiting a digest: %w", err)
+	}
+
+	// Messages are sorted by user and template, so each digest is a contiguous run.
+	for len(msgs) > 0 {
+		n := 1
+		for n < len(msgs) && msgs[n].UserID == msgs[0].UserID && msgs[n].NotificationTemplateID == msgs[0].NotificationTemplateID {
+			n++
+		}
+		group := msgs[:n]
+		msgs = msgs[n:]
+
+		ids := make([]uuid.UUID, 0, len(group))
+		for _, msg := range group {
+			ids = append(ids, msg.ID)
+		}
+
+		if len(group) == 1 || !group[0].DigestTemplateID.Valid {
+			if err := db.ReleaseNotificationMessagesFromDigest(ctx, ids); err != nil {
+				return xerrors.Errorf("unable to release messages from digest: %w", err)
+			}
+			continue
+		}
+
+		data, err := buildDataForDigest(group)
+		if err != nil {
+			// Deliver the messages individually rather than holding them back forever.
+			logger.Warn(ctx, "unable to build digest", slog.F("user_id", group[0].UserID), slog.F("notification_template_id", group[0].NotificationTemplateID), slog.Error(err))
+			if err := db.ReleaseNotificationMessagesFromDigest(ctx, ids); err != nil {
+				return xerrors.Errorf("unable to release messages from digest: %w", err)
+			}
+			continue
+		}
+
+		id, err := enqueuer.EnqueueWithData(ctx, group[0].UserID, group[0].DigestTemplateID.UUID, map[string]string{}, data, "digest_generator")
+		if xerrors.Is(err, notifications.ErrCannotEnqueueDisabledNotification) {
+			// The user opted out of the digest, so deliver the messages individually instead.
+			if err := db.ReleaseNotificationMessagesFromDigest(ctx, ids); err != nil {
+				return xerrors.Errorf("unable to release messages from digest: %w", err)
+			}
+			continue
+		}
+		if err != nil {
+			logger.Warn(ctx, "failed to send a digest", slog.F("user_id", group[0].UserID), slog.Error(err))
+			continue
+		}
+		if err := db.MarkNotificationMessagesDigested(ctx, database.MarkNotificationMessagesDigestedParams{
+			DigestMessageID: *id,
+			IDs:             ids,
+		}); err != nil {
+			return xerrors.Errorf("unable to mark messages as digested: %w", err)
+		}
+	}
+	return nil
+}
+
+// buildDataForDigest combines the payloads of the given messages into the data of their digest. Each item holds the
+// labels of one message plus the URL of its first action.
+func buildDataForDigest(msgs []database.NotificationMessage) (map[string]any, error) {
+	items := make([]map[string]any, 0, len(msgs))
+	for _, msg := range msgs {
+		var payload types.MessagePayload
+		if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+			return nil, xerrors.Errorf("unmarshal payload of message %q: %w", msg.ID, err)
+		}
+
+		item := make(map[string]any, len(payload.Labels)+1)
+		for k, v := range payload.Labels {
+			item[k] = v
+		}
+		if len(payload.Actions) > 0 {
+			item["url"] = payload.Actions[0].URL
+		}
+		items = append(items, item)
+	}
+
+	return map[string]any{
+		"count": len(items),
+		"items": items,
+	}, nil
+}
+
 func findTemplateAdmins(ctx context.Context, db database.Store, stats database.GetWorkspaceBuildStatsByTemplatesRow) ([]database.GetUsersRow, error) {
 	users, err := db.GetUsers(ctx, database.GetUsersParams{
 		RbacRole: []string{codersdk.RoleTemplateAdmin},
@@ -3,6 +3,7 @@ package reports
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"testing"
 	"time"
 
@@ -19,8 +20,10 @@ import (
 	"github.com/coder/coder/v2/coderd/database/dbauthz"
 	"github.com/coder/coder/v2/coderd/database/dbgen"
 	"github.com/coder/coder/v2/coderd/database/dbtestutil"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
 	"github.com/coder/coder/v2/coderd/database/pubsub"
 	"github.com/coder/coder/v2/coderd/notifications"
+	"github.com/coder/coder/v2/coderd/notifications/types"
 	"github.com/coder/coder/v2/coderd/rbac"
 	"github.com/coder/coder/v2/testutil"
 )
@@ -457,6 +460,165 @@ func TestReportFailedWorkspaceBuilds(t *testing.T) {
 	})
 }
 
+func TestGenerateDigests(t *testing.T) {
+	t.Parallel()
+
+	// Setup
+	ctx, logger, db, _, notifEnq, _ := setup(t)
+
+	alice := dbgen.User(t, db, database.User{})
+	bob := dbgen.User(t, db, database.User{})
+
+	enqueue := func(userID uuid.UUID, name string) uuid.UUID {
+		t.Helper()
+		payload, err := json.Marshal(types.MessagePayload{
+			Labels:  map[string]string{"name": name, "reason": "inactivity"},
+			Actions: []types.TemplateAction{{Label: "View workspace", URL: "http://test.com/" + name}},
+		})
+		require.NoError(t, err)
+		id := uuid.New()
+		err = db.EnqueueNotificationMessage(ctx, database.EnqueueNotificationMessageParams{
+			ID:                     id,
+			NotificationTemplateID: notifications.TemplateWorkspaceDormant,
+			UserID:                 userID,
+			Method:                 database.NotificationMethodSmtp,
+			Payload:                payload,
+			CreatedBy:              "test",
+			CreatedAt:              dbtime.Now(),
+			HeldUntil:              sql.NullTime{Time: dbtime.Now().Add(-time.Minute), Valid: true},
+			DigestTemplateID:       uuid.NullUUID{UUID: notifications.TemplateWorkspacesDormantDigest, Valid: true},
+		})
+		require.NoError(t, err)
+		return id
+	}
+
+	// Given: two messages for one user whose digest window has ended, and one for another user
+	aliceIDs := []uuid.UUID{enqueue(alice.ID, "alice-1"), enqueue(alice.ID, "alice-2")}
+	bobID := enqueue(bob.ID, "bob-1")
+
+	// When
+	notifEnq.Clear()
+	err := generateDigests(ctx, logger, db, notifEnq)
+	require.NoError(t, err)
+
+	// Then: the first user's messages are delivered in a single digest
+	require.Len(t, notifEnq.Sent, 1)
+	require.Equal(t, alice.ID, notifEnq.Sent[0].UserID)
+	require.Equal(t, notifications.TemplateWorkspacesDormantDigest, notifEnq.Sent[0].TemplateID)
+	require.Equal(t, map[string]any{
+		"count": 2,
+		"items": []map[string]any{
+			{"name": "alice-1", "reason": "inactivity", "url": "http://test.com/alice-1"},
+			{"name": "alice-2", "reason": "inactivity", "url": "http://test.com/alice-2"},
+		},
+	}, notifEnq.Sent[0].Data)
+
+	// Then: the digested messages are retired, and the single message is released to be dispatched on its own
+	inhibited, err := db.GetNotificationMessagesByStatus(ctx, database.GetNotificationMessagesByStatusParams{
+		Status: database.NotificationMessageStatusInhibited,
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	require.Len(t, inhibited, 2)
+	for _, msg := range inhibited {
+		require.Contains(t, aliceIDs, msg.ID)
+		require.False(t, msg.DigestTemplateID.Valid)
+	}
+	pending, err := db.GetNotificationMessagesByStatus(ctx, database.GetNotificationMessagesByStatusParams{
+		Status: database.NotificationMessageStatusPending,
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	require.Equal(t, bobID, pending[0].ID)
+	require.False(t, pending[0].DigestTemplateID.Valid)
+
+	// When: the generator runs again
+	notifEnq.Clear()
+	err = generateDigests(ctx, logger, db, notifEnq)
+
+	// Then: nothing is awaiting a digest anymore
+	require.NoError(t, err)
+	require.Empty(t, notifEnq.Sent)
+}
+
+func TestGenerateDigestsMalformedPayload(t *testing.T) {
+	t.Parallel()
+
+	// Setup
+	ctx, logger, db, _, notifEnq, _ := setup(t)
+
+	user := dbgen.User(t, db, database.User{})
+
+	enqueue := func(name string) uuid.UUID {
+		t.Helper()
+		payload, err := json.Marshal(types.MessagePayload{
+			Labels: map[string]string{"name": name, "reason": "inactivity"},
+		})
+		require.NoError(t, err)
+		id := uuid.New()
+		err = db.EnqueueNotificationMessage(ctx, database.EnqueueNotificationMessageParams{
+			ID:                     id,
+			NotificationTemplateID: notifications.TemplateWorkspaceDormant,
+			UserID:                 user.ID,
+			Method:                 database.NotificationMethodSmtp,
+			Payload:                payload,
+			CreatedBy:              "test",
+			CreatedAt:              dbtime.Now(),
+			HeldUntil:              sql.NullTime{Time: dbtime.Now().Add(-time.Minute), Valid: true},
+			DigestTemplateID:       uuid.NullUUID{UUID: notifications.TemplateWorkspacesDormantDigest, Valid: true},
+		})
+		require.NoError(t, err)
+		return id
+	}
+
+	// Given: two messages awaiting a digest, one of which has a payload that can't be decoded
+	ids := []uuid.UUID{enqueue("valid"), enqueue("malformed")}
+	store := &malformedPayloadStore{Store: db, id: ids[1]}
+
+	// When
+	notifEnq.Clear()
+	err := generateDigests(ctx, logger, store, notifEnq)
+	require.NoError(t, err)
+
+	// Then: no digest is sent, and both messages are released to be dispatched on their own
+	require.Empty(t, notifEnq.Sent)
+	pending, err := db.GetNotificationMessagesByStatus(ctx, database.GetNotificationMessagesByStatusParams{
+		Status: database.NotificationMessageStatusPending,
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	for _, msg := range pending {
+		require.Contains(t, ids, msg.ID)
+		require.False(t, msg.DigestTemplateID.Valid)
+	}
+
+	// When: the generator runs again
+	err = generateDigests(ctx, logger, store, notifEnq)
+
+	// Then: nothing is awaiting a digest anymore
+	require.NoError(t, err)
+	require.Empty(t, notifEnq.Sent)
+}
+
+// malformedPayloadStore corrupts the payload of a message awaiting a digest, which the in-memory database would reject
+// when it is enqueued.
+type malformedPayloadStore struct {
+	database.Store
+	id uuid.UUID
+}
+
+func (s *malformedPayloadStore) GetNotificationMessagesAwaitingDigest(ctx context.Context) ([]database.NotificationMessage, error) {
+	msgs, err := s.Store.GetNotificationMessagesAwaitingDigest(ctx)
+	for i := range msgs {
+		if msgs[i].ID == s.id {
+			msgs[i].Payload = []byte(`{"labels":"malformed"}`)
+		}
+	}
+	return msgs, err
+}
+
 func setup(t *testing.T) (context.Context, slog.Logger, database.Store, pubsub.Pubsub, *testutil.FakeNotificationsEnqueuer, *quartz.Mock) {
 	t.Helper()
 
@@ -1,17 +1,29 @@
 package coderd_test
 
 import (
+	"encoding/json"
 	"net/http"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/exp/slices"
 
+	"cdr.dev/slog/sloggers/slogtest"
+
 	"github.com/coder/serpent"
 
 	"github.com/coder/coder/v2/coderd/coderdtest"
 	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbgen"
+	"github.com/coder/coder/v2/coderd/database/dbtestutil"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
+	"github.com/coder/coder/v2/coderd/database/pubsub"
 	"github.com/coder/coder/v2/coderd/notifications"
+	"github.com/coder/coder/v2/coderd/notifications/dispatch"
+	"github.com/coder/coder/v2/coderd/notifications/types"
+	"github.com/coder/coder/v2/coderd/util/ptr"
 	"github.com/coder/coder/v2/codersdk"
 	"github.com/coder/coder/v2/testutil"
 )
@@ -268,6 +280,414 @@ func TestNotificationPreferences(t *testing.T) {
 		}
 		require.True(t, found, "dormant notification preference was not found")
 	})
+
+	t.Run("Set method", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitSuperLong)
+		opts := createOpts(t)
+		require.NoError(t, opts.DeploymentValues.Notifications.Webhook.Endpoint.Set("https://example.com/webhook"))
+		api := coderdtest.New(t, opts)
+		firstUser := coderdtest.CreateFirstUser(t, api)
+
+		// Given: a member with a disabled preference.
+		memberClient, member := coderdtest.CreateAnotherUser(t, api, firstUser.OrganizationID)
+		_, err := memberClient.UpdateUserNotificationPreferences(ctx, member.ID, codersdk.UpdateUserNotificationPreferences{
+			TemplateDisabledMap: map[string]bool{
+				notifications.TemplateWorkspaceDeleted.String(): true,
+			},
+		})
+		require.NoError(t, err)
+
+		// When: choosing a delivery method for another template.
+		prefs, err := memberClient.UpdateUserNotificationPreferences(ctx, member.ID, codersdk.UpdateUserNotificationPreferences{
+			TemplateMethodMap: map[string]string{
+				notifications.TemplateWorkspaceDormant.String(): string(database.NotificationMethodWebhook),
+			},
+		})
+		require.NoError(t, err)
+
+		// Then: the method is set, and the untouched preference is retained.
+		require.Len(t, prefs, 2)
+		for _, p := range prefs {
+			switch p.NotificationTemplateID {
+			case notifications.TemplateWorkspaceDormant:
+				require.Equal(t, string(database.NotificationMethodWebhook), p.Method)
+				require.False(t, p.Disabled)
+			case notifications.TemplateWorkspaceDeleted:
+				require.Empty(t, p.Method)
+				require.True(t, p.Disabled)
+			}
+		}
+
+		// When: resetting the method to the default.
+		prefs, err = memberClient.UpdateUserNotificationPreferences(ctx, member.ID, codersdk.UpdateUserNotificationPreferences{
+			TemplateMethodMap: map[string]string{
+				notifications.TemplateWorkspaceDormant.String(): "",
+			},
+		})
+		require.NoError(t, err)
+
+		// Then: the method is cleared.
+		for _, p := range prefs {
+			require.Empty(t, p.Method)
+		}
+	})
+
+	t.Run("Invalid method", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitSuperLong)
+		api := coderdtest.New(t, createOpts(t))
+		firstUser := coderdtest.CreateFirstUser(t, api)
+		memberClient, member := coderdtest.CreateAnotherUser(t, api, firstUser.OrganizationID)
+
+		// When: choosing an unknown delivery method.
+		_, err := memberClient.UpdateUserNotificationPreferences(ctx, member.ID, codersdk.UpdateUserNotificationPreferences{
+			TemplateMethodMap: map[string]string{
+				notifications.TemplateWorkspaceDormant.String(): "carrier-pigeon",
+			},
+		})
+
+		// Then: the request is rejected.
+		var sdkError *codersdk.Error
+		require.Error(t, err)
+		require.ErrorAsf(t, err, &sdkError, "error should be of type *codersdk.Error")
+		require.Equal(t, http.StatusBadRequest, sdkError.StatusCode())
+	})
+
+	t.Run("Method not enabled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitSuperLong)
+		api := coderdtest.New(t, createOpts(t))
+		firstUser := coderdtest.CreateFirstUser(t, api)
+		memberClient, member := coderdtest.CreateAnotherUser(t, api, firstUser.OrganizationID)
+
+		// When: choosing a delivery method which is not configured on the deployment.
+		_, err := memberClient.UpdateUserNotificationPreferences(ctx, member.ID, codersdk.UpdateUserNotificationPreferences{
+			TemplateMethodMap: map[string]string{
+				notifications.TemplateWorkspaceDormant.String(): string(database.NotificationMethodSlack),
+			},
+		})
+
+		// Then: the request is rejected.
+		var sdkError *codersdk.Error
+		require.Error(t, err)
+		require.ErrorAsf(t, err, &sdkError, "error should be of type *codersdk.Error")
+		require.Equal(t, http.StatusBadRequest, sdkError.StatusCode())
+		require.Len(t, sdkError.Validations, 1)
+		require.Equal(t, "template_method_map", sdkError.Validations[0].Field)
+
+		// When: choosing inbox notifications, which need no configuration.
+		prefs, err := memberClient.UpdateUserNotificationPreferences(ctx, member.ID, codersdk.UpdateUserNotificationPreferences{
+			TemplateMethodMap: map[string]string{
+				notifications.TemplateWorkspaceDormant.String(): string(database.NotificationMethodInbox),
+			},
+		})
+
+		// Then: the method is set.
+		require.NoError(t, err)
+		require.Len(t, prefs, 1)
+		require.Equal(t, string(database.NotificationMethodInbox), prefs[0].Method)
+	})
+}
+
+func TestNotificationQuietHours(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Set and clear", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitSuperLong)
+		api := coderdtest.New(t, createOpts(t))
+		firstUser := coderdtest.CreateFirstUser(t, api)
+		memberClient, member := coderdtest.CreateAnotherUser(t, api, firstUser.OrganizationID)
+
+		// Given: a member without quiet hours.
+		qh, err := memberClient.GetUserNotificationQuietHours(ctx, member.ID)
+		require.NoError(t, err)
+		require.False(t, qh.Enabled)
+
+		// When: setting quiet hours.
+		want := codersdk.NotificationQuietHours{
+			Enabled:  true,
+			Start:    "22:00",
+			End:      "07:30",
+			Timezone: "Europe/London",
+		}
+		qh, err = memberClient.UpdateUserNotificationQuietHours(ctx, member.ID, want)
+		require.NoError(t, err)
+
+		// Then: they are returned as set.
+		require.Equal(t, want, qh)
+		qh, err = memberClient.GetUserNotificationQuietHours(ctx, member.ID)
+		require.NoError(t, err)
+		require.Equal(t, want, qh)
+
+		// When: disabling quiet hours.
+		qh, err = memberClient.UpdateUserNotificationQuietHours(ctx, member.ID, codersdk.NotificationQuietHours{})
+		require.NoError(t, err)
+		require.False(t, qh.Enabled)
+
+		// Then: they are no longer set.
+		qh, err = memberClient.GetUserNotificationQuietHours(ctx, member.ID)
+		require.NoError(t, err)
+		require.False(t, qh.Enabled)
+	})
+
+	t.Run("Insufficient permissions", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitSuperLong)
+		api := coderdtest.New(t, createOpts(t))
+		firstUser := coderdtest.CreateFirstUser(t, api)
+
+		// Given: 2 members.
+		_, member1 := coderdtest.CreateAnotherUser(t, api, firstUser.OrganizationID)
+		member2Client, _ := coderdtest.CreateAnotherUser(t, api, firstUser.OrganizationID)
+
+		// When: attempting to set another member's quiet hours.
+		_, err := member2Client.UpdateUserNotificationQuietHours(ctx, member1.ID, codersdk.NotificationQuietHours{
+			Enabled:  true,
+			Start:    "22:00",
+			End:      "07:00",
+			Timezone: "UTC",
+		})
+
+		// Then: the request is rejected.
+		var sdkError *codersdk.Error
+		require.Error(t, err)
+		require.ErrorAsf(t, err, &sdkError, "error should be of type *codersdk.Error")
+		// NOTE: ExtractUserParam gets in the way here, and returns a 400 Bad Request instead of a 403 Forbidden.
+		// This is not ideal, and we should probably change this behavior.
+		require.Equal(t, http.StatusBadRequest, sdkError.StatusCode())
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitSuperLong)
+		api := coderdtest.New(t, createOpts(t))
+		firstUser := coderdtest.CreateFirstUser(t, api)
+		memberClient, member := coderdtest.CreateAnotherUser(t, api, firstUser.OrganizationID)
+
+		for _, req := range []codersdk.NotificationQuietHours{
+			{Enabled: true, Start: "25:00", End: "07:00", Timezone: "UTC"},
+			{Enabled: true, Start: "22:00", End: "", Timezone: "UTC"},
+			{Enabled: true, Start: "22:00", End: "07:00", Timezone: "Mars/Olympus_Mons"},
+		} {
+			_, err := memberClient.UpdateUserNotificationQuietHours(ctx, member.ID, req)
+			var sdkError *codersdk.Error
+			require.ErrorAsf(t, err, &sdkError, "error should be of type *codersdk.Error")
+			require.Equal(t, http.StatusBadRequest, sdkError.StatusCode())
+			require.NotEmpty(t, sdkError.Validations)
+		}
+	})
+}
+
+func TestInboxNotifications(t *testing.T) {
+	t.Parallel()
+
+	inboxOpts := func(t *testing.T) (*coderdtest.Options, database.Store, pubsub.Pubsub) {
+		t.Helper()
+
+		db, ps := dbtestutil.NewDB(t)
+		opts := createOpts(t)
+		opts.Database = db
+		opts.Pubsub = ps
+		return opts, db, ps
+	}
+
+	t.Run("List and mark read", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitSuperLong)
+		opts, db, _ := inboxOpts(t)
+		api := coderdtest.New(t, opts)
+		firstUser := coderdtest.CreateFirstUser(t, api)
+		memberClient, member := coderdtest.CreateAnotherUser(t, api, firstUser.OrganizationID)
+
+		// Given: a member with 3 notifications, and another user with 1.
+		now := dbtime.Now()
+		var notifs []database.InboxNotification
+		for i := 0; i < 3; i++ {
+			notifs = append(notifs, dbgen.InboxNotification(t, db, database.InboxNotification{
+				UserID:     member.ID,
+				TemplateID: notifications.TemplateWorkspaceDeleted,
+				Actions:    json.RawMessage(`[{"label":"View workspaces","url":"https://coder.example.com/workspaces"}]`),
+				CreatedAt:  now.Add(time.Duration(i) * time.Minute),
+			}))
+		}
+		dbgen.InboxNotification(t, db, database.InboxNotification{
+			UserID:     firstUser.UserID,
+			TemplateID: notifications.TemplateWorkspaceDeleted,
+		})
+
+		// Then: the member's notifications are listed newest first, all unread.
+		resp, err := memberClient.ListInboxNotifications(ctx, member.ID, codersdk.ListInboxNotificationsRequest{})
+		require.NoError(t, err)
+		require.Len(t, resp.Notifications, 3)
+		require.Equal(t, 3, resp.UnreadCount)
+		require.Equal(t, notifs[2].ID, resp.Notifications[0].ID)
+		require.Equal(t, notifs[0].ID, resp.Notifications[2].ID)
+		require.Equal(t, []codersdk.InboxNotificationAction{{Label: "View workspaces", URL: "https://coder.example.com/workspaces"}}, resp.Notifications[0].Actions)
+		require.Nil(t, resp.Notifications[0].ReadAt)
+
+		// When: marking one as read.
+		updated, err := memberClient.UpdateInboxNotification(ctx, member.ID, notifs[1].ID, codersdk.UpdateInboxNotificationRequest{Read: ptr.Ref(true)})
+		require.NoError(t, err)
+		require.NotNil(t, updated.ReadAt)
+
+		// Then: it is filtered accordingly.
+		resp, err = memberClient.ListInboxNotifications(ctx, member.ID, codersdk.ListInboxNotificationsRequest{ReadStatus: codersdk.InboxNotificationReadStatusUnread})
+		require.NoError(t, err)
+		require.Len(t, resp.Notifications, 2)
+		require.Equal(t, 2, resp.UnreadCount)
+		resp, err = memberClient.ListInboxNotifications(ctx, member.ID, codersdk.ListInboxNotificationsRequest{ReadStatus: codersdk.InboxNotificationReadStatusRead})
+		require.NoError(t, err)
+		require.Len(t, resp.Notifications, 1)
+		require.Equal(t, notifs[1].ID, resp.Notifications[0].ID)
+
+		// When: archiving one.
+		_, err = memberClient.UpdateInboxNotification(ctx, member.ID, notifs[0].ID, codersdk.UpdateInboxNotificationRequest{Archived: ptr.Ref(true)})
+		require.NoError(t, err)
+
+		// Then: it is only listed when archived notifications are included.
+		resp, err = memberClient.ListInboxNotifications(ctx, member.ID, codersdk.ListInboxNotificationsRequest{})
+		require.NoError(t, err)
+		require.Len(t, resp.Notifications, 2)
+		require.Equal(t, 1, resp.UnreadCount)
+		resp, err = memberClient.ListInboxNotifications(ctx, member.ID, codersdk.ListInboxNotificationsRequest{IncludeArchived: true})
+		require.NoError(t, err)
+		require.Len(t, resp.Notifications, 3)
+
+		// When: marking all as read.
+		marked, err := memberClient.MarkAllInboxNotificationsAsRead(ctx, member.ID)
+		require.NoError(t, err)
+		require.EqualValues(t, 2, marked.Updated)
+
+		// Then: none are unread.
+		resp, err = memberClient.ListInboxNotifications(ctx, member.ID, codersdk.ListInboxNotificationsRequest{})
+		require.NoError(t, err)
+		require.Zero(t, resp.UnreadCount)
+	})
+
+	t.Run("Pagination", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitSuperLong)
+		opts, db, _ := inboxOpts(t)
+		api := coderdtest.New(t, opts)
+		firstUser := coderdtest.CreateFirstUser(t, api)
+		memberClient, member := coderdtest.CreateAnotherUser(t, api, firstUser.OrganizationID)
+
+		now := dbtime.Now()
+		var ids []uuid.UUID
+		for i := 0; i < 5; i++ {
+			n := dbgen.InboxNotification(t, db, database.InboxNotification{
+				UserID:     member.ID,
+				TemplateID: notifications.TemplateWorkspaceDeleted,
+				CreatedAt:  now.Add(-time.Duration(i) * time.Minute),
+			})
+			ids = append(ids, n.ID)
+		}
+
+		page, err := memberClient.ListInboxNotifications(ctx, member.ID, codersdk.ListInboxNotificationsRequest{
+			Pagination: codersdk.Pagination{Limit: 2},
+		})
+		require.NoError(t, err)
+		require.Len(t, page.Notifications, 2)
+		require.Equal(t, ids[:2], []uuid.UUID{page.Notifications[0].ID, page.Notifications[1].ID})
+
+		page, err = memberClient.ListInboxNotifications(ctx, member.ID, codersdk.ListInboxNotificationsRequest{
+			Pagination: codersdk.Pagination{Limit: 2, AfterID: ids[1]},
+		})
+		require.NoError(t, err)
+		require.Len(t, page.Notifications, 2)
+		require.Equal(t, ids[2:4], []uuid.UUID{page.Notifications[0].ID, page.Notifications[1].ID})
+
+		page, err = memberClient.ListInboxNotifications(ctx, member.ID, codersdk.ListInboxNotificationsRequest{
+			Pagination: codersdk.Pagination{Limit: 2, Offset: 4},
+		})
+		require.NoError(t, err)
+		require.Len(t, page.Notifications, 1)
+		require.Equal(t, ids[4], page.Notifications[0].ID)
+	})
+
+	t.Run("Invalid read status", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitSuperLong)
+		api := coderdtest.New(t, createOpts(t))
+		firstUser := coderdtest.CreateFirstUser(t, api)
+		memberClient, member := coderdtest.CreateAnotherUser(t, api, firstUser.OrganizationID)
+
+		_, err := memberClient.ListInboxNotifications(ctx, member.ID, codersdk.ListInboxNotificationsRequest{ReadStatus: "skimmed"})
+		var sdkError *codersdk.Error
+		require.ErrorAsf(t, err, &sdkError, "error should be of type *codersdk.Error")
+		require.Equal(t, http.StatusBadRequest, sdkError.StatusCode())
+		require.NotEmpty(t, sdkError.Validations)
+	})
+
+	t.Run("Other user's notification", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitSuperLong)
+		opts, db, _ := inboxOpts(t)
+		api := coderdtest.New(t, opts)
+		firstUser := coderdtest.CreateFirstUser(t, api)
+		memberClient, member := coderdtest.CreateAnotherUser(t, api, firstUser.OrganizationID)
+
+		// Given: a notification belonging to the owner.
+		notif := dbgen.InboxNotification(t, db, database.InboxNotification{
+			UserID:     firstUser.UserID,
+			TemplateID: notifications.TemplateWorkspaceDeleted,
+		})
+
+		// When: a member attempts to mark it as read through their own inbox.
+		_, err := memberClient.UpdateInboxNotification(ctx, member.ID, notif.ID, codersdk.UpdateInboxNotificationRequest{Read: ptr.Ref(true)})
+
+		// Then: it is not found.
+		var sdkError *codersdk.Error
+		require.ErrorAsf(t, err, &sdkError, "error should be of type *codersdk.Error")
+		require.Equal(t, http.StatusNotFound, sdkError.StatusCode())
+	})
+
+	t.Run("Watch", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitSuperLong)
+		opts, db, ps := inboxOpts(t)
+		api := coderdtest.New(t, opts)
+		firstUser := coderdtest.CreateFirstUser(t, api)
+		memberClient, member := coderdtest.CreateAnotherUser(t, api, firstUser.OrganizationID)
+
+		notifs, err := memberClient.WatchInboxNotifications(ctx, member.ID)
+		require.NoError(t, err)
+
+		// When: a notification is dispatched to the member's inbox.
+		handler := dispatch.NewInboxHandler(db, ps, slogtest.Make(t, nil))
+		deliver, err := handler.Dispatcher(types.MessagePayload{
+			UserID:                 member.ID.String(),
+			NotificationTemplateID: notifications.TemplateWorkspaceDeleted.String(),
+		}, "Workspace **deleted**", "Your workspace was deleted.")
+		require.NoError(t, err)
+		msgID := uuid.New()
+		_, err = deliver(ctx, msgID)
+		require.NoError(t, err)
+
+		// Then: it is streamed to the watcher.
+		select {
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for inbox notification")
+		case notif, ok := <-notifs:
+			require.True(t, ok)
+			require.Equal(t, msgID, notif.ID)
+			require.Equal(t, "Workspace deleted", notif.Title)
+			require.Equal(t, "Your workspace was deleted.", notif.Content)
+		}
+	})
 }
 
 func TestNotificationDispatchMethods(t *testing.T) {
@@ -0,0 +1,93 @@
+package objectstore
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/xerrors"
+)
+
+// DirStore stores objects as files in a local directory.
+type DirStore struct {
+	dir string
+}
+
+// NewDirStore creates a store for the given directory, creating it if it does
+// not exist.
+func NewDirStore(dir string) (*DirStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, xerrors.Errorf("create directory: %w", err)
+	}
+	return &DirStore{dir: dir}, nil
+}
+
+// Put writes the object with WriteFile.
+func (s *DirStore) Put(_ context.Context, name string, data []byte) error {
+	p, err := s.Path(name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o700); err != nil {
+		return xerrors.Errorf("create directory: %w", err)
+	}
+	return WriteFile(p, data)
+}
+
+// Get reads the object.
+func (s *DirStore) Get(_ context.Context, name string) ([]byte, error) {
+	p, err := s.Path(name)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(p)
+	if xerrors.Is(err, os.ErrNotExist) {
+		return nil, xerrors.Errorf("read %q: %w", name, ErrNotFound)
+	}
+	if err != nil {
+		return nil, xerrors.Errorf("read: %w", err)
+	}
+	return data, nil
+}
+
+// Path returns the path of the named object, which must not escape the
+// directory.
+func (s *DirStore) Path(name string) (string, error) {
+	name = filepath.FromSlash(name)
+	if !filepath.IsLocal(name) {
+		return "", xerrors.Errorf("invalid object name %q", name)
+	}
+	return filepath.Join(s.dir, name), nil
+}
+
+// WriteFile writes the file to a temporary name in the same directory, syncs
+// it and renames it into place, so that a partially written file is never
+// visible under its final name. The temporary name starts with a dot, so
+// readers of the directory can tell it apart and remove it if the write was
+// interrupted.
+func WriteFile(name string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(name), "."+filepath.Base(name)+".*")
+	if err != nil {
+		return xerrors.Errorf("create temporary file: %w", err)
+	}
+	defer func() {
+		// Clean up in case of failure; this is a no-op once the file has been renamed.
+		_ = os.Remove(f.Name())
+	}()
+
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return xerrors.Errorf("write: %w", err)
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return xerrors.Errorf("sync: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return xerrors.Errorf("close: %w", err)
+	}
+	if err := os.Rename(f.Name(), name); err != nil {
+		return xerrors.Errorf("rename: %w", err)
+	}
+	return nil
+}
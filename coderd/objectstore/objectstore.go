@@ -0,0 +1,39 @@
+// Package objectstore reads and writes objects in a local directory or in an
+// S3-compatible bucket. It's shared by the features that keep data outside of
+// the database, like audit log archives and provisioner state.
+//
+// Objects are addressed by slash-separated names, and a write never leaves a
+// partially written object visible under its name.
+package objectstore
+
+import (
+	"path"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+// ErrNotFound is returned when an object doesn't exist.
+var ErrNotFound = xerrors.New("object not found")
+
+// ErrPreconditionFailed is returned when the condition of a conditional
+// request doesn't hold, e.g. because the object changed in the meantime.
+var ErrPreconditionFailed = xerrors.New("precondition failed")
+
+// Info describes a stored object.
+type Info struct {
+	// ETag identifies the version of the object, for conditional requests.
+	ETag         string
+	LastModified time.Time
+}
+
+func contentType(name string) string {
+	switch path.Ext(name) {
+	case ".gz":
+		return "application/gzip"
+	case ".json":
+		return "application/json"
+	default:
+		return "application/octet-stream"
+	}
+}
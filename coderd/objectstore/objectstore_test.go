@@ -0,0 +1,105 @@
+package objectstore_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/coderd/objectstore"
+	"github.com/coder/coder/v2/coderd/objectstore/objectstoretest"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestDirStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutil.Context(t, testutil.WaitShort)
+	dir := t.TempDir()
+	store, err := objectstore.NewDirStore(dir)
+	require.NoError(t, err)
+
+	require.NoError(t, store.Put(ctx, "a/b.json", []byte("data")))
+	data, err := store.Get(ctx, "a/b.json")
+	require.NoError(t, err)
+	require.Equal(t, []byte("data"), data)
+
+	// No temporary file is left behind.
+	entries, err := os.ReadDir(filepath.Join(dir, "a"))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	_, err = store.Get(ctx, "missing")
+	require.ErrorIs(t, err, objectstore.ErrNotFound)
+	_, err = store.Get(ctx, "../outside")
+	require.ErrorContains(t, err, "invalid object name")
+	err = store.Put(ctx, "../outside", nil)
+	require.ErrorContains(t, err, "invalid object name")
+}
+
+//nolint:paralleltest // It sets environment variables.
+func TestS3Store(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "test-access-key")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test-secret-key")
+	t.Setenv("AWS_REGION", "eu-west-2")
+
+	fake := objectstoretest.NewFakeS3()
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+
+	ctx := testutil.Context(t, testutil.WaitShort)
+	var cfg codersdk.ObjectStoreS3Config
+	require.NoError(t, cfg.Bucket.Set("bucket"))
+	require.NoError(t, cfg.Prefix.Set("/coder/"))
+	require.NoError(t, cfg.Endpoint.Set(srv.URL))
+	store, err := objectstore.NewS3Store(ctx, cfg)
+	require.NoError(t, err)
+
+	require.NoError(t, store.Put(ctx, "a/b.json", []byte("data")))
+	require.Contains(t, fake.Names(), "/bucket/coder/a/b.json")
+	data, err := store.Get(ctx, "a/b.json")
+	require.NoError(t, err)
+	require.Equal(t, []byte("data"), data)
+	_, err = store.Get(ctx, "missing")
+	require.ErrorIs(t, err, objectstore.ErrNotFound)
+	_, err = store.Head(ctx, "missing")
+	require.ErrorIs(t, err, objectstore.ErrNotFound)
+
+	// Objects are only created if they don't exist.
+	etag, err := store.Create(ctx, "created", nil)
+	require.NoError(t, err)
+	require.NotEmpty(t, etag)
+	_, err = store.Create(ctx, "created", nil)
+	require.ErrorIs(t, err, objectstore.ErrPreconditionFailed)
+
+	info, err := store.Head(ctx, "created")
+	require.NoError(t, err)
+	require.Equal(t, etag, info.ETag)
+	require.WithinDuration(t, time.Now(), info.LastModified, time.Minute)
+
+	// A conditional delete fails once the object has changed.
+	require.NoError(t, store.Put(ctx, "created", []byte("changed")))
+	err = store.Delete(ctx, "created", etag)
+	require.ErrorIs(t, err, objectstore.ErrPreconditionFailed)
+	require.Contains(t, fake.Names(), "/bucket/coder/created")
+	require.NoError(t, store.Delete(ctx, "created", ""))
+	require.NotContains(t, fake.Names(), "/bucket/coder/created")
+
+	// Errors from the object store are surfaced.
+	rejecting := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		rw.WriteHeader(http.StatusForbidden)
+		_, _ = rw.Write([]byte("AccessDenied"))
+	}))
+	t.Cleanup(rejecting.Close)
+
+	require.NoError(t, cfg.Endpoint.Set(rejecting.URL))
+	store, err = objectstore.NewS3Store(ctx, cfg)
+	require.NoError(t, err)
+	err = store.Put(ctx, "manifest.json", []byte("{}"))
+	require.ErrorContains(t, err, "unexpected status 403: AccessDenied")
+}
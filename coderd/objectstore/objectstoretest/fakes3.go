@@ -0,0 +1,98 @@
+// Package objectstoretest provides an in-memory S3-compatible object store
+// for tests.
+package objectstoretest
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// FakeS3 is an in-memory object store which supports the requests made by
+// objectstore.S3Store, including conditional writes and deletes. Objects are
+// keyed by the path of their URL, which starts with the bucket.
+type FakeS3 struct {
+	mu      sync.Mutex
+	version int
+	objects map[string]object
+}
+
+type object struct {
+	data     []byte
+	etag     string
+	modified time.Time
+}
+
+// NewFakeS3 returns an empty object store.
+func NewFakeS3() *FakeS3 {
+	return &FakeS3{objects: map[string]object{}}
+}
+
+func (f *FakeS3) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") == "" {
+		rw.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	obj, ok := f.objects[r.URL.Path]
+	ifMatch := r.Header.Get("If-Match")
+	switch r.Method {
+	case http.MethodPut:
+		if ok && r.Header.Get("If-None-Match") == "*" {
+			rw.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		data, _ := io.ReadAll(r.Body)
+		obj = f.putLocked(r.URL.Path, data, time.Now())
+		rw.Header().Set("ETag", obj.etag)
+	case http.MethodGet, http.MethodHead:
+		if !ok {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+		rw.Header().Set("ETag", obj.etag)
+		rw.Header().Set("Last-Modified", obj.modified.UTC().Format(http.TimeFormat))
+		if r.Method == http.MethodGet {
+			_, _ = rw.Write(obj.data)
+		}
+	case http.MethodDelete:
+		if ifMatch != "" && (!ok || obj.etag != ifMatch) {
+			rw.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		delete(f.objects, r.URL.Path)
+		rw.WriteHeader(http.StatusNoContent)
+	default:
+		rw.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// Put stores an object as if it was last modified at the given time, e.g.
+// to simulate an object left behind by another client.
+func (f *FakeS3) Put(name string, data []byte, modified time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.putLocked(name, data, modified)
+}
+
+func (f *FakeS3) putLocked(name string, data []byte, modified time.Time) object {
+	f.version++
+	obj := object{data: data, etag: fmt.Sprintf("%q", fmt.Sprint(f.version)), modified: modified}
+	f.objects[name] = obj
+	return obj
+}
+
+// Names returns the names of the stored objects.
+func (f *FakeS3) Names() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	names := make([]string, 0, len(f.objects))
+	for name := range f.objects {
+		names = append(names, name)
+	}
+	return names
+}
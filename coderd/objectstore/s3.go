@@ -0,0 +1,220 @@
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/codersdk"
+)
+
+// S3Store stores objects in an S3-compatible bucket. Requests are made in path
+// style, which is supported both by AWS and by most S3-compatible object
+// stores.
+type S3Store struct {
+	client   *http.Client
+	endpoint *url.URL
+	bucket   string
+	prefix   string
+	region   string
+	creds    aws.CredentialsProvider
+	signer   *v4.Signer
+}
+
+// NewS3Store creates a store for the bucket in the given configuration.
+// Credentials, and the region if it is not configured, are read from the
+// standard AWS environment variables and configuration files.
+func NewS3Store(ctx context.Context, cfg codersdk.ObjectStoreS3Config) (*S3Store, error) {
+	awsCfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, xerrors.Errorf("load aws config: %w", err)
+	}
+
+	region := cfg.Region.String()
+	if region == "" {
+		region = awsCfg.Region
+	}
+	if region == "" {
+		return nil, xerrors.Errorf("the region of bucket %q must be configured", cfg.Bucket.String())
+	}
+
+	endpoint := cfg.Endpoint.Value()
+	if endpoint == nil || endpoint.String() == "" {
+		endpoint = &url.URL{Scheme: "https", Host: fmt.Sprintf("s3.%s.amazonaws.com", region)}
+	}
+
+	return &S3Store{
+		client:   &http.Client{Timeout: time.Minute},
+		endpoint: endpoint,
+		bucket:   cfg.Bucket.String(),
+		prefix:   strings.Trim(cfg.Prefix.String(), "/"),
+		region:   region,
+		creds:    awsCfg.Credentials,
+		signer:   v4.NewSigner(),
+	}, nil
+}
+
+// Put uploads the object with a single PutObject request. S3 only makes an
+// object visible once it has been uploaded in full.
+func (s *S3Store) Put(ctx context.Context, name string, data []byte) error {
+	resp, err := s.do(ctx, http.MethodPut, name, data, nil)
+	if err != nil {
+		return xerrors.Errorf("put object: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return xerrors.Errorf("put object: %w", statusError(resp))
+	}
+	return nil
+}
+
+// Create uploads the object unless it already exists, in which case
+// ErrPreconditionFailed is returned. It returns the ETag of the object.
+func (s *S3Store) Create(ctx context.Context, name string, data []byte) (string, error) {
+	resp, err := s.do(ctx, http.MethodPut, name, data, http.Header{
+		"If-None-Match": []string{"*"},
+	})
+	if err != nil {
+		return "", xerrors.Errorf("put object: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Header.Get("ETag"), nil
+	// A conflict means that the object is being created concurrently.
+	case http.StatusPreconditionFailed, http.StatusConflict:
+		return "", xerrors.Errorf("put object: %w", ErrPreconditionFailed)
+	default:
+		return "", xerrors.Errorf("put object: %w", statusError(resp))
+	}
+}
+
+// Get downloads the object.
+func (s *S3Store) Get(ctx context.Context, name string) ([]byte, error) {
+	resp, err := s.do(ctx, http.MethodGet, name, nil, nil)
+	if err != nil {
+		return nil, xerrors.Errorf("get object: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, xerrors.Errorf("get object %q: %w", name, ErrNotFound)
+	default:
+		return nil, xerrors.Errorf("get object: %w", statusError(resp))
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, xerrors.Errorf("read object: %w", err)
+	}
+	return data, nil
+}
+
+// Head returns the ETag and the modification time of the object.
+func (s *S3Store) Head(ctx context.Context, name string) (Info, error) {
+	resp, err := s.do(ctx, http.MethodHead, name, nil, nil)
+	if err != nil {
+		return Info{}, xerrors.Errorf("head object: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return Info{}, xerrors.Errorf("head object %q: %w", name, ErrNotFound)
+	default:
+		return Info{}, xerrors.Errorf("head object: %w", statusError(resp))
+	}
+	lastModified, err := http.ParseTime(resp.Header.Get("Last-Modified"))
+	if err != nil {
+		return Info{}, xerrors.Errorf("parse last modified time: %w", err)
+	}
+	return Info{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: lastModified,
+	}, nil
+}
+
+// Delete removes the object. If etag is set, the object is only removed if
+// it's still the version with that ETag, otherwise ErrPreconditionFailed is
+// returned. Deleting an object that doesn't exist isn't an error.
+func (s *S3Store) Delete(ctx context.Context, name, etag string) error {
+	var header http.Header
+	if etag != "" {
+		header = http.Header{"If-Match": []string{etag}}
+	}
+	resp, err := s.do(ctx, http.MethodDelete, name, nil, header)
+	if err != nil {
+		return xerrors.Errorf("delete object: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	case http.StatusPreconditionFailed:
+		return xerrors.Errorf("delete object: %w", ErrPreconditionFailed)
+	default:
+		return xerrors.Errorf("delete object: %w", statusError(resp))
+	}
+}
+
+// do sends a signed request for the named object.
+func (s *S3Store) do(ctx context.Context, method, name string, data []byte, header http.Header) (*http.Response, error) {
+	u := s.endpoint.JoinPath(s.bucket, path.Join(s.prefix, name))
+	var body io.Reader
+	if data != nil {
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, xerrors.Errorf("create request: %w", err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	if method == http.MethodPut {
+		req.Header.Set("Content-Type", contentType(name))
+	}
+
+	sum := sha256.Sum256(data)
+	payloadHash := hex.EncodeToString(sum[:])
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	if s.creds == nil {
+		return nil, xerrors.New("no aws credentials are configured")
+	}
+	creds, err := s.creds.Retrieve(ctx)
+	if err != nil {
+		return nil, xerrors.Errorf("retrieve aws credentials: %w", err)
+	}
+	err = s.signer.SignHTTP(ctx, creds, req, payloadHash, "s3", s.region, time.Now(), func(o *v4.SignerOptions) {
+		// S3 expects the path to be escaped exactly once.
+		o.DisableURIPathEscaping = true
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("sign request: %w", err)
+	}
+	return s.client.Do(req)
+}
+
+func statusError(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return xerrors.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+}
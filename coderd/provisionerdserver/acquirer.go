@@ -18,6 +18,7 @@ import (
 	"github.com/coder/coder/v2/coderd/database/dbtime"
 	"github.com/coder/coder/v2/coderd/database/provisionerjobs"
 	"github.com/coder/coder/v2/coderd/database/pubsub"
+	"github.com/coder/coder/v2/provisionersdk"
 )
 
 const (
@@ -32,8 +33,8 @@ const (
 // acquiree's logic by handling retrying the database if a job is not available at the time of the
 // call.
 //
-// When multiple acquirees share a set of provisioner types and tags, we define them as part of the
-// same "domain".  Only one acquiree from each domain may query the database at a time.  If the
+// When multiple acquirees share a set of provisioner types, tags and IaC binaries, we define them as
+// part of the same "domain".  Only one acquiree from each domain may query the database at a time.  If the
 // database returns no jobs for that acquiree, the entire domain waits until the Acquirer is
 // notified over the pubsub of a new job acceptable to the domain.
 //
@@ -64,6 +65,7 @@ func TestingBackupPollDuration(dur time.Duration) AcquirerOption {
 // AcquirerStore is the subset of database.Store that the Acquirer needs
 type AcquirerStore interface {
 	AcquireProvisionerJob(context.Context, database.AcquireProvisionerJobParams) (database.ProvisionerJob, error)
+	GetPendingProvisionerJobIaCRequirements(ctx context.Context, organizationID uuid.UUID) ([]string, error)
 }
 
 func NewAcquirer(ctx context.Context, logger slog.Logger, store AcquirerStore, ps pubsub.Pubsub,
@@ -85,11 +87,13 @@ func NewAcquirer(ctx context.Context, logger slog.Logger, store AcquirerStore, p
 }
 
 // AcquireJob acquires a job with one of the given provisioner types and compatible
-// tags from the database.  The call blocks until a job is acquired, the context is
+// tags from the database.  Jobs that require an IaC engine are only acquired if one
+// of the given binaries satisfies the requirement.  The call blocks until a job is acquired, the context is
 // done, or the database returns an error _other_ than that no jobs are available.
 // If no jobs are available, this method handles retrying as appropriate.
 func (a *Acquirer) AcquireJob(
 	ctx context.Context, organization uuid.UUID, worker uuid.UUID, pt []database.ProvisionerType, tags Tags,
+	binaries []provisionersdk.IaCBinary,
 ) (
 	retJob database.ProvisionerJob, retErr error,
 ) {
@@ -97,9 +101,10 @@ func (a *Acquirer) AcquireJob(
 		slog.F("organization_id", organization),
 		slog.F("worker_id", worker),
 		slog.F("provisioner_types", pt),
-		slog.F("tags", tags))
+		slog.F("tags", tags),
+		slog.F("iac_binaries", binaries))
 	logger.Debug(ctx, "acquiring job")
-	dk := domainKey(organization, pt, tags)
+	dk := domainKey(organization, pt, tags, binaries)
 	dbTags, err := tags.ToJSON()
 	if err != nil {
 		return database.ProvisionerJob{}, err
@@ -107,7 +112,7 @@ func (a *Acquirer) AcquireJob(
 	// buffer of 1 so that cancel doesn't deadlock while writing to the channel
 	clearance := make(chan struct{}, 1)
 	for {
-		a.want(organization, pt, tags, clearance)
+		a.want(organization, pt, tags, binaries, clearance)
 		select {
 		case <-ctx.Done():
 			err := ctx.Err()
@@ -120,6 +125,15 @@ func (a *Acquirer) AcquireJob(
 			return database.ProvisionerJob{}, err
 		case <-clearance:
 			logger.Debug(ctx, "got clearance to call database")
+			requirements, err := a.satisfiedRequirements(ctx, organization, binaries)
+			if err != nil {
+				internalError := a.done(dk, clearance)
+				if internalError != nil {
+					return database.ProvisionerJob{}, internalError
+				}
+				logger.Warn(ctx, "error attempting to get IaC requirements", slog.Error(err))
+				return database.ProvisionerJob{}, xerrors.Errorf("failed to get IaC requirements: %w", err)
+			}
 			job, err := a.store.AcquireProvisionerJob(ctx, database.AcquireProvisionerJobParams{
 				OrganizationID: organization,
 				StartedAt: sql.NullTime{
@@ -130,8 +144,9 @@ func (a *Acquirer) AcquireJob(
 					UUID:  worker,
 					Valid: true,
 				},
-				Types: pt,
-				Tags:  dbTags,
+				Types:           pt,
+				Tags:            dbTags,
+				IacRequirements: requirements,
 			})
 			if xerrors.Is(err, sql.ErrNoRows) {
 				logger.Debug(ctx, "no job available")
@@ -153,9 +168,32 @@ func (a *Acquirer) AcquireJob(
 	}
 }
 
+// satisfiedRequirements returns the IaC requirements of pending jobs that one of
+// the given binaries satisfies, in the format the database matches jobs against.
+// Version constraints can't be evaluated by the database, so they are evaluated
+// here instead.
+func (a *Acquirer) satisfiedRequirements(ctx context.Context, organization uuid.UUID, binaries []provisionersdk.IaCBinary) ([]string, error) {
+	if len(binaries) == 0 {
+		return []string{}, nil
+	}
+	pending, err := a.store.GetPendingProvisionerJobIaCRequirements(ctx, organization)
+	if err != nil {
+		return nil, err
+	}
+	satisfied := []string{}
+	for _, raw := range pending {
+		engine, constraint, _ := strings.Cut(raw, " ")
+		requirement := provisionersdk.IaCRequirement{Engine: engine, Version: constraint}
+		if _, ok := requirement.Select(binaries); ok {
+			satisfied = append(satisfied, raw)
+		}
+	}
+	return satisfied, nil
+}
+
 // want signals that an acquiree wants clearance to query for a job with the given dKey.
-func (a *Acquirer) want(organization uuid.UUID, pt []database.ProvisionerType, tags Tags, clearance chan<- struct{}) {
-	dk := domainKey(organization, pt, tags)
+func (a *Acquirer) want(organization uuid.UUID, pt []database.ProvisionerType, tags Tags, binaries []provisionersdk.IaCBinary, clearance chan<- struct{}) {
+	dk := domainKey(organization, pt, tags, binaries)
 	a.mu.Lock()
 	defer a.mu.Unlock()
 	cleared := false
@@ -169,6 +207,7 @@ func (a *Acquirer) want(organization uuid.UUID, pt []database.ProvisionerType, t
 			key:            dk,
 			pt:             pt,
 			tags:           tags,
+			binaries:       binaries,
 			organizationID: organization,
 			acquirees:      make(map[chan<- struct{}]*acquiree),
 		}
@@ -402,12 +441,12 @@ func (*Acquirer) clearOrPendLocked(d domain) {
 
 type dKey string
 
-// domainKey generates a canonical map key for the given provisioner types and
-// tags.  It uses the null byte (0x00) as a delimiter because it is an
+// domainKey generates a canonical map key for the given provisioner types,
+// tags and IaC binaries.  It uses the null byte (0x00) as a delimiter because it is an
 // unprintable control character and won't show up in any "reasonable" set of
 // string tags, even in non-Latin scripts.  It is important that Tags are
 // validated not to contain this control character prior to use.
-func domainKey(orgID uuid.UUID, pt []database.ProvisionerType, tags Tags) dKey {
+func domainKey(orgID uuid.UUID, pt []database.ProvisionerType, tags Tags, binaries []provisionersdk.IaCBinary) dKey {
 	sb := strings.Builder{}
 	_, _ = sb.WriteString(orgID.String())
 	_ = sb.WriteByte(0x00)
@@ -433,6 +472,16 @@ func domainKey(orgID uuid.UUID, pt []database.ProvisionerType, tags Tags) dKey {
 		_, _ = sb.WriteString(tags[k])
 		_ = sb.WriteByte(0x00)
 	}
+	_ = sb.WriteByte(0x00)
+	bs := make([]string, 0, len(binaries))
+	for _, b := range binaries {
+		bs = append(bs, b.String())
+	}
+	slices.Sort(bs)
+	for _, b := range bs {
+		_, _ = sb.WriteString(b)
+		_ = sb.WriteByte(0x00)
+	}
 	return dKey(sb.String())
 }
 
@@ -447,8 +496,8 @@ type acquiree struct {
 	pending bool
 }
 
-// domain represents a set of acquirees with the same provisioner types and
-// tags.  Acquirees in the same domain are restricted such that only one queries
+// domain represents a set of acquirees with the same provisioner types, tags
+// and IaC binaries.  Acquirees in the same domain are restricted such that only one queries
 // the database at a time.
 type domain struct {
 	ctx            context.Context
@@ -457,6 +506,7 @@ type domain struct {
 	key            dKey
 	pt             []database.ProvisionerType
 	tags           Tags
+	binaries       []provisionersdk.IaCBinary
 	organizationID uuid.UUID
 	acquirees      map[chan<- struct{}]*acquiree
 }
@@ -471,6 +521,9 @@ func (d domain) contains(p provisionerjobs.JobPosting) bool {
 		return false
 	}
 	for k, v := range p.Tags {
+		if k == provisionersdk.TagIaCEngine || k == provisionersdk.TagIaCVersion {
+			continue
+		}
 		dv, ok := d.tags[k]
 		if !ok {
 			return false
@@ -479,6 +532,11 @@ func (d domain) contains(p provisionerjobs.JobPosting) bool {
 			return false
 		}
 	}
+	if requirement, ok := provisionersdk.IaCRequirementFromTags(p.Tags); ok {
+		if _, ok := requirement.Select(d.binaries); !ok {
+			return false
+		}
+	}
 	return true
 }
 
@@ -26,6 +26,7 @@ import (
 	"github.com/coder/coder/v2/coderd/database/provisionerjobs"
 	"github.com/coder/coder/v2/coderd/database/pubsub"
 	"github.com/coder/coder/v2/coderd/provisionerdserver"
+	"github.com/coder/coder/v2/provisionersdk"
 	"github.com/coder/coder/v2/testutil"
 )
 
@@ -507,7 +508,7 @@ func TestAcquirer_MatchTags(t *testing.T) {
 			if tt.unmatchedOrg {
 				acquireOrgID = uuid.New()
 			}
-			aj, err := acq.AcquireJob(ctx, acquireOrgID, uuid.New(), ptypes, tt.acquireJobTags)
+			aj, err := acq.AcquireJob(ctx, acquireOrgID, uuid.New(), ptypes, tt.acquireJobTags, nil)
 			if tt.expectAcquire {
 				assert.NoError(t, err)
 				assert.Equal(t, pj.ID, aj.ID)
@@ -550,6 +551,117 @@ func TestAcquirer_MatchTags(t *testing.T) {
 	})
 }
 
+func TestAcquirer_MatchIaCRequirement(t *testing.T) {
+	t.Parallel()
+	if testing.Short() {
+		t.Skip("skipping this test due to -short")
+	}
+
+	binary := func(s string) provisionersdk.IaCBinary {
+		b, err := provisionersdk.ParseIaCBinary(s)
+		require.NoError(t, err)
+		return b
+	}
+	testCases := []struct {
+		name          string
+		jobTags       map[string]string
+		binaries      []provisionersdk.IaCBinary
+		expectAcquire bool
+	}{
+		{
+			name:          "job without requirement and provisioner with binaries",
+			jobTags:       map[string]string{"scope": "organization", "owner": ""},
+			binaries:      []provisionersdk.IaCBinary{binary("terraform@1.8.5")},
+			expectAcquire: true,
+		},
+		{
+			name:          "job with requirement and provisioner without binaries",
+			jobTags:       map[string]string{"scope": "organization", "owner": "", "coder.iac.engine": "terraform"},
+			expectAcquire: false,
+		},
+		{
+			name:          "job with engine requirement and provisioner with engine",
+			jobTags:       map[string]string{"scope": "organization", "owner": "", "coder.iac.engine": "opentofu"},
+			binaries:      []provisionersdk.IaCBinary{binary("terraform@1.8.5"), binary("opentofu@1.8.2")},
+			expectAcquire: true,
+		},
+		{
+			name:          "job with engine requirement and provisioner with another engine",
+			jobTags:       map[string]string{"scope": "organization", "owner": "", "coder.iac.engine": "opentofu"},
+			binaries:      []provisionersdk.IaCBinary{binary("terraform@1.8.5")},
+			expectAcquire: false,
+		},
+		{
+			name:          "job with version requirement and provisioner with satisfying version",
+			jobTags:       map[string]string{"scope": "organization", "owner": "", "coder.iac.engine": "terraform", "coder.iac.version": "~> 1.8.0"},
+			binaries:      []provisionersdk.IaCBinary{binary("terraform@1.9.2"), binary("terraform@1.8.5")},
+			expectAcquire: true,
+		},
+		{
+			name:          "job with version requirement and provisioner without satisfying version",
+			jobTags:       map[string]string{"scope": "organization", "owner": "", "coder.iac.engine": "terraform", "coder.iac.version": "~> 1.8.0"},
+			binaries:      []provisionersdk.IaCBinary{binary("terraform@1.9.2")},
+			expectAcquire: false,
+		},
+		{
+			name:          "tagged job with requirement and tagged provisioner with binaries",
+			jobTags:       map[string]string{"scope": "organization", "owner": "", "environment": "on-prem", "coder.iac.engine": "terraform"},
+			binaries:      []provisionersdk.IaCBinary{binary("terraform@1.9.2")},
+			expectAcquire: true,
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := testutil.Context(t, testutil.WaitShort)
+			db, ps := dbtestutil.NewDB(t)
+			log := slogtest.Make(t, nil).Leveled(slog.LevelDebug)
+			org, err := db.InsertOrganization(ctx, database.InsertOrganizationParams{
+				ID:          uuid.New(),
+				Name:        "test org",
+				Description: "the organization of testing",
+				CreatedAt:   dbtime.Now(),
+				UpdatedAt:   dbtime.Now(),
+			})
+			require.NoError(t, err)
+			pj, err := db.InsertProvisionerJob(ctx, database.InsertProvisionerJobParams{
+				ID:             uuid.New(),
+				CreatedAt:      dbtime.Now(),
+				UpdatedAt:      dbtime.Now(),
+				OrganizationID: org.ID,
+				InitiatorID:    uuid.New(),
+				Provisioner:    database.ProvisionerTypeTerraform,
+				StorageMethod:  database.ProvisionerStorageMethodFile,
+				FileID:         uuid.New(),
+				Type:           database.ProvisionerJobTypeWorkspaceBuild,
+				Input:          []byte("{}"),
+				Tags:           tt.jobTags,
+				TraceMetadata:  pqtype.NullRawMessage{},
+			})
+			require.NoError(t, err)
+			ptypes := []database.ProvisionerType{database.ProvisionerTypeTerraform}
+			acq := provisionerdserver.NewAcquirer(ctx, log, db, ps)
+
+			// The provisioner has the tags of the job, other than the requirement.
+			tags := provisionerdserver.Tags{}
+			for k, v := range tt.jobTags {
+				if k != provisionersdk.TagIaCEngine && k != provisionersdk.TagIaCVersion {
+					tags[k] = v
+				}
+			}
+			aj, err := acq.AcquireJob(ctx, org.ID, uuid.New(), ptypes, tags, tt.binaries)
+			if tt.expectAcquire {
+				assert.NoError(t, err)
+				assert.Equal(t, pj.ID, aj.ID)
+			} else {
+				assert.Empty(t, aj, "should not have acquired job")
+				assert.ErrorIs(t, err, context.DeadlineExceeded, "should have timed out")
+			}
+		})
+	}
+}
+
 func postJob(t *testing.T, ps pubsub.Pubsub, pt database.ProvisionerType, tags provisionerdserver.Tags) {
 	t.Helper()
 	msg, err := json.Marshal(provisionerjobs.JobPosting{
@@ -609,6 +721,10 @@ func (s *fakeOrderedStore) AcquireProvisionerJob(
 	return job, err
 }
 
+func (*fakeOrderedStore) GetPendingProvisionerJobIaCRequirements(context.Context, uuid.UUID) ([]string, error) {
+	return nil, nil
+}
+
 func (s *fakeOrderedStore) sendCtx(ctx context.Context, job database.ProvisionerJob, err error) error {
 	select {
 	case <-ctx.Done():
@@ -676,6 +792,10 @@ jobLoop:
 	return database.ProvisionerJob{}, sql.ErrNoRows
 }
 
+func (*fakeTaggedStore) GetPendingProvisionerJobIaCRequirements(context.Context, uuid.UUID) ([]string, error) {
+	return nil, nil
+}
+
 // testAcquiree is a helper type that handles asynchronously calling AcquireJob
 // and asserting whether or not it returns, blocks, or is canceled.
 type testAcquiree struct {
@@ -702,7 +822,7 @@ func newTestAcquiree(t *testing.T, orgID uuid.UUID, workerID uuid.UUID, pt []dat
 
 func (a *testAcquiree) startAcquire(ctx context.Context, uut *provisionerdserver.Acquirer) {
 	go func() {
-		j, e := uut.AcquireJob(ctx, a.orgID, a.workerID, a.pt, a.tags)
+		j, e := uut.AcquireJob(ctx, a.orgID, a.workerID, a.pt, a.tags, nil)
 		a.ec <- e
 		a.jc <- j
 	}()
@@ -36,6 +36,7 @@ import (
 	"github.com/coder/coder/v2/coderd/externalauth"
 	"github.com/coder/coder/v2/coderd/notifications"
 	"github.com/coder/coder/v2/coderd/promoauth"
+	"github.com/coder/coder/v2/coderd/provisionerstate"
 	"github.com/coder/coder/v2/coderd/schedule"
 	"github.com/coder/coder/v2/coderd/telemetry"
 	"github.com/coder/coder/v2/coderd/tracing"
@@ -75,6 +76,15 @@ type Options struct {
 	// The default function just calls UpdateProvisionerDaemonLastSeenAt.
 	// This is mainly used for testing.
 	HeartbeatFn func(context.Context) error
+
+	// StateStore stores the provisioner state of workspace builds outside of
+	// the database. If nil, state is stored in the database.
+	StateStore provisionerstate.Store
+
+	// IaCBinaries are the versions of IaC engines the provisioner daemon can
+	// run. Jobs of template versions that require an engine are only acquired
+	// if one of them satisfies the requirement.
+	IaCBinaries []provisionersdk.IaCBinary
 }
 
 type server struct {
@@ -89,6 +99,7 @@ type server struct {
 	Provisioners                []database.ProvisionerType
 	ExternalAuthConfigs         []*externalauth.Config
 	Tags                        Tags
+	IaCBinaries                 []provisionersdk.IaCBinary
 	Database                    database.Store
 	Pubsub                      pubsub.Pubsub
 	Acquirer                    *Acquirer
@@ -100,6 +111,7 @@ type server struct {
 	UserQuietHoursScheduleStore *atomic.Pointer[schedule.UserQuietHoursScheduleStore]
 	DeploymentValues            *codersdk.DeploymentValues
 	NotificationsEnqueuer       notifications.Enqueuer
+	StateStore                  provisionerstate.Store
 
 	OIDCConfig promoauth.OAuth2Config
 
@@ -200,6 +212,7 @@ func NewServer(
 		Provisioners:                provisioners,
 		ExternalAuthConfigs:         options.ExternalAuthConfigs,
 		Tags:                        tags,
+		IaCBinaries:                 options.IaCBinaries,
 		Database:                    db,
 		Pubsub:                      ps,
 		Acquirer:                    acquirer,
@@ -211,6 +224,7 @@ func NewServer(
 		TemplateScheduleStore:       templateScheduleStore,
 		UserQuietHoursScheduleStore: userQuietHoursScheduleStore,
 		DeploymentValues:            deploymentValues,
+		StateStore:                  options.StateStore,
 		OIDCConfig:                  options.OIDCConfig,
 		TimeNowFn:                   options.TimeNowFn,
 		acquireJobLongPollDur:       options.AcquireJobLongPollDur,
@@ -296,7 +310,7 @@ func (s *server) AcquireJob(ctx context.Context, _ *proto.Empty) (*proto.Acquire
 	// database.
 	acqCtx, acqCancel := context.WithTimeout(ctx, s.acquireJobLongPollDur)
 	defer acqCancel()
-	job, err := s.Acquirer.AcquireJob(acqCtx, s.OrganizationID, s.ID, s.Provisioners, s.Tags)
+	job, err := s.Acquirer.AcquireJob(acqCtx, s.OrganizationID, s.ID, s.Provisioners, s.Tags, s.IaCBinaries)
 	if xerrors.Is(err, context.DeadlineExceeded) {
 		s.Logger.Debug(ctx, "successful cancel")
 		return &proto.AcquiredJob{}, nil
@@ -333,7 +347,7 @@ func (s *server) AcquireJobWithCancel(stream proto.DRPCProvisionerDaemon_Acquire
 	}()
 	jec := make(chan jobAndErr, 1)
 	go func() {
-		job, err := s.Acquirer.AcquireJob(acqCtx, s.OrganizationID, s.ID, s.Provisioners, s.Tags)
+		job, err := s.Acquirer.AcquireJob(acqCtx, s.OrganizationID, s.ID, s.Provisioners, s.Tags, s.IaCBinaries)
 		jec <- jobAndErr{job: job, err: err}
 	}()
 	var recvErr error
@@ -441,6 +455,10 @@ func (s *server) acquireProtoJob(ctx context.Context, job database.ProvisionerJo
 		UserName:      user.Username,
 		TraceMetadata: jobTraceMetadata,
 	}
+	if requirement, ok := provisionersdk.IaCRequirementFromTags(job.Tags); ok {
+		protoJob.IacEngine = requirement.Engine
+		protoJob.IacVersionConstraint = requirement.Version
+	}
 
 	switch job.Type {
 	case database.ProvisionerJobTypeWorkspaceBuild:
@@ -473,39 +491,15 @@ func (s *server) acquireProtoJob(ctx context.Context, job database.ProvisionerJo
 		if err != nil {
 			return nil, failJob(fmt.Sprintf("get owner: %s", err))
 		}
-		var ownerSSHPublicKey, ownerSSHPrivateKey string
-		if ownerSSHKey, err := s.Database.GetGitSSHKey(ctx, owner.ID); err != nil {
-			if !xerrors.Is(err, sql.ErrNoRows) {
-				return nil, failJob(fmt.Sprintf("get owner ssh key: %s", err))
-			}
-		} else {
-			ownerSSHPublicKey = ownerSSHKey.PublicKey
-			ownerSSHPrivateKey = ownerSSHKey.PrivateKey
-		}
-		ownerGroups, err := s.Database.GetGroups(ctx, database.GetGroupsParams{
-			HasMemberID:    owner.ID,
-			OrganizationID: s.OrganizationID,
-		})
+		ownerMetadata, err := s.getWorkspaceOwnerMetadata(ctx, owner)
 		if err != nil {
-			return nil, failJob(fmt.Sprintf("get owner group names: %s", err))
-		}
-		ownerGroupNames := []string{}
-		for _, group := range ownerGroups {
-			ownerGroupNames = append(ownerGroupNames, group.Group.Name)
+			return nil, failJob(err.Error())
 		}
 		err = s.Pubsub.Publish(codersdk.WorkspaceNotifyChannel(workspace.ID), []byte{})
 		if err != nil {
 			return nil, failJob(fmt.Sprintf("publish workspace update: %s", err))
 		}
 
-		var workspaceOwnerOIDCAccessToken string
-		if s.OIDCConfig != nil {
-			workspaceOwnerOIDCAccessToken, err = obtainOIDCAccessToken(ctx, s.Database, s.OIDCConfig, owner.ID)
-			if err != nil {
-				return nil, failJob(fmt.Sprintf("obtain OIDC access token: %s", err))
-			}
-		}
-
 		var sessionToken string
 		switch workspaceBuild.Transition {
 		case database.WorkspaceTransitionStart:
@@ -530,60 +524,21 @@ func (s *server) acquireProtoJob(ctx context.Context, job database.ProvisionerJo
 			return nil, failJob(fmt.Sprintf("get workspace build parameters: %s", err))
 		}
 
-		dbExternalAuthProviders := []database.ExternalAuthProvider{}
-		err = json.Unmarshal(templateVersion.ExternalAuthProviders, &dbExternalAuthProviders)
+		externalAuthProviders, err := s.getExternalAuthProviders(ctx, templateVersion, owner, workspaceBuild.WorkspaceID)
 		if err != nil {
-			return nil, xerrors.Errorf("failed to deserialize external_auth_providers value: %w", err)
+			return nil, failJob(err.Error())
 		}
 
-		externalAuthProviders := make([]*sdkproto.ExternalAuthProvider, 0, len(dbExternalAuthProviders))
-		for _, p := range dbExternalAuthProviders {
-			link, err := s.Database.GetExternalAuthLink(ctx, database.GetExternalAuthLinkParams{
-				ProviderID: p.ID,
-				UserID:     owner.ID,
-			})
-			if errors.Is(err, sql.ErrNoRows) {
-				continue
-			}
-			if err != nil {
-				return nil, failJob(fmt.Sprintf("acquire external auth link: %s", err))
-			}
-			var config *externalauth.Config
-			for _, c := range s.ExternalAuthConfigs {
-				if c.ID != p.ID {
-					continue
-				}
-				config = c
-				break
-			}
-			// We weren't able to find a matching config for the ID!
-			if config == nil {
-				s.Logger.Warn(ctx, "workspace build job is missing external auth provider",
-					slog.F("provider_id", p.ID),
-					slog.F("template_version_id", templateVersion.ID),
-					slog.F("workspace_id", workspaceBuild.WorkspaceID))
-				continue
-			}
-
-			refreshed, err := config.RefreshToken(ctx, s.Database, link)
-			if err != nil && !externalauth.IsInvalidTokenError(err) {
-				return nil, failJob(fmt.Sprintf("refresh external auth link %q: %s", p.ID, err))
-			}
-			if err != nil {
-				// Invalid tokens are skipped
-				continue
-			}
-			externalAuthProviders = append(externalAuthProviders, &sdkproto.ExternalAuthProvider{
-				Id:          p.ID,
-				AccessToken: refreshed.OAuthAccessToken,
-			})
+		state, err := provisionerstate.Read(ctx, s.StateStore, workspaceBuild)
+		if err != nil {
+			return nil, failJob(fmt.Sprintf("read provisioner state: %s", err))
 		}
 
 		protoJob.Type = &proto.AcquiredJob_WorkspaceBuild_{
 			WorkspaceBuild: &proto.AcquiredJob_WorkspaceBuild{
 				WorkspaceBuildId:      workspaceBuild.ID.String(),
 				WorkspaceName:         workspace.Name,
-				State:                 workspaceBuild.ProvisionerState,
+				State:                 state,
 				RichParameterValues:   convertRichParameterValues(workspaceBuildParameters),
 				VariableValues:        asVariableValues(templateVariables),
 				ExternalAuthProviders: externalAuthProviders,
@@ -594,29 +549,141 @@ func (s *server) acquireProtoJob(ctx context.Context, job database.ProvisionerJo
 					WorkspaceOwner:                owner.Username,
 					WorkspaceOwnerEmail:           owner.Email,
 					WorkspaceOwnerName:            owner.Name,
-					WorkspaceOwnerGroups:          ownerGroupNames,
-					WorkspaceOwnerOidcAccessToken: workspaceOwnerOIDCAccessToken,
+					WorkspaceOwnerGroups:          ownerMetadata.groupNames,
+					WorkspaceOwnerOidcAccessToken: ownerMetadata.oidcAccessToken,
 					WorkspaceId:                   workspace.ID.String(),
 					WorkspaceOwnerId:              owner.ID.String(),
 					TemplateId:                    template.ID.String(),
 					TemplateName:                  template.Name,
 					TemplateVersion:               templateVersion.Name,
 					WorkspaceOwnerSessionToken:    sessionToken,
-					WorkspaceOwnerSshPublicKey:    ownerSSHPublicKey,
-					WorkspaceOwnerSshPrivateKey:   ownerSSHPrivateKey,
+					WorkspaceOwnerSshPublicKey:    ownerMetadata.sshPublicKey,
+					WorkspaceOwnerSshPrivateKey:   ownerMetadata.sshPrivateKey,
 					WorkspaceBuildId:              workspaceBuild.ID.String(),
 				},
 				LogLevel: input.LogLevel,
 			},
 		}
-	case database.ProvisionerJobTypeTemplateVersionDryRun:
-		var input TemplateVersionDryRunJob
+	case database.ProvisionerJobTypeWorkspaceSnapshot:
+		var input WorkspaceSnapshotJob
 		err = json.Unmarshal(job.Input, &input)
 		if err != nil {
 			return nil, failJob(fmt.Sprintf("unmarshal job input %q: %s", job.Input, err))
 		}
+		snapshot, err := s.Database.GetWorkspaceSnapshotByID(ctx, input.WorkspaceSnapshotID)
+		if err != nil {
+			return nil, failJob(fmt.Sprintf("get workspace snapshot: %s", err))
+		}
+		workspaceBuild, err := s.Database.GetWorkspaceBuildByID(ctx, input.WorkspaceBuildID)
+		if err != nil {
+			return nil, failJob(fmt.Sprintf("get workspace build: %s", err))
+		}
+		workspace, err := s.Database.GetWorkspaceByID(ctx, workspaceBuild.WorkspaceID)
+		if err != nil {
+			return nil, failJob(fmt.Sprintf("get workspace: %s", err))
+		}
+		templateVersion, err := s.Database.GetTemplateVersionByID(ctx, workspaceBuild.TemplateVersionID)
+		if err != nil {
+			return nil, failJob(fmt.Sprintf("get template version: %s", err))
+		}
+		templateVariables, err := s.Database.GetTemplateVersionVariables(ctx, templateVersion.ID)
+		if err != nil && !xerrors.Is(err, sql.ErrNoRows) {
+			return nil, failJob(fmt.Sprintf("get template version variables: %s", err))
+		}
+		template, err := s.Database.GetTemplateByID(ctx, templateVersion.TemplateID.UUID)
+		if err != nil {
+			return nil, failJob(fmt.Sprintf("get template: %s", err))
+		}
+		owner, err := s.Database.GetUserByID(ctx, workspace.OwnerID)
+		if err != nil {
+			return nil, failJob(fmt.Sprintf("get owner: %s", err))
+		}
+		ownerMetadata, err := s.getWorkspaceOwnerMetadata(ctx, owner)
+		if err != nil {
+			return nil, failJob(err.Error())
+		}
+		transition, err := convertWorkspaceTransition(workspaceBuild.Transition)
+		if err != nil {
+			return nil, failJob(fmt.Sprintf("convert workspace transition: %s", err))
+		}
+		workspaceBuildParameters, err := s.Database.GetWorkspaceBuildParameters(ctx, workspaceBuild.ID)
+		if err != nil {
+			return nil, failJob(fmt.Sprintf("get workspace build parameters: %s", err))
+		}
+		externalAuthProviders, err := s.getExternalAuthProviders(ctx, templateVersion, owner, workspace.ID)
+		if err != nil {
+			return nil, failJob(err.Error())
+		}
 
-		templateVersion, err := s.Database.GetTemplateVersionByID(ctx, input.TemplateVersionID)
+		state, err := provisionerstate.Read(ctx, s.StateStore, workspaceBuild)
+		if err != nil {
+			return nil, failJob(fmt.Sprintf("read provisioner state: %s", err))
+		}
+
+		action := sdkproto.SnapshotAction_CREATE
+		var data []byte
+		// Creating a snapshot must leave the resources of the workspace as
+		// they are, so only a restore of a started workspace regenerates the
+		// session token of the owner, the way a start build does.
+		var sessionToken string
+		if input.Restore {
+			action = sdkproto.SnapshotAction_RESTORE
+			data = snapshot.Data
+			if workspaceBuild.Transition == database.WorkspaceTransitionStart {
+				sessionToken, err = s.regenerateSessionToken(ctx, owner, workspace)
+				if err != nil {
+					return nil, failJob(fmt.Sprintf("regenerate session token: %s", err))
+				}
+			}
+		}
+
+		protoJob.Type = &proto.AcquiredJob_WorkspaceSnapshot_{
+			WorkspaceSnapshot: &proto.AcquiredJob_WorkspaceSnapshot{
+				WorkspaceSnapshotId:   snapshot.ID.String(),
+				SnapshotName:          snapshot.Name,
+				Action:                action,
+				State:                 state,
+				Data:                  data,
+				RichParameterValues:   convertRichParameterValues(workspaceBuildParameters),
+				VariableValues:        asVariableValues(templateVariables),
+				ExternalAuthProviders: externalAuthProviders,
+				Metadata: &sdkproto.Metadata{
+					CoderUrl:                      s.AccessURL.String(),
+					WorkspaceTransition:           transition,
+					WorkspaceName:                 workspace.Name,
+					WorkspaceOwner:                owner.Username,
+					WorkspaceOwnerEmail:           owner.Email,
+					WorkspaceOwnerName:            owner.Name,
+					WorkspaceOwnerGroups:          ownerMetadata.groupNames,
+					WorkspaceOwnerOidcAccessToken: ownerMetadata.oidcAccessToken,
+					WorkspaceId:                   workspace.ID.String(),
+					WorkspaceOwnerId:              owner.ID.String(),
+					TemplateId:                    template.ID.String(),
+					TemplateName:                  template.Name,
+					TemplateVersion:               templateVersion.Name,
+					WorkspaceOwnerSessionToken:    sessionToken,
+					WorkspaceOwnerSshPublicKey:    ownerMetadata.sshPublicKey,
+					WorkspaceOwnerSshPrivateKey:   ownerMetadata.sshPrivateKey,
+					WorkspaceBuildId:              workspaceBuild.ID.String(),
+				},
+				LogLevel: input.LogLevel,
+			},
+		}
+	case database.ProvisionerJobTypeWorkspaceDriftCheck:
+		var input WorkspaceDriftCheckJob
+		err = json.Unmarshal(job.Input, &input)
+		if err != nil {
+			return nil, failJob(fmt.Sprintf("unmarshal job input %q: %s", job.Input, err))
+		}
+		workspaceBuild, err := s.Database.GetWorkspaceBuildByID(ctx, input.WorkspaceBuildID)
+		if err != nil {
+			return nil, failJob(fmt.Sprintf("get workspace build: %s", err))
+		}
+		workspace, err := s.Database.GetWorkspaceByID(ctx, workspaceBuild.WorkspaceID)
+		if err != nil {
+			return nil, failJob(fmt.Sprintf("get workspace: %s", err))
+		}
+		templateVersion, err := s.Database.GetTemplateVersionByID(ctx, workspaceBuild.TemplateVersionID)
 		if err != nil {
 			return nil, failJob(fmt.Sprintf("get template version: %s", err))
 		}
@@ -624,17 +691,134 @@ func (s *server) acquireProtoJob(ctx context.Context, job database.ProvisionerJo
 		if err != nil && !xerrors.Is(err, sql.ErrNoRows) {
 			return nil, failJob(fmt.Sprintf("get template version variables: %s", err))
 		}
+		template, err := s.Database.GetTemplateByID(ctx, templateVersion.TemplateID.UUID)
+		if err != nil {
+			return nil, failJob(fmt.Sprintf("get template: %s", err))
+		}
+		owner, err := s.Database.GetUserByID(ctx, workspace.OwnerID)
+		if err != nil {
+			return nil, failJob(fmt.Sprintf("get owner: %s", err))
+		}
+		transition, err := convertWorkspaceTransition(workspaceBuild.Transition)
+		if err != nil {
+			return nil, failJob(fmt.Sprintf("convert workspace transition: %s", err))
+		}
+		workspaceBuildParameters, err := s.Database.GetWorkspaceBuildParameters(ctx, workspaceBuild.ID)
+		if err != nil {
+			return nil, failJob(fmt.Sprintf("get workspace build parameters: %s", err))
+		}
 
-		protoJob.Type = &proto.AcquiredJob_TemplateDryRun_{
-			TemplateDryRun: &proto.AcquiredJob_TemplateDryRun{
-				RichParameterValues: convertRichParameterValues(input.RichParameterValues),
+		state, err := provisionerstate.Read(ctx, s.StateStore, workspaceBuild)
+		if err != nil {
+			return nil, failJob(fmt.Sprintf("read provisioner state: %s", err))
+		}
+
+		// Unlike a build, a drift check doesn't regenerate the session token
+		// of the owner: a refresh-only plan never applies the configuration,
+		// so it has no use for one.
+		protoJob.Type = &proto.AcquiredJob_WorkspaceDriftCheck_{
+			WorkspaceDriftCheck: &proto.AcquiredJob_WorkspaceDriftCheck{
+				WorkspaceBuildId:    workspaceBuild.ID.String(),
+				RichParameterValues: convertRichParameterValues(workspaceBuildParameters),
 				VariableValues:      asVariableValues(templateVariables),
+				State:               state,
 				Metadata: &sdkproto.Metadata{
-					CoderUrl:      s.AccessURL.String(),
-					WorkspaceName: input.WorkspaceName,
+					CoderUrl:            s.AccessURL.String(),
+					WorkspaceTransition: transition,
+					WorkspaceName:       workspace.Name,
+					WorkspaceOwner:      owner.Username,
+					WorkspaceOwnerEmail: owner.Email,
+					WorkspaceOwnerName:  owner.Name,
+					WorkspaceId:         workspace.ID.String(),
+					WorkspaceOwnerId:    owner.ID.String(),
+					TemplateId:          template.ID.String(),
+					TemplateName:        template.Name,
+					TemplateVersion:     templateVersion.Name,
+					WorkspaceBuildId:    workspaceBuild.ID.String(),
 				},
 			},
 		}
+	case database.ProvisionerJobTypeTemplateVersionDryRun:
+		var input TemplateVersionDryRunJob
+		err = json.Unmarshal(job.Input, &input)
+		if err != nil {
+			return nil, failJob(fmt.Sprintf("unmarshal job input %q: %s", job.Input, err))
+		}
+
+		templateVersion, err := s.Database.GetTemplateVersionByID(ctx, input.TemplateVersionID)
+		if err != nil {
+			return nil, failJob(fmt.Sprintf("get template version: %s", err))
+		}
+		templateVariables, err := s.Database.GetTemplateVersionVariables(ctx, templateVersion.ID)
+		if err != nil && !xerrors.Is(err, sql.ErrNoRows) {
+			return nil, failJob(fmt.Sprintf("get template version variables: %s", err))
+		}
+
+		dryRun := &proto.AcquiredJob_TemplateDryRun{
+			RichParameterValues: convertRichParameterValues(input.RichParameterValues),
+			VariableValues:      asVariableValues(templateVariables),
+			Metadata: &sdkproto.Metadata{
+				CoderUrl:      s.AccessURL.String(),
+				WorkspaceName: input.WorkspaceName,
+			},
+		}
+		if input.WorkspaceID != uuid.Nil {
+			// The plan must see the workspace the way a build of it would, so
+			// resources that depend on its identity aren't reported as changed.
+			// The session token of the owner is the exception: a build
+			// regenerates it, which would invalidate the token the running
+			// workspace uses, so resources that depend on it are reported as
+			// changed the way they are on every start.
+			workspace, err := s.Database.GetWorkspaceByID(ctx, input.WorkspaceID)
+			if err != nil {
+				return nil, failJob(fmt.Sprintf("get workspace: %s", err))
+			}
+			workspaceBuild, err := s.Database.GetLatestWorkspaceBuildByWorkspaceID(ctx, workspace.ID)
+			if err != nil {
+				return nil, failJob(fmt.Sprintf("get latest workspace build: %s", err))
+			}
+			template, err := s.Database.GetTemplateByID(ctx, workspace.TemplateID)
+			if err != nil {
+				return nil, failJob(fmt.Sprintf("get template: %s", err))
+			}
+			owner, err := s.Database.GetUserByID(ctx, workspace.OwnerID)
+			if err != nil {
+				return nil, failJob(fmt.Sprintf("get owner: %s", err))
+			}
+			ownerMetadata, err := s.getWorkspaceOwnerMetadata(ctx, owner)
+			if err != nil {
+				return nil, failJob(err.Error())
+			}
+			dryRun.ExternalAuthProviders, err = s.getExternalAuthProviders(ctx, templateVersion, owner, workspace.ID)
+			if err != nil {
+				return nil, failJob(err.Error())
+			}
+			dryRun.State, err = provisionerstate.Read(ctx, s.StateStore, workspaceBuild)
+			if err != nil {
+				return nil, failJob(fmt.Sprintf("read provisioner state: %s", err))
+			}
+			dryRun.Metadata = &sdkproto.Metadata{
+				CoderUrl:                      s.AccessURL.String(),
+				WorkspaceName:                 workspace.Name,
+				WorkspaceOwner:                owner.Username,
+				WorkspaceOwnerEmail:           owner.Email,
+				WorkspaceOwnerName:            owner.Name,
+				WorkspaceOwnerGroups:          ownerMetadata.groupNames,
+				WorkspaceOwnerOidcAccessToken: ownerMetadata.oidcAccessToken,
+				WorkspaceId:                   workspace.ID.String(),
+				WorkspaceOwnerId:              owner.ID.String(),
+				TemplateId:                    template.ID.String(),
+				TemplateName:                  template.Name,
+				TemplateVersion:               templateVersion.Name,
+				WorkspaceOwnerSshPublicKey:    ownerMetadata.sshPublicKey,
+				WorkspaceOwnerSshPrivateKey:   ownerMetadata.sshPrivateKey,
+				WorkspaceBuildId:              workspaceBuild.ID.String(),
+			}
+		}
+
+		protoJob.Type = &proto.AcquiredJob_TemplateDryRun_{
+			TemplateDryRun: dryRun,
+		}
 	case database.ProvisionerJobTypeTemplateVersionImport:
 		var input TemplateVersionImportJob
 		err = json.Unmarshal(job.Input, &input)
@@ -673,6 +857,100 @@ func (s *server) acquireProtoJob(ctx context.Context, job database.ProvisionerJo
 	return protoJob, err
 }
 
+// workspaceOwnerMetadata is the part of the metadata of a workspace job that
+// describes its owner, beyond the fields of the user itself.
+type workspaceOwnerMetadata struct {
+	groupNames      []string
+	sshPublicKey    string
+	sshPrivateKey   string
+	oidcAccessToken string
+}
+
+func (s *server) getWorkspaceOwnerMetadata(ctx context.Context, owner database.User) (workspaceOwnerMetadata, error) {
+	var metadata workspaceOwnerMetadata
+	if ownerSSHKey, err := s.Database.GetGitSSHKey(ctx, owner.ID); err != nil {
+		if !xerrors.Is(err, sql.ErrNoRows) {
+			return workspaceOwnerMetadata{}, xerrors.Errorf("get owner ssh key: %w", err)
+		}
+	} else {
+		metadata.sshPublicKey = ownerSSHKey.PublicKey
+		metadata.sshPrivateKey = ownerSSHKey.PrivateKey
+	}
+	ownerGroups, err := s.Database.GetGroups(ctx, database.GetGroupsParams{
+		HasMemberID:    owner.ID,
+		OrganizationID: s.OrganizationID,
+	})
+	if err != nil {
+		return workspaceOwnerMetadata{}, xerrors.Errorf("get owner group names: %w", err)
+	}
+	metadata.groupNames = []string{}
+	for _, group := range ownerGroups {
+		metadata.groupNames = append(metadata.groupNames, group.Group.Name)
+	}
+	if s.OIDCConfig != nil {
+		metadata.oidcAccessToken, err = obtainOIDCAccessToken(ctx, s.Database, s.OIDCConfig, owner.ID)
+		if err != nil {
+			return workspaceOwnerMetadata{}, xerrors.Errorf("obtain OIDC access token: %w", err)
+		}
+	}
+	return metadata, nil
+}
+
+// getExternalAuthProviders returns the access tokens of the owner for the
+// external auth providers the template version requires. Providers the owner
+// hasn't linked, or whose token is invalid, are skipped.
+func (s *server) getExternalAuthProviders(ctx context.Context, templateVersion database.TemplateVersion, owner database.User, workspaceID uuid.UUID) ([]*sdkproto.ExternalAuthProvider, error) {
+	dbExternalAuthProviders := []database.ExternalAuthProvider{}
+	err := json.Unmarshal(templateVersion.ExternalAuthProviders, &dbExternalAuthProviders)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to deserialize external_auth_providers value: %w", err)
+	}
+
+	externalAuthProviders := make([]*sdkproto.ExternalAuthProvider, 0, len(dbExternalAuthProviders))
+	for _, p := range dbExternalAuthProviders {
+		link, err := s.Database.GetExternalAuthLink(ctx, database.GetExternalAuthLinkParams{
+			ProviderID: p.ID,
+			UserID:     owner.ID,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, xerrors.Errorf("acquire external auth link: %w", err)
+		}
+		var config *externalauth.Config
+		for _, c := range s.ExternalAuthConfigs {
+			if c.ID != p.ID {
+				continue
+			}
+			config = c
+			break
+		}
+		// We weren't able to find a matching config for the ID!
+		if config == nil {
+			s.Logger.Warn(ctx, "workspace job is missing external auth provider",
+				slog.F("provider_id", p.ID),
+				slog.F("template_version_id", templateVersion.ID),
+				slog.F("workspace_id", workspaceID))
+			continue
+		}
+
+		refreshed, err := config.RefreshToken(ctx, s.Database, link)
+		if err != nil && !externalauth.IsInvalidTokenError(err) {
+			return nil, xerrors.Errorf("refresh external auth link %q: %w", p.ID, err)
+		}
+		if err != nil {
+			// Invalid tokens are skipped
+			continue
+		}
+		externalAuthProviders = append(externalAuthProviders, &sdkproto.ExternalAuthProvider{
+			Id:          p.ID,
+			AccessToken: refreshed.OAuthAccessToken,
+		})
+	}
+	return externalAuthProviders, nil
+}
+
 func (s *server) includeLastVariableValues(ctx context.Context, templateVersionID uuid.UUID, userVariableValues []codersdk.VariableValue) ([]codersdk.VariableValue, error) {
 	var values []codersdk.VariableValue
 	values = append(values, userVariableValues...)
@@ -982,6 +1260,15 @@ func (s *server) FailJob(ctx context.Context, failJob *proto.FailedJob) (*proto.
 			return nil, xerrors.Errorf("unmarshal workspace provision input: %w", err)
 		}
 
+		var stateParams database.UpdateWorkspaceBuildProvisionerStateByIDParams
+		unlockState := func() {}
+		if jobType.WorkspaceBuild.State != nil {
+			stateParams, unlockState, err = s.saveProvisionerState(ctx, input.WorkspaceBuildID, jobType.WorkspaceBuild.State)
+			if err != nil {
+				return nil, xerrors.Errorf("save provisioner state: %w", err)
+			}
+		}
+
 		var build database.WorkspaceBuild
 		var workspace database.Workspace
 		err = s.Database.InTx(func(db database.Store) error {
@@ -996,11 +1283,7 @@ func (s *server) FailJob(ctx context.Context, failJob *proto.FailedJob) (*proto.
 			}
 
 			if jobType.WorkspaceBuild.State != nil {
-				err = db.UpdateWorkspaceBuildProvisionerStateByID(ctx, database.UpdateWorkspaceBuildProvisionerStateByIDParams{
-					ID:               input.WorkspaceBuildID,
-					UpdatedAt:        dbtime.Now(),
-					ProvisionerState: jobType.WorkspaceBuild.State,
-				})
+				err = db.UpdateWorkspaceBuildProvisionerStateByID(ctx, stateParams)
 				if err != nil {
 					return xerrors.Errorf("update workspace build state: %w", err)
 				}
@@ -1017,6 +1300,7 @@ func (s *server) FailJob(ctx context.Context, failJob *proto.FailedJob) (*proto.
 
 			return nil
 		}, nil)
+		unlockState()
 		if err != nil {
 			return nil, err
 		}
@@ -1141,6 +1425,67 @@ func (s *server) notifyWorkspaceManualBuildFailed(ctx context.Context, workspace
 	}
 }
 
+// saveProvisionerState stores the state that a provisioner returned for a
+// build, and returns the parameters with which to update the build. See
+// provisionerstate.Save.
+func (s *server) saveProvisionerState(ctx context.Context, buildID uuid.UUID, state []byte) (database.UpdateWorkspaceBuildProvisionerStateByIDParams, func(), error) {
+	build, err := s.Database.GetWorkspaceBuildByID(ctx, buildID)
+	if err != nil {
+		return database.UpdateWorkspaceBuildProvisionerStateByIDParams{}, nil, xerrors.Errorf("get workspace build: %w", err)
+	}
+	return provisionerstate.Save(ctx, s.StateStore, build.WorkspaceID, build.ID, state, dbtime.Now())
+}
+
+// notifyWorkspaceDrifted notifies the owner of a workspace that resources of
+// it drifted from its state. The owner is only notified when the set of
+// drifted resources changed since the previous check, so drift that nobody
+// fixes isn't repeated on every check.
+func (s *server) notifyWorkspaceDrifted(ctx context.Context, build database.WorkspaceBuild, previous []database.GetWorkspaceDriftedResourcesByWorkspaceIDsRow, drift []*sdkproto.ResourceChange) {
+	if len(drift) == 0 {
+		return
+	}
+	previousAddresses := make([]string, 0, len(previous))
+	for _, resource := range previous {
+		previousAddresses = append(previousAddresses, resource.Address)
+	}
+	addresses := make([]string, 0, len(drift))
+	driftedResources := make([]map[string]any, 0, len(drift))
+	for _, change := range drift {
+		action, err := convertResourceChangeAction(change.Action)
+		if err != nil {
+			s.Logger.Warn(ctx, "failed to convert drifted resource action", slog.Error(err))
+			return
+		}
+		addresses = append(addresses, change.Address)
+		driftedResources = append(driftedResources, map[string]any{
+			"address": change.Address,
+			"action":  string(action),
+		})
+	}
+	slices.Sort(previousAddresses)
+	slices.Sort(addresses)
+	if slices.Equal(previousAddresses, addresses) {
+		return
+	}
+
+	workspace, err := s.Database.GetWorkspaceByID(ctx, build.WorkspaceID)
+	if err != nil {
+		s.Logger.Warn(ctx, "failed to get workspace for drift notification", slog.Error(err))
+		return
+	}
+	if _, err := s.NotificationsEnqueuer.EnqueueWithData(ctx, workspace.OwnerID, notifications.TemplateWorkspaceDrifted,
+		map[string]string{
+			"name": workspace.Name,
+		}, map[string]any{
+			"drifted_resources": driftedResources,
+		}, "provisionerdserver",
+		// Associate this notification with all the related entities.
+		workspace.ID, workspace.OwnerID, workspace.TemplateID, workspace.OrganizationID,
+	); err != nil {
+		s.Logger.Warn(ctx, "failed to notify of drifted workspace", slog.Error(err))
+	}
+}
+
 // prepareForNotifyWorkspaceManualBuildFailed collects data required to build notifications for template admins.
 // The template `notifications.TemplateWorkspaceManualBuildFailed` is quite detailed as it requires information about the template,
 // template version, workspace, workspace build, etc.
@@ -1378,6 +1723,13 @@ func (s *server) CompleteJob(ctx context.Context, completed *proto.CompletedJob)
 			return nil, xerrors.Errorf("get workspace build: %w", err)
 		}
 
+		// The state is written to the state store, if there is one, before
+		// the transaction, which then only points the build at it.
+		stateParams, unlockState, err := provisionerstate.Save(ctx, s.StateStore, workspaceBuild.WorkspaceID, workspaceBuild.ID, jobType.WorkspaceBuild.State, s.timeNow())
+		if err != nil {
+			return nil, xerrors.Errorf("save provisioner state: %w", err)
+		}
+
 		var workspace database.Workspace
 		var getWorkspaceError error
 
@@ -1422,11 +1774,8 @@ func (s *server) CompleteJob(ctx context.Context, completed *proto.CompletedJob)
 			if err != nil {
 				return xerrors.Errorf("update provisioner job: %w", err)
 			}
-			err = db.UpdateWorkspaceBuildProvisionerStateByID(ctx, database.UpdateWorkspaceBuildProvisionerStateByIDParams{
-				ID:               workspaceBuild.ID,
-				ProvisionerState: jobType.WorkspaceBuild.State,
-				UpdatedAt:        now,
-			})
+			stateParams.UpdatedAt = now
+			err = db.UpdateWorkspaceBuildProvisionerStateByID(ctx, stateParams)
 			if err != nil {
 				return xerrors.Errorf("update workspace build provisioner state: %w", err)
 			}
@@ -1518,6 +1867,7 @@ func (s *server) CompleteJob(ctx context.Context, completed *proto.CompletedJob)
 
 			return nil
 		}, nil)
+		unlockState()
 		if err != nil {
 			return nil, xerrors.Errorf("complete job: %w", err)
 		}
@@ -1602,6 +1952,108 @@ func (s *server) CompleteJob(ctx context.Context, completed *proto.CompletedJob)
 			})
 		}
 
+		err = s.Pubsub.Publish(codersdk.WorkspaceNotifyChannel(workspaceBuild.WorkspaceID), []byte{})
+		if err != nil {
+			return nil, xerrors.Errorf("update workspace: %w", err)
+		}
+	case *proto.CompletedJob_WorkspaceSnapshot_:
+		var input WorkspaceSnapshotJob
+		err = json.Unmarshal(job.Input, &input)
+		if err != nil {
+			return nil, xerrors.Errorf("unmarshal workspace snapshot input: %w", err)
+		}
+
+		var stateParams database.UpdateWorkspaceBuildProvisionerStateByIDParams
+		unlockState := func() {}
+		if len(jobType.WorkspaceSnapshot.State) > 0 {
+			stateParams, unlockState, err = s.saveProvisionerState(ctx, input.WorkspaceBuildID, jobType.WorkspaceSnapshot.State)
+			if err != nil {
+				return nil, xerrors.Errorf("save provisioner state: %w", err)
+			}
+		}
+
+		err = s.Database.InTx(func(db database.Store) error {
+			if !input.Restore {
+				err := db.UpdateWorkspaceSnapshotDataByID(ctx, database.UpdateWorkspaceSnapshotDataByIDParams{
+					ID:   input.WorkspaceSnapshotID,
+					Data: jobType.WorkspaceSnapshot.Data,
+				})
+				if err != nil {
+					return xerrors.Errorf("update workspace snapshot data: %w", err)
+				}
+			}
+			// Provisioners return state when the snapshot changed what they
+			// manage, which is always the case for a restore.
+			if len(jobType.WorkspaceSnapshot.State) > 0 {
+				err := db.UpdateWorkspaceBuildProvisionerStateByID(ctx, stateParams)
+				if err != nil {
+					return xerrors.Errorf("update workspace build state: %w", err)
+				}
+			}
+			return db.UpdateProvisionerJobWithCompleteByID(ctx, database.UpdateProvisionerJobWithCompleteByIDParams{
+				ID:        jobID,
+				UpdatedAt: dbtime.Now(),
+				CompletedAt: sql.NullTime{
+					Time:  dbtime.Now(),
+					Valid: true,
+				},
+				Error:     sql.NullString{},
+				ErrorCode: sql.NullString{},
+			})
+		}, nil)
+		unlockState()
+		if err != nil {
+			return nil, xerrors.Errorf("complete job: %w", err)
+		}
+		s.Logger.Debug(ctx, "marked workspace snapshot job as completed",
+			slog.F("job_id", jobID),
+			slog.F("workspace_snapshot_id", input.WorkspaceSnapshotID),
+			slog.F("restore", input.Restore))
+	case *proto.CompletedJob_WorkspaceDriftCheck_:
+		var input WorkspaceDriftCheckJob
+		err = json.Unmarshal(job.Input, &input)
+		if err != nil {
+			return nil, xerrors.Errorf("unmarshal workspace drift check input: %w", err)
+		}
+		workspaceBuild, err := s.Database.GetWorkspaceBuildByID(ctx, input.WorkspaceBuildID)
+		if err != nil {
+			return nil, xerrors.Errorf("get workspace build: %w", err)
+		}
+		// The drift found by the previous check, which is compared against to
+		// only notify the owner when the drift changed.
+		previousDrift, err := s.Database.GetWorkspaceDriftedResourcesByWorkspaceIDs(ctx, []uuid.UUID{workspaceBuild.WorkspaceID})
+		if err != nil {
+			return nil, xerrors.Errorf("get previously drifted resources: %w", err)
+		}
+
+		err = s.Database.InTx(func(db database.Store) error {
+			for _, change := range jobType.WorkspaceDriftCheck.ResourceDrift {
+				err := InsertProvisionerJobResourceChange(ctx, db, jobID, change)
+				if err != nil {
+					return xerrors.Errorf("insert drifted resource %q: %w", change.Address, err)
+				}
+			}
+			return db.UpdateProvisionerJobWithCompleteByID(ctx, database.UpdateProvisionerJobWithCompleteByIDParams{
+				ID:        jobID,
+				UpdatedAt: dbtime.Now(),
+				CompletedAt: sql.NullTime{
+					Time:  dbtime.Now(),
+					Valid: true,
+				},
+				Error:     sql.NullString{},
+				ErrorCode: sql.NullString{},
+			})
+		}, nil)
+		if err != nil {
+			return nil, xerrors.Errorf("complete job: %w", err)
+		}
+		s.Logger.Debug(ctx, "marked workspace drift check job as completed",
+			slog.F("job_id", jobID),
+			slog.F("workspace_build_id", input.WorkspaceBuildID),
+			slog.F("drifted_resources", len(jobType.WorkspaceDriftCheck.ResourceDrift)))
+
+		s.notifyWorkspaceDrifted(ctx, workspaceBuild, previousDrift, jobType.WorkspaceDriftCheck.ResourceDrift)
+
 		err = s.Pubsub.Publish(codersdk.WorkspaceNotifyChannel(workspaceBuild.WorkspaceID), []byte{})
 		if err != nil {
 			return nil, xerrors.Errorf("update workspace: %w", err)
@@ -1618,6 +2070,12 @@ func (s *server) CompleteJob(ctx context.Context, completed *proto.CompletedJob)
 				return nil, xerrors.Errorf("insert resource: %w", err)
 			}
 		}
+		for _, change := range jobType.TemplateDryRun.ResourceChanges {
+			err = InsertProvisionerJobResourceChange(ctx, s.Database, jobID, change)
+			if err != nil {
+				return nil, xerrors.Errorf("insert resource change %q: %w", change.Address, err)
+			}
+		}
 
 		err = s.Database.UpdateProvisionerJobWithCompleteByID(ctx, database.UpdateProvisionerJobWithCompleteByIDParams{
 			ID:        jobID,
@@ -1702,6 +2160,57 @@ func (s *server) startTrace(ctx context.Context, name string, opts ...trace.Span
 	))...)
 }
 
+// InsertProvisionerJobResourceChange records a change the plan of a dry-run
+// job makes to a resource of the workspace it previews an update of.
+func InsertProvisionerJobResourceChange(ctx context.Context, db database.Store, jobID uuid.UUID, protoChange *sdkproto.ResourceChange) error {
+	action, err := convertResourceChangeAction(protoChange.Action)
+	if err != nil {
+		return err
+	}
+	attributes := make([]codersdk.ResourceAttributeChange, 0, len(protoChange.Attributes))
+	for _, attribute := range protoChange.Attributes {
+		attributes = append(attributes, codersdk.ResourceAttributeChange{
+			Name:              attribute.Name,
+			Before:            attribute.Before,
+			After:             attribute.After,
+			Sensitive:         attribute.Sensitive,
+			Unknown:           attribute.Unknown,
+			ForcesReplacement: attribute.ForcesReplacement,
+		})
+	}
+	attributesRaw, err := json.Marshal(attributes)
+	if err != nil {
+		return xerrors.Errorf("marshal attributes: %w", err)
+	}
+	_, err = db.InsertProvisionerJobResourceChange(ctx, database.InsertProvisionerJobResourceChangeParams{
+		JobID:      jobID,
+		Address:    protoChange.Address,
+		Type:       protoChange.Type,
+		Name:       protoChange.Name,
+		Action:     action,
+		Attributes: attributesRaw,
+	})
+	if err != nil {
+		return xerrors.Errorf("insert provisioner job resource change: %w", err)
+	}
+	return nil
+}
+
+func convertResourceChangeAction(action sdkproto.ResourceChange_Action) (database.ResourceChangeAction, error) {
+	switch action {
+	case sdkproto.ResourceChange_CREATE:
+		return database.ResourceChangeActionCreate, nil
+	case sdkproto.ResourceChange_UPDATE:
+		return database.ResourceChangeActionUpdate, nil
+	case sdkproto.ResourceChange_REPLACE:
+		return database.ResourceChangeActionReplace, nil
+	case sdkproto.ResourceChange_DELETE:
+		return database.ResourceChangeActionDelete, nil
+	default:
+		return "", xerrors.Errorf("unknown action %q", action)
+	}
+}
+
 func InsertWorkspaceResource(ctx context.Context, db database.Store, jobID uuid.UUID, transition database.WorkspaceTransition, protoResource *sdkproto.Resource, snapshot *telemetry.Snapshot) error {
 	resource, err := db.InsertWorkspaceResource(ctx, database.InsertWorkspaceResourceParams{
 		ID:         uuid.New(),
@@ -1889,10 +2398,25 @@ func InsertWorkspaceResource(ctx context.Context, db database.Store, jobID uuid.
 			if app.Healthcheck == nil {
 				app.Healthcheck = &sdkproto.Healthcheck{}
 			}
-			if app.Healthcheck.Url != "" {
+			if err := provisioner.ValidateAppHealthcheck(app.Healthcheck); err != nil {
+				return xerrors.Errorf("app %q has an invalid healthcheck: %w", slug, err)
+			}
+			if provisioner.AppHealthcheckEnabled(app.Healthcheck) {
 				health = database.WorkspaceAppHealthInitializing
 			}
 
+			healthcheckKind := database.WorkspaceAppHealthcheckKindHttp
+			switch app.Healthcheck.Kind {
+			case sdkproto.Healthcheck_TCP:
+				healthcheckKind = database.WorkspaceAppHealthcheckKindTcp
+			case sdkproto.Healthcheck_GRPC:
+				healthcheckKind = database.WorkspaceAppHealthcheckKindGrpc
+			case sdkproto.Healthcheck_COMMAND:
+				healthcheckKind = database.WorkspaceAppHealthcheckKindCommand
+			case sdkproto.Healthcheck_FILE:
+				healthcheckKind = database.WorkspaceAppHealthcheckKindFile
+			}
+
 			sharingLevel := database.AppSharingLevelOwner
 			switch app.SharingLevel {
 			case sdkproto.AppSharingLevel_AUTHENTICATED:
@@ -1916,15 +2440,22 @@ func InsertWorkspaceResource(ctx context.Context, db database.Store, jobID uuid.
 					String: app.Url,
 					Valid:  app.Url != "",
 				},
-				External:             app.External,
-				Subdomain:            app.Subdomain,
-				SharingLevel:         sharingLevel,
-				HealthcheckUrl:       app.Healthcheck.Url,
-				HealthcheckInterval:  app.Healthcheck.Interval,
-				HealthcheckThreshold: app.Healthcheck.Threshold,
-				Health:               health,
-				DisplayOrder:         int32(app.Order),
-				Hidden:               app.Hidden,
+				External:                  app.External,
+				Subdomain:                 app.Subdomain,
+				SharingLevel:              sharingLevel,
+				HealthcheckUrl:            app.Healthcheck.Url,
+				HealthcheckInterval:       app.Healthcheck.Interval,
+				HealthcheckThreshold:      app.Healthcheck.Threshold,
+				Health:                    health,
+				DisplayOrder:              int32(app.Order),
+				Hidden:                    app.Hidden,
+				HealthcheckKind:           healthcheckKind,
+				HealthcheckExpectedStatus: app.Healthcheck.ExpectedStatus,
+				HealthcheckBodyRegex:      app.Healthcheck.BodyRegex,
+				HealthcheckAddress:        app.Healthcheck.Address,
+				HealthcheckGrpcService:    app.Healthcheck.GrpcService,
+				HealthcheckCommand:        app.Healthcheck.Command,
+				HealthcheckPath:           app.Healthcheck.Path,
 			})
 			if err != nil {
 				return xerrors.Errorf("insert app: %w", err)
@@ -2150,11 +2681,32 @@ type WorkspaceProvisionJob struct {
 	LogLevel         string    `json:"log_level,omitempty"`
 }
 
+// WorkspaceSnapshotJob is the payload for the "workspace_snapshot" job type.
+type WorkspaceSnapshotJob struct {
+	WorkspaceSnapshotID uuid.UUID `json:"workspace_snapshot_id"`
+	// WorkspaceBuildID is the build whose provisioner state is handed to the
+	// provisioner: the snapshotted build when creating, and the latest build
+	// of the workspace when restoring.
+	WorkspaceBuildID uuid.UUID `json:"workspace_build_id"`
+	Restore          bool      `json:"restore"`
+	LogLevel         string    `json:"log_level,omitempty"`
+}
+
+// WorkspaceDriftCheckJob is the payload for the "workspace_drift_check" job type.
+type WorkspaceDriftCheckJob struct {
+	// WorkspaceBuildID is the build whose provisioner state is checked for
+	// drift from the infrastructure.
+	WorkspaceBuildID uuid.UUID `json:"workspace_build_id"`
+}
+
 // TemplateVersionDryRunJob is the payload for the "template_version_dry_run" job type.
 type TemplateVersionDryRunJob struct {
 	TemplateVersionID   uuid.UUID                          `json:"template_version_id"`
 	WorkspaceName       string                             `json:"workspace_name"`
 	RichParameterValues []database.WorkspaceBuildParameter `json:"rich_parameter_values"`
+	// WorkspaceID is the workspace the dry-run previews an update of. If
+	// set, the plan is made against the state of its latest build.
+	WorkspaceID uuid.UUID `json:"workspace_id"`
 }
 
 func asVariableValues(templateVariables []database.TemplateVersionVariable) []*sdkproto.VariableValue {
@@ -37,6 +37,7 @@ import (
 	"github.com/coder/coder/v2/coderd/externalauth"
 	"github.com/coder/coder/v2/coderd/notifications"
 	"github.com/coder/coder/v2/coderd/provisionerdserver"
+	"github.com/coder/coder/v2/coderd/provisionerstate"
 	"github.com/coder/coder/v2/coderd/schedule"
 	"github.com/coder/coder/v2/coderd/schedule/cron"
 	"github.com/coder/coder/v2/coderd/telemetry"
@@ -457,6 +458,95 @@ func TestAcquireJob(t *testing.T) {
 			require.NoError(t, err)
 			require.JSONEq(t, string(want), string(got))
 		})
+		t.Run(tc.name+"_TemplateVersionDryRunWorkspace", func(t *testing.T) {
+			t.Parallel()
+			srv, db, ps, pd := setup(t, false, nil)
+			ctx := context.Background()
+
+			user := dbgen.User(t, db, database.User{})
+			group := dbgen.Group(t, db, database.Group{
+				Name:           "group1",
+				OrganizationID: pd.OrganizationID,
+			})
+			err := db.InsertGroupMember(ctx, database.InsertGroupMemberParams{
+				UserID:  user.ID,
+				GroupID: group.ID,
+			})
+			require.NoError(t, err)
+			sshKey := dbgen.GitSSHKey(t, db, database.GitSSHKey{
+				UserID: user.ID,
+			})
+			template := dbgen.Template(t, db, database.Template{
+				Provisioner:    database.ProvisionerTypeEcho,
+				OrganizationID: pd.OrganizationID,
+			})
+			version := dbgen.TemplateVersion(t, db, database.TemplateVersion{
+				OrganizationID: pd.OrganizationID,
+				TemplateID: uuid.NullUUID{
+					UUID:  template.ID,
+					Valid: true,
+				},
+			})
+			workspace := dbgen.Workspace(t, db, database.Workspace{
+				TemplateID:     template.ID,
+				OwnerID:        user.ID,
+				OrganizationID: pd.OrganizationID,
+			})
+			build := dbgen.WorkspaceBuild(t, db, database.WorkspaceBuild{
+				WorkspaceID:       workspace.ID,
+				BuildNumber:       1,
+				JobID:             uuid.New(),
+				TemplateVersionID: version.ID,
+				Transition:        database.WorkspaceTransitionStart,
+				Reason:            database.BuildReasonInitiator,
+			})
+			file := dbgen.File(t, db, database.File{CreatedBy: user.ID})
+			_ = dbgen.ProvisionerJob(t, db, ps, database.ProvisionerJob{
+				OrganizationID: pd.OrganizationID,
+				InitiatorID:    user.ID,
+				Provisioner:    database.ProvisionerTypeEcho,
+				StorageMethod:  database.ProvisionerStorageMethodFile,
+				FileID:         file.ID,
+				Type:           database.ProvisionerJobTypeTemplateVersionDryRun,
+				Input: must(json.Marshal(provisionerdserver.TemplateVersionDryRunJob{
+					TemplateVersionID: version.ID,
+					WorkspaceName:     workspace.Name,
+					WorkspaceID:       workspace.ID,
+				})),
+			})
+
+			job, err := tc.acquire(ctx, srv)
+			require.NoError(t, err)
+
+			got, err := json.Marshal(job.Type)
+			require.NoError(t, err)
+
+			// The preview must not regenerate the session token of the owner,
+			// since the running workspace uses it.
+			want, err := json.Marshal(&proto.AcquiredJob_TemplateDryRun_{
+				TemplateDryRun: &proto.AcquiredJob_TemplateDryRun{
+					ExternalAuthProviders: []*sdkproto.ExternalAuthProvider{},
+					Metadata: &sdkproto.Metadata{
+						CoderUrl:                    (&url.URL{}).String(),
+						WorkspaceName:               workspace.Name,
+						WorkspaceOwner:              user.Username,
+						WorkspaceOwnerEmail:         user.Email,
+						WorkspaceOwnerName:          user.Name,
+						WorkspaceOwnerGroups:        []string{group.Name},
+						WorkspaceId:                 workspace.ID.String(),
+						WorkspaceOwnerId:            user.ID.String(),
+						TemplateId:                  template.ID.String(),
+						TemplateName:                template.Name,
+						TemplateVersion:             version.Name,
+						WorkspaceOwnerSshPublicKey:  sshKey.PublicKey,
+						WorkspaceOwnerSshPrivateKey: sshKey.PrivateKey,
+						WorkspaceBuildId:            build.ID.String(),
+					},
+				},
+			})
+			require.NoError(t, err)
+			require.JSONEq(t, string(want), string(got))
+		})
 		t.Run(tc.name+"_TemplateVersionImport", func(t *testing.T) {
 			t.Parallel()
 			srv, db, ps, _ := setup(t, false, nil)
@@ -1364,6 +1454,77 @@ func TestCompleteJob(t *testing.T) {
 			})
 		}
 	})
+	t.Run("WorkspaceBuildStateStore", func(t *testing.T) {
+		t.Parallel()
+
+		store, err := provisionerstate.NewDirStore(t.TempDir())
+		require.NoError(t, err)
+		srv, db, ps, pd := setup(t, false, &overrides{
+			stateStore: store,
+		})
+
+		user := dbgen.User(t, db, database.User{})
+		template := dbgen.Template(t, db, database.Template{
+			Provisioner:    database.ProvisionerTypeEcho,
+			OrganizationID: pd.OrganizationID,
+		})
+		file := dbgen.File(t, db, database.File{CreatedBy: user.ID})
+		workspace := dbgen.Workspace(t, db, database.Workspace{
+			TemplateID:     template.ID,
+			OwnerID:        user.ID,
+			OrganizationID: pd.OrganizationID,
+		})
+		version := dbgen.TemplateVersion(t, db, database.TemplateVersion{
+			OrganizationID: pd.OrganizationID,
+			TemplateID: uuid.NullUUID{
+				UUID:  template.ID,
+				Valid: true,
+			},
+			JobID: uuid.New(),
+		})
+		build := dbgen.WorkspaceBuild(t, db, database.WorkspaceBuild{
+			WorkspaceID:       workspace.ID,
+			TemplateVersionID: version.ID,
+			Transition:        database.WorkspaceTransitionStart,
+			Reason:            database.BuildReasonInitiator,
+		})
+		job := dbgen.ProvisionerJob(t, db, ps, database.ProvisionerJob{
+			FileID: file.ID,
+			Type:   database.ProvisionerJobTypeWorkspaceBuild,
+			Input: must(json.Marshal(provisionerdserver.WorkspaceProvisionJob{
+				WorkspaceBuildID: build.ID,
+			})),
+			OrganizationID: pd.OrganizationID,
+		})
+		_, err = db.AcquireProvisionerJob(ctx, database.AcquireProvisionerJobParams{
+			OrganizationID: pd.OrganizationID,
+			WorkerID: uuid.NullUUID{
+				UUID:  pd.ID,
+				Valid: true,
+			},
+			Types: []database.ProvisionerType{database.ProvisionerTypeEcho},
+		})
+		require.NoError(t, err)
+
+		_, err = srv.CompleteJob(ctx, &proto.CompletedJob{
+			JobId: job.ID.String(),
+			Type: &proto.CompletedJob_WorkspaceBuild_{
+				WorkspaceBuild: &proto.CompletedJob_WorkspaceBuild{
+					State: []byte("some state"),
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		// Only a reference to the state is kept in the database.
+		build, err = db.GetWorkspaceBuildByID(ctx, build.ID)
+		require.NoError(t, err)
+		require.Empty(t, build.ProvisionerState)
+		require.Equal(t, provisionerstate.Ref(workspace.ID, []byte("some state")), build.ProvisionerStateRef)
+		state, err := provisionerstate.Read(ctx, store, build)
+		require.NoError(t, err)
+		require.Equal(t, "some state", string(state))
+	})
 	t.Run("TemplateDryRun", func(t *testing.T) {
 		t.Parallel()
 		srv, db, _, pd := setup(t, false, &overrides{})
@@ -1445,6 +1606,83 @@ func TestInsertWorkspaceResource(t *testing.T) {
 		})
 		require.ErrorContains(t, err, "duplicate app slug")
 	})
+	t.Run("InvalidAppHealthcheck", func(t *testing.T) {
+		t.Parallel()
+		err := insert(dbmem.New(), uuid.New(), &sdkproto.Resource{
+			Name: "something",
+			Type: "aws_instance",
+			Agents: []*sdkproto.Agent{{
+				Apps: []*sdkproto.App{{
+					Slug: "a",
+					Healthcheck: &sdkproto.Healthcheck{
+						Kind:      sdkproto.Healthcheck_COMMAND,
+						Interval:  5,
+						Threshold: 6,
+					},
+				}},
+			}},
+		})
+		require.ErrorContains(t, err, "command healthcheck must have a command")
+	})
+	t.Run("AppHealthcheckKinds", func(t *testing.T) {
+		t.Parallel()
+		db := dbmem.New()
+		job := uuid.New()
+		err := insert(db, job, &sdkproto.Resource{
+			Name: "something",
+			Type: "aws_instance",
+			Agents: []*sdkproto.Agent{{
+				Name: "dev",
+				Auth: &sdkproto.Agent_Token{
+					Token: uuid.NewString(),
+				},
+				Apps: []*sdkproto.App{{
+					Slug: "disabled",
+				}, {
+					Slug: "grpc",
+					Healthcheck: &sdkproto.Healthcheck{
+						Kind:        sdkproto.Healthcheck_GRPC,
+						Address:     "localhost:9090",
+						GrpcService: "app.v1.App",
+						Interval:    5,
+						Threshold:   6,
+					},
+				}, {
+					Slug: "http",
+					Healthcheck: &sdkproto.Healthcheck{
+						Url:            "http://localhost:8080/healthz",
+						ExpectedStatus: 204,
+						BodyRegex:      "^ok$",
+						Interval:       5,
+						Threshold:      6,
+					},
+				}},
+			}},
+		})
+		require.NoError(t, err)
+		resources, err := db.GetWorkspaceResourcesByJobID(ctx, job)
+		require.NoError(t, err)
+		require.Len(t, resources, 1)
+		agents, err := db.GetWorkspaceAgentsByResourceIDs(ctx, []uuid.UUID{resources[0].ID})
+		require.NoError(t, err)
+		require.Len(t, agents, 1)
+		apps, err := db.GetWorkspaceAppsByAgentID(ctx, agents[0].ID)
+		require.NoError(t, err)
+		require.Len(t, apps, 3)
+		require.Equal(t, "disabled", apps[0].Slug)
+		require.Equal(t, database.WorkspaceAppHealthDisabled, apps[0].Health)
+		require.Equal(t, database.WorkspaceAppHealthcheckKindHttp, apps[0].HealthcheckKind)
+		require.Equal(t, "grpc", apps[1].Slug)
+		require.Equal(t, database.WorkspaceAppHealthInitializing, apps[1].Health)
+		require.Equal(t, database.WorkspaceAppHealthcheckKindGrpc, apps[1].HealthcheckKind)
+		require.Equal(t, "localhost:9090", apps[1].HealthcheckAddress)
+		require.Equal(t, "app.v1.App", apps[1].HealthcheckGrpcService)
+		require.Equal(t, "http", apps[2].Slug)
+		require.Equal(t, database.WorkspaceAppHealthInitializing, apps[2].Health)
+		require.Equal(t, database.WorkspaceAppHealthcheckKindHttp, apps[2].HealthcheckKind)
+		require.EqualValues(t, 204, apps[2].HealthcheckExpectedStatus)
+		require.Equal(t, "^ok$", apps[2].HealthcheckBodyRegex)
+	})
 	t.Run("Success", func(t *testing.T) {
 		t.Parallel()
 		db := dbmem.New()
@@ -1879,6 +2117,7 @@ type overrides struct {
 	heartbeatInterval           time.Duration
 	auditor                     audit.Auditor
 	notificationEnqueuer        notifications.Enqueuer
+	stateStore                  provisionerstate.Store
 }
 
 func setup(t *testing.T, ignoreLogErrors bool, ov *overrides) (proto.DRPCProvisionerDaemonServer, database.Store, pubsub.Pubsub, database.ProvisionerDaemon) {
@@ -1985,6 +2224,7 @@ func setup(t *testing.T, ignoreLogErrors bool, ov *overrides) (proto.DRPCProvisi
 			AcquireJobLongPollDur: pollDur,
 			HeartbeatInterval:     ov.heartbeatInterval,
 			HeartbeatFn:           ov.heartbeatFn,
+			StateStore:            ov.stateStore,
 		},
 		notifEnq,
 	)
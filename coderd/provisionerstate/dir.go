@@ -0,0 +1,50 @@
+package provisionerstate
+
+import (
+	"context"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/gofrs/flock"
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/coderd/objectstore"
+)
+
+// DirStore stores provisioner state in a local directory. Locks are taken with
+// flock on files in the locks subdirectory.
+type DirStore struct {
+	*objectstore.DirStore
+}
+
+// NewDirStore creates a store for the given directory, creating it if it does
+// not exist.
+func NewDirStore(dir string) (*DirStore, error) {
+	if err := os.MkdirAll(filepath.Join(dir, "locks"), 0o700); err != nil {
+		return nil, xerrors.Errorf("create provisioner state directory: %w", err)
+	}
+	store, err := objectstore.NewDirStore(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &DirStore{DirStore: store}, nil
+}
+
+// Lock takes an exclusive flock on the lock file with the given name.
+func (s *DirStore) Lock(ctx context.Context, name string) (func(), error) {
+	p, err := s.Path(path.Join("locks", name+".lock"))
+	if err != nil {
+		return nil, err
+	}
+	lock := flock.New(p)
+	ok, err := lock.TryLockContext(ctx, 100*time.Millisecond)
+	if !ok {
+		_ = lock.Close()
+		return nil, xerrors.Errorf("could not acquire flock for %v: %w", p, err)
+	}
+	return func() {
+		_ = lock.Close()
+	}, nil
+}
@@ -0,0 +1,195 @@
+// Package provisionerstate stores the provisioner state of workspace builds,
+// which for Terraform is the state file, outside of the database.
+//
+// A build whose state is in a store only holds a reference to it. References
+// are derived from the content of the state, so every version of a workspace's
+// state is kept as a separate object and an object never changes once it has
+// been written. Writes to the state of a workspace are serialized with a lock
+// held by the store, which is shared by every replica and by the migration
+// command.
+//
+// Objects are never deleted, since older builds keep referencing them. See the
+// Terraform state storage section of the provisioner docs for how operators
+// can prune them.
+package provisionerstate
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"path"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/codersdk"
+)
+
+// Store persists provisioner state objects.
+type Store interface {
+	// Put writes the named object. Implementations must not leave a partially
+	// written object behind if they fail.
+	Put(ctx context.Context, name string, data []byte) error
+	// Get reads the named object.
+	Get(ctx context.Context, name string) ([]byte, error)
+	// Lock takes the exclusive lock with the given name, waiting until it is
+	// available or ctx is done. The returned function releases it.
+	Lock(ctx context.Context, name string) (unlock func(), err error)
+}
+
+// NewStore creates the store for the location in the given configuration. It
+// returns nil if no location is configured, in which case state is stored in
+// the database.
+func NewStore(ctx context.Context, cfg codersdk.ProvisionerStateStoreConfig) (Store, error) {
+	switch {
+	case cfg.Directory.String() != "" && cfg.S3.Bucket.String() != "":
+		return nil, xerrors.New("only one provisioner state store may be configured")
+	case cfg.Directory.String() != "":
+		return NewDirStore(cfg.Directory.String())
+	case cfg.S3.Bucket.String() != "":
+		return NewS3Store(ctx, cfg.S3)
+	default:
+		return nil, nil
+	}
+}
+
+// Ref returns the reference under which a version of a workspace's state is
+// stored.
+func Ref(workspaceID uuid.UUID, state []byte) string {
+	sum := sha256.Sum256(state)
+	return path.Join(workspaceID.String(), hex.EncodeToString(sum[:])+".tfstate")
+}
+
+// Read returns the provisioner state of a build, reading it from the store if
+// the build holds a reference to it.
+func Read(ctx context.Context, store Store, build database.WorkspaceBuild) ([]byte, error) {
+	if build.ProvisionerStateRef == "" {
+		return build.ProvisionerState, nil
+	}
+	if store == nil {
+		return nil, xerrors.Errorf("the state of build %s is in a provisioner state store, but none is configured", build.ID)
+	}
+	state, err := store.Get(ctx, build.ProvisionerStateRef)
+	if err != nil {
+		return nil, xerrors.Errorf("read state %q: %w", build.ProvisionerStateRef, err)
+	}
+	return state, nil
+}
+
+// Save stores the provisioner state of a build and returns the parameters with
+// which to update the build. If store is nil, the state is kept in the
+// database. Otherwise the state of the workspace stays locked until the
+// returned function is called, which must happen once the build has been
+// updated.
+func Save(ctx context.Context, store Store, workspaceID, buildID uuid.UUID, state []byte, now time.Time) (database.UpdateWorkspaceBuildProvisionerStateByIDParams, func(), error) {
+	params := database.UpdateWorkspaceBuildProvisionerStateByIDParams{
+		ID:               buildID,
+		ProvisionerState: state,
+		UpdatedAt:        now,
+	}
+	if store == nil || len(state) == 0 {
+		return params, func() {}, nil
+	}
+
+	unlock, err := store.Lock(ctx, workspaceID.String())
+	if err != nil {
+		return database.UpdateWorkspaceBuildProvisionerStateByIDParams{}, nil, xerrors.Errorf("lock state: %w", err)
+	}
+	ref := Ref(workspaceID, state)
+	err = store.Put(ctx, ref, state)
+	if err != nil {
+		unlock()
+		return database.UpdateWorkspaceBuildProvisionerStateByIDParams{}, nil, xerrors.Errorf("write state %q: %w", ref, err)
+	}
+	params.ProvisionerState = nil
+	params.ProvisionerStateRef = ref
+	return params, unlock, nil
+}
+
+// migrateBatchSize is the number of builds read at once by Migrate. It's kept
+// small because every build carries its state.
+const migrateBatchSize = 50
+
+// MigrateStats describes the outcome of Migrate.
+type MigrateStats struct {
+	// Moved is the number of builds whose state was moved.
+	Moved int
+	// Skipped is the number of builds which changed while they were being
+	// moved, and so were left for the build that changed them to store.
+	Skipped int
+}
+
+// Migrate moves the provisioner state of every build from the database into
+// the store or, if toDatabase is set, from the store back into the database.
+// It can run while Coder is running, but builds that complete in the meantime
+// store their state wherever Coder is configured to. Objects are left in the
+// store after their state has been moved back into the database.
+func Migrate(ctx context.Context, logger slog.Logger, db database.Store, store Store, toDatabase bool) (MigrateStats, error) {
+	var stats MigrateStats
+	if store == nil {
+		return stats, xerrors.New("no provisioner state store is configured")
+	}
+
+	var after uuid.UUID
+	for {
+		builds, err := db.GetWorkspaceBuildsToMigrateProvisionerState(ctx, database.GetWorkspaceBuildsToMigrateProvisionerStateParams{
+			AfterID:      after,
+			InStateStore: toDatabase,
+			LimitCount:   migrateBatchSize,
+		})
+		if err != nil {
+			return stats, xerrors.Errorf("get workspace builds: %w", err)
+		}
+		for _, build := range builds {
+			moved, err := migrateBuild(ctx, db, store, build, toDatabase)
+			if err != nil {
+				return stats, xerrors.Errorf("migrate state of build %s: %w", build.ID, err)
+			}
+			if !moved {
+				logger.Info(ctx, "skipped build that changed while its state was being moved", slog.F("workspace_build_id", build.ID))
+				stats.Skipped++
+				continue
+			}
+			logger.Debug(ctx, "moved state of build", slog.F("workspace_build_id", build.ID))
+			stats.Moved++
+		}
+		if len(builds) < migrateBatchSize {
+			return stats, nil
+		}
+		after = builds[len(builds)-1].ID
+	}
+}
+
+func migrateBuild(ctx context.Context, db database.Store, store Store, build database.WorkspaceBuild, toDatabase bool) (bool, error) {
+	unlock, err := store.Lock(ctx, build.WorkspaceID.String())
+	if err != nil {
+		return false, xerrors.Errorf("lock state: %w", err)
+	}
+	defer unlock()
+
+	params := database.UpdateWorkspaceBuildProvisionerStateLocationByIDParams{
+		ID:        build.ID,
+		UpdatedAt: build.UpdatedAt,
+	}
+	if toDatabase {
+		params.ProvisionerState, err = Read(ctx, store, build)
+		if err != nil {
+			return false, err
+		}
+	} else {
+		params.ProvisionerStateRef = Ref(build.WorkspaceID, build.ProvisionerState)
+		err = store.Put(ctx, params.ProvisionerStateRef, build.ProvisionerState)
+		if err != nil {
+			return false, xerrors.Errorf("write state %q: %w", params.ProvisionerStateRef, err)
+		}
+	}
+
+	rows, err := db.UpdateWorkspaceBuildProvisionerStateLocationByID(ctx, params)
+	if err != nil {
+		return false, xerrors.Errorf("update workspace build: %w", err)
+	}
+	return rows > 0, nil
+}
@@ -0,0 +1,227 @@
+package provisionerstate_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"cdr.dev/slog/sloggers/slogtest"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbgen"
+	"github.com/coder/coder/v2/coderd/database/dbmem"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
+	"github.com/coder/coder/v2/coderd/objectstore"
+	"github.com/coder/coder/v2/coderd/objectstore/objectstoretest"
+	"github.com/coder/coder/v2/coderd/provisionerstate"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestNewStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutil.Context(t, testutil.WaitShort)
+
+	store, err := provisionerstate.NewStore(ctx, codersdk.ProvisionerStateStoreConfig{})
+	require.NoError(t, err)
+	require.Nil(t, store)
+
+	var cfg codersdk.ProvisionerStateStoreConfig
+	require.NoError(t, cfg.Directory.Set(t.TempDir()))
+	store, err = provisionerstate.NewStore(ctx, cfg)
+	require.NoError(t, err)
+	require.IsType(t, &provisionerstate.DirStore{}, store)
+
+	require.NoError(t, cfg.S3.Bucket.Set("state"))
+	_, err = provisionerstate.NewStore(ctx, cfg)
+	require.ErrorContains(t, err, "only one provisioner state store")
+}
+
+func TestDirStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutil.Context(t, testutil.WaitShort)
+	store, err := provisionerstate.NewDirStore(t.TempDir())
+	require.NoError(t, err)
+
+	workspaceID := uuid.New()
+	ref := provisionerstate.Ref(workspaceID, []byte("state"))
+	require.NoError(t, store.Put(ctx, ref, []byte("state")))
+	data, err := store.Get(ctx, ref)
+	require.NoError(t, err)
+	require.Equal(t, []byte("state"), data)
+
+	_, err = store.Get(ctx, "../outside")
+	require.ErrorContains(t, err, "invalid object name")
+
+	testLock(ctx, t, store)
+}
+
+//nolint:paralleltest // It sets environment variables.
+func TestS3Store(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "test-access-key")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test-secret-key")
+	t.Setenv("AWS_REGION", "eu-west-2")
+
+	fake := objectstoretest.NewFakeS3()
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+
+	ctx := testutil.Context(t, testutil.WaitShort)
+	var cfg codersdk.ProvisionerStateStoreConfig
+	require.NoError(t, cfg.S3.Bucket.Set("state"))
+	require.NoError(t, cfg.S3.Prefix.Set("/coder/"))
+	require.NoError(t, cfg.S3.Endpoint.Set(srv.URL))
+	store, err := provisionerstate.NewStore(ctx, cfg)
+	require.NoError(t, err)
+	require.IsType(t, &provisionerstate.S3Store{}, store)
+
+	workspaceID := uuid.New()
+	ref := provisionerstate.Ref(workspaceID, []byte("state"))
+	require.NoError(t, store.Put(ctx, ref, []byte("state")))
+	data, err := store.Get(ctx, ref)
+	require.NoError(t, err)
+	require.Equal(t, []byte("state"), data)
+	require.Contains(t, fake.Names(), "/state/coder/"+ref)
+
+	_, err = store.Get(ctx, "missing")
+	require.ErrorIs(t, err, objectstore.ErrNotFound)
+
+	testLock(ctx, t, store)
+
+	// A lock that was abandoned is taken over once it expires.
+	fake.Put("/state/coder/locks/abandoned.lock", nil, time.Now().Add(-time.Hour))
+	unlock, err := store.Lock(ctx, "abandoned")
+	require.NoError(t, err)
+	unlock()
+	require.NotContains(t, fake.Names(), "/state/coder/locks/abandoned.lock")
+
+	// Releasing a lock that expired and was taken over elsewhere leaves the
+	// lock of the new holder in place.
+	unlock, err = store.Lock(ctx, "expired")
+	require.NoError(t, err)
+	fake.Put("/state/coder/locks/expired.lock", nil, time.Now())
+	unlock()
+	require.Contains(t, fake.Names(), "/state/coder/locks/expired.lock")
+}
+
+// testLock asserts that a lock can't be taken twice at once.
+func testLock(ctx context.Context, t *testing.T, store provisionerstate.Store) {
+	t.Helper()
+
+	unlock, err := store.Lock(ctx, "workspace")
+	require.NoError(t, err)
+
+	shortCtx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+	_, err = store.Lock(shortCtx, "workspace")
+	require.Error(t, err)
+
+	// Other locks are independent.
+	unlockOther, err := store.Lock(ctx, "other")
+	require.NoError(t, err)
+	unlockOther()
+
+	unlock()
+	unlock, err = store.Lock(ctx, "workspace")
+	require.NoError(t, err)
+	unlock()
+}
+
+func TestSave(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutil.Context(t, testutil.WaitShort)
+	now := dbtime.Now()
+	workspaceID, buildID := uuid.New(), uuid.New()
+
+	// Without a store, state is kept in the database.
+	params, unlock, err := provisionerstate.Save(ctx, nil, workspaceID, buildID, []byte("state"), now)
+	require.NoError(t, err)
+	unlock()
+	require.Equal(t, []byte("state"), params.ProvisionerState)
+	require.Empty(t, params.ProvisionerStateRef)
+
+	store, err := provisionerstate.NewDirStore(t.TempDir())
+	require.NoError(t, err)
+	params, unlock, err = provisionerstate.Save(ctx, store, workspaceID, buildID, []byte("state"), now)
+	require.NoError(t, err)
+	require.Nil(t, params.ProvisionerState)
+	require.Equal(t, provisionerstate.Ref(workspaceID, []byte("state")), params.ProvisionerStateRef)
+	require.Equal(t, buildID, params.ID)
+	require.Equal(t, now, params.UpdatedAt)
+
+	// The state of the workspace stays locked until the build is updated.
+	shortCtx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+	_, err = store.Lock(shortCtx, workspaceID.String())
+	require.Error(t, err)
+	unlock()
+
+	state, err := provisionerstate.Read(ctx, store, database.WorkspaceBuild{ProvisionerStateRef: params.ProvisionerStateRef})
+	require.NoError(t, err)
+	require.Equal(t, []byte("state"), state)
+
+	_, err = provisionerstate.Read(ctx, nil, database.WorkspaceBuild{ProvisionerStateRef: params.ProvisionerStateRef})
+	require.ErrorContains(t, err, "none is configured")
+}
+
+func TestMigrate(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutil.Context(t, testutil.WaitShort)
+	logger := slogtest.Make(t, nil)
+	db := dbmem.New()
+	store, err := provisionerstate.NewDirStore(t.TempDir())
+	require.NoError(t, err)
+
+	// More builds than are read at once, so that pagination is exercised.
+	builds := make([]database.WorkspaceBuild, 60)
+	for i := range builds {
+		builds[i] = dbgen.WorkspaceBuild(t, db, database.WorkspaceBuild{
+			ProvisionerState: []byte(uuid.NewString()),
+		})
+	}
+	// Builds without state are left alone.
+	empty := dbgen.WorkspaceBuild(t, db, database.WorkspaceBuild{})
+
+	stats, err := provisionerstate.Migrate(ctx, logger, db, store, false)
+	require.NoError(t, err)
+	require.Equal(t, provisionerstate.MigrateStats{Moved: len(builds)}, stats)
+
+	for _, build := range builds {
+		got, err := db.GetWorkspaceBuildByID(ctx, build.ID)
+		require.NoError(t, err)
+		require.Empty(t, got.ProvisionerState)
+		require.Equal(t, provisionerstate.Ref(build.WorkspaceID, build.ProvisionerState), got.ProvisionerStateRef)
+		state, err := provisionerstate.Read(ctx, store, got)
+		require.NoError(t, err)
+		require.Equal(t, build.ProvisionerState, state)
+	}
+	got, err := db.GetWorkspaceBuildByID(ctx, empty.ID)
+	require.NoError(t, err)
+	require.Empty(t, got.ProvisionerStateRef)
+
+	// Running it again is a no-op.
+	stats, err = provisionerstate.Migrate(ctx, logger, db, store, false)
+	require.NoError(t, err)
+	require.Zero(t, stats)
+
+	stats, err = provisionerstate.Migrate(ctx, logger, db, store, true)
+	require.NoError(t, err)
+	require.Equal(t, provisionerstate.MigrateStats{Moved: len(builds)}, stats)
+
+	for _, build := range builds {
+		got, err := db.GetWorkspaceBuildByID(ctx, build.ID)
+		require.NoError(t, err)
+		require.Equal(t, build.ProvisionerState, got.ProvisionerState)
+		require.Empty(t, got.ProvisionerStateRef)
+	}
+
+	_, err = provisionerstate.Migrate(ctx, logger, db, nil, false)
+	require.ErrorContains(t, err, "no provisioner state store is configured")
+}
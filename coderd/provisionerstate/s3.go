@@ -0,0 +1,102 @@
+package provisionerstate
+
+import (
+	"context"
+	"path"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/coderd/objectstore"
+	"github.com/coder/coder/v2/codersdk"
+)
+
+const (
+	// s3LockRetryInterval is how often a lock that is held elsewhere is
+	// retried.
+	s3LockRetryInterval = 250 * time.Millisecond
+	// s3LockExpiry is the age after which a lock is considered abandoned, for
+	// example by a replica that was killed while holding it, and is removed.
+	// Locks are only held while a single state is written.
+	s3LockExpiry = 5 * time.Minute
+)
+
+// S3Store stores provisioner state in an S3-compatible bucket.
+//
+// Locks are objects created with a conditional write, which fails if the
+// object already exists. They're removed with a conditional delete on their
+// ETag, so that a lock is only ever removed by its holder or, once it has
+// expired, by the replica that found it expired, and never after another
+// replica took it over.
+type S3Store struct {
+	*objectstore.S3Store
+}
+
+// NewS3Store creates a store for the bucket in the given configuration.
+func NewS3Store(ctx context.Context, cfg codersdk.ObjectStoreS3Config) (*S3Store, error) {
+	store, err := objectstore.NewS3Store(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &S3Store{S3Store: store}, nil
+}
+
+// Lock creates the lock object, retrying while it exists. Locks older than
+// s3LockExpiry are removed.
+func (s *S3Store) Lock(ctx context.Context, name string) (func(), error) {
+	name = path.Join("locks", name+".lock")
+	ticker := time.NewTicker(s3LockRetryInterval)
+	defer ticker.Stop()
+	for {
+		etag, acquired, err := s.tryLock(ctx, name)
+		if err != nil {
+			return nil, xerrors.Errorf("lock %q: %w", name, err)
+		}
+		if acquired {
+			return func() {
+				// Release the lock even if the context of the caller has
+				// been canceled, so that it isn't held until it expires.
+				ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+				defer cancel()
+				_ = s.Delete(ctx, name, etag)
+			}, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, xerrors.Errorf("lock %q: %w", name, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// tryLock creates the lock object and returns its ETag. If the lock is held
+// elsewhere and has expired, it's removed for the next attempt to take it.
+func (s *S3Store) tryLock(ctx context.Context, name string) (string, bool, error) {
+	etag, err := s.Create(ctx, name, []byte(time.Now().UTC().Format(time.RFC3339)))
+	if err == nil {
+		return etag, true, nil
+	}
+	if !xerrors.Is(err, objectstore.ErrPreconditionFailed) {
+		return "", false, err
+	}
+
+	info, err := s.Head(ctx, name)
+	if xerrors.Is(err, objectstore.ErrNotFound) {
+		// Released in the meantime.
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	if time.Since(info.LastModified) < s3LockExpiry {
+		return "", false, nil
+	}
+	// Only the expired version of the lock is removed. If it was released
+	// and taken again since we read it, the delete fails.
+	err = s.Delete(ctx, name, info.ETag)
+	if err != nil && !xerrors.Is(err, objectstore.ErrPreconditionFailed) {
+		return "", false, xerrors.Errorf("delete expired lock: %w", err)
+	}
+	return "", false, nil
+}
@@ -460,8 +460,8 @@ func TestAuthorizeDomain(t *testing.T) {
 		},
 	}
 
-	workspaceExceptConnect := slice.Omit(ResourceWorkspace.AvailableActions(), policy.ActionApplicationConnect, policy.ActionSSH)
-	workspaceConnect := []policy.Action{policy.ActionApplicationConnect, policy.ActionSSH}
+	workspaceExceptConnect := slice.Omit(ResourceWorkspace.AvailableActions(), policy.ActionApplicationConnect, policy.ActionSSH, policy.ActionShareSession)
+	workspaceConnect := []policy.Action{policy.ActionApplicationConnect, policy.ActionSSH, policy.ActionShareSession}
 	testAuthorize(t, "OrgAdmin", user, []authTestCase{
 		{resource: ResourceTemplate.AnyOrganization(), actions: []policy.Action{policy.ActionCreate}, allow: true},
 
@@ -812,8 +812,8 @@ func TestAuthorizeLevels(t *testing.T) {
 
 	testAuthorize(t, "OrgAllowAll", user,
 		cases(func(c authTestCase) authTestCase {
-			// SSH and app connect are not implied here.
-			c.actions = slice.Omit(ResourceWorkspace.AvailableActions(), policy.ActionApplicationConnect, policy.ActionSSH)
+			// SSH, app connect and session sharing are not implied here.
+			c.actions = slice.Omit(ResourceWorkspace.AvailableActions(), policy.ActionApplicationConnect, policy.ActionSSH, policy.ActionShareSession)
 			return c
 		}, []authTestCase{
 			// Org + me
@@ -120,6 +120,15 @@ var (
 		Type: "idpsync_settings",
 	}
 
+	// ResourceInboxNotification
+	// Valid Actions
+	//  - "ActionCreate" :: create inbox notifications
+	//  - "ActionRead" :: read inbox notifications
+	//  - "ActionUpdate" :: update inbox notifications
+	ResourceInboxNotification = Object{
+		Type: "inbox_notification",
+	}
+
 	// ResourceLicense
 	// Valid Actions
 	//  - "ActionCreate" :: create a license
@@ -220,6 +229,16 @@ var (
 		Type: "replicas",
 	}
 
+	// ResourceSavedSearch
+	// Valid Actions
+	//  - "ActionCreate" :: create saved searches
+	//  - "ActionDelete" :: delete saved searches
+	//  - "ActionRead" :: read saved searches
+	//  - "ActionUpdate" :: update saved searches
+	ResourceSavedSearch = Object{
+		Type: "saved_search",
+	}
+
 	// ResourceSystem
 	// Valid Actions
 	//  - "ActionCreate" :: create system resources
@@ -269,6 +288,7 @@ var (
 	//  - "ActionCreate" :: create a new workspace
 	//  - "ActionDelete" :: delete workspace
 	//  - "ActionRead" :: read workspace data to view on the UI
+	//  - "ActionShareSession" :: watch or take over the terminal sessions of a workspace
 	//  - "ActionSSH" :: ssh into a given workspace
 	//  - "ActionWorkspaceStart" :: allows starting a workspace
 	//  - "ActionWorkspaceStop" :: allows stopping a workspace
@@ -283,6 +303,7 @@ var (
 	//  - "ActionCreate" :: create a new workspace
 	//  - "ActionDelete" :: delete workspace
 	//  - "ActionRead" :: read workspace data to view on the UI
+	//  - "ActionShareSession" :: watch or take over the terminal sessions of a workspace
 	//  - "ActionSSH" :: ssh into a given workspace
 	//  - "ActionWorkspaceStart" :: allows starting a workspace
 	//  - "ActionWorkspaceStop" :: allows stopping a workspace
@@ -317,6 +338,7 @@ func AllResources() []Objecter {
 		ResourceGroup,
 		ResourceGroupMember,
 		ResourceIdpsyncSettings,
+		ResourceInboxNotification,
 		ResourceLicense,
 		ResourceNotificationPreference,
 		ResourceNotificationTemplate,
@@ -328,6 +350,7 @@ func AllResources() []Objecter {
 		ResourceProvisionerDaemon,
 		ResourceProvisionerKeys,
 		ResourceReplicas,
+		ResourceSavedSearch,
 		ResourceSystem,
 		ResourceTailnetCoordinator,
 		ResourceTemplate,
@@ -347,6 +370,7 @@ func AllActions() []policy.Action {
 		policy.ActionRead,
 		policy.ActionReadPersonal,
 		policy.ActionSSH,
+		policy.ActionShareSession,
 		policy.ActionUpdate,
 		policy.ActionUpdatePersonal,
 		policy.ActionUse,
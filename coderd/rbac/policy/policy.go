@@ -14,6 +14,7 @@ const (
 	ActionUse                Action = "use"
 	ActionSSH                Action = "ssh"
 	ActionApplicationConnect Action = "application_connect"
+	ActionShareSession       Action = "share_session"
 	ActionViewInsights       Action = "view_insights"
 
 	ActionWorkspaceStart Action = "start"
@@ -64,6 +65,7 @@ var workspaceActions = map[Action]ActionDefinition{
 	// Running a workspace
 	ActionSSH:                actDef("ssh into a given workspace"),
 	ActionApplicationConnect: actDef("connect to workspace apps via browser"),
+	ActionShareSession:       actDef("watch or take over the terminal sessions of a workspace"),
 }
 
 // RBACPermissions is indexed by the type
@@ -274,6 +276,21 @@ var RBACPermissions = map[string]PermissionDefinition{
 			ActionUpdate: actDef("update notification preferences"),
 		},
 	},
+	"inbox_notification": {
+		Actions: map[Action]ActionDefinition{
+			ActionCreate: actDef("create inbox notifications"),
+			ActionRead:   actDef("read inbox notifications"),
+			ActionUpdate: actDef("update inbox notifications"),
+		},
+	},
+	"saved_search": {
+		Actions: map[Action]ActionDefinition{
+			ActionCreate: actDef("create saved searches"),
+			ActionRead:   actDef("read saved searches"),
+			ActionUpdate: actDef("update saved searches"),
+			ActionDelete: actDef("delete saved searches"),
+		},
+	},
 	"crypto_key": {
 		Actions: map[Action]ActionDefinition{
 			ActionRead:   actDef("read crypto keys"),
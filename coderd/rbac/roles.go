@@ -243,10 +243,10 @@ func ReloadBuiltinRoles(opts *RoleOptions) {
 
 	ownerWorkspaceActions := ResourceWorkspace.AvailableActions()
 	if opts.NoOwnerWorkspaceExec {
-		// Remove ssh and application connect from the owner role. This
-		// prevents owners from have exec access to all workspaces.
+		// Remove ssh, application connect and session sharing from the owner
+		// role. This prevents owners from have exec access to all workspaces.
 		ownerWorkspaceActions = slice.Omit(ownerWorkspaceActions,
-			policy.ActionApplicationConnect, policy.ActionSSH)
+			policy.ActionApplicationConnect, policy.ActionSSH, policy.ActionShareSession)
 	}
 
 	// Static roles that never change should be allocated in a closure.
@@ -400,7 +400,7 @@ func ReloadBuiltinRoles(opts *RoleOptions) {
 					// Org admins should not have workspace exec perms.
 					organizationID.String(): append(allPermsExcept(ResourceWorkspace, ResourceWorkspaceDormant, ResourceAssignRole), Permissions(map[string][]policy.Action{
 						ResourceWorkspaceDormant.Type: {policy.ActionRead, policy.ActionDelete, policy.ActionCreate, policy.ActionUpdate, policy.ActionWorkspaceStop},
-						ResourceWorkspace.Type:        slice.Omit(ResourceWorkspace.AvailableActions(), policy.ActionApplicationConnect, policy.ActionSSH),
+						ResourceWorkspace.Type:        slice.Omit(ResourceWorkspace.AvailableActions(), policy.ActionApplicationConnect, policy.ActionSSH, policy.ActionShareSession),
 					})...),
 				},
 				User: []Permission{},
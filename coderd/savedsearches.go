@@ -0,0 +1,391 @@
+package coderd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
+	"github.com/coder/coder/v2/coderd/httpapi"
+	"github.com/coder/coder/v2/coderd/httpmw"
+	"github.com/coder/coder/v2/coderd/searchquery"
+	"github.com/coder/coder/v2/codersdk"
+)
+
+// @Summary Get saved searches by user
+// @ID get-saved-searches-by-user
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Users
+// @Param user path string true "User ID, name, or me"
+// @Param type query string false "Resource type" Enums(workspace,template,user,audit_log)
+// @Success 200 {array} codersdk.SavedSearch
+// @Router /users/{user}/saved-searches [get]
+func (api *API) savedSearches(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx  = r.Context()
+		user = httpmw.UserParam(r)
+	)
+
+	resourceType := r.URL.Query().Get("type")
+	if resourceType != "" && !database.SavedSearchResourceType(resourceType).Valid() {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Invalid resource type.",
+			Validations: []codersdk.ValidationError{{
+				Field:  "type",
+				Detail: fmt.Sprintf("Must be one of %v.", database.AllSavedSearchResourceTypeValues()),
+			}},
+		})
+		return
+	}
+
+	searches, err := api.Database.GetSavedSearches(ctx, database.GetSavedSearchesParams{
+		UserID:       user.ID,
+		ResourceType: resourceType,
+	})
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching saved searches.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	out := make([]codersdk.SavedSearch, 0, len(searches))
+	for _, search := range searches {
+		out = append(out, convertSavedSearch(search))
+	}
+	httpapi.Write(ctx, rw, http.StatusOK, out)
+}
+
+// @Summary Get saved search by user and ID
+// @ID get-saved-search-by-user-and-id
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Users
+// @Param user path string true "User ID, name, or me"
+// @Param savedsearch path string true "Saved search ID" format(uuid)
+// @Success 200 {object} codersdk.SavedSearch
+// @Router /users/{user}/saved-searches/{savedsearch} [get]
+func (api *API) savedSearch(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	search, ok := api.userSavedSearchParam(rw, r)
+	if !ok {
+		return
+	}
+	httpapi.Write(ctx, rw, http.StatusOK, convertSavedSearch(search))
+}
+
+// @Summary Create saved search for user
+// @ID create-saved-search-for-user
+// @Security CoderSessionToken
+// @Accept json
+// @Produce json
+// @Tags Users
+// @Param user path string true "User ID, name, or me"
+// @Param request body codersdk.CreateSavedSearchRequest true "Create saved search request"
+// @Success 201 {object} codersdk.SavedSearch
+// @Router /users/{user}/saved-searches [post]
+func (api *API) postSavedSearch(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx  = r.Context()
+		user = httpmw.UserParam(r)
+	)
+
+	var req codersdk.CreateSavedSearchRequest
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+
+	resourceType := database.SavedSearchResourceType(req.ResourceType)
+	if !resourceType.Valid() {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Invalid resource type.",
+			Validations: []codersdk.ValidationError{{
+				Field:  "resource_type",
+				Detail: fmt.Sprintf("Must be one of %v.", database.AllSavedSearchResourceTypeValues()),
+			}},
+		})
+		return
+	}
+	if !api.validateSavedSearchQuery(ctx, rw, resourceType, req.Query) {
+		return
+	}
+	orgID, groupID, ok := api.savedSearchSharing(ctx, rw, req.OrganizationID, req.GroupID)
+	if !ok {
+		return
+	}
+
+	now := dbtime.Now()
+	search, err := api.Database.InsertSavedSearch(ctx, database.InsertSavedSearchParams{
+		ID:             uuid.New(),
+		UserID:         user.ID,
+		ResourceType:   resourceType,
+		Name:           req.Name,
+		Query:          req.Query,
+		OrganizationID: orgID,
+		GroupID:        groupID,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	})
+	if database.IsUniqueViolation(err, database.UniqueSavedSearchesUserIDResourceTypeNameKey) {
+		httpapi.Write(ctx, rw, http.StatusConflict, codersdk.Response{
+			Message: fmt.Sprintf("A %s search named %q already exists.", resourceType, req.Name),
+			Validations: []codersdk.ValidationError{{
+				Field:  "name",
+				Detail: "This value is already in use and should be unique.",
+			}},
+		})
+		return
+	}
+	if httpapi.IsUnauthorizedError(err) {
+		httpapi.Forbidden(rw)
+		return
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error creating saved search.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusCreated, convertSavedSearch(search))
+}
+
+// @Summary Update saved search for user
+// @ID update-saved-search-for-user
+// @Security CoderSessionToken
+// @Accept json
+// @Produce json
+// @Tags Users
+// @Param user path string true "User ID, name, or me"
+// @Param savedsearch path string true "Saved search ID" format(uuid)
+// @Param request body codersdk.UpdateSavedSearchRequest true "Update saved search request"
+// @Success 200 {object} codersdk.SavedSearch
+// @Router /users/{user}/saved-searches/{savedsearch} [put]
+func (api *API) putSavedSearch(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	search, ok := api.userSavedSearchParam(rw, r)
+	if !ok {
+		return
+	}
+
+	var req codersdk.UpdateSavedSearchRequest
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+	if !api.validateSavedSearchQuery(ctx, rw, search.ResourceType, req.Query) {
+		return
+	}
+	orgID, groupID, ok := api.savedSearchSharing(ctx, rw, req.OrganizationID, req.GroupID)
+	if !ok {
+		return
+	}
+
+	updated, err := api.Database.UpdateSavedSearch(ctx, database.UpdateSavedSearchParams{
+		ID:             search.ID,
+		Name:           req.Name,
+		Query:          req.Query,
+		OrganizationID: orgID,
+		GroupID:        groupID,
+		UpdatedAt:      dbtime.Now(),
+	})
+	if database.IsUniqueViolation(err, database.UniqueSavedSearchesUserIDResourceTypeNameKey) {
+		httpapi.Write(ctx, rw, http.StatusConflict, codersdk.Response{
+			Message: fmt.Sprintf("A %s search named %q already exists.", search.ResourceType, req.Name),
+			Validations: []codersdk.ValidationError{{
+				Field:  "name",
+				Detail: "This value is already in use and should be unique.",
+			}},
+		})
+		return
+	}
+	if httpapi.IsUnauthorizedError(err) {
+		httpapi.Forbidden(rw)
+		return
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error updating saved search.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, convertSavedSearch(updated))
+}
+
+// @Summary Delete saved search for user
+// @ID delete-saved-search-for-user
+// @Security CoderSessionToken
+// @Tags Users
+// @Param user path string true "User ID, name, or me"
+// @Param savedsearch path string true "Saved search ID" format(uuid)
+// @Success 204
+// @Router /users/{user}/saved-searches/{savedsearch} [delete]
+func (api *API) deleteSavedSearch(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	search, ok := api.userSavedSearchParam(rw, r)
+	if !ok {
+		return
+	}
+
+	err := api.Database.DeleteSavedSearchByID(ctx, search.ID)
+	if httpapi.IsUnauthorizedError(err) {
+		httpapi.Forbidden(rw)
+		return
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error deleting saved search.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// userSavedSearchParam fetches the saved search in the URL, which must belong
+// to the user in the URL. Searches shared with the user are listed alongside
+// their own, but can only be managed through their owner.
+func (api *API) userSavedSearchParam(rw http.ResponseWriter, r *http.Request) (database.SavedSearch, bool) {
+	var (
+		ctx  = r.Context()
+		user = httpmw.UserParam(r)
+	)
+
+	id, ok := httpmw.ParseUUIDParam(rw, r, "savedsearch")
+	if !ok {
+		return database.SavedSearch{}, false
+	}
+
+	search, err := api.Database.GetSavedSearchByID(ctx, id)
+	if httpapi.Is404Error(err) || (err == nil && search.UserID != user.ID) {
+		httpapi.ResourceNotFound(rw)
+		return database.SavedSearch{}, false
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching saved search.",
+			Detail:  err.Error(),
+		})
+		return database.SavedSearch{}, false
+	}
+	return search, true
+}
+
+// validateSavedSearchQuery parses the query with the same parser used to
+// filter the resource type, so that broken searches are rejected when they are
+// saved rather than when they are used.
+func (api *API) validateSavedSearchQuery(ctx context.Context, rw http.ResponseWriter, resourceType database.SavedSearchResourceType, query string) bool {
+	var errs []codersdk.ValidationError
+	switch resourceType {
+	case database.SavedSearchResourceTypeWorkspace:
+		_, errs = searchquery.Workspaces(ctx, api.Database, query, codersdk.Pagination{}, api.AgentInactiveDisconnectTimeout)
+	case database.SavedSearchResourceTypeTemplate:
+		_, errs = searchquery.Templates(ctx, api.Database, query)
+	case database.SavedSearchResourceTypeUser:
+		_, errs = searchquery.Users(query)
+	case database.SavedSearchResourceTypeAuditLog:
+		_, errs = searchquery.AuditLogs(ctx, api.Database, query)
+	}
+	if len(errs) > 0 {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message:     fmt.Sprintf("Invalid %s search query.", resourceType),
+			Validations: errs,
+		})
+		return false
+	}
+	return true
+}
+
+// savedSearchSharing resolves who a saved search is shared with. A group
+// implies its organization, and the organization's "Everyone" group is stored
+// as sharing with the whole organization.
+func (api *API) savedSearchSharing(ctx context.Context, rw http.ResponseWriter, orgID, groupID *uuid.UUID) (uuid.NullUUID, uuid.NullUUID, bool) {
+	if groupID != nil {
+		group, err := api.Database.GetGroupByID(ctx, *groupID)
+		if httpapi.Is404Error(err) {
+			httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+				Message: "Group not found.",
+				Validations: []codersdk.ValidationError{{
+					Field:  "group_id",
+					Detail: fmt.Sprintf("Group %q does not exist.", groupID.String()),
+				}},
+			})
+			return uuid.NullUUID{}, uuid.NullUUID{}, false
+		}
+		if err != nil {
+			httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+				Message: "Internal error fetching group.",
+				Detail:  err.Error(),
+			})
+			return uuid.NullUUID{}, uuid.NullUUID{}, false
+		}
+		if orgID != nil && *orgID != group.OrganizationID {
+			httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+				Message: "Group does not belong to the organization.",
+				Validations: []codersdk.ValidationError{{
+					Field:  "group_id",
+					Detail: fmt.Sprintf("Group %q is not in organization %q.", group.Name, orgID.String()),
+				}},
+			})
+			return uuid.NullUUID{}, uuid.NullUUID{}, false
+		}
+		org := uuid.NullUUID{UUID: group.OrganizationID, Valid: true}
+		if group.IsEveryone() {
+			return org, uuid.NullUUID{}, true
+		}
+		return org, uuid.NullUUID{UUID: group.ID, Valid: true}, true
+	}
+
+	if orgID == nil {
+		return uuid.NullUUID{}, uuid.NullUUID{}, true
+	}
+	_, err := api.Database.GetOrganizationByID(ctx, *orgID)
+	if httpapi.Is404Error(err) {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Organization not found.",
+			Validations: []codersdk.ValidationError{{
+				Field:  "organization_id",
+				Detail: fmt.Sprintf("Organization %q does not exist.", orgID.String()),
+			}},
+		})
+		return uuid.NullUUID{}, uuid.NullUUID{}, false
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching organization.",
+			Detail:  err.Error(),
+		})
+		return uuid.NullUUID{}, uuid.NullUUID{}, false
+	}
+	return uuid.NullUUID{UUID: *orgID, Valid: true}, uuid.NullUUID{}, true
+}
+
+func convertSavedSearch(search database.SavedSearch) codersdk.SavedSearch {
+	out := codersdk.SavedSearch{
+		ID:           search.ID,
+		UserID:       search.UserID,
+		ResourceType: codersdk.SavedSearchResourceType(search.ResourceType),
+		Name:         search.Name,
+		Query:        search.Query,
+		CreatedAt:    search.CreatedAt,
+		UpdatedAt:    search.UpdatedAt,
+	}
+	if search.OrganizationID.Valid {
+		out.OrganizationID = &search.OrganizationID.UUID
+	}
+	if search.GroupID.Valid {
+		out.GroupID = &search.GroupID.UUID
+	}
+	return out
+}
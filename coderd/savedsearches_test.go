@@ -0,0 +1,254 @@
+package coderd_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbgen"
+	"github.com/coder/coder/v2/coderd/util/ptr"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestSavedSearches(t *testing.T) {
+	t.Parallel()
+
+	t.Run("CRUD", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		client := coderdtest.New(t, nil)
+		owner := coderdtest.CreateFirstUser(t, client)
+		memberClient, member := coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+
+		created, err := memberClient.CreateSavedSearch(ctx, codersdk.Me, codersdk.CreateSavedSearchRequest{
+			ResourceType: codersdk.SavedSearchResourceTypeWorkspace,
+			Name:         "stale-ws",
+			Query:        "dormant:true -owner:me",
+		})
+		require.NoError(t, err)
+		require.Equal(t, member.ID, created.UserID)
+		require.Equal(t, "dormant:true -owner:me", created.Query)
+		require.Nil(t, created.OrganizationID)
+		require.Nil(t, created.GroupID)
+
+		_, err = memberClient.CreateSavedSearch(ctx, codersdk.Me, codersdk.CreateSavedSearchRequest{
+			ResourceType: codersdk.SavedSearchResourceTypeAuditLog,
+			Name:         "failed-logins",
+			Query:        "action:login resource_type:api_key",
+		})
+		require.NoError(t, err)
+
+		// Searches can be filtered by resource type.
+		searches, err := memberClient.SavedSearches(ctx, codersdk.Me, codersdk.SavedSearchesRequest{})
+		require.NoError(t, err)
+		require.Len(t, searches, 2)
+		searches, err = memberClient.SavedSearches(ctx, codersdk.Me, codersdk.SavedSearchesRequest{
+			ResourceType: codersdk.SavedSearchResourceTypeWorkspace,
+		})
+		require.NoError(t, err)
+		require.Len(t, searches, 1)
+		require.Equal(t, created.ID, searches[0].ID)
+
+		got, err := memberClient.SavedSearch(ctx, codersdk.Me, created.ID)
+		require.NoError(t, err)
+		require.Equal(t, created, got)
+
+		updated, err := memberClient.UpdateSavedSearch(ctx, codersdk.Me, created.ID, codersdk.UpdateSavedSearchRequest{
+			Name:  "dormant-ws",
+			Query: "dormant:true",
+		})
+		require.NoError(t, err)
+		require.Equal(t, "dormant-ws", updated.Name)
+		require.Equal(t, "dormant:true", updated.Query)
+
+		err = memberClient.DeleteSavedSearch(ctx, codersdk.Me, created.ID)
+		require.NoError(t, err)
+		_, err = memberClient.SavedSearch(ctx, codersdk.Me, created.ID)
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusNotFound, apiErr.StatusCode())
+	})
+
+	t.Run("InvalidQuery", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		client := coderdtest.New(t, nil)
+		_ = coderdtest.CreateFirstUser(t, client)
+
+		for _, req := range []codersdk.CreateSavedSearchRequest{
+			{ResourceType: codersdk.SavedSearchResourceTypeWorkspace, Name: "bad-status", Query: "status:bogus"},
+			{ResourceType: codersdk.SavedSearchResourceTypeTemplate, Name: "bad-key", Query: "unknown:value"},
+			{ResourceType: codersdk.SavedSearchResourceTypeUser, Name: "bad-login", Query: "login_type:bogus"},
+			{ResourceType: codersdk.SavedSearchResourceTypeAuditLog, Name: "bad-action", Query: "action:bogus"},
+		} {
+			_, err := client.CreateSavedSearch(ctx, codersdk.Me, req)
+			var apiErr *codersdk.Error
+			require.ErrorAs(t, err, &apiErr, req.Name)
+			require.Equal(t, http.StatusBadRequest, apiErr.StatusCode(), req.Name)
+			require.NotEmpty(t, apiErr.Validations, req.Name)
+		}
+
+		// The resource type must be known.
+		_, err := client.CreateSavedSearch(ctx, codersdk.Me, codersdk.CreateSavedSearchRequest{
+			ResourceType: "gadget",
+			Name:         "gadgets",
+		})
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusBadRequest, apiErr.StatusCode())
+
+		searches, err := client.SavedSearches(ctx, codersdk.Me, codersdk.SavedSearchesRequest{})
+		require.NoError(t, err)
+		require.Empty(t, searches)
+	})
+
+	t.Run("DuplicateName", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		client := coderdtest.New(t, nil)
+		_ = coderdtest.CreateFirstUser(t, client)
+
+		req := codersdk.CreateSavedSearchRequest{
+			ResourceType: codersdk.SavedSearchResourceTypeWorkspace,
+			Name:         "mine",
+			Query:        "owner:me",
+		}
+		_, err := client.CreateSavedSearch(ctx, codersdk.Me, req)
+		require.NoError(t, err)
+		_, err = client.CreateSavedSearch(ctx, codersdk.Me, req)
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusConflict, apiErr.StatusCode())
+
+		// The same name can be used for another resource type.
+		req.ResourceType = codersdk.SavedSearchResourceTypeTemplate
+		req.Query = "deprecated:false"
+		_, err = client.CreateSavedSearch(ctx, codersdk.Me, req)
+		require.NoError(t, err)
+	})
+
+	t.Run("SharedWithOrganization", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		client := coderdtest.New(t, nil)
+		owner := coderdtest.CreateFirstUser(t, client)
+		aliceClient, alice := coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+		bobClient, _ := coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+
+		shared, err := aliceClient.CreateSavedSearch(ctx, codersdk.Me, codersdk.CreateSavedSearchRequest{
+			ResourceType:   codersdk.SavedSearchResourceTypeWorkspace,
+			Name:           "stale-ws",
+			Query:          "dormant:true",
+			OrganizationID: ptr.Ref(owner.OrganizationID),
+		})
+		require.NoError(t, err)
+		require.Equal(t, owner.OrganizationID, *shared.OrganizationID)
+		require.Nil(t, shared.GroupID)
+		_, err = aliceClient.CreateSavedSearch(ctx, codersdk.Me, codersdk.CreateSavedSearchRequest{
+			ResourceType: codersdk.SavedSearchResourceTypeWorkspace,
+			Name:         "private",
+			Query:        "owner:me",
+		})
+		require.NoError(t, err)
+		own, err := bobClient.CreateSavedSearch(ctx, codersdk.Me, codersdk.CreateSavedSearchRequest{
+			ResourceType: codersdk.SavedSearchResourceTypeWorkspace,
+			Name:         "stale-ws",
+			Query:        "dormant:true owner:me",
+		})
+		require.NoError(t, err)
+
+		// Bob's own searches are listed before the ones shared with him, and
+		// Alice's private search is not listed.
+		searches, err := bobClient.SavedSearches(ctx, codersdk.Me, codersdk.SavedSearchesRequest{})
+		require.NoError(t, err)
+		require.Len(t, searches, 2)
+		require.Equal(t, own.ID, searches[0].ID)
+		require.Equal(t, shared.ID, searches[1].ID)
+
+		// Only Alice may change her shared search.
+		_, err = bobClient.UpdateSavedSearch(ctx, alice.ID.String(), shared.ID, codersdk.UpdateSavedSearchRequest{
+			Name:  "stale-ws",
+			Query: "dormant:false",
+		})
+		require.Error(t, err)
+		got, err := aliceClient.SavedSearch(ctx, codersdk.Me, shared.ID)
+		require.NoError(t, err)
+		require.Equal(t, "dormant:true", got.Query)
+
+		// Once unshared, Bob no longer sees it.
+		_, err = aliceClient.UpdateSavedSearch(ctx, codersdk.Me, shared.ID, codersdk.UpdateSavedSearchRequest{
+			Name:  shared.Name,
+			Query: shared.Query,
+		})
+		require.NoError(t, err)
+		searches, err = bobClient.SavedSearches(ctx, codersdk.Me, codersdk.SavedSearchesRequest{})
+		require.NoError(t, err)
+		require.Len(t, searches, 1)
+		require.Equal(t, own.ID, searches[0].ID)
+	})
+
+	t.Run("SharedWithGroup", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		client, db := coderdtest.NewWithDatabase(t, nil)
+		owner := coderdtest.CreateFirstUser(t, client)
+		aliceClient, alice := coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+		bobClient, bob := coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+		carolClient, _ := coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+
+		group := dbgen.Group(t, db, database.Group{OrganizationID: owner.OrganizationID})
+		dbgen.GroupMember(t, db, database.GroupMemberTable{GroupID: group.ID, UserID: alice.ID})
+		dbgen.GroupMember(t, db, database.GroupMemberTable{GroupID: group.ID, UserID: bob.ID})
+
+		// The group implies its organization.
+		shared, err := aliceClient.CreateSavedSearch(ctx, codersdk.Me, codersdk.CreateSavedSearchRequest{
+			ResourceType: codersdk.SavedSearchResourceTypeAuditLog,
+			Name:         "deletes",
+			Query:        "action:delete",
+			GroupID:      ptr.Ref(group.ID),
+		})
+		require.NoError(t, err)
+		require.Equal(t, owner.OrganizationID, *shared.OrganizationID)
+		require.Equal(t, group.ID, *shared.GroupID)
+
+		searches, err := bobClient.SavedSearches(ctx, codersdk.Me, codersdk.SavedSearchesRequest{})
+		require.NoError(t, err)
+		require.Len(t, searches, 1)
+		require.Equal(t, shared.ID, searches[0].ID)
+
+		searches, err = carolClient.SavedSearches(ctx, codersdk.Me, codersdk.SavedSearchesRequest{})
+		require.NoError(t, err)
+		require.Empty(t, searches)
+
+		// Carol cannot share with a group she cannot see.
+		_, err = carolClient.CreateSavedSearch(ctx, codersdk.Me, codersdk.CreateSavedSearchRequest{
+			ResourceType: codersdk.SavedSearchResourceTypeAuditLog,
+			Name:         "deletes",
+			Query:        "action:delete",
+			GroupID:      ptr.Ref(group.ID),
+		})
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusBadRequest, apiErr.StatusCode())
+
+		// Sharing with the "Everyone" group shares with the whole organization.
+		everyone, err := carolClient.CreateSavedSearch(ctx, codersdk.Me, codersdk.CreateSavedSearchRequest{
+			ResourceType: codersdk.SavedSearchResourceTypeAuditLog,
+			Name:         "logins",
+			Query:        "action:login",
+			GroupID:      ptr.Ref(owner.OrganizationID),
+		})
+		require.NoError(t, err)
+		require.Equal(t, owner.OrganizationID, *everyone.OrganizationID)
+		require.Nil(t, everyone.GroupID)
+	})
+}
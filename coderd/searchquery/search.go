@@ -87,9 +87,7 @@ func Workspaces(ctx context.Context, db database.Store, query string, page coder
 		return filter, nil
 	}
 
-	// Always lowercase for all searches.
-	query = strings.ToLower(query)
-	values, errors := searchTerms(query, func(term string, values url.Values) error {
+	values, groups, errors := workspaceSearchTerms(query, func(term string, values url.Values) error {
 		// It is a workspace name, and maybe includes an owner
 		parts := splitQueryParameterByDelimiter(term, '/', false)
 		switch len(parts) {
@@ -106,6 +104,14 @@ func Workspaces(ctx context.Context, db database.Store, query string, page coder
 	if len(errors) > 0 {
 		return filter, errors
 	}
+	// These keys are only matched as terms, so plain terms using them are
+	// each added as a group of their own.
+	for _, key := range []string{"template_version", "agent_status", "initiator", "build_reason"} {
+		for _, value := range values[key] {
+			groups = append(groups, searchTermGroup{{key: key, value: value}})
+		}
+		values.Del(key)
+	}
 
 	parser := httpapi.NewQueryParamParser()
 	filter.WorkspaceIds = parser.UUIDs(values, []uuid.UUID{}, "id")
@@ -125,8 +131,27 @@ func Workspaces(ctx context.Context, db database.Store, query string, page coder
 		// which will return all workspaces.
 		Valid: values.Has("outdated"),
 	}
+	filter.CreatedBefore = parser.Time3339Nano(values, time.Time{}, "created_before")
+	filter.CreatedAfter = parser.Time3339Nano(values, time.Time{}, "created_after")
 	filter.OrganizationID = parseOrganization(ctx, db, parser, values, "organization")
 
+	for i, group := range groups {
+		for _, term := range group {
+			key, value, err := validateWorkspaceSearchTerm(term)
+			if err != nil {
+				parser.Errors = append(parser.Errors, codersdk.ValidationError{
+					Field:  term.key,
+					Detail: err.Error(),
+				})
+				continue
+			}
+			filter.TermGroups = append(filter.TermGroups, int32(i))
+			filter.TermKeys = append(filter.TermKeys, key)
+			filter.TermValues = append(filter.TermValues, value)
+			filter.TermNegated = append(filter.TermNegated, term.negated)
+		}
+	}
+
 	type paramMatch struct {
 		name  string
 		value *string
@@ -165,6 +190,62 @@ func Workspaces(ctx context.Context, db database.Store, query string, page coder
 	return filter, parser.Errors
 }
 
+// templateVersionOperators are the comparisons supported by template_version
+// terms, e.g. template_version:<v3, along with the keys the database matches
+// them with. Longer operators come first so that they are matched first.
+var templateVersionOperators = []struct {
+	operator string
+	key      string
+}{
+	{"<=", "template_version_lte"},
+	{">=", "template_version_gte"},
+	{"<", "template_version_lt"},
+	{">", "template_version_gt"},
+}
+
+// validateWorkspaceSearchTerm validates a term used in a workspace search, and
+// returns the key and value the database matches it with.
+func validateWorkspaceSearchTerm(term searchTerm) (string, string, error) {
+	switch term.key {
+	case "owner", "template", "name", "initiator":
+		return term.key, term.value, nil
+	case "template_version":
+		// Versions are compared by when they were created, against the
+		// version of the given name of each workspace's template.
+		for _, op := range templateVersionOperators {
+			if name, ok := strings.CutPrefix(term.value, op.operator); ok {
+				if name == "" {
+					return "", "", xerrors.Errorf("Query param %q is missing a template version to compare with", term.key)
+				}
+				return op.key, name, nil
+			}
+		}
+		return term.key, term.value, nil
+	case "status":
+		_, err := httpapi.ParseEnum[database.WorkspaceStatus](term.value)
+		if err != nil {
+			return "", "", xerrors.Errorf("Query param %q has invalid value: %w", term.key, err)
+		}
+		return term.key, term.value, nil
+	case "build_reason":
+		_, err := httpapi.ParseEnum[database.BuildReason](term.value)
+		if err != nil {
+			return "", "", xerrors.Errorf("Query param %q has invalid value: %w", term.key, err)
+		}
+		return term.key, term.value, nil
+	case "has-agent", "agent_status":
+		switch codersdk.WorkspaceAgentStatus(term.value) {
+		case codersdk.WorkspaceAgentConnecting, codersdk.WorkspaceAgentConnected,
+			codersdk.WorkspaceAgentDisconnected, codersdk.WorkspaceAgentTimeout:
+			return "agent_status", term.value, nil
+		default:
+			return "", "", xerrors.Errorf("Query param %q has invalid value: %q is not a valid value", term.key, term.value)
+		}
+	default:
+		return "", "", xerrors.Errorf("Query param %q cannot be negated or combined with OR", term.key)
+	}
+}
+
 func Templates(ctx context.Context, db database.Store, query string) (database.GetTemplatesWithFilterParams, []codersdk.ValidationError) {
 	// Always lowercase for all searches.
 	query = strings.ToLower(query)
@@ -199,37 +280,154 @@ func searchTerms(query string, defaultKey func(term string, values url.Values) e
 	// dropped.
 	elements := splitQueryParameterByDelimiter(query, ' ', true)
 	for _, element := range elements {
-		if strings.HasPrefix(element, ":") || strings.HasSuffix(element, ":") {
+		err := addSearchElement(element, defaultKey, searchValues)
+		if err != nil {
 			return nil, []codersdk.ValidationError{
-				{
-					Field:  "q",
-					Detail: fmt.Sprintf("Query element %q cannot start or end with ':'", element),
-				},
+				{Field: "q", Detail: err.Error()},
 			}
 		}
-		parts := splitQueryParameterByDelimiter(element, ':', false)
-		switch len(parts) {
-		case 1:
-			// No key:value pair. Use default behavior.
-			err := defaultKey(element, searchValues)
-			if err != nil {
-				return nil, []codersdk.ValidationError{
-					{Field: "q", Detail: err.Error()},
+	}
+
+	return searchValues, nil
+}
+
+// addSearchElement adds a single element of a query to values.
+func addSearchElement(element string, defaultKey func(term string, values url.Values) error, values url.Values) error {
+	if strings.HasPrefix(element, ":") || strings.HasSuffix(element, ":") {
+		return xerrors.Errorf("Query element %q cannot start or end with ':'", element)
+	}
+	parts := splitQueryParameterByDelimiter(element, ':', false)
+	switch len(parts) {
+	case 1:
+		// No key:value pair. Use default behavior.
+		return defaultKey(element, values)
+	case 2:
+		values.Add(strings.ToLower(parts[0]), parts[1])
+		return nil
+	default:
+		return xerrors.Errorf("Query element %q can only contain 1 ':'", element)
+	}
+}
+
+// searchTerm is a single term of a search query which is matched by the
+// database, rather than through a dedicated query parameter.
+type searchTerm struct {
+	key     string
+	value   string
+	negated bool
+}
+
+// searchTermGroup is a group of search terms, at least one of which must
+// match.
+type searchTermGroup []searchTerm
+
+// workspaceSearchTerms parses a search query like searchTerms, but also
+// supports negating terms with a '-' prefix, and combining terms with OR
+// inside parentheses:
+//
+//	template:docker -owner:bob (status:failed OR agent_status:disconnected)
+//
+// Plain terms are returned as values. Negated terms and groups are returned
+// as term groups, which must all match. A negated group is the same as
+// negating each of its terms: -(a OR b) matches neither a nor b.
+func workspaceSearchTerms(query string, defaultKey func(term string, values url.Values) error) (url.Values, []searchTermGroup, []codersdk.ValidationError) {
+	var (
+		values = make(url.Values)
+		groups []searchTermGroup
+		// group is the group being parsed, or nil outside of parentheses.
+		group        searchTermGroup
+		inGroup      bool
+		negatedGroup bool
+		// expectTerm is set when the next element in a group must be a term,
+		// rather than OR or the closing parenthesis.
+		expectTerm bool
+	)
+	invalid := func(format string, args ...any) (url.Values, []searchTermGroup, []codersdk.ValidationError) {
+		return nil, nil, []codersdk.ValidationError{
+			{Field: "q", Detail: fmt.Sprintf(format, args...)},
+		}
+	}
+
+	for _, element := range splitQueryParameterByDelimiter(query, ' ', true) {
+		if strings.HasPrefix(element, "(") || strings.HasPrefix(element, "-(") {
+			if inGroup {
+				return invalid("Query element %q cannot start a group inside another group", element)
+			}
+			negatedGroup = strings.HasPrefix(element, "-")
+			element = strings.TrimPrefix(strings.TrimPrefix(element, "-"), "(")
+			inGroup, expectTerm = true, true
+		}
+		closeGroup := false
+		if inGroup && strings.HasSuffix(element, ")") {
+			element = strings.TrimSuffix(element, ")")
+			closeGroup = true
+		}
+		// OR is case-sensitive, so it can't be confused with a workspace name.
+		if element == "OR" {
+			if !inGroup || expectTerm {
+				return invalid("OR can only be used between terms in parentheses, e.g. (owner:alice OR owner:bob)")
+			}
+			expectTerm = true
+			element = ""
+		}
+
+		if element != "" {
+			if inGroup && !expectTerm {
+				return invalid("Query element %q must be separated from the previous term in the group with OR", element)
+			}
+			expectTerm = false
+
+			negated := strings.HasPrefix(element, "-")
+			element = strings.ToLower(strings.TrimPrefix(element, "-"))
+			if element == "" {
+				return invalid("Query element '-' must be followed by a term to exclude")
+			}
+			if !inGroup && !negated {
+				if err := addSearchElement(element, defaultKey, values); err != nil {
+					return invalid("%s", err.Error())
 				}
+				continue
 			}
-		case 2:
-			searchValues.Add(strings.ToLower(parts[0]), parts[1])
-		default:
-			return nil, []codersdk.ValidationError{
-				{
-					Field:  "q",
-					Detail: fmt.Sprintf("Query element %q can only contain 1 ':'", element),
-				},
+
+			termValues := make(url.Values)
+			if err := addSearchElement(element, defaultKey, termValues); err != nil {
+				return invalid("%s", err.Error())
 			}
+			// Terms which expand to several values, like owner/name, can't be
+			// negated as a whole.
+			if len(termValues) != 1 {
+				return invalid("Query element %q cannot be negated or combined with OR", element)
+			}
+			for key, vals := range termValues {
+				term := searchTerm{key: key, value: vals[0], negated: negated}
+				if inGroup {
+					group = append(group, term)
+				} else {
+					groups = append(groups, searchTermGroup{term})
+				}
+			}
+		}
+
+		if closeGroup {
+			if expectTerm {
+				return invalid("Groups must contain terms separated by OR, e.g. (owner:alice OR owner:bob)")
+			}
+			if negatedGroup {
+				for _, term := range group {
+					term.negated = !term.negated
+					groups = append(groups, searchTermGroup{term})
+				}
+			} else {
+				groups = append(groups, group)
+			}
+			group, inGroup = nil, false
 		}
 	}
+	if inGroup {
+		return invalid("Query group is missing a closing ')'")
+	}
 
-	return searchValues, nil
+	return values, groups, nil
 }
 
 func parseOrganization(ctx context.Context, db database.Store, parser *httpapi.QueryParamParser, vals url.Values, queryParam string) uuid.UUID {
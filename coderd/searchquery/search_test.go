@@ -222,8 +222,135 @@ func TestSearchWorkspace(t *testing.T) {
 				OrganizationID: uuid.MustParse("08eb6715-02f8-45c5-b86d-03786fcfbb4e"),
 			},
 		},
+		{
+			Name:  "CreatedBeforeAfter",
+			Query: `created_before:"2024-05-02T00:00:00Z" created_after:"2024-05-01T00:00:00Z"`,
+			Expected: database.GetWorkspacesParams{
+				CreatedBefore: time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC),
+				CreatedAfter:  time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			Name:  "TermKeys",
+			Query: `template_version:v2 agent_status:disconnected initiator:alice build_reason:autostart`,
+			Expected: database.GetWorkspacesParams{
+				TermGroups:  []int32{0, 1, 2, 3},
+				TermKeys:    []string{"template_version", "agent_status", "initiator", "build_reason"},
+				TermValues:  []string{"v2", "disconnected", "alice", "autostart"},
+				TermNegated: []bool{false, false, false, false},
+			},
+		},
+		{
+			Name:  "TemplateVersionComparison",
+			Query: `template_version:<v3 -template_version:>=v1 (template_version:<=v2 OR template_version:>v4)`,
+			Expected: database.GetWorkspacesParams{
+				TermGroups:  []int32{0, 1, 1, 2},
+				TermKeys:    []string{"template_version_gte", "template_version_lte", "template_version_gt", "template_version_lt"},
+				TermValues:  []string{"v1", "v2", "v4", "v3"},
+				TermNegated: []bool{true, false, false, false},
+			},
+		},
+		{
+			Name:  "Negated",
+			Query: `template:docker -owner:Bob -foo`,
+			Expected: database.GetWorkspacesParams{
+				TemplateName: "docker",
+				TermGroups:   []int32{0, 1},
+				TermKeys:     []string{"owner", "name"},
+				TermValues:   []string{"bob", "foo"},
+				TermNegated:  []bool{true, true},
+			},
+		},
+		{
+			Name:  "OrGroup",
+			Query: `(status:failed OR -has-agent:connected) (owner:alice OR owner:bob)`,
+			Expected: database.GetWorkspacesParams{
+				TermGroups:  []int32{0, 0, 1, 1},
+				TermKeys:    []string{"status", "agent_status", "owner", "owner"},
+				TermValues:  []string{"failed", "connected", "alice", "bob"},
+				TermNegated: []bool{false, true, false, false},
+			},
+		},
+		{
+			Name:  "OrGroupSpaces",
+			Query: `( template:docker OR template:"k8s" ) name:foo`,
+			Expected: database.GetWorkspacesParams{
+				Name:        "foo",
+				TermGroups:  []int32{0, 0},
+				TermKeys:    []string{"template", "template"},
+				TermValues:  []string{"docker", "k8s"},
+				TermNegated: []bool{false, false},
+			},
+		},
+		{
+			Name:  "NegatedGroup",
+			Query: `-(owner:alice OR -template:docker)`,
+			Expected: database.GetWorkspacesParams{
+				TermGroups:  []int32{0, 1},
+				TermKeys:    []string{"owner", "template"},
+				TermValues:  []string{"alice", "docker"},
+				TermNegated: []bool{true, false},
+			},
+		},
+		{
+			Name:  "LowercaseOrIsName",
+			Query: `or`,
+			Expected: database.GetWorkspacesParams{
+				Name: "or",
+			},
+		},
 
 		// Failures
+		{
+			Name:                  "TemplateVersionComparisonWithoutVersion",
+			Query:                 `template_version:<`,
+			ExpectedErrorContains: "missing a template version",
+		},
+		{
+			Name:                  "OrOutsideGroup",
+			Query:                 `owner:alice OR owner:bob`,
+			ExpectedErrorContains: "OR can only be used between terms in parentheses",
+		},
+		{
+			Name:                  "GroupWithoutOr",
+			Query:                 `(owner:alice owner:bob)`,
+			ExpectedErrorContains: "must be separated from the previous term in the group with OR",
+		},
+		{
+			Name:                  "NestedGroup",
+			Query:                 `(owner:alice OR (owner:bob OR owner:carol))`,
+			ExpectedErrorContains: "cannot start a group inside another group",
+		},
+		{
+			Name:                  "UnclosedGroup",
+			Query:                 `(owner:alice OR owner:bob`,
+			ExpectedErrorContains: "missing a closing ')'",
+		},
+		{
+			Name:                  "TrailingOr",
+			Query:                 `(owner:alice OR)`,
+			ExpectedErrorContains: "Groups must contain terms separated by OR",
+		},
+		{
+			Name:                  "NegatedOwnerName",
+			Query:                 `-alice/foo`,
+			ExpectedErrorContains: "cannot be negated or combined with OR",
+		},
+		{
+			Name:                  "NegatedUnsupportedKey",
+			Query:                 `-dormant:true`,
+			ExpectedErrorContains: `"dormant" cannot be negated or combined with OR`,
+		},
+		{
+			Name:                  "InvalidAgentStatus",
+			Query:                 `agent_status:sleeping`,
+			ExpectedErrorContains: `"sleeping" is not a valid value`,
+		},
+		{
+			Name:                  "InvalidBuildReason",
+			Query:                 `-build_reason:whenever`,
+			ExpectedErrorContains: `"whenever" is not a valid value`,
+		},
 		{
 			Name:                  "ParamExcessValue",
 			Query:                 "param:foo=bar=baz",
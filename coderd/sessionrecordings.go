@@ -0,0 +1,135 @@
+package coderd
+
+import (
+	"net/http"
+
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/httpapi"
+	"github.com/coder/coder/v2/coderd/httpmw"
+	"github.com/coder/coder/v2/codersdk"
+)
+
+// @Summary Get workspace session recordings
+// @ID get-workspace-session-recordings
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Audit
+// @Param workspace path string true "Workspace ID" format(uuid)
+// @Success 200 {array} codersdk.WorkspaceAgentSessionRecording
+// @Router /workspaces/{workspace}/session-recordings [get]
+func (api *API) workspaceSessionRecordings(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx       = r.Context()
+		workspace = httpmw.WorkspaceParam(r)
+	)
+
+	recordings, err := api.Database.GetWorkspaceAgentSessionRecordingsByWorkspaceID(ctx, workspace.ID)
+	if httpapi.Is404Error(err) {
+		httpapi.ResourceNotFound(rw)
+		return
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching session recordings.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	out := make([]codersdk.WorkspaceAgentSessionRecording, 0, len(recordings))
+	for _, recording := range recordings {
+		out = append(out, convertSessionRecording(recording))
+	}
+	httpapi.Write(ctx, rw, http.StatusOK, out)
+}
+
+// @Summary Get session recording
+// @ID get-session-recording
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Audit
+// @Param sessionrecording path string true "Session recording ID" format(uuid)
+// @Success 200 {object} codersdk.WorkspaceAgentSessionRecording
+// @Router /session-recordings/{sessionrecording} [get]
+func (api *API) sessionRecording(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	recording, ok := api.sessionRecordingParam(rw, r)
+	if !ok {
+		return
+	}
+	httpapi.Write(ctx, rw, http.StatusOK, convertSessionRecording(recording))
+}
+
+// @Summary Get session recording contents
+// @Description The recording is returned in the asciicast v2 format.
+// @ID get-session-recording-contents
+// @Security CoderSessionToken
+// @Tags Audit
+// @Param sessionrecording path string true "Session recording ID" format(uuid)
+// @Success 200
+// @Router /session-recordings/{sessionrecording}/cast [get]
+func (api *API) sessionRecordingCast(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	recording, ok := api.sessionRecordingParam(rw, r)
+	if !ok {
+		return
+	}
+	chunks, err := api.Database.GetWorkspaceAgentSessionRecordingChunks(ctx, recording.ID)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching session recording contents.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	rw.Header().Set("Content-Type", "application/x-asciicast")
+	rw.WriteHeader(http.StatusOK)
+	for _, chunk := range chunks {
+		if _, err := rw.Write(chunk.Data); err != nil {
+			return
+		}
+	}
+}
+
+func (api *API) sessionRecordingParam(rw http.ResponseWriter, r *http.Request) (database.WorkspaceAgentSessionRecording, bool) {
+	ctx := r.Context()
+
+	id, ok := httpmw.ParseUUIDParam(rw, r, "sessionrecording")
+	if !ok {
+		return database.WorkspaceAgentSessionRecording{}, false
+	}
+
+	recording, err := api.Database.GetWorkspaceAgentSessionRecordingByID(ctx, id)
+	if httpapi.Is404Error(err) {
+		httpapi.ResourceNotFound(rw)
+		return database.WorkspaceAgentSessionRecording{}, false
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching session recording.",
+			Detail:  err.Error(),
+		})
+		return database.WorkspaceAgentSessionRecording{}, false
+	}
+	return recording, true
+}
+
+func convertSessionRecording(recording database.WorkspaceAgentSessionRecording) codersdk.WorkspaceAgentSessionRecording {
+	out := codersdk.WorkspaceAgentSessionRecording{
+		ID:          recording.ID,
+		WorkspaceID: recording.WorkspaceID,
+		AgentID:     recording.AgentID,
+		SessionType: recording.SessionType,
+		Command:     recording.Command,
+		StartedAt:   recording.StartedAt,
+		Size:        recording.Size,
+	}
+	if recording.EndedAt.Valid {
+		endedAt := recording.EndedAt.Time
+		out.EndedAt = &endedAt
+	}
+	return out
+}
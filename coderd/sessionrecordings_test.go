@@ -0,0 +1,102 @@
+package coderd_test
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbauthz"
+	"github.com/coder/coder/v2/coderd/database/dbfake"
+	"github.com/coder/coder/v2/coderd/database/dbgen"
+	"github.com/coder/coder/v2/coderd/rbac"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestSessionRecordings(t *testing.T) {
+	t.Parallel()
+
+	ownerClient, db := coderdtest.NewWithDatabase(t, nil)
+	owner := coderdtest.CreateFirstUser(t, ownerClient)
+	member, user := coderdtest.CreateAnotherUser(t, ownerClient, owner.OrganizationID)
+	auditor, _ := coderdtest.CreateAnotherUser(t, ownerClient, owner.OrganizationID, rbac.RoleAuditor())
+
+	r := dbfake.WorkspaceBuild(t, db, database.Workspace{
+		OrganizationID: owner.OrganizationID,
+		OwnerID:        user.ID,
+	}).WithAgent().Do()
+	ctx := dbauthz.AsSystemRestricted(testutil.Context(t, testutil.WaitLong))
+	agents, err := db.GetWorkspaceAgentsInLatestBuildByWorkspaceID(ctx, r.Workspace.ID)
+	require.NoError(t, err)
+	require.Len(t, agents, 1)
+	agent := agents[0]
+	recording := dbgen.WorkspaceAgentSessionRecording(t, db, database.WorkspaceAgentSessionRecording{
+		WorkspaceID: r.Workspace.ID,
+		AgentID:     agent.ID,
+		Command:     "vim",
+	})
+	header := `{"version":2,"width":80,"height":24,"timestamp":1700000000}` + "\n"
+	event := `[0.5,"o","hello"]` + "\n"
+	for i, data := range []string{header, event} {
+		err := db.InsertWorkspaceAgentSessionRecordingChunk(ctx, database.InsertWorkspaceAgentSessionRecordingChunkParams{
+			RecordingID: recording.ID,
+			Sequence:    int32(i),
+			Data:        []byte(data),
+		})
+		require.NoError(t, err)
+	}
+
+	t.Run("Owner", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		recordings, err := ownerClient.WorkspaceSessionRecordings(ctx, r.Workspace.ID)
+		require.NoError(t, err)
+		require.Len(t, recordings, 1)
+		require.Equal(t, recording.ID, recordings[0].ID)
+		require.Equal(t, agent.ID, recordings[0].AgentID)
+		require.Equal(t, "vim", recordings[0].Command)
+		require.EqualValues(t, len(header)+len(event), recordings[0].Size)
+		require.Nil(t, recordings[0].EndedAt)
+
+		got, err := ownerClient.SessionRecording(ctx, recording.ID)
+		require.NoError(t, err)
+		require.Equal(t, recordings[0], got)
+	})
+
+	t.Run("Auditor", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		got, err := auditor.SessionRecording(ctx, recording.ID)
+		require.NoError(t, err)
+		require.Equal(t, recording.ID, got.ID)
+
+		cast, err := auditor.SessionRecordingCast(ctx, recording.ID)
+		require.NoError(t, err)
+		defer cast.Close()
+		data, err := io.ReadAll(cast)
+		require.NoError(t, err)
+		require.Equal(t, header+event, string(data))
+	})
+
+	t.Run("WorkspaceOwner", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		// Recordings are audit data, so they're hidden from the workspace
+		// owner.
+		_, err := member.WorkspaceSessionRecordings(ctx, r.Workspace.ID)
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusNotFound, apiErr.StatusCode())
+
+		_, err = member.SessionRecordingCast(ctx, recording.ID)
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusNotFound, apiErr.StatusCode())
+	})
+}
@@ -17,6 +17,7 @@ import (
 
 	"github.com/coder/coder/v2/coderd/audit"
 	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/db2sdk"
 	"github.com/coder/coder/v2/coderd/database/dbauthz"
 	"github.com/coder/coder/v2/coderd/database/dbtime"
 	"github.com/coder/coder/v2/coderd/httpapi"
@@ -698,6 +699,15 @@ func (api *API) patchTemplateMeta(rw http.ResponseWriter, r *http.Request) {
 			maxPortShareLevel = database.AppSharingLevel(*req.MaxPortShareLevel)
 		}
 	}
+	portForwardingPolicy := template.PortForwardingPolicy
+	if req.PortForwardingPolicy != nil {
+		err := req.PortForwardingPolicy.Validate()
+		if err != nil {
+			validErrs = append(validErrs, codersdk.ValidationError{Field: "port_forwarding_policy", Detail: err.Error()})
+		} else {
+			portForwardingPolicy = convertPortForwardingPolicy(*req.PortForwardingPolicy)
+		}
+	}
 
 	if len(validErrs) > 0 {
 		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
@@ -707,6 +717,16 @@ func (api *API) patchTemplateMeta(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	sessionRecording := template.SessionRecording
+	if req.SessionRecording != nil {
+		sessionRecording = *req.SessionRecording
+	}
+
+	agentActivityBump := template.AgentActivityBump
+	if req.AgentActivityBump != nil {
+		agentActivityBump = *req.AgentActivityBump
+	}
+
 	var updated database.Template
 	err = api.Database.InTx(func(tx database.Store) error {
 		if req.Name == template.Name &&
@@ -726,7 +746,10 @@ func (api *API) patchTemplateMeta(rw http.ResponseWriter, r *http.Request) {
 			req.TimeTilDormantAutoDeleteMillis == time.Duration(template.TimeTilDormantAutoDelete).Milliseconds() &&
 			req.RequireActiveVersion == template.RequireActiveVersion &&
 			(deprecationMessage == template.Deprecated) &&
-			maxPortShareLevel == template.MaxPortSharingLevel {
+			maxPortShareLevel == template.MaxPortSharingLevel &&
+			sessionRecording == template.SessionRecording &&
+			agentActivityBump == template.AgentActivityBump &&
+			req.PortForwardingPolicy == nil {
 			return nil
 		}
 
@@ -767,6 +790,9 @@ func (api *API) patchTemplateMeta(rw http.ResponseWriter, r *http.Request) {
 			AllowUserCancelWorkspaceJobs: req.AllowUserCancelWorkspaceJobs,
 			GroupACL:                     groupACL,
 			MaxPortSharingLevel:          maxPortShareLevel,
+			SessionRecording:             sessionRecording,
+			PortForwardingPolicy:         portForwardingPolicy,
+			AgentActivityBump:            agentActivityBump,
 		})
 		if err != nil {
 			return xerrors.Errorf("update template metadata: %w", err)
@@ -997,7 +1023,32 @@ func (api *API) convertTemplate(
 		Deprecated:           templateAccessControl.IsDeprecated(),
 		DeprecationMessage:   templateAccessControl.Deprecated,
 		MaxPortShareLevel:    maxPortShareLevel,
+		SessionRecording:     template.SessionRecording,
+		PortForwardingPolicy: db2sdk.PortForwardingPolicy(template.PortForwardingPolicy),
+		AgentActivityBump:    template.AgentActivityBump,
+	}
+}
+
+func convertPortForwardingPolicy(policy codersdk.PortForwardingPolicy) database.PortForwardingPolicy {
+	var out database.PortForwardingPolicy
+	if policy.DirectTCPIP != nil {
+		out.DirectTCPIP = &database.PortForwardingRule{
+			AllowedCIDRs: policy.DirectTCPIP.AllowedCIDRs,
+			AllowedPorts: policy.DirectTCPIP.AllowedPorts,
+		}
+	}
+	if policy.RemoteForward != nil {
+		out.RemoteForward = &database.PortForwardingRule{
+			AllowedCIDRs: policy.RemoteForward.AllowedCIDRs,
+			AllowedPorts: policy.RemoteForward.AllowedPorts,
+		}
+	}
+	if policy.UnixSocket != nil {
+		out.UnixSocket = &database.UnixSocketForwardingRule{
+			AllowedPaths: policy.UnixSocket.AllowedPaths,
+		}
 	}
+	return out
 }
 
 // findTemplateAdmins fetches all users with template admin permission including owners.
@@ -1042,6 +1042,66 @@ func TestPatchTemplateMeta(t *testing.T) {
 		assert.Equal(t, updated.Icon, "")
 	})
 
+	t.Run("PortForwardingPolicy", func(t *testing.T) {
+		t.Parallel()
+
+		client := coderdtest.New(t, nil)
+		user := coderdtest.CreateFirstUser(t, client)
+		version := coderdtest.CreateTemplateVersion(t, client, user.OrganizationID, nil)
+		template := coderdtest.CreateTemplate(t, client, user.OrganizationID, version.ID)
+		require.Equal(t, codersdk.PortForwardingPolicy{}, template.PortForwardingPolicy)
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		policy := codersdk.PortForwardingPolicy{
+			DirectTCPIP: &codersdk.PortForwardingRule{
+				AllowedCIDRs: []string{"127.0.0.0/8", "::1"},
+				AllowedPorts: []string{"22", "8000-8999"},
+			},
+			RemoteForward: &codersdk.PortForwardingRule{
+				AllowedCIDRs: []string{},
+				AllowedPorts: []string{},
+			},
+			UnixSocket: &codersdk.UnixSocketForwardingRule{
+				AllowedPaths: []string{"/run/user/*/gnupg/*"},
+			},
+		}
+		updated, err := client.UpdateTemplateMeta(ctx, template.ID, codersdk.UpdateTemplateMeta{
+			PortForwardingPolicy: &policy,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, policy, updated.PortForwardingPolicy)
+
+		// Other edits leave the policy unchanged.
+		updated, err = client.UpdateTemplateMeta(ctx, template.ID, codersdk.UpdateTemplateMeta{
+			Description: "restricted",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, policy, updated.PortForwardingPolicy)
+
+		for _, invalid := range []codersdk.PortForwardingPolicy{
+			{DirectTCPIP: &codersdk.PortForwardingRule{AllowedCIDRs: []string{"10.0.0.0/33"}}},
+			{RemoteForward: &codersdk.PortForwardingRule{AllowedPorts: []string{"65536"}}},
+			{RemoteForward: &codersdk.PortForwardingRule{AllowedPorts: []string{"9000-8000"}}},
+			{UnixSocket: &codersdk.UnixSocketForwardingRule{AllowedPaths: []string{"relative/*.sock"}}},
+		} {
+			_, err = client.UpdateTemplateMeta(ctx, template.ID, codersdk.UpdateTemplateMeta{
+				PortForwardingPolicy: &invalid,
+			})
+			var apiErr *codersdk.Error
+			require.ErrorAs(t, err, &apiErr)
+			require.Len(t, apiErr.Validations, 1)
+			assert.Equal(t, "port_forwarding_policy", apiErr.Validations[0].Field)
+		}
+
+		// An empty policy removes the restrictions.
+		updated, err = client.UpdateTemplateMeta(ctx, template.ID, codersdk.UpdateTemplateMeta{
+			PortForwardingPolicy: &codersdk.PortForwardingPolicy{},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, codersdk.PortForwardingPolicy{}, updated.PortForwardingPolicy)
+	})
+
 	t.Run("AutostopRequirement", func(t *testing.T) {
 		t.Parallel()
 
@@ -452,6 +452,37 @@ func (api *API) postTemplateVersionDryRun(rw http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	workspaceName := req.WorkspaceName
+	if req.WorkspaceID != uuid.Nil {
+		workspace, err := api.Database.GetWorkspaceByID(ctx, req.WorkspaceID)
+		if httpapi.Is404Error(err) {
+			httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+				Message: fmt.Sprintf("Workspace %q not found.", req.WorkspaceID),
+			})
+			return
+		}
+		if err != nil {
+			httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+				Message: "Internal error fetching workspace.",
+				Detail:  err.Error(),
+			})
+			return
+		}
+		// Previewing an update plans against the state of the workspace, so
+		// it requires the permission to update it.
+		if !api.Authorize(r, policy.ActionUpdate, workspace) {
+			httpapi.Forbidden(rw)
+			return
+		}
+		if !templateVersion.TemplateID.Valid || templateVersion.TemplateID.UUID != workspace.TemplateID {
+			httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+				Message: "The template version must be a version of the template of the workspace.",
+			})
+			return
+		}
+		workspaceName = workspace.Name
+	}
+
 	richParameterValues := make([]database.WorkspaceBuildParameter, len(req.RichParameterValues))
 	for i, v := range req.RichParameterValues {
 		richParameterValues[i] = database.WorkspaceBuildParameter{
@@ -465,8 +496,9 @@ func (api *API) postTemplateVersionDryRun(rw http.ResponseWriter, r *http.Reques
 	// request.
 	input, err := json.Marshal(provisionerdserver.TemplateVersionDryRunJob{
 		TemplateVersionID:   templateVersion.ID,
-		WorkspaceName:       req.WorkspaceName,
+		WorkspaceName:       workspaceName,
 		RichParameterValues: richParameterValues,
+		WorkspaceID:         req.WorkspaceID,
 	})
 	if err != nil {
 		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
@@ -561,6 +593,72 @@ func (api *API) templateVersionDryRunResources(rw http.ResponseWriter, r *http.R
 	api.provisionerJobResources(rw, r, job.ProvisionerJob)
 }
 
+// @Summary Get template version dry-run resource changes by job ID
+// @Description The changes are only recorded for dry-runs that preview an
+// @Description update of a workspace.
+// @ID get-template-version-dry-run-resource-changes-by-job-id
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Templates
+// @Param templateversion path string true "Template version ID" format(uuid)
+// @Param jobID path string true "Job ID" format(uuid)
+// @Success 200 {array} codersdk.ResourceChange
+// @Router /templateversions/{templateversion}/dry-run/{jobID}/resource-changes [get]
+func (api *API) templateVersionDryRunResourceChanges(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	job, ok := api.fetchTemplateVersionDryRunJob(rw, r)
+	if !ok {
+		return
+	}
+	if !job.ProvisionerJob.CompletedAt.Valid {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Job hasn't completed!",
+		})
+		return
+	}
+
+	dbChanges, err := api.Database.GetProvisionerJobResourceChangesByJobID(ctx, job.ProvisionerJob.ID)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching resource changes.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	changes := make([]codersdk.ResourceChange, 0, len(dbChanges))
+	for _, dbChange := range dbChanges {
+		change, err := convertResourceChange(dbChange)
+		if err != nil {
+			httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+				Message: "Internal error converting resource change.",
+				Detail:  err.Error(),
+			})
+			return
+		}
+		changes = append(changes, change)
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, changes)
+}
+
+func convertResourceChange(change database.ProvisionerJobResourceChange) (codersdk.ResourceChange, error) {
+	var attributes []codersdk.ResourceAttributeChange
+	err := json.Unmarshal(change.Attributes, &attributes)
+	if err != nil {
+		return codersdk.ResourceChange{}, xerrors.Errorf("unmarshal attributes of %q: %w", change.Address, err)
+	}
+	if attributes == nil {
+		attributes = []codersdk.ResourceAttributeChange{}
+	}
+	return codersdk.ResourceChange{
+		Address:    change.Address,
+		Type:       change.Type,
+		Name:       change.Name,
+		Action:     codersdk.ResourceChangeAction(change.Action),
+		Attributes: attributes,
+	}, nil
+}
+
 // @Summary Get template version dry-run logs by job ID
 // @ID get-template-version-dry-run-logs-by-job-id
 // @Security CoderSessionToken
@@ -1341,9 +1439,51 @@ func (api *API) postTemplateVersionsByOrganization(rw http.ResponseWriter, r *ht
 		}
 	}
 
+	// The IaC requirement is set through its own fields, so that it's validated.
+	for _, key := range []string{provisionersdk.TagIaCEngine, provisionersdk.TagIaCVersion} {
+		if _, ok := req.ProvisionerTags[key]; ok {
+			httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+				Message: fmt.Sprintf("The tag %q is reserved.", key),
+				Detail:  "Set iac_engine and iac_version_constraint instead.",
+			})
+			return
+		}
+	}
+
 	// Ensures the "owner" is properly applied.
 	tags := provisionersdk.MutateTags(apiKey.UserID, req.ProvisionerTags)
 
+	if req.IaCEngine != "" {
+		requirement := provisionersdk.IaCRequirement{Engine: req.IaCEngine, Version: req.IaCVersionConstraint}
+		if err := requirement.Validate(); err != nil {
+			httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+				Message: "Invalid IaC requirement.",
+				Validations: []codersdk.ValidationError{
+					{Field: "iac_engine", Detail: err.Error()},
+				},
+			})
+			return
+		}
+		if req.Provisioner != codersdk.ProvisionerTypeTerraform {
+			httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+				Message: fmt.Sprintf("An IaC engine can only be required with the %q provisioner.", codersdk.ProvisionerTypeTerraform),
+			})
+			return
+		}
+		tags[provisionersdk.TagIaCEngine] = requirement.Engine
+		if requirement.Version != "" {
+			tags[provisionersdk.TagIaCVersion] = requirement.Version
+		}
+	} else if req.IaCVersionConstraint != "" {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "An IaC version constraint requires an IaC engine.",
+			Validations: []codersdk.ValidationError{
+				{Field: "iac_engine", Detail: "required with iac_version_constraint"},
+			},
+		})
+		return
+	}
+
 	if req.ExampleID != "" && req.FileID != uuid.Nil {
 		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
 			Message: "You cannot specify both an example_id and a file_id.",
@@ -1623,6 +1763,7 @@ func (api *API) templateVersionLogs(rw http.ResponseWriter, r *http.Request) {
 }
 
 func convertTemplateVersion(version database.TemplateVersion, job codersdk.ProvisionerJob, warnings []codersdk.TemplateVersionWarning) codersdk.TemplateVersion {
+	requirement, _ := provisionersdk.IaCRequirementFromTags(job.Tags)
 	return codersdk.TemplateVersion{
 		ID:             version.ID,
 		TemplateID:     &version.TemplateID.UUID,
@@ -1638,8 +1779,10 @@ func convertTemplateVersion(version database.TemplateVersion, job codersdk.Provi
 			Username:  version.CreatedByUsername,
 			AvatarURL: version.CreatedByAvatarURL,
 		},
-		Archived: version.Archived,
-		Warnings: warnings,
+		Archived:             version.Archived,
+		IaCEngine:            requirement.Engine,
+		IaCVersionConstraint: requirement.Version,
+		Warnings:             warnings,
 	}
 }
 
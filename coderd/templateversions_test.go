@@ -130,6 +130,57 @@ func TestPostTemplateVersionsByOrganization(t *testing.T) {
 		require.Equal(t, http.StatusNotFound, apiErr.StatusCode())
 	})
 
+	t.Run("IaCRequirement", func(t *testing.T) {
+		t.Parallel()
+		client := coderdtest.New(t, nil)
+		user := coderdtest.CreateFirstUser(t, client)
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		file, err := client.Upload(ctx, codersdk.ContentTypeTar, bytes.NewReader([]byte{}))
+		require.NoError(t, err)
+		create := func(mut func(req *codersdk.CreateTemplateVersionRequest)) (codersdk.TemplateVersion, error) {
+			req := codersdk.CreateTemplateVersionRequest{
+				StorageMethod: codersdk.ProvisionerStorageMethodFile,
+				FileID:        file.ID,
+				Provisioner:   codersdk.ProvisionerTypeTerraform,
+			}
+			mut(&req)
+			return client.CreateTemplateVersion(ctx, user.OrganizationID, req)
+		}
+
+		for _, mut := range []func(req *codersdk.CreateTemplateVersionRequest){
+			func(req *codersdk.CreateTemplateVersionRequest) { req.IaCEngine = "pulumi" },
+			func(req *codersdk.CreateTemplateVersionRequest) {
+				req.IaCEngine = "opentofu"
+				req.IaCVersionConstraint = "latest"
+			},
+			func(req *codersdk.CreateTemplateVersionRequest) { req.IaCVersionConstraint = "~> 1.8.0" },
+			func(req *codersdk.CreateTemplateVersionRequest) {
+				req.IaCEngine = "opentofu"
+				req.Provisioner = codersdk.ProvisionerTypeEcho
+			},
+			func(req *codersdk.CreateTemplateVersionRequest) {
+				req.ProvisionerTags = map[string]string{provisionersdk.TagIaCEngine: "opentofu"}
+			},
+		} {
+			_, err := create(mut)
+			var apiErr *codersdk.Error
+			require.ErrorAs(t, err, &apiErr)
+			require.Equal(t, http.StatusBadRequest, apiErr.StatusCode())
+		}
+
+		version, err := create(func(req *codersdk.CreateTemplateVersionRequest) {
+			req.IaCEngine = "opentofu"
+			req.IaCVersionConstraint = "~> 1.8.0"
+		})
+		require.NoError(t, err)
+		require.Equal(t, "opentofu", version.IaCEngine)
+		require.Equal(t, "~> 1.8.0", version.IaCVersionConstraint)
+		require.Equal(t, "opentofu", version.Job.Tags[provisionersdk.TagIaCEngine])
+		require.Equal(t, "~> 1.8.0", version.Job.Tags[provisionersdk.TagIaCVersion])
+	})
+
 	t.Run("WithParameters", func(t *testing.T) {
 		t.Parallel()
 		auditor := audit.NewMock()
@@ -750,6 +801,86 @@ func TestTemplateVersionDryRun(t *testing.T) {
 		require.Equal(t, resource.Type, resources[0].Type)
 	})
 
+	t.Run("WorkspaceUpdatePreview", func(t *testing.T) {
+		t.Parallel()
+
+		change := &proto.ResourceChange{
+			Address: "docker_volume.home",
+			Type:    "docker_volume",
+			Name:    "home",
+			Action:  proto.ResourceChange_REPLACE,
+			Attributes: []*proto.ResourceChange_AttributeChange{{
+				Name:              "name",
+				Before:            `"coder-home"`,
+				After:             `"coder-home-2"`,
+				ForcesReplacement: true,
+			}, {
+				Name:      "labels",
+				Sensitive: true,
+			}},
+		}
+
+		client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+		user := coderdtest.CreateFirstUser(t, client)
+		version := coderdtest.CreateTemplateVersion(t, client, user.OrganizationID, nil)
+		coderdtest.AwaitTemplateVersionJobCompleted(t, client, version.ID)
+		template := coderdtest.CreateTemplate(t, client, user.OrganizationID, version.ID)
+		workspace := coderdtest.CreateWorkspace(t, client, template.ID)
+		coderdtest.AwaitWorkspaceBuildJobCompleted(t, client, workspace.LatestBuild.ID)
+
+		newVersion := coderdtest.UpdateTemplateVersion(t, client, user.OrganizationID, &echo.Responses{
+			Parse:          echo.ParseComplete,
+			ProvisionApply: echo.ApplyComplete,
+			ProvisionPlan: []*proto.Response{{
+				Type: &proto.Response_Plan{
+					Plan: &proto.PlanComplete{
+						ResourceChanges: []*proto.ResourceChange{change},
+					},
+				},
+			}},
+		}, template.ID)
+		coderdtest.AwaitTemplateVersionJobCompleted(t, client, newVersion.ID)
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		job, err := client.CreateTemplateVersionDryRun(ctx, newVersion.ID, codersdk.CreateTemplateVersionDryRunRequest{
+			WorkspaceID: workspace.ID,
+		})
+		require.NoError(t, err)
+		require.Eventually(t, func() bool {
+			job, err := client.TemplateVersionDryRun(ctx, newVersion.ID, job.ID)
+			return assert.NoError(t, err) && job.Status == codersdk.ProvisionerJobSucceeded
+		}, testutil.WaitShort, testutil.IntervalFast)
+
+		changes, err := client.TemplateVersionDryRunResourceChanges(ctx, newVersion.ID, job.ID)
+		require.NoError(t, err)
+		require.Equal(t, []codersdk.ResourceChange{{
+			Address: change.Address,
+			Type:    change.Type,
+			Name:    change.Name,
+			Action:  codersdk.ResourceChangeActionReplace,
+			Attributes: []codersdk.ResourceAttributeChange{{
+				Name:              "name",
+				Before:            `"coder-home"`,
+				After:             `"coder-home-2"`,
+				ForcesReplacement: true,
+			}, {
+				Name:      "labels",
+				Sensitive: true,
+			}},
+		}}, changes)
+
+		// Versions of other templates can't be previewed for the workspace.
+		otherVersion := coderdtest.CreateTemplateVersion(t, client, user.OrganizationID, nil)
+		coderdtest.AwaitTemplateVersionJobCompleted(t, client, otherVersion.ID)
+		_, err = client.CreateTemplateVersionDryRun(ctx, otherVersion.ID, codersdk.CreateTemplateVersionDryRunRequest{
+			WorkspaceID: workspace.ID,
+		})
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusBadRequest, apiErr.StatusCode())
+	})
+
 	t.Run("ImportNotFinished", func(t *testing.T) {
 		t.Parallel()
 		client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
@@ -326,8 +326,9 @@ func unhangJob(ctx context.Context, log slog.Logger, db database.Store, pub pubs
 			}
 
 			// Only copy the provisioner state if there's no state in
-			// the current build.
-			if len(build.ProvisionerState) == 0 {
+			// the current build. State in a state store is immutable, so
+			// copying a reference to it is enough.
+			if len(build.ProvisionerState) == 0 && build.ProvisionerStateRef == "" {
 				// Get the previous build if it exists.
 				prevBuild, err := db.GetWorkspaceBuildByWorkspaceIDAndBuildNumber(ctx, database.GetWorkspaceBuildByWorkspaceIDAndBuildNumberParams{
 					WorkspaceID: build.WorkspaceID,
@@ -338,9 +339,10 @@ func unhangJob(ctx context.Context, log slog.Logger, db database.Store, pub pubs
 				}
 				if err == nil {
 					err = db.UpdateWorkspaceBuildProvisionerStateByID(ctx, database.UpdateWorkspaceBuildProvisionerStateByIDParams{
-						ID:               build.ID,
-						UpdatedAt:        dbtime.Now(),
-						ProvisionerState: prevBuild.ProvisionerState,
+						ID:                  build.ID,
+						UpdatedAt:           dbtime.Now(),
+						ProvisionerState:    prevBuild.ProvisionerState,
+						ProvisionerStateRef: prevBuild.ProvisionerStateRef,
 					})
 					if err != nil {
 						return xerrors.Errorf("update workspace build by id: %w", err)
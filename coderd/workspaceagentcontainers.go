@@ -0,0 +1,64 @@
+package coderd
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/coder/coder/v2/agent/agentcontainers"
+	"github.com/coder/coder/v2/coderd/httpapi"
+	"github.com/coder/coder/v2/coderd/httpmw"
+	"github.com/coder/coder/v2/codersdk"
+)
+
+// @Summary Get running containers for workspace agent
+// @Description Containers are listed periodically by the agent with the
+// @Description docker or podman CLI and reported to coderd. Devcontainers are
+// @Description detected by the labels the devcontainer CLI sets.
+// @ID get-running-containers-for-workspace-agent
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Agents
+// @Param workspaceagent path string true "Workspace agent ID" format(uuid)
+// @Success 200 {object} codersdk.WorkspaceAgentListContainersResponse
+// @Router /workspaceagents/{workspaceagent}/containers [get]
+func (api *API) workspaceAgentListContainers(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	workspaceAgent := httpmw.WorkspaceAgentParam(r)
+
+	rows, err := api.Database.GetWorkspaceAgentContainersByAgentID(ctx, workspaceAgent.ID)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching containers.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	res := codersdk.WorkspaceAgentListContainersResponse{
+		Containers: make([]codersdk.WorkspaceAgentContainer, 0, len(rows)),
+	}
+	for _, row := range rows {
+		container := codersdk.WorkspaceAgentContainer{
+			ID:        row.ContainerID,
+			Name:      row.Name,
+			Image:     row.Image,
+			CreatedAt: row.CreatedAt,
+			Status:    row.Status,
+		}
+		err = json.Unmarshal(row.Labels, &container.Labels)
+		if err == nil {
+			err = json.Unmarshal(row.Ports, &container.Ports)
+		}
+		if err != nil {
+			httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+				Message: "Internal error reading container.",
+				Detail:  err.Error(),
+			})
+			return
+		}
+		res.Runtime = row.Runtime
+		res.Containers = append(res.Containers, agentcontainers.WithDevcontainer(container))
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, res)
+}
@@ -0,0 +1,139 @@
+package coderd_test
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/agent"
+	"github.com/coder/coder/v2/agent/agentcontainers"
+	"github.com/coder/coder/v2/agent/agentcontainers/agentcontainerstest"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/codersdk/workspacesdk"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestWorkspaceAgentListContainers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("OK", func(t *testing.T) {
+		t.Parallel()
+
+		fake := &agentcontainerstest.FakeClient{
+			Containers: []codersdk.WorkspaceAgentContainer{
+				{
+					ID:        "0b1c2d3e",
+					Name:      "vibrant_tesla",
+					Image:     "vsc-coder-1a2b3c",
+					CreatedAt: time.Date(2024, 9, 10, 11, 0, 0, 0, time.UTC),
+					Status:    "running",
+					Labels: map[string]string{
+						agentcontainers.DevcontainerLocalFolderLabel: "/home/coder/coder",
+						agentcontainers.DevcontainerConfigFileLabel:  "/home/coder/coder/.devcontainer/devcontainer.json",
+					},
+					Ports: []codersdk.WorkspaceAgentContainerPort{
+						{Port: 3000, Network: "tcp", HostIP: "0.0.0.0", HostPort: 3000},
+					},
+				},
+				{
+					ID:     "4f2a0bfa",
+					Name:   "postgres",
+					Image:  "postgres:16",
+					Status: "running",
+					Labels: map[string]string{},
+					Ports:  []codersdk.WorkspaceAgentContainerPort{},
+				},
+			},
+		}
+		client, _, agentID := setupWorkspaceAgentForShell(t, nil, func(o *agent.Options) {
+			o.ContainerClient = fake
+			o.ReportContainersInterval = testutil.IntervalFast
+		})
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		// The agent reports the containers asynchronously.
+		var res codersdk.WorkspaceAgentListContainersResponse
+		require.Eventually(t, func() bool {
+			var err error
+			res, err = client.WorkspaceAgentListContainers(ctx, agentID)
+			return assert.NoError(t, err) && len(res.Containers) > 0
+		}, testutil.WaitLong, testutil.IntervalFast)
+		require.Equal(t, "fake", res.Runtime)
+		require.Len(t, res.Containers, 2)
+
+		// Containers are sorted by name.
+		require.Equal(t, "postgres", res.Containers[0].Name)
+		require.Empty(t, res.Containers[0].DevcontainerWorkspaceFolder)
+
+		devcontainer := res.Containers[1]
+		require.Equal(t, "vibrant_tesla", devcontainer.Name)
+		require.True(t, devcontainer.CreatedAt.Equal(fake.Containers[0].CreatedAt))
+		require.Equal(t, "/home/coder/coder", devcontainer.DevcontainerWorkspaceFolder)
+		require.Equal(t, "/home/coder/coder/.devcontainer/devcontainer.json", devcontainer.DevcontainerConfigPath)
+		require.Equal(t, fake.Containers[0].Ports, devcontainer.Ports)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		t.Parallel()
+
+		fake := &agentcontainerstest.FakeClient{
+			ListErr: xerrors.New("Cannot connect to the Docker daemon"),
+		}
+		client, _, agentID := setupWorkspaceAgentForShell(t, nil, func(o *agent.Options) {
+			o.ContainerClient = fake
+			o.ReportContainersInterval = testutil.IntervalFast
+		})
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		// The agent logs the failure and reports nothing, so no containers
+		// are listed.
+		res, err := client.WorkspaceAgentListContainers(ctx, agentID)
+		require.NoError(t, err)
+		require.Empty(t, res.Runtime)
+		require.Empty(t, res.Containers)
+	})
+}
+
+func TestWorkspaceAgentReconnectingPTYContainer(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake container command is a POSIX shell command")
+	}
+
+	// The fake runs a command in the workspace that reports the container
+	// and user instead of exec'ing into a container.
+	fake := &agentcontainerstest.FakeClient{
+		Exec: func(container string, opts agentcontainers.ExecOptions, args ...string) []string {
+			return []string{"echo", "exec", container, "as", opts.User, "tty", strconv.FormatBool(opts.TTY), "run", strings.Join(args[2:], " ")}
+		},
+	}
+	client, _, agentID := setupWorkspaceAgentForShell(t, nil, func(o *agent.Options) {
+		o.ContainerClient = fake
+	})
+	ctx := testutil.Context(t, testutil.WaitLong)
+
+	conn, err := workspacesdk.New(client).AgentReconnectingPTY(ctx, workspacesdk.WorkspaceAgentReconnectingPTYOpts{
+		AgentID:       agentID,
+		Reconnect:     uuid.New(),
+		Width:         80,
+		Height:        80,
+		Command:       "uname",
+		Container:     "vibrant_tesla",
+		ContainerUser: "node",
+	})
+	require.NoError(t, err)
+	defer conn.Close()
+
+	tr := testutil.NewTerminalReader(t, conn)
+	require.NoError(t, tr.ReadUntil(ctx, func(line string) bool {
+		return strings.Contains(line, "exec vibrant_tesla as node tty true run uname")
+	}))
+}
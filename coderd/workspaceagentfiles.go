@@ -0,0 +1,185 @@
+package coderd
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/coder/coder/v2/coderd/database/db2sdk"
+	"github.com/coder/coder/v2/coderd/httpapi"
+	"github.com/coder/coder/v2/coderd/httpmw"
+	"github.com/coder/coder/v2/coderd/rbac/policy"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/codersdk/workspacesdk"
+)
+
+// @Summary Get file info from workspace agent
+// @ID get-file-info-from-workspace-agent
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Agents
+// @Param workspaceagent path string true "Workspace agent ID" format(uuid)
+// @Param path query string true "Path of the file, relative paths are relative to the agent directory"
+// @Success 200 {object} codersdk.WorkspaceAgentFileInfo
+// @Router /workspaceagents/{workspaceagent}/files/stat [get]
+func (api *API) workspaceAgentFileStat(rw http.ResponseWriter, r *http.Request) {
+	api.proxyWorkspaceAgentFiles(rw, r, "/stat")
+}
+
+// @Summary List directory of workspace agent
+// @ID list-directory-of-workspace-agent
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Agents
+// @Param workspaceagent path string true "Workspace agent ID" format(uuid)
+// @Param path query string true "Path of the directory, relative paths are relative to the agent directory"
+// @Success 200 {object} codersdk.WorkspaceAgentFileList
+// @Router /workspaceagents/{workspaceagent}/files/list [get]
+func (api *API) workspaceAgentListFiles(rw http.ResponseWriter, r *http.Request) {
+	api.proxyWorkspaceAgentFiles(rw, r, "/list")
+}
+
+// @Summary Download file from workspace agent
+// @Description Range requests are supported to resume downloads.
+// @ID download-file-from-workspace-agent
+// @Security CoderSessionToken
+// @Tags Agents
+// @Param workspaceagent path string true "Workspace agent ID" format(uuid)
+// @Param path query string true "Path of the file, relative paths are relative to the agent directory"
+// @Success 200
+// @Router /workspaceagents/{workspaceagent}/files/download [get]
+func (api *API) workspaceAgentDownloadFile(rw http.ResponseWriter, r *http.Request) {
+	api.proxyWorkspaceAgentFiles(rw, r, "/download")
+}
+
+// @Summary Download directory from workspace agent as tar archive
+// @ID download-directory-from-workspace-agent-as-tar-archive
+// @Security CoderSessionToken
+// @Tags Agents
+// @Param workspaceagent path string true "Workspace agent ID" format(uuid)
+// @Param path query string true "Path of the directory, relative paths are relative to the agent directory"
+// @Success 200
+// @Router /workspaceagents/{workspaceagent}/files/archive [get]
+func (api *API) workspaceAgentArchiveDirectory(rw http.ResponseWriter, r *http.Request) {
+	api.proxyWorkspaceAgentFiles(rw, r, "/archive")
+}
+
+// @Summary Get file upload progress from workspace agent
+// @ID get-file-upload-progress-from-workspace-agent
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Agents
+// @Param workspaceagent path string true "Workspace agent ID" format(uuid)
+// @Param path query string true "Path of the file, relative paths are relative to the agent directory"
+// @Success 200 {object} codersdk.WorkspaceAgentFileUpload
+// @Router /workspaceagents/{workspaceagent}/files/upload [get]
+func (api *API) workspaceAgentFileUploadProgress(rw http.ResponseWriter, r *http.Request) {
+	api.proxyWorkspaceAgentFiles(rw, r, "/upload")
+}
+
+// @Summary Upload file chunk to workspace agent
+// @Description Chunks must be sent in order, starting at the offset of the upload progress.
+// @ID upload-file-chunk-to-workspace-agent
+// @Security CoderSessionToken
+// @Accept application/octet-stream
+// @Produce json
+// @Tags Agents
+// @Param workspaceagent path string true "Workspace agent ID" format(uuid)
+// @Param path query string true "Path of the file, relative paths are relative to the agent directory"
+// @Param offset query int true "Offset of the chunk in the file"
+// @Param complete query bool false "Move the upload to its path after this chunk"
+// @Param mode query string false "Octal permission bits of the file, defaults to 644"
+// @Param request body string true "Contents of the chunk"
+// @Success 200 {object} codersdk.WorkspaceAgentFileUpload
+// @Router /workspaceagents/{workspaceagent}/files/upload [put]
+func (api *API) workspaceAgentUploadFile(rw http.ResponseWriter, r *http.Request) {
+	api.proxyWorkspaceAgentFiles(rw, r, "/upload")
+}
+
+// proxyWorkspaceAgentFiles forwards a request to the file transfer API of
+// the workspace agent.
+func (api *API) proxyWorkspaceAgentFiles(rw http.ResponseWriter, r *http.Request, path string) {
+	ctx := r.Context()
+	agentConn, release, ok := api.workspaceAgentConnForShell(rw, r)
+	if !ok {
+		return
+	}
+	defer release()
+
+	header := http.Header{}
+	for _, key := range []string{"Range", "If-Range", "Content-Type"} {
+		if values := r.Header.Values(key); len(values) > 0 {
+			header[key] = values
+		}
+	}
+	var body io.Reader
+	if r.Method == http.MethodPut {
+		body = r.Body
+	}
+	res, err := agentConn.FilesRequest(ctx, r.Method, path, r.URL.Query(), header, body)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error proxying request to workspace agent.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	defer res.Body.Close()
+
+	for _, key := range []string{"Accept-Ranges", "Content-Disposition", "Content-Length", "Content-Range", "Content-Type", "Last-Modified"} {
+		if value := res.Header.Get(key); value != "" {
+			rw.Header().Set(key, value)
+		}
+	}
+	rw.WriteHeader(res.StatusCode)
+	_, _ = io.Copy(rw, res.Body)
+}
+
+// workspaceAgentConnForShell dials the workspace agent for endpoints that
+// are as powerful as a shell, so they require permission to SSH into the
+// workspace. It writes an error response if it returns false.
+func (api *API) workspaceAgentConnForShell(rw http.ResponseWriter, r *http.Request) (*workspacesdk.AgentConn, func(), bool) {
+	var (
+		ctx            = r.Context()
+		workspace      = httpmw.WorkspaceParam(r)
+		workspaceAgent = httpmw.WorkspaceAgentParam(r)
+	)
+
+	// Workspace proxies can't act on behalf of users.
+	if _, ok := httpmw.APIKeyOptional(r); !ok {
+		httpapi.Forbidden(rw)
+		return nil, nil, false
+	}
+	if !api.Authorize(r, policy.ActionSSH, workspace) {
+		httpapi.Forbidden(rw)
+		return nil, nil, false
+	}
+
+	apiAgent, err := db2sdk.WorkspaceAgent(
+		api.DERPMap(), *api.TailnetCoordinator.Load(), workspaceAgent, nil, nil, nil, api.AgentInactiveDisconnectTimeout,
+		api.DeploymentValues.AgentFallbackTroubleshootingURL.String(),
+	)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error reading workspace agent.",
+			Detail:  err.Error(),
+		})
+		return nil, nil, false
+	}
+	if apiAgent.Status != codersdk.WorkspaceAgentConnected {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: fmt.Sprintf("Agent state is %q, it must be in the %q state.", apiAgent.Status, codersdk.WorkspaceAgentConnected),
+		})
+		return nil, nil, false
+	}
+
+	agentConn, release, err := api.agentProvider.AgentConn(ctx, workspaceAgent.ID)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error dialing workspace agent.",
+			Detail:  err.Error(),
+		})
+		return nil, nil, false
+	}
+	return agentConn, release, true
+}
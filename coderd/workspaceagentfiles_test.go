@@ -0,0 +1,193 @@
+package coderd_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/agent"
+	"github.com/coder/coder/v2/agent/agenttest"
+	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbfake"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestWorkspaceAgentFiles(t *testing.T) {
+	t.Parallel()
+
+	setup := func(t *testing.T, opts ...func(*agent.Options)) (*codersdk.Client, uuid.UUID) {
+		t.Helper()
+
+		client, db := coderdtest.NewWithDatabase(t, nil)
+		user := coderdtest.CreateFirstUser(t, client)
+		r := dbfake.WorkspaceBuild(t, db, database.Workspace{
+			OrganizationID: user.OrganizationID,
+			OwnerID:        user.UserID,
+		}).WithAgent().Do()
+		_ = agenttest.New(t, client.URL, r.AgentToken, opts...)
+		resources := coderdtest.AwaitWorkspaceAgents(t, client, r.Workspace.ID)
+		return client, resources[0].Agents[0].ID
+	}
+
+	t.Run("StatAndList", func(t *testing.T) {
+		t.Parallel()
+
+		client, agentID := setup(t)
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("hello"), 0o600))
+		require.NoError(t, os.Mkdir(filepath.Join(dir, "sub"), 0o755))
+
+		info, err := client.WorkspaceAgentFileStat(ctx, agentID, filepath.Join(dir, "a.txt"))
+		require.NoError(t, err)
+		require.Equal(t, "a.txt", info.Name)
+		require.EqualValues(t, 5, info.Size)
+		require.False(t, info.IsDir)
+
+		list, err := client.WorkspaceAgentListFiles(ctx, agentID, dir)
+		require.NoError(t, err)
+		require.Equal(t, dir, list.Path)
+		require.Len(t, list.Files, 2)
+		require.Equal(t, "a.txt", list.Files[0].Name)
+		require.Equal(t, "sub", list.Files[1].Name)
+		require.True(t, list.Files[1].IsDir)
+
+		_, err = client.WorkspaceAgentFileStat(ctx, agentID, filepath.Join(dir, "missing"))
+		var sdkErr *codersdk.Error
+		require.True(t, errors.As(err, &sdkErr))
+		require.Equal(t, http.StatusNotFound, sdkErr.StatusCode())
+	})
+
+	t.Run("ResumableUpload", func(t *testing.T) {
+		t.Parallel()
+
+		client, agentID := setup(t)
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		path := filepath.Join(t.TempDir(), "nested", "upload.txt")
+		upload, err := client.WorkspaceAgentFileUploadProgress(ctx, agentID, path)
+		require.NoError(t, err)
+		require.Zero(t, upload.Offset)
+
+		upload, err = client.WorkspaceAgentUploadFile(ctx, agentID, codersdk.UploadWorkspaceAgentFileRequest{
+			Path: path,
+		}, bytes.NewBufferString("hello "))
+		require.NoError(t, err)
+		require.EqualValues(t, 6, upload.Offset)
+		require.False(t, upload.Complete)
+
+		// The upload must be resumed where it left off.
+		_, err = client.WorkspaceAgentUploadFile(ctx, agentID, codersdk.UploadWorkspaceAgentFileRequest{
+			Path:   path,
+			Offset: 3,
+		}, bytes.NewBufferString("lo world"))
+		var sdkErr *codersdk.Error
+		require.True(t, errors.As(err, &sdkErr))
+		require.Equal(t, http.StatusConflict, sdkErr.StatusCode())
+
+		upload, err = client.WorkspaceAgentFileUploadProgress(ctx, agentID, path)
+		require.NoError(t, err)
+		require.EqualValues(t, 6, upload.Offset)
+		upload, err = client.WorkspaceAgentUploadFile(ctx, agentID, codersdk.UploadWorkspaceAgentFileRequest{
+			Path:     path,
+			Offset:   upload.Offset,
+			Complete: true,
+			Mode:     0o600,
+		}, bytes.NewBufferString("world"))
+		require.NoError(t, err)
+		require.True(t, upload.Complete)
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.Equal(t, "hello world", string(data))
+		stat, err := os.Stat(path)
+		require.NoError(t, err)
+		require.Equal(t, os.FileMode(0o600), stat.Mode().Perm())
+	})
+
+	t.Run("Download", func(t *testing.T) {
+		t.Parallel()
+
+		client, agentID := setup(t)
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		path := filepath.Join(t.TempDir(), "download.txt")
+		require.NoError(t, os.WriteFile(path, []byte("hello world"), 0o600))
+
+		rc, err := client.WorkspaceAgentDownloadFile(ctx, agentID, path, 0)
+		require.NoError(t, err)
+		data, err := io.ReadAll(rc)
+		_ = rc.Close()
+		require.NoError(t, err)
+		require.Equal(t, "hello world", string(data))
+
+		rc, err = client.WorkspaceAgentDownloadFile(ctx, agentID, path, 6)
+		require.NoError(t, err)
+		data, err = io.ReadAll(rc)
+		_ = rc.Close()
+		require.NoError(t, err)
+		require.Equal(t, "world", string(data))
+
+		rc, err = client.WorkspaceAgentDownloadFile(ctx, agentID, path, 11)
+		require.NoError(t, err)
+		data, err = io.ReadAll(rc)
+		_ = rc.Close()
+		require.NoError(t, err)
+		require.Empty(t, data)
+	})
+
+	t.Run("Archive", func(t *testing.T) {
+		t.Parallel()
+
+		client, agentID := setup(t)
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		dir := filepath.Join(t.TempDir(), "project")
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "sub"), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "b.txt"), []byte("nested"), 0o600))
+
+		rc, err := client.WorkspaceAgentArchiveDirectory(ctx, agentID, dir)
+		require.NoError(t, err)
+		defer rc.Close()
+
+		files := map[string]string{}
+		tr := tar.NewReader(rc)
+		for {
+			hdr, err := tr.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			require.NoError(t, err)
+			data, err := io.ReadAll(tr)
+			require.NoError(t, err)
+			files[hdr.Name] = string(data)
+		}
+		require.Equal(t, "nested", files["project/sub/b.txt"])
+		require.Contains(t, files, "project/sub/")
+	})
+
+	t.Run("BlockFileTransfer", func(t *testing.T) {
+		t.Parallel()
+
+		client, agentID := setup(t, func(o *agent.Options) {
+			o.BlockFileTransfer = true
+		})
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		_, err := client.WorkspaceAgentFileStat(ctx, agentID, t.TempDir())
+		var sdkErr *codersdk.Error
+		require.True(t, errors.As(err, &sdkErr))
+		require.Equal(t, http.StatusForbidden, sdkErr.StatusCode())
+	})
+}
@@ -0,0 +1,100 @@
+package coderd
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/httpapi"
+	"github.com/coder/coder/v2/coderd/httpmw"
+	"github.com/coder/coder/v2/codersdk"
+)
+
+const (
+	// defaultMetadataHistoryLimit is the number of samples of a metadata key
+	// that are returned when no limit is given.
+	defaultMetadataHistoryLimit = 1000
+	// maxMetadataHistoryLimit matches the number of samples dbpurge keeps for
+	// each key.
+	maxMetadataHistoryLimit = 10_000
+)
+
+// @Summary Get workspace agent metadata history
+// @Description Samples are only recorded when the agent metadata history
+// @Description retention of the deployment is greater than zero.
+// @ID get-workspace-agent-metadata-history
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Agents
+// @Param workspaceagent path string true "Workspace agent ID" format(uuid)
+// @Param key path string true "Metadata key"
+// @Param after query string false "Only return samples collected after this time" format(date-time)
+// @Param limit query int false "Maximum number of the newest samples to return"
+// @Success 200 {array} codersdk.WorkspaceAgentMetadataSample
+// @Router /workspaceagents/{workspaceagent}/metadata/{key}/history [get]
+func (api *API) workspaceAgentMetadataHistory(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx            = r.Context()
+		workspaceAgent = httpmw.WorkspaceAgentParam(r)
+		key            = chi.URLParam(r, "key")
+	)
+
+	parser := httpapi.NewQueryParamParser()
+	after := parser.Time3339Nano(r.URL.Query(), time.Time{}, "after")
+	limit := parser.PositiveInt32(r.URL.Query(), defaultMetadataHistoryLimit, "limit")
+	parser.ErrorExcessParams(r.URL.Query())
+	if len(parser.Errors) > 0 {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message:     "Invalid query parameters.",
+			Validations: parser.Errors,
+		})
+		return
+	}
+	if limit == 0 || limit > maxMetadataHistoryLimit {
+		limit = maxMetadataHistoryLimit
+	}
+
+	metadata, err := api.Database.GetWorkspaceAgentMetadata(ctx, database.GetWorkspaceAgentMetadataParams{
+		WorkspaceAgentID: workspaceAgent.ID,
+		Keys:             []string{key},
+	})
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching workspace agent metadata.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	if len(metadata) == 0 {
+		httpapi.ResourceNotFound(rw)
+		return
+	}
+
+	history, err := api.Database.GetWorkspaceAgentMetadataHistory(ctx, database.GetWorkspaceAgentMetadataHistoryParams{
+		WorkspaceAgentID: workspaceAgent.ID,
+		Key:              key,
+		CollectedAfter:   after,
+		Limit:            limit,
+	})
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching workspace agent metadata history.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	// The newest samples are fetched so the limit drops the oldest, but they
+	// are returned oldest first to read as a time series.
+	samples := make([]codersdk.WorkspaceAgentMetadataSample, len(history))
+	for i, sample := range history {
+		samples[len(history)-1-i] = codersdk.WorkspaceAgentMetadataSample{
+			CollectedAt: sample.CollectedAt,
+			Value:       sample.Value,
+			Error:       sample.Error,
+		}
+	}
+	httpapi.Write(ctx, rw, http.StatusOK, samples)
+}
@@ -0,0 +1,108 @@
+package coderd
+
+import (
+	"net/http"
+
+	"github.com/coder/coder/v2/coderd/audit"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/httpapi"
+	"github.com/coder/coder/v2/coderd/httpmw"
+	"github.com/coder/coder/v2/codersdk"
+)
+
+// @Summary Get processes of workspace agent
+// @ID get-processes-of-workspace-agent
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Agents
+// @Param workspaceagent path string true "Workspace agent ID" format(uuid)
+// @Success 200 {object} codersdk.WorkspaceAgentProcessesResponse
+// @Router /workspaceagents/{workspaceagent}/processes [get]
+func (api *API) workspaceAgentProcesses(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	agentConn, release, ok := api.workspaceAgentConnForShell(rw, r)
+	if !ok {
+		return
+	}
+	defer release()
+
+	processes, err := agentConn.Processes(ctx)
+	if sdkErr, ok := codersdk.AsError(err); ok {
+		httpapi.Write(ctx, rw, sdkErr.StatusCode(), sdkErr.Response)
+		return
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching processes.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, processes)
+}
+
+// @Summary Run command in workspace agent
+// @Description The command is run without a terminal, and the response is
+// @Description returned once it exits.
+// @ID run-command-in-workspace-agent
+// @Security CoderSessionToken
+// @Accept json
+// @Produce json
+// @Tags Agents
+// @Param workspaceagent path string true "Workspace agent ID" format(uuid)
+// @Param request body codersdk.WorkspaceAgentExecRequest true "Exec request"
+// @Success 200 {object} codersdk.WorkspaceAgentExecResponse
+// @Router /workspaceagents/{workspaceagent}/exec [post]
+func (api *API) workspaceAgentExec(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx            = r.Context()
+		workspace      = httpmw.WorkspaceParam(r)
+		workspaceAgent = httpmw.WorkspaceAgentParam(r)
+		auditor        = api.Auditor.Load()
+	)
+
+	var req codersdk.WorkspaceAgentExecRequest
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+
+	aReq, commitAudit := audit.InitRequest[database.Workspace](rw, &audit.RequestParams{
+		Audit:          *auditor,
+		Log:            api.Logger,
+		Request:        r,
+		Action:         database.AuditActionExec,
+		OrganizationID: workspace.OrganizationID,
+		AdditionalFields: audit.AdditionalFields{
+			WorkspaceName: workspace.Name,
+			WorkspaceID:   workspace.ID,
+			AgentName:     workspaceAgent.Name,
+			Command:       req.Command,
+		},
+	})
+	defer commitAudit()
+	aReq.Old = workspace
+	aReq.New = workspace
+
+	agentConn, release, ok := api.workspaceAgentConnForShell(rw, r)
+	if !ok {
+		return
+	}
+	defer release()
+
+	resp, err := agentConn.Exec(ctx, req)
+	if sdkErr, ok := codersdk.AsError(err); ok {
+		// The agent refused to run the command.
+		httpapi.Write(ctx, rw, sdkErr.StatusCode(), sdkErr.Response)
+		return
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error running command.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, resp)
+}
@@ -0,0 +1,153 @@
+package coderd_test
+
+import (
+	"net/http"
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/agent"
+	"github.com/coder/coder/v2/agent/agenttest"
+	"github.com/coder/coder/v2/coderd/audit"
+	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbfake"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func setupWorkspaceAgentForShell(t *testing.T, auditor audit.Auditor, opts ...func(*agent.Options)) (*codersdk.Client, uuid.UUID, uuid.UUID) {
+	t.Helper()
+
+	client, db := coderdtest.NewWithDatabase(t, &coderdtest.Options{
+		Auditor: auditor,
+	})
+	user := coderdtest.CreateFirstUser(t, client)
+	r := dbfake.WorkspaceBuild(t, db, database.Workspace{
+		OrganizationID: user.OrganizationID,
+		OwnerID:        user.UserID,
+	}).WithAgent().Do()
+	_ = agenttest.New(t, client.URL, r.AgentToken, opts...)
+	resources := coderdtest.AwaitWorkspaceAgents(t, client, r.Workspace.ID)
+	return client, r.Workspace.ID, resources[0].Agents[0].ID
+}
+
+func TestWorkspaceAgentProcesses(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS != "linux" {
+		t.Skip("listing processes is only supported on Linux")
+	}
+
+	client, _, agentID := setupWorkspaceAgentForShell(t, nil)
+	ctx := testutil.Context(t, testutil.WaitLong)
+
+	res, err := client.WorkspaceAgentProcesses(ctx, agentID)
+	require.NoError(t, err)
+	require.NotEmpty(t, res.Processes)
+
+	// The agent runs in the test process, so it must be in the tree.
+	var find func(procs []codersdk.WorkspaceAgentProcess) *codersdk.WorkspaceAgentProcess
+	find = func(procs []codersdk.WorkspaceAgentProcess) *codersdk.WorkspaceAgentProcess {
+		for i, proc := range procs {
+			if proc.PID == int32(os.Getpid()) {
+				return &procs[i]
+			}
+			if found := find(proc.Children); found != nil {
+				return found
+			}
+		}
+		return nil
+	}
+	proc := find(res.Processes)
+	require.NotNil(t, proc)
+	assert.EqualValues(t, os.Getppid(), proc.ParentPID)
+	assert.NotEmpty(t, proc.Command)
+	assert.NotEmpty(t, proc.User)
+	assert.Positive(t, proc.MemoryBytes)
+	assert.False(t, proc.StartedAt.IsZero())
+}
+
+func TestWorkspaceAgentExec(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("the commands are POSIX shell")
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		t.Parallel()
+
+		auditor := audit.NewMock()
+		client, workspaceID, agentID := setupWorkspaceAgentForShell(t, auditor)
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		res, err := client.WorkspaceAgentExec(ctx, agentID, codersdk.WorkspaceAgentExecRequest{
+			Command: "echo hello; echo world >&2; exit 3",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 3, res.ExitCode)
+		assert.Equal(t, "hello\n", res.Stdout)
+		assert.Equal(t, "world\n", res.Stderr)
+		assert.False(t, res.Truncated)
+		assert.False(t, res.TimedOut)
+
+		assert.True(t, auditor.Contains(t, database.AuditLog{
+			Action:       database.AuditActionExec,
+			ResourceType: database.ResourceTypeWorkspace,
+			ResourceID:   workspaceID,
+			StatusCode:   http.StatusOK,
+		}))
+	})
+
+	t.Run("Dir", func(t *testing.T) {
+		t.Parallel()
+
+		client, _, agentID := setupWorkspaceAgentForShell(t, nil)
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		dir := t.TempDir()
+		res, err := client.WorkspaceAgentExec(ctx, agentID, codersdk.WorkspaceAgentExecRequest{
+			Command: "pwd",
+			Dir:     dir,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 0, res.ExitCode)
+		assert.Equal(t, dir+"\n", res.Stdout)
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		t.Parallel()
+
+		client, _, agentID := setupWorkspaceAgentForShell(t, nil)
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		res, err := client.WorkspaceAgentExec(ctx, agentID, codersdk.WorkspaceAgentExecRequest{
+			Command:       "sleep 30",
+			TimeoutMillis: 100,
+		})
+		require.NoError(t, err)
+		assert.True(t, res.TimedOut)
+		assert.Equal(t, -1, res.ExitCode)
+	})
+
+	t.Run("BlockFileTransfer", func(t *testing.T) {
+		t.Parallel()
+
+		client, _, agentID := setupWorkspaceAgentForShell(t, nil, func(o *agent.Options) {
+			o.BlockFileTransfer = true
+		})
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		_, err := client.WorkspaceAgentExec(ctx, agentID, codersdk.WorkspaceAgentExecRequest{
+			Command: "/usr/bin/scp file host:",
+		})
+		sdkErr, ok := codersdk.AsError(err)
+		require.True(t, ok)
+		require.Equal(t, http.StatusForbidden, sdkErr.StatusCode())
+	})
+}
@@ -0,0 +1,60 @@
+package coderd
+
+import (
+	"net/http"
+
+	"github.com/coder/coder/v2/coderd/httpapi"
+	"github.com/coder/coder/v2/coderd/httpmw"
+	"github.com/coder/coder/v2/coderd/rbac/policy"
+	"github.com/coder/coder/v2/codersdk"
+)
+
+// @Summary Get reconnecting PTY sessions of workspace agent
+// @Description Sessions can be attached to read only or taken over through
+// @Description the PTY endpoint with the read_only and takeover query
+// @Description parameters, which requires the share_session permission on the
+// @Description workspace. The sessions of other users are only listed with
+// @Description that permission as well.
+// @ID get-reconnecting-pty-sessions-of-workspace-agent
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Agents
+// @Param workspaceagent path string true "Workspace agent ID" format(uuid)
+// @Success 200 {object} codersdk.WorkspaceAgentReconnectingPTYsResponse
+// @Router /workspaceagents/{workspaceagent}/reconnecting-ptys [get]
+func (api *API) workspaceAgentReconnectingPTYs(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	agentConn, release, ok := api.workspaceAgentConnForShell(rw, r)
+	if !ok {
+		return
+	}
+	defer release()
+
+	sessions, err := agentConn.ReconnectingPTYs(ctx)
+	if sdkErr, ok := codersdk.AsError(err); ok {
+		httpapi.Write(ctx, rw, sdkErr.StatusCode(), sdkErr.Response)
+		return
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching reconnecting PTY sessions.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	// The sessions of other users can only be attached to with the
+	// share_session permission, so they're hidden from everyone else.
+	if !api.Authorize(r, policy.ActionShareSession, httpmw.WorkspaceParam(r)) {
+		apiKey := httpmw.APIKey(r)
+		owned := make([]codersdk.WorkspaceAgentReconnectingPTY, 0, len(sessions.Sessions))
+		for _, session := range sessions.Sessions {
+			if session.UserID == apiKey.UserID {
+				owned = append(owned, session)
+			}
+		}
+		sessions.Sessions = owned
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, sessions)
+}
@@ -0,0 +1,318 @@
+package coderd_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/agent/agenttest"
+	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbfake"
+	"github.com/coder/coder/v2/coderd/rbac"
+	"github.com/coder/coder/v2/coderd/rbac/policy"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/codersdk/workspacesdk"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestWorkspaceAgentReconnectingPTYs(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("the commands are POSIX shell")
+	}
+
+	client, _, agentID := setupWorkspaceAgentForShell(t, nil)
+	ctx := testutil.Context(t, testutil.WaitLong)
+	sdk := workspacesdk.New(client)
+	id := uuid.New()
+
+	dial := func(mut func(*workspacesdk.WorkspaceAgentReconnectingPTYOpts)) net.Conn {
+		t.Helper()
+		opts := workspacesdk.WorkspaceAgentReconnectingPTYOpts{
+			AgentID:   agentID,
+			Reconnect: id,
+			Width:     80,
+			Height:    80,
+			// --norc disables executing .bashrc, which is often used to
+			// customize the bash prompt.
+			Command: "bash --norc",
+		}
+		if mut != nil {
+			mut(&opts)
+		}
+		conn, err := sdk.AgentReconnectingPTY(ctx, opts)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			_ = conn.Close()
+		})
+		return conn
+	}
+	write := func(conn net.Conn, data string) {
+		t.Helper()
+		req, err := json.Marshal(workspacesdk.ReconnectingPTYRequest{Data: data})
+		require.NoError(t, err)
+		_, err = conn.Write(req)
+		require.NoError(t, err)
+	}
+	listSessions := func() []codersdk.WorkspaceAgentReconnectingPTY {
+		t.Helper()
+		res, err := client.WorkspaceAgentReconnectingPTYs(ctx, agentID)
+		require.NoError(t, err)
+		return res.Sessions
+	}
+	matchPrompt := func(line string) bool {
+		return strings.Contains(line, "$ ") || strings.Contains(line, "# ")
+	}
+
+	require.Empty(t, listSessions())
+
+	writer := dial(nil)
+	writerReader := testutil.NewTerminalReader(t, writer)
+	require.NoError(t, writerReader.ReadUntil(ctx, matchPrompt), "find prompt")
+
+	sessions := listSessions()
+	require.Len(t, sessions, 1)
+	require.Equal(t, id, sessions[0].ID)
+	require.Equal(t, "bash --norc", sessions[0].Command)
+	require.Len(t, sessions[0].Connections, 1)
+	require.False(t, sessions[0].Connections[0].ReadOnly)
+
+	viewer := dial(func(opts *workspacesdk.WorkspaceAgentReconnectingPTYOpts) {
+		opts.ReadOnly = true
+	})
+	viewerReader := testutil.NewTerminalReader(t, viewer)
+	require.Eventually(t, func() bool {
+		sessions := listSessions()
+		return len(sessions) == 1 && len(sessions[0].Connections) == 2 && sessions[0].Connections[1].ReadOnly
+	}, testutil.WaitShort, testutil.IntervalFast)
+
+	// The input of the viewer is discarded while the output of the writer
+	// reaches it.
+	write(viewer, "echo viewer-$((2+2))\r")
+	write(writer, "echo writer-$((1+1))\r")
+	sawViewer := false
+	require.NoError(t, viewerReader.ReadUntil(ctx, func(line string) bool {
+		if strings.Contains(line, "viewer-4") {
+			sawViewer = true
+		}
+		return strings.Contains(line, "writer-2") && !strings.Contains(line, "echo")
+	}), "find writer output")
+	require.False(t, sawViewer, "input of the viewer was run")
+
+	// Taking over detaches the writer but not the viewer.
+	_ = dial(func(opts *workspacesdk.WorkspaceAgentReconnectingPTYOpts) {
+		opts.Takeover = true
+	})
+	require.ErrorIs(t, writerReader.ReadUntil(ctx, nil), io.EOF)
+	require.Eventually(t, func() bool {
+		sessions := listSessions()
+		if len(sessions) != 1 || len(sessions[0].Connections) != 2 {
+			return false
+		}
+		readOnly := 0
+		for _, conn := range sessions[0].Connections {
+			if conn.ReadOnly {
+				readOnly++
+			}
+		}
+		return readOnly == 1
+	}, testutil.WaitShort, testutil.IntervalFast)
+
+	// Viewers can't start sessions.
+	unknown := dial(func(opts *workspacesdk.WorkspaceAgentReconnectingPTYOpts) {
+		opts.Reconnect = uuid.New()
+		opts.ReadOnly = true
+	})
+	require.ErrorIs(t, testutil.NewTerminalReader(t, unknown).ReadUntil(ctx, nil), io.EOF)
+	require.Len(t, listSessions(), 1)
+}
+
+func TestWorkspaceAgentReconnectingPTYsExited(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("the commands are POSIX shell")
+	}
+
+	client, _, agentID := setupWorkspaceAgentForShell(t, nil)
+	ctx := testutil.Context(t, testutil.WaitLong)
+
+	// A session is forgotten once its process exits.
+	conn, err := workspacesdk.New(client).AgentReconnectingPTY(ctx, workspacesdk.WorkspaceAgentReconnectingPTYOpts{
+		AgentID:   agentID,
+		Reconnect: uuid.New(),
+		Width:     80,
+		Height:    80,
+		Command:   "echo done",
+	})
+	require.NoError(t, err)
+	defer conn.Close()
+	require.NoError(t, testutil.NewTerminalReader(t, conn).ReadUntil(ctx, func(line string) bool {
+		return strings.TrimSpace(line) == "done"
+	}))
+	require.Eventually(t, func() bool {
+		res, err := client.WorkspaceAgentReconnectingPTYs(ctx, agentID)
+		return err == nil && len(res.Sessions) == 0
+	}, testutil.WaitShort, testutil.IntervalFast)
+}
+
+// denyActionAuthorizer denies an action that the wrapped authorizer would
+// allow. With allowOwners, site owners are still allowed the action.
+type denyActionAuthorizer struct {
+	rbac.Authorizer
+	action      policy.Action
+	allowOwners bool
+}
+
+func (a *denyActionAuthorizer) Authorize(ctx context.Context, subject rbac.Subject, action policy.Action, object rbac.Object) error {
+	if action == a.action && !(a.allowOwners && slices.Contains(subject.Roles.Names(), rbac.RoleOwner())) {
+		return xerrors.Errorf("%s is denied", action)
+	}
+	return a.Authorizer.Authorize(ctx, subject, action, object)
+}
+
+func TestWorkspaceAgentReconnectingPTYShareSessionPermission(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("the commands are POSIX shell")
+	}
+
+	// The user can ssh into the workspace but not share its sessions.
+	client, db := coderdtest.NewWithDatabase(t, &coderdtest.Options{
+		Authorizer: &denyActionAuthorizer{
+			Authorizer: rbac.NewStrictCachingAuthorizer(prometheus.NewRegistry()),
+			action:     policy.ActionShareSession,
+		},
+	})
+	user := coderdtest.CreateFirstUser(t, client)
+	r := dbfake.WorkspaceBuild(t, db, database.Workspace{
+		OrganizationID: user.OrganizationID,
+		OwnerID:        user.UserID,
+	}).WithAgent().Do()
+	_ = agenttest.New(t, client.URL, r.AgentToken)
+	agentID := coderdtest.AwaitWorkspaceAgents(t, client, r.Workspace.ID)[0].Agents[0].ID
+	ctx := testutil.Context(t, testutil.WaitLong)
+	sdk := workspacesdk.New(client)
+
+	id := uuid.New()
+	opts := workspacesdk.WorkspaceAgentReconnectingPTYOpts{
+		AgentID:   agentID,
+		Reconnect: id,
+		Width:     80,
+		Height:    80,
+		Command:   "bash --norc",
+	}
+	conn, err := sdk.AgentReconnectingPTY(ctx, opts)
+	require.NoError(t, err)
+	defer conn.Close()
+	require.Eventually(t, func() bool {
+		res, err := client.WorkspaceAgentReconnectingPTYs(ctx, agentID)
+		return err == nil && len(res.Sessions) == 1
+	}, testutil.WaitShort, testutil.IntervalFast)
+
+	viewOpts := opts
+	viewOpts.ReadOnly = true
+	_, err = sdk.AgentReconnectingPTY(ctx, viewOpts)
+	require.Error(t, err, "watch session")
+
+	takeoverOpts := opts
+	takeoverOpts.Takeover = true
+	_, err = sdk.AgentReconnectingPTY(ctx, takeoverOpts)
+	require.Error(t, err, "take over session")
+
+	res, err := client.WorkspaceAgentReconnectingPTYs(ctx, agentID)
+	require.NoError(t, err)
+	require.Len(t, res.Sessions, 1)
+	require.Len(t, res.Sessions[0].Connections, 1)
+}
+
+func TestWorkspaceAgentReconnectingPTYShareSessionOtherUser(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("the commands are POSIX shell")
+	}
+
+	// The member can ssh into their workspace but not share its sessions,
+	// while the owner can do both.
+	client, db := coderdtest.NewWithDatabase(t, &coderdtest.Options{
+		Authorizer: &denyActionAuthorizer{
+			Authorizer:  rbac.NewStrictCachingAuthorizer(prometheus.NewRegistry()),
+			action:      policy.ActionShareSession,
+			allowOwners: true,
+		},
+	})
+	owner := coderdtest.CreateFirstUser(t, client)
+	memberClient, member := coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+	r := dbfake.WorkspaceBuild(t, db, database.Workspace{
+		OrganizationID: owner.OrganizationID,
+		OwnerID:        member.ID,
+	}).WithAgent().Do()
+	_ = agenttest.New(t, client.URL, r.AgentToken)
+	agentID := coderdtest.AwaitWorkspaceAgents(t, client, r.Workspace.ID)[0].Agents[0].ID
+	ctx := testutil.Context(t, testutil.WaitLong)
+
+	dial := func(client *codersdk.Client, id uuid.UUID) net.Conn {
+		t.Helper()
+		conn, err := workspacesdk.New(client).AgentReconnectingPTY(ctx, workspacesdk.WorkspaceAgentReconnectingPTYOpts{
+			AgentID:   agentID,
+			Reconnect: id,
+			Width:     80,
+			Height:    80,
+			Command:   "bash --norc",
+		})
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			_ = conn.Close()
+		})
+		return conn
+	}
+	listSessions := func(client *codersdk.Client) []codersdk.WorkspaceAgentReconnectingPTY {
+		t.Helper()
+		res, err := client.WorkspaceAgentReconnectingPTYs(ctx, agentID)
+		require.NoError(t, err)
+		return res.Sessions
+	}
+
+	ownerSession := uuid.New()
+	_ = dial(client, ownerSession)
+	require.Eventually(t, func() bool {
+		return len(listSessions(client)) == 1
+	}, testutil.WaitShort, testutil.IntervalFast)
+	require.Equal(t, owner.UserID, listSessions(client)[0].UserID)
+
+	// The session of the owner is neither listed for the member nor can it
+	// be joined by its ID.
+	require.Empty(t, listSessions(memberClient))
+	joined := dial(memberClient, ownerSession)
+	require.ErrorIs(t, testutil.NewTerminalReader(t, joined).ReadUntil(ctx, nil), io.EOF)
+	sessions := listSessions(client)
+	require.Len(t, sessions, 1)
+	require.Len(t, sessions[0].Connections, 1)
+
+	// The member can still reconnect to their own sessions.
+	memberSession := uuid.New()
+	_ = dial(memberClient, memberSession)
+	require.Eventually(t, func() bool {
+		return len(listSessions(memberClient)) == 1
+	}, testutil.WaitShort, testutil.IntervalFast)
+	_ = dial(memberClient, memberSession)
+	require.Eventually(t, func() bool {
+		sessions := listSessions(memberClient)
+		return len(sessions) == 1 && sessions[0].ID == memberSession && len(sessions[0].Connections) == 2
+	}, testutil.WaitShort, testutil.IntervalFast)
+	require.Len(t, listSessions(client), 2)
+}
@@ -1449,6 +1449,87 @@ func TestWorkspaceAgent_Metadata_DisplayOrder(t *testing.T) {
 	require.Equal(t, "Fourth Meta", update[3].Description.DisplayName)
 }
 
+func TestWorkspaceAgent_MetadataHistory(t *testing.T) {
+	t.Parallel()
+
+	client, db := coderdtest.NewWithDatabase(t, nil)
+	user := coderdtest.CreateFirstUser(t, client)
+	r := dbfake.WorkspaceBuild(t, db, database.Workspace{
+		OrganizationID: user.OrganizationID,
+		OwnerID:        user.UserID,
+	}).WithAgent(func(agents []*proto.Agent) []*proto.Agent {
+		agents[0].Metadata = []*proto.Agent_Metadata{
+			{
+				DisplayName: "CPU",
+				Key:         "cpu",
+				Script:      "echo 10%",
+				Interval:    10,
+				Timeout:     3,
+			},
+		}
+		return agents
+	}).Do()
+
+	agentClient := agentsdk.New(client.URL)
+	agentClient.SetSessionToken(r.AgentToken)
+
+	ctx := testutil.Context(t, testutil.WaitMedium)
+	conn, err := agentClient.ConnectRPC(ctx)
+	require.NoError(t, err)
+	defer func() {
+		cErr := conn.Close()
+		require.NoError(t, cErr)
+	}()
+	aAPI := agentproto.NewDRPCAgentClient(conn)
+
+	for _, value := range []string{"10%", "20%", "30%"} {
+		_, err := aAPI.BatchUpdateMetadata(ctx, &agentproto.BatchUpdateMetadataRequest{
+			Metadata: []*agentproto.Metadata{
+				{
+					Key: "cpu",
+					Result: agentsdk.ProtoFromMetadataResult(codersdk.WorkspaceAgentMetadataResult{
+						CollectedAt: time.Now(),
+						Value:       value,
+					}),
+				},
+			},
+		})
+		require.NoError(t, err)
+		// Samples are ordered by the time they were collected at.
+		time.Sleep(time.Millisecond)
+	}
+
+	workspace, err := client.Workspace(ctx, r.Workspace.ID)
+	require.NoError(t, err)
+	agentID := workspace.LatestBuild.Resources[0].Agents[0].ID
+	samples, err := client.WorkspaceAgentMetadataHistory(ctx, agentID, "cpu", codersdk.WorkspaceAgentMetadataHistoryRequest{})
+	require.NoError(t, err)
+	require.Len(t, samples, 3)
+	require.Equal(t, "10%", samples[0].Value)
+	require.Equal(t, "30%", samples[2].Value)
+
+	// The limit keeps the newest samples.
+	samples, err = client.WorkspaceAgentMetadataHistory(ctx, agentID, "cpu", codersdk.WorkspaceAgentMetadataHistoryRequest{
+		Limit: 2,
+	})
+	require.NoError(t, err)
+	require.Len(t, samples, 2)
+	require.Equal(t, "20%", samples[0].Value)
+	require.Equal(t, "30%", samples[1].Value)
+
+	samples, err = client.WorkspaceAgentMetadataHistory(ctx, agentID, "cpu", codersdk.WorkspaceAgentMetadataHistoryRequest{
+		After: samples[0].CollectedAt,
+	})
+	require.NoError(t, err)
+	require.Len(t, samples, 1)
+	require.Equal(t, "30%", samples[0].Value)
+
+	_, err = client.WorkspaceAgentMetadataHistory(ctx, agentID, "memory", codersdk.WorkspaceAgentMetadataHistoryRequest{})
+	var sdkErr *codersdk.Error
+	require.ErrorAs(t, err, &sdkErr)
+	require.Equal(t, http.StatusNotFound, sdkErr.StatusCode())
+}
+
 type testWAMErrorStore struct {
 	database.Store
 	err atomic.Pointer[error]
@@ -0,0 +1,134 @@
+package coderd
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbauthz"
+	"github.com/coder/coder/v2/coderd/httpapi"
+	"github.com/coder/coder/v2/coderd/httpmw"
+	"github.com/coder/coder/v2/codersdk"
+)
+
+// scriptRunsLimit is the number of runs of a script that are returned.
+const scriptRunsLimit = 100
+
+// @Summary Get runs of workspace agent script
+// @ID get-runs-of-workspace-agent-script
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Agents
+// @Param workspaceagent path string true "Workspace agent ID" format(uuid)
+// @Param script path string true "Script ID" format(uuid)
+// @Success 200 {array} codersdk.WorkspaceAgentScriptRun
+// @Router /workspaceagents/{workspaceagent}/scripts/{script}/runs [get]
+func (api *API) workspaceAgentScriptRuns(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	script, ok := api.workspaceAgentScriptParam(rw, r)
+	if !ok {
+		return
+	}
+
+	// nolint:gocritic // The workspace agent was authorized by the middleware.
+	timings, err := api.Database.GetWorkspaceAgentScriptTimingsByScriptID(dbauthz.AsSystemRestricted(ctx), database.GetWorkspaceAgentScriptTimingsByScriptIDParams{
+		ScriptID: script.ID,
+		Limit:    scriptRunsLimit,
+	})
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching script runs.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	runs := make([]codersdk.WorkspaceAgentScriptRun, 0, len(timings))
+	for _, timing := range timings {
+		runs = append(runs, codersdk.WorkspaceAgentScriptRun{
+			ScriptID:    timing.ScriptID,
+			Stage:       codersdk.WorkspaceAgentScriptRunStage(timing.Stage),
+			Status:      codersdk.WorkspaceAgentScriptRunStatus(timing.Status),
+			ExitCode:    timing.ExitCode,
+			StartedAt:   timing.StartedAt,
+			EndedAt:     timing.EndedAt,
+			LogSourceID: script.LogSourceID,
+			LogPath:     script.LogPath,
+		})
+	}
+	httpapi.Write(ctx, rw, http.StatusOK, runs)
+}
+
+// @Summary Run workspace agent script
+// @Description The script runs in the background. Its run is added to the
+// @Description runs of the script when it exits.
+// @ID run-workspace-agent-script
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Agents
+// @Param workspaceagent path string true "Workspace agent ID" format(uuid)
+// @Param script path string true "Script ID" format(uuid)
+// @Success 202 {object} codersdk.Response
+// @Router /workspaceagents/{workspaceagent}/scripts/{script}/run [post]
+func (api *API) workspaceAgentRunScript(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	script, ok := api.workspaceAgentScriptParam(rw, r)
+	if !ok {
+		return
+	}
+
+	agentConn, release, ok := api.workspaceAgentConnForShell(rw, r)
+	if !ok {
+		return
+	}
+	defer release()
+
+	err := agentConn.RunScript(ctx, script.ID)
+	if sdkErr, ok := codersdk.AsError(err); ok {
+		// The script is unknown to the agent or already running.
+		httpapi.Write(ctx, rw, sdkErr.StatusCode(), sdkErr.Response)
+		return
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error running script.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusAccepted, codersdk.Response{
+		Message: "The script is running.",
+	})
+}
+
+// workspaceAgentScriptParam returns the script of the workspace agent in the
+// URL, writing an error if it doesn't exist.
+func (api *API) workspaceAgentScriptParam(rw http.ResponseWriter, r *http.Request) (database.WorkspaceAgentScript, bool) {
+	var (
+		ctx            = r.Context()
+		workspaceAgent = httpmw.WorkspaceAgentParam(r)
+	)
+	id, ok := httpmw.ParseUUIDParam(rw, r, "script")
+	if !ok {
+		return database.WorkspaceAgentScript{}, false
+	}
+
+	// nolint:gocritic // GetWorkspaceAgentScriptsByAgentIDs is a system function.
+	scripts, err := api.Database.GetWorkspaceAgentScriptsByAgentIDs(dbauthz.AsSystemRestricted(ctx), []uuid.UUID{workspaceAgent.ID})
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching workspace agent scripts.",
+			Detail:  err.Error(),
+		})
+		return database.WorkspaceAgentScript{}, false
+	}
+	for _, script := range scripts {
+		if script.ID == id {
+			return script, true
+		}
+	}
+	httpapi.ResourceNotFound(rw)
+	return database.WorkspaceAgentScript{}, false
+}
@@ -0,0 +1,67 @@
+package coderd_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/agent/agenttest"
+	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbfake"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/provisionersdk/proto"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestWorkspaceAgentRunScript(t *testing.T) {
+	t.Parallel()
+
+	client, db := coderdtest.NewWithDatabase(t, nil)
+	user := coderdtest.CreateFirstUser(t, client)
+	r := dbfake.WorkspaceBuild(t, db, database.Workspace{
+		OrganizationID: user.OrganizationID,
+		OwnerID:        user.UserID,
+	}).WithAgent(func(agents []*proto.Agent) []*proto.Agent {
+		agents[0].Scripts = []*proto.Script{{
+			DisplayName: "Hello",
+			Script:      "echo hello",
+		}}
+		return agents
+	}).Do()
+	_ = agenttest.New(t, client.URL, r.AgentToken)
+	resources := coderdtest.AwaitWorkspaceAgents(t, client, r.Workspace.ID)
+	agent := resources[0].Agents[0]
+	require.Len(t, agent.Scripts, 1)
+	script := agent.Scripts[0]
+
+	ctx := testutil.Context(t, testutil.WaitLong)
+
+	// The script doesn't run on start, so it has no runs yet.
+	runs, err := client.WorkspaceAgentScriptRuns(ctx, agent.ID, script.ID)
+	require.NoError(t, err)
+	require.Empty(t, runs)
+
+	err = client.WorkspaceAgentRunScript(ctx, agent.ID, script.ID)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		runs, err = client.WorkspaceAgentScriptRuns(ctx, agent.ID, script.ID)
+		return assert.NoError(t, err) && len(runs) == 1
+	}, testutil.WaitLong, testutil.IntervalFast)
+	run := runs[0]
+	assert.Equal(t, script.ID, run.ScriptID)
+	assert.Equal(t, codersdk.WorkspaceAgentScriptRunStageManual, run.Stage)
+	assert.Equal(t, codersdk.WorkspaceAgentScriptRunStatusOK, run.Status)
+	assert.EqualValues(t, 0, run.ExitCode)
+	assert.False(t, run.EndedAt.Before(run.StartedAt))
+	assert.Equal(t, script.LogSourceID, run.LogSourceID)
+
+	err = client.WorkspaceAgentRunScript(ctx, agent.ID, uuid.New())
+	sdkErr, ok := codersdk.AsError(err)
+	require.True(t, ok)
+	require.Equal(t, http.StatusNotFound, sdkErr.StatusCode())
+}
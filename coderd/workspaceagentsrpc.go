@@ -158,6 +158,7 @@ func (api *API) workspaceAgentRPC(rw http.ResponseWriter, r *http.Request) {
 		DerpMapUpdateFrequency:    api.Options.DERPMapUpdateFrequency,
 		ExternalAuthConfigs:       api.ExternalAuthConfigs,
 		Experiments:               api.Experiments,
+		RecordMetadataHistory:     api.DeploymentValues.AgentMetadataHistoryRetention.Value() > 0,
 
 		// Optional:
 		WorkspaceID:          build.WorkspaceID, // saves the extra lookup later
@@ -192,6 +192,13 @@ func (p *DBTokenProvider) Issue(ctx context.Context, rw http.ResponseWriter, r *
 		return nil, "", false
 	}
 
+	// Terminals can reconnect to the sessions of other users by their ID,
+	// which needs the share_session permission just like watching them.
+	if appReq.AccessMethod == AccessMethodTerminal && apiKey != nil && authz != nil {
+		token.RequesterID = apiKey.UserID
+		token.CanShareSession = p.Authorizer.Authorize(ctx, *authz, policy.ActionShareSession, dbReq.Workspace.RBACObject()) == nil
+	}
+
 	// Check that the agent is online.
 	agentStatus := dbReq.Agent.Status(p.WorkspaceAgentInactiveTimeout)
 	if agentStatus.Status != database.WorkspaceAgentStatusConnected {
@@ -292,6 +299,15 @@ func (p *DBTokenProvider) authorizeRequest(ctx context.Context, roles *rbac.Subj
 	if dbReq.AccessMethod == AccessMethodTerminal {
 		rbacAction = policy.ActionSSH
 		rbacResourceOwned = rbac.ResourceWorkspace.WithOwner(roles.ID)
+
+		// Watching or taking over a session that may belong to someone else
+		// needs a permission of its own, so roles can grant ssh without it.
+		if dbReq.ShareSession {
+			err := p.Authorizer.Authorize(ctx, *roles, policy.ActionShareSession, rbacResource)
+			if err != nil {
+				return false, warnings, nil
+			}
+		}
 	}
 
 	// Do a standard RBAC check. This accounts for share level "owner" and any
@@ -631,6 +631,7 @@ func (s *Server) workspaceAgentPTY(rw http.ResponseWriter, r *http.Request) {
 			AccessMethod:  AccessMethodTerminal,
 			BasePath:      r.URL.Path,
 			AgentNameOrID: chi.URLParam(r, "workspaceagent"),
+			ShareSession:  r.URL.Query().Get("read_only") == "true" || r.URL.Query().Get("takeover") == "true",
 		},
 		AppPath:  "",
 		AppQuery: "",
@@ -684,7 +685,19 @@ func (s *Server) workspaceAgentPTY(rw http.ResponseWriter, r *http.Request) {
 	}
 	defer release()
 	log.Debug(ctx, "dialed workspace agent")
-	ptNetConn, err := agentConn.ReconnectingPTY(ctx, reconnect, uint16(height), uint16(width), r.URL.Query().Get("command"))
+	initOpts := []workspacesdk.AgentReconnectingPTYInitOption{
+		workspacesdk.AgentReconnectingPTYInitWithUser(appToken.RequesterID, appToken.CanShareSession),
+	}
+	if container := r.URL.Query().Get("container"); container != "" {
+		initOpts = append(initOpts, workspacesdk.AgentReconnectingPTYInitWithContainer(container, r.URL.Query().Get("container_user")))
+	}
+	if r.URL.Query().Get("read_only") == "true" {
+		initOpts = append(initOpts, workspacesdk.AgentReconnectingPTYInitWithReadOnly())
+	}
+	if r.URL.Query().Get("takeover") == "true" {
+		initOpts = append(initOpts, workspacesdk.AgentReconnectingPTYInitWithTakeover())
+	}
+	ptNetConn, err := agentConn.ReconnectingPTY(ctx, reconnect, uint16(height), uint16(width), r.URL.Query().Get("command"), initOpts...)
 	if err != nil {
 		log.Debug(ctx, "dial reconnecting pty server in workspace agent", slog.Error(err))
 		_ = conn.Close(websocket.StatusInternalError, httpapi.WebsocketCloseSprintf("dial: %s", err))
@@ -101,6 +101,11 @@ type Request struct {
 	// AgentNameOrID is not required if the workspace has only one agent.
 	AgentNameOrID string `json:"agent_name_or_id"`
 	AppSlugOrPort string `json:"app_slug_or_port"`
+
+	// ShareSession is only valid for AccessMethodTerminal. It's set when the
+	// terminal watches or takes over an existing session, which requires the
+	// share_session permission on top of ssh.
+	ShareSession bool `json:"share_session"`
 }
 
 // Normalize replaces WorkspaceAndAgent with WorkspaceNameOrID and
@@ -139,6 +144,9 @@ func (r Request) Validate() error {
 	if r.WorkspaceAndAgent != "" {
 		return xerrors.New("dev error: appReq.Validate() called before appReq.Normalize()")
 	}
+	if r.ShareSession && r.AccessMethod != AccessMethodTerminal {
+		return xerrors.New("sessions can only be shared with the terminal access method")
+	}
 
 	if r.AccessMethod == AccessMethodTerminal {
 		if r.UsernameOrID != "" || r.WorkspaceNameOrID != "" || r.AppSlugOrPort != "" {
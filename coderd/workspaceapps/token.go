@@ -34,6 +34,12 @@ type SignedToken struct {
 	WorkspaceID uuid.UUID `json:"workspace_id"`
 	AgentID     uuid.UUID `json:"agent_id"`
 	AppURL      string    `json:"app_url"`
+	// RequesterID is set for terminals to the user the token was issued to,
+	// while UserID is the owner of the workspace. CanShareSession is set if
+	// the requester may attach to the sessions of other users, which the
+	// agent enforces.
+	RequesterID     uuid.UUID `json:"requester_id"`
+	CanShareSession bool      `json:"can_share_session"`
 }
 
 // MatchesRequest returns true if the token matches the request. Any token that
@@ -54,7 +60,8 @@ func (t SignedToken) MatchesRequest(req Request) bool {
 		t.UsernameOrID == req.UsernameOrID &&
 		t.WorkspaceNameOrID == req.WorkspaceNameOrID &&
 		t.AgentNameOrID == req.AgentNameOrID &&
-		t.AppSlugOrPort == req.AppSlugOrPort
+		t.AppSlugOrPort == req.AppSlugOrPort &&
+		t.ShareSession == req.ShareSession
 }
 
 // SecurityKey is used for signing and encrypting app tokens and API keys.
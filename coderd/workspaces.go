@@ -112,6 +112,7 @@ func (api *API) workspace(rw http.ResponseWriter, r *http.Request) {
 		apiKey.UserID,
 		workspace,
 		data.builds[0],
+		data.driftedResources[workspace.ID],
 		data.templates[0],
 		owner.Username,
 		owner.AvatarURL,
@@ -135,7 +136,7 @@ func (api *API) workspace(rw http.ResponseWriter, r *http.Request) {
 // @Security CoderSessionToken
 // @Produce json
 // @Tags Workspaces
-// @Param q query string false "Search query in the format `key:value`. Available keys are: owner, template, name, status, has-agent, dormant, last_used_after, last_used_before."
+// @Param q query string false "Search query in the format `key:value`. Available keys are: owner, template, template_version, name, status, has-agent, agent_status, initiator, build_reason, dormant, last_used_after, last_used_before, created_after, created_before. Terms can be negated with a `-` prefix, and combined with OR inside parentheses."
 // @Param limit query int false "Page limit"
 // @Param offset query int false "Page offset"
 // @Success 200 {object} codersdk.WorkspacesResponse
@@ -163,6 +164,11 @@ func (api *API) workspaces(rw http.ResponseWriter, r *http.Request) {
 		filter.OwnerID = apiKey.UserID
 		filter.OwnerUsername = ""
 	}
+	for i, key := range filter.TermKeys {
+		if (key == "owner" || key == "initiator") && filter.TermValues[i] == "me" {
+			filter.TermValues[i] = httpmw.UserAuthorization(r).FriendlyName
+		}
+	}
 
 	// Workspaces do not have ACL columns.
 	prepared, err := api.HTTPAuth.AuthorizeSQLFilter(r, policy.ActionRead, rbac.ResourceWorkspace.Type)
@@ -319,6 +325,7 @@ func (api *API) workspaceByOwnerAndName(rw http.ResponseWriter, r *http.Request)
 		apiKey.UserID,
 		workspace,
 		data.builds[0],
+		data.driftedResources[workspace.ID],
 		data.templates[0],
 		owner.Username,
 		owner.AvatarURL,
@@ -438,6 +445,137 @@ func (api *API) postUserWorkspaces(rw http.ResponseWriter, r *http.Request) {
 	createWorkspace(ctx, aReq, apiKey.UserID, api, owner, req, rw, r)
 }
 
+// Clone a workspace for the currently authenticated user.
+//
+// @Summary Clone workspace
+// @Description Create a new workspace for the authenticated user from an
+// @Description existing workspace, which may be owned by another user. The
+// @Description clone uses the template version, build parameters, schedule and
+// @Description automatic updates setting of the source workspace.
+// @ID clone-workspace
+// @Security CoderSessionToken
+// @Accept json
+// @Produce json
+// @Tags Workspaces
+// @Param workspace path string true "Workspace ID" format(uuid)
+// @Param request body codersdk.CloneWorkspaceRequest true "Clone workspace request"
+// @Success 201 {object} codersdk.Workspace
+// @Router /workspaces/{workspace}/clone [post]
+func (api *API) postWorkspaceClone(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx     = r.Context()
+		apiKey  = httpmw.APIKey(r)
+		auditor = api.Auditor.Load()
+		source  = httpmw.WorkspaceParam(r)
+	)
+
+	var req codersdk.CloneWorkspaceRequest
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+
+	user, err := api.Database.GetUserByID(ctx, apiKey.UserID)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching user.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	// Anyone who can read the source workspace can see the name of its
+	// owner, so this is only used to describe the source in the audit log.
+	// nolint:gocritic
+	sourceOwner, err := api.Database.GetUserByID(dbauthz.AsSystemRestricted(ctx), source.OwnerID)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching source workspace owner.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	aReq, commitAudit := audit.InitRequest[database.Workspace](rw, &audit.RequestParams{
+		Audit:   *auditor,
+		Log:     api.Logger,
+		Request: r,
+		Action:  database.AuditActionCreate,
+		AdditionalFields: audit.AdditionalFields{
+			WorkspaceOwner:    user.Username,
+			SourceWorkspace:   sourceOwner.Username + "/" + source.Name,
+			SourceWorkspaceID: &source.ID,
+		},
+		OrganizationID: source.OrganizationID,
+	})
+	defer commitAudit()
+
+	build, err := api.Database.GetLatestWorkspaceBuildByWorkspaceID(ctx, source.ID)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching source workspace build.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	buildParameters, err := api.Database.GetWorkspaceBuildParameters(ctx, build.ID)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching source workspace build parameters.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	templateVersionParameters, err := api.Database.GetTemplateVersionParameters(ctx, build.TemplateVersionID)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching template version parameters.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	// Ephemeral parameters only apply to the build they were given for, so
+	// they are left for the caller to provide like on any other create.
+	ephemeral := make(map[string]bool)
+	for _, parameter := range templateVersionParameters {
+		if parameter.Ephemeral {
+			ephemeral[parameter.Name] = true
+		}
+	}
+	overridden := make(map[string]bool)
+	for _, parameter := range req.RichParameterValues {
+		overridden[parameter.Name] = true
+	}
+	richParameterValues := req.RichParameterValues
+	for _, parameter := range buildParameters {
+		if ephemeral[parameter.Name] || overridden[parameter.Name] {
+			continue
+		}
+		richParameterValues = append(richParameterValues, codersdk.WorkspaceBuildParameter{
+			Name:  parameter.Name,
+			Value: parameter.Value,
+		})
+	}
+
+	var autostartSchedule *string
+	if source.AutostartSchedule.Valid {
+		autostartSchedule = &source.AutostartSchedule.String
+	}
+
+	owner := workspaceOwner{
+		ID:        user.ID,
+		Username:  user.Username,
+		AvatarURL: user.AvatarURL,
+	}
+	createWorkspace(ctx, aReq, apiKey.UserID, api, owner, codersdk.CreateWorkspaceRequest{
+		TemplateVersionID:   build.TemplateVersionID,
+		Name:                req.Name,
+		AutostartSchedule:   autostartSchedule,
+		TTLMillis:           convertWorkspaceTTLMillis(source.Ttl),
+		RichParameterValues: richParameterValues,
+		AutomaticUpdates:    codersdk.AutomaticUpdates(source.AutomaticUpdates),
+	}, rw, r)
+}
+
 type workspaceOwner struct {
 	ID        uuid.UUID
 	Username  string
@@ -721,6 +859,8 @@ func createWorkspace(
 		initiatorID,
 		workspace,
 		apiBuild,
+		// A new workspace can't have drifted yet.
+		nil,
 		template,
 		owner.Username,
 		owner.AvatarURL,
@@ -1115,6 +1255,7 @@ func (api *API) putWorkspaceDormant(rw http.ResponseWriter, r *http.Request) {
 		apiKey.UserID,
 		workspace,
 		data.builds[0],
+		data.driftedResources[workspace.ID],
 		data.templates[0],
 		owner.Username,
 		owner.AvatarURL,
@@ -1340,7 +1481,7 @@ func (api *API) postWorkspaceUsage(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = api.statsReporter.ReportAgentStats(ctx, dbtime.Now(), workspace, agent, template.Name, stat, true)
+	err = api.statsReporter.ReportAgentStats(ctx, dbtime.Now(), workspace, agent, template.Name, template.AgentActivityBump, stat, true)
 	if err != nil {
 		httpapi.InternalServerError(rw, err)
 		return
@@ -1674,6 +1815,7 @@ func (api *API) watchWorkspace(rw http.ResponseWriter, r *http.Request) {
 			apiKey.UserID,
 			workspace,
 			data.builds[0],
+			data.driftedResources[workspace.ID],
 			data.templates[0],
 			owner.Username,
 			owner.AvatarURL,
@@ -1794,6 +1936,9 @@ type workspaceData struct {
 	builds       []codersdk.WorkspaceBuild
 	users        []database.User
 	allowRenames bool
+	// driftedResources are the resources of each workspace that drifted from
+	// the state of its latest build, by workspace ID.
+	driftedResources map[uuid.UUID][]codersdk.ResourceChange
 }
 
 // workspacesData only returns the data the caller can access. If the caller
@@ -1844,11 +1989,34 @@ func (api *API) workspaceData(ctx context.Context, workspaces []database.Workspa
 		return workspaceData{}, xerrors.Errorf("convert workspace builds: %w", err)
 	}
 
+	// The caller can read the workspaces, so it can read what drifted in them.
+	// nolint:gocritic
+	drifted, err := api.Database.GetWorkspaceDriftedResourcesByWorkspaceIDs(dbauthz.AsSystemRestricted(ctx), workspaceIDs)
+	if err != nil {
+		return workspaceData{}, xerrors.Errorf("get workspace drifted resources: %w", err)
+	}
+	driftedResources := map[uuid.UUID][]codersdk.ResourceChange{}
+	for _, row := range drifted {
+		change, err := convertResourceChange(database.ProvisionerJobResourceChange{
+			JobID:      row.JobID,
+			Address:    row.Address,
+			Type:       row.Type,
+			Name:       row.Name,
+			Action:     row.Action,
+			Attributes: row.Attributes,
+		})
+		if err != nil {
+			return workspaceData{}, xerrors.Errorf("convert drifted resource: %w", err)
+		}
+		driftedResources[row.WorkspaceID] = append(driftedResources[row.WorkspaceID], change)
+	}
+
 	return workspaceData{
-		templates:    templates,
-		builds:       apiBuilds,
-		users:        data.users,
-		allowRenames: api.Options.AllowWorkspaceRenames,
+		templates:        templates,
+		builds:           apiBuilds,
+		users:            data.users,
+		allowRenames:     api.Options.AllowWorkspaceRenames,
+		driftedResources: driftedResources,
 	}, nil
 }
 
@@ -1890,6 +2058,7 @@ func convertWorkspaces(requesterID uuid.UUID, workspaces []database.Workspace, d
 			requesterID,
 			workspace,
 			build,
+			data.driftedResources[workspace.ID],
 			template,
 			owner.Username,
 			owner.AvatarURL,
@@ -1908,6 +2077,7 @@ func convertWorkspace(
 	requesterID uuid.UUID,
 	workspace database.Workspace,
 	workspaceBuild codersdk.WorkspaceBuild,
+	driftedResources []codersdk.ResourceChange,
 	template database.Template,
 	username string,
 	avatarURL string,
@@ -1940,6 +2110,10 @@ func convertWorkspace(
 		}
 	}
 
+	if driftedResources == nil {
+		driftedResources = []codersdk.ResourceChange{}
+	}
+
 	ttlMillis := convertWorkspaceTTLMillis(workspace.Ttl)
 	// If the template doesn't allow a workspace-configured value, then report the
 	// template value instead.
@@ -1975,8 +2149,9 @@ func convertWorkspace(
 		DeletingAt:                           deletingAt,
 		DormantAt:                            dormantAt,
 		Health: codersdk.WorkspaceHealth{
-			Healthy:       len(failingAgents) == 0,
-			FailingAgents: failingAgents,
+			Healthy:          len(failingAgents) == 0 && len(driftedResources) == 0,
+			FailingAgents:    failingAgents,
+			DriftedResources: driftedResources,
 		},
 		AutomaticUpdates: codersdk.AutomaticUpdates(workspace.AutomaticUpdates),
 		AllowRenames:     allowRenames,
@@ -1449,6 +1449,74 @@ func TestWorkspaceFilterManual(t *testing.T) {
 		require.Equal(t, workspace.ID, res.Workspaces[0].ID)
 		require.Equal(t, workspace.OrganizationName, org.Name)
 	})
+	t.Run("FilterQueryNegationAndOr", func(t *testing.T) {
+		t.Parallel()
+		client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+		user := coderdtest.CreateFirstUser(t, client)
+		memberClient, member := coderdtest.CreateAnotherUser(t, client, user.OrganizationID)
+		version := coderdtest.CreateTemplateVersion(t, client, user.OrganizationID, nil)
+		version2 := coderdtest.CreateTemplateVersion(t, client, user.OrganizationID, nil)
+		coderdtest.AwaitTemplateVersionJobCompleted(t, client, version.ID)
+		coderdtest.AwaitTemplateVersionJobCompleted(t, client, version2.ID)
+		template := coderdtest.CreateTemplate(t, client, user.OrganizationID, version.ID)
+		template2 := coderdtest.CreateTemplate(t, client, user.OrganizationID, version2.ID)
+		workspace := coderdtest.CreateWorkspace(t, client, template.ID)
+		workspace2 := coderdtest.CreateWorkspace(t, client, template2.ID)
+		memberWorkspace := coderdtest.CreateWorkspace(t, memberClient, template.ID)
+
+		ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+		defer cancel()
+
+		for _, tc := range []struct {
+			query string
+			exp   []codersdk.Workspace
+		}{
+			{query: fmt.Sprintf("-template:%s", template.Name), exp: []codersdk.Workspace{workspace2}},
+			{query: fmt.Sprintf("(template:%s OR owner:%s)", template2.Name, member.Username), exp: []codersdk.Workspace{workspace2, memberWorkspace}},
+			{query: fmt.Sprintf("template_version:%s", version2.Name), exp: []codersdk.Workspace{workspace2}},
+			{query: "-owner:me", exp: []codersdk.Workspace{memberWorkspace}},
+			{query: "initiator:me", exp: []codersdk.Workspace{workspace, workspace2}},
+			{query: fmt.Sprintf("-(template:%s OR initiator:%s)", template2.Name, member.Username), exp: []codersdk.Workspace{workspace}},
+			{query: "build_reason:initiator", exp: []codersdk.Workspace{workspace, workspace2, memberWorkspace}},
+			{query: "-build_reason:initiator", exp: []codersdk.Workspace{}},
+		} {
+			res, err := client.Workspaces(ctx, codersdk.WorkspaceFilter{FilterQuery: tc.query})
+			require.NoError(t, err, tc.query)
+			expectIDs(t, tc.exp, res.Workspaces)
+		}
+	})
+	t.Run("FilterQueryTemplateVersionComparison", func(t *testing.T) {
+		t.Parallel()
+		client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+		user := coderdtest.CreateFirstUser(t, client)
+		version1 := coderdtest.CreateTemplateVersion(t, client, user.OrganizationID, nil)
+		coderdtest.AwaitTemplateVersionJobCompleted(t, client, version1.ID)
+		template := coderdtest.CreateTemplate(t, client, user.OrganizationID, version1.ID)
+		oldWorkspace := coderdtest.CreateWorkspace(t, client, template.ID)
+		version2 := coderdtest.UpdateTemplateVersion(t, client, user.OrganizationID, nil, template.ID)
+		coderdtest.AwaitTemplateVersionJobCompleted(t, client, version2.ID)
+		coderdtest.UpdateActiveTemplateVersion(t, client, template.ID, version2.ID)
+		newWorkspace := coderdtest.CreateWorkspace(t, client, template.ID)
+
+		ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+		defer cancel()
+
+		for _, tc := range []struct {
+			query string
+			exp   []codersdk.Workspace
+		}{
+			{query: fmt.Sprintf("template_version:<%s", version2.Name), exp: []codersdk.Workspace{oldWorkspace}},
+			{query: fmt.Sprintf("template_version:<=%s", version1.Name), exp: []codersdk.Workspace{oldWorkspace}},
+			{query: fmt.Sprintf("template_version:>%s", version1.Name), exp: []codersdk.Workspace{newWorkspace}},
+			{query: fmt.Sprintf("template_version:>=%s", version1.Name), exp: []codersdk.Workspace{oldWorkspace, newWorkspace}},
+			{query: fmt.Sprintf("-template_version:<%s", version2.Name), exp: []codersdk.Workspace{newWorkspace}},
+			{query: "template_version:<unknown", exp: []codersdk.Workspace{}},
+		} {
+			res, err := client.Workspaces(ctx, codersdk.WorkspaceFilter{FilterQuery: tc.query})
+			require.NoError(t, err, tc.query)
+			expectIDs(t, tc.exp, res.Workspaces)
+		}
+	})
 	t.Run("FilterQueryHasAgentConnecting", func(t *testing.T) {
 		t.Parallel()
 
@@ -3722,3 +3790,147 @@ func TestWorkspaceTimings(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkspaceClone(t *testing.T) {
+	t.Parallel()
+
+	const (
+		parameterName          = "region"
+		ephemeralParameterName = "debug"
+	)
+	richParameters := &echo.Responses{
+		Parse: echo.ParseComplete,
+		ProvisionPlan: []*proto.Response{{
+			Type: &proto.Response_Plan{
+				Plan: &proto.PlanComplete{
+					Parameters: []*proto.RichParameter{
+						{Name: parameterName, Type: "string", DefaultValue: "eu", Mutable: true},
+						{Name: ephemeralParameterName, Type: "bool", DefaultValue: "false", Mutable: true, Ephemeral: true},
+					},
+				},
+			},
+		}},
+		ProvisionApply: echo.ApplyComplete,
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		t.Parallel()
+		auditor := audit.NewMock()
+		client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true, Auditor: auditor})
+		owner := coderdtest.CreateFirstUser(t, client)
+		member, _ := coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+		version := coderdtest.CreateTemplateVersion(t, client, owner.OrganizationID, richParameters)
+		coderdtest.AwaitTemplateVersionJobCompleted(t, client, version.ID)
+		template := coderdtest.CreateTemplate(t, client, owner.OrganizationID, version.ID)
+
+		source := coderdtest.CreateWorkspace(t, member, template.ID, func(cwr *codersdk.CreateWorkspaceRequest) {
+			cwr.AutostartSchedule = ptr.Ref("CRON_TZ=Europe/Dublin 30 9 * * 1-5")
+			cwr.TTLMillis = ptr.Ref((2 * time.Hour).Milliseconds())
+			cwr.AutomaticUpdates = codersdk.AutomaticUpdatesAlways
+			cwr.RichParameterValues = []codersdk.WorkspaceBuildParameter{
+				{Name: parameterName, Value: "us"},
+				{Name: ephemeralParameterName, Value: "true"},
+			}
+		})
+		coderdtest.AwaitWorkspaceBuildJobCompleted(t, member, source.LatestBuild.ID)
+
+		// The clone keeps the version of the source even once it is no
+		// longer active.
+		newVersion := coderdtest.UpdateTemplateVersion(t, client, owner.OrganizationID, richParameters, template.ID)
+		coderdtest.AwaitTemplateVersionJobCompleted(t, client, newVersion.ID)
+		ctx := testutil.Context(t, testutil.WaitLong)
+		err := client.UpdateActiveTemplateVersion(ctx, template.ID, codersdk.UpdateActiveTemplateVersion{ID: newVersion.ID})
+		require.NoError(t, err)
+
+		clone, err := client.CloneWorkspace(ctx, source.ID, codersdk.CloneWorkspaceRequest{Name: "clone"})
+		require.NoError(t, err)
+		require.Equal(t, owner.UserID, clone.OwnerID)
+		require.Equal(t, source.AutostartSchedule, clone.AutostartSchedule)
+		require.Equal(t, source.TTLMillis, clone.TTLMillis)
+		require.Equal(t, codersdk.AutomaticUpdatesAlways, clone.AutomaticUpdates)
+		build := coderdtest.AwaitWorkspaceBuildJobCompleted(t, client, clone.LatestBuild.ID)
+		require.Equal(t, version.ID, build.TemplateVersionID)
+
+		parameters, err := client.WorkspaceBuildParameters(ctx, build.ID)
+		require.NoError(t, err)
+		// Ephemeral parameters fall back to their defaults.
+		require.ElementsMatch(t, []codersdk.WorkspaceBuildParameter{
+			{Name: parameterName, Value: "us"},
+			{Name: ephemeralParameterName, Value: "false"},
+		}, parameters)
+
+		var found bool
+		for _, log := range auditor.AuditLogs() {
+			if log.ResourceID != clone.ID || log.Action != database.AuditActionCreate {
+				continue
+			}
+			var fields audit.AdditionalFields
+			require.NoError(t, json.Unmarshal(log.AdditionalFields, &fields))
+			require.Equal(t, source.ID, *fields.SourceWorkspaceID)
+			require.Equal(t, source.FullName(), fields.SourceWorkspace)
+			found = true
+		}
+		require.True(t, found, "clone audit log not found")
+	})
+
+	t.Run("OverrideParameters", func(t *testing.T) {
+		t.Parallel()
+		client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+		owner := coderdtest.CreateFirstUser(t, client)
+		version := coderdtest.CreateTemplateVersion(t, client, owner.OrganizationID, richParameters)
+		coderdtest.AwaitTemplateVersionJobCompleted(t, client, version.ID)
+		template := coderdtest.CreateTemplate(t, client, owner.OrganizationID, version.ID)
+		source := coderdtest.CreateWorkspace(t, client, template.ID)
+		coderdtest.AwaitWorkspaceBuildJobCompleted(t, client, source.LatestBuild.ID)
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		clone, err := client.CloneWorkspace(ctx, source.ID, codersdk.CloneWorkspaceRequest{
+			Name: "clone",
+			RichParameterValues: []codersdk.WorkspaceBuildParameter{
+				{Name: parameterName, Value: "ap"},
+				{Name: ephemeralParameterName, Value: "true"},
+			},
+		})
+		require.NoError(t, err)
+		build := coderdtest.AwaitWorkspaceBuildJobCompleted(t, client, clone.LatestBuild.ID)
+		parameters, err := client.WorkspaceBuildParameters(ctx, build.ID)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []codersdk.WorkspaceBuildParameter{
+			{Name: parameterName, Value: "ap"},
+			{Name: ephemeralParameterName, Value: "true"},
+		}, parameters)
+	})
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		t.Parallel()
+		client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+		owner := coderdtest.CreateFirstUser(t, client)
+		member, _ := coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+		version := coderdtest.CreateTemplateVersion(t, client, owner.OrganizationID, nil)
+		coderdtest.AwaitTemplateVersionJobCompleted(t, client, version.ID)
+		template := coderdtest.CreateTemplate(t, client, owner.OrganizationID, version.ID)
+		source := coderdtest.CreateWorkspace(t, client, template.ID)
+		coderdtest.AwaitWorkspaceBuildJobCompleted(t, client, source.LatestBuild.ID)
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		_, err := member.CloneWorkspace(ctx, source.ID, codersdk.CloneWorkspaceRequest{Name: "clone"})
+		require.Error(t, err)
+		require.Equal(t, http.StatusNotFound, coderdtest.SDKError(t, err).StatusCode())
+	})
+
+	t.Run("NameTaken", func(t *testing.T) {
+		t.Parallel()
+		client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+		owner := coderdtest.CreateFirstUser(t, client)
+		version := coderdtest.CreateTemplateVersion(t, client, owner.OrganizationID, nil)
+		coderdtest.AwaitTemplateVersionJobCompleted(t, client, version.ID)
+		template := coderdtest.CreateTemplate(t, client, owner.OrganizationID, version.ID)
+		source := coderdtest.CreateWorkspace(t, client, template.ID)
+		coderdtest.AwaitWorkspaceBuildJobCompleted(t, client, source.LatestBuild.ID)
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		_, err := client.CloneWorkspace(ctx, source.ID, codersdk.CloneWorkspaceRequest{Name: source.Name})
+		require.Error(t, err)
+		require.Equal(t, http.StatusConflict, coderdtest.SDKError(t, err).StatusCode())
+	})
+}
@@ -0,0 +1,624 @@
+package coderd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/sqlc-dev/pqtype"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
+
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
+	"github.com/coder/coder/v2/coderd/database/provisionerjobs"
+	"github.com/coder/coder/v2/coderd/httpapi"
+	"github.com/coder/coder/v2/coderd/httpmw"
+	"github.com/coder/coder/v2/coderd/provisionerdserver"
+	"github.com/coder/coder/v2/coderd/rbac/policy"
+	"github.com/coder/coder/v2/coderd/tracing"
+	"github.com/coder/coder/v2/codersdk"
+)
+
+// @Summary Get workspace snapshots
+// @ID get-workspace-snapshots
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Workspaces
+// @Param workspace path string true "Workspace ID" format(uuid)
+// @Success 200 {array} codersdk.WorkspaceSnapshot
+// @Router /workspaces/{workspace}/snapshots [get]
+func (api *API) workspaceSnapshots(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx       = r.Context()
+		workspace = httpmw.WorkspaceParam(r)
+	)
+
+	snapshots, err := api.Database.GetWorkspaceSnapshotsByWorkspaceID(ctx, workspace.ID)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching workspace snapshots.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	jobIDs := make([]uuid.UUID, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		jobIDs = append(jobIDs, snapshot.JobID)
+	}
+	jobs, err := api.Database.GetProvisionerJobsByIDsWithQueuePosition(ctx, jobIDs)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching provisioner jobs.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	jobByID := make(map[uuid.UUID]database.GetProvisionerJobsByIDsWithQueuePositionRow, len(jobs))
+	for _, job := range jobs {
+		jobByID[job.ProvisionerJob.ID] = job
+	}
+
+	out := make([]codersdk.WorkspaceSnapshot, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		job, ok := jobByID[snapshot.JobID]
+		if !ok {
+			httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+				Message: fmt.Sprintf("Job %q for snapshot %q not found.", snapshot.JobID, snapshot.Name),
+			})
+			return
+		}
+		out = append(out, convertWorkspaceSnapshot(snapshot, job))
+	}
+	httpapi.Write(ctx, rw, http.StatusOK, out)
+}
+
+// @Summary Get workspace snapshot
+// @ID get-workspace-snapshot
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Workspaces
+// @Param workspace path string true "Workspace ID" format(uuid)
+// @Param workspacesnapshot path string true "Workspace snapshot ID" format(uuid)
+// @Success 200 {object} codersdk.WorkspaceSnapshot
+// @Router /workspaces/{workspace}/snapshots/{workspacesnapshot} [get]
+func (api *API) workspaceSnapshot(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	snapshot, ok := api.workspaceSnapshotParam(rw, r)
+	if !ok {
+		return
+	}
+	job, ok := api.fetchWorkspaceSnapshotJob(rw, r, snapshot.JobID)
+	if !ok {
+		return
+	}
+	httpapi.Write(ctx, rw, http.StatusOK, convertWorkspaceSnapshot(snapshot, job))
+}
+
+// @Summary Create workspace snapshot
+// @ID create-workspace-snapshot
+// @Security CoderSessionToken
+// @Accept json
+// @Produce json
+// @Tags Workspaces
+// @Param workspace path string true "Workspace ID" format(uuid)
+// @Param request body codersdk.CreateWorkspaceSnapshotRequest true "Create workspace snapshot request"
+// @Success 201 {object} codersdk.WorkspaceSnapshot
+// @Router /workspaces/{workspace}/snapshots [post]
+func (api *API) postWorkspaceSnapshot(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx       = r.Context()
+		apiKey    = httpmw.APIKey(r)
+		workspace = httpmw.WorkspaceParam(r)
+	)
+
+	if !api.Authorize(r, policy.ActionUpdate, workspace) {
+		httpapi.ResourceNotFound(rw)
+		return
+	}
+
+	var req codersdk.CreateWorkspaceSnapshotRequest
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+
+	build, buildJob, ok := api.latestWorkspaceBuildJob(rw, r)
+	if !ok {
+		return
+	}
+	if buildJob.JobStatus != database.ProvisionerJobStatusSucceeded {
+		httpapi.Write(ctx, rw, http.StatusConflict, codersdk.Response{
+			Message: fmt.Sprintf("The latest build of the workspace is %s.", buildJob.JobStatus),
+			Detail:  "Snapshots can only be taken of workspaces whose latest build succeeded.",
+		})
+		return
+	}
+
+	snapshotID := uuid.New()
+	var snapshot database.WorkspaceSnapshot
+	var job database.ProvisionerJob
+	err := api.Database.InTx(func(tx database.Store) error {
+		err := checkWorkspaceIdle(ctx, tx, workspace.ID, build.ID)
+		if err != nil {
+			return err
+		}
+		// Check the name before queueing the job, so a taken name doesn't
+		// leave a job behind in stores that can't roll back.
+		existing, err := tx.GetWorkspaceSnapshotsByWorkspaceID(ctx, workspace.ID)
+		if err != nil {
+			return xerrors.Errorf("get workspace snapshots: %w", err)
+		}
+		for _, other := range existing {
+			if other.Name == req.Name {
+				return errSnapshotNameTaken
+			}
+		}
+		job, err = insertWorkspaceSnapshotJob(ctx, tx, buildJob, apiKey.UserID, provisionerdserver.WorkspaceSnapshotJob{
+			WorkspaceSnapshotID: snapshotID,
+			WorkspaceBuildID:    build.ID,
+			LogLevel:            string(req.LogLevel),
+		})
+		if err != nil {
+			return err
+		}
+		snapshot, err = tx.InsertWorkspaceSnapshot(ctx, database.InsertWorkspaceSnapshotParams{
+			ID:               snapshotID,
+			WorkspaceID:      workspace.ID,
+			WorkspaceBuildID: build.ID,
+			JobID:            job.ID,
+			Name:             req.Name,
+			CreatedBy:        apiKey.UserID,
+			CreatedAt:        dbtime.Now(),
+		})
+		if err != nil {
+			return xerrors.Errorf("insert workspace snapshot: %w", err)
+		}
+		return nil
+	}, nil)
+	if xerrors.Is(err, errWorkspaceBusy) {
+		httpapi.Write(ctx, rw, http.StatusConflict, codersdk.Response{
+			Message: "The workspace has an active build or snapshot job.",
+		})
+		return
+	}
+	if xerrors.Is(err, errSnapshotNameTaken) || database.IsUniqueViolation(err, database.UniqueWorkspaceSnapshotsWorkspaceIDNameKey) {
+		httpapi.Write(ctx, rw, http.StatusConflict, codersdk.Response{
+			Message: fmt.Sprintf("A snapshot named %q already exists.", req.Name),
+			Validations: []codersdk.ValidationError{{
+				Field:  "name",
+				Detail: "This value is already in use and should be unique.",
+			}},
+		})
+		return
+	}
+	if httpapi.IsUnauthorizedError(err) {
+		httpapi.Forbidden(rw)
+		return
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error creating workspace snapshot.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	err = provisionerjobs.PostJob(api.Pubsub, job)
+	if err != nil {
+		// Client probably doesn't care about this error, so just log it.
+		api.Logger.Error(ctx, "failed to post provisioner job to pubsub", slog.Error(err))
+	}
+
+	httpapi.Write(ctx, rw, http.StatusCreated, convertWorkspaceSnapshot(snapshot, database.GetProvisionerJobsByIDsWithQueuePositionRow{
+		ProvisionerJob: job,
+		QueuePosition:  0,
+	}))
+}
+
+// @Summary Delete workspace snapshot
+// @ID delete-workspace-snapshot
+// @Security CoderSessionToken
+// @Tags Workspaces
+// @Param workspace path string true "Workspace ID" format(uuid)
+// @Param workspacesnapshot path string true "Workspace snapshot ID" format(uuid)
+// @Success 204
+// @Router /workspaces/{workspace}/snapshots/{workspacesnapshot} [delete]
+func (api *API) deleteWorkspaceSnapshot(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	snapshot, ok := api.workspaceSnapshotParam(rw, r)
+	if !ok {
+		return
+	}
+	job, ok := api.fetchWorkspaceSnapshotJob(rw, r, snapshot.JobID)
+	if !ok {
+		return
+	}
+	if !job.ProvisionerJob.CompletedAt.Valid {
+		httpapi.Write(ctx, rw, http.StatusConflict, codersdk.Response{
+			Message: "The snapshot is still being taken.",
+		})
+		return
+	}
+
+	err := api.Database.DeleteWorkspaceSnapshotByID(ctx, snapshot.ID)
+	if httpapi.IsUnauthorizedError(err) {
+		httpapi.Forbidden(rw)
+		return
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error deleting workspace snapshot.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary Get workspace snapshot logs
+// @ID get-workspace-snapshot-logs
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Workspaces
+// @Param workspace path string true "Workspace ID" format(uuid)
+// @Param workspacesnapshot path string true "Workspace snapshot ID" format(uuid)
+// @Param before query int false "Before log id"
+// @Param after query int false "After log id"
+// @Param follow query bool false "Follow log stream"
+// @Success 200 {array} codersdk.ProvisionerJobLog
+// @Router /workspaces/{workspace}/snapshots/{workspacesnapshot}/logs [get]
+func (api *API) workspaceSnapshotLogs(rw http.ResponseWriter, r *http.Request) {
+	snapshot, ok := api.workspaceSnapshotParam(rw, r)
+	if !ok {
+		return
+	}
+	job, ok := api.fetchWorkspaceSnapshotJob(rw, r, snapshot.JobID)
+	if !ok {
+		return
+	}
+
+	api.provisionerJobLogs(rw, r, job.ProvisionerJob)
+}
+
+// @Summary Restore workspace snapshot
+// @ID restore-workspace-snapshot
+// @Security CoderSessionToken
+// @Accept json
+// @Produce json
+// @Tags Workspaces
+// @Param workspace path string true "Workspace ID" format(uuid)
+// @Param workspacesnapshot path string true "Workspace snapshot ID" format(uuid)
+// @Param request body codersdk.RestoreWorkspaceSnapshotRequest true "Restore workspace snapshot request"
+// @Success 201 {object} codersdk.ProvisionerJob
+// @Router /workspaces/{workspace}/snapshots/{workspacesnapshot}/restore [post]
+func (api *API) postWorkspaceSnapshotRestore(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx       = r.Context()
+		apiKey    = httpmw.APIKey(r)
+		workspace = httpmw.WorkspaceParam(r)
+	)
+
+	if !api.Authorize(r, policy.ActionUpdate, workspace) {
+		httpapi.ResourceNotFound(rw)
+		return
+	}
+
+	var req codersdk.RestoreWorkspaceSnapshotRequest
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+
+	snapshot, ok := api.workspaceSnapshotParam(rw, r)
+	if !ok {
+		return
+	}
+	snapshotJob, ok := api.fetchWorkspaceSnapshotJob(rw, r, snapshot.JobID)
+	if !ok {
+		return
+	}
+	if snapshotJob.ProvisionerJob.JobStatus != database.ProvisionerJobStatusSucceeded {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: fmt.Sprintf("The snapshot is %s.", snapshotJob.ProvisionerJob.JobStatus),
+			Detail:  "Only snapshots which were taken successfully can be restored.",
+		})
+		return
+	}
+
+	build, buildJob, ok := api.latestWorkspaceBuildJob(rw, r)
+	if !ok {
+		return
+	}
+	if build.Transition != database.WorkspaceTransitionStop || buildJob.JobStatus != database.ProvisionerJobStatusSucceeded {
+		httpapi.Write(ctx, rw, http.StatusConflict, codersdk.Response{
+			Message: "The workspace must be stopped to restore a snapshot.",
+		})
+		return
+	}
+
+	var job database.ProvisionerJob
+	err := api.Database.InTx(func(tx database.Store) error {
+		err := checkWorkspaceIdle(ctx, tx, workspace.ID, build.ID)
+		if err != nil {
+			return err
+		}
+		job, err = insertWorkspaceSnapshotJob(ctx, tx, buildJob, apiKey.UserID, provisionerdserver.WorkspaceSnapshotJob{
+			WorkspaceSnapshotID: snapshot.ID,
+			WorkspaceBuildID:    build.ID,
+			Restore:             true,
+			LogLevel:            string(req.LogLevel),
+		})
+		return err
+	}, nil)
+	if xerrors.Is(err, errWorkspaceBusy) {
+		httpapi.Write(ctx, rw, http.StatusConflict, codersdk.Response{
+			Message: "The workspace has an active build or snapshot job.",
+		})
+		return
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error restoring workspace snapshot.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	err = provisionerjobs.PostJob(api.Pubsub, job)
+	if err != nil {
+		// Client probably doesn't care about this error, so just log it.
+		api.Logger.Error(ctx, "failed to post provisioner job to pubsub", slog.Error(err))
+	}
+
+	httpapi.Write(ctx, rw, http.StatusCreated, convertProvisionerJob(database.GetProvisionerJobsByIDsWithQueuePositionRow{
+		ProvisionerJob: job,
+		QueuePosition:  0,
+	}))
+}
+
+// @Summary Get workspace snapshot restore by job ID
+// @ID get-workspace-snapshot-restore-by-job-id
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Workspaces
+// @Param workspace path string true "Workspace ID" format(uuid)
+// @Param workspacesnapshot path string true "Workspace snapshot ID" format(uuid)
+// @Param jobID path string true "Job ID" format(uuid)
+// @Success 200 {object} codersdk.ProvisionerJob
+// @Router /workspaces/{workspace}/snapshots/{workspacesnapshot}/restore/{jobID} [get]
+func (api *API) workspaceSnapshotRestore(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	job, ok := api.fetchWorkspaceSnapshotRestoreJob(rw, r)
+	if !ok {
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, convertProvisionerJob(job))
+}
+
+// @Summary Get workspace snapshot restore logs by job ID
+// @ID get-workspace-snapshot-restore-logs-by-job-id
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Workspaces
+// @Param workspace path string true "Workspace ID" format(uuid)
+// @Param workspacesnapshot path string true "Workspace snapshot ID" format(uuid)
+// @Param jobID path string true "Job ID" format(uuid)
+// @Param before query int false "Before log id"
+// @Param after query int false "After log id"
+// @Param follow query bool false "Follow log stream"
+// @Success 200 {array} codersdk.ProvisionerJobLog
+// @Router /workspaces/{workspace}/snapshots/{workspacesnapshot}/restore/{jobID}/logs [get]
+func (api *API) workspaceSnapshotRestoreLogs(rw http.ResponseWriter, r *http.Request) {
+	job, ok := api.fetchWorkspaceSnapshotRestoreJob(rw, r)
+	if !ok {
+		return
+	}
+
+	api.provisionerJobLogs(rw, r, job.ProvisionerJob)
+}
+
+// workspaceSnapshotParam fetches the snapshot in the URL, which must belong
+// to the workspace in the URL.
+func (api *API) workspaceSnapshotParam(rw http.ResponseWriter, r *http.Request) (database.WorkspaceSnapshot, bool) {
+	var (
+		ctx       = r.Context()
+		workspace = httpmw.WorkspaceParam(r)
+	)
+
+	id, ok := httpmw.ParseUUIDParam(rw, r, "workspacesnapshot")
+	if !ok {
+		return database.WorkspaceSnapshot{}, false
+	}
+
+	snapshot, err := api.Database.GetWorkspaceSnapshotByID(ctx, id)
+	if httpapi.Is404Error(err) || (err == nil && snapshot.WorkspaceID != workspace.ID) {
+		httpapi.ResourceNotFound(rw)
+		return database.WorkspaceSnapshot{}, false
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching workspace snapshot.",
+			Detail:  err.Error(),
+		})
+		return database.WorkspaceSnapshot{}, false
+	}
+	return snapshot, true
+}
+
+func (api *API) fetchWorkspaceSnapshotJob(rw http.ResponseWriter, r *http.Request, jobID uuid.UUID) (database.GetProvisionerJobsByIDsWithQueuePositionRow, bool) {
+	ctx := r.Context()
+	jobs, err := api.Database.GetProvisionerJobsByIDsWithQueuePosition(ctx, []uuid.UUID{jobID})
+	if err == nil && len(jobs) == 0 {
+		err = xerrors.Errorf("provisioner job %q not found", jobID)
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching provisioner job.",
+			Detail:  err.Error(),
+		})
+		return database.GetProvisionerJobsByIDsWithQueuePositionRow{}, false
+	}
+	return jobs[0], true
+}
+
+func (api *API) fetchWorkspaceSnapshotRestoreJob(rw http.ResponseWriter, r *http.Request) (database.GetProvisionerJobsByIDsWithQueuePositionRow, bool) {
+	var (
+		ctx   = r.Context()
+		jobID = chi.URLParam(r, "jobID")
+	)
+
+	snapshot, ok := api.workspaceSnapshotParam(rw, r)
+	if !ok {
+		return database.GetProvisionerJobsByIDsWithQueuePositionRow{}, false
+	}
+
+	jobUUID, err := uuid.Parse(jobID)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: fmt.Sprintf("Job ID %q must be a valid UUID.", jobID),
+			Detail:  err.Error(),
+		})
+		return database.GetProvisionerJobsByIDsWithQueuePositionRow{}, false
+	}
+	job, ok := api.fetchWorkspaceSnapshotJob(rw, r, jobUUID)
+	if !ok {
+		return database.GetProvisionerJobsByIDsWithQueuePositionRow{}, false
+	}
+	if job.ProvisionerJob.Type != database.ProvisionerJobTypeWorkspaceSnapshot {
+		httpapi.ResourceNotFound(rw)
+		return database.GetProvisionerJobsByIDsWithQueuePositionRow{}, false
+	}
+
+	// Verify that the job restores the snapshot used in the request.
+	var input provisionerdserver.WorkspaceSnapshotJob
+	err = json.Unmarshal(job.ProvisionerJob.Input, &input)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error unmarshalling job metadata.",
+			Detail:  err.Error(),
+		})
+		return database.GetProvisionerJobsByIDsWithQueuePositionRow{}, false
+	}
+	if !input.Restore || input.WorkspaceSnapshotID != snapshot.ID {
+		httpapi.ResourceNotFound(rw)
+		return database.GetProvisionerJobsByIDsWithQueuePositionRow{}, false
+	}
+
+	return job, true
+}
+
+// latestWorkspaceBuildJob returns the latest build of the workspace in the
+// URL, along with its job.
+func (api *API) latestWorkspaceBuildJob(rw http.ResponseWriter, r *http.Request) (database.WorkspaceBuild, database.ProvisionerJob, bool) {
+	var (
+		ctx       = r.Context()
+		workspace = httpmw.WorkspaceParam(r)
+	)
+
+	build, err := api.Database.GetLatestWorkspaceBuildByWorkspaceID(ctx, workspace.ID)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching latest workspace build.",
+			Detail:  err.Error(),
+		})
+		return database.WorkspaceBuild{}, database.ProvisionerJob{}, false
+	}
+	job, err := api.Database.GetProvisionerJobByID(ctx, build.JobID)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching provisioner job.",
+			Detail:  err.Error(),
+		})
+		return database.WorkspaceBuild{}, database.ProvisionerJob{}, false
+	}
+	return build, job, true
+}
+
+// errSnapshotNameTaken is returned if the workspace already has a snapshot
+// with the requested name.
+var errSnapshotNameTaken = xerrors.New("the snapshot name is taken")
+
+// errWorkspaceBusy is returned by checkWorkspaceIdle if a job that uses the
+// state of the workspace is queued or running.
+var errWorkspaceBusy = xerrors.New("the workspace has an active job")
+
+// checkWorkspaceIdle locks the workspace, and returns errWorkspaceBusy if a
+// build was queued after the given one, or a snapshot of the workspace is
+// being created or restored. Builds lock the workspace too, so none can be
+// queued until the transaction completes.
+func checkWorkspaceIdle(ctx context.Context, tx database.Store, workspaceID, buildID uuid.UUID) error {
+	err := tx.LockWorkspaceByID(ctx, workspaceID)
+	if err != nil {
+		return xerrors.Errorf("lock workspace: %w", err)
+	}
+	build, err := tx.GetLatestWorkspaceBuildByWorkspaceID(ctx, workspaceID)
+	if err != nil {
+		return xerrors.Errorf("get latest workspace build: %w", err)
+	}
+	if build.ID != buildID {
+		return errWorkspaceBusy
+	}
+	jobs, err := tx.GetActiveWorkspaceSnapshotJobsByWorkspaceID(ctx, workspaceID)
+	if err != nil {
+		return xerrors.Errorf("get active snapshot jobs: %w", err)
+	}
+	if len(jobs) > 0 {
+		return errWorkspaceBusy
+	}
+	return nil
+}
+
+// insertWorkspaceSnapshotJob queues a snapshot job on the provisioners that
+// ran the build, using the template version the build was made from.
+func insertWorkspaceSnapshotJob(ctx context.Context, db database.Store, buildJob database.ProvisionerJob, initiatorID uuid.UUID, input provisionerdserver.WorkspaceSnapshotJob) (database.ProvisionerJob, error) {
+	rawInput, err := json.Marshal(input)
+	if err != nil {
+		return database.ProvisionerJob{}, xerrors.Errorf("marshal job input: %w", err)
+	}
+	metadataRaw, err := json.Marshal(tracing.MetadataFromContext(ctx))
+	if err != nil {
+		return database.ProvisionerJob{}, xerrors.Errorf("marshal metadata: %w", err)
+	}
+
+	now := dbtime.Now()
+	job, err := db.InsertProvisionerJob(ctx, database.InsertProvisionerJobParams{
+		ID:             uuid.New(),
+		CreatedAt:      now,
+		UpdatedAt:      now,
+		OrganizationID: buildJob.OrganizationID,
+		InitiatorID:    initiatorID,
+		Provisioner:    buildJob.Provisioner,
+		StorageMethod:  buildJob.StorageMethod,
+		FileID:         buildJob.FileID,
+		Type:           database.ProvisionerJobTypeWorkspaceSnapshot,
+		Input:          rawInput,
+		// Copy tags from the build, so the job runs on the same provisioners.
+		Tags: buildJob.Tags,
+		TraceMetadata: pqtype.NullRawMessage{
+			Valid:      true,
+			RawMessage: metadataRaw,
+		},
+	})
+	if err != nil {
+		return database.ProvisionerJob{}, xerrors.Errorf("insert provisioner job: %w", err)
+	}
+	return job, nil
+}
+
+func convertWorkspaceSnapshot(snapshot database.WorkspaceSnapshot, job database.GetProvisionerJobsByIDsWithQueuePositionRow) codersdk.WorkspaceSnapshot {
+	return codersdk.WorkspaceSnapshot{
+		ID:               snapshot.ID,
+		WorkspaceID:      snapshot.WorkspaceID,
+		WorkspaceBuildID: snapshot.WorkspaceBuildID,
+		Name:             snapshot.Name,
+		CreatedBy:        snapshot.CreatedBy,
+		CreatedAt:        snapshot.CreatedAt,
+		Job:              convertProvisionerJob(job),
+	}
+}
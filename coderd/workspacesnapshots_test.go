@@ -0,0 +1,198 @@
+package coderd_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/provisioner/echo"
+	"github.com/coder/coder/v2/provisionersdk/proto"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestWorkspaceSnapshots(t *testing.T) {
+	t.Parallel()
+
+	t.Run("CreateAndRestore", func(t *testing.T) {
+		t.Parallel()
+		client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+		owner := coderdtest.CreateFirstUser(t, client)
+		member, _ := coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+		version := coderdtest.CreateTemplateVersion(t, client, owner.OrganizationID, &echo.Responses{
+			Parse:          echo.ParseComplete,
+			ProvisionPlan:  echo.PlanComplete,
+			ProvisionApply: echo.ApplyComplete,
+			ProvisionSnapshotMap: map[proto.SnapshotAction][]*proto.Response{
+				proto.SnapshotAction_CREATE: {{
+					Type: &proto.Response_Snapshot{
+						Snapshot: &proto.SnapshotComplete{Data: []byte("volume-snapshot")},
+					},
+				}},
+				proto.SnapshotAction_RESTORE: {{
+					Type: &proto.Response_Snapshot{
+						Snapshot: &proto.SnapshotComplete{State: []byte("restored")},
+					},
+				}},
+			},
+		})
+		coderdtest.AwaitTemplateVersionJobCompleted(t, client, version.ID)
+		template := coderdtest.CreateTemplate(t, client, owner.OrganizationID, version.ID)
+		workspace := coderdtest.CreateWorkspace(t, member, template.ID)
+		coderdtest.AwaitWorkspaceBuildJobCompleted(t, member, workspace.LatestBuild.ID)
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		snapshot, err := member.CreateWorkspaceSnapshot(ctx, workspace.ID, codersdk.CreateWorkspaceSnapshotRequest{
+			Name: "before-upgrade",
+		})
+		require.NoError(t, err)
+		require.Equal(t, "before-upgrade", snapshot.Name)
+		require.Equal(t, workspace.LatestBuild.ID, snapshot.WorkspaceBuildID)
+		awaitWorkspaceSnapshot(ctx, t, member, workspace.ID, snapshot.ID)
+
+		// Names are unique per workspace.
+		_, err = member.CreateWorkspaceSnapshot(ctx, workspace.ID, codersdk.CreateWorkspaceSnapshotRequest{
+			Name: "before-upgrade",
+		})
+		require.Error(t, err)
+		require.Equal(t, http.StatusConflict, coderdtest.SDKError(t, err).StatusCode())
+
+		snapshots, err := member.WorkspaceSnapshots(ctx, workspace.ID)
+		require.NoError(t, err)
+		require.Len(t, snapshots, 1)
+		require.Equal(t, snapshot.ID, snapshots[0].ID)
+
+		// Snapshots can only be restored into stopped workspaces.
+		_, err = member.RestoreWorkspaceSnapshot(ctx, workspace.ID, snapshot.ID, codersdk.RestoreWorkspaceSnapshotRequest{})
+		require.Error(t, err)
+		require.Equal(t, http.StatusConflict, coderdtest.SDKError(t, err).StatusCode())
+
+		workspace = coderdtest.MustTransitionWorkspace(t, member, workspace.ID, database.WorkspaceTransitionStart, database.WorkspaceTransitionStop)
+
+		job, err := member.RestoreWorkspaceSnapshot(ctx, workspace.ID, snapshot.ID, codersdk.RestoreWorkspaceSnapshotRequest{})
+		require.NoError(t, err)
+		require.Eventually(t, func() bool {
+			job, err = member.WorkspaceSnapshotRestore(ctx, workspace.ID, snapshot.ID, job.ID)
+			return assert.NoError(t, err) && job.Status == codersdk.ProvisionerJobSucceeded
+		}, testutil.WaitLong, testutil.IntervalFast)
+
+		// The restored state replaces the state of the stopped build.
+		state, err := client.WorkspaceBuildState(ctx, workspace.LatestBuild.ID)
+		require.NoError(t, err)
+		require.Equal(t, []byte("restored"), state)
+
+		err = member.DeleteWorkspaceSnapshot(ctx, workspace.ID, snapshot.ID)
+		require.NoError(t, err)
+		snapshots, err = member.WorkspaceSnapshots(ctx, workspace.ID)
+		require.NoError(t, err)
+		require.Empty(t, snapshots)
+	})
+
+	t.Run("ExclusiveWithBuilds", func(t *testing.T) {
+		t.Parallel()
+		client, closer := coderdtest.NewWithProvisionerCloser(t, nil)
+		owner := coderdtest.CreateFirstUser(t, client)
+		version := coderdtest.CreateTemplateVersion(t, client, owner.OrganizationID, nil)
+		coderdtest.AwaitTemplateVersionJobCompleted(t, client, version.ID)
+		template := coderdtest.CreateTemplate(t, client, owner.OrganizationID, version.ID)
+		workspace := coderdtest.CreateWorkspace(t, client, template.ID)
+		coderdtest.AwaitWorkspaceBuildJobCompleted(t, client, workspace.LatestBuild.ID)
+		// Without provisioners, the jobs queued below stay pending.
+		require.NoError(t, closer.Close())
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		// Queue a build and a snapshot at the same time: only one of them
+		// may be queued, and the other must conflict with it.
+		var (
+			wg          sync.WaitGroup
+			buildErr    error
+			snapshotErr error
+		)
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, buildErr = client.CreateWorkspaceBuild(ctx, workspace.ID, codersdk.CreateWorkspaceBuildRequest{
+				Transition: codersdk.WorkspaceTransitionStop,
+			})
+		}()
+		go func() {
+			defer wg.Done()
+			_, snapshotErr = client.CreateWorkspaceSnapshot(ctx, workspace.ID, codersdk.CreateWorkspaceSnapshotRequest{
+				Name: "racing",
+			})
+		}()
+		wg.Wait()
+
+		if buildErr == nil {
+			require.Error(t, snapshotErr)
+			require.Equal(t, http.StatusConflict, coderdtest.SDKError(t, snapshotErr).StatusCode())
+		} else {
+			require.NoError(t, snapshotErr)
+			require.Equal(t, http.StatusConflict, coderdtest.SDKError(t, buildErr).StatusCode())
+
+			// Neither another snapshot nor a restore may run alongside it.
+			_, err := client.CreateWorkspaceSnapshot(ctx, workspace.ID, codersdk.CreateWorkspaceSnapshotRequest{
+				Name: "another",
+			})
+			require.Error(t, err)
+			require.Equal(t, http.StatusConflict, coderdtest.SDKError(t, err).StatusCode())
+		}
+	})
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		t.Parallel()
+		client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+		owner := coderdtest.CreateFirstUser(t, client)
+		member, _ := coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+		other, _ := coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+		version := coderdtest.CreateTemplateVersion(t, client, owner.OrganizationID, nil)
+		coderdtest.AwaitTemplateVersionJobCompleted(t, client, version.ID)
+		template := coderdtest.CreateTemplate(t, client, owner.OrganizationID, version.ID)
+		workspace := coderdtest.CreateWorkspace(t, member, template.ID)
+		coderdtest.AwaitWorkspaceBuildJobCompleted(t, member, workspace.LatestBuild.ID)
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		_, err := other.CreateWorkspaceSnapshot(ctx, workspace.ID, codersdk.CreateWorkspaceSnapshotRequest{
+			Name: "mine",
+		})
+		require.Error(t, err)
+		require.Equal(t, http.StatusNotFound, coderdtest.SDKError(t, err).StatusCode())
+	})
+
+	t.Run("InvalidName", func(t *testing.T) {
+		t.Parallel()
+		client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+		owner := coderdtest.CreateFirstUser(t, client)
+		version := coderdtest.CreateTemplateVersion(t, client, owner.OrganizationID, nil)
+		coderdtest.AwaitTemplateVersionJobCompleted(t, client, version.ID)
+		template := coderdtest.CreateTemplate(t, client, owner.OrganizationID, version.ID)
+		workspace := coderdtest.CreateWorkspace(t, client, template.ID)
+		coderdtest.AwaitWorkspaceBuildJobCompleted(t, client, workspace.LatestBuild.ID)
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		_, err := client.CreateWorkspaceSnapshot(ctx, workspace.ID, codersdk.CreateWorkspaceSnapshotRequest{
+			Name: "not a valid name!",
+		})
+		require.Error(t, err)
+		require.Equal(t, http.StatusBadRequest, coderdtest.SDKError(t, err).StatusCode())
+	})
+}
+
+func awaitWorkspaceSnapshot(ctx context.Context, t *testing.T, client *codersdk.Client, workspace, snapshot uuid.UUID) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		s, err := client.WorkspaceSnapshot(ctx, workspace, snapshot)
+		return assert.NoError(t, err) && s.Job.Status == codersdk.ProvisionerJobSucceeded
+	}, testutil.WaitLong, testutil.IntervalFast)
+}
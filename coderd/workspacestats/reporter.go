@@ -118,8 +118,11 @@ func (r *Reporter) ReportAppStats(ctx context.Context, stats []workspaceapps.Sta
 	return nil
 }
 
-func (r *Reporter) ReportAgentStats(ctx context.Context, now time.Time, workspace database.Workspace, workspaceAgent database.WorkspaceAgent, templateName string, stats *agentproto.Stats, usage bool) error {
-	if stats.ConnectionCount > 0 {
+// ReportAgentStats records the stats of an agent. agentActivityBump is the
+// setting of the template of the workspace that bumps the deadline on activity
+// the agent reports without a connection.
+func (r *Reporter) ReportAgentStats(ctx context.Context, now time.Time, workspace database.Workspace, workspaceAgent database.WorkspaceAgent, templateName string, agentActivityBump bool, stats *agentproto.Stats, usage bool) error {
+	if stats.ConnectionCount > 0 || (stats.Active && agentActivityBump) {
 		var nextAutostart time.Time
 		if workspace.AutostartSchedule.String != "" {
 			templateSchedule, err := (*(r.opts.TemplateScheduleStore.Load())).Get(ctx, r.opts.Database, workspace.TemplateID)
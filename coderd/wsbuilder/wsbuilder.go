@@ -266,6 +266,10 @@ func (b *Builder) buildTx(authFunc func(action policy.Action, object rbac.Object
 	if err != nil {
 		return nil, nil, err
 	}
+	err = b.checkActiveSnapshotJob()
+	if err != nil {
+		return nil, nil, err
+	}
 
 	template, err := b.getTemplate()
 	if err != nil {
@@ -340,7 +344,7 @@ func (b *Builder) buildTx(authFunc func(action policy.Action, object rbac.Object
 	if err != nil {
 		return nil, nil, BuildError{http.StatusInternalServerError, "compute build number", err}
 	}
-	state, err := b.getState()
+	state, stateRef, err := b.getState()
 	if err != nil {
 		return nil, nil, BuildError{http.StatusInternalServerError, "compute build state", err}
 	}
@@ -348,19 +352,20 @@ func (b *Builder) buildTx(authFunc func(action policy.Action, object rbac.Object
 	var workspaceBuild database.WorkspaceBuild
 	err = b.store.InTx(func(store database.Store) error {
 		err = store.InsertWorkspaceBuild(b.ctx, database.InsertWorkspaceBuildParams{
-			ID:                workspaceBuildID,
-			CreatedAt:         now,
-			UpdatedAt:         now,
-			WorkspaceID:       b.workspace.ID,
-			TemplateVersionID: templateVersionID,
-			BuildNumber:       buildNum,
-			ProvisionerState:  state,
-			InitiatorID:       b.initiator,
-			Transition:        b.trans,
-			JobID:             provisionerJob.ID,
-			Reason:            b.reason,
-			Deadline:          time.Time{}, // set by provisioner upon completion
-			MaxDeadline:       time.Time{}, // set by provisioner upon completion
+			ID:                  workspaceBuildID,
+			CreatedAt:           now,
+			UpdatedAt:           now,
+			WorkspaceID:         b.workspace.ID,
+			TemplateVersionID:   templateVersionID,
+			BuildNumber:         buildNum,
+			ProvisionerState:    state,
+			ProvisionerStateRef: stateRef,
+			InitiatorID:         b.initiator,
+			Transition:          b.trans,
+			JobID:               provisionerJob.ID,
+			Reason:              b.reason,
+			Deadline:            time.Time{}, // set by provisioner upon completion
+			MaxDeadline:         time.Time{}, // set by provisioner upon completion
 		})
 		if err != nil {
 			code := http.StatusInternalServerError
@@ -493,24 +498,28 @@ func (b *Builder) getBuildNumber() (int32, error) {
 	return bld.BuildNumber + 1, nil
 }
 
-func (b *Builder) getState() ([]byte, error) {
+// getState returns the provisioner state for the build, either as the state
+// itself or as a reference to it in the provisioner state store. Explicit
+// state is always stored in the database; the provisioner server moves it to
+// the state store once the build completes.
+func (b *Builder) getState() ([]byte, string, error) {
 	if b.state.orphan {
 		// Orphan means empty state.
-		return nil, nil
+		return nil, "", nil
 	}
 	if b.state.explicit != nil {
-		return *b.state.explicit, nil
+		return *b.state.explicit, "", nil
 	}
 	// Default is to use state from prior build
 	bld, err := b.getLastBuild()
 	if xerrors.Is(err, sql.ErrNoRows) {
 		// last build does not exist, which implies empty state
-		return nil, nil
+		return nil, "", nil
 	}
 	if err != nil {
-		return nil, xerrors.Errorf("get last build to get state: %w", err)
+		return nil, "", xerrors.Errorf("get last build to get state: %w", err)
 	}
-	return bld.ProvisionerState, nil
+	return bld.ProvisionerState, bld.ProvisionerStateRef, nil
 }
 
 func (b *Builder) getParameters() (names, values []string, err error) {
@@ -887,3 +896,28 @@ func (b *Builder) checkRunningBuild() error {
 	}
 	return nil
 }
+
+// checkActiveSnapshotJob rejects the build while a snapshot of the workspace
+// is being created or restored, as the snapshot job uses the state of the
+// workspace too. Queueing a snapshot job locks the workspace, so one that is
+// queued concurrently makes the transaction fail with a serialization error,
+// and retry.
+func (b *Builder) checkActiveSnapshotJob() error {
+	err := b.store.LockWorkspaceByID(b.ctx, b.workspace.ID)
+	if err != nil {
+		return BuildError{http.StatusInternalServerError, "failed to lock workspace", err}
+	}
+	jobs, err := b.store.GetActiveWorkspaceSnapshotJobsByWorkspaceID(b.ctx, b.workspace.ID)
+	if err != nil {
+		return BuildError{http.StatusInternalServerError, "failed to fetch workspace snapshot jobs", err}
+	}
+	if len(jobs) > 0 {
+		msg := "A workspace snapshot is being created or restored."
+		return BuildError{
+			http.StatusConflict,
+			msg,
+			xerrors.New(msg),
+		}
+	}
+	return nil
+}
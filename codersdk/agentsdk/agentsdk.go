@@ -33,6 +33,11 @@ import (
 // log-source. This should be removed in the future.
 var ExternalLogSourceID = uuid.MustParse("3b579bf4-1ed8-4b99-87a8-e9a1e3410410")
 
+// PortForwardingLogSourceID is the statically-defined ID of the log-source
+// the agent reports port forwards denied by the template's port forwarding
+// policy to. It appears as "Port forwarding" in the dashboard.
+var PortForwardingLogSourceID = uuid.MustParse("c8f6d5a4-3b2e-4f1d-9a7c-6e5b4d3c2a19")
+
 // New returns a client that is used to interact with the
 // Coder API from a workspace agent.
 func New(serverURL *url.URL) *Client {
@@ -108,6 +113,11 @@ type Manifest struct {
 	DisableDirectConnections bool                                         `json:"disable_direct_connections"`
 	Metadata                 []codersdk.WorkspaceAgentMetadataDescription `json:"metadata"`
 	Scripts                  []codersdk.WorkspaceAgentScript              `json:"scripts"`
+	// SessionRecording is set when the template requires interactive SSH
+	// sessions to be recorded.
+	SessionRecording bool `json:"session_recording"`
+	// PortForwardingPolicy restricts the SSH port forwards the agent allows.
+	PortForwardingPolicy codersdk.PortForwardingPolicy `json:"port_forwarding_policy"`
 }
 
 type LogSource struct {
@@ -177,6 +187,26 @@ func (c *Client) ConnectRPC21(ctx context.Context) (proto.DRPCAgentClient21, err
 	return proto.NewDRPCAgentClient(conn), nil
 }
 
+// ConnectRPC22 returns a dRPC client to the Agent API v2.2.  It is useful when you want to be
+// maximally compatible with Coderd Release Versions that do not support session recording.
+func (c *Client) ConnectRPC22(ctx context.Context) (proto.DRPCAgentClient22, error) {
+	conn, err := c.connectRPCVersion(ctx, apiversion.New(2, 2))
+	if err != nil {
+		return nil, err
+	}
+	return proto.NewDRPCAgentClient(conn), nil
+}
+
+// ConnectRPC23 returns a dRPC client to the Agent API v2.3.  It is useful when you want to be
+// maximally compatible with Coderd Release Versions that do not support container reporting.
+func (c *Client) ConnectRPC23(ctx context.Context) (proto.DRPCAgentClient23, error) {
+	conn, err := c.connectRPCVersion(ctx, apiversion.New(2, 3))
+	if err != nil {
+		return nil, err
+	}
+	return proto.NewDRPCAgentClient(conn), nil
+}
+
 // ConnectRPC connects to the workspace agent API and tailnet API
 func (c *Client) ConnectRPC(ctx context.Context) (drpc.Conn, error) {
 	return c.connectRPCVersion(ctx, proto.CurrentVersion)
@@ -1,6 +1,7 @@
 package agentsdk
 
 import (
+	"math"
 	"strings"
 	"time"
 
@@ -48,6 +49,8 @@ func ManifestFromProto(manifest *proto.Manifest) (Manifest, error) {
 		MOTDFile:                 manifest.MotdPath,
 		DisableDirectConnections: manifest.DisableDirectConnections,
 		Metadata:                 MetadataDescriptionsFromProto(manifest.Metadata),
+		SessionRecording:         manifest.SessionRecording,
+		PortForwardingPolicy:     PortForwardingPolicyFromProto(manifest.PortForwardingPolicy),
 	}, nil
 }
 
@@ -73,9 +76,58 @@ func ProtoFromManifest(manifest Manifest) (*proto.Manifest, error) {
 		Scripts:                  ProtoFromScripts(manifest.Scripts),
 		Apps:                     apps,
 		Metadata:                 ProtoFromMetadataDescriptions(manifest.Metadata),
+		SessionRecording:         manifest.SessionRecording,
+		PortForwardingPolicy:     ProtoFromPortForwardingPolicy(manifest.PortForwardingPolicy),
 	}, nil
 }
 
+func PortForwardingPolicyFromProto(policy *proto.PortForwardingPolicy) codersdk.PortForwardingPolicy {
+	var out codersdk.PortForwardingPolicy
+	if policy == nil {
+		return out
+	}
+	if policy.DirectTcpip != nil {
+		out.DirectTCPIP = &codersdk.PortForwardingRule{
+			AllowedCIDRs: policy.DirectTcpip.AllowedCidrs,
+			AllowedPorts: policy.DirectTcpip.AllowedPorts,
+		}
+	}
+	if policy.RemoteForward != nil {
+		out.RemoteForward = &codersdk.PortForwardingRule{
+			AllowedCIDRs: policy.RemoteForward.AllowedCidrs,
+			AllowedPorts: policy.RemoteForward.AllowedPorts,
+		}
+	}
+	if policy.UnixSocket != nil {
+		out.UnixSocket = &codersdk.UnixSocketForwardingRule{
+			AllowedPaths: policy.UnixSocket.AllowedPaths,
+		}
+	}
+	return out
+}
+
+func ProtoFromPortForwardingPolicy(policy codersdk.PortForwardingPolicy) *proto.PortForwardingPolicy {
+	out := &proto.PortForwardingPolicy{}
+	if policy.DirectTCPIP != nil {
+		out.DirectTcpip = &proto.PortForwardingPolicy_Rule{
+			AllowedCidrs: policy.DirectTCPIP.AllowedCIDRs,
+			AllowedPorts: policy.DirectTCPIP.AllowedPorts,
+		}
+	}
+	if policy.RemoteForward != nil {
+		out.RemoteForward = &proto.PortForwardingPolicy_Rule{
+			AllowedCidrs: policy.RemoteForward.AllowedCIDRs,
+			AllowedPorts: policy.RemoteForward.AllowedPorts,
+		}
+	}
+	if policy.UnixSocket != nil {
+		out.UnixSocket = &proto.PortForwardingPolicy_UnixSocketRule{
+			AllowedPaths: policy.UnixSocket.AllowedPaths,
+		}
+	}
+	return out
+}
+
 func MetadataDescriptionsFromProto(descriptions []*proto.WorkspaceAgentMetadata_Description) []codersdk.WorkspaceAgentMetadataDescription {
 	ret := make([]codersdk.WorkspaceAgentMetadataDescription, len(descriptions))
 	for i, description := range descriptions {
@@ -237,6 +289,11 @@ func AppFromProto(protoApp *proto.WorkspaceApp) (codersdk.WorkspaceApp, error) {
 		return codersdk.WorkspaceApp{}, xerrors.Errorf("unknown app health: %v (%q)", protoApp.Health, protoApp.Health.String())
 	}
 
+	healthcheckKind := codersdk.WorkspaceAppHealthcheckKind(strings.ToLower(protoApp.Healthcheck.GetKind().String()))
+	if _, ok := codersdk.MapWorkspaceAppHealthcheckKinds[healthcheckKind]; !ok {
+		return codersdk.WorkspaceApp{}, xerrors.Errorf("unknown app healthcheck kind: %v (%q)", protoApp.Healthcheck.GetKind(), protoApp.Healthcheck.GetKind().String())
+	}
+
 	return codersdk.WorkspaceApp{
 		ID:            id,
 		URL:           protoApp.Url,
@@ -249,9 +306,16 @@ func AppFromProto(protoApp *proto.WorkspaceApp) (codersdk.WorkspaceApp, error) {
 		SubdomainName: protoApp.SubdomainName,
 		SharingLevel:  sharingLevel,
 		Healthcheck: codersdk.Healthcheck{
-			URL:       protoApp.Healthcheck.Url,
-			Interval:  int32(protoApp.Healthcheck.Interval.AsDuration().Seconds()),
-			Threshold: protoApp.Healthcheck.Threshold,
+			URL:            protoApp.Healthcheck.Url,
+			Interval:       int32(protoApp.Healthcheck.Interval.AsDuration().Seconds()),
+			Threshold:      protoApp.Healthcheck.Threshold,
+			Kind:           healthcheckKind,
+			ExpectedStatus: protoApp.Healthcheck.ExpectedStatus,
+			BodyRegex:      protoApp.Healthcheck.BodyRegex,
+			Address:        protoApp.Healthcheck.Address,
+			GRPCService:    protoApp.Healthcheck.GrpcService,
+			Command:        protoApp.Healthcheck.Command,
+			Path:           protoApp.Healthcheck.Path,
 		},
 		Health: health,
 		Hidden: protoApp.Hidden,
@@ -267,6 +331,14 @@ func ProtoFromApp(a codersdk.WorkspaceApp) (*proto.WorkspaceApp, error) {
 	if !ok {
 		return nil, xerrors.Errorf("unknown health %s", a.Health)
 	}
+	healthcheckKind := a.Healthcheck.Kind
+	if healthcheckKind == "" {
+		healthcheckKind = codersdk.WorkspaceAppHealthcheckKindHTTP
+	}
+	protoHealthcheckKind, ok := proto.WorkspaceApp_Healthcheck_Kind_value[strings.ToUpper(string(healthcheckKind))]
+	if !ok {
+		return nil, xerrors.Errorf("unknown healthcheck kind %s", a.Healthcheck.Kind)
+	}
 	return &proto.WorkspaceApp{
 		Id:            a.ID[:],
 		Url:           a.URL,
@@ -279,9 +351,16 @@ func ProtoFromApp(a codersdk.WorkspaceApp) (*proto.WorkspaceApp, error) {
 		SubdomainName: a.SubdomainName,
 		SharingLevel:  proto.WorkspaceApp_SharingLevel(sharingLevel),
 		Healthcheck: &proto.WorkspaceApp_Healthcheck{
-			Url:       a.Healthcheck.URL,
-			Interval:  durationpb.New(time.Duration(a.Healthcheck.Interval) * time.Second),
-			Threshold: a.Healthcheck.Threshold,
+			Url:            a.Healthcheck.URL,
+			Interval:       durationpb.New(time.Duration(a.Healthcheck.Interval) * time.Second),
+			Threshold:      a.Healthcheck.Threshold,
+			Kind:           proto.WorkspaceApp_Healthcheck_Kind(protoHealthcheckKind),
+			ExpectedStatus: a.Healthcheck.ExpectedStatus,
+			BodyRegex:      a.Healthcheck.BodyRegex,
+			Address:        a.Healthcheck.Address,
+			GrpcService:    a.Healthcheck.GRPCService,
+			Command:        a.Healthcheck.Command,
+			Path:           a.Healthcheck.Path,
 		},
 		Health: proto.WorkspaceApp_Health(health),
 		Hidden: a.Hidden,
@@ -390,3 +469,54 @@ func ProtoFromLifecycleState(s codersdk.WorkspaceAgentLifecycle) (proto.Lifecycl
 	}
 	return proto.Lifecycle_State(caps), nil
 }
+
+func ProtoFromContainer(c codersdk.WorkspaceAgentContainer) *proto.WorkspaceAgentContainer {
+	ports := make([]*proto.WorkspaceAgentContainer_Port, 0, len(c.Ports))
+	for _, port := range c.Ports {
+		ports = append(ports, &proto.WorkspaceAgentContainer_Port{
+			Port:     uint32(port.Port),
+			Network:  port.Network,
+			HostIp:   port.HostIP,
+			HostPort: uint32(port.HostPort),
+		})
+	}
+	return &proto.WorkspaceAgentContainer{
+		Id:        c.ID,
+		Name:      c.Name,
+		Image:     c.Image,
+		CreatedAt: timestamppb.New(c.CreatedAt),
+		Status:    c.Status,
+		Labels:    c.Labels,
+		Ports:     ports,
+	}
+}
+
+// ContainerFromProto converts a container reported by an agent. The
+// devcontainer fields are derived from the labels, so they're left empty.
+func ContainerFromProto(p *proto.WorkspaceAgentContainer) (codersdk.WorkspaceAgentContainer, error) {
+	ports := make([]codersdk.WorkspaceAgentContainerPort, 0, len(p.GetPorts()))
+	for _, port := range p.GetPorts() {
+		if port.GetPort() > math.MaxUint16 || port.GetHostPort() > math.MaxUint16 {
+			return codersdk.WorkspaceAgentContainer{}, xerrors.Errorf("port %d of container %s is out of range", port.GetPort(), p.GetName())
+		}
+		ports = append(ports, codersdk.WorkspaceAgentContainerPort{
+			Port:     uint16(port.GetPort()),
+			Network:  port.GetNetwork(),
+			HostIP:   port.GetHostIp(),
+			HostPort: uint16(port.GetHostPort()),
+		})
+	}
+	labels := p.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	return codersdk.WorkspaceAgentContainer{
+		ID:        p.GetId(),
+		Name:      p.GetName(),
+		Image:     p.GetImage(),
+		CreatedAt: p.GetCreatedAt().AsTime(),
+		Status:    p.GetStatus(),
+		Labels:    labels,
+		Ports:     ports,
+	}, nil
+}
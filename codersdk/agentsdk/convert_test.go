@@ -39,9 +39,12 @@ func TestManifest(t *testing.T) {
 				SubdomainName: "app1.example.com",
 				SharingLevel:  codersdk.WorkspaceAppSharingLevelAuthenticated,
 				Healthcheck: codersdk.Healthcheck{
-					URL:       "http://localhost:3030/healthz",
-					Interval:  55555666,
-					Threshold: 55555666,
+					URL:            "http://localhost:3030/healthz",
+					Interval:       55555666,
+					Threshold:      55555666,
+					Kind:           codersdk.WorkspaceAppHealthcheckKindHTTP,
+					ExpectedStatus: 204,
+					BodyRegex:      "^ok$",
 				},
 				Health: codersdk.WorkspaceAppHealthHealthy,
 				Hidden: false,
@@ -58,9 +61,11 @@ func TestManifest(t *testing.T) {
 				SubdomainName: "app2.example.com",
 				SharingLevel:  codersdk.WorkspaceAppSharingLevelPublic,
 				Healthcheck: codersdk.Healthcheck{
-					URL:       "http://localhost:3032/healthz",
-					Interval:  22555666,
-					Threshold: 22555666,
+					Interval:    22555666,
+					Threshold:   22555666,
+					Kind:        codersdk.WorkspaceAppHealthcheckKindGRPC,
+					Address:     "localhost:3032",
+					GRPCService: "app2.v1.App",
 				},
 				Health: codersdk.WorkspaceAppHealthInitializing,
 				Hidden: true,
@@ -130,6 +135,16 @@ func TestManifest(t *testing.T) {
 				DisplayName:      "bar",
 			},
 		},
+		SessionRecording: true,
+		PortForwardingPolicy: codersdk.PortForwardingPolicy{
+			DirectTCPIP: &codersdk.PortForwardingRule{
+				AllowedCIDRs: []string{"127.0.0.0/8"},
+				AllowedPorts: []string{"8000-8999"},
+			},
+			UnixSocket: &codersdk.UnixSocketForwardingRule{
+				AllowedPaths: []string{"/run/user/*/gnupg/*"},
+			},
+		},
 	}
 	p, err := agentsdk.ProtoFromManifest(manifest)
 	require.NoError(t, err)
@@ -152,6 +167,8 @@ func TestManifest(t *testing.T) {
 	require.Equal(t, manifest.DisableDirectConnections, back.DisableDirectConnections)
 	require.Equal(t, manifest.Metadata, back.Metadata)
 	require.Equal(t, manifest.Scripts, back.Scripts)
+	require.Equal(t, manifest.SessionRecording, back.SessionRecording)
+	require.Equal(t, manifest.PortForwardingPolicy, back.PortForwardingPolicy)
 }
 
 func TestSubsystems(t *testing.T) {
@@ -222,3 +239,22 @@ func TestMetadataFromProto(t *testing.T) {
 	require.Equal(t, "lemons", smd.Value)
 	require.Equal(t, "rats", smd.Error)
 }
+
+func TestContainer(t *testing.T) {
+	t.Parallel()
+	container := codersdk.WorkspaceAgentContainer{
+		ID:        "0b1c2d3e",
+		Name:      "vibrant_tesla",
+		Image:     "postgres:16",
+		CreatedAt: time.Date(2024, 9, 10, 11, 0, 0, 0, time.UTC),
+		Status:    "running",
+		Labels:    map[string]string{"devcontainer.local_folder": "/home/coder/coder"},
+		Ports: []codersdk.WorkspaceAgentContainerPort{
+			{Port: 5432, Network: "tcp", HostIP: "0.0.0.0", HostPort: 15432},
+			{Port: 8080, Network: "tcp"},
+		},
+	}
+	back, err := agentsdk.ContainerFromProto(agentsdk.ProtoFromContainer(container))
+	require.NoError(t, err)
+	require.Equal(t, container, back)
+}
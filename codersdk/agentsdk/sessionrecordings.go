@@ -0,0 +1,297 @@
+package agentsdk
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"cdr.dev/slog"
+	"github.com/coder/coder/v2/agent/proto"
+)
+
+const (
+	// maxSessionRecordingChunkBytes is the largest chunk of a recording sent
+	// in a single request.
+	maxSessionRecordingChunkBytes = 256 << 10 // 256KiB
+	// maxSessionRecordingBytesQueued bounds the memory used by recordings
+	// that have not been uploaded yet, e.g. while coderd is unreachable.
+	// Output written after the queue is full is dropped.
+	maxSessionRecordingBytesQueued = 32 << 20 // 32MiB
+)
+
+type SessionRecordingDest interface {
+	StartSessionRecording(ctx context.Context, req *proto.StartSessionRecordingRequest) (*proto.StartSessionRecordingResponse, error)
+	UploadSessionRecordingChunk(ctx context.Context, req *proto.UploadSessionRecordingChunkRequest) (*proto.UploadSessionRecordingChunkResponse, error)
+	EndSessionRecording(ctx context.Context, req *proto.EndSessionRecordingRequest) (*proto.EndSessionRecordingResponse, error)
+}
+
+// sessionRecordingOp is a single request that is pending upload. Exactly one
+// of start, chunk and end is set.
+type sessionRecordingOp struct {
+	start *proto.StartSessionRecordingRequest
+	chunk *proto.UploadSessionRecordingChunkRequest
+	end   *proto.EndSessionRecordingRequest
+
+	queuedAt time.Time
+	// sending is set once the op has been handed to the agent API. Chunks
+	// are not appended to after that, so that retrying them is idempotent.
+	sending bool
+}
+
+// SessionRecordingSender is a component that handles queueing session
+// recordings and then uploading them over the agent API. Sessions are recorded
+// by writing to the writer returned by Record. When the agent API becomes
+// available, call SendLoop to upload pending recordings.
+type SessionRecordingSender struct {
+	*sync.Cond
+	logger      slog.Logger
+	queue       []*sessionRecordingOp
+	queuedBytes int
+}
+
+func NewSessionRecordingSender(logger slog.Logger) *SessionRecordingSender {
+	return &SessionRecordingSender{
+		Cond:   sync.NewCond(&sync.Mutex{}),
+		logger: logger,
+	}
+}
+
+// Record starts a new recording and returns a writer for its contents.
+// Closing the writer ends the recording. Writes never fail while the writer
+// is open, so that a slow or unavailable coderd cannot affect the session
+// being recorded.
+func (s *SessionRecordingSender) Record(id uuid.UUID, sessionType, command string, startedAt time.Time) io.WriteCloser {
+	s.L.Lock()
+	defer s.L.Unlock()
+	defer s.Broadcast()
+	s.queue = append(s.queue, &sessionRecordingOp{
+		start: &proto.StartSessionRecordingRequest{
+			Recording: &proto.SessionRecording{
+				Id:          id[:],
+				SessionType: sessionType,
+				Command:     command,
+				StartedAt:   timestamppb.New(startedAt),
+			},
+		},
+		queuedAt: time.Now(),
+	})
+	return &sessionRecordingWriter{
+		sender: s,
+		id:     id,
+		logger: s.logger.With(slog.F("recording_id", id)),
+	}
+}
+
+// SendLoop uploads pending recordings until it hits an error or the context
+// is canceled. It does not retry as it is expected that a higher layer
+// retries establishing connection to the agent API and calls SendLoop again.
+func (s *SessionRecordingSender) SendLoop(ctx context.Context, dest SessionRecordingDest) error {
+	s.L.Lock()
+	defer s.L.Unlock()
+
+	ctxDone := false
+	defer s.logger.Debug(ctx, "session recording sender send loop exiting")
+
+	// wake up periodically so that partially filled chunks are flushed
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		tkr := time.NewTicker(flushInterval / 4)
+		defer tkr.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				s.L.Lock()
+				ctxDone = true
+				s.L.Unlock()
+				s.Broadcast()
+				return
+			case <-tkr.C:
+				s.Broadcast()
+			}
+		}
+	}()
+
+	for {
+		for !ctxDone && !s.hasPendingWorkLocked() {
+			s.Wait()
+		}
+		if ctxDone {
+			return ctx.Err()
+		}
+
+		op := s.queue[0]
+		op.sending = true
+		s.L.Unlock()
+		err := s.send(ctx, dest, op)
+		s.L.Lock()
+		if err != nil {
+			return xerrors.Errorf("upload session recording: %w", err)
+		}
+
+		s.queue[0] = nil
+		s.queue = s.queue[1:]
+		if op.chunk != nil {
+			s.queuedBytes -= len(op.chunk.Data)
+		}
+		if len(s.queue) == 0 {
+			s.Broadcast() // might unblock WaitUntilEmpty
+		}
+	}
+}
+
+func (*SessionRecordingSender) send(ctx context.Context, dest SessionRecordingDest, op *sessionRecordingOp) error {
+	var err error
+	switch {
+	case op.start != nil:
+		_, err = dest.StartSessionRecording(ctx, op.start)
+	case op.chunk != nil:
+		_, err = dest.UploadSessionRecordingChunk(ctx, op.chunk)
+	case op.end != nil:
+		_, err = dest.EndSessionRecording(ctx, op.end)
+	}
+	return err
+}
+
+// hasPendingWorkLocked returns true if the first queued op should be sent.
+// Chunks are held back for a little while so that small writes, e.g. the
+// echo of a single keystroke, are batched together.
+func (s *SessionRecordingSender) hasPendingWorkLocked() bool {
+	if len(s.queue) == 0 {
+		return false
+	}
+	op := s.queue[0]
+	if op.chunk == nil || op.sending || len(s.queue) > 1 {
+		return true
+	}
+	return len(op.chunk.Data) >= maxSessionRecordingChunkBytes || time.Since(op.queuedAt) > flushInterval
+}
+
+// WaitUntilEmpty waits until all recordings have been uploaded or the given
+// context expires.
+func (s *SessionRecordingSender) WaitUntilEmpty(ctx context.Context) error {
+	ctxDone := false
+	nevermind := make(chan struct{})
+	defer close(nevermind)
+	go func() {
+		select {
+		case <-ctx.Done():
+			s.L.Lock()
+			defer s.L.Unlock()
+			ctxDone = true
+			s.Broadcast()
+			return
+		case <-nevermind:
+			return
+		}
+	}()
+	s.L.Lock()
+	defer s.L.Unlock()
+	for len(s.queue) != 0 && !ctxDone {
+		s.Wait()
+	}
+	if len(s.queue) == 0 {
+		return nil
+	}
+	return ctx.Err()
+}
+
+type sessionRecordingWriter struct {
+	sender *SessionRecordingSender
+	id     uuid.UUID
+	logger slog.Logger
+
+	// The fields below are protected by the sender's lock.
+	sequence int32
+	dropping bool
+	closed   bool
+}
+
+// Write queues p for upload. Each call is either queued or dropped as a whole,
+// so callers that write complete records keep the recording well-formed.
+func (w *sessionRecordingWriter) Write(p []byte) (int, error) {
+	s := w.sender
+	s.L.Lock()
+	defer s.L.Unlock()
+	if w.closed {
+		return 0, io.ErrClosedPipe
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if s.queuedBytes+len(p) > maxSessionRecordingBytesQueued {
+		if !w.dropping {
+			w.logger.Warn(context.Background(), "session recording queue full; dropping output")
+			w.dropping = true
+		}
+		return len(p), nil
+	}
+	w.dropping = false
+	defer s.Broadcast()
+
+	data := p
+	for len(data) > 0 {
+		if last := w.lastOpenChunkLocked(); last != nil {
+			n := min(maxSessionRecordingChunkBytes-len(last.chunk.Data), len(data))
+			last.chunk.Data = append(last.chunk.Data, data[:n]...)
+			data = data[n:]
+			continue
+		}
+		n := min(maxSessionRecordingChunkBytes, len(data))
+		s.queue = append(s.queue, &sessionRecordingOp{
+			chunk: &proto.UploadSessionRecordingChunkRequest{
+				RecordingId: w.id[:],
+				Sequence:    w.sequence,
+				Data:        append([]byte(nil), data[:n]...),
+			},
+			queuedAt: time.Now(),
+		})
+		w.sequence++
+		data = data[n:]
+	}
+	s.queuedBytes += len(p)
+	return len(p), nil
+}
+
+// lastOpenChunkLocked returns the last queued op if it is a chunk of this
+// recording that can still be appended to.
+func (w *sessionRecordingWriter) lastOpenChunkLocked() *sessionRecordingOp {
+	s := w.sender
+	if len(s.queue) == 0 {
+		return nil
+	}
+	last := s.queue[len(s.queue)-1]
+	if last.chunk == nil || last.sending || len(last.chunk.Data) >= maxSessionRecordingChunkBytes {
+		return nil
+	}
+	if !bytes.Equal(last.chunk.RecordingId, w.id[:]) {
+		return nil
+	}
+	return last
+}
+
+// Close ends the recording.
+func (w *sessionRecordingWriter) Close() error {
+	s := w.sender
+	s.L.Lock()
+	defer s.L.Unlock()
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	defer s.Broadcast()
+	s.queue = append(s.queue, &sessionRecordingOp{
+		end: &proto.EndSessionRecordingRequest{
+			RecordingId: w.id[:],
+			EndedAt:     timestamppb.Now(),
+		},
+		queuedAt: time.Now(),
+	})
+	return nil
+}
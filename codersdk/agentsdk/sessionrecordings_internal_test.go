@@ -0,0 +1,128 @@
+package agentsdk
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
+	"cdr.dev/slog/sloggers/slogtest"
+	"github.com/coder/coder/v2/agent/proto"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestSessionRecordingSender_Mainline(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.Context(t, testutil.WaitShort)
+	logger := slogtest.Make(t, nil).Leveled(slog.LevelDebug)
+	fDest := &fakeSessionRecordingDest{}
+	uut := NewSessionRecordingSender(logger)
+
+	id := uuid.UUID{0x11}
+	startedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	w := uut.Record(id, "ssh", "", startedAt)
+	for _, s := range []string{"hello", " ", "world"} {
+		_, err := w.Write([]byte(s))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	_, err := w.Write([]byte("too late"))
+	require.Error(t, err)
+
+	loopCtx, cancel := context.WithCancel(ctx)
+	loopErr := make(chan error, 1)
+	go func() {
+		loopErr <- uut.SendLoop(loopCtx, fDest)
+	}()
+
+	require.NoError(t, uut.WaitUntilEmpty(ctx))
+	cancel()
+	require.ErrorIs(t, testutil.RequireRecvCtx(ctx, t, loopErr), context.Canceled)
+
+	fDest.Lock()
+	defer fDest.Unlock()
+	require.Len(t, fDest.starts, 1)
+	require.Equal(t, id[:], fDest.starts[0].Recording.Id)
+	require.Equal(t, "ssh", fDest.starts[0].Recording.SessionType)
+	require.Equal(t, startedAt, fDest.starts[0].Recording.StartedAt.AsTime())
+	// small writes are coalesced into a single chunk
+	require.Len(t, fDest.chunks, 1)
+	require.Equal(t, int32(0), fDest.chunks[0].Sequence)
+	require.Equal(t, "hello world", string(fDest.chunks[0].Data))
+	require.Len(t, fDest.ends, 1)
+	require.Equal(t, id[:], fDest.ends[0].RecordingId)
+}
+
+func TestSessionRecordingSender_RetryChunk(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.Context(t, testutil.WaitShort)
+	logger := slogtest.Make(t, nil).Leveled(slog.LevelDebug)
+	fDest := &fakeSessionRecordingDest{chunkErr: xerrors.New("connection lost")}
+	uut := NewSessionRecordingSender(logger)
+
+	id := uuid.UUID{0x22}
+	w := uut.Record(id, "ssh", "", time.Now())
+	_, err := w.Write([]byte("first"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	err = uut.SendLoop(ctx, fDest)
+	require.ErrorContains(t, err, "connection lost")
+
+	fDest.Lock()
+	fDest.chunkErr = nil
+	fDest.Unlock()
+
+	loopCtx, cancel := context.WithCancel(ctx)
+	loopErr := make(chan error, 1)
+	go func() {
+		loopErr <- uut.SendLoop(loopCtx, fDest)
+	}()
+	require.NoError(t, uut.WaitUntilEmpty(ctx))
+	cancel()
+	require.ErrorIs(t, testutil.RequireRecvCtx(ctx, t, loopErr), context.Canceled)
+
+	fDest.Lock()
+	defer fDest.Unlock()
+	// the failed chunk is retried as is
+	require.Len(t, fDest.chunks, 2)
+	require.Equal(t, fDest.chunks[0], fDest.chunks[1])
+	require.Len(t, fDest.ends, 1)
+}
+
+type fakeSessionRecordingDest struct {
+	sync.Mutex
+	starts   []*proto.StartSessionRecordingRequest
+	chunks   []*proto.UploadSessionRecordingChunkRequest
+	ends     []*proto.EndSessionRecordingRequest
+	chunkErr error
+}
+
+func (f *fakeSessionRecordingDest) StartSessionRecording(_ context.Context, req *proto.StartSessionRecordingRequest) (*proto.StartSessionRecordingResponse, error) {
+	f.Lock()
+	defer f.Unlock()
+	f.starts = append(f.starts, req)
+	return &proto.StartSessionRecordingResponse{}, nil
+}
+
+func (f *fakeSessionRecordingDest) UploadSessionRecordingChunk(_ context.Context, req *proto.UploadSessionRecordingChunkRequest) (*proto.UploadSessionRecordingChunkResponse, error) {
+	f.Lock()
+	defer f.Unlock()
+	f.chunks = append(f.chunks, req)
+	if f.chunkErr != nil {
+		return nil, f.chunkErr
+	}
+	return &proto.UploadSessionRecordingChunkResponse{}, nil
+}
+
+func (f *fakeSessionRecordingDest) EndSessionRecording(_ context.Context, req *proto.EndSessionRecordingRequest) (*proto.EndSessionRecordingResponse, error) {
+	f.Lock()
+	defer f.Unlock()
+	f.ends = append(f.ends, req)
+	return &proto.EndSessionRecordingResponse{}, nil
+}
@@ -94,6 +94,7 @@ const (
 	AuditActionLogin    AuditAction = "login"
 	AuditActionLogout   AuditAction = "logout"
 	AuditActionRegister AuditAction = "register"
+	AuditActionExec     AuditAction = "exec"
 )
 
 func (a AuditAction) Friendly() string {
@@ -114,6 +115,8 @@ func (a AuditAction) Friendly() string {
 		return "退出了"
 	case AuditActionRegister:
 		return "注册了"
+	case AuditActionExec:
+		return "执行了命令于"
 	default:
 		return "未知"
 	}
@@ -364,6 +364,8 @@ type DeploymentValues struct {
 	MetricsCacheRefreshInterval     serpent.Duration                     `json:"metrics_cache_refresh_interval,omitempty" typescript:",notnull"`
 	AgentStatRefreshInterval        serpent.Duration                     `json:"agent_stat_refresh_interval,omitempty" typescript:",notnull"`
 	AgentFallbackTroubleshootingURL serpent.URL                          `json:"agent_fallback_troubleshooting_url,omitempty" typescript:",notnull"`
+	AgentMetadataHistoryRetention   serpent.Duration                     `json:"agent_metadata_history_retention,omitempty" typescript:",notnull"`
+	WorkspaceDriftCheckInterval     serpent.Duration                     `json:"workspace_drift_check_interval,omitempty" typescript:",notnull"`
 	BrowserOnly                     serpent.Bool                         `json:"browser_only,omitempty" typescript:",notnull"`
 	SCIMAPIKey                      serpent.String                       `json:"scim_api_key,omitempty" typescript:",notnull"`
 	ExternalTokenEncryptionKeys     serpent.StringArray                  `json:"external_token_encryption_keys,omitempty" typescript:",notnull"`
@@ -391,6 +393,7 @@ type DeploymentValues struct {
 	CLIUpgradeMessage               serpent.String                       `json:"cli_upgrade_message,omitempty" typescript:",notnull"`
 	TermsOfServiceURL               serpent.String                       `json:"terms_of_service_url,omitempty" typescript:",notnull"`
 	Notifications                   NotificationsConfig                  `json:"notifications,omitempty" typescript:",notnull"`
+	AuditLogging                    AuditLoggingConfig                   `json:"audit_logging,omitempty" typescript:",notnull"`
 
 	Config      serpent.YAMLConfigPath `json:"config,omitempty" typescript:",notnull"`
 	WriteConfig serpent.Bool           `json:"write_config,omitempty" typescript:",notnull"`
@@ -604,12 +607,41 @@ type ExternalAuthConfig struct {
 
 type ProvisionerConfig struct {
 	// Daemons is the number of built-in terraform provisioners.
-	Daemons             serpent.Int64       `json:"daemons" typescript:",notnull"`
-	DaemonTypes         serpent.StringArray `json:"daemon_types" typescript:",notnull"`
-	DaemonPollInterval  serpent.Duration    `json:"daemon_poll_interval" typescript:",notnull"`
-	DaemonPollJitter    serpent.Duration    `json:"daemon_poll_jitter" typescript:",notnull"`
-	ForceCancelInterval serpent.Duration    `json:"force_cancel_interval" typescript:",notnull"`
-	DaemonPSK           serpent.String      `json:"daemon_psk" typescript:",notnull"`
+	Daemons             serpent.Int64               `json:"daemons" typescript:",notnull"`
+	DaemonTypes         serpent.StringArray         `json:"daemon_types" typescript:",notnull"`
+	DaemonPollInterval  serpent.Duration            `json:"daemon_poll_interval" typescript:",notnull"`
+	DaemonPollJitter    serpent.Duration            `json:"daemon_poll_jitter" typescript:",notnull"`
+	ForceCancelInterval serpent.Duration            `json:"force_cancel_interval" typescript:",notnull"`
+	DaemonPSK           serpent.String              `json:"daemon_psk" typescript:",notnull"`
+	IaCMirrorDir        serpent.String              `json:"iac_mirror_dir" typescript:",notnull"`
+	ModuleCacheMaxSize  serpent.Int64               `json:"module_cache_max_size" typescript:",notnull"`
+	StateStore          ProvisionerStateStoreConfig `json:"state_store" typescript:",notnull"`
+}
+
+// ProvisionerStateStoreConfig configures where the provisioner state of workspace builds is stored. At most one
+// location may be set; if none is, the state is stored in the database.
+type ProvisionerStateStoreConfig struct {
+	// The local directory in which provisioner state is stored.
+	Directory serpent.String      `json:"directory" typescript:",notnull"`
+	S3        ObjectStoreS3Config `json:"s3" typescript:",notnull"`
+}
+
+// Enabled reports whether the provisioner state is stored outside of the database.
+func (c ProvisionerStateStoreConfig) Enabled() bool {
+	return c.Directory.String() != "" || c.S3.Bucket.String() != ""
+}
+
+// ObjectStoreS3Config configures an S3-compatible bucket in which Coder stores objects, like audit log archives or
+// provisioner state.
+type ObjectStoreS3Config struct {
+	// The bucket in which objects are stored.
+	Bucket serpent.String `json:"bucket" typescript:",notnull"`
+	// A prefix prepended to the name of every object.
+	Prefix serpent.String `json:"prefix" typescript:",notnull"`
+	// The region of the bucket. Defaults to the region of the ambient AWS configuration.
+	Region serpent.String `json:"region" typescript:",notnull"`
+	// The endpoint of an S3-compatible object store. Defaults to AWS S3.
+	Endpoint serpent.URL `json:"endpoint" typescript:",notnull"`
 }
 
 type RateLimitConfig struct {
@@ -676,14 +708,20 @@ type NotificationsConfig struct {
 	// How often to query the database for queued notifications.
 	FetchInterval serpent.Duration `json:"fetch_interval"`
 
-	// Which delivery method to use (available options: 'smtp', 'webhook').
+	// Which delivery method to use (available options: 'smtp', 'webhook', 'slack', 'teams', 'inbox').
 	Method serpent.String `json:"method"`
 	// How long to wait while a notification is being sent before giving up.
 	DispatchTimeout serpent.Duration `json:"dispatch_timeout"`
+	// How long to collect notifications which have a digest before delivering them together; 0 disables digests.
+	DigestWindow serpent.Duration `json:"digest_window"`
 	// SMTP settings.
 	SMTP NotificationsEmailConfig `json:"email" typescript:",notnull"`
 	// Webhook settings.
 	Webhook NotificationsWebhookConfig `json:"webhook" typescript:",notnull"`
+	// Slack settings.
+	Slack NotificationsSlackConfig `json:"slack" typescript:",notnull"`
+	// Microsoft Teams settings.
+	Teams NotificationsTeamsConfig `json:"teams" typescript:",notnull"`
 }
 
 type NotificationsEmailConfig struct {
@@ -741,6 +779,67 @@ type NotificationsWebhookConfig struct {
 	Endpoint serpent.URL `json:"endpoint" typescript:",notnull"`
 }
 
+type NotificationsSlackConfig struct {
+	// The incoming webhook URL to which messages will be posted.
+	// Ignored if BotToken is set.
+	Endpoint serpent.URL `json:"endpoint" typescript:",notnull"`
+	// The bot token used to send direct messages to users, who are looked up by their email address.
+	BotToken serpent.String `json:"bot_token" typescript:",notnull"`
+	// The base URL of the Slack Web API.
+	APIURL serpent.URL `json:"api_url" typescript:",notnull"`
+}
+
+type NotificationsTeamsConfig struct {
+	// The workflow webhook URL to which Adaptive Card messages will be posted.
+	Endpoint serpent.URL `json:"endpoint" typescript:",notnull"`
+}
+
+// AuditLoggingConfig contains configuration for the retention of audit logs.
+type AuditLoggingConfig struct {
+	// How long audit logs are kept before they are deleted. Zero keeps them forever.
+	Retention serpent.Duration      `json:"retention" typescript:",notnull"`
+	Archive   AuditLogArchiveConfig `json:"archive" typescript:",notnull"`
+	Syslog    AuditLogSyslogConfig  `json:"syslog" typescript:",notnull"`
+	OTLP      AuditLogOTLPConfig    `json:"otlp" typescript:",notnull"`
+	// The directory in which audit logs are buffered until they have been delivered to the syslog or OTLP collector.
+	// Defaults to a directory within the cache directory.
+	BufferDir serpent.String `json:"buffer_dir" typescript:",notnull"`
+	// The maximum size in bytes of the buffer of each streaming backend. The oldest audit logs are dropped once it is
+	// full.
+	BufferMaxSize serpent.Int64 `json:"buffer_max_size" typescript:",notnull"`
+}
+
+// AuditLogArchiveConfig configures where audit logs are archived before they are deleted. At most one destination
+// may be set; if none is, expired audit logs are deleted without being archived.
+type AuditLogArchiveConfig struct {
+	// The local directory to which archives are written.
+	Directory serpent.String      `json:"directory" typescript:",notnull"`
+	S3        ObjectStoreS3Config `json:"s3" typescript:",notnull"`
+}
+
+// Enabled reports whether an archive destination has been configured.
+func (c AuditLogArchiveConfig) Enabled() bool {
+	return c.Directory.String() != "" || c.S3.Bucket.String() != ""
+}
+
+// AuditLogSyslogConfig configures streaming of audit logs to a syslog server as RFC 5424 messages.
+type AuditLogSyslogConfig struct {
+	// The host:port address of the syslog server. Messages are sent over TCP.
+	Address serpent.String `json:"address" typescript:",notnull"`
+	// Whether to connect to the syslog server with TLS, as described in RFC 5425.
+	TLS serpent.Bool `json:"tls" typescript:",notnull"`
+	// A PEM-encoded CA certificate used to verify the syslog server. Defaults to the system certificate pool.
+	TLSCAFile serpent.String `json:"tls_ca_file" typescript:",notnull"`
+}
+
+// AuditLogOTLPConfig configures streaming of audit logs to an OpenTelemetry collector as OTLP log records.
+type AuditLogOTLPConfig struct {
+	// The OTLP/HTTP endpoint of the collector.
+	Endpoint serpent.URL `json:"endpoint" typescript:",notnull"`
+	// Headers sent with every export request, formatted as key=value.
+	Headers serpent.StringArray `json:"headers" typescript:",notnull"`
+}
+
 const (
 	annotationFormatDuration = "format_duration"
 	annotationEnterpriseKey  = "enterprise"
@@ -896,6 +995,18 @@ func (c *DeploymentValues) Options() serpent.OptionSet {
 			Description: "O调整配置生成器的行为，生成器负责创建、更新和删除工作区资源。",
 			YAML:        "provisioning",
 		}
+		deploymentGroupProvisioningStateStore = serpent.Group{
+			Name:        "State Store",
+			Parent:      &deploymentGroupProvisioning,
+			Description: "Store the Terraform state of workspace builds outside of the database, keeping only a reference to it in the database. Every version of a workspace's state is kept under a new name. Use `coder server provisioner-state migrate` to move existing state into or out of the store.",
+			YAML:        "stateStore",
+		}
+		deploymentGroupProvisioningStateStoreS3 = serpent.Group{
+			Name:        "S3",
+			Parent:      &deploymentGroupProvisioningStateStore,
+			Description: "Store provisioner state in an S3-compatible bucket. Credentials are read from the standard AWS environment variables and configuration files.",
+			YAML:        "s3",
+		}
 		deploymentGroupUserQuietHoursSchedule = serpent.Group{
 			Name:        "User Quiet Hours Schedule",
 			Description: "O允许用户为工作区设置每天的安静时间安排，以避免工作区因模板安排而在白天停止。",
@@ -942,6 +1053,47 @@ func (c *DeploymentValues) Options() serpent.OptionSet {
 			Parent: &deploymentGroupNotifications,
 			YAML:   "webhook",
 		}
+		deploymentGroupNotificationsSlack = serpent.Group{
+			Name:        "Slack",
+			Parent:      &deploymentGroupNotifications,
+			Description: "Configure how Slack notifications are sent.",
+			YAML:        "slack",
+		}
+		deploymentGroupNotificationsTeams = serpent.Group{
+			Name:        "Microsoft Teams",
+			Parent:      &deploymentGroupNotifications,
+			Description: "Configure how Microsoft Teams notifications are sent.",
+			YAML:        "teams",
+		}
+		deploymentGroupAuditLogging = serpent.Group{
+			Name:        "Audit Logging",
+			YAML:        "auditLogging",
+			Description: "Configure how long audit logs are retained, where they are archived before deletion, and where they are streamed to.",
+		}
+		deploymentGroupAuditLoggingArchive = serpent.Group{
+			Name:        "Archive",
+			Parent:      &deploymentGroupAuditLogging,
+			Description: "Configure where expired audit logs are archived. Archives are written as gzipped JSON Lines files alongside a manifest of their checksums.",
+			YAML:        "archive",
+		}
+		deploymentGroupAuditLoggingArchiveS3 = serpent.Group{
+			Name:        "S3",
+			Parent:      &deploymentGroupAuditLoggingArchive,
+			Description: "Upload archives to an S3-compatible bucket. Credentials are read from the standard AWS environment variables and configuration files.",
+			YAML:        "s3",
+		}
+		deploymentGroupAuditLoggingSyslog = serpent.Group{
+			Name:        "Syslog",
+			Parent:      &deploymentGroupAuditLogging,
+			Description: "Stream audit logs to a syslog server as RFC 5424 messages over TCP or TLS.",
+			YAML:        "syslog",
+		}
+		deploymentGroupAuditLoggingOTLP = serpent.Group{
+			Name:        "OTLP",
+			Parent:      &deploymentGroupAuditLogging,
+			Description: "Stream audit logs to an OpenTelemetry collector as log records, using the OTLP/HTTP protocol.",
+			YAML:        "otlp",
+		}
 	)
 
 	httpAddress := serpent.Option{
@@ -1882,6 +2034,70 @@ func (c *DeploymentValues) Options() serpent.OptionSet {
 			Group:       &deploymentGroupProvisioning,
 			Annotations: serpent.Annotations{}.Mark(annotationSecretKey, "true"),
 		},
+		{
+			Name:        "Provisioner IaC Mirror Directory",
+			Description: "O包含 Terraform 和 OpenTofu 发布归档的本地目录，结构为 <engine>/<version>/<archive>.zip。内置供应商会安装其中的每个版本，并运行模板版本所需的版本.",
+			Flag:        "provisioner-iac-mirror-dir",
+			Env:         "CODER_PROVISIONER_IAC_MIRROR_DIR",
+			Value:       &c.Provisioner.IaCMirrorDir,
+			Group:       &deploymentGroupProvisioning,
+			YAML:        "iacMirrorDir",
+		},
+		{
+			Name:        "Provisioner Module Cache Max Size",
+			Description: "O内置供应商共享的远程 Terraform 模块缓存的最大大小 (以字节为单位)。只有固定到确切版本、版本标签或提交的模块才会被缓存，这样构建无需在每次 init 时下载它们。缓存已满时会淘汰最近最少使用的模块。设置为 0 时禁用缓存.",
+			Flag:        "provisioner-module-cache-max-size",
+			Env:         "CODER_PROVISIONER_MODULE_CACHE_MAX_SIZE",
+			Value:       &c.Provisioner.ModuleCacheMaxSize,
+			Default:     "0",
+			Group:       &deploymentGroupProvisioning,
+			YAML:        "moduleCacheMaxSize",
+		},
+		{
+			Name:        "Provisioner State Store: Directory",
+			Description: "The local directory in which the provisioner state of workspace builds is stored. Every replica must see the same directory, and the filesystem must support file locks.",
+			Flag:        "provisioner-state-store-dir",
+			Env:         "CODER_PROVISIONER_STATE_STORE_DIR",
+			Value:       &c.Provisioner.StateStore.Directory,
+			Group:       &deploymentGroupProvisioningStateStore,
+			YAML:        "directory",
+		},
+		{
+			Name:        "Provisioner State Store: S3: Bucket",
+			Description: "The bucket in which the provisioner state of workspace builds is stored.",
+			Flag:        "provisioner-state-store-s3-bucket",
+			Env:         "CODER_PROVISIONER_STATE_STORE_S3_BUCKET",
+			Value:       &c.Provisioner.StateStore.S3.Bucket,
+			Group:       &deploymentGroupProvisioningStateStoreS3,
+			YAML:        "bucket",
+		},
+		{
+			Name:        "Provisioner State Store: S3: Prefix",
+			Description: "A prefix prepended to the name of every object stored in the bucket.",
+			Flag:        "provisioner-state-store-s3-prefix",
+			Env:         "CODER_PROVISIONER_STATE_STORE_S3_PREFIX",
+			Value:       &c.Provisioner.StateStore.S3.Prefix,
+			Group:       &deploymentGroupProvisioningStateStoreS3,
+			YAML:        "prefix",
+		},
+		{
+			Name:        "Provisioner State Store: S3: Region",
+			Description: "The region of the bucket. Defaults to the region of the ambient AWS configuration.",
+			Flag:        "provisioner-state-store-s3-region",
+			Env:         "CODER_PROVISIONER_STATE_STORE_S3_REGION",
+			Value:       &c.Provisioner.StateStore.S3.Region,
+			Group:       &deploymentGroupProvisioningStateStoreS3,
+			YAML:        "region",
+		},
+		{
+			Name:        "Provisioner State Store: S3: Endpoint",
+			Description: "The endpoint of an S3-compatible object store, such as MinIO. Defaults to AWS S3 in the bucket's region. The object store must support conditional writes, which are used to lock state.",
+			Flag:        "provisioner-state-store-s3-endpoint",
+			Env:         "CODER_PROVISIONER_STATE_STORE_S3_ENDPOINT",
+			Value:       &c.Provisioner.StateStore.S3.Endpoint,
+			Group:       &deploymentGroupProvisioningStateStoreS3,
+			YAML:        "endpoint",
+		},
 		// RateLimit settings
 		{
 			Name:        "Disable All Rate Limits",
@@ -2180,6 +2396,26 @@ func (c *DeploymentValues) Options() serpent.OptionSet {
 			Value:       &c.AgentFallbackTroubleshootingURL,
 			YAML:        "agentFallbackTroubleshootingURL",
 		},
+		{
+			Name:        "Agent Metadata History Retention",
+			Description: "O用于显示趋势的工作区代理元数据样本（例如CPU和内存使用率）的保留时长。设置为0则只保留每个元数据键的最新值.",
+			Flag:        "agent-metadata-history-retention",
+			Env:         "CODER_AGENT_METADATA_HISTORY_RETENTION",
+			Default:     (24 * time.Hour).String(),
+			Value:       &c.AgentMetadataHistoryRetention,
+			YAML:        "agentMetadataHistoryRetention",
+			Annotations: serpent.Annotations{}.Mark(annotationFormatDuration, "true"),
+		},
+		{
+			Name:        "Workspace Drift Check Interval",
+			Description: "O检查每个运行中的工作区的基础设施是否偏离其 Terraform 状态的频率，例如在 Coder 之外被删除的虚拟机或卷。检查以低优先级的供应商作业运行。设置为 0 可禁用偏离检查.",
+			Flag:        "workspace-drift-check-interval",
+			Env:         "CODER_WORKSPACE_DRIFT_CHECK_INTERVAL",
+			Default:     "0",
+			Value:       &c.WorkspaceDriftCheckInterval,
+			YAML:        "workspaceDriftCheckInterval",
+			Annotations: serpent.Annotations{}.Mark(annotationFormatDuration, "true"),
+		},
 		{
 			Name:        "Browser Only",
 			Description: "O是否只允许通过浏览器连接工作区.",
@@ -2411,7 +2647,7 @@ func (c *DeploymentValues) Options() serpent.OptionSet {
 		// Notifications Options
 		{
 			Name:        "Notifications: Method",
-			Description: "Which delivery method to use (available options: 'smtp', 'webhook').",
+			Description: "Which delivery method to use (available options: 'smtp', 'webhook', 'slack', 'teams', 'inbox').",
 			Flag:        "notifications-method",
 			Env:         "CODER_NOTIFICATIONS_METHOD",
 			Value:       &c.Notifications.Method,
@@ -2430,6 +2666,17 @@ func (c *DeploymentValues) Options() serpent.OptionSet {
 			YAML:        "dispatchTimeout",
 			Annotations: serpent.Annotations{}.Mark(annotationFormatDuration, "true"),
 		},
+		{
+			Name:        "Notifications: Digest Window",
+			Description: "How long to collect notifications which have a digest before delivering them together in a single notification per user. Set to 0 to deliver every notification individually.",
+			Flag:        "notifications-digest-window",
+			Env:         "CODER_NOTIFICATIONS_DIGEST_WINDOW",
+			Value:       &c.Notifications.DigestWindow,
+			Default:     (5 * time.Minute).String(),
+			Group:       &deploymentGroupNotifications,
+			YAML:        "digestWindow",
+			Annotations: serpent.Annotations{}.Mark(annotationFormatDuration, "true"),
+		},
 		{
 			Name:        "Notifications: Email: From Address",
 			Description: "The sender's address to use.",
@@ -2568,6 +2815,44 @@ func (c *DeploymentValues) Options() serpent.OptionSet {
 			Group:       &deploymentGroupNotificationsWebhook,
 			YAML:        "endpoint",
 		},
+		{
+			Name:        "Notifications: Slack: Endpoint",
+			Description: "The Slack incoming webhook URL to which messages are posted. Ignored if a bot token is set.",
+			Flag:        "notifications-slack-endpoint",
+			Env:         "CODER_NOTIFICATIONS_SLACK_ENDPOINT",
+			Value:       &c.Notifications.Slack.Endpoint,
+			Group:       &deploymentGroupNotificationsSlack,
+			YAML:        "endpoint",
+		},
+		{
+			Name:        "Notifications: Slack: Bot Token",
+			Description: "The Slack bot token used to send direct messages to users, who are looked up by their email address. Requires the 'chat:write' and 'users:read.email' scopes.",
+			Flag:        "notifications-slack-bot-token",
+			Env:         "CODER_NOTIFICATIONS_SLACK_BOT_TOKEN",
+			Annotations: serpent.Annotations{}.Mark(annotationSecretKey, "true"),
+			Value:       &c.Notifications.Slack.BotToken,
+			Group:       &deploymentGroupNotificationsSlack,
+		},
+		{
+			Name:        "Notifications: Slack: API URL",
+			Description: "The base URL of the Slack Web API.",
+			Flag:        "notifications-slack-api-url",
+			Env:         "CODER_NOTIFICATIONS_SLACK_API_URL",
+			Value:       &c.Notifications.Slack.APIURL,
+			Default:     "https://slack.com/api",
+			Group:       &deploymentGroupNotificationsSlack,
+			YAML:        "apiURL",
+			Hidden:      true, // Hidden because most operators should not need to modify this.
+		},
+		{
+			Name:        "Notifications: Microsoft Teams: Endpoint",
+			Description: "The Microsoft Teams workflow webhook URL to which Adaptive Card messages are posted.",
+			Flag:        "notifications-teams-endpoint",
+			Env:         "CODER_NOTIFICATIONS_TEAMS_ENDPOINT",
+			Value:       &c.Notifications.Teams.Endpoint,
+			Group:       &deploymentGroupNotificationsTeams,
+			YAML:        "endpoint",
+		},
 		{
 			Name:        "Notifications: Max Send Attempts",
 			Description: "The upper limit of attempts to send a notification.",
@@ -2658,6 +2943,133 @@ func (c *DeploymentValues) Options() serpent.OptionSet {
 			Annotations: serpent.Annotations{}.Mark(annotationFormatDuration, "true"),
 			Hidden:      true, // Hidden because most operators should not need to modify this.
 		},
+		{
+			Name:        "Audit Logging: Retention",
+			Description: "How long audit logs are kept before they are deleted. Expired audit logs are archived first if an archive destination is configured. Set to 0 to keep audit logs forever.",
+			Flag:        "audit-logging-retention",
+			Env:         "CODER_AUDIT_LOGGING_RETENTION",
+			Value:       &c.AuditLogging.Retention,
+			Default:     "0",
+			Group:       &deploymentGroupAuditLogging,
+			YAML:        "retention",
+			Annotations: serpent.Annotations{}.Mark(annotationFormatDuration, "true"),
+		},
+		{
+			Name:        "Audit Logging: Archive: Directory",
+			Description: "The local directory to which expired audit logs are archived.",
+			Flag:        "audit-logging-archive-dir",
+			Env:         "CODER_AUDIT_LOGGING_ARCHIVE_DIR",
+			Value:       &c.AuditLogging.Archive.Directory,
+			Group:       &deploymentGroupAuditLoggingArchive,
+			YAML:        "directory",
+		},
+		{
+			Name:        "Audit Logging: Archive: S3: Bucket",
+			Description: "The bucket to which expired audit logs are archived.",
+			Flag:        "audit-logging-archive-s3-bucket",
+			Env:         "CODER_AUDIT_LOGGING_ARCHIVE_S3_BUCKET",
+			Value:       &c.AuditLogging.Archive.S3.Bucket,
+			Group:       &deploymentGroupAuditLoggingArchiveS3,
+			YAML:        "bucket",
+		},
+		{
+			Name:        "Audit Logging: Archive: S3: Prefix",
+			Description: "A prefix prepended to the name of every archive file uploaded to the bucket.",
+			Flag:        "audit-logging-archive-s3-prefix",
+			Env:         "CODER_AUDIT_LOGGING_ARCHIVE_S3_PREFIX",
+			Value:       &c.AuditLogging.Archive.S3.Prefix,
+			Group:       &deploymentGroupAuditLoggingArchiveS3,
+			YAML:        "prefix",
+		},
+		{
+			Name:        "Audit Logging: Archive: S3: Region",
+			Description: "The region of the bucket. Defaults to the region of the ambient AWS configuration.",
+			Flag:        "audit-logging-archive-s3-region",
+			Env:         "CODER_AUDIT_LOGGING_ARCHIVE_S3_REGION",
+			Value:       &c.AuditLogging.Archive.S3.Region,
+			Group:       &deploymentGroupAuditLoggingArchiveS3,
+			YAML:        "region",
+		},
+		{
+			Name:        "Audit Logging: Archive: S3: Endpoint",
+			Description: "The endpoint of an S3-compatible object store, such as MinIO. Defaults to AWS S3 in the bucket's region.",
+			Flag:        "audit-logging-archive-s3-endpoint",
+			Env:         "CODER_AUDIT_LOGGING_ARCHIVE_S3_ENDPOINT",
+			Value:       &c.AuditLogging.Archive.S3.Endpoint,
+			Group:       &deploymentGroupAuditLoggingArchiveS3,
+			YAML:        "endpoint",
+		},
+		{
+			Name:        "Audit Logging: Buffer Directory",
+			Description: "The directory in which audit logs are buffered until they have been delivered to the syslog server or OTLP collector. Defaults to a directory within the cache directory.",
+			Flag:        "audit-logging-buffer-dir",
+			Env:         "CODER_AUDIT_LOGGING_BUFFER_DIR",
+			Value:       &c.AuditLogging.BufferDir,
+			Group:       &deploymentGroupAuditLogging,
+			YAML:        "bufferDir",
+			Annotations: serpent.Annotations{}.Mark(annotationEnterpriseKey, "true"),
+		},
+		{
+			Name:        "Audit Logging: Buffer Max Size",
+			Description: "The maximum size in bytes of the buffer kept for each of the syslog server and OTLP collector. Once it is full, the oldest undelivered audit logs are dropped.",
+			Flag:        "audit-logging-buffer-max-size",
+			Env:         "CODER_AUDIT_LOGGING_BUFFER_MAX_SIZE",
+			Value:       &c.AuditLogging.BufferMaxSize,
+			Default:     "104857600", // 100 MiB
+			Group:       &deploymentGroupAuditLogging,
+			YAML:        "bufferMaxSize",
+			Annotations: serpent.Annotations{}.Mark(annotationEnterpriseKey, "true"),
+		},
+		{
+			Name:        "Audit Logging: Syslog: Address",
+			Description: "The host:port address of a syslog server to which audit logs are streamed over TCP.",
+			Flag:        "audit-logging-syslog-address",
+			Env:         "CODER_AUDIT_LOGGING_SYSLOG_ADDRESS",
+			Value:       &c.AuditLogging.Syslog.Address,
+			Group:       &deploymentGroupAuditLoggingSyslog,
+			YAML:        "address",
+			Annotations: serpent.Annotations{}.Mark(annotationEnterpriseKey, "true"),
+		},
+		{
+			Name:        "Audit Logging: Syslog: TLS",
+			Description: "Connect to the syslog server with TLS, as described in RFC 5425.",
+			Flag:        "audit-logging-syslog-tls",
+			Env:         "CODER_AUDIT_LOGGING_SYSLOG_TLS",
+			Value:       &c.AuditLogging.Syslog.TLS,
+			Default:     "false",
+			Group:       &deploymentGroupAuditLoggingSyslog,
+			YAML:        "tls",
+			Annotations: serpent.Annotations{}.Mark(annotationEnterpriseKey, "true"),
+		},
+		{
+			Name:        "Audit Logging: Syslog: TLS CA File",
+			Description: "A PEM-encoded CA certificate used to verify the syslog server. Defaults to the system certificate pool.",
+			Flag:        "audit-logging-syslog-tls-ca-file",
+			Env:         "CODER_AUDIT_LOGGING_SYSLOG_TLS_CA_FILE",
+			Value:       &c.AuditLogging.Syslog.TLSCAFile,
+			Group:       &deploymentGroupAuditLoggingSyslog,
+			YAML:        "tlsCAFile",
+			Annotations: serpent.Annotations{}.Mark(annotationEnterpriseKey, "true"),
+		},
+		{
+			Name:        "Audit Logging: OTLP: Endpoint",
+			Description: "The OTLP/HTTP endpoint of an OpenTelemetry collector to which audit logs are streamed, e.g. http://collector:4318. The /v1/logs path is used unless the URL includes a path.",
+			Flag:        "audit-logging-otlp-endpoint",
+			Env:         "CODER_AUDIT_LOGGING_OTLP_ENDPOINT",
+			Value:       &c.AuditLogging.OTLP.Endpoint,
+			Group:       &deploymentGroupAuditLoggingOTLP,
+			YAML:        "endpoint",
+			Annotations: serpent.Annotations{}.Mark(annotationEnterpriseKey, "true"),
+		},
+		{
+			Name:        "Audit Logging: OTLP: Headers",
+			Description: "Headers sent with every request to the OpenTelemetry collector, formatted as key=value, e.g. for authentication.",
+			Flag:        "audit-logging-otlp-headers",
+			Env:         "CODER_AUDIT_LOGGING_OTLP_HEADERS",
+			Value:       &c.AuditLogging.OTLP.Headers,
+			Group:       &deploymentGroupAuditLoggingOTLP,
+			Annotations: serpent.Annotations{}.Mark(annotationEnterpriseKey, "true").Mark(annotationSecretKey, "true"),
+		},
 	}
 
 	return opts
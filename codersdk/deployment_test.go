@@ -81,6 +81,12 @@ func TestDeploymentValues_HighlyConfigurable(t *testing.T) {
 		"Notifications: Email Auth: Password": {
 			yaml: true,
 		},
+		"Notifications: Slack: Bot Token": {
+			yaml: true,
+		},
+		"Audit Logging: OTLP: Headers": {
+			yaml: true,
+		},
 	}
 
 	set := (&codersdk.DeploymentValues{}).Options()
@@ -35,7 +35,86 @@ type NotificationMethodsResponse struct {
 type NotificationPreference struct {
 	NotificationTemplateID uuid.UUID `json:"id" format:"uuid"`
 	Disabled               bool      `json:"disabled"`
-	UpdatedAt              time.Time `json:"updated_at" format:"date-time"`
+	// Method is the user's chosen dispatch method for this template. It is empty if the template or deployment default
+	// applies.
+	Method    string    `json:"method,omitempty"`
+	UpdatedAt time.Time `json:"updated_at" format:"date-time"`
+}
+
+// NotificationQuietHours describes a daily window, in the user's timezone, during which non-urgent notifications are
+// held back and only delivered once the window ends.
+type NotificationQuietHours struct {
+	Enabled bool `json:"enabled"`
+	// Start and End are times of day in 24-hour "HH:MM" format. If End is before Start, the window spans midnight.
+	Start    string `json:"start,omitempty" example:"22:00"`
+	End      string `json:"end,omitempty" example:"07:00"`
+	Timezone string `json:"timezone,omitempty" example:"Europe/London"`
+}
+
+// InboxNotification is a notification which was delivered to a user's in-app inbox.
+type InboxNotification struct {
+	ID         uuid.UUID `json:"id" format:"uuid"`
+	UserID     uuid.UUID `json:"user_id" format:"uuid"`
+	TemplateID uuid.UUID `json:"template_id" format:"uuid"`
+	Title      string    `json:"title"`
+	// Content is the body of the notification, in markdown.
+	Content    string                    `json:"content"`
+	Actions    []InboxNotificationAction `json:"actions"`
+	ReadAt     *time.Time                `json:"read_at,omitempty" format:"date-time"`
+	ArchivedAt *time.Time                `json:"archived_at,omitempty" format:"date-time"`
+	CreatedAt  time.Time                 `json:"created_at" format:"date-time"`
+}
+
+type InboxNotificationAction struct {
+	Label string `json:"label"`
+	URL   string `json:"url"`
+}
+
+type InboxNotificationReadStatus string
+
+const (
+	InboxNotificationReadStatusAll    InboxNotificationReadStatus = "all"
+	InboxNotificationReadStatusRead   InboxNotificationReadStatus = "read"
+	InboxNotificationReadStatusUnread InboxNotificationReadStatus = "unread"
+)
+
+type ListInboxNotificationsRequest struct {
+	// ReadStatus filters notifications by whether they have been read. Defaults to all.
+	ReadStatus InboxNotificationReadStatus `json:"read_status,omitempty"`
+	// IncludeArchived includes archived notifications, which are otherwise omitted.
+	IncludeArchived bool `json:"include_archived,omitempty"`
+	Pagination
+}
+
+func (r ListInboxNotificationsRequest) asRequestOption() RequestOption {
+	return func(req *http.Request) {
+		q := req.URL.Query()
+		if r.ReadStatus != "" {
+			q.Set("read_status", string(r.ReadStatus))
+		}
+		if r.IncludeArchived {
+			q.Set("include_archived", "true")
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+}
+
+type ListInboxNotificationsResponse struct {
+	Notifications []InboxNotification `json:"notifications"`
+	// UnreadCount is the total number of unread, unarchived notifications in the user's inbox, regardless of the
+	// filters and pagination of the request.
+	UnreadCount int `json:"unread_count"`
+}
+
+// UpdateInboxNotificationRequest changes the state of an inbox notification. Fields which are omitted are left
+// unchanged.
+type UpdateInboxNotificationRequest struct {
+	Read     *bool `json:"read,omitempty"`
+	Archived *bool `json:"archived,omitempty"`
+}
+
+type MarkAllInboxNotificationsAsReadResponse struct {
+	Updated int64 `json:"updated"`
 }
 
 // GetNotificationsSettings retrieves the notifications settings, which currently just describes whether all
@@ -167,6 +246,140 @@ func (c *Client) UpdateUserNotificationPreferences(ctx context.Context, userID u
 	return prefs, nil
 }
 
+// GetUserNotificationQuietHours retrieves the notification quiet hours of a given user.
+func (c *Client) GetUserNotificationQuietHours(ctx context.Context, userID uuid.UUID) (NotificationQuietHours, error) {
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/users/%s/notifications/quiet-hours", userID.String()), nil)
+	if err != nil {
+		return NotificationQuietHours{}, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return NotificationQuietHours{}, ReadBodyAsError(res)
+	}
+
+	var qh NotificationQuietHours
+	return qh, json.NewDecoder(res.Body).Decode(&qh)
+}
+
+// UpdateUserNotificationQuietHours sets or, if not enabled, removes the notification quiet hours of a given user.
+func (c *Client) UpdateUserNotificationQuietHours(ctx context.Context, userID uuid.UUID, req NotificationQuietHours) (NotificationQuietHours, error) {
+	res, err := c.Request(ctx, http.MethodPut, fmt.Sprintf("/api/v2/users/%s/notifications/quiet-hours", userID.String()), req)
+	if err != nil {
+		return NotificationQuietHours{}, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return NotificationQuietHours{}, ReadBodyAsError(res)
+	}
+
+	var qh NotificationQuietHours
+	return qh, json.NewDecoder(res.Body).Decode(&qh)
+}
+
+// ListInboxNotifications lists the notifications in a user's inbox, newest first.
+func (c *Client) ListInboxNotifications(ctx context.Context, userID uuid.UUID, req ListInboxNotificationsRequest) (ListInboxNotificationsResponse, error) {
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/users/%s/notifications/inbox", userID.String()), nil,
+		req.Pagination.asRequestOption(),
+		req.asRequestOption(),
+	)
+	if err != nil {
+		return ListInboxNotificationsResponse{}, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return ListInboxNotificationsResponse{}, ReadBodyAsError(res)
+	}
+
+	var resp ListInboxNotificationsResponse
+	return resp, json.NewDecoder(res.Body).Decode(&resp)
+}
+
+// UpdateInboxNotification marks a notification in a user's inbox as read or unread, and/or archives or unarchives it.
+func (c *Client) UpdateInboxNotification(ctx context.Context, userID, notificationID uuid.UUID, req UpdateInboxNotificationRequest) (InboxNotification, error) {
+	res, err := c.Request(ctx, http.MethodPut, fmt.Sprintf("/api/v2/users/%s/notifications/inbox/%s", userID.String(), notificationID.String()), req)
+	if err != nil {
+		return InboxNotification{}, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return InboxNotification{}, ReadBodyAsError(res)
+	}
+
+	var notif InboxNotification
+	return notif, json.NewDecoder(res.Body).Decode(&notif)
+}
+
+// MarkAllInboxNotificationsAsRead marks every unread notification in a user's inbox as read.
+func (c *Client) MarkAllInboxNotificationsAsRead(ctx context.Context, userID uuid.UUID) (MarkAllInboxNotificationsAsReadResponse, error) {
+	res, err := c.Request(ctx, http.MethodPut, fmt.Sprintf("/api/v2/users/%s/notifications/inbox/mark-all-read", userID.String()), nil)
+	if err != nil {
+		return MarkAllInboxNotificationsAsReadResponse{}, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return MarkAllInboxNotificationsAsReadResponse{}, ReadBodyAsError(res)
+	}
+
+	var resp MarkAllInboxNotificationsAsReadResponse
+	return resp, json.NewDecoder(res.Body).Decode(&resp)
+}
+
+// WatchInboxNotifications streams notifications as they are delivered to a user's inbox. The returned channel is closed
+// when the context is canceled or the stream ends.
+func (c *Client) WatchInboxNotifications(ctx context.Context, userID uuid.UUID) (<-chan InboxNotification, error) {
+	//nolint:bodyclose
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/users/%s/notifications/inbox/watch", userID.String()), nil)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, ReadBodyAsError(res)
+	}
+	nextEvent := ServerSentEventReader(ctx, res.Body)
+
+	nc := make(chan InboxNotification, 256)
+	go func() {
+		defer close(nc)
+		defer res.Body.Close()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+				sse, err := nextEvent()
+				if err != nil {
+					return
+				}
+				if sse.Type != ServerSentEventTypeData {
+					continue
+				}
+				var notif InboxNotification
+				b, ok := sse.Data.([]byte)
+				if !ok {
+					return
+				}
+				err = json.Unmarshal(b, &notif)
+				if err != nil {
+					return
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case nc <- notif:
+				}
+			}
+		}
+	}()
+
+	return nc, nil
+}
+
 // GetNotificationDispatchMethods the available and default notification dispatch methods.
 func (c *Client) GetNotificationDispatchMethods(ctx context.Context) (NotificationMethodsResponse, error) {
 	res, err := c.Request(ctx, http.MethodGet, "/api/v2/notifications/dispatch-methods", nil)
@@ -198,4 +411,7 @@ type UpdateNotificationTemplateMethod struct {
 
 type UpdateUserNotificationPreferences struct {
 	TemplateDisabledMap map[string]bool `json:"template_disabled_map"`
+	// TemplateMethodMap sets the user's chosen dispatch method per template. An empty method reverts to the template or
+	// deployment default. Templates which are omitted retain their current method.
+	TemplateMethodMap map[string]string `json:"template_method_map,omitempty"`
 }
@@ -105,6 +105,14 @@ type CreateTemplateVersionRequest struct {
 	ExampleID       string                   `json:"example_id,omitempty" validate:"required_without=FileID"`
 	Provisioner     ProvisionerType          `json:"provisioner" validate:"oneof=terraform echo,required"`
 	ProvisionerTags map[string]string        `json:"tags"`
+	// IaCEngine optionally requires the version to be provisioned by a specific
+	// IaC engine. Jobs of the version, and of workspace builds that use it, are
+	// only acquired by provisioner daemons with a binary of the engine that
+	// satisfies IaCVersionConstraint.
+	IaCEngine string `json:"iac_engine,omitempty" enums:"terraform,opentofu"`
+	// IaCVersionConstraint constrains the version of IaCEngine, in the syntax of
+	// Terraform's required_version. If empty, any version is accepted.
+	IaCVersionConstraint string `json:"iac_version_constraint,omitempty" example:"~> 1.8.0"`
 
 	UserVariableValues []VariableValue `json:"user_variable_values,omitempty"`
 }
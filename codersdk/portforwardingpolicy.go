@@ -0,0 +1,140 @@
+package codersdk
+
+import (
+	"net/netip"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// PortForwardingPolicy restricts the SSH port forwards the agents of a
+// template's workspaces allow. Each kind of forward has its own rule. A nil
+// rule allows every forward of its kind, while a rule with empty allowlists
+// denies them all.
+type PortForwardingPolicy struct {
+	// DirectTCPIP restricts local port forwards (`ssh -L`, `coder
+	// port-forward`) by destination. Connections made to the workspace over
+	// the Coder network are checked against it as connections to localhost.
+	DirectTCPIP *PortForwardingRule `json:"direct_tcpip,omitempty"`
+	// RemoteForward restricts remote port forwards (`ssh -R`) by the address
+	// the agent listens on.
+	RemoteForward *PortForwardingRule `json:"remote_forward,omitempty"`
+	// UnixSocket restricts forwards of Unix sockets in both directions by
+	// socket path.
+	UnixSocket *UnixSocketForwardingRule `json:"unix_socket,omitempty"`
+}
+
+// PortForwardingRule allows TCP forwards whose address is in one of the
+// allowed CIDRs and whose port is in one of the allowed ports.
+type PortForwardingRule struct {
+	// AllowedCIDRs are CIDRs or single IP addresses, e.g. "127.0.0.0/8".
+	AllowedCIDRs []string `json:"allowed_cidrs"`
+	// AllowedPorts are ports or inclusive port ranges, e.g. "8000-8999".
+	AllowedPorts []string `json:"allowed_ports"`
+}
+
+// UnixSocketForwardingRule allows Unix socket forwards whose absolute path
+// matches one of the allowed glob patterns, e.g. "/run/user/*/gnupg/*".
+type UnixSocketForwardingRule struct {
+	AllowedPaths []string `json:"allowed_paths"`
+}
+
+// PortRange is an inclusive range of ports.
+type PortRange struct {
+	Start uint16
+	End   uint16
+}
+
+// Contains returns whether the port is in the range.
+func (r PortRange) Contains(port uint16) bool {
+	return port >= r.Start && port <= r.End
+}
+
+// Validate returns an error if any rule of the policy can't be parsed.
+func (p PortForwardingPolicy) Validate() error {
+	if p.DirectTCPIP != nil {
+		if err := p.DirectTCPIP.validate(); err != nil {
+			return xerrors.Errorf("direct_tcpip: %w", err)
+		}
+	}
+	if p.RemoteForward != nil {
+		if err := p.RemoteForward.validate(); err != nil {
+			return xerrors.Errorf("remote_forward: %w", err)
+		}
+	}
+	if p.UnixSocket != nil {
+		for _, pattern := range p.UnixSocket.AllowedPaths {
+			if !filepath.IsAbs(pattern) {
+				return xerrors.Errorf("unix_socket: path %q must be absolute", pattern)
+			}
+			if _, err := filepath.Match(pattern, ""); err != nil {
+				return xerrors.Errorf("unix_socket: invalid path pattern %q: %w", pattern, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (r PortForwardingRule) validate() error {
+	if _, err := r.Prefixes(); err != nil {
+		return err
+	}
+	if _, err := r.PortRanges(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Prefixes parses the allowed CIDRs of the rule.
+func (r PortForwardingRule) Prefixes() ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(r.AllowedCIDRs))
+	for _, cidr := range r.AllowedCIDRs {
+		if addr, err := netip.ParseAddr(cidr); err == nil {
+			prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return nil, xerrors.Errorf("invalid CIDR %q: %w", cidr, err)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes, nil
+}
+
+// PortRanges parses the allowed ports of the rule.
+func (r PortForwardingRule) PortRanges() ([]PortRange, error) {
+	ranges := make([]PortRange, 0, len(r.AllowedPorts))
+	for _, ports := range r.AllowedPorts {
+		start, end, isRange := strings.Cut(ports, "-")
+		if !isRange {
+			end = start
+		}
+		startPort, err := parseForwardingPort(start)
+		if err != nil {
+			return nil, xerrors.Errorf("invalid port range %q: %w", ports, err)
+		}
+		endPort, err := parseForwardingPort(end)
+		if err != nil {
+			return nil, xerrors.Errorf("invalid port range %q: %w", ports, err)
+		}
+		if startPort > endPort {
+			return nil, xerrors.Errorf("invalid port range %q: start is greater than end", ports)
+		}
+		ranges = append(ranges, PortRange{Start: startPort, End: endPort})
+	}
+	return ranges, nil
+}
+
+func parseForwardingPort(s string) (uint16, error) {
+	port, err := strconv.ParseUint(strings.TrimSpace(s), 10, 16)
+	if err != nil {
+		return 0, err
+	}
+	if port == 0 {
+		return 0, xerrors.New("port must be greater than zero")
+	}
+	return uint16(port), nil
+}
@@ -188,6 +188,10 @@ type ServeProvisionerDaemonRequest struct {
 	Provisioners []ProvisionerType `json:"provisioners"`
 	// Tags is a map of key-value pairs that tag the jobs this provisioner daemon can handle
 	Tags map[string]string `json:"tags"`
+	// IaCBinaries are the versions of IaC engines the provisioner daemon can run,
+	// formatted as "<engine>@<version>". Jobs of template versions that require an
+	// engine are only routed to provisioner daemons with a binary that satisfies it.
+	IaCBinaries []string `json:"iac_binaries"`
 	// PreSharedKey is an authentication key to use on the API instead of the normal session token from the client.
 	PreSharedKey string `json:"pre_shared_key"`
 	// ProvisionerKey is an authentication key to use on the API instead of the normal session token from the client.
@@ -219,6 +223,9 @@ func (c *Client) ServeProvisionerDaemon(ctx context.Context, req ServeProvisione
 	for key, value := range req.Tags {
 		query.Add("tag", fmt.Sprintf("%s=%s", key, value))
 	}
+	for _, binary := range req.IaCBinaries {
+		query.Add("iac_binary", binary)
+	}
 	serverURL.RawQuery = query.Encode()
 	httpClient := &http.Client{
 		Transport: c.HTTPClient.Transport,
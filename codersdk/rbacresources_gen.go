@@ -17,6 +17,7 @@ const (
 	ResourceGroup                  RBACResource = "group"
 	ResourceGroupMember            RBACResource = "group_member"
 	ResourceIdpsyncSettings        RBACResource = "idpsync_settings"
+	ResourceInboxNotification      RBACResource = "inbox_notification"
 	ResourceLicense                RBACResource = "license"
 	ResourceNotificationPreference RBACResource = "notification_preference"
 	ResourceNotificationTemplate   RBACResource = "notification_template"
@@ -28,6 +29,7 @@ const (
 	ResourceProvisionerDaemon      RBACResource = "provisioner_daemon"
 	ResourceProvisionerKeys        RBACResource = "provisioner_keys"
 	ResourceReplicas               RBACResource = "replicas"
+	ResourceSavedSearch            RBACResource = "saved_search"
 	ResourceSystem                 RBACResource = "system"
 	ResourceTailnetCoordinator     RBACResource = "tailnet_coordinator"
 	ResourceTemplate               RBACResource = "template"
@@ -47,6 +49,7 @@ const (
 	ActionRead               RBACAction = "read"
 	ActionReadPersonal       RBACAction = "read_personal"
 	ActionSSH                RBACAction = "ssh"
+	ActionShareSession       RBACAction = "share_session"
 	ActionUpdate             RBACAction = "update"
 	ActionUpdatePersonal     RBACAction = "update_personal"
 	ActionUse                RBACAction = "use"
@@ -71,6 +74,7 @@ var RBACResourceActions = map[RBACResource][]RBACAction{
 	ResourceGroup:                  {ActionCreate, ActionDelete, ActionRead, ActionUpdate},
 	ResourceGroupMember:            {ActionRead},
 	ResourceIdpsyncSettings:        {ActionRead, ActionUpdate},
+	ResourceInboxNotification:      {ActionCreate, ActionRead, ActionUpdate},
 	ResourceLicense:                {ActionCreate, ActionDelete, ActionRead},
 	ResourceNotificationPreference: {ActionRead, ActionUpdate},
 	ResourceNotificationTemplate:   {ActionRead, ActionUpdate},
@@ -82,11 +86,12 @@ var RBACResourceActions = map[RBACResource][]RBACAction{
 	ResourceProvisionerDaemon:      {ActionCreate, ActionDelete, ActionRead, ActionUpdate},
 	ResourceProvisionerKeys:        {ActionCreate, ActionDelete, ActionRead},
 	ResourceReplicas:               {ActionRead},
+	ResourceSavedSearch:            {ActionCreate, ActionDelete, ActionRead, ActionUpdate},
 	ResourceSystem:                 {ActionCreate, ActionDelete, ActionRead, ActionUpdate},
 	ResourceTailnetCoordinator:     {ActionCreate, ActionDelete, ActionRead, ActionUpdate},
 	ResourceTemplate:               {ActionCreate, ActionDelete, ActionRead, ActionUpdate, ActionViewInsights},
 	ResourceUser:                   {ActionCreate, ActionDelete, ActionRead, ActionReadPersonal, ActionUpdate, ActionUpdatePersonal},
-	ResourceWorkspace:              {ActionApplicationConnect, ActionCreate, ActionDelete, ActionRead, ActionSSH, ActionWorkspaceStart, ActionWorkspaceStop, ActionUpdate},
-	ResourceWorkspaceDormant:       {ActionApplicationConnect, ActionCreate, ActionDelete, ActionRead, ActionSSH, ActionWorkspaceStart, ActionWorkspaceStop, ActionUpdate},
+	ResourceWorkspace:              {ActionApplicationConnect, ActionCreate, ActionDelete, ActionRead, ActionShareSession, ActionSSH, ActionWorkspaceStart, ActionWorkspaceStop, ActionUpdate},
+	ResourceWorkspaceDormant:       {ActionApplicationConnect, ActionCreate, ActionDelete, ActionRead, ActionShareSession, ActionSSH, ActionWorkspaceStart, ActionWorkspaceStop, ActionUpdate},
 	ResourceWorkspaceProxy:         {ActionCreate, ActionDelete, ActionRead, ActionUpdate},
 }
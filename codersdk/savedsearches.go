@@ -0,0 +1,148 @@
+package codersdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SavedSearchResourceType is the kind of resource a saved search filters. It
+// determines the syntax the search query is validated against.
+type SavedSearchResourceType string
+
+const (
+	SavedSearchResourceTypeWorkspace SavedSearchResourceType = "workspace"
+	SavedSearchResourceTypeTemplate  SavedSearchResourceType = "template"
+	SavedSearchResourceTypeUser      SavedSearchResourceType = "user"
+	SavedSearchResourceTypeAuditLog  SavedSearchResourceType = "audit_log"
+)
+
+// SavedSearch is a named search query. Searches are private to the user who
+// saved them, unless they are shared with an organization or a group.
+type SavedSearch struct {
+	ID           uuid.UUID               `json:"id" format:"uuid"`
+	UserID       uuid.UUID               `json:"user_id" format:"uuid"`
+	ResourceType SavedSearchResourceType `json:"resource_type" enums:"workspace,template,user,audit_log"`
+	Name         string                  `json:"name"`
+	Query        string                  `json:"query"`
+	// OrganizationID is the organization the search is shared with. It is
+	// omitted for private searches.
+	OrganizationID *uuid.UUID `json:"organization_id,omitempty" format:"uuid"`
+	// GroupID is the group the search is shared with. It is omitted for
+	// private searches and for searches shared with a whole organization.
+	GroupID   *uuid.UUID `json:"group_id,omitempty" format:"uuid"`
+	CreatedAt time.Time  `json:"created_at" format:"date-time"`
+	UpdatedAt time.Time  `json:"updated_at" format:"date-time"`
+}
+
+// CreateSavedSearchRequest saves a search query under a name which is unique
+// per user and resource type. Setting GroupID alone shares the search with the
+// group's organization as well.
+type CreateSavedSearchRequest struct {
+	ResourceType   SavedSearchResourceType `json:"resource_type" validate:"required" enums:"workspace,template,user,audit_log"`
+	Name           string                  `json:"name" validate:"required,saved_search_name"`
+	Query          string                  `json:"query"`
+	OrganizationID *uuid.UUID              `json:"organization_id,omitempty" format:"uuid"`
+	GroupID        *uuid.UUID              `json:"group_id,omitempty" format:"uuid"`
+}
+
+// UpdateSavedSearchRequest replaces the name, query and sharing of a saved
+// search. Omitting OrganizationID and GroupID makes the search private.
+type UpdateSavedSearchRequest struct {
+	Name           string     `json:"name" validate:"required,saved_search_name"`
+	Query          string     `json:"query"`
+	OrganizationID *uuid.UUID `json:"organization_id,omitempty" format:"uuid"`
+	GroupID        *uuid.UUID `json:"group_id,omitempty" format:"uuid"`
+}
+
+// SavedSearchesRequest filters the saved searches returned for a user.
+type SavedSearchesRequest struct {
+	// ResourceType only returns searches for the given resource type.
+	ResourceType SavedSearchResourceType `json:"resource_type,omitempty"`
+}
+
+func (r SavedSearchesRequest) asRequestOption() RequestOption {
+	return func(req *http.Request) {
+		q := req.URL.Query()
+		if r.ResourceType != "" {
+			q.Set("type", string(r.ResourceType))
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+}
+
+// SavedSearches returns the searches saved by a user, followed by the
+// searches other users have shared with them.
+func (c *Client) SavedSearches(ctx context.Context, user string, req SavedSearchesRequest) ([]SavedSearch, error) {
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/users/%s/saved-searches", user), nil, req.asRequestOption())
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, ReadBodyAsError(res)
+	}
+	var searches []SavedSearch
+	return searches, json.NewDecoder(res.Body).Decode(&searches)
+}
+
+// SavedSearch returns a search saved by a user.
+func (c *Client) SavedSearch(ctx context.Context, user string, id uuid.UUID) (SavedSearch, error) {
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/users/%s/saved-searches/%s", user, id.String()), nil)
+	if err != nil {
+		return SavedSearch{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return SavedSearch{}, ReadBodyAsError(res)
+	}
+	var search SavedSearch
+	return search, json.NewDecoder(res.Body).Decode(&search)
+}
+
+// CreateSavedSearch saves a search for a user. The query is rejected if it is
+// not valid for the resource type.
+func (c *Client) CreateSavedSearch(ctx context.Context, user string, req CreateSavedSearchRequest) (SavedSearch, error) {
+	res, err := c.Request(ctx, http.MethodPost, fmt.Sprintf("/api/v2/users/%s/saved-searches", user), req)
+	if err != nil {
+		return SavedSearch{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusCreated {
+		return SavedSearch{}, ReadBodyAsError(res)
+	}
+	var search SavedSearch
+	return search, json.NewDecoder(res.Body).Decode(&search)
+}
+
+// UpdateSavedSearch replaces the name, query and sharing of a user's saved
+// search.
+func (c *Client) UpdateSavedSearch(ctx context.Context, user string, id uuid.UUID, req UpdateSavedSearchRequest) (SavedSearch, error) {
+	res, err := c.Request(ctx, http.MethodPut, fmt.Sprintf("/api/v2/users/%s/saved-searches/%s", user, id.String()), req)
+	if err != nil {
+		return SavedSearch{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return SavedSearch{}, ReadBodyAsError(res)
+	}
+	var search SavedSearch
+	return search, json.NewDecoder(res.Body).Decode(&search)
+}
+
+// DeleteSavedSearch deletes a user's saved search.
+func (c *Client) DeleteSavedSearch(ctx context.Context, user string, id uuid.UUID) error {
+	res, err := c.Request(ctx, http.MethodDelete, fmt.Sprintf("/api/v2/users/%s/saved-searches/%s", user, id.String()), nil)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusNoContent {
+		return ReadBodyAsError(res)
+	}
+	return nil
+}
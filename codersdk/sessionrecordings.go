@@ -0,0 +1,74 @@
+package codersdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WorkspaceAgentSessionRecording is the recording of a terminal session of a
+// workspace agent. Sessions are only recorded if the template of the
+// workspace enables session recording.
+type WorkspaceAgentSessionRecording struct {
+	ID          uuid.UUID `json:"id" format:"uuid"`
+	WorkspaceID uuid.UUID `json:"workspace_id" format:"uuid"`
+	AgentID     uuid.UUID `json:"agent_id" format:"uuid"`
+	// SessionType is the kind of SSH session that was recorded, e.g. "ssh"
+	// or "vscode".
+	SessionType string `json:"session_type"`
+	// Command is the command the session ran, empty for a login shell.
+	Command   string    `json:"command"`
+	StartedAt time.Time `json:"started_at" format:"date-time"`
+	// EndedAt is nil while the session is in progress.
+	EndedAt *time.Time `json:"ended_at,omitempty" format:"date-time"`
+	// Size is the number of bytes of the recording that were uploaded.
+	Size int64 `json:"size"`
+}
+
+// WorkspaceSessionRecordings returns the session recordings of a workspace,
+// newest first.
+func (c *Client) WorkspaceSessionRecordings(ctx context.Context, workspace uuid.UUID) ([]WorkspaceAgentSessionRecording, error) {
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/workspaces/%s/session-recordings", workspace), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, ReadBodyAsError(res)
+	}
+	var recordings []WorkspaceAgentSessionRecording
+	return recordings, json.NewDecoder(res.Body).Decode(&recordings)
+}
+
+// SessionRecording returns a session recording.
+func (c *Client) SessionRecording(ctx context.Context, id uuid.UUID) (WorkspaceAgentSessionRecording, error) {
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/session-recordings/%s", id), nil)
+	if err != nil {
+		return WorkspaceAgentSessionRecording{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return WorkspaceAgentSessionRecording{}, ReadBodyAsError(res)
+	}
+	var recording WorkspaceAgentSessionRecording
+	return recording, json.NewDecoder(res.Body).Decode(&recording)
+}
+
+// SessionRecordingCast streams the contents of a session recording in the
+// asciicast v2 format. The caller must close the returned reader.
+func (c *Client) SessionRecordingCast(ctx context.Context, id uuid.UUID) (io.ReadCloser, error) {
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/session-recordings/%s/cast", id), nil)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		defer res.Body.Close()
+		return nil, ReadBodyAsError(res)
+	}
+	return res.Body, nil
+}
@@ -61,6 +61,16 @@ type Template struct {
 	// template version.
 	RequireActiveVersion bool                         `json:"require_active_version"`
 	MaxPortShareLevel    WorkspaceAgentPortShareLevel `json:"max_port_share_level"`
+	// SessionRecording records the terminal sessions of workspaces built
+	// from the template.
+	SessionRecording bool `json:"session_recording"`
+	// PortForwardingPolicy restricts the SSH port forwards the agents of
+	// workspaces built from the template allow.
+	PortForwardingPolicy PortForwardingPolicy `json:"port_forwarding_policy"`
+	// AgentActivityBump bumps the deadline of workspaces built from the
+	// template while their agents report activity without a connection,
+	// e.g. a running build.
+	AgentActivityBump bool `json:"agent_activity_bump"`
 }
 
 // WeekdaysToBitmap converts a list of weekdays to a bitmap in accordance with
@@ -250,6 +260,17 @@ type UpdateTemplateMeta struct {
 	// of the template.
 	DisableEveryoneGroupAccess bool                          `json:"disable_everyone_group_access"`
 	MaxPortShareLevel          *WorkspaceAgentPortShareLevel `json:"max_port_share_level"`
+	// SessionRecording enables or disables recording the terminal sessions
+	// of workspaces built from the template. If nil, the setting is not
+	// changed.
+	SessionRecording *bool `json:"session_recording,omitempty"`
+	// PortForwardingPolicy replaces the port forwarding policy of the
+	// template. If nil, the policy is not changed.
+	PortForwardingPolicy *PortForwardingPolicy `json:"port_forwarding_policy,omitempty"`
+	// AgentActivityBump enables or disables bumping the deadline of
+	// workspaces built from the template on activity their agents report
+	// without a connection. If nil, the setting is not changed.
+	AgentActivityBump *bool `json:"agent_activity_bump,omitempty"`
 }
 
 type TemplateExample struct {
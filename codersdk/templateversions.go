@@ -30,6 +30,10 @@ type TemplateVersion struct {
 	Readme         string         `json:"readme"`
 	CreatedBy      MinimalUser    `json:"created_by"`
 	Archived       bool           `json:"archived"`
+	// IaCEngine and IaCVersionConstraint are the IaC engine, and the constraint
+	// on its version, that the version requires, if any.
+	IaCEngine            string `json:"iac_engine,omitempty"`
+	IaCVersionConstraint string `json:"iac_version_constraint,omitempty"`
 
 	Warnings []TemplateVersionWarning `json:"warnings,omitempty" enums:"DEPRECATED_PARAMETERS"`
 }
@@ -189,6 +193,11 @@ type CreateTemplateVersionDryRunRequest struct {
 	WorkspaceName       string                    `json:"workspace_name"`
 	RichParameterValues []WorkspaceBuildParameter `json:"rich_parameter_values"`
 	UserVariableValues  []VariableValue           `json:"user_variable_values,omitempty"`
+	// WorkspaceID previews an update of the workspace to the template
+	// version. The plan is made against the state of its latest build, and
+	// the changes to its resources are returned by
+	// TemplateVersionDryRunResourceChanges.
+	WorkspaceID uuid.UUID `json:"workspace_id,omitempty" format:"uuid"`
 }
 
 // CreateTemplateVersionDryRun begins a dry-run provisioner job against the
@@ -239,6 +248,62 @@ func (c *Client) TemplateVersionDryRunResources(ctx context.Context, version, jo
 	return resources, json.NewDecoder(res.Body).Decode(&resources)
 }
 
+// ResourceChangeAction is what an update of a workspace does to one of its
+// resources.
+type ResourceChangeAction string
+
+const (
+	ResourceChangeActionCreate  ResourceChangeAction = "create"
+	ResourceChangeActionUpdate  ResourceChangeAction = "update"
+	ResourceChangeActionReplace ResourceChangeAction = "replace"
+	ResourceChangeActionDelete  ResourceChangeAction = "delete"
+)
+
+// ResourceChange is a change to a resource of a workspace: either one a
+// dry-run plans to make when previewing an update of the workspace, or one
+// made outside of Coder that a drift check found.
+type ResourceChange struct {
+	Address    string                    `json:"address"`
+	Type       string                    `json:"type"`
+	Name       string                    `json:"name"`
+	Action     ResourceChangeAction      `json:"action" enums:"create,update,replace,delete"`
+	Attributes []ResourceAttributeChange `json:"attributes"`
+}
+
+// ResourceAttributeChange is a top-level attribute of a resource that
+// changes.
+type ResourceAttributeChange struct {
+	Name string `json:"name"`
+	// Before and After are the JSON encoded values of the attribute. They
+	// are empty if the attribute is sensitive, or if the resource doesn't
+	// have it before or after the change.
+	Before    string `json:"before,omitempty"`
+	After     string `json:"after,omitempty"`
+	Sensitive bool   `json:"sensitive"`
+	// Unknown is true if the value is only known once the change is made.
+	Unknown bool `json:"unknown"`
+	// ForcesReplacement is true if the change of the attribute is why the
+	// resource is replaced.
+	ForcesReplacement bool `json:"forces_replacement"`
+}
+
+// TemplateVersionDryRunResourceChanges returns the changes a finished
+// template version dry-run job plans to make to the resources of the
+// workspace it previews an update of.
+func (c *Client) TemplateVersionDryRunResourceChanges(ctx context.Context, version, job uuid.UUID) ([]ResourceChange, error) {
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/templateversions/%s/dry-run/%s/resource-changes", version, job), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, ReadBodyAsError(res)
+	}
+
+	var changes []ResourceChange
+	return changes, json.NewDecoder(res.Body).Decode(&changes)
+}
+
 // TemplateVersionDryRunLogsAfter streams logs for a template version dry-run
 // that occurred after a specific log ID.
 func (c *Client) TemplateVersionDryRunLogsAfter(ctx context.Context, version, job uuid.UUID, after int64) (<-chan ProvisionerJobLog, io.Closer, error) {
@@ -0,0 +1,68 @@
+package codersdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WorkspaceAgentContainer is a Docker or Podman container running in a
+// workspace.
+type WorkspaceAgentContainer struct {
+	ID string `json:"id"`
+	// Name is the name of the container without a leading slash. It can be
+	// used instead of the ID to attach to the container.
+	Name      string                        `json:"name"`
+	Image     string                        `json:"image"`
+	CreatedAt time.Time                     `json:"created_at" format:"date-time"`
+	Status    string                        `json:"status"`
+	Labels    map[string]string             `json:"labels"`
+	Ports     []WorkspaceAgentContainerPort `json:"ports"`
+	// DevcontainerWorkspaceFolder is the folder of the workspace the
+	// container was created from by the devcontainer CLI. It's empty if the
+	// container isn't a devcontainer.
+	DevcontainerWorkspaceFolder string `json:"devcontainer_workspace_folder,omitempty"`
+	// DevcontainerConfigPath is the path to the devcontainer.json the
+	// container was created from.
+	DevcontainerConfigPath string `json:"devcontainer_config_path,omitempty"`
+}
+
+// WorkspaceAgentContainerPort is a port of a container that is published on
+// the workspace.
+type WorkspaceAgentContainerPort struct {
+	// Port is the port inside the container.
+	Port uint16 `json:"port"`
+	// Network is the protocol of the port, "tcp" or "udp".
+	Network string `json:"network"`
+	// HostIP is the address the port is published on in the workspace.
+	HostIP   string `json:"host_ip,omitempty"`
+	HostPort uint16 `json:"host_port,omitempty"`
+}
+
+// WorkspaceAgentListContainersResponse is the list of containers running in
+// a workspace.
+type WorkspaceAgentListContainersResponse struct {
+	// Runtime is the container runtime the containers were listed with,
+	// "docker" or "podman". It's empty if there are no containers.
+	Runtime    string                    `json:"runtime"`
+	Containers []WorkspaceAgentContainer `json:"containers"`
+}
+
+// WorkspaceAgentListContainers returns the containers running in the
+// workspace of a workspace agent.
+func (c *Client) WorkspaceAgentListContainers(ctx context.Context, agentID uuid.UUID) (WorkspaceAgentListContainersResponse, error) {
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/workspaceagents/%s/containers", agentID), nil)
+	if err != nil {
+		return WorkspaceAgentListContainersResponse{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return WorkspaceAgentListContainersResponse{}, ReadBodyAsError(res)
+	}
+	var resp WorkspaceAgentListContainersResponse
+	return resp, json.NewDecoder(res.Body).Decode(&resp)
+}
@@ -0,0 +1,178 @@
+package codersdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+)
+
+// WorkspaceAgentFileInfo describes a file in a workspace.
+type WorkspaceAgentFileInfo struct {
+	Name string `json:"name"`
+	// Path is the absolute path of the file in the workspace.
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+	// Mode is the file mode in the format of `ls -l`, e.g. "-rw-r--r--".
+	Mode    string    `json:"mode"`
+	ModTime time.Time `json:"mod_time" format:"date-time"`
+	IsDir   bool      `json:"is_dir"`
+	// SymlinkTarget is the target of the file if it's a symbolic link.
+	SymlinkTarget string `json:"symlink_target,omitempty"`
+}
+
+// WorkspaceAgentFileList is the contents of a directory in a workspace.
+type WorkspaceAgentFileList struct {
+	// Path is the absolute path of the directory.
+	Path  string                   `json:"path"`
+	Files []WorkspaceAgentFileInfo `json:"files"`
+}
+
+// WorkspaceAgentFileUpload is the progress of a resumable upload to a
+// workspace.
+type WorkspaceAgentFileUpload struct {
+	// Path is the absolute path of the file being uploaded.
+	Path string `json:"path"`
+	// Offset is the number of bytes received so far. The next chunk of the
+	// upload must start at this offset.
+	Offset int64 `json:"offset"`
+	// Complete is true once the upload has been moved to its path.
+	Complete bool `json:"complete"`
+}
+
+// UploadWorkspaceAgentFileRequest describes a chunk of a resumable upload.
+// Chunks must be sent in order, and the chunk with Complete set moves the
+// uploaded file to its path.
+type UploadWorkspaceAgentFileRequest struct {
+	Path     string `json:"path"`
+	Offset   int64  `json:"offset"`
+	Complete bool   `json:"complete"`
+	// Mode is the permission bits of the file, e.g. 0o644. Only used when
+	// the upload is completed. Defaults to 0o644.
+	Mode uint32 `json:"mode,omitempty"`
+}
+
+// WorkspaceAgentFileStat returns information about a file in a workspace.
+// Relative paths are relative to the working directory of the agent.
+func (c *Client) WorkspaceAgentFileStat(ctx context.Context, agentID uuid.UUID, path string) (WorkspaceAgentFileInfo, error) {
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/workspaceagents/%s/files/stat", agentID), nil, WithQueryParam("path", path))
+	if err != nil {
+		return WorkspaceAgentFileInfo{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return WorkspaceAgentFileInfo{}, ReadBodyAsError(res)
+	}
+	var info WorkspaceAgentFileInfo
+	return info, json.NewDecoder(res.Body).Decode(&info)
+}
+
+// WorkspaceAgentListFiles lists the contents of a directory in a workspace.
+func (c *Client) WorkspaceAgentListFiles(ctx context.Context, agentID uuid.UUID, path string) (WorkspaceAgentFileList, error) {
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/workspaceagents/%s/files/list", agentID), nil, WithQueryParam("path", path))
+	if err != nil {
+		return WorkspaceAgentFileList{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return WorkspaceAgentFileList{}, ReadBodyAsError(res)
+	}
+	var list WorkspaceAgentFileList
+	return list, json.NewDecoder(res.Body).Decode(&list)
+}
+
+// WorkspaceAgentDownloadFile downloads a file from a workspace, starting at
+// offset to resume an interrupted download. The caller must close the
+// returned reader.
+func (c *Client) WorkspaceAgentDownloadFile(ctx context.Context, agentID uuid.UUID, path string, offset int64) (io.ReadCloser, error) {
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/workspaceagents/%s/files/download", agentID), nil,
+		WithQueryParam("path", path),
+		func(r *http.Request) {
+			if offset > 0 {
+				r.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+			}
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case res.StatusCode == http.StatusOK && offset == 0, res.StatusCode == http.StatusPartialContent:
+		return res.Body, nil
+	case res.StatusCode == http.StatusRequestedRangeNotSatisfiable:
+		// The file has no bytes after offset.
+		_ = res.Body.Close()
+		return io.NopCloser(http.NoBody), nil
+	case res.StatusCode == http.StatusOK:
+		_ = res.Body.Close()
+		return nil, xerrors.New("the workspace agent does not support resuming downloads")
+	default:
+		defer res.Body.Close()
+		return nil, ReadBodyAsError(res)
+	}
+}
+
+// WorkspaceAgentArchiveDirectory downloads a directory from a workspace as a
+// tar archive. The caller must close the returned reader.
+func (c *Client) WorkspaceAgentArchiveDirectory(ctx context.Context, agentID uuid.UUID, path string) (io.ReadCloser, error) {
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/workspaceagents/%s/files/archive", agentID), nil, WithQueryParam("path", path))
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		defer res.Body.Close()
+		return nil, ReadBodyAsError(res)
+	}
+	return res.Body, nil
+}
+
+// WorkspaceAgentFileUploadProgress returns the progress of an upload to a
+// workspace, so an interrupted upload can be resumed.
+func (c *Client) WorkspaceAgentFileUploadProgress(ctx context.Context, agentID uuid.UUID, path string) (WorkspaceAgentFileUpload, error) {
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/workspaceagents/%s/files/upload", agentID), nil, WithQueryParam("path", path))
+	if err != nil {
+		return WorkspaceAgentFileUpload{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return WorkspaceAgentFileUpload{}, ReadBodyAsError(res)
+	}
+	var upload WorkspaceAgentFileUpload
+	return upload, json.NewDecoder(res.Body).Decode(&upload)
+}
+
+// WorkspaceAgentUploadFile uploads a chunk of a file to a workspace.
+func (c *Client) WorkspaceAgentUploadFile(ctx context.Context, agentID uuid.UUID, req UploadWorkspaceAgentFileRequest, chunk io.Reader) (WorkspaceAgentFileUpload, error) {
+	opts := []RequestOption{
+		WithQueryParam("path", req.Path),
+		WithQueryParam("offset", strconv.FormatInt(req.Offset, 10)),
+		func(r *http.Request) {
+			r.Header.Set("Content-Type", "application/octet-stream")
+		},
+	}
+	if req.Complete {
+		opts = append(opts, WithQueryParam("complete", "true"))
+	}
+	if req.Mode != 0 {
+		opts = append(opts, WithQueryParam("mode", strconv.FormatUint(uint64(req.Mode), 8)))
+	}
+	if chunk == nil {
+		chunk = http.NoBody
+	}
+	res, err := c.Request(ctx, http.MethodPut, fmt.Sprintf("/api/v2/workspaceagents/%s/files/upload", agentID), chunk, opts...)
+	if err != nil {
+		return WorkspaceAgentFileUpload{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return WorkspaceAgentFileUpload{}, ReadBodyAsError(res)
+	}
+	var upload WorkspaceAgentFileUpload
+	return upload, json.NewDecoder(res.Body).Decode(&upload)
+}
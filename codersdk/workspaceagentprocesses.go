@@ -0,0 +1,95 @@
+package codersdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WorkspaceAgentProcess is a process running in a workspace.
+type WorkspaceAgentProcess struct {
+	PID       int32 `json:"pid"`
+	ParentPID int32 `json:"parent_pid"`
+	// Name is the name of the executable, truncated to 15 characters.
+	Name string `json:"name"`
+	// Command is the command line of the process. It's empty for kernel
+	// threads.
+	Command string `json:"command"`
+	User    string `json:"user"`
+	// CPUPercent is the CPU usage of the process averaged over its
+	// lifetime, like `ps` reports it. It may exceed 100 for processes that
+	// use multiple cores.
+	CPUPercent float64 `json:"cpu_percent"`
+	// MemoryBytes is the resident set size of the process.
+	MemoryBytes int64     `json:"memory_bytes"`
+	StartedAt   time.Time `json:"started_at" format:"date-time"`
+	// Children are the processes started by this process.
+	Children []WorkspaceAgentProcess `json:"children"`
+}
+
+// WorkspaceAgentProcessesResponse is the process tree of a workspace.
+type WorkspaceAgentProcessesResponse struct {
+	// Processes are the processes whose parent isn't visible to the agent,
+	// usually only the init process.
+	Processes []WorkspaceAgentProcess `json:"processes"`
+}
+
+// WorkspaceAgentExecRequest runs a command in a workspace without a
+// terminal.
+type WorkspaceAgentExecRequest struct {
+	// Command is run by the shell of the workspace user, like commands run
+	// over SSH.
+	Command string `json:"command" validate:"required"`
+	// Dir is the working directory of the command. Defaults to the working
+	// directory of the agent.
+	Dir string `json:"dir,omitempty"`
+	// TimeoutMillis kills the command if it runs longer. Defaults to no
+	// timeout.
+	TimeoutMillis int64 `json:"timeout_ms,omitempty"`
+}
+
+// WorkspaceAgentExecResponse is the result of a command run in a workspace.
+type WorkspaceAgentExecResponse struct {
+	// ExitCode is -1 if the command was killed by a signal, e.g. because it
+	// timed out.
+	ExitCode int    `json:"exit_code"`
+	Stdout   string `json:"stdout"`
+	Stderr   string `json:"stderr"`
+	// Truncated is true if the command wrote more output than the agent
+	// keeps, which is 1 MiB per stream.
+	Truncated bool `json:"truncated"`
+	TimedOut  bool `json:"timed_out"`
+}
+
+// WorkspaceAgentProcesses returns the process tree of a workspace agent.
+func (c *Client) WorkspaceAgentProcesses(ctx context.Context, agentID uuid.UUID) (WorkspaceAgentProcessesResponse, error) {
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/workspaceagents/%s/processes", agentID), nil)
+	if err != nil {
+		return WorkspaceAgentProcessesResponse{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return WorkspaceAgentProcessesResponse{}, ReadBodyAsError(res)
+	}
+	var resp WorkspaceAgentProcessesResponse
+	return resp, json.NewDecoder(res.Body).Decode(&resp)
+}
+
+// WorkspaceAgentExec runs a command in a workspace and waits for it to
+// exit.
+func (c *Client) WorkspaceAgentExec(ctx context.Context, agentID uuid.UUID, req WorkspaceAgentExecRequest) (WorkspaceAgentExecResponse, error) {
+	res, err := c.Request(ctx, http.MethodPost, fmt.Sprintf("/api/v2/workspaceagents/%s/exec", agentID), req)
+	if err != nil {
+		return WorkspaceAgentExecResponse{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return WorkspaceAgentExecResponse{}, ReadBodyAsError(res)
+	}
+	var resp WorkspaceAgentExecResponse
+	return resp, json.NewDecoder(res.Body).Decode(&resp)
+}
@@ -0,0 +1,57 @@
+package codersdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WorkspaceAgentReconnectingPTY is a live reconnecting PTY session of a
+// workspace agent, e.g. a web terminal.
+type WorkspaceAgentReconnectingPTY struct {
+	ID uuid.UUID `json:"id" format:"uuid"`
+	// UserID is the user that started the session.
+	UserID uuid.UUID `json:"user_id" format:"uuid"`
+	// Command is the command the session was started with. It's empty for
+	// the login shell of the workspace user.
+	Command string `json:"command"`
+	// Container is the container of the workspace the session runs in.
+	Container string    `json:"container,omitempty"`
+	StartedAt time.Time `json:"started_at" format:"date-time"`
+	// Connections are the clients attached to the session.
+	Connections []WorkspaceAgentReconnectingPTYConnection `json:"connections"`
+}
+
+// WorkspaceAgentReconnectingPTYConnection is a client attached to a
+// reconnecting PTY session.
+type WorkspaceAgentReconnectingPTYConnection struct {
+	ID uuid.UUID `json:"id" format:"uuid"`
+	// ReadOnly is true if the input of the client is discarded.
+	ReadOnly   bool      `json:"read_only"`
+	AttachedAt time.Time `json:"attached_at" format:"date-time"`
+}
+
+// WorkspaceAgentReconnectingPTYsResponse is the list of live reconnecting
+// PTY sessions of a workspace agent.
+type WorkspaceAgentReconnectingPTYsResponse struct {
+	Sessions []WorkspaceAgentReconnectingPTY `json:"sessions"`
+}
+
+// WorkspaceAgentReconnectingPTYs returns the live reconnecting PTY sessions
+// of a workspace agent.
+func (c *Client) WorkspaceAgentReconnectingPTYs(ctx context.Context, agentID uuid.UUID) (WorkspaceAgentReconnectingPTYsResponse, error) {
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/workspaceagents/%s/reconnecting-ptys", agentID), nil)
+	if err != nil {
+		return WorkspaceAgentReconnectingPTYsResponse{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return WorkspaceAgentReconnectingPTYsResponse{}, ReadBodyAsError(res)
+	}
+	var resp WorkspaceAgentReconnectingPTYsResponse
+	return resp, json.NewDecoder(res.Body).Decode(&resp)
+}
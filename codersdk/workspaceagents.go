@@ -7,6 +7,8 @@ import (
 	"io"
 	"net/http"
 	"net/http/cookiejar"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -321,6 +323,52 @@ func (c *Client) WatchWorkspaceAgentMetadata(ctx context.Context, id uuid.UUID)
 	return metadataChan, errorChan
 }
 
+// WorkspaceAgentMetadataSample is a value of workspace agent metadata that
+// was collected in the past.
+type WorkspaceAgentMetadataSample struct {
+	CollectedAt time.Time `json:"collected_at" format:"date-time"`
+	Value       string    `json:"value"`
+	Error       string    `json:"error"`
+}
+
+// WorkspaceAgentMetadataHistoryRequest filters the samples returned by
+// WorkspaceAgentMetadataHistory.
+type WorkspaceAgentMetadataHistoryRequest struct {
+	// After only returns samples collected after this time.
+	After time.Time `json:"after,omitempty" format:"date-time"`
+	// Limit is the maximum number of the newest samples to return.
+	Limit int `json:"limit,omitempty"`
+}
+
+func (r WorkspaceAgentMetadataHistoryRequest) asRequestOption() RequestOption {
+	return func(req *http.Request) {
+		q := req.URL.Query()
+		if !r.After.IsZero() {
+			q.Set("after", r.After.Format(time.RFC3339Nano))
+		}
+		if r.Limit > 0 {
+			q.Set("limit", strconv.Itoa(r.Limit))
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+}
+
+// WorkspaceAgentMetadataHistory returns the recorded samples of a metadata key
+// of a workspace agent, from oldest to newest. Samples are only recorded when
+// the deployment has agent metadata history retention enabled.
+func (c *Client) WorkspaceAgentMetadataHistory(ctx context.Context, agentID uuid.UUID, key string, req WorkspaceAgentMetadataHistoryRequest) ([]WorkspaceAgentMetadataSample, error) {
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/workspaceagents/%s/metadata/%s/history", agentID, url.PathEscape(key)), nil, req.asRequestOption())
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, ReadBodyAsError(res)
+	}
+	var samples []WorkspaceAgentMetadataSample
+	return samples, json.NewDecoder(res.Body).Decode(&samples)
+}
+
 // WorkspaceAgent returns an agent by ID.
 func (c *Client) WorkspaceAgent(ctx context.Context, id uuid.UUID) (WorkspaceAgent, error) {
 	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/workspaceagents/%s", id), nil)
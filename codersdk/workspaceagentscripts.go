@@ -0,0 +1,78 @@
+package codersdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WorkspaceAgentScriptRunStage is why a script was run.
+type WorkspaceAgentScriptRunStage string
+
+const (
+	WorkspaceAgentScriptRunStageStart  WorkspaceAgentScriptRunStage = "start"
+	WorkspaceAgentScriptRunStageStop   WorkspaceAgentScriptRunStage = "stop"
+	WorkspaceAgentScriptRunStageCron   WorkspaceAgentScriptRunStage = "cron"
+	WorkspaceAgentScriptRunStageManual WorkspaceAgentScriptRunStage = "manual"
+)
+
+// WorkspaceAgentScriptRunStatus is how a run of a script ended.
+type WorkspaceAgentScriptRunStatus string
+
+const (
+	WorkspaceAgentScriptRunStatusOK            WorkspaceAgentScriptRunStatus = "ok"
+	WorkspaceAgentScriptRunStatusExitFailure   WorkspaceAgentScriptRunStatus = "exit_failure"
+	WorkspaceAgentScriptRunStatusTimedOut      WorkspaceAgentScriptRunStatus = "timed_out"
+	WorkspaceAgentScriptRunStatusPipesLeftOpen WorkspaceAgentScriptRunStatus = "pipes_left_open"
+)
+
+// WorkspaceAgentScriptRun is a completed run of a workspace agent script.
+type WorkspaceAgentScriptRun struct {
+	ScriptID  uuid.UUID                     `json:"script_id" format:"uuid"`
+	Stage     WorkspaceAgentScriptRunStage  `json:"stage" enums:"start,stop,cron,manual"`
+	Status    WorkspaceAgentScriptRunStatus `json:"status" enums:"ok,exit_failure,timed_out,pipes_left_open"`
+	ExitCode  int32                         `json:"exit_code"`
+	StartedAt time.Time                     `json:"started_at" format:"date-time"`
+	EndedAt   time.Time                     `json:"ended_at" format:"date-time"`
+	// LogSourceID is the source of the output of the run in the logs of the
+	// agent. The output is the logs of the source created between the start
+	// and the end of the run.
+	LogSourceID uuid.UUID `json:"log_source_id" format:"uuid"`
+	// LogPath is the file in the workspace the output of the latest run of
+	// the script is written to.
+	LogPath string `json:"log_path"`
+}
+
+// WorkspaceAgentScriptRuns returns the most recent runs of a workspace agent
+// script, newest first.
+func (c *Client) WorkspaceAgentScriptRuns(ctx context.Context, agentID, scriptID uuid.UUID) ([]WorkspaceAgentScriptRun, error) {
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/workspaceagents/%s/scripts/%s/runs", agentID, scriptID), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, ReadBodyAsError(res)
+	}
+	var runs []WorkspaceAgentScriptRun
+	return runs, json.NewDecoder(res.Body).Decode(&runs)
+}
+
+// WorkspaceAgentRunScript runs a workspace agent script on demand. It returns
+// once the script has started, the run is added to the runs of the script
+// when it exits.
+func (c *Client) WorkspaceAgentRunScript(ctx context.Context, agentID, scriptID uuid.UUID) error {
+	res, err := c.Request(ctx, http.MethodPost, fmt.Sprintf("/api/v2/workspaceagents/%s/scripts/%s/run", agentID, scriptID), nil)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusAccepted {
+		return ReadBodyAsError(res)
+	}
+	return nil
+}
@@ -34,6 +34,24 @@ var MapWorkspaceAppSharingLevels = map[WorkspaceAppSharingLevel]struct{}{
 	WorkspaceAppSharingLevelPublic:        {},
 }
 
+type WorkspaceAppHealthcheckKind string
+
+const (
+	WorkspaceAppHealthcheckKindHTTP    WorkspaceAppHealthcheckKind = "http"
+	WorkspaceAppHealthcheckKindTCP     WorkspaceAppHealthcheckKind = "tcp"
+	WorkspaceAppHealthcheckKindGRPC    WorkspaceAppHealthcheckKind = "grpc"
+	WorkspaceAppHealthcheckKindCommand WorkspaceAppHealthcheckKind = "command"
+	WorkspaceAppHealthcheckKindFile    WorkspaceAppHealthcheckKind = "file"
+)
+
+var MapWorkspaceAppHealthcheckKinds = map[WorkspaceAppHealthcheckKind]struct{}{
+	WorkspaceAppHealthcheckKindHTTP:    {},
+	WorkspaceAppHealthcheckKindTCP:     {},
+	WorkspaceAppHealthcheckKindGRPC:    {},
+	WorkspaceAppHealthcheckKindCommand: {},
+	WorkspaceAppHealthcheckKindFile:    {},
+}
+
 type WorkspaceApp struct {
 	ID uuid.UUID `json:"id" format:"uuid"`
 	// URL is the address being proxied to inside the workspace.
@@ -71,4 +89,23 @@ type Healthcheck struct {
 	Interval int32 `json:"interval"`
 	// Threshold specifies the number of consecutive failed health checks before returning "unhealthy".
 	Threshold int32 `json:"threshold"`
+	// Kind specifies how the app health is checked. An empty kind is "http".
+	Kind WorkspaceAppHealthcheckKind `json:"kind,omitempty" enums:"http,tcp,grpc,command,file"`
+	// ExpectedStatus is the status code an "http" health check must return.
+	// When zero, any status code below 500 is healthy.
+	ExpectedStatus int32 `json:"expected_status,omitempty"`
+	// BodyRegex is a regular expression the response body of an "http"
+	// health check must match.
+	BodyRegex string `json:"body_regex,omitempty"`
+	// Address is the host and port a "tcp" or "grpc" health check connects to.
+	Address string `json:"address,omitempty"`
+	// GRPCService is the service a "grpc" health check asks the status of
+	// using the gRPC health checking protocol. When empty, the status of the
+	// server is checked.
+	GRPCService string `json:"grpc_service,omitempty"`
+	// Command is run by a "command" health check, which is healthy when the
+	// command exits with code 0.
+	Command string `json:"command,omitempty"`
+	// Path is the file a "file" health check checks the existence of.
+	Path string `json:"path,omitempty"`
 }
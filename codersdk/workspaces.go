@@ -72,6 +72,10 @@ func (w Workspace) FullName() string {
 type WorkspaceHealth struct {
 	Healthy       bool        `json:"healthy" example:"false"`      // Healthy is true if the workspace is healthy.
 	FailingAgents []uuid.UUID `json:"failing_agents" format:"uuid"` // FailingAgents lists the IDs of the agents that are failing, if any.
+	// DriftedResources lists the resources that were changed or deleted
+	// outside of Coder since the latest build, as found by the latest drift
+	// check of the workspace.
+	DriftedResources []ResourceChange `json:"drifted_resources"`
 }
 
 type WorkspacesRequest struct {
@@ -480,6 +484,34 @@ func (c *Client) UpdateWorkspaceAutomaticUpdates(ctx context.Context, id uuid.UU
 	return nil
 }
 
+// CloneWorkspaceRequest creates a workspace for the authenticated user from an
+// existing workspace, which may be owned by another user. The clone uses the
+// template version, build parameters, schedule and automatic updates setting
+// of the source workspace.
+type CloneWorkspaceRequest struct {
+	Name string `json:"name" validate:"workspace_name,required"`
+	// RichParameterValues override the build parameters of the source
+	// workspace. Ephemeral parameters are never copied, so they must be
+	// provided here if needed.
+	RichParameterValues []WorkspaceBuildParameter `json:"rich_parameter_values,omitempty"`
+}
+
+// CloneWorkspace creates a workspace for the authenticated user from an
+// existing workspace.
+func (c *Client) CloneWorkspace(ctx context.Context, id uuid.UUID, req CloneWorkspaceRequest) (Workspace, error) {
+	path := fmt.Sprintf("/api/v2/workspaces/%s/clone", id.String())
+	res, err := c.Request(ctx, http.MethodPost, path, req)
+	if err != nil {
+		return Workspace{}, xerrors.Errorf("clone workspace: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusCreated {
+		return Workspace{}, ReadBodyAsError(res)
+	}
+	var workspace Workspace
+	return workspace, json.NewDecoder(res.Body).Decode(&workspace)
+}
+
 type WorkspaceFilter struct {
 	// Owner can be "me" or a username
 	Owner string `json:"owner,omitempty" typescript:"-"`
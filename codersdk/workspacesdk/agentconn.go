@@ -1,6 +1,7 @@
 package workspacesdk
 
 import (
+	"bytes"
 	"context"
 	"encoding/binary"
 	"encoding/json"
@@ -9,6 +10,7 @@ import (
 	"net"
 	"net/http"
 	"net/netip"
+	"net/url"
 	"strconv"
 	"time"
 
@@ -93,6 +95,62 @@ type AgentReconnectingPTYInit struct {
 	Height  uint16
 	Width   uint16
 	Command string
+	// Container is the name or ID of a container in the workspace the
+	// command is run in. If empty, the command runs in the workspace.
+	Container string
+	// ContainerUser is the user the command runs as in the container. If
+	// empty, the default user of the container is used.
+	ContainerUser string
+	// ReadOnly attaches to an existing session as a viewer whose input and
+	// resizes are discarded.
+	ReadOnly bool
+	// Takeover detaches the other clients of the session that aren't read
+	// only when attaching.
+	Takeover bool
+	// UserID is the user the connection is made for, who is recorded as the
+	// user that started the session if it's new.
+	UserID uuid.UUID
+	// ShareSession allows attaching to a session that was started by another
+	// user.
+	ShareSession bool
+}
+
+// AgentReconnectingPTYInitOption is a functional option for
+// AgentReconnectingPTYInit.
+type AgentReconnectingPTYInitOption func(*AgentReconnectingPTYInit)
+
+// AgentReconnectingPTYInitWithContainer runs the command of the reconnecting
+// PTY in a container of the workspace as the user.
+func AgentReconnectingPTYInitWithContainer(container, user string) AgentReconnectingPTYInitOption {
+	return func(init *AgentReconnectingPTYInit) {
+		init.Container = container
+		init.ContainerUser = user
+	}
+}
+
+// AgentReconnectingPTYInitWithReadOnly attaches to an existing reconnecting
+// PTY as a viewer.
+func AgentReconnectingPTYInitWithReadOnly() AgentReconnectingPTYInitOption {
+	return func(init *AgentReconnectingPTYInit) {
+		init.ReadOnly = true
+	}
+}
+
+// AgentReconnectingPTYInitWithTakeover detaches the other writers of the
+// reconnecting PTY when attaching.
+func AgentReconnectingPTYInitWithTakeover() AgentReconnectingPTYInitOption {
+	return func(init *AgentReconnectingPTYInit) {
+		init.Takeover = true
+	}
+}
+
+// AgentReconnectingPTYInitWithUser makes the connection for the user. The
+// session of another user can only be attached to with shareSession.
+func AgentReconnectingPTYInitWithUser(userID uuid.UUID, shareSession bool) AgentReconnectingPTYInitOption {
+	return func(init *AgentReconnectingPTYInit) {
+		init.UserID = userID
+		init.ShareSession = shareSession
+	}
 }
 
 // ReconnectingPTYRequest is sent from the client to the server
@@ -107,7 +165,7 @@ type ReconnectingPTYRequest struct {
 // ReconnectingPTY spawns a new reconnecting terminal session.
 // `ReconnectingPTYRequest` should be JSON marshaled and written to the returned net.Conn.
 // Raw terminal output will be read from the returned net.Conn.
-func (c *AgentConn) ReconnectingPTY(ctx context.Context, id uuid.UUID, height, width uint16, command string) (net.Conn, error) {
+func (c *AgentConn) ReconnectingPTY(ctx context.Context, id uuid.UUID, height, width uint16, command string, initOpts ...AgentReconnectingPTYInitOption) (net.Conn, error) {
 	ctx, span := tracing.StartSpan(ctx)
 	defer span.End()
 
@@ -119,12 +177,16 @@ func (c *AgentConn) ReconnectingPTY(ctx context.Context, id uuid.UUID, height, w
 	if err != nil {
 		return nil, err
 	}
-	data, err := json.Marshal(AgentReconnectingPTYInit{
+	init := AgentReconnectingPTYInit{
 		ID:      id,
 		Height:  height,
 		Width:   width,
 		Command: command,
-	})
+	}
+	for _, o := range initOpts {
+		o(&init)
+	}
+	data, err := json.Marshal(init)
 	if err != nil {
 		_ = conn.Close()
 		return nil, err
@@ -242,6 +304,77 @@ func (c *AgentConn) ListeningPorts(ctx context.Context) (codersdk.WorkspaceAgent
 	return resp, json.NewDecoder(res.Body).Decode(&resp)
 }
 
+// ReconnectingPTYs lists the live reconnecting PTY sessions of the agent.
+func (c *AgentConn) ReconnectingPTYs(ctx context.Context) (codersdk.WorkspaceAgentReconnectingPTYsResponse, error) {
+	ctx, span := tracing.StartSpan(ctx)
+	defer span.End()
+	res, err := c.apiRequest(ctx, http.MethodGet, "/api/v0/reconnecting-ptys", nil)
+	if err != nil {
+		return codersdk.WorkspaceAgentReconnectingPTYsResponse{}, xerrors.Errorf("do request: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return codersdk.WorkspaceAgentReconnectingPTYsResponse{}, codersdk.ReadBodyAsError(res)
+	}
+
+	var resp codersdk.WorkspaceAgentReconnectingPTYsResponse
+	return resp, json.NewDecoder(res.Body).Decode(&resp)
+}
+
+// Processes returns the process tree of the workspace.
+func (c *AgentConn) Processes(ctx context.Context) (codersdk.WorkspaceAgentProcessesResponse, error) {
+	ctx, span := tracing.StartSpan(ctx)
+	defer span.End()
+	res, err := c.apiRequest(ctx, http.MethodGet, "/api/v0/processes", nil)
+	if err != nil {
+		return codersdk.WorkspaceAgentProcessesResponse{}, xerrors.Errorf("do request: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return codersdk.WorkspaceAgentProcessesResponse{}, codersdk.ReadBodyAsError(res)
+	}
+
+	var resp codersdk.WorkspaceAgentProcessesResponse
+	return resp, json.NewDecoder(res.Body).Decode(&resp)
+}
+
+// Exec runs a command in the workspace and waits for it to exit.
+func (c *AgentConn) Exec(ctx context.Context, req codersdk.WorkspaceAgentExecRequest) (codersdk.WorkspaceAgentExecResponse, error) {
+	ctx, span := tracing.StartSpan(ctx)
+	defer span.End()
+	body, err := json.Marshal(req)
+	if err != nil {
+		return codersdk.WorkspaceAgentExecResponse{}, xerrors.Errorf("marshal request: %w", err)
+	}
+	res, err := c.apiRequest(ctx, http.MethodPost, "/api/v0/exec", bytes.NewReader(body))
+	if err != nil {
+		return codersdk.WorkspaceAgentExecResponse{}, xerrors.Errorf("do request: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return codersdk.WorkspaceAgentExecResponse{}, codersdk.ReadBodyAsError(res)
+	}
+
+	var resp codersdk.WorkspaceAgentExecResponse
+	return resp, json.NewDecoder(res.Body).Decode(&resp)
+}
+
+// RunScript runs a script of the workspace agent on demand. It returns once
+// the script has started.
+func (c *AgentConn) RunScript(ctx context.Context, id uuid.UUID) error {
+	ctx, span := tracing.StartSpan(ctx)
+	defer span.End()
+	res, err := c.apiRequest(ctx, http.MethodPost, fmt.Sprintf("/api/v0/scripts/%s/run", id), nil)
+	if err != nil {
+		return xerrors.Errorf("do request: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusAccepted {
+		return codersdk.ReadBodyAsError(res)
+	}
+	return nil
+}
+
 // Netcheck returns a network check report from the workspace agent.
 func (c *AgentConn) Netcheck(ctx context.Context) (healthsdk.AgentNetcheckReport, error) {
 	ctx, span := tracing.StartSpan(ctx)
@@ -336,6 +469,27 @@ func (c *AgentConn) PrometheusMetrics(ctx context.Context) ([]byte, error) {
 	return bs, nil
 }
 
+// FilesRequest makes a request to the file transfer API of the workspace
+// agent, e.g. "/download". The response is returned unread so files can be
+// streamed, and the caller must close its body.
+func (c *AgentConn) FilesRequest(ctx context.Context, method, path string, query url.Values, header http.Header, body io.Reader) (*http.Response, error) {
+	ctx, span := tracing.StartSpan(ctx)
+	defer span.End()
+
+	host := net.JoinHostPort(c.agentAddress().String(), strconv.Itoa(AgentHTTPAPIServerPort))
+	u := fmt.Sprintf("http://%s/api/v0/files%s?%s", host, path, query.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return nil, xerrors.Errorf("new http api request to %q: %w", u, err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+
+	return c.apiClient().Do(req)
+}
+
 // apiRequest makes a request to the workspace agent's HTTP API server.
 func (c *AgentConn) apiRequest(ctx context.Context, method, path string, body io.Reader) (*http.Response, error) {
 	ctx, span := tracing.StartSpan(ctx)
@@ -303,6 +303,17 @@ type WorkspaceAgentReconnectingPTYOpts struct {
 	Width     uint16
 	Height    uint16
 	Command   string
+	// Container is the name or ID of a container in the workspace the
+	// command is run in. If empty, the command runs in the workspace.
+	Container string
+	// ContainerUser is the user the command runs as in the container.
+	ContainerUser string
+	// ReadOnly attaches to the existing session with the Reconnect ID as a
+	// viewer whose input is discarded.
+	ReadOnly bool
+	// Takeover detaches the other clients of the session that aren't read
+	// only.
+	Takeover bool
 
 	// SignedToken is an optional signed token from the
 	// issue-reconnecting-pty-signed-token endpoint. If set, the session token
@@ -323,6 +334,18 @@ func (c *Client) AgentReconnectingPTY(ctx context.Context, opts WorkspaceAgentRe
 	q.Set("width", strconv.Itoa(int(opts.Width)))
 	q.Set("height", strconv.Itoa(int(opts.Height)))
 	q.Set("command", opts.Command)
+	if opts.Container != "" {
+		q.Set("container", opts.Container)
+	}
+	if opts.ContainerUser != "" {
+		q.Set("container_user", opts.ContainerUser)
+	}
+	if opts.ReadOnly {
+		q.Set("read_only", "true")
+	}
+	if opts.Takeover {
+		q.Set("takeover", "true")
+	}
 	// If we're using a signed token, set the query parameter.
 	if opts.SignedToken != "" {
 		q.Set(codersdk.SignedAppTokenQueryParameter, opts.SignedToken)
@@ -0,0 +1,138 @@
+package codersdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WorkspaceSnapshot is a named snapshot of a workspace, taken by the
+// provisioner of its template. The snapshot is usable once its job has
+// succeeded.
+type WorkspaceSnapshot struct {
+	ID          uuid.UUID `json:"id" format:"uuid"`
+	WorkspaceID uuid.UUID `json:"workspace_id" format:"uuid"`
+	// WorkspaceBuildID is the build the snapshot was taken from.
+	WorkspaceBuildID uuid.UUID      `json:"workspace_build_id" format:"uuid"`
+	Name             string         `json:"name"`
+	CreatedBy        uuid.UUID      `json:"created_by" format:"uuid"`
+	CreatedAt        time.Time      `json:"created_at" format:"date-time"`
+	Job              ProvisionerJob `json:"job"`
+}
+
+// CreateWorkspaceSnapshotRequest snapshots the latest build of a workspace
+// under a name which is unique per workspace.
+type CreateWorkspaceSnapshotRequest struct {
+	Name string `json:"name" validate:"required,workspace_snapshot_name"`
+	// Log level changes the default logging verbosity of a provider ("info" if empty).
+	LogLevel ProvisionerLogLevel `json:"log_level,omitempty" validate:"omitempty,oneof=debug"`
+}
+
+// RestoreWorkspaceSnapshotRequest restores a snapshot into a stopped
+// workspace. The restored workspace is started by a regular build.
+type RestoreWorkspaceSnapshotRequest struct {
+	// Log level changes the default logging verbosity of a provider ("info" if empty).
+	LogLevel ProvisionerLogLevel `json:"log_level,omitempty" validate:"omitempty,oneof=debug"`
+}
+
+// WorkspaceSnapshots returns the snapshots of a workspace, newest first.
+func (c *Client) WorkspaceSnapshots(ctx context.Context, workspace uuid.UUID) ([]WorkspaceSnapshot, error) {
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/workspaces/%s/snapshots", workspace), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, ReadBodyAsError(res)
+	}
+	var snapshots []WorkspaceSnapshot
+	return snapshots, json.NewDecoder(res.Body).Decode(&snapshots)
+}
+
+// WorkspaceSnapshot returns a snapshot of a workspace.
+func (c *Client) WorkspaceSnapshot(ctx context.Context, workspace, snapshot uuid.UUID) (WorkspaceSnapshot, error) {
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/workspaces/%s/snapshots/%s", workspace, snapshot), nil)
+	if err != nil {
+		return WorkspaceSnapshot{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return WorkspaceSnapshot{}, ReadBodyAsError(res)
+	}
+	var s WorkspaceSnapshot
+	return s, json.NewDecoder(res.Body).Decode(&s)
+}
+
+// CreateWorkspaceSnapshot queues a job snapshotting the latest build of a
+// workspace.
+func (c *Client) CreateWorkspaceSnapshot(ctx context.Context, workspace uuid.UUID, req CreateWorkspaceSnapshotRequest) (WorkspaceSnapshot, error) {
+	res, err := c.Request(ctx, http.MethodPost, fmt.Sprintf("/api/v2/workspaces/%s/snapshots", workspace), req)
+	if err != nil {
+		return WorkspaceSnapshot{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusCreated {
+		return WorkspaceSnapshot{}, ReadBodyAsError(res)
+	}
+	var s WorkspaceSnapshot
+	return s, json.NewDecoder(res.Body).Decode(&s)
+}
+
+// DeleteWorkspaceSnapshot deletes a snapshot of a workspace.
+func (c *Client) DeleteWorkspaceSnapshot(ctx context.Context, workspace, snapshot uuid.UUID) error {
+	res, err := c.Request(ctx, http.MethodDelete, fmt.Sprintf("/api/v2/workspaces/%s/snapshots/%s", workspace, snapshot), nil)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusNoContent {
+		return ReadBodyAsError(res)
+	}
+	return nil
+}
+
+// WorkspaceSnapshotLogsAfter streams logs of the job creating a snapshot
+// that occurred after a specific log ID.
+func (c *Client) WorkspaceSnapshotLogsAfter(ctx context.Context, workspace, snapshot uuid.UUID, after int64) (<-chan ProvisionerJobLog, io.Closer, error) {
+	return c.provisionerJobLogsAfter(ctx, fmt.Sprintf("/api/v2/workspaces/%s/snapshots/%s/logs", workspace, snapshot), after)
+}
+
+// RestoreWorkspaceSnapshot queues a job restoring a snapshot into its
+// workspace, which must be stopped.
+func (c *Client) RestoreWorkspaceSnapshot(ctx context.Context, workspace, snapshot uuid.UUID, req RestoreWorkspaceSnapshotRequest) (ProvisionerJob, error) {
+	res, err := c.Request(ctx, http.MethodPost, fmt.Sprintf("/api/v2/workspaces/%s/snapshots/%s/restore", workspace, snapshot), req)
+	if err != nil {
+		return ProvisionerJob{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusCreated {
+		return ProvisionerJob{}, ReadBodyAsError(res)
+	}
+	var job ProvisionerJob
+	return job, json.NewDecoder(res.Body).Decode(&job)
+}
+
+// WorkspaceSnapshotRestore returns a job restoring a snapshot.
+func (c *Client) WorkspaceSnapshotRestore(ctx context.Context, workspace, snapshot, job uuid.UUID) (ProvisionerJob, error) {
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/workspaces/%s/snapshots/%s/restore/%s", workspace, snapshot, job), nil)
+	if err != nil {
+		return ProvisionerJob{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return ProvisionerJob{}, ReadBodyAsError(res)
+	}
+	var j ProvisionerJob
+	return j, json.NewDecoder(res.Body).Decode(&j)
+}
+
+// WorkspaceSnapshotRestoreLogsAfter streams logs of a job restoring a
+// snapshot that occurred after a specific log ID.
+func (c *Client) WorkspaceSnapshotRestoreLogsAfter(ctx context.Context, workspace, snapshot, job uuid.UUID, after int64) (<-chan ProvisionerJobLog, io.Closer, error) {
+	return c.provisionerJobLogsAfter(ctx, fmt.Sprintf("/api/v2/workspaces/%s/snapshots/%s/restore/%s/logs", workspace, snapshot, job), after)
+}
@@ -0,0 +1,162 @@
+package backends
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/coderd/objectstore"
+)
+
+const bufferEntrySuffix = ".json"
+
+// diskBuffer is a bounded, persistent FIFO queue. Each entry is stored in its
+// own file, named after its sequence number so that entries are read back in
+// the order in which they were written, including after a restart.
+type diskBuffer struct {
+	dir     string
+	maxSize int64
+
+	mu      sync.Mutex
+	next    uint64
+	size    int64
+	entries []bufferEntry
+}
+
+type bufferEntry struct {
+	seq  uint64
+	size int64
+}
+
+// bufferedData is the content of a buffered entry.
+type bufferedData struct {
+	seq  uint64
+	data []byte
+}
+
+// openDiskBuffer opens the buffer in the given directory, creating the
+// directory if it does not exist. Entries left in the directory by a previous
+// buffer are kept.
+func openDiskBuffer(dir string, maxSize int64) (*diskBuffer, error) {
+	if maxSize <= 0 {
+		return nil, xerrors.Errorf("buffer size must be positive, got %d", maxSize)
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, xerrors.Errorf("create buffer directory: %w", err)
+	}
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, xerrors.Errorf("read buffer directory: %w", err)
+	}
+
+	b := &diskBuffer{dir: dir, maxSize: maxSize}
+	for _, f := range files {
+		name := f.Name()
+		if strings.HasPrefix(name, ".") {
+			// A temporary file left behind by an interrupted write.
+			_ = os.Remove(filepath.Join(dir, name))
+			continue
+		}
+		seq, err := strconv.ParseUint(strings.TrimSuffix(name, bufferEntrySuffix), 10, 64)
+		if err != nil || !strings.HasSuffix(name, bufferEntrySuffix) {
+			continue
+		}
+		info, err := f.Info()
+		if err != nil {
+			return nil, xerrors.Errorf("stat buffered entry: %w", err)
+		}
+		b.entries = append(b.entries, bufferEntry{seq: seq, size: info.Size()})
+		b.size += info.Size()
+	}
+	slices.SortFunc(b.entries, func(x, y bufferEntry) int {
+		return cmp.Compare(x.seq, y.seq)
+	})
+	if len(b.entries) > 0 {
+		b.next = b.entries[len(b.entries)-1].seq + 1
+	}
+	return b, nil
+}
+
+// push appends an entry to the buffer. If the buffer would exceed its maximum
+// size, the oldest entries are dropped to make room; the number of dropped
+// entries is returned.
+func (b *diskBuffer) push(data []byte) (int, error) {
+	size := int64(len(data))
+	if size > b.maxSize {
+		return 0, xerrors.Errorf("entry of %d bytes exceeds the buffer size of %d bytes", size, b.maxSize)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	dropped := 0
+	for b.size+size > b.maxSize && len(b.entries) > 0 {
+		b.removeLocked(0)
+		dropped++
+	}
+
+	seq := b.next
+	if err := objectstore.WriteFile(b.path(seq), data); err != nil {
+		return dropped, xerrors.Errorf("write buffered entry: %w", err)
+	}
+
+	b.next++
+	b.entries = append(b.entries, bufferEntry{seq: seq, size: size})
+	b.size += size
+	return dropped, nil
+}
+
+// peek returns up to n of the oldest entries without removing them.
+func (b *diskBuffer) peek(n int) ([]bufferedData, error) {
+	b.mu.Lock()
+	entries := slices.Clone(b.entries[:min(n, len(b.entries))])
+	b.mu.Unlock()
+
+	out := make([]bufferedData, 0, len(entries))
+	for _, e := range entries {
+		data, err := os.ReadFile(b.path(e.seq))
+		if err != nil {
+			if xerrors.Is(err, os.ErrNotExist) {
+				// The entry was dropped to make room after we copied the list.
+				continue
+			}
+			return nil, xerrors.Errorf("read buffered entry: %w", err)
+		}
+		out = append(out, bufferedData{seq: e.seq, data: data})
+	}
+	return out, nil
+}
+
+// remove deletes the entries with the given sequence numbers. Entries which
+// have already been removed are ignored.
+func (b *diskBuffer) remove(seqs ...uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, seq := range seqs {
+		i, ok := slices.BinarySearchFunc(b.entries, seq, func(e bufferEntry, seq uint64) int {
+			return cmp.Compare(e.seq, seq)
+		})
+		if ok {
+			b.removeLocked(i)
+		}
+	}
+}
+
+func (b *diskBuffer) removeLocked(i int) {
+	_ = os.Remove(b.path(b.entries[i].seq))
+	b.size -= b.entries[i].size
+	b.entries = slices.Delete(b.entries, i, i+1)
+}
+
+func (b *diskBuffer) path(seq uint64) string {
+	// Zero-pad the sequence number so that the files also sort by name.
+	return filepath.Join(b.dir, fmt.Sprintf("%020d%s", seq, bufferEntrySuffix))
+}
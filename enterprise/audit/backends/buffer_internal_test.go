@@ -0,0 +1,71 @@
+package backends
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiskBuffer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("DropsOldest", func(t *testing.T) {
+		t.Parallel()
+
+		b, err := openDiskBuffer(t.TempDir(), 25)
+		require.NoError(t, err)
+
+		for _, data := range []string{"aaaaaaaaaa", "bbbbbbbbbb"} {
+			dropped, err := b.push([]byte(data))
+			require.NoError(t, err)
+			require.Zero(t, dropped)
+		}
+		dropped, err := b.push([]byte("cccccccccc"))
+		require.NoError(t, err)
+		require.Equal(t, 1, dropped)
+
+		entries, err := b.peek(10)
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		require.Equal(t, "bbbbbbbbbb", string(entries[0].data))
+		require.Equal(t, "cccccccccc", string(entries[1].data))
+
+		_, err = b.push(make([]byte, 26))
+		require.ErrorContains(t, err, "exceeds the buffer size")
+	})
+
+	t.Run("Persists", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		b, err := openDiskBuffer(dir, 1024)
+		require.NoError(t, err)
+		for _, data := range []string{"first", "second", "third"} {
+			_, err := b.push([]byte(data))
+			require.NoError(t, err)
+		}
+		entries, err := b.peek(1)
+		require.NoError(t, err)
+		b.remove(entries[0].seq)
+
+		// A temporary file left behind by an interrupted write is cleaned up.
+		require.NoError(t, os.WriteFile(filepath.Join(dir, ".entry.123"), []byte("partial"), 0o600))
+
+		b, err = openDiskBuffer(dir, 1024)
+		require.NoError(t, err)
+		require.EqualValues(t, len("second")+len("third"), b.size)
+		_, err = b.push([]byte("fourth"))
+		require.NoError(t, err)
+
+		entries, err = b.peek(10)
+		require.NoError(t, err)
+		got := make([]string, 0, len(entries))
+		for _, e := range entries {
+			got = append(got, string(e.data))
+		}
+		require.Equal(t, []string{"second", "third", "fourth"}, got)
+		require.NoFileExists(t, filepath.Join(dir, ".entry.123"))
+	})
+}
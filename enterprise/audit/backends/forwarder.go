@@ -0,0 +1,209 @@
+package backends
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
+	"github.com/coder/coder/v2/coderd/auditarchive"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/enterprise/audit"
+	"github.com/coder/quartz"
+)
+
+const (
+	// forwarderBatchSize is the maximum number of audit logs sent to a
+	// collector at once.
+	forwarderBatchSize = 100
+	// forwarderRetryInterval is how long to wait before retrying delivery
+	// after a collector could not be reached.
+	forwarderRetryInterval = 10 * time.Second
+)
+
+// sender delivers audit logs to a remote collector.
+type sender interface {
+	// send delivers the records in order, and returns how many of them were
+	// delivered before an error occurred.
+	send(ctx context.Context, records []auditarchive.Record) (int, error)
+	close() error
+}
+
+// permanentError is returned by a sender if the collector rejected records
+// that it will never accept, so that retrying them is pointless.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// BufferOptions configures the on-disk buffer of a Forwarder.
+type BufferOptions struct {
+	// Dir is the directory in which undelivered audit logs are stored. It
+	// must not be shared with any other forwarder.
+	Dir string
+	// MaxSize is the maximum size of the buffer in bytes. Once it is full,
+	// the oldest undelivered audit logs are dropped.
+	MaxSize int64
+}
+
+// ForwarderOption configures a Forwarder.
+type ForwarderOption func(*Forwarder)
+
+// WithClock sets the clock used to schedule retries.
+func WithClock(clock quartz.Clock) ForwarderOption {
+	return func(f *Forwarder) {
+		f.clock = clock
+	}
+}
+
+// Forwarder is an audit backend which streams audit logs to a remote
+// collector. Exported audit logs are first written to a bounded buffer on
+// disk, from which a background goroutine delivers them in order, so that they
+// are not lost while the collector is unavailable or Coder is restarted.
+//
+// Audit logs are sent in the same JSON representation as archived audit logs.
+// Their diffs only contain the fields tracked by audit.AuditableResources, as
+// the auditor computes them before exporting.
+type Forwarder struct {
+	logger slog.Logger
+	clock  quartz.Clock
+	buffer *diskBuffer
+	sender sender
+
+	notify chan struct{}
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+var _ audit.Backend = (*Forwarder)(nil)
+
+func newForwarder(ctx context.Context, logger slog.Logger, bufOpts BufferOptions, s sender, opts ...ForwarderOption) (*Forwarder, error) {
+	buffer, err := openDiskBuffer(bufOpts.Dir, bufOpts.MaxSize)
+	if err != nil {
+		return nil, xerrors.Errorf("open buffer: %w", err)
+	}
+
+	f := &Forwarder{
+		logger: logger,
+		clock:  quartz.NewReal(),
+		buffer: buffer,
+		sender: s,
+		notify: make(chan struct{}, 1),
+		done:   make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	ctx, f.cancel = context.WithCancel(ctx)
+	go f.run(ctx)
+	return f, nil
+}
+
+func (*Forwarder) Decision() audit.FilterDecision {
+	return audit.FilterDecisionExport
+}
+
+// Export buffers the audit log for delivery. It does not wait for the audit
+// log to be delivered.
+func (f *Forwarder) Export(ctx context.Context, alog database.AuditLog, details audit.BackendDetails) error {
+	var username, email string
+	if details.Actor != nil {
+		username, email = details.Actor.Username, details.Actor.Email
+	}
+	data, err := json.Marshal(auditarchive.RecordFromLog(alog, username, email))
+	if err != nil {
+		return xerrors.Errorf("marshal audit log: %w", err)
+	}
+
+	dropped, err := f.buffer.push(data)
+	if err != nil {
+		return xerrors.Errorf("buffer audit log: %w", err)
+	}
+	if dropped > 0 {
+		f.logger.Warn(ctx, "audit log buffer is full, dropped the oldest undelivered audit logs", slog.F("count", dropped))
+	}
+
+	select {
+	case f.notify <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Close stops delivering audit logs. Undelivered audit logs are kept on disk,
+// and delivered once a forwarder is started with the same buffer directory.
+func (f *Forwarder) Close() error {
+	f.cancel()
+	<-f.done
+	return f.sender.close()
+}
+
+func (f *Forwarder) run(ctx context.Context) {
+	defer close(f.done)
+
+	ticker := f.clock.NewTicker(forwarderRetryInterval, "auditForwarder")
+	defer ticker.Stop()
+
+	for {
+		f.flush(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-f.notify:
+		case <-ticker.C:
+		}
+	}
+}
+
+// flush delivers buffered audit logs until the buffer is empty or delivery
+// fails.
+func (f *Forwarder) flush(ctx context.Context) {
+	for ctx.Err() == nil {
+		entries, err := f.buffer.peek(forwarderBatchSize)
+		if err != nil {
+			f.logger.Error(ctx, "read buffered audit logs", slog.Error(err))
+			return
+		}
+		if len(entries) == 0 {
+			return
+		}
+
+		records := make([]auditarchive.Record, 0, len(entries))
+		seqs := make([]uint64, 0, len(entries))
+		for _, e := range entries {
+			var r auditarchive.Record
+			if err := json.Unmarshal(e.data, &r); err != nil {
+				f.logger.Error(ctx, "dropping corrupt buffered audit log", slog.Error(err))
+				f.buffer.remove(e.seq)
+				continue
+			}
+			records = append(records, r)
+			seqs = append(seqs, e.seq)
+		}
+		if len(records) == 0 {
+			continue
+		}
+
+		n, err := f.sender.send(ctx, records)
+		f.buffer.remove(seqs[:n]...)
+		if err != nil {
+			var perr *permanentError
+			if xerrors.As(err, &perr) {
+				f.logger.Error(ctx, "collector rejected audit logs, dropping them",
+					slog.F("count", len(records)-n), slog.Error(err))
+				f.buffer.remove(seqs[n:]...)
+				continue
+			}
+			if ctx.Err() == nil {
+				f.logger.Warn(ctx, "failed to deliver audit logs, will retry",
+					slog.F("retry_in", forwarderRetryInterval), slog.Error(err))
+			}
+			return
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package backends
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	"golang.org/x/xerrors"
+	"google.golang.org/protobuf/proto"
+
+	"cdr.dev/slog"
+	"github.com/coder/coder/v2/coderd/auditarchive"
+	"github.com/coder/coder/v2/codersdk"
+)
+
+const (
+	otlpLogsPath     = "/v1/logs"
+	otlpTimeout      = 30 * time.Second
+	otlpScopeName    = "github.com/coder/coder/v2/enterprise/audit"
+	otlpEventName    = "coder.audit_log"
+	otlpServiceName  = "coderd"
+	otlpContentType  = "application/x-protobuf"
+	otlpMaxErrorBody = 1024
+)
+
+// NewOTLP creates a backend which streams audit logs to an OpenTelemetry
+// collector as log records, using the OTLP/HTTP protocol with protobuf
+// encoding. The body of each log record is the JSON representation of the
+// audit log, and its most commonly queried fields are also set as attributes.
+func NewOTLP(ctx context.Context, logger slog.Logger, cfg codersdk.AuditLogOTLPConfig, buf BufferOptions, opts ...ForwarderOption) (*Forwarder, error) {
+	endpoint := cfg.Endpoint.Value()
+	if endpoint == nil || endpoint.Host == "" {
+		return nil, xerrors.New("the OTLP endpoint must be an absolute URL")
+	}
+	u := *endpoint
+	if u.Path == "" || u.Path == "/" {
+		u.Path = otlpLogsPath
+	}
+
+	headers := http.Header{}
+	for _, h := range cfg.Headers.Value() {
+		k, v, ok := strings.Cut(h, "=")
+		if !ok || strings.TrimSpace(k) == "" {
+			return nil, xerrors.Errorf("invalid OTLP header %q: expected key=value", h)
+		}
+		headers.Add(strings.TrimSpace(k), strings.TrimSpace(v))
+	}
+
+	attrs := []*commonpb.KeyValue{otlpString("service.name", otlpServiceName)}
+	if hostname, err := os.Hostname(); err == nil {
+		attrs = append(attrs, otlpString("host.name", hostname))
+	}
+
+	s := &otlpSender{
+		client:   &http.Client{Timeout: otlpTimeout},
+		endpoint: u.String(),
+		headers:  headers,
+		resource: &resourcepb.Resource{Attributes: attrs},
+	}
+	return newForwarder(ctx, logger, buf, s, opts...)
+}
+
+type otlpSender struct {
+	client   *http.Client
+	endpoint string
+	headers  http.Header
+	resource *resourcepb.Resource
+}
+
+func (s *otlpSender) send(ctx context.Context, records []auditarchive.Record) (int, error) {
+	observed := uint64(time.Now().UnixNano())
+	logRecords := make([]*logspb.LogRecord, 0, len(records))
+	for _, r := range records {
+		body, err := json.Marshal(r)
+		if err != nil {
+			return 0, xerrors.Errorf("marshal audit log: %w", err)
+		}
+		attrs := []*commonpb.KeyValue{
+			otlpString("event.name", otlpEventName),
+			otlpString("coder.audit.action", r.Action),
+			otlpString("coder.audit.resource_type", r.ResourceType),
+			otlpString("coder.audit.resource_id", r.ResourceID.String()),
+			otlpString("coder.audit.resource_target", r.ResourceTarget),
+			otlpString("coder.audit.request_id", r.RequestID.String()),
+			otlpString("coder.organization.id", r.OrganizationID.String()),
+			otlpString("user.id", r.UserID.String()),
+			{Key: "http.response.status_code", Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: int64(r.StatusCode)}}},
+		}
+		if r.Username != "" {
+			attrs = append(attrs, otlpString("user.name", r.Username))
+		}
+		if r.UserEmail != "" {
+			attrs = append(attrs, otlpString("user.email", r.UserEmail))
+		}
+		if r.IP != "" {
+			attrs = append(attrs, otlpString("client.address", r.IP))
+		}
+		logRecords = append(logRecords, &logspb.LogRecord{
+			TimeUnixNano:         uint64(r.Time.UnixNano()),
+			ObservedTimeUnixNano: observed,
+			SeverityNumber:       logspb.SeverityNumber_SEVERITY_NUMBER_INFO,
+			SeverityText:         "INFO",
+			Body:                 &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: string(body)}},
+			Attributes:           attrs,
+		})
+	}
+
+	data, err := proto.Marshal(&collogspb.ExportLogsServiceRequest{
+		ResourceLogs: []*logspb.ResourceLogs{{
+			Resource: s.resource,
+			ScopeLogs: []*logspb.ScopeLogs{{
+				Scope:      &commonpb.InstrumentationScope{Name: otlpScopeName},
+				LogRecords: logRecords,
+			}},
+		}},
+	})
+	if err != nil {
+		return 0, xerrors.Errorf("marshal export request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(data))
+	if err != nil {
+		return 0, xerrors.Errorf("create request: %w", err)
+	}
+	req.Header = s.headers.Clone()
+	req.Header.Set("Content-Type", otlpContentType)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, xerrors.Errorf("export logs: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return len(records), nil
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, otlpMaxErrorBody))
+	err = xerrors.Errorf("export logs: unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	if resp.StatusCode == http.StatusBadRequest {
+		// The collector could not parse the request, so it would never accept
+		// it. Other errors, such as authentication failures, are retried as
+		// they are likely to be fixed by reconfiguring the collector.
+		return 0, &permanentError{err: err}
+	}
+	return 0, err
+}
+
+func (s *otlpSender) close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
+
+func otlpString(key, value string) *commonpb.KeyValue {
+	return &commonpb.KeyValue{
+		Key:   key,
+		Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: value}},
+	}
+}
@@ -0,0 +1,191 @@
+package backends_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	"google.golang.org/protobuf/proto"
+
+	"cdr.dev/slog/sloggers/slogtest"
+	"github.com/coder/coder/v2/coderd/auditarchive"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/enterprise/audit"
+	"github.com/coder/coder/v2/enterprise/audit/audittest"
+	"github.com/coder/coder/v2/enterprise/audit/backends"
+	"github.com/coder/coder/v2/testutil"
+	"github.com/coder/quartz"
+)
+
+func TestOTLPBackend(t *testing.T) {
+	t.Parallel()
+
+	t.Run("OK", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+		collector := newFakeCollector(t)
+		collector.available.Store(true)
+
+		var cfg codersdk.AuditLogOTLPConfig
+		require.NoError(t, cfg.Endpoint.Set(collector.URL))
+		require.NoError(t, cfg.Headers.Set("Authorization=Bearer secret"))
+		backend, err := backends.NewOTLP(ctx, slogtest.Make(t, nil), cfg, backends.BufferOptions{
+			Dir:     t.TempDir(),
+			MaxSize: 1 << 20,
+		})
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = backend.Close() })
+
+		alog := audittest.RandomLog()
+		err = backend.Export(ctx, alog, audit.BackendDetails{Actor: &audit.Actor{ID: alog.UserID, Username: "coadler"}})
+		require.NoError(t, err)
+
+		req := testutil.RequireRecvCtx(ctx, t, collector.requests)
+		require.Equal(t, "/v1/logs", req.path)
+		require.Equal(t, "Bearer secret", req.authorization)
+		records := req.body.GetResourceLogs()[0].GetScopeLogs()[0].GetLogRecords()
+		require.Len(t, records, 1)
+		require.EqualValues(t, alog.Time.UnixNano(), records[0].GetTimeUnixNano())
+
+		var record auditarchive.Record
+		require.NoError(t, json.Unmarshal([]byte(records[0].GetBody().GetStringValue()), &record))
+		require.Equal(t, alog.ID, record.ID)
+		require.Equal(t, "coadler", record.Username)
+
+		attrs := map[string]string{}
+		for _, kv := range records[0].GetAttributes() {
+			attrs[kv.GetKey()] = kv.GetValue().GetStringValue()
+		}
+		require.Equal(t, string(alog.Action), attrs["coder.audit.action"])
+		require.Equal(t, alog.ResourceID.String(), attrs["coder.audit.resource_id"])
+		require.Equal(t, "coadler", attrs["user.name"])
+	})
+
+	t.Run("RetriesUntilAvailable", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+		collector := newFakeCollector(t)
+
+		clock := quartz.NewMock(t)
+		trap := clock.Trap().NewTicker("auditForwarder")
+		defer trap.Close()
+
+		var cfg codersdk.AuditLogOTLPConfig
+		require.NoError(t, cfg.Endpoint.Set(collector.URL))
+		backend, err := backends.NewOTLP(ctx, slogtest.Make(t, &slogtest.Options{IgnoreErrors: true}), cfg, backends.BufferOptions{
+			Dir:     t.TempDir(),
+			MaxSize: 1 << 20,
+		}, backends.WithClock(clock))
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = backend.Close() })
+		trap.MustWait(ctx).Release()
+
+		// Given: the collector is unavailable when an audit log is exported.
+		alog := audittest.RandomLog()
+		require.NoError(t, backend.Export(ctx, alog, audit.BackendDetails{}))
+		_ = testutil.RequireRecvCtx(ctx, t, collector.rejected)
+
+		// When: the collector becomes available and the retry interval elapses.
+		collector.available.Store(true)
+		clock.Advance(10 * time.Second).MustWait(ctx)
+
+		// Then: the audit log is delivered.
+		req := testutil.RequireRecvCtx(ctx, t, collector.requests)
+		records := req.body.GetResourceLogs()[0].GetScopeLogs()[0].GetLogRecords()
+		require.Len(t, records, 1)
+		var record auditarchive.Record
+		require.NoError(t, json.Unmarshal([]byte(records[0].GetBody().GetStringValue()), &record))
+		require.Equal(t, alog.ID, record.ID)
+	})
+
+	t.Run("BuffersAcrossRestarts", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+		collector := newFakeCollector(t)
+		dir := t.TempDir()
+
+		var cfg codersdk.AuditLogOTLPConfig
+		require.NoError(t, cfg.Endpoint.Set(collector.URL))
+		logger := slogtest.Make(t, &slogtest.Options{IgnoreErrors: true})
+		bufOpts := backends.BufferOptions{Dir: dir, MaxSize: 1 << 20}
+
+		// Given: an audit log which could not be delivered before shutdown.
+		backend, err := backends.NewOTLP(ctx, logger, cfg, bufOpts)
+		require.NoError(t, err)
+		alog := audittest.RandomLog()
+		require.NoError(t, backend.Export(ctx, alog, audit.BackendDetails{}))
+		_ = testutil.RequireRecvCtx(ctx, t, collector.rejected)
+		require.NoError(t, backend.Close())
+
+		// When: the backend is started again once the collector is available.
+		collector.available.Store(true)
+		backend, err = backends.NewOTLP(ctx, logger, cfg, bufOpts)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = backend.Close() })
+
+		// Then: the buffered audit log is delivered.
+		req := testutil.RequireRecvCtx(ctx, t, collector.requests)
+		records := req.body.GetResourceLogs()[0].GetScopeLogs()[0].GetLogRecords()
+		require.Len(t, records, 1)
+		var record auditarchive.Record
+		require.NoError(t, json.Unmarshal([]byte(records[0].GetBody().GetStringValue()), &record))
+		require.Equal(t, alog.ID, record.ID)
+	})
+}
+
+type otlpRequest struct {
+	path          string
+	authorization string
+	body          *collogspb.ExportLogsServiceRequest
+}
+
+type fakeCollector struct {
+	*httptest.Server
+	available atomic.Bool
+	requests  chan otlpRequest
+	rejected  chan struct{}
+}
+
+func newFakeCollector(t *testing.T) *fakeCollector {
+	t.Helper()
+
+	c := &fakeCollector{
+		requests: make(chan otlpRequest, 16),
+		rejected: make(chan struct{}, 16),
+	}
+	c.Server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if !c.available.Load() {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			c.rejected <- struct{}{}
+			return
+		}
+		assert.Equal(t, "application/x-protobuf", r.Header.Get("Content-Type"))
+		data, err := io.ReadAll(r.Body)
+		if !assert.NoError(t, err) {
+			rw.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		var body collogspb.ExportLogsServiceRequest
+		if !assert.NoError(t, proto.Unmarshal(data, &body)) {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		c.requests <- otlpRequest{
+			path:          r.URL.Path,
+			authorization: r.Header.Get("Authorization"),
+			body:          &body,
+		}
+	}))
+	t.Cleanup(c.Close)
+	return c
+}
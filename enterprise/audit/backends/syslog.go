@@ -0,0 +1,157 @@
+package backends
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
+	"github.com/coder/coder/v2/coderd/auditarchive"
+	"github.com/coder/coder/v2/codersdk"
+)
+
+const (
+	// syslogPriority is the PRI of audit log messages: the "log audit"
+	// facility (13) at the "informational" severity (6).
+	syslogPriority = 13*8 + 6
+	// syslogTimeFormat is the RFC 3339 profile required by RFC 5424, which
+	// allows at most microsecond precision.
+	syslogTimeFormat = "2006-01-02T15:04:05.000000Z07:00"
+	syslogTimeout    = 10 * time.Second
+)
+
+// NewSyslog creates a backend which streams audit logs to a syslog server as
+// RFC 5424 messages over TCP, or TLS as described in RFC 5425. The body of each
+// message is the JSON representation of the audit log.
+func NewSyslog(ctx context.Context, logger slog.Logger, cfg codersdk.AuditLogSyslogConfig, buf BufferOptions, opts ...ForwarderOption) (*Forwarder, error) {
+	address := cfg.Address.String()
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid syslog address %q: %w", address, err)
+	}
+
+	s := &syslogSender{
+		address:  address,
+		hostname: syslogHostname(),
+		procID:   strconv.Itoa(os.Getpid()),
+	}
+	if cfg.TLS.Value() {
+		s.tlsConfig = &tls.Config{
+			MinVersion: tls.VersionTLS12,
+			ServerName: host,
+		}
+		if caFile := cfg.TLSCAFile.String(); caFile != "" {
+			pem, err := os.ReadFile(caFile)
+			if err != nil {
+				return nil, xerrors.Errorf("read syslog CA file: %w", err)
+			}
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, xerrors.Errorf("no certificates found in syslog CA file %q", caFile)
+			}
+			s.tlsConfig.RootCAs = pool
+		}
+	}
+
+	return newForwarder(ctx, logger, buf, s, opts...)
+}
+
+type syslogSender struct {
+	address   string
+	tlsConfig *tls.Config
+	hostname  string
+	procID    string
+
+	// conn is only accessed by the forwarder's delivery goroutine.
+	conn net.Conn
+}
+
+func (s *syslogSender) send(ctx context.Context, records []auditarchive.Record) (int, error) {
+	if s.conn == nil {
+		conn, err := s.dial(ctx)
+		if err != nil {
+			return 0, xerrors.Errorf("connect to syslog server: %w", err)
+		}
+		s.conn = conn
+	}
+
+	for i, r := range records {
+		msg, err := s.format(r)
+		if err != nil {
+			return i, err
+		}
+		// Messages are framed by octet counting, which RFC 5425 requires and
+		// RFC 6587 describes for plain TCP.
+		frame := fmt.Appendf(nil, "%d %s", len(msg), msg)
+
+		_ = s.conn.SetWriteDeadline(time.Now().Add(syslogTimeout))
+		if _, err := s.conn.Write(frame); err != nil {
+			_ = s.conn.Close()
+			s.conn = nil
+			return i, xerrors.Errorf("write to syslog server: %w", err)
+		}
+	}
+	return len(records), nil
+}
+
+func (s *syslogSender) dial(ctx context.Context) (net.Conn, error) {
+	ctx, cancel := context.WithTimeout(ctx, syslogTimeout)
+	defer cancel()
+
+	if s.tlsConfig != nil {
+		d := &tls.Dialer{Config: s.tlsConfig}
+		return d.DialContext(ctx, "tcp", s.address)
+	}
+	var d net.Dialer
+	return d.DialContext(ctx, "tcp", s.address)
+}
+
+// format formats the record as an RFC 5424 message.
+func (s *syslogSender) format(r auditarchive.Record) ([]byte, error) {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, xerrors.Errorf("marshal audit log: %w", err)
+	}
+	// <PRI>VERSION TIMESTAMP HOSTNAME APP-NAME PROCID MSGID STRUCTURED-DATA MSG
+	return fmt.Appendf(nil, "<%d>1 %s %s coder %s audit_log - %s",
+		syslogPriority, r.Time.UTC().Format(syslogTimeFormat), s.hostname, s.procID, body), nil
+}
+
+func (s *syslogSender) close() error {
+	if s.conn == nil {
+		return nil
+	}
+	return s.conn.Close()
+}
+
+// syslogHostname returns the hostname in the form allowed by RFC 5424, or the
+// nil value if it is unknown.
+func syslogHostname() string {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		return "-"
+	}
+	hostname = strings.Map(func(r rune) rune {
+		// Only printable US-ASCII characters other than space are allowed.
+		if r < 33 || r > 126 {
+			return -1
+		}
+		return r
+	}, hostname)
+	if len(hostname) > 255 {
+		hostname = hostname[:255]
+	}
+	if hostname == "" {
+		return "-"
+	}
+	return hostname
+}
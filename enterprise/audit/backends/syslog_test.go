@@ -0,0 +1,147 @@
+package backends_test
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"cdr.dev/slog/sloggers/slogtest"
+	"github.com/coder/coder/v2/coderd/auditarchive"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/enterprise/audit"
+	"github.com/coder/coder/v2/enterprise/audit/audittest"
+	"github.com/coder/coder/v2/enterprise/audit/backends"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestSyslogBackend(t *testing.T) {
+	t.Parallel()
+
+	t.Run("OK", func(t *testing.T) {
+		t.Parallel()
+
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		messages := serveSyslog(t, ln)
+
+		var cfg codersdk.AuditLogSyslogConfig
+		require.NoError(t, cfg.Address.Set(ln.Addr().String()))
+		assertSyslogDelivery(t, cfg, messages)
+	})
+
+	t.Run("TLS", func(t *testing.T) {
+		t.Parallel()
+
+		cert := testutil.GenerateTLSCertificate(t, "localhost")
+		ln, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+			MinVersion:   tls.VersionTLS12,
+			Certificates: []tls.Certificate{cert},
+		})
+		require.NoError(t, err)
+		messages := serveSyslog(t, ln)
+
+		caFile := filepath.Join(t.TempDir(), "ca.pem")
+		require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Certificate[0]}), 0o600))
+
+		var cfg codersdk.AuditLogSyslogConfig
+		require.NoError(t, cfg.Address.Set(ln.Addr().String()))
+		require.NoError(t, cfg.TLS.Set("true"))
+		require.NoError(t, cfg.TLSCAFile.Set(caFile))
+		assertSyslogDelivery(t, cfg, messages)
+	})
+
+	t.Run("InvalidAddress", func(t *testing.T) {
+		t.Parallel()
+
+		var cfg codersdk.AuditLogSyslogConfig
+		require.NoError(t, cfg.Address.Set("syslog.example.com"))
+		_, err := backends.NewSyslog(testutil.Context(t, testutil.WaitShort), slogtest.Make(t, nil), cfg, backends.BufferOptions{
+			Dir:     t.TempDir(),
+			MaxSize: 1 << 20,
+		})
+		require.ErrorContains(t, err, "invalid syslog address")
+	})
+}
+
+func assertSyslogDelivery(t *testing.T, cfg codersdk.AuditLogSyslogConfig, messages <-chan string) {
+	t.Helper()
+
+	ctx := testutil.Context(t, testutil.WaitShort)
+	backend, err := backends.NewSyslog(ctx, slogtest.Make(t, nil), cfg, backends.BufferOptions{
+		Dir:     t.TempDir(),
+		MaxSize: 1 << 20,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = backend.Close() })
+
+	alog := audittest.RandomLog()
+	err = backend.Export(ctx, alog, audit.BackendDetails{Actor: &audit.Actor{
+		ID:       alog.UserID,
+		Username: "coadler",
+		Email:    "doug@coder.com",
+	}})
+	require.NoError(t, err)
+
+	msg := testutil.RequireRecvCtx(ctx, t, messages)
+	// <PRI>VERSION TIMESTAMP HOSTNAME APP-NAME PROCID MSGID STRUCTURED-DATA MSG
+	parts := strings.SplitN(msg, " ", 8)
+	require.Len(t, parts, 8)
+	require.Equal(t, "<110>1", parts[0])
+	require.Equal(t, alog.Time.UTC().Format("2006-01-02T15:04:05.000000Z07:00"), parts[1])
+	require.Equal(t, "coder", parts[3])
+	require.Equal(t, "audit_log", parts[5])
+	require.Equal(t, "-", parts[6])
+
+	var record auditarchive.Record
+	require.NoError(t, json.Unmarshal([]byte(parts[7]), &record))
+	require.Equal(t, alog.ID, record.ID)
+	require.Equal(t, "coadler", record.Username)
+	require.Equal(t, "127.0.0.1", record.IP)
+}
+
+// serveSyslog accepts connections and returns the octet-counted messages sent
+// over them.
+func serveSyslog(t *testing.T, ln net.Listener) <-chan string {
+	t.Helper()
+	t.Cleanup(func() { _ = ln.Close() })
+
+	messages := make(chan string, 16)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				r := bufio.NewReader(conn)
+				for {
+					length, err := r.ReadString(' ')
+					if err != nil {
+						return
+					}
+					n, err := strconv.Atoi(strings.TrimSpace(length))
+					if err != nil {
+						return
+					}
+					msg := make([]byte, n)
+					if _, err := io.ReadFull(r, msg); err != nil {
+						return
+					}
+					messages <- string(msg)
+				}
+			}()
+		}
+	}()
+	return messages
+}
@@ -20,7 +20,7 @@ var AuditActionMap = map[string][]codersdk.AuditAction{
 	"Template":        {codersdk.AuditActionWrite, codersdk.AuditActionDelete},
 	"TemplateVersion": {codersdk.AuditActionCreate, codersdk.AuditActionWrite},
 	"User":            {codersdk.AuditActionCreate, codersdk.AuditActionWrite, codersdk.AuditActionDelete},
-	"Workspace":       {codersdk.AuditActionCreate, codersdk.AuditActionWrite, codersdk.AuditActionDelete},
+	"Workspace":       {codersdk.AuditActionCreate, codersdk.AuditActionWrite, codersdk.AuditActionDelete, codersdk.AuditActionExec},
 	"WorkspaceBuild":  {codersdk.AuditActionStart, codersdk.AuditActionStop},
 	"Group":           {codersdk.AuditActionCreate, codersdk.AuditActionWrite, codersdk.AuditActionDelete},
 	"APIKey":          {codersdk.AuditActionLogin, codersdk.AuditActionLogout, codersdk.AuditActionRegister, codersdk.AuditActionCreate, codersdk.AuditActionDelete},
@@ -110,6 +110,9 @@ var auditableResourcesTypes = map[any]map[string]Action{
 		"require_active_version":            ActionTrack,
 		"deprecated":                        ActionTrack,
 		"max_port_sharing_level":            ActionTrack,
+		"session_recording":                 ActionTrack,
+		"port_forwarding_policy":            ActionTrack,
+		"agent_activity_bump":               ActionTrack,
 		"activity_bump":                     ActionTrack,
 	},
 	&database.TemplateVersion{}: {
@@ -178,6 +181,7 @@ var auditableResourcesTypes = map[any]map[string]Action{
 		"reason":                  ActionIgnore,
 		"daily_cost":              ActionIgnore,
 		"max_deadline":            ActionIgnore,
+		"provisioner_state_ref":   ActionIgnore,
 		"initiator_by_avatar_url": ActionIgnore,
 		"initiator_by_username":   ActionIgnore,
 	},
@@ -273,14 +277,15 @@ var auditableResourcesTypes = map[any]map[string]Action{
 		"icon":         ActionTrack,
 	},
 	&database.NotificationTemplate{}: {
-		"id":             ActionIgnore,
-		"name":           ActionTrack,
-		"title_template": ActionTrack,
-		"body_template":  ActionTrack,
-		"actions":        ActionTrack,
-		"group":          ActionTrack,
-		"method":         ActionTrack,
-		"kind":           ActionTrack,
+		"id":                 ActionIgnore,
+		"name":               ActionTrack,
+		"title_template":     ActionTrack,
+		"body_template":      ActionTrack,
+		"actions":            ActionTrack,
+		"group":              ActionTrack,
+		"method":             ActionTrack,
+		"kind":               ActionTrack,
+		"digest_template_id": ActionTrack,
 	},
 }
 
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"path/filepath"
 	"regexp"
 	"time"
 
@@ -47,6 +48,8 @@ func (r *RootCmd) provisionerDaemonStart() *serpent.Command {
 		pollJitter     time.Duration
 		preSharedKey   string
 		provisionerKey string
+		iacMirrorDir   string
+		moduleCacheMax int64
 		verbose        bool
 
 		prometheusEnable  bool
@@ -157,6 +160,29 @@ func (r *RootCmd) provisionerDaemonStart() *serpent.Command {
 				return err
 			}
 
+			var (
+				iacBinaries []terraform.Binary
+				advertised  []string
+			)
+			if iacMirrorDir != "" {
+				iacBinaries, err = terraform.InstallFromMirror(ctx, logger.Named("terraform"), iacMirrorDir, filepath.Join(cacheDir, "iac"))
+				if err != nil {
+					return xerrors.Errorf("install IaC binaries from mirror: %w", err)
+				}
+				for _, b := range iacBinaries {
+					advertised = append(advertised, b.String())
+				}
+			}
+
+			prometheusRegistry := prometheus.NewRegistry()
+			var moduleCache *terraform.ModuleCache
+			if moduleCacheMax > 0 {
+				moduleCache, err = terraform.NewModuleCache(filepath.Join(cacheDir, "modules"), moduleCacheMax, terraform.NewModuleCacheMetrics(prometheusRegistry))
+				if err != nil {
+					return xerrors.Errorf("create module cache: %w", err)
+				}
+			}
+
 			terraformClient, terraformServer := drpc.MemTransportPipe()
 			go func() {
 				<-ctx.Done()
@@ -174,7 +200,9 @@ func (r *RootCmd) provisionerDaemonStart() *serpent.Command {
 						Logger:        logger.Named("terraform"),
 						WorkDirectory: tempDir,
 					},
-					CachePath: cacheDir,
+					CachePath:   cacheDir,
+					Binaries:    iacBinaries,
+					ModuleCache: moduleCache,
 				})
 				if err != nil && !xerrors.Is(err, context.Canceled) {
 					select {
@@ -188,7 +216,6 @@ func (r *RootCmd) provisionerDaemonStart() *serpent.Command {
 			if prometheusEnable {
 				logger.Info(ctx, "starting Prometheus endpoint", slog.F("address", prometheusAddress))
 
-				prometheusRegistry := prometheus.NewRegistry()
 				prometheusRegistry.MustRegister(collectors.NewGoCollector())
 				prometheusRegistry.MustRegister(collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
 
@@ -202,7 +229,7 @@ func (r *RootCmd) provisionerDaemonStart() *serpent.Command {
 				defer closeFunc()
 			}
 
-			logger.Info(ctx, "starting provisioner daemon", slog.F("tags", tags), slog.F("name", name))
+			logger.Info(ctx, "starting provisioner daemon", slog.F("tags", tags), slog.F("name", name), slog.F("iac_binaries", advertised))
 
 			connector := provisionerd.LocalProvisioners{
 				string(database.ProvisionerTypeTerraform): proto.NewDRPCProvisionerClient(terraformClient),
@@ -215,6 +242,7 @@ func (r *RootCmd) provisionerDaemonStart() *serpent.Command {
 						codersdk.ProvisionerTypeTerraform,
 					},
 					Tags:           tags,
+					IaCBinaries:    advertised,
 					PreSharedKey:   preSharedKey,
 					Organization:   orgID,
 					ProvisionerKey: provisionerKey,
@@ -317,6 +345,19 @@ func (r *RootCmd) provisionerDaemonStart() *serpent.Command {
 			Value:       serpent.StringOf(&name),
 			Default:     "",
 		},
+		{
+			Flag:        "iac-mirror-dir",
+			Env:         "CODER_PROVISIONER_DAEMON_IAC_MIRROR_DIR",
+			Description: "A local directory of Terraform and OpenTofu release archives, laid out as <engine>/<version>/<archive>.zip. Every version in it is installed, and jobs of template versions that require one of them are routed to this provisioner daemon.",
+			Value:       serpent.StringOf(&iacMirrorDir),
+		},
+		{
+			Flag:        "module-cache-max-size",
+			Env:         "CODER_PROVISIONER_DAEMON_MODULE_CACHE_MAX_SIZE",
+			Description: "The maximum size in bytes of the cache of remote Terraform modules, pinned to an exact version, a version tag or a commit, so that builds don't download them on every init. The least recently used modules are evicted once it is full. The cache is disabled if it's 0.",
+			Value:       serpent.Int64Of(&moduleCacheMax),
+			Default:     "0",
+		},
 		{
 			Flag:        "verbose",
 			Env:         "CODER_PROVISIONER_DAEMON_VERBOSE",
@@ -9,6 +9,7 @@ import (
 	"errors"
 	"io"
 	"net/url"
+	"path/filepath"
 
 	"golang.org/x/xerrors"
 	"tailscale.com/derp"
@@ -74,11 +75,14 @@ func (r *RootCmd) Server(_ func()) *serpent.Command {
 			options.DERPServer.SetMeshKey(meshKey)
 		}
 
+		auditBackends, err := newAuditBackends(ctx, options)
+		if err != nil {
+			return nil, nil, err
+		}
 		options.Auditor = audit.NewAuditor(
 			options.Database,
 			audit.DefaultFilter,
-			backends.NewPostgres(options.Database, true),
-			backends.NewSlog(options.Logger),
+			auditBackends...,
 		)
 
 		options.TrialGenerator = trialer.New(options.Database, "https://v2-licensor.coder.com/trial", coderd.Keys)
@@ -126,3 +130,42 @@ func (r *RootCmd) Server(_ func()) *serpent.Command {
 	)
 	return cmd
 }
+
+// newAuditBackends returns the backends to which audit logs are exported,
+// including any configured syslog server or OTLP collector. Those backends
+// deliver audit logs in the background until ctx is canceled.
+func newAuditBackends(ctx context.Context, options *agplcoderd.Options) ([]audit.Backend, error) {
+	list := []audit.Backend{
+		backends.NewPostgres(options.Database, true),
+		backends.NewSlog(options.Logger),
+	}
+
+	cfg := options.DeploymentValues.AuditLogging
+	bufferDir := cfg.BufferDir.String()
+	if bufferDir == "" {
+		bufferDir = filepath.Join(options.DeploymentValues.CacheDir.String(), "audit")
+	}
+	logger := options.Logger.Named("audit")
+
+	if cfg.Syslog.Address.String() != "" {
+		backend, err := backends.NewSyslog(ctx, logger.Named("syslog"), cfg.Syslog, backends.BufferOptions{
+			Dir:     filepath.Join(bufferDir, "syslog"),
+			MaxSize: cfg.BufferMaxSize.Value(),
+		})
+		if err != nil {
+			return nil, xerrors.Errorf("create syslog audit backend: %w", err)
+		}
+		list = append(list, backend)
+	}
+	if cfg.OTLP.Endpoint.String() != "" {
+		backend, err := backends.NewOTLP(ctx, logger.Named("otlp"), cfg.OTLP, backends.BufferOptions{
+			Dir:     filepath.Join(bufferDir, "otlp"),
+			MaxSize: cfg.BufferMaxSize.Value(),
+		})
+		if err != nil {
+			return nil, xerrors.Errorf("create OTLP audit backend: %w", err)
+		}
+		list = append(list, backend)
+	}
+	return list, nil
+}
@@ -194,6 +194,18 @@ func (api *API) provisionerDaemonServe(rw http.ResponseWriter, r *http.Request)
 			tags[parts[0]] = parts[1]
 		}
 	}
+	iacBinaries := make([]provisionersdk.IaCBinary, 0, len(r.URL.Query()["iac_binary"]))
+	for _, raw := range r.URL.Query()["iac_binary"] {
+		binary, err := provisionersdk.ParseIaCBinary(raw)
+		if err != nil {
+			httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+				Message: fmt.Sprintf("Invalid IaC binary %q.", raw),
+				Detail:  err.Error(),
+			})
+			return
+		}
+		iacBinaries = append(iacBinaries, binary)
+	}
 	if !r.URL.Query().Has("provisioner") {
 		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
 			Message: "必须指定 provisioner 查询参数。",
@@ -266,6 +278,7 @@ func (api *API) provisionerDaemonServe(rw http.ResponseWriter, r *http.Request)
 		slog.F("name", name),
 		slog.F("provisioners", provisioners),
 		slog.F("tags", tags),
+		slog.F("iac_binaries", iacBinaries),
 	)
 
 	authCtx := ctx
@@ -383,6 +396,8 @@ func (api *API) provisionerDaemonServe(rw http.ResponseWriter, r *http.Request)
 		provisionerdserver.Options{
 			ExternalAuthConfigs: api.ExternalAuthConfigs,
 			OIDCConfig:          api.OIDCConfig,
+			StateStore:          api.AGPL.ProvisionerStateStore,
+			IaCBinaries:         iacBinaries,
 		},
 		api.NotificationsEnqueuer,
 	)
@@ -0,0 +1,64 @@
+package provisioner
+
+import (
+	"net"
+	"regexp"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/provisionersdk/proto"
+)
+
+// AppHealthcheckEnabled returns whether the health of a coder_app resource is
+// checked. An "http" health check without a URL is disabled, which is also
+// what a coder_app without a healthcheck block is converted to.
+func AppHealthcheckEnabled(healthcheck *proto.Healthcheck) bool {
+	if healthcheck == nil {
+		return false
+	}
+	return healthcheck.Kind != proto.Healthcheck_HTTP || healthcheck.Url != ""
+}
+
+// ValidateAppHealthcheck returns an error if the health check of a coder_app
+// resource is missing the field its kind requires or has an invalid value.
+func ValidateAppHealthcheck(healthcheck *proto.Healthcheck) error {
+	if healthcheck == nil {
+		return nil
+	}
+
+	switch healthcheck.Kind {
+	case proto.Healthcheck_HTTP:
+		if healthcheck.Url == "" {
+			if healthcheck.ExpectedStatus != 0 || healthcheck.BodyRegex != "" {
+				return xerrors.New("http healthcheck must have a url to check the status or body of")
+			}
+			return nil
+		}
+		if healthcheck.ExpectedStatus != 0 && (healthcheck.ExpectedStatus < 100 || healthcheck.ExpectedStatus > 599) {
+			return xerrors.Errorf("http healthcheck expected status %d is not a valid status code", healthcheck.ExpectedStatus)
+		}
+		if _, err := regexp.Compile(healthcheck.BodyRegex); err != nil {
+			return xerrors.Errorf("http healthcheck body regex %q is invalid: %w", healthcheck.BodyRegex, err)
+		}
+	case proto.Healthcheck_TCP, proto.Healthcheck_GRPC:
+		kind := strings.ToLower(healthcheck.Kind.String())
+		if healthcheck.Address == "" {
+			return xerrors.Errorf("%s healthcheck must have an address", kind)
+		}
+		if _, _, err := net.SplitHostPort(healthcheck.Address); err != nil {
+			return xerrors.Errorf("%s healthcheck address %q must be a host and port: %w", kind, healthcheck.Address, err)
+		}
+	case proto.Healthcheck_COMMAND:
+		if healthcheck.Command == "" {
+			return xerrors.New("command healthcheck must have a command")
+		}
+	case proto.Healthcheck_FILE:
+		if healthcheck.Path == "" {
+			return xerrors.New("file healthcheck must have a path")
+		}
+	default:
+		return xerrors.Errorf("unknown healthcheck kind %d", healthcheck.Kind)
+	}
+	return nil
+}
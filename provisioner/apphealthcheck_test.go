@@ -0,0 +1,107 @@
+package provisioner_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/provisioner"
+	"github.com/coder/coder/v2/provisionersdk/proto"
+)
+
+func TestValidateAppHealthcheck(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name        string
+		healthcheck *proto.Healthcheck
+		enabled     bool
+		err         string
+	}{
+		{
+			name: "None",
+		},
+		{
+			name:        "Empty",
+			healthcheck: &proto.Healthcheck{},
+		},
+		{
+			name:        "HTTP",
+			healthcheck: &proto.Healthcheck{Url: "http://localhost:8080/healthz", ExpectedStatus: 204, BodyRegex: "^ok$"},
+			enabled:     true,
+		},
+		{
+			name:        "HTTPWithoutURL",
+			healthcheck: &proto.Healthcheck{ExpectedStatus: 200},
+			err:         "must have a url",
+		},
+		{
+			name:        "HTTPInvalidStatus",
+			healthcheck: &proto.Healthcheck{Url: "http://localhost:8080/healthz", ExpectedStatus: 42},
+			err:         "not a valid status code",
+		},
+		{
+			name:        "HTTPInvalidRegex",
+			healthcheck: &proto.Healthcheck{Url: "http://localhost:8080/healthz", BodyRegex: "(ok"},
+			err:         "body regex",
+		},
+		{
+			name:        "TCP",
+			healthcheck: &proto.Healthcheck{Kind: proto.Healthcheck_TCP, Address: "localhost:5432"},
+			enabled:     true,
+		},
+		{
+			name:        "TCPWithoutAddress",
+			healthcheck: &proto.Healthcheck{Kind: proto.Healthcheck_TCP},
+			err:         "tcp healthcheck must have an address",
+		},
+		{
+			name:        "GRPC",
+			healthcheck: &proto.Healthcheck{Kind: proto.Healthcheck_GRPC, Address: "localhost:9090", GrpcService: "app.v1.App"},
+			enabled:     true,
+		},
+		{
+			name:        "GRPCWithoutPort",
+			healthcheck: &proto.Healthcheck{Kind: proto.Healthcheck_GRPC, Address: "localhost"},
+			err:         "must be a host and port",
+		},
+		{
+			name:        "Command",
+			healthcheck: &proto.Healthcheck{Kind: proto.Healthcheck_COMMAND, Command: "pg_isready"},
+			enabled:     true,
+		},
+		{
+			name:        "CommandWithoutCommand",
+			healthcheck: &proto.Healthcheck{Kind: proto.Healthcheck_COMMAND},
+			err:         "must have a command",
+		},
+		{
+			name:        "File",
+			healthcheck: &proto.Healthcheck{Kind: proto.Healthcheck_FILE, Path: "/tmp/ready"},
+			enabled:     true,
+		},
+		{
+			name:        "FileWithoutPath",
+			healthcheck: &proto.Healthcheck{Kind: proto.Healthcheck_FILE},
+			err:         "must have a path",
+		},
+		{
+			name:        "UnknownKind",
+			healthcheck: &proto.Healthcheck{Kind: proto.Healthcheck_Kind(42)},
+			err:         "unknown healthcheck kind",
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := provisioner.ValidateAppHealthcheck(tc.healthcheck)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.enabled, provisioner.AppHealthcheckEnabled(tc.healthcheck))
+		})
+	}
+}
@@ -60,6 +60,12 @@ var (
 			Apply: &proto.ApplyComplete{},
 		},
 	}}
+	// SnapshotComplete is a helper to indicate an empty snapshot completion.
+	SnapshotComplete = []*proto.Response{{
+		Type: &proto.Response_Snapshot{
+			Snapshot: &proto.SnapshotComplete{},
+		},
+	}}
 
 	// PlanFailed is a helper to convey a failed plan operation
 	PlanFailed = []*proto.Response{{
@@ -77,6 +83,14 @@ var (
 			},
 		},
 	}}
+	// SnapshotFailed is a helper to convey a failed snapshot operation
+	SnapshotFailed = []*proto.Response{{
+		Type: &proto.Response_Snapshot{
+			Snapshot: &proto.SnapshotComplete{
+				Error: "failed!",
+			},
+		},
+	}}
 )
 
 // Serve starts the echo provisioner.
@@ -192,6 +206,29 @@ func (*echo) Apply(sess *provisionersdk.Session, req *proto.ApplyRequest, cancel
 	return provisionersdk.ApplyErrorf("canceled")
 }
 
+// Snapshot reads requests from the provided directory to stream responses.
+func (*echo) Snapshot(sess *provisionersdk.Session, req *proto.SnapshotRequest, canceledOrComplete <-chan struct{}) *proto.SnapshotComplete {
+	responses, err := readResponses(
+		sess,
+		strings.ToLower(req.GetAction().String()),
+		"snapshot.protobuf")
+	if err != nil {
+		return &proto.SnapshotComplete{Error: err.Error()}
+	}
+	for _, response := range responses {
+		if log := response.GetLog(); log != nil {
+			sess.ProvisionLog(log.Level, log.Output)
+		}
+		if complete := response.GetSnapshot(); complete != nil {
+			return complete
+		}
+	}
+
+	// some tests use Echo without a complete response to test cancel
+	<-canceledOrComplete
+	return provisionersdk.SnapshotErrorf("canceled")
+}
+
 func (*echo) Shutdown(_ context.Context, _ *proto.Empty) (*proto.Empty, error) {
 	return &proto.Empty{}, nil
 }
@@ -209,6 +246,13 @@ type Responses struct {
 	// transition responses. They are prioritized over the generic responses.
 	ProvisionApplyMap map[proto.WorkspaceTransition][]*proto.Response
 	ProvisionPlanMap  map[proto.WorkspaceTransition][]*proto.Response
+
+	// ProvisionSnapshot is used to mock ALL responses of Snapshot, regardless
+	// of action. It defaults to SnapshotComplete.
+	ProvisionSnapshot []*proto.Response
+	// ProvisionSnapshotMap is used to mock specific action responses. They
+	// are prioritized over the generic responses.
+	ProvisionSnapshotMap map[proto.SnapshotAction][]*proto.Response
 }
 
 // Tar returns a tar archive of responses to provisioner operations.
@@ -224,10 +268,14 @@ func TarWithOptions(ctx context.Context, logger slog.Logger, responses *Response
 
 	if responses == nil {
 		responses = &Responses{
-			ParseComplete, ApplyComplete, PlanComplete,
-			nil, nil,
+			Parse:          ParseComplete,
+			ProvisionApply: ApplyComplete,
+			ProvisionPlan:  PlanComplete,
 		}
 	}
+	if responses.ProvisionSnapshot == nil {
+		responses.ProvisionSnapshot = SnapshotComplete
+	}
 	if responses.ProvisionPlan == nil {
 		for _, resp := range responses.ProvisionApply {
 			if resp.GetLog() != nil {
@@ -290,6 +338,12 @@ func TarWithOptions(ctx context.Context, logger slog.Logger, responses *Response
 			return nil, err
 		}
 	}
+	for index, response := range responses.ProvisionSnapshot {
+		err := writeProto(fmt.Sprintf("%d.snapshot.protobuf", index), response)
+		if err != nil {
+			return nil, err
+		}
+	}
 	for trans, m := range responses.ProvisionApplyMap {
 		for i, rs := range m {
 			err := writeProto(fmt.Sprintf("%d.%s.apply.protobuf", i, strings.ToLower(trans.String())), rs)
@@ -306,6 +360,14 @@ func TarWithOptions(ctx context.Context, logger slog.Logger, responses *Response
 			}
 		}
 	}
+	for action, m := range responses.ProvisionSnapshotMap {
+		for i, rs := range m {
+			err := writeProto(fmt.Sprintf("%d.%s.snapshot.protobuf", i, strings.ToLower(action.String())), rs)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
 	// `writer.Close()` function flushes the writer buffer, and adds extra padding to create a legal tarball.
 	err := writer.Close()
 	if err != nil {
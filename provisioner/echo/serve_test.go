@@ -289,6 +289,55 @@ func TestEcho(t *testing.T) {
 		require.Equal(t, responses[2].GetApply().Resources[0].Name,
 			complete.GetApply().Resources[0].Name)
 	})
+
+	t.Run("Snapshot", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithTimeout(ctx, testutil.WaitShort)
+		defer cancel()
+
+		data, err := echo.Tar(&echo.Responses{
+			Parse: echo.ParseComplete,
+			ProvisionSnapshotMap: map[proto.SnapshotAction][]*proto.Response{
+				proto.SnapshotAction_CREATE: {{
+					Type: &proto.Response_Snapshot{
+						Snapshot: &proto.SnapshotComplete{
+							Data: []byte("volume-snapshot-id"),
+						},
+					},
+				}},
+			},
+		})
+		require.NoError(t, err)
+		client, err := api.Session(ctx)
+		require.NoError(t, err)
+		defer func() {
+			err := client.Close()
+			require.NoError(t, err)
+		}()
+		err = client.Send(&proto.Request{Type: &proto.Request_Config{Config: &proto.Config{
+			TemplateSourceArchive: data,
+		}}})
+		require.NoError(t, err)
+
+		err = client.Send(&proto.Request{Type: &proto.Request_Snapshot{Snapshot: &proto.SnapshotRequest{
+			Action: proto.SnapshotAction_CREATE,
+		}}})
+		require.NoError(t, err)
+		complete, err := client.Recv()
+		require.NoError(t, err)
+		require.Equal(t, []byte("volume-snapshot-id"), complete.GetSnapshot().GetData())
+
+		// Actions without specific responses fall back to the default.
+		err = client.Send(&proto.Request{Type: &proto.Request_Snapshot{Snapshot: &proto.SnapshotRequest{
+			Action: proto.SnapshotAction_RESTORE,
+			Data:   []byte("volume-snapshot-id"),
+		}}})
+		require.NoError(t, err)
+		complete, err = client.Recv()
+		require.NoError(t, err)
+		require.NotNil(t, complete.GetSnapshot())
+		require.Empty(t, complete.GetSnapshot().GetError())
+	})
 }
 
 func planCompleteResource(name string) []*proto.Response {
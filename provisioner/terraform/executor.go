@@ -234,7 +234,7 @@ func getStateFilePath(workdir string) string {
 }
 
 // revive:disable-next-line:flag-parameter
-func (e *executor) plan(ctx, killCtx context.Context, env, vars []string, logr logSink, destroy bool) (*proto.PlanComplete, error) {
+func (e *executor) plan(ctx, killCtx context.Context, env, vars []string, logr logSink, destroy, refreshOnly bool) (*proto.PlanComplete, error) {
 	ctx, span := e.server.startTrace(ctx, tracing.FuncName())
 	defer span.End()
 
@@ -253,6 +253,9 @@ func (e *executor) plan(ctx, killCtx context.Context, env, vars []string, logr l
 	if destroy {
 		args = append(args, "-destroy")
 	}
+	if refreshOnly {
+		args = append(args, "-refresh-only")
+	}
 	for _, variable := range vars {
 		args = append(args, "-var", variable)
 	}
@@ -275,7 +278,7 @@ func (e *executor) plan(ctx, killCtx context.Context, env, vars []string, logr l
 	graphTimings := newTimingAggregator(database.ProvisionerJobTimingStageGraph)
 	graphTimings.ingest(createGraphTimingsEvent(timingGraphStart))
 
-	state, err := e.planResources(ctx, killCtx, planfilePath)
+	state, plan, err := e.planResources(ctx, killCtx, planfilePath)
 	if err != nil {
 		graphTimings.ingest(createGraphTimingsEvent(timingGraphErrored))
 		return nil, err
@@ -288,6 +291,8 @@ func (e *executor) plan(ctx, killCtx context.Context, env, vars []string, logr l
 		Resources:             state.Resources,
 		ExternalAuthProviders: state.ExternalAuthProviders,
 		Timings:               append(e.timings.aggregate(), graphTimings.aggregate()...),
+		ResourceChanges:       ConvertResourceChanges(plan.ResourceChanges),
+		ResourceDrift:         ConvertResourceChanges(plan.ResourceDrift),
 	}, nil
 }
 
@@ -308,19 +313,20 @@ func onlyDataResources(sm tfjson.StateModule) tfjson.StateModule {
 	return filtered
 }
 
-// planResources must only be called while the lock is held.
-func (e *executor) planResources(ctx, killCtx context.Context, planfilePath string) (*State, error) {
+// planResources returns the resources of the plan, and the plan itself.
+// It must only be called while the lock is held.
+func (e *executor) planResources(ctx, killCtx context.Context, planfilePath string) (*State, *tfjson.Plan, error) {
 	ctx, span := e.server.startTrace(ctx, tracing.FuncName())
 	defer span.End()
 
 	plan, err := e.showPlan(ctx, killCtx, planfilePath)
 	if err != nil {
-		return nil, xerrors.Errorf("show terraform plan file: %w", err)
+		return nil, nil, xerrors.Errorf("show terraform plan file: %w", err)
 	}
 
 	rawGraph, err := e.graph(ctx, killCtx)
 	if err != nil {
-		return nil, xerrors.Errorf("graph: %w", err)
+		return nil, nil, xerrors.Errorf("graph: %w", err)
 	}
 	modules := []*tfjson.StateModule{}
 	if plan.PriorState != nil {
@@ -338,9 +344,9 @@ func (e *executor) planResources(ctx, killCtx context.Context, planfilePath stri
 
 	state, err := ConvertState(modules, rawGraph)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return state, nil
+	return state, plan, nil
 }
 
 // showPlan must only be called while the lock is held.
@@ -487,6 +493,26 @@ func (e *executor) state(ctx, killCtx context.Context) (*tfjson.State, error) {
 	return state, nil
 }
 
+// output returns the value of the named output of the state, JSON encoded
+// unless it is a string.
+func (e *executor) output(ctx, killCtx context.Context, name string) ([]byte, error) {
+	e.mut.Lock()
+	defer e.mut.Unlock()
+
+	state, err := e.state(ctx, killCtx)
+	if err != nil {
+		return nil, err
+	}
+	if state.Values == nil || state.Values.Outputs[name] == nil {
+		return nil, xerrors.Errorf("the state has no output named %q", name)
+	}
+	value := state.Values.Outputs[name].Value
+	if str, ok := value.(string); ok {
+		return []byte(str), nil
+	}
+	return json.Marshal(value)
+}
+
 func interruptCommandOnCancel(ctx, killCtx context.Context, logger slog.Logger, cmd *exec.Cmd) {
 	go func() {
 		select {
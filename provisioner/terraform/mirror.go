@@ -0,0 +1,198 @@
+package terraform
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/gofrs/flock"
+	"github.com/hashicorp/go-version"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
+	"github.com/coder/coder/v2/provisionersdk"
+	"github.com/coder/coder/v2/provisionersdk/proto"
+)
+
+// Binary is a version of an IaC engine installed from a mirror.
+type Binary struct {
+	provisionersdk.IaCBinary
+	Path string
+}
+
+// binaryBaseName returns the name of the executable of an IaC engine, without
+// any extension. Release archives are named after it.
+func binaryBaseName(engine string) string {
+	if engine == provisionersdk.IaCEngineOpenTofu {
+		return "tofu"
+	}
+	return "terraform"
+}
+
+// InstallFromMirror installs every version of every IaC engine found in a
+// local mirror directory into dir, and returns the installed binaries. The
+// mirror is laid out as the release archives of each engine:
+//
+//	<mirror>/terraform/1.8.5/terraform_1.8.5_linux_amd64.zip
+//	<mirror>/opentofu/1.8.2/tofu_1.8.2_linux_amd64.zip
+//
+// Versions without an archive for the current platform are skipped. Binaries
+// that are already installed are not extracted again, so it's cheap to call on
+// every start.
+func InstallFromMirror(ctx context.Context, log slog.Logger, mirrorDir, dir string) ([]Binary, error) {
+	err := os.MkdirAll(dir, 0o750)
+	if err != nil {
+		return nil, err
+	}
+
+	lockFilePath := filepath.Join(dir, "lock")
+	lock := flock.New(lockFilePath)
+	ok, err := lock.TryLockContext(ctx, time.Millisecond*100)
+	if !ok {
+		return nil, xerrors.Errorf("could not acquire flock for %v: %w", lockFilePath, err)
+	}
+	defer lock.Close()
+
+	var binaries []Binary
+	for _, engine := range provisionersdk.IaCEngines {
+		entries, err := os.ReadDir(filepath.Join(mirrorDir, engine))
+		if xerrors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, xerrors.Errorf("read mirror of %s: %w", engine, err)
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+			v, err := version.NewVersion(entry.Name())
+			if err != nil {
+				log.Warn(ctx, "skipping mirror directory that isn't a version",
+					slog.F("engine", engine), slog.F("name", entry.Name()))
+				continue
+			}
+			binary, err := installFromArchive(ctx, log, mirrorDir, dir, engine, v)
+			if err != nil {
+				return nil, xerrors.Errorf("install %s %s: %w", engine, v, err)
+			}
+			if binary.Path == "" {
+				continue
+			}
+			binaries = append(binaries, binary)
+		}
+	}
+	return binaries, nil
+}
+
+// installFromArchive extracts the binary of a version of an engine from its
+// release archive in the mirror. The returned binary has an empty path if the
+// mirror has no archive for the current platform.
+func installFromArchive(ctx context.Context, log slog.Logger, mirrorDir, dir, engine string, v *version.Version) (Binary, error) {
+	name := binaryBaseName(engine)
+	binary := Binary{IaCBinary: provisionersdk.IaCBinary{Engine: engine, Version: v}}
+	// The directory and archive of a version are named after the original
+	// version string rather than the normalized one.
+	archivePath := filepath.Join(mirrorDir, engine, v.Original(),
+		fmt.Sprintf("%s_%s_%s_%s.zip", name, v.Original(), runtime.GOOS, runtime.GOARCH))
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	if _, err := os.Stat(archivePath); xerrors.Is(err, os.ErrNotExist) {
+		log.Warn(ctx, "skipping version without an archive for this platform",
+			slog.F("engine", engine), slog.F("version", v.String()), slog.F("archive", archivePath))
+		return binary, nil
+	}
+
+	installDir := filepath.Join(dir, engine, v.String())
+	binPath := filepath.Join(installDir, name)
+	if hasVersion, err := versionFromBinaryPath(ctx, binPath); err == nil && hasVersion.Equal(v) {
+		binary.Path = binPath
+		return binary, nil
+	}
+
+	log.Debug(ctx, "installing binary from mirror",
+		slog.F("engine", engine), slog.F("version", v.String()), slog.F("archive", archivePath))
+	err := os.MkdirAll(installDir, 0o750)
+	if err != nil {
+		return binary, err
+	}
+	archive, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return binary, xerrors.Errorf("open archive: %w", err)
+	}
+	defer archive.Close()
+	for _, file := range archive.File {
+		if file.Name != name {
+			continue
+		}
+		err = extractFile(file, binPath)
+		if err != nil {
+			return binary, xerrors.Errorf("extract %s: %w", name, err)
+		}
+		binary.Path = binPath
+		return binary, nil
+	}
+	return binary, xerrors.Errorf("archive %s doesn't contain %s", archivePath, name)
+}
+
+func extractFile(file *zip.File, path string) error {
+	src, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	// Write to a temporary file first so that a partially extracted binary is
+	// never run.
+	tmpPath := path + ".tmp"
+	//nolint:gosec // The binary must be executable.
+	dst, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o750)
+	if err != nil {
+		return err
+	}
+	//nolint:gosec // Release archives are trusted and of a bounded size.
+	_, err = io.Copy(dst, src)
+	if err != nil {
+		_ = dst.Close()
+		return err
+	}
+	err = dst.Close()
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// binaryPathFor returns the path of the binary that a job configured with the
+// given config runs. Jobs of template versions that require an IaC engine run
+// the newest installed binary that satisfies the requirement, and others run
+// the default binary.
+func (s *server) binaryPathFor(config *proto.Config) (string, error) {
+	if config.GetIacEngine() == "" {
+		return s.binaryPath, nil
+	}
+	requirement := provisionersdk.IaCRequirement{
+		Engine:  config.GetIacEngine(),
+		Version: config.GetIacVersionConstraint(),
+	}
+	available := make([]provisionersdk.IaCBinary, 0, len(s.binaries))
+	for _, b := range s.binaries {
+		available = append(available, b.IaCBinary)
+	}
+	selected, ok := requirement.Select(available)
+	if !ok {
+		return "", xerrors.Errorf("no installed %s binary satisfies the version constraint %q of the template version", requirement.Engine, requirement.Version)
+	}
+	for _, b := range s.binaries {
+		if b.Engine == selected.Engine && b.Version.Equal(selected.Version) {
+			return b.Path, nil
+		}
+	}
+	return "", xerrors.Errorf("binary %s is not installed", selected)
+}
@@ -0,0 +1,100 @@
+package terraform
+
+import (
+	"archive/zip"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"cdr.dev/slog/sloggers/slogtest"
+	"github.com/coder/coder/v2/provisionersdk"
+	"github.com/coder/coder/v2/provisionersdk/proto"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestInstallFromMirror(t *testing.T) {
+	t.Parallel()
+	if runtime.GOOS == "windows" {
+		t.Skip("Dummy terraform executable on Windows requires sh which isn't very practical.")
+	}
+
+	ctx := testutil.Context(t, testutil.WaitShort)
+	log := slogtest.Make(t, nil)
+	mirrorDir := t.TempDir()
+	writeMirrorArchive(t, mirrorDir, provisionersdk.IaCEngineTerraform, "1.8.5")
+	writeMirrorArchive(t, mirrorDir, provisionersdk.IaCEngineTerraform, "1.9.2")
+	writeMirrorArchive(t, mirrorDir, provisionersdk.IaCEngineOpenTofu, "1.8.2")
+	// Versions without an archive for this platform are skipped.
+	require.NoError(t, os.MkdirAll(filepath.Join(mirrorDir, provisionersdk.IaCEngineOpenTofu, "1.7.0"), 0o750))
+
+	dir := t.TempDir()
+	binaries, err := InstallFromMirror(ctx, log, mirrorDir, dir)
+	require.NoError(t, err)
+	names := make([]string, 0, len(binaries))
+	for _, b := range binaries {
+		names = append(names, b.String())
+		v, err := versionFromBinaryPath(ctx, b.Path)
+		require.NoError(t, err)
+		require.True(t, v.Equal(b.Version))
+	}
+	require.ElementsMatch(t, []string{"terraform@1.8.5", "terraform@1.9.2", "opentofu@1.8.2"}, names)
+
+	// Installing again reuses the installed binaries.
+	again, err := InstallFromMirror(ctx, log, mirrorDir, dir)
+	require.NoError(t, err)
+	require.Equal(t, binaries, again)
+
+	s := &server{binaryPath: "/usr/bin/terraform", binaries: binaries}
+	path, err := s.binaryPathFor(&proto.Config{})
+	require.NoError(t, err)
+	require.Equal(t, "/usr/bin/terraform", path)
+
+	path, err = s.binaryPathFor(&proto.Config{IacEngine: provisionersdk.IaCEngineTerraform})
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(dir, "terraform", "1.9.2", "terraform"), path)
+
+	path, err = s.binaryPathFor(&proto.Config{IacEngine: provisionersdk.IaCEngineTerraform, IacVersionConstraint: "~> 1.8.0"})
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(dir, "terraform", "1.8.5", "terraform"), path)
+
+	path, err = s.binaryPathFor(&proto.Config{IacEngine: provisionersdk.IaCEngineOpenTofu})
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(dir, "opentofu", "1.8.2", "tofu"), path)
+
+	_, err = s.binaryPathFor(&proto.Config{IacEngine: provisionersdk.IaCEngineOpenTofu, IacVersionConstraint: ">= 1.9"})
+	require.ErrorContains(t, err, "no installed opentofu binary satisfies")
+}
+
+// writeMirrorArchive writes a release archive of a dummy binary that reports
+// the given version to a mirror.
+func writeMirrorArchive(t *testing.T, mirrorDir, engine, v string) {
+	t.Helper()
+
+	dir := filepath.Join(mirrorDir, engine, v)
+	require.NoError(t, os.MkdirAll(dir, 0o750))
+	name := binaryBaseName(engine)
+	f, err := os.Create(filepath.Join(dir, fmt.Sprintf("%s_%s_%s_%s.zip", name, v, runtime.GOOS, runtime.GOARCH)))
+	require.NoError(t, err)
+	defer f.Close()
+
+	w := zip.NewWriter(f)
+	header := &zip.FileHeader{Name: name, Method: zip.Deflate}
+	header.SetMode(0o755)
+	bin, err := w.CreateHeader(header)
+	require.NoError(t, err)
+	_, err = fmt.Fprintf(bin, `#!/bin/sh
+cat <<-EOF
+{
+	"terraform_version": "%s",
+	"platform": "linux_amd64",
+	"provider_selections": {},
+	"terraform_outdated": false
+}
+EOF`, v)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+}
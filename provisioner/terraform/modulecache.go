@@ -0,0 +1,584 @@
+package terraform
+
+import (
+	"archive/tar"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gofrs/flock"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-config-inspect/tfconfig"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
+)
+
+const (
+	moduleCacheEntryExt = ".tar"
+	// moduleCacheRecordsName is the name of the first file of an entry, which
+	// holds the records of the modules in it.
+	moduleCacheRecordsName = "records.json"
+	// moduleCachePackageName replaces the name of the module call in the
+	// directories and keys of the modules in an entry, so that an entry can be
+	// restored for a call of any name.
+	moduleCachePackageName = "module"
+)
+
+// ModuleCacheMetrics are the metrics of a module cache.
+type ModuleCacheMetrics struct {
+	Lookups   *prometheus.CounterVec
+	Evictions prometheus.Counter
+	SizeBytes prometheus.Gauge
+}
+
+func NewModuleCacheMetrics(reg prometheus.Registerer) *ModuleCacheMetrics {
+	auto := promauto.With(reg)
+
+	return &ModuleCacheMetrics{
+		Lookups: auto.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "coderd",
+			Subsystem: "provisionerd",
+			Name:      "module_cache_lookups_total",
+			Help:      "The number of lookups of Terraform modules in the module cache, by whether they were found.",
+		}, []string{"result"}),
+		Evictions: auto.NewCounter(prometheus.CounterOpts{
+			Namespace: "coderd",
+			Subsystem: "provisionerd",
+			Name:      "module_cache_evictions_total",
+			Help:      "The number of Terraform modules evicted from the module cache to keep it under its maximum size.",
+		}),
+		SizeBytes: auto.NewGauge(prometheus.GaugeOpts{
+			Namespace: "coderd",
+			Subsystem: "provisionerd",
+			Name:      "module_cache_size_bytes",
+			Help:      "The size of the module cache in bytes, as of its last eviction pass.",
+		}),
+	}
+}
+
+// ModuleCache stores the remote Terraform modules that `terraform init`
+// downloads, so that later jobs don't download them again. Each module call
+// whose source is pinned to a version or a ref is stored as a single archive,
+// named after a hash of its source and version, together with the modules it
+// calls. Archives are written to a temporary file and renamed into place, so a
+// cache directory can be shared by provisioners in different processes.
+type ModuleCache struct {
+	dir     string
+	maxSize int64
+	metrics *ModuleCacheMetrics
+}
+
+// NewModuleCache returns a module cache stored in dir. Once the archives in it
+// exceed maxSize bytes, the least recently used ones are evicted.
+func NewModuleCache(dir string, maxSize int64, metrics *ModuleCacheMetrics) (*ModuleCache, error) {
+	err := os.MkdirAll(dir, 0o700)
+	if err != nil {
+		return nil, xerrors.Errorf("mkdir %q: %w", dir, err)
+	}
+	if metrics == nil {
+		metrics = NewModuleCacheMetrics(prometheus.NewRegistry())
+	}
+	return &ModuleCache{
+		dir:     dir,
+		maxSize: maxSize,
+		metrics: metrics,
+	}, nil
+}
+
+// withModuleCache runs fn against the module cache, if there is one, and
+// records how long it took in timings. A failure only makes init download the
+// modules again, so it's logged rather than failing the job.
+func (s *server) withModuleCache(ctx context.Context, timings *timingAggregator, action string, fn func(c *ModuleCache) error) {
+	if s.moduleCache == nil {
+		return
+	}
+	timings.ingest(createModuleCacheTimingsEvent(timingModuleCacheStart, action))
+	err := fn(s.moduleCache)
+	if err != nil {
+		timings.ingest(createModuleCacheTimingsEvent(timingModuleCacheErrored, action))
+		s.logger.Warn(ctx, "module cache failed", slog.F("action", action), slog.Error(err))
+		return
+	}
+	timings.ingest(createModuleCacheTimingsEvent(timingModuleCacheComplete, action))
+}
+
+// moduleManifest mirrors the modules.json manifest that `terraform init`
+// writes to .terraform/modules. A module recorded in it with a matching source
+// and version isn't downloaded again.
+type moduleManifest struct {
+	Modules []moduleRecord `json:"Modules"`
+}
+
+type moduleRecord struct {
+	Key     string `json:"Key"`
+	Source  string `json:"Source"`
+	Version string `json:"Version,omitempty"`
+	Dir     string `json:"Dir"`
+}
+
+func modulesDir(workdir string) string {
+	return filepath.Join(workdir, ".terraform", "modules")
+}
+
+// cacheableModuleCalls returns the module calls of the root module in workdir
+// whose source is remote and pinned, sorted by name. Downloading other sources
+// again may give different modules, so they are never cached. A source is
+// pinned by an exact registry version, or by a ref that is a commit SHA or a
+// version tag; other refs are usually branches, which move.
+func cacheableModuleCalls(workdir string) ([]*tfconfig.ModuleCall, error) {
+	module, diags := tfconfig.LoadModule(workdir)
+	if diags.HasErrors() {
+		return nil, xerrors.Errorf("load module: %s", formatDiagnostics(workdir, diags))
+	}
+	calls := make([]*tfconfig.ModuleCall, 0, len(module.ModuleCalls))
+	for _, call := range module.ModuleCalls {
+		if strings.HasPrefix(call.Source, "./") || strings.HasPrefix(call.Source, "../") {
+			continue
+		}
+		if call.Version != "" {
+			// Only an exact version of a registry module is pinned.
+			_, err := version.NewVersion(strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(call.Version), "=")))
+			if err != nil {
+				continue
+			}
+		} else if !pinnedRef(call.Source) {
+			continue
+		}
+		calls = append(calls, call)
+	}
+	sort.Slice(calls, func(i, j int) bool {
+		return calls[i].Name < calls[j].Name
+	})
+	return calls, nil
+}
+
+var (
+	// commitSHARegex matches full SHA-1 and SHA-256 commit hashes.
+	// Abbreviated hashes aren't matched, since they can't be told apart from
+	// other refs.
+	commitSHARegex = regexp.MustCompile(`^([0-9a-fA-F]{40}|[0-9a-fA-F]{64})$`)
+	// versionTagRegex matches full semantic version tags. Tags of only a
+	// major or minor version, like v1, are often moved to the latest release.
+	versionTagRegex = regexp.MustCompile(`^v?\d+\.\d+\.\d+(-[0-9A-Za-z.-]+)?(\+[0-9A-Za-z.-]+)?$`)
+)
+
+// pinnedRef returns true if the source has a ref query parameter which is a
+// commit SHA or a full version tag, like v1.0.0.
+func pinnedRef(source string) bool {
+	_, query, ok := strings.Cut(source, "?")
+	if !ok {
+		return false
+	}
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return false
+	}
+	ref := values.Get("ref")
+	if ref == "" {
+		return false
+	}
+	return commitSHARegex.MatchString(ref) || versionTagRegex.MatchString(ref)
+}
+
+func (c *ModuleCache) entryPath(call *tfconfig.ModuleCall) string {
+	sum := sha256.Sum256([]byte(call.Source + "\n" + call.Version))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+moduleCacheEntryExt)
+}
+
+// restore extracts the cached modules of the module calls in workdir into its
+// .terraform/modules directory, and records them in the manifest so that
+// `terraform init` only downloads the others.
+func (c *ModuleCache) restore(ctx context.Context, logger slog.Logger, workdir string) error {
+	calls, err := cacheableModuleCalls(workdir)
+	if err != nil {
+		return err
+	}
+	records := []moduleRecord{{Key: "", Source: "", Dir: "."}}
+	for _, call := range calls {
+		entryPath := c.entryPath(call)
+		restored, err := restoreModuleCacheEntry(entryPath, workdir, call.Name)
+		if xerrors.Is(err, os.ErrNotExist) {
+			c.metrics.Lookups.WithLabelValues("miss").Inc()
+			logger.Debug(ctx, "module not cached", slog.F("module", call.Name), slog.F("source", call.Source))
+			continue
+		}
+		if err != nil {
+			// Leave nothing behind for `terraform init` to trip over.
+			_ = os.RemoveAll(modulesDir(workdir))
+			return xerrors.Errorf("restore module %q: %w", call.Name, err)
+		}
+		c.metrics.Lookups.WithLabelValues("hit").Inc()
+		logger.Debug(ctx, "restored cached module", slog.F("module", call.Name), slog.F("source", call.Source))
+		records = append(records, restored...)
+		// Evict the least recently used entries first.
+		now := time.Now()
+		_ = os.Chtimes(entryPath, now, now)
+	}
+	if len(records) == 1 {
+		return nil
+	}
+	manifest, err := json.Marshal(moduleManifest{Modules: records})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(modulesDir(workdir), "modules.json"), manifest, 0o600)
+}
+
+// store adds the modules that `terraform init` downloaded into workdir for
+// module calls that aren't cached yet, and evicts entries if the cache grew
+// over its maximum size.
+func (c *ModuleCache) store(ctx context.Context, logger slog.Logger, workdir string) error {
+	calls, err := cacheableModuleCalls(workdir)
+	if err != nil {
+		return err
+	}
+	manifestData, err := os.ReadFile(filepath.Join(modulesDir(workdir), "modules.json"))
+	if xerrors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return xerrors.Errorf("read module manifest: %w", err)
+	}
+	var manifest moduleManifest
+	err = json.Unmarshal(manifestData, &manifest)
+	if err != nil {
+		return xerrors.Errorf("decode module manifest: %w", err)
+	}
+
+	stored := false
+	for _, call := range calls {
+		entryPath := c.entryPath(call)
+		if _, err := os.Stat(entryPath); err == nil {
+			continue
+		}
+		ok, err := c.storeEntry(entryPath, workdir, call.Name, manifest.Modules)
+		if err != nil {
+			return xerrors.Errorf("store module %q: %w", call.Name, err)
+		}
+		if !ok {
+			logger.Debug(ctx, "module can't be cached", slog.F("module", call.Name), slog.F("source", call.Source))
+			continue
+		}
+		logger.Debug(ctx, "cached module", slog.F("module", call.Name), slog.F("source", call.Source))
+		stored = true
+	}
+	if !stored {
+		return nil
+	}
+	return c.evict(ctx, logger)
+}
+
+// storeEntry archives the modules of a module call, and the modules it calls,
+// to entryPath. It returns false if they can't be cached.
+func (c *ModuleCache) storeEntry(entryPath, workdir, name string, manifest []moduleRecord) (bool, error) {
+	var (
+		records  []moduleRecord
+		packages = map[string]struct{}{}
+	)
+	for _, record := range manifest {
+		key, ok := rekeyModule(record.Key, name, moduleCachePackageName)
+		if !ok {
+			continue
+		}
+		dir, ok := strings.CutPrefix(filepath.ToSlash(record.Dir), ".terraform/modules/")
+		if !ok {
+			// The module isn't in a downloaded package.
+			return false, nil
+		}
+		pkg, rest, _ := strings.Cut(dir, "/")
+		pkg, ok = rekeyModule(pkg, name, moduleCachePackageName)
+		if !ok {
+			return false, nil
+		}
+		packages[pkg] = struct{}{}
+		record.Key = key
+		record.Dir = path.Join(pkg, rest)
+		records = append(records, record)
+	}
+	if len(records) == 0 {
+		return false, nil
+	}
+
+	tmp, err := os.CreateTemp(c.dir, "*.tmp")
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+	}()
+	tw := tar.NewWriter(tmp)
+	recordsData, err := json.Marshal(records)
+	if err != nil {
+		return false, err
+	}
+	err = tw.WriteHeader(&tar.Header{
+		Name:     moduleCacheRecordsName,
+		Typeflag: tar.TypeReg,
+		Mode:     0o600,
+		Size:     int64(len(recordsData)),
+	})
+	if err != nil {
+		return false, err
+	}
+	_, err = tw.Write(recordsData)
+	if err != nil {
+		return false, err
+	}
+	for pkg := range packages {
+		original, _ := rekeyModule(pkg, moduleCachePackageName, name)
+		ok, err := writeModulePackage(tw, filepath.Join(modulesDir(workdir), original), pkg)
+		if err != nil {
+			return false, xerrors.Errorf("archive %q: %w", original, err)
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+	err = tw.Close()
+	if err != nil {
+		return false, err
+	}
+	info, err := tmp.Stat()
+	if err != nil {
+		return false, err
+	}
+	if info.Size() > c.maxSize {
+		// It would evict everything else.
+		return false, nil
+	}
+	err = tmp.Close()
+	if err != nil {
+		return false, err
+	}
+	return true, os.Rename(tmp.Name(), entryPath)
+}
+
+// writeModulePackage archives the directory of a downloaded package under
+// name. It returns false if the package contains symlinks, which aren't
+// cached.
+func writeModulePackage(tw *tar.Writer, dir, name string) (bool, error) {
+	ok := true
+	err := filepath.WalkDir(dir, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type()&fs.ModeSymlink != 0 {
+			ok = false
+			return filepath.SkipAll
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, file)
+		if err != nil {
+			return err
+		}
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = path.Join(name, filepath.ToSlash(rel))
+		if d.IsDir() {
+			header.Name += "/"
+		}
+		err = tw.WriteHeader(header)
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	return ok, err
+}
+
+// restoreModuleCacheEntry extracts an entry for the module call of the given
+// name into the modules directory of workdir, and returns its records.
+func restoreModuleCacheEntry(entryPath, workdir, name string) ([]moduleRecord, error) {
+	f, err := os.Open(entryPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	tr := tar.NewReader(f)
+	header, err := tr.Next()
+	if err != nil {
+		return nil, xerrors.Errorf("read records: %w", err)
+	}
+	if header.Name != moduleCacheRecordsName {
+		return nil, xerrors.Errorf("entry doesn't start with %s", moduleCacheRecordsName)
+	}
+	var records []moduleRecord
+	err = json.NewDecoder(tr).Decode(&records)
+	if err != nil {
+		return nil, xerrors.Errorf("decode records: %w", err)
+	}
+	for i, record := range records {
+		key, ok := rekeyModule(record.Key, moduleCachePackageName, name)
+		if !ok {
+			return nil, xerrors.Errorf("unexpected module key %q", record.Key)
+		}
+		pkg, rest, _ := strings.Cut(record.Dir, "/")
+		pkg, ok = rekeyModule(pkg, moduleCachePackageName, name)
+		if !ok {
+			return nil, xerrors.Errorf("unexpected module directory %q", record.Dir)
+		}
+		records[i].Key = key
+		records[i].Dir = path.Join(".terraform/modules", pkg, rest)
+	}
+
+	dir := modulesDir(workdir)
+	for {
+		header, err := tr.Next()
+		if xerrors.Is(err, io.EOF) {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if strings.Contains(header.Name, "..") {
+			return nil, xerrors.Errorf("unexpected file %q", header.Name)
+		}
+		pkg, rest, _ := strings.Cut(strings.TrimSuffix(header.Name, "/"), "/")
+		pkg, ok := rekeyModule(pkg, moduleCachePackageName, name)
+		if !ok {
+			return nil, xerrors.Errorf("unexpected file %q", header.Name)
+		}
+		target := filepath.Join(dir, pkg, filepath.FromSlash(rest))
+		switch header.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(target, 0o750)
+			if err != nil {
+				return nil, err
+			}
+		case tar.TypeReg:
+			err = os.MkdirAll(filepath.Dir(target), 0o750)
+			if err != nil {
+				return nil, err
+			}
+			err = extractModuleFile(tr, target, header.FileInfo().Mode().Perm())
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+func extractModuleFile(r io.Reader, target string, mode os.FileMode) error {
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode)
+	if err != nil {
+		return err
+	}
+	//nolint:gosec // Entries are written by the cache itself.
+	_, err = io.Copy(f, r)
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// rekeyModule replaces the name of a module call at the start of the key of a
+// module, or the name of its package directory. It returns false if the key
+// doesn't belong to the call.
+func rekeyModule(key, from, to string) (string, bool) {
+	if key == from {
+		return to, true
+	}
+	if rest, ok := strings.CutPrefix(key, from+"."); ok {
+		return to + "." + rest, true
+	}
+	return "", false
+}
+
+// evict removes the least recently used entries until the cache is under its
+// maximum size. Only one provisioner evicts at a time; the others skip it.
+func (c *ModuleCache) evict(ctx context.Context, logger slog.Logger) error {
+	lock := flock.New(filepath.Join(c.dir, "lock"))
+	locked, err := lock.TryLock()
+	if err != nil {
+		return xerrors.Errorf("lock module cache: %w", err)
+	}
+	if !locked {
+		return nil
+	}
+	defer lock.Close()
+
+	dirEntries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return xerrors.Errorf("read module cache: %w", err)
+	}
+	type entry struct {
+		path    string
+		size    int64
+		modTime time.Time
+	}
+	var (
+		entries []entry
+		size    int64
+	)
+	for _, dirEntry := range dirEntries {
+		info, err := dirEntry.Info()
+		if xerrors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if strings.HasSuffix(dirEntry.Name(), ".tmp") && time.Since(info.ModTime()) > time.Hour {
+			// Left behind by a provisioner that stopped while storing an entry.
+			_ = os.Remove(filepath.Join(c.dir, dirEntry.Name()))
+			continue
+		}
+		if !strings.HasSuffix(dirEntry.Name(), moduleCacheEntryExt) {
+			continue
+		}
+		entries = append(entries, entry{
+			path:    filepath.Join(c.dir, dirEntry.Name()),
+			size:    info.Size(),
+			modTime: info.ModTime(),
+		})
+		size += info.Size()
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].modTime.Before(entries[j].modTime)
+	})
+	for _, e := range entries {
+		if size <= c.maxSize {
+			break
+		}
+		err = os.Remove(e.path)
+		if err != nil && !xerrors.Is(err, os.ErrNotExist) {
+			return xerrors.Errorf("evict %q: %w", e.path, err)
+		}
+		logger.Debug(ctx, "evicted module from cache", slog.F("path", e.path), slog.F("size", e.size))
+		c.metrics.Evictions.Inc()
+		size -= e.size
+	}
+	c.metrics.SizeBytes.Set(float64(size))
+	return nil
+}
@@ -0,0 +1,165 @@
+package terraform
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	ptestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"cdr.dev/slog/sloggers/slogtest"
+	"github.com/coder/coder/v2/testutil"
+)
+
+const moduleCacheTemplate = `
+module "code-server" {
+  source  = "registry.coder.com/modules/code-server/coder"
+  version = "1.0.18"
+}
+
+module "dotfiles" {
+  source = "git::https://github.com/coder/modules.git//dotfiles?ref=v1.0.0"
+}
+
+module "unpinned" {
+  source  = "registry.coder.com/modules/jetbrains-gateway/coder"
+  version = ">= 1.0.0"
+}
+
+module "branch" {
+  source = "git::https://github.com/coder/modules.git//dotfiles?ref=main"
+}
+
+module "local" {
+  source = "./local"
+}
+`
+
+func TestModuleCache(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutil.Context(t, testutil.WaitShort)
+	log := slogtest.Make(t, nil)
+	reg := prometheus.NewRegistry()
+	cache, err := NewModuleCache(t.TempDir(), 1<<20, NewModuleCacheMetrics(reg))
+	require.NoError(t, err)
+
+	workdir := t.TempDir()
+	writeModuleFile(t, workdir, "main.tf", moduleCacheTemplate)
+	// Nothing is cached yet.
+	require.NoError(t, cache.restore(ctx, log, workdir))
+	require.NoDirExists(t, modulesDir(workdir))
+
+	// Lay out the workdir as `terraform init` leaves it.
+	manifest := []moduleRecord{
+		{Key: "", Source: "", Dir: "."},
+		{Key: "code-server", Source: "registry.coder.com/modules/code-server/coder", Version: "1.0.18", Dir: ".terraform/modules/code-server"},
+		{Key: "dotfiles", Source: "git::https://github.com/coder/modules.git//dotfiles?ref=v1.0.0", Dir: ".terraform/modules/dotfiles/dotfiles"},
+		{Key: "dotfiles.helper", Source: "./helper", Dir: ".terraform/modules/dotfiles/dotfiles/helper"},
+		{Key: "dotfiles.remote", Source: "registry.coder.com/modules/remote/coder", Version: "2.0.0", Dir: ".terraform/modules/dotfiles.remote"},
+		{Key: "unpinned", Source: "registry.coder.com/modules/jetbrains-gateway/coder", Version: "1.0.2", Dir: ".terraform/modules/unpinned"},
+		{Key: "local", Source: "./local", Dir: "local"},
+	}
+	writeModuleFile(t, workdir, ".terraform/modules/code-server/main.tf", "# code-server")
+	writeModuleFile(t, workdir, ".terraform/modules/dotfiles/dotfiles/main.tf", "# dotfiles")
+	writeModuleFile(t, workdir, ".terraform/modules/dotfiles/dotfiles/helper/main.tf", "# helper")
+	writeModuleFile(t, workdir, ".terraform/modules/dotfiles.remote/main.tf", "# remote")
+	writeModuleFile(t, workdir, ".terraform/modules/unpinned/main.tf", "# unpinned")
+	writeModuleFile(t, workdir, "local/main.tf", "# local")
+	data, err := json.Marshal(moduleManifest{Modules: manifest})
+	require.NoError(t, err)
+	writeModuleFile(t, workdir, ".terraform/modules/modules.json", string(data))
+
+	require.NoError(t, cache.store(ctx, log, workdir))
+	entries, err := filepath.Glob(filepath.Join(cache.dir, "*"+moduleCacheEntryExt))
+	require.NoError(t, err)
+	// Only pinned modules are cached.
+	require.Len(t, entries, 2)
+
+	// A module cached for one call is restored for another call of the same
+	// source and version.
+	restoredDir := t.TempDir()
+	writeModuleFile(t, restoredDir, "main.tf", `
+module "ide" {
+  source  = "registry.coder.com/modules/code-server/coder"
+  version = "1.0.18"
+}
+
+module "dotfiles" {
+  source = "git::https://github.com/coder/modules.git//dotfiles?ref=v1.0.0"
+}
+
+module "other" {
+  source  = "registry.coder.com/modules/code-server/coder"
+  version = "1.0.19"
+}
+`)
+	require.NoError(t, cache.restore(ctx, log, restoredDir))
+	requireModuleFile(t, restoredDir, ".terraform/modules/ide/main.tf", "# code-server")
+	requireModuleFile(t, restoredDir, ".terraform/modules/dotfiles/dotfiles/main.tf", "# dotfiles")
+	requireModuleFile(t, restoredDir, ".terraform/modules/dotfiles/dotfiles/helper/main.tf", "# helper")
+	requireModuleFile(t, restoredDir, ".terraform/modules/dotfiles.remote/main.tf", "# remote")
+	data, err = os.ReadFile(filepath.Join(restoredDir, ".terraform/modules/modules.json"))
+	require.NoError(t, err)
+	var restored moduleManifest
+	require.NoError(t, json.Unmarshal(data, &restored))
+	require.ElementsMatch(t, []moduleRecord{
+		{Key: "", Source: "", Dir: "."},
+		{Key: "ide", Source: "registry.coder.com/modules/code-server/coder", Version: "1.0.18", Dir: ".terraform/modules/ide"},
+		{Key: "dotfiles", Source: "git::https://github.com/coder/modules.git//dotfiles?ref=v1.0.0", Dir: ".terraform/modules/dotfiles/dotfiles"},
+		{Key: "dotfiles.helper", Source: "./helper", Dir: ".terraform/modules/dotfiles/dotfiles/helper"},
+		{Key: "dotfiles.remote", Source: "registry.coder.com/modules/remote/coder", Version: "2.0.0", Dir: ".terraform/modules/dotfiles.remote"},
+	}, restored.Modules)
+
+	require.Equal(t, 2.0, ptestutil.ToFloat64(cache.metrics.Lookups.WithLabelValues("hit")))
+	// The two pinned modules before they were cached, and the other version.
+	require.Equal(t, 3.0, ptestutil.ToFloat64(cache.metrics.Lookups.WithLabelValues("miss")))
+
+	// The least recently used entries are evicted once the cache is full.
+	var total int64
+	for _, entry := range entries {
+		info, err := os.Stat(entry)
+		require.NoError(t, err)
+		total += info.Size()
+	}
+	cache.maxSize = total - 1
+	require.NoError(t, cache.evict(ctx, log))
+	remaining, err := filepath.Glob(filepath.Join(cache.dir, "*"+moduleCacheEntryExt))
+	require.NoError(t, err)
+	require.Len(t, remaining, 1)
+	require.Equal(t, 1.0, ptestutil.ToFloat64(cache.metrics.Evictions))
+}
+
+func TestPinnedRef(t *testing.T) {
+	t.Parallel()
+
+	for source, pinned := range map[string]bool{
+		"git::https://github.com/coder/modules.git//dotfiles?ref=v1.0.0":                         true,
+		"github.com/coder/modules?depth=1&ref=1.2.3-rc.1":                                        true,
+		"git::https://github.com/coder/modules.git?ref=0123456789abcdef0123456789abcdef01234567": true,
+		"git::https://github.com/coder/modules.git//dotfiles?ref=main":                           false,
+		"git::https://github.com/coder/modules.git//dotfiles?ref=v1":                             false,
+		"git::https://github.com/coder/modules.git//dotfiles?ref=0123456":                        false,
+		"git::https://github.com/coder/modules.git//dotfiles":                                    false,
+		"s3::https://s3-eu-west-1.amazonaws.com/bucket/module.zip?version=v1.0.0":                false,
+	} {
+		require.Equal(t, pinned, pinnedRef(source), source)
+	}
+}
+
+func writeModuleFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	p := filepath.Join(dir, filepath.FromSlash(name))
+	require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o750))
+	require.NoError(t, os.WriteFile(p, []byte(content), 0o600))
+}
+
+func requireModuleFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+	require.NoError(t, err)
+	require.Equal(t, content, string(data))
+}
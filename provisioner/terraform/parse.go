@@ -160,6 +160,11 @@ func loadTerraformVariables(module *tfconfig.Module) ([]*proto.TemplateVariable,
 	// Sort variables by (filename, line) to make the ordering consistent
 	variables := make([]*tfconfig.Variable, 0, len(module.Variables))
 	for _, v := range module.Variables {
+		// The snapshot variables are set by snapshot jobs, not by template
+		// admins.
+		if strings.HasPrefix(v.Name, snapshotVariablePrefix) {
+			continue
+		}
 		variables = append(variables, v)
 	}
 	sort.Slice(variables, func(i, j int) bool {
@@ -74,6 +74,28 @@ func TestParse(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "snapshot-variables",
+			Files: map[string]string{
+				"main.tf": `variable "A" {
+				default = "wow"
+			}
+			variable "coder_snapshot_action" {
+				default = ""
+			}
+			variable "coder_snapshot_data" {
+				default = ""
+			}`,
+			},
+			Response: &proto.ParseComplete{
+				TemplateVariables: []*proto.TemplateVariable{
+					{
+						Name:         "A",
+						DefaultValue: "wow",
+					},
+				},
+			},
+		},
 		{
 			Name: "bad-syntax",
 			Files: map[string]string{
@@ -72,7 +72,11 @@ func (s *server) Plan(
 	defer cancel()
 	defer kill()
 
-	e := s.executor(sess.WorkDirectory, database.ProvisionerJobTimingStagePlan)
+	binaryPath, err := s.binaryPathFor(sess.Config)
+	if err != nil {
+		return provisionersdk.PlanErrorf(err.Error())
+	}
+	e := s.executor(binaryPath, sess.WorkDirectory, database.ProvisionerJobTimingStagePlan)
 	if err := e.checkMinVersion(ctx); err != nil {
 		return provisionersdk.PlanErrorf(err.Error())
 	}
@@ -96,30 +100,11 @@ func (s *server) Plan(
 		}
 	}
 
-	err := CleanStaleTerraformPlugins(sess.Context(), s.cachePath, afero.NewOsFs(), time.Now(), s.logger)
+	initTimings, err := s.initWorkspace(ctx, killCtx, e, sess)
 	if err != nil {
-		return provisionersdk.PlanErrorf("unable to clean stale Terraform plugins: %s", err)
-	}
-
-	s.logger.Debug(ctx, "running initialization")
-
-	// The JSON output of `terraform init` doesn't include discrete fields for capturing timings of each plugin,
-	// so we capture the whole init process.
-	initTimings := newTimingAggregator(database.ProvisionerJobTimingStageInit)
-	initTimings.ingest(createInitTimingsEvent(timingInitStart))
-
-	err = e.init(ctx, killCtx, sess)
-	if err != nil {
-		initTimings.ingest(createInitTimingsEvent(timingInitErrored))
-
-		s.logger.Debug(ctx, "init failed", slog.Error(err))
-		return provisionersdk.PlanErrorf("initialize terraform: %s", err)
+		return provisionersdk.PlanErrorf(err.Error())
 	}
 
-	initTimings.ingest(createInitTimingsEvent(timingInitComplete))
-
-	s.logger.Debug(ctx, "ran initialization")
-
 	env, err := provisionEnv(sess.Config, request.Metadata, request.RichParameterValues, request.ExternalAuthProviders)
 	if err != nil {
 		return provisionersdk.PlanErrorf("setup env: %s", err)
@@ -133,6 +118,7 @@ func (s *server) Plan(
 	resp, err := e.plan(
 		ctx, killCtx, env, vars, sess,
 		request.Metadata.GetWorkspaceTransition() == proto.WorkspaceTransition_DESTROY,
+		request.RefreshOnly,
 	)
 	if err != nil {
 		return provisionersdk.PlanErrorf(err.Error())
@@ -144,6 +130,41 @@ func (s *server) Plan(
 	return resp
 }
 
+// initWorkspace runs `terraform init` in the work directory of the session,
+// restoring and storing cached modules around it.
+func (s *server) initWorkspace(ctx, killCtx context.Context, e *executor, sess *provisionersdk.Session) (*timingAggregator, error) {
+	err := CleanStaleTerraformPlugins(sess.Context(), s.cachePath, afero.NewOsFs(), time.Now(), s.logger)
+	if err != nil {
+		return nil, xerrors.Errorf("unable to clean stale Terraform plugins: %w", err)
+	}
+
+	s.logger.Debug(ctx, "running initialization")
+
+	// The JSON output of `terraform init` doesn't include discrete fields for capturing timings of each plugin,
+	// so we capture the whole init process.
+	initTimings := newTimingAggregator(database.ProvisionerJobTimingStageInit)
+	s.withModuleCache(ctx, initTimings, "restoring cached modules", func(c *ModuleCache) error {
+		return c.restore(ctx, s.logger, sess.WorkDirectory)
+	})
+	initTimings.ingest(createInitTimingsEvent(timingInitStart))
+
+	err = e.init(ctx, killCtx, sess)
+	if err != nil {
+		initTimings.ingest(createInitTimingsEvent(timingInitErrored))
+
+		s.logger.Debug(ctx, "init failed", slog.Error(err))
+		return nil, xerrors.Errorf("initialize terraform: %w", err)
+	}
+
+	initTimings.ingest(createInitTimingsEvent(timingInitComplete))
+	s.withModuleCache(ctx, initTimings, "caching modules", func(c *ModuleCache) error {
+		return c.store(ctx, s.logger, sess.WorkDirectory)
+	})
+
+	s.logger.Debug(ctx, "ran initialization")
+	return initTimings, nil
+}
+
 func (s *server) Apply(
 	sess *provisionersdk.Session, request *proto.ApplyRequest, canceledOrComplete <-chan struct{},
 ) *proto.ApplyComplete {
@@ -153,7 +174,11 @@ func (s *server) Apply(
 	defer cancel()
 	defer kill()
 
-	e := s.executor(sess.WorkDirectory, database.ProvisionerJobTimingStageApply)
+	binaryPath, err := s.binaryPathFor(sess.Config)
+	if err != nil {
+		return provisionersdk.ApplyErrorf(err.Error())
+	}
+	e := s.executor(binaryPath, sess.WorkDirectory, database.ProvisionerJobTimingStageApply)
 	if err := e.checkMinVersion(ctx); err != nil {
 		return provisionersdk.ApplyErrorf(err.Error())
 	}
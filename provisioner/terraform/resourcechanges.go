@@ -0,0 +1,138 @@
+package terraform
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/coder/coder/v2/provisionersdk/proto"
+)
+
+// ConvertResourceChanges returns the changes of a plan to managed resources,
+// either the changes it makes or the drift it detected. Resources without
+// changes and data sources are omitted, and the values of sensitive
+// attributes are masked.
+func ConvertResourceChanges(changes []*tfjson.ResourceChange) []*proto.ResourceChange {
+	converted := make([]*proto.ResourceChange, 0, len(changes))
+	for _, change := range changes {
+		if change == nil || change.Change == nil || change.Mode != tfjson.ManagedResourceMode {
+			continue
+		}
+		var action proto.ResourceChange_Action
+		switch actions := change.Change.Actions; {
+		case actions.Replace():
+			action = proto.ResourceChange_REPLACE
+		case actions.Create():
+			action = proto.ResourceChange_CREATE
+		case actions.Update():
+			action = proto.ResourceChange_UPDATE
+		case actions.Delete():
+			action = proto.ResourceChange_DELETE
+		default:
+			continue
+		}
+		converted = append(converted, &proto.ResourceChange{
+			Address:    change.Address,
+			Type:       change.Type,
+			Name:       change.Name,
+			Action:     action,
+			Attributes: convertAttributeChanges(change.Change),
+		})
+	}
+	return converted
+}
+
+// convertAttributeChanges returns the top-level attributes that differ
+// between the prior and the planned resource, sorted by name.
+func convertAttributeChanges(change *tfjson.Change) []*proto.ResourceChange_AttributeChange {
+	before, _ := change.Before.(map[string]interface{})
+	after, _ := change.After.(map[string]interface{})
+	unknown, _ := change.AfterUnknown.(map[string]interface{})
+	beforeSensitive, _ := change.BeforeSensitive.(map[string]interface{})
+	afterSensitive, _ := change.AfterSensitive.(map[string]interface{})
+
+	replace := map[string]bool{}
+	for _, path := range change.ReplacePaths {
+		steps, ok := path.([]interface{})
+		if !ok || len(steps) == 0 {
+			continue
+		}
+		if name, ok := steps[0].(string); ok {
+			replace[name] = true
+		}
+	}
+
+	names := map[string]struct{}{}
+	for name := range before {
+		names[name] = struct{}{}
+	}
+	for name := range after {
+		names[name] = struct{}{}
+	}
+	for name := range unknown {
+		names[name] = struct{}{}
+	}
+
+	attributes := make([]*proto.ResourceChange_AttributeChange, 0, len(names))
+	for name := range names {
+		isUnknown := containsTrue(unknown[name])
+		if !isUnknown && reflect.DeepEqual(before[name], after[name]) {
+			continue
+		}
+		attribute := &proto.ResourceChange_AttributeChange{
+			Name:              name,
+			Sensitive:         containsTrue(beforeSensitive[name]) || containsTrue(afterSensitive[name]),
+			Unknown:           isUnknown,
+			ForcesReplacement: replace[name],
+		}
+		if !attribute.Sensitive {
+			attribute.Before = encodeAttributeValue(before, name)
+			if !isUnknown {
+				attribute.After = encodeAttributeValue(after, name)
+			}
+		}
+		attributes = append(attributes, attribute)
+	}
+	sort.Slice(attributes, func(i, j int) bool {
+		return attributes[i].Name < attributes[j].Name
+	})
+	return attributes
+}
+
+// containsTrue returns true if v is true or a collection that contains true,
+// which is how Terraform marks (parts of) values as sensitive or unknown.
+func containsTrue(v interface{}) bool {
+	switch v := v.(type) {
+	case bool:
+		return v
+	case []interface{}:
+		for _, e := range v {
+			if containsTrue(e) {
+				return true
+			}
+		}
+	case map[string]interface{}:
+		for _, e := range v {
+			if containsTrue(e) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// encodeAttributeValue returns the JSON encoded value of an attribute, or
+// an empty string if the object doesn't have it.
+func encodeAttributeValue(object map[string]interface{}, name string) string {
+	v, ok := object[name]
+	if !ok {
+		return ""
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
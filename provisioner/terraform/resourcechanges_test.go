@@ -0,0 +1,132 @@
+package terraform_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/stretchr/testify/require"
+	protobuf "google.golang.org/protobuf/proto"
+
+	"github.com/coder/coder/v2/provisioner/terraform"
+	"github.com/coder/coder/v2/provisionersdk/proto"
+)
+
+func TestConvertResourceChanges(t *testing.T) {
+	t.Parallel()
+
+	// The changes are in the format of `terraform show -json`.
+	var plan tfjson.Plan
+	err := json.Unmarshal([]byte(`{
+		"format_version": "1.2",
+		"resource_changes": [
+			{
+				"address": "docker_volume.home",
+				"mode": "managed",
+				"type": "docker_volume",
+				"name": "home",
+				"change": {
+					"actions": ["delete", "create"],
+					"before": {"name": "coder-home", "id": "abc"},
+					"after": {"name": "coder-home-2"},
+					"after_unknown": {"id": true},
+					"before_sensitive": {},
+					"after_sensitive": {},
+					"replace_paths": [["name"]]
+				}
+			},
+			{
+				"address": "docker_container.workspace[0]",
+				"mode": "managed",
+				"type": "docker_container",
+				"name": "workspace",
+				"change": {
+					"actions": ["update"],
+					"before": {"image": "ubuntu:22.04", "env": ["CODER_AGENT_TOKEN=old"], "hostname": "dev"},
+					"after": {"image": "ubuntu:24.04", "env": ["CODER_AGENT_TOKEN=new"], "hostname": "dev"},
+					"after_unknown": {},
+					"before_sensitive": {"env": [true]},
+					"after_sensitive": {"env": [true]}
+				}
+			},
+			{
+				"address": "coder_agent.main",
+				"mode": "managed",
+				"type": "coder_agent",
+				"name": "main",
+				"change": {
+					"actions": ["no-op"],
+					"before": {"os": "linux"},
+					"after": {"os": "linux"}
+				}
+			},
+			{
+				"address": "null_resource.old",
+				"mode": "managed",
+				"type": "null_resource",
+				"name": "old",
+				"change": {
+					"actions": ["delete"],
+					"before": {"id": "123"},
+					"after": null
+				}
+			},
+			{
+				"address": "data.coder_workspace.me",
+				"mode": "data",
+				"type": "coder_workspace",
+				"name": "me",
+				"change": {
+					"actions": ["read"],
+					"before": null,
+					"after": {"name": "dev"}
+				}
+			}
+		]
+	}`), &plan)
+	require.NoError(t, err)
+
+	expected := []*proto.ResourceChange{{
+		Address: "docker_volume.home",
+		Type:    "docker_volume",
+		Name:    "home",
+		Action:  proto.ResourceChange_REPLACE,
+		Attributes: []*proto.ResourceChange_AttributeChange{{
+			Name:    "id",
+			Before:  `"abc"`,
+			Unknown: true,
+		}, {
+			Name:              "name",
+			Before:            `"coder-home"`,
+			After:             `"coder-home-2"`,
+			ForcesReplacement: true,
+		}},
+	}, {
+		Address: "docker_container.workspace[0]",
+		Type:    "docker_container",
+		Name:    "workspace",
+		Action:  proto.ResourceChange_UPDATE,
+		Attributes: []*proto.ResourceChange_AttributeChange{{
+			Name:      "env",
+			Sensitive: true,
+		}, {
+			Name:   "image",
+			Before: `"ubuntu:22.04"`,
+			After:  `"ubuntu:24.04"`,
+		}},
+	}, {
+		Address: "null_resource.old",
+		Type:    "null_resource",
+		Name:    "old",
+		Action:  proto.ResourceChange_DELETE,
+		Attributes: []*proto.ResourceChange_AttributeChange{{
+			Name:   "id",
+			Before: `"123"`,
+		}},
+	}}
+	actual := terraform.ConvertResourceChanges(plan.ResourceChanges)
+	require.Len(t, actual, len(expected))
+	for i := range expected {
+		require.True(t, protobuf.Equal(expected[i], actual[i]), "expected %v, got %v", expected[i], actual[i])
+	}
+}
@@ -102,9 +102,16 @@ type agentScriptAttributes struct {
 
 // A mapping of attributes on the "healthcheck" resource.
 type appHealthcheckAttributes struct {
-	URL       string `mapstructure:"url"`
-	Interval  int32  `mapstructure:"interval"`
-	Threshold int32  `mapstructure:"threshold"`
+	URL            string `mapstructure:"url"`
+	Interval       int32  `mapstructure:"interval"`
+	Threshold      int32  `mapstructure:"threshold"`
+	Kind           string `mapstructure:"kind"`
+	ExpectedStatus int32  `mapstructure:"expected_status"`
+	BodyRegex      string `mapstructure:"body_regex"`
+	Address        string `mapstructure:"address"`
+	GRPCService    string `mapstructure:"grpc_service"`
+	Command        string `mapstructure:"command"`
+	Path           string `mapstructure:"path"`
 }
 
 // A mapping of attributes on the "coder_metadata" resource.
@@ -408,10 +415,30 @@ func ConvertState(modules []*tfjson.StateModule, rawGraph string) (*State, error
 
 			var healthcheck *proto.Healthcheck
 			if len(attrs.Healthcheck) != 0 {
+				// Older versions of the provider don't have the kind
+				// attribute, and only support HTTP health checks.
+				kind := proto.Healthcheck_HTTP
+				if attrs.Healthcheck[0].Kind != "" {
+					value, ok := proto.Healthcheck_Kind_value[strings.ToUpper(attrs.Healthcheck[0].Kind)]
+					if !ok {
+						return nil, xerrors.Errorf("unknown healthcheck kind %q for app %q", attrs.Healthcheck[0].Kind, attrs.Slug)
+					}
+					kind = proto.Healthcheck_Kind(value)
+				}
 				healthcheck = &proto.Healthcheck{
-					Url:       attrs.Healthcheck[0].URL,
-					Interval:  attrs.Healthcheck[0].Interval,
-					Threshold: attrs.Healthcheck[0].Threshold,
+					Url:            attrs.Healthcheck[0].URL,
+					Interval:       attrs.Healthcheck[0].Interval,
+					Threshold:      attrs.Healthcheck[0].Threshold,
+					Kind:           kind,
+					ExpectedStatus: attrs.Healthcheck[0].ExpectedStatus,
+					BodyRegex:      attrs.Healthcheck[0].BodyRegex,
+					Address:        attrs.Healthcheck[0].Address,
+					GrpcService:    attrs.Healthcheck[0].GRPCService,
+					Command:        attrs.Healthcheck[0].Command,
+					Path:           attrs.Healthcheck[0].Path,
+				}
+				if err := provisioner.ValidateAppHealthcheck(healthcheck); err != nil {
+					return nil, xerrors.Errorf("invalid healthcheck for app %q: %w", attrs.Slug, err)
 				}
 			}
 
@@ -846,6 +846,87 @@ func TestAppSlugValidation(t *testing.T) {
 	require.ErrorContains(t, err, "duplicate app slug")
 }
 
+func TestAppHealthcheckKinds(t *testing.T) {
+	t.Parallel()
+
+	loadPlan := func(t *testing.T, healthcheck map[string]interface{}) (*tfjson.Plan, string) {
+		t.Helper()
+
+		// Load the multiple-apps state file and edit the healthcheck of app2.
+		dir := filepath.Join("testdata", "multiple-apps")
+		tfPlanRaw, err := os.ReadFile(filepath.Join(dir, "multiple-apps.tfplan.json"))
+		require.NoError(t, err)
+		var tfPlan tfjson.Plan
+		err = json.Unmarshal(tfPlanRaw, &tfPlan)
+		require.NoError(t, err)
+		tfPlanGraph, err := os.ReadFile(filepath.Join(dir, "multiple-apps.tfplan.dot"))
+		require.NoError(t, err)
+
+		for _, resource := range tfPlan.PlannedValues.RootModule.Resources {
+			if resource.Type == "coder_app" && resource.Name == "app2" {
+				resource.AttributeValues["healthcheck"] = []interface{}{healthcheck}
+			}
+		}
+		return &tfPlan, string(tfPlanGraph)
+	}
+
+	t.Run("Kinds", func(t *testing.T) {
+		t.Parallel()
+
+		for _, tc := range []struct {
+			healthcheck map[string]interface{}
+			expected    *proto.Healthcheck
+		}{{
+			healthcheck: map[string]interface{}{"kind": "http", "url": "http://localhost:13337/healthz", "expected_status": 204, "body_regex": "^ok$", "interval": 5, "threshold": 6},
+			expected:    &proto.Healthcheck{Kind: proto.Healthcheck_HTTP, Url: "http://localhost:13337/healthz", ExpectedStatus: 204, BodyRegex: "^ok$", Interval: 5, Threshold: 6},
+		}, {
+			healthcheck: map[string]interface{}{"kind": "tcp", "address": "localhost:5432", "interval": 5, "threshold": 6},
+			expected:    &proto.Healthcheck{Kind: proto.Healthcheck_TCP, Address: "localhost:5432", Interval: 5, Threshold: 6},
+		}, {
+			healthcheck: map[string]interface{}{"kind": "grpc", "address": "localhost:9090", "grpc_service": "app.v1.App", "interval": 5, "threshold": 6},
+			expected:    &proto.Healthcheck{Kind: proto.Healthcheck_GRPC, Address: "localhost:9090", GrpcService: "app.v1.App", Interval: 5, Threshold: 6},
+		}, {
+			healthcheck: map[string]interface{}{"kind": "command", "command": "pg_isready", "interval": 5, "threshold": 6},
+			expected:    &proto.Healthcheck{Kind: proto.Healthcheck_COMMAND, Command: "pg_isready", Interval: 5, Threshold: 6},
+		}, {
+			healthcheck: map[string]interface{}{"kind": "file", "path": "/tmp/ready", "interval": 5, "threshold": 6},
+			expected:    &proto.Healthcheck{Kind: proto.Healthcheck_FILE, Path: "/tmp/ready", Interval: 5, Threshold: 6},
+		}} {
+			tfPlan, tfPlanGraph := loadPlan(t, tc.healthcheck)
+			state, err := terraform.ConvertState([]*tfjson.StateModule{tfPlan.PlannedValues.RootModule}, tfPlanGraph)
+			require.NoError(t, err)
+			require.Len(t, state.Resources, 1)
+			require.Len(t, state.Resources[0].Agents, 1)
+			var found bool
+			for _, app := range state.Resources[0].Agents[0].Apps {
+				if app.Slug == "app2" {
+					require.True(t, protobuf.Equal(tc.expected, app.Healthcheck), "expected %v, got %v", tc.expected, app.Healthcheck)
+					found = true
+				}
+			}
+			require.True(t, found)
+		}
+	})
+
+	t.Run("UnknownKind", func(t *testing.T) {
+		t.Parallel()
+
+		tfPlan, tfPlanGraph := loadPlan(t, map[string]interface{}{"kind": "udp", "address": "localhost:53", "interval": 5, "threshold": 6})
+		state, err := terraform.ConvertState([]*tfjson.StateModule{tfPlan.PlannedValues.RootModule}, tfPlanGraph)
+		require.Nil(t, state)
+		require.ErrorContains(t, err, `unknown healthcheck kind "udp"`)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		t.Parallel()
+
+		tfPlan, tfPlanGraph := loadPlan(t, map[string]interface{}{"kind": "tcp", "interval": 5, "threshold": 6})
+		state, err := terraform.ConvertState([]*tfjson.StateModule{tfPlan.PlannedValues.RootModule}, tfPlanGraph)
+		require.Nil(t, state)
+		require.ErrorContains(t, err, "tcp healthcheck must have an address")
+	})
+}
+
 func TestMetadataResourceDuplicate(t *testing.T) {
 	t.Parallel()
 
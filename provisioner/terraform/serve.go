@@ -26,7 +26,15 @@ type ServeOptions struct {
 	BinaryPath string
 	// CachePath must not be used by multiple processes at once.
 	CachePath string
-	Tracer    trace.Tracer
+	// Binaries are the versions of IaC engines installed from a mirror, which
+	// jobs of template versions that require an engine run. Other jobs run
+	// BinaryPath.
+	Binaries []Binary
+	// ModuleCache stores the remote modules that jobs download, so that later
+	// jobs don't download them again. It may be shared by several
+	// provisioners. If nil, modules are downloaded by every job.
+	ModuleCache *ModuleCache
+	Tracer      trace.Tracer
 
 	// ExitTimeout defines how long we will wait for a running Terraform
 	// command to exit (cleanly) if the provision was stopped. This
@@ -119,6 +127,8 @@ func Serve(ctx context.Context, options *ServeOptions) error {
 		execMut:     &sync.Mutex{},
 		binaryPath:  options.BinaryPath,
 		cachePath:   options.CachePath,
+		binaries:    options.Binaries,
+		moduleCache: options.ModuleCache,
 		logger:      options.Logger,
 		tracer:      options.Tracer,
 		exitTimeout: options.ExitTimeout,
@@ -129,6 +139,8 @@ type server struct {
 	execMut     *sync.Mutex
 	binaryPath  string
 	cachePath   string
+	binaries    []Binary
+	moduleCache *ModuleCache
 	logger      slog.Logger
 	tracer      trace.Tracer
 	exitTimeout time.Duration
@@ -140,11 +152,11 @@ func (s *server) startTrace(ctx context.Context, name string, opts ...trace.Span
 	))...)
 }
 
-func (s *server) executor(workdir string, stage database.ProvisionerJobTimingStage) *executor {
+func (s *server) executor(binaryPath, workdir string, stage database.ProvisionerJobTimingStage) *executor {
 	return &executor{
 		server:     s,
 		mut:        s.execMut,
-		binaryPath: s.binaryPath,
+		binaryPath: binaryPath,
 		cachePath:  s.cachePath,
 		workdir:    workdir,
 		logger:     s.logger.Named("executor"),
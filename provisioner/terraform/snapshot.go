@@ -0,0 +1,139 @@
+package terraform
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-config-inspect/tfconfig"
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/tracing"
+	"github.com/coder/coder/v2/provisionersdk"
+	"github.com/coder/coder/v2/provisionersdk/proto"
+)
+
+const (
+	// snapshotVariablePrefix is the prefix of the Terraform variables that a
+	// snapshot job sets. Templates declare the resources that back a
+	// workspace up, or restore it, against them, so they aren't template
+	// variables.
+	snapshotVariablePrefix = "coder_snapshot_"
+	// snapshotOutput is the Terraform output whose value becomes the data of
+	// a created snapshot.
+	snapshotOutput = "coder_snapshot"
+)
+
+// Snapshot applies the template against the state of the workspace with the
+// coder_snapshot_* variables set. Creating a snapshot may only create
+// resources; its state is discarded, and the value of the coder_snapshot
+// output becomes the data of the snapshot. Restoring passes that data back in
+// coder_snapshot_data, and returns the resulting state as the new state of
+// the workspace.
+func (s *server) Snapshot(
+	sess *provisionersdk.Session, request *proto.SnapshotRequest, canceledOrComplete <-chan struct{},
+) *proto.SnapshotComplete {
+	ctx, span := s.startTrace(sess.Context(), tracing.FuncName())
+	defer span.End()
+	ctx, cancel, killCtx, kill := s.setupContexts(ctx, canceledOrComplete)
+	defer cancel()
+	defer kill()
+
+	module, diags := tfconfig.LoadModule(sess.WorkDirectory)
+	if diags.HasErrors() {
+		return provisionersdk.SnapshotErrorf("load module: %s", formatDiagnostics(sess.WorkDirectory, diags))
+	}
+	if _, ok := module.Outputs[snapshotOutput]; !ok {
+		return provisionersdk.SnapshotErrorf("the template does not support snapshots: it must declare an output named %q", snapshotOutput)
+	}
+	if len(sess.Config.State) == 0 {
+		return provisionersdk.SnapshotErrorf("the workspace has no state to snapshot")
+	}
+
+	binaryPath, err := s.binaryPathFor(sess.Config)
+	if err != nil {
+		return provisionersdk.SnapshotErrorf(err.Error())
+	}
+	e := s.executor(binaryPath, sess.WorkDirectory, database.ProvisionerJobTimingStageApply)
+	if err := e.checkMinVersion(ctx); err != nil {
+		return provisionersdk.SnapshotErrorf(err.Error())
+	}
+	logTerraformEnvVars(sess)
+
+	statefilePath := getStateFilePath(sess.WorkDirectory)
+	err = os.WriteFile(statefilePath, sess.Config.State, 0o600)
+	if err != nil {
+		return provisionersdk.SnapshotErrorf("write statefile %q: %s", statefilePath, err)
+	}
+
+	_, err = s.initWorkspace(ctx, killCtx, e, sess)
+	if err != nil {
+		return provisionersdk.SnapshotErrorf(err.Error())
+	}
+
+	env, err := provisionEnv(sess.Config, request.Metadata, request.RichParameterValues, request.ExternalAuthProviders)
+	if err != nil {
+		return provisionersdk.SnapshotErrorf("setup env: %s", err)
+	}
+	env = append(env, snapshotEnv(request)...)
+
+	vars := make([]string, 0, len(request.VariableValues))
+	for _, variable := range request.VariableValues {
+		vars = append(vars, fmt.Sprintf("%s=%s", variable.Name, variable.Value))
+	}
+
+	plan, err := e.plan(ctx, killCtx, env, vars, sess, false, false)
+	if err != nil {
+		return provisionersdk.SnapshotErrorf(err.Error())
+	}
+	if request.Action == proto.SnapshotAction_CREATE {
+		err = checkSnapshotChanges(plan.ResourceChanges)
+		if err != nil {
+			return provisionersdk.SnapshotErrorf(err.Error())
+		}
+	}
+
+	applied, err := e.apply(ctx, killCtx, env, sess)
+	if err != nil {
+		return provisionersdk.SnapshotErrorf(err.Error())
+	}
+	if request.Action == proto.SnapshotAction_RESTORE {
+		return &proto.SnapshotComplete{State: applied.State}
+	}
+
+	data, err := e.output(ctx, killCtx, snapshotOutput)
+	if err != nil {
+		return provisionersdk.SnapshotErrorf("read snapshot data: %s", err)
+	}
+	return &proto.SnapshotComplete{Data: data}
+}
+
+// snapshotEnv returns the environment that sets the snapshot variables of
+// the template.
+func snapshotEnv(request *proto.SnapshotRequest) []string {
+	return []string{
+		"TF_VAR_" + snapshotVariablePrefix + "action=" + strings.ToLower(request.Action.String()),
+		"TF_VAR_" + snapshotVariablePrefix + "id=" + request.SnapshotId,
+		"TF_VAR_" + snapshotVariablePrefix + "name=" + request.SnapshotName,
+		"TF_VAR_" + snapshotVariablePrefix + "data=" + string(request.Data),
+	}
+}
+
+// checkSnapshotChanges returns an error if creating a snapshot would change a
+// resource of the workspace rather than create a new one. Changes to coder_*
+// resources are fine, as they only exist in the state that a snapshot
+// discards.
+func checkSnapshotChanges(changes []*proto.ResourceChange) error {
+	var changed []string
+	for _, change := range changes {
+		if change.Action == proto.ResourceChange_CREATE || strings.HasPrefix(change.Type, "coder_") {
+			continue
+		}
+		changed = append(changed, fmt.Sprintf("%s (%s)", change.Address, strings.ToLower(change.Action.String())))
+	}
+	if len(changed) > 0 {
+		return xerrors.Errorf("creating a snapshot must not change the resources of the workspace, but would change %s", strings.Join(changed, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/provisionersdk/proto"
+)
+
+func TestCheckSnapshotChanges(t *testing.T) {
+	t.Parallel()
+
+	t.Run("OnlyCreates", func(t *testing.T) {
+		t.Parallel()
+		err := checkSnapshotChanges([]*proto.ResourceChange{
+			{Address: "aws_ebs_snapshot.home[0]", Type: "aws_ebs_snapshot", Action: proto.ResourceChange_CREATE},
+			// coder_* resources only exist in the discarded state.
+			{Address: "coder_agent.main", Type: "coder_agent", Action: proto.ResourceChange_REPLACE},
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("ChangesWorkspace", func(t *testing.T) {
+		t.Parallel()
+		err := checkSnapshotChanges([]*proto.ResourceChange{
+			{Address: "aws_ebs_snapshot.home[0]", Type: "aws_ebs_snapshot", Action: proto.ResourceChange_CREATE},
+			{Address: "aws_instance.dev", Type: "aws_instance", Action: proto.ResourceChange_REPLACE},
+			{Address: "aws_ebs_volume.home", Type: "aws_ebs_volume", Action: proto.ResourceChange_UPDATE},
+		})
+		require.ErrorContains(t, err, "aws_instance.dev (replace), aws_ebs_volume.home (update)")
+	})
+}
+
+func TestSnapshotEnv(t *testing.T) {
+	t.Parallel()
+
+	env := snapshotEnv(&proto.SnapshotRequest{
+		Action:       proto.SnapshotAction_RESTORE,
+		SnapshotId:   "8c1a6b5e-4f3e-4d54-9a3b-2f6f8b1c9d10",
+		SnapshotName: "before-upgrade",
+		Data:         []byte(`{"home":"snap-0123"}`),
+	})
+	require.Equal(t, []string{
+		"TF_VAR_coder_snapshot_action=restore",
+		"TF_VAR_coder_snapshot_id=8c1a6b5e-4f3e-4d54-9a3b-2f6f8b1c9d10",
+		"TF_VAR_coder_snapshot_name=before-upgrade",
+		`TF_VAR_coder_snapshot_data={"home":"snap-0123"}`,
+	}, env)
+}
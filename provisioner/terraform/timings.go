@@ -43,6 +43,10 @@ const (
 	timingGraphStart    timingKind = "graph_start"
 	timingGraphComplete timingKind = "graph_complete"
 	timingGraphErrored  timingKind = "graph_errored"
+	// Restoring modules from, and storing them in, the module cache around init.
+	timingModuleCacheStart    timingKind = "module_cache_start"
+	timingModuleCacheComplete timingKind = "module_cache_complete"
+	timingModuleCacheErrored  timingKind = "module_cache_errored"
 	// Other terraform log types which we ignore.
 	timingLog        timingKind = "log"
 	timingInitOutput timingKind = "init_output"
@@ -85,13 +89,13 @@ func (t *timingAggregator) ingest(ts time.Time, s *timingSpan) {
 	ts = dbtime.Time(ts.UTC())
 
 	switch s.kind {
-	case timingApplyStart, timingProvisionStart, timingRefreshStart, timingInitStart, timingGraphStart:
+	case timingApplyStart, timingProvisionStart, timingRefreshStart, timingInitStart, timingGraphStart, timingModuleCacheStart:
 		s.start = ts
 		s.state = proto.TimingState_STARTED
-	case timingApplyComplete, timingProvisionComplete, timingRefreshComplete, timingInitComplete, timingGraphComplete:
+	case timingApplyComplete, timingProvisionComplete, timingRefreshComplete, timingInitComplete, timingGraphComplete, timingModuleCacheComplete:
 		s.end = ts
 		s.state = proto.TimingState_COMPLETED
-	case timingApplyErrored, timingProvisionErrored, timingInitErrored, timingGraphErrored:
+	case timingApplyErrored, timingProvisionErrored, timingInitErrored, timingGraphErrored, timingModuleCacheErrored:
 		s.end = ts
 		s.state = proto.TimingState_FAILED
 	default:
@@ -172,6 +176,9 @@ func (l timingKind) Valid() bool {
 		timingGraphStart,
 		timingGraphComplete,
 		timingGraphErrored,
+		timingModuleCacheStart,
+		timingModuleCacheComplete,
+		timingModuleCacheErrored,
 		timingLog,
 		timingInitOutput,
 	}, l)
@@ -186,6 +193,8 @@ func (l timingKind) Category() string {
 		return "init"
 	case timingGraphStart, timingGraphComplete, timingGraphErrored:
 		return "graph"
+	case timingModuleCacheStart, timingModuleCacheComplete, timingModuleCacheErrored:
+		return "module cache"
 	case timingApplyStart, timingApplyProgress, timingApplyComplete, timingApplyErrored:
 		return "apply"
 	case timingProvisionStart, timingProvisionProgress, timingProvisionComplete, timingProvisionErrored:
@@ -238,3 +247,15 @@ func createGraphTimingsEvent(event timingKind) (time.Time, *timingSpan) {
 		resource: "state file",
 	}
 }
+
+// createModuleCacheTimingsEvent creates an event of restoring modules from the
+// module cache before init, or storing them in it after init, as given by
+// action.
+func createModuleCacheTimingsEvent(event timingKind, action string) (time.Time, *timingSpan) {
+	return dbtime.Now(), &timingSpan{
+		kind:     event,
+		action:   action,
+		provider: "terraform",
+		resource: "module cache",
+	}
+}
@@ -599,6 +599,73 @@ func TestProvisionerd(t *testing.T) {
 
 	// Simulates when there is no coderd to connect to. So the client connection
 	// will never be established.
+	t.Run("WorkspaceDriftCheck", func(t *testing.T) {
+		t.Parallel()
+		done := make(chan struct{})
+		t.Cleanup(func() {
+			close(done)
+		})
+		var (
+			completed = make(chan *proto.CompletedJob, 1)
+			acq       = newAcquireOne(t, &proto.AcquiredJob{
+				JobId:       "test",
+				Provisioner: "someprovisioner",
+				TemplateSourceArchive: createTar(t, map[string]string{
+					"test.txt": "content",
+				}),
+				Type: &proto.AcquiredJob_WorkspaceDriftCheck_{
+					WorkspaceDriftCheck: &proto.AcquiredJob_WorkspaceDriftCheck{
+						Metadata: &sdkproto.Metadata{},
+						State:    []byte("state"),
+					},
+				},
+			})
+		)
+
+		closer := createProvisionerd(t, func(ctx context.Context) (proto.DRPCProvisionerDaemonClient, error) {
+			return createProvisionerDaemonClient(t, done, provisionerDaemonTestServer{
+				acquireJobWithCancel: acq.acquireWithCancel,
+				updateJob:            noopUpdateJob,
+				completeJob: func(ctx context.Context, job *proto.CompletedJob) (*proto.Empty, error) {
+					completed <- job
+					return &proto.Empty{}, nil
+				},
+			}), nil
+		}, provisionerd.LocalProvisioners{
+			"someprovisioner": createProvisionerClient(t, done, provisionerTestServer{
+				plan: func(
+					s *provisionersdk.Session,
+					r *sdkproto.PlanRequest,
+					_ <-chan struct{},
+				) *sdkproto.PlanComplete {
+					assert.True(t, r.RefreshOnly, "drift check should only refresh")
+					assert.Equal(t, []byte("state"), s.Config.State)
+					return &sdkproto.PlanComplete{
+						ResourceDrift: []*sdkproto.ResourceChange{{
+							Address: "docker_volume.home",
+							Action:  sdkproto.ResourceChange_DELETE,
+						}},
+					}
+				},
+				apply: func(
+					_ *provisionersdk.Session,
+					_ *sdkproto.ApplyRequest,
+					_ <-chan struct{},
+				) *sdkproto.ApplyComplete {
+					t.Error("drift check should not apply")
+					return &sdkproto.ApplyComplete{}
+				},
+			}),
+		})
+
+		require.Condition(t, closedWithin(acq.complete, testutil.WaitShort))
+		require.NoError(t, closer.Close())
+		job := <-completed
+		drift := job.GetWorkspaceDriftCheck().GetResourceDrift()
+		require.Len(t, drift, 1)
+		assert.Equal(t, "docker_volume.home", drift[0].Address)
+	})
+
 	t.Run("ShutdownNoCoderd", func(t *testing.T) {
 		t.Parallel()
 		done := make(chan struct{})
@@ -1196,9 +1263,10 @@ func createProvisionerClient(t *testing.T, done <-chan struct{}, server provisio
 }
 
 type provisionerTestServer struct {
-	parse func(s *provisionersdk.Session, r *sdkproto.ParseRequest, canceledOrComplete <-chan struct{}) *sdkproto.ParseComplete
-	plan  func(s *provisionersdk.Session, r *sdkproto.PlanRequest, canceledOrComplete <-chan struct{}) *sdkproto.PlanComplete
-	apply func(s *provisionersdk.Session, r *sdkproto.ApplyRequest, canceledOrComplete <-chan struct{}) *sdkproto.ApplyComplete
+	parse    func(s *provisionersdk.Session, r *sdkproto.ParseRequest, canceledOrComplete <-chan struct{}) *sdkproto.ParseComplete
+	plan     func(s *provisionersdk.Session, r *sdkproto.PlanRequest, canceledOrComplete <-chan struct{}) *sdkproto.PlanComplete
+	apply    func(s *provisionersdk.Session, r *sdkproto.ApplyRequest, canceledOrComplete <-chan struct{}) *sdkproto.ApplyComplete
+	snapshot func(s *provisionersdk.Session, r *sdkproto.SnapshotRequest, canceledOrComplete <-chan struct{}) *sdkproto.SnapshotComplete
 }
 
 func (p *provisionerTestServer) Parse(s *provisionersdk.Session, r *sdkproto.ParseRequest, canceledOrComplete <-chan struct{}) *sdkproto.ParseComplete {
@@ -1213,6 +1281,10 @@ func (p *provisionerTestServer) Apply(s *provisionersdk.Session, r *sdkproto.App
 	return p.apply(s, r, canceledOrComplete)
 }
 
+func (p *provisionerTestServer) Snapshot(s *provisionersdk.Session, r *sdkproto.SnapshotRequest, canceledOrComplete <-chan struct{}) *sdkproto.SnapshotComplete {
+	return p.snapshot(s, r, canceledOrComplete)
+}
+
 // Fulfills the protobuf interface for a ProvisionerDaemon with
 // passable functions for dynamic functionality.
 type provisionerDaemonTestServer struct {
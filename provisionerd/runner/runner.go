@@ -434,6 +434,21 @@ func (r *Runner) do(ctx context.Context) (*proto.CompletedJob, *proto.FailedJob)
 			slog.F("variable_values", redactVariableValues(jobType.WorkspaceBuild.VariableValues)),
 		)
 		return r.runWorkspaceBuild(ctx)
+	case *proto.AcquiredJob_WorkspaceSnapshot_:
+		r.logger.Debug(context.Background(), "acquired job is workspace snapshot",
+			slog.F("workspace_snapshot_id", jobType.WorkspaceSnapshot.WorkspaceSnapshotId),
+			slog.F("action", jobType.WorkspaceSnapshot.Action),
+			slog.F("state_length", len(jobType.WorkspaceSnapshot.State)),
+		)
+		return r.runWorkspaceSnapshot(ctx)
+	case *proto.AcquiredJob_WorkspaceDriftCheck_:
+		r.logger.Debug(context.Background(), "acquired job is workspace drift check",
+			slog.F("workspace_build_id", jobType.WorkspaceDriftCheck.WorkspaceBuildId),
+			slog.F("state_length", len(jobType.WorkspaceDriftCheck.State)),
+			slog.F("rich_parameter_values", jobType.WorkspaceDriftCheck.RichParameterValues),
+			slog.F("variable_values", redactVariableValues(jobType.WorkspaceDriftCheck.VariableValues)),
+		)
+		return r.runWorkspaceDriftCheck(ctx)
 	default:
 		return nil, r.failedJobf("unknown job type %q; ensure your provisioner daemon is up-to-date",
 			reflect.TypeOf(r.job.Type).String())
@@ -441,6 +456,8 @@ func (r *Runner) do(ctx context.Context) (*proto.CompletedJob, *proto.FailedJob)
 }
 
 func (r *Runner) configure(config *sdkproto.Config) *proto.FailedJob {
+	config.IacEngine = r.job.IacEngine
+	config.IacVersionConstraint = r.job.IacVersionConstraint
 	err := r.session.Send(&sdkproto.Request{Type: &sdkproto.Request_Config{Config: config}})
 	if err != nil {
 		return r.failedJobf("send config: %s", err)
@@ -640,13 +657,14 @@ type templateImportProvision struct {
 	Resources             []*sdkproto.Resource
 	Parameters            []*sdkproto.RichParameter
 	ExternalAuthProviders []*sdkproto.ExternalAuthProviderResource
+	ResourceChanges       []*sdkproto.ResourceChange
 }
 
 // Performs a dry-run provision when importing a template.
 // This is used to detect resources that would be provisioned for a workspace in various states.
 // It doesn't define values for rich parameters as they're unknown during template import.
 func (r *Runner) runTemplateImportProvision(ctx context.Context, variableValues []*sdkproto.VariableValue, metadata *sdkproto.Metadata) (*templateImportProvision, error) {
-	return r.runTemplateImportProvisionWithRichParameters(ctx, variableValues, nil, metadata)
+	return r.runTemplateImportProvisionWithRichParameters(ctx, variableValues, nil, nil, metadata)
 }
 
 // Performs a dry-run provision with provided rich parameters.
@@ -655,6 +673,7 @@ func (r *Runner) runTemplateImportProvisionWithRichParameters(
 	ctx context.Context,
 	variableValues []*sdkproto.VariableValue,
 	richParameterValues []*sdkproto.RichParameterValue,
+	externalAuthProviders []*sdkproto.ExternalAuthProvider,
 	metadata *sdkproto.Metadata,
 ) (*templateImportProvision, error) {
 	ctx, span := r.startTrace(ctx, tracing.FuncName())
@@ -670,9 +689,10 @@ func (r *Runner) runTemplateImportProvisionWithRichParameters(
 	// use the notStopped so that if we attempt to gracefully cancel, the stream will still be available for us
 	// to send the cancel to the provisioner
 	err := r.session.Send(&sdkproto.Request{Type: &sdkproto.Request_Plan{Plan: &sdkproto.PlanRequest{
-		Metadata:            metadata,
-		RichParameterValues: richParameterValues,
-		VariableValues:      variableValues,
+		Metadata:              metadata,
+		RichParameterValues:   richParameterValues,
+		VariableValues:        variableValues,
+		ExternalAuthProviders: externalAuthProviders,
 	}}})
 	if err != nil {
 		return nil, xerrors.Errorf("start provision: %w", err)
@@ -731,6 +751,7 @@ func (r *Runner) runTemplateImportProvisionWithRichParameters(
 				Resources:             c.Resources,
 				Parameters:            c.Parameters,
 				ExternalAuthProviders: c.ExternalAuthProviders,
+				ResourceChanges:       c.ResourceChanges,
 			}, nil
 		default:
 			return nil, xerrors.Errorf("invalid message type %q received from provisioner",
@@ -752,7 +773,10 @@ func (r *Runner) runTemplateDryRun(ctx context.Context) (*proto.CompletedJob, *p
 	if metadata.WorkspaceName == "" {
 		metadata.WorkspaceName = "dryrun"
 	}
-	metadata.WorkspaceOwner = r.job.UserName
+	// Dry-runs that preview an update of a workspace are run as its owner.
+	if metadata.WorkspaceOwner == "" {
+		metadata.WorkspaceOwner = r.job.UserName
+	}
 	if metadata.WorkspaceOwner == "" {
 		metadata.WorkspaceOwner = "dryrunner"
 	}
@@ -771,8 +795,11 @@ func (r *Runner) runTemplateDryRun(ctx context.Context) (*proto.CompletedJob, *p
 		metadata.WorkspaceOwnerId = id.String()
 	}
 
+	// The state is only set when previewing an update of a workspace, so the
+	// plan is made against its resources.
 	failedJob := r.configure(&sdkproto.Config{
 		TemplateSourceArchive: r.job.GetTemplateSourceArchive(),
+		State:                 r.job.GetTemplateDryRun().GetState(),
 	})
 	if failedJob != nil {
 		return nil, failedJob
@@ -782,6 +809,7 @@ func (r *Runner) runTemplateDryRun(ctx context.Context) (*proto.CompletedJob, *p
 	provision, err := r.runTemplateImportProvisionWithRichParameters(ctx,
 		r.job.GetTemplateDryRun().GetVariableValues(),
 		r.job.GetTemplateDryRun().GetRichParameterValues(),
+		r.job.GetTemplateDryRun().GetExternalAuthProviders(),
 		metadata,
 	)
 	if err != nil {
@@ -792,7 +820,8 @@ func (r *Runner) runTemplateDryRun(ctx context.Context) (*proto.CompletedJob, *p
 		JobId: r.job.JobId,
 		Type: &proto.CompletedJob_TemplateDryRun_{
 			TemplateDryRun: &proto.CompletedJob_TemplateDryRun{
-				Resources: provision.Resources,
+				Resources:       provision.Resources,
+				ResourceChanges: provision.ResourceChanges,
 			},
 		},
 	}, nil
@@ -800,12 +829,24 @@ func (r *Runner) runTemplateDryRun(ctx context.Context) (*proto.CompletedJob, *p
 
 func (r *Runner) buildWorkspace(ctx context.Context, stage string, req *sdkproto.Request) (
 	*sdkproto.Response, *proto.FailedJob,
+) {
+	return r.provisionWorkspace(ctx, stage, req, r.failedWorkspaceBuildf,
+		slog.F("workspace_build_id", r.job.GetWorkspaceBuild().WorkspaceBuildId))
+}
+
+// provisionWorkspace sends a request for a workspace to the provisioner and
+// queues the logs it streams back, until the provisioner responds with
+// anything but a log.
+func (r *Runner) provisionWorkspace(ctx context.Context, stage string, req *sdkproto.Request,
+	failedf func(format string, args ...interface{}) *proto.FailedJob, logField slog.Field,
+) (
+	*sdkproto.Response, *proto.FailedJob,
 ) {
 	// use the notStopped so that if we attempt to gracefully cancel, the stream
 	// will still be available for us to send the cancel to the provisioner
 	err := r.session.Send(req)
 	if err != nil {
-		return nil, r.failedWorkspaceBuildf("start provision: %s", err)
+		return nil, failedf("start provision: %s", err)
 	}
 	nevermind := make(chan struct{})
 	defer close(nevermind)
@@ -827,14 +868,14 @@ func (r *Runner) buildWorkspace(ctx context.Context, stage string, req *sdkproto
 	for {
 		msg, err := r.session.Recv()
 		if err != nil {
-			return nil, r.failedWorkspaceBuildf("recv workspace provision: %s", err)
+			return nil, failedf("recv workspace provision: %s", err)
 		}
 		switch msgType := msg.Type.(type) {
 		case *sdkproto.Response_Log:
 			r.logProvisionerJobLog(context.Background(), msgType.Log.Level, "workspace provisioner job logged",
 				slog.F("level", msgType.Log.Level),
 				slog.F("output", msgType.Log.Output),
-				slog.F("workspace_build_id", r.job.GetWorkspaceBuild().WorkspaceBuildId),
+				logField,
 			)
 
 			r.queueLog(ctx, &proto.Log{
@@ -1038,6 +1079,141 @@ func (r *Runner) runWorkspaceBuild(ctx context.Context) (*proto.CompletedJob, *p
 	}, nil
 }
 
+func (r *Runner) runWorkspaceSnapshot(ctx context.Context) (*proto.CompletedJob, *proto.FailedJob) {
+	ctx, span := r.startTrace(ctx, tracing.FuncName())
+	defer span.End()
+
+	job := r.job.GetWorkspaceSnapshot()
+	stage := "正在创建快照"
+	if job.Action == sdkproto.SnapshotAction_RESTORE {
+		stage = "正在恢复快照"
+	}
+
+	failedJob := r.configure(&sdkproto.Config{
+		TemplateSourceArchive: r.job.GetTemplateSourceArchive(),
+		State:                 job.State,
+		ProvisionerLogLevel:   job.LogLevel,
+	})
+	if failedJob != nil {
+		return nil, failedJob
+	}
+
+	r.queueLog(ctx, &proto.Log{
+		Source:    proto.LogSource_PROVISIONER_DAEMON,
+		Level:     sdkproto.LogLevel_INFO,
+		Stage:     stage,
+		CreatedAt: time.Now().UnixMilli(),
+	})
+
+	resp, failed := r.provisionWorkspace(ctx, stage, &sdkproto.Request{
+		Type: &sdkproto.Request_Snapshot{
+			Snapshot: &sdkproto.SnapshotRequest{
+				Metadata:              job.Metadata,
+				Action:                job.Action,
+				SnapshotId:            job.WorkspaceSnapshotId,
+				SnapshotName:          job.SnapshotName,
+				Data:                  job.Data,
+				RichParameterValues:   job.RichParameterValues,
+				VariableValues:        job.VariableValues,
+				ExternalAuthProviders: job.ExternalAuthProviders,
+			},
+		},
+	}, r.failedWorkspaceSnapshotf, slog.F("workspace_snapshot_id", job.WorkspaceSnapshotId))
+	if failed != nil {
+		return nil, failed
+	}
+	snapshotComplete := resp.GetSnapshot()
+	if snapshotComplete == nil {
+		return nil, r.failedWorkspaceSnapshotf("invalid message type %T received from provisioner", resp.Type)
+	}
+	if snapshotComplete.Error != "" {
+		r.logger.Warn(context.Background(), "snapshot request failed",
+			slog.F("error", snapshotComplete.Error),
+		)
+		return nil, r.failedWorkspaceSnapshotf("%s", snapshotComplete.Error)
+	}
+
+	r.logger.Info(context.Background(), "snapshot request successful",
+		slog.F("data_len", len(snapshotComplete.Data)),
+		slog.F("state_len", len(snapshotComplete.State)),
+	)
+	r.flushQueuedLogs(ctx)
+
+	return &proto.CompletedJob{
+		JobId: r.job.JobId,
+		Type: &proto.CompletedJob_WorkspaceSnapshot_{
+			WorkspaceSnapshot: &proto.CompletedJob_WorkspaceSnapshot{
+				Data:  snapshotComplete.Data,
+				State: snapshotComplete.State,
+			},
+		},
+	}, nil
+}
+
+// runWorkspaceDriftCheck plans the latest build of a workspace again without
+// changing anything, to find the resources that were changed or deleted
+// outside of the provisioner.
+func (r *Runner) runWorkspaceDriftCheck(ctx context.Context) (*proto.CompletedJob, *proto.FailedJob) {
+	ctx, span := r.startTrace(ctx, tracing.FuncName())
+	defer span.End()
+
+	const stage = "正在检测状态漂移"
+	job := r.job.GetWorkspaceDriftCheck()
+
+	failedJob := r.configure(&sdkproto.Config{
+		TemplateSourceArchive: r.job.GetTemplateSourceArchive(),
+		State:                 job.State,
+	})
+	if failedJob != nil {
+		return nil, failedJob
+	}
+
+	r.queueLog(ctx, &proto.Log{
+		Source:    proto.LogSource_PROVISIONER_DAEMON,
+		Level:     sdkproto.LogLevel_INFO,
+		Stage:     stage,
+		CreatedAt: time.Now().UnixMilli(),
+	})
+
+	resp, failed := r.provisionWorkspace(ctx, stage, &sdkproto.Request{
+		Type: &sdkproto.Request_Plan{
+			Plan: &sdkproto.PlanRequest{
+				Metadata:            job.Metadata,
+				RichParameterValues: job.RichParameterValues,
+				VariableValues:      job.VariableValues,
+				RefreshOnly:         true,
+			},
+		},
+	}, r.failedWorkspaceDriftCheckf, slog.F("workspace_build_id", job.WorkspaceBuildId))
+	if failed != nil {
+		return nil, failed
+	}
+	planComplete := resp.GetPlan()
+	if planComplete == nil {
+		return nil, r.failedWorkspaceDriftCheckf("invalid message type %T received from provisioner", resp.Type)
+	}
+	if planComplete.Error != "" {
+		r.logger.Warn(context.Background(), "drift check plan request failed",
+			slog.F("error", planComplete.Error),
+		)
+		return nil, r.failedWorkspaceDriftCheckf("%s", planComplete.Error)
+	}
+
+	r.logger.Info(context.Background(), "drift check plan request successful",
+		slog.F("drifted_resource_count", len(planComplete.ResourceDrift)),
+	)
+	r.flushQueuedLogs(ctx)
+
+	return &proto.CompletedJob{
+		JobId: r.job.JobId,
+		Type: &proto.CompletedJob_WorkspaceDriftCheck_{
+			WorkspaceDriftCheck: &proto.CompletedJob_WorkspaceDriftCheck{
+				ResourceDrift: planComplete.ResourceDrift,
+			},
+		},
+	}, nil
+}
+
 func resourceNames(rs []*sdkproto.Resource) []string {
 	var sb strings.Builder
 	names := make([]string, 0, len(rs))
@@ -1057,6 +1233,18 @@ func (r *Runner) failedWorkspaceBuildf(format string, args ...interface{}) *prot
 	return failedJob
 }
 
+func (r *Runner) failedWorkspaceSnapshotf(format string, args ...interface{}) *proto.FailedJob {
+	failedJob := r.failedJobf(format, args...)
+	failedJob.Type = &proto.FailedJob_WorkspaceSnapshot_{}
+	return failedJob
+}
+
+func (r *Runner) failedWorkspaceDriftCheckf(format string, args ...interface{}) *proto.FailedJob {
+	failedJob := r.failedJobf(format, args...)
+	failedJob.Type = &proto.FailedJob_WorkspaceDriftCheck_{}
+	return failedJob
+}
+
 func (r *Runner) failedJobf(format string, args ...interface{}) *proto.FailedJob {
 	message := fmt.Sprintf(format, args...)
 	var code string
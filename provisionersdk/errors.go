@@ -17,3 +17,7 @@ func PlanErrorf(format string, args ...any) *proto.PlanComplete {
 func ApplyErrorf(format string, args ...any) *proto.ApplyComplete {
 	return &proto.ApplyComplete{Error: fmt.Sprintf(format, args...)}
 }
+
+func SnapshotErrorf(format string, args ...any) *proto.SnapshotComplete {
+	return &proto.SnapshotComplete{Error: fmt.Sprintf(format, args...)}
+}
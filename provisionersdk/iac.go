@@ -0,0 +1,129 @@
+package provisionersdk
+
+import (
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"golang.org/x/xerrors"
+)
+
+const (
+	// TagIaCEngine and TagIaCVersion are reserved job tags holding the
+	// infrastructure as code engine, and the constraint on its version, that
+	// the template version of a job requires. Unlike other tags, they are
+	// matched against the binaries a provisioner daemon advertises instead of
+	// its tags.
+	TagIaCEngine  = "coder.iac.engine"
+	TagIaCVersion = "coder.iac.version"
+
+	IaCEngineTerraform = "terraform"
+	IaCEngineOpenTofu  = "opentofu"
+)
+
+// IaCEngines are the infrastructure as code engines the Terraform
+// provisioner can run.
+var IaCEngines = []string{IaCEngineTerraform, IaCEngineOpenTofu}
+
+// IaCBinary is a version of an infrastructure as code engine that a
+// provisioner daemon can run.
+type IaCBinary struct {
+	Engine  string
+	Version *version.Version
+}
+
+// ParseIaCBinary parses a binary formatted as "<engine>@<version>".
+func ParseIaCBinary(s string) (IaCBinary, error) {
+	engine, raw, ok := strings.Cut(s, "@")
+	if !ok {
+		return IaCBinary{}, xerrors.Errorf("binary %q must be formatted as <engine>@<version>", s)
+	}
+	if !isIaCEngine(engine) {
+		return IaCBinary{}, xerrors.Errorf("unknown engine %q, must be one of %s", engine, strings.Join(IaCEngines, ", "))
+	}
+	v, err := version.NewVersion(raw)
+	if err != nil {
+		return IaCBinary{}, xerrors.Errorf("parse version of %q: %w", s, err)
+	}
+	return IaCBinary{Engine: engine, Version: v}, nil
+}
+
+func (b IaCBinary) String() string {
+	return b.Engine + "@" + b.Version.String()
+}
+
+// IaCRequirement is the infrastructure as code engine, and optionally the
+// constraint on its version, that a template version requires.
+type IaCRequirement struct {
+	Engine string
+	// Version is a constraint in the syntax of Terraform's required_version,
+	// such as "~> 1.8.0". If empty, any version of the engine is accepted.
+	Version string
+}
+
+// IaCRequirementFromTags returns the requirement held by the reserved tags of
+// a job, if any.
+func IaCRequirementFromTags(tags map[string]string) (IaCRequirement, bool) {
+	engine, ok := tags[TagIaCEngine]
+	if !ok {
+		return IaCRequirement{}, false
+	}
+	return IaCRequirement{Engine: engine, Version: tags[TagIaCVersion]}, true
+}
+
+// Validate returns an error if the engine is unknown or the version
+// constraint can't be parsed.
+func (r IaCRequirement) Validate() error {
+	if !isIaCEngine(r.Engine) {
+		return xerrors.Errorf("unknown engine %q, must be one of %s", r.Engine, strings.Join(IaCEngines, ", "))
+	}
+	if r.Version == "" {
+		return nil
+	}
+	_, err := version.NewConstraint(r.Version)
+	if err != nil {
+		return xerrors.Errorf("parse version constraint: %w", err)
+	}
+	return nil
+}
+
+// Select returns the newest of the given binaries that satisfies the
+// requirement.
+func (r IaCRequirement) Select(binaries []IaCBinary) (IaCBinary, bool) {
+	var constraints version.Constraints
+	if r.Version != "" {
+		var err error
+		constraints, err = version.NewConstraint(r.Version)
+		if err != nil {
+			return IaCBinary{}, false
+		}
+	}
+	var (
+		selected IaCBinary
+		found    bool
+	)
+	for _, b := range binaries {
+		if b.Engine != r.Engine || !constraints.Check(b.Version) {
+			continue
+		}
+		if !found || b.Version.GreaterThan(selected.Version) {
+			selected, found = b, true
+		}
+	}
+	return selected, found
+}
+
+// String formats the requirement as the engine, followed by the version
+// constraint if there is one. The database matches jobs against requirements
+// in this format.
+func (r IaCRequirement) String() string {
+	return r.Engine + " " + r.Version
+}
+
+func isIaCEngine(engine string) bool {
+	for _, e := range IaCEngines {
+		if e == engine {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+package provisionersdk_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/provisionersdk"
+)
+
+func TestIaCRequirement(t *testing.T) {
+	t.Parallel()
+
+	binaries := make([]provisionersdk.IaCBinary, 0, 3)
+	for _, s := range []string{"terraform@1.8.5", "terraform@1.9.2", "opentofu@1.8.2"} {
+		b, err := provisionersdk.ParseIaCBinary(s)
+		require.NoError(t, err)
+		require.Equal(t, s, b.String())
+		binaries = append(binaries, b)
+	}
+
+	for _, tc := range []struct {
+		requirement provisionersdk.IaCRequirement
+		expected    string
+	}{
+		{provisionersdk.IaCRequirement{Engine: "terraform"}, "terraform@1.9.2"},
+		{provisionersdk.IaCRequirement{Engine: "terraform", Version: "~> 1.8.0"}, "terraform@1.8.5"},
+		{provisionersdk.IaCRequirement{Engine: "opentofu", Version: ">= 1.8"}, "opentofu@1.8.2"},
+		{provisionersdk.IaCRequirement{Engine: "opentofu", Version: ">= 1.9"}, ""},
+	} {
+		require.NoError(t, tc.requirement.Validate())
+		selected, ok := tc.requirement.Select(binaries)
+		if tc.expected == "" {
+			require.False(t, ok, tc.requirement.String())
+			continue
+		}
+		require.True(t, ok, tc.requirement.String())
+		require.Equal(t, tc.expected, selected.String())
+	}
+
+	require.ErrorContains(t, provisionersdk.IaCRequirement{Engine: "pulumi"}.Validate(), "unknown engine")
+	require.ErrorContains(t, provisionersdk.IaCRequirement{Engine: "terraform", Version: "latest"}.Validate(), "parse version constraint")
+
+	_, err := provisionersdk.ParseIaCBinary("terraform")
+	require.ErrorContains(t, err, "must be formatted as")
+	_, err = provisionersdk.ParseIaCBinary("pulumi@1.0.0")
+	require.ErrorContains(t, err, "unknown engine")
+
+	requirement, ok := provisionersdk.IaCRequirementFromTags(map[string]string{
+		provisionersdk.TagScope:      provisionersdk.ScopeOrganization,
+		provisionersdk.TagIaCEngine:  "opentofu",
+		provisionersdk.TagIaCVersion: "~> 1.8",
+	})
+	require.True(t, ok)
+	require.Equal(t, provisionersdk.IaCRequirement{Engine: "opentofu", Version: "~> 1.8"}, requirement)
+	_, ok = provisionersdk.IaCRequirementFromTags(map[string]string{provisionersdk.TagScope: provisionersdk.ScopeOrganization})
+	require.False(t, ok)
+}
@@ -34,6 +34,7 @@ type Server interface {
 	Parse(s *Session, r *proto.ParseRequest, canceledOrComplete <-chan struct{}) *proto.ParseComplete
 	Plan(s *Session, r *proto.PlanRequest, canceledOrComplete <-chan struct{}) *proto.PlanComplete
 	Apply(s *Session, r *proto.ApplyRequest, canceledOrComplete <-chan struct{}) *proto.ApplyComplete
+	Snapshot(s *Session, r *proto.SnapshotRequest, canceledOrComplete <-chan struct{}) *proto.SnapshotComplete
 }
 
 // Serve starts a dRPC connection for the provisioner and transport provided.
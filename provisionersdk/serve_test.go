@@ -146,3 +146,7 @@ func (unimplementedServer) Plan(_ *provisionersdk.Session, _ *proto.PlanRequest,
 func (unimplementedServer) Apply(_ *provisionersdk.Session, _ *proto.ApplyRequest, _ <-chan struct{}) *proto.ApplyComplete {
 	return &proto.ApplyComplete{Error: "unimplemented"}
 }
+
+func (unimplementedServer) Snapshot(_ *provisionersdk.Session, _ *proto.SnapshotRequest, _ <-chan struct{}) *proto.SnapshotComplete {
+	return &proto.SnapshotComplete{Error: "unimplemented"}
+}
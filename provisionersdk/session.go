@@ -177,6 +177,19 @@ func (s *Session) handleRequests() error {
 			}
 			resp.Type = &proto.Response_Apply{Apply: complete}
 		}
+		if snapshot := req.GetSnapshot(); snapshot != nil {
+			r := &request[*proto.SnapshotRequest, *proto.SnapshotComplete]{
+				req:      snapshot,
+				session:  s,
+				serverFn: s.server.Snapshot,
+				cancels:  requests,
+			}
+			complete, err := r.do()
+			if err != nil {
+				return err
+			}
+			resp.Type = &proto.Response_Snapshot{Snapshot: complete}
+		}
 		err := s.stream.Send(resp)
 		if err != nil {
 			return xerrors.Errorf("send response: %w", err)
@@ -301,11 +314,11 @@ func (s *Session) ProvisionLog(level proto.LogLevel, output string) {
 }
 
 type pRequest interface {
-	*proto.ParseRequest | *proto.PlanRequest | *proto.ApplyRequest
+	*proto.ParseRequest | *proto.PlanRequest | *proto.ApplyRequest | *proto.SnapshotRequest
 }
 
 type pComplete interface {
-	*proto.ParseComplete | *proto.PlanComplete | *proto.ApplyComplete
+	*proto.ParseComplete | *proto.PlanComplete | *proto.ApplyComplete | *proto.SnapshotComplete
 }
 
 // request processes a single request call to the Server and returns its complete result, while also processing cancel
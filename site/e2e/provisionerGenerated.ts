@@ -22,6 +22,15 @@ export enum AppSharingLevel {
 	UNRECOGNIZED = -1,
 }
 
+export enum Healthcheck_Kind {
+	HTTP = 0,
+	TCP = 1,
+	GRPC = 2,
+	COMMAND = 3,
+	FILE = 4,
+	UNRECOGNIZED = -1,
+}
+
 /** WorkspaceTransition is the desired outcome of a build */
 export enum WorkspaceTransition {
 	START = 0,
@@ -30,6 +39,14 @@ export enum WorkspaceTransition {
 	UNRECOGNIZED = -1,
 }
 
+export enum ResourceChange_Action {
+	CREATE = 0,
+	UPDATE = 1,
+	REPLACE = 2,
+	DELETE = 3,
+	UNRECOGNIZED = -1,
+}
+
 export enum TimingState {
 	STARTED = 0,
 	COMPLETED = 1,
@@ -37,6 +54,13 @@ export enum TimingState {
 	UNRECOGNIZED = -1,
 }
 
+/** SnapshotAction is the operation a SnapshotRequest performs. */
+export enum SnapshotAction {
+	CREATE = 0,
+	RESTORE = 1,
+	UNRECOGNIZED = -1,
+}
+
 /** Empty indicates a successful request/response. */
 export interface Empty {}
 
@@ -203,6 +227,13 @@ export interface Healthcheck {
 	url: string;
 	interval: number;
 	threshold: number;
+	kind: Healthcheck_Kind;
+	expectedStatus: number;
+	bodyRegex: string;
+	address: string;
+	grpcService: string;
+	command: string;
+	path: string;
 }
 
 /** Resource represents created infrastructure. */
@@ -252,6 +283,12 @@ export interface Config {
 	/** state is the provisioner state (if any) */
 	state: Uint8Array;
 	provisionerLogLevel: string;
+	/**
+	 * iac_engine and iac_version_constraint select the binary the provisioner
+	 * runs. If iac_engine is empty, the default binary is used.
+	 */
+	iacEngine: string;
+	iacVersionConstraint: string;
 }
 
 /** ParseRequest consumes source-code to produce inputs. */
@@ -276,6 +313,34 @@ export interface PlanRequest {
 	richParameterValues: RichParameterValue[];
 	variableValues: VariableValue[];
 	externalAuthProviders: ExternalAuthProvider[];
+	/**
+	 * refresh_only only compares the prior state in the Config of the Session with the infrastructure, and returns
+	 * the differences in resource_drift.  The plan makes no changes, so it must not be applied.
+	 */
+	refreshOnly: boolean;
+}
+
+/** ResourceChange is a change to a managed resource that a plan makes to the prior state. */
+export interface ResourceChange {
+	address: string;
+	type: string;
+	name: string;
+	action: ResourceChange_Action;
+	attributes: ResourceChange_AttributeChange[];
+}
+
+/**
+ * AttributeChange is a top-level attribute that differs between the prior and the planned resource.  Values are
+ * JSON encoded, and are empty if they are sensitive.
+ */
+export interface ResourceChange_AttributeChange {
+	name: string;
+	before: string;
+	after: string;
+	sensitive: boolean;
+	/** unknown is true if the value is only known after apply. */
+	unknown: boolean;
+	forcesReplacement: boolean;
 }
 
 /** PlanComplete indicates a request to plan completed. */
@@ -285,6 +350,10 @@ export interface PlanComplete {
 	parameters: RichParameter[];
 	externalAuthProviders: ExternalAuthProviderResource[];
 	timings: Timing[];
+	/** resource_changes are the changes to the managed resources of the prior state in the Config of the Session. */
+	resourceChanges: ResourceChange[];
+	/** resource_drift are the changes made to the managed resources of the prior state outside of the provisioner. */
+	resourceDrift: ResourceChange[];
 }
 
 /**
@@ -315,6 +384,33 @@ export interface Timing {
 	state: TimingState;
 }
 
+/**
+ * SnapshotRequest asks the provisioner to checkpoint the persistent resources of a workspace, or to restore them from a
+ * previously created snapshot.  The workspace state is provided in the Config of the Session.
+ */
+export interface SnapshotRequest {
+	metadata: Metadata | undefined;
+	action: SnapshotAction;
+	/** snapshot_id uniquely identifies the snapshot, and may be used to name the backups it consists of. */
+	snapshotId: string;
+	snapshotName: string;
+	/** data is the opaque data returned when the snapshot was created.  It is only set when restoring. */
+	data: Uint8Array;
+	/** The values of the latest build of the workspace, so the provisioner sees the workspace the way the build did. */
+	richParameterValues: RichParameterValue[];
+	variableValues: VariableValue[];
+	externalAuthProviders: ExternalAuthProvider[];
+}
+
+/** SnapshotComplete indicates a request to snapshot completed. */
+export interface SnapshotComplete {
+	error: string;
+	/** data is opaque data describing a created snapshot, which is passed back to the provisioner on restore. */
+	data: Uint8Array;
+	/** state is the workspace state after a restore.  It is left empty if the state did not change. */
+	state: Uint8Array;
+}
+
 /** CancelRequest requests that the previous request be canceled gracefully. */
 export interface CancelRequest {}
 
@@ -324,6 +420,7 @@ export interface Request {
 	plan?: PlanRequest | undefined;
 	apply?: ApplyRequest | undefined;
 	cancel?: CancelRequest | undefined;
+	snapshot?: SnapshotRequest | undefined;
 }
 
 export interface Response {
@@ -331,6 +428,7 @@ export interface Response {
 	parse?: ParseComplete | undefined;
 	plan?: PlanComplete | undefined;
 	apply?: ApplyComplete | undefined;
+	snapshot?: SnapshotComplete | undefined;
 }
 
 export const Empty = {
@@ -764,6 +862,27 @@ export const Healthcheck = {
 		if (message.threshold !== 0) {
 			writer.uint32(24).int32(message.threshold);
 		}
+		if (message.kind !== 0) {
+			writer.uint32(32).int32(message.kind);
+		}
+		if (message.expectedStatus !== 0) {
+			writer.uint32(40).int32(message.expectedStatus);
+		}
+		if (message.bodyRegex !== "") {
+			writer.uint32(50).string(message.bodyRegex);
+		}
+		if (message.address !== "") {
+			writer.uint32(58).string(message.address);
+		}
+		if (message.grpcService !== "") {
+			writer.uint32(66).string(message.grpcService);
+		}
+		if (message.command !== "") {
+			writer.uint32(74).string(message.command);
+		}
+		if (message.path !== "") {
+			writer.uint32(82).string(message.path);
+		}
 		return writer;
 	},
 };
@@ -896,6 +1015,12 @@ export const Config = {
 		if (message.provisionerLogLevel !== "") {
 			writer.uint32(26).string(message.provisionerLogLevel);
 		}
+		if (message.iacEngine !== "") {
+			writer.uint32(34).string(message.iacEngine);
+		}
+		if (message.iacVersionConstraint !== "") {
+			writer.uint32(42).string(message.iacVersionConstraint);
+		}
 		return writer;
 	},
 };
@@ -965,6 +1090,63 @@ export const PlanRequest = {
 		for (const v of message.externalAuthProviders) {
 			ExternalAuthProvider.encode(v!, writer.uint32(34).fork()).ldelim();
 		}
+		if (message.refreshOnly === true) {
+			writer.uint32(40).bool(message.refreshOnly);
+		}
+		return writer;
+	},
+};
+
+export const ResourceChange = {
+	encode(
+		message: ResourceChange,
+		writer: _m0.Writer = _m0.Writer.create(),
+	): _m0.Writer {
+		if (message.address !== "") {
+			writer.uint32(10).string(message.address);
+		}
+		if (message.type !== "") {
+			writer.uint32(18).string(message.type);
+		}
+		if (message.name !== "") {
+			writer.uint32(26).string(message.name);
+		}
+		if (message.action !== 0) {
+			writer.uint32(32).int32(message.action);
+		}
+		for (const v of message.attributes) {
+			ResourceChange_AttributeChange.encode(
+				v!,
+				writer.uint32(42).fork(),
+			).ldelim();
+		}
+		return writer;
+	},
+};
+
+export const ResourceChange_AttributeChange = {
+	encode(
+		message: ResourceChange_AttributeChange,
+		writer: _m0.Writer = _m0.Writer.create(),
+	): _m0.Writer {
+		if (message.name !== "") {
+			writer.uint32(10).string(message.name);
+		}
+		if (message.before !== "") {
+			writer.uint32(18).string(message.before);
+		}
+		if (message.after !== "") {
+			writer.uint32(26).string(message.after);
+		}
+		if (message.sensitive === true) {
+			writer.uint32(32).bool(message.sensitive);
+		}
+		if (message.unknown === true) {
+			writer.uint32(40).bool(message.unknown);
+		}
+		if (message.forcesReplacement === true) {
+			writer.uint32(48).bool(message.forcesReplacement);
+		}
 		return writer;
 	},
 };
@@ -992,6 +1174,12 @@ export const PlanComplete = {
 		for (const v of message.timings) {
 			Timing.encode(v!, writer.uint32(50).fork()).ldelim();
 		}
+		for (const v of message.resourceChanges) {
+			ResourceChange.encode(v!, writer.uint32(58).fork()).ldelim();
+		}
+		for (const v of message.resourceDrift) {
+			ResourceChange.encode(v!, writer.uint32(66).fork()).ldelim();
+		}
 		return writer;
 	},
 };
@@ -1074,6 +1262,57 @@ export const Timing = {
 	},
 };
 
+export const SnapshotRequest = {
+	encode(
+		message: SnapshotRequest,
+		writer: _m0.Writer = _m0.Writer.create(),
+	): _m0.Writer {
+		if (message.metadata !== undefined) {
+			Metadata.encode(message.metadata, writer.uint32(10).fork()).ldelim();
+		}
+		if (message.action !== 0) {
+			writer.uint32(16).int32(message.action);
+		}
+		if (message.snapshotId !== "") {
+			writer.uint32(26).string(message.snapshotId);
+		}
+		if (message.snapshotName !== "") {
+			writer.uint32(34).string(message.snapshotName);
+		}
+		if (message.data.length !== 0) {
+			writer.uint32(42).bytes(message.data);
+		}
+		for (const v of message.richParameterValues) {
+			RichParameterValue.encode(v!, writer.uint32(50).fork()).ldelim();
+		}
+		for (const v of message.variableValues) {
+			VariableValue.encode(v!, writer.uint32(58).fork()).ldelim();
+		}
+		for (const v of message.externalAuthProviders) {
+			ExternalAuthProvider.encode(v!, writer.uint32(66).fork()).ldelim();
+		}
+		return writer;
+	},
+};
+
+export const SnapshotComplete = {
+	encode(
+		message: SnapshotComplete,
+		writer: _m0.Writer = _m0.Writer.create(),
+	): _m0.Writer {
+		if (message.error !== "") {
+			writer.uint32(10).string(message.error);
+		}
+		if (message.data.length !== 0) {
+			writer.uint32(18).bytes(message.data);
+		}
+		if (message.state.length !== 0) {
+			writer.uint32(26).bytes(message.state);
+		}
+		return writer;
+	},
+};
+
 export const CancelRequest = {
 	encode(
 		_: CancelRequest,
@@ -1103,6 +1342,12 @@ export const Request = {
 		if (message.cancel !== undefined) {
 			CancelRequest.encode(message.cancel, writer.uint32(42).fork()).ldelim();
 		}
+		if (message.snapshot !== undefined) {
+			SnapshotRequest.encode(
+				message.snapshot,
+				writer.uint32(50).fork(),
+			).ldelim();
+		}
 		return writer;
 	},
 };
@@ -1124,6 +1369,12 @@ export const Response = {
 		if (message.apply !== undefined) {
 			ApplyComplete.encode(message.apply, writer.uint32(34).fork()).ldelim();
 		}
+		if (message.snapshot !== undefined) {
+			SnapshotComplete.encode(
+				message.snapshot,
+				writer.uint32(42).fork(),
+			).ldelim();
+		}
 		return writer;
 	},
 };
@@ -1131,14 +1382,14 @@ export const Response = {
 export interface Provisioner {
 	/**
 	 * Session represents provisioning a single template import or workspace.  The daemon always sends Config followed
-	 * by one of the requests (ParseRequest, PlanRequest, ApplyRequest).  The provisioner should respond with a stream
-	 * of zero or more Logs, followed by the corresponding complete message (ParseComplete, PlanComplete,
-	 * ApplyComplete).  The daemon may then send a new request.  A request to apply MUST be preceded by a request plan,
+	 * by one of the requests (ParseRequest, PlanRequest, ApplyRequest, SnapshotRequest).  The provisioner should respond
+	 * with a stream of zero or more Logs, followed by the corresponding complete message (ParseComplete, PlanComplete,
+	 * ApplyComplete, SnapshotComplete).  The daemon may then send a new request.  A request to apply MUST be preceded by a request plan,
 	 * and the provisioner should store the plan data on the Session after a successful plan, so that the daemon may
 	 * request an apply.  If the daemon closes the Session without an apply, the plan data may be safely discarded.
 	 *
 	 * The daemon may send a CancelRequest, asynchronously to ask the provisioner to cancel the previous ParseRequest,
-	 * PlanRequest, or ApplyRequest.  The provisioner MUST reply with a complete message corresponding to the request
+	 * PlanRequest, ApplyRequest, or SnapshotRequest.  The provisioner MUST reply with a complete message corresponding to the request
 	 * that was canceled.  If the provisioner has already completed the request, it may ignore the CancelRequest.
 	 */
 	Session(request: Observable<Request>): Observable<Response>;
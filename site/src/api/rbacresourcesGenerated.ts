@@ -65,6 +65,11 @@ export const RBACResourceActions: Partial<
     read: "read IdP sync settings",
     update: "update IdP sync settings",
   },
+  inbox_notification: {
+    create: "create inbox notifications",
+    read: "read inbox notifications",
+    update: "update inbox notifications",
+  },
   license: {
     create: "create a license",
     delete: "delete license",
@@ -121,6 +126,12 @@ export const RBACResourceActions: Partial<
   replicas: {
     read: "read replicas",
   },
+  saved_search: {
+    create: "create saved searches",
+    delete: "delete saved searches",
+    read: "read saved searches",
+    update: "update saved searches",
+  },
   system: {
     create: "create system resources",
     delete: "delete system resources",
@@ -153,6 +164,7 @@ export const RBACResourceActions: Partial<
     create: "create a new workspace",
     delete: "delete workspace",
     read: "read workspace data to view on the UI",
+    share_session: "watch or take over the terminal sessions of a workspace",
     ssh: "ssh into a given workspace",
     start: "allows starting a workspace",
     stop: "allows stopping a workspace",
@@ -163,6 +175,7 @@ export const RBACResourceActions: Partial<
     create: "create a new workspace",
     delete: "delete workspace",
     read: "read workspace data to view on the UI",
+    share_session: "watch or take over the terminal sessions of a workspace",
     ssh: "ssh into a given workspace",
     start: "allows starting a workspace",
     stop: "allows stopping a workspace",
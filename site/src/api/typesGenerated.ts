@@ -106,12 +106,41 @@ export interface AuditLog {
 	readonly user?: User;
 }
 
+// From codersdk/deployment.go
+export interface AuditLogArchiveConfig {
+	readonly directory: string;
+	readonly s3: ObjectStoreS3Config;
+}
+
+// From codersdk/deployment.go
+export interface AuditLogOTLPConfig {
+	readonly endpoint: string;
+	readonly headers: string[];
+}
+
 // From codersdk/audit.go
 export interface AuditLogResponse {
 	readonly audit_logs: Readonly<Array<AuditLog>>;
 	readonly count: number;
 }
 
+// From codersdk/deployment.go
+export interface AuditLogSyslogConfig {
+	readonly address: string;
+	readonly tls: boolean;
+	readonly tls_ca_file: string;
+}
+
+// From codersdk/deployment.go
+export interface AuditLoggingConfig {
+	readonly retention: number;
+	readonly archive: AuditLogArchiveConfig;
+	readonly syslog: AuditLogSyslogConfig;
+	readonly otlp: AuditLogOTLPConfig;
+	readonly buffer_dir: string;
+	readonly buffer_max_size: number;
+}
+
 // From codersdk/audit.go
 export interface AuditLogsRequest extends Pagination {
 	readonly q?: string;
@@ -178,6 +207,12 @@ export interface BuildInfoResponse {
 	readonly deployment_id: string;
 }
 
+// From codersdk/workspaces.go
+export interface CloneWorkspaceRequest {
+	readonly name: string;
+	readonly rich_parameter_values?: Readonly<Array<WorkspaceBuildParameter>>;
+}
+
 // From codersdk/insights.go
 export interface ConnectionLatency {
 	readonly p50: number;
@@ -244,6 +279,15 @@ export interface CreateProvisionerKeyResponse {
 	readonly key: string;
 }
 
+// From codersdk/savedsearches.go
+export interface CreateSavedSearchRequest {
+	readonly resource_type: SavedSearchResourceType;
+	readonly name: string;
+	readonly query: string;
+	readonly organization_id?: string;
+	readonly group_id?: string;
+}
+
 // From codersdk/organizations.go
 export interface CreateTemplateRequest {
 	readonly name: string;
@@ -271,6 +315,7 @@ export interface CreateTemplateVersionDryRunRequest {
 	readonly workspace_name: string;
 	readonly rich_parameter_values: Readonly<Array<WorkspaceBuildParameter>>;
 	readonly user_variable_values?: Readonly<Array<VariableValue>>;
+	readonly workspace_id?: string;
 }
 
 // From codersdk/organizations.go
@@ -283,6 +328,8 @@ export interface CreateTemplateVersionRequest {
 	readonly example_id?: string;
 	readonly provisioner: ProvisionerType;
 	readonly tags: Record<string, string>;
+	readonly iac_engine?: string;
+	readonly iac_version_constraint?: string;
 	readonly user_variable_values?: Readonly<Array<VariableValue>>;
 }
 
@@ -343,6 +390,12 @@ export interface CreateWorkspaceRequest {
 	readonly automatic_updates?: AutomaticUpdates;
 }
 
+// From codersdk/workspacesnapshots.go
+export interface CreateWorkspaceSnapshotRequest {
+	readonly name: string;
+	readonly log_level?: ProvisionerLogLevel;
+}
+
 // From codersdk/roles.go
 export interface CustomRoleRequest {
 	readonly name: string;
@@ -458,6 +511,8 @@ export interface DeploymentValues {
 	readonly metrics_cache_refresh_interval?: number;
 	readonly agent_stat_refresh_interval?: number;
 	readonly agent_fallback_troubleshooting_url?: string;
+	readonly agent_metadata_history_retention?: number;
+	readonly workspace_drift_check_interval?: number;
 	readonly browser_only?: boolean;
 	readonly scim_api_key?: string;
 	readonly external_token_encryption_keys?: string[];
@@ -485,6 +540,7 @@ export interface DeploymentValues {
 	readonly cli_upgrade_message?: string;
 	readonly terms_of_service_url?: string;
 	readonly notifications?: NotificationsConfig;
+	readonly audit_logging?: AuditLoggingConfig;
 	readonly config?: string;
 	readonly write_config?: boolean;
 	readonly address?: string;
@@ -650,6 +706,13 @@ export interface Healthcheck {
 	readonly url: string;
 	readonly interval: number;
 	readonly threshold: number;
+	readonly kind?: WorkspaceAppHealthcheckKind;
+	readonly expected_status?: number;
+	readonly body_regex?: string;
+	readonly address?: string;
+	readonly grpc_service?: string;
+	readonly command?: string;
+	readonly path?: string;
 }
 
 // From codersdk/deployment.go
@@ -658,6 +721,25 @@ export interface HealthcheckConfig {
 	readonly threshold_database: number;
 }
 
+// From codersdk/notifications.go
+export interface InboxNotification {
+	readonly id: string;
+	readonly user_id: string;
+	readonly template_id: string;
+	readonly title: string;
+	readonly content: string;
+	readonly actions: Readonly<Array<InboxNotificationAction>>;
+	readonly read_at?: string;
+	readonly archived_at?: string;
+	readonly created_at: string;
+}
+
+// From codersdk/notifications.go
+export interface InboxNotificationAction {
+	readonly label: string;
+	readonly url: string;
+}
+
 // From codersdk/workspaceagents.go
 export interface IssueReconnectingPTYSignedTokenRequest {
 	readonly url: string;
@@ -695,6 +777,18 @@ export interface LinkConfig {
 	readonly icon: string;
 }
 
+// From codersdk/notifications.go
+export interface ListInboxNotificationsRequest extends Pagination {
+	readonly read_status?: InboxNotificationReadStatus;
+	readonly include_archived?: boolean;
+}
+
+// From codersdk/notifications.go
+export interface ListInboxNotificationsResponse {
+	readonly notifications: Readonly<Array<InboxNotification>>;
+	readonly unread_count: number;
+}
+
 // From codersdk/externalauth.go
 export interface ListUserExternalAuthResponse {
 	readonly providers: Readonly<Array<ExternalAuthLinkProvider>>;
@@ -720,6 +814,11 @@ export interface LoginWithPasswordResponse {
 	readonly session_token: string;
 }
 
+// From codersdk/notifications.go
+export interface MarkAllInboxNotificationsAsReadResponse {
+	readonly updated: number;
+}
+
 // From codersdk/organizations.go
 export interface MinimalOrganization {
 	readonly id: string;
@@ -745,9 +844,18 @@ export interface NotificationMethodsResponse {
 export interface NotificationPreference {
 	readonly id: string;
 	readonly disabled: boolean;
+	readonly method?: string;
 	readonly updated_at: string;
 }
 
+// From codersdk/notifications.go
+export interface NotificationQuietHours {
+	readonly enabled: boolean;
+	readonly start?: string;
+	readonly end?: string;
+	readonly timezone?: string;
+}
+
 // From codersdk/notifications.go
 export interface NotificationTemplate {
 	readonly id: string;
@@ -771,8 +879,11 @@ export interface NotificationsConfig {
 	readonly fetch_interval: number;
 	readonly method: string;
 	readonly dispatch_timeout: number;
+	readonly digest_window: number;
 	readonly email: NotificationsEmailConfig;
 	readonly webhook: NotificationsWebhookConfig;
+	readonly slack: NotificationsSlackConfig;
+	readonly teams: NotificationsTeamsConfig;
 }
 
 // From codersdk/deployment.go
@@ -808,6 +919,18 @@ export interface NotificationsSettings {
 	readonly notifier_paused: boolean;
 }
 
+// From codersdk/deployment.go
+export interface NotificationsSlackConfig {
+	readonly endpoint: string;
+	readonly bot_token: string;
+	readonly api_url: string;
+}
+
+// From codersdk/deployment.go
+export interface NotificationsTeamsConfig {
+	readonly endpoint: string;
+}
+
 // From codersdk/deployment.go
 export interface NotificationsWebhookConfig {
 	readonly endpoint: string;
@@ -910,6 +1033,14 @@ export interface OIDCConfig {
 	readonly skip_issuer_checks: boolean;
 }
 
+// From codersdk/deployment.go
+export interface ObjectStoreS3Config {
+	readonly bucket: string;
+	readonly prefix: string;
+	readonly region: string;
+	readonly endpoint: string;
+}
+
 // From codersdk/organizations.go
 export interface Organization extends MinimalOrganization {
 	readonly description: string;
@@ -975,6 +1106,25 @@ export interface Permission {
 	readonly action: RBACAction;
 }
 
+// From codersdk/portforwardingpolicy.go
+export interface PortForwardingPolicy {
+	readonly direct_tcpip?: PortForwardingRule;
+	readonly remote_forward?: PortForwardingRule;
+	readonly unix_socket?: UnixSocketForwardingRule;
+}
+
+// From codersdk/portforwardingpolicy.go
+export interface PortForwardingRule {
+	readonly allowed_cidrs: Readonly<Array<string>>;
+	readonly allowed_ports: Readonly<Array<string>>;
+}
+
+// From codersdk/portforwardingpolicy.go
+export interface PortRange {
+	readonly Start: number;
+	readonly End: number;
+}
+
 // From codersdk/oauth2.go
 export interface PostOAuth2ProviderAppRequest {
 	readonly name: string;
@@ -1011,6 +1161,9 @@ export interface ProvisionerConfig {
 	readonly daemon_poll_jitter: number;
 	readonly force_cancel_interval: number;
 	readonly daemon_psk: string;
+	readonly iac_mirror_dir: string;
+	readonly module_cache_max_size: number;
+	readonly state_store: ProvisionerStateStoreConfig;
 }
 
 // From codersdk/provisionerdaemons.go
@@ -1069,6 +1222,12 @@ export interface ProvisionerKeyDaemons {
 	readonly daemons: Readonly<Array<ProvisionerDaemon>>;
 }
 
+// From codersdk/deployment.go
+export interface ProvisionerStateStoreConfig {
+	readonly directory: string;
+	readonly s3: ObjectStoreS3Config;
+}
+
 // From codersdk/workspaces.go
 export interface ProvisionerTiming {
 	readonly job_id: string;
@@ -1148,6 +1307,25 @@ export interface ResolveAutostartResponse {
 	readonly parameter_mismatch: boolean;
 }
 
+// From codersdk/templateversions.go
+export interface ResourceAttributeChange {
+	readonly name: string;
+	readonly before?: string;
+	readonly after?: string;
+	readonly sensitive: boolean;
+	readonly unknown: boolean;
+	readonly forces_replacement: boolean;
+}
+
+// From codersdk/templateversions.go
+export interface ResourceChange {
+	readonly address: string;
+	readonly type: string;
+	readonly name: string;
+	readonly action: ResourceChangeAction;
+	readonly attributes: Readonly<Array<ResourceAttributeChange>>;
+}
+
 // From codersdk/client.go
 export interface Response {
 	readonly message: string;
@@ -1155,6 +1333,11 @@ export interface Response {
 	readonly validations?: Readonly<Array<ValidationError>>;
 }
 
+// From codersdk/workspacesnapshots.go
+export interface RestoreWorkspaceSnapshotRequest {
+	readonly log_level?: ProvisionerLogLevel;
+}
+
 // From codersdk/roles.go
 export interface Role {
 	readonly name: string;
@@ -1183,6 +1366,24 @@ export interface SSHConfigResponse {
 	readonly ssh_config_options: Record<string, string>;
 }
 
+// From codersdk/savedsearches.go
+export interface SavedSearch {
+	readonly id: string;
+	readonly user_id: string;
+	readonly resource_type: SavedSearchResourceType;
+	readonly name: string;
+	readonly query: string;
+	readonly organization_id?: string;
+	readonly group_id?: string;
+	readonly created_at: string;
+	readonly updated_at: string;
+}
+
+// From codersdk/savedsearches.go
+export interface SavedSearchesRequest {
+	readonly resource_type?: SavedSearchResourceType;
+}
+
 // From codersdk/serversentevents.go
 export interface ServerSentEvent {
 	readonly type: ServerSentEventType;
@@ -1286,6 +1487,9 @@ export interface Template {
 	readonly time_til_dormant_autodelete_ms: number;
 	readonly require_active_version: boolean;
 	readonly max_port_share_level: WorkspaceAgentPortShareLevel;
+	readonly session_recording: boolean;
+	readonly port_forwarding_policy: PortForwardingPolicy;
+	readonly agent_activity_bump: boolean;
 }
 
 // From codersdk/templates.go
@@ -1409,6 +1613,8 @@ export interface TemplateVersion {
 	readonly readme: string;
 	readonly created_by: MinimalUser;
 	readonly archived: boolean;
+	readonly iac_engine?: string;
+	readonly iac_version_constraint?: string;
 	readonly warnings?: Readonly<Array<TemplateVersionWarning>>;
 }
 
@@ -1492,6 +1698,11 @@ export interface TransitionStats {
 	readonly P95?: number;
 }
 
+// From codersdk/portforwardingpolicy.go
+export interface UnixSocketForwardingRule {
+	readonly allowed_paths: Readonly<Array<string>>;
+}
+
 // From codersdk/templates.go
 export interface UpdateActiveTemplateVersion {
 	readonly id: string;
@@ -1512,6 +1723,12 @@ export interface UpdateCheckResponse {
 	readonly url: string;
 }
 
+// From codersdk/notifications.go
+export interface UpdateInboxNotificationRequest {
+	readonly read?: boolean;
+	readonly archived?: boolean;
+}
+
 // From codersdk/notifications.go
 export interface UpdateNotificationTemplateMethod {
 	readonly method?: string;
@@ -1530,6 +1747,14 @@ export interface UpdateRoles {
 	readonly roles: Readonly<Array<string>>;
 }
 
+// From codersdk/savedsearches.go
+export interface UpdateSavedSearchRequest {
+	readonly name: string;
+	readonly query: string;
+	readonly organization_id?: string;
+	readonly group_id?: string;
+}
+
 // From codersdk/templates.go
 export interface UpdateTemplateACL {
 	readonly user_perms?: Record<string, TemplateRole>;
@@ -1558,6 +1783,9 @@ export interface UpdateTemplateMeta {
 	readonly deprecation_message?: string;
 	readonly disable_everyone_group_access: boolean;
 	readonly max_port_share_level?: WorkspaceAgentPortShareLevel;
+	readonly session_recording?: boolean;
+	readonly port_forwarding_policy?: PortForwardingPolicy;
+	readonly agent_activity_bump?: boolean;
 }
 
 // From codersdk/users.go
@@ -1568,6 +1796,7 @@ export interface UpdateUserAppearanceSettingsRequest {
 // From codersdk/notifications.go
 export interface UpdateUserNotificationPreferences {
 	readonly template_disabled_map: Record<string, boolean>;
+	readonly template_method_map?: Record<string, string>;
 }
 
 // From codersdk/users.go
@@ -1623,6 +1852,14 @@ export interface UploadResponse {
 	readonly hash: string;
 }
 
+// From codersdk/workspaceagentfiles.go
+export interface UploadWorkspaceAgentFileRequest {
+	readonly path: string;
+	readonly offset: number;
+	readonly complete: boolean;
+	readonly mode?: number;
+}
+
 // From codersdk/workspaceagentportshare.go
 export interface UpsertWorkspaceAgentPortShareRequest {
 	readonly agent_name: string;
@@ -1812,12 +2049,79 @@ export interface WorkspaceAgent {
 	readonly startup_script_behavior: WorkspaceAgentStartupScriptBehavior;
 }
 
+// From codersdk/workspaceagentcontainers.go
+export interface WorkspaceAgentContainer {
+	readonly id: string;
+	readonly name: string;
+	readonly image: string;
+	readonly created_at: string;
+	readonly status: string;
+	readonly labels: Record<string, string>;
+	readonly ports: Readonly<Array<WorkspaceAgentContainerPort>>;
+	readonly devcontainer_workspace_folder?: string;
+	readonly devcontainer_config_path?: string;
+}
+
+// From codersdk/workspaceagentcontainers.go
+export interface WorkspaceAgentContainerPort {
+	readonly port: number;
+	readonly network: string;
+	readonly host_ip?: string;
+	readonly host_port?: number;
+}
+
+// From codersdk/workspaceagentprocesses.go
+export interface WorkspaceAgentExecRequest {
+	readonly command: string;
+	readonly dir?: string;
+	readonly timeout_ms?: number;
+}
+
+// From codersdk/workspaceagentprocesses.go
+export interface WorkspaceAgentExecResponse {
+	readonly exit_code: number;
+	readonly stdout: string;
+	readonly stderr: string;
+	readonly truncated: boolean;
+	readonly timed_out: boolean;
+}
+
+// From codersdk/workspaceagentfiles.go
+export interface WorkspaceAgentFileInfo {
+	readonly name: string;
+	readonly path: string;
+	readonly size: number;
+	readonly mode: string;
+	readonly mod_time: string;
+	readonly is_dir: boolean;
+	readonly symlink_target?: string;
+}
+
+// From codersdk/workspaceagentfiles.go
+export interface WorkspaceAgentFileList {
+	readonly path: string;
+	readonly files: Readonly<Array<WorkspaceAgentFileInfo>>;
+}
+
+// From codersdk/workspaceagentfiles.go
+export interface WorkspaceAgentFileUpload {
+	readonly path: string;
+	readonly offset: number;
+	readonly complete: boolean;
+}
+
 // From codersdk/workspaceagents.go
 export interface WorkspaceAgentHealth {
 	readonly healthy: boolean;
 	readonly reason?: string;
 }
 
+// From codersdk/workspaceagentcontainers.go
+export interface WorkspaceAgentListContainersResponse {
+	readonly runtime: string;
+	readonly containers: Readonly<Array<WorkspaceAgentContainer>>;
+}
+
 // From codersdk/workspaceagents.go
 export interface WorkspaceAgentListeningPort {
 	readonly process_name: string;
@@ -1863,6 +2167,12 @@ export interface WorkspaceAgentMetadataDescription {
 	readonly timeout: number;
 }
 
+// From codersdk/workspaceagents.go
+export interface WorkspaceAgentMetadataHistoryRequest {
+	readonly after?: string;
+	readonly limit?: number;
+}
+
 // From codersdk/workspaceagents.go
 export interface WorkspaceAgentMetadataResult {
 	readonly collected_at: string;
@@ -1871,6 +2181,13 @@ export interface WorkspaceAgentMetadataResult {
 	readonly error: string;
 }
 
+// From codersdk/workspaceagents.go
+export interface WorkspaceAgentMetadataSample {
+	readonly collected_at: string;
+	readonly value: string;
+	readonly error: string;
+}
+
 // From codersdk/workspaceagentportshare.go
 export interface WorkspaceAgentPortShare {
 	readonly workspace_id: string;
@@ -1885,6 +2202,46 @@ export interface WorkspaceAgentPortShares {
 	readonly shares: Readonly<Array<WorkspaceAgentPortShare>>;
 }
 
+// From codersdk/workspaceagentprocesses.go
+export interface WorkspaceAgentProcess {
+	readonly pid: number;
+	readonly parent_pid: number;
+	readonly name: string;
+	readonly command: string;
+	readonly user: string;
+	readonly cpu_percent: number;
+	readonly memory_bytes: number;
+	readonly started_at: string;
+	readonly children: Readonly<Array<WorkspaceAgentProcess>>;
+}
+
+// From codersdk/workspaceagentprocesses.go
+export interface WorkspaceAgentProcessesResponse {
+	readonly processes: Readonly<Array<WorkspaceAgentProcess>>;
+}
+
+// From codersdk/workspaceagentreconnectingptys.go
+export interface WorkspaceAgentReconnectingPTY {
+	readonly id: string;
+	readonly user_id: string;
+	readonly command: string;
+	readonly container?: string;
+	readonly started_at: string;
+	readonly connections: Readonly<Array<WorkspaceAgentReconnectingPTYConnection>>;
+}
+
+// From codersdk/workspaceagentreconnectingptys.go
+export interface WorkspaceAgentReconnectingPTYConnection {
+	readonly id: string;
+	readonly read_only: boolean;
+	readonly attached_at: string;
+}
+
+// From codersdk/workspaceagentreconnectingptys.go
+export interface WorkspaceAgentReconnectingPTYsResponse {
+	readonly sessions: Readonly<Array<WorkspaceAgentReconnectingPTY>>;
+}
+
 // From codersdk/workspaceagents.go
 export interface WorkspaceAgentScript {
 	readonly id: string;
@@ -1899,6 +2256,30 @@ export interface WorkspaceAgentScript {
 	readonly display_name: string;
 }
 
+// From codersdk/workspaceagentscripts.go
+export interface WorkspaceAgentScriptRun {
+	readonly script_id: string;
+	readonly stage: WorkspaceAgentScriptRunStage;
+	readonly status: WorkspaceAgentScriptRunStatus;
+	readonly exit_code: number;
+	readonly started_at: string;
+	readonly ended_at: string;
+	readonly log_source_id: string;
+	readonly log_path: string;
+}
+
+// From codersdk/sessionrecordings.go
+export interface WorkspaceAgentSessionRecording {
+	readonly id: string;
+	readonly workspace_id: string;
+	readonly agent_id: string;
+	readonly session_type: string;
+	readonly command: string;
+	readonly started_at: string;
+	readonly ended_at?: string;
+	readonly size: number;
+}
+
 // From codersdk/workspaceapps.go
 export interface WorkspaceApp {
 	readonly id: string;
@@ -1979,6 +2360,7 @@ export interface WorkspaceFilter {
 export interface WorkspaceHealth {
 	readonly healthy: boolean;
 	readonly failing_agents: Readonly<Array<string>>;
+	readonly drifted_resources: Readonly<Array<ResourceChange>>;
 }
 
 // From codersdk/workspaces.go
@@ -2038,6 +2420,17 @@ export interface WorkspaceResourceMetadata {
 	readonly sensitive: boolean;
 }
 
+// From codersdk/workspacesnapshots.go
+export interface WorkspaceSnapshot {
+	readonly id: string;
+	readonly workspace_id: string;
+	readonly workspace_build_id: string;
+	readonly name: string;
+	readonly created_by: string;
+	readonly created_at: string;
+	readonly job: ProvisionerJob;
+}
+
 // From codersdk/workspaces.go
 export interface WorkspaceTimings {
 	readonly provisioner_timings: Readonly<Array<ProvisionerTiming>>;
@@ -2063,8 +2456,8 @@ export type AgentSubsystem = "envbox" | "envbuilder" | "exectrace"
 export const AgentSubsystems: AgentSubsystem[] = ["envbox", "envbuilder", "exectrace"]
 
 // From codersdk/audit.go
-export type AuditAction = "create" | "delete" | "login" | "logout" | "register" | "start" | "stop" | "write"
-export const AuditActions: AuditAction[] = ["create", "delete", "login", "logout", "register", "start", "stop", "write"]
+export type AuditAction = "create" | "delete" | "exec" | "login" | "logout" | "register" | "start" | "stop" | "write"
+export const AuditActions: AuditAction[] = ["create", "delete", "exec", "login", "logout", "register", "start", "stop", "write"]
 
 // From codersdk/workspaces.go
 export type AutomaticUpdates = "always" | "never"
@@ -2102,6 +2495,10 @@ export const FeatureSets: FeatureSet[] = ["", "enterprise", "premium"]
 export type GroupSource = "oidc" | "user"
 export const GroupSources: GroupSource[] = ["oidc", "user"]
 
+// From codersdk/notifications.go
+export type InboxNotificationReadStatus = "all" | "read" | "unread"
+export const InboxNotificationReadStatuses: InboxNotificationReadStatus[] = ["all", "read", "unread"]
+
 // From codersdk/insights.go
 export type InsightsReportInterval = "day" | "week"
 export const InsightsReportIntervals: InsightsReportInterval[] = ["day", "week"]
@@ -2155,17 +2552,25 @@ export type ProxyHealthStatus = "ok" | "unhealthy" | "unreachable" | "unregister
 export const ProxyHealthStatuses: ProxyHealthStatus[] = ["ok", "unhealthy", "unreachable", "unregistered"]
 
 // From codersdk/rbacresources_gen.go
-export type RBACAction = "application_connect" | "assign" | "create" | "delete" | "read" | "read_personal" | "ssh" | "start" | "stop" | "update" | "update_personal" | "use" | "view_insights"
-export const RBACActions: RBACAction[] = ["application_connect", "assign", "create", "delete", "read", "read_personal", "ssh", "start", "stop", "update", "update_personal", "use", "view_insights"]
+export type RBACAction = "application_connect" | "assign" | "create" | "delete" | "read" | "read_personal" | "share_session" | "ssh" | "start" | "stop" | "update" | "update_personal" | "use" | "view_insights"
+export const RBACActions: RBACAction[] = ["application_connect", "assign", "create", "delete", "read", "read_personal", "share_session", "ssh", "start", "stop", "update", "update_personal", "use", "view_insights"]
 
 // From codersdk/rbacresources_gen.go
-export type RBACResource = "*" | "api_key" | "assign_org_role" | "assign_role" | "audit_log" | "crypto_key" | "debug_info" | "deployment_config" | "deployment_stats" | "file" | "group" | "group_member" | "idpsync_settings" | "license" | "notification_preference" | "notification_template" | "oauth2_app" | "oauth2_app_code_token" | "oauth2_app_secret" | "organization" | "organization_member" | "provisioner_daemon" | "provisioner_keys" | "replicas" | "system" | "tailnet_coordinator" | "template" | "user" | "workspace" | "workspace_dormant" | "workspace_proxy"
-export const RBACResources: RBACResource[] = ["*", "api_key", "assign_org_role", "assign_role", "audit_log", "crypto_key", "debug_info", "deployment_config", "deployment_stats", "file", "group", "group_member", "idpsync_settings", "license", "notification_preference", "notification_template", "oauth2_app", "oauth2_app_code_token", "oauth2_app_secret", "organization", "organization_member", "provisioner_daemon", "provisioner_keys", "replicas", "system", "tailnet_coordinator", "template", "user", "workspace", "workspace_dormant", "workspace_proxy"]
+export type RBACResource = "*" | "api_key" | "assign_org_role" | "assign_role" | "audit_log" | "crypto_key" | "debug_info" | "deployment_config" | "deployment_stats" | "file" | "group" | "group_member" | "idpsync_settings" | "inbox_notification" | "license" | "notification_preference" | "notification_template" | "oauth2_app" | "oauth2_app_code_token" | "oauth2_app_secret" | "organization" | "organization_member" | "provisioner_daemon" | "provisioner_keys" | "replicas" | "saved_search" | "system" | "tailnet_coordinator" | "template" | "user" | "workspace" | "workspace_dormant" | "workspace_proxy"
+export const RBACResources: RBACResource[] = ["*", "api_key", "assign_org_role", "assign_role", "audit_log", "crypto_key", "debug_info", "deployment_config", "deployment_stats", "file", "group", "group_member", "idpsync_settings", "inbox_notification", "license", "notification_preference", "notification_template", "oauth2_app", "oauth2_app_code_token", "oauth2_app_secret", "organization", "organization_member", "provisioner_daemon", "provisioner_keys", "replicas", "saved_search", "system", "tailnet_coordinator", "template", "user", "workspace", "workspace_dormant", "workspace_proxy"]
+
+// From codersdk/templateversions.go
+export type ResourceChangeAction = "create" | "delete" | "replace" | "update"
+export const ResourceChangeActions: ResourceChangeAction[] = ["create", "delete", "replace", "update"]
 
 // From codersdk/audit.go
 export type ResourceType = "api_key" | "convert_login" | "custom_role" | "git_ssh_key" | "group" | "health_settings" | "license" | "notifications_settings" | "oauth2_provider_app" | "oauth2_provider_app_secret" | "organization" | "template" | "template_version" | "user" | "workspace" | "workspace_build" | "workspace_proxy"
 export const ResourceTypes: ResourceType[] = ["api_key", "convert_login", "custom_role", "git_ssh_key", "group", "health_settings", "license", "notifications_settings", "oauth2_provider_app", "oauth2_provider_app_secret", "organization", "template", "template_version", "user", "workspace", "workspace_build", "workspace_proxy"]
 
+// From codersdk/savedsearches.go
+export type SavedSearchResourceType = "audit_log" | "template" | "user" | "workspace"
+export const SavedSearchResourceTypes: SavedSearchResourceType[] = ["audit_log", "template", "user", "workspace"]
+
 // From codersdk/serversentevents.go
 export type ServerSentEventType = "data" | "error" | "ping"
 export const ServerSentEventTypes: ServerSentEventType[] = ["data", "error", "ping"]
@@ -2210,6 +2615,14 @@ export const WorkspaceAgentPortShareLevels: WorkspaceAgentPortShareLevel[] = ["a
 export type WorkspaceAgentPortShareProtocol = "http" | "https"
 export const WorkspaceAgentPortShareProtocols: WorkspaceAgentPortShareProtocol[] = ["http", "https"]
 
+// From codersdk/workspaceagentscripts.go
+export type WorkspaceAgentScriptRunStage = "cron" | "manual" | "start" | "stop"
+export const WorkspaceAgentScriptRunStages: WorkspaceAgentScriptRunStage[] = ["cron", "manual", "start", "stop"]
+
+// From codersdk/workspaceagentscripts.go
+export type WorkspaceAgentScriptRunStatus = "exit_failure" | "ok" | "pipes_left_open" | "timed_out"
+export const WorkspaceAgentScriptRunStatuses: WorkspaceAgentScriptRunStatus[] = ["exit_failure", "ok", "pipes_left_open", "timed_out"]
+
 // From codersdk/workspaceagents.go
 export type WorkspaceAgentStartupScriptBehavior = "blocking" | "non-blocking"
 export const WorkspaceAgentStartupScriptBehaviors: WorkspaceAgentStartupScriptBehavior[] = ["blocking", "non-blocking"]
@@ -2222,6 +2635,10 @@ export const WorkspaceAgentStatuses: WorkspaceAgentStatus[] = ["connected", "con
 export type WorkspaceAppHealth = "disabled" | "healthy" | "initializing" | "unhealthy"
 export const WorkspaceAppHealths: WorkspaceAppHealth[] = ["disabled", "healthy", "initializing", "unhealthy"]
 
+// From codersdk/workspaceapps.go
+export type WorkspaceAppHealthcheckKind = "command" | "file" | "grpc" | "http" | "tcp"
+export const WorkspaceAppHealthcheckKinds: WorkspaceAppHealthcheckKind[] = ["command", "file", "grpc", "http", "tcp"]
+
 // From codersdk/workspaceapps.go
 export type WorkspaceAppSharingLevel = "authenticated" | "owner" | "public"
 export const WorkspaceAppSharingLevels: WorkspaceAppSharingLevel[] = ["authenticated", "owner", "public"]
@@ -55,6 +55,9 @@ const TerminalPage: FC = () => {
 	// a round-trip, and must be a UUIDv4.
 	const reconnectionToken = searchParams.get("reconnect") ?? uuidv4();
 	const command = searchParams.get("command") || undefined;
+	// The terminal is started in this container of the workspace if set.
+	const container = searchParams.get("container") || undefined;
+	const containerUser = searchParams.get("container_user") || undefined;
 	// The workspace name is in the format:
 	// <workspace name>[.<agent name>]
 	const workspaceNameParts = params.workspace?.split(".");
@@ -234,6 +237,8 @@ const TerminalPage: FC = () => {
 			command,
 			terminal.rows,
 			terminal.cols,
+			container,
+			containerUser,
 		)
 			.then((url) => {
 				if (disposed) {
@@ -296,6 +301,8 @@ const TerminalPage: FC = () => {
 		};
 	}, [
 		command,
+		container,
+		containerUser,
 		proxy.preferredPathAppURL,
 		reconnectionToken,
 		terminal,
@@ -153,6 +153,44 @@ export const UnhealthyWithoutUpdatePermission: Story = {
 	play: Unhealthy.play,
 };
 
+export const Drifted: Story = {
+	args: {
+		workspace: {
+			...MockWorkspace,
+			health: {
+				...MockWorkspace.health,
+				healthy: false,
+				drifted_resources: [
+					{
+						address: "docker_volume.home_volume",
+						type: "docker_volume",
+						name: "home_volume",
+						action: "delete",
+						attributes: [],
+					},
+				],
+			},
+			latest_build: {
+				...MockWorkspace.latest_build,
+				status: "running",
+			},
+		},
+	},
+
+	play: async ({ canvasElement, step }) => {
+		const screen = within(canvasElement);
+
+		await step("activate hover trigger", async () => {
+			await userEvent.hover(screen.getByTestId("warning-notifications"));
+			await waitFor(() =>
+				expect(
+					screen.getByText(/changed or deleted outside of coder/i),
+				).toBeInTheDocument(),
+			);
+		});
+	},
+};
+
 const DormantWorkspace = {
 	...MockWorkspace,
 	dormant_at: new Date("2020-01-01T00:00:00Z").toISOString(),
@@ -82,18 +82,26 @@ export const WorkspaceNotifications: FC<WorkspaceNotificationsProps> = ({
 		workspace.latest_build.status === "running" &&
 		!workspace.health.healthy
 	) {
+		const { failing_agents, drifted_resources } = workspace.health;
+		const reasons: string[] = [];
+		if (failing_agents.length > 0 || drifted_resources.length === 0) {
+			reasons.push(
+				failing_agents.length > 1
+					? `${failing_agents.length} agents are unhealthy`
+					: "1 agent is unhealthy",
+			);
+		}
+		if (drifted_resources.length > 0) {
+			reasons.push(
+				drifted_resources.length > 1
+					? `${drifted_resources.length} resources were changed or deleted outside of Coder`
+					: `${drifted_resources[0].address} was changed or deleted outside of Coder`,
+			);
+		}
 		notifications.push({
 			title: "Workspace is unhealthy",
 			severity: "warning",
-			detail: (
-				<>
-					Your workspace is running but{" "}
-					{workspace.health.failing_agents.length > 1
-						? `${workspace.health.failing_agents.length} agents are unhealthy`
-						: "1 agent is unhealthy"}
-					.
-				</>
-			),
+			detail: <>Your workspace is running but {reasons.join(" and ")}.</>,
 			actions: permissions.updateWorkspace ? (
 				<NotificationActionButton onClick={onRestartWorkspace}>
 					Restart
@@ -241,6 +241,7 @@ export const UnhealthyWorkspace: Story = {
 				health: {
 					healthy: false,
 					failing_agents: [],
+					drifted_resources: [],
 				},
 			},
 		],
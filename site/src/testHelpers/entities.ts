@@ -775,6 +775,9 @@ export const MockTemplate: TypesGen.Template = {
 	deprecated: false,
 	deprecation_message: "",
 	max_port_share_level: "public",
+	session_recording: false,
+	port_forwarding_policy: {},
+	agent_activity_bump: false,
 };
 
 export const MockTemplateVersionFiles: TemplateVersionFiles = {
@@ -1314,6 +1317,7 @@ export const MockWorkspace: TypesGen.Workspace = {
 	health: {
 		healthy: true,
 		failing_agents: [],
+		drifted_resources: [],
 	},
 	automatic_updates: "never",
 	allow_renames: true,
@@ -7,11 +7,19 @@ export const terminalWebsocketUrl = async (
 	command: string | undefined,
 	height: number,
 	width: number,
+	container?: string,
+	containerUser?: string,
 ): Promise<string> => {
 	const query = new URLSearchParams({ reconnect });
 	if (command) {
 		query.set("command", command);
 	}
+	if (container) {
+		query.set("container", container);
+		if (containerUser) {
+			query.set("container_user", containerUser);
+		}
+	}
 	query.set("height", height.toString());
 	query.set("width", width.toString());
 
@@ -106,6 +106,11 @@ type Options struct {
 	ClientType proto.TelemetryEvent_ClientType
 	// TelemetrySink is optional.
 	TelemetrySink TelemetrySink
+	// AllowForwardTCP is called for TCP connections that don't match a
+	// listener on the Conn, which are otherwise forwarded to the same port on
+	// localhost. Returning false rejects the connection. If nil, all
+	// connections are forwarded.
+	AllowForwardTCP func(src, dst netip.AddrPort) bool
 }
 
 // TelemetrySink allows tailnet.Conn to send network telemetry to the Coder
@@ -283,6 +288,7 @@ func NewConn(options *Options) (conn *Conn, err error) {
 		nodeUpdater:     nodeUp,
 		telemetrySink:   options.TelemetrySink,
 		telemetryStore:  telemetryStore,
+		allowForwardTCP: options.AllowForwardTCP,
 		createdAt:       time.Now(),
 		watchCtx:        ctx,
 		watchCancel:     ctxCancel,
@@ -371,6 +377,7 @@ type Conn struct {
 	listeners        map[listenKey]*listener
 	clientType       proto.TelemetryEvent_ClientType
 	createdAt        time.Time
+	allowForwardTCP  func(src, dst netip.AddrPort) bool
 
 	telemetrySink TelemetrySink
 	// telemetryStore will be nil if telemetrySink is nil.
@@ -681,6 +688,11 @@ func (c *Conn) forwardTCP(src, dst netip.AddrPort) (handler func(net.Conn), opts
 	ln, ok := c.listeners[listenKey{"tcp", "", fmt.Sprint(dst.Port())}]
 	c.mutex.Unlock()
 	if !ok {
+		if c.allowForwardTCP != nil && !c.allowForwardTCP(src, dst) {
+			logger.Debug(context.Background(), "forwarding connection to localhost denied")
+			// Intercepting the flow without a handler rejects it.
+			return nil, nil, true
+		}
 		return nil, nil, false
 	}
 	// See: https://github.com/tailscale/tailscale/blob/c7cea825aea39a00aca71ea02bab7266afc03e7c/wgengine/netstack/netstack.go#L888